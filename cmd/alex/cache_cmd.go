@@ -0,0 +1,117 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"alex/internal/infra/filestore"
+	"alex/internal/infra/httpcache"
+	"alex/internal/infra/llm"
+)
+
+const (
+	cacheUsage      = "usage: alex cache {stats|purge|llm} [options]"
+	cachePurgeUsage = "usage: alex cache purge [--domain <host>] [--all]"
+	cacheLLMUsage   = "usage: alex cache llm {stats|purge}"
+)
+
+func runCacheCommand(args []string) error {
+	if len(args) > 0 && strings.EqualFold(args[0], "llm") {
+		cfg, _, err := loadRuntimeConfigSnapshot()
+		if err != nil {
+			return err
+		}
+		sessionDir := filestore.ResolvePath(cfg.SessionDir, "~/.alex/sessions")
+		return executeLLMCacheCommand(args[1:], os.Stdout, llm.ResponseCacheDir(sessionDir))
+	}
+	return executeCacheCommand(args, os.Stdout, httpcache.ResolveDir(runtimeEnvLookup(), nil))
+}
+
+func executeCacheCommand(args []string, w io.Writer, dir string) error {
+	if len(args) == 0 || args[0] == "-h" || args[0] == "--help" || args[0] == "help" {
+		fmt.Fprintln(w, cacheUsage)
+		return nil
+	}
+
+	switch strings.ToLower(args[0]) {
+	case "stats":
+		cache, err := httpcache.New(httpcache.Config{Dir: dir})
+		if err != nil {
+			return err
+		}
+		stats := cache.Stats()
+		fmt.Fprintf(w, "Web cache: %s\n  entries: %d\n  bytes:   %d\n", dir, stats.Entries, stats.Bytes)
+		return nil
+	case "purge":
+		return runCachePurge(args[1:], w, dir)
+	default:
+		return &ExitCodeError{Code: 2, Err: fmt.Errorf("unknown cache subcommand %q (expected: stats|purge|llm)", args[0])}
+	}
+}
+
+func runCachePurge(args []string, w io.Writer, dir string) error {
+	fs, flagBuf := newBufferedFlagSet("alex cache purge")
+	domain := fs.String("domain", "", "Purge entries for this host and its subdomains")
+	all := fs.Bool("all", false, "Purge every cached entry")
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			fmt.Fprintln(w, cachePurgeUsage)
+			return nil
+		}
+		return &ExitCodeError{Code: 2, Err: formatBufferedFlagParseError(err, flagBuf)}
+	}
+	target := strings.TrimSpace(*domain)
+	if target == "" && !*all {
+		return &ExitCodeError{Code: 2, Err: fmt.Errorf("%s", cachePurgeUsage)}
+	}
+
+	cache, err := httpcache.New(httpcache.Config{Dir: dir})
+	if err != nil {
+		return err
+	}
+	removed, err := cache.PurgeDomain(target)
+	if err != nil {
+		return err
+	}
+	if target == "" {
+		fmt.Fprintf(w, "Purged %d cached entries\n", removed)
+		return nil
+	}
+	fmt.Fprintf(w, "Purged %d cached entries for %s\n", removed, target)
+	return nil
+}
+
+// executeLLMCacheCommand inspects or purges the LLM response cache in dir.
+func executeLLMCacheCommand(args []string, w io.Writer, dir string) error {
+	if len(args) == 0 || args[0] == "-h" || args[0] == "--help" || args[0] == "help" {
+		fmt.Fprintln(w, cacheLLMUsage)
+		return nil
+	}
+	if len(args) > 1 {
+		return &ExitCodeError{Code: 2, Err: fmt.Errorf("%s", cacheLLMUsage)}
+	}
+
+	cache, err := llm.NewResponseCache(llm.ResponseCacheConfig{Dir: dir})
+	if err != nil {
+		return err
+	}
+	switch strings.ToLower(args[0]) {
+	case "stats":
+		stats := cache.Stats()
+		fmt.Fprintf(w, "LLM response cache: %s\n  entries: %d\n  bytes:   %d\n", dir, stats.Entries, stats.Bytes)
+		return nil
+	case "purge":
+		removed, err := cache.Purge()
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "Purged %d cached LLM responses\n", removed)
+		return nil
+	default:
+		return &ExitCodeError{Code: 2, Err: fmt.Errorf("unknown cache llm subcommand %q (expected: stats|purge)", args[0])}
+	}
+}
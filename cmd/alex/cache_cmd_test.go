@@ -0,0 +1,76 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"alex/internal/domain/agent/ports"
+	"alex/internal/infra/httpcache"
+	"alex/internal/infra/llm"
+)
+
+func TestExecuteCacheCommandPurgesByDomain(t *testing.T) {
+	dir := t.TempDir()
+	cache, err := httpcache.New(httpcache.Config{Dir: dir})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	for _, raw := range []string{"https://docs.example.com/a", "https://other.org/b"} {
+		if err := cache.Store(httpcache.Entry{Key: raw, URL: raw, Host: strings.Split(strings.TrimPrefix(raw, "https://"), "/")[0], StatusCode: 200}, []byte("x")); err != nil {
+			t.Fatalf("Store: %v", err)
+		}
+	}
+
+	var out bytes.Buffer
+	if err := executeCacheCommand([]string{"purge", "--domain", "example.com"}, &out, dir); err != nil {
+		t.Fatalf("purge: %v", err)
+	}
+	if !strings.Contains(out.String(), "Purged 1 cached entries for example.com") {
+		t.Fatalf("unexpected output: %q", out.String())
+	}
+
+	out.Reset()
+	if err := executeCacheCommand([]string{"stats"}, &out, dir); err != nil {
+		t.Fatalf("stats: %v", err)
+	}
+	if !strings.Contains(out.String(), "entries: 1") {
+		t.Fatalf("expected one remaining entry, got %q", out.String())
+	}
+}
+
+func TestExecuteCacheCommandPurgeRequiresTarget(t *testing.T) {
+	err := executeCacheCommand([]string{"purge"}, &bytes.Buffer{}, t.TempDir())
+	if err == nil || !strings.Contains(err.Error(), "--domain") {
+		t.Fatalf("expected usage error, got %v", err)
+	}
+}
+
+func TestExecuteLLMCacheCommandPurges(t *testing.T) {
+	dir := t.TempDir()
+	cache, err := llm.NewResponseCache(llm.ResponseCacheConfig{Dir: dir})
+	if err != nil {
+		t.Fatalf("NewResponseCache: %v", err)
+	}
+	for _, key := range []string{"k1", "k2"} {
+		if err := cache.Store(key, "openai", "gpt-4o", &ports.CompletionResponse{Content: key}); err != nil {
+			t.Fatalf("Store: %v", err)
+		}
+	}
+
+	var out bytes.Buffer
+	if err := executeLLMCacheCommand([]string{"stats"}, &out, dir); err != nil {
+		t.Fatalf("stats: %v", err)
+	}
+	if !strings.Contains(out.String(), "entries: 2") {
+		t.Fatalf("expected two entries, got %q", out.String())
+	}
+
+	out.Reset()
+	if err := executeLLMCacheCommand([]string{"purge"}, &out, dir); err != nil {
+		t.Fatalf("purge: %v", err)
+	}
+	if !strings.Contains(out.String(), "Purged 2 cached LLM responses") {
+		t.Fatalf("unexpected output: %q", out.String())
+	}
+}
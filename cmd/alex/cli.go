@@ -22,6 +22,10 @@ func (c *CLI) Run(args []string) error {
 		return err
 	}
 
+	if isPromptModeInvocation(args) {
+		return c.runPromptMode(args)
+	}
+
 	// Default: treat as task and run with stream output.
 	task := strings.Join(args, " ")
 	return RunTaskWithStreamOutput(c.container, task, "")
@@ -51,7 +55,25 @@ func (c *CLI) runRegisteredCommand(args []string) (handled bool, err error) {
 		}
 		return true, c.handleSessions(cmdArgs)
 
+	case "import":
+		if c.container == nil {
+			return false, nil
+		}
+		return true, c.handleImport(cmdArgs)
+
+	case "memory":
+		if c.container == nil {
+			return false, nil
+		}
+		return true, c.handleMemory(cmdArgs)
+
 	case "config":
+		if isConfigComponentsArgs(cmdArgs) {
+			if c.container == nil {
+				return false, nil
+			}
+			return true, printComponentStatuses(os.Stdout, c.container.ComponentStatuses())
+		}
 		return true, executeConfigCommand(cmdArgs, os.Stdout)
 
 	case "health":
@@ -91,6 +113,13 @@ func (c *CLI) runRegisteredCommand(args []string) (handled bool, err error) {
 		return true, runRuntimeCommand(cmdArgs)
 	case "leader":
 		return true, runLeaderCommand(cmdArgs)
+	case "cache":
+		return true, runCacheCommand(cmdArgs)
+	case "journal":
+		if c.container == nil {
+			return false, nil
+		}
+		return true, c.handleJournal(cmdArgs)
 
 	default:
 		return false, nil
@@ -104,12 +133,17 @@ elephant.ai - Fragment-to-Fabric Agent Console (v%s)
 
 Usage:
   alex <task>                    Execute a task with streaming output
+  alex -p <task> [--output json] Run one task without the interactive UI (stdin is task context)
+  alex --resume                  Open the chat UI with the session picker (also /resume in chat)
   alex resume <session-id>       Resume a session from the latest checkpoint
   alex help                      Show this help message
   alex version                   Show version
   alex sessions                  List all sessions
+  alex sessions list --search q  Filter sessions by title or tag
   alex sessions pull <id> [...]  Inspect or export context snapshots
   alex sessions cleanup [...]    Remove historical sessions (see options below)
+  alex import chatgpt <export>   Import ChatGPT/Claude exports as sessions (import claude <export>)
+  alex memory reindex [--force]  Re-embed existing memories for hybrid recall
   alex runtime session [...]     Manage local runtime sessions
   alex dev <command>             Manage local development services
   alex lark inject [...]         Inject a message into the local Lark gateway
@@ -129,6 +163,10 @@ Usage:
   alex leader status              Show leader agent status (tasks, blockers, jobs)
   alex leader dashboard           Compact terminal dashboard view
   alex leader config show         Dump leader configuration as YAML
+  alex cache stats                Show web_fetch cache size
+  alex cache purge --domain <h>   Purge cached pages for a domain (or --all)
+  alex cache llm {stats|purge}    Show or purge the LLM response cache
+  alex journal <session-id>      Show a session's event journal size and segments
   alex cost                      Show cost tracking commands
   alex eval [options]            Run local agent evaluation against SWE-Bench datasets
   alex acp [--initial-message]        Run ACP (Agent Client Protocol) over stdio
@@ -146,6 +184,7 @@ Examples:
   alex "list files in current directory"
   alex "analyze the authentication flow in this codebase"
   alex "explain how the ReAct engine works"
+  alex -p "review this patch" --output json < patch.diff
 
 Features:
   ✓ Real-time streaming output with tool visualization
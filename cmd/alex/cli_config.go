@@ -5,8 +5,10 @@ import (
 	"io"
 	"os"
 	"strings"
+	"text/tabwriter"
 	"time"
 
+	"alex/internal/app/di"
 	runtimeconfig "alex/internal/shared/config"
 	configadmin "alex/internal/shared/config/admin"
 	"alex/internal/shared/utils"
@@ -167,6 +169,7 @@ func printConfigUsage(out io.Writer) {
 		"  alex config clear <field>         Remove an override",
 		"  alex config validate [--profile]  Validate runtime configuration",
 		"  alex config path                  Print the runtime config file location",
+		"  alex config components            Show startup status of llm-factory, memory and memory-indexer",
 		"",
 		"Supported fields: llm_provider, llm_model, llm_vision_model, base_url, api_key, ark_api_key, tavily_api_key, profile, environment, max_tokens, max_iterations, temperature, top_p, verbose, stop_sequences, agent_preset, tool_preset.",
 	}
@@ -179,6 +182,32 @@ func printConfigUsage(out io.Writer) {
 	}
 }
 
+// isConfigComponentsArgs reports whether args select `alex config components`,
+// which needs a started container rather than the overrides file.
+func isConfigComponentsArgs(args []string) bool {
+	return len(args) > 0 && utils.TrimLower(args[0]) == "components"
+}
+
+func printComponentStatuses(out io.Writer, statuses []di.ComponentStatus) error {
+	if len(statuses) == 0 {
+		_, err := fmt.Fprintln(out, "No container components registered.")
+		return err
+	}
+	tw := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "COMPONENT\tSTATE\tREQUIRED\tDURATION\tMESSAGE")
+	for _, status := range statuses {
+		duration := "-"
+		if status.DurationMS > 0 {
+			duration = fmt.Sprintf("%dms", status.DurationMS)
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%t\t%s\t%s\n", status.Name, status.State, status.Required, duration, status.Message)
+	}
+	if err := tw.Flush(); err != nil {
+		return fmt.Errorf("write component statuses: %w", err)
+	}
+	return nil
+}
+
 func parseSetArgs(args []string) (string, string, error) {
 	if len(args) == 0 {
 		return "", "", fmt.Errorf("usage: alex config set <field> <value>")
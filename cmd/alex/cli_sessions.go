@@ -12,6 +12,7 @@ import (
 	"text/tabwriter"
 	"time"
 
+	"alex/internal/app/agent/sessiontitle"
 	sessionstate "alex/internal/infra/session/state_store"
 	"alex/internal/shared/utils"
 )
@@ -275,24 +276,30 @@ func summarizeSnapshotGaps(snapshot sessionstate.Snapshot) string {
 
 // sessionListRow is the structured data for one session in list output.
 type sessionListRow struct {
-	ID        string `json:"id"`
-	Title     string `json:"title"`
-	Messages  int    `json:"messages"`
-	CreatedAt string `json:"created_at"`
-	UpdatedAt string `json:"updated_at"`
-	Age       string `json:"age"`
+	ID        string   `json:"id"`
+	Title     string   `json:"title"`
+	Tags      []string `json:"tags,omitempty"`
+	Messages  int      `json:"messages"`
+	CreatedAt string   `json:"created_at"`
+	UpdatedAt string   `json:"updated_at"`
+	Age       string   `json:"age"`
 }
 
 func (c *CLI) listSessionsCommand(ctx context.Context, args []string) error {
 	fs, flagBuf := newBufferedFlagSet("alex sessions list")
 	jsonOut := fs.Bool("json", false, "Output as JSON array")
+	search := fs.String("search", "", "Only list sessions whose title or tags contain this text")
 	if err := fs.Parse(args); err != nil {
 		return formatBufferedFlagParseError(err, flagBuf)
 	}
-	return c.listSessionsWithWriter(ctx, os.Stdout, *jsonOut)
+	return c.searchSessionsWithWriter(ctx, os.Stdout, *jsonOut, *search)
 }
 
 func (c *CLI) listSessionsWithWriter(ctx context.Context, out io.Writer, jsonOut bool) error {
+	return c.searchSessionsWithWriter(ctx, out, jsonOut, "")
+}
+
+func (c *CLI) searchSessionsWithWriter(ctx context.Context, out io.Writer, jsonOut bool, query string) error {
 	sessionIDs, err := c.listAllSessions(ctx)
 	if err != nil {
 		return err
@@ -311,16 +318,23 @@ func (c *CLI) listSessionsWithWriter(ctx context.Context, out io.Writer, jsonOut
 	for _, sid := range sessionIDs {
 		session, err := c.container.Container.SessionStore.Get(ctx, sid)
 		if err != nil {
-			rows = append(rows, sessionListRow{ID: sid, Title: "(error)"})
+			if query == "" {
+				rows = append(rows, sessionListRow{ID: sid, Title: "(error)"})
+			}
 			continue
 		}
 		title := session.Metadata["title"]
+		tags := sessiontitle.Tags(session.Metadata)
+		if !sessiontitle.Matches(title, tags, query) {
+			continue
+		}
 		if title == "" {
 			title = "-"
 		}
 		rows = append(rows, sessionListRow{
 			ID:        sid,
 			Title:     utils.TruncateWithEllipsis(title, 40),
+			Tags:      tags,
 			Messages:  len(session.Messages),
 			CreatedAt: session.CreatedAt.Format("2006-01-02 15:04"),
 			UpdatedAt: session.UpdatedAt.Format("2006-01-02 15:04"),
@@ -336,10 +350,14 @@ func (c *CLI) listSessionsWithWriter(ctx context.Context, out io.Writer, jsonOut
 
 	_, _ = fmt.Fprintf(out, "Sessions: %d\n\n", len(rows))
 	tw := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
-	_, _ = fmt.Fprintln(tw, "ID\tTITLE\tMSGS\tCREATED\tLAST ACTIVE\tAGE")
+	_, _ = fmt.Fprintln(tw, "ID\tTITLE\tTAGS\tMSGS\tCREATED\tLAST ACTIVE\tAGE")
 	for _, r := range rows {
-		_, _ = fmt.Fprintf(tw, "%s\t%s\t%d\t%s\t%s\t%s\n",
-			r.ID, r.Title, r.Messages, r.CreatedAt, r.UpdatedAt, r.Age)
+		tags := strings.Join(r.Tags, ",")
+		if tags == "" {
+			tags = "-"
+		}
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%d\t%s\t%s\t%s\n",
+			r.ID, r.Title, tags, r.Messages, r.CreatedAt, r.UpdatedAt, r.Age)
 	}
 	return tw.Flush()
 }
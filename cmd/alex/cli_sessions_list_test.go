@@ -106,6 +106,27 @@ func TestListSessions_JSON(t *testing.T) {
 	}
 }
 
+func TestListSessions_SearchMatchesTitleAndTags(t *testing.T) {
+	now := time.Now()
+	sessions := []*agentstorage.Session{
+		{Metadata: map[string]string{"title": "Kafka lag triage", "tags": "kafka,ops"}, CreatedAt: now, UpdatedAt: now},
+		{Metadata: map[string]string{"title": "Billing refactor", "tags": "finance"}, CreatedAt: now, UpdatedAt: now},
+	}
+	cli := newTestCLI(t, sessions)
+
+	var buf bytes.Buffer
+	if err := cli.searchSessionsWithWriter(context.Background(), &buf, true, "FINANCE"); err != nil {
+		t.Fatalf("search sessions: %v", err)
+	}
+	var rows []sessionListRow
+	if err := json.Unmarshal(buf.Bytes(), &rows); err != nil {
+		t.Fatalf("unmarshal json: %v\nraw: %s", err, buf.String())
+	}
+	if len(rows) != 1 || rows[0].Title != "Billing refactor" || strings.Join(rows[0].Tags, ",") != "finance" {
+		t.Fatalf("expected only the billing session, got %+v", rows)
+	}
+}
+
 func TestListSessions_Empty(t *testing.T) {
 	cli := newTestCLI(t, nil)
 
@@ -1,7 +1,9 @@
 package main
 
 import (
+	"fmt"
 	"log"
+	"strings"
 
 	agent_eval "alex/evaluation/agent_eval"
 )
@@ -16,6 +18,15 @@ func (c *CLI) runFoundationEvaluation(args []string) error {
 	casesPath := fs.String("cases", "evaluation/agent_eval/datasets/foundation_eval_cases.yaml", "Path to foundation implicit-intent scenario set (YAML)")
 	topK := fs.Int("top-k", 3, "Top-K cutoff for implicit discoverability pass/fail")
 	reportFormat := fs.String("format", "markdown", "Report format: markdown|json")
+	ranker := fs.String("ranker", "lexical", "Implicit-case tool ranker: lexical|bm25")
+	compareRanker := fs.String("compare-ranker", "", "Also score implicit cases with this ranker and report a side-by-side comparison")
+	baseline := fs.String("baseline", "", "Previous foundation_result JSON; fail when a category's pass@5 regresses against it")
+	maxCategoryDrop := fs.Float64("max-category-drop", 0.05, "Allowed per-category pass@5 drop against --baseline (0.05 = 5 points)")
+	caseIDs := fs.String("case-id", "", "Comma-separated scenario IDs to run")
+	categories := fs.String("category", "", "Comma-separated scenario categories to run")
+	failedOnly := fs.String("failed-only", "", "Previous foundation_result JSON; rerun only the cases that failed there")
+	publishURL := fs.String("publish-url", "", "Eval-server base URL to publish the result to (token from ALEX_EVAL_SERVER_TOKEN)")
+	explain := fs.String("explain", "", "Print the full ranked tool list with per-token and per-rule scores for this case ID, then exit")
 
 	if err := fs.Parse(args); err != nil {
 		return formatBufferedFlagParseError(err, flagBuf)
@@ -29,9 +40,34 @@ func (c *CLI) runFoundationEvaluation(args []string) error {
 	options.CasesPath = *casesPath
 	options.TopK = *topK
 	options.ReportFormat = *reportFormat
+	options.RankerStrategy = *ranker
+	options.CompareRankerStrategy = *compareRanker
+	options.BaselinePath = *baseline
+	options.MaxCategoryPassAt5Drop = *maxCategoryDrop
+	options.CaseIDs = splitFlagList(*caseIDs)
+	options.Categories = splitFlagList(*categories)
+	options.FailedOnlyFrom = *failedOnly
+	options.PublishEndpoint = *publishURL
+
+	if strings.TrimSpace(*explain) != "" {
+		explanation, err := agent_eval.ExplainFoundationCase(cliBaseContext(), options, *explain)
+		if err != nil {
+			return err
+		}
+		fmt.Print(agent_eval.FormatFoundationCaseExplanation(explanation))
+		return nil
+	}
 
 	result, err := agent_eval.RunFoundationEvaluation(cliBaseContext(), options)
 	if err != nil {
+		if result != nil {
+			for _, regression := range result.Regressions {
+				log.Printf("Foundation regression: %s", regression)
+			}
+			for _, artifact := range result.ReportArtifacts {
+				log.Printf("Foundation artifact: %s (%s) -> %s", artifact.Name, artifact.Format, artifact.Path)
+			}
+		}
 		return err
 	}
 
@@ -52,9 +88,36 @@ func (c *CLI) runFoundationEvaluation(args []string) error {
 		result.Implicit.TotalCases,
 		result.Implicit.TopKHitRate*100,
 	)
+	if cmp := result.RankerComparison; cmp != nil {
+		log.Printf(
+			"Ranker comparison: %s pass@1 %.1f%% mrr %.3f vs %s pass@1 %.1f%% mrr %.3f (improved %d, regressed %d, unchanged %d)",
+			cmp.Baseline.Ranker,
+			cmp.Baseline.PassAt1Rate*100,
+			cmp.Baseline.MRR,
+			cmp.Candidate.Ranker,
+			cmp.Candidate.PassAt1Rate*100,
+			cmp.Candidate.MRR,
+			cmp.Improved,
+			cmp.Regressed,
+			cmp.Unchanged,
+		)
+	}
 	for _, artifact := range result.ReportArtifacts {
 		log.Printf("Foundation artifact: %s (%s) -> %s", artifact.Name, artifact.Format, artifact.Path)
 	}
+	if strings.TrimSpace(options.PublishEndpoint) != "" {
+		log.Printf("Foundation result %s published to %s", result.RunID, options.PublishEndpoint)
+	}
 
 	return nil
 }
+
+func splitFlagList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
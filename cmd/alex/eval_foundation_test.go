@@ -77,3 +77,41 @@ scenarios:
 		t.Fatalf("unexpected error: %v", err)
 	}
 }
+
+func TestRunFoundationEvaluationExplainUnknownCase(t *testing.T) {
+	t.Parallel()
+	tmp := t.TempDir()
+	casePath := filepath.Join(tmp, "cases.yaml")
+	caseYAML := `
+version: "1"
+name: "mini"
+scenarios:
+  - id: "case-1"
+    category: "planning"
+    intent: "Break this task into milestones."
+    expected_tools: ["plan"]
+`
+	if err := os.WriteFile(casePath, []byte(caseYAML), 0644); err != nil {
+		t.Fatalf("write cases: %v", err)
+	}
+
+	var c CLI
+	err := c.runFoundationEvaluation([]string{
+		"--cases", casePath,
+		"--explain", "case-2",
+	})
+	if err == nil || !strings.Contains(err.Error(), `"case-2" not found`) {
+		t.Fatalf("expected unknown case error, got %v", err)
+	}
+}
+
+func TestSplitFlagList(t *testing.T) {
+	t.Parallel()
+	got := splitFlagList(" a, ,b ,")
+	if strings.Join(got, "|") != "a|b" {
+		t.Fatalf("unexpected split: %q", got)
+	}
+	if splitFlagList("") != nil {
+		t.Fatal("expected nil for empty flag")
+	}
+}
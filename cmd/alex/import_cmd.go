@@ -0,0 +1,109 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"alex/internal/app/sessionimport"
+	agentstorage "alex/internal/domain/agent/ports/storage"
+	"alex/internal/infra/memory"
+)
+
+const importUsage = "usage: alex import {chatgpt|claude} <export.zip|conversations.json> [--capture-memory] [--user <id>] [--json]"
+
+func (c *CLI) handleImport(args []string) error {
+	if c == nil || c.container == nil {
+		return fmt.Errorf("container not initialized")
+	}
+	return executeImportCommand(cliBaseContext(), args, os.Stdout, c.container.Container.SessionStore, c.container.Container.MemoryEngine)
+}
+
+func executeImportCommand(ctx context.Context, args []string, w io.Writer, sessions agentstorage.SessionStore, engine memory.Engine) error {
+	if len(args) == 0 || args[0] == "-h" || args[0] == "--help" || args[0] == "help" {
+		fmt.Fprintln(w, importUsage)
+		return nil
+	}
+	source, err := sessionimport.ParseSource(args[0])
+	if err != nil {
+		return &ExitCodeError{Code: 2, Err: err}
+	}
+
+	fs, flagBuf := newBufferedFlagSet("alex import")
+	captureMemory := fs.Bool("capture-memory", false, "Run memory capture over imported conversations")
+	userID := fs.String("user", "", "Owner user ID recorded on imported sessions")
+	jsonOut := fs.Bool("json", false, "Print the import report as JSON")
+
+	rest := args[1:]
+	var filePath string
+	if len(rest) > 0 && !strings.HasPrefix(rest[0], "-") {
+		filePath = rest[0]
+		rest = rest[1:]
+	}
+	if err := fs.Parse(rest); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			fmt.Fprintln(w, importUsage)
+			return nil
+		}
+		return &ExitCodeError{Code: 2, Err: formatBufferedFlagParseError(err, flagBuf)}
+	}
+	if filePath == "" && fs.NArg() > 0 {
+		filePath = fs.Arg(0)
+	}
+	if strings.TrimSpace(filePath) == "" {
+		return &ExitCodeError{Code: 2, Err: errors.New(importUsage)}
+	}
+	if sessions == nil {
+		return fmt.Errorf("session store not configured")
+	}
+	if *captureMemory && engine == nil {
+		return fmt.Errorf("--capture-memory requires the memory engine")
+	}
+
+	importer := sessionimport.New(sessions, sessionimport.NewMemoryCapture(engine), nil)
+	report, importErr := importer.ImportFile(ctx, filePath, sessionimport.Options{
+		Source:        source,
+		UserID:        strings.TrimSpace(*userID),
+		CaptureMemory: *captureMemory,
+	})
+	if *jsonOut {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(report); err != nil {
+			return err
+		}
+	} else {
+		printImportReport(w, report)
+	}
+	if importErr != nil {
+		return fmt.Errorf("import stopped after %d sessions: %w", report.SessionsCreated, importErr)
+	}
+	return nil
+}
+
+func printImportReport(w io.Writer, report sessionimport.Report) {
+	fmt.Fprintf(w, "Imported %s export\n", report.Source)
+	fmt.Fprintf(w, "  sessions created:  %d\n", report.SessionsCreated)
+	fmt.Fprintf(w, "  messages imported: %d\n", report.MessagesImported)
+	if report.MemoryCaptured > 0 || report.MemoryErrors > 0 {
+		fmt.Fprintf(w, "  memory captured:   %d (%d failed)\n", report.MemoryCaptured, report.MemoryErrors)
+	}
+	if len(report.SkippedByReason) == 0 {
+		return
+	}
+	reasons := make([]string, 0, len(report.SkippedByReason))
+	for reason := range report.SkippedByReason {
+		reasons = append(reasons, reason)
+	}
+	sort.Strings(reasons)
+	fmt.Fprintln(w, "  skipped:")
+	for _, reason := range reasons {
+		fmt.Fprintf(w, "    %-24s %d\n", reason, report.SkippedByReason[reason])
+	}
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"alex/internal/testutil"
+)
+
+func TestExecuteImportCommandPrintsReport(t *testing.T) {
+	exportPath := filepath.Join(t.TempDir(), "conversations.json")
+	export := `[{"uuid":"c1","name":"Hello","created_at":"2024-05-01T10:00:00Z","chat_messages":[
+		{"uuid":"m1","sender":"human","content":[{"type":"text","text":"hi"}]},
+		{"uuid":"m2","sender":"assistant","content":[{"type":"tool_use","name":"web_search"},{"type":"text","text":"hello"}]}
+	]}]`
+	if err := os.WriteFile(exportPath, []byte(export), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	store := &testutil.StubSessionStore{}
+
+	var out bytes.Buffer
+	if err := executeImportCommand(context.Background(), []string{"claude", exportPath, "--user", "u-1"}, &out, store, nil); err != nil {
+		t.Fatalf("import: %v", err)
+	}
+	for _, want := range []string{"sessions created:  1", "messages imported: 2", "tool_call"} {
+		if !strings.Contains(out.String(), want) {
+			t.Fatalf("expected %q in output, got %q", want, out.String())
+		}
+	}
+	if store.Session == nil || store.Session.Metadata["user_id"] != "u-1" {
+		t.Fatalf("expected imported session owned by u-1, got %+v", store.Session)
+	}
+}
+
+func TestExecuteImportCommandRejectsUnknownSource(t *testing.T) {
+	err := executeImportCommand(context.Background(), []string{"bard", "x.zip"}, &bytes.Buffer{}, &testutil.StubSessionStore{}, nil)
+	if err == nil || exitCodeFromError(err) != 2 {
+		t.Fatalf("expected usage error, got %v", err)
+	}
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	serverApp "alex/internal/delivery/server/app"
+)
+
+const journalUsage = "usage: alex journal [--dir <events-root>] <session-id>"
+
+func (c *CLI) handleJournal(args []string) error {
+	// The server persists event journals under <session_dir>/_server.
+	return executeJournalCommand(args, os.Stdout, filepath.Join(c.container.Container.SessionDir(), "_server"))
+}
+
+func executeJournalCommand(args []string, w io.Writer, defaultDir string) error {
+	fs, flagBuf := newBufferedFlagSet("alex journal")
+	dir := fs.String("dir", defaultDir, "Event history root (contains events/)")
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			fmt.Fprintln(w, journalUsage)
+			return nil
+		}
+		return &ExitCodeError{Code: 2, Err: formatBufferedFlagParseError(err, flagBuf)}
+	}
+	if fs.NArg() != 1 || strings.TrimSpace(fs.Arg(0)) == "" {
+		return &ExitCodeError{Code: 2, Err: fmt.Errorf("%s", journalUsage)}
+	}
+	sessionID := strings.TrimSpace(fs.Arg(0))
+
+	segments, err := serverApp.NewFileEventHistoryStore(*dir).Segments(sessionID)
+	if err != nil {
+		return fmt.Errorf("list journal segments: %w", err)
+	}
+	if len(segments) == 0 {
+		fmt.Fprintf(w, "No journal for session %s under %s\n", sessionID, *dir)
+		return nil
+	}
+
+	var total int64
+	for _, seg := range segments {
+		total += seg.Size
+	}
+	fmt.Fprintf(w, "Journal: %s\n  segments: %d\n  bytes:    %d\n\n", sessionID, len(segments), total)
+	for _, seg := range segments {
+		label := fmt.Sprintf("#%d", seg.Index)
+		if seg.Active {
+			label = "active"
+		}
+		kind := "plain"
+		if seg.Compressed {
+			kind = "gzip"
+		}
+		fmt.Fprintf(w, "  %-7s %-5s %12d  %s  %s\n", label, kind, seg.Size, seg.ModTime.Format(time.RFC3339), filepath.Base(seg.Path))
+	}
+	return nil
+}
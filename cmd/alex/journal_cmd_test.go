@@ -0,0 +1,53 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	serverApp "alex/internal/delivery/server/app"
+	domain "alex/internal/domain/agent"
+	agent "alex/internal/domain/agent/ports/agent"
+	"alex/internal/domain/agent/types"
+)
+
+func TestExecuteJournalCommandListsSegments(t *testing.T) {
+	dir := t.TempDir()
+	store := serverApp.NewFileEventHistoryStore(dir, serverApp.WithSegmentMaxBytes(256))
+	for i := 0; i < 5; i++ {
+		base := domain.NewBaseEventFull(agent.LevelCore, "sess-cli", "run", "", "", "", uint64(i), time.Now())
+		if err := store.Append(context.Background(), domain.NewEvent(types.EventNodeStarted, base)); err != nil {
+			t.Fatalf("Append: %v", err)
+		}
+	}
+
+	var out bytes.Buffer
+	if err := executeJournalCommand([]string{"sess-cli"}, &out, dir); err != nil {
+		t.Fatalf("journal: %v", err)
+	}
+	got := out.String()
+	for _, want := range []string{"Journal: sess-cli", "#1      gzip", "sess-cli.jsonl.1.gz"} {
+		if !strings.Contains(got, want) {
+			t.Fatalf("expected %q in output:\n%s", want, got)
+		}
+	}
+
+	out.Reset()
+	if err := executeJournalCommand([]string{"missing"}, &out, dir); err != nil {
+		t.Fatalf("journal missing: %v", err)
+	}
+	if !strings.Contains(out.String(), "No journal for session missing") {
+		t.Fatalf("unexpected output: %q", out.String())
+	}
+}
+
+func TestExecuteJournalCommandRequiresSessionID(t *testing.T) {
+	err := executeJournalCommand(nil, &bytes.Buffer{}, t.TempDir())
+	var exitErr *ExitCodeError
+	if err == nil || !errors.As(err, &exitErr) || exitErr.Code != 2 {
+		t.Fatalf("expected usage exit error, got %v", err)
+	}
+}
@@ -66,9 +66,9 @@ func main() {
 	cleanup := func() { shutdown(container) }
 	defer cleanup()
 
-	// No arguments: enter interactive mode
-	if len(args) == 0 {
-		if err := RunNativeChatUI(container); err != nil {
+	// No arguments (or --resume): enter interactive mode
+	if len(args) == 0 || isChatResumeArgs(args) {
+		if err := RunNativeChatUI(container, len(args) > 0); err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			cleanup()
 			os.Exit(1)
@@ -122,6 +122,12 @@ func isTopLevelHelp(args []string) bool {
 	}
 }
 
+// isChatResumeArgs reports whether args ask for the chat UI with the
+// session picker open (`alex --resume`).
+func isChatResumeArgs(args []string) bool {
+	return len(args) == 1 && args[0] == "--resume"
+}
+
 func exitCodeFromError(err error) int {
 	var exitErr *ExitCodeError
 	if errors.As(err, &exitErr) && exitErr.Code != 0 {
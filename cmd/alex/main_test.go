@@ -117,6 +117,7 @@ func TestRunRegisteredCommand_LeavesContainerCommandsForMainPath(t *testing.T) {
 		{"sessions"},
 		{"cost", "show"},
 		{"resume", "session-123"},
+		{"import", "chatgpt", "export.zip"},
 		{"acp"},
 	} {
 		handled, err := NewCLI(nil).runRegisteredCommand(args)
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"alex/internal/infra/memory"
+)
+
+const memoryUsage = "usage: alex memory reindex [--force] [--json]"
+
+// memoryReindexer is implemented by memory engines with an embedding index.
+type memoryReindexer interface {
+	Reindex(ctx context.Context, opts memory.ReindexOptions) (memory.ReindexStats, error)
+}
+
+func (c *CLI) handleMemory(args []string) error {
+	if c == nil || c.container == nil {
+		return fmt.Errorf("container not initialized")
+	}
+	return executeMemoryCommand(cliBaseContext(), args, os.Stdout, c.container.Container.MemoryEngine)
+}
+
+func executeMemoryCommand(ctx context.Context, args []string, w io.Writer, engine memory.Engine) error {
+	if len(args) == 0 || args[0] == "-h" || args[0] == "--help" || args[0] == "help" {
+		fmt.Fprintln(w, memoryUsage)
+		return nil
+	}
+	switch strings.ToLower(args[0]) {
+	case "reindex":
+		return runMemoryReindex(ctx, args[1:], w, engine)
+	default:
+		return &ExitCodeError{Code: 2, Err: fmt.Errorf("unknown memory subcommand %q (expected: reindex)", args[0])}
+	}
+}
+
+func runMemoryReindex(ctx context.Context, args []string, w io.Writer, engine memory.Engine) error {
+	fs, flagBuf := newBufferedFlagSet("alex memory reindex")
+	force := fs.Bool("force", false, "Drop the index and re-embed every memory (after changing embedder_model)")
+	jsonOut := fs.Bool("json", false, "Print the reindex summary as JSON")
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			fmt.Fprintln(w, memoryUsage)
+			return nil
+		}
+		return &ExitCodeError{Code: 2, Err: formatBufferedFlagParseError(err, flagBuf)}
+	}
+
+	reindexer, ok := engine.(memoryReindexer)
+	if !ok {
+		return memory.ErrIndexDisabled
+	}
+	stats, err := reindexer.Reindex(ctx, memory.ReindexOptions{Force: *force})
+	if err != nil {
+		return err
+	}
+	if *jsonOut {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(stats)
+	}
+	fmt.Fprintf(w, "Memory index rebuilt\n  files:    %d\n  chunks:   %d\n  embedded: %d\n", stats.Files, stats.Chunks, stats.Embedded)
+	if stats.Failed > 0 {
+		fmt.Fprintf(w, "  failed:   %d (see logs; rerun once the embedding provider is reachable)\n", stats.Failed)
+	}
+	return nil
+}
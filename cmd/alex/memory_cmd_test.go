@@ -0,0 +1,48 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"alex/internal/infra/memory"
+)
+
+type stubMemoryReindexer struct {
+	memory.Engine
+	opts memory.ReindexOptions
+}
+
+func (s *stubMemoryReindexer) Reindex(_ context.Context, opts memory.ReindexOptions) (memory.ReindexStats, error) {
+	s.opts = opts
+	return memory.ReindexStats{Files: 3, Chunks: 12, Embedded: 5, Failed: 1}, nil
+}
+
+func TestExecuteMemoryReindexPrintsSummary(t *testing.T) {
+	engine := &stubMemoryReindexer{}
+	var out bytes.Buffer
+	if err := executeMemoryCommand(context.Background(), []string{"reindex", "--force"}, &out, engine); err != nil {
+		t.Fatalf("reindex: %v", err)
+	}
+	if !engine.opts.Force {
+		t.Fatal("expected --force to be passed through")
+	}
+	for _, want := range []string{"files:    3", "embedded: 5", "failed:   1"} {
+		if !strings.Contains(out.String(), want) {
+			t.Fatalf("expected %q in output, got %q", want, out.String())
+		}
+	}
+}
+
+func TestExecuteMemoryReindexWithoutIndex(t *testing.T) {
+	engine := memory.NewMarkdownEngine(t.TempDir())
+	err := executeMemoryCommand(context.Background(), []string{"reindex"}, &bytes.Buffer{}, engine)
+	if !errors.Is(err, memory.ErrIndexDisabled) {
+		t.Fatalf("expected ErrIndexDisabled, got %v", err)
+	}
+	if err := executeMemoryCommand(context.Background(), []string{"compact"}, &bytes.Buffer{}, engine); exitCodeFromError(err) != 2 {
+		t.Fatalf("expected usage error, got %v", err)
+	}
+}
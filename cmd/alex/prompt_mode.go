@@ -0,0 +1,428 @@
+package main
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	domain "alex/internal/domain/agent"
+	"alex/internal/domain/agent/ports"
+	agent "alex/internal/domain/agent/ports/agent"
+	"alex/internal/domain/agent/types"
+	"alex/internal/infra/tools/builtin/shared"
+	"alex/internal/shared/logging"
+	id "alex/internal/shared/utils/id"
+)
+
+const (
+	promptOutputText = "text"
+	promptOutputJSON = "json"
+
+	// promptStopAwaitUserInput is the stop reason the ReAct loop reports when
+	// it pauses for a reply. A headless run cannot answer, so it fails.
+	promptStopAwaitUserInput = "await_user_input"
+
+	promptExitTaskFailed    = 1
+	promptExitAwaitingInput = 2
+
+	// maxPromptStdinBytes bounds piped context so a stray `< /dev/zero`
+	// cannot exhaust memory before the task even starts.
+	maxPromptStdinBytes = 4 << 20
+)
+
+// promptModeOptions configures a non-interactive `alex -p` run.
+type promptModeOptions struct {
+	Prompt string
+	Output string
+}
+
+// promptToolCall is one tool invocation observed during a prompt-mode run.
+type promptToolCall struct {
+	CallID     string         `json:"call_id"`
+	Name       string         `json:"name"`
+	Arguments  map[string]any `json:"arguments,omitempty"`
+	Error      string         `json:"error,omitempty"`
+	DurationMS int64          `json:"duration_ms"`
+}
+
+// promptAttachment is a final-answer attachment materialized on disk.
+type promptAttachment struct {
+	Name      string `json:"name"`
+	MediaType string `json:"media_type,omitempty"`
+	Path      string `json:"path,omitempty"`
+	URI       string `json:"uri,omitempty"`
+}
+
+// promptUsage reports token usage for a prompt-mode run.
+type promptUsage struct {
+	ContextTokens    int `json:"context_tokens"`
+	PromptTokens     int `json:"prompt_tokens"`
+	CompletionTokens int `json:"completion_tokens"`
+	TotalTokens      int `json:"total_tokens"`
+	LLMCalls         int `json:"llm_calls"`
+}
+
+// promptModeResult is the machine-readable output of `alex -p --output json`.
+type promptModeResult struct {
+	SessionID   string             `json:"session_id,omitempty"`
+	RunID       string             `json:"run_id,omitempty"`
+	Answer      string             `json:"answer"`
+	StopReason  string             `json:"stop_reason,omitempty"`
+	Iterations  int                `json:"iterations"`
+	DurationMS  int64              `json:"duration_ms"`
+	Usage       promptUsage        `json:"usage"`
+	ToolCalls   []promptToolCall   `json:"tool_calls"`
+	Attachments []promptAttachment `json:"attachments"`
+	Error       string             `json:"error,omitempty"`
+}
+
+// isPromptModeInvocation reports whether args request a non-interactive
+// single-prompt run rather than a subcommand or a plain task.
+func isPromptModeInvocation(args []string) bool {
+	if len(args) == 0 {
+		return false
+	}
+	name := strings.TrimLeft(args[0], "-")
+	if name == args[0] {
+		return false
+	}
+	if idx := strings.Index(name, "="); idx >= 0 {
+		name = name[:idx]
+	}
+	switch name {
+	case "p", "prompt", "output":
+		return true
+	default:
+		return false
+	}
+}
+
+func parsePromptModeArgs(args []string) (promptModeOptions, error) {
+	opts := promptModeOptions{Output: promptOutputText}
+	fs, flagBuf := newBufferedFlagSet("alex -p")
+	fs.StringVar(&opts.Prompt, "p", "", "Task prompt to run non-interactively")
+	fs.StringVar(&opts.Prompt, "prompt", "", "Task prompt to run non-interactively")
+	fs.StringVar(&opts.Output, "output", promptOutputText, "Output format: text|json")
+	if err := fs.Parse(args); err != nil {
+		return opts, formatBufferedFlagParseError(err, flagBuf)
+	}
+
+	opts.Prompt = strings.TrimSpace(opts.Prompt)
+	if rest := strings.TrimSpace(strings.Join(fs.Args(), " ")); rest != "" {
+		if opts.Prompt == "" {
+			opts.Prompt = rest
+		} else {
+			opts.Prompt += " " + rest
+		}
+	}
+	if opts.Prompt == "" {
+		return opts, fmt.Errorf("usage: alex -p \"<task>\" [--output text|json] [< context]")
+	}
+
+	opts.Output = strings.ToLower(strings.TrimSpace(opts.Output))
+	switch opts.Output {
+	case promptOutputText, promptOutputJSON:
+	default:
+		return opts, fmt.Errorf("invalid --output %q (expected text or json)", opts.Output)
+	}
+	return opts, nil
+}
+
+// readPromptStdin returns piped stdin content, or "" when stdin is a terminal.
+func readPromptStdin(in io.Reader) (string, error) {
+	if in == nil || detectInteractive(in) {
+		return "", nil
+	}
+	data, err := io.ReadAll(io.LimitReader(in, maxPromptStdinBytes+1))
+	if err != nil {
+		return "", fmt.Errorf("read stdin: %w", err)
+	}
+	if len(data) > maxPromptStdinBytes {
+		return "", fmt.Errorf("stdin exceeds %d bytes", maxPromptStdinBytes)
+	}
+	return string(data), nil
+}
+
+// buildPromptTask appends piped stdin to the prompt as task context.
+func buildPromptTask(prompt, stdin string) string {
+	if strings.TrimSpace(stdin) == "" {
+		return prompt
+	}
+	return fmt.Sprintf("%s\n\nContext from stdin:\n```\n%s\n```", prompt, strings.TrimRight(stdin, "\n"))
+}
+
+// promptToolRecorder collects tool calls from the event stream.
+type promptToolRecorder struct {
+	mu      sync.Mutex
+	order   []string
+	calls   map[string]*promptToolCall
+	counter int
+}
+
+func newPromptToolRecorder() *promptToolRecorder {
+	return &promptToolRecorder{calls: make(map[string]*promptToolCall)}
+}
+
+// OnEvent implements agent.EventListener
+func (r *promptToolRecorder) OnEvent(event agent.AgentEvent) {
+	var evt *domain.Event
+	switch e := event.(type) {
+	case *domain.WorkflowEventEnvelope:
+		evt = envelopeToEvent(e)
+	case *domain.Event:
+		evt = e
+	}
+	if evt == nil {
+		return
+	}
+	// Subagent tool calls are reported through their parent's tool call.
+	if evt.GetAgentLevel() == types.LevelSubagent {
+		return
+	}
+
+	switch evt.Kind {
+	case types.EventToolStarted:
+		call := r.lookup(evt.Data.CallID, evt.Data.ToolName)
+		r.mu.Lock()
+		call.Arguments = evt.Data.Arguments
+		r.mu.Unlock()
+	case types.EventToolCompleted:
+		call := r.lookup(evt.Data.CallID, evt.Data.ToolName)
+		r.mu.Lock()
+		call.DurationMS = evt.Data.Duration.Milliseconds()
+		call.Error = evt.Data.ErrorStr
+		if call.Error == "" && evt.Data.Error != nil {
+			call.Error = evt.Data.Error.Error()
+		}
+		r.mu.Unlock()
+	}
+}
+
+func (r *promptToolRecorder) lookup(callID, toolName string) *promptToolCall {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if callID == "" {
+		r.counter++
+		callID = fmt.Sprintf("call-%d", r.counter)
+	}
+	call, ok := r.calls[callID]
+	if !ok {
+		call = &promptToolCall{CallID: callID}
+		r.calls[callID] = call
+		r.order = append(r.order, callID)
+	}
+	if call.Name == "" {
+		call.Name = toolName
+	}
+	return call
+}
+
+// Calls returns recorded tool calls in start order.
+func (r *promptToolRecorder) Calls() []promptToolCall {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	out := make([]promptToolCall, 0, len(r.order))
+	for _, callID := range r.order {
+		out = append(out, *r.calls[callID])
+	}
+	return out
+}
+
+// writePromptAttachments writes inline attachments into dir. Attachments that
+// only carry a remote URI are reported by URI without a local file.
+func writePromptAttachments(dir string, attachments map[string]ports.Attachment) ([]promptAttachment, error) {
+	names := make([]string, 0, len(attachments))
+	for key := range attachments {
+		names = append(names, key)
+	}
+	sort.Strings(names)
+
+	out := make([]promptAttachment, 0, len(names))
+	for _, key := range names {
+		att := attachments[key]
+		name := strings.TrimSpace(att.Name)
+		if name == "" {
+			name = key
+		}
+		entry := promptAttachment{Name: name, MediaType: att.MediaType}
+
+		payload := ports.AttachmentInlineBase64(att)
+		if payload == "" {
+			entry.URI = strings.TrimSpace(att.URI)
+			out = append(out, entry)
+			continue
+		}
+		data, err := base64.StdEncoding.DecodeString(payload)
+		if err != nil {
+			return out, fmt.Errorf("decode attachment %s: %w", name, err)
+		}
+		base := filepath.Base(name)
+		if base == "." || base == ".." || base == string(filepath.Separator) {
+			base = fmt.Sprintf("attachment-%d", len(out)+1)
+		}
+		path := filepath.Join(dir, base)
+		if err := os.WriteFile(path, data, 0o600); err != nil {
+			return out, fmt.Errorf("write attachment %s: %w", name, err)
+		}
+		entry.Path = path
+		out = append(out, entry)
+	}
+	return out, nil
+}
+
+func newPromptModeResult(result *agent.TaskResult, calls []promptToolCall) promptModeResult {
+	out := promptModeResult{
+		ToolCalls:   calls,
+		Attachments: []promptAttachment{},
+	}
+	if out.ToolCalls == nil {
+		out.ToolCalls = []promptToolCall{}
+	}
+	if result == nil {
+		return out
+	}
+	out.SessionID = result.SessionID
+	out.RunID = result.RunID
+	out.Answer = result.Answer
+	out.StopReason = result.StopReason
+	out.Iterations = result.Iterations
+	out.DurationMS = result.Duration.Milliseconds()
+	out.Usage = promptUsage{
+		ContextTokens:    result.TokensUsed,
+		PromptTokens:     result.TokenBreakdown.TotalPromptTokens,
+		CompletionTokens: result.TokenBreakdown.TotalCompletionTokens,
+		TotalTokens:      result.TokenBreakdown.TotalTokens,
+		LLMCalls:         result.TokenBreakdown.LLMCalls,
+	}
+	return out
+}
+
+// promptModeExitError maps a finished run to the process exit status.
+func promptModeExitError(result promptModeResult) error {
+	if result.Error != "" {
+		return &ExitCodeError{Code: promptExitTaskFailed, Err: errors.New(result.Error)}
+	}
+	if result.StopReason == promptStopAwaitUserInput {
+		return &ExitCodeError{Code: promptExitAwaitingInput, Err: fmt.Errorf("task is waiting for user input")}
+	}
+	return nil
+}
+
+func renderPromptModeResult(out io.Writer, format string, result promptModeResult) error {
+	if format == promptOutputJSON {
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(result)
+	}
+
+	if answer := strings.TrimSpace(result.Answer); answer != "" {
+		if _, err := fmt.Fprintln(out, answer); err != nil {
+			return err
+		}
+	}
+	for _, att := range result.Attachments {
+		ref := att.Path
+		if ref == "" {
+			ref = att.URI
+		}
+		if _, err := fmt.Fprintf(out, "attachment: %s (%s)\n", att.Name, ref); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// runPromptMode executes a single task without the interactive UI and prints
+// the result in the requested format.
+func (c *CLI) runPromptMode(args []string) error {
+	opts, err := parsePromptModeArgs(args)
+	if err != nil {
+		return err
+	}
+	if c.container == nil || c.container.Container == nil || c.container.Container.AgentCoordinator == nil {
+		return fmt.Errorf("container not initialized")
+	}
+	stdin, err := readPromptStdin(os.Stdin)
+	if err != nil {
+		return err
+	}
+	return executePromptMode(c.container, buildPromptTask(opts.Prompt, stdin), opts.Output, os.Stdout)
+}
+
+func executePromptMode(container *Container, task, format string, out io.Writer) error {
+	ctx, cancel := signal.NotifyContext(cliBaseContext(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	session, err := container.Container.SessionStore.Create(ctx)
+	if err != nil {
+		return fmt.Errorf("create session: %w", err)
+	}
+	sessionID := session.ID
+
+	ctx = id.WithSessionID(ctx, sessionID)
+	ctx = id.WithRunID(ctx, id.NewRunID())
+	// Nobody is at the terminal to answer approval prompts, so approvals take
+	// the same non-interactive path as piped stdin.
+	ctx = shared.WithApprover(ctx, newCLIApproverWithIO(sessionID, nil, os.Stderr, false))
+	ctx = shared.WithAutoApprove(ctx, false)
+
+	ids := id.IDsFromContext(ctx)
+	ctx = types.WithOutputContext(ctx, &types.OutputContext{
+		Level:        types.LevelCore,
+		AgentID:      "core",
+		SessionID:    ids.SessionID,
+		TaskID:       ids.RunID,
+		ParentTaskID: ids.ParentRunID,
+		LogID:        ids.LogID,
+	})
+
+	logger := logging.FromContext(ctx, logging.NewComponentLogger("CLIPromptMode"))
+	ctx = applyPinnedCLILLMSelection(ctx, runtimeEnvLookup(), logger)
+
+	recorder := newPromptToolRecorder()
+	ctx = shared.WithParentListener(ctx, recorder)
+
+	startedAt := time.Now()
+	taskResult, execErr := container.Container.AgentCoordinator.ExecuteTask(ctx, task, sessionID, recorder)
+	return finishPromptMode(out, format, taskResult, execErr, recorder.Calls(), sessionID, time.Since(startedAt))
+}
+
+func finishPromptMode(out io.Writer, format string, taskResult *agent.TaskResult, execErr error, calls []promptToolCall, sessionID string, elapsed time.Duration) error {
+	result := newPromptModeResult(taskResult, calls)
+	if result.SessionID == "" {
+		result.SessionID = sessionID
+	}
+	if result.DurationMS == 0 {
+		result.DurationMS = elapsed.Milliseconds()
+	}
+	if execErr != nil {
+		result.Error = fmt.Sprintf("task execution failed: %v", execErr)
+	}
+
+	if taskResult != nil && len(taskResult.Attachments) > 0 {
+		dir, err := os.MkdirTemp("", "alex-attachments-")
+		if err != nil {
+			return fmt.Errorf("create attachment dir: %w", err)
+		}
+		attachments, err := writePromptAttachments(dir, taskResult.Attachments)
+		result.Attachments = attachments
+		if err != nil && result.Error == "" {
+			result.Error = err.Error()
+		}
+	}
+
+	if err := renderPromptModeResult(out, format, result); err != nil {
+		return fmt.Errorf("write output: %w", err)
+	}
+	return promptModeExitError(result)
+}
@@ -0,0 +1,198 @@
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	domain "alex/internal/domain/agent"
+	"alex/internal/domain/agent/ports"
+	agent "alex/internal/domain/agent/ports/agent"
+	"alex/internal/domain/agent/types"
+)
+
+func TestIsPromptModeInvocation(t *testing.T) {
+	cases := map[string]bool{
+		"-p":          true,
+		"--prompt":    true,
+		"--prompt=hi": true,
+		"--output":    true,
+		"-v":          false,
+		"sessions":    false,
+		"list files":  false,
+	}
+	for arg, want := range cases {
+		if got := isPromptModeInvocation([]string{arg}); got != want {
+			t.Errorf("isPromptModeInvocation(%q) = %v, want %v", arg, got, want)
+		}
+	}
+	if isPromptModeInvocation(nil) {
+		t.Error("expected empty args not to be prompt mode")
+	}
+}
+
+func TestParsePromptModeArgs(t *testing.T) {
+	opts, err := parsePromptModeArgs([]string{"-p", "review this", "--output", "JSON"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if opts.Prompt != "review this" || opts.Output != promptOutputJSON {
+		t.Fatalf("unexpected options: %+v", opts)
+	}
+
+	opts, err = parsePromptModeArgs([]string{"--output=text", "fix", "the", "bug"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if opts.Prompt != "fix the bug" || opts.Output != promptOutputText {
+		t.Fatalf("unexpected options: %+v", opts)
+	}
+
+	if _, err := parsePromptModeArgs([]string{"--output", "json"}); err == nil || !strings.Contains(err.Error(), "usage") {
+		t.Fatalf("expected usage error for missing prompt, got %v", err)
+	}
+	if _, err := parsePromptModeArgs([]string{"-p", "x", "--output", "yaml"}); err == nil || !strings.Contains(err.Error(), "invalid --output") {
+		t.Fatalf("expected invalid output error, got %v", err)
+	}
+}
+
+func TestBuildPromptTaskAppendsStdin(t *testing.T) {
+	if got := buildPromptTask("review", "  \n"); got != "review" {
+		t.Fatalf("blank stdin should be ignored, got %q", got)
+	}
+	got := buildPromptTask("review", "diff --git a/x b/x\n")
+	if !strings.HasPrefix(got, "review\n\nContext from stdin:\n```\n") || !strings.Contains(got, "diff --git a/x b/x\n```") {
+		t.Fatalf("unexpected task: %q", got)
+	}
+}
+
+func TestReadPromptStdinRejectsOversizedInput(t *testing.T) {
+	got, err := readPromptStdin(strings.NewReader("patch"))
+	if err != nil || got != "patch" {
+		t.Fatalf("readPromptStdin = %q, %v", got, err)
+	}
+	if _, err := readPromptStdin(bytes.NewReader(make([]byte, maxPromptStdinBytes+1))); err == nil {
+		t.Fatal("expected error for oversized stdin")
+	}
+}
+
+func TestPromptToolRecorderCollectsCalls(t *testing.T) {
+	rec := newPromptToolRecorder()
+	base := domain.NewBaseEvent(types.LevelCore, "sess", "run", "", time.Now())
+	rec.OnEvent(domain.NewToolStartedEvent(base, 1, "c1", "read_file", map[string]any{"path": "a.go"}))
+	rec.OnEvent(domain.NewToolStartedEvent(base, 1, "c2", "shell_exec", nil))
+	rec.OnEvent(domain.NewToolCompletedEvent(base, "c2", "shell_exec", "", errors.New("exit 1"), 30*time.Millisecond, nil, nil))
+	rec.OnEvent(domain.NewToolCompletedEvent(base, "c1", "read_file", "ok", nil, 5*time.Millisecond, nil, nil))
+
+	sub := domain.NewBaseEvent(types.LevelSubagent, "sess", "child", "run", time.Now())
+	rec.OnEvent(domain.NewToolStartedEvent(sub, 1, "c3", "web_search", nil))
+
+	calls := rec.Calls()
+	if len(calls) != 2 {
+		t.Fatalf("expected 2 calls, got %+v", calls)
+	}
+	if calls[0].Name != "read_file" || calls[0].Arguments["path"] != "a.go" || calls[0].DurationMS != 5 || calls[0].Error != "" {
+		t.Fatalf("unexpected first call: %+v", calls[0])
+	}
+	if calls[1].Name != "shell_exec" || calls[1].Error != "exit 1" || calls[1].DurationMS != 30 {
+		t.Fatalf("unexpected second call: %+v", calls[1])
+	}
+}
+
+func TestWritePromptAttachments(t *testing.T) {
+	dir := t.TempDir()
+	attachments := map[string]ports.Attachment{
+		"report.md": {Name: "report.md", MediaType: "text/markdown", Data: base64.StdEncoding.EncodeToString([]byte("# hi"))},
+		"chart.png": {Name: "chart.png", MediaType: "image/png", URI: "data:image/png;base64," + base64.StdEncoding.EncodeToString([]byte("png"))},
+		"remote":    {Name: "remote.pdf", URI: "https://cdn.example.com/remote.pdf"},
+	}
+
+	got, err := writePromptAttachments(dir, attachments)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 3 {
+		t.Fatalf("expected 3 attachments, got %+v", got)
+	}
+	if got[0].Name != "chart.png" || got[1].Name != "remote.pdf" || got[2].Name != "report.md" {
+		t.Fatalf("attachments not sorted by key: %+v", got)
+	}
+	data, err := os.ReadFile(got[2].Path)
+	if err != nil || string(data) != "# hi" {
+		t.Fatalf("report.md = %q, %v", data, err)
+	}
+	if data, _ := os.ReadFile(got[0].Path); string(data) != "png" {
+		t.Fatalf("chart.png = %q", data)
+	}
+	if got[1].Path != "" || got[1].URI != "https://cdn.example.com/remote.pdf" {
+		t.Fatalf("remote attachment should be reported by URI: %+v", got[1])
+	}
+}
+
+func TestFinishPromptModeJSONOutput(t *testing.T) {
+	result := &agent.TaskResult{
+		Answer:     "looks good",
+		StopReason: "final_answer",
+		Iterations: 2,
+		SessionID:  "sess-1",
+		RunID:      "run-1",
+		TokensUsed: 900,
+		TokenBreakdown: agent.LLMTokenBreakdown{
+			TotalPromptTokens:     700,
+			TotalCompletionTokens: 120,
+			TotalTokens:           820,
+			LLMCalls:              2,
+		},
+	}
+	calls := []promptToolCall{{CallID: "c1", Name: "read_file", DurationMS: 4}}
+
+	var out bytes.Buffer
+	if err := finishPromptMode(&out, promptOutputJSON, result, nil, calls, "sess-1", time.Second); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var decoded promptModeResult
+	if err := json.Unmarshal(out.Bytes(), &decoded); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, out.String())
+	}
+	if decoded.Answer != "looks good" || decoded.Usage.TotalTokens != 820 || decoded.Usage.ContextTokens != 900 {
+		t.Fatalf("unexpected result: %+v", decoded)
+	}
+	if len(decoded.ToolCalls) != 1 || decoded.ToolCalls[0].Name != "read_file" {
+		t.Fatalf("unexpected tool calls: %+v", decoded.ToolCalls)
+	}
+	if decoded.Attachments == nil || decoded.DurationMS != 1000 {
+		t.Fatalf("expected empty attachments and elapsed fallback: %+v", decoded)
+	}
+}
+
+func TestFinishPromptModeExitCodes(t *testing.T) {
+	var out bytes.Buffer
+	err := finishPromptMode(&out, promptOutputText, &agent.TaskResult{Answer: "which file?", StopReason: promptStopAwaitUserInput}, nil, nil, "s", 0)
+	if got := exitCodeFromError(err); err == nil || got != promptExitAwaitingInput {
+		t.Fatalf("await_user_input exit = %d (%v), want %d", got, err, promptExitAwaitingInput)
+	}
+	if !strings.Contains(out.String(), "which file?") {
+		t.Fatalf("text output should include the answer, got %q", out.String())
+	}
+
+	out.Reset()
+	err = finishPromptMode(&out, promptOutputJSON, nil, errors.New("llm unavailable"), nil, "s", 0)
+	if got := exitCodeFromError(err); err == nil || got != promptExitTaskFailed {
+		t.Fatalf("failed task exit = %d (%v), want %d", got, err, promptExitTaskFailed)
+	}
+	var decoded promptModeResult
+	if jsonErr := json.Unmarshal(out.Bytes(), &decoded); jsonErr != nil || !strings.Contains(decoded.Error, "llm unavailable") {
+		t.Fatalf("expected JSON error payload, got %q (%v)", out.String(), jsonErr)
+	}
+
+	out.Reset()
+	if err := finishPromptMode(&out, promptOutputText, &agent.TaskResult{Answer: "done", StopReason: "final_answer"}, nil, nil, "s", 0); err != nil {
+		t.Fatalf("expected success, got %v", err)
+	}
+}
@@ -8,11 +8,12 @@ import (
 )
 
 // RunNativeChatUI starts the interactive chat UI using native line-mode.
-func RunNativeChatUI(container *Container) error {
+// When resume is set the session picker opens before the first prompt.
+func RunNativeChatUI(container *Container, resume bool) error {
 	if container == nil {
 		return fmt.Errorf("container is nil")
 	}
 
 	output.ConfigureCLIColorProfile(os.Stdout)
-	return runLineChatUI(container, os.Stdin, os.Stdout, os.Stderr)
+	return runLineChatUI(container, os.Stdin, os.Stdout, os.Stderr, resume)
 }
@@ -10,6 +10,10 @@ const (
 	commandQuit
 	commandClear
 	commandHelp
+	commandTitle
+	commandMarks
+	commandResume
+	commandExport
 	commandRun
 )
 
@@ -31,7 +35,26 @@ func parseUserCommand(input string) userCommand {
 		return userCommand{kind: commandClear}
 	case "/help", "/?":
 		return userCommand{kind: commandHelp}
-	default:
-		return userCommand{kind: commandRun, task: trimmed}
+	case "/title":
+		return userCommand{kind: commandTitle}
+	case "/marks":
+		return userCommand{kind: commandMarks}
+	case "/resume":
+		return userCommand{kind: commandResume}
+	case "/export":
+		return userCommand{kind: commandExport}
 	}
+	if rest, ok := strings.CutPrefix(trimmed, "/title "); ok {
+		return userCommand{kind: commandTitle, task: strings.TrimSpace(rest)}
+	}
+	if rest, ok := strings.CutPrefix(trimmed, "/marks "); ok {
+		return userCommand{kind: commandMarks, task: strings.TrimSpace(rest)}
+	}
+	if rest, ok := strings.CutPrefix(trimmed, "/resume "); ok {
+		return userCommand{kind: commandResume, task: strings.TrimSpace(rest)}
+	}
+	if rest, ok := strings.CutPrefix(trimmed, "/export "); ok {
+		return userCommand{kind: commandExport, task: strings.TrimSpace(rest)}
+	}
+	return userCommand{kind: commandRun, task: trimmed}
 }
@@ -16,6 +16,14 @@ func TestParseUserCommand(t *testing.T) {
 		{name: "clear", input: "/clear", kind: commandClear},
 		{name: "help", input: "/help", kind: commandHelp},
 		{name: "help short", input: "/?", kind: commandHelp},
+		{name: "title show", input: "/title", kind: commandTitle},
+		{name: "title set", input: "/title  Release prep ", kind: commandTitle, task: "Release prep"},
+		{name: "marks list", input: "/marks", kind: commandMarks},
+		{name: "marks jump", input: "/marks 2 ", kind: commandMarks, task: "2"},
+		{name: "resume picker", input: "/resume", kind: commandResume},
+		{name: "export default", input: "/export", kind: commandExport},
+		{name: "export json", input: "/export out.json --format json", kind: commandExport, task: "out.json --format json"},
+		{name: "resume filtered", input: "/resume  deploy ", kind: commandResume, task: "deploy"},
 		{name: "task trimmed", input: "  hello  ", kind: commandRun, task: "hello"},
 		{name: "command as task", input: "/unknown", kind: commandRun, task: "/unknown"},
 	}
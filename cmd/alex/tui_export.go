@@ -0,0 +1,230 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"alex/internal/domain/agent/ports"
+	storage "alex/internal/domain/agent/ports/storage"
+)
+
+const (
+	exportFormatMarkdown = "md"
+	exportFormatJSON     = "json"
+)
+
+// exportOptions is the parsed argument of /export.
+type exportOptions struct {
+	Path   string
+	Format string
+}
+
+// parseExportArgs accepts `[path] [--format md|json]` in any order.
+func parseExportArgs(arg string) (exportOptions, error) {
+	opts := exportOptions{Format: exportFormatMarkdown}
+	fields := strings.Fields(arg)
+	for i := 0; i < len(fields); i++ {
+		field := fields[i]
+		switch {
+		case field == "--format":
+			if i+1 >= len(fields) {
+				return opts, fmt.Errorf("usage: /export [path] [--format md|json]")
+			}
+			i++
+			opts.Format = fields[i]
+		case strings.HasPrefix(field, "--format="):
+			opts.Format = strings.TrimPrefix(field, "--format=")
+		case opts.Path == "":
+			opts.Path = field
+		default:
+			return opts, fmt.Errorf("usage: /export [path] [--format md|json]")
+		}
+	}
+	switch strings.ToLower(opts.Format) {
+	case "md", "markdown":
+		opts.Format = exportFormatMarkdown
+	case exportFormatJSON:
+		opts.Format = exportFormatJSON
+	default:
+		return opts, fmt.Errorf("unknown export format %q (expected md or json)", opts.Format)
+	}
+	return opts, nil
+}
+
+func defaultExportPath(sessionID, format string, now time.Time) string {
+	return fmt.Sprintf("alex-session-%s-%s.%s", sessionID, now.Format("20060102-150405"), format)
+}
+
+// sessionExportFunc binds /export to the current session. It returns nil when
+// the session store is not configured.
+func sessionExportFunc(ctx context.Context, sessions storage.SessionStore, sessionID string) func(string) (string, error) {
+	if sessions == nil {
+		return nil
+	}
+	return func(arg string) (string, error) {
+		opts, err := parseExportArgs(arg)
+		if err != nil {
+			return "", err
+		}
+		session, err := sessions.Get(ctx, sessionID)
+		if err != nil {
+			return "", fmt.Errorf("load session: %w", err)
+		}
+		now := time.Now()
+		path := opts.Path
+		if path == "" {
+			path = defaultExportPath(sessionID, opts.Format, now)
+		}
+
+		var data []byte
+		if opts.Format == exportFormatJSON {
+			data, err = json.MarshalIndent(session.Messages, "", "  ")
+			if err != nil {
+				return "", fmt.Errorf("encode messages: %w", err)
+			}
+			data = append(data, '\n')
+		} else {
+			data = []byte(renderSessionMarkdown(session, now))
+		}
+		if err := os.WriteFile(path, data, 0o600); err != nil {
+			return "", fmt.Errorf("write %s: %w", path, err)
+		}
+		if abs, err := filepath.Abs(path); err == nil {
+			path = abs
+		}
+		return path, nil
+	}
+}
+
+// exportToolOutcome is what the transcript shows for one tool call.
+type exportToolOutcome struct {
+	Duration time.Duration
+	Failed   bool
+}
+
+// renderSessionMarkdown renders user/assistant turns with each turn's tool
+// calls collapsed into a <details> block, followed by an attachments list.
+func renderSessionMarkdown(session *storage.Session, now time.Time) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Session %s\n\n", session.ID)
+	if title := strings.TrimSpace(session.Metadata["title"]); title != "" {
+		fmt.Fprintf(&b, "- Title: %s\n", title)
+	}
+	if !session.CreatedAt.IsZero() {
+		fmt.Fprintf(&b, "- Created: %s\n", session.CreatedAt.Format(time.RFC3339))
+	}
+	fmt.Fprintf(&b, "- Exported: %s\n", now.Format(time.RFC3339))
+
+	outcomes := collectToolOutcomes(session.Messages)
+	for _, msg := range session.Messages {
+		switch {
+		case isTranscriptUserMessage(msg):
+			fmt.Fprintf(&b, "\n## User\n\n%s\n", strings.TrimSpace(msg.Content))
+		case msg.Role == "assistant" && (msg.Source == ports.MessageSourceAssistantReply || msg.Source == ports.MessageSourceUnknown):
+			content := strings.TrimSpace(msg.Content)
+			if content == "" && len(msg.ToolCalls) == 0 {
+				continue
+			}
+			b.WriteString("\n## Assistant\n")
+			if content != "" {
+				fmt.Fprintf(&b, "\n%s\n", content)
+			}
+			if len(msg.ToolCalls) > 0 {
+				writeToolCallSummary(&b, msg.ToolCalls, outcomes)
+			}
+		}
+	}
+
+	attachments := collectExportAttachments(session)
+	if len(attachments) > 0 {
+		b.WriteString("\n## Attachments\n\n")
+		for _, line := range attachments {
+			fmt.Fprintf(&b, "- %s\n", line)
+		}
+	}
+	return b.String()
+}
+
+func writeToolCallSummary(b *strings.Builder, calls []ports.ToolCall, outcomes map[string]exportToolOutcome) {
+	fmt.Fprintf(b, "\n<details>\n<summary>Tool calls (%d)</summary>\n\n", len(calls))
+	for _, call := range calls {
+		fmt.Fprintf(b, "- `%s`", call.Name)
+		if outcome, ok := outcomes[call.ID]; ok {
+			if outcome.Duration > 0 {
+				fmt.Fprintf(b, " — %s", outcome.Duration.Round(time.Millisecond))
+			}
+			if outcome.Failed {
+				b.WriteString(" — failed")
+			}
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString("\n</details>\n")
+}
+
+// collectToolOutcomes indexes tool results by call ID. Durations come from
+// the `_duration_ms` metadata the ReAct engine records on every result; it is
+// an int64 in memory and a float64 once the session has been reloaded.
+func collectToolOutcomes(messages []ports.Message) map[string]exportToolOutcome {
+	outcomes := make(map[string]exportToolOutcome)
+	for _, msg := range messages {
+		for _, result := range msg.ToolResults {
+			outcome := exportToolOutcome{Failed: result.Error != nil}
+			switch ms := result.Metadata["_duration_ms"].(type) {
+			case int64:
+				outcome.Duration = time.Duration(ms) * time.Millisecond
+			case float64:
+				outcome.Duration = time.Duration(ms) * time.Millisecond
+			case int:
+				outcome.Duration = time.Duration(ms) * time.Millisecond
+			}
+			outcomes[result.CallID] = outcome
+		}
+	}
+	return outcomes
+}
+
+// collectExportAttachments lists session and message attachments once each,
+// by name. Inline payloads are summarized instead of dumped.
+func collectExportAttachments(session *storage.Session) []string {
+	all := make(map[string]ports.Attachment)
+	for _, msg := range session.Messages {
+		for key, att := range msg.Attachments {
+			all[key] = att
+		}
+	}
+	for key, att := range session.Attachments {
+		all[key] = att
+	}
+
+	names := make([]string, 0, len(all))
+	for key := range all {
+		names = append(names, key)
+	}
+	sort.Strings(names)
+
+	lines := make([]string, 0, len(names))
+	for _, key := range names {
+		att := all[key]
+		name := strings.TrimSpace(att.Name)
+		if name == "" {
+			name = key
+		}
+		location := "(inline)"
+		if uri := strings.TrimSpace(att.URI); uri != "" && !strings.HasPrefix(strings.ToLower(uri), "data:") {
+			location = uri
+		}
+		line := fmt.Sprintf("`%s` — %s", name, location)
+		if att.MediaType != "" {
+			line += " (" + att.MediaType + ")"
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
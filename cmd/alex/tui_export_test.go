@@ -0,0 +1,136 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"alex/internal/domain/agent/ports"
+	storage "alex/internal/domain/agent/ports/storage"
+)
+
+func exportTestSession() *storage.Session {
+	return &storage.Session{
+		ID:        "s1",
+		Metadata:  map[string]string{"title": "Schema review"},
+		CreatedAt: time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC),
+		Messages: []ports.Message{
+			{Role: "system", Content: "you are alex", Source: ports.MessageSourceSystemPrompt},
+			{Role: "user", Content: "inspect the schema", Source: ports.MessageSourceUserInput},
+			{Role: "assistant", Content: "Reading files.", Source: ports.MessageSourceAssistantReply, ToolCalls: []ports.ToolCall{
+				{ID: "c1", Name: "read_file"},
+				{ID: "c2", Name: "shell_exec"},
+			}},
+			{Role: "tool", ToolCallID: "c1", Source: ports.MessageSourceToolResult, ToolResults: []ports.ToolResult{
+				{CallID: "c1", Content: "schema.sql", Metadata: map[string]any{"_duration_ms": float64(1250)}},
+			}},
+			{Role: "tool", ToolCallID: "c2", Source: ports.MessageSourceToolResult, ToolResults: []ports.ToolResult{
+				{CallID: "c2", Error: errors.New("exit 1"), Metadata: map[string]any{"_duration_ms": int64(40)}},
+			}, Attachments: map[string]ports.Attachment{
+				"erd.png": {Name: "erd.png", MediaType: "image/png", URI: "data:image/png;base64,AAAA"},
+			}},
+			{Role: "assistant", Content: "The schema is flat.", Source: ports.MessageSourceAssistantReply},
+		},
+		Attachments: map[string]ports.Attachment{
+			"report.md": {Name: "report.md", MediaType: "text/markdown", URI: "https://cdn.example.com/report.md"},
+		},
+	}
+}
+
+func TestParseExportArgs(t *testing.T) {
+	opts, err := parseExportArgs("")
+	if err != nil || opts.Path != "" || opts.Format != exportFormatMarkdown {
+		t.Fatalf("unexpected default options: %+v, %v", opts, err)
+	}
+	opts, err = parseExportArgs("--format json out.json")
+	if err != nil || opts.Path != "out.json" || opts.Format != exportFormatJSON {
+		t.Fatalf("unexpected options: %+v, %v", opts, err)
+	}
+	opts, err = parseExportArgs("notes.md --format=markdown")
+	if err != nil || opts.Path != "notes.md" || opts.Format != exportFormatMarkdown {
+		t.Fatalf("unexpected options: %+v, %v", opts, err)
+	}
+	for _, bad := range []string{"--format", "--format yaml", "a.md b.md"} {
+		if _, err := parseExportArgs(bad); err == nil {
+			t.Fatalf("expected error for %q", bad)
+		}
+	}
+}
+
+func TestDefaultExportPath(t *testing.T) {
+	got := defaultExportPath("s1", exportFormatMarkdown, time.Date(2026, 3, 1, 9, 5, 7, 0, time.UTC))
+	if got != "alex-session-s1-20260301-090507.md" {
+		t.Fatalf("unexpected default path %q", got)
+	}
+}
+
+func TestRenderSessionMarkdown(t *testing.T) {
+	text := renderSessionMarkdown(exportTestSession(), time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC))
+
+	for _, want := range []string{
+		"# Session s1",
+		"- Title: Schema review",
+		"## User\n\ninspect the schema",
+		"## Assistant\n\nReading files.",
+		"<summary>Tool calls (2)</summary>",
+		"- `read_file` — 1.25s",
+		"- `shell_exec` — 40ms — failed",
+		"## Assistant\n\nThe schema is flat.",
+		"## Attachments",
+		"- `erd.png` — (inline) (image/png)",
+		"- `report.md` — https://cdn.example.com/report.md (text/markdown)",
+	} {
+		if !strings.Contains(text, want) {
+			t.Fatalf("markdown missing %q:\n%s", want, text)
+		}
+	}
+	if strings.Contains(text, "you are alex") || strings.Contains(text, "AAAA") {
+		t.Fatalf("markdown leaked system prompt or inline data:\n%s", text)
+	}
+}
+
+func TestSessionExportFuncWritesFiles(t *testing.T) {
+	dir := t.TempDir()
+	store := &marksSessionStore{session: exportTestSession()}
+	export := sessionExportFunc(context.Background(), store, "s1")
+
+	mdPath := filepath.Join(dir, "out.md")
+	written, err := export(mdPath)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if written != mdPath {
+		t.Fatalf("expected %s, got %s", mdPath, written)
+	}
+	if data, err := os.ReadFile(mdPath); err != nil || !strings.Contains(string(data), "inspect the schema") {
+		t.Fatalf("markdown export = %q, %v", data, err)
+	}
+
+	jsonPath := filepath.Join(dir, "out.json")
+	if _, err := export(jsonPath + " --format json"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	data, err := os.ReadFile(jsonPath)
+	if err != nil {
+		t.Fatalf("read json export: %v", err)
+	}
+	var messages []map[string]any
+	if err := json.Unmarshal(data, &messages); err != nil {
+		t.Fatalf("json export is not a message array: %v", err)
+	}
+	if len(messages) != 6 {
+		t.Fatalf("expected all 6 raw messages, got %d", len(messages))
+	}
+
+	if _, err := sessionExportFunc(context.Background(), store, "missing")(""); err == nil {
+		t.Fatal("expected error for unknown session")
+	}
+	if sessionExportFunc(context.Background(), nil, "s1") != nil {
+		t.Fatal("expected nil export func without a session store")
+	}
+}
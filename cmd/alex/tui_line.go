@@ -2,6 +2,7 @@ package main
 
 import (
 	"bufio"
+	"context"
 	"errors"
 	"fmt"
 	"io"
@@ -9,6 +10,7 @@ import (
 	"strings"
 	"time"
 
+	"alex/internal/app/agent/sessiontitle"
 	agentports "alex/internal/domain/agent/ports/agent"
 
 	"golang.org/x/term"
@@ -24,12 +26,24 @@ type lineChatLoop struct {
 	selectUI func(question string, options []string) (string, bool, error)
 	clear    func()
 	header   func()
+	// title shows the session title (empty arg) or overrides it.
+	title func(text string) (string, error)
+	// marks lists the session's annotations (empty arg) or shows the n-th one.
+	marks func(arg string) (string, error)
+	// export writes the session transcript and returns the written path.
+	export func(arg string) (string, error)
+	// listSessions feeds the /resume picker; switchSession rebinds the loop to
+	// the picked session and returns its transcript.
+	listSessions  func() ([]sessionPickerEntry, error)
+	switchSession func(sessionID string) (string, error)
+	// resumeOnStart opens the /resume picker before the first prompt.
+	resumeOnStart bool
 
 	abortCount int
 	lastAbort  time.Time
 }
 
-func runLineChatUI(container *Container, in io.Reader, out io.Writer, errOut io.Writer) error {
+func runLineChatUI(container *Container, in io.Reader, out io.Writer, errOut io.Writer, resume bool) error {
 	if container == nil {
 		return fmt.Errorf("container is nil")
 	}
@@ -45,10 +59,12 @@ func runLineChatUI(container *Container, in io.Reader, out io.Writer, errOut io.
 
 	interactive := isInteractiveTTY(in, out)
 	ctx := cliBaseContext()
-	session, err := container.Container.AgentCoordinator.GetSession(ctx, "")
+	coordinator := container.Container.AgentCoordinator
+	session, err := coordinator.GetSession(ctx, "")
 	if err != nil {
 		return fmt.Errorf("create session: %w", err)
 	}
+	sessionID := session.ID
 
 	header := func() {
 		if interactive {
@@ -73,11 +89,29 @@ func runLineChatUI(container *Container, in io.Reader, out io.Writer, errOut io.
 		out:      out,
 		errOut:   errOut,
 		runTask: func(task string) (*agentports.TaskResult, error) {
-			return RunTaskWithStreamOutputResult(container, task, session.ID)
+			return RunTaskWithStreamOutputResult(container, task, sessionID)
 		},
 		selectUI: newAwaitChoiceSelector(in, out, interactive).Select,
 		clear:    clear,
 		header:   header,
+		title:    sessionTitleFunc(ctx, container.SessionTitler, sessionID),
+		marks:    sessionMarksFunc(ctx, container.SessionStore, container.AnnotationStore, sessionID),
+		export:   sessionExportFunc(ctx, container.SessionStore, sessionID),
+		listSessions: func() ([]sessionPickerEntry, error) {
+			return loadSessionPickerEntries(ctx, coordinator, sessionID)
+		},
+		resumeOnStart: resume,
+	}
+	loop.switchSession = func(id string) (string, error) {
+		resumed, err := coordinator.GetSession(ctx, id)
+		if err != nil {
+			return "", fmt.Errorf("load session %s: %w", id, err)
+		}
+		sessionID = resumed.ID
+		loop.title = sessionTitleFunc(ctx, container.SessionTitler, sessionID)
+		loop.marks = sessionMarksFunc(ctx, container.SessionStore, container.AnnotationStore, sessionID)
+		loop.export = sessionExportFunc(ctx, container.SessionStore, sessionID)
+		return renderSessionTranscript(resumed), nil
 	}
 
 	loop.header()
@@ -89,6 +123,12 @@ func (l *lineChatLoop) run() error {
 		return nil
 	}
 
+	if l.resumeOnStart {
+		if err := l.handleResume(""); err != nil {
+			return err
+		}
+	}
+
 	for {
 		line, ok, err := l.readPrompt()
 		if err != nil {
@@ -114,6 +154,20 @@ func (l *lineChatLoop) run() error {
 				printLineModeHelp(l.out)
 			}
 			continue
+		case commandTitle:
+			l.handleTitle(cmd.task)
+			continue
+		case commandMarks:
+			l.handleMarks(cmd.task)
+			continue
+		case commandExport:
+			l.handleExport(cmd.task)
+			continue
+		case commandResume:
+			if err := l.handleResume(cmd.task); err != nil {
+				return err
+			}
+			continue
 		case commandRun:
 			if l.prompter != nil {
 				l.prompter.AppendHistory(cmd.task)
@@ -127,6 +181,54 @@ func (l *lineChatLoop) run() error {
 	}
 }
 
+func (l *lineChatLoop) handleTitle(text string) {
+	if l.out == nil {
+		return
+	}
+	if l.title == nil {
+		fmt.Fprintln(l.out, styleGray.Render("Session titles are not available."))
+		return
+	}
+	line, err := l.title(text)
+	if err != nil {
+		fmt.Fprintln(l.out, styleGray.Render("Title update failed: "+err.Error()))
+		return
+	}
+	fmt.Fprintln(l.out, styleGray.Render(line))
+}
+
+func (l *lineChatLoop) handleMarks(arg string) {
+	if l.out == nil {
+		return
+	}
+	if l.marks == nil {
+		fmt.Fprintln(l.out, styleGray.Render("Annotations are not available."))
+		return
+	}
+	text, err := l.marks(arg)
+	if err != nil {
+		fmt.Fprintln(l.out, styleGray.Render(err.Error()))
+		return
+	}
+	fmt.Fprintln(l.out, text)
+}
+
+func (l *lineChatLoop) handleExport(arg string) {
+	if l.out == nil {
+		return
+	}
+	if l.export == nil {
+		fmt.Fprintln(l.out, styleGray.Render("Transcript export is not available."))
+		return
+	}
+	path, err := l.export(arg)
+	if err != nil {
+		fmt.Fprintln(l.out, styleGray.Render("Export failed: "+err.Error()))
+		return
+	}
+	fmt.Fprintln(l.out, styleGray.Render("Transcript written to "+path))
+}
+
 func (l *lineChatLoop) readPrompt() (string, bool, error) {
 	if l == nil || l.prompter == nil {
 		return "", false, nil
@@ -231,7 +333,7 @@ func printLineModeHeader(out io.Writer) {
 	if branch := currentGitBranch(); branch != "" {
 		fmt.Fprintf(out, "%s %s\n", styleGray.Render("git:"), styleGreen.Render(branch))
 	}
-	fmt.Fprintf(out, "%s\n\n", styleGray.Render("commands: /help, /quit, /exit, /clear"))
+	fmt.Fprintf(out, "%s\n\n", styleGray.Render("commands: /help, /resume, /quit, /exit, /clear"))
 }
 
 func printLineModeHelp(out io.Writer) {
@@ -284,5 +386,31 @@ func buildLinePrompter(in io.Reader, out io.Writer, errOut io.Writer, prompt str
 }
 
 func lineModeCommands() []string {
-	return []string{"/help", "/quit", "/exit", "/clear"}
+	return []string{"/help", "/quit", "/exit", "/clear", "/title [text]", "/marks [n]", "/resume [filter]", "/export [path] [--format json]"}
+}
+
+// sessionTitleFunc binds /title to the current session. It returns nil when
+// titling is not configured.
+func sessionTitleFunc(ctx context.Context, titler *sessiontitle.Service, sessionID string) func(string) (string, error) {
+	if titler == nil {
+		return nil
+	}
+	return func(text string) (string, error) {
+		if text != "" {
+			if _, err := titler.SetTitle(ctx, sessionID, text, nil); err != nil {
+				return "", err
+			}
+		}
+		title, tags, err := titler.Current(ctx, sessionID)
+		if err != nil {
+			return "", err
+		}
+		if title == "" {
+			return "Session has no title yet.", nil
+		}
+		if len(tags) > 0 {
+			return fmt.Sprintf("Title: %s [%s]", title, strings.Join(tags, ", ")), nil
+		}
+		return "Title: " + title, nil
+	}
 }
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"alex/internal/app/annotations"
+	"alex/internal/domain/agent/ports"
+	storage "alex/internal/domain/agent/ports/storage"
+)
+
+const (
+	marksGutterBookmark = "★"
+	marksGutterNote     = "✎"
+)
+
+// sessionMarksFunc binds /marks to the current session. It returns nil when
+// annotations are not configured.
+func sessionMarksFunc(ctx context.Context, sessions storage.SessionStore, store *annotations.Store, sessionID string) func(string) (string, error) {
+	if sessions == nil || store == nil {
+		return nil
+	}
+	return func(arg string) (string, error) {
+		var messages []ports.Message
+		session, err := sessions.Get(ctx, sessionID)
+		switch {
+		case err == nil:
+			messages = session.Messages
+		case !errors.Is(err, storage.ErrSessionNotFound):
+			return "", err
+		}
+		list, err := store.List(ctx, sessionID, messages)
+		if err != nil {
+			return "", err
+		}
+		if arg == "" {
+			return renderMarksList(list), nil
+		}
+		n, err := strconv.Atoi(arg)
+		if err != nil || n < 1 || n > len(list) {
+			return "", fmt.Errorf("no annotation %s; run /marks for the list", arg)
+		}
+		return renderMarkedMessage(list[n-1], messages), nil
+	}
+}
+
+// marksGutter is the marker drawn beside an annotated message.
+func marksGutter(a annotations.Annotation) string {
+	if a.Bookmark {
+		return marksGutterBookmark
+	}
+	return marksGutterNote
+}
+
+// renderMarksList renders the jump list: one numbered line per annotation
+// with its gutter marker, message position, and note.
+func renderMarksList(list []annotations.Annotation) string {
+	if len(list) == 0 {
+		return "No annotations in this session."
+	}
+	var b strings.Builder
+	b.WriteString("Annotations:\n")
+	for i, a := range list {
+		fmt.Fprintf(&b, "  [%d] %s #%d %s", i+1, marksGutter(a), a.MessageIndex, a.Role)
+		if a.Compacted {
+			b.WriteString(" (summarized)")
+		}
+		if a.Text != "" {
+			fmt.Fprintf(&b, "  %s", a.Text)
+		}
+		fmt.Fprintf(&b, "\n      %s\n", ports.TruncateRuneSnippet(a.Snippet, 80))
+	}
+	b.WriteString("Use /marks <n> to jump to an annotated message.")
+	return b.String()
+}
+
+// renderMarkedMessage shows the annotated message with the gutter marker on
+// every line. Compacted messages show the preserved snippet, since the
+// original content now only exists in the summary block.
+func renderMarkedMessage(a annotations.Annotation, messages []ports.Message) string {
+	gutter := marksGutter(a)
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s #%d %s · %s\n", gutter, a.MessageIndex, a.Role, a.Author)
+	content := a.Snippet
+	switch {
+	case a.Compacted:
+		b.WriteString(gutter + " (summarized; original snippet)\n")
+	case a.MessageIndex >= 0 && a.MessageIndex < len(messages):
+		content = messages[a.MessageIndex].Content
+	}
+	for _, line := range strings.Split(strings.TrimSpace(content), "\n") {
+		fmt.Fprintf(&b, "%s │ %s\n", gutter, line)
+	}
+	if a.Text != "" {
+		fmt.Fprintf(&b, "%s note: %s", gutter, a.Text)
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
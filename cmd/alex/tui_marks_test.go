@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"alex/internal/app/annotations"
+	"alex/internal/domain/agent/ports"
+	storage "alex/internal/domain/agent/ports/storage"
+)
+
+type marksSessionStore struct {
+	storage.SessionStore
+	session *storage.Session
+}
+
+func (s *marksSessionStore) Get(_ context.Context, id string) (*storage.Session, error) {
+	if s.session == nil || s.session.ID != id {
+		return nil, storage.ErrSessionNotFound
+	}
+	return s.session, nil
+}
+
+func TestSessionMarksFuncListsAndJumps(t *testing.T) {
+	ctx := context.Background()
+	session := &storage.Session{ID: "s1", Messages: []ports.Message{
+		{Role: "user", Content: "design the schema"},
+		{Role: "assistant", Content: "Using a flat table.\nNo foreign keys."},
+		{Role: "user", Content: "looks good"},
+	}}
+	store, err := annotations.NewStore("")
+	if err != nil {
+		t.Fatalf("new store: %v", err)
+	}
+	if _, err := store.Add(ctx, "s1", session.Messages, 2, annotations.Input{Text: "approved"}); err != nil {
+		t.Fatalf("add note: %v", err)
+	}
+	if _, err := store.Add(ctx, "s1", session.Messages, 1, annotations.Input{Text: "chose the wrong schema", Bookmark: true}); err != nil {
+		t.Fatalf("add bookmark: %v", err)
+	}
+
+	if sessionMarksFunc(ctx, &marksSessionStore{}, nil, "s1") != nil {
+		t.Fatal("expected nil marks func without an annotation store")
+	}
+	marks := sessionMarksFunc(ctx, &marksSessionStore{session: session}, store, "s1")
+
+	list, err := marks("")
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if !strings.Contains(list, "[1] ★ #1 assistant  chose the wrong schema") || !strings.Contains(list, "[2] ✎ #2 user  approved") {
+		t.Fatalf("unexpected jump list:\n%s", list)
+	}
+
+	shown, err := marks("1")
+	if err != nil {
+		t.Fatalf("jump: %v", err)
+	}
+	for _, want := range []string{"★ │ Using a flat table.", "★ │ No foreign keys.", "★ note: chose the wrong schema"} {
+		if !strings.Contains(shown, want) {
+			t.Fatalf("expected %q in jumped message:\n%s", want, shown)
+		}
+	}
+
+	if _, err := marks("3"); err == nil {
+		t.Fatal("expected error for out-of-range mark")
+	}
+
+	empty := sessionMarksFunc(ctx, &marksSessionStore{}, store, "missing")
+	if text, err := empty(""); err != nil || text != "No annotations in this session." {
+		t.Fatalf("expected empty list for unsaved session, got %q err=%v", text, err)
+	}
+}
+
+func TestRenderMarkedMessageUsesSnippetWhenCompacted(t *testing.T) {
+	a := annotations.Annotation{MessageIndex: 0, Role: "assistant", Author: "local", Snippet: "original answer", Compacted: true, Text: "keep"}
+	messages := []ports.Message{{Role: "assistant", Content: ports.CompressionSummaryPrefix + " earlier turns."}}
+	got := renderMarkedMessage(a, messages)
+	if !strings.Contains(got, "✎ │ original answer") || strings.Contains(got, "earlier turns") {
+		t.Fatalf("expected preserved snippet for compacted message, got:\n%s", got)
+	}
+}
@@ -0,0 +1,228 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"alex/internal/domain/agent/ports"
+	storage "alex/internal/domain/agent/ports/storage"
+	"alex/internal/shared/utils"
+)
+
+const (
+	sessionPickerPageSize    = 200
+	sessionPickerVisibleRows = 20
+	sessionTranscriptTail    = 20
+)
+
+// sessionPickerSource is the slice of the coordinator the /resume picker
+// reads from.
+type sessionPickerSource interface {
+	ListSessions(ctx context.Context, limit int, offset int) ([]string, error)
+	GetSession(ctx context.Context, id string) (*storage.Session, error)
+}
+
+// sessionPickerEntry summarizes one session in the /resume picker.
+type sessionPickerEntry struct {
+	ID        string
+	Title     string
+	FirstUser string
+	UpdatedAt time.Time
+}
+
+// loadSessionPickerEntries lists every session except skipID, newest first.
+// Sessions that fail to load are skipped rather than failing the picker.
+func loadSessionPickerEntries(ctx context.Context, source sessionPickerSource, skipID string) ([]sessionPickerEntry, error) {
+	var entries []sessionPickerEntry
+	for offset := 0; ; {
+		ids, err := source.ListSessions(ctx, sessionPickerPageSize, offset)
+		if err != nil {
+			return nil, fmt.Errorf("list sessions: %w", err)
+		}
+		for _, sid := range ids {
+			// GetSession creates a session for an empty ID.
+			if sid == "" || sid == skipID {
+				continue
+			}
+			session, err := source.GetSession(ctx, sid)
+			if err != nil || session == nil {
+				continue
+			}
+			entries = append(entries, sessionPickerEntry{
+				ID:        sid,
+				Title:     strings.TrimSpace(session.Metadata["title"]),
+				FirstUser: firstUserMessage(session.Messages),
+				UpdatedAt: session.UpdatedAt,
+			})
+		}
+		if len(ids) < sessionPickerPageSize {
+			break
+		}
+		offset += len(ids)
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].UpdatedAt.After(entries[j].UpdatedAt)
+	})
+	return entries, nil
+}
+
+func firstUserMessage(messages []ports.Message) string {
+	for _, msg := range messages {
+		if isTranscriptUserMessage(msg) {
+			return strings.Join(strings.Fields(msg.Content), " ")
+		}
+	}
+	return ""
+}
+
+// filterSessionPickerEntries keeps entries whose ID, title or first message
+// contain every whitespace-separated term of query, ignoring case.
+func filterSessionPickerEntries(entries []sessionPickerEntry, query string) []sessionPickerEntry {
+	terms := strings.Fields(strings.ToLower(query))
+	if len(terms) == 0 {
+		return entries
+	}
+	out := make([]sessionPickerEntry, 0, len(entries))
+	for _, entry := range entries {
+		haystack := strings.ToLower(entry.ID + " " + entry.Title + " " + entry.FirstUser)
+		matched := true
+		for _, term := range terms {
+			if !strings.Contains(haystack, term) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			out = append(out, entry)
+		}
+	}
+	return out
+}
+
+func renderSessionPicker(entries []sessionPickerEntry, query string, now time.Time) string {
+	var b strings.Builder
+	if query != "" {
+		fmt.Fprintf(&b, "Sessions matching %q: %d\n", query, len(entries))
+	} else {
+		fmt.Fprintf(&b, "Recent sessions: %d\n", len(entries))
+	}
+	for i, entry := range entries {
+		if i == sessionPickerVisibleRows {
+			fmt.Fprintf(&b, "  … %d more; type to narrow the list\n", len(entries)-i)
+			break
+		}
+		label := entry.FirstUser
+		if label == "" {
+			label = "(no messages)"
+		}
+		if entry.Title != "" {
+			label = entry.Title + " — " + label
+		}
+		fmt.Fprintf(&b, "%3d. %s  %s  %s\n", i+1,
+			entry.UpdatedAt.Format("2006-01-02 15:04"),
+			formatAge(now.Sub(entry.UpdatedAt)),
+			utils.TruncateWithEllipsis(label, 72))
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// renderSessionTranscript prints the user/assistant turns of a resumed
+// session, keeping only the most recent ones.
+func renderSessionTranscript(session *storage.Session) string {
+	if session == nil {
+		return ""
+	}
+	var turns []string
+	for _, msg := range session.Messages {
+		switch {
+		case isTranscriptUserMessage(msg):
+			turns = append(turns, styleBoldGreen.Render("❯ ")+strings.TrimSpace(msg.Content))
+		case isTranscriptAssistantMessage(msg):
+			turns = append(turns, strings.TrimSpace(msg.Content))
+		}
+	}
+	if len(turns) == 0 {
+		return styleGray.Render("(session has no messages yet)")
+	}
+
+	var b strings.Builder
+	if hidden := len(turns) - sessionTranscriptTail; hidden > 0 {
+		fmt.Fprintln(&b, styleGray.Render(fmt.Sprintf("… %d earlier messages", hidden)))
+		turns = turns[hidden:]
+	}
+	b.WriteString(strings.Join(turns, "\n\n"))
+	return b.String()
+}
+
+func isTranscriptUserMessage(msg ports.Message) bool {
+	if msg.Role != "user" || strings.TrimSpace(msg.Content) == "" {
+		return false
+	}
+	return msg.Source == ports.MessageSourceUserInput || msg.Source == ports.MessageSourceUnknown
+}
+
+func isTranscriptAssistantMessage(msg ports.Message) bool {
+	if msg.Role != "assistant" || strings.TrimSpace(msg.Content) == "" {
+		return false
+	}
+	return msg.Source == ports.MessageSourceAssistantReply || msg.Source == ports.MessageSourceUnknown
+}
+
+// handleResume runs the session picker. Each non-numeric line replaces the
+// filter, a number picks the listed session, and an empty line cancels.
+func (l *lineChatLoop) handleResume(query string) error {
+	if l.out == nil {
+		return nil
+	}
+	if l.listSessions == nil || l.switchSession == nil {
+		fmt.Fprintln(l.out, styleGray.Render("Session resume is not available."))
+		return nil
+	}
+	entries, err := l.listSessions()
+	if err != nil {
+		fmt.Fprintln(l.out, styleGray.Render("Failed to list sessions: "+err.Error()))
+		return nil
+	}
+	if len(entries) == 0 {
+		fmt.Fprintln(l.out, styleGray.Render("No other sessions to resume."))
+		return nil
+	}
+
+	for {
+		matches := filterSessionPickerEntries(entries, query)
+		fmt.Fprintln(l.out, renderSessionPicker(matches, query, time.Now()))
+		fmt.Fprintln(l.out, styleGray.Render("Enter a number to resume, text to filter, or an empty line to cancel."))
+
+		line, ok, err := l.readPrompt()
+		if err != nil {
+			return err
+		}
+		line = strings.TrimSpace(line)
+		if !ok || line == "" {
+			fmt.Fprintln(l.out, styleGray.Render("Resume cancelled."))
+			return nil
+		}
+		n, convErr := strconv.Atoi(line)
+		if convErr != nil {
+			query = line
+			continue
+		}
+		if n < 1 || n > len(matches) || n > sessionPickerVisibleRows {
+			fmt.Fprintln(l.out, styleGray.Render(fmt.Sprintf("No session %d in the list.", n)))
+			continue
+		}
+
+		transcript, err := l.switchSession(matches[n-1].ID)
+		if err != nil {
+			fmt.Fprintln(l.out, styleGray.Render("Resume failed: "+err.Error()))
+			return nil
+		}
+		fmt.Fprintln(l.out, transcript)
+		fmt.Fprintln(l.out, styleGray.Render("Resumed session "+matches[n-1].ID))
+		return nil
+	}
+}
@@ -0,0 +1,193 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"alex/internal/domain/agent/ports"
+	agentports "alex/internal/domain/agent/ports/agent"
+	storage "alex/internal/domain/agent/ports/storage"
+)
+
+type fakePickerSource struct {
+	sessions map[string]*storage.Session
+	order    []string
+}
+
+func (s *fakePickerSource) ListSessions(_ context.Context, limit int, offset int) ([]string, error) {
+	if offset >= len(s.order) {
+		return nil, nil
+	}
+	end := offset + limit
+	if end > len(s.order) {
+		end = len(s.order)
+	}
+	return s.order[offset:end], nil
+}
+
+func (s *fakePickerSource) GetSession(_ context.Context, id string) (*storage.Session, error) {
+	if id == "" {
+		panic("GetSession called with empty ID")
+	}
+	session, ok := s.sessions[id]
+	if !ok {
+		return nil, storage.ErrSessionNotFound
+	}
+	return session, nil
+}
+
+func TestLoadSessionPickerEntriesPagesAndSorts(t *testing.T) {
+	base := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	source := &fakePickerSource{sessions: map[string]*storage.Session{}}
+	for i := 0; i < sessionPickerPageSize+50; i++ {
+		sid := fmt.Sprintf("s%03d", i)
+		source.order = append(source.order, sid)
+		source.sessions[sid] = &storage.Session{
+			ID:        sid,
+			UpdatedAt: base.Add(time.Duration(i) * time.Minute),
+			Messages: []ports.Message{
+				{Role: "system", Content: "prompt", Source: ports.MessageSourceSystemPrompt},
+				{Role: "user", Content: fmt.Sprintf("task   number\n%d", i), Source: ports.MessageSourceUserInput},
+			},
+		}
+	}
+	source.order = append(source.order, "missing")
+
+	entries, err := loadSessionPickerEntries(context.Background(), source, "s005")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(entries) != sessionPickerPageSize+49 {
+		t.Fatalf("expected %d entries, got %d", sessionPickerPageSize+49, len(entries))
+	}
+	if entries[0].ID != "s249" || entries[0].FirstUser != "task number 249" {
+		t.Fatalf("expected newest session first, got %+v", entries[0])
+	}
+	for _, entry := range entries {
+		if entry.ID == "s005" {
+			t.Fatal("current session should be excluded")
+		}
+	}
+}
+
+func TestFilterSessionPickerEntriesMatchesAllTerms(t *testing.T) {
+	entries := []sessionPickerEntry{
+		{ID: "a", FirstUser: "Deploy the API gateway"},
+		{ID: "b", Title: "Gateway", FirstUser: "fix lark webhook"},
+		{ID: "c", FirstUser: "deploy docs"},
+	}
+	if got := filterSessionPickerEntries(entries, ""); len(got) != 3 {
+		t.Fatalf("empty query should keep everything, got %d", len(got))
+	}
+	got := filterSessionPickerEntries(entries, "gateway DEPLOY")
+	if len(got) != 1 || got[0].ID != "a" {
+		t.Fatalf("expected only a, got %+v", got)
+	}
+	got = filterSessionPickerEntries(entries, "gateway")
+	if len(got) != 2 {
+		t.Fatalf("expected title and message matches, got %+v", got)
+	}
+}
+
+func TestRenderSessionPickerCapsVisibleRows(t *testing.T) {
+	now := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+	var entries []sessionPickerEntry
+	for i := 0; i < sessionPickerVisibleRows+5; i++ {
+		entries = append(entries, sessionPickerEntry{ID: fmt.Sprintf("s%d", i), FirstUser: "hello", UpdatedAt: now.Add(-time.Hour)})
+	}
+	text := renderSessionPicker(entries, "", now)
+	if !strings.Contains(text, " 20. ") || strings.Contains(text, " 21. ") {
+		t.Fatalf("expected exactly %d rows:\n%s", sessionPickerVisibleRows, text)
+	}
+	if !strings.Contains(text, "5 more") {
+		t.Fatalf("expected overflow hint:\n%s", text)
+	}
+}
+
+func TestRenderSessionTranscriptKeepsConversationTurns(t *testing.T) {
+	session := &storage.Session{Messages: []ports.Message{
+		{Role: "system", Content: "you are alex", Source: ports.MessageSourceSystemPrompt},
+		{Role: "user", Content: "earlier context", Source: ports.MessageSourceUserHistory},
+		{Role: "user", Content: "list files", Source: ports.MessageSourceUserInput},
+		{Role: "tool", Content: "a.go b.go", Source: ports.MessageSourceToolResult},
+		{Role: "assistant", Content: "Found a.go and b.go.", Source: ports.MessageSourceAssistantReply},
+	}}
+	text := renderSessionTranscript(session)
+	if !strings.Contains(text, "list files") || !strings.Contains(text, "Found a.go and b.go.") {
+		t.Fatalf("missing conversation turns:\n%s", text)
+	}
+	for _, hidden := range []string{"you are alex", "earlier context", "a.go b.go"} {
+		if strings.Contains(text, hidden) {
+			t.Fatalf("transcript should not include %q:\n%s", hidden, text)
+		}
+	}
+}
+
+func TestLineChatLoopResumeFiltersAndSwitches(t *testing.T) {
+	entries := []sessionPickerEntry{
+		{ID: "s-old", FirstUser: "deploy gateway"},
+		{ID: "s-new", FirstUser: "write release notes"},
+	}
+	var switched []string
+	var tasks []string
+	var out bytes.Buffer
+
+	prompter := &fakePrompter{lines: []string{"release", "1", "continue", "/exit"}}
+	loop := &lineChatLoop{
+		prompter: prompter,
+		out:      &out,
+		errOut:   &out,
+		runTask: func(task string) (*agentports.TaskResult, error) {
+			tasks = append(tasks, task)
+			return &agentports.TaskResult{}, nil
+		},
+		listSessions: func() ([]sessionPickerEntry, error) { return entries, nil },
+		switchSession: func(id string) (string, error) {
+			switched = append(switched, id)
+			return "transcript of " + id, nil
+		},
+		resumeOnStart: true,
+	}
+
+	if err := loop.run(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(switched) != 1 || switched[0] != "s-new" {
+		t.Fatalf("expected switch to s-new, got %#v", switched)
+	}
+	if !strings.Contains(out.String(), "transcript of s-new") {
+		t.Fatalf("expected transcript in output:\n%s", out.String())
+	}
+	if len(tasks) != 1 || tasks[0] != "continue" {
+		t.Fatalf("expected picker input to stay out of tasks, got %#v", tasks)
+	}
+	if len(prompter.history) != 1 {
+		t.Fatalf("picker input should not enter history, got %#v", prompter.history)
+	}
+}
+
+func TestLineChatLoopResumeCancel(t *testing.T) {
+	var out bytes.Buffer
+	loop := &lineChatLoop{
+		prompter: &fakePrompter{lines: []string{"/resume", "9", "", "/exit"}},
+		out:      &out,
+		errOut:   &out,
+		listSessions: func() ([]sessionPickerEntry, error) {
+			return []sessionPickerEntry{{ID: "s1", FirstUser: "hi"}}, nil
+		},
+		switchSession: func(id string) (string, error) {
+			t.Fatalf("unexpected switch to %s", id)
+			return "", nil
+		},
+	}
+	if err := loop.run(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(out.String(), "No session 9") || !strings.Contains(out.String(), "Resume cancelled.") {
+		t.Fatalf("unexpected output:\n%s", out.String())
+	}
+}
@@ -163,6 +163,8 @@ func (cm *CLIManager) waitForCompletion(ctx context.Context, job *EvaluationJob)
 
 			case JobStatusFailed:
 				return job, fmt.Errorf("job %s failed", job.ID)
+			case JobStatusCancelled:
+				return job, fmt.Errorf("job %s cancelled", job.ID)
 			}
 		}
 	}
@@ -3,7 +3,6 @@ package agent_eval
 import (
 	"context"
 	"fmt"
-	"log"
 	"os"
 	"path/filepath"
 	"strings"
@@ -31,6 +30,7 @@ type EvaluationManager struct {
 	mu         sync.RWMutex
 	activeJobs map[string]*EvaluationJob
 	config     *EvaluationConfig
+	onFinished func(*EvaluationJob)
 }
 
 // fallbackAnalysisResult provides a minimal summary when full metric analysis
@@ -60,6 +60,7 @@ const (
 	JobStatusRunning   JobStatus = "running"
 	JobStatusCompleted JobStatus = "completed"
 	JobStatusFailed    JobStatus = "failed"
+	JobStatusCancelled JobStatus = "cancelled"
 )
 
 // EvaluationConfig 评估配置
@@ -101,30 +102,54 @@ func NewEvaluationManager(config *EvaluationConfig) *EvaluationManager {
 func (em *EvaluationManager) ScheduleEvaluation(ctx context.Context, config *EvaluationConfig) (*EvaluationJob, error) {
 	em.ensureHydrated()
 
+	job := em.registerJob("", config)
+	snapshot := em.cloneJob(job)
+
+	// 异步执行评估
+	async.Go(panicLogger{}, "agent-eval.execute", func() {
+		em.executeEvaluation(ctx, job)
+	})
+
+	return snapshot, nil
+}
+
+// RunEvaluation executes an evaluation inline and returns the final job
+// snapshot. An empty jobID is generated. Cancelling ctx stops the run and
+// marks the job cancelled; progress is written to the run log attached with
+// WithRunLog.
+func (em *EvaluationManager) RunEvaluation(ctx context.Context, jobID string, config *EvaluationConfig) *EvaluationJob {
+	em.ensureHydrated()
+
+	job := em.registerJob(jobID, config)
+	em.executeEvaluation(ctx, job)
+
+	em.mu.RLock()
+	defer em.mu.RUnlock()
+	return em.cloneJob(job)
+}
+
+func (em *EvaluationManager) registerJob(jobID string, config *EvaluationConfig) *EvaluationJob {
 	em.mu.Lock()
 	defer em.mu.Unlock()
 
-	jobID := em.nextJobID()
+	if jobID == "" {
+		jobID = em.nextJobID()
+	}
 	job := &EvaluationJob{
 		ID:        jobID,
 		Status:    JobStatusPending,
 		Config:    em.cloneConfig(config),
 		StartTime: time.Now(),
 	}
-
 	em.activeJobs[jobID] = job
-
-	// 异步执行评估
-	async.Go(panicLogger{}, "agent-eval.execute", func() {
-		em.executeEvaluation(ctx, job)
-	})
-
-	return job, nil
+	return job
 }
 
 // executeEvaluation 执行评估任务
 func (em *EvaluationManager) executeEvaluation(ctx context.Context, job *EvaluationJob) {
 	em.updateJobStatus(job.ID, JobStatusRunning)
+	runLogf(ctx, "Evaluation job %s started (dataset=%s type=%s limit=%d workers=%d)",
+		job.ID, job.Config.DatasetPath, job.Config.DatasetType, job.Config.InstanceLimit, job.Config.MaxWorkers)
 
 	defer func() {
 		em.mu.Lock()
@@ -132,13 +157,18 @@ func (em *EvaluationManager) executeEvaluation(ctx context.Context, job *Evaluat
 		hasError := job.Error != nil
 		em.mu.Unlock()
 
-		if hasError {
+		switch {
+		case hasError && ctx.Err() != nil:
+			em.updateJobStatus(job.ID, JobStatusCancelled)
+			runLogf(ctx, "Evaluation job %s cancelled: %v", job.ID, job.Error)
+		case hasError:
 			em.updateJobStatus(job.ID, JobStatusFailed)
-			log.Printf("Evaluation job %s failed: %v", job.ID, job.Error)
-		} else {
+			runLogf(ctx, "Evaluation job %s failed: %v", job.ID, job.Error)
+		default:
 			em.updateJobStatus(job.ID, JobStatusCompleted)
-			log.Printf("Evaluation job %s completed successfully", job.ID)
+			runLogf(ctx, "Evaluation job %s completed successfully", job.ID)
 		}
+		em.notifyFinished(job)
 	}()
 
 	// 1. 加载数据集
@@ -147,6 +177,7 @@ func (em *EvaluationManager) executeEvaluation(ctx context.Context, job *Evaluat
 		em.setJobError(job.ID, fmt.Errorf("failed to load dataset: %w", err))
 		return
 	}
+	runLogf(ctx, "Loaded %d instances", len(bundle.instances))
 
 	// 2. 执行评估（基于现有SWE-Bench处理器）
 	results, err := em.runEvaluation(ctx, bundle.instances, job.Config)
@@ -154,6 +185,11 @@ func (em *EvaluationManager) executeEvaluation(ctx context.Context, job *Evaluat
 		em.setJobError(job.ID, fmt.Errorf("failed to run evaluation: %w", err))
 		return
 	}
+	if err := ctx.Err(); err != nil {
+		em.setJobError(job.ID, err)
+		return
+	}
+	logWorkerResults(ctx, results)
 
 	autoScores := em.scoreResults(results)
 	evaluation := &EvaluationResults{
@@ -168,7 +204,7 @@ func (em *EvaluationManager) executeEvaluation(ctx context.Context, job *Evaluat
 	if bundle.evalSet != nil && bundle.rubric != nil {
 		summary, judgeRuns, err := RunJudgementPipeline(ctx, bundle.evalSet, results, *bundle.rubric, NoopAgentJudge{})
 		if err != nil {
-			log.Printf("Warning: Failed to run judgement pipeline: %v", err)
+			runLogf(ctx, "Warning: Failed to run judgement pipeline: %v", err)
 		} else {
 			evaluation.Judgements = summary
 			evaluation.JudgeRuns = judgeRuns
@@ -177,7 +213,7 @@ func (em *EvaluationManager) executeEvaluation(ctx context.Context, job *Evaluat
 
 	metrics, metricsErr := em.collectMetrics(ctx, results)
 	if metricsErr != nil {
-		log.Printf("Warning: Failed to collect metrics: %v", metricsErr)
+		runLogf(ctx, "Warning: Failed to collect metrics: %v", metricsErr)
 	} else {
 		metrics.EvaluationID = job.ID
 		evaluation.Metrics = metrics
@@ -187,7 +223,7 @@ func (em *EvaluationManager) executeEvaluation(ctx context.Context, job *Evaluat
 	if evaluation.Metrics != nil {
 		if job.Config.EnableMetrics {
 			if err := em.metricsStore.Store(job.ID, evaluation.Metrics); err != nil {
-				log.Printf("Warning: Failed to store metrics: %v", err)
+				runLogf(ctx, "Warning: Failed to store metrics: %v", err)
 			}
 		}
 
@@ -197,7 +233,7 @@ func (em *EvaluationManager) executeEvaluation(ctx context.Context, job *Evaluat
 		if job.Config.EnableMetrics {
 			reportPath, err := em.generateReport(ctx, evaluation, job.Config)
 			if err != nil {
-				log.Printf("Warning: Failed to generate report: %v", err)
+				runLogf(ctx, "Warning: Failed to generate report: %v", err)
 			} else {
 				format := normalizeReportFormat(job.Config.ReportFormat)
 				evaluation.ReportPath = reportPath
@@ -223,12 +259,12 @@ func (em *EvaluationManager) executeEvaluation(ctx context.Context, job *Evaluat
 
 	if evaluation.Agent != nil && em.agentStore != nil {
 		if profile, err := em.agentStore.UpsertProfile(evaluation.Agent); err != nil {
-			log.Printf("Warning: Failed to store agent profile: %v", err)
+			runLogf(ctx, "Warning: Failed to store agent profile: %v", err)
 		} else {
 			evaluation.Agent = profile
 		}
 		if err := em.agentStore.StoreEvaluation(job.Config.AgentID, evaluation); err != nil {
-			log.Printf("Warning: Failed to store agent evaluation: %v", err)
+			runLogf(ctx, "Warning: Failed to store agent evaluation: %v", err)
 		}
 	}
 
@@ -589,6 +625,24 @@ func (em *EvaluationManager) buildAgentProfile(agentID string, metrics *Evaluati
 	return profile
 }
 
+// SetJobFinishedHook registers fn to receive a snapshot of every job that
+// reaches completed or failed. fn runs on the job goroutine and must not block.
+func (em *EvaluationManager) SetJobFinishedHook(fn func(*EvaluationJob)) {
+	em.mu.Lock()
+	defer em.mu.Unlock()
+	em.onFinished = fn
+}
+
+func (em *EvaluationManager) notifyFinished(job *EvaluationJob) {
+	em.mu.RLock()
+	fn := em.onFinished
+	snapshot := em.cloneJob(job)
+	em.mu.RUnlock()
+	if fn != nil {
+		fn(snapshot)
+	}
+}
+
 // updateJobStatus 更新任务状态
 func (em *EvaluationManager) updateJobStatus(jobID string, status JobStatus) {
 	em.mu.Lock()
@@ -4,6 +4,7 @@ import (
 	"context"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -360,3 +361,49 @@ func TestGenerateReportReturnsNormalizedArtifactPath(t *testing.T) {
 		t.Fatalf("expected report artifact to exist: %v", err)
 	}
 }
+
+func TestJobFinishedHookReceivesFailedJob(t *testing.T) {
+	dir := t.TempDir()
+	em := NewEvaluationManager(&EvaluationConfig{OutputDir: dir})
+	finished := make(chan *EvaluationJob, 1)
+	em.SetJobFinishedHook(func(job *EvaluationJob) { finished <- job })
+
+	job, err := em.ScheduleEvaluation(context.Background(), &EvaluationConfig{
+		DatasetType: "swe_bench",
+		DatasetPath: filepath.Join(dir, "missing.json"),
+		OutputDir:   dir,
+	})
+	if err != nil {
+		t.Fatalf("ScheduleEvaluation() error = %v", err)
+	}
+
+	select {
+	case got := <-finished:
+		if got.ID != job.ID || got.Status != JobStatusFailed || got.EndTime.IsZero() || got.Error == nil {
+			t.Fatalf("unexpected finished job %+v", got)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("job finished hook was not called")
+	}
+}
+
+func TestRunEvaluationWritesRunLog(t *testing.T) {
+	dir := t.TempDir()
+	em := NewEvaluationManager(&EvaluationConfig{OutputDir: dir})
+	var runLog strings.Builder
+
+	job := em.RunEvaluation(WithRunLog(context.Background(), &runLog), "eval_inline", &EvaluationConfig{
+		DatasetType: "general_agent",
+		DatasetPath: filepath.Join(dir, "missing.json"),
+		OutputDir:   dir,
+	})
+
+	if job.ID != "eval_inline" || job.Status != JobStatusFailed || job.EndTime.IsZero() {
+		t.Fatalf("unexpected job %+v", job)
+	}
+	for _, want := range []string{"Evaluation job eval_inline started", "failed to load dataset"} {
+		if !strings.Contains(runLog.String(), want) {
+			t.Fatalf("run log missing %q: %q", want, runLog.String())
+		}
+	}
+}
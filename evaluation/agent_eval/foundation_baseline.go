@@ -0,0 +1,119 @@
+package agent_eval
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// defaultMaxCategoryPassAt5Drop is the pass@5 drop (as a rate) a category
+// may show against the baseline before the run counts as a regression.
+const defaultMaxCategoryPassAt5Drop = 0.05
+
+// FoundationCategorySummary holds implicit-case scores for one scenario category.
+type FoundationCategorySummary struct {
+	Cases        int     `json:"cases"`
+	PassAt1Cases int     `json:"pass_at_1_cases"`
+	PassAt5Cases int     `json:"pass_at_5_cases"`
+	PassAt1Rate  float64 `json:"pass_at_1_rate"`
+	PassAt5Rate  float64 `json:"pass_at_5_rate"`
+	MRR          float64 `json:"mrr"`
+}
+
+// buildCategoryBreakdown scores applicable case results per category.
+func buildCategoryBreakdown(results []FoundationCaseResult) map[string]FoundationCategorySummary {
+	type accumulator struct {
+		cases, passAt1, passAt5 int
+		reciprocalRank          float64
+	}
+	acc := make(map[string]*accumulator)
+	for _, c := range results {
+		if c.NotApplicable {
+			continue
+		}
+		category := strings.TrimSpace(c.Category)
+		if category == "" {
+			category = "uncategorized"
+		}
+		a := acc[category]
+		if a == nil {
+			a = &accumulator{}
+			acc[category] = a
+		}
+		a.cases++
+		if c.HitRank == 1 {
+			a.passAt1++
+		}
+		if c.HitRank > 0 && c.HitRank <= 5 {
+			a.passAt5++
+		}
+		if c.HitRank > 0 {
+			a.reciprocalRank += 1.0 / float64(c.HitRank)
+		}
+	}
+	if len(acc) == 0 {
+		return nil
+	}
+
+	breakdown := make(map[string]FoundationCategorySummary, len(acc))
+	for category, a := range acc {
+		denominator := float64(a.cases)
+		breakdown[category] = FoundationCategorySummary{
+			Cases:        a.cases,
+			PassAt1Cases: a.passAt1,
+			PassAt5Cases: a.passAt5,
+			PassAt1Rate:  round3(float64(a.passAt1) / denominator),
+			PassAt5Rate:  round3(float64(a.passAt5) / denominator),
+			MRR:          round3(a.reciprocalRank / denominator),
+		}
+	}
+	return breakdown
+}
+
+// loadFoundationBaseline reads a previous foundation_result JSON. Results
+// written before categories were tracked get their breakdown rebuilt from
+// the stored case results.
+func loadFoundationBaseline(path string) (*FoundationEvaluationResult, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read foundation baseline: %w", err)
+	}
+	var baseline FoundationEvaluationResult
+	if err := json.Unmarshal(data, &baseline); err != nil {
+		return nil, fmt.Errorf("decode foundation baseline: %w", err)
+	}
+	if len(baseline.Implicit.CategoryBreakdown) == 0 {
+		baseline.Implicit.CategoryBreakdown = buildCategoryBreakdown(baseline.Implicit.CaseResults)
+	}
+	return &baseline, nil
+}
+
+// detectCategoryRegressions lists categories whose pass@5 fell by more than
+// maxDrop against the baseline. Categories missing from either run are not
+// compared, since the scenario set itself changed.
+func detectCategoryRegressions(baseline, current map[string]FoundationCategorySummary, maxDrop float64) []string {
+	categories := make([]string, 0, len(current))
+	for category := range current {
+		categories = append(categories, category)
+	}
+	sort.Strings(categories)
+
+	var regressions []string
+	for _, category := range categories {
+		before, ok := baseline[category]
+		if !ok {
+			continue
+		}
+		after := current[category]
+		drop := before.PassAt5Rate - after.PassAt5Rate
+		if drop > maxDrop+1e-9 {
+			regressions = append(regressions, fmt.Sprintf(
+				"category %s pass@5 dropped %.1f%% -> %.1f%% (-%.1fpp, allowed %.1fpp)",
+				category, before.PassAt5Rate*100, after.PassAt5Rate*100, drop*100, maxDrop*100,
+			))
+		}
+	}
+	return regressions
+}
@@ -0,0 +1,112 @@
+package agent_eval
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestBuildCategoryBreakdown(t *testing.T) {
+	t.Parallel()
+
+	breakdown := buildCategoryBreakdown([]FoundationCaseResult{
+		{ID: "a", Category: "files", HitRank: 1},
+		{ID: "b", Category: "files", HitRank: 4},
+		{ID: "c", Category: "files", HitRank: 0},
+		{ID: "d", Category: "web", HitRank: 2},
+		{ID: "e", Category: "web", NotApplicable: true},
+	})
+
+	files := breakdown["files"]
+	if files.Cases != 3 || files.PassAt1Cases != 1 || files.PassAt5Cases != 2 {
+		t.Fatalf("unexpected files summary: %+v", files)
+	}
+	if files.PassAt5Rate != 0.667 || files.MRR != 0.417 {
+		t.Fatalf("unexpected files rates: %+v", files)
+	}
+	if web := breakdown["web"]; web.Cases != 1 || web.PassAt1Cases != 0 || web.MRR != 0.5 {
+		t.Fatalf("expected N/A case excluded from web summary, got %+v", web)
+	}
+}
+
+func TestDetectCategoryRegressions(t *testing.T) {
+	t.Parallel()
+
+	baseline := map[string]FoundationCategorySummary{
+		"files":   {PassAt5Rate: 1.0},
+		"web":     {PassAt5Rate: 0.8},
+		"retired": {PassAt5Rate: 1.0},
+	}
+	current := map[string]FoundationCategorySummary{
+		"files": {PassAt5Rate: 0.96},
+		"web":   {PassAt5Rate: 0.6},
+		"new":   {PassAt5Rate: 0.0},
+	}
+
+	regressions := detectCategoryRegressions(baseline, current, 0.05)
+	if len(regressions) != 1 || !strings.Contains(regressions[0], "category web") {
+		t.Fatalf("expected only web to regress, got %v", regressions)
+	}
+	if got := detectCategoryRegressions(baseline, current, 0.25); len(got) != 0 {
+		t.Fatalf("expected no regressions within tolerance, got %v", got)
+	}
+}
+
+func TestRunFoundationEvaluationFailsOnBaselineRegression(t *testing.T) {
+	t.Parallel()
+	tmp := t.TempDir()
+	casePath := filepath.Join(tmp, "cases.yaml")
+	caseYAML := `
+version: "1"
+name: "mini"
+scenarios:
+  - id: "one"
+    category: "planning"
+    intent: "Break this task into milestones and explicit checkpoints."
+    expected_tools: ["plan"]
+  - id: "two"
+    category: "impossible"
+    intent: "zzqx vvkj"
+    expected_tools: ["plan"]
+`
+	if err := os.WriteFile(casePath, []byte(caseYAML), 0644); err != nil {
+		t.Fatalf("write case yaml: %v", err)
+	}
+	baseline := FoundationEvaluationResult{Implicit: FoundationImplicitSummary{
+		CaseResults: []FoundationCaseResult{
+			{ID: "one", Category: "planning", HitRank: 1},
+			{ID: "two", Category: "impossible", HitRank: 1},
+		},
+	}}
+	data, err := json.Marshal(baseline)
+	if err != nil {
+		t.Fatalf("marshal baseline: %v", err)
+	}
+	baselinePath := filepath.Join(tmp, "baseline.json")
+	if err := os.WriteFile(baselinePath, data, 0644); err != nil {
+		t.Fatalf("write baseline: %v", err)
+	}
+
+	result, err := RunFoundationEvaluation(context.Background(), &FoundationEvaluationOptions{
+		OutputDir:              filepath.Join(tmp, "out"),
+		CasesPath:              casePath,
+		ReportFormat:           "json",
+		BaselinePath:           baselinePath,
+		MaxCategoryPassAt5Drop: 0.05,
+	})
+	if err == nil {
+		t.Fatal("expected regression error")
+	}
+	if result == nil || len(result.Regressions) != 1 || !strings.Contains(result.Regressions[0], "category impossible") {
+		t.Fatalf("expected impossible category regression, got %+v", result)
+	}
+	if _, ok := result.Implicit.CategoryBreakdown["planning"]; !ok {
+		t.Fatalf("expected planning in category breakdown, got %+v", result.Implicit.CategoryBreakdown)
+	}
+	if len(result.ReportArtifacts) == 0 {
+		t.Fatal("expected artifacts written despite regression")
+	}
+}
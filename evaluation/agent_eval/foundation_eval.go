@@ -7,6 +7,7 @@ import (
 	"math"
 	"os"
 	"path/filepath"
+	"runtime"
 	"sort"
 	"strings"
 	"time"
@@ -34,36 +35,62 @@ type FoundationEvaluationOptions struct {
 	CasesPath    string
 	TopK         int
 	ReportFormat string
+	// RankerStrategy selects the implicit-case tool ranker: lexical or bm25.
+	RankerStrategy string
+	// CompareRankerStrategy, when set, also scores the implicit cases with
+	// this ranker and records a side-by-side comparison in the result.
+	CompareRankerStrategy string
+	// BaselinePath points to a previous foundation_result JSON. When set,
+	// the run fails if any category's pass@5 drops by more than
+	// MaxCategoryPassAt5Drop.
+	BaselinePath           string
+	MaxCategoryPassAt5Drop float64
+	// CaseIDs and Categories restrict the run to matching scenarios.
+	// FailedOnlyFrom points to a previous foundation_result JSON and keeps
+	// only the scenarios that failed there. Filters combine.
+	CaseIDs        []string
+	Categories     []string
+	FailedOnlyFrom string
+	// PublishEndpoint is the eval-server base URL. When set, the result is
+	// also POSTed to its foundation-runs ingestion API, authenticated with
+	// the token in ALEX_EVAL_SERVER_TOKEN.
+	PublishEndpoint string
 }
 
 // DefaultFoundationEvaluationOptions returns stable defaults for offline eval.
 func DefaultFoundationEvaluationOptions() *FoundationEvaluationOptions {
 	return &FoundationEvaluationOptions{
-		OutputDir:    "./evaluation_results/foundation",
-		Mode:         "web",
-		Preset:       string(presets.ToolPresetFull),
-		Toolset:      string(toolregistry.ToolsetDefault),
-		CasesPath:    defaultFoundationCasesPath,
-		TopK:         3,
-		ReportFormat: "markdown",
+		OutputDir:              "./evaluation_results/foundation",
+		Mode:                   "web",
+		Preset:                 string(presets.ToolPresetFull),
+		Toolset:                string(toolregistry.ToolsetDefault),
+		CasesPath:              defaultFoundationCasesPath,
+		TopK:                   3,
+		ReportFormat:           "markdown",
+		RankerStrategy:         defaultRankerStrategy,
+		MaxCategoryPassAt5Drop: defaultMaxCategoryPassAt5Drop,
 	}
 }
 
 // FoundationEvaluationResult is the full output of offline baseline scoring.
 type FoundationEvaluationResult struct {
-	RunID           string                    `json:"run_id"`
-	GeneratedAt     time.Time                 `json:"generated_at"`
-	Mode            string                    `json:"mode"`
-	Preset          string                    `json:"preset"`
-	Toolset         string                    `json:"toolset"`
-	CasesPath       string                    `json:"cases_path"`
-	TopK            int                       `json:"top_k"`
-	Prompt          FoundationPromptSummary   `json:"prompt"`
-	Tools           FoundationToolSummary     `json:"tools"`
-	Implicit        FoundationImplicitSummary `json:"implicit"`
-	OverallScore    float64                   `json:"overall_score"`
-	Recommendations []string                  `json:"recommendations"`
-	ReportArtifacts []EvaluationArtifact      `json:"report_artifacts,omitempty"`
+	RunID            string                      `json:"run_id"`
+	GeneratedAt      time.Time                   `json:"generated_at"`
+	Mode             string                      `json:"mode"`
+	Preset           string                      `json:"preset"`
+	Toolset          string                      `json:"toolset"`
+	CasesPath        string                      `json:"cases_path"`
+	TopK             int                         `json:"top_k"`
+	Ranker           string                      `json:"ranker"`
+	Prompt           FoundationPromptSummary     `json:"prompt"`
+	Tools            FoundationToolSummary       `json:"tools"`
+	Implicit         FoundationImplicitSummary   `json:"implicit"`
+	RankerComparison *FoundationRankerComparison `json:"ranker_comparison,omitempty"`
+	BaselinePath     string                      `json:"baseline_path,omitempty"`
+	Regressions      []string                    `json:"regressions,omitempty"`
+	OverallScore     float64                     `json:"overall_score"`
+	Recommendations  []string                    `json:"recommendations"`
+	ReportArtifacts  []EvaluationArtifact        `json:"report_artifacts,omitempty"`
 }
 
 // FoundationPromptSummary holds prompt-quality scoring.
@@ -107,25 +134,26 @@ type FoundationToolScore struct {
 
 // FoundationImplicitSummary contains scenario-based implicit tool readiness.
 type FoundationImplicitSummary struct {
-	TotalCases               int                    `json:"total_cases"`
-	ApplicableCases          int                    `json:"applicable_cases"`
-	NotApplicableCases       int                    `json:"not_applicable_cases"`
-	PassedCases              int                    `json:"passed_cases"`
-	FailedCases              int                    `json:"failed_cases"`
-	PassAt1Cases             int                    `json:"pass_at_1_cases"`
-	PassAt5Cases             int                    `json:"pass_at_5_cases"`
-	PassAt1Rate              float64                `json:"pass_at_1_rate"`
-	PassAt5Rate              float64                `json:"pass_at_5_rate"`
-	Top1HitRate              float64                `json:"top1_hit_rate"`
-	TopKHitRate              float64                `json:"topk_hit_rate"`
-	MRR                      float64                `json:"mrr"`
-	TotalEvaluationLatencyMs int64                  `json:"total_evaluation_latency_ms"`
-	AverageCaseLatencyMs     float64                `json:"average_case_latency_ms"`
-	CaseLatencyP50Ms         float64                `json:"case_latency_p50_ms"`
-	CaseLatencyP95Ms         float64                `json:"case_latency_p95_ms"`
-	CaseLatencyP99Ms         float64                `json:"case_latency_p99_ms"`
-	ThroughputCasesPerSec    float64                `json:"throughput_cases_per_sec"`
-	CaseResults              []FoundationCaseResult `json:"case_results"`
+	TotalCases               int                                  `json:"total_cases"`
+	ApplicableCases          int                                  `json:"applicable_cases"`
+	NotApplicableCases       int                                  `json:"not_applicable_cases"`
+	PassedCases              int                                  `json:"passed_cases"`
+	FailedCases              int                                  `json:"failed_cases"`
+	PassAt1Cases             int                                  `json:"pass_at_1_cases"`
+	PassAt5Cases             int                                  `json:"pass_at_5_cases"`
+	PassAt1Rate              float64                              `json:"pass_at_1_rate"`
+	PassAt5Rate              float64                              `json:"pass_at_5_rate"`
+	Top1HitRate              float64                              `json:"top1_hit_rate"`
+	TopKHitRate              float64                              `json:"topk_hit_rate"`
+	MRR                      float64                              `json:"mrr"`
+	TotalEvaluationLatencyMs int64                                `json:"total_evaluation_latency_ms"`
+	AverageCaseLatencyMs     float64                              `json:"average_case_latency_ms"`
+	CaseLatencyP50Ms         float64                              `json:"case_latency_p50_ms"`
+	CaseLatencyP95Ms         float64                              `json:"case_latency_p95_ms"`
+	CaseLatencyP99Ms         float64                              `json:"case_latency_p99_ms"`
+	ThroughputCasesPerSec    float64                              `json:"throughput_cases_per_sec"`
+	CategoryBreakdown        map[string]FoundationCategorySummary `json:"category_breakdown,omitempty"`
+	CaseResults              []FoundationCaseResult               `json:"case_results"`
 }
 
 // FoundationCaseResult captures one implicit-intent scenario result.
@@ -233,6 +261,16 @@ func RunFoundationEvaluation(ctx context.Context, options *FoundationEvaluationO
 	if err != nil {
 		return nil, err
 	}
+	scenarios, err := filterFoundationScenarios(caseSet.Scenarios, opts)
+	if err != nil {
+		return nil, err
+	}
+	var baseline *FoundationEvaluationResult
+	if strings.TrimSpace(opts.BaselinePath) != "" {
+		if baseline, err = loadFoundationBaseline(opts.BaselinePath); err != nil {
+			return nil, err
+		}
+	}
 
 	promptSummary := evaluatePrompts(mode)
 
@@ -242,7 +280,21 @@ func RunFoundationEvaluation(ctx context.Context, options *FoundationEvaluationO
 	}
 	toolSummary := evaluateTools(toolProfiles)
 
-	implicitSummary := evaluateImplicitCases(caseSet.Scenarios, toolProfiles, opts.TopK)
+	ranker, err := newToolRanker(opts.RankerStrategy, toolProfiles)
+	if err != nil {
+		return nil, err
+	}
+	implicitSummary := evaluateImplicitCases(scenarios, toolProfiles, opts.TopK, ranker)
+
+	var rankerComparison *FoundationRankerComparison
+	if strings.TrimSpace(opts.CompareRankerStrategy) != "" {
+		candidate, err := newToolRanker(opts.CompareRankerStrategy, toolProfiles)
+		if err != nil {
+			return nil, err
+		}
+		candidateSummary := evaluateImplicitCases(scenarios, toolProfiles, opts.TopK, candidate)
+		rankerComparison = compareRankers(ranker.Name(), implicitSummary, candidate.Name(), candidateSummary)
+	}
 
 	overall := clamp01(
 		0.25*(promptSummary.AverageScore/100.0)+
@@ -252,18 +304,24 @@ func RunFoundationEvaluation(ctx context.Context, options *FoundationEvaluationO
 	) * 100
 
 	result := &FoundationEvaluationResult{
-		RunID:           fmt.Sprintf("foundation-%s", time.Now().UTC().Format("20060102-150405")),
-		GeneratedAt:     time.Now().UTC(),
-		Mode:            string(mode),
-		Preset:          opts.Preset,
-		Toolset:         string(toolregistry.NormalizeToolset(opts.Toolset)),
-		CasesPath:       opts.CasesPath,
-		TopK:            opts.TopK,
-		Prompt:          promptSummary,
-		Tools:           toolSummary,
-		Implicit:        implicitSummary,
-		OverallScore:    round1(overall),
-		Recommendations: buildFoundationRecommendations(promptSummary, toolSummary, implicitSummary),
+		RunID:            fmt.Sprintf("foundation-%s", time.Now().UTC().Format("20060102-150405")),
+		GeneratedAt:      time.Now().UTC(),
+		Mode:             string(mode),
+		Preset:           opts.Preset,
+		Toolset:          string(toolregistry.NormalizeToolset(opts.Toolset)),
+		CasesPath:        opts.CasesPath,
+		TopK:             opts.TopK,
+		Ranker:           ranker.Name(),
+		Prompt:           promptSummary,
+		Tools:            toolSummary,
+		Implicit:         implicitSummary,
+		RankerComparison: rankerComparison,
+		OverallScore:     round1(overall),
+		Recommendations:  buildFoundationRecommendations(promptSummary, toolSummary, implicitSummary),
+	}
+	if baseline != nil {
+		result.BaselinePath = opts.BaselinePath
+		result.Regressions = detectCategoryRegressions(baseline.Implicit.CategoryBreakdown, implicitSummary.CategoryBreakdown, opts.MaxCategoryPassAt5Drop)
 	}
 
 	artifacts, err := writeFoundationArtifacts(result, opts.OutputDir, opts.ReportFormat)
@@ -272,6 +330,16 @@ func RunFoundationEvaluation(ctx context.Context, options *FoundationEvaluationO
 	}
 	result.ReportArtifacts = artifacts
 
+	if strings.TrimSpace(opts.PublishEndpoint) != "" {
+		if err := publishFoundationResult(ctx, opts.PublishEndpoint, result); err != nil {
+			return result, fmt.Errorf("publish foundation result: %w", err)
+		}
+	}
+
+	// The result is still returned so callers can report the regressions.
+	if len(result.Regressions) > 0 {
+		return result, fmt.Errorf("foundation eval regressed against baseline %s: %s", opts.BaselinePath, strings.Join(result.Regressions, "; "))
+	}
 	return result, nil
 }
 
@@ -735,7 +803,7 @@ func scoreToolProfile(profile foundationToolProfile) FoundationToolScore {
 	}
 }
 
-func evaluateImplicitCases(scenarios []FoundationScenario, profiles []foundationToolProfile, topK int) FoundationImplicitSummary {
+func evaluateImplicitCases(scenarios []FoundationScenario, profiles []foundationToolProfile, topK int, ranker ToolRanker) FoundationImplicitSummary {
 	evalStart := time.Now()
 	results := make([]FoundationCaseResult, 0, len(scenarios))
 	latencies := make([]float64, 0, len(scenarios))
@@ -753,7 +821,7 @@ func evaluateImplicitCases(scenarios []FoundationScenario, profiles []foundation
 	for _, scenario := range scenarios {
 		caseStart := time.Now()
 		intentTokens := tokenize(scenario.Intent)
-		ranked := rankToolsForIntent(intentTokens, profiles)
+		ranked := ranker.Rank(intentTokens)
 
 		expectedAvailable := make([]string, 0, len(scenario.ExpectedTools))
 		expectedMissing := make([]string, 0, len(scenario.ExpectedTools))
@@ -909,6 +977,7 @@ func evaluateImplicitCases(scenarios []FoundationScenario, profiles []foundation
 		CaseLatencyP95Ms:         round3(percentileFloat(latencies, 95)),
 		CaseLatencyP99Ms:         round3(percentileFloat(latencies, 99)),
 		ThroughputCasesPerSec:    round3(float64(total) / math.Max(totalEvalMs/1000.0, 1e-9)),
+		CategoryBreakdown:        buildCategoryBreakdown(results),
 		CaseResults:              results,
 	}
 }
@@ -1029,15 +1098,7 @@ func collectDeliverableSignals(contract *FoundationDeliverableContract) map[stri
 }
 
 func rankToolsForIntent(intentTokens []string, profiles []foundationToolProfile) []FoundationToolMatch {
-	tokenSet := make(map[string]struct{}, len(intentTokens))
-	for _, token := range intentTokens {
-		norm := normalizeToken(token)
-		if norm == "" {
-			continue
-		}
-		tokenSet[norm] = struct{}{}
-	}
-
+	tokenSet := normalizedTokenSet(intentTokens)
 	ranked := make([]FoundationToolMatch, 0, len(profiles))
 	for _, profile := range profiles {
 		score := 0.0
@@ -1047,17 +1108,40 @@ func rankToolsForIntent(intentTokens []string, profiles []foundationToolProfile)
 		score += heuristicIntentBoost(profile.Definition.Name, tokenSet)
 		ranked = append(ranked, FoundationToolMatch{Name: profile.Definition.Name, Score: round2(score)})
 	}
+	sortToolMatches(ranked)
+	return ranked
+}
+
+func normalizedTokenSet(tokens []string) map[string]struct{} {
+	tokenSet := make(map[string]struct{}, len(tokens))
+	for _, token := range tokens {
+		norm := normalizeToken(token)
+		if norm == "" {
+			continue
+		}
+		tokenSet[norm] = struct{}{}
+	}
+	return tokenSet
+}
 
+// sortToolMatches orders matches by score, breaking ties by name.
+func sortToolMatches(ranked []FoundationToolMatch) {
 	sort.Slice(ranked, func(i, j int) bool {
 		if ranked[i].Score == ranked[j].Score {
 			return ranked[i].Name < ranked[j].Name
 		}
 		return ranked[i].Score > ranked[j].Score
 	})
-	return ranked
 }
 
 func heuristicIntentBoost(toolName string, tokenSet map[string]struct{}) float64 {
+	return tracedIntentBoost(toolName, tokenSet, nil)
+}
+
+// tracedIntentBoost computes heuristicIntentBoost. When onRule is non-nil it
+// is called for every rule that fired, with the rule's source line and the
+// boost it added, so --explain can attribute a score to individual rules.
+func tracedIntentBoost(toolName string, tokenSet map[string]struct{}, onRule func(line int, delta float64)) float64 {
 	has := func(tokens ...string) bool {
 		for _, token := range tokens {
 			if _, ok := tokenSet[token]; ok {
@@ -1084,513 +1168,521 @@ func heuristicIntentBoost(toolName string, tokenSet map[string]struct{}) float64
 		return count
 	}
 
+	rule := func(delta float64) float64 {
+		if onRule != nil {
+			_, _, line, _ := runtime.Caller(1)
+			onRule(line, delta)
+		}
+		return delta
+	}
+
 	boost := 0.0
 	switch toolName {
 	case "plan":
 		if has("phase", "milestone", "checkpoint", "risk", "roadmap", "timeline", "migration") {
-			boost += 14
+			boost += rule(14)
 		}
 		if countMatches("minimal", "smallest", "viable", "weekly", "review", "checkpoint", "rollback") >= 2 {
-			boost += 16
+			boost += rule(16)
 		}
 		if countMatches("plan", "steps", "fix", "before", "mutation", "change", "apply", "execution") >= 3 {
-			boost += 18
+			boost += rule(18)
 		}
 		if countMatches("phased", "checklist", "rollback", "checkpoint", "reproduce", "patch", "verify", "gates") >= 3 {
-			boost += 20
+			boost += rule(20)
 		}
 		if countMatches("before", "task", "updates", "rollout", "phased", "milestones", "risk", "checkpoints") >= 4 {
-			boost += 18
+			boost += rule(18)
 		}
 		if countMatches("release", "checklist", "milestones", "rollback", "checkpoint", "checkpoints") >= 3 {
-			boost += 26
+			boost += rule(26)
 		}
 	case "read_file":
 		if countMatches("read", "open", "inspect", "view") >= 1 &&
 			countMatches("source", "workspace", "file", "content", "line") >= 1 {
-			boost += 18
+			boost += rule(18)
 		}
 		if countMatches("read", "local", "workspace", "notes", "file", "before") >= 3 {
-			boost += 18
+			boost += rule(18)
 		}
 		if countMatches("failing", "failure", "function", "context", "logic", "contract", "neighboring") >= 3 {
-			boost += 20
+			boost += rule(20)
 		}
 		if countMatches("logic", "window", "suspected", "behavior", "before", "patch", "regression") >= 3 {
-			boost += 24
+			boost += rule(24)
 		}
 		// Absorbed from former memory_search tool.
 		if countMatches("memory", "prior", "history", "decision", "note", "context", "summary", "recall") >= 2 {
-			boost += 20
+			boost += rule(20)
 		}
 		if hasAll("before", "offset") && has("known", "exact", "line", "lines") {
-			boost += 20
+			boost += rule(20)
 		}
 		if countMatches("preference", "habit", "style", "tone", "persona", "format", "choice") >= 2 {
-			boost += 16
+			boost += rule(16)
 		}
 		if has("recall", "recover", "retrieve") &&
 			countMatches("preference", "preferred", "habit", "style", "tone", "persona", "format", "choice", "interaction", "interactions", "behavior", "pattern") >= 1 {
-			boost += 14
+			boost += rule(14)
 		}
 		if countMatches("motivation", "successful", "pattern", "previous", "cadence", "nudge") >= 2 {
-			boost += 12
+			boost += rule(12)
 		}
 		if countMatches("previous", "prior", "successful", "pattern", "decision", "policy", "history") >= 3 {
-			boost += 10
+			boost += rule(10)
 		}
 		if countMatches("communication", "tone", "style", "voice", "habit", "preference", "persona", "soul") >= 3 {
-			boost += 20
+			boost += rule(20)
 		}
 		if countMatches("memory", "preference", "retrieval", "retrieve", "habit", "persona", "policy", "history") >= 3 {
-			boost += 18
+			boost += rule(18)
 		}
 		if countMatches("memory", "historical", "incident", "signature", "regression", "guardrail", "before", "patch") >= 3 {
-			boost += 22
+			boost += rule(22)
 		}
 		if countMatches("sparse", "hidden", "long", "tail", "fact", "facts", "corpus", "notes", "retrieve") >= 3 {
-			boost += 24
+			boost += rule(24)
 		}
 		if countMatches("historical", "remediation", "playbook", "worked", "similar", "incident", "incidents") >= 3 {
-			boost += 24
+			boost += rule(24)
 		}
 		// Absorbed from former memory_get tool.
 		if countMatches("open", "exact", "line", "lines", "offset", "fragment", "citation", "verbatim", "proof", "evidence", "selected", "note") >= 2 {
-			boost += 24
+			boost += rule(24)
 		}
 		if countMatches("selected", "memory", "note", "open", "detail", "detailed", "guidance", "context", "root", "cause") >= 3 {
-			boost += 20
+			boost += rule(20)
 		}
 	case "ask_user":
 		if has("ambiguity", "clarify", "blocking", "requirement", "missing", "unclear", "constraint", "conflict") {
-			boost += 14
+			boost += rule(14)
 		}
 		if countMatches("you", "decide", "anything", "work", "delegate", "default", "low", "reversible", "status", "message", "thread", "again") >= 6 &&
 			countMatches("approval", "consent", "confirm", "manual", "external", "irreversible", "critical") == 0 {
-			boost -= 24
+			boost += rule(-24)
 		}
 		if countMatches("human", "manual", "approval", "approve", "confirm", "consent", "operator", "go-signal", "gate", "out-of-band", "acknowledgement", "wait") >= 2 {
-			boost += 26
+			boost += rule(26)
 		}
 		if hasAll("before", "continue") && has("manual", "approval", "confirm", "human") {
-			boost += 10
+			boost += rule(10)
 		}
 		if countMatches("sensitive", "personal", "private", "consent", "confirmation") >= 2 {
-			boost += 12
+			boost += rule(12)
 		}
 		if countMatches("external", "outreach", "third", "party", "before", "approval", "consent") >= 2 {
-			boost += 14
+			boost += rule(14)
 		}
 		if countMatches("secret", "token", "user", "provided", "before", "execution", "request") >= 3 {
-			boost += 18
+			boost += rule(18)
 		}
 		if countMatches("critical", "irreversible", "human", "go", "ahead", "before", "step") >= 3 {
-			boost += 28
+			boost += rule(28)
 		}
 		if countMatches("freeze", "wait", "greenlight", "silence", "no", "continue") >= 3 {
-			boost += 34
+			boost += rule(34)
 		}
 		if countMatches("user", "requir", "explicit", "consent", "before", "outreach") >= 4 {
-			boost += 34
+			boost += rule(34)
 		}
 		if countMatches("you", "decide", "anything", "work", "delegate", "default", "low", "reversible", "status", "message", "thread", "again") >= 6 &&
 			countMatches("approval", "consent", "confirm", "manual", "external", "irreversible", "critical") == 0 {
-			boost -= 28
+			boost += rule(-28)
 		}
 		if countMatches("view", "check", "list", "inspect", "status", "repo", "branch", "directory", "structure", "workspace", "read", "only") >= 4 &&
 			countMatches("approval", "consent", "manual", "external", "irreversible", "critical", "captcha", "2fa", "login", "publish", "go-signal", "greenlight") == 0 {
-			boost -= 32
+			boost += rule(-32)
 		}
 	case "web_search":
 		if countMatches("search", "lookup", "find", "query", "compare") >= 1 &&
 			countMatches("web", "internet", "doc", "reference", "official", "site", "url") >= 1 {
-			boost += 14
+			boost += rule(14)
 		}
 		if countMatches("authoritative", "canonical", "trusted", "reference", "primary", "discover", "shortlist") >= 2 {
-			boost += 20
+			boost += rule(20)
 		}
 		if has("no", "fixed", "web") || hasAll("source", "not", "selected") {
-			boost += 12
+			boost += rule(12)
 		}
 	case "web_fetch":
 		if countMatches("fetch", "read", "extract", "open", "pull") >= 1 &&
 			countMatches("url", "exact", "single", "provided", "fixed", "page", "content") >= 2 {
-			boost += 18
+			boost += rule(18)
 		}
 		if countMatches("single", "exact", "provided", "fixed", "url", "source", "no", "search") >= 4 {
-			boost += 18
+			boost += rule(18)
 		}
 		if countMatches("only", "source", "url", "avoid", "broad", "search", "discovery", "already", "chosen") >= 4 {
-			boost += 20
+			boost += rule(20)
 		}
 		if countMatches("approved", "canonical", "single", "exact", "url", "ingest", "without", "discovery") >= 4 {
-			boost += 26
+			boost += rule(26)
 		}
 		if countMatches("single", "approved", "link", "ingest", "only", "page") >= 3 {
-			boost += 20
+			boost += rule(20)
 		}
 	case "browser_dom":
 		if has("selector", "dom", "form", "field", "fill", "submit") {
-			boost += 14
+			boost += rule(14)
 		}
 	case "browser_action":
 		if has("coordinate", "canvas", "pixel", "drag", "position") {
-			boost += 12
+			boost += rule(12)
 		}
 	case "browser_info":
 		if countMatches("browser", "tab", "state", "session", "metadata", "url", "current", "title", "viewport", "info", "status", "web") >= 2 {
-			boost += 18
+			boost += rule(18)
 		}
 		if countMatches("read", "inspect", "state", "status", "metadata", "without", "interaction") >= 3 {
-			boost += 14
+			boost += rule(14)
 		}
 	case "browser_screenshot":
 		if has("capture", "screenshot", "proof", "visual", "evidence", "page") {
-			boost += 14
+			boost += rule(14)
 		}
 		if countMatches("single", "exact", "approved", "canonical", "url", "ingest", "without", "discovery") >= 4 &&
 			!has("visual", "proof", "screenshot", "ui", "capture") {
-			boost -= 36
+			boost += rule(-36)
 		}
 	case "write_file":
 		if countMatches("write", "create", "new", "save") >= 1 &&
 			countMatches("file", "markdown", "note", "report", "content") >= 1 {
-			boost += 18
+			boost += rule(18)
 		}
 		if countMatches("ledger", "durable", "audit", "artifact", "progress", "proof") >= 3 {
-			boost -= 12
+			boost += rule(-12)
 		}
 		if countMatches("artifacts_write", "artifact", "artifacts", "report", "downstream", "reusable", "deliverable") >= 2 &&
 			!has("workspace", "local", "markdown", "create", "write", "file") {
-			boost -= 22
+			boost += rule(-22)
 		}
 		if countMatches("identify", "locate", "candidate", "pattern", "inside", "files") >= 3 &&
 			!has("write", "create", "save", "draft", "new") {
-			boost -= 18
+			boost += rule(-18)
 		}
 		if countMatches("local", "new", "file", "materialization", "materialize", "workspace", "markdown") >= 3 {
-			boost += 20
+			boost += rule(20)
 		}
 	case "list_dir":
 		if countMatches("list", "show", "enumerate", "browse", "tree") >= 1 &&
 			countMatches("directory", "folder", "workspace", "path") >= 1 {
-			boost += 18
+			boost += rule(18)
 		}
 		if countMatches("inventory", "candidate", "path", "directory", "nested", "root", "roots") >= 3 {
-			boost += 14
+			boost += rule(14)
 		}
 	case "search_file":
 		if countMatches("search", "find", "locate", "occurrence", "symbol", "token", "regex", "pattern") >= 1 &&
 			countMatches("file", "project", "repo", "source", "code", "across") >= 1 {
-			boost += 18
+			boost += rule(18)
 		}
 		if countMatches("semantic", "content", "inside", "files", "instead", "path", "names") >= 3 {
-			boost += 16
+			boost += rule(16)
 		}
 		if countMatches("multihop", "reference", "references", "chain", "authoritative", "statement", "resolve", "across", "files") >= 3 {
-			boost += 18
+			boost += rule(18)
 		}
 		if has("regex", "needle", "sweep", "fast", "quickly") && !has("content", "snippet", "lines", "inside", "within") {
 			// Prefer ripgrep for fast regex repository sweeps over semantic content search.
-			boost -= 8
+			boost += rule(-8)
 		}
 	case "replace_in_file":
 		if has("replace", "deprecated", "endpoint", "api", "path", "file", "update") {
-			boost += 16
+			boost += rule(16)
 		}
 	case "write_attachment":
 		if countMatches("attach", "download", "artifact", "generated", "deliver", "share", "export", "write", "file", "summary", "handoff") >= 2 {
-			boost += 30
+			boost += rule(30)
 		}
 		if hasAll("write", "attach") {
-			boost += 8
+			boost += rule(8)
 		}
 	case "find":
 		if countMatches("find", "locate", "lookup", "name", "filename", "directory", "path") >= 2 {
-			boost += 20
+			boost += rule(20)
 		}
 		if countMatches("name", "path", "directory", "filename") >= 2 &&
 			!has("content", "line", "lines", "snippet", "inside", "within") {
-			boost += 8
+			boost += rule(8)
 		}
 		if countMatches("nested", "path", "root", "tree", "directory", "name") >= 3 {
-			boost += 8
+			boost += rule(8)
 		}
 		if countMatches("monorepo", "scope", "candidate", "folder", "filename", "before", "open") >= 3 &&
 			!has("content", "snippet", "inside", "within") {
-			boost += 12
+			boost += rule(12)
 		}
 		if countMatches("directory", "name", "constraint", "constraints", "before", "open") >= 3 &&
 			!has("content", "snippet", "inside", "within") {
-			boost += 14
+			boost += rule(14)
 		}
 		if countMatches("path", "pattern", "before", "content", "inspection", "reduce", "large", "tree") >= 3 {
-			boost += 22
+			boost += rule(22)
 		}
 		if countMatches("entrypoint", "entrypoints", "module", "layer", "package", "path", "folder", "directory") >= 3 {
-			boost += 14
+			boost += rule(14)
 		}
 	case "grep":
 		if countMatches("grep", "log", "error", "line", "pattern", "match") >= 2 {
-			boost += 18
+			boost += rule(18)
 		}
 		if countMatches("simple", "grep", "local", "log", "logs", "502", "http") >= 3 {
-			boost += 18
+			boost += rule(18)
 		}
 	case "lark_calendar_query":
 		if countMatches("calendar", "event", "query", "upcoming", "schedule", "check") >= 2 {
-			boost += 16
+			boost += rule(16)
 		}
 		if countMatches("compute", "calculate", "deterministic", "numeric", "consistency", "snippet", "slices", "fragments") >= 3 &&
 			!has("calendar", "event", "meeting", "schedule") {
-			boost -= 26
+			boost += rule(-26)
 		}
 	case "lark_calendar_create":
 		if countMatches("calendar", "event", "block", "deadline", "focus", "recovery", "work") >= 2 {
-			boost += 18
+			boost += rule(18)
 		}
 		if countMatches("create", "calendar", "events", "meeting", "meetings", "kickoff", "review") >= 3 {
-			boost += 22
+			boost += rule(22)
 		}
 		if countMatches("reserve", "execution", "window", "calendar", "block", "create", "creating") >= 3 {
-			boost += 24
+			boost += rule(24)
 		}
 	case "lark_calendar_delete":
 		if countMatches("calendar", "event", "delete", "remove", "cancel", "stale", "obsolete", "cleanup") >= 2 {
-			boost += 20
+			boost += rule(20)
 		}
 	case "lark_chat_history":
 		if countMatches("chat", "thread", "conversation", "history", "context", "recent", "before") >= 2 {
-			boost += 24
+			boost += rule(24)
 		}
 		if countMatches("reconstruct", "chronology", "prior", "thread", "turns", "before", "replying", "answer") >= 3 {
-			boost += 18
+			boost += rule(18)
 		}
 		if countMatches("prior", "chat", "context", "thread", "history", "before", "replying", "no", "file", "transfer") >= 5 {
-			boost += 24
+			boost += rule(24)
 		}
 	case "okr_write":
 		if countMatches("okr", "objective", "key", "result", "progress", "update", "write", "status") >= 2 {
-			boost += 16
+			boost += rule(16)
 		}
 	case "okr_read":
 		if countMatches("okr", "objective", "status", "read", "current", "before", "baseline") >= 2 {
-			boost += 18
+			boost += rule(18)
 		}
 		if countMatches("workspace", "local", "repo", "repository", "path", "file", "notes") >= 2 &&
 			!has("okr", "objective", "key", "result", "goal") {
-			boost -= 26
+			boost += rule(-26)
 		}
 		if countMatches("local", "workspace", "notes", "file", "read", "before") >= 3 &&
 			!has("okr", "objective", "key", "result", "goal", "kr") {
-			boost -= 34
+			boost += rule(-34)
 		}
 		if countMatches("code", "source", "function", "failing", "logic", "contract", "module", "repository", "repo") >= 3 &&
 			!has("okr", "objective", "key", "result", "goal", "kr") {
-			boost -= 28
+			boost += rule(-28)
 		}
 	case "artifact_manifest":
 		if countMatches("manifest", "metadata", "generated", "describe", "artifact") >= 2 {
-			boost += 22
+			boost += rule(22)
 		}
 	case "artifacts_write":
 		if countMatches("artifact", "report", "persist", "save", "write", "reference", "final", "output") >= 2 {
-			boost += 20
+			boost += rule(20)
 		}
 		if countMatches("concise", "chat", "durable", "reusable", "downstream", "audit", "package", "full") >= 2 {
-			boost += 18
+			boost += rule(18)
 		}
 		if countMatches("progress", "progres", "momentum", "completed", "proof", "evidence", "summary") >= 2 {
-			boost += 14
+			boost += rule(14)
 		}
 		if countMatches("progress", "progres", "momentum", "completed", "artifact", "durable", "proof") >= 3 &&
 			!has("browser", "dom", "click", "drag", "canvas", "selector", "ui", "page") {
-			boost += 16
+			boost += rule(16)
 		}
 		if has("artifact", "concise") &&
 			countMatches("progress", "progres", "momentum", "completed", "action", "actions", "proof", "evidence") >= 3 {
-			boost += 28
+			boost += rule(28)
 		}
 		if countMatches("multi", "round", "ledger", "durable", "progress", "artifact", "record") >= 3 {
-			boost += 22
+			boost += rule(22)
 		}
 		if countMatches("list", "inventory", "enumerate", "existing", "current", "artifacts", "artifact") >= 3 &&
 			!has("write", "create", "save", "new", "persist") {
-			boost -= 26
+			boost += rule(-26)
 		}
 		if countMatches("diagram", "architecture", "visual", "brief", "render") >= 3 &&
 			!has("artifact", "report", "persist", "write", "deliverable") {
-			boost -= 20
+			boost += rule(-20)
 		}
 	case "diagram_render":
 		if countMatches("diagram", "architecture", "visual", "brief", "service", "relationship") >= 3 {
-			boost += 24
+			boost += rule(24)
 		}
 	case "artifacts_list":
 		if countMatches("list", "enumerate", "index", "show", "generated", "artifact") >= 2 {
-			boost += 10
+			boost += rule(10)
 		}
 		if countMatches("enumerate", "outputs", "produced", "run", "choose", "files", "release", "reviewer") >= 3 {
-			boost += 22
+			boost += rule(22)
 		}
 		if countMatches("list", "inventory", "enumerate", "existing", "current", "artifacts", "artifact") >= 3 {
-			boost += 16
+			boost += rule(16)
 		}
 		if countMatches("before", "release", "share", "latest", "valid", "existing", "generated", "outputs", "artifacts") >= 4 {
-			boost += 24
+			boost += rule(24)
 		}
 		if countMatches("before", "share", "sharing", "execution", "output", "outputs", "list", "existing", "latest", "valid", "artifacts") >= 5 {
-			boost += 26
+			boost += rule(26)
 		}
 		if countMatches("surface", "existing", "outputs", "produced", "before", "release") >= 3 {
-			boost += 24
+			boost += rule(24)
 		}
 	case "artifacts_delete":
 		if countMatches("delete", "remove", "prune", "cleanup", "stale", "obsolete", "artifact", "artifacts", "legacy") >= 2 {
-			boost += 24
+			boost += rule(24)
 		}
 		if countMatches("stale", "failed", "run", "bundles", "polluted", "evidence", "cleanup") >= 3 {
-			boost += 20
+			boost += rule(20)
 		}
 	case "cancel_timer":
 		if countMatches("cancel", "remove", "delete", "drop", "prune", "obsolete", "stale", "duplicate", "timer", "reminder") >= 2 {
-			boost += 22
+			boost += rule(22)
 		}
 		if countMatches("withdraw", "stale", "nudge", "queue", "queued", "reminder") >= 3 {
-			boost += 22
+			boost += rule(22)
 		}
 	case "set_timer":
 		if countMatches("set", "new", "create", "schedule", "later", "after", "timer", "reminder") >= 2 {
-			boost += 12
+			boost += rule(12)
 		}
 		if countMatches("arm", "short", "nudge", "next", "focus", "window") >= 3 {
-			boost += 20
+			boost += rule(20)
 		}
 	case "ripgrep":
 		if countMatches("regex", "pattern", "needle", "sweep", "scan", "repo", "repository", "across", "fast", "quick", "hotspot") >= 2 {
-			boost += 26
+			boost += rule(26)
 		}
 	case "shell_exec":
 		if countMatches("shell", "command", "cli", "terminal", "process", "port", "inspect", "check", "grep", "log") >= 2 {
-			boost += 18
+			boost += rule(18)
 		}
 		// Absorbed from former execute_code tool.
 		if countMatches("script", "snippet", "compute", "calculate", "deterministic", "metric", "validate", "score") >= 2 {
-			boost += 16
+			boost += rule(16)
 		}
 		if countMatches("consistency", "numeric", "fragments", "slices", "deterministic", "check") >= 3 {
-			boost += 16
+			boost += rule(16)
 		}
 		if has("shell_exec") {
-			boost += 20
+			boost += rule(20)
 		}
 		if countMatches("reproduce", "failure", "failing", "test", "command", "before", "fix") >= 3 {
-			boost += 22
+			boost += rule(22)
 		}
 		if countMatches("view", "check", "list", "inspect", "repo", "branch", "status", "directory", "structure", "workspace", "read", "only") >= 4 &&
 			countMatches("approval", "consent", "manual", "external", "irreversible", "critical", "captcha", "2fa", "login", "publish", "go-signal", "greenlight") == 0 {
-			boost += 18
+			boost += rule(18)
 		}
 	case "scheduler_list_jobs":
 		if countMatches("job", "jobs", "list", "inventory", "registered", "cadence", "schedule", "show") >= 2 {
-			boost += 18
+			boost += rule(18)
 		}
 		if countMatches("audit", "inspect", "current", "existing", "recurring", "automation", "automations", "next", "fire", "time", "times", "before", "change", "policy", "cadence") >= 3 {
-			boost += 18
+			boost += rule(18)
 		}
 		if countMatches("freeze", "frozen", "mutation", "resume", "state", "scheduled", "before", "write", "writes") >= 3 {
-			boost += 22
+			boost += rule(22)
 		}
 		if countMatches("show", "registered", "recurrence", "recurrences", "recurring", "before", "mutation") >= 3 {
-			boost += 24
+			boost += rule(24)
 		}
 		if countMatches("inspect", "current", "schedule", "state", "frozen", "mutation", "first") >= 3 {
-			boost += 28
+			boost += rule(28)
 		}
 		if countMatches("reveal", "currently", "registered", "recurring", "automations") >= 3 {
-			boost += 30
+			boost += rule(30)
 		}
 	case "scheduler_create_job":
 		if countMatches("recurring", "weekday", "daily", "nightly", "followup", "accountability", "checkin", "scheduler", "job") >= 2 {
-			boost += 20
+			boost += rule(20)
 		}
 		if countMatches("schedule", "automatic", "followup", "reply", "status", "when", "no") >= 3 {
-			boost += 14
+			boost += rule(14)
 		}
 		if countMatches("register", "new", "cadence", "stable", "identifier", "recurring", "job") >= 3 {
-			boost += 20
+			boost += rule(20)
 		}
 		if countMatches("spin", "fresh", "recurring", "line", "stable", "handle") >= 3 {
-			boost += 22
+			boost += rule(22)
 		}
 	case "scheduler_delete_job":
 		if countMatches("obsolete", "stale", "scheduler", "job", "delete", "remove", "checkin") >= 2 {
-			boost += 14
+			boost += rule(14)
 		}
 		if countMatches("legacy", "deprecation", "deprecated", "retired", "obsolete", "recurring", "cadence", "checkin", "checkins", "automation", "automations", "remove", "delete") >= 3 {
-			boost += 16
+			boost += rule(16)
 		}
 		if countMatches("violates", "policy", "remove", "removed", "not", "recreated", "recreate", "cadence", "recurring") >= 4 {
-			boost += 24
+			boost += rule(24)
 		}
 		if countMatches("violate", "policy", "remove", "not", "recreate", "cadence", "recurring") >= 4 {
-			boost += 24
+			boost += rule(24)
 		}
 		if countMatches("old", "retired", "recurring", "cadence", "violate", "policy", "removed") >= 3 {
-			boost += 24
+			boost += rule(24)
 		}
 		if countMatches("sunset", "retire", "standing", "recurring", "cadence", "circulation") >= 3 {
-			boost += 24
+			boost += rule(24)
 		}
 		if countMatches("legacy", "recurring", "automation", "violates", "policy", "retired", "remove", "obsolete", "schedule") >= 3 {
-			boost += 28
+			boost += rule(28)
 		}
 	case "list_timers":
 		if countMatches("timer", "timers", "reminder", "reminders", "remaining", "active", "schedule") >= 2 {
-			boost += 20
+			boost += rule(20)
 		}
 		if countMatches("queued", "queue", "later", "nudge", "today", "active") >= 3 {
-			boost += 20
+			boost += rule(20)
 		}
 	case "lark_upload_file":
 		if countMatches("upload", "file", "lark", "thread", "chat", "conversation") >= 2 {
-			boost += 24
+			boost += rule(24)
 		}
 	case "channel":
 		if countMatches("send", "message", "status", "thread", "chat", "lark") >= 2 {
-			boost += 14
+			boost += rule(14)
 		}
 		if countMatches("user", "requir", "explicit", "consent", "before", "outreach", "approval", "external") >= 4 &&
 			countMatches("send", "message", "status", "thread", "chat", "lark") < 2 {
-			boost -= 36
+			boost += rule(-36)
 		}
 		if countMatches("you", "decide", "anything", "work", "delegate", "default", "low", "reversible", "status", "message", "thread", "again") >= 6 &&
 			countMatches("approval", "consent", "confirm", "manual", "external", "irreversible", "critical") == 0 {
-			boost += 28
+			boost += rule(28)
 		}
 	case "lark_send_message":
 		if countMatches("send", "message", "update", "status", "lark", "thread", "chat") >= 2 {
-			boost += 14
+			boost += rule(14)
 		}
 		if countMatches("status", "announce", "broadcast", "notify", "share") >= 1 {
-			boost += 8
+			boost += rule(8)
 		}
 		if countMatches("checkin", "encourage", "nudge", "progress", "reminder", "followup") >= 2 {
-			boost += 10
+			boost += rule(10)
 		}
 		if has("without", "no", "not") && countMatches("upload", "attach", "file") >= 1 {
-			boost += 14
+			boost += rule(14)
 		}
 		if countMatches("checkpoint", "status", "message", "short", "brief", "thread", "chat") >= 3 &&
 			!has("edit", "replace", "patch", "file", "upload", "attach") {
-			boost += 14
+			boost += rule(14)
 		}
 		if countMatches("thread", "status", "ping", "brief", "short", "no", "file", "transfer") >= 4 {
-			boost += 18
+			boost += rule(18)
 		}
 	case "a2ui_emit":
 		if has("payload", "renderer", "render", "ui", "protocol", "structured") {
-			boost += 12
+			boost += rule(12)
 		}
 	}
 
@@ -1598,623 +1690,623 @@ func heuristicIntentBoost(toolName string, tokenSet map[string]struct{}) float64
 	if has(toolName) {
 		switch toolName {
 		case "plan", "ask_user", "find", "search_file", "read_file", "write_file":
-			boost += 6
+			boost += rule(6)
 		default:
-			boost += 24
+			boost += rule(24)
 		}
 	}
 
 	if strings.HasSuffix(toolName, "_list") && has("list", "show", "enumerate", "all") {
-		boost += 4
+		boost += rule(4)
 	}
 	if strings.HasSuffix(toolName, "_create") && has("create", "new") {
-		boost += 3
+		boost += rule(3)
 	}
 	if strings.HasSuffix(toolName, "_update") && has("update", "modify", "change") {
-		boost += 3
+		boost += rule(3)
 	}
 	if strings.HasSuffix(toolName, "_delete") && has("delete", "remove") {
-		boost += 3
+		boost += rule(3)
 	}
 	if strings.HasSuffix(toolName, "_query") && has("query", "search", "lookup") {
-		boost += 3
+		boost += rule(3)
 	}
 	if strings.HasPrefix(toolName, "web_") && has("web", "url", "page") {
-		boost += 2
+		boost += rule(2)
 	}
 	if strings.HasPrefix(toolName, "lark_") && has("lark") {
-		boost += 2
+		boost += rule(2)
 	}
 	if toolName == "lark_calendar_create" || toolName == "lark_calendar_update" || toolName == "lark_calendar_delete" {
 		if has("query", "check", "upcoming", "search", "list") &&
 			!has("create", "new", "update", "modify", "change", "delete", "remove") {
-			boost -= 6
+			boost += rule(-6)
 		}
 	}
 	if toolName == "replace_in_file" {
 		if has("okr", "objective", "key", "result", "progress") && !has("replace", "patch", "endpoint", "string") {
-			boost -= 6
+			boost += rule(-6)
 		}
 	}
 	if toolName == "lark_task_manage" {
 		if has("artifact", "artifacts", "manifest", "cleanup", "delete", "remove", "stale") &&
 			!has("task", "assign", "owner", "due", "todo") {
-			boost -= 12
+			boost += rule(-12)
 		}
 	}
 	if toolName == "ask_user" {
 		if countMatches("delegate", "executor", "parallel", "subagent", "handoff", "heavy") >= 2 {
-			boost -= 12
+			boost += rule(-12)
 		}
 		if countMatches("memory", "habit", "preference", "style", "persona", "soul") >= 2 {
-			boost -= 18
+			boost += rule(-18)
 		}
 		if countMatches("create", "event", "calendar", "schedule", "timer", "artifact", "attach", "downloadable") >= 2 &&
 			!has("unclear", "ambiguity", "clarify", "conflict") {
-			boost -= 14
+			boost += rule(-14)
 		}
 		if has("artifact", "report", "attachment", "downloadable") &&
 			!has("unclear", "ambiguity", "clarify", "conflict") {
-			boost -= 10
+			boost += rule(-10)
 		}
 		if countMatches("replace", "patch", "rewrite", "update", "shift", "run", "show", "apply", "exact", "existing", "inplace", "event") >= 3 &&
 			!has("unclear", "ambiguity", "clarify", "conflict", "missing", "question") {
-			boost -= 30
+			boost += rule(-30)
 		}
 		if countMatches("tracked", "task", "item", "operationalize", "commitment", "calendar", "block", "reserve", "window") >= 3 &&
 			!has("unclear", "ambiguity", "clarify", "conflict", "missing", "question") {
-			boost -= 30
+			boost += rule(-30)
 		}
 	}
 	if toolName == "lark_calendar_delete" || toolName == "lark_calendar_create" || toolName == "lark_calendar_update" {
 		if has("artifact", "artifacts", "manifest", "cleanup", "stale", "obsolete", "legacy") &&
 			!has("calendar", "event", "meeting", "schedule") {
-			boost -= 10
+			boost += rule(-10)
 		}
 	}
 	if toolName == "file_edit" {
 		if has("list", "directory", "folder", "workspace", "metadata", "state", "session", "url", "tab") {
-			boost -= 10
+			boost += rule(-10)
 		}
 		if has("search", "find", "locate", "occurrence", "symbol", "token", "regex", "pattern") && !has("replace", "edit", "modify", "update", "create") {
-			boost -= 12
+			boost += rule(-12)
 		}
 		if has("artifact", "memory", "timer", "reminder") && !has("replace", "edit", "modify", "update", "create") {
-			boost -= 10
+			boost += rule(-10)
 		}
 		if has("attach", "download", "generated", "deliver", "export") && !has("replace", "edit", "modify", "update", "create") {
-			boost -= 10
+			boost += rule(-10)
 		}
 	}
 	if toolName == "read_file" {
 		if has("inventory", "candidate", "path", "directory", "nested", "root", "roots") && !has("content", "line", "lines", "snippet", "open", "read") {
-			boost -= 12
+			boost += rule(-12)
 		}
 	}
 	if toolName == "write_attachment" {
 		if countMatches("lark", "thread", "chat", "conversation", "upload") >= 2 {
-			boost -= 18
+			boost += rule(-18)
 		}
 		if has("concise", "chat", "durable", "reusable", "downstream", "audit") &&
 			!has("download", "thread", "upload", "attach", "handoff", "receiver") {
-			boost -= 16
+			boost += rule(-16)
 		}
 		if countMatches("artifact", "persist", "store", "report", "bundle", "manifest") >= 2 &&
 			!has("attach", "upload", "download", "thread", "chat") {
-			boost -= 20
+			boost += rule(-20)
 		}
 		if countMatches("write", "file", "save", "create") >= 2 &&
 			!has("attach", "upload", "download", "thread", "chat") {
-			boost -= 12
+			boost += rule(-12)
 		}
 	}
 	if toolName == "lark_upload_file" {
 		if countMatches("history", "context", "recent", "before", "conversation", "thread", "chat") >= 3 &&
 			!has("upload", "attach", "file", "share", "send") {
-			boost -= 26
+			boost += rule(-26)
 		}
 		if countMatches("send", "message", "status", "announce", "notify") >= 2 &&
 			!has("upload", "attach", "file") {
-			boost -= 20
+			boost += rule(-20)
 		}
 		if countMatches("checkin", "encourage", "nudge", "progress", "reminder", "followup") >= 2 &&
 			!has("upload", "attach", "file", "download") {
-			boost -= 16
+			boost += rule(-16)
 		}
 		if has("without", "no", "not") && countMatches("upload", "attach", "file") >= 1 {
-			boost -= 30
+			boost += rule(-30)
 		}
 	}
 	if toolName == "set_timer" {
 		if countMatches("cancel", "remove", "delete", "drop", "prune", "obsolete", "stale", "duplicate", "timer", "reminder") >= 2 {
-			boost -= 18
+			boost += rule(-18)
 		}
 		if countMatches("scheduler", "job", "cron", "cadence", "recurring", "daily", "weekly") >= 2 {
-			boost -= 14
+			boost += rule(-14)
 		}
 		if countMatches("audit", "inspect", "before", "policy", "automation", "automations", "next", "fire", "time", "times") >= 3 &&
 			!has("timer", "reminder", "minutes", "hours") {
-			boost -= 18
+			boost += rule(-18)
 		}
 		if has("conflict", "interrupt", "interruption", "boundary") && has("timer", "reminder") {
-			boost -= 12
+			boost += rule(-12)
 		}
 	}
 	if toolName == "cancel_timer" {
 		if countMatches("list", "active", "remaining", "show", "enumerate", "status") >= 2 &&
 			!has("cancel", "remove", "delete") {
-			boost -= 16
+			boost += rule(-16)
 		}
 		if countMatches("scheduler", "job", "recurring", "cadence", "checkin") >= 2 {
-			boost -= 16
+			boost += rule(-16)
 		}
 	}
 	if toolName == "search_file" {
 		if countMatches("memory", "history", "prior", "note", "notes", "recall", "habit", "preference") >= 2 {
-			boost -= 18
+			boost += rule(-18)
 		}
 		if countMatches("official", "rfc", "spec", "web", "source", "url", "reference") >= 2 {
-			boost -= 12
+			boost += rule(-12)
 		}
 		if countMatches("regex", "needle", "sweep", "repo", "fast", "quick") >= 2 {
-			boost -= 10
+			boost += rule(-10)
 		}
 		if countMatches("motivation", "pattern", "previous", "successful", "memory", "recall") >= 2 {
-			boost -= 12
+			boost += rule(-12)
 		}
 		if countMatches("decision", "policy", "preference", "history", "prior", "memory") >= 3 {
-			boost -= 12
+			boost += rule(-12)
 		}
 		if countMatches("filename", "path", "folder", "directory", "nested", "candidate", "before", "open") >= 3 &&
 			!has("content", "snippet", "inside", "within", "line", "lines") {
-			boost -= 16
+			boost += rule(-16)
 		}
 		if countMatches("persona", "soul", "interaction", "tone", "style", "habit", "preference", "memory") >= 3 &&
 			!has("file", "repo", "repository", "source", "code", "content", "search") {
-			boost -= 18
+			boost += rule(-18)
 		}
 		if countMatches("memory", "historical", "incident", "signature", "regression", "guardrail", "before", "patch") >= 3 &&
 			!has("file", "repo", "repository", "source", "code", "content", "search") {
-			boost -= 24
+			boost += rule(-24)
 		}
 		if countMatches("entrypoint", "entrypoints", "layer", "module", "package", "path", "folder", "directory") >= 3 &&
 			!has("inside", "content", "snippet", "line", "lines", "semantic") {
-			boost -= 12
+			boost += rule(-12)
 		}
 	}
 	if toolName == "browser_screenshot" {
 		if countMatches("authoritative", "canonical", "reference", "rfc", "official", "primary") >= 2 &&
 			!has("visual", "screenshot", "proof", "ui", "page") {
-			boost -= 16
+			boost += rule(-16)
 		}
 		if countMatches("url", "link", "ticket", "approved", "exact", "single", "source", "ingest") >= 3 &&
 			!has("visual", "screenshot", "proof", "ui", "page") {
-			boost -= 18
+			boost += rule(-18)
 		}
 		if countMatches("extract", "text", "content", "from", "url", "single", "exact", "source") >= 4 &&
 			!has("visual", "proof", "screenshot", "ui") {
-			boost -= 24
+			boost += rule(-24)
 		}
 		if countMatches("single", "approved", "link", "ingest", "only", "page", "source") >= 3 &&
 			!has("visual", "proof", "screenshot", "ui", "capture") {
-			boost -= 30
+			boost += rule(-30)
 		}
 	}
 	if toolName == "web_fetch" {
 		if countMatches("authoritative", "canonical", "reference", "discover", "shortlist") >= 2 &&
 			!has("fixed", "provided", "single", "exact", "url", "source") {
-			boost -= 12
+			boost += rule(-12)
 		}
 		if countMatches("fixed", "provided", "single", "exact", "url", "approved", "ticket", "source", "direct", "ingest") >= 3 {
-			boost += 16
+			boost += rule(16)
 		}
 		if countMatches("extract", "text", "content", "from", "url", "single", "exact", "source") >= 4 {
-			boost += 16
+			boost += rule(16)
 		}
 	}
 	if toolName == "web_search" {
 		if countMatches("exact", "single", "provided", "fixed", "one", "specific") >= 2 &&
 			has("url", "page") {
-			boost -= 10
+			boost += rule(-10)
 		}
 		if countMatches("approved", "ticket", "single", "exact", "url", "direct", "ingest") >= 3 {
-			boost -= 12
+			boost += rule(-12)
 		}
 		if countMatches("no", "search", "single", "exact", "url", "source") >= 4 {
-			boost -= 22
+			boost += rule(-22)
 		}
 		if countMatches("only", "source", "url", "avoid", "broad", "search", "discovery", "already", "chosen") >= 4 {
-			boost -= 24
+			boost += rule(-24)
 		}
 	}
 	if toolName == "replace_in_file" {
 		if countMatches("grep", "log", "logs", "filter", "pattern", "match", "line") >= 2 &&
 			!has("replace", "patch", "edit", "modify", "update") {
-			boost -= 14
+			boost += rule(-14)
 		}
 		if countMatches("enumerate", "outputs", "produced", "run", "choose", "files", "release", "reviewer") >= 3 &&
 			!has("replace", "patch", "edit", "modify", "update") {
-			boost -= 18
+			boost += rule(-18)
 		}
 		if countMatches("send", "message", "status", "checkpoint", "thread", "chat", "no", "file") >= 4 &&
 			!has("replace", "patch", "edit", "modify", "update") {
-			boost -= 22
+			boost += rule(-22)
 		}
 		if countMatches("thread", "status", "ping", "brief", "short", "no", "file", "transfer", "checkpoint") >= 4 &&
 			!has("replace", "patch", "edit", "modify", "update") {
-			boost -= 30
+			boost += rule(-30)
 		}
 		if countMatches("list", "directory", "directories", "tree", "workspace", "paths", "inventory") >= 3 &&
 			!has("replace", "patch", "edit", "modify", "update") {
-			boost -= 22
+			boost += rule(-22)
 		}
 		if countMatches("list", "directories", "files", "recursively", "before", "choosing", "target", "file") >= 4 &&
 			!has("replace", "patch", "edit", "modify", "update") {
-			boost -= 32
+			boost += rule(-32)
 		}
 	}
 	if toolName == "browser_action" {
 		if countMatches("state", "status", "metadata", "url", "tab", "session", "current", "info") >= 3 &&
 			!has("click", "drag", "coordinate", "canvas", "pixel", "tap") {
-			boost -= 18
+			boost += rule(-18)
 		}
 		if countMatches("read", "inspect", "state", "status", "metadata", "without", "interaction") >= 3 &&
 			!has("click", "drag", "coordinate", "canvas", "pixel", "tap", "type", "typing", "press") {
-			boost -= 28
+			boost += rule(-28)
 		}
 		if countMatches("artifact", "report", "progress", "progres", "proof", "deliverable", "summary") >= 2 &&
 			!has("click", "drag", "coordinate", "canvas", "pixel", "tap", "browser", "dom", "page", "ui") {
-			boost -= 16
+			boost += rule(-16)
 		}
 		if countMatches("momentum", "completed", "progress", "progres", "artifact", "durable", "action", "actions") >= 3 &&
 			!has("click", "drag", "coordinate", "canvas", "pixel", "tap", "browser", "dom", "page", "ui") {
-			boost -= 30
+			boost += rule(-30)
 		}
 		if countMatches("memory", "prior", "history", "habit", "persona", "sparse", "fact", "corpus", "note", "notes") >= 3 &&
 			!has("click", "drag", "coordinate", "canvas", "pixel", "tap", "browser", "dom", "page", "ui") {
-			boost -= 24
+			boost += rule(-24)
 		}
 	}
 	if toolName == "browser_dom" {
 		if countMatches("canvas", "coordinate", "pixel", "drag", "offset") >= 2 {
-			boost -= 12
+			boost += rule(-12)
 		}
 	}
 	if toolName == "artifacts_delete" {
 		if countMatches("scheduler", "job", "cron", "cadence", "run") >= 2 &&
 			!has("artifact", "artifacts", "manifest", "bundle") {
-			boost -= 14
+			boost += rule(-14)
 		}
 		if countMatches("legacy", "recurring", "automation", "automations", "job", "jobs", "cadence", "deprecation", "retired") >= 3 &&
 			!has("artifact", "artifacts", "manifest", "bundle", "output", "report") {
-			boost -= 20
+			boost += rule(-20)
 		}
 	}
 	if toolName == "write_file" {
 		if countMatches("enumerate", "list", "inspect", "audit", "show", "state", "current", "existing") >= 3 &&
 			!has("write", "create", "new", "save", "draft", "record") {
-			boost -= 20
+			boost += rule(-20)
 		}
 		if countMatches("scheduler", "recurring", "cadence", "jobs", "automation", "automations") >= 2 &&
 			!has("write", "create", "new", "save", "draft", "record", "runbook") {
-			boost -= 24
+			boost += rule(-24)
 		}
 		if countMatches("inspect", "current", "schedule", "state", "before", "change", "mutation", "frozen") >= 3 &&
 			!has("write", "create", "new", "save", "draft", "record", "runbook") {
-			boost -= 26
+			boost += rule(-26)
 		}
 	}
 	if toolName == "search_file" {
 		if countMatches("directory", "name", "constraint", "constraints", "before", "open") >= 3 &&
 			!has("content", "snippet", "inside", "within", "line", "lines") {
-			boost -= 14
+			boost += rule(-14)
 		}
 		if countMatches("entrypoint", "entrypoints", "layer", "module", "package", "path", "folder", "directory") >= 3 &&
 			!has("inside", "content", "snippet", "line", "lines", "semantic") {
-			boost -= 24
+			boost += rule(-24)
 		}
 		if countMatches("path", "topology", "directory", "folder", "narrow", "first", "before", "reading") >= 3 &&
 			!has("content", "inside", "snippet", "semantic", "line", "lines") {
-			boost -= 16
+			boost += rule(-16)
 		}
 	}
 	if toolName == "scheduler_create_job" {
 		if countMatches("violates", "policy", "remove", "removed", "not", "recreated", "recreate", "cadence", "recurring") >= 4 {
-			boost -= 24
+			boost += rule(-24)
 		}
 		if countMatches("violat", "policy", "remove", "not", "recreat", "cadence", "recurring") >= 4 {
-			boost -= 24
+			boost += rule(-24)
 		}
 	}
 	if toolName == "lark_calendar_query" || toolName == "lark_calendar_update" || toolName == "lark_calendar_create" {
 		if countMatches("scheduler", "recurring", "automation", "automations", "job", "jobs", "cadence") >= 2 &&
 			!has("calendar", "event", "meeting") {
-			boost -= 20
+			boost += rule(-20)
 		}
 		if countMatches("violat", "policy", "remove", "not", "recreat", "cadence", "recurring") >= 4 &&
 			!has("calendar", "event", "meeting") {
-			boost -= 24
+			boost += rule(-24)
 		}
 		if countMatches("legacy", "recurring", "automation", "retire", "retired", "obsolete", "schedule", "remove") >= 3 &&
 			!has("calendar", "event", "meeting") {
-			boost -= 28
+			boost += rule(-28)
 		}
 	}
 	if toolName == "video_generate" {
 		if countMatches("scheduler", "recurring", "automation", "automations", "job", "jobs", "cadence", "state", "inspect", "audit") >= 3 {
-			boost -= 32
+			boost += rule(-32)
 		}
 		if countMatches("enumerate", "outputs", "produced", "run", "choose", "files", "release", "reviewer") >= 3 {
-			boost -= 26
+			boost += rule(-26)
 		}
 	}
 	if toolName == "lark_task_manage" {
 		if countMatches("plan", "roadmap", "phase", "milestone", "strategy") >= 2 &&
 			!has("task", "owner", "due", "todo", "assign") {
-			boost -= 18
+			boost += rule(-18)
 		}
 		if countMatches("consent", "approval", "confirm", "external", "outreach", "sensitive") >= 2 &&
 			!has("task", "owner", "due", "todo", "assign", "batch", "update") {
-			boost -= 16
+			boost += rule(-16)
 		}
 		if countMatches("plan", "steps", "before", "mutation", "change", "apply", "fix", "execution") >= 3 &&
 			!has("task", "owner", "due", "todo", "assign", "batch", "update") {
-			boost -= 16
+			boost += rule(-16)
 		}
 		if countMatches("phased", "checklist", "rollback", "checkpoint", "reproduce", "patch", "verify", "gates") >= 3 &&
 			!has("task", "owner", "due", "todo", "assign", "batch", "update") {
-			boost -= 20
+			boost += rule(-20)
 		}
 		if countMatches("before", "task", "updates", "rollout", "phased", "milestones", "risk", "checkpoints") >= 4 &&
 			!has("task", "owner", "due", "todo", "assign", "batch", "update", "manage", "ticket") {
-			boost -= 22
+			boost += rule(-22)
 		}
 		if countMatches("release", "checklist", "milestones", "rollback", "checkpoint", "checkpoints") >= 3 &&
 			!has("task", "owner", "due", "todo", "assign", "batch", "update", "manage", "ticket") {
-			boost -= 30
+			boost += rule(-30)
 		}
 		if countMatches("operationalize", "tracked", "task", "item", "commitment", "work", "ticket") >= 3 {
-			boost += 22
+			boost += rule(22)
 		}
 	}
 	if toolName == "lark_send_message" {
 		if countMatches("file", "report", "package", "upload", "in", "thread") >= 3 &&
 			has("without", "plain", "status") {
-			boost -= 18
+			boost += rule(-18)
 		}
 		if countMatches("text", "status", "message", "checkpoint", "without", "file", "upload", "attachment") >= 4 {
-			boost += 20
+			boost += rule(20)
 		}
 		if countMatches("no", "file", "no", "upload", "status", "thread", "message") >= 4 {
-			boost += 24
+			boost += rule(24)
 		}
 		if countMatches("brief", "textual", "checkpoint", "status", "forbid", "forbids", "without", "upload", "file") >= 4 {
-			boost += 34
+			boost += rule(34)
 		}
 		if countMatches("avoid", "file", "transfer", "compact", "progress", "update", "thread") >= 4 {
-			boost += 26
+			boost += rule(26)
 		}
 		if countMatches("prior", "chat", "context", "thread", "history", "before", "replying") >= 3 &&
 			!has("send", "message", "update", "status", "notify", "broadcast") {
-			boost -= 30
+			boost += rule(-30)
 		}
 	}
 	if toolName == "lark_upload_file" {
 		if countMatches("review", "cannot", "proceed", "without", "generated", "report", "file", "thread", "deliver", "package") >= 4 {
-			boost += 20
+			boost += rule(20)
 		}
 		if countMatches("artifact", "artifacts", "report", "reusable", "downstream", "full", "deep", "dive") >= 3 &&
 			!has("upload", "attach", "thread", "chat", "lark", "conversation") {
-			boost -= 26
+			boost += rule(-26)
 		}
 		if countMatches("no", "file", "no", "upload", "status", "thread", "message") >= 4 {
-			boost -= 38
+			boost += rule(-38)
 		}
 		if countMatches("brief", "textual", "checkpoint", "status", "forbid", "forbids", "without", "upload", "file") >= 4 {
-			boost -= 56
+			boost += rule(-56)
 		}
 		if countMatches("avoid", "file", "transfer", "compact", "progress", "update", "thread") >= 4 {
-			boost -= 30
+			boost += rule(-30)
 		}
 	}
 	if toolName == "artifacts_write" {
 		if countMatches("artifacts_write", "artifact", "artifacts", "reusable", "downstream", "full", "report", "deep", "dive") >= 3 {
-			boost += 20
+			boost += rule(20)
 		}
 		if countMatches("list", "enumerate", "inventory", "existing", "latest", "valid", "release", "share", "outputs", "artifacts") >= 4 &&
 			!has("write", "create", "save", "new", "persist") {
-			boost -= 32
+			boost += rule(-32)
 		}
 	}
 	if toolName == "ask_user" {
 		if countMatches("read_file", "selected", "memory", "note", "open", "detail", "detailed", "guidance", "context") >= 3 &&
 			!has("unclear", "ambiguity", "clarify", "conflict", "missing") {
-			boost -= 24
+			boost += rule(-24)
 		}
 		if countMatches("read_file", "before", "action", "retrieve", "recall", "history") >= 3 &&
 			!has("unclear", "ambiguity", "clarify", "conflict", "missing") {
-			boost -= 18
+			boost += rule(-18)
 		}
 	}
 	if toolName == "search_file" {
 		if countMatches("find", "read_file", "ripgrep", "replace_in_file", "list_dir", "a2ui_emit", "artifacts_write") >= 2 &&
 			!has("content", "inside", "semantic", "snippet", "line", "lines") {
-			boost -= 16
+			boost += rule(-16)
 		}
 	}
 	if toolName == "find" {
 		if has("search_file") {
-			boost -= 14
+			boost += rule(-14)
 		}
 		if hasAll("find", "read_file") {
-			boost += 14
+			boost += rule(14)
 		}
 		if countMatches("path", "topology", "directory", "folder", "narrow", "first", "before", "reading", "open") >= 3 {
-			boost += 20
+			boost += rule(20)
 		}
 	}
 	if toolName == "read_file" {
 		if hasAll("find", "read_file") {
-			boost += 14
+			boost += rule(14)
 		}
 		if has("find") && has("ordered", "events", "sequential") {
-			boost += 10
+			boost += rule(10)
 		}
 		if countMatches("path", "topology", "directory", "folder", "narrow", "first", "before", "reading", "open") >= 3 {
-			boost -= 12
+			boost += rule(-12)
 		}
 	}
 	if toolName == "replace_in_file" {
 		if countMatches("new", "file", "not", "place", "inplace", "materialize", "generated") >= 4 {
-			boost -= 30
+			boost += rule(-30)
 		}
 		if has("write_file") && !has("replace_in_file", "patch", "replace") {
-			boost -= 16
+			boost += rule(-16)
 		}
 		if countMatches("multihop", "reference", "references", "chain", "authoritative", "statement", "resolve", "across", "files") >= 3 &&
 			!has("replace", "patch", "edit", "modify", "update", "fix") {
-			boost -= 28
+			boost += rule(-28)
 		}
 	}
 	if toolName == "write_file" {
 		if has("write_file") {
-			boost += 16
+			boost += rule(16)
 		}
 		if countMatches("new", "file", "not", "place", "inplace", "materialize", "generated") >= 4 {
-			boost += 18
+			boost += rule(18)
 		}
 	}
 	if toolName == "write_attachment" {
 		if countMatches("a2ui_emit", "artifacts_write") >= 2 && !has("downloadable", "handoff", "receiver", "attachment") {
-			boost -= 18
+			boost += rule(-18)
 		}
 		if countMatches("replace_in_file", "artifacts_write") >= 2 && !has("downloadable", "handoff", "receiver", "attachment") {
-			boost -= 16
+			boost += rule(-16)
 		}
 	}
 	if toolName == "lark_calendar_query" {
 		if countMatches("create", "new", "add", "schedule", "meeting", "meetings", "events") >= 3 {
-			boost -= 20
+			boost += rule(-20)
 		}
 	}
 	if toolName == "lark_task_manage" {
 		if has("ask_user") || countMatches("approval", "confirm", "consent", "manual", "before") >= 3 {
-			boost -= 18
+			boost += rule(-18)
 		}
 	}
 	if toolName == "ask_user" {
 		if has("ask_user") {
-			boost += 24
+			boost += rule(24)
 		}
 	}
 	if toolName == "shell_exec" {
 		if has("grep") && !has("script", "compute", "calculate", "python", "snippet") {
-			boost += 12
+			boost += rule(12)
 		}
 		if countMatches("run", "shell", "verification", "check", "checks", "after", "code", "change", "changes") >= 4 {
-			boost += 18
+			boost += rule(18)
 		}
 	}
 	if toolName == "plan" {
 		if countMatches("thread", "checkpoint", "message", "status", "textual", "short", "in", "stage", "reviewer") >= 3 &&
 			!has("milestone", "roadmap", "rollback", "phase", "phased", "strategy", "timeline") {
-			boost -= 24
+			boost += rule(-24)
 		}
 		if countMatches("calendar", "event", "meeting", "update", "shift") >= 3 &&
 			!has("milestone", "roadmap", "rollback", "phase", "phased", "strategy", "timeline") {
-			boost -= 28
+			boost += rule(-28)
 		}
 		if countMatches("multistep", "planning", "rollback", "checkpoints", "before", "execution") >= 3 &&
 			!has("ui", "browser", "click", "drag", "canvas") {
-			boost += 14
+			boost += rule(14)
 		}
 		if countMatches("legacy", "recurring", "automation", "retired", "policy", "remove", "obsolete", "schedule") >= 3 &&
 			!has("milestone", "roadmap", "rollback", "phase", "phased", "strategy", "timeline") {
-			boost -= 30
+			boost += rule(-30)
 		}
 	}
 	if toolName == "browser_action" {
 		if countMatches("multistep", "planning", "rollback", "checkpoints", "before", "execution") >= 3 &&
 			!has("ui", "browser", "click", "drag", "canvas", "coordinate", "pixel") {
-			boost -= 24
+			boost += rule(-24)
 		}
 	}
 	if toolName == "lark_calendar_update" {
 		if countMatches("update", "existing", "calendar", "event", "meeting", "shift", "minutes", "day", "timeline") >= 3 {
-			boost += 20
+			boost += rule(20)
 		}
 		if countMatches("register", "cadence", "identifier", "scheduler", "job", "recurring") >= 3 &&
 			!has("calendar", "event", "meeting") {
-			boost -= 20
+			boost += rule(-20)
 		}
 	}
 	if toolName == "scheduler_list_jobs" {
 		if countMatches("show", "current", "currently", "registered", "jobs", "execution", "cadence", "list") >= 3 {
-			boost += 18
+			boost += rule(18)
 		}
 	}
 	if toolName == "scheduler_create_job" {
 		if countMatches("show", "current", "currently", "registered", "jobs", "execution", "cadence", "list") >= 3 &&
 			!has("create", "new", "add") {
-			boost -= 22
+			boost += rule(-22)
 		}
 	}
 	if toolName == "ask_user" {
 		if countMatches("manual", "user", "confirmation", "before", "continuing", "continue", "cutover", "production") >= 4 {
-			boost += 18
+			boost += rule(18)
 		}
 	}
 	if toolName == "write_attachment" {
 		if countMatches("downloadable", "handoff", "receiver", "immediate", "deliver") >= 2 {
-			boost += 28
+			boost += rule(28)
 		}
 		if has("not") && countMatches("background", "storage", "persist", "persistence", "only") >= 2 {
-			boost += 18
+			boost += rule(18)
 		}
 		if countMatches("workspace", "local", "markdown", "file", "not", "attachment") >= 4 {
-			boost -= 44
+			boost += rule(-44)
 		}
 		if has("not", "no", "without") && has("attachment", "attach") {
-			boost -= 40
+			boost += rule(-40)
 		}
 	}
 	if toolName == "write_file" {
 		if countMatches("workspace", "local", "markdown", "file", "not", "attachment") >= 4 {
-			boost += 20
+			boost += rule(20)
 		}
 	}
 	if toolName == "scheduler_create_job" {
 		if countMatches("remove", "delete", "obsolete", "legacy", "retired", "deprecation", "old") >= 2 {
-			boost -= 18
+			boost += rule(-18)
 		}
 		if countMatches("audit", "inspect", "existing", "current", "before", "change", "policy", "cadence") >= 3 &&
 			!has("create", "new", "add") {
-			boost -= 14
+			boost += rule(-14)
 		}
 	}
 	if toolName == "scheduler_list_jobs" {
 		if countMatches("remove", "delete", "obsolete", "legacy", "retired", "deprecation") >= 2 &&
 			!has("list", "show", "inspect", "audit", "current", "existing", "before") {
-			boost -= 10
+			boost += rule(-10)
 		}
 	}
 	if toolName == "cancel_timer" {
 		if countMatches("calendar", "event", "meeting") >= 2 &&
 			!has("timer", "reminder", "alarm") {
-			boost -= 20
+			boost += rule(-20)
 		}
 	}
 	if toolName == "ask_user" {
 		if countMatches("requires", "require", "user", "approval", "confirm", "before", "publish", "continue") >= 4 {
-			boost += 16
+			boost += rule(16)
 		}
 	}
 	if hasAll("task", "delegate") && toolName == "acp_executor" {
-		boost += 8
+		boost += rule(8)
 	}
 	if toolName == "acp_executor" {
 		if countMatches("delegate", "executor", "subagent", "parallel", "heavy", "long", "deep") >= 2 {
-			boost += 16
+			boost += rule(16)
 		}
 	}
 	return boost
@@ -2361,6 +2453,10 @@ var stopwords = map[string]struct{}{
 	"on": {}, "with": {}, "from": {}, "by": {}, "is": {}, "are": {}, "this": {}, "that": {},
 	"it": {}, "as": {}, "be": {}, "at": {}, "into": {}, "under": {}, "all": {}, "current": {},
 	"need": {}, "needs": {}, "your": {}, "their": {}, "our": {}, "can": {}, "should": {},
+	// Chinese function-word bigrams.
+	"一个": {}, "这个": {}, "那个": {}, "这次": {}, "我们": {}, "你们": {}, "需要": {}, "然后": {},
+	"并且": {}, "或者": {}, "以及": {}, "之后": {}, "之前": {}, "可以": {}, "应该": {}, "哪些": {},
+	"已经": {}, "进行": {}, "一下": {}, "所有": {}, "当前": {}, "不要": {}, "的话": {}, "什么": {},
 }
 
 var tokenAliases = map[string]string{
@@ -2500,30 +2596,131 @@ var tokenAliases = map[string]string{
 	"reusable":      "artifact",
 	"durable":       "artifact",
 	"downstream":    "artifact",
+	// Chinese bigrams map onto the English vocabulary tool definitions use.
+	"计划": "plan",
+	"规划": "plan",
+	"拆分": "split",
+	"阶段": "phase",
+	"步骤": "step",
+	"里程": "milestone",
+	"检查": "check",
+	"风险": "risk",
+	"迁移": "migration",
+	"需求": "requirement",
+	"缺失": "missing",
+	"阻塞": "blocking",
+	"澄清": "clarify",
+	"问题": "question",
+	"询问": "ask",
+	"提问": "ask",
+	"确认": "confirmation",
+	"用户": "user",
+	"人工": "manual",
+	"手动": "manual",
+	"验证": "verification",
+	"账号": "account",
+	"登录": "login",
+	"继续": "continue",
+	"打开": "open",
+	"读取": "read",
+	"只读": "read",
+	"查看": "view",
+	"源码": "source",
+	"文件": "file",
+	"内容": "content",
+	"函数": "function",
+	"替换": "replace",
+	"废弃": "deprecated",
+	"接口": "endpoint",
+	"字符": "string",
+	"路径": "path",
+	"修改": "update",
+	"更新": "update",
+	"编辑": "edit",
+	"删除": "delete",
+	"创建": "create",
+	"新建": "create",
+	"写入": "write",
+	"保存": "save",
+	"生成": "generate",
+	"报告": "report",
+	"分析": "analysis",
+	"运行": "run",
+	"执行": "execute",
+	"命令": "command",
+	"脚本": "script",
+	"代码": "code",
+	"分支": "branch",
+	"状态": "status",
+	"终端": "terminal",
+	"项目": "project",
+	"目录": "directory",
+	"结构": "structure",
+	"汇报": "report",
+	"列出": "list",
+	"快速": "quick",
+	"搜索": "search",
+	"查找": "search",
+	"查询": "query",
+	"官方": "official",
+	"一手": "primary",
+	"文档": "docs",
+	"资料": "reference",
+	"引用": "reference",
+	"页面": "page",
+	"网页": "webpage",
+	"网站": "website",
+	"链接": "url",
+	"最新": "newest",
+	"摘要": "summary",
+	"博客": "blog",
+	"厂商": "vendor",
+	"下载": "download",
+	"上传": "upload",
+	"发送": "send",
+	"消息": "message",
+	"日历": "calendar",
+	"会议": "meeting",
+	"任务": "task",
+	"提醒": "reminder",
+	"记忆": "memory",
+	"截图": "screenshot",
+	"浏览": "browser",
+	"点击": "click",
+	"表单": "form",
 }
 
+// tokenize splits value into lowercase word tokens, breaking on script
+// boundaries so mixed text like "markdown报告" separates cleanly. Han runs have
+// no word delimiters and are emitted as overlapping character bigrams.
 func tokenize(value string) []string {
 	value = strings.ToLower(value)
 	tokens := make([]string, 0, 24)
-	var sb strings.Builder
+	var run []rune
+	runHan := false
 	flush := func() {
-		if sb.Len() == 0 {
+		if len(run) == 0 {
 			return
 		}
-		token := sb.String()
-		sb.Reset()
-		if token == "" {
-			return
+		if runHan {
+			tokens = append(tokens, hanBigrams(run)...)
+		} else {
+			tokens = append(tokens, string(run))
 		}
-		tokens = append(tokens, token)
+		run = run[:0]
 	}
 
 	for _, r := range value {
-		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' {
-			sb.WriteRune(r)
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' {
+			flush()
 			continue
 		}
-		flush()
+		isHan := unicode.Is(unicode.Han, r)
+		if len(run) > 0 && isHan != runHan {
+			flush()
+		}
+		runHan = isHan
+		run = append(run, r)
 	}
 	flush()
 
@@ -2543,6 +2740,19 @@ func tokenize(value string) []string {
 	return result
 }
 
+// hanBigrams returns the overlapping two-character windows of a Han run; a
+// single character is returned as is.
+func hanBigrams(run []rune) []string {
+	if len(run) == 1 {
+		return []string{string(run)}
+	}
+	bigrams := make([]string, 0, len(run)-1)
+	for i := 0; i+1 < len(run); i++ {
+		bigrams = append(bigrams, string(run[i:i+2]))
+	}
+	return bigrams
+}
+
 func normalizeToken(token string) string {
 	token = strings.ToLower(strings.TrimSpace(token))
 	token = strings.Trim(token, "_")
@@ -2650,6 +2860,15 @@ func percentileFloat(values []float64, percentile float64) float64 {
 	return sorted[low]*(1-weight) + sorted[high]*weight
 }
 
+// marshalFoundationResult encodes the foundation_result JSON artifact.
+func marshalFoundationResult(result *FoundationEvaluationResult) ([]byte, error) {
+	data, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshal foundation result: %w", err)
+	}
+	return data, nil
+}
+
 func writeFoundationArtifacts(result *FoundationEvaluationResult, outputDir, format string) ([]EvaluationArtifact, error) {
 	cleanedOutputDir, err := sanitizeOutputPath(defaultOutputBaseDir, outputDir)
 	if err != nil {
@@ -2662,9 +2881,9 @@ func writeFoundationArtifacts(result *FoundationEvaluationResult, outputDir, for
 	artifacts := make([]EvaluationArtifact, 0, 2)
 
 	jsonPath := filepath.Join(cleanedOutputDir, fmt.Sprintf("foundation_result_%s.json", result.RunID))
-	data, err := json.MarshalIndent(result, "", "  ")
+	data, err := marshalFoundationResult(result)
 	if err != nil {
-		return nil, fmt.Errorf("marshal foundation result: %w", err)
+		return nil, err
 	}
 	if err := os.WriteFile(jsonPath, data, 0644); err != nil {
 		return nil, fmt.Errorf("write foundation json: %w", err)
@@ -158,7 +158,7 @@ func TestEvaluateImplicitCasesComputesTopK(t *testing.T) {
 		},
 	}
 
-	summary := evaluateImplicitCases(scenarios, profiles, 2)
+	summary := evaluateImplicitCases(scenarios, profiles, 2, lexicalToolRanker{profiles: profiles})
 	if summary.TotalCases != 2 {
 		t.Fatalf("unexpected total cases: %d", summary.TotalCases)
 	}
@@ -191,7 +191,7 @@ func TestEvaluateImplicitCasesAvailabilityMarkedNA(t *testing.T) {
 		},
 	}
 
-	summary := evaluateImplicitCases(scenarios, profiles, 3)
+	summary := evaluateImplicitCases(scenarios, profiles, 3, lexicalToolRanker{profiles: profiles})
 	if summary.TotalCases != 1 {
 		t.Fatalf("expected total cases 1, got %d", summary.TotalCases)
 	}
@@ -247,7 +247,7 @@ func TestEvaluateImplicitCasesComputesDeliverableChecks(t *testing.T) {
 		},
 	}
 
-	summary := evaluateImplicitCases(scenarios, profiles, 3)
+	summary := evaluateImplicitCases(scenarios, profiles, 3, lexicalToolRanker{profiles: profiles})
 	if len(summary.CaseResults) != 1 {
 		t.Fatalf("expected single case result, got %d", len(summary.CaseResults))
 	}
@@ -446,7 +446,7 @@ func TestEvaluateImplicitCasesMarksAvailabilityAsNotApplicable(t *testing.T) {
 		},
 	}
 
-	summary := evaluateImplicitCases(scenarios, profiles, 3)
+	summary := evaluateImplicitCases(scenarios, profiles, 3, lexicalToolRanker{profiles: profiles})
 	if summary.TotalCases != 1 || summary.NotApplicableCases != 1 || summary.FailedCases != 0 {
 		t.Fatalf("expected one N/A case and zero failed cases, got %+v", summary)
 	}
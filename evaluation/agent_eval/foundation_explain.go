@@ -0,0 +1,256 @@
+package agent_eval
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// FoundationCaseExplanation is the full ranking of one scenario with the
+// score contributions behind each tool's position.
+type FoundationCaseExplanation struct {
+	CaseID        string                      `json:"case_id"`
+	Category      string                      `json:"category"`
+	Intent        string                      `json:"intent"`
+	IntentTokens  []string                    `json:"intent_tokens"`
+	ExpectedTools []string                    `json:"expected_tools"`
+	Ranker        string                      `json:"ranker"`
+	HitRank       int                         `json:"hit_rank"`
+	Tools         []FoundationToolExplanation `json:"tools"`
+}
+
+// FoundationToolExplanation breaks one tool's score into token and rule
+// contributions.
+type FoundationToolExplanation struct {
+	Rank           int                         `json:"rank"`
+	Name           string                      `json:"name"`
+	Score          float64                     `json:"score"`
+	Expected       bool                        `json:"expected,omitempty"`
+	TokenScores    []FoundationTokenScore      `json:"token_scores,omitempty"`
+	HeuristicBoost float64                     `json:"heuristic_boost,omitempty"`
+	BoostRules     []FoundationBoostRuleResult `json:"boost_rules,omitempty"`
+}
+
+// FoundationTokenScore is what one matched intent token added to a tool's score.
+type FoundationTokenScore struct {
+	Token string  `json:"token"`
+	Score float64 `json:"score"`
+}
+
+// FoundationBoostRuleResult is one heuristic rule that fired, identified by
+// its source line in heuristicIntentBoost.
+type FoundationBoostRuleResult struct {
+	Rule  string  `json:"rule"`
+	Boost float64 `json:"boost"`
+}
+
+// explainingToolRanker is implemented by rankers that can attribute their
+// scores; both built-in rankers do.
+type explainingToolRanker interface {
+	ToolRanker
+	explain(intentTokens []string) []FoundationToolExplanation
+}
+
+// ExplainFoundationCase ranks every tool for one scenario and reports the
+// per-token and per-rule contributions to each score. Only the mode,
+// preset, toolset, cases path and ranker options are used.
+func ExplainFoundationCase(ctx context.Context, options *FoundationEvaluationOptions, caseID string) (*FoundationCaseExplanation, error) {
+	if options == nil {
+		options = DefaultFoundationEvaluationOptions()
+	}
+	defaults := DefaultFoundationEvaluationOptions()
+	opts := *options
+	if strings.TrimSpace(opts.Mode) == "" {
+		opts.Mode = defaults.Mode
+	}
+	if strings.TrimSpace(opts.Preset) == "" {
+		opts.Preset = defaults.Preset
+	}
+	if strings.TrimSpace(opts.CasesPath) == "" {
+		opts.CasesPath = defaultFoundationCasesPath
+	}
+	mode := normalizeFoundationMode(opts.Mode)
+
+	caseSet, err := LoadFoundationCaseSet(opts.CasesPath)
+	if err != nil {
+		return nil, err
+	}
+	var scenario *FoundationScenario
+	for i := range caseSet.Scenarios {
+		if caseSet.Scenarios[i].ID == strings.TrimSpace(caseID) {
+			scenario = &caseSet.Scenarios[i]
+			break
+		}
+	}
+	if scenario == nil {
+		return nil, fmt.Errorf("foundation case %q not found in %s", caseID, opts.CasesPath)
+	}
+
+	profiles, err := collectToolProfiles(ctx, mode, opts.Preset, opts.Toolset)
+	if err != nil {
+		return nil, err
+	}
+	ranker, err := newToolRanker(opts.RankerStrategy, profiles)
+	if err != nil {
+		return nil, err
+	}
+	return explainScenario(*scenario, ranker)
+}
+
+func explainScenario(scenario FoundationScenario, ranker ToolRanker) (*FoundationCaseExplanation, error) {
+	explainer, ok := ranker.(explainingToolRanker)
+	if !ok {
+		return nil, fmt.Errorf("ranker %s cannot explain scores", ranker.Name())
+	}
+	intentTokens := tokenize(scenario.Intent)
+	tools := explainer.explain(intentTokens)
+
+	expected := make(map[string]struct{}, len(scenario.ExpectedTools))
+	for _, name := range scenario.ExpectedTools {
+		expected[name] = struct{}{}
+	}
+	hitRank := 0
+	for i := range tools {
+		tools[i].Rank = i + 1
+		if _, ok := expected[tools[i].Name]; ok {
+			tools[i].Expected = true
+			if hitRank == 0 && tools[i].Score > 0 {
+				hitRank = i + 1
+			}
+		}
+	}
+
+	return &FoundationCaseExplanation{
+		CaseID:        scenario.ID,
+		Category:      scenario.Category,
+		Intent:        scenario.Intent,
+		IntentTokens:  sortedTokenSet(intentTokens),
+		ExpectedTools: append([]string(nil), scenario.ExpectedTools...),
+		Ranker:        ranker.Name(),
+		HitRank:       hitRank,
+		Tools:         tools,
+	}, nil
+}
+
+func (r lexicalToolRanker) explain(intentTokens []string) []FoundationToolExplanation {
+	tokenSet := normalizedTokenSet(intentTokens)
+	tokens := sortedTokenSet(intentTokens)
+	tools := make([]FoundationToolExplanation, 0, len(r.profiles))
+	for _, profile := range r.profiles {
+		tool := FoundationToolExplanation{Name: profile.Definition.Name}
+		score := 0.0
+		for _, token := range tokens {
+			if weight := profile.TokenWeights[token]; weight != 0 {
+				tool.TokenScores = append(tool.TokenScores, FoundationTokenScore{Token: token, Score: round2(weight)})
+				score += weight
+			}
+		}
+		boost := tracedIntentBoost(profile.Definition.Name, tokenSet, func(line int, delta float64) {
+			tool.BoostRules = append(tool.BoostRules, FoundationBoostRuleResult{
+				Rule:  fmt.Sprintf("foundation_eval.go:%d", line),
+				Boost: delta,
+			})
+		})
+		tool.HeuristicBoost = round2(boost)
+		tool.Score = round2(score + boost)
+		tools = append(tools, tool)
+	}
+	sortToolExplanations(tools)
+	return tools
+}
+
+func (r *bm25ToolRanker) explain(intentTokens []string) []FoundationToolExplanation {
+	tokens := sortedTokenSet(intentTokens)
+	tools := make([]FoundationToolExplanation, 0, len(r.profiles))
+	for i, profile := range r.profiles {
+		tool := FoundationToolExplanation{Name: profile.Definition.Name}
+		score := 0.0
+		for _, token := range tokens {
+			if contribution := r.termScore(i, token); contribution > 0 {
+				tool.TokenScores = append(tool.TokenScores, FoundationTokenScore{Token: token, Score: round2(contribution)})
+				score += contribution
+			}
+		}
+		tool.Score = round2(score)
+		tools = append(tools, tool)
+	}
+	sortToolExplanations(tools)
+	return tools
+}
+
+// sortToolExplanations orders explanations exactly like sortToolMatches.
+func sortToolExplanations(tools []FoundationToolExplanation) {
+	sort.Slice(tools, func(i, j int) bool {
+		if tools[i].Score == tools[j].Score {
+			return tools[i].Name < tools[j].Name
+		}
+		return tools[i].Score > tools[j].Score
+	})
+}
+
+func sortedTokenSet(tokens []string) []string {
+	set := normalizedTokenSet(tokens)
+	sorted := make([]string, 0, len(set))
+	for token := range set {
+		sorted = append(sorted, token)
+	}
+	sort.Strings(sorted)
+	return sorted
+}
+
+// filterFoundationScenarios keeps the scenarios selected by the case ID,
+// category and failed-only options. Filters combine; an empty filter keeps
+// everything.
+func filterFoundationScenarios(scenarios []FoundationScenario, opts FoundationEvaluationOptions) ([]FoundationScenario, error) {
+	caseIDs := stringSet(opts.CaseIDs)
+	categories := stringSet(opts.Categories)
+	var failedIDs map[string]struct{}
+	if strings.TrimSpace(opts.FailedOnlyFrom) != "" {
+		prior, err := loadFoundationBaseline(opts.FailedOnlyFrom)
+		if err != nil {
+			return nil, err
+		}
+		failedIDs = make(map[string]struct{})
+		for _, c := range prior.Implicit.CaseResults {
+			if !c.Passed && !c.NotApplicable {
+				failedIDs[c.ID] = struct{}{}
+			}
+		}
+	}
+	if len(caseIDs) == 0 && len(categories) == 0 && failedIDs == nil {
+		return scenarios, nil
+	}
+
+	filtered := make([]FoundationScenario, 0, len(scenarios))
+	for _, scenario := range scenarios {
+		if len(caseIDs) > 0 {
+			if _, ok := caseIDs[scenario.ID]; !ok {
+				continue
+			}
+		}
+		if len(categories) > 0 {
+			if _, ok := categories[strings.TrimSpace(scenario.Category)]; !ok {
+				continue
+			}
+		}
+		if failedIDs != nil {
+			if _, ok := failedIDs[scenario.ID]; !ok {
+				continue
+			}
+		}
+		filtered = append(filtered, scenario)
+	}
+	if len(filtered) == 0 {
+		return nil, fmt.Errorf("no foundation scenarios match the case filters")
+	}
+	return filtered, nil
+}
+
+func stringSet(values []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(values))
+	for _, value := range uniqueNonEmptyStrings(values) {
+		set[strings.TrimSpace(value)] = struct{}{}
+	}
+	return set
+}
@@ -0,0 +1,143 @@
+package agent_eval
+
+import (
+	"encoding/json"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	ports "alex/internal/domain/agent/ports"
+)
+
+func TestFilterFoundationScenarios(t *testing.T) {
+	t.Parallel()
+
+	scenarios := []FoundationScenario{
+		{ID: "a", Category: "files"},
+		{ID: "b", Category: "files"},
+		{ID: "c", Category: "web"},
+	}
+	prior := FoundationEvaluationResult{Implicit: FoundationImplicitSummary{CaseResults: []FoundationCaseResult{
+		{ID: "a", Passed: true},
+		{ID: "b", Passed: false},
+		{ID: "c", Passed: false},
+	}}}
+	data, err := json.Marshal(prior)
+	if err != nil {
+		t.Fatalf("marshal prior: %v", err)
+	}
+	priorPath := filepath.Join(t.TempDir(), "prior.json")
+	if err := os.WriteFile(priorPath, data, 0644); err != nil {
+		t.Fatalf("write prior: %v", err)
+	}
+
+	ids := func(list []FoundationScenario) string {
+		out := make([]string, 0, len(list))
+		for _, s := range list {
+			out = append(out, s.ID)
+		}
+		return strings.Join(out, ",")
+	}
+	tests := []struct {
+		name string
+		opts FoundationEvaluationOptions
+		want string
+	}{
+		{"no filters", FoundationEvaluationOptions{}, "a,b,c"},
+		{"case ids", FoundationEvaluationOptions{CaseIDs: []string{" c ", "a"}}, "a,c"},
+		{"category", FoundationEvaluationOptions{Categories: []string{"files"}}, "a,b"},
+		{"failed only", FoundationEvaluationOptions{FailedOnlyFrom: priorPath}, "b,c"},
+		{"combined", FoundationEvaluationOptions{FailedOnlyFrom: priorPath, Categories: []string{"files"}}, "b"},
+	}
+	for _, tt := range tests {
+		got, err := filterFoundationScenarios(scenarios, tt.opts)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tt.name, err)
+		}
+		if ids(got) != tt.want {
+			t.Fatalf("%s: got %s, want %s", tt.name, ids(got), tt.want)
+		}
+	}
+
+	if _, err := filterFoundationScenarios(scenarios, FoundationEvaluationOptions{CaseIDs: []string{"missing"}}); err == nil {
+		t.Fatal("expected error when no scenario matches")
+	}
+}
+
+func TestExplainScenarioMatchesRanking(t *testing.T) {
+	t.Parallel()
+
+	profiles := []foundationToolProfile{
+		{Definition: ports.ToolDefinition{Name: "plan"}, TokenWeights: map[string]float64{"plan": 6, "milestone": 4}},
+		{Definition: ports.ToolDefinition{Name: "lark_task_manage"}, TokenWeights: map[string]float64{"task": 6, "plan": 2}},
+		{Definition: ports.ToolDefinition{Name: "web_search"}, TokenWeights: map[string]float64{"search": 4}},
+	}
+	scenario := FoundationScenario{
+		ID:            "plan-case",
+		Category:      "planning",
+		Intent:        "Plan the migration with milestones, risks and checkpoints before task updates.",
+		ExpectedTools: []string{"plan"},
+	}
+
+	for _, strategy := range []string{RankerStrategyLexical, RankerStrategyBM25} {
+		ranker, err := newToolRanker(strategy, profiles)
+		if err != nil {
+			t.Fatalf("newToolRanker(%s): %v", strategy, err)
+		}
+		exp, err := explainScenario(scenario, ranker)
+		if err != nil {
+			t.Fatalf("explainScenario(%s): %v", strategy, err)
+		}
+		ranked := ranker.Rank(tokenize(scenario.Intent))
+		if len(exp.Tools) != len(ranked) {
+			t.Fatalf("%s: expected %d tools, got %d", strategy, len(ranked), len(exp.Tools))
+		}
+		for i, match := range ranked {
+			if exp.Tools[i].Name != match.Name || exp.Tools[i].Score != match.Score || exp.Tools[i].Rank != i+1 {
+				t.Fatalf("%s: explanation %d = %+v, ranking = %+v", strategy, i, exp.Tools[i], match)
+			}
+		}
+		hitRank := 0
+		for i, match := range ranked {
+			if match.Name == "plan" {
+				hitRank = i + 1
+			}
+		}
+		if exp.HitRank != hitRank || !exp.Tools[hitRank-1].Expected {
+			t.Fatalf("%s: expected plan hit at #%d, got %+v", strategy, hitRank, exp)
+		}
+	}
+}
+
+func TestLexicalExplanationAttributesBoostRules(t *testing.T) {
+	t.Parallel()
+
+	profiles := []foundationToolProfile{
+		{Definition: ports.ToolDefinition{Name: "plan"}, TokenWeights: map[string]float64{"plan": 6}},
+	}
+	tokens := tokenize("release checklist with milestones, rollback and checkpoints")
+	tools := lexicalToolRanker{profiles: profiles}.explain(tokens)
+
+	plan := tools[0]
+	if len(plan.BoostRules) == 0 {
+		t.Fatalf("expected fired boost rules for plan, got %+v", plan)
+	}
+	sum := 0.0
+	for _, rule := range plan.BoostRules {
+		if !strings.HasPrefix(rule.Rule, "foundation_eval.go:") {
+			t.Fatalf("expected rule source location, got %q", rule.Rule)
+		}
+		sum += rule.Boost
+	}
+	if math.Abs(sum-plan.HeuristicBoost) > 0.01 {
+		t.Fatalf("rule boosts sum to %.2f, heuristic boost is %.2f", sum, plan.HeuristicBoost)
+	}
+	if want := heuristicIntentBoost("plan", normalizedTokenSet(tokens)); math.Abs(want-plan.HeuristicBoost) > 0.01 {
+		t.Fatalf("traced boost %.2f differs from heuristicIntentBoost %.2f", plan.HeuristicBoost, want)
+	}
+	if out := FormatFoundationCaseExplanation(&FoundationCaseExplanation{CaseID: "x", Tools: tools}); !strings.Contains(out, "rule foundation_eval.go:") {
+		t.Fatalf("expected rule lines in formatted output, got:\n%s", out)
+	}
+}
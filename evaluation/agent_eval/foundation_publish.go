@@ -0,0 +1,91 @@
+package agent_eval
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	coreerrors "alex/internal/core/errors"
+	alexerrors "alex/internal/shared/errors"
+	"alex/internal/shared/httpclient"
+)
+
+const (
+	// FoundationPublishTokenEnv holds the bearer token sent when publishing
+	// foundation results to the eval-server.
+	FoundationPublishTokenEnv = "ALEX_EVAL_SERVER_TOKEN"
+
+	foundationIngestPath         = "/api/foundation-runs"
+	foundationPublishTimeout     = 30 * time.Second
+	maxFoundationPublishRespBody = 64 << 10
+)
+
+// foundationPublishRetry is a variable so tests can shorten the backoff.
+var foundationPublishRetry = alexerrors.RetryConfig{
+	MaxAttempts:  3,
+	BaseDelay:    time.Second,
+	MaxDelay:     10 * time.Second,
+	JitterFactor: 0.25,
+}
+
+// publishFoundationResult POSTs result to the eval-server at baseURL.
+// Network failures, 429 and 5xx responses are retried with backoff; any
+// other rejection fails immediately with the server's error message.
+func publishFoundationResult(ctx context.Context, baseURL string, result *FoundationEvaluationResult) error {
+	endpoint := strings.TrimRight(strings.TrimSpace(baseURL), "/") + foundationIngestPath
+	payload, err := json.Marshal(result)
+	if err != nil {
+		return fmt.Errorf("marshal foundation result: %w", err)
+	}
+	token := strings.TrimSpace(os.Getenv(FoundationPublishTokenEnv))
+	client := &http.Client{Timeout: foundationPublishTimeout}
+
+	return alexerrors.Retry(ctx, foundationPublishRetry, func(ctx context.Context) error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(payload))
+		if err != nil {
+			return coreerrors.NewPermanentError(err, fmt.Sprintf("invalid publish URL %q: %v", endpoint, err))
+		}
+		req.Header.Set("Content-Type", "application/json")
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return coreerrors.NewTransientError(err, fmt.Sprintf("eval-server unreachable: %v", err))
+		}
+		defer resp.Body.Close()
+		body, _ := httpclient.ReadAllWithLimit(resp.Body, maxFoundationPublishRespBody)
+		_, _ = io.Copy(io.Discard, resp.Body)
+
+		if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+			return nil
+		}
+		rejection := fmt.Errorf("eval-server rejected foundation result %s (%d): %s", result.RunID, resp.StatusCode, foundationPublishErrorMessage(body))
+		if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500 {
+			return coreerrors.NewTransientError(rejection, rejection.Error())
+		}
+		return coreerrors.NewPermanentError(rejection, rejection.Error())
+	})
+}
+
+// foundationPublishErrorMessage extracts the {"error": "..."} message the
+// eval-server returns, falling back to the raw body.
+func foundationPublishErrorMessage(body []byte) string {
+	var parsed struct {
+		Error string `json:"error"`
+	}
+	if err := json.Unmarshal(body, &parsed); err == nil && strings.TrimSpace(parsed.Error) != "" {
+		return strings.TrimSpace(parsed.Error)
+	}
+	if text := strings.TrimSpace(string(body)); text != "" {
+		return text
+	}
+	return "empty response"
+}
@@ -0,0 +1,110 @@
+package agent_eval
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func shortenFoundationPublishRetry(t *testing.T) {
+	t.Helper()
+	previous := foundationPublishRetry
+	foundationPublishRetry.BaseDelay = time.Millisecond
+	foundationPublishRetry.MaxDelay = time.Millisecond
+	t.Cleanup(func() { foundationPublishRetry = previous })
+}
+
+func TestPublishFoundationResultRetriesTransientFailures(t *testing.T) {
+	shortenFoundationPublishRetry(t)
+	t.Setenv(FoundationPublishTokenEnv, "secret")
+
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != foundationIngestPath {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer secret" {
+			t.Errorf("Authorization = %q", got)
+		}
+		if attempts.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		var result FoundationEvaluationResult
+		if err := json.NewDecoder(r.Body).Decode(&result); err != nil || result.RunID != "run-1" {
+			t.Errorf("unexpected payload: %+v (%v)", result, err)
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	err := publishFoundationResult(context.Background(), server.URL+"/", &FoundationEvaluationResult{RunID: "run-1"})
+	if err != nil {
+		t.Fatalf("publish: %v", err)
+	}
+	if got := attempts.Load(); got != 2 {
+		t.Fatalf("expected 2 attempts, got %d", got)
+	}
+}
+
+func TestPublishFoundationResultFailsFastOnRejection(t *testing.T) {
+	shortenFoundationPublishRetry(t)
+
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		attempts.Add(1)
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"error":"Invalid or missing ingest token"}`))
+	}))
+	defer server.Close()
+
+	err := publishFoundationResult(context.Background(), server.URL, &FoundationEvaluationResult{RunID: "run-1"})
+	if err == nil {
+		t.Fatal("expected rejection error")
+	}
+	if !strings.Contains(err.Error(), "rejected foundation result run-1 (401): Invalid or missing ingest token") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := attempts.Load(); got != 1 {
+		t.Fatalf("expected a single attempt, got %d", got)
+	}
+}
+
+func TestFoundationRunStoreListsNewestFirst(t *testing.T) {
+	t.Parallel()
+
+	store := NewFoundationRunStore(t.TempDir())
+	older := &FoundationEvaluationResult{RunID: "old", GeneratedAt: time.Unix(100, 0).UTC(), OverallScore: 80}
+	newer := &FoundationEvaluationResult{RunID: "new", GeneratedAt: time.Unix(200, 0).UTC(), OverallScore: 90}
+	newer.Implicit.PassAt1Rate = 0.75
+	newer.Implicit.PassAt5Rate = 0.9
+	for _, result := range []*FoundationEvaluationResult{older, newer} {
+		if err := store.Save(result); err != nil {
+			t.Fatalf("save %s: %v", result.RunID, err)
+		}
+	}
+	if err := store.Save(&FoundationEvaluationResult{RunID: "../escape"}); err == nil {
+		t.Fatal("expected unsafe run id to be rejected")
+	}
+
+	runs, err := store.List()
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if len(runs) != 2 || runs[0].RunID != "new" || runs[1].RunID != "old" {
+		t.Fatalf("unexpected run order: %+v", runs)
+	}
+	if runs[0].OverallScore != 90 || runs[0].PassAt1Rate != 0.75 || runs[0].PassAt5Rate != 0.9 {
+		t.Fatalf("unexpected summary: %+v", runs[0])
+	}
+
+	got, err := store.Get("old")
+	if err != nil || got.OverallScore != 80 {
+		t.Fatalf("get old: %+v (%v)", got, err)
+	}
+}
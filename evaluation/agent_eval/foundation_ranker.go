@@ -0,0 +1,213 @@
+package agent_eval
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+const (
+	// RankerStrategyLexical sums intent-token weights and applies the
+	// hand-tuned per-tool intent boosts.
+	RankerStrategyLexical = "lexical"
+	// RankerStrategyBM25 scores tools with Okapi BM25 over their token
+	// weights; it uses no per-tool rules.
+	RankerStrategyBM25 = "bm25"
+
+	defaultRankerStrategy = RankerStrategyLexical
+
+	bm25K1 = 1.2
+	bm25B  = 0.75
+)
+
+// ToolRanker orders the available tools for one implicit-intent scenario.
+type ToolRanker interface {
+	// Name returns the strategy name recorded in results.
+	Name() string
+	// Rank scores every tool against the intent tokens, best match first.
+	Rank(intentTokens []string) []FoundationToolMatch
+}
+
+// FoundationRankerComparison contrasts two rankers on the same scenarios.
+type FoundationRankerComparison struct {
+	Baseline     FoundationRankerMetrics    `json:"baseline"`
+	Candidate    FoundationRankerMetrics    `json:"candidate"`
+	Improved     int                        `json:"improved"`
+	Regressed    int                        `json:"regressed"`
+	Unchanged    int                        `json:"unchanged"`
+	ChangedCases []FoundationRankerCaseDiff `json:"changed_cases,omitempty"`
+}
+
+// FoundationRankerMetrics summarizes one ranker's implicit-case scores.
+type FoundationRankerMetrics struct {
+	Ranker          string  `json:"ranker"`
+	ApplicableCases int     `json:"applicable_cases"`
+	PassedCases     int     `json:"passed_cases"`
+	PassAt1Rate     float64 `json:"pass_at_1_rate"`
+	PassAt5Rate     float64 `json:"pass_at_5_rate"`
+	TopKHitRate     float64 `json:"topk_hit_rate"`
+	MRR             float64 `json:"mrr"`
+}
+
+// FoundationRankerCaseDiff is one scenario whose hit rank differs between
+// the two rankers. A zero rank means the expected tool was not matched.
+type FoundationRankerCaseDiff struct {
+	ID               string `json:"id"`
+	BaselineHitRank  int    `json:"baseline_hit_rank"`
+	CandidateHitRank int    `json:"candidate_hit_rank"`
+}
+
+// newToolRanker builds the ranker for strategy over the given tool profiles.
+func newToolRanker(strategy string, profiles []foundationToolProfile) (ToolRanker, error) {
+	switch normalizeRankerStrategy(strategy) {
+	case RankerStrategyLexical:
+		return lexicalToolRanker{profiles: profiles}, nil
+	case RankerStrategyBM25:
+		return newBM25ToolRanker(profiles), nil
+	default:
+		return nil, fmt.Errorf("unsupported ranker strategy: %s", strategy)
+	}
+}
+
+func normalizeRankerStrategy(strategy string) string {
+	strategy = strings.ToLower(strings.TrimSpace(strategy))
+	if strategy == "" {
+		return defaultRankerStrategy
+	}
+	return strategy
+}
+
+// lexicalToolRanker is the original ranking: summed token weights plus
+// heuristicIntentBoost.
+type lexicalToolRanker struct {
+	profiles []foundationToolProfile
+}
+
+func (r lexicalToolRanker) Name() string { return RankerStrategyLexical }
+
+func (r lexicalToolRanker) Rank(intentTokens []string) []FoundationToolMatch {
+	return rankToolsForIntent(intentTokens, r.profiles)
+}
+
+// bm25ToolRanker treats each tool's TokenWeights as a document whose term
+// frequencies are the field-weighted token counts.
+type bm25ToolRanker struct {
+	profiles  []foundationToolProfile
+	idf       map[string]float64
+	docLength []float64
+	avgLength float64
+}
+
+func newBM25ToolRanker(profiles []foundationToolProfile) *bm25ToolRanker {
+	docFreq := make(map[string]int)
+	docLength := make([]float64, len(profiles))
+	total := 0.0
+	for i, profile := range profiles {
+		for token, weight := range profile.TokenWeights {
+			if weight <= 0 {
+				continue
+			}
+			docFreq[token]++
+			docLength[i] += weight
+		}
+		total += docLength[i]
+	}
+
+	n := float64(len(profiles))
+	idf := make(map[string]float64, len(docFreq))
+	for token, df := range docFreq {
+		idf[token] = math.Log(1 + (n-float64(df)+0.5)/(float64(df)+0.5))
+	}
+	avgLength := 0.0
+	if len(profiles) > 0 {
+		avgLength = total / n
+	}
+	return &bm25ToolRanker{profiles: profiles, idf: idf, docLength: docLength, avgLength: avgLength}
+}
+
+func (r *bm25ToolRanker) Name() string { return RankerStrategyBM25 }
+
+func (r *bm25ToolRanker) Rank(intentTokens []string) []FoundationToolMatch {
+	tokenSet := normalizedTokenSet(intentTokens)
+	ranked := make([]FoundationToolMatch, 0, len(r.profiles))
+	for i, profile := range r.profiles {
+		score := 0.0
+		for token := range tokenSet {
+			score += r.termScore(i, token)
+		}
+		ranked = append(ranked, FoundationToolMatch{Name: profile.Definition.Name, Score: round2(score)})
+	}
+	sortToolMatches(ranked)
+	return ranked
+}
+
+// termScore is token's BM25 contribution to the score of profile i.
+func (r *bm25ToolRanker) termScore(i int, token string) float64 {
+	tf := r.profiles[i].TokenWeights[token]
+	if tf <= 0 {
+		return 0
+	}
+	norm := 1.0
+	if r.avgLength > 0 {
+		norm = 1 - bm25B + bm25B*r.docLength[i]/r.avgLength
+	}
+	return r.idf[token] * tf * (bm25K1 + 1) / (tf + bm25K1*norm)
+}
+
+// compareRankers reports how candidate changes hit ranks and pass rates
+// relative to baseline on the same scenarios.
+func compareRankers(baselineName string, baseline FoundationImplicitSummary, candidateName string, candidate FoundationImplicitSummary) *FoundationRankerComparison {
+	comparison := &FoundationRankerComparison{
+		Baseline:  rankerMetrics(baselineName, baseline),
+		Candidate: rankerMetrics(candidateName, candidate),
+	}
+	candidateRanks := make(map[string]int, len(candidate.CaseResults))
+	for _, c := range candidate.CaseResults {
+		candidateRanks[c.ID] = c.HitRank
+	}
+	for _, c := range baseline.CaseResults {
+		if c.NotApplicable {
+			continue
+		}
+		candidateRank := candidateRanks[c.ID]
+		switch {
+		case candidateRank == c.HitRank:
+			comparison.Unchanged++
+			continue
+		case rankBetter(candidateRank, c.HitRank):
+			comparison.Improved++
+		default:
+			comparison.Regressed++
+		}
+		comparison.ChangedCases = append(comparison.ChangedCases, FoundationRankerCaseDiff{
+			ID:               c.ID,
+			BaselineHitRank:  c.HitRank,
+			CandidateHitRank: candidateRank,
+		})
+	}
+	sort.Slice(comparison.ChangedCases, func(i, j int) bool {
+		return comparison.ChangedCases[i].ID < comparison.ChangedCases[j].ID
+	})
+	return comparison
+}
+
+func rankerMetrics(name string, summary FoundationImplicitSummary) FoundationRankerMetrics {
+	return FoundationRankerMetrics{
+		Ranker:          name,
+		ApplicableCases: summary.ApplicableCases,
+		PassedCases:     summary.PassedCases,
+		PassAt1Rate:     summary.PassAt1Rate,
+		PassAt5Rate:     summary.PassAt5Rate,
+		TopKHitRate:     summary.TopKHitRate,
+		MRR:             summary.MRR,
+	}
+}
+
+// rankBetter reports whether hit rank a beats b; 0 (no hit) is worst.
+func rankBetter(a, b int) bool {
+	if a == 0 {
+		return false
+	}
+	return b == 0 || a < b
+}
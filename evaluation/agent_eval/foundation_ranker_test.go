@@ -0,0 +1,135 @@
+package agent_eval
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	ports "alex/internal/domain/agent/ports"
+)
+
+func TestNewToolRankerStrategies(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		strategy string
+		want     string
+	}{
+		{"", RankerStrategyLexical},
+		{"lexical", RankerStrategyLexical},
+		{" BM25 ", RankerStrategyBM25},
+	} {
+		ranker, err := newToolRanker(tc.strategy, nil)
+		if err != nil {
+			t.Fatalf("newToolRanker(%q) error: %v", tc.strategy, err)
+		}
+		if ranker.Name() != tc.want {
+			t.Fatalf("newToolRanker(%q) = %s, want %s", tc.strategy, ranker.Name(), tc.want)
+		}
+	}
+	if _, err := newToolRanker("embedding", nil); err == nil {
+		t.Fatal("expected unsupported ranker error")
+	}
+}
+
+func TestBM25RankerWeighsRareTerms(t *testing.T) {
+	t.Parallel()
+
+	profiles := []foundationToolProfile{
+		{Definition: ports.ToolDefinition{Name: "read_file"}, TokenWeights: map[string]float64{"file": 5, "read": 3, "path": 2}},
+		{Definition: ports.ToolDefinition{Name: "write_file"}, TokenWeights: map[string]float64{"file": 5, "write": 3, "path": 2}},
+		{Definition: ports.ToolDefinition{Name: "replace_in_file"}, TokenWeights: map[string]float64{"file": 5, "replace": 3, "path": 2}},
+		{Definition: ports.ToolDefinition{Name: "web_search"}, TokenWeights: map[string]float64{"search": 4, "web": 2}},
+	}
+	ranked := newBM25ToolRanker(profiles).Rank(tokenize("replace the file path"))
+
+	if ranked[0].Name != "replace_in_file" {
+		t.Fatalf("expected the tool with the rare term first, got %+v", ranked)
+	}
+	if last := ranked[len(ranked)-1]; last.Name != "web_search" || last.Score != 0 {
+		t.Fatalf("expected non-overlapping tool last with zero score, got %+v", last)
+	}
+}
+
+func TestCompareRankersCountsRankChanges(t *testing.T) {
+	t.Parallel()
+
+	baseline := FoundationImplicitSummary{
+		ApplicableCases: 3,
+		CaseResults: []FoundationCaseResult{
+			{ID: "a", HitRank: 3},
+			{ID: "b", HitRank: 1},
+			{ID: "c", HitRank: 0},
+			{ID: "na", NotApplicable: true},
+		},
+	}
+	candidate := FoundationImplicitSummary{
+		ApplicableCases: 3,
+		CaseResults: []FoundationCaseResult{
+			{ID: "a", HitRank: 1},
+			{ID: "b", HitRank: 1},
+			{ID: "c", HitRank: 0},
+			{ID: "na", NotApplicable: true},
+		},
+	}
+	cmp := compareRankers("lexical", baseline, "bm25", candidate)
+	if cmp.Improved != 1 || cmp.Regressed != 0 || cmp.Unchanged != 2 {
+		t.Fatalf("unexpected counts: %+v", cmp)
+	}
+	if len(cmp.ChangedCases) != 1 || cmp.ChangedCases[0].ID != "a" || cmp.ChangedCases[0].CandidateHitRank != 1 {
+		t.Fatalf("unexpected changed cases: %+v", cmp.ChangedCases)
+	}
+
+	reverse := compareRankers("bm25", candidate, "lexical", baseline)
+	if reverse.Regressed != 1 || reverse.Improved != 0 {
+		t.Fatalf("expected regression when swapping rankers, got %+v", reverse)
+	}
+}
+
+func TestRunFoundationEvaluationComparesRankers(t *testing.T) {
+	t.Parallel()
+	tmp := t.TempDir()
+	casePath := filepath.Join(tmp, "cases.yaml")
+	caseYAML := `
+version: "1"
+name: "mini"
+scenarios:
+  - id: "one"
+    category: "planning"
+    intent: "Break this task into milestones and explicit checkpoints."
+    expected_tools: ["plan"]
+`
+	if err := os.WriteFile(casePath, []byte(caseYAML), 0644); err != nil {
+		t.Fatalf("write case yaml: %v", err)
+	}
+
+	result, err := RunFoundationEvaluation(context.Background(), &FoundationEvaluationOptions{
+		OutputDir:             filepath.Join(tmp, "out"),
+		CasesPath:             casePath,
+		ReportFormat:          "markdown",
+		RankerStrategy:        RankerStrategyLexical,
+		CompareRankerStrategy: RankerStrategyBM25,
+	})
+	if err != nil {
+		t.Fatalf("RunFoundationEvaluation error: %v", err)
+	}
+	if result.Ranker != RankerStrategyLexical {
+		t.Fatalf("expected lexical ranker recorded, got %q", result.Ranker)
+	}
+	cmp := result.RankerComparison
+	if cmp == nil || cmp.Baseline.Ranker != RankerStrategyLexical || cmp.Candidate.Ranker != RankerStrategyBM25 {
+		t.Fatalf("expected lexical vs bm25 comparison, got %+v", cmp)
+	}
+	if cmp.Improved+cmp.Regressed+cmp.Unchanged != result.Implicit.ApplicableCases {
+		t.Fatalf("comparison should cover every applicable case: %+v", cmp)
+	}
+
+	if _, err := RunFoundationEvaluation(context.Background(), &FoundationEvaluationOptions{
+		OutputDir:      filepath.Join(tmp, "out"),
+		CasesPath:      casePath,
+		RankerStrategy: "embedding",
+	}); err == nil {
+		t.Fatal("expected unsupported ranker error")
+	}
+}
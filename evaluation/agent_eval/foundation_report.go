@@ -14,7 +14,8 @@ func buildFoundationMarkdownReport(result *FoundationEvaluationResult) string {
 	b.WriteString(fmt.Sprintf("- Generated At (UTC): `%s`\n", result.GeneratedAt.Format("2006-01-02 15:04:05")))
 	b.WriteString(fmt.Sprintf("- Mode/Preset/Toolset: `%s / %s / %s`\n", result.Mode, result.Preset, result.Toolset))
 	b.WriteString(fmt.Sprintf("- Scenario Set: `%s`\n", result.CasesPath))
-	b.WriteString(fmt.Sprintf("- Top-K (legacy pass cutoff): `%d`\n\n", result.TopK))
+	b.WriteString(fmt.Sprintf("- Top-K (legacy pass cutoff): `%d`\n", result.TopK))
+	b.WriteString(fmt.Sprintf("- Ranker: `%s`\n\n", result.Ranker))
 
 	b.WriteString("## Executive Summary\n\n")
 	b.WriteString("| Dimension | Score |\n")
@@ -117,6 +118,34 @@ func buildFoundationMarkdownReport(result *FoundationEvaluationResult) string {
 	b.WriteString(fmt.Sprintf("- Top-%d hit rate (legacy): %.1f%%\n", result.TopK, result.Implicit.TopKHitRate*100))
 	b.WriteString(fmt.Sprintf("- MRR: %.3f\n\n", result.Implicit.MRR))
 
+	if len(result.Implicit.CategoryBreakdown) > 0 {
+		categories := make([]string, 0, len(result.Implicit.CategoryBreakdown))
+		for category := range result.Implicit.CategoryBreakdown {
+			categories = append(categories, category)
+		}
+		sort.Strings(categories)
+		b.WriteString("### Category Breakdown\n\n")
+		b.WriteString("| Category | Cases | pass@1 | pass@5 | MRR |\n")
+		b.WriteString("|---|---:|---:|---:|---:|\n")
+		for _, category := range categories {
+			c := result.Implicit.CategoryBreakdown[category]
+			b.WriteString(fmt.Sprintf("| `%s` | %d | %.1f%% | %.1f%% | %.3f |\n", category, c.Cases, c.PassAt1Rate*100, c.PassAt5Rate*100, c.MRR))
+		}
+		b.WriteString("\n")
+	}
+
+	if result.BaselinePath != "" {
+		b.WriteString("### Baseline Regressions\n\n")
+		b.WriteString(fmt.Sprintf("- Baseline: `%s`\n", result.BaselinePath))
+		if len(result.Regressions) == 0 {
+			b.WriteString("- No category regressed beyond the allowed pass@5 drop.\n")
+		}
+		for _, regression := range result.Regressions {
+			b.WriteString(fmt.Sprintf("- %s\n", regression))
+		}
+		b.WriteString("\n")
+	}
+
 	failedCases := make([]FoundationCaseResult, 0, result.Implicit.FailedCases)
 	successCases := make([]FoundationCaseResult, 0, result.Implicit.PassedCases)
 	for _, c := range result.Implicit.CaseResults {
@@ -182,6 +211,10 @@ func buildFoundationMarkdownReport(result *FoundationEvaluationResult) string {
 		b.WriteString("\n")
 	}
 
+	if result.RankerComparison != nil {
+		writeRankerComparison(&b, result.RankerComparison)
+	}
+
 	b.WriteString("## Recommendations\n\n")
 	for _, rec := range result.Recommendations {
 		b.WriteString(fmt.Sprintf("- %s\n", rec))
@@ -191,6 +224,40 @@ func buildFoundationMarkdownReport(result *FoundationEvaluationResult) string {
 	return b.String()
 }
 
+func writeRankerComparison(b *strings.Builder, cmp *FoundationRankerComparison) {
+	b.WriteString("## Ranker Comparison\n\n")
+	b.WriteString("| Ranker | Passed | pass@1 | pass@5 | Top-K | MRR |\n")
+	b.WriteString("|---|---:|---:|---:|---:|---:|\n")
+	for _, m := range []FoundationRankerMetrics{cmp.Baseline, cmp.Candidate} {
+		b.WriteString(fmt.Sprintf("| `%s` | %d/%d | %.1f%% | %.1f%% | %.1f%% | %.3f |\n",
+			m.Ranker,
+			m.PassedCases,
+			m.ApplicableCases,
+			m.PassAt1Rate*100,
+			m.PassAt5Rate*100,
+			m.TopKHitRate*100,
+			m.MRR,
+		))
+	}
+	b.WriteString(fmt.Sprintf("\n- `%s` vs `%s`: improved %d, regressed %d, unchanged %d\n\n", cmp.Candidate.Ranker, cmp.Baseline.Ranker, cmp.Improved, cmp.Regressed, cmp.Unchanged))
+
+	if len(cmp.ChangedCases) > 0 {
+		b.WriteString(fmt.Sprintf("| Case | `%s` Rank | `%s` Rank |\n", cmp.Baseline.Ranker, cmp.Candidate.Ranker))
+		b.WriteString("|---|---:|---:|\n")
+		for _, c := range cmp.ChangedCases {
+			b.WriteString(fmt.Sprintf("| `%s` | %s | %s |\n", c.ID, formatHitRank(c.BaselineHitRank), formatHitRank(c.CandidateHitRank)))
+		}
+		b.WriteString("\n")
+	}
+}
+
+func formatHitRank(rank int) string {
+	if rank == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%d", rank)
+}
+
 func formatTopMatches(matches []FoundationToolMatch) string {
 	if len(matches) == 0 {
 		return "-"
@@ -211,3 +278,45 @@ func escapeTable(value string) string {
 	value = strings.ReplaceAll(value, "\n", " ")
 	return value
 }
+
+// FormatFoundationCaseExplanation renders an explanation as plain text for
+// the terminal. Tools without any score contribution are summarized in one
+// line instead of being listed.
+func FormatFoundationCaseExplanation(exp *FoundationCaseExplanation) string {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("Case %s (%s), ranker %s\n", exp.CaseID, exp.Category, exp.Ranker))
+	b.WriteString(fmt.Sprintf("Intent: %s\n", exp.Intent))
+	b.WriteString(fmt.Sprintf("Tokens: %s\n", strings.Join(exp.IntentTokens, " ")))
+	hit := "not matched"
+	if exp.HitRank > 0 {
+		hit = fmt.Sprintf("#%d", exp.HitRank)
+	}
+	b.WriteString(fmt.Sprintf("Expected: %s, hit rank %s\n\n", strings.Join(exp.ExpectedTools, ", "), hit))
+
+	zero := 0
+	for _, tool := range exp.Tools {
+		if tool.Score == 0 && len(tool.TokenScores) == 0 && len(tool.BoostRules) == 0 && !tool.Expected {
+			zero++
+			continue
+		}
+		marker := " "
+		if tool.Expected {
+			marker = "*"
+		}
+		b.WriteString(fmt.Sprintf("%s#%-3d %-28s %8.2f\n", marker, tool.Rank, tool.Name, tool.Score))
+		if len(tool.TokenScores) > 0 {
+			parts := make([]string, 0, len(tool.TokenScores))
+			for _, ts := range tool.TokenScores {
+				parts = append(parts, fmt.Sprintf("%s=%.2f", ts.Token, ts.Score))
+			}
+			b.WriteString(fmt.Sprintf("       tokens: %s\n", strings.Join(parts, " ")))
+		}
+		for _, rule := range tool.BoostRules {
+			b.WriteString(fmt.Sprintf("       rule %s: %+.0f\n", rule.Rule, rule.Boost))
+		}
+	}
+	if zero > 0 {
+		b.WriteString(fmt.Sprintf("\n%d other tools scored 0.\n", zero))
+	}
+	return b.String()
+}
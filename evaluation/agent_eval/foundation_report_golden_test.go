@@ -0,0 +1,112 @@
+package agent_eval
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Regenerate with: go test ./evaluation/agent_eval -run TestFoundationReportGoldens -update
+var updateGoldens = flag.Bool("update", false, "rewrite foundation report golden files")
+
+const (
+	foundationGoldenDir   = "testdata/foundation_report"
+	foundationGoldenRunID = "foundation-golden"
+)
+
+var foundationGoldenTime = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func TestFoundationReportGoldens(t *testing.T) {
+	fixtures, err := filepath.Glob(filepath.Join(foundationGoldenDir, "*.json"))
+	if err != nil {
+		t.Fatalf("glob fixtures: %v", err)
+	}
+	if len(fixtures) == 0 {
+		t.Fatalf("no fixtures under %s", foundationGoldenDir)
+	}
+	for _, fixture := range fixtures {
+		name := strings.TrimSuffix(filepath.Base(fixture), ".json")
+		t.Run(name, func(t *testing.T) {
+			result := loadFoundationFixture(t, fixture)
+			normalizeFoundationResult(result)
+
+			data, err := marshalFoundationResult(result)
+			if err != nil {
+				t.Fatalf("marshal: %v", err)
+			}
+			var decoded FoundationEvaluationResult
+			if err := json.Unmarshal(data, &decoded); err != nil {
+				t.Fatalf("decode JSON artifact: %v", err)
+			}
+			if !reflect.DeepEqual(&decoded, result) {
+				t.Fatalf("JSON artifact does not round-trip:\n%s", data)
+			}
+
+			compareGolden(t, filepath.Join(foundationGoldenDir, name+".json.golden"), append(data, '\n'))
+			compareGolden(t, filepath.Join(foundationGoldenDir, name+".md.golden"), []byte(buildFoundationMarkdownReport(result)))
+		})
+	}
+}
+
+// loadFoundationFixture decodes a fixture strictly so fields the structs do
+// not know about fail the test instead of vanishing from the artifacts.
+func loadFoundationFixture(t *testing.T, path string) *FoundationEvaluationResult {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read fixture: %v", err)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	var result FoundationEvaluationResult
+	if err := decoder.Decode(&result); err != nil {
+		t.Fatalf("decode fixture %s: %v", path, err)
+	}
+	return &result
+}
+
+// normalizeFoundationResult pins the fields that change on every run: the
+// run ID (also embedded in artifact names), the timestamp and latencies.
+func normalizeFoundationResult(result *FoundationEvaluationResult) {
+	for i := range result.ReportArtifacts {
+		artifact := &result.ReportArtifacts[i]
+		artifact.Name = strings.ReplaceAll(artifact.Name, result.RunID, foundationGoldenRunID)
+		artifact.Path = strings.ReplaceAll(artifact.Path, result.RunID, foundationGoldenRunID)
+	}
+	result.RunID = foundationGoldenRunID
+	result.GeneratedAt = foundationGoldenTime
+
+	implicit := &result.Implicit
+	implicit.TotalEvaluationLatencyMs = 0
+	implicit.AverageCaseLatencyMs = 0
+	implicit.CaseLatencyP50Ms = 0
+	implicit.CaseLatencyP95Ms = 0
+	implicit.CaseLatencyP99Ms = 0
+	implicit.ThroughputCasesPerSec = 0
+	for i := range implicit.CaseResults {
+		implicit.CaseResults[i].RoutingLatencyMs = 0
+	}
+}
+
+func compareGolden(t *testing.T, path string, got []byte) {
+	t.Helper()
+	if *updateGoldens {
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatalf("write golden: %v", err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read golden (run with -update to create it): %v", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Fatalf("%s is out of date; review the diff and rerun with -update.\n--- got ---\n%s", path, got)
+	}
+}
@@ -0,0 +1,151 @@
+package agent_eval
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// FoundationRunSummary is the listing view of a stored foundation run.
+type FoundationRunSummary struct {
+	RunID           string    `json:"run_id"`
+	GeneratedAt     time.Time `json:"generated_at"`
+	IngestedAt      time.Time `json:"ingested_at"`
+	Mode            string    `json:"mode"`
+	Preset          string    `json:"preset"`
+	Toolset         string    `json:"toolset"`
+	Ranker          string    `json:"ranker,omitempty"`
+	TotalCases      int       `json:"total_cases"`
+	OverallScore    float64   `json:"overall_score"`
+	PassAt1Rate     float64   `json:"pass_at_1_rate"`
+	PassAt5Rate     float64   `json:"pass_at_5_rate"`
+	TopKHitRate     float64   `json:"topk_hit_rate"`
+	RegressionCount int       `json:"regression_count,omitempty"`
+}
+
+// FoundationRunStore persists published foundation results, one JSON file
+// per run ID.
+type FoundationRunStore struct {
+	basePath string
+	mu       sync.RWMutex
+}
+
+// NewFoundationRunStore creates a store rooted at basePath.
+func NewFoundationRunStore(basePath string) *FoundationRunStore {
+	return &FoundationRunStore{basePath: basePath}
+}
+
+// Save stores result under its run ID, replacing an earlier upload of the
+// same run.
+func (store *FoundationRunStore) Save(result *FoundationEvaluationResult) error {
+	if result == nil {
+		return fmt.Errorf("foundation result is nil")
+	}
+	runID, err := sanitizePathComponent(strings.TrimSpace(result.RunID), "run id")
+	if err != nil {
+		return err
+	}
+
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	if err := os.MkdirAll(store.basePath, 0o755); err != nil {
+		return fmt.Errorf("failed to create foundation run dir: %w", err)
+	}
+	record := storedFoundationRun{IngestedAt: time.Now().UTC(), Result: result}
+	return writeJSON(filepath.Join(store.basePath, runID+".json"), &record)
+}
+
+// Get loads one stored run.
+func (store *FoundationRunStore) Get(runID string) (*FoundationEvaluationResult, error) {
+	safeRunID, err := sanitizePathComponent(runID, "run id")
+	if err != nil {
+		return nil, err
+	}
+
+	store.mu.RLock()
+	defer store.mu.RUnlock()
+
+	record, err := readStoredFoundationRun(filepath.Join(store.basePath, safeRunID+".json"))
+	if err != nil {
+		return nil, err
+	}
+	return record.Result, nil
+}
+
+// List returns summaries of all stored runs, newest first.
+func (store *FoundationRunStore) List() ([]FoundationRunSummary, error) {
+	store.mu.RLock()
+	defer store.mu.RUnlock()
+
+	entries, err := os.ReadDir(store.basePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to list foundation runs: %w", err)
+	}
+
+	summaries := make([]FoundationRunSummary, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+		record, err := readStoredFoundationRun(filepath.Join(store.basePath, entry.Name()))
+		if err != nil {
+			continue
+		}
+		summaries = append(summaries, summarizeFoundationRun(record))
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		if summaries[i].GeneratedAt.Equal(summaries[j].GeneratedAt) {
+			return summaries[i].RunID > summaries[j].RunID
+		}
+		return summaries[i].GeneratedAt.After(summaries[j].GeneratedAt)
+	})
+	return summaries, nil
+}
+
+type storedFoundationRun struct {
+	IngestedAt time.Time                   `json:"ingested_at"`
+	Result     *FoundationEvaluationResult `json:"result"`
+}
+
+func readStoredFoundationRun(path string) (*storedFoundationRun, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var record storedFoundationRun
+	if err := json.Unmarshal(data, &record); err != nil {
+		return nil, fmt.Errorf("failed to decode foundation run: %w", err)
+	}
+	if record.Result == nil {
+		return nil, fmt.Errorf("foundation run %s has no result", filepath.Base(path))
+	}
+	return &record, nil
+}
+
+func summarizeFoundationRun(record *storedFoundationRun) FoundationRunSummary {
+	result := record.Result
+	return FoundationRunSummary{
+		RunID:           result.RunID,
+		GeneratedAt:     result.GeneratedAt,
+		IngestedAt:      record.IngestedAt,
+		Mode:            result.Mode,
+		Preset:          result.Preset,
+		Toolset:         result.Toolset,
+		Ranker:          result.Ranker,
+		TotalCases:      result.Implicit.TotalCases,
+		OverallScore:    result.OverallScore,
+		PassAt1Rate:     result.Implicit.PassAt1Rate,
+		PassAt5Rate:     result.Implicit.PassAt5Rate,
+		TopKHitRate:     result.Implicit.TopKHitRate,
+		RegressionCount: len(result.Regressions),
+	}
+}
@@ -0,0 +1,77 @@
+package agent_eval
+
+import (
+	"context"
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestTokenizeSplitsHanIntoBigrams(t *testing.T) {
+	t.Parallel()
+
+	got := tokenize("创建markdown报告文件")
+	want := []string{"创建", "markdown", "报告", "告文", "文件"}
+	if !slices.Equal(got, want) {
+		t.Fatalf("tokenize() = %v, want %v", got, want)
+	}
+	if got := tokenize("用 shell_exec 跑"); !slices.Equal(got, []string{"用", "shell", "exec", "shell_exec", "跑"}) {
+		t.Fatalf("unexpected single-rune and underscore handling: %v", got)
+	}
+}
+
+func TestNormalizeTokenMapsChineseBigrams(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]string{
+		"文件": "file",
+		"搜索": "search",
+		"查看": "query", // 查看 -> view -> query
+		"一手": "official",
+		"需要": "",
+		"告文": "告文",
+	}
+	for token, want := range cases {
+		if got := normalizeToken(token); got != want {
+			t.Fatalf("normalizeToken(%q) = %q, want %q", token, got, want)
+		}
+	}
+}
+
+func TestChineseToolCoverageMatchesEnglishParity(t *testing.T) {
+	t.Parallel()
+
+	english, err := LoadFoundationCaseSet("datasets/foundation_eval_cases_tool_coverage.yaml")
+	if err != nil {
+		t.Fatalf("load english cases: %v", err)
+	}
+	chinese, err := LoadFoundationCaseSet("datasets/foundation_eval_cases_tool_coverage_zh.yaml")
+	if err != nil {
+		t.Fatalf("load chinese cases: %v", err)
+	}
+	if len(chinese.Scenarios) != len(english.Scenarios) {
+		t.Fatalf("expected translated set to mirror english: %d vs %d scenarios", len(chinese.Scenarios), len(english.Scenarios))
+	}
+	profiles, err := collectToolProfiles(context.Background(), normalizeFoundationMode("web"), "full", "default")
+	if err != nil {
+		t.Fatalf("collect tool profiles: %v", err)
+	}
+
+	for _, strategy := range []string{RankerStrategyLexical, RankerStrategyBM25} {
+		ranker, err := newToolRanker(strategy, profiles)
+		if err != nil {
+			t.Fatalf("new ranker %s: %v", strategy, err)
+		}
+		en := evaluateImplicitCases(english.Scenarios, profiles, 3, ranker)
+		zh := evaluateImplicitCases(chinese.Scenarios, profiles, 3, ranker)
+		if zh.PassAt5Rate == 0 {
+			t.Fatalf("%s: chinese scenarios never hit an expected tool", strategy)
+		}
+		if diff := math.Abs(en.PassAt5Rate - zh.PassAt5Rate); diff > 0.1+1e-9 {
+			t.Fatalf("%s: pass@5 parity gap %.2f (en %.2f, zh %.2f)", strategy, diff, en.PassAt5Rate, zh.PassAt5Rate)
+		}
+		if diff := math.Abs(en.TopKHitRate - zh.TopKHitRate); diff > 0.2+1e-9 {
+			t.Fatalf("%s: top-3 parity gap %.2f (en %.2f, zh %.2f)", strategy, diff, en.TopKHitRate, zh.TopKHitRate)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package agent_eval
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"sync"
+	"time"
+
+	"alex/evaluation/swe_bench"
+)
+
+type runLogKey struct{}
+
+type runLog struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// WithRunLog attaches w to ctx so RunEvaluation mirrors its progress lines
+// into a per-run log in addition to the process log.
+func WithRunLog(ctx context.Context, w io.Writer) context.Context {
+	if w == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, runLogKey{}, &runLog{w: w})
+}
+
+func runLogf(ctx context.Context, format string, args ...any) {
+	log.Printf(format, args...)
+	sink, ok := ctx.Value(runLogKey{}).(*runLog)
+	if !ok {
+		return
+	}
+	sink.mu.Lock()
+	defer sink.mu.Unlock()
+	_, _ = fmt.Fprintf(sink.w, "%s %s\n", time.Now().UTC().Format(time.RFC3339), fmt.Sprintf(format, args...))
+}
+
+// logWorkerResults records a pass/fail summary plus the error of every
+// unsuccessful instance so failed runs can be debugged from the run log.
+func logWorkerResults(ctx context.Context, results []swe_bench.WorkerResult) {
+	passed := 0
+	for _, result := range results {
+		if result.Status == swe_bench.StatusCompleted {
+			passed++
+			continue
+		}
+		runLogf(ctx, "Instance %s %s after %s: %s", result.InstanceID, result.Status, result.Duration.Round(time.Millisecond), result.Error)
+	}
+	runLogf(ctx, "Finished %d instances: %d passed, %d not passed", len(results), passed, len(results)-passed)
+}
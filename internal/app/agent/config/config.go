@@ -33,6 +33,8 @@ type Config struct {
 	SessionStaleAfter          time.Duration
 	Proactive           runtimeconfig.ProactiveConfig
 	ToolPolicy          toolspolicy.ToolPolicyConfig
+	// TraceToolArguments records sanitized tool arguments on tool trace spans.
+	TraceToolArguments bool
 }
 
 // ResolveEnvironmentSummary returns the environment summary, preferring the
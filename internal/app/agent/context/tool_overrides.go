@@ -0,0 +1,51 @@
+package context
+
+import (
+	"context"
+
+	tools "alex/internal/domain/agent/ports/tools"
+)
+
+type toolOverridesKey struct{}
+
+// WithToolOverrides attaches session-scoped tool allow/deny overrides. They
+// take precedence over the overrides persisted on the session.
+func WithToolOverrides(ctx context.Context, overrides tools.ToolOverrides) context.Context {
+	return context.WithValue(ctx, toolOverridesKey{}, overrides.Normalize())
+}
+
+// ToolOverridesFromContext returns the overrides and true if any were set.
+func ToolOverridesFromContext(ctx context.Context) (tools.ToolOverrides, bool) {
+	if ctx == nil {
+		return tools.ToolOverrides{}, false
+	}
+	overrides, ok := ctx.Value(toolOverridesKey{}).(tools.ToolOverrides)
+	return overrides, ok
+}
+
+// WithSessionToolOverrides attaches the overrides persisted in session
+// metadata unless ctx already carries overrides. Undecodable metadata leaves
+// ctx unchanged and is reported as an error.
+func WithSessionToolOverrides(ctx context.Context, metadata map[string]string) (context.Context, error) {
+	if _, ok := ToolOverridesFromContext(ctx); ok {
+		return ctx, nil
+	}
+	raw := metadata[tools.ToolOverridesMetadataKey]
+	if raw == "" {
+		return ctx, nil
+	}
+	overrides, err := tools.ParseToolOverrides(raw)
+	if err != nil {
+		return ctx, err
+	}
+	return WithToolOverrides(ctx, overrides), nil
+}
+
+// PropagateToolOverrides copies tool overrides onto a background task context
+// so subagents cannot use tools the parent session disabled.
+func PropagateToolOverrides(from, to context.Context) context.Context {
+	if overrides, ok := ToolOverridesFromContext(from); ok {
+		return WithToolOverrides(to, overrides)
+	}
+	return to
+}
@@ -0,0 +1,29 @@
+package context
+
+import (
+	"context"
+	"strings"
+)
+
+type workspaceKey struct{}
+
+// WithWorkspace annotates the request context with the workspace whose
+// output policy applies to the run.
+func WithWorkspace(ctx context.Context, workspace string) context.Context {
+	return context.WithValue(ctx, workspaceKey{}, workspace)
+}
+
+// WorkspaceFromContext returns the explicit workspace, falling back to the
+// channel identifier so each channel can carry its own policy. It returns
+// "" when neither is set.
+func WorkspaceFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	if workspace, ok := ctx.Value(workspaceKey{}).(string); ok {
+		if trimmed := strings.TrimSpace(workspace); trimmed != "" {
+			return trimmed
+		}
+	}
+	return strings.TrimSpace(ChannelFromContext(ctx))
+}
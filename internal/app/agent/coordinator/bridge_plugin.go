@@ -199,6 +199,11 @@ func (p *bridgePlugin) RunModel(ctx context.Context, state *corehook.TurnState,
 	// Build and run the ReAct engine.
 	env := p.lastEnv
 	task := state.Input
+	if env.Session != nil {
+		// Carry session tool overrides into tool calls so background
+		// subagents inherit them via PropagateToolOverrides.
+		ctx, _ = appcontext.WithSessionToolOverrides(ctx, env.Session.Metadata)
+	}
 
 	completionDefaults := buildCompletionDefaultsFromConfig(effectiveCfg)
 	idAdapter := infraruntime.IDsAdapter{}
@@ -229,6 +234,7 @@ func (p *bridgePlugin) RunModel(ctx context.Context, state *corehook.TurnState,
 				MaxConcurrentTasks:  effectiveCfg.MaxBackgroundTasks,
 				ContextPropagators: []agent.ContextPropagatorFunc{
 					appcontext.PropagateLLMSelection,
+					appcontext.PropagateToolOverrides,
 				},
 				TmuxSender:    infraadapters.NewExecTmuxSender(),
 				EventAppender: infraadapters.NewFileEventAppender(),
@@ -259,6 +265,7 @@ func (p *bridgePlugin) RunModel(ctx context.Context, state *corehook.TurnState,
 		BackgroundExecutor: backgroundExecutor,
 		BackgroundManager:  bgManager,
 		AtomicFileWriter:   infraadapters.NewOSAtomicWriter(),
+		TraceToolArguments: effectiveCfg.TraceToolArguments,
 	})
 
 	if p.eventListener != nil {
@@ -279,6 +286,8 @@ func (p *bridgePlugin) RunModel(ctx context.Context, state *corehook.TurnState,
 		}
 	}
 
+	c.enforceOutputPolicy(ctx, result)
+
 	p.lastResult = result
 	p.lastExecErr = execErr
 
@@ -10,6 +10,7 @@ import (
 	appconfig "alex/internal/app/agent/config"
 	"alex/internal/app/agent/cost"
 	"alex/internal/app/agent/preparation"
+	"alex/internal/app/outputpolicy"
 	corehook "alex/internal/core/hook"
 	coretape "alex/internal/core/tape"
 	domain "alex/internal/domain/agent"
@@ -51,17 +52,20 @@ type coordinatorExecution struct {
 
 // coordinatorIntegrations groups external integration dependencies.
 type coordinatorIntegrations struct {
-	costDecorator       *cost.CostTrackingDecorator
-	attachmentMigrator  materialports.Migrator
-	attachmentPersister ports.AttachmentPersister
-	hookRuntime         *corehook.HookRuntime
-	okrContextProvider  preparation.OKRContextProvider
-	credentialRefresher preparation.CredentialRefresher
-	timerManager        shared.TimerManagerService // injected at bootstrap; tools retrieve via shared.TimerManagerFromContext
-	schedulerService    any                        // injected at bootstrap; tools retrieve via shared.SchedulerFromContext
-	toolSLACollector    *toolspolicy.SLACollector
-	turnRecorder        agent.TurnRecorder
-	tapeManager         *coretape.TapeManager
+	costDecorator              *cost.CostTrackingDecorator
+	attachmentMigrator         materialports.Migrator
+	attachmentPersister        ports.AttachmentPersister
+	hookRuntime                *corehook.HookRuntime
+	okrContextProvider         preparation.OKRContextProvider
+	credentialRefresher        preparation.CredentialRefresher
+	preferencesContextProvider preparation.PreferencesContextProvider
+	timerManager               shared.TimerManagerService // injected at bootstrap; tools retrieve via shared.TimerManagerFromContext
+	schedulerService           any                        // injected at bootstrap; tools retrieve via shared.SchedulerFromContext
+	toolSLACollector           *toolspolicy.SLACollector
+	turnRecorder               agent.TurnRecorder
+	tapeManager                *coretape.TapeManager
+	sessionTitler              SessionTitler
+	outputPolicy               OutputPolicy
 }
 
 // coordinatorSessionSave groups the debounced session-save mechanism.
@@ -88,6 +92,20 @@ type AgentCoordinator struct {
 	channelHints    map[string]string
 }
 
+// SessionTitler generates session titles and tags once a task completes.
+// Implementations must return immediately and do the work asynchronously.
+type SessionTitler interface {
+	OnTaskCompleted(ctx context.Context, sessionID string)
+}
+
+// OutputPolicy filters final answers per workspace before they leave the
+// coordinator. *outputpolicy.Service satisfies it.
+type OutputPolicy interface {
+	Active(workspace string) bool
+	Evaluate(workspace, text string) outputpolicy.Result
+	Enforce(ctx context.Context, target outputpolicy.Target, text string) outputpolicy.Result
+}
+
 type preparationService interface {
 	Prepare(ctx context.Context, task string, sessionID string) (*agent.ExecutionEnvironment, error)
 	SetEnvironmentSummary(summary string)
@@ -142,21 +160,22 @@ func NewAgentCoordinator(
 	}
 
 	coordinator.prepService = preparation.NewExecutionPreparationService(preparation.ExecutionPreparationDeps{
-		LLMFactory:          llmFactory,
-		ToolRegistry:        toolRegistry,
-		SessionStore:        sessionStore,
-		ContextMgr:          contextMgr,
-		HistoryMgr:          historyManager,
-		Parser:              parser,
-		Config:              config,
-		Logger:              coordinator.logger,
-		Clock:               coordinator.clock,
-		CostDecorator:       coordinator.costDecorator,
-		CostTracker:         coordinator.costTracker,
-		OKRContextProvider:  coordinator.okrContextProvider,
-		CredentialRefresher: coordinator.credentialRefresher,
-		ChannelHints:        coordinator.channelHints,
-		TurnRecorder:        coordinator.turnRecorder,
+		LLMFactory:                 llmFactory,
+		ToolRegistry:               toolRegistry,
+		SessionStore:               sessionStore,
+		ContextMgr:                 contextMgr,
+		HistoryMgr:                 historyManager,
+		Parser:                     parser,
+		Config:                     config,
+		Logger:                     coordinator.logger,
+		Clock:                      coordinator.clock,
+		CostDecorator:              coordinator.costDecorator,
+		CostTracker:                coordinator.costTracker,
+		OKRContextProvider:         coordinator.okrContextProvider,
+		PreferencesContextProvider: coordinator.preferencesContextProvider,
+		CredentialRefresher:        coordinator.credentialRefresher,
+		ChannelHints:               coordinator.channelHints,
+		TurnRecorder:               coordinator.turnRecorder,
 	})
 
 	if coordinator.contextMgr != nil {
@@ -265,22 +284,23 @@ func (c *AgentCoordinator) prepareExecutionWithListener(ctx context.Context, tas
 	}
 	logger := c.loggerFor(ctx)
 	prepService := preparation.NewExecutionPreparationService(preparation.ExecutionPreparationDeps{
-		LLMFactory:          c.llmFactory,
-		ToolRegistry:        c.toolRegistry,
-		SessionStore:        c.sessionStore,
-		ContextMgr:          c.contextMgr,
-		HistoryMgr:          c.historyMgr,
-		Parser:              c.parser,
-		Config:              cfg,
-		Logger:              logger,
-		Clock:               c.clock,
-		CostDecorator:       c.costDecorator,
-		EventEmitter:        listener,
-		CostTracker:         c.costTracker,
-		OKRContextProvider:  c.okrContextProvider,
-		CredentialRefresher: c.credentialRefresher,
-		ChannelHints:        c.channelHints,
-		TurnRecorder:        c.turnRecorder,
+		LLMFactory:                 c.llmFactory,
+		ToolRegistry:               c.toolRegistry,
+		SessionStore:               c.sessionStore,
+		ContextMgr:                 c.contextMgr,
+		HistoryMgr:                 c.historyMgr,
+		Parser:                     c.parser,
+		Config:                     cfg,
+		Logger:                     logger,
+		Clock:                      c.clock,
+		CostDecorator:              c.costDecorator,
+		EventEmitter:               listener,
+		CostTracker:                c.costTracker,
+		OKRContextProvider:         c.okrContextProvider,
+		PreferencesContextProvider: c.preferencesContextProvider,
+		CredentialRefresher:        c.credentialRefresher,
+		ChannelHints:               c.channelHints,
+		TurnRecorder:               c.turnRecorder,
 	})
 	return prepService.Prepare(ctx, task, sessionID)
 }
@@ -99,6 +99,10 @@ func (c *AgentCoordinator) ExecuteTask(
 		return result, fwErr
 	}
 
+	if c.sessionTitler != nil && !appcontext.IsSubagentContext(ctx) {
+		c.sessionTitler.OnTaskCompleted(ctx, resolvedSession)
+	}
+
 	return result, nil
 }
 
@@ -129,7 +133,7 @@ func (c *AgentCoordinator) setupExecutionContext(
 			c.persistSessionTitle(ctx, sessionID, title)
 		},
 	})
-	eventListener = dispatcher.Listener()
+	eventListener = c.wrapOutputPolicyListener(ctx, dispatcher.Listener())
 
 	ctx = id.WithSessionID(ctx, sessionID)
 	if c.timerManager != nil {
@@ -6,8 +6,10 @@ import (
 	"testing"
 	"time"
 
+	appconfig "alex/internal/app/agent/config"
 	agent "alex/internal/domain/agent/ports/agent"
 	storage "alex/internal/domain/agent/ports/storage"
+	"alex/internal/infra/llm"
 )
 
 type titleUpdateStore struct {
@@ -123,3 +125,41 @@ func TestPersistSessionTitleSkipsBlankTitle(t *testing.T) {
 	case <-time.After(100 * time.Millisecond):
 	}
 }
+
+type recordingSessionTitler struct {
+	mu       sync.Mutex
+	sessions []string
+}
+
+func (r *recordingSessionTitler) OnTaskCompleted(_ context.Context, sessionID string) {
+	r.mu.Lock()
+	r.sessions = append(r.sessions, sessionID)
+	r.mu.Unlock()
+}
+
+func TestExecuteTaskNotifiesSessionTitlerOnSuccess(t *testing.T) {
+	titler := &recordingSessionTitler{}
+	coordinator := NewAgentCoordinator(
+		llm.NewFactory(),
+		stubToolRegistry{},
+		&stubSessionStore{},
+		stubContextManager{},
+		nil,
+		stubParser{},
+		nil,
+		appconfig.Config{LLMProvider: "mock", LLMModel: "title", MaxIterations: 2},
+		WithSessionTitler(titler),
+	)
+
+	ctx := agent.WithOutputContext(context.Background(), &agent.OutputContext{Level: agent.LevelCore})
+	result, err := coordinator.ExecuteTask(ctx, "Return a concise answer", "", nil)
+	if err != nil {
+		t.Fatalf("ExecuteTask: %v", err)
+	}
+
+	titler.mu.Lock()
+	defer titler.mu.Unlock()
+	if len(titler.sessions) != 1 || titler.sessions[0] != result.SessionID {
+		t.Fatalf("expected titler notified once for %q, got %v", result.SessionID, titler.sessions)
+	}
+}
@@ -9,8 +9,8 @@ import (
 )
 
 // EventDispatcher provides a single assembly point for the coordinator event
-// pipeline (envelope translation, SLA enrichment, plan-title extraction, and
-// per-run serialization).
+// pipeline (usage pricing, envelope translation, SLA enrichment, plan-title
+// extraction, and per-run serialization).
 type EventDispatcher interface {
 	Listener() agent.EventListener
 	Flush(ctx context.Context, runID string)
@@ -33,6 +33,12 @@ func (workflowEnvelopeStage) Wrap(listener agent.EventListener) agent.EventListe
 	return wrapWithWorkflowEnvelope(listener)
 }
 
+type usageCostStage struct{}
+
+func (usageCostStage) Wrap(listener agent.EventListener) agent.EventListener {
+	return wrapWithUsageCost(listener)
+}
+
 type slaEnrichmentStage struct {
 	collector *toolspolicy.SLACollector
 }
@@ -57,6 +63,7 @@ func NewEventDispatcher(listener agent.EventListener, collector *toolspolicy.SLA
 	stages := []eventStage{
 		slaEnrichmentStage{collector: collector},
 		workflowEnvelopeStage{},
+		usageCostStage{},
 	}
 	for _, stage := range stages {
 		sink = stage.Wrap(sink)
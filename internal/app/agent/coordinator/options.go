@@ -48,6 +48,15 @@ func WithOKRContextProvider(provider preparation.OKRContextProvider) Coordinator
 	}
 }
 
+// WithPreferencesContextProvider provides the per-user preferences renderer for system prompt injection.
+func WithPreferencesContextProvider(provider preparation.PreferencesContextProvider) CoordinatorOption {
+	return func(c *AgentCoordinator) {
+		if provider != nil {
+			c.preferencesContextProvider = provider
+		}
+	}
+}
+
 // WithCredentialRefresher provides a function that re-resolves CLI credentials
 // at task execution time. This keeps long-running servers (e.g. Lark) working
 // when startup tokens expire and need OAuth refresh.
@@ -107,3 +116,22 @@ func WithTapeManager(mgr *coretape.TapeManager) CoordinatorOption {
 		}
 	}
 }
+
+// WithSessionTitler provides the background session titler invoked after each
+// successful top-level task.
+func WithSessionTitler(titler SessionTitler) CoordinatorOption {
+	return func(c *AgentCoordinator) {
+		if titler != nil {
+			c.sessionTitler = titler
+		}
+	}
+}
+
+// WithOutputPolicy applies per-workspace content rules to final answers.
+func WithOutputPolicy(policy OutputPolicy) CoordinatorOption {
+	return func(c *AgentCoordinator) {
+		if policy != nil {
+			c.outputPolicy = policy
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package coordinator
+
+import (
+	"context"
+
+	appcontext "alex/internal/app/agent/context"
+	"alex/internal/app/outputpolicy"
+	domain "alex/internal/domain/agent"
+	agent "alex/internal/domain/agent/ports/agent"
+	"alex/internal/domain/agent/types"
+	id "alex/internal/shared/utils/id"
+)
+
+const outputPolicyMetadataKey = "output_policy"
+
+// outputPolicyWorkspace returns the workspace whose policy applies to ctx.
+// Subagent runs are internal traffic and are never filtered; their output
+// reaches users only through the parent's final answer.
+func (c *AgentCoordinator) outputPolicyWorkspace(ctx context.Context) (string, bool) {
+	if c.outputPolicy == nil || appcontext.IsSubagentContext(ctx) {
+		return "", false
+	}
+	workspace := outputpolicy.NormalizeWorkspace(appcontext.WorkspaceFromContext(ctx))
+	if !c.outputPolicy.Active(workspace) {
+		return "", false
+	}
+	return workspace, true
+}
+
+// enforceOutputPolicy filters the final answer and the final assistant
+// message before the session is persisted. The original is journaled by the
+// policy, so the session only ever holds the filtered text.
+func (c *AgentCoordinator) enforceOutputPolicy(ctx context.Context, result *agent.TaskResult) {
+	if result == nil || result.Answer == "" {
+		return
+	}
+	workspace, ok := c.outputPolicyWorkspace(ctx)
+	if !ok {
+		return
+	}
+	filtered := c.outputPolicy.Enforce(ctx, outputpolicy.Target{
+		Workspace: workspace,
+		SessionID: result.SessionID,
+		RunID:     defaultString(result.RunID, id.RunIDFromContext(ctx)),
+		Channel:   appcontext.ChannelFromContext(ctx),
+	}, result.Answer)
+	if !filtered.Changed() {
+		return
+	}
+
+	mark := &agent.OutputPolicyMark{
+		Workspace: workspace,
+		Outcome:   string(filtered.Outcome),
+		RuleIDs:   filtered.RuleIDs(),
+		AuditID:   filtered.AuditID,
+	}
+	result.Answer = filtered.Text
+	result.OutputPolicy = mark
+
+	for i := len(result.Messages) - 1; i >= 0; i-- {
+		msg := &result.Messages[i]
+		if msg.Role != "assistant" || len(msg.ToolCalls) > 0 || msg.Content == "" {
+			continue
+		}
+		msg.Content = c.outputPolicy.Evaluate(workspace, msg.Content).Text
+		if msg.Metadata == nil {
+			msg.Metadata = make(map[string]any)
+		}
+		msg.Metadata[outputPolicyMetadataKey] = mark
+		break
+	}
+}
+
+// wrapOutputPolicyListener filters streamed final answers for workspaces with
+// an active policy. Token-level output deltas are dropped for those
+// workspaces because they cannot be filtered before the answer is complete.
+func (c *AgentCoordinator) wrapOutputPolicyListener(ctx context.Context, next agent.EventListener) agent.EventListener {
+	if next == nil {
+		return nil
+	}
+	workspace, ok := c.outputPolicyWorkspace(ctx)
+	if !ok {
+		return next
+	}
+	return &outputPolicyListener{next: next, policy: c.outputPolicy, workspace: workspace}
+}
+
+type outputPolicyListener struct {
+	next      agent.EventListener
+	policy    OutputPolicy
+	workspace string
+}
+
+func (l *outputPolicyListener) OnEvent(event agent.AgentEvent) {
+	evt, ok := event.(*domain.Event)
+	if !ok {
+		l.next.OnEvent(event)
+		return
+	}
+	switch evt.Kind {
+	case types.EventNodeOutputDelta:
+		return
+	case types.EventResultFinal:
+		filtered := l.policy.Evaluate(l.workspace, evt.Data.FinalAnswer)
+		if filtered.Changed() {
+			cloned := *evt
+			cloned.Data.FinalAnswer = filtered.Text
+			l.next.OnEvent(&cloned)
+			return
+		}
+	}
+	l.next.OnEvent(event)
+}
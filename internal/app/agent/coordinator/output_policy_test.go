@@ -0,0 +1,145 @@
+package coordinator
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	appconfig "alex/internal/app/agent/config"
+	appcontext "alex/internal/app/agent/context"
+	"alex/internal/app/outputpolicy"
+	domain "alex/internal/domain/agent"
+	core "alex/internal/domain/agent/ports"
+	agent "alex/internal/domain/agent/ports/agent"
+	"alex/internal/domain/agent/types"
+	"alex/internal/infra/llm"
+)
+
+func newTestOutputPolicy(t *testing.T, sets ...outputpolicy.RuleSet) *outputpolicy.Service {
+	t.Helper()
+	store, err := outputpolicy.NewStore("")
+	if err != nil {
+		t.Fatalf("new store: %v", err)
+	}
+	svc := outputpolicy.NewService(store, nil, nil)
+	for _, set := range sets {
+		if _, err := svc.PutRuleSet(context.Background(), outputpolicy.AnyVersion, set); err != nil {
+			t.Fatalf("put rule set: %v", err)
+		}
+	}
+	return svc
+}
+
+func TestEnforceOutputPolicyMasksAnswerAndFinalMessage(t *testing.T) {
+	policy := newTestOutputPolicy(t, outputpolicy.RuleSet{
+		Workspace:  "lark",
+		Redactions: []outputpolicy.RegexRule{{ID: "hosts", Pattern: `\w+\.corp\.internal`}},
+	})
+	coordinator := &AgentCoordinator{logger: agent.NoopLogger{}}
+	coordinator.outputPolicy = policy
+
+	answer := "Use build.corp.internal"
+	newResult := func() *agent.TaskResult {
+		return &agent.TaskResult{
+			Answer: answer,
+			RunID:  "run-1",
+			Messages: []core.Message{
+				{Role: "assistant", Content: "checking", ToolCalls: []core.ToolCall{{ID: "call-1"}}},
+				{Role: "tool", Content: "host: build.corp.internal"},
+				{Role: "assistant", Content: answer},
+			},
+		}
+	}
+
+	result := newResult()
+	coordinator.enforceOutputPolicy(appcontext.WithChannel(context.Background(), "lark"), result)
+	if result.Answer != "Use [REDACTED]" {
+		t.Fatalf("answer = %q", result.Answer)
+	}
+	if result.OutputPolicy == nil || result.OutputPolicy.Outcome != "masked" || result.OutputPolicy.RuleIDs[0] != "hosts" || result.OutputPolicy.AuditID == "" {
+		t.Fatalf("unexpected policy mark: %+v", result.OutputPolicy)
+	}
+	final := result.Messages[2]
+	if final.Content != "Use [REDACTED]" || final.Metadata[outputPolicyMetadataKey] == nil {
+		t.Fatalf("final message not filtered or marked: %+v", final)
+	}
+	if result.Messages[1].Content != "host: build.corp.internal" {
+		t.Fatal("tool traffic must not be filtered")
+	}
+
+	// Other workspaces and subagent runs are untouched.
+	web := newResult()
+	coordinator.enforceOutputPolicy(appcontext.WithChannel(context.Background(), "web"), web)
+	sub := newResult()
+	coordinator.enforceOutputPolicy(appcontext.MarkSubagentContext(appcontext.WithChannel(context.Background(), "lark")), sub)
+	if web.Answer != answer || web.OutputPolicy != nil || sub.Answer != answer {
+		t.Fatalf("expected unfiltered answers, got web=%q sub=%q", web.Answer, sub.Answer)
+	}
+
+	entries, err := policy.Audit(context.Background(), "lark", 0)
+	if err != nil || len(entries) != 1 || entries[0].Original != answer {
+		t.Fatalf("expected one audit copy of the original, got %+v err=%v", entries, err)
+	}
+}
+
+func TestOutputPolicyListenerFiltersFinalEvents(t *testing.T) {
+	policy := newTestOutputPolicy(t, outputpolicy.RuleSet{
+		Workspace:    "acme",
+		BlockedTerms: []outputpolicy.TermRule{{ID: "secret", Terms: []string{"project x"}, Action: outputpolicy.ActionBlock}},
+	})
+	coordinator := &AgentCoordinator{logger: agent.NoopLogger{}}
+	coordinator.outputPolicy = policy
+	next := &recordingListener{}
+
+	listener := coordinator.wrapOutputPolicyListener(appcontext.WithWorkspace(context.Background(), "acme"), next)
+	listener.OnEvent(&domain.Event{Kind: types.EventNodeOutputDelta, Data: domain.EventData{Delta: "Project X"}})
+	original := domain.NewResultFinalEvent(domain.BaseEvent{}, "About Project X", 1, 1, "final_answer", 0, false, true, nil)
+	listener.OnEvent(original)
+
+	events := next.snapshot()
+	if len(events) != 1 {
+		t.Fatalf("expected deltas dropped and one final event, got %d", len(events))
+	}
+	forwarded := events[0].(*domain.Event)
+	if strings.Contains(forwarded.Data.FinalAnswer, "Project X") {
+		t.Fatalf("final answer leaked: %q", forwarded.Data.FinalAnswer)
+	}
+	if original.Data.FinalAnswer != "About Project X" {
+		t.Fatal("original event must not be mutated")
+	}
+
+	if got := coordinator.wrapOutputPolicyListener(context.Background(), next); got != agent.EventListener(next) {
+		t.Fatal("expected listener unwrapped for workspaces without rules")
+	}
+}
+
+func TestExecuteTaskAppliesOutputPolicy(t *testing.T) {
+	policy := newTestOutputPolicy(t, outputpolicy.RuleSet{
+		Workspace: outputpolicy.DefaultWorkspace,
+		MaxLength: &outputpolicy.LengthRule{ID: "cap", MaxChars: 1, Suffix: "~"},
+	})
+	coordinator := NewAgentCoordinator(
+		llm.NewFactory(),
+		stubToolRegistry{},
+		&stubSessionStore{},
+		stubContextManager{},
+		nil,
+		stubParser{},
+		nil,
+		appconfig.Config{LLMProvider: "mock", LLMModel: "policy", MaxIterations: 2},
+		WithOutputPolicy(policy),
+	)
+	listener := &recordingListener{}
+
+	ctx := agent.WithOutputContext(context.Background(), &agent.OutputContext{Level: agent.LevelCore})
+	result, err := coordinator.ExecuteTask(ctx, "Return a concise answer", "", listener)
+	if err != nil {
+		t.Fatalf("ExecuteTask: %v", err)
+	}
+	if !strings.HasSuffix(result.Answer, "~") || len([]rune(result.Answer)) != 2 {
+		t.Fatalf("expected truncated answer, got %q", result.Answer)
+	}
+	if result.OutputPolicy == nil || result.OutputPolicy.Outcome != string(outputpolicy.OutcomeTruncated) {
+		t.Fatalf("expected truncation mark, got %+v", result.OutputPolicy)
+	}
+}
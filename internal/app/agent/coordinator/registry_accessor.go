@@ -27,6 +27,12 @@ func (c *AgentCoordinator) SetEnvironmentSummary(summary string) {
 	}
 }
 
+// SetTraceToolArguments controls whether tool spans carry the (sanitized)
+// call arguments. Disable it when arguments may contain private data.
+func (c *AgentCoordinator) SetTraceToolArguments(enabled bool) {
+	c.config.TraceToolArguments = enabled
+}
+
 // SetAttachmentMigrator wires an attachment migrator for boundary externalization.
 // Agent state keeps inline payloads; CDN rewriting happens at HTTP/SSE boundaries.
 func (c *AgentCoordinator) SetAttachmentMigrator(migrator materialports.Migrator) {
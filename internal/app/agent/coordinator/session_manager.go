@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -157,6 +158,7 @@ func applyTaskResultMetadata(session *storage.Session, result *agent.TaskResult)
 	if result.SessionID != "" {
 		metadata["session_id"] = result.SessionID
 	}
+	accumulateSessionTokens(metadata, result.TokenBreakdown.TotalTokens)
 	if result.RunID != "" {
 		metadata["last_task_id"] = result.RunID
 	}
@@ -496,6 +498,16 @@ func stripUserHistoryMessages(messages []ports.Message) []ports.Message {
 	return trimmed
 }
 
+// accumulateSessionTokens adds a run's LLM-reported tokens to the session's
+// cumulative "total_tokens" counter. Channels use it for rotation limits.
+func accumulateSessionTokens(metadata map[string]string, tokens int) {
+	if tokens <= 0 {
+		return
+	}
+	previous, _ := strconv.Atoi(strings.TrimSpace(metadata["total_tokens"]))
+	metadata["total_tokens"] = strconv.Itoa(previous + tokens)
+}
+
 func updateAwaitUserInputMetadata(session *storage.Session, result *agent.TaskResult) {
 	if session == nil {
 		return
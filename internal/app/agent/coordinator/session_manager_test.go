@@ -840,3 +840,17 @@ func TestListSessions_DelegatesToStore(t *testing.T) {
 		t.Fatalf("expected nil from stub, got %v", ids)
 	}
 }
+
+// --- accumulateSessionTokens ---
+
+func TestApplyTaskResultMetadata_AccumulatesTotalTokens(t *testing.T) {
+	session := &storage.Session{Metadata: map[string]string{"total_tokens": "1200"}}
+	applyTaskResultMetadata(session, &agent.TaskResult{TokenBreakdown: agent.LLMTokenBreakdown{TotalTokens: 300}})
+	if got := session.Metadata["total_tokens"]; got != "1500" {
+		t.Fatalf("total_tokens = %q, want 1500", got)
+	}
+	applyTaskResultMetadata(session, &agent.TaskResult{})
+	if got := session.Metadata["total_tokens"]; got != "1500" {
+		t.Fatalf("total_tokens changed on zero-token run: %q", got)
+	}
+}
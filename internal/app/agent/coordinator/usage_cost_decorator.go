@@ -0,0 +1,69 @@
+package coordinator
+
+import (
+	"strings"
+	"sync"
+
+	"alex/internal/app/agent/cost"
+	"alex/internal/domain/agent"
+	agent "alex/internal/domain/agent/ports/agent"
+	"alex/internal/domain/agent/types"
+)
+
+// usageCostDecorator prices iteration usage events with per-model pricing
+// and accumulates tokens and cost per run, so downstream consumers (SSE,
+// Lark) can show a live spend meter without their own pricing tables.
+type usageCostDecorator struct {
+	sink agent.EventListener
+
+	mu     sync.Mutex
+	totals map[string]usageTotals
+}
+
+type usageTotals struct {
+	tokens  int
+	costUSD float64
+}
+
+// wrapWithUsageCost returns a listener that fills in cost fields on
+// iteration usage events. If listener is nil, returns nil.
+func wrapWithUsageCost(listener agent.EventListener) agent.EventListener {
+	if listener == nil {
+		return nil
+	}
+	return &usageCostDecorator{sink: listener, totals: make(map[string]usageTotals)}
+}
+
+func (d *usageCostDecorator) OnEvent(evt agent.AgentEvent) {
+	if e, ok := evt.(*domain.Event); ok {
+		switch e.Kind {
+		case types.EventIterationUsage:
+			d.price(e)
+		case types.EventResultFinal, types.EventResultCancelled:
+			d.forget(e.GetRunID())
+		}
+	}
+	d.sink.OnEvent(evt)
+}
+
+func (d *usageCostDecorator) price(e *domain.Event) {
+	data := &e.Data
+	_, _, data.CostUSD = cost.CalculateCost(data.PromptTokens, data.CompletionTokens, data.SourceModel)
+
+	runID := strings.TrimSpace(e.GetRunID())
+	d.mu.Lock()
+	totals := d.totals[runID]
+	totals.tokens += data.TokensUsed
+	totals.costUSD += data.CostUSD
+	d.totals[runID] = totals
+	d.mu.Unlock()
+
+	data.CumulativeTokens = totals.tokens
+	data.CumulativeCostUSD = totals.costUSD
+}
+
+func (d *usageCostDecorator) forget(runID string) {
+	d.mu.Lock()
+	delete(d.totals, strings.TrimSpace(runID))
+	d.mu.Unlock()
+}
@@ -0,0 +1,74 @@
+package coordinator
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"alex/internal/app/agent/cost"
+	domain "alex/internal/domain/agent"
+	"alex/internal/domain/agent/ports"
+	agent "alex/internal/domain/agent/ports/agent"
+	"alex/internal/domain/agent/types"
+)
+
+func TestEventDispatcher_PricesIterationUsagePerRun(t *testing.T) {
+	sink := &recordingEventListener{}
+	dispatcher := NewEventDispatcher(sink, nil, EventDispatcherOptions{})
+	listener := dispatcher.Listener()
+
+	usage := ports.TokenUsage{PromptTokens: 2000, CompletionTokens: 500, TotalTokens: 2500, CachedTokens: 1000}
+	_, _, perIteration := cost.CalculateCost(2000, 500, "gpt-4o")
+	emit := func(runID string, iteration int) {
+		base := domain.NewBaseEvent(agent.LevelCore, "session-1", runID, "", time.Now())
+		listener.OnEvent(domain.NewIterationUsageEvent(base, iteration, "gpt-4o", usage))
+	}
+	emit("run-1", 1)
+	emit("run-1", 2)
+	emit("run-2", 1)
+	dispatcher.Flush(t.Context(), "run-1")
+	dispatcher.Flush(t.Context(), "run-2")
+
+	// Serialization is per run, so only order within a run is guaranteed.
+	byRun := map[string][]*domain.WorkflowEventEnvelope{}
+	for _, evt := range sink.snapshot() {
+		if env, ok := evt.(*domain.WorkflowEventEnvelope); ok && env.Event == types.EventIterationUsage {
+			byRun[env.GetRunID()] = append(byRun[env.GetRunID()], env)
+		}
+	}
+	if len(byRun["run-1"]) != 2 || len(byRun["run-2"]) != 1 {
+		t.Fatalf("expected 2+1 usage envelopes, got %d+%d", len(byRun["run-1"]), len(byRun["run-2"]))
+	}
+
+	second := byRun["run-1"][1].Payload
+	if second["iteration"] != 2 || second["model"] != "gpt-4o" || second["cached_tokens"] != 1000 {
+		t.Fatalf("unexpected payload: %+v", second)
+	}
+	if got := second["cost_usd"].(float64); math.Abs(got-perIteration) > 1e-12 {
+		t.Fatalf("cost_usd = %v, want %v", got, perIteration)
+	}
+	if second["cumulative_tokens"] != 5000 {
+		t.Fatalf("cumulative_tokens = %v, want 5000", second["cumulative_tokens"])
+	}
+	if got := second["cumulative_cost_usd"].(float64); math.Abs(got-2*perIteration) > 1e-12 {
+		t.Fatalf("cumulative_cost_usd = %v, want %v", got, 2*perIteration)
+	}
+	if got := byRun["run-2"][0].Payload["cumulative_tokens"]; got != 2500 {
+		t.Fatalf("expected run-2 to accumulate separately, got %v", got)
+	}
+}
+
+func TestUsageCostDecorator_ForgetsRunOnResult(t *testing.T) {
+	sink := &recordingEventListener{}
+	decorator := wrapWithUsageCost(sink).(*usageCostDecorator)
+	base := domain.NewBaseEvent(agent.LevelCore, "session-1", "run-1", "", time.Now())
+
+	decorator.OnEvent(domain.NewIterationUsageEvent(base, 1, "gpt-4o", ports.TokenUsage{PromptTokens: 10, TotalTokens: 10}))
+	decorator.OnEvent(domain.NewEvent(types.EventResultFinal, base))
+	if len(decorator.totals) != 0 {
+		t.Fatalf("expected run totals to be released, got %+v", decorator.totals)
+	}
+	if got := len(sink.snapshot()); got != 2 {
+		t.Fatalf("expected events forwarded, got %d", got)
+	}
+}
@@ -93,6 +93,19 @@ var unifiedHandlers = map[string]unifiedEventHandler{
 	types.EventNodeOutputSummary: func(t *workflowEventTranslator, evt agent.AgentEvent, d *domain.EventData) []*domain.WorkflowEventEnvelope {
 		return t.translateNodeOutputSummary(evt, d)
 	},
+	types.EventIterationUsage: func(t *workflowEventTranslator, evt agent.AgentEvent, d *domain.EventData) []*domain.WorkflowEventEnvelope {
+		return t.singleEnvelope(evt, types.EventIterationUsage, "generation", "", map[string]any{
+			"iteration":           d.Iteration,
+			"model":               d.SourceModel,
+			"prompt_tokens":       d.PromptTokens,
+			"completion_tokens":   d.CompletionTokens,
+			"cached_tokens":       d.CachedTokens,
+			"total_tokens":        d.TokensUsed,
+			"cost_usd":            d.CostUSD,
+			"cumulative_tokens":   d.CumulativeTokens,
+			"cumulative_cost_usd": d.CumulativeCostUSD,
+		})
+	},
 	types.EventNodeOutputDelta: func(t *workflowEventTranslator, evt agent.AgentEvent, d *domain.EventData) []*domain.WorkflowEventEnvelope {
 		return t.translateNodeOutputDelta(evt, d)
 	},
@@ -8,6 +8,7 @@ import (
 	agent "alex/internal/domain/agent/ports/agent"
 	llm "alex/internal/domain/agent/ports/llm"
 	storage "alex/internal/domain/agent/ports/storage"
+	id "alex/internal/shared/utils/id"
 )
 
 // CostTrackingDecorator creates isolated wrappers for LLM clients to track costs per session
@@ -113,20 +114,33 @@ func (w *costTrackingWrapper) recordUsage(ctx context.Context, resp *ports.Compl
 		return
 	}
 
+	// Attribute usage to whoever actually answered; after a provider
+	// failover that differs from the client's configured model.
+	model := w.client.Model()
+	provider := ""
+	if servedProvider, servedModel := resp.ServedBy(); servedModel != "" {
+		model, provider = servedModel, servedProvider
+	}
+	if provider == "" {
+		provider = inferProvider(model)
+	}
+
 	record := storage.UsageRecord{
 		SessionID:    w.sessionID,
-		Model:        w.client.Model(),
-		Provider:     inferProvider(w.client.Model()),
+		UserID:       id.UserIDFromContext(ctx),
+		Model:        model,
+		Provider:     provider,
 		InputTokens:  resp.Usage.PromptTokens,
 		OutputTokens: resp.Usage.CompletionTokens,
 		TotalTokens:  resp.Usage.TotalTokens,
 		Timestamp:    w.clock.Now(),
+		CacheStatus:  resp.CacheStatus(),
 	}
 
 	record.InputCost, record.OutputCost, record.TotalCost = CalculateCost(
 		resp.Usage.PromptTokens,
 		resp.Usage.CompletionTokens,
-		w.client.Model(),
+		model,
 	)
 
 	if err := w.tracker.RecordUsage(ctx, record); err != nil {
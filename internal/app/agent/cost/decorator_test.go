@@ -185,6 +185,10 @@ func (m *mockCostTracker) Export(ctx context.Context, format storage.ExportForma
 	return nil, nil
 }
 
+func (m *mockCostTracker) QueryUsage(ctx context.Context, query storage.UsageQuery) (*storage.UsageReport, error) {
+	return nil, nil
+}
+
 func (m *mockCostTracker) GetRecordsBySession(sessionID string) []storage.UsageRecord {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -716,6 +720,85 @@ func TestCostRecordFields(t *testing.T) {
 	}
 }
 
+// failoverLLMClient answers as if a fallback provider served the call.
+type failoverLLMClient struct {
+	*mockLLMClient
+}
+
+func (m *failoverLLMClient) Complete(ctx context.Context, req ports.CompletionRequest) (*ports.CompletionResponse, error) {
+	resp, err := m.mockLLMClient.Complete(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	resp.Metadata = map[string]any{
+		ports.ResponseMetaProvider:     "deepseek",
+		ports.ResponseMetaModel:        "deepseek-chat",
+		ports.ResponseMetaFailoverFrom: "openai/gpt-4o",
+	}
+	return resp, nil
+}
+
+func TestCostRecordAttributesFailoverProvider(t *testing.T) {
+	t.Parallel()
+
+	tracker := newMockCostTracker()
+	decorator := NewCostTrackingDecorator(tracker, newMockLogger(), newMockClock(time.Now()))
+	ctx := context.Background()
+	wrappedClient := decorator.Wrap(ctx, "failover-session", &failoverLLMClient{newMockLLMClient("gpt-4o")})
+
+	if _, err := wrappedClient.Complete(ctx, ports.CompletionRequest{}); err != nil {
+		t.Fatalf("Complete failed: %v", err)
+	}
+
+	records := tracker.GetRecordsBySession("failover-session")
+	if len(records) != 1 {
+		t.Fatalf("Expected 1 record, got %d", len(records))
+	}
+	if records[0].Model != "deepseek-chat" || records[0].Provider != "deepseek" {
+		t.Fatalf("expected usage attributed to deepseek/deepseek-chat, got %s/%s", records[0].Provider, records[0].Model)
+	}
+	inputCost, outputCost, _ := CalculateCost(100, 50, "deepseek-chat")
+	if absFloat(records[0].InputCost-inputCost) > 0.000001 || absFloat(records[0].OutputCost-outputCost) > 0.000001 {
+		t.Fatalf("expected deepseek pricing, got input=%f output=%f", records[0].InputCost, records[0].OutputCost)
+	}
+}
+
+// cacheHitLLMClient answers as if the response cache served the call.
+type cacheHitLLMClient struct {
+	*mockLLMClient
+}
+
+func (m *cacheHitLLMClient) Complete(ctx context.Context, req ports.CompletionRequest) (*ports.CompletionResponse, error) {
+	return &ports.CompletionResponse{
+		Content:  "cached",
+		Metadata: map[string]any{ports.ResponseMetaCache: ports.ResponseCacheHit},
+	}, nil
+}
+
+func TestCostRecordMarksResponseCacheHitAtZeroCost(t *testing.T) {
+	t.Parallel()
+
+	tracker := newMockCostTracker()
+	decorator := NewCostTrackingDecorator(tracker, newMockLogger(), newMockClock(time.Now()))
+	ctx := context.Background()
+	wrappedClient := decorator.Wrap(ctx, "cache-session", &cacheHitLLMClient{newMockLLMClient("gpt-4o")})
+
+	if _, err := wrappedClient.Complete(ctx, ports.CompletionRequest{}); err != nil {
+		t.Fatalf("Complete failed: %v", err)
+	}
+
+	records := tracker.GetRecordsBySession("cache-session")
+	if len(records) != 1 {
+		t.Fatalf("Expected 1 record, got %d", len(records))
+	}
+	if records[0].CacheStatus != ports.ResponseCacheHit {
+		t.Fatalf("expected cache hit status, got %q", records[0].CacheStatus)
+	}
+	if records[0].TotalCost != 0 || records[0].TotalTokens != 0 {
+		t.Fatalf("expected zero-cost record, got cost=%f tokens=%d", records[0].TotalCost, records[0].TotalTokens)
+	}
+}
+
 // absFloat returns the absolute value of a float64
 func absFloat(x float64) float64 {
 	if x < 0 {
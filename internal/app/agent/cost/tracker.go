@@ -8,6 +8,7 @@ import (
 	"strings"
 	"time"
 
+	"alex/internal/domain/agent/ports"
 	"alex/internal/domain/agent/ports/storage"
 	"alex/internal/shared/logging"
 )
@@ -26,14 +27,28 @@ type CostStore interface {
 type costTracker struct {
 	store  CostStore
 	logger logging.Logger
+	// aggregates is nil when the store cannot persist aggregates; usage
+	// reports are then computed from raw records.
+	aggregates *usageAggregator
 }
 
-// NewCostTracker creates a new cost tracker instance.
+// NewCostTracker creates a new cost tracker instance. When store also
+// implements AggregateStore, per-day usage aggregates are maintained in
+// batches alongside the raw records.
 func NewCostTracker(store CostStore) storage.CostTracker {
-	return &costTracker{
+	t := &costTracker{
 		store:  store,
 		logger: logging.NewComponentLogger("CostTracker"),
 	}
+	if aggStore, ok := store.(AggregateStore); ok {
+		epoch, err := aggStore.AggregationEpoch(time.Now())
+		if err != nil {
+			t.logger.Warn("Usage aggregation disabled: %v", err)
+		} else {
+			t.aggregates = newUsageAggregator(aggStore, store, epoch, t.logger)
+		}
+	}
+	return t
 }
 
 // RecordUsage records a single LLM API call usage
@@ -72,10 +87,47 @@ func (t *costTracker) RecordUsage(ctx context.Context, usage storage.UsageRecord
 		t.logger.Error("Failed to save usage: %v", err)
 		return fmt.Errorf("save usage: %w", err)
 	}
+	if t.aggregates != nil {
+		t.aggregates.add(usage)
+	}
 
 	return nil
 }
 
+// QueryUsage reports usage totals for a user, session or model over whole
+// UTC days, optionally grouped by day, model, user or session.
+func (t *costTracker) QueryUsage(ctx context.Context, query storage.UsageQuery) (*storage.UsageReport, error) {
+	from, to, err := usageDayRange(query.From, query.To)
+	if err != nil {
+		return nil, err
+	}
+	var aggregates []storage.UsageAggregate
+	if t.aggregates != nil {
+		aggregates, err = t.aggregates.aggregatesBetween(ctx, from, to)
+	} else {
+		var records []storage.UsageRecord
+		records, err = t.store.GetByDateRange(ctx, from, to)
+		aggregates = aggregateRecords(records, func(r storage.UsageRecord) bool {
+			return !r.Timestamp.Before(from) && r.Timestamp.Before(to)
+		})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("load usage aggregates: %w", err)
+	}
+	return buildUsageReport(query, from, to, aggregates)
+}
+
+// Drain flushes pending usage aggregates; it implements lifecycle.Drainable.
+func (t *costTracker) Drain(ctx context.Context) error {
+	if t.aggregates == nil {
+		return nil
+	}
+	return t.aggregates.flush(ctx)
+}
+
+// Name implements lifecycle.Drainable.
+func (t *costTracker) Name() string { return "cost-tracker" }
+
 // GetSessionCost returns total cost for a specific session
 func (t *costTracker) GetSessionCost(ctx context.Context, sessionID string) (*storage.CostSummary, error) {
 	records, err := t.store.GetBySession(ctx, sessionID)
@@ -115,6 +167,9 @@ func (t *costTracker) GetSessionStats(ctx context.Context, sessionID string) (*s
 		stats.OutputTokens += record.OutputTokens
 		stats.TotalTokens += record.TotalTokens
 		stats.RequestCount++
+		hit, miss := cacheOutcome(record)
+		stats.CacheHits += hit
+		stats.CacheMisses += miss
 
 		// Aggregate by model
 		stats.ByModel[record.Model] += record.TotalCost
@@ -230,6 +285,9 @@ func (t *costTracker) aggregateRecords(records []storage.UsageRecord) *storage.C
 		summary.OutputTokens += record.OutputTokens
 		summary.TotalTokens += record.TotalTokens
 		summary.RequestCount++
+		hit, miss := cacheOutcome(record)
+		summary.CacheHits += hit
+		summary.CacheMisses += miss
 
 		// Aggregate by model
 		summary.ByModel[record.Model] += record.TotalCost
@@ -249,6 +307,18 @@ func (t *costTracker) aggregateRecords(records []storage.UsageRecord) *storage.C
 	return summary
 }
 
+// cacheOutcome reports a record's response-cache hit and miss counts.
+func cacheOutcome(record storage.UsageRecord) (hit, miss int) {
+	switch record.CacheStatus {
+	case ports.ResponseCacheHit:
+		return 1, 0
+	case ports.ResponseCacheMiss:
+		return 0, 1
+	default:
+		return 0, 0
+	}
+}
+
 // getFilteredRecords retrieves records based on filter criteria
 func (t *costTracker) getFilteredRecords(ctx context.Context, filter storage.ExportFilter) ([]storage.UsageRecord, error) {
 	var records []storage.UsageRecord
@@ -147,6 +147,38 @@ func TestCostTracker_GetSessionCost(t *testing.T) {
 	}
 }
 
+func TestCostTracker_CountsResponseCacheOutcomes(t *testing.T) {
+	now := time.Now()
+	store := &mockCostStore{
+		records: []storage.UsageRecord{
+			{SessionID: "eval", Model: "gpt-4o", TotalTokens: 1500, TotalCost: 0.0125, Timestamp: now, CacheStatus: "miss"},
+			{SessionID: "eval", Model: "gpt-4o", Timestamp: now, CacheStatus: "hit"},
+			{SessionID: "eval", Model: "gpt-4o", Timestamp: now, CacheStatus: "hit"},
+			{SessionID: "eval", Model: "gpt-4o", TotalTokens: 900, TotalCost: 0.01, Timestamp: now},
+		},
+	}
+	tracker := NewCostTracker(store)
+
+	summary, err := tracker.GetSessionCost(context.Background(), "eval")
+	if err != nil {
+		t.Fatalf("GetSessionCost failed: %v", err)
+	}
+	if summary.CacheHits != 2 || summary.CacheMisses != 1 {
+		t.Errorf("cache hits/misses = %d/%d, want 2/1", summary.CacheHits, summary.CacheMisses)
+	}
+	if summary.RequestCount != 4 {
+		t.Errorf("RequestCount = %d, want 4", summary.RequestCount)
+	}
+
+	stats, err := tracker.GetSessionStats(context.Background(), "eval")
+	if err != nil {
+		t.Fatalf("GetSessionStats failed: %v", err)
+	}
+	if stats.CacheHits != 2 || stats.CacheMisses != 1 {
+		t.Errorf("stats cache hits/misses = %d/%d, want 2/1", stats.CacheHits, stats.CacheMisses)
+	}
+}
+
 func abs(x float64) float64 {
 	if x < 0 {
 		return -x
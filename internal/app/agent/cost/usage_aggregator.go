@@ -0,0 +1,333 @@
+package cost
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"alex/internal/domain/agent/ports/storage"
+	"alex/internal/shared/logging"
+)
+
+// AggregateStore persists per-day usage aggregates. Stores that do not
+// implement it get usage reports computed from raw records on every query.
+type AggregateStore interface {
+	LoadAggregates(ctx context.Context, day string) ([]storage.UsageAggregate, bool, error)
+	SaveAggregates(ctx context.Context, day string, aggregates []storage.UsageAggregate) error
+	AggregationEpoch(now time.Time) (time.Time, error)
+}
+
+const (
+	// usageFlushInterval bounds how long recorded usage stays in memory
+	// before it is merged into the persisted aggregates.
+	usageFlushInterval = 5 * time.Second
+	// maxUsageQueryDays caps the range of a single usage query.
+	maxUsageQueryDays = 366
+	usageDayLayout    = "2006-01-02"
+)
+
+type aggregateKey struct {
+	day, userID, sessionID, model, provider string
+}
+
+func keyOf(a storage.UsageAggregate) aggregateKey {
+	return aggregateKey{day: a.Day, userID: a.UserID, sessionID: a.SessionID, model: a.Model, provider: a.Provider}
+}
+
+// usageAggregator batches per-day aggregates in memory and merges them into
+// the AggregateStore on a timer, so recording usage never waits on an
+// aggregate read-modify-write.
+type usageAggregator struct {
+	store    AggregateStore
+	raw      CostStore
+	epoch    time.Time
+	interval time.Duration
+	logger   logging.Logger
+
+	// flushMu serialises load-merge-save cycles and lets queries see a
+	// consistent persisted+pending view.
+	flushMu sync.Mutex
+	mu      sync.Mutex
+	pending map[aggregateKey]*storage.UsageAggregate
+	timer   *time.Timer // armed while pending is non-empty
+}
+
+func newUsageAggregator(store AggregateStore, raw CostStore, epoch time.Time, logger logging.Logger) *usageAggregator {
+	return &usageAggregator{
+		store:    store,
+		raw:      raw,
+		epoch:    epoch,
+		interval: usageFlushInterval,
+		logger:   logger,
+		pending:  make(map[aggregateKey]*storage.UsageAggregate),
+	}
+}
+
+func aggregateOf(record storage.UsageRecord) storage.UsageAggregate {
+	return storage.UsageAggregate{
+		Day:          record.Timestamp.UTC().Format(usageDayLayout),
+		UserID:       record.UserID,
+		SessionID:    record.SessionID,
+		Model:        record.Model,
+		Provider:     record.Provider,
+		InputTokens:  record.InputTokens,
+		OutputTokens: record.OutputTokens,
+		TotalTokens:  record.TotalTokens,
+		TotalCost:    record.TotalCost,
+		RequestCount: 1,
+	}
+}
+
+func addAggregate(dst *storage.UsageAggregate, src storage.UsageAggregate) {
+	dst.InputTokens += src.InputTokens
+	dst.OutputTokens += src.OutputTokens
+	dst.TotalTokens += src.TotalTokens
+	dst.TotalCost += src.TotalCost
+	dst.RequestCount += src.RequestCount
+}
+
+// add queues a recorded usage for the next flush.
+func (a *usageAggregator) add(record storage.UsageRecord) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.addLocked(aggregateOf(record))
+}
+
+func (a *usageAggregator) addLocked(agg storage.UsageAggregate) {
+	key := keyOf(agg)
+	if existing, ok := a.pending[key]; ok {
+		addAggregate(existing, agg)
+	} else {
+		a.pending[key] = &agg
+	}
+	if a.timer == nil {
+		a.timer = time.AfterFunc(a.interval, func() { _ = a.flush(context.Background()) })
+	}
+}
+
+// flush merges every pending aggregate into the store. Days that fail to
+// save are re-queued for the next attempt.
+func (a *usageAggregator) flush(ctx context.Context) error {
+	a.flushMu.Lock()
+	defer a.flushMu.Unlock()
+
+	a.mu.Lock()
+	if a.timer != nil {
+		a.timer.Stop()
+		a.timer = nil
+	}
+	byDay := make(map[string][]storage.UsageAggregate)
+	for _, agg := range a.pending {
+		byDay[agg.Day] = append(byDay[agg.Day], *agg)
+	}
+	a.pending = make(map[aggregateKey]*storage.UsageAggregate)
+	a.mu.Unlock()
+
+	var firstErr error
+	for day, batch := range byDay {
+		if err := a.mergeDayLocked(ctx, day, batch); err != nil {
+			a.logger.Warn("Failed to flush usage aggregates for %s: %v", day, err)
+			if firstErr == nil {
+				firstErr = err
+			}
+			a.mu.Lock()
+			for _, agg := range batch {
+				a.addLocked(agg)
+			}
+			a.mu.Unlock()
+		}
+	}
+	return firstErr
+}
+
+func (a *usageAggregator) mergeDayLocked(ctx context.Context, day string, batch []storage.UsageAggregate) error {
+	persisted, err := a.loadDayLocked(ctx, day)
+	if err != nil {
+		return err
+	}
+	merged := make(map[aggregateKey]*storage.UsageAggregate, len(persisted)+len(batch))
+	for _, agg := range append(persisted, batch...) {
+		key := keyOf(agg)
+		if existing, ok := merged[key]; ok {
+			addAggregate(existing, agg)
+			continue
+		}
+		copied := agg
+		merged[key] = &copied
+	}
+	out := make([]storage.UsageAggregate, 0, len(merged))
+	for _, agg := range merged {
+		out = append(out, *agg)
+	}
+	sortAggregates(out)
+	return a.store.SaveAggregates(ctx, day, out)
+}
+
+// loadDayLocked returns the persisted aggregates for day. A day that started
+// before aggregation was enabled is backfilled once from the raw records
+// written before the epoch. Must be called with flushMu held.
+func (a *usageAggregator) loadDayLocked(ctx context.Context, day string) ([]storage.UsageAggregate, error) {
+	persisted, found, err := a.store.LoadAggregates(ctx, day)
+	if err != nil || found {
+		return persisted, err
+	}
+	start, err := time.Parse(usageDayLayout, day)
+	if err != nil || !start.Before(a.epoch) {
+		return nil, err
+	}
+	// Raw records are filed by local date, so read a day either side.
+	records, err := a.raw.GetByDateRange(ctx, start.Add(-24*time.Hour), start.Add(48*time.Hour))
+	if err != nil {
+		return nil, fmt.Errorf("backfill %s: %w", day, err)
+	}
+	backfilled := aggregateRecords(records, func(r storage.UsageRecord) bool {
+		return r.Timestamp.Before(a.epoch) && r.Timestamp.UTC().Format(usageDayLayout) == day
+	})
+	if err := a.store.SaveAggregates(ctx, day, backfilled); err != nil {
+		return nil, fmt.Errorf("backfill %s: %w", day, err)
+	}
+	return backfilled, nil
+}
+
+// aggregatesBetween returns persisted and pending aggregates for the UTC
+// days in [from, to).
+func (a *usageAggregator) aggregatesBetween(ctx context.Context, from, to time.Time) ([]storage.UsageAggregate, error) {
+	a.flushMu.Lock()
+	defer a.flushMu.Unlock()
+
+	var out []storage.UsageAggregate
+	for day := from; day.Before(to); day = day.AddDate(0, 0, 1) {
+		persisted, err := a.loadDayLocked(ctx, day.Format(usageDayLayout))
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, persisted...)
+	}
+	fromDay, toDay := from.Format(usageDayLayout), to.Format(usageDayLayout)
+	a.mu.Lock()
+	for _, agg := range a.pending {
+		if agg.Day >= fromDay && agg.Day < toDay {
+			out = append(out, *agg)
+		}
+	}
+	a.mu.Unlock()
+	return out, nil
+}
+
+// aggregateRecords folds raw records accepted by keep into aggregates.
+func aggregateRecords(records []storage.UsageRecord, keep func(storage.UsageRecord) bool) []storage.UsageAggregate {
+	merged := make(map[aggregateKey]*storage.UsageAggregate)
+	for _, record := range records {
+		if keep != nil && !keep(record) {
+			continue
+		}
+		agg := aggregateOf(record)
+		if existing, ok := merged[keyOf(agg)]; ok {
+			addAggregate(existing, agg)
+			continue
+		}
+		merged[keyOf(agg)] = &agg
+	}
+	out := make([]storage.UsageAggregate, 0, len(merged))
+	for _, agg := range merged {
+		out = append(out, *agg)
+	}
+	sortAggregates(out)
+	return out
+}
+
+func sortAggregates(aggs []storage.UsageAggregate) {
+	sort.Slice(aggs, func(i, j int) bool {
+		a, b := keyOf(aggs[i]), keyOf(aggs[j])
+		if a.day != b.day {
+			return a.day < b.day
+		}
+		if a.userID != b.userID {
+			return a.userID < b.userID
+		}
+		if a.sessionID != b.sessionID {
+			return a.sessionID < b.sessionID
+		}
+		if a.model != b.model {
+			return a.model < b.model
+		}
+		return a.provider < b.provider
+	})
+}
+
+// usageDayRange widens a query to whole UTC days: the day containing from
+// through the day containing to, unless to falls exactly on midnight.
+func usageDayRange(from, to time.Time) (time.Time, time.Time, error) {
+	if from.IsZero() {
+		return time.Time{}, time.Time{}, fmt.Errorf("usage query needs a start time")
+	}
+	if to.IsZero() {
+		to = time.Now()
+	}
+	from = from.UTC().Truncate(24 * time.Hour)
+	end := to.UTC().Truncate(24 * time.Hour)
+	if end.Before(to.UTC()) {
+		end = end.AddDate(0, 0, 1)
+	}
+	if !from.Before(end) {
+		return time.Time{}, time.Time{}, fmt.Errorf("usage query range is empty: from %s is not before to %s", from.Format(usageDayLayout), end.Format(usageDayLayout))
+	}
+	if days := int(end.Sub(from) / (24 * time.Hour)); days > maxUsageQueryDays {
+		return time.Time{}, time.Time{}, fmt.Errorf("usage query spans %d days; the maximum is %d", days, maxUsageQueryDays)
+	}
+	return from, end, nil
+}
+
+// buildUsageReport filters aggregates by the query and groups the totals.
+func buildUsageReport(query storage.UsageQuery, from, to time.Time, aggregates []storage.UsageAggregate) (*storage.UsageReport, error) {
+	var groupKey func(storage.UsageAggregate) string
+	switch query.GroupBy {
+	case storage.UsageGroupByNone:
+	case storage.UsageGroupByDay:
+		groupKey = func(a storage.UsageAggregate) string { return a.Day }
+	case storage.UsageGroupByModel:
+		groupKey = func(a storage.UsageAggregate) string { return a.Model }
+	case storage.UsageGroupByUser:
+		groupKey = func(a storage.UsageAggregate) string { return a.UserID }
+	case storage.UsageGroupBySession:
+		groupKey = func(a storage.UsageAggregate) string { return a.SessionID }
+	default:
+		return nil, fmt.Errorf("unsupported group_by %q: use day, model, user or session", query.GroupBy)
+	}
+
+	report := &storage.UsageReport{From: from, To: to, GroupBy: query.GroupBy, Groups: []storage.UsageGroup{}}
+	groups := make(map[string]*storage.UsageGroup)
+	for _, agg := range aggregates {
+		if (query.UserID != "" && agg.UserID != query.UserID) ||
+			(query.SessionID != "" && agg.SessionID != query.SessionID) ||
+			(query.Model != "" && agg.Model != query.Model) {
+			continue
+		}
+		addTotals(&report.Totals, agg)
+		if groupKey == nil {
+			continue
+		}
+		key := groupKey(agg)
+		group, ok := groups[key]
+		if !ok {
+			group = &storage.UsageGroup{Key: key}
+			groups[key] = group
+		}
+		addTotals(&group.UsageTotals, agg)
+	}
+	for _, group := range groups {
+		report.Groups = append(report.Groups, *group)
+	}
+	sort.Slice(report.Groups, func(i, j int) bool { return report.Groups[i].Key < report.Groups[j].Key })
+	return report, nil
+}
+
+func addTotals(dst *storage.UsageTotals, agg storage.UsageAggregate) {
+	dst.InputTokens += agg.InputTokens
+	dst.OutputTokens += agg.OutputTokens
+	dst.TotalTokens += agg.TotalTokens
+	dst.TotalCost += agg.TotalCost
+	dst.RequestCount += agg.RequestCount
+}
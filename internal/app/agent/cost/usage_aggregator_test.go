@@ -0,0 +1,183 @@
+package cost
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	storage "alex/internal/domain/agent/ports/storage"
+)
+
+// aggregatingCostStore adds in-memory aggregate persistence to mockCostStore.
+type aggregatingCostStore struct {
+	mockCostStore
+	mu         sync.Mutex
+	epoch      time.Time
+	aggregates map[string][]storage.UsageAggregate
+	saves      int
+}
+
+func (s *aggregatingCostStore) LoadAggregates(_ context.Context, day string) ([]storage.UsageAggregate, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	aggs, ok := s.aggregates[day]
+	return append([]storage.UsageAggregate(nil), aggs...), ok, nil
+}
+
+func (s *aggregatingCostStore) SaveAggregates(_ context.Context, day string, aggregates []storage.UsageAggregate) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.aggregates == nil {
+		s.aggregates = make(map[string][]storage.UsageAggregate)
+	}
+	s.aggregates[day] = append([]storage.UsageAggregate(nil), aggregates...)
+	s.saves++
+	return nil
+}
+
+func (s *aggregatingCostStore) AggregationEpoch(now time.Time) (time.Time, error) {
+	if s.epoch.IsZero() {
+		s.epoch = now
+	}
+	return s.epoch, nil
+}
+
+func newAggregatingTracker(t *testing.T, store *aggregatingCostStore) *costTracker {
+	t.Helper()
+	tracker := NewCostTracker(store).(*costTracker)
+	if tracker.aggregates == nil {
+		t.Fatal("expected aggregation to be enabled")
+	}
+	// Flush only when the test drains, never from the timer.
+	tracker.aggregates.interval = time.Hour
+	return tracker
+}
+
+func TestQueryUsageAggregatesByUserAndModel(t *testing.T) {
+	store := &aggregatingCostStore{}
+	tracker := newAggregatingTracker(t, store)
+	ctx := context.Background()
+	day := time.Now().UTC()
+
+	for _, r := range []storage.UsageRecord{
+		{SessionID: "s1", UserID: "alice", Model: "gpt-4o", InputTokens: 100, OutputTokens: 50, TotalCost: 0.10, Timestamp: day},
+		{SessionID: "s1", UserID: "alice", Model: "gpt-4o", InputTokens: 100, OutputTokens: 50, TotalCost: 0.10, Timestamp: day},
+		{SessionID: "s2", UserID: "alice", Model: "deepseek-chat", InputTokens: 10, OutputTokens: 5, TotalCost: 0.01, Timestamp: day},
+		{SessionID: "s3", UserID: "bob", Model: "gpt-4o", InputTokens: 1000, OutputTokens: 500, TotalCost: 1.00, Timestamp: day},
+	} {
+		if err := tracker.RecordUsage(ctx, r); err != nil {
+			t.Fatalf("RecordUsage: %v", err)
+		}
+	}
+	if store.saves != 0 {
+		t.Fatalf("recording must not write aggregates synchronously, got %d saves", store.saves)
+	}
+
+	query := storage.UsageQuery{UserID: "alice", From: day, To: day, GroupBy: storage.UsageGroupByModel}
+	assertAliceReport := func(label string, report *storage.UsageReport) {
+		t.Helper()
+		if report.Totals.RequestCount != 3 || report.Totals.TotalTokens != 315 {
+			t.Fatalf("%s: unexpected totals %+v", label, report.Totals)
+		}
+		if len(report.Groups) != 2 || report.Groups[0].Key != "deepseek-chat" || report.Groups[1].Key != "gpt-4o" || report.Groups[1].RequestCount != 2 {
+			t.Fatalf("%s: unexpected groups %+v", label, report.Groups)
+		}
+	}
+
+	pending, err := tracker.QueryUsage(ctx, query)
+	if err != nil {
+		t.Fatalf("QueryUsage: %v", err)
+	}
+	assertAliceReport("pending", pending)
+
+	if err := tracker.Drain(ctx); err != nil {
+		t.Fatalf("Drain: %v", err)
+	}
+	if persisted := store.aggregates[day.Format(usageDayLayout)]; len(persisted) != 3 {
+		t.Fatalf("expected 3 persisted aggregates (user, session, model), got %+v", persisted)
+	}
+	reloaded, err := newAggregatingTracker(t, store).QueryUsage(ctx, query)
+	if err != nil {
+		t.Fatalf("QueryUsage after reload: %v", err)
+	}
+	assertAliceReport("persisted", reloaded)
+
+	all, err := tracker.QueryUsage(ctx, storage.UsageQuery{From: day, GroupBy: storage.UsageGroupByUser})
+	if err != nil {
+		t.Fatalf("QueryUsage all users: %v", err)
+	}
+	if len(all.Groups) != 2 || all.Groups[1].Key != "bob" || all.Groups[1].TotalCost != 1.00 {
+		t.Fatalf("unexpected per-user groups %+v", all.Groups)
+	}
+}
+
+func TestQueryUsageBackfillsPreEpochRecordsOnce(t *testing.T) {
+	epoch := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	store := &aggregatingCostStore{epoch: epoch}
+	store.records = []storage.UsageRecord{
+		{SessionID: "old", UserID: "alice", Model: "gpt-4o", TotalTokens: 100, TotalCost: 0.5, Timestamp: epoch.Add(-2 * time.Hour)},
+		{SessionID: "old", UserID: "alice", Model: "gpt-4o", TotalTokens: 100, TotalCost: 0.5, Timestamp: epoch.Add(-26 * time.Hour)},
+	}
+	tracker := newAggregatingTracker(t, store)
+	ctx := context.Background()
+
+	// Recorded after the epoch: counted through the aggregator, not the backfill.
+	if err := tracker.RecordUsage(ctx, storage.UsageRecord{SessionID: "new", UserID: "alice", Model: "gpt-4o", TotalTokens: 10, TotalCost: 0.1, Timestamp: epoch.Add(time.Hour)}); err != nil {
+		t.Fatalf("RecordUsage: %v", err)
+	}
+	if err := tracker.Drain(ctx); err != nil {
+		t.Fatalf("Drain: %v", err)
+	}
+
+	query := storage.UsageQuery{UserID: "alice", From: epoch.AddDate(0, 0, -1), To: epoch, GroupBy: storage.UsageGroupByDay}
+	for i := 0; i < 2; i++ {
+		report, err := tracker.QueryUsage(ctx, query)
+		if err != nil {
+			t.Fatalf("QueryUsage: %v", err)
+		}
+		if report.Totals.RequestCount != 3 || report.Totals.TotalTokens != 210 {
+			t.Fatalf("query %d: expected each record once, got %+v", i, report.Totals)
+		}
+		if len(report.Groups) != 2 || report.Groups[0].Key != "2026-03-09" || report.Groups[1].RequestCount != 2 {
+			t.Fatalf("query %d: unexpected day groups %+v", i, report.Groups)
+		}
+	}
+}
+
+func TestQueryUsageWithoutAggregateStoreUsesRawRecords(t *testing.T) {
+	tracker := NewCostTracker(&mockCostStore{})
+	ctx := context.Background()
+	now := time.Now()
+	if err := tracker.RecordUsage(ctx, storage.UsageRecord{UserID: "alice", Model: "gpt-4o", TotalTokens: 42, TotalCost: 0.2, Timestamp: now}); err != nil {
+		t.Fatalf("RecordUsage: %v", err)
+	}
+	report, err := tracker.QueryUsage(ctx, storage.UsageQuery{UserID: "alice", From: now})
+	if err != nil {
+		t.Fatalf("QueryUsage: %v", err)
+	}
+	if report.Totals.RequestCount != 1 || report.Totals.TotalTokens != 42 {
+		t.Fatalf("unexpected totals %+v", report.Totals)
+	}
+}
+
+func TestQueryUsageRejectsBadQueries(t *testing.T) {
+	tracker := NewCostTracker(&mockCostStore{})
+	ctx := context.Background()
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	cases := []struct {
+		query storage.UsageQuery
+		want  string
+	}{
+		{storage.UsageQuery{}, "needs a start time"},
+		{storage.UsageQuery{From: from, To: from}, "range is empty"},
+		{storage.UsageQuery{From: from, To: from.AddDate(2, 0, 0)}, "maximum is 366"},
+		{storage.UsageQuery{From: from, To: from.AddDate(0, 0, 1), GroupBy: "week"}, "unsupported group_by"},
+	}
+	for _, tc := range cases {
+		if _, err := tracker.QueryUsage(ctx, tc.query); err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("QueryUsage(%+v) = %v, want error containing %q", tc.query, err, tc.want)
+		}
+	}
+}
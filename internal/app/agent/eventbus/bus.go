@@ -0,0 +1,359 @@
+// Package eventbus fans agent events out to independent consumers. Publishers
+// emit each event once; every subscriber drains its own bounded FIFO queue on a
+// dedicated goroutine so a slow or failing consumer cannot stall the agent loop
+// or its siblings.
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	agent "alex/internal/domain/agent/ports/agent"
+	"alex/internal/shared/async"
+	"alex/internal/shared/logging"
+)
+
+const (
+	defaultQueueSize   = 256
+	defaultMaxFailures = 5
+)
+
+// DeliveryMode controls what Publish does when a subscriber queue is full.
+type DeliveryMode int
+
+const (
+	// DeliveryGuaranteed blocks the publisher until the queue has room
+	// (backpressure). Use for consumers that must see every event, such as
+	// the event journal.
+	DeliveryGuaranteed DeliveryMode = iota
+	// DeliveryDropNewest drops the event for this subscriber and records it
+	// in the subscriber's drop count. Use for best-effort consumers (metrics).
+	DeliveryDropNewest
+)
+
+// ErrClosed is returned by Subscribe after the bus has been closed.
+var ErrClosed = errors.New("event bus closed")
+
+// Consumer handles events delivered by the bus. Returning an error counts as
+// a delivery failure for detach accounting.
+type Consumer interface {
+	HandleEvent(event agent.AgentEvent) error
+}
+
+// ConsumerFunc adapts a function to Consumer.
+type ConsumerFunc func(event agent.AgentEvent) error
+
+// HandleEvent implements Consumer.
+func (f ConsumerFunc) HandleEvent(event agent.AgentEvent) error { return f(event) }
+
+// ListenerConsumer adapts an agent.EventListener, which cannot report errors,
+// to Consumer. Only panics count as failures.
+func ListenerConsumer(listener agent.EventListener) Consumer {
+	return ConsumerFunc(func(event agent.AgentEvent) error {
+		listener.OnEvent(event)
+		return nil
+	})
+}
+
+// Subscription describes a consumer registration.
+type Subscription struct {
+	Name     string
+	Consumer Consumer
+	Mode     DeliveryMode
+	// QueueSize bounds the subscriber queue (default 256).
+	QueueSize int
+	// MaxFailures detaches the subscriber after this many consecutive
+	// failures (errors or panics). Zero uses the default (5); negative
+	// disables detaching.
+	MaxFailures int
+}
+
+// SubscriberStats is a point-in-time view of a subscriber's counters.
+type SubscriberStats struct {
+	Name      string
+	Delivered uint64
+	Dropped   uint64
+	Failures  uint64
+	Detached  bool
+}
+
+// Bus is an asynchronous fan-out event bus. It implements agent.EventListener
+// so it can be handed to anything that emits events.
+type Bus struct {
+	logger logging.Logger
+
+	mu          sync.RWMutex
+	subscribers []*subscriber
+	closed      bool
+}
+
+// New creates an empty bus.
+func New(logger logging.Logger) *Bus {
+	if logging.IsNil(logger) {
+		logger = logging.NewComponentLogger("EventBus")
+	}
+	return &Bus{logger: logger}
+}
+
+// Subscribe registers a consumer and starts its delivery goroutine. The
+// returned function detaches the subscriber without draining its queue.
+func (b *Bus) Subscribe(sub Subscription) (func(), error) {
+	if sub.Consumer == nil {
+		return nil, fmt.Errorf("subscribe %q: consumer is nil", sub.Name)
+	}
+	name := strings.TrimSpace(sub.Name)
+	if name == "" {
+		name = "anonymous"
+	}
+	queueSize := sub.QueueSize
+	if queueSize <= 0 {
+		queueSize = defaultQueueSize
+	}
+	maxFailures := sub.MaxFailures
+	if maxFailures == 0 {
+		maxFailures = defaultMaxFailures
+	}
+	s := &subscriber{
+		bus:         b,
+		name:        name,
+		consumer:    sub.Consumer,
+		mode:        sub.Mode,
+		maxFailures: maxFailures,
+		queue:       make(chan busItem, queueSize),
+		detached:    make(chan struct{}),
+	}
+
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return nil, ErrClosed
+	}
+	b.subscribers = append(b.subscribers, s)
+	b.mu.Unlock()
+
+	async.Go(b.logger, "eventbus."+name, s.run)
+	return func() { b.detach(s, "unsubscribed") }, nil
+}
+
+// OnEvent implements agent.EventListener by publishing the event.
+func (b *Bus) OnEvent(event agent.AgentEvent) {
+	b.Publish(event)
+}
+
+// Publish enqueues event for every attached subscriber. Events are delivered
+// to each subscriber in publish order. Guaranteed subscribers apply
+// backpressure when full; drop-mode subscribers never block.
+func (b *Bus) Publish(event agent.AgentEvent) {
+	if b == nil || event == nil {
+		return
+	}
+	for _, s := range b.snapshot() {
+		s.enqueue(busItem{event: event})
+	}
+}
+
+// Flush blocks until every event published before the call has been handled
+// by all attached subscribers, or ctx is done.
+func (b *Bus) Flush(ctx context.Context) error {
+	if b == nil {
+		return nil
+	}
+	type pending struct {
+		sub     *subscriber
+		barrier chan struct{}
+	}
+	var waits []pending
+	for _, s := range b.snapshot() {
+		barrier := make(chan struct{})
+		if s.enqueueBarrier(ctx, barrier) {
+			waits = append(waits, pending{sub: s, barrier: barrier})
+		}
+	}
+	for _, w := range waits {
+		select {
+		case <-w.barrier:
+		case <-w.sub.detached:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return ctx.Err()
+}
+
+// Close flushes pending events, then stops all subscribers. Publishing after
+// Close is a no-op.
+func (b *Bus) Close(ctx context.Context) error {
+	if b == nil {
+		return nil
+	}
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return nil
+	}
+	b.closed = true
+	b.mu.Unlock()
+
+	err := b.Flush(ctx)
+	for _, s := range b.snapshot() {
+		b.detach(s, "bus closed")
+	}
+	return err
+}
+
+// Stats returns counters for every subscriber ever attached, including
+// detached ones, in subscription order.
+func (b *Bus) Stats() []SubscriberStats {
+	if b == nil {
+		return nil
+	}
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	stats := make([]SubscriberStats, 0, len(b.subscribers))
+	for _, s := range b.subscribers {
+		stats = append(stats, s.stats())
+	}
+	return stats
+}
+
+// snapshot returns attached subscribers. Detached subscribers stay in the
+// list for Stats but are skipped here.
+func (b *Bus) snapshot() []*subscriber {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	out := make([]*subscriber, 0, len(b.subscribers))
+	for _, s := range b.subscribers {
+		if !s.isDetached() {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+func (b *Bus) detach(s *subscriber, reason string) {
+	if s.markDetached() {
+		b.logger.Warn("Event bus subscriber %s detached: %s", s.name, reason)
+	}
+}
+
+type busItem struct {
+	event   agent.AgentEvent
+	barrier chan struct{}
+}
+
+type subscriber struct {
+	bus         *Bus
+	name        string
+	consumer    Consumer
+	mode        DeliveryMode
+	maxFailures int
+	queue       chan busItem
+
+	detachOnce sync.Once
+	detached   chan struct{}
+
+	delivered           atomic.Uint64
+	dropped             atomic.Uint64
+	failures            atomic.Uint64
+	consecutiveFailures int // owned by run goroutine
+}
+
+func (s *subscriber) enqueue(item busItem) {
+	if s.mode == DeliveryDropNewest {
+		select {
+		case s.queue <- item:
+		case <-s.detached:
+		default:
+			s.dropped.Add(1)
+		}
+		return
+	}
+	select {
+	case s.queue <- item:
+	case <-s.detached:
+	}
+}
+
+func (s *subscriber) enqueueBarrier(ctx context.Context, barrier chan struct{}) bool {
+	select {
+	case s.queue <- busItem{barrier: barrier}:
+		return true
+	case <-s.detached:
+	case <-ctx.Done():
+	}
+	return false
+}
+
+func (s *subscriber) run() {
+	for {
+		select {
+		case <-s.detached:
+			return
+		case item := <-s.queue:
+			if s.isDetached() {
+				return
+			}
+			if item.barrier != nil {
+				close(item.barrier)
+				continue
+			}
+			s.deliver(item.event)
+		}
+	}
+}
+
+func (s *subscriber) deliver(event agent.AgentEvent) {
+	err := s.safeHandle(event)
+	if err == nil {
+		s.delivered.Add(1)
+		s.consecutiveFailures = 0
+		return
+	}
+	s.failures.Add(1)
+	s.consecutiveFailures++
+	s.bus.logger.Warn("Event bus subscriber %s failed on %s: %v", s.name, event.EventType(), err)
+	if s.maxFailures > 0 && s.consecutiveFailures >= s.maxFailures {
+		s.bus.detach(s, fmt.Sprintf("%d consecutive failures", s.consecutiveFailures))
+	}
+}
+
+func (s *subscriber) safeHandle(event agent.AgentEvent) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return s.consumer.HandleEvent(event)
+}
+
+func (s *subscriber) markDetached() bool {
+	first := false
+	s.detachOnce.Do(func() {
+		close(s.detached)
+		first = true
+	})
+	return first
+}
+
+func (s *subscriber) isDetached() bool {
+	select {
+	case <-s.detached:
+		return true
+	default:
+		return false
+	}
+}
+
+func (s *subscriber) stats() SubscriberStats {
+	return SubscriberStats{
+		Name:      s.name,
+		Delivered: s.delivered.Load(),
+		Dropped:   s.dropped.Load(),
+		Failures:  s.failures.Load(),
+		Detached:  s.isDetached(),
+	}
+}
@@ -0,0 +1,228 @@
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	domain "alex/internal/domain/agent"
+	agent "alex/internal/domain/agent/ports/agent"
+)
+
+func testEvent(seq int) agent.AgentEvent {
+	return &domain.WorkflowEventEnvelope{
+		BaseEvent: domain.NewBaseEvent(agent.LevelCore, "s1", "r1", "", time.Now()),
+		Event:     fmt.Sprintf("test.event.%d", seq),
+	}
+}
+
+type recorder struct {
+	mu     sync.Mutex
+	events []string
+}
+
+func (r *recorder) HandleEvent(event agent.AgentEvent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, event.EventType())
+	return nil
+}
+
+func (r *recorder) snapshot() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.events...)
+}
+
+func mustSubscribe(t *testing.T, bus *Bus, sub Subscription) {
+	t.Helper()
+	if _, err := bus.Subscribe(sub); err != nil {
+		t.Fatalf("Subscribe(%s): %v", sub.Name, err)
+	}
+}
+
+func flush(t *testing.T, bus *Bus) {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := bus.Flush(ctx); err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+}
+
+func statsByName(bus *Bus) map[string]SubscriberStats {
+	out := make(map[string]SubscriberStats)
+	for _, s := range bus.Stats() {
+		out[s.Name] = s
+	}
+	return out
+}
+
+func TestBusPreservesPublishOrderPerSubscriber(t *testing.T) {
+	bus := New(nil)
+	journal := &recorder{}
+	broadcaster := &recorder{}
+	mustSubscribe(t, bus, Subscription{Name: "journal", Consumer: journal, QueueSize: 4})
+	mustSubscribe(t, bus, Subscription{Name: "broadcaster", Consumer: broadcaster})
+
+	const n = 200
+	for i := 0; i < n; i++ {
+		bus.Publish(testEvent(i))
+	}
+	flush(t, bus)
+
+	for name, rec := range map[string]*recorder{"journal": journal, "broadcaster": broadcaster} {
+		got := rec.snapshot()
+		if len(got) != n {
+			t.Fatalf("%s received %d events, want %d", name, len(got), n)
+		}
+		for i, eventType := range got {
+			if want := fmt.Sprintf("test.event.%d", i); eventType != want {
+				t.Fatalf("%s event %d = %s, want %s", name, i, eventType, want)
+			}
+		}
+	}
+}
+
+func TestBusIsolatesFailingConsumers(t *testing.T) {
+	bus := New(nil)
+	healthy := &recorder{}
+	mustSubscribe(t, bus, Subscription{Name: "healthy", Consumer: healthy})
+	mustSubscribe(t, bus, Subscription{
+		Name:        "panicky",
+		Consumer:    ConsumerFunc(func(agent.AgentEvent) error { panic("boom") }),
+		MaxFailures: 3,
+	})
+	mustSubscribe(t, bus, Subscription{
+		Name:        "erroring",
+		Consumer:    ConsumerFunc(func(agent.AgentEvent) error { return errors.New("webhook down") }),
+		MaxFailures: -1,
+	})
+
+	for i := 0; i < 10; i++ {
+		bus.Publish(testEvent(i))
+	}
+	flush(t, bus)
+
+	if got := len(healthy.snapshot()); got != 10 {
+		t.Fatalf("healthy consumer received %d events, want 10", got)
+	}
+	stats := statsByName(bus)
+	if s := stats["panicky"]; !s.Detached || s.Failures != 3 {
+		t.Fatalf("expected panicky detached after 3 failures, got %+v", s)
+	}
+	if s := stats["erroring"]; s.Detached || s.Failures != 10 {
+		t.Fatalf("expected erroring consumer kept with 10 failures, got %+v", s)
+	}
+	if s := stats["healthy"]; s.Delivered != 10 || s.Failures != 0 {
+		t.Fatalf("unexpected healthy stats: %+v", s)
+	}
+}
+
+func TestBusGuaranteedSubscriberAppliesBackpressure(t *testing.T) {
+	bus := New(nil)
+	release := make(chan struct{})
+	journal := &recorder{}
+	mustSubscribe(t, bus, Subscription{
+		Name:      "journal",
+		QueueSize: 2,
+		Consumer: ConsumerFunc(func(event agent.AgentEvent) error {
+			<-release
+			return journal.HandleEvent(event)
+		}),
+	})
+
+	published := make(chan int, 10)
+	go func() {
+		for i := 0; i < 6; i++ {
+			bus.Publish(testEvent(i))
+			published <- i
+		}
+		close(published)
+	}()
+
+	// One event in flight plus a queue of two: the fourth publish must block.
+	deadline := time.After(200 * time.Millisecond)
+	count := 0
+wait:
+	for {
+		select {
+		case _, ok := <-published:
+			if !ok {
+				break wait
+			}
+			count++
+		case <-deadline:
+			break wait
+		}
+	}
+	if count != 3 {
+		t.Fatalf("expected publisher blocked after 3 events, got %d", count)
+	}
+
+	close(release)
+	for range published {
+	}
+	flush(t, bus)
+	if got := len(journal.snapshot()); got != 6 {
+		t.Fatalf("journal received %d events, want all 6", got)
+	}
+	if s := statsByName(bus)["journal"]; s.Dropped != 0 {
+		t.Fatalf("guaranteed subscriber must not drop, got %+v", s)
+	}
+}
+
+func TestBusDropSubscriberNeverBlocksPublisher(t *testing.T) {
+	bus := New(nil)
+	release := make(chan struct{})
+	defer close(release)
+	mustSubscribe(t, bus, Subscription{
+		Name:      "metrics",
+		Mode:      DeliveryDropNewest,
+		QueueSize: 1,
+		Consumer: ConsumerFunc(func(agent.AgentEvent) error {
+			<-release
+			return nil
+		}),
+	})
+
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < 50; i++ {
+			bus.Publish(testEvent(i))
+		}
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("publish blocked on a drop-mode subscriber")
+	}
+	if s := statsByName(bus)["metrics"]; s.Dropped < 48 {
+		t.Fatalf("expected drops to be counted, got %+v", s)
+	}
+}
+
+func TestBusCloseDrainsAndRejectsNewSubscribers(t *testing.T) {
+	bus := New(nil)
+	rec := &recorder{}
+	mustSubscribe(t, bus, Subscription{Name: "journal", Consumer: rec})
+	for i := 0; i < 5; i++ {
+		bus.Publish(testEvent(i))
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := bus.Close(ctx); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if got := len(rec.snapshot()); got != 5 {
+		t.Fatalf("expected pending events drained on close, got %d", got)
+	}
+	bus.Publish(testEvent(99))
+	if _, err := bus.Subscribe(Subscription{Name: "late", Consumer: rec}); !errors.Is(err, ErrClosed) {
+		t.Fatalf("expected ErrClosed, got %v", err)
+	}
+}
@@ -0,0 +1,37 @@
+package preparation
+
+import (
+	"context"
+	"strings"
+
+	"alex/internal/app/preferences"
+	storage "alex/internal/domain/agent/ports/storage"
+	id "alex/internal/shared/utils/id"
+)
+
+// PreferencesContextProvider renders the user's explicit preferences for
+// system prompt injection. Returns "" when the user has none.
+type PreferencesContextProvider func(ctx context.Context, userID string) string
+
+// NewPreferencesContextProvider creates a PreferencesContextProvider backed by a preferences store.
+func NewPreferencesContextProvider(store *preferences.Store) PreferencesContextProvider {
+	return func(ctx context.Context, userID string) string {
+		record, ok, err := store.Get(ctx, userID)
+		if err != nil || !ok {
+			return ""
+		}
+		return preferences.Render(record.Preferences)
+	}
+}
+
+// resolvePreferencesUserID prefers the request identity and falls back to the
+// session owner. An empty result maps to preferences.DefaultUserID in the store.
+func resolvePreferencesUserID(ctx context.Context, session *storage.Session) string {
+	if uid := strings.TrimSpace(id.UserIDFromContext(ctx)); uid != "" {
+		return uid
+	}
+	if session == nil || session.Metadata == nil {
+		return ""
+	}
+	return strings.TrimSpace(session.Metadata["user_id"])
+}
@@ -0,0 +1,92 @@
+package preparation
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	appconfig "alex/internal/app/agent/config"
+	"alex/internal/app/agent/cost"
+	"alex/internal/app/preferences"
+	"alex/internal/domain/agent/ports"
+	agent "alex/internal/domain/agent/ports/agent"
+	storage "alex/internal/domain/agent/ports/storage"
+	id "alex/internal/shared/utils/id"
+)
+
+type preferencesCapturingContextManager struct {
+	stubContextManager
+	mu  sync.Mutex
+	got string
+}
+
+func (m *preferencesCapturingContextManager) BuildWindow(ctx context.Context, session *storage.Session, cfg agent.ContextWindowConfig) (agent.ContextWindow, error) {
+	m.mu.Lock()
+	m.got = cfg.PreferencesContext
+	m.mu.Unlock()
+	return m.stubContextManager.BuildWindow(ctx, session, cfg)
+}
+
+func TestNewPreferencesContextProvider(t *testing.T) {
+	store, _ := preferences.NewStore("")
+	provider := NewPreferencesContextProvider(store)
+	if got := provider(context.Background(), "ou_none"); got != "" {
+		t.Fatalf("expected empty block for user without preferences, got %q", got)
+	}
+	if _, err := store.Update(context.Background(), "ou_1", preferences.AnyVersion, preferences.Preferences{Language: "Chinese"}); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if got := provider(context.Background(), "ou_1"); got != "- Language: Chinese" {
+		t.Fatalf("unexpected block: %q", got)
+	}
+}
+
+func TestPrepareInjectsPreferencesConsistentlyAcrossChannels(t *testing.T) {
+	store, _ := preferences.NewStore("")
+	ctx := context.Background()
+	if _, err := store.Update(ctx, "ou_lark_user", preferences.AnyVersion, preferences.Preferences{Tone: "terse"}); err != nil {
+		t.Fatalf("seed lark user: %v", err)
+	}
+	if _, err := store.Update(ctx, preferences.DefaultUserID, preferences.AnyVersion, preferences.Preferences{Language: "English"}); err != nil {
+		t.Fatalf("seed local user: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		metadata map[string]string
+		want     string
+	}{
+		{name: "lark request identity", ctx: id.WithUserID(ctx, "ou_lark_user"), want: "- Tone: terse"},
+		{name: "session owner", ctx: ctx, metadata: map[string]string{"user_id": "ou_lark_user"}, want: "- Tone: terse"},
+		{name: "local cli or web", ctx: ctx, want: "- Language: English"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			session := &storage.Session{ID: "session-prefs", Messages: []ports.Message{}, Metadata: tt.metadata}
+			ctxMgr := &preferencesCapturingContextManager{}
+			service := NewExecutionPreparationService(ExecutionPreparationDeps{
+				LLMFactory:                 &fakeLLMFactory{client: fakeLLMClient{}},
+				ToolRegistry:               &registryWithList{},
+				SessionStore:               &stubSessionStore{session: session},
+				ContextMgr:                 ctxMgr,
+				Parser:                     stubParser{},
+				Config:                     appconfig.Config{LLMProvider: "mock", LLMModel: "test-model", MaxIterations: 3},
+				Logger:                     agent.NoopLogger{},
+				Clock:                      agent.ClockFunc(func() time.Time { return time.Date(2026, time.March, 1, 9, 0, 0, 0, time.UTC) }),
+				CostDecorator:              cost.NewCostTrackingDecorator(nil, agent.NoopLogger{}, agent.ClockFunc(time.Now)),
+				EventEmitter:               agent.NoopEventListener{},
+				PreferencesContextProvider: NewPreferencesContextProvider(store),
+			})
+			if _, err := service.Prepare(tt.ctx, "Summarize the release notes", session.ID); err != nil {
+				t.Fatalf("prepare: %v", err)
+			}
+			ctxMgr.mu.Lock()
+			defer ctxMgr.mu.Unlock()
+			if ctxMgr.got != tt.want {
+				t.Fatalf("PreferencesContext = %q, want %q", ctxMgr.got, tt.want)
+			}
+		})
+	}
+}
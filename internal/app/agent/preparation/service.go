@@ -57,6 +57,8 @@ type ExecutionPreparationDeps struct {
 	CredentialRefresher CredentialRefresher // Optional: re-resolves CLI credentials at task time
 	ChannelHints        map[string]string   // Optional: channel-name → formatting hint text
 	TurnRecorder        agent.TurnRecorder  // Optional: tape-based audit trail
+
+	PreferencesContextProvider PreferencesContextProvider // Optional: renders per-user preferences for system prompt
 }
 
 // ExecutionPreparationService prepares everything needed before executing a task.
@@ -79,6 +81,8 @@ type ExecutionPreparationService struct {
 	credentialRefresher CredentialRefresher
 	channelHints        map[string]string
 	turnRecorder        agent.TurnRecorder
+
+	preferencesContextProvider PreferencesContextProvider
 }
 
 // NewExecutionPreparationService creates a service instance.
@@ -132,6 +136,8 @@ func NewExecutionPreparationService(deps ExecutionPreparationDeps) *ExecutionPre
 		credentialRefresher: deps.CredentialRefresher,
 		channelHints:        deps.ChannelHints,
 		turnRecorder:        deps.TurnRecorder,
+
+		preferencesContextProvider: deps.PreferencesContextProvider,
 	}
 }
 
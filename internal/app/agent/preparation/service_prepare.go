@@ -286,6 +286,10 @@ func (s *ExecutionPreparationService) buildContextWindow(prepareCtx context.Cont
 	if s.okrContextProvider != nil {
 		okrContext = s.okrContextProvider()
 	}
+	var preferencesContext string
+	if s.preferencesContextProvider != nil {
+		preferencesContext = s.preferencesContextProvider(prepareCtx, resolvePreferencesUserID(prepareCtx, pc.session))
+	}
 
 	channel := appcontext.ChannelFromContext(prepareCtx)
 	channelHint := ""
@@ -307,6 +311,7 @@ func (s *ExecutionPreparationService) buildContextWindow(prepareCtx context.Cont
 		ReplyTagsEnabled:   s.config.Proactive.Prompt.ReplyTagsEnabled,
 		Skills:             buildSkillsConfig(s.config.Proactive.Skills),
 		OKRContext:         okrContext,
+		PreferencesContext: preferencesContext,
 		Unattended:         unattended,
 		Channel:            channel,
 		ChannelHint:        channelHint,
@@ -464,9 +469,10 @@ func (s *ExecutionPreparationService) assembleTaskState(pc *prepareContext) *dom
 
 // assembleServices builds the domain.Services struct for execution.
 func (s *ExecutionPreparationService) assembleServices(pc *prepareContext) domain.Services {
-	toolRegistry := s.selectToolRegistry(pc.ctx, pc.toolMode, pc.toolPreset)
+	toolRegistry := s.selectToolRegistry(s.withSessionToolOverrides(pc.ctx, pc.session), pc.toolMode, pc.toolPreset)
 	return domain.Services{
 		LLM:          pc.streamingClient,
+		LLMProvider:  strings.TrimSpace(pc.effectiveProfile.Provider),
 		ToolExecutor: toolRegistry,
 		ToolLimiter:  NewToolExecutionLimiter(s.config.ToolMaxConcurrent),
 		Parser:       s.parser,
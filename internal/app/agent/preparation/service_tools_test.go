@@ -12,6 +12,7 @@ import (
 	"alex/internal/domain/agent/ports"
 	agent "alex/internal/domain/agent/ports/agent"
 	storage "alex/internal/domain/agent/ports/storage"
+	tools "alex/internal/domain/agent/ports/tools"
 	"alex/internal/domain/agent/presets"
 )
 
@@ -165,3 +166,36 @@ func containsString(list []string, target string) bool {
 	}
 	return false
 }
+
+func TestSelectToolRegistryAppliesSessionToolOverrides(t *testing.T) {
+	session := &storage.Session{ID: "core", Metadata: map[string]string{
+		tools.ToolOverridesMetadataKey: tools.ToolOverrides{Deny: []string{"bash"}}.Encode(),
+	}}
+	deps := ExecutionPreparationDeps{
+		LLMFactory:    &fakeLLMFactory{client: fakeLLMClient{}},
+		ToolRegistry:  &registryWithList{defs: []ports.ToolDefinition{{Name: "final"}, {Name: "file_read"}, {Name: "bash"}}},
+		SessionStore:  &stubSessionStore{session: session},
+		ContextMgr:    stubContextManager{},
+		Parser:        stubParser{},
+		Config:        appconfig.Config{LLMProvider: "mock", LLMModel: "stub", MaxIterations: 1},
+		Logger:        agent.NoopLogger{},
+		Clock:         agent.ClockFunc(func() time.Time { return time.Unix(0, 0) }),
+		CostDecorator: cost.NewCostTrackingDecorator(nil, agent.NoopLogger{}, agent.ClockFunc(time.Now)),
+		EventEmitter:  agent.NoopEventListener{},
+	}
+
+	service := NewExecutionPreparationService(deps)
+	ctx := service.withSessionToolOverrides(context.Background(), session)
+	names := sortedToolNames(service.selectToolRegistry(ctx, presets.ToolModeCLI, "").List())
+	if len(names) != 2 || names[0] != "file_read" || names[1] != "final" {
+		t.Fatalf("expected session deny list to hide bash, got %v", names)
+	}
+
+	// Overrides already on ctx (e.g. inherited by a subagent) take precedence.
+	ctx = appcontext.WithToolOverrides(context.Background(), tools.ToolOverrides{Allow: []string{"final"}})
+	ctx = service.withSessionToolOverrides(ctx, session)
+	names = sortedToolNames(service.selectToolRegistry(ctx, presets.ToolModeCLI, "").List())
+	if len(names) != 1 || names[0] != "final" {
+		t.Fatalf("expected ctx overrides to win, got %v", names)
+	}
+}
@@ -38,6 +38,9 @@ func (s *ExecutionPreparationService) selectToolRegistry(ctx context.Context, to
 		s.logger.Debug("Using filtered registry (orchestration excluded) for nested call")
 	}
 	registry = s.applyToolPolicy(ctx, registry)
+	if overrides, ok := appcontext.ToolOverridesFromContext(ctx); ok {
+		registry = presets.WithToolOverrides(registry, overrides)
+	}
 
 	// Apply preset configured for subagents (context overrides allowed)
 	return s.presetResolver.ResolveToolRegistry(ctx, registry, toolMode, configPreset)
@@ -68,3 +71,16 @@ func (s *ExecutionPreparationService) applyToolPolicy(ctx context.Context, regis
 	}
 	return registry
 }
+
+// withSessionToolOverrides attaches the tool overrides persisted on session
+// unless the caller already set overrides on ctx.
+func (s *ExecutionPreparationService) withSessionToolOverrides(ctx context.Context, session *storage.Session) context.Context {
+	if session == nil {
+		return ctx
+	}
+	withOverrides, err := appcontext.WithSessionToolOverrides(ctx, session.Metadata)
+	if err != nil {
+		s.logger.Warn("Ignoring tool overrides on session %s: %v", session.ID, err)
+	}
+	return withOverrides
+}
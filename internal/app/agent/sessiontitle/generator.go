@@ -0,0 +1,170 @@
+package sessiontitle
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"alex/internal/domain/agent/ports"
+	llm "alex/internal/domain/agent/ports/llm"
+	utils "alex/internal/shared/utils"
+)
+
+const (
+	transcriptTurns        = 6
+	transcriptMessageChars = 400
+	minTagWordChars        = 4
+)
+
+// Result is a generated title with optional topic tags.
+type Result struct {
+	Title string   `json:"title"`
+	Tags  []string `json:"tags"`
+}
+
+// Generator produces a title for a conversation.
+type Generator interface {
+	Generate(ctx context.Context, messages []ports.Message) (Result, error)
+}
+
+// ClientFunc resolves the LLM client used for a generation. It is called per
+// attempt so credential refreshes take effect between retries.
+type ClientFunc func(ctx context.Context) (llm.LLMClient, error)
+
+// LLMGenerator asks a (cheap) model for a title and tags.
+type LLMGenerator struct {
+	client ClientFunc
+}
+
+// NewLLMGenerator creates an LLM-backed generator.
+func NewLLMGenerator(client ClientFunc) *LLMGenerator {
+	return &LLMGenerator{client: client}
+}
+
+// Generate implements Generator.
+func (g *LLMGenerator) Generate(ctx context.Context, messages []ports.Message) (Result, error) {
+	if g == nil || g.client == nil {
+		return Result{}, fmt.Errorf("llm client not configured")
+	}
+	transcript := buildTranscript(messages)
+	if transcript == "" {
+		return Result{}, fmt.Errorf("no conversation to title")
+	}
+	client, err := g.client(ctx)
+	if err != nil {
+		return Result{}, err
+	}
+	resp, err := client.Complete(ctx, ports.CompletionRequest{
+		Messages: []ports.Message{
+			{
+				Role: "system",
+				Content: "You title chat sessions for a session list.\n" +
+					"- title: <= 32 characters, same language as the user, no quotes or trailing punctuation.\n" +
+					fmt.Sprintf("- tags: up to %d lowercase topic keywords.\n", MaxTags) +
+					`Respond ONLY with JSON: {"title":"...","tags":["..."]}`,
+			},
+			{Role: "user", Content: transcript},
+		},
+		Temperature: 0.2,
+		MaxTokens:   96,
+		Metadata:    map[string]any{"intent": "session_title"},
+	})
+	if err != nil {
+		return Result{}, err
+	}
+	if resp == nil {
+		return Result{}, fmt.Errorf("empty completion")
+	}
+	return parseResult(resp.Content)
+}
+
+func parseResult(content string) (Result, error) {
+	content = strings.TrimSpace(content)
+	start := strings.Index(content, "{")
+	end := strings.LastIndex(content, "}")
+	if start < 0 || end <= start {
+		return Result{}, fmt.Errorf("unparsable title response")
+	}
+	var result Result
+	if err := json.Unmarshal([]byte(content[start:end+1]), &result); err != nil {
+		return Result{}, fmt.Errorf("unparsable title response: %w", err)
+	}
+	result.Title = strings.Trim(strings.TrimSpace(result.Title), `"'.。`)
+	return result, nil
+}
+
+// buildTranscript renders the most recent user turns, clipped per message.
+func buildTranscript(messages []ports.Message) string {
+	var lines []string
+	for _, msg := range ports.KeepRecentTurns(messages, transcriptTurns) {
+		role := strings.TrimSpace(msg.Role)
+		if role == "assistant" && strings.TrimSpace(msg.Content) == "" {
+			continue
+		}
+		if role != "assistant" && !isUserTurn(msg) {
+			continue
+		}
+		content := utils.Truncate(strings.TrimSpace(msg.Content), transcriptMessageChars, "…")
+		if content == "" {
+			continue
+		}
+		lines = append(lines, role+": "+content)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Heuristic derives a title from the first user message and tags from the
+// most frequent longer words across user messages. It needs no network access.
+func Heuristic(messages []ports.Message) Result {
+	var result Result
+	counts := map[string]int{}
+	firstSeen := map[string]int{}
+	for _, msg := range messages {
+		if !isUserTurn(msg) {
+			continue
+		}
+		if result.Title == "" {
+			result.Title = utils.NormalizeSessionTitle(msg.Content)
+		}
+		for _, word := range strings.FieldsFunc(strings.ToLower(msg.Content), func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '-' && r != '_'
+		}) {
+			if len([]rune(word)) < minTagWordChars || stopWords[word] {
+				continue
+			}
+			if _, ok := firstSeen[word]; !ok {
+				firstSeen[word] = len(firstSeen)
+			}
+			counts[word]++
+		}
+	}
+	words := make([]string, 0, len(counts))
+	for word := range counts {
+		words = append(words, word)
+	}
+	sort.Slice(words, func(i, j int) bool {
+		if counts[words[i]] != counts[words[j]] {
+			return counts[words[i]] > counts[words[j]]
+		}
+		return firstSeen[words[i]] < firstSeen[words[j]]
+	})
+	if len(words) > MaxTags {
+		words = words[:MaxTags]
+	}
+	result.Tags = words
+	return result
+}
+
+var stopWords = map[string]bool{
+	"about": true, "after": true, "again": true, "also": true, "could": true,
+	"does": true, "from": true, "have": true, "help": true, "here": true,
+	"into": true, "just": true, "like": true, "make": true, "need": true,
+	"please": true, "should": true, "some": true, "than": true, "that": true,
+	"their": true, "them": true, "then": true, "there": true, "these": true,
+	"they": true, "this": true, "what": true, "when": true, "where": true,
+	"which": true, "while": true, "with": true, "would": true, "your": true,
+	"want": true, "will": true,
+}
@@ -0,0 +1,430 @@
+// Package sessiontitle generates short titles and topic tags for sessions so
+// session lists are searchable by topic instead of raw IDs. Generation runs
+// after a task completes, off the critical path, and never overwrites a title
+// the user set explicitly.
+package sessiontitle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"alex/internal/domain/agent/ports"
+	storage "alex/internal/domain/agent/ports/storage"
+	"alex/internal/shared/async"
+	"alex/internal/shared/logging"
+	utils "alex/internal/shared/utils"
+	id "alex/internal/shared/utils/id"
+	"golang.org/x/time/rate"
+)
+
+// Session metadata keys written by the titler.
+const (
+	MetadataTitle       = "title"
+	MetadataTags        = "tags"
+	MetadataSource      = "title_source"
+	MetadataGenerations = "title_generations"
+	MetadataTurns       = "title_turns"
+)
+
+// Title sources recorded in MetadataSource.
+const (
+	SourceAuto = "auto"
+	SourceUser = "user"
+)
+
+// MaxTags bounds the number of topic tags stored per session.
+const MaxTags = 3
+
+const (
+	defaultRetitleAfterTurns = 8
+	defaultMinInterval       = 2 * time.Second
+	defaultBurst             = 3
+	defaultMaxAttempts       = 3
+	defaultRetryBackoff      = 2 * time.Second
+	defaultAttemptTimeout    = 15 * time.Second
+	maxTagChars              = 24
+)
+
+// maxGenerations is the initial title plus one re-title after significant
+// new activity.
+const maxGenerations = 2
+
+// ErrEmptyOverride is returned when an override carries neither a title nor tags.
+var ErrEmptyOverride = errors.New("title or tags required")
+
+// Config tunes automatic titling. The zero value enables titling with defaults.
+type Config struct {
+	Disabled bool
+	// RetitleAfterTurns is the number of new user turns after the first
+	// generation that triggers the single re-title. Negative disables it.
+	RetitleAfterTurns int
+	// MinInterval and Burst rate-limit generations across all sessions.
+	MinInterval time.Duration
+	Burst       int
+	// MaxAttempts and RetryBackoff control retries of the primary generator
+	// before falling back to the heuristic.
+	MaxAttempts    int
+	RetryBackoff   time.Duration
+	AttemptTimeout time.Duration
+	// SkipGuests skips sessions without an owning user_id.
+	SkipGuests bool
+}
+
+func (c Config) withDefaults() Config {
+	if c.RetitleAfterTurns == 0 {
+		c.RetitleAfterTurns = defaultRetitleAfterTurns
+	}
+	if c.MinInterval <= 0 {
+		c.MinInterval = defaultMinInterval
+	}
+	if c.Burst <= 0 {
+		c.Burst = defaultBurst
+	}
+	if c.MaxAttempts <= 0 {
+		c.MaxAttempts = defaultMaxAttempts
+	}
+	if c.RetryBackoff <= 0 {
+		c.RetryBackoff = defaultRetryBackoff
+	}
+	if c.AttemptTimeout <= 0 {
+		c.AttemptTimeout = defaultAttemptTimeout
+	}
+	return c
+}
+
+// Service decides when a session needs a title and persists the result.
+type Service struct {
+	store     storage.SessionStore
+	generator Generator
+	cfg       Config
+	limiter   *rate.Limiter
+	logger    logging.Logger
+	sleep     func(context.Context, time.Duration) error
+
+	mu       sync.Mutex
+	inflight map[string]struct{}
+}
+
+// New creates a titler. A nil generator uses the offline heuristic only.
+func New(store storage.SessionStore, generator Generator, cfg Config, logger logging.Logger) *Service {
+	cfg = cfg.withDefaults()
+	if logging.IsNil(logger) {
+		logger = logging.NewComponentLogger("SessionTitle")
+	}
+	return &Service{
+		store:     store,
+		generator: generator,
+		cfg:       cfg,
+		limiter:   rate.NewLimiter(rate.Every(cfg.MinInterval), cfg.Burst),
+		logger:    logger,
+		sleep:     sleepContext,
+		inflight:  make(map[string]struct{}),
+	}
+}
+
+// OnTaskCompleted schedules titling for sessionID in the background. It never
+// blocks the caller; concurrent requests for the same session are coalesced.
+func (s *Service) OnTaskCompleted(ctx context.Context, sessionID string) {
+	if s == nil || s.cfg.Disabled || s.store == nil || utils.IsBlank(sessionID) {
+		return
+	}
+	if !s.begin(sessionID) {
+		return
+	}
+	bgCtx := context.Background()
+	if logID := id.LogIDFromContext(ctx); logID != "" {
+		bgCtx = id.WithLogID(bgCtx, logID)
+	}
+	async.Go(s.logger, "session-title", func() {
+		defer s.end(sessionID)
+		if _, err := s.Refresh(bgCtx, sessionID); err != nil {
+			s.logger.Warn("Session title generation failed: %v", err)
+		}
+	})
+}
+
+// Refresh titles sessionID synchronously when it is due. It reports whether a
+// new title was stored.
+func (s *Service) Refresh(ctx context.Context, sessionID string) (bool, error) {
+	if s == nil || s.cfg.Disabled || s.store == nil {
+		return false, nil
+	}
+	session, err := s.store.Get(ctx, sessionID)
+	if err != nil {
+		return false, fmt.Errorf("load session: %w", err)
+	}
+	if !s.due(session) {
+		return false, nil
+	}
+	if !s.limiter.Allow() {
+		// Left due; the next completed task retries.
+		s.logger.Debug("Session title generation rate-limited for %s", sessionID)
+		return false, nil
+	}
+
+	result := s.generate(ctx, session)
+	if result.Title == "" {
+		return false, nil
+	}
+
+	// Re-read so a user override or concurrent save that landed during
+	// generation is not clobbered.
+	latest, err := s.store.Get(ctx, sessionID)
+	if err != nil {
+		return false, fmt.Errorf("reload session: %w", err)
+	}
+	if !s.due(latest) {
+		return false, nil
+	}
+	metadata := storage.EnsureMetadata(latest)
+	metadata[MetadataTitle] = result.Title
+	setTags(metadata, result.Tags)
+	metadata[MetadataSource] = SourceAuto
+	metadata[MetadataGenerations] = strconv.Itoa(generations(metadata) + 1)
+	metadata[MetadataTurns] = strconv.Itoa(UserTurns(latest))
+	if err := s.store.Save(ctx, latest); err != nil {
+		return false, fmt.Errorf("save session: %w", err)
+	}
+	return true, nil
+}
+
+// SetTitle applies a user override and persists it. Overridden titles are
+// never replaced by automatic generation.
+func (s *Service) SetTitle(ctx context.Context, sessionID, title string, tags []string) (*storage.Session, error) {
+	if s == nil || s.store == nil {
+		return nil, fmt.Errorf("session titles not configured")
+	}
+	session, err := s.store.Get(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if err := ApplyOverride(session, title, tags); err != nil {
+		return nil, err
+	}
+	if err := s.store.Save(ctx, session); err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+// Current returns the stored title and tags for sessionID.
+func (s *Service) Current(ctx context.Context, sessionID string) (string, []string, error) {
+	if s == nil || s.store == nil {
+		return "", nil, fmt.Errorf("session titles not configured")
+	}
+	session, err := s.store.Get(ctx, sessionID)
+	if err != nil {
+		return "", nil, err
+	}
+	return strings.TrimSpace(session.Metadata[MetadataTitle]), Tags(session.Metadata), nil
+}
+
+// ApplyOverride records a user-chosen title and/or tags on session. A nil
+// tags slice keeps the current tags.
+func ApplyOverride(session *storage.Session, title string, tags []string) error {
+	if session == nil {
+		return fmt.Errorf("session is nil")
+	}
+	title = utils.NormalizeSessionTitle(title)
+	if title == "" && tags == nil {
+		return ErrEmptyOverride
+	}
+	metadata := storage.EnsureMetadata(session)
+	if title != "" {
+		metadata[MetadataTitle] = title
+	}
+	if tags != nil {
+		setTags(metadata, tags)
+	}
+	metadata[MetadataSource] = SourceUser
+	return nil
+}
+
+// Tags returns the topic tags stored in session metadata.
+func Tags(metadata map[string]string) []string {
+	raw := strings.TrimSpace(metadata[MetadataTags])
+	if raw == "" {
+		return nil
+	}
+	var tags []string
+	for _, tag := range strings.Split(raw, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// Matches reports whether query occurs in the title or any tag
+// (case-insensitive). An empty query matches everything.
+func Matches(title string, tags []string, query string) bool {
+	query = utils.TrimLower(query)
+	if query == "" {
+		return true
+	}
+	if strings.Contains(strings.ToLower(title), query) {
+		return true
+	}
+	for _, tag := range tags {
+		if strings.Contains(strings.ToLower(tag), query) {
+			return true
+		}
+	}
+	return false
+}
+
+// UserTurns counts user-authored messages in the session.
+func UserTurns(session *storage.Session) int {
+	if session == nil {
+		return 0
+	}
+	turns := 0
+	for _, msg := range session.Messages {
+		if isUserTurn(msg) {
+			turns++
+		}
+	}
+	return turns
+}
+
+// isUserTurn reports whether msg was typed by the user rather than injected
+// into the user role (proactive context, notices, checkpoints).
+func isUserTurn(msg ports.Message) bool {
+	if !strings.EqualFold(strings.TrimSpace(msg.Role), "user") {
+		return false
+	}
+	switch msg.Source {
+	case ports.MessageSourceUnknown, ports.MessageSourceUserInput, ports.MessageSourceUserHistory:
+		return true
+	default:
+		return false
+	}
+}
+
+// due reports whether session should be (re-)titled now.
+func (s *Service) due(session *storage.Session) bool {
+	if session == nil {
+		return false
+	}
+	metadata := session.Metadata
+	if metadata[MetadataSource] == SourceUser {
+		return false
+	}
+	if s.cfg.SkipGuests && utils.IsBlank(metadata["user_id"]) {
+		return false
+	}
+	turns := UserTurns(session)
+	if turns == 0 {
+		return false
+	}
+	switch gens := generations(metadata); {
+	case gens == 0:
+		return true
+	case gens < maxGenerations && s.cfg.RetitleAfterTurns > 0:
+		last, _ := strconv.Atoi(metadata[MetadataTurns])
+		return turns-last >= s.cfg.RetitleAfterTurns
+	default:
+		return false
+	}
+}
+
+// generate tries the primary generator with retries, then falls back to the
+// heuristic so offline deployments still get titles.
+func (s *Service) generate(ctx context.Context, session *storage.Session) Result {
+	messages := session.Messages
+	if s.generator != nil {
+		backoff := s.cfg.RetryBackoff
+		for attempt := 1; attempt <= s.cfg.MaxAttempts; attempt++ {
+			attemptCtx, cancel := context.WithTimeout(ctx, s.cfg.AttemptTimeout)
+			result, err := s.generator.Generate(attemptCtx, messages)
+			cancel()
+			if err == nil {
+				if result = normalizeResult(result); result.Title != "" {
+					return result
+				}
+				err = errors.New("empty title")
+			}
+			s.logger.Warn("Session title attempt %d/%d failed: %v", attempt, s.cfg.MaxAttempts, err)
+			if attempt == s.cfg.MaxAttempts {
+				break
+			}
+			if s.sleep(ctx, backoff) != nil {
+				break
+			}
+			backoff *= 2
+		}
+	}
+	return normalizeResult(Heuristic(messages))
+}
+
+func (s *Service) begin(sessionID string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.inflight[sessionID]; ok {
+		return false
+	}
+	s.inflight[sessionID] = struct{}{}
+	return true
+}
+
+func (s *Service) end(sessionID string) {
+	s.mu.Lock()
+	delete(s.inflight, sessionID)
+	s.mu.Unlock()
+}
+
+func generations(metadata map[string]string) int {
+	n, _ := strconv.Atoi(metadata[MetadataGenerations])
+	return n
+}
+
+func setTags(metadata map[string]string, tags []string) {
+	tags = normalizeTags(tags)
+	if len(tags) == 0 {
+		delete(metadata, MetadataTags)
+		return
+	}
+	metadata[MetadataTags] = strings.Join(tags, ",")
+}
+
+func normalizeResult(result Result) Result {
+	return Result{
+		Title: utils.NormalizeSessionTitle(result.Title),
+		Tags:  normalizeTags(result.Tags),
+	}
+}
+
+func normalizeTags(tags []string) []string {
+	seen := make(map[string]bool, len(tags))
+	out := make([]string, 0, MaxTags)
+	for _, tag := range tags {
+		tag = utils.TrimLower(strings.Trim(strings.TrimSpace(tag), "#"))
+		tag = strings.ReplaceAll(tag, ",", " ")
+		tag = utils.Truncate(strings.Join(strings.Fields(tag), "-"), maxTagChars, "")
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		out = append(out, tag)
+		if len(out) == MaxTags {
+			break
+		}
+	}
+	return out
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
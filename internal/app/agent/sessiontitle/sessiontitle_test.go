@@ -0,0 +1,288 @@
+package sessiontitle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"alex/internal/domain/agent/ports"
+	llm "alex/internal/domain/agent/ports/llm"
+	storage "alex/internal/domain/agent/ports/storage"
+)
+
+type memorySessionStore struct {
+	mu       sync.Mutex
+	sessions map[string]*storage.Session
+}
+
+func newMemorySessionStore(sessions ...*storage.Session) *memorySessionStore {
+	store := &memorySessionStore{sessions: map[string]*storage.Session{}}
+	for _, session := range sessions {
+		store.sessions[session.ID] = session
+	}
+	return store
+}
+
+func (m *memorySessionStore) Create(context.Context) (*storage.Session, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *memorySessionStore) Get(_ context.Context, id string) (*storage.Session, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	session, ok := m.sessions[id]
+	if !ok {
+		return nil, fmt.Errorf("session %s not found", id)
+	}
+	cloned := *session
+	cloned.Metadata = make(map[string]string, len(session.Metadata))
+	for k, v := range session.Metadata {
+		cloned.Metadata[k] = v
+	}
+	return &cloned, nil
+}
+
+func (m *memorySessionStore) Save(_ context.Context, session *storage.Session) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.sessions[session.ID] = session
+	return nil
+}
+
+func (m *memorySessionStore) List(context.Context, int, int) ([]string, error) { return nil, nil }
+func (m *memorySessionStore) Delete(context.Context, string) error             { return nil }
+
+func (m *memorySessionStore) metadata(t *testing.T, id string) map[string]string {
+	t.Helper()
+	session, err := m.Get(context.Background(), id)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	return session.Metadata
+}
+
+func (m *memorySessionStore) appendUserTurns(t *testing.T, id string, n int) {
+	t.Helper()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	session := m.sessions[id]
+	for i := 0; i < n; i++ {
+		session.Messages = append(session.Messages,
+			ports.Message{Role: "user", Content: fmt.Sprintf("follow-up %d", i), Source: ports.MessageSourceUserInput},
+			ports.Message{Role: "assistant", Content: "ok", Source: ports.MessageSourceAssistantReply},
+		)
+	}
+}
+
+type fakeLLM struct {
+	mu       sync.Mutex
+	replies  []string
+	failures int
+	calls    int
+}
+
+func (f *fakeLLM) Complete(context.Context, ports.CompletionRequest) (*ports.CompletionResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls++
+	if f.failures > 0 {
+		f.failures--
+		return nil, errors.New("provider unavailable")
+	}
+	reply := f.replies[0]
+	if len(f.replies) > 1 {
+		f.replies = f.replies[1:]
+	}
+	return &ports.CompletionResponse{Content: reply}, nil
+}
+
+func (f *fakeLLM) Model() string { return "fake-mini" }
+
+func (f *fakeLLM) callCount() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.calls
+}
+
+func newTestService(store storage.SessionStore, client *fakeLLM, cfg Config) *Service {
+	var gen Generator
+	if client != nil {
+		gen = NewLLMGenerator(func(context.Context) (llm.LLMClient, error) { return client, nil })
+	}
+	if cfg.MinInterval == 0 {
+		cfg.MinInterval = time.Millisecond
+		cfg.Burst = 100
+	}
+	svc := New(store, gen, cfg, nil)
+	svc.sleep = func(context.Context, time.Duration) error { return nil }
+	return svc
+}
+
+func newSession(id string, messages ...string) *storage.Session {
+	session := &storage.Session{ID: id, Metadata: map[string]string{"user_id": "ou_user"}}
+	for _, content := range messages {
+		session.Messages = append(session.Messages,
+			ports.Message{Role: "user", Content: content, Source: ports.MessageSourceUserInput},
+			ports.Message{Role: "assistant", Content: "done", Source: ports.MessageSourceAssistantReply},
+		)
+	}
+	return session
+}
+
+func TestRefreshTitlesAfterFirstTaskAndRetitlesOnce(t *testing.T) {
+	store := newMemorySessionStore(newSession("s1", "deploy the api gateway to staging"))
+	client := &fakeLLM{replies: []string{
+		`{"title":"Staging gateway deploy","tags":["Deploy","gateway","staging","extra"]}`,
+		"```json\n{\"title\":\"Gateway rollout\",\"tags\":[\"rollout\"]}\n```",
+	}}
+	svc := newTestService(store, client, Config{RetitleAfterTurns: 3})
+	ctx := context.Background()
+
+	if ok, err := svc.Refresh(ctx, "s1"); err != nil || !ok {
+		t.Fatalf("first Refresh = %v, %v; want titled", ok, err)
+	}
+	meta := store.metadata(t, "s1")
+	if meta[MetadataTitle] != "Staging gateway deploy" || meta[MetadataTags] != "deploy,gateway,staging" {
+		t.Fatalf("unexpected metadata after first title: %v", meta)
+	}
+
+	// Below the threshold: no new generation.
+	store.appendUserTurns(t, "s1", 2)
+	if ok, _ := svc.Refresh(ctx, "s1"); ok {
+		t.Fatal("expected no re-title before the turn threshold")
+	}
+
+	store.appendUserTurns(t, "s1", 1)
+	if ok, err := svc.Refresh(ctx, "s1"); err != nil || !ok {
+		t.Fatalf("re-title Refresh = %v, %v; want titled", ok, err)
+	}
+	if got := store.metadata(t, "s1")[MetadataTitle]; got != "Gateway rollout" {
+		t.Fatalf("expected re-generated title, got %q", got)
+	}
+
+	// Generation budget exhausted: further activity never re-titles.
+	store.appendUserTurns(t, "s1", 10)
+	if ok, _ := svc.Refresh(ctx, "s1"); ok {
+		t.Fatal("expected at most one re-title")
+	}
+	if client.callCount() != 2 {
+		t.Fatalf("expected 2 LLM calls, got %d", client.callCount())
+	}
+}
+
+func TestRefreshSkipsEmptyAndGuestSessions(t *testing.T) {
+	guest := newSession("guest", "hello there")
+	guest.Metadata = nil
+	store := newMemorySessionStore(newSession("empty"), guest)
+	client := &fakeLLM{replies: []string{`{"title":"x"}`}}
+	svc := newTestService(store, client, Config{SkipGuests: true})
+
+	for _, id := range []string{"empty", "guest"} {
+		if ok, err := svc.Refresh(context.Background(), id); ok || err != nil {
+			t.Fatalf("Refresh(%s) = %v, %v; want skipped", id, ok, err)
+		}
+	}
+	if client.callCount() != 0 {
+		t.Fatalf("expected no LLM calls, got %d", client.callCount())
+	}
+}
+
+func TestUserOverridePersistsAgainstAutoTitling(t *testing.T) {
+	store := newMemorySessionStore(newSession("s1", "refactor the billing module"))
+	client := &fakeLLM{replies: []string{`{"title":"Billing refactor","tags":["billing"]}`}}
+	svc := newTestService(store, client, Config{RetitleAfterTurns: 1})
+	ctx := context.Background()
+
+	if _, err := svc.SetTitle(ctx, "s1", "Q3 billing cleanup", []string{"finance", "#Billing"}); err != nil {
+		t.Fatalf("SetTitle: %v", err)
+	}
+	store.appendUserTurns(t, "s1", 5)
+	if ok, _ := svc.Refresh(ctx, "s1"); ok {
+		t.Fatal("auto titling must not replace a user override")
+	}
+	meta := store.metadata(t, "s1")
+	if meta[MetadataTitle] != "Q3 billing cleanup" || meta[MetadataTags] != "finance,billing" || meta[MetadataSource] != SourceUser {
+		t.Fatalf("override not persisted: %v", meta)
+	}
+
+	// Tags-only override keeps the title.
+	if _, err := svc.SetTitle(ctx, "s1", "", []string{"ops"}); err != nil {
+		t.Fatalf("SetTitle tags: %v", err)
+	}
+	if meta := store.metadata(t, "s1"); meta[MetadataTitle] != "Q3 billing cleanup" || meta[MetadataTags] != "ops" {
+		t.Fatalf("unexpected metadata after tags override: %v", meta)
+	}
+	if _, err := svc.SetTitle(ctx, "s1", "  ", nil); !errors.Is(err, ErrEmptyOverride) {
+		t.Fatalf("expected ErrEmptyOverride, got %v", err)
+	}
+	if client.callCount() != 0 {
+		t.Fatalf("expected no LLM calls, got %d", client.callCount())
+	}
+}
+
+func TestRefreshFallsBackToHeuristicWhenOffline(t *testing.T) {
+	store := newMemorySessionStore(newSession("s1",
+		"Why does the kafka consumer lag spike?\nIt happens nightly.",
+		"kafka consumer rebalance logs attached",
+	))
+	client := &fakeLLM{failures: 100}
+	svc := newTestService(store, client, Config{MaxAttempts: 2})
+
+	if ok, err := svc.Refresh(context.Background(), "s1"); err != nil || !ok {
+		t.Fatalf("Refresh = %v, %v; want heuristic title", ok, err)
+	}
+	if client.callCount() != 2 {
+		t.Fatalf("expected 2 attempts before fallback, got %d", client.callCount())
+	}
+	meta := store.metadata(t, "s1")
+	if meta[MetadataTitle] != "Why does the kafka consumer lag…" {
+		t.Fatalf("unexpected heuristic title %q", meta[MetadataTitle])
+	}
+	if meta[MetadataTags] != "kafka,consumer,spike" {
+		t.Fatalf("unexpected heuristic tags %q", meta[MetadataTags])
+	}
+}
+
+func TestRefreshIsRateLimited(t *testing.T) {
+	store := newMemorySessionStore(newSession("a", "first topic"), newSession("b", "second topic"))
+	svc := newTestService(store, nil, Config{MinInterval: time.Hour, Burst: 1})
+	ctx := context.Background()
+
+	if ok, _ := svc.Refresh(ctx, "a"); !ok {
+		t.Fatal("expected first generation within burst")
+	}
+	if ok, _ := svc.Refresh(ctx, "b"); ok {
+		t.Fatal("expected second generation to be rate-limited")
+	}
+	if _, ok := store.metadata(t, "b")[MetadataTitle]; ok {
+		t.Fatal("rate-limited session must stay untitled so a later task retries")
+	}
+}
+
+func TestOnTaskCompletedRunsInBackground(t *testing.T) {
+	store := newMemorySessionStore(newSession("s1", "write release notes"))
+	client := &fakeLLM{replies: []string{`{"title":"Release notes","tags":["release"]}`}}
+	svc := newTestService(store, client, Config{})
+
+	svc.OnTaskCompleted(context.Background(), "s1")
+	deadline := time.Now().Add(2 * time.Second)
+	for store.metadata(t, "s1")[MetadataTitle] != "Release notes" {
+		if time.Now().After(deadline) {
+			t.Fatalf("title not generated in background: %v", store.metadata(t, "s1"))
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestMatches(t *testing.T) {
+	tags := []string{"kafka", "ops"}
+	if !Matches("Consumer lag", tags, "LAG") || !Matches("Consumer lag", tags, "kaf") || !Matches("x", nil, "") {
+		t.Fatal("expected matches on title, tag, and empty query")
+	}
+	if Matches("Consumer lag", tags, "billing") {
+		t.Fatal("unexpected match")
+	}
+}
@@ -0,0 +1,118 @@
+// Package sessiontools persists per-session tool allow/deny overrides in
+// session metadata. The preparation service applies them on top of the mode
+// preset and tool policy whenever a session runs a task.
+package sessiontools
+
+import (
+	"context"
+	"fmt"
+
+	storage "alex/internal/domain/agent/ports/storage"
+	tools "alex/internal/domain/agent/ports/tools"
+)
+
+// Service reads and updates the tool overrides stored on sessions.
+type Service struct {
+	store storage.SessionStore
+}
+
+// New creates a Service backed by store.
+func New(store storage.SessionStore) *Service {
+	return &Service{store: store}
+}
+
+// Current returns the overrides stored on sessionID.
+func (s *Service) Current(ctx context.Context, sessionID string) (tools.ToolOverrides, error) {
+	if s == nil || s.store == nil {
+		return tools.ToolOverrides{}, fmt.Errorf("session tool overrides not configured")
+	}
+	session, err := s.store.Get(ctx, sessionID)
+	if err != nil {
+		return tools.ToolOverrides{}, err
+	}
+	return FromSession(session)
+}
+
+// Set replaces the overrides stored on sessionID. Empty overrides clear them.
+func (s *Service) Set(ctx context.Context, sessionID string, overrides tools.ToolOverrides) (tools.ToolOverrides, error) {
+	return s.update(ctx, sessionID, func(tools.ToolOverrides) tools.ToolOverrides { return overrides })
+}
+
+// Disable adds name to the session's deny list.
+func (s *Service) Disable(ctx context.Context, sessionID, name string) (tools.ToolOverrides, error) {
+	return s.update(ctx, sessionID, func(current tools.ToolOverrides) tools.ToolOverrides {
+		current.Deny = append(current.Deny, name)
+		return current
+	})
+}
+
+// Enable removes name from the deny list and, when the session keeps an
+// allow list, adds it there so the tool becomes reachable.
+func (s *Service) Enable(ctx context.Context, sessionID, name string) (tools.ToolOverrides, error) {
+	return s.update(ctx, sessionID, func(current tools.ToolOverrides) tools.ToolOverrides {
+		current.Deny = without(current.Deny, name)
+		if len(current.Allow) > 0 {
+			current.Allow = append(current.Allow, name)
+		}
+		return current
+	})
+}
+
+func (s *Service) update(ctx context.Context, sessionID string, mutate func(tools.ToolOverrides) tools.ToolOverrides) (tools.ToolOverrides, error) {
+	if s == nil || s.store == nil {
+		return tools.ToolOverrides{}, fmt.Errorf("session tool overrides not configured")
+	}
+	session, err := s.store.Get(ctx, sessionID)
+	if err != nil {
+		return tools.ToolOverrides{}, err
+	}
+	current, err := FromSession(session)
+	if err != nil {
+		// A corrupt value should not lock the user out of fixing it.
+		current = tools.ToolOverrides{}
+	}
+	next := mutate(current).Normalize()
+	if err := Apply(session, next); err != nil {
+		return tools.ToolOverrides{}, err
+	}
+	if err := s.store.Save(ctx, session); err != nil {
+		return tools.ToolOverrides{}, err
+	}
+	return next, nil
+}
+
+// FromSession decodes the overrides stored in session metadata.
+func FromSession(session *storage.Session) (tools.ToolOverrides, error) {
+	if session == nil {
+		return tools.ToolOverrides{}, nil
+	}
+	return tools.ParseToolOverrides(session.Metadata[tools.ToolOverridesMetadataKey])
+}
+
+// Apply records overrides in session metadata. Clearing writes an empty
+// value rather than deleting the key: append-only stores (tape) merge
+// metadata across saves and would otherwise resurrect the old overrides.
+func Apply(session *storage.Session, overrides tools.ToolOverrides) error {
+	if session == nil {
+		return fmt.Errorf("session is nil")
+	}
+	encoded := overrides.Encode()
+	if encoded == "" {
+		if _, ok := session.Metadata[tools.ToolOverridesMetadataKey]; ok {
+			session.Metadata[tools.ToolOverridesMetadataKey] = ""
+		}
+		return nil
+	}
+	storage.EnsureMetadata(session)[tools.ToolOverridesMetadataKey] = encoded
+	return nil
+}
+
+func without(names []string, name string) []string {
+	out := names[:0:0]
+	for _, n := range names {
+		if n != name {
+			out = append(out, n)
+		}
+	}
+	return out
+}
@@ -0,0 +1,113 @@
+package sessiontools
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	storage "alex/internal/domain/agent/ports/storage"
+	tools "alex/internal/domain/agent/ports/tools"
+)
+
+type memorySessionStore struct {
+	sessions map[string]*storage.Session
+}
+
+func (m *memorySessionStore) Create(context.Context) (*storage.Session, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
+func (m *memorySessionStore) Get(_ context.Context, id string) (*storage.Session, error) {
+	session, ok := m.sessions[id]
+	if !ok {
+		return nil, fmt.Errorf("session %s not found", id)
+	}
+	cloned := *session
+	cloned.Metadata = make(map[string]string, len(session.Metadata))
+	for k, v := range session.Metadata {
+		cloned.Metadata[k] = v
+	}
+	return &cloned, nil
+}
+
+func (m *memorySessionStore) Save(_ context.Context, session *storage.Session) error {
+	m.sessions[session.ID] = session
+	return nil
+}
+
+func (m *memorySessionStore) List(context.Context, int, int) ([]string, error) { return nil, nil }
+func (m *memorySessionStore) Delete(context.Context, string) error             { return nil }
+
+func TestServiceDisableEnableReset(t *testing.T) {
+	store := &memorySessionStore{sessions: map[string]*storage.Session{"s1": {ID: "s1"}}}
+	svc := New(store)
+	ctx := context.Background()
+
+	got, err := svc.Disable(ctx, "s1", " shell_exec ")
+	if err != nil {
+		t.Fatalf("Disable() error = %v", err)
+	}
+	if !reflect.DeepEqual(got.Deny, []string{"shell_exec"}) {
+		t.Fatalf("unexpected deny list %v", got.Deny)
+	}
+	if _, err := svc.Disable(ctx, "s1", "web_fetch"); err != nil {
+		t.Fatalf("Disable() error = %v", err)
+	}
+	current, err := svc.Current(ctx, "s1")
+	if err != nil {
+		t.Fatalf("Current() error = %v", err)
+	}
+	if !reflect.DeepEqual(current.Deny, []string{"shell_exec", "web_fetch"}) {
+		t.Fatalf("expected persisted deny list, got %v", current.Deny)
+	}
+
+	got, err = svc.Enable(ctx, "s1", "shell_exec")
+	if err != nil {
+		t.Fatalf("Enable() error = %v", err)
+	}
+	if !reflect.DeepEqual(got.Deny, []string{"web_fetch"}) || len(got.Allow) != 0 {
+		t.Fatalf("unexpected overrides after enable: %+v", got)
+	}
+
+	if _, err := svc.Set(ctx, "s1", tools.ToolOverrides{}); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	if raw := store.sessions["s1"].Metadata[tools.ToolOverridesMetadataKey]; raw != "" {
+		t.Fatalf("expected reset to clear the stored overrides, got %q", raw)
+	}
+}
+
+func TestServiceEnableExtendsAllowList(t *testing.T) {
+	store := &memorySessionStore{sessions: map[string]*storage.Session{"s1": {ID: "s1"}}}
+	svc := New(store)
+	ctx := context.Background()
+
+	if _, err := svc.Set(ctx, "s1", tools.ToolOverrides{Allow: []string{"file_read"}}); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	got, err := svc.Enable(ctx, "s1", "web_search")
+	if err != nil {
+		t.Fatalf("Enable() error = %v", err)
+	}
+	if !got.Allows("web_search") || !got.Allows("file_read") || got.Allows("shell_exec") {
+		t.Fatalf("unexpected overrides: %+v", got)
+	}
+}
+
+func TestServiceRecoversFromCorruptOverrides(t *testing.T) {
+	store := &memorySessionStore{sessions: map[string]*storage.Session{
+		"s1": {ID: "s1", Metadata: map[string]string{tools.ToolOverridesMetadataKey: "{not json"}},
+	}}
+	svc := New(store)
+	if _, err := svc.Current(context.Background(), "s1"); err == nil {
+		t.Fatal("expected decode error for corrupt overrides")
+	}
+	got, err := svc.Disable(context.Background(), "s1", "shell_exec")
+	if err != nil {
+		t.Fatalf("Disable() error = %v", err)
+	}
+	if !reflect.DeepEqual(got.Deny, []string{"shell_exec"}) {
+		t.Fatalf("expected corrupt value to be replaced, got %+v", got)
+	}
+}
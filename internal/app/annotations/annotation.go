@@ -0,0 +1,166 @@
+// Package annotations stores reviewer notes and bookmarks attached to
+// individual session messages. Annotations live outside the session record so
+// agent turns that rewrite the transcript never race with reviewers, and each
+// one keeps a fingerprint and snippet of its message so it can be re-anchored
+// after history compaction moves or summarizes that message away.
+package annotations
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"alex/internal/domain/agent/ports"
+)
+
+const (
+	// DefaultAuthor attributes annotations when no authenticated user is known.
+	DefaultAuthor = "local"
+
+	snippetRunes = 160
+	maxTextRunes = 4000
+)
+
+var (
+	// ErrInvalidAnnotation is returned when an annotation has neither text nor a
+	// bookmark, or its text is too long.
+	ErrInvalidAnnotation = errors.New("invalid annotation")
+	// ErrMessageNotFound is returned when the message index is out of range.
+	ErrMessageNotFound = errors.New("message not found")
+	// ErrAnnotationNotFound is returned when no annotation has the given ID.
+	ErrAnnotationNotFound = errors.New("annotation not found")
+)
+
+// Annotation is a note and/or bookmark on one session message.
+type Annotation struct {
+	ID        string `json:"id"`
+	SessionID string `json:"session_id"`
+	// MessageIndex is the message's current position in the session transcript.
+	MessageIndex int    `json:"message_index"`
+	Role         string `json:"role,omitempty"`
+	// Snippet is the start of the annotated message, captured when the
+	// annotation was created and kept verbatim after compaction.
+	Snippet     string `json:"snippet"`
+	Fingerprint string `json:"fingerprint"`
+	// Compacted reports that the original message was summarized away and
+	// MessageIndex now points at the summary block that replaced it.
+	Compacted bool      `json:"compacted,omitempty"`
+	Text      string    `json:"text,omitempty"`
+	Bookmark  bool      `json:"bookmark,omitempty"`
+	Author    string    `json:"author"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Input is the reviewer-supplied part of an annotation.
+type Input struct {
+	Text     string
+	Bookmark bool
+	Author   string
+}
+
+// Patch updates an existing annotation. Nil fields are left unchanged.
+type Patch struct {
+	Text     *string
+	Bookmark *bool
+}
+
+func validate(text string, bookmark bool) error {
+	if text == "" && !bookmark {
+		return fmt.Errorf("%w: text or bookmark is required", ErrInvalidAnnotation)
+	}
+	if len([]rune(text)) > maxTextRunes {
+		return fmt.Errorf("%w: text exceeds %d characters", ErrInvalidAnnotation, maxTextRunes)
+	}
+	return nil
+}
+
+func normalizeAuthor(author string) string {
+	if trimmed := strings.TrimSpace(author); trimmed != "" {
+		return trimmed
+	}
+	return DefaultAuthor
+}
+
+// fingerprint identifies a message by role and content so the annotation can
+// find it again after earlier messages are dropped or summarized.
+func fingerprint(msg ports.Message) string {
+	sum := sha256.Sum256([]byte(strings.TrimSpace(msg.Role) + "\x00" + msg.Content))
+	return hex.EncodeToString(sum[:8])
+}
+
+func isSummaryBlock(msg ports.Message) bool {
+	return ports.IsSyntheticSummary(msg.Content)
+}
+
+// reanchor resolves a against the current transcript. A message that moved is
+// found again by fingerprint (nearest match to the old index wins); one that
+// was compacted away is anchored to the summary block that now stands in for
+// it. The snippet is never rewritten, so the original context survives.
+// It reports whether a changed.
+func reanchor(a Annotation, messages []ports.Message) (Annotation, bool) {
+	if len(messages) == 0 {
+		return a, false
+	}
+	idx := a.MessageIndex
+	inRange := idx >= 0 && idx < len(messages)
+	if inRange {
+		if a.Compacted && isSummaryBlock(messages[idx]) {
+			return a, false
+		}
+		if !a.Compacted && fingerprint(messages[idx]) == a.Fingerprint {
+			return a, false
+		}
+	}
+
+	if found := nearestFingerprint(messages, a.Fingerprint, idx); found >= 0 {
+		a.MessageIndex, a.Compacted = found, false
+		return a, true
+	}
+	if summary := summaryBlockFor(messages, idx); summary >= 0 {
+		changed := !a.Compacted || a.MessageIndex != summary
+		a.MessageIndex, a.Compacted = summary, true
+		return a, changed
+	}
+	return a, false
+}
+
+func nearestFingerprint(messages []ports.Message, want string, around int) int {
+	best := -1
+	for i, msg := range messages {
+		if fingerprint(msg) != want {
+			continue
+		}
+		if best < 0 || absInt(i-around) < absInt(best-around) {
+			best = i
+		}
+	}
+	return best
+}
+
+// summaryBlockFor returns the last summary block at or before the message's
+// old position. Compaction only removes messages, so the summary that replaced
+// it can never sit later than where the message used to be.
+func summaryBlockFor(messages []ports.Message, oldIndex int) int {
+	first := -1
+	for i := len(messages) - 1; i >= 0; i-- {
+		if !isSummaryBlock(messages[i]) {
+			continue
+		}
+		if i <= oldIndex {
+			return i
+		}
+		first = i
+	}
+	return first
+}
+
+func absInt(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
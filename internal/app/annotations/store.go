@@ -0,0 +1,200 @@
+package annotations
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"alex/internal/domain/agent/ports"
+	"alex/internal/infra/filestore"
+	jsonx "alex/internal/shared/json"
+	id "alex/internal/shared/utils/id"
+)
+
+const storeDocVersion = 1
+
+type storeDoc struct {
+	Version     int          `json:"version"`
+	Annotations []Annotation `json:"annotations"`
+}
+
+// Store persists annotations for every session in a single JSON file.
+type Store struct {
+	coll *filestore.Collection[string, Annotation]
+}
+
+// NewStore loads the store from path. An empty path yields an in-memory store.
+func NewStore(path string) (*Store, error) {
+	coll := filestore.NewCollection[string, Annotation](filestore.CollectionConfig{
+		FilePath: strings.TrimSpace(path),
+		Perm:     0o600,
+		Name:     "annotations",
+	})
+	coll.SetMarshalDoc(marshalStoreDoc)
+	coll.SetUnmarshalDoc(unmarshalStoreDoc)
+	if err := coll.Load(); err != nil {
+		return nil, fmt.Errorf("load annotations: %w", err)
+	}
+	return &Store{coll: coll}, nil
+}
+
+// Add annotates messages[index] of sessionID.
+func (s *Store) Add(ctx context.Context, sessionID string, messages []ports.Message, index int, in Input) (Annotation, error) {
+	if err := ctx.Err(); err != nil {
+		return Annotation{}, err
+	}
+	text := strings.TrimSpace(in.Text)
+	if err := validate(text, in.Bookmark); err != nil {
+		return Annotation{}, err
+	}
+	if index < 0 || index >= len(messages) {
+		return Annotation{}, fmt.Errorf("message %d: %w", index, ErrMessageNotFound)
+	}
+	msg := messages[index]
+	now := s.coll.Now().UTC()
+	annotation := Annotation{
+		ID:           "ann-" + id.NewKSUID(),
+		SessionID:    sessionID,
+		MessageIndex: index,
+		Role:         strings.TrimSpace(msg.Role),
+		Snippet:      ports.TruncateRuneSnippet(msg.Content, snippetRunes),
+		Fingerprint:  fingerprint(msg),
+		Compacted:    isSummaryBlock(msg),
+		Text:         text,
+		Bookmark:     in.Bookmark,
+		Author:       normalizeAuthor(in.Author),
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+	err := s.coll.Mutate(func(items map[string]Annotation) error {
+		items[annotation.ID] = annotation
+		return nil
+	})
+	return annotation, err
+}
+
+// List returns the annotations of sessionID ordered by message position,
+// re-anchored against messages. Anchors that moved are persisted so later
+// reads agree even if the transcript is unavailable.
+func (s *Store) List(ctx context.Context, sessionID string, messages []ports.Message) ([]Annotation, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	var result []Annotation
+	moved := false
+	s.coll.ReadLocked(func(items map[string]Annotation) {
+		for _, a := range items {
+			if a.SessionID != sessionID {
+				continue
+			}
+			resolved, changed := reanchor(a, messages)
+			moved = moved || changed
+			result = append(result, resolved)
+		}
+	})
+	if moved {
+		err := s.coll.Mutate(func(items map[string]Annotation) error {
+			for _, a := range result {
+				if current, ok := items[a.ID]; ok {
+					current.MessageIndex, current.Compacted = a.MessageIndex, a.Compacted
+					items[a.ID] = current
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].MessageIndex != result[j].MessageIndex {
+			return result[i].MessageIndex < result[j].MessageIndex
+		}
+		return result[i].CreatedAt.Before(result[j].CreatedAt)
+	})
+	return result, nil
+}
+
+// Update applies patch to an annotation of sessionID.
+func (s *Store) Update(ctx context.Context, sessionID, annotationID string, patch Patch) (Annotation, error) {
+	if err := ctx.Err(); err != nil {
+		return Annotation{}, err
+	}
+	var updated Annotation
+	err := s.coll.Mutate(func(items map[string]Annotation) error {
+		current, ok := items[annotationID]
+		if !ok || current.SessionID != sessionID {
+			return ErrAnnotationNotFound
+		}
+		if patch.Text != nil {
+			current.Text = strings.TrimSpace(*patch.Text)
+		}
+		if patch.Bookmark != nil {
+			current.Bookmark = *patch.Bookmark
+		}
+		if err := validate(current.Text, current.Bookmark); err != nil {
+			return err
+		}
+		current.UpdatedAt = s.coll.Now().UTC()
+		items[annotationID] = current
+		updated = current
+		return nil
+	})
+	return updated, err
+}
+
+// Delete removes one annotation of sessionID.
+func (s *Store) Delete(ctx context.Context, sessionID, annotationID string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return s.coll.Mutate(func(items map[string]Annotation) error {
+		current, ok := items[annotationID]
+		if !ok || current.SessionID != sessionID {
+			return ErrAnnotationNotFound
+		}
+		delete(items, annotationID)
+		return nil
+	})
+}
+
+// DeleteSession removes every annotation of sessionID.
+func (s *Store) DeleteSession(ctx context.Context, sessionID string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return s.coll.Mutate(func(items map[string]Annotation) error {
+		for key, a := range items {
+			if a.SessionID == sessionID {
+				delete(items, key)
+			}
+		}
+		return nil
+	})
+}
+
+func marshalStoreDoc(items map[string]Annotation) ([]byte, error) {
+	doc := storeDoc{Version: storeDocVersion, Annotations: make([]Annotation, 0, len(items))}
+	for _, a := range items {
+		doc.Annotations = append(doc.Annotations, a)
+	}
+	sort.Slice(doc.Annotations, func(i, j int) bool {
+		return doc.Annotations[i].ID < doc.Annotations[j].ID
+	})
+	return filestore.MarshalJSONIndent(doc)
+}
+
+func unmarshalStoreDoc(data []byte) (map[string]Annotation, error) {
+	var doc storeDoc
+	if err := jsonx.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("decode annotations: %w", err)
+	}
+	items := make(map[string]Annotation, len(doc.Annotations))
+	for _, a := range doc.Annotations {
+		if a.ID != "" {
+			items[a.ID] = a
+		}
+	}
+	return items, nil
+}
@@ -0,0 +1,159 @@
+package annotations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	agentcontext "alex/internal/app/context"
+	"alex/internal/domain/agent/ports"
+)
+
+func transcript(turns int) []ports.Message {
+	messages := []ports.Message{{Role: "system", Content: "You are helpful.", Source: ports.MessageSourceSystemPrompt}}
+	for i := 0; i < turns; i++ {
+		messages = append(messages,
+			ports.Message{Role: "user", Content: fmt.Sprintf("question %d %s", i, strings.Repeat("detail ", 40)), Source: ports.MessageSourceUserInput},
+			ports.Message{Role: "assistant", Content: fmt.Sprintf("answer %d %s", i, strings.Repeat("reasoning ", 40))},
+		)
+	}
+	return messages
+}
+
+func TestStoreCRUDAndSessionCascade(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "annotations.json")
+	store, err := NewStore(path)
+	if err != nil {
+		t.Fatalf("new store: %v", err)
+	}
+	messages := transcript(2)
+
+	if _, err := store.Add(ctx, "s1", messages, 1, Input{}); !errors.Is(err, ErrInvalidAnnotation) {
+		t.Fatalf("expected ErrInvalidAnnotation for empty annotation, got %v", err)
+	}
+	if _, err := store.Add(ctx, "s1", messages, len(messages), Input{Bookmark: true}); !errors.Is(err, ErrMessageNotFound) {
+		t.Fatalf("expected ErrMessageNotFound, got %v", err)
+	}
+
+	note, err := store.Add(ctx, "s1", messages, 2, Input{Text: " wrong schema here ", Author: "ou_reviewer"})
+	if err != nil {
+		t.Fatalf("add: %v", err)
+	}
+	if note.Text != "wrong schema here" || note.Author != "ou_reviewer" || note.Role != "assistant" || !strings.HasPrefix(note.Snippet, "answer 0") {
+		t.Fatalf("unexpected annotation: %+v", note)
+	}
+	mark, err := store.Add(ctx, "s1", messages, 1, Input{Bookmark: true})
+	if err != nil {
+		t.Fatalf("add bookmark: %v", err)
+	}
+	if mark.Author != DefaultAuthor {
+		t.Fatalf("expected default author, got %q", mark.Author)
+	}
+	if _, err := store.Add(ctx, "s2", messages, 1, Input{Text: "other session"}); err != nil {
+		t.Fatalf("add other session: %v", err)
+	}
+
+	text := "chose the wrong schema"
+	if _, err := store.Update(ctx, "s1", note.ID, Patch{Text: &text}); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if _, err := store.Update(ctx, "s2", note.ID, Patch{Text: &text}); !errors.Is(err, ErrAnnotationNotFound) {
+		t.Fatalf("expected cross-session update to fail, got %v", err)
+	}
+
+	reloaded, err := NewStore(path)
+	if err != nil {
+		t.Fatalf("reload: %v", err)
+	}
+	list, err := reloaded.List(ctx, "s1", messages)
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if len(list) != 2 || list[0].ID != mark.ID || list[1].Text != text {
+		t.Fatalf("expected annotations ordered by message, got %+v", list)
+	}
+
+	if err := reloaded.Delete(ctx, "s1", mark.ID); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if err := reloaded.Delete(ctx, "s1", mark.ID); !errors.Is(err, ErrAnnotationNotFound) {
+		t.Fatalf("expected ErrAnnotationNotFound on second delete, got %v", err)
+	}
+	if err := reloaded.DeleteSession(ctx, "s1"); err != nil {
+		t.Fatalf("delete session: %v", err)
+	}
+	if list, _ := reloaded.List(ctx, "s1", messages); len(list) != 0 {
+		t.Fatalf("expected session annotations removed, got %+v", list)
+	}
+	if list, _ := reloaded.List(ctx, "s2", messages); len(list) != 1 {
+		t.Fatalf("expected other session untouched, got %+v", list)
+	}
+}
+
+func TestListReanchorsAcrossCompaction(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "annotations.json")
+	store, err := NewStore(path)
+	if err != nil {
+		t.Fatalf("new store: %v", err)
+	}
+	messages := transcript(4)
+	early, err := store.Add(ctx, "s1", messages, 2, Input{Text: "this is where it chose the wrong schema", Bookmark: true})
+	if err != nil {
+		t.Fatalf("add early: %v", err)
+	}
+	last := len(messages) - 1
+	recent, err := store.Add(ctx, "s1", messages, last, Input{Text: "final answer"})
+	if err != nil {
+		t.Fatalf("add recent: %v", err)
+	}
+
+	compacted, err := agentcontext.NewManager().Compress(messages, 50)
+	if err != nil {
+		t.Fatalf("compress: %v", err)
+	}
+	if len(compacted) >= len(messages) {
+		t.Fatalf("expected compaction to drop messages, got %d -> %d", len(messages), len(compacted))
+	}
+
+	list, err := store.List(ctx, "s1", compacted)
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if len(list) != 2 {
+		t.Fatalf("expected both annotations to survive compaction, got %+v", list)
+	}
+	got := map[string]Annotation{list[0].ID: list[0], list[1].ID: list[1]}
+
+	summarized := got[early.ID]
+	if !summarized.Compacted || !isSummaryBlock(compacted[summarized.MessageIndex]) {
+		t.Fatalf("expected early annotation re-anchored to the summary block, got %+v", summarized)
+	}
+	if summarized.Snippet != early.Snippet || summarized.Text != early.Text || !summarized.Bookmark {
+		t.Fatalf("expected original snippet and note preserved, got %+v", summarized)
+	}
+
+	kept := got[recent.ID]
+	if kept.Compacted || kept.MessageIndex != len(compacted)-1 || compacted[kept.MessageIndex].Content != messages[last].Content {
+		t.Fatalf("expected surviving message to be found at its new index, got %+v", kept)
+	}
+
+	// Re-anchoring is persisted, so a reload sees the same anchors.
+	reloaded, err := NewStore(path)
+	if err != nil {
+		t.Fatalf("reload: %v", err)
+	}
+	again, err := reloaded.List(ctx, "s1", compacted)
+	if err != nil {
+		t.Fatalf("list after reload: %v", err)
+	}
+	for _, a := range again {
+		if a != got[a.ID] {
+			t.Fatalf("expected persisted anchor %+v, got %+v", got[a.ID], a)
+		}
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"alex/internal/domain/agent/ports"
 	agent "alex/internal/domain/agent/ports/agent"
 	"alex/internal/shared/logging"
+	tokenutil "alex/internal/shared/token"
 )
 
 type systemPromptInput struct {
@@ -27,6 +28,7 @@ type systemPromptInput struct {
 	ToolMode         string
 	SkillsConfig     agent.SkillsConfig
 	OKRContext       string
+	Preferences      string // Pre-rendered user preferences block
 	SOPSummaryOnly   bool   // If true, only show SOP references without full content
 	Unattended       bool   // If true, inject autonomous behavior override (no user interaction)
 	ChannelHint      string // Pre-rendered channel-specific formatting hint
@@ -40,76 +42,105 @@ const (
 	maxComposedSystemPromptChars = 32000
 )
 
+// promptSection is a named system prompt fragment; names feed the prompt
+// token breakdown so each section's cost is attributable.
+type promptSection struct {
+	Name    string
+	Content string
+}
+
 func composeSystemPrompt(input systemPromptInput) string {
+	return joinPromptSections(composePromptSections(input))
+}
+
+func composePromptSections(input systemPromptInput) []promptSection {
 	mode := normalizePromptMode(input.PromptMode)
 	if mode == promptModeNone {
-		return clampSystemPromptSize(buildIdentityLine(input.Static.Persona))
-	}
-
-	fullSections := []string{
-		buildIdentitySection(input.Static.Persona),
-		buildToolingSection(input.Static.Tools),
-		buildToolRoutingSection(),
-		buildSafetySection(),
-		buildGoalsSection(input.Static.Goal),
-		buildPoliciesSection(input.Static.Policies),
-		buildKnowledgeSection(input.Static.Knowledge, input.SOPSummaryOnly),
-		buildMemorySection(input.Memory),
-		buildPredictiveMemorySection(input.PredictiveMemory),
-		buildOKRSection(input.OKRContext),
-		buildSkillsSection(input.Logger, input.TaskInput, input.Messages, input.SessionID, input.SkillsConfig),
-		buildWorkspaceSection(),
-		buildWorkspaceFilesSection(input.BootstrapRecords),
-		buildTimezoneSection(input.PromptTimezone),
-		buildChatIDSection(input.ChatID),
-		buildReplyTagsSection(input.ReplyTagsEnabled),
-		buildRuntimeSection(input.ToolMode),
-		buildReasoningSection(),
-		buildChannelFormattingSection(input.ChannelHint),
+		return []promptSection{{Name: "identity", Content: buildIdentityLine(input.Static.Persona)}}
+	}
+
+	fullSections := []promptSection{
+		{"identity", buildIdentitySection(input.Static.Persona)},
+		{"tooling", buildToolingSection(input.Static.Tools)},
+		{"tool_routing", buildToolRoutingSection()},
+		{"safety", buildSafetySection()},
+		{"goals", buildGoalsSection(input.Static.Goal)},
+		{"policies", buildPoliciesSection(input.Static.Policies)},
+		{"knowledge", buildKnowledgeSection(input.Static.Knowledge, input.SOPSummaryOnly)},
+		{"preferences", buildPreferencesSection(input.Preferences)},
+		{"memory", buildMemorySection(input.Memory)},
+		{"predictive_memory", buildPredictiveMemorySection(input.PredictiveMemory)},
+		{"okr", buildOKRSection(input.OKRContext)},
+		{"skills", buildSkillsSection(input.Logger, input.TaskInput, input.Messages, input.SessionID, input.SkillsConfig)},
+		{"workspace", buildWorkspaceSection()},
+		{"workspace_files", buildWorkspaceFilesSection(input.BootstrapRecords)},
+		{"timezone", buildTimezoneSection(input.PromptTimezone)},
+		{"chat_id", buildChatIDSection(input.ChatID)},
+		{"reply_tags", buildReplyTagsSection(input.ReplyTagsEnabled)},
+		{"runtime", buildRuntimeSection(input.ToolMode)},
+		{"reasoning", buildReasoningSection()},
+		{"channel_formatting", buildChannelFormattingSection(input.ChannelHint)},
 	}
 	if !input.OmitEnvironment {
-		fullSections = append(fullSections, buildEnvironmentSection(input.Static))
+		fullSections = append(fullSections, promptSection{"environment", buildEnvironmentSection(input.Static)})
 	}
-	fullSections = append(fullSections, buildDynamicSection(input.Dynamic))
+	fullSections = append(fullSections, promptSection{"dynamic", buildDynamicSection(input.Dynamic)})
 	if input.Unattended {
-		fullSections = append(fullSections, buildUnattendedOverrideSection())
-	}
-
-	minimalSections := []string{
-		buildIdentitySection(input.Static.Persona),
-		buildToolingSection(input.Static.Tools),
-		buildToolRoutingSection(),
-		buildSafetySection(),
-		buildGoalsSection(input.Static.Goal),
-		buildPoliciesSection(input.Static.Policies),
-		buildWorkspaceSection(),
-		buildTimezoneSection(input.PromptTimezone),
-		buildChatIDSection(input.ChatID),
-		buildRuntimeSection(input.ToolMode),
-		buildReasoningSection(),
-		buildChannelFormattingSection(input.ChannelHint),
+		fullSections = append(fullSections, promptSection{"unattended", buildUnattendedOverrideSection()})
+	}
+
+	minimalSections := []promptSection{
+		{"identity", buildIdentitySection(input.Static.Persona)},
+		{"tooling", buildToolingSection(input.Static.Tools)},
+		{"tool_routing", buildToolRoutingSection()},
+		{"safety", buildSafetySection()},
+		{"goals", buildGoalsSection(input.Static.Goal)},
+		{"policies", buildPoliciesSection(input.Static.Policies)},
+		{"preferences", buildPreferencesSection(input.Preferences)},
+		{"workspace", buildWorkspaceSection()},
+		{"timezone", buildTimezoneSection(input.PromptTimezone)},
+		{"chat_id", buildChatIDSection(input.ChatID)},
+		{"runtime", buildRuntimeSection(input.ToolMode)},
+		{"reasoning", buildReasoningSection()},
+		{"channel_formatting", buildChannelFormattingSection(input.ChannelHint)},
 	}
 	if !input.OmitEnvironment {
-		minimalSections = append(minimalSections, buildEnvironmentSection(input.Static))
+		minimalSections = append(minimalSections, promptSection{"environment", buildEnvironmentSection(input.Static)})
 	}
 	if input.Unattended {
-		minimalSections = append(minimalSections, buildUnattendedOverrideSection())
+		minimalSections = append(minimalSections, promptSection{"unattended", buildUnattendedOverrideSection()})
 	}
 
-	var selected []string
+	selected := fullSections
 	if mode == promptModeMinimal {
 		selected = minimalSections
-	} else {
-		selected = fullSections
 	}
 
-	var compact []string
+	var compact []promptSection
 	for _, section := range selected {
-		if trimmed := strings.TrimSpace(section); trimmed != "" {
-			compact = append(compact, trimmed)
+		if trimmed := strings.TrimSpace(section.Content); trimmed != "" {
+			compact = append(compact, promptSection{Name: section.Name, Content: trimmed})
 		}
 	}
-	return clampSystemPromptSize(strings.Join(compact, "\n\n"))
+	return compact
+}
+
+func joinPromptSections(sections []promptSection) string {
+	parts := make([]string, 0, len(sections))
+	for _, section := range sections {
+		parts = append(parts, section.Content)
+	}
+	return clampSystemPromptSize(strings.Join(parts, "\n\n"))
+}
+
+// promptBreakdown estimates tokens per section. Totals are pre-clamp, so a
+// truncated prompt still shows which sections caused the overflow.
+func promptBreakdown(sections []promptSection) []agent.PromptSectionUsage {
+	usage := make([]agent.PromptSectionUsage, 0, len(sections))
+	for _, section := range sections {
+		usage = append(usage, agent.PromptSectionUsage{Section: section.Name, Tokens: tokenutil.EstimateFast(section.Content)})
+	}
+	return usage
 }
 
 func normalizePromptMode(mode string) string {
@@ -192,6 +192,17 @@ func buildPredictiveMemorySection(snapshot string) string {
 	})
 }
 
+func buildPreferencesSection(preferences string) string {
+	trimmed := strings.TrimSpace(preferences)
+	if trimmed == "" {
+		return ""
+	}
+	return formatSection("# User Preferences", []string{
+		"Explicit settings the user manages via /prefs or the preferences API. Apply them verbatim; they override memory-derived guesses.",
+		trimmed,
+	})
+}
+
 func buildOKRSection(okrContext string) string {
 	trimmed := strings.TrimSpace(okrContext)
 	if trimmed == "" {
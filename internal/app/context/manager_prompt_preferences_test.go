@@ -0,0 +1,61 @@
+package context
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestComposeSystemPrompt_IncludesPreferencesSeparateFromMemory(t *testing.T) {
+	prompt := composeSystemPrompt(systemPromptInput{
+		Preferences: "- Language: Chinese",
+		Memory:      "user likes tea",
+	})
+	prefsIdx := strings.Index(prompt, "# User Preferences")
+	memoryIdx := strings.Index(prompt, "# Persistent Memory")
+	if prefsIdx < 0 || memoryIdx < 0 {
+		t.Fatalf("expected both preferences and memory sections, got:\n%s", prompt)
+	}
+	if !strings.Contains(prompt[prefsIdx:memoryIdx], "- Language: Chinese") {
+		t.Fatalf("expected preferences content inside its own section")
+	}
+}
+
+func TestComposeSystemPrompt_PreferencesKeptInMinimalMode(t *testing.T) {
+	prompt := composeSystemPrompt(systemPromptInput{
+		PromptMode:  promptModeMinimal,
+		Preferences: "- Tone: terse",
+	})
+	if !strings.Contains(prompt, "- Tone: terse") {
+		t.Fatalf("expected preferences in minimal prompt, got:\n%s", prompt)
+	}
+}
+
+func TestComposeSystemPrompt_NoPreferencesSectionWhenEmpty(t *testing.T) {
+	if prompt := composeSystemPrompt(systemPromptInput{}); strings.Contains(prompt, "# User Preferences") {
+		t.Fatal("did not expect preferences section when no preferences are set")
+	}
+}
+
+func TestPromptBreakdown_AttributesPreferencesTokens(t *testing.T) {
+	sections := composePromptSections(systemPromptInput{Preferences: "- Custom instructions: never use emojis"})
+	breakdown := promptBreakdown(sections)
+	if len(breakdown) != len(sections) {
+		t.Fatalf("expected one usage entry per section, got %d for %d", len(breakdown), len(sections))
+	}
+	for _, usage := range breakdown {
+		if usage.Section == "preferences" {
+			if usage.Tokens <= 0 {
+				t.Fatalf("expected positive preferences tokens, got %d", usage.Tokens)
+			}
+			return
+		}
+	}
+	t.Fatalf("expected preferences entry in breakdown, got %#v", breakdown)
+}
+
+func TestComposeSystemPrompt_MatchesJoinedSections(t *testing.T) {
+	input := systemPromptInput{Preferences: "- Language: English", OKRContext: "goal"}
+	if got, want := composeSystemPrompt(input), joinPromptSections(composePromptSections(input)); got != want {
+		t.Fatal("composeSystemPrompt must equal the joined prompt sections")
+	}
+}
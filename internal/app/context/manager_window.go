@@ -122,7 +122,7 @@ func (m *manager) BuildWindow(ctx context.Context, session *storage.Session, cfg
 		window.Static.EnvironmentSummary = ""
 	}
 
-	window.SystemPrompt = composeSystemPrompt(systemPromptInput{
+	promptSections := composePromptSections(systemPromptInput{
 		Logger:           m.logger,
 		Static:           window.Static,
 		Dynamic:          window.Dynamic,
@@ -139,11 +139,14 @@ func (m *manager) BuildWindow(ctx context.Context, session *storage.Session, cfg
 		ToolMode:         cfg.ToolMode,
 		SkillsConfig:     cfg.Skills,
 		OKRContext:       cfg.OKRContext,
+		Preferences:      cfg.PreferencesContext,
 		SOPSummaryOnly:   true, // Default to summary-only mode for token optimization
 		Unattended:       cfg.Unattended,
 		ChannelHint:      cfg.ChannelHint,
 		ChatID:           cfg.ChatID,
 	})
+	window.SystemPrompt = joinPromptSections(promptSections)
+	window.PromptBreakdown = promptBreakdown(promptSections)
 	if runtimeHistoryChunk != nil {
 		window.Messages = append(window.Messages, *runtimeHistoryChunk)
 	}
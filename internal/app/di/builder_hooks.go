@@ -8,12 +8,17 @@ import (
 	appcontext "alex/internal/app/agent/context"
 	agentcoordinator "alex/internal/app/agent/coordinator"
 	"alex/internal/app/agent/hooks"
+	"alex/internal/app/agent/llmclient"
 	"alex/internal/app/agent/preparation"
+	"alex/internal/app/agent/sessiontitle"
 	ctxmgr "alex/internal/app/context"
+	"alex/internal/app/outputpolicy"
+	"alex/internal/app/preferences"
 	"alex/internal/app/subscription"
 	toolregistry "alex/internal/app/toolregistry"
 	corehook "alex/internal/core/hook"
 	portsllm "alex/internal/domain/agent/ports/llm"
+	agentstorage "alex/internal/domain/agent/ports/storage"
 	"alex/internal/infra/adapters"
 	"alex/internal/infra/llm"
 	"alex/internal/infra/memory"
@@ -109,6 +114,28 @@ func (b *containerBuilder) buildOKRContextProvider(store *okrtools.GoalStore) pr
 	return preparation.NewOKRContextProvider(store)
 }
 
+func (b *containerBuilder) buildPreferencesContextProvider(store *preferences.Store) preparation.PreferencesContextProvider {
+	if store == nil {
+		return nil
+	}
+	return preparation.NewPreferencesContextProvider(store)
+}
+
+// buildSessionTitler wires background session titling against the default
+// LLM profile. Generation falls back to a local heuristic when the model is
+// unreachable, so titles still appear offline.
+func (b *containerBuilder) buildSessionTitler(store agentstorage.SessionStore, llmFactory portsllm.LLMClientFactory, refresher preparation.CredentialRefresher) *sessiontitle.Service {
+	if store == nil || b.config.SessionTitle.Disabled {
+		return nil
+	}
+	profile := b.buildAgentAppConfig().DefaultLLMProfile()
+	generator := sessiontitle.NewLLMGenerator(func(context.Context) (portsllm.LLMClient, error) {
+		client, _, err := llmclient.GetIsolatedClientFromProfile(llmFactory, profile, llmclient.CredentialRefresher(refresher), true)
+		return client, err
+	})
+	return sessiontitle.New(store, generator, b.config.SessionTitle, nil)
+}
+
 // buildAlternateFrom creates an AlternateCoordinator that shares the parent
 // container's heavy resources (LLM Factory, Session Store, Memory Engine,
 // Cost Tracker, Context Manager, History Manager, Parser) but owns its own
@@ -155,11 +182,14 @@ func (b *containerBuilder) buildAlternateFrom(parent *Container) (*AlternateCoor
 		b.buildAgentAppConfig(),
 		agentcoordinator.WithHookRuntime(hookRuntime),
 		agentcoordinator.WithOKRContextProvider(okrContextProvider),
+		agentcoordinator.WithPreferencesContextProvider(b.buildPreferencesContextProvider(parent.PreferencesStore)),
 		agentcoordinator.WithCheckpointStore(parent.CheckpointStore),
 		agentcoordinator.WithCredentialRefresher(credentialRefresher),
 		agentcoordinator.WithToolSLACollector(toolSLACollector),
 		agentcoordinator.WithAtomicWriter(adapters.NewOSAtomicWriter()),
 		agentcoordinator.WithTapeManager(parent.TapeManager),
+		agentcoordinator.WithSessionTitler(sessionTitlerOption(parent.SessionTitler)),
+		agentcoordinator.WithOutputPolicy(outputPolicyOption(parent.OutputPolicy)),
 	)
 
 	// Inherit runtime config resolver from parent coordinator so that
@@ -179,6 +209,22 @@ func (b *containerBuilder) buildAlternateFrom(parent *Container) (*AlternateCoor
 	}, nil
 }
 
+// sessionTitlerOption avoids handing the coordinator a typed-nil interface.
+func sessionTitlerOption(titler *sessiontitle.Service) agentcoordinator.SessionTitler {
+	if titler == nil {
+		return nil
+	}
+	return titler
+}
+
+// outputPolicyOption avoids handing the coordinator a typed-nil interface.
+func outputPolicyOption(policy *outputpolicy.Service) agentcoordinator.OutputPolicy {
+	if policy == nil {
+		return nil
+	}
+	return policy
+}
+
 func memoryGateFunc(enabled bool) func(context.Context) bool {
 	return func(ctx context.Context) bool {
 		if !enabled {
@@ -1,6 +1,8 @@
 package di
 
 import (
+	"time"
+
 	"alex/internal/app/agent/preparation"
 	"alex/internal/infra/llm"
 	runtimeconfig "alex/internal/shared/config"
@@ -22,6 +24,25 @@ func (b *containerBuilder) buildLLMFactory() *llm.Factory {
 		llmFactory.SetFallbackRules(rules)
 		b.logger.Info("LLM fallback rules configured: %d rule(s)", len(rules))
 	}
+	if providers := buildFallbackProviders(b.config.LLMFallbackProviders); len(providers) > 0 {
+		llmFactory.SetFallbackProviders(providers)
+		b.logger.Info("LLM failover chain configured: %d provider(s)", len(providers))
+	}
+	if cacheCfg := b.config.LLMResponseCache; cacheCfg.Enabled {
+		dir := llm.ResponseCacheDir(b.sessionDir)
+		cache, err := llm.NewResponseCache(llm.ResponseCacheConfig{
+			Dir:        dir,
+			TTL:        time.Duration(cacheCfg.TTLSeconds) * time.Second,
+			MaxBytes:   cacheCfg.MaxBytes,
+			MaxEntries: cacheCfg.MaxEntries,
+		})
+		if err != nil {
+			b.logger.Warn("LLM response cache disabled: %v", err)
+		} else {
+			llmFactory.SetResponseCache(cache, cacheCfg.ForceCache)
+			b.logger.Info("LLM response cache enabled at %s (force=%t)", dir, cacheCfg.ForceCache)
+		}
+	}
 	return llmFactory
 }
 
@@ -44,6 +65,23 @@ func buildFallbackRules(configs []runtimeconfig.LLMFallbackRuleConfig) map[strin
 	return rules
 }
 
+func buildFallbackProviders(configs []runtimeconfig.LLMFallbackProviderConfig) []llm.FallbackProvider {
+	providers := make([]llm.FallbackProvider, 0, len(configs))
+	for _, cfg := range configs {
+		if cfg.Provider == "" || (cfg.Model == "" && len(cfg.Models) == 0) {
+			continue
+		}
+		providers = append(providers, llm.FallbackProvider{
+			Provider: cfg.Provider,
+			Model:    cfg.Model,
+			Models:   cfg.Models,
+			APIKey:   cfg.APIKey,
+			BaseURL:  cfg.BaseURL,
+		})
+	}
+	return providers
+}
+
 // buildCredentialRefresher creates a function that re-resolves CLI credentials
 // at task execution time. This ensures long-running servers (e.g. Lark) use
 // fresh tokens even after the startup token expires (Codex).
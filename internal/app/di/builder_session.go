@@ -7,13 +7,18 @@ import (
 	"time"
 
 	agentcost "alex/internal/app/agent/cost"
+	"alex/internal/app/annotations"
 	"alex/internal/app/decision"
+	"alex/internal/app/outputpolicy"
+	"alex/internal/app/preferences"
+	coreerrors "alex/internal/core/errors"
 	coretape "alex/internal/core/tape"
 	agentstorage "alex/internal/domain/agent/ports/storage"
 	"alex/internal/infra/memory"
 	sessionstate "alex/internal/infra/session/state_store"
 	"alex/internal/infra/storage"
 	"alex/internal/infra/tape"
+	runtimeconfig "alex/internal/shared/config"
 )
 
 // tapeStore returns the shared FileStore for all tape-backed components.
@@ -52,6 +57,14 @@ func (b *containerBuilder) buildTurnRecorder(mgr *coretape.TapeManager) *tape.Tu
 	return tape.NewTurnRecorder(mgr)
 }
 
+const (
+	memoryComponentTimeout        = 10 * time.Second
+	memoryIndexerComponentTimeout = 20 * time.Second
+)
+
+// buildMemoryEngine constructs the markdown memory engine. Schema setup and
+// the optional embedding indexer are registered as components so Start can
+// report them without blocking required components.
 func (b *containerBuilder) buildMemoryEngine(ctx context.Context) memory.Engine {
 	root := resolveStorageDir(b.config.MemoryDir, "~/.alex/memory")
 	engine := memory.NewMarkdownEngine(root)
@@ -59,15 +72,48 @@ func (b *containerBuilder) buildMemoryEngine(ctx context.Context) memory.Engine
 	if indexCfg.ChunkTokens > 0 || indexCfg.ChunkOverlap >= 0 {
 		engine.SetChunkConfig(indexCfg.ChunkTokens, indexCfg.ChunkOverlap)
 	}
-	if indexCfg.Enabled {
-		b.logger.Warn("Memory indexer requires an embedding provider; skipping (no provider configured)")
-	}
-	if err := engine.EnsureSchema(context.Background()); err != nil {
-		b.logger.Warn("Failed to initialize memory root: %v", err)
+	b.addComponent(component{
+		name:    "memory",
+		timeout: memoryComponentTimeout,
+		start: func(startCtx context.Context) error {
+			if err := engine.EnsureSchema(startCtx); err != nil {
+				b.logger.Warn("Failed to initialize memory root: %v", err)
+				return err
+			}
+			return nil
+		},
+	})
+	if indexer := b.buildMemoryIndexer(root); indexer != nil {
+		engine.SetIndexer(indexer)
+		b.addComponent(component{
+			name:      "memory-indexer",
+			dependsOn: []string{"memory"},
+			timeout:   memoryIndexerComponentTimeout,
+			start: func(startCtx context.Context) error {
+				// The indexer's watch loop lives as long as the container, so
+				// it runs on ctx; startCtx only bounds how long Start waits
+				// for the initial scan.
+				started := make(chan error, 1)
+				go func() { started <- indexer.Start(ctx) }()
+				select {
+				case err := <-started:
+					if err != nil {
+						b.logger.Warn("Memory indexer failed to start: %v", err)
+					}
+					return err
+				case <-startCtx.Done():
+					return coreerrors.NewDegradedError(startCtx.Err(), "initial memory index scan still running", "")
+				}
+			},
+		})
 	}
 
 	memoryCfg := b.config.Proactive.Memory
-	if memoryCfg.ArchiveAfterDays > 0 {
+	compaction := memory.CompactionPolicy{
+		MaxEntries:         memoryCfg.MaxEntries,
+		DuplicateThreshold: memoryCfg.DuplicateThreshold,
+	}
+	if memoryCfg.ArchiveAfterDays > 0 || compaction.Enabled() {
 		interval, err := time.ParseDuration(memoryCfg.CleanupInterval)
 		if err != nil || interval <= 0 {
 			interval = 24 * time.Hour
@@ -75,12 +121,49 @@ func (b *containerBuilder) buildMemoryEngine(ctx context.Context) memory.Engine
 		engine.StartCleanupLoop(ctx, memory.CleanupConfig{
 			ArchiveAfterDays: memoryCfg.ArchiveAfterDays,
 			CleanupInterval:  interval,
+			Compaction:       compaction,
 		})
 	}
 
 	return engine
 }
 
+// buildMemoryIndexer returns the embedding index for hybrid recall, or nil
+// when indexing is disabled or no embedding endpoint is configured, in which
+// case recall stays lexical.
+func (b *containerBuilder) buildMemoryIndexer(root string) *memory.Indexer {
+	indexCfg := b.config.Proactive.Memory.Index
+	if !indexCfg.Enabled || indexCfg.EmbedderBaseURL == "" {
+		b.recordComponent(ComponentStatus{Name: "memory-indexer", State: ComponentSkipped, Message: "indexing disabled or no embedding endpoint"})
+		return nil
+	}
+	embedder, err := memory.NewOpenAIEmbedder(memory.OpenAIEmbedderConfig{
+		BaseURL:   indexCfg.EmbedderBaseURL,
+		APIKey:    indexCfg.EmbedderAPIKey,
+		Model:     indexCfg.EmbedderModel,
+		BatchSize: indexCfg.EmbedderBatchSize,
+	}, b.logger)
+	if err != nil {
+		b.logger.Warn("Memory embedding provider misconfigured; recall stays lexical: %v", err)
+		b.recordComponent(ComponentStatus{Name: "memory-indexer", State: ComponentDegraded, Message: "embedding provider misconfigured; recall stays lexical: " + err.Error()})
+		return nil
+	}
+	indexer, err := memory.NewIndexer(root, memory.IndexerConfig{
+		DBPath:             resolveStorageDir(indexCfg.DBPath, filepath.Join(root, "index.sqlite")),
+		ChunkTokens:        indexCfg.ChunkTokens,
+		ChunkOverlap:       indexCfg.ChunkOverlap,
+		MinScore:           indexCfg.MinScore,
+		FusionWeightVector: indexCfg.FusionWeightVector,
+		FusionWeightBM25:   indexCfg.FusionWeightBM25,
+	}, embedder, b.logger)
+	if err != nil {
+		b.logger.Warn("Failed to create memory indexer; recall stays lexical: %v", err)
+		b.recordComponent(ComponentStatus{Name: "memory-indexer", State: ComponentDegraded, Message: "indexer unavailable; recall stays lexical: " + err.Error()})
+		return nil
+	}
+	return indexer
+}
+
 func (b *containerBuilder) buildCheckpointStore() *tape.CheckpointStore {
 	return tape.NewCheckpointStore(b.tapeStore(), filepath.Join(b.sessionDir, "checkpoints"))
 }
@@ -90,6 +173,30 @@ func (b *containerBuilder) buildDecisionStore() (*decision.Store, error) {
 	return decision.NewStore(path)
 }
 
+// buildAnnotationStore keeps message annotations under the session directory
+// so they follow the sessions they describe.
+func (b *containerBuilder) buildAnnotationStore() (*annotations.Store, error) {
+	path := filepath.Join(b.sessionDir, "_annotations", "annotations.json")
+	return annotations.NewStore(path)
+}
+
+// buildPreferencesStore places preferences next to the runtime config so the
+// CLI, web server, and Lark gateway on one host share the same settings.
+func (b *containerBuilder) buildPreferencesStore() (*preferences.Store, error) {
+	return preferences.NewStore(preferences.ResolveStorePath(runtimeconfig.DefaultEnvLookup, nil))
+}
+
+// buildOutputPolicy keeps workspace rule sets and the audit journal next to
+// the runtime config, alongside preferences.
+func (b *containerBuilder) buildOutputPolicy() (*outputpolicy.Service, error) {
+	path := outputpolicy.ResolveStorePath(runtimeconfig.DefaultEnvLookup, nil)
+	store, err := outputpolicy.NewStore(path)
+	if err != nil {
+		return nil, err
+	}
+	return outputpolicy.NewService(store, outputpolicy.NewJournal(outputpolicy.JournalPathFor(path)), b.logger), nil
+}
+
 func (b *containerBuilder) buildCostTracker() (agentstorage.CostTracker, error) {
 	costStore, err := storage.NewFileCostStore(b.costDir)
 	if err != nil {
@@ -0,0 +1,244 @@
+package di
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	coreerrors "alex/internal/core/errors"
+)
+
+// ComponentState is the startup outcome of a container component.
+type ComponentState string
+
+const (
+	ComponentOK       ComponentState = "ok"
+	ComponentDegraded ComponentState = "degraded"
+	ComponentFailed   ComponentState = "failed"
+	ComponentSkipped  ComponentState = "skipped"
+)
+
+const (
+	// defaultComponentTimeout bounds a component's start when it sets no timeout.
+	defaultComponentTimeout = 30 * time.Second
+	// componentTimeoutGrace is how long a timed-out start gets to report its
+	// own error before it is recorded as a timeout.
+	componentTimeoutGrace = time.Second
+)
+
+// ComponentStatus reports how one container component initialized.
+type ComponentStatus struct {
+	Name       string         `json:"name"`
+	State      ComponentState `json:"state"`
+	Required   bool           `json:"required"`
+	Message    string         `json:"message,omitempty"`
+	DurationMS int64          `json:"duration_ms,omitempty"`
+}
+
+// component is a unit of heavy initialization run by Container.Start.
+// Components whose dependencies are all up start concurrently; a component
+// is skipped when any dependency failed or was skipped. Returning a
+// coreerrors.DegradedError marks the component degraded instead of failed.
+type component struct {
+	name      string
+	required  bool
+	dependsOn []string
+	timeout   time.Duration
+	start     func(context.Context) error
+}
+
+// componentRegistry tracks declared components and their recorded status.
+type componentRegistry struct {
+	mu         sync.RWMutex
+	components []component
+	statuses   map[string]ComponentStatus
+}
+
+// register declares a component to initialize during Start.
+func (r *componentRegistry) register(c component) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.components = append(r.components, c)
+}
+
+// record stores a status directly, for components resolved at build time.
+func (r *componentRegistry) record(status ComponentStatus) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.statuses == nil {
+		r.statuses = make(map[string]ComponentStatus)
+	}
+	r.statuses[status.Name] = status
+}
+
+// snapshot returns all recorded statuses sorted by name.
+func (r *componentRegistry) snapshot() []ComponentStatus {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	out := make([]ComponentStatus, 0, len(r.statuses))
+	for _, status := range r.statuses {
+		out = append(out, status)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+// startAll runs every registered component once and returns an aggregate
+// error for required components that did not come up.
+func (r *componentRegistry) startAll(ctx context.Context) error {
+	r.mu.RLock()
+	components := append([]component(nil), r.components...)
+	r.mu.RUnlock()
+
+	if err := validateComponentGraph(components); err != nil {
+		return err
+	}
+
+	done := make(map[string]chan struct{}, len(components))
+	for _, c := range components {
+		done[c.name] = make(chan struct{})
+	}
+
+	var wg sync.WaitGroup
+	for _, c := range components {
+		wg.Add(1)
+		go func(c component) {
+			defer wg.Done()
+			defer close(done[c.name])
+			for _, dep := range c.dependsOn {
+				<-done[dep]
+			}
+			r.record(r.runComponent(ctx, c))
+		}(c)
+	}
+	wg.Wait()
+
+	var errs []error
+	for _, c := range components {
+		if !c.required {
+			continue
+		}
+		status := r.status(c.name)
+		if status.State == ComponentFailed || status.State == ComponentSkipped {
+			errs = append(errs, fmt.Errorf("component %s %s: %s", c.name, status.State, status.Message))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (r *componentRegistry) status(name string) ComponentStatus {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.statuses[name]
+}
+
+func (r *componentRegistry) runComponent(ctx context.Context, c component) ComponentStatus {
+	status := ComponentStatus{Name: c.name, Required: c.required}
+	for _, dep := range c.dependsOn {
+		if depState := r.status(dep).State; depState != ComponentOK && depState != ComponentDegraded {
+			status.State = ComponentSkipped
+			status.Message = fmt.Sprintf("dependency %s is %s", dep, depState)
+			return status
+		}
+	}
+
+	timeout := c.timeout
+	if timeout <= 0 {
+		timeout = defaultComponentTimeout
+	}
+	startCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	began := time.Now()
+	result := make(chan error, 1)
+	go func() { result <- c.start(startCtx) }()
+
+	var err error
+	select {
+	case err = <-result:
+	case <-startCtx.Done():
+		select {
+		case err = <-result:
+		case <-time.After(componentTimeoutGrace):
+			err = fmt.Errorf("timed out after %s", timeout)
+		}
+	}
+	status.DurationMS = time.Since(began).Milliseconds()
+
+	switch {
+	case err == nil:
+		status.State = ComponentOK
+	case coreerrors.IsDegraded(err):
+		status.State = ComponentDegraded
+		status.Message = err.Error()
+	default:
+		status.State = ComponentFailed
+		status.Message = err.Error()
+	}
+	return status
+}
+
+// validateComponentGraph rejects duplicate names, unknown dependencies and
+// cycles before anything starts.
+func validateComponentGraph(components []component) error {
+	byName := make(map[string]component, len(components))
+	for _, c := range components {
+		if _, dup := byName[c.name]; dup {
+			return fmt.Errorf("duplicate container component %q", c.name)
+		}
+		byName[c.name] = c
+	}
+	const (
+		visiting = iota + 1
+		visited
+	)
+	marks := make(map[string]int, len(components))
+	var visit func(name string) error
+	visit = func(name string) error {
+		switch marks[name] {
+		case visiting:
+			return fmt.Errorf("container component dependency cycle at %q", name)
+		case visited:
+			return nil
+		}
+		marks[name] = visiting
+		for _, dep := range byName[name].dependsOn {
+			if _, ok := byName[dep]; !ok {
+				return fmt.Errorf("container component %q depends on unknown %q", name, dep)
+			}
+			if err := visit(dep); err != nil {
+				return err
+			}
+		}
+		marks[name] = visited
+		return nil
+	}
+	for _, c := range components {
+		if err := visit(c.name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// summarizeComponentStatuses renders counts per state, e.g. "3 ok, 1 degraded".
+func summarizeComponentStatuses(statuses []ComponentStatus) string {
+	if len(statuses) == 0 {
+		return "no components"
+	}
+	counts := make(map[ComponentState]int, 4)
+	for _, status := range statuses {
+		counts[status.State]++
+	}
+	parts := make([]string, 0, len(counts))
+	for _, state := range []ComponentState{ComponentOK, ComponentDegraded, ComponentFailed, ComponentSkipped} {
+		if counts[state] > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", counts[state], state))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,173 @@
+package di
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	coreerrors "alex/internal/core/errors"
+)
+
+func statusByName(t *testing.T, statuses []ComponentStatus, name string) ComponentStatus {
+	t.Helper()
+	for _, status := range statuses {
+		if status.Name == name {
+			return status
+		}
+	}
+	t.Fatalf("no status recorded for %s in %+v", name, statuses)
+	return ComponentStatus{}
+}
+
+func TestComponentRegistryStartsIndependentComponentsConcurrently(t *testing.T) {
+	var reg componentRegistry
+	var running, peak atomic.Int32
+	slow := func(context.Context) error {
+		n := running.Add(1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(50 * time.Millisecond)
+		running.Add(-1)
+		return nil
+	}
+	reg.register(component{name: "a", start: slow})
+	reg.register(component{name: "b", start: slow})
+
+	var depSawBoth atomic.Bool
+	reg.register(component{
+		name:      "c",
+		required:  true,
+		dependsOn: []string{"a", "b"},
+		start: func(context.Context) error {
+			depSawBoth.Store(reg.status("a").State == ComponentOK && reg.status("b").State == ComponentOK)
+			return nil
+		},
+	})
+
+	if err := reg.startAll(context.Background()); err != nil {
+		t.Fatalf("startAll() error = %v", err)
+	}
+	if peak.Load() != 2 {
+		t.Fatalf("expected independent components to overlap, peak concurrency %d", peak.Load())
+	}
+	if !depSawBoth.Load() {
+		t.Fatal("expected dependent component to start after its dependencies")
+	}
+	for _, status := range reg.snapshot() {
+		if status.State != ComponentOK {
+			t.Fatalf("expected all components ok, got %+v", status)
+		}
+	}
+}
+
+func TestComponentRegistryOptionalFailureDoesNotBlockRequired(t *testing.T) {
+	var reg componentRegistry
+	reg.register(component{name: "mcp", start: func(context.Context) error { return errors.New("connection refused") }})
+	reg.register(component{name: "mcp-tools", dependsOn: []string{"mcp"}, start: func(context.Context) error {
+		t.Error("dependent of a failed component should not start")
+		return nil
+	}})
+	reg.register(component{name: "index", start: func(context.Context) error {
+		return coreerrors.NewDegradedError(errors.New("no embedder"), "recall stays lexical", "")
+	}})
+	reg.register(component{name: "store", required: true, start: func(context.Context) error { return nil }})
+
+	if err := reg.startAll(context.Background()); err != nil {
+		t.Fatalf("optional failures should not fail startAll, got %v", err)
+	}
+	statuses := reg.snapshot()
+	if got := statusByName(t, statuses, "mcp"); got.State != ComponentFailed || got.Message != "connection refused" {
+		t.Fatalf("unexpected mcp status: %+v", got)
+	}
+	if got := statusByName(t, statuses, "mcp-tools"); got.State != ComponentSkipped {
+		t.Fatalf("expected dependent to be skipped, got %+v", got)
+	}
+	if got := statusByName(t, statuses, "index"); got.State != ComponentDegraded {
+		t.Fatalf("expected degraded error to mark component degraded, got %+v", got)
+	}
+	if got := statusByName(t, statuses, "store"); got.State != ComponentOK {
+		t.Fatalf("expected required component ok, got %+v", got)
+	}
+}
+
+func TestComponentRegistryRequiredFailureAndTimeout(t *testing.T) {
+	var reg componentRegistry
+	reg.register(component{name: "db", required: true, start: func(context.Context) error { return errors.New("disk full") }})
+	reg.register(component{name: "slow", required: true, timeout: 20 * time.Millisecond, start: func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}})
+	reg.register(component{name: "hung", timeout: 10 * time.Millisecond, start: func(context.Context) error {
+		time.Sleep(2 * time.Second)
+		return nil
+	}})
+
+	err := reg.startAll(context.Background())
+	if err == nil {
+		t.Fatal("expected required failures to be reported")
+	}
+	if !strings.Contains(err.Error(), "component db failed: disk full") || !strings.Contains(err.Error(), "component slow failed") {
+		t.Fatalf("expected both required failures in aggregate error, got %v", err)
+	}
+	if strings.Contains(err.Error(), "hung") {
+		t.Fatalf("optional component should not appear in aggregate error: %v", err)
+	}
+	if got := statusByName(t, reg.snapshot(), "hung"); got.State != ComponentFailed || !strings.Contains(got.Message, "timed out") {
+		t.Fatalf("expected hung component to time out, got %+v", got)
+	}
+}
+
+func TestComponentRegistryRejectsInvalidGraph(t *testing.T) {
+	noop := func(context.Context) error { return nil }
+	cases := map[string][]component{
+		"unknown dependency": {{name: "a", dependsOn: []string{"missing"}, start: noop}},
+		"cycle":              {{name: "a", dependsOn: []string{"b"}, start: noop}, {name: "b", dependsOn: []string{"a"}, start: noop}},
+		"duplicate":          {{name: "a", start: noop}, {name: "a", start: noop}},
+	}
+	for name, components := range cases {
+		t.Run(name, func(t *testing.T) {
+			var reg componentRegistry
+			for _, c := range components {
+				reg.register(c)
+			}
+			if err := reg.startAll(context.Background()); err == nil {
+				t.Fatal("expected invalid component graph to be rejected")
+			}
+		})
+	}
+}
+
+func TestContainerStartRecordsComponentStatuses(t *testing.T) {
+	container, err := BuildContainer(Config{
+		LLMProvider: "mock",
+		LLMModel:    "test",
+		SessionDir:  t.TempDir(),
+		CostDir:     t.TempDir(),
+		MemoryDir:   t.TempDir(),
+	})
+	if err != nil {
+		t.Fatalf("BuildContainer() error = %v", err)
+	}
+	defer func() { _ = container.Shutdown() }()
+
+	if err := container.Start(); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	statuses := container.ComponentStatuses()
+	if got := statusByName(t, statuses, "llm-factory"); got.State != ComponentOK || !got.Required {
+		t.Fatalf("unexpected llm-factory status: %+v", got)
+	}
+	if got := statusByName(t, statuses, "memory"); got.State != ComponentOK {
+		t.Fatalf("unexpected memory status: %+v", got)
+	}
+	if got := statusByName(t, statuses, "memory-indexer"); got.State != ComponentSkipped {
+		t.Fatalf("expected indexer skipped without an embedder, got %+v", got)
+	}
+}
@@ -4,10 +4,16 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	agentcoordinator "alex/internal/app/agent/coordinator"
+	"alex/internal/app/agent/sessiontitle"
+	"alex/internal/app/agent/sessiontools"
+	"alex/internal/app/annotations"
 	"alex/internal/app/lifecycle"
+	"alex/internal/app/maintenance"
+	"alex/internal/app/notifications"
 	"alex/internal/app/toolregistry"
 	coretape "alex/internal/core/tape"
 	lark "alex/internal/delivery/channels/lark"
@@ -21,6 +27,8 @@ import (
 	larkoauth "alex/internal/infra/lark/oauth"
 	"alex/internal/infra/llm"
 	"alex/internal/app/decision"
+	"alex/internal/app/outputpolicy"
+	"alex/internal/app/preferences"
 	"alex/internal/infra/memory"
 	sessionstate "alex/internal/infra/session/state_store"
 	toolspolicy "alex/internal/infra/tools"
@@ -37,15 +45,17 @@ type LarkGateway interface {
 
 // StorageResources groups persistence-related dependencies.
 type StorageResources struct {
-	SessionStore    agentstorage.SessionStore
-	StateStore      sessionstate.Store
-	HistoryStore    sessionstate.Store
-	HistoryManager  agentstorage.HistoryManager
-	CostTracker     agentstorage.CostTracker
-	CheckpointStore react.CheckpointStore
-	MemoryEngine    memory.Engine
-	TaskStore       taskdomain.Store // Unified durable task store (nil when unavailable)
-	DecisionStore   *decision.Store  // Team decision memory (nil when unavailable)
+	SessionStore     agentstorage.SessionStore
+	StateStore       sessionstate.Store
+	HistoryStore     sessionstate.Store
+	HistoryManager   agentstorage.HistoryManager
+	CostTracker      agentstorage.CostTracker
+	CheckpointStore  react.CheckpointStore
+	MemoryEngine     memory.Engine
+	TaskStore        taskdomain.Store   // Unified durable task store (nil when unavailable)
+	DecisionStore    *decision.Store    // Team decision memory (nil when unavailable)
+	PreferencesStore *preferences.Store // Per-user explicit preferences
+	AnnotationStore  *annotations.Store // Reviewer notes and bookmarks on session messages
 }
 
 // Gateways groups external integration gateways.
@@ -62,6 +72,18 @@ type Container struct {
 	StorageResources
 	Gateways
 	TapeManager *coretape.TapeManager
+	// SessionTitler generates session titles/tags and applies user overrides.
+	SessionTitler *sessiontitle.Service
+	// SessionTools persists per-session tool allow/deny overrides.
+	SessionTools *sessiontools.Service
+	// OutputPolicy filters final answers against per-workspace content rules.
+	OutputPolicy *outputpolicy.Service
+	// Notifications is the in-app notification center. Set by the server
+	// bootstrap; nil in CLI mode.
+	Notifications *notifications.Center
+	// Maintenance schedules maintenance windows and their notices. Set by
+	// the server bootstrap; nil in CLI mode.
+	Maintenance *maintenance.Service
 
 	// Drainables holds subsystems that support graceful drain.
 	Drainables []lifecycle.Drainable
@@ -71,6 +93,8 @@ type Container struct {
 	toolRegistry *toolregistry.Registry
 	llmFactory   *llm.Factory
 	bgCancel     context.CancelFunc // cancels background goroutines (e.g. memory cleanup)
+	started      atomic.Bool        // set once Start has completed
+	components   componentRegistry  // heavy initialization run by Start
 }
 
 // Config holds the dependency injection configuration.
@@ -120,13 +144,43 @@ type Config struct {
 	Proactive        runtimeconfig.ProactiveConfig
 	ExternalAgents   runtimeconfig.ExternalAgentsConfig
 	LLMFallbackRules []runtimeconfig.LLMFallbackRuleConfig
+	LLMFallbackProviders []runtimeconfig.LLMFallbackProviderConfig
+	LLMResponseCache runtimeconfig.LLMResponseCacheConfig
+	SessionTitle     sessiontitle.Config
 }
 
-// Start initializes container lifecycle hooks.
+// Start initializes the container's registered components, running
+// independent ones concurrently with per-component timeouts. Only memory
+// schema setup and the memory indexer are components today; other services
+// are still built eagerly by BuildContainer and are not reported. Optional
+// components that fail are recorded in ComponentStatuses without blocking the
+// rest; an error is returned only when a required component fails. Started
+// reports true once it has completed successfully.
 func (c *Container) Start() error {
+	if c.started.Load() {
+		return nil
+	}
+	if err := c.components.startAll(context.Background()); err != nil {
+		return err
+	}
+	logging.NewComponentLogger("DI").Info("Container started (%s)", summarizeComponentStatuses(c.components.snapshot()))
+	c.started.Store(true)
 	return nil
 }
 
+// ComponentStatuses reports how each registered component initialized,
+// sorted by name: llm-factory (resolved at build time), memory and
+// memory-indexer.
+func (c *Container) ComponentStatuses() []ComponentStatus {
+	return c.components.snapshot()
+}
+
+// Started reports whether Start has completed. Readiness checks consult it so
+// the server is not marked ready before lifecycle initialization finishes.
+func (c *Container) Started() bool {
+	return c.started.Load()
+}
+
 // drainTimeout is the per-subsystem timeout for graceful drain.
 const drainTimeout = 5 * time.Second
 
@@ -191,6 +245,23 @@ func (c *Container) LLMFactory() portsllm.LLMClientFactory {
 	return c.llmFactory
 }
 
+// InvalidateLLMClients discards cached LLM clients after a runtime settings
+// change. Tasks that already hold a client are unaffected.
+func (c *Container) InvalidateLLMClients() {
+	if c.llmFactory == nil {
+		return
+	}
+	c.llmFactory.InvalidateCache()
+}
+
+// LLMResponseCache returns the LLM response cache, or nil when disabled.
+func (c *Container) LLMResponseCache() *llm.ResponseCache {
+	if c.llmFactory == nil {
+		return nil
+	}
+	return c.llmFactory.ResponseCache()
+}
+
 // GetModelHealth returns per-model health snapshots from the LLM factory.
 // Returns nil if the factory is not initialized or has no health data.
 func (c *Container) GetModelHealth() []llm.ProviderHealth {
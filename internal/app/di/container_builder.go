@@ -6,6 +6,7 @@ import (
 	"fmt"
 
 	agentcoordinator "alex/internal/app/agent/coordinator"
+	"alex/internal/app/agent/sessiontools"
 	ctxmgr "alex/internal/app/context"
 	"alex/internal/app/lifecycle"
 	coretape "alex/internal/core/tape"
@@ -27,6 +28,11 @@ type containerBuilder struct {
 	sessionDir    string
 	costDir       string
 	cachedTapeStore coretape.TapeStore
+
+	// components are started by Container.Start; componentStatuses are
+	// outcomes already known at build time (disabled or misconfigured).
+	components        []component
+	componentStatuses []ComponentStatus
 }
 
 type sessionResources struct {
@@ -59,6 +65,16 @@ func newContainerBuilder(config Config) *containerBuilder {
 	}
 }
 
+// addComponent defers a component's initialization to Container.Start.
+func (b *containerBuilder) addComponent(c component) {
+	b.components = append(b.components, c)
+}
+
+// recordComponent records a component outcome decided while building.
+func (b *containerBuilder) recordComponent(status ComponentStatus) {
+	b.componentStatuses = append(b.componentStatuses, status)
+}
+
 func (b *containerBuilder) Build() (*Container, error) {
 	b.logger.Debug("Building container with session_dir=%s, cost_dir=%s", b.sessionDir, b.costDir)
 
@@ -69,6 +85,18 @@ func (b *containerBuilder) Build() (*Container, error) {
 	if err != nil {
 		return nil, fmt.Errorf("build decision store: %w", err)
 	}
+	annotationStore, err := b.buildAnnotationStore()
+	if err != nil {
+		return nil, fmt.Errorf("build annotation store: %w", err)
+	}
+	preferencesStore, err := b.buildPreferencesStore()
+	if err != nil {
+		return nil, fmt.Errorf("build preferences store: %w", err)
+	}
+	outputPolicy, err := b.buildOutputPolicy()
+	if err != nil {
+		return nil, fmt.Errorf("build output policy: %w", err)
+	}
 
 	bgCtx, bgCancel := context.WithCancel(context.Background())
 	buildOK := false
@@ -122,6 +150,7 @@ func (b *containerBuilder) Build() (*Container, error) {
 	checkpointStore := b.buildCheckpointStore()
 	credentialRefresher := buildCredentialRefresher()
 	tapeMgr := b.buildTapeManager()
+	sessionTitler := b.buildSessionTitler(resources.sessionStore, llmFactory, credentialRefresher)
 
 	coordinator := agentcoordinator.NewAgentCoordinator(
 		llmFactory,
@@ -134,6 +163,7 @@ func (b *containerBuilder) Build() (*Container, error) {
 		b.buildAgentAppConfig(),
 		agentcoordinator.WithHookRuntime(hookRuntime),
 		agentcoordinator.WithOKRContextProvider(okrContextProvider),
+		agentcoordinator.WithPreferencesContextProvider(b.buildPreferencesContextProvider(preferencesStore)),
 		agentcoordinator.WithCheckpointStore(checkpointStore),
 		agentcoordinator.WithCredentialRefresher(credentialRefresher),
 		agentcoordinator.WithToolSLACollector(toolSLACollector),
@@ -141,6 +171,8 @@ func (b *containerBuilder) Build() (*Container, error) {
 		agentcoordinator.WithAtomicWriter(adapters.NewOSAtomicWriter()),
 		agentcoordinator.WithTurnRecorder(b.buildTurnRecorder(tapeMgr)),
 		agentcoordinator.WithTapeManager(tapeMgr),
+		agentcoordinator.WithSessionTitler(sessionTitlerOption(sessionTitler)),
+		agentcoordinator.WithOutputPolicy(outputPolicyOption(outputPolicy)),
 	)
 
 	b.logger.Info("Container built successfully (heavy initialization deferred to Start())")
@@ -148,25 +180,40 @@ func (b *containerBuilder) Build() (*Container, error) {
 	container := &Container{
 		AgentCoordinator: coordinator,
 		StorageResources: StorageResources{
-			SessionStore:    resources.sessionStore,
-			StateStore:      resources.stateStore,
-			HistoryStore:    resources.historyStore,
-			HistoryManager:  historyMgr,
-			CostTracker:     costTracker,
-			MemoryEngine:    memoryEngine,
-			CheckpointStore: checkpointStore,
-			TaskStore:       taskStore,
-			DecisionStore:   decisionStore,
+			SessionStore:     resources.sessionStore,
+			StateStore:       resources.stateStore,
+			HistoryStore:     resources.historyStore,
+			HistoryManager:   historyMgr,
+			CostTracker:      costTracker,
+			MemoryEngine:     memoryEngine,
+			CheckpointStore:  checkpointStore,
+			TaskStore:        taskStore,
+			DecisionStore:    decisionStore,
+			PreferencesStore: preferencesStore,
+			AnnotationStore:  annotationStore,
 		},
-		TapeManager:  tapeMgr,
-		config:       b.config,
-		toolRegistry: toolRegistry,
-		llmFactory:   llmFactory,
-		bgCancel:     bgCancel,
+		TapeManager:   tapeMgr,
+		SessionTitler: sessionTitler,
+		SessionTools:  sessiontools.New(resources.sessionStore),
+		OutputPolicy:  outputPolicy,
+		config:        b.config,
+		toolRegistry:  toolRegistry,
+		llmFactory:    llmFactory,
+		bgCancel:      bgCancel,
+	}
+	container.components.record(ComponentStatus{Name: "llm-factory", State: ComponentOK, Required: true})
+	for _, status := range b.componentStatuses {
+		container.components.record(status)
+	}
+	for _, c := range b.components {
+		container.components.register(c)
 	}
 	if drainable, ok := memoryEngine.(lifecycle.Drainable); ok {
 		container.Drainables = append(container.Drainables, drainable)
 	}
+	if drainable, ok := costTracker.(lifecycle.Drainable); ok {
+		container.Drainables = append(container.Drainables, drainable)
+	}
 	if taskStoreCloser, ok := taskStore.(interface{ Close() }); ok {
 		container.Drainables = append(container.Drainables, lifecycle.DrainFunc{
 			DrainName: "task-store",
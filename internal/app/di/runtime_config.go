@@ -55,5 +55,7 @@ func ConfigFromRuntimeConfig(runtime runtimeconfig.RuntimeConfig) Config {
 		Proactive:          runtime.Proactive,
 		ExternalAgents:     runtime.ExternalAgents,
 		LLMFallbackRules:   runtime.LLMFallbackRules,
+		LLMFallbackProviders: runtime.LLMFallbackProviders,
+		LLMResponseCache: runtime.LLMResponseCache,
 	}
 }
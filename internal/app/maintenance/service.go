@@ -0,0 +1,236 @@
+package maintenance
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"alex/internal/shared/logging"
+)
+
+const (
+	// defaultReloadInterval bounds how long a window scheduled by another
+	// process (e.g. the web server while the Lark gateway runs standalone)
+	// takes to show up.
+	defaultReloadInterval = 30 * time.Second
+	subscriberBuffer      = 1
+)
+
+// Config tunes notice timing.
+type Config struct {
+	// NoticeLead is how long before StartsAt the upcoming notice appears.
+	NoticeLead time.Duration
+	// ReloadInterval is how often the store file is re-read.
+	ReloadInterval time.Duration
+}
+
+func (c Config) withDefaults() Config {
+	if c.NoticeLead <= 0 {
+		c.NoticeLead = DefaultNoticeLead
+	}
+	if c.ReloadInterval <= 0 {
+		c.ReloadInterval = defaultReloadInterval
+	}
+	return c
+}
+
+// Service schedules maintenance windows, answers what notice is current, and
+// wakes subscribers whenever the notice may have changed: on schedule and
+// cancel, when a window's lead time or start is reached, and when a window
+// ends and is cleared.
+type Service struct {
+	store  *Store
+	cfg    Config
+	logger logging.Logger
+	now    func() time.Time
+
+	mu          sync.Mutex
+	subscribers map[chan struct{}]struct{}
+	wake        chan struct{}
+}
+
+// NewService creates a Service. Call Run to enable auto-clear and
+// time-based wakeups.
+func NewService(store *Store, cfg Config, logger logging.Logger) *Service {
+	if logging.IsNil(logger) {
+		logger = logging.NewComponentLogger("Maintenance")
+	}
+	return &Service{
+		store:       store,
+		cfg:         cfg.withDefaults(),
+		logger:      logger,
+		now:         time.Now,
+		subscribers: make(map[chan struct{}]struct{}),
+		wake:        make(chan struct{}, 1),
+	}
+}
+
+// Schedule validates and stores a new window.
+func (s *Service) Schedule(ctx context.Context, w Window) (Window, error) {
+	w = normalizeWindow(w)
+	if err := validateWindow(w, s.now()); err != nil {
+		return Window{}, err
+	}
+	stored, err := s.store.Add(ctx, w)
+	if err != nil {
+		return Window{}, err
+	}
+	s.logger.Info("Maintenance window %s scheduled: %s → %s", stored.ID, stored.StartsAt.Format(time.RFC3339), stored.EndsAt.Format(time.RFC3339))
+	s.changed()
+	return stored, nil
+}
+
+// Cancel removes a scheduled or in-progress window.
+func (s *Service) Cancel(ctx context.Context, windowID string) error {
+	if err := s.store.Delete(ctx, windowID); err != nil {
+		return err
+	}
+	s.logger.Info("Maintenance window %s cancelled", windowID)
+	s.changed()
+	return nil
+}
+
+// Windows lists windows that have not ended yet.
+func (s *Service) Windows(ctx context.Context) ([]Window, error) {
+	windows, err := s.store.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	now := s.now()
+	live := windows[:0]
+	for _, w := range windows {
+		if w.EndsAt.After(now) {
+			live = append(live, w)
+		}
+	}
+	return live, nil
+}
+
+// Notice returns the announcement for the earliest window that is in
+// progress or starts within the notice lead.
+func (s *Service) Notice() (Notice, bool) {
+	now := s.now()
+	for _, w := range s.snapshot() {
+		if !w.EndsAt.After(now) || now.Before(w.StartsAt.Add(-s.cfg.NoticeLead)) {
+			continue
+		}
+		phase := PhaseUpcoming
+		if w.Contains(now) {
+			phase = PhaseActive
+		}
+		return Notice{WindowID: w.ID, Phase: phase, Message: w.Message, StartsAt: w.StartsAt, EndsAt: w.EndsAt}, true
+	}
+	return Notice{}, false
+}
+
+// Active returns the window in progress, if any. Task creation is refused
+// until its EndsAt.
+func (s *Service) Active() (Window, bool) {
+	now := s.now()
+	for _, w := range s.snapshot() {
+		if w.Contains(now) {
+			return w, true
+		}
+	}
+	return Window{}, false
+}
+
+// Subscribe registers a listener that is signalled whenever the current
+// notice may have changed. Signals coalesce; callers re-read Notice.
+func (s *Service) Subscribe() (<-chan struct{}, func()) {
+	ch := make(chan struct{}, subscriberBuffer)
+	s.mu.Lock()
+	s.subscribers[ch] = struct{}{}
+	s.mu.Unlock()
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			s.mu.Lock()
+			delete(s.subscribers, ch)
+			s.mu.Unlock()
+		})
+	}
+}
+
+// Run clears ended windows and wakes subscribers at each notice transition
+// until ctx is cancelled.
+func (s *Service) Run(ctx context.Context) {
+	reload := time.NewTicker(s.cfg.ReloadInterval)
+	defer reload.Stop()
+	for {
+		s.clearEnded()
+		timer := time.NewTimer(s.untilNextTransition())
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+			s.notify()
+		case <-reload.C:
+			timer.Stop()
+			if err := s.store.Reload(); err != nil {
+				s.logger.Warn("Maintenance windows reload failed: %v", err)
+			}
+			s.notify()
+		case <-s.wake:
+			timer.Stop()
+		}
+	}
+}
+
+func (s *Service) clearEnded() {
+	ended, err := s.store.PruneEnded(s.now())
+	if err != nil {
+		s.logger.Warn("Maintenance window auto-clear failed: %v", err)
+	}
+	for _, w := range ended {
+		s.logger.Info("Maintenance window %s ended and was cleared", w.ID)
+	}
+	if len(ended) > 0 {
+		s.notify()
+	}
+}
+
+// untilNextTransition returns the delay until the next lead, start, or end
+// instant of any window, capped by the reload interval.
+func (s *Service) untilNextTransition() time.Duration {
+	now := s.now()
+	next := s.cfg.ReloadInterval
+	for _, w := range s.snapshot() {
+		for _, at := range []time.Time{w.StartsAt.Add(-s.cfg.NoticeLead), w.StartsAt, w.EndsAt} {
+			if delay := at.Sub(now); delay > 0 && delay < next {
+				next = delay
+			}
+		}
+	}
+	return next
+}
+
+func (s *Service) snapshot() []Window {
+	windows, err := s.store.List(context.Background())
+	if err != nil {
+		return nil
+	}
+	return windows
+}
+
+// changed wakes Run to recompute its timer and signals subscribers.
+func (s *Service) changed() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+	s.notify()
+}
+
+func (s *Service) notify() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for ch := range s.subscribers {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package maintenance
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func newTestService(t *testing.T, path string, now time.Time) *Service {
+	t.Helper()
+	store, err := NewStore(path)
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	svc := NewService(store, Config{NoticeLead: time.Hour}, nil)
+	svc.now = func() time.Time { return now }
+	return svc
+}
+
+func TestScheduleValidatesAndPersistsAcrossRestarts(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "maintenance.json")
+	now := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	svc := newTestService(t, path, now)
+	ctx := context.Background()
+
+	for _, bad := range []Window{
+		{StartsAt: now.Add(time.Hour), EndsAt: now.Add(30 * time.Minute), Message: "x"},
+		{StartsAt: now.Add(-2 * time.Hour), EndsAt: now.Add(-time.Hour), Message: "x"},
+		{StartsAt: now.Add(time.Hour), EndsAt: now.Add(2 * time.Hour)},
+	} {
+		if _, err := svc.Schedule(ctx, bad); !errors.Is(err, ErrInvalidWindow) {
+			t.Fatalf("expected ErrInvalidWindow for %+v, got %v", bad, err)
+		}
+	}
+	stored, err := svc.Schedule(ctx, Window{StartsAt: now.Add(2 * time.Hour), EndsAt: now.Add(3 * time.Hour), Message: " DB upgrade "})
+	if err != nil {
+		t.Fatalf("Schedule: %v", err)
+	}
+	if stored.ID == "" || stored.Message != "DB upgrade" {
+		t.Fatalf("unexpected stored window: %+v", stored)
+	}
+
+	restarted := newTestService(t, path, now)
+	windows, err := restarted.Windows(ctx)
+	if err != nil || len(windows) != 1 || windows[0].ID != stored.ID {
+		t.Fatalf("expected window to survive restart, got %+v err=%v", windows, err)
+	}
+	if err := restarted.Cancel(ctx, stored.ID); err != nil {
+		t.Fatalf("Cancel: %v", err)
+	}
+	if err := restarted.Cancel(ctx, stored.ID); !errors.Is(err, ErrWindowNotFound) {
+		t.Fatalf("expected ErrWindowNotFound, got %v", err)
+	}
+}
+
+func TestNoticeFollowsLeadTimeAndWindow(t *testing.T) {
+	start := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	svc := newTestService(t, "", start.Add(-3*time.Hour))
+	window, err := svc.Schedule(context.Background(), Window{StartsAt: start, EndsAt: start.Add(time.Hour), Message: "upgrade"})
+	if err != nil {
+		t.Fatalf("Schedule: %v", err)
+	}
+
+	cases := []struct {
+		at     time.Time
+		phase  Phase
+		active bool
+	}{
+		{at: start.Add(-61 * time.Minute)},
+		{at: start.Add(-time.Hour), phase: PhaseUpcoming},
+		{at: start, phase: PhaseActive, active: true},
+		{at: start.Add(59 * time.Minute), phase: PhaseActive, active: true},
+		{at: start.Add(time.Hour)},
+	}
+	for _, tc := range cases {
+		svc.now = func() time.Time { return tc.at }
+		notice, ok := svc.Notice()
+		if ok != (tc.phase != "") || notice.Phase != tc.phase {
+			t.Fatalf("at %s: notice=%+v ok=%v, want phase %q", tc.at, notice, ok, tc.phase)
+		}
+		if ok && notice.WindowID != window.ID {
+			t.Fatalf("at %s: notice for wrong window %+v", tc.at, notice)
+		}
+		if _, active := svc.Active(); active != tc.active {
+			t.Fatalf("at %s: active=%v, want %v", tc.at, active, tc.active)
+		}
+	}
+}
+
+func TestRunClearsWindowAtEndAndWakesSubscribers(t *testing.T) {
+	store, err := NewStore(filepath.Join(t.TempDir(), "maintenance.json"))
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	svc := NewService(store, Config{}, nil)
+	updates, unsubscribe := svc.Subscribe()
+	defer unsubscribe()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go svc.Run(ctx)
+
+	now := time.Now()
+	if _, err := svc.Schedule(ctx, Window{StartsAt: now.Add(-time.Minute), EndsAt: now.Add(150 * time.Millisecond), Message: "hotfix"}); err != nil {
+		t.Fatalf("Schedule: %v", err)
+	}
+	if _, ok := svc.Active(); !ok {
+		t.Fatal("expected window to be active")
+	}
+
+	deadline := time.After(5 * time.Second)
+	for {
+		select {
+		case <-updates:
+		case <-deadline:
+			t.Fatal("window was not cleared at its end time")
+		}
+		windows, _ := store.List(ctx)
+		if len(windows) == 0 {
+			break
+		}
+	}
+	if _, ok := svc.Notice(); ok {
+		t.Fatal("expected no notice after the window cleared")
+	}
+}
@@ -0,0 +1,169 @@
+package maintenance
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"alex/internal/infra/filestore"
+	runtimeconfig "alex/internal/shared/config"
+	jsonx "alex/internal/shared/json"
+	id "alex/internal/shared/utils/id"
+)
+
+const (
+	storeDocVersion = 1
+	storeFilename   = "maintenance.json"
+	storePathEnvVar = "ALEX_MAINTENANCE_PATH"
+)
+
+type storeDoc struct {
+	Version int      `json:"version"`
+	Windows []Window `json:"windows"`
+}
+
+// Store persists maintenance windows in a single JSON file so schedules
+// survive restarts and are shared by the web server and channel gateways.
+type Store struct {
+	coll *filestore.Collection[string, Window]
+}
+
+// ResolveStorePath returns the maintenance windows file path.
+//
+// Priority:
+//  1. Explicit ALEX_MAINTENANCE_PATH.
+//  2. Sibling to the resolved config path (defaults to ~/.alex/maintenance.json).
+func ResolveStorePath(envLookup runtimeconfig.EnvLookup, homeDir func() (string, error)) string {
+	if envLookup == nil {
+		envLookup = runtimeconfig.DefaultEnvLookup
+	}
+	if value, ok := envLookup(storePathEnvVar); ok {
+		if trimmed := strings.TrimSpace(value); trimmed != "" {
+			return trimmed
+		}
+	}
+	configPath, _ := runtimeconfig.ResolveConfigPath(envLookup, homeDir)
+	return filepath.Join(filepath.Dir(configPath), storeFilename)
+}
+
+// NewStore loads the store from path. An empty path yields an in-memory store.
+func NewStore(path string) (*Store, error) {
+	coll := filestore.NewCollection[string, Window](filestore.CollectionConfig{
+		FilePath: strings.TrimSpace(path),
+		Perm:     0o600,
+		Name:     "maintenance",
+	})
+	coll.SetMarshalDoc(marshalStoreDoc)
+	coll.SetUnmarshalDoc(unmarshalStoreDoc)
+	if err := coll.Load(); err != nil {
+		return nil, fmt.Errorf("load maintenance windows: %w", err)
+	}
+	return &Store{coll: coll}, nil
+}
+
+// Reload re-reads the backing file to pick up windows scheduled by another
+// process.
+func (s *Store) Reload() error {
+	return s.coll.Load()
+}
+
+// List returns every window ordered by start time.
+func (s *Store) List(ctx context.Context) ([]Window, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	snapshot := s.coll.Snapshot()
+	windows := make([]Window, 0, len(snapshot))
+	for _, w := range snapshot {
+		windows = append(windows, w)
+	}
+	sortWindows(windows)
+	return windows, nil
+}
+
+// Add stores w under a fresh ID.
+func (s *Store) Add(ctx context.Context, w Window) (Window, error) {
+	if err := ctx.Err(); err != nil {
+		return Window{}, err
+	}
+	w.ID = "mw-" + id.NewKSUID()
+	w.CreatedAt = s.coll.Now().UTC()
+	if err := s.coll.Put(w.ID, w); err != nil {
+		return Window{}, err
+	}
+	return w, nil
+}
+
+// Delete removes the window with windowID.
+func (s *Store) Delete(ctx context.Context, windowID string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	windowID = strings.TrimSpace(windowID)
+	return s.coll.Mutate(func(items map[string]Window) error {
+		if _, ok := items[windowID]; !ok {
+			return ErrWindowNotFound
+		}
+		delete(items, windowID)
+		return nil
+	})
+}
+
+// PruneEnded removes windows whose end time is not after now and returns them.
+func (s *Store) PruneEnded(now time.Time) ([]Window, error) {
+	var ended []Window
+	s.coll.ReadLocked(func(items map[string]Window) {
+		for _, w := range items {
+			if !w.EndsAt.After(now) {
+				ended = append(ended, w)
+			}
+		}
+	})
+	if len(ended) == 0 {
+		return nil, nil
+	}
+	err := s.coll.Mutate(func(items map[string]Window) error {
+		for _, w := range ended {
+			delete(items, w.ID)
+		}
+		return nil
+	})
+	return ended, err
+}
+
+func sortWindows(windows []Window) {
+	sort.Slice(windows, func(i, j int) bool {
+		if !windows[i].StartsAt.Equal(windows[j].StartsAt) {
+			return windows[i].StartsAt.Before(windows[j].StartsAt)
+		}
+		return windows[i].ID < windows[j].ID
+	})
+}
+
+func marshalStoreDoc(items map[string]Window) ([]byte, error) {
+	doc := storeDoc{Version: storeDocVersion, Windows: make([]Window, 0, len(items))}
+	for _, w := range items {
+		doc.Windows = append(doc.Windows, w)
+	}
+	sortWindows(doc.Windows)
+	return filestore.MarshalJSONIndent(doc)
+}
+
+func unmarshalStoreDoc(data []byte) (map[string]Window, error) {
+	var doc storeDoc
+	if err := jsonx.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("decode maintenance windows: %w", err)
+	}
+	items := make(map[string]Window, len(doc.Windows))
+	for _, w := range doc.Windows {
+		w = normalizeWindow(w)
+		if w.ID == "" {
+			continue
+		}
+		items[w.ID] = w
+	}
+	return items, nil
+}
@@ -0,0 +1,81 @@
+package maintenance
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DefaultNoticeLead is how long before a window starts its notice is shown.
+const DefaultNoticeLead = 30 * time.Minute
+
+// ErrInvalidWindow is returned when a window fails validation.
+var ErrInvalidWindow = errors.New("invalid maintenance window")
+
+// ErrWindowNotFound is returned when cancelling an unknown window.
+var ErrWindowNotFound = errors.New("maintenance window not found")
+
+// Window is a scheduled maintenance period. New task creation is refused
+// between StartsAt and EndsAt; the window is removed once EndsAt passes.
+type Window struct {
+	ID        string    `json:"id"`
+	StartsAt  time.Time `json:"starts_at"`
+	EndsAt    time.Time `json:"ends_at"`
+	Message   string    `json:"message"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Contains reports whether now falls inside the window.
+func (w Window) Contains(now time.Time) bool {
+	return !now.Before(w.StartsAt) && now.Before(w.EndsAt)
+}
+
+// Phase describes where a notice sits relative to its window.
+type Phase string
+
+const (
+	// PhaseUpcoming is shown from StartsAt minus the notice lead until StartsAt.
+	PhaseUpcoming Phase = "upcoming"
+	// PhaseActive is shown while the window is in progress.
+	PhaseActive Phase = "active"
+	// PhaseCleared is pushed to live subscribers once an announced window
+	// ends or is cancelled.
+	PhaseCleared Phase = "cleared"
+)
+
+// Notice is the user-facing announcement for the nearest window.
+type Notice struct {
+	WindowID string    `json:"window_id"`
+	Phase    Phase     `json:"phase"`
+	Message  string    `json:"message,omitempty"`
+	StartsAt time.Time `json:"starts_at,omitempty"`
+	EndsAt   time.Time `json:"ends_at,omitempty"`
+}
+
+// Cleared returns the notice that retracts n.
+func (n Notice) Cleared() Notice {
+	return Notice{WindowID: n.WindowID, Phase: PhaseCleared}
+}
+
+func normalizeWindow(w Window) Window {
+	w.ID = strings.TrimSpace(w.ID)
+	w.Message = strings.TrimSpace(w.Message)
+	w.StartsAt = w.StartsAt.UTC()
+	w.EndsAt = w.EndsAt.UTC()
+	return w
+}
+
+func validateWindow(w Window, now time.Time) error {
+	switch {
+	case w.StartsAt.IsZero() || w.EndsAt.IsZero():
+		return fmt.Errorf("%w: starts_at and ends_at are required", ErrInvalidWindow)
+	case !w.EndsAt.After(w.StartsAt):
+		return fmt.Errorf("%w: ends_at must be after starts_at", ErrInvalidWindow)
+	case !w.EndsAt.After(now):
+		return fmt.Errorf("%w: ends_at is in the past", ErrInvalidWindow)
+	case w.Message == "":
+		return fmt.Errorf("%w: message is required", ErrInvalidWindow)
+	}
+	return nil
+}
@@ -0,0 +1,230 @@
+package notifications
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	agent "alex/internal/domain/agent/ports/agent"
+	"alex/internal/shared/logging"
+	"alex/internal/shared/notification"
+)
+
+const (
+	// deliveredLedgerSize bounds the cross-channel dedup ledger.
+	deliveredLedgerSize = 4096
+	subscriberBuffer    = 16
+)
+
+// UserResolver maps a session to the user that owns it. An empty result
+// falls back to DefaultUserID.
+type UserResolver func(ctx context.Context, sessionID string) string
+
+// Update is pushed to live subscribers when a notification is created or the
+// unread count changes.
+type Update struct {
+	Notification *Notification `json:"notification,omitempty"`
+	Unread       int           `json:"unread"`
+}
+
+// Center turns bus events into notifications and fans out live updates.
+type Center struct {
+	store       *Store
+	cfg         Config
+	resolveUser UserResolver
+	logger      logging.Logger
+
+	mu          sync.Mutex
+	subscribers map[string]map[chan Update]struct{}
+	delivered   map[string]struct{}
+	deliveredQ  []string
+}
+
+// New creates a Center. resolver may be nil, in which case every event is
+// attributed to DefaultUserID.
+func New(store *Store, cfg Config, resolver UserResolver, logger logging.Logger) *Center {
+	if logging.IsNil(logger) {
+		logger = logging.NewComponentLogger("Notifications")
+	}
+	return &Center{
+		store:       store,
+		cfg:         cfg.withDefaults(),
+		resolveUser: resolver,
+		logger:      logger,
+		subscribers: make(map[string]map[chan Update]struct{}),
+		delivered:   make(map[string]struct{}),
+	}
+}
+
+// HandleEvent implements eventbus.Consumer.
+func (c *Center) HandleEvent(event agent.AgentEvent) error {
+	draft, ok := draftFromEvent(event)
+	if !ok || !c.cfg.allows(draft.Type) {
+		return nil
+	}
+	eventID := event.GetEventID()
+	if c.wasDelivered(eventID) {
+		return nil
+	}
+	ctx := context.Background()
+	sessionID := event.GetSessionID()
+	draft.EventID = eventID
+	draft.SessionID = sessionID
+	draft.Link = SessionLink(sessionID)
+	if c.resolveUser != nil && sessionID != "" {
+		draft.UserID = c.resolveUser(ctx, sessionID)
+	}
+	_, _, err := c.Notify(ctx, draft)
+	return err
+}
+
+// Notify stores n and announces it to the user's live subscribers. It is the
+// entry point for producers outside the event bus (budget monitors, digests).
+// created is false when n's type is disabled, its event was already delivered
+// on another channel, or an identical event notification exists.
+func (c *Center) Notify(ctx context.Context, n Notification) (Notification, bool, error) {
+	if c == nil || c.store == nil {
+		return Notification{}, false, nil
+	}
+	if !c.cfg.allows(n.Type) || c.wasDelivered(n.EventID) {
+		return Notification{}, false, nil
+	}
+	if strings.TrimSpace(n.Title) == "" {
+		return Notification{}, false, fmt.Errorf("notification title is required")
+	}
+	stored, created, err := c.store.Add(ctx, n, c.cfg.MaxPerUser)
+	if err != nil || !created {
+		return stored, false, err
+	}
+	c.announce(ctx, stored.UserID, &stored)
+	return stored, true, nil
+}
+
+// Send implements notification.Notifier so digest-style producers can target
+// the in-app channel; target.ChatID carries the user ID.
+func (c *Center) Send(ctx context.Context, target notification.Target, content string) error {
+	content = strings.TrimSpace(content)
+	title, body, _ := strings.Cut(content, "\n")
+	_, _, err := c.Notify(ctx, Notification{
+		UserID: target.ChatID,
+		Type:   TypeDigest,
+		Title:  clipBody(strings.TrimLeft(title, "# ")),
+		Body:   clipBody(body),
+	})
+	return err
+}
+
+// List returns the user's notifications newest-first and the unread count.
+func (c *Center) List(ctx context.Context, userID string, unreadOnly bool, limit int) ([]Notification, int, error) {
+	return c.store.List(ctx, userID, unreadOnly, limit)
+}
+
+// MarkRead marks one notification read and announces the new unread count.
+func (c *Center) MarkRead(ctx context.Context, userID, notificationID string) (Notification, error) {
+	updated, err := c.store.MarkRead(ctx, userID, notificationID)
+	if err != nil {
+		return Notification{}, err
+	}
+	c.announce(ctx, updated.UserID, nil)
+	return updated, nil
+}
+
+// MarkAllRead marks all of the user's notifications read.
+func (c *Center) MarkAllRead(ctx context.Context, userID string) (int, error) {
+	changed, err := c.store.MarkAllRead(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+	if changed > 0 {
+		c.announce(ctx, normalizeUserID(userID), nil)
+	}
+	return changed, nil
+}
+
+// UnreadCount returns the user's unread count.
+func (c *Center) UnreadCount(ctx context.Context, userID string) (int, error) {
+	return c.store.UnreadCount(ctx, userID)
+}
+
+// Subscribe registers a live listener for the user. Slow subscribers miss
+// updates rather than blocking producers; each update carries the current
+// unread count so a later update resynchronises the badge.
+func (c *Center) Subscribe(userID string) (<-chan Update, func()) {
+	userID = normalizeUserID(userID)
+	ch := make(chan Update, subscriberBuffer)
+	c.mu.Lock()
+	if c.subscribers[userID] == nil {
+		c.subscribers[userID] = make(map[chan Update]struct{})
+	}
+	c.subscribers[userID][ch] = struct{}{}
+	c.mu.Unlock()
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			c.mu.Lock()
+			delete(c.subscribers[userID], ch)
+			if len(c.subscribers[userID]) == 0 {
+				delete(c.subscribers, userID)
+			}
+			c.mu.Unlock()
+		})
+	}
+}
+
+// MarkDelivered records that the user was already messaged about eventID on
+// another channel, suppressing the in-app notification for it.
+func (c *Center) MarkDelivered(eventID string) {
+	eventID = strings.TrimSpace(eventID)
+	if c == nil || eventID == "" {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.delivered[eventID]; ok {
+		return
+	}
+	c.delivered[eventID] = struct{}{}
+	c.deliveredQ = append(c.deliveredQ, eventID)
+	if len(c.deliveredQ) > deliveredLedgerSize {
+		delete(c.delivered, c.deliveredQ[0])
+		c.deliveredQ = c.deliveredQ[1:]
+	}
+}
+
+func (c *Center) wasDelivered(eventID string) bool {
+	if eventID == "" {
+		return false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, ok := c.delivered[eventID]
+	return ok
+}
+
+func (c *Center) announce(ctx context.Context, userID string, created *Notification) {
+	c.mu.Lock()
+	subs := make([]chan Update, 0, len(c.subscribers[userID]))
+	for ch := range c.subscribers[userID] {
+		subs = append(subs, ch)
+	}
+	c.mu.Unlock()
+	if len(subs) == 0 {
+		return
+	}
+	unread, err := c.store.UnreadCount(ctx, userID)
+	if err != nil {
+		c.logger.Warn("Notification unread count for %s failed: %v", userID, err)
+		return
+	}
+	update := Update{Notification: created, Unread: unread}
+	for _, ch := range subs {
+		select {
+		case ch <- update:
+		default:
+		}
+	}
+}
+
+var _ notification.Notifier = (*Center)(nil)
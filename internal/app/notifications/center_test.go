@@ -0,0 +1,162 @@
+package notifications
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"alex/internal/app/agent/eventbus"
+	domain "alex/internal/domain/agent"
+	agent "alex/internal/domain/agent/ports/agent"
+	"alex/internal/domain/agent/types"
+	"alex/internal/shared/notification"
+)
+
+func envelope(level agent.AgentLevel, sessionID, eventType string, payload map[string]any) *domain.WorkflowEventEnvelope {
+	return &domain.WorkflowEventEnvelope{
+		BaseEvent: domain.NewBaseEvent(level, sessionID, "run-1", "", time.Now()),
+		Event:     eventType,
+		Payload:   payload,
+	}
+}
+
+func newTestCenter(t *testing.T, cfg Config) *Center {
+	t.Helper()
+	store, err := NewStore("")
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	users := map[string]string{"web-1": "ou_alice", "web-2": "ou_bob"}
+	return New(store, cfg, func(_ context.Context, sessionID string) string { return users[sessionID] }, nil)
+}
+
+func TestCenterCreatesNotificationsFromBusEvents(t *testing.T) {
+	center := newTestCenter(t, Config{})
+	bus := eventbus.New(nil)
+	if _, err := bus.Subscribe(eventbus.Subscription{Name: "notifications", Consumer: center}); err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+
+	bus.Publish(envelope(agent.LevelCore, "web-1", types.EventResultFinal, map[string]any{"final_answer": "Report ready", "total_tokens": 120}))
+	bus.Publish(envelope(agent.LevelCore, "web-1", types.EventToolCompleted, map[string]any{
+		"tool_name": "ask_user",
+		"metadata":  map[string]any{"action": "request", "message": "Approve the release?"},
+	}))
+	// Clarifying questions, subagent results, and progress never notify.
+	bus.Publish(envelope(agent.LevelCore, "web-1", types.EventToolCompleted, map[string]any{
+		"tool_name": "ask_user",
+		"metadata":  map[string]any{"action": "clarify", "message": "Which repo?"},
+	}))
+	bus.Publish(envelope(agent.LevelSubagent, "web-1", types.EventResultFinal, map[string]any{"final_answer": "sub"}))
+	bus.Publish(envelope(agent.LevelCore, "web-1", types.EventNodeStarted, nil))
+	bus.Publish(envelope(agent.LevelCore, "web-2", types.EventNodeFailed, map[string]any{"recoverable": false, "error": "provider down"}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := bus.Flush(ctx); err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+
+	alice, unread, err := center.List(context.Background(), "ou_alice", false, 0)
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(alice) != 2 || unread != 2 {
+		t.Fatalf("expected 2 unread notifications for alice, got %d (unread %d): %+v", len(alice), unread, alice)
+	}
+	if alice[0].Type != TypeInputRequested || alice[0].Body != "Approve the release?" {
+		t.Fatalf("unexpected newest notification: %+v", alice[0])
+	}
+	if alice[1].Type != TypeTaskCompleted || alice[1].Link != "/sessions/web-1" || alice[1].EventID == "" {
+		t.Fatalf("unexpected completion notification: %+v", alice[1])
+	}
+	bob, _, _ := center.List(context.Background(), "ou_bob", false, 0)
+	if len(bob) != 1 || bob[0].Type != TypeTaskFailed || bob[0].Body != "provider down" {
+		t.Fatalf("unexpected notifications for bob: %+v", bob)
+	}
+}
+
+func TestCenterRespectsConfiguredTypes(t *testing.T) {
+	center := newTestCenter(t, Config{Types: ParseTypes([]string{"task_failed", "unknown"})})
+	if err := center.HandleEvent(envelope(agent.LevelCore, "web-1", types.EventResultFinal, nil)); err != nil {
+		t.Fatalf("HandleEvent: %v", err)
+	}
+	if _, unread, _ := center.List(context.Background(), "ou_alice", false, 0); unread != 0 {
+		t.Fatalf("expected disabled type to be skipped, got %d", unread)
+	}
+}
+
+func TestCenterReadStateTransitionsAndLiveUpdates(t *testing.T) {
+	center := newTestCenter(t, Config{})
+	ctx := context.Background()
+	updates, cancel := center.Subscribe("ou_alice")
+	defer cancel()
+
+	first, created, err := center.Notify(ctx, Notification{UserID: "ou_alice", Type: TypeBudgetWarning, Title: "80% of budget used"})
+	if err != nil || !created {
+		t.Fatalf("Notify = %v, %v", created, err)
+	}
+	if _, _, err := center.Notify(ctx, Notification{UserID: "ou_alice", Type: TypeDigest, Title: "Daily digest"}); err != nil {
+		t.Fatalf("Notify: %v", err)
+	}
+	for want := 1; want <= 2; want++ {
+		update := <-updates
+		if update.Notification == nil || update.Unread != want {
+			t.Fatalf("unexpected creation update: %+v", update)
+		}
+	}
+
+	read, err := center.MarkRead(ctx, "ou_alice", first.ID)
+	if err != nil || !read.Read || read.ReadAt == nil {
+		t.Fatalf("MarkRead = %+v, %v", read, err)
+	}
+	if update := <-updates; update.Notification != nil || update.Unread != 1 {
+		t.Fatalf("unexpected read update: %+v", update)
+	}
+	unreadOnly, unread, _ := center.List(ctx, "ou_alice", true, 0)
+	if len(unreadOnly) != 1 || unreadOnly[0].Type != TypeDigest || unread != 1 {
+		t.Fatalf("unexpected unread list: %+v (unread %d)", unreadOnly, unread)
+	}
+
+	if _, err := center.MarkRead(ctx, "ou_bob", first.ID); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound for another user, got %v", err)
+	}
+	if changed, err := center.MarkAllRead(ctx, "ou_alice"); err != nil || changed != 1 {
+		t.Fatalf("MarkAllRead = %d, %v", changed, err)
+	}
+	if update := <-updates; update.Unread != 0 {
+		t.Fatalf("expected unread 0 after read-all, got %+v", update)
+	}
+	if changed, _ := center.MarkAllRead(ctx, "ou_alice"); changed != 0 {
+		t.Fatalf("expected read-all to be idempotent, changed %d", changed)
+	}
+}
+
+func TestCenterSuppressesEventsDeliveredOnLark(t *testing.T) {
+	center := newTestCenter(t, Config{})
+	delivered := envelope(agent.LevelCore, "web-1", types.EventResultFinal, map[string]any{"final_answer": "sent to lark"})
+	other := envelope(agent.LevelCore, "web-1", types.EventResultCancelled, map[string]any{"reason": "user stop"})
+
+	center.MarkDelivered(delivered.GetEventID())
+	for _, event := range []agent.AgentEvent{delivered, other, other} {
+		if err := center.HandleEvent(event); err != nil {
+			t.Fatalf("HandleEvent: %v", err)
+		}
+	}
+	list, _, _ := center.List(context.Background(), "ou_alice", false, 0)
+	if len(list) != 1 || list[0].Type != TypeTaskCancelled {
+		t.Fatalf("expected only the non-delivered event (once), got %+v", list)
+	}
+}
+
+func TestCenterSendCreatesDigest(t *testing.T) {
+	center := newTestCenter(t, Config{})
+	if err := center.Send(context.Background(), notification.Target{ChatID: "ou_alice"}, "# Weekly pulse\n3 tasks shipped"); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	list, _, _ := center.List(context.Background(), "ou_alice", false, 0)
+	if len(list) != 1 || list[0].Type != TypeDigest || list[0].Title != "Weekly pulse" || list[0].Body != "3 tasks shipped" {
+		t.Fatalf("unexpected digest notification: %+v", list)
+	}
+}
@@ -0,0 +1,199 @@
+package notifications
+
+import (
+	"fmt"
+	"strings"
+
+	domain "alex/internal/domain/agent"
+	agent "alex/internal/domain/agent/ports/agent"
+	"alex/internal/domain/agent/types"
+	"alex/internal/shared/utils"
+)
+
+const bodyMaxChars = 280
+
+// TypeForEvent reports which notification type, if any, an agent event
+// produces. Channels that surface these events themselves (e.g. Lark) use it
+// to record deliveries for cross-channel dedup.
+func TypeForEvent(event agent.AgentEvent) (Type, bool) {
+	draft, ok := draftFromEvent(event)
+	return draft.Type, ok
+}
+
+// draftFromEvent maps a top-level task event to a notification draft without
+// user or ID. Subagent and subtask events never notify.
+func draftFromEvent(event agent.AgentEvent) (Notification, bool) {
+	if event == nil || !isTopLevel(event) {
+		return Notification{}, false
+	}
+	switch e := event.(type) {
+	case *domain.WorkflowEventEnvelope:
+		if e.IsSubtask {
+			return Notification{}, false
+		}
+		return draftFromPayload(strings.TrimSpace(e.Event), e.Payload)
+	case *domain.Event:
+		return draftFromDomainEvent(e)
+	default:
+		return Notification{}, false
+	}
+}
+
+func isTopLevel(event agent.AgentEvent) bool {
+	level := event.GetAgentLevel()
+	return level == "" || level == agent.LevelCore
+}
+
+func draftFromDomainEvent(e *domain.Event) (Notification, bool) {
+	d := e.Data
+	switch e.Kind {
+	case types.EventResultFinal:
+		return taskCompleted(d.FinalAnswer, d.StopReason, d.TotalIterations, d.TotalTokens), true
+	case types.EventResultCancelled:
+		return taskCancelled(d.Reason, d.RequestedBy), true
+	case types.EventNodeFailed:
+		if d.Recoverable {
+			return Notification{}, false
+		}
+		errText := d.ErrorStr
+		if d.Error != nil {
+			errText = d.Error.Error()
+		}
+		return taskFailed(errText, d.PhaseLabel), true
+	case types.EventToolCompleted:
+		if d.Error != nil {
+			return Notification{}, false
+		}
+		return inputRequestedFromTool(d.ToolName, d.Metadata)
+	case types.EventExternalInputRequested:
+		return inputRequested(d.Summary, map[string]any{"agent_type": d.AgentType, "request_type": d.Type}), true
+	default:
+		return Notification{}, false
+	}
+}
+
+func draftFromPayload(eventType string, payload map[string]any) (Notification, bool) {
+	switch eventType {
+	case types.EventResultFinal:
+		return taskCompleted(
+			payloadString(payload, "final_answer"),
+			payloadString(payload, "stop_reason"),
+			payloadInt(payload, "total_iterations"),
+			payloadInt(payload, "total_tokens"),
+		), true
+	case types.EventResultCancelled:
+		return taskCancelled(payloadString(payload, "reason"), payloadString(payload, "requested_by")), true
+	case types.EventNodeFailed:
+		if recoverable, _ := payload["recoverable"].(bool); recoverable {
+			return Notification{}, false
+		}
+		return taskFailed(payloadString(payload, "error"), payloadString(payload, "phase")), true
+	case types.EventToolCompleted:
+		if payloadString(payload, "error") != "" {
+			return Notification{}, false
+		}
+		metadata, _ := payload["metadata"].(map[string]any)
+		return inputRequestedFromTool(payloadString(payload, "tool_name"), metadata)
+	case types.EventExternalInputRequested:
+		return inputRequested(payloadString(payload, "summary"), map[string]any{
+			"agent_type":   payloadString(payload, "agent_type"),
+			"request_type": payloadString(payload, "type"),
+		}), true
+	default:
+		return Notification{}, false
+	}
+}
+
+func taskCompleted(answer, stopReason string, iterations, tokens int) Notification {
+	return Notification{
+		Type:  TypeTaskCompleted,
+		Title: "Task completed",
+		Body:  clipBody(answer),
+		Payload: map[string]any{
+			"stop_reason":      stopReason,
+			"total_iterations": iterations,
+			"total_tokens":     tokens,
+		},
+	}
+}
+
+func taskCancelled(reason, requestedBy string) Notification {
+	return Notification{
+		Type:    TypeTaskCancelled,
+		Title:   "Task cancelled",
+		Body:    clipBody(reason),
+		Payload: map[string]any{"requested_by": requestedBy},
+	}
+}
+
+func taskFailed(errText, phase string) Notification {
+	return Notification{
+		Type:    TypeTaskFailed,
+		Title:   "Task failed",
+		Body:    clipBody(errText),
+		Payload: map[string]any{"phase": phase},
+	}
+}
+
+// inputRequestedFromTool matches ask_user calls with action=request, i.e.
+// explicit approval or manual-step escalations. Clarifying questions do not
+// notify.
+func inputRequestedFromTool(toolName string, metadata map[string]any) (Notification, bool) {
+	if utils.TrimLower(toolName) != "ask_user" || metadata == nil {
+		return Notification{}, false
+	}
+	if action, _ := metadata["action"].(string); action != "request" {
+		return Notification{}, false
+	}
+	message, _ := metadata["message"].(string)
+	payload := map[string]any{}
+	if title, _ := metadata["title"].(string); title != "" {
+		payload["request_title"] = title
+	}
+	if options, ok := metadata["options"]; ok {
+		payload["options"] = options
+	}
+	return inputRequested(message, payload), true
+}
+
+func inputRequested(summary string, payload map[string]any) Notification {
+	return Notification{
+		Type:    TypeInputRequested,
+		Title:   "Your input is needed",
+		Body:    clipBody(summary),
+		Payload: payload,
+	}
+}
+
+func clipBody(text string) string {
+	return utils.Truncate(strings.TrimSpace(text), bodyMaxChars, "…")
+}
+
+func payloadString(payload map[string]any, key string) string {
+	if payload == nil {
+		return ""
+	}
+	switch v := payload[key].(type) {
+	case string:
+		return strings.TrimSpace(v)
+	case error:
+		return v.Error()
+	case nil:
+		return ""
+	default:
+		return strings.TrimSpace(fmt.Sprint(v))
+	}
+}
+
+func payloadInt(payload map[string]any, key string) int {
+	switch v := payload[key].(type) {
+	case int:
+		return v
+	case int64:
+		return int(v)
+	case float64:
+		return int(v)
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,115 @@
+// Package notifications keeps per-user in-app notifications for asynchronous
+// events (finished tasks, approval requests, budget warnings, digests) so web
+// clients can show unread badges for things that happened while they were
+// away.
+package notifications
+
+import (
+	"strings"
+	"time"
+)
+
+// DefaultUserID identifies the local operator when no authenticated user is
+// attached to a request or session. It matches the preferences store.
+const DefaultUserID = "local"
+
+// DefaultMaxPerUser caps stored notifications per user; older entries are
+// pruned first.
+const DefaultMaxPerUser = 200
+
+// Type classifies a notification.
+type Type string
+
+const (
+	TypeTaskCompleted  Type = "task_completed"
+	TypeTaskFailed     Type = "task_failed"
+	TypeTaskCancelled  Type = "task_cancelled"
+	TypeInputRequested Type = "input_requested"
+	TypeBudgetWarning  Type = "budget_warning"
+	TypeDigest         Type = "digest"
+)
+
+// AllTypes returns every supported notification type.
+func AllTypes() []Type {
+	return []Type{
+		TypeTaskCompleted,
+		TypeTaskFailed,
+		TypeTaskCancelled,
+		TypeInputRequested,
+		TypeBudgetWarning,
+		TypeDigest,
+	}
+}
+
+// Notification is a single in-app notification.
+type Notification struct {
+	ID        string         `json:"id"`
+	UserID    string         `json:"user_id"`
+	Type      Type           `json:"type"`
+	Title     string         `json:"title"`
+	Body      string         `json:"body,omitempty"`
+	Payload   map[string]any `json:"payload,omitempty"`
+	Link      string         `json:"link,omitempty"`
+	EventID   string         `json:"event_id,omitempty"`
+	SessionID string         `json:"session_id,omitempty"`
+	Read      bool           `json:"read"`
+	CreatedAt time.Time      `json:"created_at"`
+	ReadAt    *time.Time     `json:"read_at,omitempty"`
+}
+
+// Config selects which notification types are created and how many are kept.
+type Config struct {
+	// Types limits creation to these types. Empty enables all types.
+	Types []Type
+	// MaxPerUser caps stored notifications per user (default 200).
+	MaxPerUser int
+}
+
+func (c Config) withDefaults() Config {
+	if c.MaxPerUser <= 0 {
+		c.MaxPerUser = DefaultMaxPerUser
+	}
+	return c
+}
+
+func (c Config) allows(t Type) bool {
+	if len(c.Types) == 0 {
+		return true
+	}
+	for _, allowed := range c.Types {
+		if allowed == t {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseTypes converts configured names to types, ignoring unknown entries.
+func ParseTypes(names []string) []Type {
+	var out []Type
+	for _, name := range names {
+		candidate := Type(strings.ToLower(strings.TrimSpace(name)))
+		for _, known := range AllTypes() {
+			if candidate == known {
+				out = append(out, known)
+				break
+			}
+		}
+	}
+	return out
+}
+
+// SessionLink returns the web path for a session.
+func SessionLink(sessionID string) string {
+	if sessionID = strings.TrimSpace(sessionID); sessionID == "" {
+		return ""
+	}
+	return "/sessions/" + sessionID
+}
+
+func normalizeUserID(userID string) string {
+	if trimmed := strings.TrimSpace(userID); trimmed != "" {
+		return trimmed
+	}
+	return DefaultUserID
+}
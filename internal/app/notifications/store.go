@@ -0,0 +1,216 @@
+package notifications
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"alex/internal/infra/filestore"
+	runtimeconfig "alex/internal/shared/config"
+	jsonx "alex/internal/shared/json"
+	id "alex/internal/shared/utils/id"
+)
+
+const (
+	storeDocVersion = 1
+	storeFilename   = "notifications.json"
+)
+
+// ErrNotFound is returned when a notification does not exist for the user.
+var ErrNotFound = errors.New("notification not found")
+
+type storeDoc struct {
+	Version       int            `json:"version"`
+	Notifications []Notification `json:"notifications"`
+}
+
+// Store persists notifications in a single JSON file. Each user's list is kept
+// oldest-first so retention pruning trims from the front.
+type Store struct {
+	coll *filestore.Collection[string, []Notification]
+}
+
+// ResolveStorePath returns the notifications file path.
+//
+// Priority:
+//  1. Explicit ALEX_NOTIFICATIONS_PATH.
+//  2. Sibling to the resolved config path (defaults to ~/.alex/notifications.json).
+func ResolveStorePath(envLookup runtimeconfig.EnvLookup, homeDir func() (string, error)) string {
+	if envLookup == nil {
+		envLookup = runtimeconfig.DefaultEnvLookup
+	}
+	if value, ok := envLookup("ALEX_NOTIFICATIONS_PATH"); ok {
+		if trimmed := strings.TrimSpace(value); trimmed != "" {
+			return trimmed
+		}
+	}
+	configPath, _ := runtimeconfig.ResolveConfigPath(envLookup, homeDir)
+	return filepath.Join(filepath.Dir(configPath), storeFilename)
+}
+
+// NewStore loads the store from path. An empty path yields an in-memory store.
+func NewStore(path string) (*Store, error) {
+	coll := filestore.NewCollection[string, []Notification](filestore.CollectionConfig{
+		FilePath: strings.TrimSpace(path),
+		Perm:     0o600,
+		Name:     "notifications",
+	})
+	coll.SetMarshalDoc(marshalStoreDoc)
+	coll.SetUnmarshalDoc(unmarshalStoreDoc)
+	if err := coll.Load(); err != nil {
+		return nil, fmt.Errorf("load notifications: %w", err)
+	}
+	return &Store{coll: coll}, nil
+}
+
+// Add stores n for its user and prunes the oldest entries beyond maxPerUser.
+// A notification whose EventID already exists for the user is not stored
+// again; created reports whether n was added.
+func (s *Store) Add(ctx context.Context, n Notification, maxPerUser int) (stored Notification, created bool, err error) {
+	if err := ctx.Err(); err != nil {
+		return Notification{}, false, err
+	}
+	n.UserID = normalizeUserID(n.UserID)
+	if n.ID == "" {
+		n.ID = "ntf-" + id.NewKSUID()
+	}
+	if n.CreatedAt.IsZero() {
+		n.CreatedAt = s.coll.Now().UTC()
+	}
+	err = s.coll.Mutate(func(items map[string][]Notification) error {
+		list := items[n.UserID]
+		if n.EventID != "" {
+			for _, existing := range list {
+				if existing.EventID == n.EventID && existing.Type == n.Type {
+					stored = existing
+					return nil
+				}
+			}
+		}
+		list = append(list, n)
+		if maxPerUser > 0 && len(list) > maxPerUser {
+			list = append([]Notification(nil), list[len(list)-maxPerUser:]...)
+		}
+		items[n.UserID] = list
+		stored, created = n, true
+		return nil
+	})
+	return stored, created, err
+}
+
+// List returns the user's notifications newest-first together with the unread
+// count. limit <= 0 returns all matches.
+func (s *Store) List(ctx context.Context, userID string, unreadOnly bool, limit int) ([]Notification, int, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, 0, err
+	}
+	userID = normalizeUserID(userID)
+	var (
+		out    []Notification
+		unread int
+	)
+	s.coll.ReadLocked(func(items map[string][]Notification) {
+		list := items[userID]
+		for i := len(list) - 1; i >= 0; i-- {
+			n := list[i]
+			if !n.Read {
+				unread++
+			}
+			if unreadOnly && n.Read {
+				continue
+			}
+			if limit > 0 && len(out) >= limit {
+				continue
+			}
+			out = append(out, n)
+		}
+	})
+	return out, unread, nil
+}
+
+// MarkRead marks one notification as read. Marking an already-read
+// notification is a no-op that returns it unchanged.
+func (s *Store) MarkRead(ctx context.Context, userID, notificationID string) (Notification, error) {
+	if err := ctx.Err(); err != nil {
+		return Notification{}, err
+	}
+	userID = normalizeUserID(userID)
+	var updated Notification
+	err := s.coll.Mutate(func(items map[string][]Notification) error {
+		list := items[userID]
+		for i := range list {
+			if list[i].ID != notificationID {
+				continue
+			}
+			if !list[i].Read {
+				now := s.coll.Now().UTC()
+				list[i].Read = true
+				list[i].ReadAt = &now
+			}
+			updated = list[i]
+			return nil
+		}
+		return ErrNotFound
+	})
+	return updated, err
+}
+
+// MarkAllRead marks every unread notification of the user as read and returns
+// how many changed.
+func (s *Store) MarkAllRead(ctx context.Context, userID string) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	userID = normalizeUserID(userID)
+	changed := 0
+	err := s.coll.Mutate(func(items map[string][]Notification) error {
+		now := s.coll.Now().UTC()
+		list := items[userID]
+		for i := range list {
+			if list[i].Read {
+				continue
+			}
+			list[i].Read = true
+			list[i].ReadAt = &now
+			changed++
+		}
+		return nil
+	})
+	return changed, err
+}
+
+// UnreadCount returns the number of unread notifications for the user.
+func (s *Store) UnreadCount(ctx context.Context, userID string) (int, error) {
+	_, unread, err := s.List(ctx, userID, true, 1)
+	return unread, err
+}
+
+func marshalStoreDoc(items map[string][]Notification) ([]byte, error) {
+	doc := storeDoc{Version: storeDocVersion}
+	for _, list := range items {
+		doc.Notifications = append(doc.Notifications, list...)
+	}
+	sort.SliceStable(doc.Notifications, func(i, j int) bool {
+		return doc.Notifications[i].CreatedAt.Before(doc.Notifications[j].CreatedAt)
+	})
+	return filestore.MarshalJSONIndent(doc)
+}
+
+func unmarshalStoreDoc(data []byte) (map[string][]Notification, error) {
+	var doc storeDoc
+	if err := jsonx.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("decode notifications: %w", err)
+	}
+	sort.SliceStable(doc.Notifications, func(i, j int) bool {
+		return doc.Notifications[i].CreatedAt.Before(doc.Notifications[j].CreatedAt)
+	})
+	items := make(map[string][]Notification)
+	for _, n := range doc.Notifications {
+		n.UserID = normalizeUserID(n.UserID)
+		items[n.UserID] = append(items[n.UserID], n)
+	}
+	return items, nil
+}
@@ -0,0 +1,66 @@
+package notifications
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+)
+
+func TestStorePrunesOldestPerUser(t *testing.T) {
+	store, err := NewStore("")
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	ctx := context.Background()
+	for i := 0; i < 5; i++ {
+		if _, _, err := store.Add(ctx, Notification{UserID: "ou_alice", Type: TypeDigest, Title: fmt.Sprintf("n%d", i)}, 3); err != nil {
+			t.Fatalf("Add: %v", err)
+		}
+	}
+	if _, _, err := store.Add(ctx, Notification{UserID: "ou_bob", Type: TypeDigest, Title: "bob"}, 3); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+
+	list, unread, err := store.List(ctx, "ou_alice", false, 0)
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(list) != 3 || unread != 3 || list[0].Title != "n4" || list[2].Title != "n2" {
+		t.Fatalf("expected newest three notifications, got %+v", list)
+	}
+	if bob, _, _ := store.List(ctx, "ou_bob", false, 0); len(bob) != 1 {
+		t.Fatalf("pruning must not affect other users, got %+v", bob)
+	}
+	if limited, _, _ := store.List(ctx, "ou_alice", false, 2); len(limited) != 2 {
+		t.Fatalf("expected limit to apply, got %d", len(limited))
+	}
+}
+
+func TestStorePersistsReadState(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "notifications.json")
+	store, err := NewStore(path)
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	ctx := context.Background()
+	added, _, err := store.Add(ctx, Notification{Type: TypeTaskCompleted, Title: "done", EventID: "evt-1"}, 0)
+	if err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	if _, created, _ := store.Add(ctx, Notification{Type: TypeTaskCompleted, Title: "done", EventID: "evt-1"}, 0); created {
+		t.Fatal("expected duplicate event notification to be skipped")
+	}
+	if _, err := store.MarkRead(ctx, "", added.ID); err != nil {
+		t.Fatalf("MarkRead: %v", err)
+	}
+
+	reloaded, err := NewStore(path)
+	if err != nil {
+		t.Fatalf("reload: %v", err)
+	}
+	list, unread, _ := reloaded.List(ctx, DefaultUserID, false, 0)
+	if len(list) != 1 || !list[0].Read || unread != 0 || list[0].UserID != DefaultUserID {
+		t.Fatalf("unexpected reloaded state: %+v (unread %d)", list, unread)
+	}
+}
@@ -0,0 +1,131 @@
+package outputpolicy
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"alex/internal/infra/filestore"
+)
+
+// AuditEntry preserves the original text of an answer the policy changed.
+// Entries are append-only and only exposed through admin endpoints.
+type AuditEntry struct {
+	ID         string      `json:"id"`
+	Workspace  string      `json:"workspace"`
+	SessionID  string      `json:"session_id,omitempty"`
+	RunID      string      `json:"run_id,omitempty"`
+	Channel    string      `json:"channel,omitempty"`
+	Outcome    Outcome     `json:"outcome"`
+	Violations []Violation `json:"violations"`
+	Original   string      `json:"original"`
+	Filtered   string      `json:"filtered"`
+	At         time.Time   `json:"at"`
+}
+
+// Journal is an append-only JSONL audit log. An empty path keeps entries in
+// memory only.
+type Journal struct {
+	path string
+
+	mu      sync.Mutex
+	entries []AuditEntry
+}
+
+// NewJournal opens the journal at path.
+func NewJournal(path string) *Journal {
+	return &Journal{path: strings.TrimSpace(path)}
+}
+
+// Append writes entry to the end of the journal.
+func (j *Journal) Append(entry AuditEntry) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.path == "" {
+		j.entries = append(j.entries, entry)
+		return nil
+	}
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	if err := filestore.EnsureParentDir(j.path); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(j.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("open output policy journal: %w", err)
+	}
+	defer file.Close()
+	if _, err := file.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("append output policy journal: %w", err)
+	}
+	return nil
+}
+
+// List returns entries for workspace, newest first. An empty workspace lists
+// every workspace; limit <= 0 returns all matches.
+func (j *Journal) List(ctx context.Context, workspace string, limit int) ([]AuditEntry, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	entries, err := j.readAll()
+	if err != nil {
+		return nil, err
+	}
+	if workspace != "" {
+		workspace = NormalizeWorkspace(workspace)
+	}
+	out := make([]AuditEntry, 0)
+	for i := len(entries) - 1; i >= 0; i-- {
+		if workspace != "" && entries[i].Workspace != workspace {
+			continue
+		}
+		out = append(out, entries[i])
+		if limit > 0 && len(out) >= limit {
+			break
+		}
+	}
+	return out, nil
+}
+
+func (j *Journal) readAll() ([]AuditEntry, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.path == "" {
+		return append([]AuditEntry(nil), j.entries...), nil
+	}
+	file, err := os.Open(j.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("open output policy journal: %w", err)
+	}
+	defer file.Close()
+
+	var entries []AuditEntry
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		var entry AuditEntry
+		if err := json.Unmarshal(line, &entry); err != nil {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read output policy journal: %w", err)
+	}
+	return entries, nil
+}
@@ -0,0 +1,312 @@
+// Package outputpolicy filters final answers and outgoing channel messages
+// against per-workspace rule sets (regex redactions, blocked terms, and a
+// length cap) before they leave the system.
+package outputpolicy
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// DefaultWorkspace is used when a request carries no workspace or channel.
+const DefaultWorkspace = "default"
+
+const (
+	defaultRedaction     = "[REDACTED]"
+	defaultTruncSuffix   = "…[truncated]"
+	defaultBlockedNotice = "This response was withheld by the workspace output policy."
+	maxRulesPerSet       = 200
+)
+
+// Action is what a blocked-term rule does on a match.
+type Action string
+
+const (
+	ActionMask  Action = "mask"
+	ActionBlock Action = "block"
+)
+
+// Outcome summarises how a text was changed by a rule set.
+type Outcome string
+
+const (
+	OutcomeAllowed   Outcome = "allowed"
+	OutcomeTruncated Outcome = "truncated"
+	OutcomeMasked    Outcome = "masked"
+	OutcomeBlocked   Outcome = "blocked"
+)
+
+// Rule kinds reported in violations.
+const (
+	KindRedaction   = "redaction"
+	KindBlockedTerm = "blocked_term"
+	KindMaxLength   = "max_length"
+)
+
+// ErrInvalidRuleSet wraps validation failures.
+var ErrInvalidRuleSet = errors.New("invalid output policy rule set")
+
+// RegexRule replaces every match of Pattern with Replacement.
+type RegexRule struct {
+	ID          string `json:"id"`
+	Pattern     string `json:"pattern"`
+	Replacement string `json:"replacement,omitempty"`
+}
+
+// TermRule matches a list of literal terms (case-insensitive unless
+// CaseSensitive). Mask replaces each match with asterisks; block withholds
+// the whole answer.
+type TermRule struct {
+	ID            string   `json:"id"`
+	Terms         []string `json:"terms"`
+	Action        Action   `json:"action"`
+	CaseSensitive bool     `json:"case_sensitive,omitempty"`
+	WholeWord     bool     `json:"whole_word,omitempty"`
+}
+
+// LengthRule truncates answers longer than MaxChars runes.
+type LengthRule struct {
+	ID       string `json:"id"`
+	MaxChars int    `json:"max_chars"`
+	Suffix   string `json:"suffix,omitempty"`
+}
+
+// RuleSet is the policy for one workspace.
+type RuleSet struct {
+	Workspace     string      `json:"workspace"`
+	Redactions    []RegexRule `json:"redactions,omitempty"`
+	BlockedTerms  []TermRule  `json:"blocked_terms,omitempty"`
+	MaxLength     *LengthRule `json:"max_length,omitempty"`
+	BlockedNotice string      `json:"blocked_notice,omitempty"`
+	Version       int64       `json:"version"`
+	UpdatedAt     time.Time   `json:"updated_at"`
+}
+
+// Violation records one rule that fired.
+type Violation struct {
+	RuleID  string `json:"rule_id"`
+	Kind    string `json:"kind"`
+	Action  Action `json:"action"`
+	Matches int    `json:"matches"`
+}
+
+// Result is the filtered text plus what changed.
+type Result struct {
+	Text       string      `json:"text"`
+	Outcome    Outcome     `json:"outcome"`
+	Violations []Violation `json:"violations,omitempty"`
+	// AuditID references the journal entry holding the original text. It is
+	// set only by Service.Enforce when the text was changed.
+	AuditID string `json:"audit_id,omitempty"`
+}
+
+// Changed reports whether the filtered text differs from the input.
+func (r Result) Changed() bool {
+	return r.Outcome != "" && r.Outcome != OutcomeAllowed
+}
+
+// RuleIDs lists the IDs of the rules that fired, in evaluation order.
+func (r Result) RuleIDs() []string {
+	ids := make([]string, 0, len(r.Violations))
+	for _, v := range r.Violations {
+		ids = append(ids, v.RuleID)
+	}
+	return ids
+}
+
+// Normalize trims identifiers and fills default actions.
+func Normalize(set RuleSet) RuleSet {
+	set.Workspace = NormalizeWorkspace(set.Workspace)
+	set.BlockedNotice = strings.TrimSpace(set.BlockedNotice)
+	set.Redactions = append([]RegexRule(nil), set.Redactions...)
+	set.BlockedTerms = append([]TermRule(nil), set.BlockedTerms...)
+	for i := range set.Redactions {
+		set.Redactions[i].ID = strings.TrimSpace(set.Redactions[i].ID)
+	}
+	for i := range set.BlockedTerms {
+		rule := &set.BlockedTerms[i]
+		rule.ID = strings.TrimSpace(rule.ID)
+		rule.Action = Action(strings.ToLower(strings.TrimSpace(string(rule.Action))))
+		if rule.Action == "" {
+			rule.Action = ActionMask
+		}
+		terms := make([]string, 0, len(rule.Terms))
+		for _, term := range rule.Terms {
+			if trimmed := strings.TrimSpace(term); trimmed != "" {
+				terms = append(terms, trimmed)
+			}
+		}
+		rule.Terms = terms
+	}
+	if set.MaxLength != nil {
+		maxLength := *set.MaxLength
+		maxLength.ID = strings.TrimSpace(maxLength.ID)
+		set.MaxLength = &maxLength
+	}
+	return set
+}
+
+// NormalizeWorkspace maps blank workspaces to DefaultWorkspace.
+func NormalizeWorkspace(workspace string) string {
+	if trimmed := strings.ToLower(strings.TrimSpace(workspace)); trimmed != "" {
+		return trimmed
+	}
+	return DefaultWorkspace
+}
+
+// Validate checks rule IDs, actions, and patterns.
+func Validate(set RuleSet) error {
+	_, err := compile(set)
+	return err
+}
+
+type compiledRegex struct {
+	id          string
+	re          *regexp.Regexp
+	replacement string
+}
+
+type compiledTerms struct {
+	id     string
+	re     *regexp.Regexp
+	action Action
+}
+
+type compiledSet struct {
+	version    int64
+	redactions []compiledRegex
+	terms      []compiledTerms
+	maxLength  *LengthRule
+	notice     string
+}
+
+func compile(set RuleSet) (*compiledSet, error) {
+	if len(set.Redactions)+len(set.BlockedTerms) > maxRulesPerSet {
+		return nil, fmt.Errorf("%w: at most %d rules per workspace", ErrInvalidRuleSet, maxRulesPerSet)
+	}
+	seen := make(map[string]struct{})
+	checkID := func(id string) error {
+		if id == "" {
+			return fmt.Errorf("%w: every rule needs an id", ErrInvalidRuleSet)
+		}
+		if _, dup := seen[id]; dup {
+			return fmt.Errorf("%w: duplicate rule id %q", ErrInvalidRuleSet, id)
+		}
+		seen[id] = struct{}{}
+		return nil
+	}
+
+	out := &compiledSet{version: set.Version, notice: set.BlockedNotice}
+	if out.notice == "" {
+		out.notice = defaultBlockedNotice
+	}
+	for _, rule := range set.Redactions {
+		if err := checkID(rule.ID); err != nil {
+			return nil, err
+		}
+		re, err := regexp.Compile(rule.Pattern)
+		if err != nil || rule.Pattern == "" {
+			return nil, fmt.Errorf("%w: rule %q has an invalid pattern", ErrInvalidRuleSet, rule.ID)
+		}
+		replacement := rule.Replacement
+		if replacement == "" {
+			replacement = defaultRedaction
+		}
+		out.redactions = append(out.redactions, compiledRegex{id: rule.ID, re: re, replacement: replacement})
+	}
+	for _, rule := range set.BlockedTerms {
+		if err := checkID(rule.ID); err != nil {
+			return nil, err
+		}
+		if rule.Action != ActionMask && rule.Action != ActionBlock {
+			return nil, fmt.Errorf("%w: rule %q action must be mask or block", ErrInvalidRuleSet, rule.ID)
+		}
+		if len(rule.Terms) == 0 {
+			return nil, fmt.Errorf("%w: rule %q has no terms", ErrInvalidRuleSet, rule.ID)
+		}
+		quoted := make([]string, len(rule.Terms))
+		for i, term := range rule.Terms {
+			quoted[i] = regexp.QuoteMeta(term)
+		}
+		pattern := "(?:" + strings.Join(quoted, "|") + ")"
+		if rule.WholeWord {
+			pattern = `\b` + pattern + `\b`
+		}
+		if !rule.CaseSensitive {
+			pattern = "(?i)" + pattern
+		}
+		out.terms = append(out.terms, compiledTerms{id: rule.ID, re: regexp.MustCompile(pattern), action: rule.Action})
+	}
+	if set.MaxLength != nil {
+		if err := checkID(set.MaxLength.ID); err != nil {
+			return nil, err
+		}
+		if set.MaxLength.MaxChars <= 0 {
+			return nil, fmt.Errorf("%w: rule %q max_chars must be positive", ErrInvalidRuleSet, set.MaxLength.ID)
+		}
+		rule := *set.MaxLength
+		if rule.Suffix == "" {
+			rule.Suffix = defaultTruncSuffix
+		}
+		out.maxLength = &rule
+	}
+	return out, nil
+}
+
+// apply runs block checks first so a blocked answer is never partially
+// masked, then redactions and mask terms, then the length cap.
+func (c *compiledSet) apply(text string) Result {
+	result := Result{Text: text, Outcome: OutcomeAllowed}
+
+	for _, rule := range c.terms {
+		if rule.action != ActionBlock {
+			continue
+		}
+		if matches := len(rule.re.FindAllStringIndex(text, -1)); matches > 0 {
+			result.Violations = append(result.Violations, Violation{RuleID: rule.id, Kind: KindBlockedTerm, Action: ActionBlock, Matches: matches})
+		}
+	}
+	if len(result.Violations) > 0 {
+		result.Text = c.notice
+		result.Outcome = OutcomeBlocked
+		return result
+	}
+
+	for _, rule := range c.redactions {
+		matches := len(rule.re.FindAllStringIndex(result.Text, -1))
+		if matches == 0 {
+			continue
+		}
+		result.Text = rule.re.ReplaceAllLiteralString(result.Text, rule.replacement)
+		result.Violations = append(result.Violations, Violation{RuleID: rule.id, Kind: KindRedaction, Action: ActionMask, Matches: matches})
+		result.Outcome = OutcomeMasked
+	}
+	for _, rule := range c.terms {
+		if rule.action != ActionMask {
+			continue
+		}
+		matches := len(rule.re.FindAllStringIndex(result.Text, -1))
+		if matches == 0 {
+			continue
+		}
+		result.Text = rule.re.ReplaceAllStringFunc(result.Text, func(match string) string {
+			return strings.Repeat("*", utf8.RuneCountInString(match))
+		})
+		result.Violations = append(result.Violations, Violation{RuleID: rule.id, Kind: KindBlockedTerm, Action: ActionMask, Matches: matches})
+		result.Outcome = OutcomeMasked
+	}
+
+	if c.maxLength != nil && utf8.RuneCountInString(result.Text) > c.maxLength.MaxChars {
+		runes := []rune(result.Text)
+		result.Text = string(runes[:c.maxLength.MaxChars]) + c.maxLength.Suffix
+		result.Violations = append(result.Violations, Violation{RuleID: c.maxLength.ID, Kind: KindMaxLength, Action: ActionMask, Matches: 1})
+		if result.Outcome == OutcomeAllowed {
+			result.Outcome = OutcomeTruncated
+		}
+	}
+	return result
+}
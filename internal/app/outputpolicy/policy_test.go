@@ -0,0 +1,182 @@
+package outputpolicy
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func testRuleSet(workspace string) RuleSet {
+	return RuleSet{
+		Workspace: workspace,
+		Redactions: []RegexRule{
+			{ID: "hostnames", Pattern: `[a-z0-9-]+\.corp\.internal`, Replacement: "[host]"},
+		},
+		BlockedTerms: []TermRule{
+			{ID: "profanity", Terms: []string{"darn"}, Action: ActionMask, WholeWord: true},
+			{ID: "customers", Terms: []string{"ACME-42"}, Action: ActionBlock},
+		},
+		BlockedNotice: "Withheld.",
+	}
+}
+
+func newTestService(t *testing.T, dir string) *Service {
+	t.Helper()
+	path := ""
+	if dir != "" {
+		path = filepath.Join(dir, storeFilename)
+	}
+	store, err := NewStore(path)
+	if err != nil {
+		t.Fatalf("new store: %v", err)
+	}
+	return NewService(store, NewJournal(JournalPathFor(path)), nil)
+}
+
+func TestEvaluateMasksRedactionsAndTerms(t *testing.T) {
+	svc := newTestService(t, "")
+	if _, err := svc.PutRuleSet(context.Background(), AnyVersion, testRuleSet("acme")); err != nil {
+		t.Fatalf("put: %v", err)
+	}
+
+	result := svc.Evaluate("acme", "Deploy to db-1.corp.internal, darn it. Darned tests stay.")
+	if result.Outcome != OutcomeMasked {
+		t.Fatalf("outcome = %s, want masked", result.Outcome)
+	}
+	if want := "Deploy to [host], **** it. Darned tests stay."; result.Text != want {
+		t.Fatalf("text = %q, want %q", result.Text, want)
+	}
+	if ids := result.RuleIDs(); len(ids) != 2 || ids[0] != "hostnames" || ids[1] != "profanity" {
+		t.Fatalf("rule ids = %v", ids)
+	}
+}
+
+func TestEvaluateBlocksAndTruncates(t *testing.T) {
+	set := testRuleSet("acme")
+	set.MaxLength = &LengthRule{ID: "short", MaxChars: 5, Suffix: "..."}
+	compiled, err := compile(Normalize(set))
+	if err != nil {
+		t.Fatalf("compile: %v", err)
+	}
+
+	blocked := compiled.apply("Ticket for acme-42 at db-1.corp.internal")
+	if blocked.Outcome != OutcomeBlocked || blocked.Text != "Withheld." {
+		t.Fatalf("expected blocked notice, got %+v", blocked)
+	}
+	if len(blocked.Violations) != 1 || blocked.Violations[0].RuleID != "customers" {
+		t.Fatalf("expected only the block rule reported, got %+v", blocked.Violations)
+	}
+
+	truncated := compiled.apply("Hello world")
+	if truncated.Outcome != OutcomeTruncated || truncated.Text != "Hello..." {
+		t.Fatalf("expected truncation, got %+v", truncated)
+	}
+}
+
+func TestValidateRejectsBadRules(t *testing.T) {
+	cases := map[string]RuleSet{
+		"missing id":  {Redactions: []RegexRule{{Pattern: "x"}}},
+		"bad pattern": {Redactions: []RegexRule{{ID: "r", Pattern: "("}}},
+		"bad action":  {BlockedTerms: []TermRule{{ID: "t", Terms: []string{"x"}, Action: "delete"}}},
+		"no terms":    {BlockedTerms: []TermRule{{ID: "t", Terms: []string{" "}}}},
+		"duplicate":   {Redactions: []RegexRule{{ID: "r", Pattern: "a"}}, BlockedTerms: []TermRule{{ID: "r", Terms: []string{"b"}}}},
+		"bad length":  {MaxLength: &LengthRule{ID: "l"}},
+	}
+	for name, set := range cases {
+		if err := Validate(Normalize(set)); !errors.Is(err, ErrInvalidRuleSet) {
+			t.Errorf("%s: expected ErrInvalidRuleSet, got %v", name, err)
+		}
+	}
+}
+
+func TestServiceIsolatesWorkspaces(t *testing.T) {
+	svc := newTestService(t, "")
+	if _, err := svc.PutRuleSet(context.Background(), AnyVersion, testRuleSet("acme")); err != nil {
+		t.Fatalf("put: %v", err)
+	}
+
+	if svc.Active("globex") || !svc.Active("ACME") {
+		t.Fatal("expected only the acme workspace to be active")
+	}
+	text := "db-1.corp.internal for ACME-42"
+	if result := svc.Enforce(context.Background(), Target{Workspace: "globex"}, text); result.Changed() || result.Text != text {
+		t.Fatalf("globex answer must pass through unchanged, got %+v", result)
+	}
+	entries, err := svc.Audit(context.Background(), "", 0)
+	if err != nil || len(entries) != 0 {
+		t.Fatalf("unchanged answers must not be journaled, got %d entries err=%v", len(entries), err)
+	}
+}
+
+func TestEnforceJournalsOriginalAndSurvivesReload(t *testing.T) {
+	dir := t.TempDir()
+	svc := newTestService(t, dir)
+	ctx := context.Background()
+	if _, err := svc.PutRuleSet(ctx, AnyVersion, testRuleSet("acme")); err != nil {
+		t.Fatalf("put: %v", err)
+	}
+
+	original := "Escalate ACME-42 on db-1.corp.internal"
+	result := svc.Enforce(ctx, Target{Workspace: "acme", SessionID: "s1", RunID: "r1", Channel: "lark"}, original)
+	if result.Outcome != OutcomeBlocked || result.AuditID == "" {
+		t.Fatalf("expected blocked result with audit id, got %+v", result)
+	}
+	masked := svc.Enforce(ctx, Target{Workspace: "acme", RunID: "r2"}, "see db-1.corp.internal")
+	if masked.Outcome != OutcomeMasked {
+		t.Fatalf("expected masked result, got %+v", masked)
+	}
+
+	// Dry runs never touch the journal.
+	if _, err := svc.DryRun(testRuleSet("acme"), original); err != nil {
+		t.Fatalf("dry run: %v", err)
+	}
+
+	reloaded := newTestService(t, dir)
+	if !reloaded.Active("acme") {
+		t.Fatal("expected rule set to persist")
+	}
+	entries, err := reloaded.Audit(ctx, "acme", 0)
+	if err != nil {
+		t.Fatalf("audit: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 audit entries, got %d", len(entries))
+	}
+	first := entries[1]
+	if first.ID != result.AuditID || first.Original != original || first.Filtered != "Withheld." {
+		t.Fatalf("audit copy altered: %+v", first)
+	}
+	if first.SessionID != "s1" || first.RunID != "r1" || first.Channel != "lark" || first.Outcome != OutcomeBlocked {
+		t.Fatalf("audit metadata missing: %+v", first)
+	}
+	if !strings.Contains(entries[0].Original, "db-1.corp.internal") {
+		t.Fatalf("expected newest entry to keep the unmasked original, got %+v", entries[0])
+	}
+	if limited, _ := reloaded.Audit(ctx, "acme", 1); len(limited) != 1 || limited[0].RunID != "r2" {
+		t.Fatalf("expected limit to keep the newest entry, got %+v", limited)
+	}
+}
+
+func TestPutRuleSetVersionConflict(t *testing.T) {
+	svc := newTestService(t, "")
+	ctx := context.Background()
+	stored, err := svc.PutRuleSet(ctx, 0, testRuleSet("acme"))
+	if err != nil || stored.Version != 1 {
+		t.Fatalf("first put: version=%d err=%v", stored.Version, err)
+	}
+	if _, err := svc.PutRuleSet(ctx, 0, testRuleSet("acme")); !errors.Is(err, ErrVersionConflict) {
+		t.Fatalf("expected version conflict, got %v", err)
+	}
+
+	// Updating the rules recompiles the cached set.
+	updated := testRuleSet("acme")
+	updated.BlockedTerms = nil
+	if _, err := svc.PutRuleSet(ctx, 1, updated); err != nil {
+		t.Fatalf("second put: %v", err)
+	}
+	if result := svc.Evaluate("acme", "ACME-42"); result.Changed() {
+		t.Fatalf("expected updated rules to apply, got %+v", result)
+	}
+}
@@ -0,0 +1,158 @@
+package outputpolicy
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"alex/internal/shared/logging"
+	id "alex/internal/shared/utils/id"
+)
+
+// Target identifies where a filtered text is going.
+type Target struct {
+	Workspace string
+	SessionID string
+	RunID     string
+	Channel   string
+}
+
+// Service applies stored rule sets and journals the originals of changed
+// answers.
+type Service struct {
+	store   *Store
+	journal *Journal
+	logger  logging.Logger
+	now     func() time.Time
+
+	mu    sync.Mutex
+	cache map[string]*compiledSet
+}
+
+// NewService wires a store and journal. A nil journal keeps audit entries in
+// memory.
+func NewService(store *Store, journal *Journal, logger logging.Logger) *Service {
+	if store == nil {
+		return nil
+	}
+	if journal == nil {
+		journal = NewJournal("")
+	}
+	return &Service{
+		store:   store,
+		journal: journal,
+		logger:  logging.OrNop(logger),
+		now:     time.Now,
+		cache:   make(map[string]*compiledSet),
+	}
+}
+
+// RuleSet returns the stored rule set for workspace.
+func (s *Service) RuleSet(ctx context.Context, workspace string) (RuleSet, bool, error) {
+	return s.store.Get(ctx, workspace)
+}
+
+// ListRuleSets returns every stored rule set.
+func (s *Service) ListRuleSets(ctx context.Context) ([]RuleSet, error) {
+	return s.store.List(ctx)
+}
+
+// PutRuleSet validates and stores set; see Store.Put for versioning.
+func (s *Service) PutRuleSet(ctx context.Context, expectedVersion int64, set RuleSet) (RuleSet, error) {
+	return s.store.Put(ctx, expectedVersion, set)
+}
+
+// DeleteRuleSet removes the rule set for workspace.
+func (s *Service) DeleteRuleSet(ctx context.Context, workspace string) error {
+	return s.store.Delete(ctx, workspace)
+}
+
+// Active reports whether workspace has any rules configured.
+func (s *Service) Active(workspace string) bool {
+	return s.compiled(workspace) != nil
+}
+
+// Evaluate filters text without journaling. It is used for streamed partial
+// answers, whose final form is enforced separately.
+func (s *Service) Evaluate(workspace, text string) Result {
+	set := s.compiled(workspace)
+	if set == nil {
+		return Result{Text: text, Outcome: OutcomeAllowed}
+	}
+	return set.apply(text)
+}
+
+// Enforce filters a final answer. Violations are logged with their rule IDs
+// and, when the text changed, the original is journaled for audit.
+func (s *Service) Enforce(ctx context.Context, target Target, text string) Result {
+	target.Workspace = NormalizeWorkspace(target.Workspace)
+	result := s.Evaluate(target.Workspace, text)
+	if !result.Changed() {
+		return result
+	}
+	for _, v := range result.Violations {
+		s.logger.Warn("Output policy violation: workspace=%s rule=%s kind=%s action=%s matches=%d run=%s",
+			target.Workspace, v.RuleID, v.Kind, v.Action, v.Matches, target.RunID)
+	}
+	entry := AuditEntry{
+		ID:         id.NewUUIDv7(),
+		Workspace:  target.Workspace,
+		SessionID:  target.SessionID,
+		RunID:      target.RunID,
+		Channel:    target.Channel,
+		Outcome:    result.Outcome,
+		Violations: result.Violations,
+		Original:   text,
+		Filtered:   result.Text,
+		At:         s.now().UTC(),
+	}
+	if err := s.journal.Append(entry); err != nil {
+		s.logger.Error("Failed to journal output policy audit entry: %v", err)
+	} else {
+		result.AuditID = entry.ID
+	}
+	return result
+}
+
+// DryRun validates set and applies it to text without storing or journaling.
+func (s *Service) DryRun(set RuleSet, text string) (Result, error) {
+	compiled, err := compile(Normalize(set))
+	if err != nil {
+		return Result{}, err
+	}
+	return compiled.apply(text), nil
+}
+
+// Audit lists journaled originals for workspace, newest first.
+func (s *Service) Audit(ctx context.Context, workspace string, limit int) ([]AuditEntry, error) {
+	return s.journal.List(ctx, workspace, limit)
+}
+
+// compiled returns the cached compiled rule set, recompiling when the stored
+// version changes. Workspaces without rules yield nil.
+func (s *Service) compiled(workspace string) *compiledSet {
+	if s == nil {
+		return nil
+	}
+	set, ok, err := s.store.Get(context.Background(), workspace)
+	if err != nil || !ok || isEmpty(set) {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if cached := s.cache[set.Workspace]; cached != nil && cached.version == set.Version {
+		return cached
+	}
+	compiled, err := compile(set)
+	if err != nil {
+		s.logger.Error("Output policy for workspace %s failed to compile: %v", set.Workspace, err)
+		return nil
+	}
+	s.cache[set.Workspace] = compiled
+	return compiled
+}
+
+func isEmpty(set RuleSet) bool {
+	return len(set.Redactions) == 0 && len(set.BlockedTerms) == 0 && set.MaxLength == nil
+}
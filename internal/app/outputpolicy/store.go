@@ -0,0 +1,162 @@
+package outputpolicy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"alex/internal/infra/filestore"
+	runtimeconfig "alex/internal/shared/config"
+	jsonx "alex/internal/shared/json"
+)
+
+const (
+	storeDocVersion = 1
+	storeFilename   = "output_policy.json"
+	journalFilename = "output_policy_audit.jsonl"
+	storePathEnvVar = "ALEX_OUTPUT_POLICY_PATH"
+)
+
+// AnyVersion disables the optimistic version check on Put.
+const AnyVersion int64 = -1
+
+// ErrVersionConflict is returned when Put is called with a stale version.
+var ErrVersionConflict = errors.New("output policy version conflict")
+
+type storeDoc struct {
+	Version  int       `json:"version"`
+	RuleSets []RuleSet `json:"rule_sets"`
+}
+
+// Store persists per-workspace rule sets in a single JSON file.
+type Store struct {
+	coll *filestore.Collection[string, RuleSet]
+}
+
+// ResolveStorePath returns the rule set file path.
+//
+// Priority:
+//  1. Explicit ALEX_OUTPUT_POLICY_PATH.
+//  2. Sibling to the resolved config path (defaults to ~/.alex/output_policy.json).
+func ResolveStorePath(envLookup runtimeconfig.EnvLookup, homeDir func() (string, error)) string {
+	if envLookup == nil {
+		envLookup = runtimeconfig.DefaultEnvLookup
+	}
+	if value, ok := envLookup(storePathEnvVar); ok {
+		if trimmed := strings.TrimSpace(value); trimmed != "" {
+			return trimmed
+		}
+	}
+	configPath, _ := runtimeconfig.ResolveConfigPath(envLookup, homeDir)
+	return filepath.Join(filepath.Dir(configPath), storeFilename)
+}
+
+// JournalPathFor returns the audit journal path stored next to storePath.
+// An empty storePath yields an empty (in-memory) journal path.
+func JournalPathFor(storePath string) string {
+	if strings.TrimSpace(storePath) == "" {
+		return ""
+	}
+	return filepath.Join(filepath.Dir(storePath), journalFilename)
+}
+
+// NewStore loads the store from path. An empty path yields an in-memory store.
+func NewStore(path string) (*Store, error) {
+	coll := filestore.NewCollection[string, RuleSet](filestore.CollectionConfig{
+		FilePath: strings.TrimSpace(path),
+		Perm:     0o600,
+		Name:     "output_policy",
+	})
+	coll.SetMarshalDoc(marshalStoreDoc)
+	coll.SetUnmarshalDoc(unmarshalStoreDoc)
+	if err := coll.Load(); err != nil {
+		return nil, fmt.Errorf("load output policy: %w", err)
+	}
+	return &Store{coll: coll}, nil
+}
+
+// Get returns the rule set for workspace.
+func (s *Store) Get(ctx context.Context, workspace string) (RuleSet, bool, error) {
+	if err := ctx.Err(); err != nil {
+		return RuleSet{}, false, err
+	}
+	workspace = NormalizeWorkspace(workspace)
+	set, ok := s.coll.Get(workspace)
+	if !ok {
+		return RuleSet{Workspace: workspace}, false, nil
+	}
+	return set, true, nil
+}
+
+// List returns every rule set ordered by workspace.
+func (s *Store) List(ctx context.Context) ([]RuleSet, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	snapshot := s.coll.Snapshot()
+	sets := make([]RuleSet, 0, len(snapshot))
+	for _, set := range snapshot {
+		sets = append(sets, set)
+	}
+	sort.Slice(sets, func(i, j int) bool { return sets[i].Workspace < sets[j].Workspace })
+	return sets, nil
+}
+
+// Put validates and stores set under its workspace. When expectedVersion is
+// not AnyVersion, the write is rejected with ErrVersionConflict unless it
+// matches the current version (0 for workspaces without a rule set).
+func (s *Store) Put(ctx context.Context, expectedVersion int64, set RuleSet) (RuleSet, error) {
+	if err := ctx.Err(); err != nil {
+		return RuleSet{}, err
+	}
+	set = Normalize(set)
+	if err := Validate(set); err != nil {
+		return RuleSet{}, err
+	}
+	var stored RuleSet
+	err := s.coll.Mutate(func(items map[string]RuleSet) error {
+		current := items[set.Workspace]
+		if expectedVersion != AnyVersion && expectedVersion != current.Version {
+			return ErrVersionConflict
+		}
+		set.Version = current.Version + 1
+		set.UpdatedAt = s.coll.Now().UTC()
+		items[set.Workspace] = set
+		stored = set
+		return nil
+	})
+	return stored, err
+}
+
+// Delete removes the rule set for workspace.
+func (s *Store) Delete(ctx context.Context, workspace string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return s.coll.Delete(NormalizeWorkspace(workspace))
+}
+
+func marshalStoreDoc(items map[string]RuleSet) ([]byte, error) {
+	doc := storeDoc{Version: storeDocVersion, RuleSets: make([]RuleSet, 0, len(items))}
+	for _, set := range items {
+		doc.RuleSets = append(doc.RuleSets, set)
+	}
+	sort.Slice(doc.RuleSets, func(i, j int) bool { return doc.RuleSets[i].Workspace < doc.RuleSets[j].Workspace })
+	return filestore.MarshalJSONIndent(doc)
+}
+
+func unmarshalStoreDoc(data []byte) (map[string]RuleSet, error) {
+	var doc storeDoc
+	if err := jsonx.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("decode output policy: %w", err)
+	}
+	items := make(map[string]RuleSet, len(doc.RuleSets))
+	for _, set := range doc.RuleSets {
+		set.Workspace = NormalizeWorkspace(set.Workspace)
+		items[set.Workspace] = set
+	}
+	return items, nil
+}
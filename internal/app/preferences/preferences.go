@@ -0,0 +1,109 @@
+// Package preferences stores explicit per-user settings (language, tone,
+// code style, response length, custom instructions) and renders them as a
+// compact block for system prompt injection. Unlike memories, preferences are
+// user-edited verbatim so users can see exactly what is applied.
+package preferences
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"alex/internal/shared/utils"
+)
+
+// DefaultUserID identifies the local operator when no authenticated user is
+// attached to the request (CLI and single-user web deployments).
+const DefaultUserID = "local"
+
+const (
+	MaxLanguageChars           = 32
+	MaxToneChars               = 64
+	MaxCodeStyleChars          = 500
+	MaxCustomInstructionsChars = 2000
+)
+
+// ResponseLength expresses how verbose answers should be.
+type ResponseLength string
+
+const (
+	ResponseLengthConcise  ResponseLength = "concise"
+	ResponseLengthBalanced ResponseLength = "balanced"
+	ResponseLengthDetailed ResponseLength = "detailed"
+)
+
+// Preferences is the typed schema users edit via API or chat commands.
+type Preferences struct {
+	Language           string         `json:"language,omitempty"`
+	Tone               string         `json:"tone,omitempty"`
+	CodeStyle          string         `json:"code_style,omitempty"`
+	ResponseLength     ResponseLength `json:"response_length,omitempty"`
+	CustomInstructions string         `json:"custom_instructions,omitempty"`
+}
+
+// Normalize trims whitespace and lowercases enum fields.
+func Normalize(p Preferences) Preferences {
+	p.Language = strings.TrimSpace(p.Language)
+	p.Tone = strings.TrimSpace(p.Tone)
+	p.CodeStyle = strings.TrimSpace(p.CodeStyle)
+	p.ResponseLength = ResponseLength(utils.TrimLower(string(p.ResponseLength)))
+	p.CustomInstructions = strings.TrimSpace(p.CustomInstructions)
+	return p
+}
+
+// IsZero reports whether no preference is set.
+func (p Preferences) IsZero() bool {
+	return p == Preferences{}
+}
+
+// Validate checks field sizes and enum values of normalized preferences.
+func Validate(p Preferences) error {
+	limits := []struct {
+		field string
+		value string
+		max   int
+	}{
+		{"language", p.Language, MaxLanguageChars},
+		{"tone", p.Tone, MaxToneChars},
+		{"code_style", p.CodeStyle, MaxCodeStyleChars},
+		{"custom_instructions", p.CustomInstructions, MaxCustomInstructionsChars},
+	}
+	for _, limit := range limits {
+		if utf8.RuneCountInString(limit.value) > limit.max {
+			return fmt.Errorf("%s exceeds %d characters", limit.field, limit.max)
+		}
+	}
+	switch p.ResponseLength {
+	case "", ResponseLengthConcise, ResponseLengthBalanced, ResponseLengthDetailed:
+		return nil
+	default:
+		return fmt.Errorf("response_length must be one of concise|balanced|detailed")
+	}
+}
+
+// Render formats preferences as a compact bullet list. Empty preferences
+// render as an empty string so callers can skip the section entirely.
+func Render(p Preferences) string {
+	lines := []string{
+		renderLine("Language", p.Language),
+		renderLine("Tone", p.Tone),
+		renderLine("Code style", p.CodeStyle),
+		renderLine("Response length", string(p.ResponseLength)),
+		renderLine("Custom instructions", p.CustomInstructions),
+	}
+	var builder strings.Builder
+	for _, line := range lines {
+		if line != "" {
+			builder.WriteString(line)
+			builder.WriteString("\n")
+		}
+	}
+	return strings.TrimSpace(builder.String())
+}
+
+func renderLine(label, value string) string {
+	if value == "" {
+		return ""
+	}
+	return "- " + label + ": " + value
+}
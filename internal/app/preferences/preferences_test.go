@@ -0,0 +1,57 @@
+package preferences
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNormalize(t *testing.T) {
+	got := Normalize(Preferences{Language: "  Chinese ", ResponseLength: " Concise "})
+	if got.Language != "Chinese" || got.ResponseLength != ResponseLengthConcise {
+		t.Fatalf("unexpected normalized preferences: %#v", got)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		prefs   Preferences
+		wantErr string
+	}{
+		{name: "empty", prefs: Preferences{}},
+		{name: "valid", prefs: Preferences{Language: "zh", ResponseLength: ResponseLengthDetailed}},
+		{name: "bad length enum", prefs: Preferences{ResponseLength: "huge"}, wantErr: "response_length"},
+		{name: "language too long", prefs: Preferences{Language: strings.Repeat("x", MaxLanguageChars+1)}, wantErr: "language"},
+		{
+			name:    "custom instructions capped",
+			prefs:   Preferences{CustomInstructions: strings.Repeat("长", MaxCustomInstructionsChars+1)},
+			wantErr: "custom_instructions",
+		},
+		{name: "custom instructions at cap", prefs: Preferences{CustomInstructions: strings.Repeat("长", MaxCustomInstructionsChars)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(tt.prefs)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestRender(t *testing.T) {
+	if got := Render(Preferences{}); got != "" {
+		t.Fatalf("expected empty render, got %q", got)
+	}
+	got := Render(Preferences{Language: "Chinese", CustomInstructions: "never use emojis"})
+	want := "- Language: Chinese\n- Custom instructions: never use emojis"
+	if got != want {
+		t.Fatalf("Render() = %q, want %q", got, want)
+	}
+}
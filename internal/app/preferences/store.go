@@ -0,0 +1,141 @@
+package preferences
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"alex/internal/infra/filestore"
+	runtimeconfig "alex/internal/shared/config"
+	jsonx "alex/internal/shared/json"
+)
+
+const (
+	storeDocVersion = 1
+	storeFilename   = "preferences.json"
+)
+
+// AnyVersion disables the optimistic version check on Update.
+const AnyVersion int64 = -1
+
+// ErrVersionConflict is returned when Update is called with a stale version.
+var ErrVersionConflict = errors.New("preferences version conflict")
+
+// Record is a stored preferences document. Version increments on every write.
+type Record struct {
+	UserID      string      `json:"user_id"`
+	Preferences Preferences `json:"preferences"`
+	Version     int64       `json:"version"`
+	UpdatedAt   time.Time   `json:"updated_at"`
+}
+
+type storeDoc struct {
+	Version int      `json:"version"`
+	Records []Record `json:"records"`
+}
+
+// Store persists per-user preferences in a single JSON file.
+type Store struct {
+	coll *filestore.Collection[string, Record]
+}
+
+// ResolveStorePath returns the preferences file path.
+//
+// Priority:
+//  1. Explicit ALEX_PREFERENCES_PATH.
+//  2. Sibling to the resolved config path (defaults to ~/.alex/preferences.json).
+func ResolveStorePath(envLookup runtimeconfig.EnvLookup, homeDir func() (string, error)) string {
+	if envLookup == nil {
+		envLookup = runtimeconfig.DefaultEnvLookup
+	}
+	if value, ok := envLookup("ALEX_PREFERENCES_PATH"); ok {
+		if trimmed := strings.TrimSpace(value); trimmed != "" {
+			return trimmed
+		}
+	}
+	configPath, _ := runtimeconfig.ResolveConfigPath(envLookup, homeDir)
+	return filepath.Join(filepath.Dir(configPath), storeFilename)
+}
+
+// NewStore loads the store from path. An empty path yields an in-memory store.
+func NewStore(path string) (*Store, error) {
+	coll := filestore.NewCollection[string, Record](filestore.CollectionConfig{
+		FilePath: strings.TrimSpace(path),
+		Perm:     0o600,
+		Name:     "preferences",
+	})
+	coll.SetMarshalDoc(marshalStoreDoc)
+	coll.SetUnmarshalDoc(unmarshalStoreDoc)
+	if err := coll.Load(); err != nil {
+		return nil, fmt.Errorf("load preferences: %w", err)
+	}
+	return &Store{coll: coll}, nil
+}
+
+// Get returns the record for userID. Missing users yield a zero record with ok=false.
+func (s *Store) Get(ctx context.Context, userID string) (Record, bool, error) {
+	if err := ctx.Err(); err != nil {
+		return Record{}, false, err
+	}
+	userID = normalizeUserID(userID)
+	record, ok := s.coll.Get(userID)
+	if !ok {
+		return Record{UserID: userID}, false, nil
+	}
+	return record, true, nil
+}
+
+// Update validates and stores prefs for userID. When expectedVersion is not
+// AnyVersion, the write is rejected with ErrVersionConflict unless it matches
+// the current version (0 for users without a record).
+func (s *Store) Update(ctx context.Context, userID string, expectedVersion int64, prefs Preferences) (Record, error) {
+	if err := ctx.Err(); err != nil {
+		return Record{}, err
+	}
+	prefs = Normalize(prefs)
+	if err := Validate(prefs); err != nil {
+		return Record{}, err
+	}
+	userID = normalizeUserID(userID)
+	var stored Record
+	err := s.coll.Mutate(func(items map[string]Record) error {
+		current := items[userID]
+		if expectedVersion != AnyVersion && expectedVersion != current.Version {
+			return ErrVersionConflict
+		}
+		stored = Record{UserID: userID, Preferences: prefs, Version: current.Version + 1, UpdatedAt: s.coll.Now().UTC()}
+		items[userID] = stored
+		return nil
+	})
+	return stored, err
+}
+
+func normalizeUserID(userID string) string {
+	if trimmed := strings.TrimSpace(userID); trimmed != "" {
+		return trimmed
+	}
+	return DefaultUserID
+}
+
+func marshalStoreDoc(items map[string]Record) ([]byte, error) {
+	doc := storeDoc{Version: storeDocVersion, Records: make([]Record, 0, len(items))}
+	for _, record := range items {
+		doc.Records = append(doc.Records, record)
+	}
+	return filestore.MarshalJSONIndent(doc)
+}
+
+func unmarshalStoreDoc(data []byte) (map[string]Record, error) {
+	var doc storeDoc
+	if err := jsonx.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("decode preferences: %w", err)
+	}
+	items := make(map[string]Record, len(doc.Records))
+	for _, record := range doc.Records {
+		items[normalizeUserID(record.UserID)] = record
+	}
+	return items, nil
+}
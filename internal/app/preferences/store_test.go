@@ -0,0 +1,79 @@
+package preferences
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+func TestStoreUpdateIncrementsVersionAndPersists(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "preferences.json")
+	store, err := NewStore(path)
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	ctx := context.Background()
+
+	first, err := store.Update(ctx, "ou_1", 0, Preferences{Language: " Chinese "})
+	if err != nil {
+		t.Fatalf("first update: %v", err)
+	}
+	if first.Version != 1 || first.Preferences.Language != "Chinese" {
+		t.Fatalf("unexpected first record: %#v", first)
+	}
+	second, err := store.Update(ctx, "ou_1", AnyVersion, Preferences{Tone: "terse"})
+	if err != nil {
+		t.Fatalf("second update: %v", err)
+	}
+	if second.Version != 2 {
+		t.Fatalf("expected version 2, got %d", second.Version)
+	}
+
+	reloaded, err := NewStore(path)
+	if err != nil {
+		t.Fatalf("reload: %v", err)
+	}
+	record, ok, err := reloaded.Get(ctx, "ou_1")
+	if err != nil || !ok {
+		t.Fatalf("Get after reload: ok=%v err=%v", ok, err)
+	}
+	if record.Version != 2 || record.Preferences.Tone != "terse" || record.Preferences.Language != "" {
+		t.Fatalf("unexpected reloaded record: %#v", record)
+	}
+}
+
+func TestStoreUpdateRejectsStaleVersion(t *testing.T) {
+	store, _ := NewStore("")
+	ctx := context.Background()
+	if _, err := store.Update(ctx, "ou_1", 0, Preferences{Language: "en"}); err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+	_, err := store.Update(ctx, "ou_1", 0, Preferences{Language: "zh"})
+	if !errors.Is(err, ErrVersionConflict) {
+		t.Fatalf("expected ErrVersionConflict, got %v", err)
+	}
+	record, _, _ := store.Get(ctx, "ou_1")
+	if record.Preferences.Language != "en" {
+		t.Fatalf("stale write must not apply, got %#v", record.Preferences)
+	}
+}
+
+func TestStoreUpdateRejectsInvalidPreferences(t *testing.T) {
+	store, _ := NewStore("")
+	if _, err := store.Update(context.Background(), "ou_1", AnyVersion, Preferences{ResponseLength: "huge"}); err == nil {
+		t.Fatal("expected validation error")
+	}
+}
+
+func TestStoreBlankUserMapsToDefault(t *testing.T) {
+	store, _ := NewStore("")
+	ctx := context.Background()
+	if _, err := store.Update(ctx, "", AnyVersion, Preferences{Language: "en"}); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	record, ok, _ := store.Get(ctx, DefaultUserID)
+	if !ok || record.UserID != DefaultUserID || record.Preferences.Language != "en" {
+		t.Fatalf("expected default user record, got ok=%v %#v", ok, record)
+	}
+}
@@ -23,18 +23,19 @@ type AgentCoordinator interface {
 // Notifier routes scheduler results to external channels.
 type Notifier = notification.Notifier
 
-// executeTrigger runs a trigger's task via the agent coordinator and routes the result.
-func (s *Scheduler) executeTrigger(trigger Trigger) error {
+// executeTrigger runs a trigger's task via the agent coordinator and routes
+// the result. It returns the run ID of the created task, if one was started.
+func (s *Scheduler) executeTrigger(trigger Trigger) (string, error) {
 	select {
 	case <-s.stopped:
 		s.logger.Debug("Scheduler: skipping trigger %q because scheduler is stopped", trigger.Name)
-		return errSchedulerStopped
+		return "", errSchedulerStopped
 	default:
 	}
 
 	if err := validateLarkTrigger(trigger); err != nil {
 		s.logger.Warn("Scheduler: %v (trigger=%q)", err, trigger.Name)
-		return err
+		return "", err
 	}
 
 	ctx := s.buildTriggerContext(trigger)
@@ -48,7 +49,7 @@ func (s *Scheduler) executeTrigger(trigger Trigger) error {
 
 	s.notifyTriggerResult(ctx, trigger, formatResult(trigger, result, err))
 
-	return err
+	return id.RunIDFromContext(ctx), err
 }
 
 // validateLarkTrigger checks Lark-specific preconditions.
@@ -0,0 +1,120 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"alex/internal/shared/notification"
+	"alex/internal/shared/runhistory"
+)
+
+// recordRun appends the outcome of a finished execution to the job's history.
+func (s *Scheduler) recordRun(run jobRun, taskID string, err error) {
+	rec := runhistory.Record{
+		ScheduledAt: run.scheduledAt,
+		StartedAt:   run.startedAt,
+		DurationMs:  s.now().Sub(run.startedAt).Milliseconds(),
+		Outcome:     runhistory.OutcomeSuccess,
+		TaskID:      taskID,
+	}
+	if err != nil {
+		rec.Outcome = runhistory.OutcomeFailure
+		rec.Error = err.Error()
+		if errors.Is(err, errSchedulerStopped) {
+			rec.Outcome = runhistory.OutcomeMissed
+		}
+	}
+	s.appendHistory(run.jobID, rec)
+}
+
+func (s *Scheduler) appendHistory(jobID string, rec runhistory.Record) {
+	if err := s.history.Append(jobID, rec); err != nil {
+		s.logger.Warn("Scheduler: failed to record history for %q: %v", jobID, err)
+	}
+}
+
+func (s *Scheduler) deleteHistory(jobID string) {
+	if err := s.history.Delete(jobID); err != nil {
+		s.logger.Warn("Scheduler: failed to delete history for %q: %v", jobID, err)
+	}
+}
+
+// recordMissedFiresLocked records a missed entry for every fire that fell due
+// between the persisted NextRun and now, i.e. fires skipped while the process
+// was down. Missed fires leave the failure streak untouched.
+func (s *Scheduler) recordMissedFiresLocked(job *Job) {
+	if job.NextRun.IsZero() {
+		return
+	}
+	schedule, err := s.jobSchedule(job)
+	if err != nil {
+		return
+	}
+	now := s.now().UTC()
+	missed := 0
+	for at := job.NextRun; !at.After(now) && missed < maxMissedRecords; at = schedule.Next(at) {
+		s.appendHistory(job.ID, runhistory.Record{
+			ScheduledAt: at,
+			Outcome:     runhistory.OutcomeMissed,
+			Error:       "scheduler was not running at the scheduled time",
+		})
+		missed++
+	}
+	if missed == 0 {
+		return
+	}
+	job.LastOutcome = string(runhistory.OutcomeMissed)
+	s.persistJobLocked(context.Background(), job)
+	s.logger.Warn("Scheduler: job %q missed %d scheduled fire(s) while down", job.ID, missed)
+}
+
+func (s *Scheduler) failureAlertLocked(job *Job, now time.Time) *runhistory.Alert {
+	alert := &runhistory.Alert{
+		Kind:          runhistory.KindSchedulerJob,
+		ID:            job.ID,
+		Name:          job.Name,
+		FailureStreak: job.FailureCount,
+		LastError:     job.LastError,
+		At:            now,
+	}
+	if trigger, err := triggerFromJob(*job); err == nil {
+		alert.Target = notification.Target{Channel: trigger.Channel, ChatID: trigger.ChatID}
+	}
+	return alert
+}
+
+func (s *Scheduler) sendFailureAlert(alert runhistory.Alert) {
+	ctx := s.runCtx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err := s.alerter.Send(ctx, alert); err != nil {
+		s.logger.Warn("Scheduler: failed to send failure alert for %q: %v", alert.ID, err)
+		return
+	}
+	s.logger.Info("Scheduler: job %q failure alert sent (streak=%d)", alert.ID, alert.FailureStreak)
+}
+
+// JobHistory returns up to limit execution records for a job, newest first.
+// Returns an error wrapping ErrJobNotFound for unknown jobs.
+func (s *Scheduler) JobHistory(ctx context.Context, jobID string, limit int) ([]runhistory.Record, error) {
+	s.mu.Lock()
+	_, known := s.jobs[jobID]
+	s.mu.Unlock()
+	if !known {
+		if s.jobStore == nil {
+			return nil, fmt.Errorf("%w: %s", ErrJobNotFound, jobID)
+		}
+		if _, err := s.jobStore.Load(ctx, jobID); err != nil {
+			return nil, err
+		}
+	}
+	return s.history.List(jobID, limit)
+}
+
+// HistoryRetention returns the per-job history retention cap.
+func (s *Scheduler) HistoryRetention() int {
+	return s.history.MaxRecords()
+}
@@ -0,0 +1,224 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"alex/internal/shared/runhistory"
+	"alex/internal/testutil"
+)
+
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func newHistoryTestJob(t *testing.T, sched *Scheduler, nextRun time.Time) {
+	t.Helper()
+	payload, err := payloadFromTrigger(Trigger{Channel: "lark", UserID: "ou_owner", ChatID: "oc_owner"})
+	if err != nil {
+		t.Fatalf("payloadFromTrigger: %v", err)
+	}
+	sched.mu.Lock()
+	sched.jobs["digest"] = &Job{
+		ID:       "digest",
+		Name:     "Nightly digest",
+		CronExpr: "0 * * * *",
+		Trigger:  "Send digest",
+		Payload:  payload,
+		Status:   JobStatusActive,
+		NextRun:  nextRun,
+	}
+	sched.mu.Unlock()
+}
+
+func alertMessages(n *testutil.StubNotifier) []string {
+	var out []string
+	for _, content := range n.Contents() {
+		if strings.Contains(content, "in a row") {
+			out = append(out, content)
+		}
+	}
+	return out
+}
+
+func TestJobHistoryRecordsOutcomesAndStreak(t *testing.T) {
+	start := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	clock := &fakeClock{now: start.Add(2 * time.Second)}
+	coord := &mockCoordinator{err: errors.New("upstream down")}
+	sched := New(Config{Enabled: true}, coord, nil, nil)
+	sched.now = clock.Now
+	newHistoryTestJob(t, sched, start)
+
+	sched.runJob("digest", jobRunOptions{})
+	clock.Advance(time.Hour)
+	sched.runJob("digest", jobRunOptions{})
+
+	dto := sched.jobToDTO(sched.jobs["digest"])
+	if dto.FailureCount != 2 || dto.LastOutcome != string(runhistory.OutcomeFailure) {
+		t.Fatalf("expected failure streak 2, got count=%d outcome=%q", dto.FailureCount, dto.LastOutcome)
+	}
+
+	coord.mu.Lock()
+	coord.err = nil
+	coord.mu.Unlock()
+	clock.Advance(time.Hour)
+	sched.runJob("digest", jobRunOptions{})
+
+	dto = sched.jobToDTO(sched.jobs["digest"])
+	if dto.FailureCount != 0 || dto.LastOutcome != string(runhistory.OutcomeSuccess) {
+		t.Fatalf("expected streak reset on success, got count=%d outcome=%q", dto.FailureCount, dto.LastOutcome)
+	}
+
+	records, err := sched.JobHistory(context.Background(), "digest", 0)
+	if err != nil {
+		t.Fatalf("JobHistory: %v", err)
+	}
+	if len(records) != 3 {
+		t.Fatalf("expected 3 records, got %d", len(records))
+	}
+	oldest := records[2]
+	if !oldest.ScheduledAt.Equal(start) || !oldest.StartedAt.Equal(start.Add(2*time.Second)) {
+		t.Fatalf("unexpected timing on first record: %+v", oldest)
+	}
+	if oldest.Outcome != runhistory.OutcomeFailure || oldest.Error != "upstream down" || oldest.TaskID == "" {
+		t.Fatalf("unexpected first record: %+v", oldest)
+	}
+	if records[0].Outcome != runhistory.OutcomeSuccess || records[0].Error != "" {
+		t.Fatalf("unexpected latest record: %+v", records[0])
+	}
+}
+
+func TestJobFailureAlertFiresOncePerStreak(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)}
+	coord := &mockCoordinator{err: errors.New("boom")}
+	notifier := &testutil.StubNotifier{}
+	sched := New(Config{Enabled: true, FailureAlert: runhistory.AlertConfig{Threshold: 2}}, coord, notifier, nil)
+	sched.now = clock.Now
+	newHistoryTestJob(t, sched, time.Time{})
+
+	setErr := func(err error) {
+		coord.mu.Lock()
+		coord.err = err
+		coord.mu.Unlock()
+	}
+	run := func() {
+		clock.Advance(time.Hour)
+		sched.runJob("digest", jobRunOptions{})
+	}
+
+	run()
+	if got := alertMessages(notifier); len(got) != 0 {
+		t.Fatalf("expected no alert below threshold, got %v", got)
+	}
+	run()
+	run()
+	run()
+	if got := alertMessages(notifier); len(got) != 1 {
+		t.Fatalf("expected exactly one alert for the streak, got %v", got)
+	}
+	if !strings.Contains(alertMessages(notifier)[0], "Nightly digest") {
+		t.Fatalf("alert should name the job: %q", alertMessages(notifier)[0])
+	}
+
+	setErr(nil)
+	run()
+	setErr(errors.New("boom again"))
+	run()
+	run()
+	if got := alertMessages(notifier); len(got) != 2 {
+		t.Fatalf("expected a fresh alert for the new streak, got %v", got)
+	}
+	for _, sent := range notifier.Sent {
+		if sent.Target.ChatID != "oc_owner" {
+			t.Fatalf("alert sent to %q, want owning chat", sent.Target.ChatID)
+		}
+	}
+}
+
+func TestSchedulerRecordsMissedFiresOnRestart(t *testing.T) {
+	lastDue := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	store := NewFileJobStore(t.TempDir())
+	if err := store.Save(context.Background(), Job{
+		ID:           "hourly",
+		Name:         "Hourly",
+		CronExpr:     "0 * * * *",
+		Trigger:      "Hourly task",
+		Status:       JobStatusActive,
+		NextRun:      lastDue,
+		FailureCount: 1,
+	}); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	clock := &fakeClock{now: lastDue.Add(3*time.Hour + 30*time.Minute)}
+	sched := New(Config{Enabled: true, JobStore: store}, &mockCoordinator{answer: "ok"}, nil, nil)
+	sched.now = clock.Now
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := sched.Start(ctx); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	defer sched.Stop()
+
+	records, err := sched.JobHistory(ctx, "hourly", 0)
+	if err != nil {
+		t.Fatalf("JobHistory: %v", err)
+	}
+	if len(records) != 4 {
+		t.Fatalf("expected 4 missed fires, got %d: %+v", len(records), records)
+	}
+	for i, rec := range records {
+		want := lastDue.Add(time.Duration(3-i) * time.Hour)
+		if rec.Outcome != runhistory.OutcomeMissed || !rec.ScheduledAt.Equal(want) {
+			t.Fatalf("record %d = %+v, want missed at %v", i, rec, want)
+		}
+	}
+
+	dto, err := sched.LoadJob(ctx, "hourly")
+	if err != nil {
+		t.Fatalf("LoadJob: %v", err)
+	}
+	if dto.LastOutcome != string(runhistory.OutcomeMissed) || dto.FailureCount != 1 {
+		t.Fatalf("missed fires must not touch the failure streak: %+v", dto)
+	}
+}
+
+func TestJobHistoryRetentionAndUnknownJob(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)}
+	sched := New(Config{Enabled: true, History: runhistory.NewStore(t.TempDir(), 3)}, &mockCoordinator{answer: "ok"}, nil, nil)
+	sched.now = clock.Now
+	newHistoryTestJob(t, sched, time.Time{})
+
+	for i := 0; i < 5; i++ {
+		clock.Advance(time.Hour)
+		sched.runJob("digest", jobRunOptions{})
+	}
+	records, err := sched.JobHistory(context.Background(), "digest", 0)
+	if err != nil {
+		t.Fatalf("JobHistory: %v", err)
+	}
+	if len(records) != 3 || !records[0].StartedAt.Equal(clock.Now()) {
+		t.Fatalf("expected the 3 newest records, got %+v", records)
+	}
+
+	if _, err := sched.JobHistory(context.Background(), "missing", 0); !errors.Is(err, ErrJobNotFound) {
+		t.Fatalf("expected ErrJobNotFound, got %v", err)
+	}
+}
@@ -39,6 +39,7 @@ func triggerFromJob(job Job) (Trigger, error) {
 	return Trigger{
 		Name:     job.ID,
 		Schedule: job.CronExpr,
+		Timezone: job.Timezone,
 		Task:     job.Trigger,
 		Channel:  payload.Channel,
 		UserID:   payload.UserID,
@@ -4,12 +4,28 @@ import (
 	"context"
 	"fmt"
 	"time"
+
+	"alex/internal/shared/runhistory"
+
+	"github.com/robfig/cron/v3"
 )
 
+// maxMissedRecords caps the missed-fire entries recorded for one job on
+// restart, so a long outage on a frequent schedule cannot flood history.
+const maxMissedRecords = 50
+
 type jobRunOptions struct {
 	bypassCooldown bool
 }
 
+// jobRun carries the timing of a single execution from startJob to
+// recordRun.
+type jobRun struct {
+	jobID       string
+	scheduledAt time.Time
+	startedAt   time.Time
+}
+
 func (s *Scheduler) loadPersistedJobsLocked(ctx context.Context) error {
 	jobs, err := s.jobStore.List(ctx)
 	if err != nil {
@@ -21,9 +37,22 @@ func (s *Scheduler) loadPersistedJobsLocked(ctx context.Context) error {
 			job.Status = JobStatusActive
 		}
 		s.jobs[job.ID] = &job
-		if job.Status == JobStatusPaused || job.Status == JobStatusCompleted {
+		if job.Status == JobStatusPaused || job.Status == JobStatusCompleted || job.Status == JobStatusErrored {
+			continue
+		}
+		if migrateLegacySchedule(&job) {
+			s.persistJobLocked(ctx, &job)
+		}
+		if _, err := s.jobSchedule(&job); err != nil {
+			// Never fall back to another zone: a job that cannot be evaluated
+			// where it was defined is surfaced instead of firing at the wrong time.
+			job.Status = JobStatusErrored
+			job.LastError = err.Error()
+			s.persistJobLocked(ctx, &job)
+			s.logger.Warn("Scheduler: job %q marked errored: %v", job.ID, err)
 			continue
 		}
+		s.recordMissedFiresLocked(&job)
 		if err := s.registerJobLocked(ctx, &job); err != nil {
 			s.logger.Warn("Scheduler: failed to register persisted job %q: %v", job.ID, err)
 		}
@@ -55,6 +84,9 @@ func validateTrigger(trigger Trigger) error {
 	if trigger.Schedule == "" {
 		return fmt.Errorf("trigger %q has no schedule", trigger.Name)
 	}
+	if err := ValidateSchedule(trigger.Schedule, trigger.Timezone); err != nil {
+		return fmt.Errorf("trigger %q: %w", trigger.Name, err)
+	}
 	return nil
 }
 
@@ -67,6 +99,11 @@ func (s *Scheduler) scheduleTriggerLocked(ctx context.Context, trigger Trigger)
 	if err != nil {
 		return nil, err
 	}
+	if job.Status == JobStatusErrored {
+		// The definition validated above, so whatever broke it is fixed.
+		job.Status = JobStatusActive
+		job.LastError = ""
+	}
 	if job.Status == JobStatusPaused || job.Status == JobStatusCompleted {
 		s.logger.Info("Scheduler: job %q is %s, not scheduling", job.ID, job.Status)
 		return job, nil
@@ -82,10 +119,12 @@ func (s *Scheduler) buildJobFromTrigger(trigger Trigger) (Job, error) {
 	if err != nil {
 		return Job{}, err
 	}
+	expr, timezone := normalizeSchedule(trigger.Schedule, trigger.Timezone)
 	return Job{
 		ID:       trigger.Name,
 		Name:     trigger.Name,
-		CronExpr: trigger.Schedule,
+		CronExpr: expr,
+		Timezone: timezone,
 		Trigger:  trigger.Task,
 		Payload:  payload,
 		Status:   JobStatusActive,
@@ -104,6 +143,8 @@ func mergeJobRuntime(existing *Job, desired Job) Job {
 	desired.FailureCount = existing.FailureCount
 	desired.LastFailure = existing.LastFailure
 	desired.LastError = existing.LastError
+	desired.LastOutcome = existing.LastOutcome
+	desired.FailureAlerted = existing.FailureAlerted
 	return desired
 }
 
@@ -114,26 +155,20 @@ func (s *Scheduler) registerJobLocked(ctx context.Context, job *Job) error {
 	if job.CronExpr == "" {
 		return fmt.Errorf("job %q has no schedule", job.ID)
 	}
-	if _, err := s.parser.Parse(job.CronExpr); err != nil {
-		return fmt.Errorf("invalid cron expression for %q: %w", job.ID, err)
+	schedule, err := s.jobSchedule(job)
+	if err != nil {
+		return fmt.Errorf("job %q: %w", job.ID, err)
 	}
 
 	jobID := job.ID
-	entryID, err := s.cron.AddFunc(job.CronExpr, func() {
+	s.entryIDs[job.ID] = s.cron.Schedule(schedule, cron.FuncJob(func() {
 		s.runJob(jobID, jobRunOptions{})
-	})
-	if err != nil {
-		return fmt.Errorf("register cron for %q: %w", job.ID, err)
-	}
-	s.entryIDs[job.ID] = entryID
+	}))
 
-	nextRun, err := s.nextRun(job.CronExpr, time.Now().UTC())
-	if err == nil {
-		job.NextRun = nextRun
-		s.persistJobLocked(ctx, job)
-	}
+	job.NextRun = schedule.Next(s.now().UTC())
+	s.persistJobLocked(ctx, job)
 
-	s.logger.Info("Scheduler: registered trigger %q (schedule=%s)", job.ID, job.CronExpr)
+	s.logger.Info("Scheduler: registered trigger %q (schedule=%s timezone=%s)", job.ID, job.CronExpr, job.Timezone)
 	return nil
 }
 
@@ -156,32 +191,35 @@ func (s *Scheduler) runJob(jobID string, opts jobRunOptions) bool {
 	default:
 	}
 
-	_, trigger, ok := s.startJob(jobID, opts)
+	run, trigger, ok := s.startJob(jobID, opts)
 	if !ok {
 		return false
 	}
 
-	err := s.executeTrigger(trigger)
-	s.finishJob(jobID, err)
+	taskID, err := s.executeTrigger(trigger)
+	s.recordRun(run, taskID, err)
+	if alert := s.finishJob(jobID, err); alert != nil {
+		s.sendFailureAlert(*alert)
+	}
 	return true
 }
 
-func (s *Scheduler) startJob(jobID string, opts jobRunOptions) (*Job, Trigger, bool) {
+func (s *Scheduler) startJob(jobID string, opts jobRunOptions) (jobRun, Trigger, bool) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
 	job := s.jobs[jobID]
 	if job == nil {
-		return nil, Trigger{}, false
+		return jobRun{}, Trigger{}, false
 	}
-	if job.Status == JobStatusPaused || job.Status == JobStatusCompleted {
-		return nil, Trigger{}, false
+	if job.Status == JobStatusPaused || job.Status == JobStatusCompleted || job.Status == JobStatusErrored {
+		return jobRun{}, Trigger{}, false
 	}
 
 	if !opts.bypassCooldown && s.config.Cooldown > 0 && !job.LastRun.IsZero() {
-		if time.Since(job.LastRun) < s.config.Cooldown {
+		if s.now().Sub(job.LastRun) < s.config.Cooldown {
 			s.logger.Debug("Scheduler: skipping %q due to cooldown", jobID)
-			return nil, Trigger{}, false
+			return jobRun{}, Trigger{}, false
 		}
 	}
 
@@ -191,37 +229,55 @@ func (s *Scheduler) startJob(jobID string, opts jobRunOptions) (*Job, Trigger, b
 	}
 	if s.inFlight[jobID] >= maxConcurrent {
 		s.logger.Debug("Scheduler: skipping %q due to concurrency limit", jobID)
-		return nil, Trigger{}, false
+		return jobRun{}, Trigger{}, false
 	}
 
 	trigger, err := triggerFromJob(*job)
 	if err != nil {
-		now := time.Now().UTC()
+		now := s.now().UTC()
 		job.FailureCount++
 		job.LastFailure = now
 		job.LastError = fmt.Sprintf("decode payload: %v", err)
+		job.LastOutcome = string(runhistory.OutcomeFailure)
 		job.UpdatedAt = now
+		s.appendHistory(jobID, runhistory.Record{
+			ScheduledAt: s.scheduledAt(job, now, opts),
+			StartedAt:   now,
+			Outcome:     runhistory.OutcomeFailure,
+			Error:       job.LastError,
+		})
 		s.scheduleRecoveryLocked(context.Background(), job)
 		s.persistJobLocked(context.Background(), job)
 		s.logger.Warn("Scheduler: failed to decode job %q payload: %v", jobID, err)
-		return nil, Trigger{}, false
+		return jobRun{}, Trigger{}, false
 	}
 
 	s.inFlight[jobID]++
-	now := time.Now().UTC()
+	now := s.now().UTC()
+	run := jobRun{jobID: jobID, scheduledAt: s.scheduledAt(job, now, opts), startedAt: now}
 	job.LastRun = now
 	job.Status = JobStatusActive
 	job.UpdatedAt = now
-	if nextRun, err := s.nextRun(job.CronExpr, now); err == nil {
-		job.NextRun = nextRun
+	if schedule, err := s.jobSchedule(job); err == nil {
+		job.NextRun = schedule.Next(now)
 	}
 	s.persistJobLocked(context.Background(), job)
 
-	jobCopy := *job
-	return &jobCopy, trigger, true
+	return run, trigger, true
+}
+
+// scheduledAt returns the time a run was due. Cron fires are due at the
+// persisted NextRun; recovery retries and early fires are due immediately.
+func (s *Scheduler) scheduledAt(job *Job, now time.Time, opts jobRunOptions) time.Time {
+	if opts.bypassCooldown || job.NextRun.IsZero() || job.NextRun.After(now) {
+		return now
+	}
+	return job.NextRun
 }
 
-func (s *Scheduler) finishJob(jobID string, err error) {
+// finishJob updates the job's failure streak after a run and returns the
+// alert to send when the streak has just crossed the alert threshold.
+func (s *Scheduler) finishJob(jobID string, err error) *runhistory.Alert {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
@@ -231,21 +287,29 @@ func (s *Scheduler) finishJob(jobID string, err error) {
 
 	job := s.jobs[jobID]
 	if job == nil {
-		return
+		return nil
 	}
 
-	now := time.Now().UTC()
+	now := s.now().UTC()
 	job.UpdatedAt = now
 
+	var alert *runhistory.Alert
 	if err != nil {
 		job.FailureCount++
 		job.LastFailure = now
 		job.LastError = err.Error()
+		job.LastOutcome = string(runhistory.OutcomeFailure)
+		if s.alerter.ShouldAlert(job.FailureCount, job.FailureAlerted) {
+			job.FailureAlerted = true
+			alert = s.failureAlertLocked(job, now)
+		}
 		s.scheduleRecoveryLocked(context.Background(), job)
 	} else {
 		job.FailureCount = 0
 		job.LastFailure = time.Time{}
 		job.LastError = ""
+		job.LastOutcome = string(runhistory.OutcomeSuccess)
+		job.FailureAlerted = false
 		if timer := s.recoveryTimers[jobID]; timer != nil {
 			timer.Stop()
 			delete(s.recoveryTimers, jobID)
@@ -253,6 +317,7 @@ func (s *Scheduler) finishJob(jobID string, err error) {
 	}
 
 	s.persistJobLocked(context.Background(), job)
+	return alert
 }
 
 func (s *Scheduler) scheduleRecoveryLocked(ctx context.Context, job *Job) {
@@ -293,7 +358,7 @@ func (s *Scheduler) scheduleRecoveryFromJobLocked(ctx context.Context, job *Job)
 			job.ID, job.LastRun, job.LastFailure)
 		return
 	}
-	elapsed := time.Since(job.LastFailure)
+	elapsed := s.now().Sub(job.LastFailure)
 	remaining := s.recoveryDelay(job.FailureCount) - elapsed
 	if remaining < 0 {
 		remaining = 0
@@ -346,10 +411,7 @@ func (s *Scheduler) recoveryDelay(failureCount int) time.Duration {
 	return delay
 }
 
-func (s *Scheduler) nextRun(expr string, now time.Time) (time.Time, error) {
-	schedule, err := s.parser.Parse(expr)
-	if err != nil {
-		return time.Time{}, err
-	}
-	return schedule.Next(now), nil
+// jobSchedule parses the job's schedule in its timezone.
+func (s *Scheduler) jobSchedule(job *Job) (cron.Schedule, error) {
+	return parseSchedule(s.parser, job.CronExpr, job.Timezone)
 }
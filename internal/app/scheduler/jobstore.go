@@ -15,6 +15,10 @@ const (
 	JobStatusActive    JobStatus = "active"
 	JobStatusPaused    JobStatus = "paused"
 	JobStatusCompleted JobStatus = "completed"
+	// JobStatusErrored marks a job whose schedule can no longer be evaluated
+	// (for example its timezone vanished from tzdata). It is not scheduled
+	// until its definition is fixed.
+	JobStatusErrored JobStatus = "errored"
 )
 
 // validJobStatuses enumerates all accepted status values.
@@ -23,6 +27,7 @@ var validJobStatuses = map[JobStatus]bool{
 	JobStatusActive:    true,
 	JobStatusPaused:    true,
 	JobStatusCompleted: true,
+	JobStatusErrored:   true,
 }
 
 // IsValid returns true if the status is one of the recognized values.
@@ -38,8 +43,12 @@ type Job struct {
 	ID string `json:"id"`
 	// Name is a human-readable label for the job.
 	Name string `json:"name"`
-	// CronExpr is the cron schedule expression (5-field).
+	// CronExpr is the cron schedule expression: 5 fields, 6 with leading
+	// seconds, or a descriptor such as @daily.
 	CronExpr string `json:"cron_expr"`
+	// Timezone is the IANA zone CronExpr is evaluated in. Empty means the
+	// scheduler's local time, as for jobs persisted before it existed.
+	Timezone string `json:"timezone,omitempty"`
 	// Trigger describes what action the job performs (e.g. "okr_review",
 	// "daily_briefing").
 	Trigger string `json:"trigger"`
@@ -57,6 +66,12 @@ type Job struct {
 	LastFailure time.Time `json:"last_failure,omitempty"`
 	// LastError is the most recent failure message.
 	LastError string `json:"last_error,omitempty"`
+	// LastOutcome is the outcome of the most recent scheduled fire
+	// (success, failure, or missed).
+	LastOutcome string `json:"last_outcome,omitempty"`
+	// FailureAlerted is true once the current failure streak has raised an
+	// alert; it resets on the next success.
+	FailureAlerted bool `json:"failure_alerted,omitempty"`
 	// CreatedAt is when the job was first persisted.
 	CreatedAt time.Time `json:"created_at"`
 	// UpdatedAt is when the job was last modified.
@@ -0,0 +1,157 @@
+package scheduler
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// nextFireCount is how many upcoming fire times are reported per job.
+const nextFireCount = 3
+
+// allHours is the Hour bitmask of a "*" hour field.
+const allHours = 1<<24 - 1
+
+// legacyPresets maps the bare recurrence words accepted before cron
+// descriptors were supported to their descriptor form.
+var legacyPresets = map[string]string{
+	"hourly":   "@hourly",
+	"daily":    "@daily",
+	"weekly":   "@weekly",
+	"monthly":  "@monthly",
+	"yearly":   "@yearly",
+	"annually": "@annually",
+}
+
+// newScheduleParser accepts 5-field cron, 6-field cron with leading seconds,
+// and descriptors such as @daily or @every 10m.
+func newScheduleParser() cron.Parser {
+	return cron.NewParser(cron.SecondOptional | cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor)
+}
+
+// ValidateSchedule reports whether expr is a valid schedule in timezone. An
+// empty timezone means the scheduler's local time.
+func ValidateSchedule(expr, timezone string) error {
+	_, err := parseSchedule(newScheduleParser(), expr, timezone)
+	return err
+}
+
+// normalizeSchedule lifts an inline CRON_TZ=/TZ= prefix into the timezone
+// and rewrites bare recurrence presets, so schedules persisted before the
+// timezone field existed keep their meaning.
+func normalizeSchedule(expr, timezone string) (string, string) {
+	expr = strings.TrimSpace(expr)
+	timezone = strings.TrimSpace(timezone)
+	for _, prefix := range []string{"CRON_TZ=", "TZ="} {
+		if !strings.HasPrefix(expr, prefix) {
+			continue
+		}
+		zone, rest, _ := strings.Cut(strings.TrimPrefix(expr, prefix), " ")
+		if timezone == "" {
+			timezone = zone
+		}
+		expr = strings.TrimSpace(rest)
+		break
+	}
+	if preset, ok := legacyPresets[strings.ToLower(expr)]; ok {
+		expr = preset
+	}
+	return expr, timezone
+}
+
+// migrateLegacySchedule normalizes a persisted job's schedule in place and
+// reports whether anything changed.
+func migrateLegacySchedule(job *Job) bool {
+	expr, timezone := normalizeSchedule(job.CronExpr, job.Timezone)
+	if expr == job.CronExpr && timezone == job.Timezone {
+		return false
+	}
+	job.CronExpr, job.Timezone = expr, timezone
+	return true
+}
+
+func parseSchedule(parser cron.Parser, expr, timezone string) (cron.Schedule, error) {
+	expr, timezone = normalizeSchedule(expr, timezone)
+	if expr == "" {
+		return nil, fmt.Errorf("schedule is empty")
+	}
+	var loc *time.Location
+	if timezone != "" {
+		var err error
+		if loc, err = time.LoadLocation(timezone); err != nil {
+			return nil, fmt.Errorf("unknown timezone %q: use an IANA name such as Asia/Shanghai or America/New_York", timezone)
+		}
+	}
+	schedule, err := parser.Parse(expr)
+	if err != nil {
+		if fields := len(strings.Fields(expr)); !strings.HasPrefix(expr, "@") && (fields < 5 || fields > 6) {
+			return nil, fmt.Errorf("invalid cron expression %q: got %d fields, want 5 (minute hour day-of-month month day-of-week) or 6 with leading seconds", expr, fields)
+		}
+		return nil, fmt.Errorf("invalid cron expression %q: %w", expr, err)
+	}
+	if loc == nil {
+		return schedule, nil
+	}
+	spec, ok := schedule.(*cron.SpecSchedule)
+	if !ok {
+		// Constant delays (@every) are independent of wall-clock time.
+		return schedule, nil
+	}
+	if spec.Hour&allHours == allHours {
+		// Sub-daily schedules run on elapsed time across DST changes.
+		spec.Location = loc
+		return spec, nil
+	}
+	spec.Location = time.UTC
+	return wallClockSchedule{spec: spec, loc: loc}, nil
+}
+
+// wallClockSchedule fires at local wall-clock times in loc. A time skipped by
+// a spring-forward change fires once the clocks have moved on; a time
+// repeated by a fall-back change fires only once.
+type wallClockSchedule struct {
+	spec *cron.SpecSchedule // evaluated on wall-clock times expressed in UTC
+	loc  *time.Location
+}
+
+func (w wallClockSchedule) Next(t time.Time) time.Time {
+	local := t.In(w.loc)
+	wall := time.Date(local.Year(), local.Month(), local.Day(), local.Hour(), local.Minute(), local.Second(), local.Nanosecond(), time.UTC)
+	for range 8 {
+		wall = w.spec.Next(wall)
+		if wall.IsZero() {
+			return wall
+		}
+		if next := fromWallClock(wall, w.loc); next.After(t) {
+			return next
+		}
+	}
+	return time.Time{}
+}
+
+func fromWallClock(wall time.Time, loc *time.Location) time.Time {
+	at := time.Date(wall.Year(), wall.Month(), wall.Day(), wall.Hour(), wall.Minute(), wall.Second(), 0, loc)
+	if at.Hour() == wall.Hour() && at.Minute() == wall.Minute() {
+		return at
+	}
+	// wall falls in a spring-forward gap, which time.Date resolves with the
+	// earlier offset; shift by the gap so it lands after the change.
+	_, before := at.Zone()
+	_, after := at.Add(3 * time.Hour).Zone()
+	return at.Add(time.Duration(after-before) * time.Second)
+}
+
+// nextFireTimes returns the next n fire times after from.
+func nextFireTimes(schedule cron.Schedule, from time.Time, n int) []time.Time {
+	times := make([]time.Time, 0, n)
+	for at := from; len(times) < n; {
+		at = schedule.Next(at)
+		if at.IsZero() {
+			break
+		}
+		times = append(times, at)
+	}
+	return times
+}
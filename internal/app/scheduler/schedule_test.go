@@ -0,0 +1,165 @@
+package scheduler
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func mustLocation(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Skipf("tzdata for %s unavailable: %v", name, err)
+	}
+	return loc
+}
+
+func TestValidateScheduleMessages(t *testing.T) {
+	cases := []struct {
+		expr, timezone, want string
+	}{
+		{"0 9 * * 1-5", "Asia/Shanghai", ""},
+		{"30 0 9 * * 1-5", "", ""},
+		{"@daily", "UTC", ""},
+		{"daily", "", ""},
+		{"0 9 * *", "", "got 4 fields"},
+		{"0 25 * * *", "", "invalid cron expression"},
+		{"0 9 * * 1-5", "Asia/Beijing", "unknown timezone \"Asia/Beijing\""},
+	}
+	for _, tc := range cases {
+		err := ValidateSchedule(tc.expr, tc.timezone)
+		if tc.want == "" {
+			if err != nil {
+				t.Errorf("ValidateSchedule(%q, %q) = %v, want nil", tc.expr, tc.timezone, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("ValidateSchedule(%q, %q) = %v, want error containing %q", tc.expr, tc.timezone, err, tc.want)
+		}
+	}
+}
+
+func TestScheduleFiresOnWeekdaysInTimezone(t *testing.T) {
+	shanghai := mustLocation(t, "Asia/Shanghai")
+	schedule, err := parseSchedule(newScheduleParser(), "0 9 * * 1-5", "Asia/Shanghai")
+	if err != nil {
+		t.Fatalf("parseSchedule: %v", err)
+	}
+	// Friday 2026-03-13 10:00 Shanghai: next fires are Mon, Tue, Wed at 09:00.
+	from := time.Date(2026, 3, 13, 10, 0, 0, 0, shanghai)
+	got := nextFireTimes(schedule, from, 3)
+	for i, day := range []int{16, 17, 18} {
+		want := time.Date(2026, 3, day, 9, 0, 0, 0, shanghai)
+		if !got[i].Equal(want) {
+			t.Fatalf("fire %d = %v, want %v", i, got[i], want)
+		}
+	}
+}
+
+func TestScheduleHandlesDSTTransitions(t *testing.T) {
+	newYork := mustLocation(t, "America/New_York")
+	parser := newScheduleParser()
+
+	// 2026-03-08 02:30 does not exist; the fire moves past the gap.
+	spring, err := parseSchedule(parser, "30 2 * * *", "America/New_York")
+	if err != nil {
+		t.Fatalf("parseSchedule: %v", err)
+	}
+	got := spring.Next(time.Date(2026, 3, 7, 12, 0, 0, 0, newYork))
+	if want := time.Date(2026, 3, 8, 3, 30, 0, 0, newYork); !got.Equal(want) {
+		t.Fatalf("spring-forward fire = %v, want %v", got, want)
+	}
+
+	// 2026-11-01 01:30 happens twice; the job fires once.
+	fall, err := parseSchedule(parser, "30 1 * * *", "America/New_York")
+	if err != nil {
+		t.Fatalf("parseSchedule: %v", err)
+	}
+	fires := nextFireTimes(fall, time.Date(2026, 10, 31, 12, 0, 0, 0, newYork), 3)
+	if fires[1].Sub(fires[0]) != 25*time.Hour {
+		t.Fatalf("fall-back fires %v and %v should be one wall-clock day apart", fires[0], fires[1])
+	}
+
+	// Sub-daily schedules keep running on elapsed time through the change.
+	hourly, err := parseSchedule(parser, "0 * * * *", "America/New_York")
+	if err != nil {
+		t.Fatalf("parseSchedule: %v", err)
+	}
+	start := time.Date(2026, 3, 8, 0, 30, 0, 0, newYork)
+	for _, at := range nextFireTimes(hourly, start, 3) {
+		if at.Sub(start) > time.Hour {
+			t.Fatalf("hourly fire %v is more than an hour after %v", at, start)
+		}
+		start = at
+	}
+}
+
+func TestNormalizeScheduleMigratesLegacyForms(t *testing.T) {
+	cases := []struct {
+		expr, timezone, wantExpr, wantZone string
+	}{
+		{"CRON_TZ=Asia/Shanghai 0 9 * * *", "", "0 9 * * *", "Asia/Shanghai"},
+		{"TZ=UTC 0 9 * * *", "", "0 9 * * *", "UTC"},
+		{"CRON_TZ=UTC 0 9 * * *", "Europe/Berlin", "0 9 * * *", "Europe/Berlin"},
+		{"Weekly", "", "@weekly", ""},
+		{"0 9 * * *", "", "0 9 * * *", ""},
+	}
+	for _, tc := range cases {
+		expr, zone := normalizeSchedule(tc.expr, tc.timezone)
+		if expr != tc.wantExpr || zone != tc.wantZone {
+			t.Errorf("normalizeSchedule(%q, %q) = (%q, %q), want (%q, %q)", tc.expr, tc.timezone, expr, zone, tc.wantExpr, tc.wantZone)
+		}
+	}
+}
+
+func TestSchedulerLoadMigratesLegacyAndErrorsInvalidTimezone(t *testing.T) {
+	mustLocation(t, "Asia/Shanghai")
+	store := NewFileJobStore(t.TempDir())
+	ctx := context.Background()
+	for _, job := range []Job{
+		{ID: "legacy", Name: "Legacy", CronExpr: "CRON_TZ=Asia/Shanghai 0 9 * * 1-5", Trigger: "brief", Status: JobStatusActive},
+		{ID: "stale-zone", Name: "Stale", CronExpr: "0 9 * * *", Timezone: "Pacific/Atlantis", Trigger: "brief", Status: JobStatusActive},
+	} {
+		if err := store.Save(ctx, job); err != nil {
+			t.Fatalf("Save: %v", err)
+		}
+	}
+
+	sched := New(Config{Enabled: true, JobStore: store}, &mockCoordinator{answer: "ok"}, nil, nil)
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	if err := sched.Start(runCtx); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	defer sched.Stop()
+
+	legacy, err := sched.LoadJob(ctx, "legacy")
+	if err != nil {
+		t.Fatalf("LoadJob: %v", err)
+	}
+	if legacy.CronExpr != "0 9 * * 1-5" || legacy.Timezone != "Asia/Shanghai" {
+		t.Fatalf("legacy schedule not migrated: %+v", legacy)
+	}
+	if len(legacy.NextRuns) != nextFireCount || legacy.NextRuns[0].Location().String() != "Asia/Shanghai" {
+		t.Fatalf("expected %d next runs in Asia/Shanghai, got %v", nextFireCount, legacy.NextRuns)
+	}
+
+	stale, err := sched.LoadJob(ctx, "stale-zone")
+	if err != nil {
+		t.Fatalf("LoadJob: %v", err)
+	}
+	if stale.Status != string(JobStatusErrored) || !strings.Contains(stale.LastError, "Pacific/Atlantis") {
+		t.Fatalf("expected job with unknown timezone to be errored, got %+v", stale)
+	}
+	if len(stale.NextRuns) != 0 {
+		t.Fatalf("errored job must not report fire times: %v", stale.NextRuns)
+	}
+	for _, name := range sched.TriggerNames() {
+		if name == "stale-zone" {
+			t.Fatal("errored job must not be scheduled")
+		}
+	}
+}
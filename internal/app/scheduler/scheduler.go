@@ -10,6 +10,7 @@ import (
 	"alex/internal/infra/tools/builtin/okr"
 	"alex/internal/shared/config"
 	"alex/internal/shared/logging"
+	"alex/internal/shared/runhistory"
 	"alex/internal/shared/utils"
 
 	"github.com/robfig/cron/v3"
@@ -61,6 +62,8 @@ type Config struct {
 	RecoveryMaxRetries  int
 	RecoveryBackoff     time.Duration
 	LeaderLock          LeaderLock
+	History             *runhistory.Store // optional; defaults to in-memory history
+	FailureAlert        runhistory.AlertConfig
 }
 
 // LeaderLock coordinates single-leader scheduler execution across processes.
@@ -78,6 +81,9 @@ type Scheduler struct {
 	notifier       Notifier
 	goalStore      *okr.GoalStore
 	jobStore       JobStore
+	history        *runhistory.Store
+	alerter        *runhistory.Alerter
+	now            func() time.Time
 	config         Config
 	logger         logging.Logger
 	mu             sync.Mutex
@@ -100,7 +106,11 @@ func New(cfg Config, coordinator AgentCoordinator, notifier Notifier, logger log
 	if cfg.OKRGoalsRoot != "" {
 		goalStore = okr.NewGoalStore(okr.OKRConfig{GoalsRoot: cfg.OKRGoalsRoot})
 	}
-	parser := cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow)
+	parser := newScheduleParser()
+	history := cfg.History
+	if history == nil {
+		history = runhistory.NewStore("", runhistory.DefaultMaxRecords)
+	}
 
 	return &Scheduler{
 		cron:           newCron(cfg, logger, parser),
@@ -109,6 +119,9 @@ func New(cfg Config, coordinator AgentCoordinator, notifier Notifier, logger log
 		notifier:       notifier,
 		goalStore:      goalStore,
 		jobStore:       cfg.JobStore,
+		history:        history,
+		alerter:        runhistory.NewAlerter(cfg.FailureAlert, notifier, logger),
+		now:            time.Now,
 		config:         cfg,
 		logger:         logger,
 		runCtx:         context.Background(),
@@ -192,6 +205,7 @@ func (s *Scheduler) registerConfiguredJobsLocked(ctx context.Context) {
 		trigger := Trigger{
 			Name:     triggerCfg.Name,
 			Schedule: triggerCfg.Schedule,
+			Timezone: triggerCfg.Timezone,
 			Task:     triggerCfg.Task,
 			Channel:  triggerCfg.Channel,
 			UserID:   triggerCfg.UserID,
@@ -396,6 +410,7 @@ func (s *Scheduler) pruneStaleOKRTriggers(ctx context.Context, activeGoalIDs map
 			timer.Stop()
 			delete(s.recoveryTimers, name)
 		}
+		s.deleteHistory(name)
 		s.logger.Info("Scheduler: pruned stale OKR trigger %q", name)
 	}
 }
@@ -404,10 +419,11 @@ func (s *Scheduler) pruneStaleOKRTriggers(ctx context.Context, activeGoalIDs map
 // agent tool call). It creates the corresponding Job, persists it, and
 // schedules it in the cron runner. Returns a JobDTO so callers can inspect
 // the computed NextRun time.
-func (s *Scheduler) RegisterDynamicTrigger(ctx context.Context, name, schedule, task, channel string) (*JobDTO, error) {
+func (s *Scheduler) RegisterDynamicTrigger(ctx context.Context, name, schedule, timezone, task, channel string) (*JobDTO, error) {
 	trigger := Trigger{
 		Name:     name,
 		Schedule: schedule,
+		Timezone: timezone,
 		Task:     task,
 		Channel:  channel,
 	}
@@ -419,7 +435,7 @@ func (s *Scheduler) RegisterDynamicTrigger(ctx context.Context, name, schedule,
 	if err != nil {
 		return nil, err
 	}
-	return jobToDTO(job), nil
+	return s.jobToDTO(job), nil
 }
 
 // UnregisterTrigger removes a trigger (and its associated Job) by name. The
@@ -441,6 +457,7 @@ func (s *Scheduler) UnregisterTrigger(ctx context.Context, name string) error {
 		timer.Stop()
 		delete(s.recoveryTimers, name)
 	}
+	s.deleteHistory(name)
 
 	if s.jobStore != nil {
 		if err := s.jobStore.Delete(ctx, name); err != nil {
@@ -464,7 +481,7 @@ func (s *Scheduler) ListJobs(ctx context.Context) ([]JobDTO, error) {
 	}
 	dtos := make([]JobDTO, len(jobs))
 	for i := range jobs {
-		dtos[i] = *jobToDTO(&jobs[i])
+		dtos[i] = *s.jobToDTO(&jobs[i])
 	}
 	return dtos, nil
 }
@@ -478,15 +495,17 @@ func (s *Scheduler) LoadJob(ctx context.Context, id string) (*JobDTO, error) {
 	if err != nil {
 		return nil, err
 	}
-	return jobToDTO(job), nil
+	return s.jobToDTO(job), nil
 }
 
-// jobToDTO converts an internal Job to a JobDTO.
-func jobToDTO(j *Job) *JobDTO {
-	return &JobDTO{
+// jobToDTO converts an internal Job to a JobDTO, adding the next fire times
+// of schedulable jobs rendered in the job's timezone.
+func (s *Scheduler) jobToDTO(j *Job) *JobDTO {
+	dto := &JobDTO{
 		ID:           j.ID,
 		Name:         j.Name,
 		CronExpr:     j.CronExpr,
+		Timezone:     j.Timezone,
 		Trigger:      j.Trigger,
 		Payload:      j.Payload,
 		Status:       string(j.Status),
@@ -495,9 +514,27 @@ func jobToDTO(j *Job) *JobDTO {
 		FailureCount: j.FailureCount,
 		LastFailure:  j.LastFailure,
 		LastError:    j.LastError,
+		LastOutcome:  j.LastOutcome,
 		CreatedAt:    j.CreatedAt,
 		UpdatedAt:    j.UpdatedAt,
 	}
+	if j.Status != JobStatusActive && j.Status != JobStatusPending {
+		return dto
+	}
+	schedule, err := s.jobSchedule(j)
+	if err != nil {
+		return dto
+	}
+	loc := time.Local
+	if j.Timezone != "" {
+		if zone, err := time.LoadLocation(j.Timezone); err == nil {
+			loc = zone
+		}
+	}
+	for _, at := range nextFireTimes(schedule, s.now(), nextFireCount) {
+		dto.NextRuns = append(dto.NextRuns, at.In(loc))
+	}
+	return dto
 }
 
 // TriggerCount returns the number of registered triggers.
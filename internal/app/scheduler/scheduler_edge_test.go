@@ -83,7 +83,7 @@ func TestScheduler_RegisterDynamicTrigger_Success(t *testing.T) {
 	}
 	defer sched.Stop()
 
-	dto, err := sched.RegisterDynamicTrigger(ctx, "dynamic-test", "*/10 * * * *", "do something", "lark")
+	dto, err := sched.RegisterDynamicTrigger(ctx, "dynamic-test", "*/10 * * * *", "", "do something", "lark")
 	if err != nil {
 		t.Fatalf("RegisterDynamicTrigger: %v", err)
 	}
@@ -108,7 +108,7 @@ func TestScheduler_RegisterDynamicTrigger_Success(t *testing.T) {
 
 func TestScheduler_RegisterDynamicTrigger_EmptyName(t *testing.T) {
 	sched := New(Config{Enabled: true}, &mockCoordinator{answer: "ok"}, nil, nil)
-	_, err := sched.RegisterDynamicTrigger(context.Background(), "", "* * * * *", "task", "")
+	_, err := sched.RegisterDynamicTrigger(context.Background(), "", "* * * * *", "", "task", "")
 	if err == nil {
 		t.Fatal("expected error for empty name")
 	}
@@ -116,7 +116,7 @@ func TestScheduler_RegisterDynamicTrigger_EmptyName(t *testing.T) {
 
 func TestScheduler_RegisterDynamicTrigger_EmptySchedule(t *testing.T) {
 	sched := New(Config{Enabled: true}, &mockCoordinator{answer: "ok"}, nil, nil)
-	_, err := sched.RegisterDynamicTrigger(context.Background(), "test", "", "task", "")
+	_, err := sched.RegisterDynamicTrigger(context.Background(), "test", "", "", "task", "")
 	if err == nil {
 		t.Fatal("expected error for empty schedule")
 	}
@@ -124,7 +124,7 @@ func TestScheduler_RegisterDynamicTrigger_EmptySchedule(t *testing.T) {
 
 func TestScheduler_RegisterDynamicTrigger_InvalidCron(t *testing.T) {
 	sched := New(Config{Enabled: true}, &mockCoordinator{answer: "ok"}, nil, nil)
-	_, err := sched.RegisterDynamicTrigger(context.Background(), "test", "bad-cron", "task", "")
+	_, err := sched.RegisterDynamicTrigger(context.Background(), "test", "bad-cron", "", "task", "")
 	if err == nil {
 		t.Fatal("expected error for invalid cron expression")
 	}
@@ -146,7 +146,7 @@ func TestScheduler_UnregisterTrigger_Success(t *testing.T) {
 	defer sched.Stop()
 
 	// Register first.
-	if _, err := sched.RegisterDynamicTrigger(ctx, "unreg-test", "*/5 * * * *", "task", ""); err != nil {
+	if _, err := sched.RegisterDynamicTrigger(ctx, "unreg-test", "*/5 * * * *", "", "task", ""); err != nil {
 		t.Fatalf("RegisterDynamicTrigger: %v", err)
 	}
 
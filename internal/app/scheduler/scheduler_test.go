@@ -415,10 +415,10 @@ func TestSchedulerExecuteTriggerUsesUniqueSessionID(t *testing.T) {
 		Task:     "Test task",
 	}
 
-	if err := sched.executeTrigger(trigger); err != nil {
+	if _, err := sched.executeTrigger(trigger); err != nil {
 		t.Fatalf("executeTrigger: %v", err)
 	}
-	if err := sched.executeTrigger(trigger); err != nil {
+	if _, err := sched.executeTrigger(trigger); err != nil {
 		t.Fatalf("executeTrigger: %v", err)
 	}
 
@@ -452,7 +452,7 @@ func TestSchedulerExecuteTriggerAppliesTimeout(t *testing.T) {
 		TriggerTimeout: 2 * time.Second,
 	}, coord, nil, nil)
 
-	if err := sched.executeTrigger(Trigger{Name: "timeout-test", Schedule: "* * * * *", Task: "Task"}); err != nil {
+	if _, err := sched.executeTrigger(Trigger{Name: "timeout-test", Schedule: "* * * * *", Task: "Task"}); err != nil {
 		t.Fatalf("executeTrigger: %v", err)
 	}
 
@@ -475,7 +475,7 @@ func TestScheduler_ExecuteTrigger(t *testing.T) {
 		UserID:  "ou_exec",
 	}
 
-	if err := sched.executeTrigger(trigger); err != nil {
+	if _, err := sched.executeTrigger(trigger); err != nil {
 		t.Fatalf("executeTrigger: %v", err)
 	}
 
@@ -511,7 +511,7 @@ func TestScheduler_ExecuteTrigger_LarkRequiresOpenID(t *testing.T) {
 		UserID:  "user-1",
 	}
 
-	if err := sched.executeTrigger(trigger); err == nil {
+	if _, err := sched.executeTrigger(trigger); err == nil {
 		t.Fatal("expected error for non-open_id user_id")
 	}
 
@@ -537,7 +537,7 @@ func TestScheduler_ExecuteTrigger_NoNotifier(t *testing.T) {
 	}
 
 	// Should not panic with nil notifier
-	if err := sched.executeTrigger(trigger); err != nil {
+	if _, err := sched.executeTrigger(trigger); err != nil {
 		t.Fatalf("executeTrigger: %v", err)
 	}
 	if coord.callCount() != 1 {
@@ -561,7 +561,7 @@ func TestScheduler_ExecuteTrigger_SkipsWhenStopped(t *testing.T) {
 		UserID:  "ou_stopped",
 	}
 
-	err := sched.executeTrigger(trigger)
+	_, err := sched.executeTrigger(trigger)
 	if !errors.Is(err, errSchedulerStopped) {
 		t.Fatalf("expected errSchedulerStopped, got %v", err)
 	}
@@ -580,7 +580,7 @@ func TestScheduler_ExecuteTrigger_PrefersStoppedGuardOverValidation(t *testing.T
 
 	// Invalid lark user_id would normally fail validation, but stopped guard
 	// should short-circuit first.
-	err := sched.executeTrigger(Trigger{
+	_, err := sched.executeTrigger(Trigger{
 		Name:    "stopped-invalid",
 		Task:    "Run this",
 		Channel: "lark",
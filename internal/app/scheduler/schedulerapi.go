@@ -14,18 +14,25 @@ var _ Service = (*Scheduler)(nil)
 // JobDTO is a Data Transfer Object for job data exposed to external consumers.
 // The scheduler converts its internal Job type to this DTO when returning
 // results through the Service interface.
+//
+// FailureCount doubles as the failure streak: it counts consecutive failed
+// runs and resets on success. Missed fires do not affect it. NextRuns lists
+// the upcoming fire times rendered in the job's timezone.
 type JobDTO struct {
 	ID           string          `json:"id"`
 	Name         string          `json:"name"`
 	CronExpr     string          `json:"cron_expr"`
+	Timezone     string          `json:"timezone,omitempty"`
 	Trigger      string          `json:"trigger"`
 	Payload      json.RawMessage `json:"payload,omitempty"`
 	Status       string          `json:"status"`
 	LastRun      time.Time       `json:"last_run,omitempty"`
 	NextRun      time.Time       `json:"next_run,omitempty"`
+	NextRuns     []time.Time     `json:"next_runs,omitempty"`
 	FailureCount int             `json:"failure_count,omitempty"`
 	LastFailure  time.Time       `json:"last_failure,omitempty"`
 	LastError    string          `json:"last_error,omitempty"`
+	LastOutcome  string          `json:"last_outcome,omitempty"`
 	CreatedAt    time.Time       `json:"created_at"`
 	UpdatedAt    time.Time       `json:"updated_at"`
 }
@@ -34,8 +41,9 @@ type JobDTO struct {
 // scheduler subsystem. The *Scheduler type satisfies this interface.
 type Service interface {
 	// RegisterDynamicTrigger creates and schedules a new job, returning its
-	// persisted representation as a DTO.
-	RegisterDynamicTrigger(ctx context.Context, name, schedule, task, channel string) (*JobDTO, error)
+	// persisted representation as a DTO. timezone is an IANA zone name;
+	// empty uses the scheduler's local time.
+	RegisterDynamicTrigger(ctx context.Context, name, schedule, timezone, task, channel string) (*JobDTO, error)
 	// UnregisterTrigger removes a job by name from the scheduler and store.
 	UnregisterTrigger(ctx context.Context, name string) error
 	// ListJobs returns all persisted jobs as DTOs.
@@ -4,6 +4,7 @@ package scheduler
 type Trigger struct {
 	Name     string // unique trigger name (e.g. "daily_briefing" or "okr:q1-2026-revenue")
 	Schedule string // cron expression
+	Timezone string // IANA timezone for Schedule; empty = scheduler local time
 	Task     string // task text for agent execution
 	Channel  string // delivery channel: lark | web
 	UserID   string // for channel=lark, this must be Lark open_id (ou_*)
@@ -0,0 +1,62 @@
+package sessionimport
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	ports "alex/internal/domain/agent/ports"
+	"alex/internal/infra/memory"
+)
+
+const (
+	maxCapturedTurns     = 3
+	maxCapturedTurnChars = 240
+)
+
+type engineCapture struct {
+	engine memory.Engine
+}
+
+// NewMemoryCapture returns a MemoryCapture that appends a short digest of
+// each imported conversation (title plus its opening user requests) to the
+// user's daily memory, dated at the original conversation time.
+func NewMemoryCapture(engine memory.Engine) MemoryCapture {
+	if engine == nil {
+		return nil
+	}
+	return &engineCapture{engine: engine}
+}
+
+func (c *engineCapture) CaptureConversation(ctx context.Context, userID string, conv Conversation) error {
+	var lines []string
+	for _, msg := range conv.Messages {
+		if msg.Role != "user" {
+			continue
+		}
+		text := strings.Join(strings.Fields(msg.Content), " ")
+		if text == "" {
+			continue
+		}
+		lines = append(lines, "- "+ports.TruncateRuneSnippet(text, maxCapturedTurnChars))
+		if len(lines) >= maxCapturedTurns {
+			break
+		}
+	}
+	if len(lines) == 0 {
+		return nil
+	}
+	title := strings.TrimSpace(conv.Title)
+	if title == "" {
+		title = "Untitled conversation"
+	}
+	_, err := c.engine.AppendDaily(ctx, strings.TrimSpace(userID), memory.DailyEntry{
+		Title:     "Imported: " + title,
+		Content:   strings.Join(lines, "\n"),
+		CreatedAt: conv.CreatedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("append imported memory: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,212 @@
+package sessionimport
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	ports "alex/internal/domain/agent/ports"
+)
+
+// chatgptConversation mirrors one entry of ChatGPT's conversations.json.
+// Messages form a tree in Mapping; CurrentNode is the leaf of the branch the
+// user last viewed.
+type chatgptConversation struct {
+	ID             string                 `json:"id"`
+	ConversationID string                 `json:"conversation_id"`
+	Title          string                 `json:"title"`
+	CreateTime     float64                `json:"create_time"`
+	UpdateTime     float64                `json:"update_time"`
+	CurrentNode    string                 `json:"current_node"`
+	Mapping        map[string]chatgptNode `json:"mapping"`
+}
+
+type chatgptNode struct {
+	ID       string          `json:"id"`
+	Message  *chatgptMessage `json:"message"`
+	Parent   string          `json:"parent"`
+	Children []string        `json:"children"`
+}
+
+type chatgptMessage struct {
+	ID     string `json:"id"`
+	Author struct {
+		Role string `json:"role"`
+		Name string `json:"name"`
+	} `json:"author"`
+	CreateTime *float64 `json:"create_time"`
+	Content    struct {
+		ContentType string            `json:"content_type"`
+		Parts       []json.RawMessage `json:"parts"`
+		Text        string            `json:"text"`
+	} `json:"content"`
+	Recipient string         `json:"recipient"`
+	Metadata  map[string]any `json:"metadata"`
+}
+
+type chatgptAssetPart struct {
+	ContentType  string `json:"content_type"`
+	AssetPointer string `json:"asset_pointer"`
+}
+
+// convertChatGPT keeps only the active branch (root → current_node); sibling
+// branches from regenerated or edited turns are reported as skipped.
+func convertChatGPT(raw json.RawMessage, report *Report) (Conversation, error) {
+	var src chatgptConversation
+	if err := json.Unmarshal(raw, &src); err != nil {
+		return Conversation{}, fmt.Errorf("decode chatgpt conversation: %w", err)
+	}
+	conv := Conversation{
+		ExternalID: firstNonEmpty(src.ConversationID, src.ID),
+		Title:      src.Title,
+		CreatedAt:  unixSeconds(src.CreateTime),
+		UpdatedAt:  unixSeconds(src.UpdateTime),
+	}
+
+	path := chatgptActivePath(src)
+	onPath := make(map[string]struct{}, len(path))
+	for _, nodeID := range path {
+		onPath[nodeID] = struct{}{}
+	}
+	for nodeID, node := range src.Mapping {
+		if _, ok := onPath[nodeID]; ok || node.Message == nil {
+			continue
+		}
+		report.skip(conv.ExternalID, node.Message.ID, SkipAlternateNode)
+	}
+
+	for _, nodeID := range path {
+		msg := src.Mapping[nodeID].Message
+		if msg == nil {
+			continue
+		}
+		converted, reason := convertChatGPTMessage(msg, report, conv.ExternalID)
+		if reason != "" {
+			report.skip(conv.ExternalID, msg.ID, reason)
+			continue
+		}
+		conv.Messages = append(conv.Messages, converted)
+	}
+	return conv, nil
+}
+
+// chatgptActivePath returns node IDs from the root to current_node. When
+// current_node is missing it follows the last child at each fork, which is
+// the most recent regeneration.
+func chatgptActivePath(src chatgptConversation) []string {
+	leaf := src.CurrentNode
+	if _, ok := src.Mapping[leaf]; !ok {
+		leaf = ""
+		for nodeID, node := range src.Mapping {
+			if node.Parent == "" {
+				leaf = nodeID
+				break
+			}
+		}
+		for leaf != "" {
+			children := src.Mapping[leaf].Children
+			if len(children) == 0 {
+				break
+			}
+			leaf = children[len(children)-1]
+		}
+	}
+
+	var reversed []string
+	seen := make(map[string]struct{})
+	for nodeID := leaf; nodeID != ""; nodeID = src.Mapping[nodeID].Parent {
+		if _, ok := seen[nodeID]; ok {
+			break
+		}
+		if _, ok := src.Mapping[nodeID]; !ok {
+			break
+		}
+		seen[nodeID] = struct{}{}
+		reversed = append(reversed, nodeID)
+	}
+	path := make([]string, 0, len(reversed))
+	for idx := len(reversed) - 1; idx >= 0; idx-- {
+		path = append(path, reversed[idx])
+	}
+	return path
+}
+
+func convertChatGPTMessage(msg *chatgptMessage, report *Report, conversationID string) (ports.Message, string) {
+	if hidden, _ := msg.Metadata["is_visually_hidden_from_conversation"].(bool); hidden {
+		return ports.Message{}, SkipHiddenMessage
+	}
+	role := msg.Author.Role
+	switch role {
+	case "system":
+		return ports.Message{}, SkipSystemMessage
+	case "tool":
+		return ports.Message{}, SkipToolOutput
+	case "assistant":
+		if recipient := strings.TrimSpace(msg.Recipient); recipient != "" && recipient != "all" {
+			return ports.Message{}, SkipToolCall
+		}
+	case "user":
+	default:
+		return ports.Message{}, SkipUnsupported
+	}
+
+	var text string
+	switch msg.Content.ContentType {
+	case "text", "multimodal_text":
+		text = chatgptPartsText(msg, report, conversationID)
+	case "code":
+		text = "```\n" + msg.Content.Text + "\n```"
+	default:
+		return ports.Message{}, SkipUnsupported
+	}
+	if strings.TrimSpace(text) == "" {
+		return ports.Message{}, SkipEmptyMessage
+	}
+	var at time.Time
+	if msg.CreateTime != nil {
+		at = unixSeconds(*msg.CreateTime)
+	}
+	return importedMessage(role, text, msg.ID, at), ""
+}
+
+// chatgptPartsText joins string parts and replaces image parts with
+// placeholders.
+func chatgptPartsText(msg *chatgptMessage, report *Report, conversationID string) string {
+	segments := make([]string, 0, len(msg.Content.Parts))
+	for _, part := range msg.Content.Parts {
+		var s string
+		if err := json.Unmarshal(part, &s); err == nil {
+			if strings.TrimSpace(s) != "" {
+				segments = append(segments, s)
+			}
+			continue
+		}
+		var asset chatgptAssetPart
+		if err := json.Unmarshal(part, &asset); err == nil && asset.ContentType == "image_asset_pointer" {
+			segments = append(segments, attachmentPlaceholder("image", strings.TrimPrefix(asset.AssetPointer, "file-service://")))
+			report.skip(conversationID, msg.ID, SkipAttachment)
+			continue
+		}
+		report.skip(conversationID, msg.ID, SkipUnsupported)
+	}
+	return strings.Join(segments, "\n")
+}
+
+func unixSeconds(v float64) time.Time {
+	if v <= 0 {
+		return time.Time{}
+	}
+	sec, frac := math.Modf(v)
+	return time.Unix(int64(sec), int64(frac*1e9)).UTC()
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if strings.TrimSpace(v) != "" {
+			return v
+		}
+	}
+	return ""
+}
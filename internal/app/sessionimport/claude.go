@@ -0,0 +1,123 @@
+package sessionimport
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// claudeConversation mirrors one entry of Claude's conversations.json.
+type claudeConversation struct {
+	UUID         string          `json:"uuid"`
+	Name         string          `json:"name"`
+	CreatedAt    string          `json:"created_at"`
+	UpdatedAt    string          `json:"updated_at"`
+	ChatMessages []claudeMessage `json:"chat_messages"`
+}
+
+type claudeMessage struct {
+	UUID        string               `json:"uuid"`
+	Text        string               `json:"text"`
+	Sender      string               `json:"sender"`
+	CreatedAt   string               `json:"created_at"`
+	Content     []claudeContentBlock `json:"content"`
+	Attachments []claudeAttachment   `json:"attachments"`
+	Files       []claudeFile         `json:"files"`
+}
+
+type claudeContentBlock struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+	Name string `json:"name"`
+}
+
+type claudeAttachment struct {
+	FileName         string `json:"file_name"`
+	ExtractedContent string `json:"extracted_content"`
+}
+
+type claudeFile struct {
+	FileName string `json:"file_name"`
+}
+
+// convertClaude maps human/assistant turns. Tool use and tool result blocks
+// are dropped from the text (tool state cannot be replayed) and reported;
+// text attachments are inlined and uploaded files become placeholders.
+func convertClaude(raw json.RawMessage, report *Report) (Conversation, error) {
+	var src claudeConversation
+	if err := json.Unmarshal(raw, &src); err != nil {
+		return Conversation{}, fmt.Errorf("decode claude conversation: %w", err)
+	}
+	conv := Conversation{
+		ExternalID: src.UUID,
+		Title:      src.Name,
+		CreatedAt:  parseClaudeTime(src.CreatedAt),
+		UpdatedAt:  parseClaudeTime(src.UpdatedAt),
+	}
+	for _, msg := range src.ChatMessages {
+		var role string
+		switch msg.Sender {
+		case "human":
+			role = "user"
+		case "assistant":
+			role = "assistant"
+		default:
+			report.skip(conv.ExternalID, msg.UUID, SkipUnsupported)
+			continue
+		}
+		text := claudeMessageText(msg, report, conv.ExternalID)
+		if strings.TrimSpace(text) == "" {
+			report.skip(conv.ExternalID, msg.UUID, SkipEmptyMessage)
+			continue
+		}
+		conv.Messages = append(conv.Messages, importedMessage(role, text, msg.UUID, parseClaudeTime(msg.CreatedAt)))
+	}
+	return conv, nil
+}
+
+func claudeMessageText(msg claudeMessage, report *Report, conversationID string) string {
+	var segments []string
+	if len(msg.Content) == 0 {
+		if strings.TrimSpace(msg.Text) != "" {
+			segments = append(segments, msg.Text)
+		}
+	}
+	for _, block := range msg.Content {
+		switch block.Type {
+		case "text":
+			if strings.TrimSpace(block.Text) != "" {
+				segments = append(segments, block.Text)
+			}
+		case "tool_use":
+			report.skip(conversationID, msg.UUID, SkipToolCall)
+		case "tool_result":
+			report.skip(conversationID, msg.UUID, SkipToolOutput)
+		case "image":
+			segments = append(segments, attachmentPlaceholder("image", block.Name))
+			report.skip(conversationID, msg.UUID, SkipAttachment)
+		default:
+			report.skip(conversationID, msg.UUID, SkipUnsupported)
+		}
+	}
+	for _, att := range msg.Attachments {
+		if content := strings.TrimSpace(att.ExtractedContent); content != "" {
+			segments = append(segments, fmt.Sprintf("[attachment: %s]\n%s", att.FileName, content))
+		}
+	}
+	for _, file := range msg.Files {
+		segments = append(segments, attachmentPlaceholder("file", file.FileName))
+		report.skip(conversationID, msg.UUID, SkipAttachment)
+	}
+	return strings.Join(segments, "\n\n")
+}
+
+// parseClaudeTime tolerates missing or malformed timestamps; the session
+// keeps its own timestamps when these are zero.
+func parseClaudeTime(raw string) time.Time {
+	parsed, err := time.Parse(time.RFC3339Nano, strings.TrimSpace(raw))
+	if err != nil {
+		return time.Time{}
+	}
+	return parsed.UTC()
+}
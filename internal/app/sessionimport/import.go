@@ -0,0 +1,307 @@
+// Package sessionimport converts conversation exports from other assistants
+// (ChatGPT, Claude) into alex sessions so migrated history is available as
+// context. Exports are stream-parsed one conversation at a time so large
+// archives never have to fit in memory.
+package sessionimport
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"alex/internal/app/agent/sessiontitle"
+	ports "alex/internal/domain/agent/ports"
+	storage "alex/internal/domain/agent/ports/storage"
+	"alex/internal/shared/logging"
+)
+
+// Source identifies the vendor export format.
+type Source string
+
+const (
+	SourceChatGPT Source = "chatgpt"
+	SourceClaude  Source = "claude"
+)
+
+// ParseSource normalizes a user-supplied source name.
+func ParseSource(raw string) (Source, error) {
+	switch strings.ToLower(strings.TrimSpace(raw)) {
+	case "chatgpt", "openai":
+		return SourceChatGPT, nil
+	case "claude", "anthropic":
+		return SourceClaude, nil
+	default:
+		return "", fmt.Errorf("unsupported import source %q (expected chatgpt or claude)", raw)
+	}
+}
+
+// Session metadata keys written for imported sessions.
+const (
+	MetadataImportSource = "import_source"
+	MetadataImportID     = "import_conversation_id"
+	MetadataImportedAt   = "imported_at"
+	metadataUserID       = "user_id"
+)
+
+// Skip reasons recorded in the import report.
+const (
+	SkipSystemMessage = "system_message"
+	SkipHiddenMessage = "hidden_message"
+	SkipToolCall      = "tool_call"
+	SkipToolOutput    = "tool_output"
+	SkipUnsupported   = "unsupported_content"
+	SkipEmptyMessage  = "empty_message"
+	SkipAlternateNode = "alternate_branch"
+	SkipEmptyThread   = "empty_conversation"
+	SkipAttachment    = "attachment_placeholder"
+)
+
+// maxReportedSkips bounds the per-item skip list; totals are always complete.
+const maxReportedSkips = 200
+
+// conversationsEntry is the export member holding conversations for both vendors.
+const conversationsEntry = "conversations.json"
+
+// Options controls a single import run.
+type Options struct {
+	Source Source
+	// UserID owns the created sessions. Empty leaves sessions unowned.
+	UserID string
+	// CaptureMemory runs the memory capture pipeline over each imported
+	// conversation.
+	CaptureMemory bool
+}
+
+// SkippedItem explains why part of an export was not imported verbatim.
+type SkippedItem struct {
+	ConversationID string `json:"conversation_id,omitempty"`
+	MessageID      string `json:"message_id,omitempty"`
+	Reason         string `json:"reason"`
+}
+
+// Report summarizes an import run.
+type Report struct {
+	Source           Source         `json:"source"`
+	SessionsCreated  int            `json:"sessions_created"`
+	MessagesImported int            `json:"messages_imported"`
+	SessionIDs       []string       `json:"session_ids,omitempty"`
+	SkippedByReason  map[string]int `json:"skipped_by_reason,omitempty"`
+	Skipped          []SkippedItem  `json:"skipped,omitempty"`
+	MemoryCaptured   int            `json:"memory_captured,omitempty"`
+	MemoryErrors     int            `json:"memory_errors,omitempty"`
+}
+
+func (r *Report) skip(conversationID, messageID, reason string) {
+	if r.SkippedByReason == nil {
+		r.SkippedByReason = make(map[string]int)
+	}
+	r.SkippedByReason[reason]++
+	if len(r.Skipped) < maxReportedSkips {
+		r.Skipped = append(r.Skipped, SkippedItem{ConversationID: conversationID, MessageID: messageID, Reason: reason})
+	}
+}
+
+// Conversation is a vendor conversation normalized to alex messages.
+type Conversation struct {
+	ExternalID string
+	Title      string
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	Messages   []ports.Message
+}
+
+// MemoryCapture receives each imported conversation when
+// Options.CaptureMemory is set.
+type MemoryCapture interface {
+	CaptureConversation(ctx context.Context, userID string, conv Conversation) error
+}
+
+// Importer writes parsed conversations into the session store.
+type Importer struct {
+	sessions storage.SessionStore
+	capture  MemoryCapture
+	logger   logging.Logger
+	clock    func() time.Time
+}
+
+// New constructs an Importer. capture may be nil when memory capture is
+// unavailable; CaptureMemory is then ignored.
+func New(sessions storage.SessionStore, capture MemoryCapture, logger logging.Logger) *Importer {
+	return &Importer{
+		sessions: sessions,
+		capture:  capture,
+		logger:   logging.OrNop(logger),
+		clock:    time.Now,
+	}
+}
+
+// ImportFile imports a vendor export from disk. Both the original .zip
+// archive and an extracted conversations.json are accepted.
+func (i *Importer) ImportFile(ctx context.Context, filePath string, opts Options) (Report, error) {
+	if strings.EqualFold(path.Ext(filePath), ".zip") {
+		return i.importZip(ctx, filePath, opts)
+	}
+	f, err := os.Open(filePath)
+	if err != nil {
+		return Report{Source: opts.Source}, fmt.Errorf("open export: %w", err)
+	}
+	defer f.Close()
+	return i.Import(ctx, f, opts)
+}
+
+func (i *Importer) importZip(ctx context.Context, filePath string, opts Options) (Report, error) {
+	archive, err := zip.OpenReader(filePath)
+	if err != nil {
+		return Report{Source: opts.Source}, fmt.Errorf("open export archive: %w", err)
+	}
+	defer archive.Close()
+	for _, file := range archive.File {
+		if path.Base(file.Name) != conversationsEntry {
+			continue
+		}
+		rc, err := file.Open()
+		if err != nil {
+			return Report{Source: opts.Source}, fmt.Errorf("open %s: %w", file.Name, err)
+		}
+		defer rc.Close()
+		return i.Import(ctx, rc, opts)
+	}
+	return Report{Source: opts.Source}, fmt.Errorf("export archive has no %s", conversationsEntry)
+}
+
+// Import stream-parses a conversations.json document and creates one
+// session per conversation.
+func (i *Importer) Import(ctx context.Context, r io.Reader, opts Options) (Report, error) {
+	report := Report{Source: opts.Source}
+	if i == nil || i.sessions == nil {
+		return report, errors.New("session store not configured")
+	}
+	var convert func(json.RawMessage, *Report) (Conversation, error)
+	switch opts.Source {
+	case SourceChatGPT:
+		convert = convertChatGPT
+	case SourceClaude:
+		convert = convertClaude
+	default:
+		return report, fmt.Errorf("unsupported import source %q", opts.Source)
+	}
+
+	err := decodeArray(r, func(raw json.RawMessage) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		conv, err := convert(raw, &report)
+		if err != nil {
+			return err
+		}
+		if len(conv.Messages) == 0 {
+			report.skip(conv.ExternalID, "", SkipEmptyThread)
+			return nil
+		}
+		sessionID, err := i.save(ctx, conv, opts)
+		if err != nil {
+			return err
+		}
+		report.SessionsCreated++
+		report.MessagesImported += len(conv.Messages)
+		report.SessionIDs = append(report.SessionIDs, sessionID)
+		if opts.CaptureMemory && i.capture != nil {
+			if err := i.capture.CaptureConversation(ctx, opts.UserID, conv); err != nil {
+				report.MemoryErrors++
+				i.logger.Warn("Import memory capture failed for %s: %v", conv.ExternalID, err)
+			} else {
+				report.MemoryCaptured++
+			}
+		}
+		return nil
+	})
+	return report, err
+}
+
+func (i *Importer) save(ctx context.Context, conv Conversation, opts Options) (string, error) {
+	session, err := i.sessions.Create(ctx)
+	if err != nil {
+		return "", fmt.Errorf("create session: %w", err)
+	}
+	session.Messages = conv.Messages
+	metadata := storage.EnsureMetadata(session)
+	metadata[MetadataImportSource] = string(opts.Source)
+	metadata[MetadataImportID] = conv.ExternalID
+	metadata[MetadataImportedAt] = i.clock().UTC().Format(time.RFC3339)
+	if title := strings.TrimSpace(conv.Title); title != "" {
+		metadata[sessiontitle.MetadataTitle] = title
+		metadata[sessiontitle.MetadataSource] = sessiontitle.SourceUser
+	}
+	if userID := strings.TrimSpace(opts.UserID); userID != "" {
+		metadata[metadataUserID] = userID
+	}
+	if !conv.CreatedAt.IsZero() {
+		session.CreatedAt = conv.CreatedAt
+	}
+	if !conv.UpdatedAt.IsZero() {
+		session.UpdatedAt = conv.UpdatedAt
+	}
+	if err := i.sessions.Save(ctx, session); err != nil {
+		return "", fmt.Errorf("save session %s: %w", session.ID, err)
+	}
+	return session.ID, nil
+}
+
+// decodeArray walks a top-level JSON array and hands each element to fn
+// without buffering the whole document.
+func decodeArray(r io.Reader, fn func(json.RawMessage) error) error {
+	dec := json.NewDecoder(r)
+	tok, err := dec.Token()
+	if err != nil {
+		return fmt.Errorf("read export: %w", err)
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '[' {
+		return errors.New("read export: expected a JSON array of conversations")
+	}
+	for dec.More() {
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			return fmt.Errorf("decode conversation: %w", err)
+		}
+		if err := fn(raw); err != nil {
+			return err
+		}
+	}
+	if _, err := dec.Token(); err != nil {
+		return fmt.Errorf("read export: %w", err)
+	}
+	return nil
+}
+
+func importedMessage(role, content, externalID string, at time.Time) ports.Message {
+	source := ports.MessageSourceUserInput
+	if role == "assistant" {
+		source = ports.MessageSourceAssistantReply
+	}
+	metadata := map[string]any{"import_message_id": externalID}
+	if !at.IsZero() {
+		metadata["created_at"] = at.UTC().Format(time.RFC3339)
+	}
+	return ports.Message{
+		Role:     role,
+		Content:  content,
+		Metadata: metadata,
+		Source:   source,
+	}
+}
+
+// attachmentPlaceholder stands in for binary content that cannot be imported.
+func attachmentPlaceholder(kind, name string) string {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return fmt.Sprintf("[%s not imported]", kind)
+	}
+	return fmt.Sprintf("[%s not imported: %s]", kind, name)
+}
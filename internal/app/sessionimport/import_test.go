@@ -0,0 +1,246 @@
+package sessionimport
+
+import (
+	"archive/zip"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"alex/internal/app/agent/sessiontitle"
+	ports "alex/internal/domain/agent/ports"
+	storage "alex/internal/domain/agent/ports/storage"
+)
+
+type memorySessionStore struct {
+	mu       sync.Mutex
+	next     int
+	sessions map[string]*storage.Session
+	order    []string
+}
+
+func newMemorySessionStore() *memorySessionStore {
+	return &memorySessionStore{sessions: make(map[string]*storage.Session)}
+}
+
+func (s *memorySessionStore) Create(context.Context) (*storage.Session, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.next++
+	return storage.NewSession(fmt.Sprintf("session-%d", s.next), time.Now()), nil
+}
+
+func (s *memorySessionStore) Get(_ context.Context, id string) (*storage.Session, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	session, ok := s.sessions[id]
+	if !ok {
+		return nil, storage.ErrSessionNotFound
+	}
+	return session, nil
+}
+
+func (s *memorySessionStore) Save(_ context.Context, session *storage.Session) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.sessions[session.ID]; !ok {
+		s.order = append(s.order, session.ID)
+	}
+	s.sessions[session.ID] = session
+	return nil
+}
+
+func (s *memorySessionStore) List(context.Context, int, int) ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.order...), nil
+}
+
+func (s *memorySessionStore) Delete(_ context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.sessions, id)
+	return nil
+}
+
+type recordingCapture struct {
+	titles []string
+}
+
+func (c *recordingCapture) CaptureConversation(_ context.Context, _ string, conv Conversation) error {
+	c.titles = append(c.titles, conv.Title)
+	return nil
+}
+
+func contents(messages []ports.Message) []string {
+	out := make([]string, 0, len(messages))
+	for _, msg := range messages {
+		out = append(out, msg.Role+": "+msg.Content)
+	}
+	return out
+}
+
+func TestImportChatGPTFollowsActiveBranch(t *testing.T) {
+	store := newMemorySessionStore()
+	importer := New(store, nil, nil)
+
+	report, err := importer.ImportFile(context.Background(), "testdata/chatgpt_conversations.json", Options{Source: SourceChatGPT, UserID: "u-1"})
+	if err != nil {
+		t.Fatalf("ImportFile: %v", err)
+	}
+	if report.SessionsCreated != 2 || report.MessagesImported != 4 {
+		t.Fatalf("unexpected totals: %+v", report)
+	}
+	for reason, want := range map[string]int{
+		SkipAlternateNode: 1,
+		SkipHiddenMessage: 1,
+		SkipToolCall:      1,
+		SkipToolOutput:    1,
+		SkipAttachment:    1,
+		SkipEmptyThread:   1,
+	} {
+		if got := report.SkippedByReason[reason]; got != want {
+			t.Errorf("skipped[%s] = %d, want %d (all: %v)", reason, got, want, report.SkippedByReason)
+		}
+	}
+
+	branch := store.sessions[report.SessionIDs[0]]
+	got := contents(branch.Messages)
+	want := []string{"user: Plan a trip to Kyoto", "assistant: Day 1: Fushimi Inari"}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Fatalf("branch messages = %v, want %v", got, want)
+	}
+	if branch.Messages[0].Source != ports.MessageSourceUserInput || branch.Messages[1].Source != ports.MessageSourceAssistantReply {
+		t.Fatalf("unexpected sources: %q %q", branch.Messages[0].Source, branch.Messages[1].Source)
+	}
+	if branch.Messages[0].Metadata["created_at"] != "2023-11-14T22:13:30Z" {
+		t.Fatalf("unexpected message timestamp: %v", branch.Messages[0].Metadata["created_at"])
+	}
+	if !branch.CreatedAt.Equal(time.Unix(1700000000, 5e8)) {
+		t.Fatalf("expected session CreatedAt from export, got %v", branch.CreatedAt)
+	}
+	if branch.Metadata[sessiontitle.MetadataTitle] != "Branched trip planning" ||
+		branch.Metadata[MetadataImportSource] != "chatgpt" ||
+		branch.Metadata[MetadataImportID] != "conv-branch" ||
+		branch.Metadata["user_id"] != "u-1" {
+		t.Fatalf("unexpected metadata: %v", branch.Metadata)
+	}
+
+	tools := store.sessions[report.SessionIDs[1]]
+	got = contents(tools.Messages)
+	want = []string{"user: [image not imported: file-abc]\nWhat is in this photo?", "assistant: It is Kinkaku-ji."}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Fatalf("tool conversation messages = %v, want %v", got, want)
+	}
+}
+
+func TestImportClaudeExport(t *testing.T) {
+	store := newMemorySessionStore()
+	capture := &recordingCapture{}
+	importer := New(store, capture, nil)
+
+	report, err := importer.ImportFile(context.Background(), "testdata/claude_conversations.json", Options{Source: SourceClaude, CaptureMemory: true})
+	if err != nil {
+		t.Fatalf("ImportFile: %v", err)
+	}
+	if report.SessionsCreated != 2 || report.MessagesImported != 3 {
+		t.Fatalf("unexpected totals: %+v", report)
+	}
+	for reason, want := range map[string]int{
+		SkipToolCall:     1,
+		SkipToolOutput:   1,
+		SkipAttachment:   1,
+		SkipEmptyMessage: 1,
+	} {
+		if got := report.SkippedByReason[reason]; got != want {
+			t.Errorf("skipped[%s] = %d, want %d (all: %v)", reason, got, want, report.SkippedByReason)
+		}
+	}
+	if report.MemoryCaptured != 2 || len(capture.titles) != 2 {
+		t.Fatalf("expected memory capture for both conversations, got %+v", report)
+	}
+
+	first := store.sessions[report.SessionIDs[0]]
+	got := contents(first.Messages)
+	want := []string{
+		"user: Can you review this file?\n\n[attachment: main.go]\npackage main\n\n[file not imported: diagram.png]",
+		"assistant: The file looks good.",
+	}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Fatalf("claude messages = %q, want %q", got, want)
+	}
+
+	legacy := store.sessions[report.SessionIDs[1]]
+	if len(legacy.Messages) != 1 || legacy.Messages[0].Content != "Legacy text-only message" {
+		t.Fatalf("expected text fallback for content-less message, got %+v", legacy.Messages)
+	}
+	if _, ok := legacy.Metadata[sessiontitle.MetadataTitle]; ok {
+		t.Fatal("expected untitled conversation to leave title unset")
+	}
+}
+
+func TestImportZipArchive(t *testing.T) {
+	fixture, err := os.ReadFile("testdata/claude_conversations.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	archivePath := filepath.Join(t.TempDir(), "export.zip")
+	f, err := os.Create(archivePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	zw := zip.NewWriter(f)
+	w, err := zw.Create("data-2024/conversations.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.Write(fixture); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := New(newMemorySessionStore(), nil, nil).ImportFile(context.Background(), archivePath, Options{Source: SourceClaude})
+	if err != nil {
+		t.Fatalf("ImportFile: %v", err)
+	}
+	if report.SessionsCreated != 2 {
+		t.Fatalf("expected 2 sessions from archive, got %+v", report)
+	}
+}
+
+func TestImportRejectsNonArray(t *testing.T) {
+	_, err := New(newMemorySessionStore(), nil, nil).Import(context.Background(), strings.NewReader(`{"title":"x"}`), Options{Source: SourceChatGPT})
+	if err == nil {
+		t.Fatal("expected error for non-array export")
+	}
+}
+
+func TestJobsReportCompletion(t *testing.T) {
+	jobs := NewJobs(New(newMemorySessionStore(), nil, nil), nil)
+	cleaned := make(chan struct{})
+	job, err := jobs.Start("testdata/chatgpt_conversations.json", Options{Source: SourceChatGPT}, func() { close(cleaned) })
+	if err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	select {
+	case <-cleaned:
+	case <-time.After(5 * time.Second):
+		t.Fatal("import job did not finish")
+	}
+	got, ok := jobs.Get(job.ID)
+	if !ok {
+		t.Fatal("job not found")
+	}
+	if got.Status != JobCompleted || got.Report == nil || got.Report.SessionsCreated != 2 {
+		t.Fatalf("unexpected job state: %+v", got)
+	}
+}
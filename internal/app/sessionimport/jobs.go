@@ -0,0 +1,143 @@
+package sessionimport
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"alex/internal/shared/async"
+	"alex/internal/shared/logging"
+	id "alex/internal/shared/utils/id"
+)
+
+// JobStatus is the lifecycle state of an asynchronous import.
+type JobStatus string
+
+const (
+	JobPending   JobStatus = "pending"
+	JobRunning   JobStatus = "running"
+	JobCompleted JobStatus = "completed"
+	JobFailed    JobStatus = "failed"
+)
+
+// maxRetainedJobs bounds finished jobs kept for status polling.
+const maxRetainedJobs = 100
+
+// Job is a point-in-time view of an asynchronous import.
+type Job struct {
+	ID         string     `json:"id"`
+	Status     JobStatus  `json:"status"`
+	Source     Source     `json:"source"`
+	UserID     string     `json:"user_id,omitempty"`
+	Report     *Report    `json:"report,omitempty"`
+	Error      string     `json:"error,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+}
+
+// Jobs runs imports in the background and keeps their reports for polling.
+type Jobs struct {
+	importer *Importer
+	logger   logging.Logger
+	clock    func() time.Time
+
+	mu   sync.Mutex
+	jobs map[string]*Job
+}
+
+// NewJobs constructs a background job runner for importer.
+func NewJobs(importer *Importer, logger logging.Logger) *Jobs {
+	return &Jobs{
+		importer: importer,
+		logger:   logging.OrNop(logger),
+		clock:    time.Now,
+		jobs:     make(map[string]*Job),
+	}
+}
+
+// Start imports filePath in the background. cleanup, when non-nil, runs
+// after the import finishes (e.g. to remove an uploaded temp file).
+func (j *Jobs) Start(filePath string, opts Options, cleanup func()) (Job, error) {
+	if j == nil || j.importer == nil {
+		return Job{}, errors.New("session import not configured")
+	}
+	job := &Job{
+		ID:        id.NewKSUID(),
+		Status:    JobPending,
+		Source:    opts.Source,
+		UserID:    opts.UserID,
+		CreatedAt: j.clock(),
+	}
+	j.mu.Lock()
+	j.jobs[job.ID] = job
+	j.pruneLocked()
+	snapshot := *job
+	j.mu.Unlock()
+
+	async.Go(j.logger, "sessionimport.job", func() {
+		if cleanup != nil {
+			defer cleanup()
+		}
+		j.update(job.ID, func(job *Job) { job.Status = JobRunning })
+		report, err := j.importer.ImportFile(context.Background(), filePath, opts)
+		j.update(job.ID, func(job *Job) {
+			finished := j.clock()
+			job.FinishedAt = &finished
+			job.Report = &report
+			if err != nil {
+				job.Status = JobFailed
+				job.Error = err.Error()
+				return
+			}
+			job.Status = JobCompleted
+		})
+		if err != nil {
+			j.logger.Warn("Session import %s failed after %d sessions: %v", job.ID, report.SessionsCreated, err)
+		}
+	})
+	return snapshot, nil
+}
+
+// Get returns a snapshot of the job with the given ID.
+func (j *Jobs) Get(jobID string) (Job, bool) {
+	if j == nil {
+		return Job{}, false
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	job, ok := j.jobs[jobID]
+	if !ok {
+		return Job{}, false
+	}
+	return *job, true
+}
+
+func (j *Jobs) update(jobID string, fn func(*Job)) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if job, ok := j.jobs[jobID]; ok {
+		fn(job)
+	}
+}
+
+// pruneLocked drops the oldest finished jobs beyond maxRetainedJobs.
+func (j *Jobs) pruneLocked() {
+	if len(j.jobs) <= maxRetainedJobs {
+		return
+	}
+	finished := make([]*Job, 0, len(j.jobs))
+	for _, job := range j.jobs {
+		if job.FinishedAt != nil {
+			finished = append(finished, job)
+		}
+	}
+	sort.Slice(finished, func(a, b int) bool { return finished[a].CreatedAt.Before(finished[b].CreatedAt) })
+	for _, job := range finished {
+		if len(j.jobs) <= maxRetainedJobs {
+			return
+		}
+		delete(j.jobs, job.ID)
+	}
+}
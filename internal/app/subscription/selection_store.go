@@ -7,8 +7,10 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"sync"
+	"time"
 
 	"alex/internal/infra/filestore"
 	runtimeconfig "alex/internal/shared/config"
@@ -17,20 +19,30 @@ import (
 )
 
 const (
-	selectionStoreVersion  = 1
-	selectionStoreFilename = "llm_selection.json"
+	selectionStoreVersion       = 2
+	legacySelectionStoreVersion = 1
+	selectionStoreFilename      = "llm_selection.json"
 )
 
 // SelectionScope identifies where an LLM selection applies.
 // Channel is required.
 // Global selections omit ChatID/UserID.
 // Chat selections set ChatID; UserID is optional for legacy per-user scopes.
+// User selections set only UserID and apply across every chat of the channel.
 type SelectionScope struct {
 	Channel string
 	ChatID  string
 	UserID  string
 }
 
+// SelectionMatch is a stored selection together with the scope it is pinned
+// to and when it was pinned (zero for pins migrated from version 1 files).
+type SelectionMatch struct {
+	Scope     SelectionScope
+	Selection Selection
+	PinnedAt  time.Time
+}
+
 func (s SelectionScope) key() (string, error) {
 	channel := utils.TrimLower(s.Channel)
 	if channel == "" {
@@ -45,16 +57,64 @@ func (s SelectionScope) key() (string, error) {
 		return fmt.Sprintf("%s:chat=%s", channel, chatID), nil
 	}
 	if chatID == "" && userID != "" {
-		return "", fmt.Errorf("chat_id required when user_id is set")
+		return fmt.Sprintf("%s:user=%s", channel, userID), nil
 	}
 	return fmt.Sprintf("%s:chat=%s:user=%s", channel, chatID, userID), nil
 }
 
+// parseLegacySelectionKey converts a version 1 map key back into its scope.
+func parseLegacySelectionKey(key string) (SelectionScope, bool) {
+	parts := strings.Split(key, ":")
+	scope := SelectionScope{Channel: parts[0]}
+	for _, part := range parts[1:] {
+		name, value, ok := strings.Cut(part, "=")
+		if !ok {
+			return SelectionScope{}, false
+		}
+		switch name {
+		case "chat":
+			scope.ChatID = value
+		case "user":
+			scope.UserID = value
+		default:
+			return SelectionScope{}, false
+		}
+	}
+	if _, err := scope.key(); err != nil {
+		return SelectionScope{}, false
+	}
+	return scope, true
+}
+
+// selectionPin is one persisted selection in the version 2 file format.
+type selectionPin struct {
+	Channel   string    `json:"channel"`
+	ChatID    string    `json:"chat_id,omitempty"`
+	UserID    string    `json:"user_id,omitempty"`
+	Selection Selection `json:"selection"`
+	PinnedAt  time.Time `json:"pinned_at,omitempty"`
+}
+
+func (p selectionPin) match() SelectionMatch {
+	return SelectionMatch{
+		Scope:     SelectionScope{Channel: p.Channel, ChatID: p.ChatID, UserID: p.UserID},
+		Selection: p.Selection,
+		PinnedAt:  p.PinnedAt,
+	}
+}
+
+// selectionStoreDoc is the on-disk document. Version 1 files stored
+// Selections keyed by scope key; they are migrated to Pins on load and
+// rewritten as version 2 on the next save.
 type selectionStoreDoc struct {
 	Version    int                  `json:"version"`
+	Pins       []selectionPin       `json:"pins,omitempty"`
 	Selections map[string]Selection `json:"selections,omitempty"`
 }
 
+// selectionPins maps scope keys to pins.
+type selectionPins map[string]selectionPin
+
 // ResolveSelectionStorePath returns the file path used to persist pinned LLM selections.
 //
 // Priority:
@@ -98,29 +158,40 @@ func (s *SelectionStore) Get(ctx context.Context, scope SelectionScope) (Selecti
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	doc, err := s.loadDocLocked(ctx)
+	pins, err := s.loadPinsLocked(ctx)
 	if err != nil {
 		return Selection{}, false, err
 	}
-	selection, ok := doc.Selections[key]
-	return selection, ok, nil
+	pin, ok := pins[key]
+	return pin.Selection, ok, nil
 }
 
 // GetWithFallback tries each scope in order and returns the first match.
 // A single lock acquisition and file read is used for all lookups.
 func (s *SelectionStore) GetWithFallback(ctx context.Context, scopes ...SelectionScope) (Selection, SelectionScope, bool, error) {
+	matches, err := s.Matches(ctx, scopes...)
+	if err != nil || len(matches) == 0 {
+		return Selection{}, SelectionScope{}, false, err
+	}
+	return matches[0].Selection, matches[0].Scope, true, nil
+}
+
+// Matches returns every stored selection among scopes, in the order the
+// scopes were given. The first match is the one in effect; the rest are the
+// pins it overrides.
+func (s *SelectionStore) Matches(ctx context.Context, scopes ...SelectionScope) ([]SelectionMatch, error) {
 	if s == nil || len(scopes) == 0 {
-		return Selection{}, SelectionScope{}, false, nil
+		return nil, nil
 	}
 	if err := contextErr(ctx); err != nil {
-		return Selection{}, SelectionScope{}, false, err
+		return nil, err
 	}
 
 	keys := make([]string, len(scopes))
 	for i, scope := range scopes {
 		k, err := scope.key()
 		if err != nil {
-			return Selection{}, SelectionScope{}, false, err
+			return nil, err
 		}
 		keys[i] = k
 	}
@@ -128,16 +199,19 @@ func (s *SelectionStore) GetWithFallback(ctx context.Context, scopes ...Selectio
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	doc, err := s.loadDocLocked(ctx)
+	pins, err := s.loadPinsLocked(ctx)
 	if err != nil {
-		return Selection{}, SelectionScope{}, false, err
+		return nil, err
 	}
+	var matches []SelectionMatch
 	for i, k := range keys {
-		if sel, ok := doc.Selections[k]; ok {
-			return sel, scopes[i], true, nil
+		if pin, ok := pins[k]; ok {
+			match := pin.match()
+			match.Scope = scopes[i]
+			matches = append(matches, match)
 		}
 	}
-	return Selection{}, SelectionScope{}, false, nil
+	return matches, nil
 }
 
 func (s *SelectionStore) Set(ctx context.Context, scope SelectionScope, selection Selection) error {
@@ -155,15 +229,18 @@ func (s *SelectionStore) Set(ctx context.Context, scope SelectionScope, selectio
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	doc, err := s.loadDocLocked(ctx)
+	pins, err := s.loadPinsLocked(ctx)
 	if err != nil {
 		return err
 	}
-	if doc.Selections == nil {
-		doc.Selections = map[string]Selection{}
+	pins[key] = selectionPin{
+		Channel:   utils.TrimLower(scope.Channel),
+		ChatID:    strings.TrimSpace(scope.ChatID),
+		UserID:    strings.TrimSpace(scope.UserID),
+		Selection: selection,
+		PinnedAt:  time.Now().UTC(),
 	}
-	doc.Selections[key] = selection
-	return s.saveDocLocked(ctx, doc)
+	return s.savePinsLocked(ctx, pins)
 }
 
 func (s *SelectionStore) Clear(ctx context.Context, scope SelectionScope) error {
@@ -181,69 +258,86 @@ func (s *SelectionStore) Clear(ctx context.Context, scope SelectionScope) error
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	doc, err := s.loadDocLocked(ctx)
+	pins, err := s.loadPinsLocked(ctx)
 	if err != nil {
 		return err
 	}
-	if len(doc.Selections) == 0 {
+	if _, ok := pins[key]; !ok {
 		return nil
 	}
-	delete(doc.Selections, key)
-	return s.saveDocLocked(ctx, doc)
+	delete(pins, key)
+	return s.savePinsLocked(ctx, pins)
 }
 
-func (s *SelectionStore) loadDocLocked(ctx context.Context) (selectionStoreDoc, error) {
+func (s *SelectionStore) loadPinsLocked(ctx context.Context) (selectionPins, error) {
 	if s.path == "" {
-		return selectionStoreDoc{}, fmt.Errorf("selection store path not configured")
+		return nil, fmt.Errorf("selection store path not configured")
 	}
 	if err := contextErr(ctx); err != nil {
-		return selectionStoreDoc{}, err
+		return nil, err
 	}
 
+	pins := selectionPins{}
 	data, err := os.ReadFile(s.path)
 	if err != nil {
 		if errors.Is(err, os.ErrNotExist) {
-			return selectionStoreDoc{Version: selectionStoreVersion, Selections: map[string]Selection{}}, nil
+			return pins, nil
 		}
-		return selectionStoreDoc{}, fmt.Errorf("read selection store: %w", err)
+		return nil, fmt.Errorf("read selection store: %w", err)
 	}
 	if len(bytes.TrimSpace(data)) == 0 {
-		return selectionStoreDoc{Version: selectionStoreVersion, Selections: map[string]Selection{}}, nil
+		return pins, nil
 	}
 
 	var doc selectionStoreDoc
 	if err := jsonx.Unmarshal(data, &doc); err != nil {
-		return selectionStoreDoc{}, fmt.Errorf("parse selection store: %w", err)
-	}
-	if doc.Version == 0 {
-		doc.Version = selectionStoreVersion
-	}
-	if doc.Version != selectionStoreVersion {
-		return selectionStoreDoc{}, fmt.Errorf("unsupported selection store version %d", doc.Version)
-	}
-	if doc.Selections == nil {
-		doc.Selections = map[string]Selection{}
+		return nil, fmt.Errorf("parse selection store: %w", err)
+	}
+	switch doc.Version {
+	case 0, legacySelectionStoreVersion:
+		for key, selection := range doc.Selections {
+			scope, ok := parseLegacySelectionKey(key)
+			if !ok {
+				return nil, fmt.Errorf("parse selection store: invalid scope key %q", key)
+			}
+			pins[key] = selectionPin{Channel: scope.Channel, ChatID: scope.ChatID, UserID: scope.UserID, Selection: selection}
+		}
+	case selectionStoreVersion:
+		for _, pin := range doc.Pins {
+			key, err := pin.match().Scope.key()
+			if err != nil {
+				return nil, fmt.Errorf("parse selection store: %w", err)
+			}
+			pins[key] = pin
+		}
+	default:
+		return nil, fmt.Errorf("unsupported selection store version %d", doc.Version)
 	}
-	return doc, nil
+	return pins, nil
 }
 
-func (s *SelectionStore) saveDocLocked(ctx context.Context, doc selectionStoreDoc) error {
+func (s *SelectionStore) savePinsLocked(ctx context.Context, pins selectionPins) error {
 	if s.path == "" {
 		return fmt.Errorf("selection store path not configured")
 	}
 	if err := contextErr(ctx); err != nil {
 		return err
 	}
-	doc.Version = selectionStoreVersion
-	if doc.Selections == nil {
-		doc.Selections = map[string]Selection{}
-	}
-	if len(doc.Selections) == 0 {
+	if len(pins) == 0 {
 		if err := os.Remove(s.path); err != nil && !errors.Is(err, os.ErrNotExist) {
 			return fmt.Errorf("remove selection store: %w", err)
 		}
 		return nil
 	}
+	keys := make([]string, 0, len(pins))
+	for key := range pins {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	doc := selectionStoreDoc{Version: selectionStoreVersion, Pins: make([]selectionPin, 0, len(keys))}
+	for _, key := range keys {
+		doc.Pins = append(doc.Pins, pins[key])
+	}
 	data, err := jsonx.MarshalIndent(doc, "", "  ")
 	if err != nil {
 		return fmt.Errorf("encode selection store: %w", err)
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -97,8 +98,8 @@ func TestSelectionStoreRejectsInvalidScope(t *testing.T) {
 	if err := store.Set(context.Background(), SelectionScope{Channel: "lark", ChatID: "c"}, Selection{Mode: "cli"}); err != nil {
 		t.Fatalf("expected chat-level scope to be valid, got error: %v", err)
 	}
-	if err := store.Set(context.Background(), SelectionScope{Channel: "lark", UserID: "u"}, Selection{Mode: "cli"}); err == nil {
-		t.Fatalf("expected error when user_id is set without chat_id")
+	if err := store.Set(context.Background(), SelectionScope{Channel: "lark", UserID: "u"}, Selection{Mode: "cli"}); err != nil {
+		t.Fatalf("expected user-level scope to be valid, got error: %v", err)
 	}
 }
 
@@ -106,7 +107,7 @@ func TestSelectionStoreRejectsUnsupportedVersion(t *testing.T) {
 	t.Parallel()
 	tmp := t.TempDir()
 	path := filepath.Join(tmp, "llm_selection.json")
-	if err := os.WriteFile(path, []byte(`{"version":2,"selections":{"cli":{"mode":"cli","provider":"anthropic","model":"claude-sonnet-4"}}}`), 0o600); err != nil {
+	if err := os.WriteFile(path, []byte(`{"version":3,"selections":{"cli":{"mode":"cli","provider":"anthropic","model":"claude-sonnet-4"}}}`), 0o600); err != nil {
 		t.Fatalf("write store: %v", err)
 	}
 
@@ -267,3 +268,66 @@ func TestSelectionStoreOperationsRespectCanceledContext(t *testing.T) {
 		t.Fatalf("Clear expected context canceled, got %v", err)
 	}
 }
+
+func TestSelectionStoreMigratesVersionOneFile(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "llm_selection.json")
+	legacy := `{"version":1,"selections":{` +
+		`"lark":{"mode":"cli","provider":"codex","model":"global"},` +
+		`"lark:chat=oc_chat":{"mode":"cli","provider":"codex","model":"chat"},` +
+		`"lark:chat=oc_dm:user=ou_user":{"mode":"cli","provider":"codex","model":"legacy-user"}}}`
+	if err := os.WriteFile(path, []byte(legacy), 0o600); err != nil {
+		t.Fatalf("write store: %v", err)
+	}
+	store := NewSelectionStore(path)
+	ctx := context.Background()
+
+	for scope, want := range map[SelectionScope]string{
+		{Channel: "lark"}:                                     "global",
+		{Channel: "lark", ChatID: "oc_chat"}:                  "chat",
+		{Channel: "lark", ChatID: "oc_dm", UserID: "ou_user"}: "legacy-user",
+	} {
+		got, ok, err := store.Get(ctx, scope)
+		if err != nil || !ok || got.Model != want {
+			t.Fatalf("Get(%+v) = %+v ok=%v err=%v, want model %q", scope, got, ok, err, want)
+		}
+	}
+
+	// The next write rewrites the file in the current format, keeping old pins.
+	if err := store.Set(ctx, SelectionScope{Channel: "lark", UserID: "ou_user"}, Selection{Mode: "cli", Provider: "codex", Model: "mine"}); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read store: %v", err)
+	}
+	if !strings.Contains(string(data), `"version": 2`) || strings.Contains(string(data), `"selections"`) {
+		t.Fatalf("expected migrated version 2 document, got %s", data)
+	}
+	if got, ok, _ := store.Get(ctx, SelectionScope{Channel: "lark", ChatID: "oc_chat"}); !ok || got.Model != "chat" {
+		t.Fatalf("expected chat pin to survive migration, got %+v ok=%v", got, ok)
+	}
+}
+
+func TestSelectionStoreMatchesReturnsPinsInScopeOrder(t *testing.T) {
+	t.Parallel()
+	store := NewSelectionStore(filepath.Join(t.TempDir(), "llm_selection.json"))
+	ctx := context.Background()
+
+	user := SelectionScope{Channel: "lark", UserID: "ou_user"}
+	chat := SelectionScope{Channel: "lark", ChatID: "oc_chat"}
+	channel := SelectionScope{Channel: "lark"}
+	_ = store.Set(ctx, channel, Selection{Mode: "cli", Provider: "codex", Model: "global"})
+	_ = store.Set(ctx, user, Selection{Mode: "cli", Provider: "codex", Model: "mine"})
+
+	matches, err := store.Matches(ctx, user, chat, channel)
+	if err != nil {
+		t.Fatalf("Matches: %v", err)
+	}
+	if len(matches) != 2 || matches[0].Scope != user || matches[1].Scope != channel {
+		t.Fatalf("unexpected matches %+v", matches)
+	}
+	if matches[0].PinnedAt.IsZero() {
+		t.Fatal("expected pinned_at to be recorded")
+	}
+}
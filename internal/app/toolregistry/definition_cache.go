@@ -0,0 +1,110 @@
+package toolregistry
+
+import (
+	"fmt"
+	"hash/fnv"
+	"sort"
+
+	"alex/internal/domain/agent/ports"
+	tools "alex/internal/domain/agent/ports/tools"
+	jsonx "alex/internal/shared/json"
+)
+
+// definitionEntry is the cached definition of one tool. The marshaled schema
+// doubles as the hash input, so an entry is only rebuilt when the tool's
+// definition actually changes.
+type definitionEntry struct {
+	def    ports.ToolDefinition
+	schema []byte
+	hash   uint64
+}
+
+func newDefinitionEntry(tool tools.ToolExecutor) (*definitionEntry, error) {
+	def := tool.Definition()
+	schema, err := jsonx.Marshal(def)
+	if err != nil {
+		return nil, fmt.Errorf("marshal tool schema %s: %w", def.Name, err)
+	}
+	hasher := fnv.New64a()
+	_, _ = hasher.Write(schema)
+	return &definitionEntry{def: def, schema: schema, hash: hasher.Sum64()}, nil
+}
+
+// definitionCache holds per-tool definitions and the sorted snapshot served by
+// List. Snapshots are never mutated after publication: any change produces a
+// new slice and bumps version, so callers holding an older snapshot keep a
+// consistent view. All methods require the owning registry's lock.
+type definitionCache struct {
+	entries  map[string]*definitionEntry
+	snapshot []ports.ToolDefinition
+	version  uint64
+}
+
+func newDefinitionCache() definitionCache {
+	return definitionCache{entries: make(map[string]*definitionEntry)}
+}
+
+// invalidate drops the entry for name and the published snapshot.
+func (c *definitionCache) invalidate(name string) {
+	delete(c.entries, name)
+	c.snapshot = nil
+}
+
+// refresh re-reads a tool's definition and reports whether it changed.
+// Unchanged definitions keep their entry and the current snapshot.
+func (c *definitionCache) refresh(name string, tool tools.ToolExecutor) (bool, error) {
+	entry, err := newDefinitionEntry(tool)
+	if err != nil {
+		return false, err
+	}
+	if cached, ok := c.entries[name]; ok && cached.hash == entry.hash {
+		return false, nil
+	}
+	c.entries[name] = entry
+	c.snapshot = nil
+	return true, nil
+}
+
+// build publishes a snapshot for the given tools, constructing entries only
+// for tools that are missing from the cache.
+func (c *definitionCache) build(toolSets ...map[string]tools.ToolExecutor) []ports.ToolDefinition {
+	if c.snapshot != nil {
+		return c.snapshot
+	}
+	size := 0
+	for _, set := range toolSets {
+		size += len(set)
+	}
+	defs := make([]ports.ToolDefinition, 0, size)
+	for _, set := range toolSets {
+		for name, tool := range set {
+			entry, ok := c.entries[name]
+			if !ok {
+				built, err := newDefinitionEntry(tool)
+				if err != nil {
+					// Keep the tool listed; it is simply not cached.
+					defs = append(defs, tool.Definition())
+					continue
+				}
+				c.entries[name] = built
+				entry = built
+			}
+			defs = append(defs, entry.def)
+		}
+	}
+	sort.Slice(defs, func(i, j int) bool {
+		return defs[i].Name < defs[j].Name
+	})
+	c.snapshot = defs
+	c.version++
+	return defs
+}
+
+// schema returns the cached marshaled definition for name.
+func (c *definitionCache) schema(name string) ([]byte, uint64, bool) {
+	entry, ok := c.entries[name]
+	if !ok {
+		return nil, 0, false
+	}
+	return entry.schema, entry.hash, true
+}
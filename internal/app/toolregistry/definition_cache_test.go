@@ -0,0 +1,282 @@
+package toolregistry
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	ports "alex/internal/domain/agent/ports"
+	tools "alex/internal/domain/agent/ports/tools"
+	toolspolicy "alex/internal/infra/tools"
+)
+
+// countingTool counts Definition calls and allows its description to change
+// in place, mimicking alias or deprecation updates.
+type countingTool struct {
+	name        string
+	mu          sync.Mutex
+	description string
+	calls       atomic.Int64
+}
+
+func newCountingTool(name string) *countingTool {
+	return &countingTool{name: name, description: name + " tool"}
+}
+
+func (t *countingTool) Execute(_ context.Context, call ports.ToolCall) (*ports.ToolResult, error) {
+	return &ports.ToolResult{CallID: call.ID, Content: "ok"}, nil
+}
+
+func (t *countingTool) Definition() ports.ToolDefinition {
+	t.calls.Add(1)
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return ports.ToolDefinition{
+		Name:        t.name,
+		Description: t.description,
+		Parameters: ports.ParameterSchema{
+			Type: "object",
+			Properties: map[string]ports.Property{
+				"query": {Type: "string", Description: "search query"},
+				"limit": {Type: "integer", Description: "max results"},
+			},
+			Required: []string{"query"},
+		},
+	}
+}
+
+func (t *countingTool) Metadata() ports.ToolMetadata { return ports.ToolMetadata{Name: t.name} }
+
+func (t *countingTool) setDescription(description string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.description = description
+}
+
+var _ tools.ToolExecutor = (*countingTool)(nil)
+
+func newBareRegistry() *Registry {
+	return &Registry{
+		static:   make(map[string]tools.ToolExecutor),
+		dynamic:  make(map[string]tools.ToolExecutor),
+		defs:     newDefinitionCache(),
+		policy:   toolspolicy.NewToolPolicy(toolspolicy.DefaultToolPolicyConfig()),
+		breakers: newCircuitBreakerStore(normalizeCircuitBreakerConfig(CircuitBreakerConfig{})),
+	}
+}
+
+func registerCountingTools(t testing.TB, r *Registry, n int) []*countingTool {
+	t.Helper()
+	created := make([]*countingTool, 0, n)
+	for i := 0; i < n; i++ {
+		tool := newCountingTool(fmt.Sprintf("tool_%02d", i))
+		if err := r.Register(tool); err != nil {
+			t.Fatalf("Register: %v", err)
+		}
+		// Wrapping reads the definition once; count only List-driven builds.
+		tool.calls.Store(0)
+		created = append(created, tool)
+	}
+	return created
+}
+
+func TestRegistryListReusesSnapshot(t *testing.T) {
+	r := newBareRegistry()
+	created := registerCountingTools(t, r, 3)
+
+	first := r.List()
+	second := r.List()
+	if len(first) != 3 || &first[0] != &second[0] {
+		t.Fatal("expected List to return the same snapshot while the tool set is unchanged")
+	}
+	for _, tool := range created {
+		if got := tool.calls.Load(); got != 1 {
+			t.Fatalf("%s: expected one Definition call, got %d", tool.name, got)
+		}
+	}
+}
+
+func TestRegistryRegisterInvalidatesOnlyNewTool(t *testing.T) {
+	r := newBareRegistry()
+	created := registerCountingTools(t, r, 3)
+	before := r.List()
+
+	added := newCountingTool("tool_99")
+	if err := r.Register(added); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	added.calls.Store(0)
+	after := r.List()
+	if len(before) != 3 || len(after) != 4 || after[3].Name != "tool_99" {
+		t.Fatalf("unexpected snapshots: before=%d after=%v", len(before), after)
+	}
+	for _, tool := range created {
+		if got := tool.calls.Load(); got != 1 {
+			t.Fatalf("%s rebuilt after unrelated registration: %d calls", tool.name, got)
+		}
+	}
+	if got := added.calls.Load(); got != 1 {
+		t.Fatalf("expected new tool definition to be built once, got %d", got)
+	}
+
+	if err := r.Unregister("tool_99"); err != nil {
+		t.Fatalf("Unregister: %v", err)
+	}
+	if defs := r.List(); len(defs) != 3 {
+		t.Fatalf("expected 3 tools after unregister, got %d", len(defs))
+	}
+	for _, tool := range created {
+		if got := tool.calls.Load(); got != 1 {
+			t.Fatalf("%s rebuilt after unregister: %d calls", tool.name, got)
+		}
+	}
+}
+
+func TestRegistryRefreshDefinitionUpdatesOnlyChangedTool(t *testing.T) {
+	r := newBareRegistry()
+	created := registerCountingTools(t, r, 3)
+	before := r.List()
+	_, hashBefore, err := r.Schema("tool_01")
+	if err != nil {
+		t.Fatalf("Schema: %v", err)
+	}
+
+	changed, err := r.RefreshDefinition("tool_00")
+	if err != nil || changed {
+		t.Fatalf("RefreshDefinition(unchanged) = %v, %v", changed, err)
+	}
+	if same := r.List(); &same[0] != &before[0] {
+		t.Fatal("unchanged refresh must keep the published snapshot")
+	}
+
+	created[1].setDescription("deprecated: use tool_02")
+	changed, err = r.RefreshDefinition("tool_01")
+	if err != nil || !changed {
+		t.Fatalf("RefreshDefinition(changed) = %v, %v", changed, err)
+	}
+	after := r.List()
+	if after[1].Description != "deprecated: use tool_02" {
+		t.Fatalf("expected updated description, got %q", after[1].Description)
+	}
+	if before[1].Description != "tool_01 tool" {
+		t.Fatal("previously returned snapshot must not be mutated")
+	}
+	if _, hashAfter, _ := r.Schema("tool_01"); hashAfter == hashBefore {
+		t.Fatal("expected schema hash to change with the definition")
+	}
+	if got := created[2].calls.Load(); got != 1 {
+		t.Fatalf("unrelated tool rebuilt: %d calls", got)
+	}
+	if _, err := r.RefreshDefinition("missing"); err == nil {
+		t.Fatal("expected error for unknown tool")
+	}
+}
+
+func TestRegistryConcurrentListSnapshotsAreConsistent(t *testing.T) {
+	r := newBareRegistry()
+	registerCountingTools(t, r, 8)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 16)
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 200; j++ {
+				defs := r.List()
+				if !sort.SliceIsSorted(defs, func(a, b int) bool { return defs[a].Name < defs[b].Name }) {
+					errs <- fmt.Errorf("snapshot not sorted")
+					return
+				}
+				if len(defs) < 8 {
+					errs <- fmt.Errorf("snapshot lost base tools: %d", len(defs))
+					return
+				}
+			}
+		}()
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 50; i++ {
+			name := fmt.Sprintf("dyn_%02d", i)
+			if err := r.Register(newCountingTool(name)); err != nil {
+				errs <- err
+				return
+			}
+			if i%2 == 0 {
+				_ = r.Unregister(name)
+			}
+		}
+	}()
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Fatal(err)
+	}
+	if defs := r.List(); len(defs) != 8+25 {
+		t.Fatalf("expected 33 tools, got %d", len(defs))
+	}
+}
+
+func TestPolicyAwareRegistryCachesFilteredView(t *testing.T) {
+	r := newBareRegistry()
+	registerCountingTools(t, r, 2)
+
+	disabled := false
+	cfg := toolspolicy.DefaultToolPolicyConfig()
+	cfg.Rules = []toolspolicy.PolicyRule{{
+		Name:    "deny-tool-00",
+		Match:   toolspolicy.PolicySelector{Tools: []string{"tool_00"}},
+		Enabled: &disabled,
+	}}
+	wrapped := r.WithPolicy(toolspolicy.NewToolPolicy(cfg), "cli")
+
+	first := wrapped.List()
+	if len(first) != 1 || first[0].Name != "tool_01" {
+		t.Fatalf("unexpected filtered view: %v", first)
+	}
+	if second := wrapped.List(); &second[0] != &first[0] {
+		t.Fatal("expected filtered view to be reused")
+	}
+	if err := r.Register(newCountingTool("tool_02")); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	if defs := wrapped.List(); len(defs) != 2 {
+		t.Fatalf("expected filtered view to pick up registration, got %v", defs)
+	}
+}
+
+func BenchmarkRegistryList(b *testing.B) {
+	r := newBareRegistry()
+	registerCountingTools(b, r, 64)
+
+	b.Run("cached", func(b *testing.B) {
+		r.List()
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			_ = r.List()
+		}
+	})
+	b.Run("after_register", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = r.Register(newCountingTool("bench_dynamic"))
+			_ = r.List()
+			_ = r.Unregister("bench_dynamic")
+		}
+	})
+	b.Run("uncached", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			r.mu.Lock()
+			r.defs = newDefinitionCache()
+			r.mu.Unlock()
+			_ = r.List()
+		}
+	})
+}
@@ -3,6 +3,7 @@ package toolregistry
 import (
 	"fmt"
 	"strings"
+	"sync"
 
 	ports "alex/internal/domain/agent/ports"
 	tools "alex/internal/domain/agent/ports/tools"
@@ -20,6 +21,18 @@ type policyAwareRegistry struct {
 	parent  tools.ToolRegistry
 	policy  tools.ToolPolicy
 	channel string
+
+	// view caches the filtered definitions for the parent snapshot version
+	// it was built from, so repeated List calls skip policy resolution.
+	viewMu      sync.Mutex
+	view        []ports.ToolDefinition
+	viewVersion uint64
+}
+
+// snapshotter is implemented by registries that publish versioned,
+// immutable definition snapshots.
+type snapshotter interface {
+	snapshot() ([]ports.ToolDefinition, uint64)
 }
 
 // WithPolicy replaces the policy wrapper with a new policy/channel.
@@ -53,10 +66,25 @@ func (p *policyAwareRegistry) Get(name string) (tools.ToolExecutor, error) {
 }
 
 func (p *policyAwareRegistry) List() []ports.ToolDefinition {
-	defs := p.parent.List()
 	if p.policy == nil {
-		return defs
+		return p.parent.List()
+	}
+	source, ok := p.parent.(snapshotter)
+	if !ok {
+		return p.filter(p.parent.List())
 	}
+	defs, version := source.snapshot()
+	p.viewMu.Lock()
+	defer p.viewMu.Unlock()
+	if p.view != nil && p.viewVersion == version {
+		return p.view
+	}
+	p.view = p.filter(defs)
+	p.viewVersion = version
+	return p.view
+}
+
+func (p *policyAwareRegistry) filter(defs []ports.ToolDefinition) []ports.ToolDefinition {
 	filtered := make([]ports.ToolDefinition, 0, len(defs))
 	for _, def := range defs {
 		tool, err := p.parent.Get(def.Name)
@@ -99,4 +127,7 @@ func (p *policyAwareRegistry) isAllowed(tool tools.ToolExecutor) bool {
 	return strings.EqualFold(resolved.EnforcementMode, "warn_allow")
 }
 
-var _ tools.ToolRegistry = (*policyAwareRegistry)(nil)
+var (
+	_ tools.ToolRegistry = (*policyAwareRegistry)(nil)
+	_ snapshotter        = (*Registry)(nil)
+)
@@ -4,7 +4,6 @@ import (
 	"alex/internal/shared/utils"
 	"context"
 	"fmt"
-	"sort"
 	"sync"
 
 	"alex/internal/domain/agent/ports"
@@ -19,8 +18,7 @@ type Registry struct {
 	static       map[string]tools.ToolExecutor
 	dynamic      map[string]tools.ToolExecutor
 	mu           sync.RWMutex
-	cachedDefs   []ports.ToolDefinition
-	defsDirty    bool
+	defs         definitionCache
 	policy       tools.ToolPolicy
 	breakers     *circuitBreakerStore
 	degradation  DegradationConfig
@@ -72,7 +70,7 @@ func NewRegistry(config Config) (*Registry, error) {
 	r := &Registry{
 		static:       make(map[string]tools.ToolExecutor),
 		dynamic:      make(map[string]tools.ToolExecutor),
-		defsDirty:    true,
+		defs:         newDefinitionCache(),
 		policy:       policy,
 		breakers:     breakers,
 		degradation:  degradation,
@@ -95,7 +93,7 @@ func (r *Registry) Register(tool tools.ToolExecutor) error {
 	wrapped := wrapTool(tool, r.policy, r.breakers, r.SLACollector)
 	wrapped = r.wrapDegradationLocked(name, wrapped)
 	r.dynamic[name] = wrapped
-	r.defsDirty = true
+	r.defs.invalidate(name)
 	return nil
 }
 
@@ -128,7 +126,7 @@ func wrapTool(tool tools.ToolExecutor, policy tools.ToolPolicy, breakers *circui
 	base := unwrapTool(tool)
 	validated := &validatingExecutor{delegate: base}
 	approval := toolspolicy.NewApprovalExecutor(validated)
-	retry := newRetryExecutor(approval, policy, breakers)
+	retry := newRetryExecutor(approval, policy, breakers, sla)
 	id := &idAwareExecutor{delegate: retry}
 	if sla == nil {
 		return id
@@ -213,34 +211,68 @@ func (r *Registry) WithoutOrchestration() tools.ToolRegistry {
 	return r
 }
 
+// List returns the sorted tool definitions. The returned slice is a shared,
+// read-only snapshot that is rebuilt only after the tool set changes, and then
+// only the changed tools' definitions are regenerated.
 func (r *Registry) List() []ports.ToolDefinition {
+	defs, _ := r.snapshot()
+	return defs
+}
+
+// snapshot returns the current definition snapshot and its version.
+func (r *Registry) snapshot() ([]ports.ToolDefinition, uint64) {
 	r.mu.RLock()
-	if !r.defsDirty && r.cachedDefs != nil {
-		defs := r.cachedDefs
+	if r.defs.snapshot != nil {
+		defs, version := r.defs.snapshot, r.defs.version
 		r.mu.RUnlock()
-		return defs
+		return defs, version
 	}
 	r.mu.RUnlock()
 
 	r.mu.Lock()
 	defer r.mu.Unlock()
-	// Double-check after acquiring write lock.
-	if !r.defsDirty && r.cachedDefs != nil {
-		return r.cachedDefs
+	defs := r.defs.build(r.static, r.dynamic)
+	return defs, r.defs.version
+}
+
+// Schema returns the marshaled definition of a tool together with its content
+// hash. The payload is cached until the tool is re-registered or refreshed.
+func (r *Registry) Schema(name string) ([]byte, uint64, error) {
+	r.mu.RLock()
+	schema, hash, ok := r.defs.schema(name)
+	r.mu.RUnlock()
+	if ok {
+		return schema, hash, nil
 	}
-	defs := make([]ports.ToolDefinition, 0, len(r.static)+len(r.dynamic))
-	for _, tool := range r.static {
-		defs = append(defs, tool.Definition())
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if schema, hash, ok := r.defs.schema(name); ok {
+		return schema, hash, nil
 	}
-	for _, tool := range r.dynamic {
-		defs = append(defs, tool.Definition())
+	tool, ok := r.getRawLocked(name)
+	if !ok {
+		return nil, 0, fmt.Errorf("tool not found: %s", name)
 	}
-	sort.Slice(defs, func(i, j int) bool {
-		return defs[i].Name < defs[j].Name
-	})
-	r.cachedDefs = defs
-	r.defsDirty = false
-	return defs
+	if _, err := r.defs.refresh(name, tool); err != nil {
+		return nil, 0, err
+	}
+	schema, hash, _ = r.defs.schema(name)
+	return schema, hash, nil
+}
+
+// RefreshDefinition re-reads a tool's definition after its schema changed in
+// place (e.g. updated aliases or deprecation notes). Only that tool's cache
+// entry is rebuilt, and the List snapshot is replaced only when the
+// definition actually differs. It reports whether the definition changed.
+func (r *Registry) RefreshDefinition(name string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	tool, ok := r.getRawLocked(name)
+	if !ok {
+		return false, fmt.Errorf("tool not found: %s", name)
+	}
+	return r.defs.refresh(name, tool)
 }
 
 // Close releases managed resources.
@@ -255,7 +287,7 @@ func (r *Registry) Unregister(name string) error {
 		return fmt.Errorf("cannot unregister built-in tool: %s", name)
 	}
 	delete(r.dynamic, name)
-	r.defsDirty = true
+	r.defs.invalidate(name)
 	return nil
 }
 
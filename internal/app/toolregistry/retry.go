@@ -3,6 +3,7 @@ package toolregistry
 import (
 	"alex/internal/shared/utils"
 	"context"
+	"errors"
 	"fmt"
 	"math"
 	"math/rand"
@@ -12,12 +13,30 @@ import (
 	appcontext "alex/internal/app/agent/context"
 	ports "alex/internal/domain/agent/ports"
 	tools "alex/internal/domain/agent/ports/tools"
+	toolspolicy "alex/internal/infra/tools"
 	alexerrors "alex/internal/shared/errors"
 	coreerrors "alex/internal/core/errors"
 )
 
 const retryJitterFactor = 0.25
 
+// Result metadata keys recording automatic retries, so excessive retries
+// surface in traces even though the agent only sees the final outcome.
+// attempts and error_class are set on every result so the journal and the
+// web UI can show how a call ended.
+const (
+	retryAttemptsMetadataKey = "retry_attempts"
+	retryCategoryMetadataKey = "retry_category"
+	attemptsMetadataKey      = "attempts"
+	errorClassMetadataKey    = "error_class"
+)
+
+// Final error classifications recorded under errorClassMetadataKey.
+const (
+	errorClassRetryable = "retryable"
+	errorClassTerminal  = "terminal"
+)
+
 // resolveToolName extracts the best available name from tool metadata, falling
 // back through call name and definition name. Returns "tool" when all are empty.
 func resolveToolName(candidates ...string) string {
@@ -50,6 +69,7 @@ type retryExecutor struct {
 	delegate tools.ToolExecutor
 	policy   tools.ToolPolicy
 	breaker  *alexerrors.CircuitBreaker
+	sla      *toolspolicy.SLACollector
 }
 
 // Unwrap returns the inner executor (implements tools.Unwrappable).
@@ -80,7 +100,7 @@ func (s *circuitBreakerStore) Get(name string) *alexerrors.CircuitBreaker {
 	return s.manager.Get(name)
 }
 
-func newRetryExecutor(delegate tools.ToolExecutor, policy tools.ToolPolicy, breakers *circuitBreakerStore) tools.ToolExecutor {
+func newRetryExecutor(delegate tools.ToolExecutor, policy tools.ToolPolicy, breakers *circuitBreakerStore, sla *toolspolicy.SLACollector) tools.ToolExecutor {
 	if delegate == nil {
 		return delegate
 	}
@@ -93,9 +113,14 @@ func newRetryExecutor(delegate tools.ToolExecutor, policy tools.ToolPolicy, brea
 		delegate: delegate,
 		policy:   policy,
 		breaker:  breaker,
+		sla:      sla,
 	}
 }
 
+// Execute runs the tool, retrying transient failures per the resolved retry
+// policy. Only retriable tools (read-only or marked idempotent) are retried,
+// backoff is tuned per transient error category, and all attempts share the
+// tool's timeout budget.
 func (r *retryExecutor) Execute(ctx context.Context, call ports.ToolCall) (*ports.ToolResult, error) {
 	if r == nil || r.delegate == nil {
 		return &ports.ToolResult{CallID: call.ID, Error: fmt.Errorf("tool executor missing")}, nil
@@ -105,30 +130,58 @@ func (r *retryExecutor) Execute(ctx context.Context, call ports.ToolCall) (*port
 	// Access control (Enabled check) is handled by policyAwareRegistry.
 	resolved := r.resolvePolicy(ctx, call)
 	retryCfg := normalizeRetryConfig(resolved.Retry)
+	meta := r.delegate.Metadata()
+	retriable := meta.Retriable()
+
+	var deadline time.Time
+	if resolved.Timeout > 0 {
+		deadline = time.Now().Add(resolved.Timeout)
+	}
+
 	var lastErr error
 	var lastResult *ports.ToolResult
+	retries := 0
+	category := ""
 
-	for attempt := 0; attempt <= retryCfg.MaxRetries; attempt++ {
+	for {
 		if ctx.Err() != nil {
 			lastErr = ctx.Err()
 			break
 		}
 
-		result, err := r.executeOnce(ctx, call, resolved.Timeout)
+		timeout := resolved.Timeout
+		if !deadline.IsZero() {
+			if timeout = time.Until(deadline); timeout <= 0 {
+				lastErr = context.DeadlineExceeded
+				break
+			}
+		}
+		result, err := r.executeOnce(ctx, call, timeout)
 		if err == nil {
-			return result, nil
+			r.recordRetries(meta, call, category, retries, true)
+			return annotateRetries(result, retries, category, nil), nil
 		}
 		lastResult = result
 		lastErr = err
 
-		if !coreerrors.IsTransient(err) {
+		if !retriable {
 			break
 		}
-		if attempt >= retryCfg.MaxRetries {
+		errCategory := coreerrors.RetryCategory(err)
+		if errCategory == "" {
 			break
 		}
-
-		delay := calculateRetryBackoff(attempt, retryCfg)
+		categoryCfg := normalizeRetryConfig(retryCfg.ForCategory(errCategory))
+		if retries >= categoryCfg.MaxRetries {
+			break
+		}
+		delay := calculateRetryBackoff(retries, categoryCfg)
+		// Do not start an attempt the remaining budget cannot fit.
+		if !deadline.IsZero() && time.Until(deadline) <= delay {
+			break
+		}
+		category = errCategory
+		retries++
 		if delay <= 0 {
 			continue
 		}
@@ -137,21 +190,52 @@ func (r *retryExecutor) Execute(ctx context.Context, call ports.ToolCall) (*port
 		case <-timer.C:
 		case <-ctx.Done():
 			timer.Stop()
-			lastErr = ctx.Err()
-			attempt = retryCfg.MaxRetries
 		}
 	}
 
+	r.recordRetries(meta, call, category, retries, false)
 	if lastErr == nil {
 		lastErr = fmt.Errorf("tool execution failed")
 	}
 	if lastResult == nil {
-		return &ports.ToolResult{CallID: call.ID, Error: lastErr}, nil
+		lastResult = &ports.ToolResult{CallID: call.ID}
 	}
 	if lastResult.Error == nil {
 		lastResult.Error = lastErr
 	}
-	return lastResult, nil
+	return annotateRetries(lastResult, retries, category, lastErr), nil
+}
+
+func (r *retryExecutor) recordRetries(meta ports.ToolMetadata, call ports.ToolCall, category string, retries int, recovered bool) {
+	if retries == 0 {
+		return
+	}
+	r.sla.RecordRetries(resolveToolName(meta.Name, call.Name), category, retries, recovered)
+}
+
+// annotateRetries records the attempt count on result and, when finalErr is
+// set, whether it was retryable (a transient category) or terminal.
+func annotateRetries(result *ports.ToolResult, retries int, category string, finalErr error) *ports.ToolResult {
+	if result == nil {
+		return result
+	}
+	if result.Metadata == nil {
+		result.Metadata = make(map[string]any, 4)
+	}
+	result.Metadata[attemptsMetadataKey] = retries + 1
+	if retries > 0 {
+		result.Metadata[retryAttemptsMetadataKey] = retries
+		result.Metadata[retryCategoryMetadataKey] = category
+	}
+	if finalErr != nil {
+		if errCategory := coreerrors.RetryCategory(finalErr); errCategory != "" {
+			result.Metadata[errorClassMetadataKey] = errorClassRetryable
+			result.Metadata[retryCategoryMetadataKey] = errCategory
+		} else {
+			result.Metadata[errorClassMetadataKey] = errorClassTerminal
+		}
+	}
+	return result
 }
 
 func (r *retryExecutor) executeOnce(ctx context.Context, call ports.ToolCall, timeout time.Duration) (*ports.ToolResult, error) {
@@ -172,7 +256,7 @@ func (r *retryExecutor) executeOnce(ctx context.Context, call ports.ToolCall, ti
 	// tool output that the LLM should see and adapt to — they must NOT
 	// count toward the breaker failure threshold.
 	exec := func(inner context.Context) error {
-		res, err := r.delegate.Execute(inner, call)
+		res, err := r.executeBounded(inner, call, timeout)
 		result = res
 		return err // only infra errors reach the breaker
 	}
@@ -198,6 +282,38 @@ func (r *retryExecutor) executeOnce(ctx context.Context, call ports.ToolCall, ti
 	return result, nil
 }
 
+type toolOutcome struct {
+	result *ports.ToolResult
+	err    error
+}
+
+// executeBounded runs the delegate in its own goroutine so a tool that
+// ignores context cancellation cannot hold the iteration past its timeout.
+// The channel is buffered, so the goroutine exits as soon as the tool
+// returns even when nobody is waiting any more.
+func (r *retryExecutor) executeBounded(ctx context.Context, call ports.ToolCall, timeout time.Duration) (*ports.ToolResult, error) {
+	done := make(chan toolOutcome, 1)
+	go func() {
+		defer func() {
+			if p := recover(); p != nil {
+				done <- toolOutcome{err: fmt.Errorf("tool %s panicked: %v", call.Name, p)}
+			}
+		}()
+		res, err := r.delegate.Execute(ctx, call)
+		done <- toolOutcome{result: res, err: err}
+	}()
+
+	select {
+	case outcome := <-done:
+		return outcome.result, outcome.err
+	case <-ctx.Done():
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return nil, fmt.Errorf("tool %s timed out after %s: %w", call.Name, timeout, context.DeadlineExceeded)
+		}
+		return nil, ctx.Err()
+	}
+}
+
 func (r *retryExecutor) resolvePolicy(ctx context.Context, call ports.ToolCall) tools.ResolvedPolicy {
 	if r.policy == nil {
 		return tools.ResolvedPolicy{Enabled: true}
@@ -212,6 +328,8 @@ func (r *retryExecutor) resolvePolicy(ctx context.Context, call ports.ToolCall)
 		Dangerous:   meta.Dangerous,
 		Channel:     channel,
 		SafetyLevel: meta.EffectiveSafetyLevel(),
+		Timeout:     meta.Timeout,
+		MaxRetries:  meta.MaxRetries,
 	})
 }
 
@@ -15,12 +15,17 @@ import (
 )
 
 type retryStubTool struct {
-	attempts  int
-	failUntil int
+	attempts    int
+	failUntil   int
+	safetyLevel int
+	delay       time.Duration
 }
 
 func (t *retryStubTool) Execute(_ context.Context, call ports.ToolCall) (*ports.ToolResult, error) {
 	t.attempts++
+	if t.delay > 0 {
+		time.Sleep(t.delay)
+	}
 	if t.attempts <= t.failUntil {
 		return &ports.ToolResult{
 			CallID: call.ID,
@@ -35,7 +40,7 @@ func (t *retryStubTool) Definition() ports.ToolDefinition {
 }
 
 func (t *retryStubTool) Metadata() ports.ToolMetadata {
-	return ports.ToolMetadata{Name: "retry_tool"}
+	return ports.ToolMetadata{Name: "retry_tool", SafetyLevel: t.safetyLevel}
 }
 
 // infraFailTool returns Go-level errors (infrastructure failures) that
@@ -121,7 +126,7 @@ func TestRetryExecutorRetriesTransientErrors(t *testing.T) {
 		SuccessThreshold: 1,
 		Timeout:          time.Minute,
 	})
-	executor := newRetryExecutor(tool, toolspolicy.NewToolPolicy(policyCfg), breakers)
+	executor := newRetryExecutor(tool, toolspolicy.NewToolPolicy(policyCfg), breakers, nil)
 
 	result, err := executor.Execute(context.Background(), ports.ToolCall{ID: "call-1", Name: "retry_tool"})
 	if err != nil {
@@ -133,6 +138,69 @@ func TestRetryExecutorRetriesTransientErrors(t *testing.T) {
 	if tool.attempts != 3 {
 		t.Fatalf("expected 3 attempts, got %d", tool.attempts)
 	}
+	if got := result.Metadata[retryAttemptsMetadataKey]; got != 2 {
+		t.Fatalf("expected retry_attempts=2 in metadata, got %v", got)
+	}
+	if got := result.Metadata[retryCategoryMetadataKey]; got != alexerrors.RetryCategoryOther {
+		t.Fatalf("expected retry_category=%q, got %v", alexerrors.RetryCategoryOther, got)
+	}
+}
+
+func TestRetryExecutorSkipsMutatingTools(t *testing.T) {
+	tool := &retryStubTool{failUntil: 1, safetyLevel: ports.SafetyLevelReversible}
+	policyCfg := toolspolicy.ToolPolicyConfig{
+		Retry: toolspolicy.ToolRetryConfig{
+			MaxRetries:     3,
+			InitialBackoff: time.Millisecond,
+			MaxBackoff:     time.Millisecond,
+			BackoffFactor:  1,
+		},
+	}
+	executor := newRetryExecutor(tool, toolspolicy.NewToolPolicy(policyCfg), nil, nil)
+
+	result, err := executor.Execute(context.Background(), ports.ToolCall{ID: "call-m", Name: "retry_tool"})
+	if err != nil {
+		t.Fatalf("Execute returned error: %v", err)
+	}
+	if result == nil || result.Error == nil {
+		t.Fatalf("expected transient error to surface, got %+v", result)
+	}
+	if tool.attempts != 1 {
+		t.Fatalf("expected mutating tool to run once, got %d attempts", tool.attempts)
+	}
+	if _, ok := result.Metadata[retryAttemptsMetadataKey]; ok {
+		t.Fatal("expected no retry metadata when no retry happened")
+	}
+}
+
+func TestRetryExecutorStopsWithinTimeoutBudget(t *testing.T) {
+	tool := &retryStubTool{failUntil: 100, delay: 10 * time.Millisecond}
+	policyCfg := toolspolicy.ToolPolicyConfig{
+		Timeout: toolspolicy.ToolTimeoutConfig{Default: 60 * time.Millisecond},
+		Retry: toolspolicy.ToolRetryConfig{
+			MaxRetries:     50,
+			InitialBackoff: 10 * time.Millisecond,
+			MaxBackoff:     10 * time.Millisecond,
+			BackoffFactor:  1,
+		},
+	}
+	executor := newRetryExecutor(tool, toolspolicy.NewToolPolicy(policyCfg), nil, nil)
+
+	start := time.Now()
+	result, err := executor.Execute(context.Background(), ports.ToolCall{ID: "call-b", Name: "retry_tool"})
+	elapsed := time.Since(start)
+	if err != nil {
+		t.Fatalf("Execute returned error: %v", err)
+	}
+	if result == nil || result.Error == nil {
+		t.Fatalf("expected error result once budget is spent, got %+v", result)
+	}
+	if tool.attempts >= 10 {
+		t.Fatalf("expected retries to stop at the timeout budget, got %d attempts", tool.attempts)
+	}
+	if elapsed > 200*time.Millisecond {
+		t.Fatalf("expected retries bounded by the 60ms budget, took %v", elapsed)
+	}
 }
 
 func TestRetryExecutorCircuitBreakerStopsAfterOpen(t *testing.T) {
@@ -151,7 +219,7 @@ func TestRetryExecutorCircuitBreakerStopsAfterOpen(t *testing.T) {
 		SuccessThreshold: 1,
 		Timeout:          time.Minute,
 	})
-	executor := newRetryExecutor(tool, toolspolicy.NewToolPolicy(policyCfg), breakers)
+	executor := newRetryExecutor(tool, toolspolicy.NewToolPolicy(policyCfg), breakers, nil)
 
 	result, err := executor.Execute(context.Background(), ports.ToolCall{ID: "call-2", Name: "infra_fail_tool"})
 	if err != nil {
@@ -183,7 +251,7 @@ func TestRetryExecutorToolResultErrorDoesNotTripBreaker(t *testing.T) {
 		SuccessThreshold: 1,
 		Timeout:          time.Minute,
 	})
-	executor := newRetryExecutor(tool, toolspolicy.NewToolPolicy(policyCfg), breakers)
+	executor := newRetryExecutor(tool, toolspolicy.NewToolPolicy(policyCfg), breakers, nil)
 
 	// Call 10 times — all return ToolResult.Error but no Go error.
 	for i := 0; i < 10; i++ {
@@ -220,7 +288,7 @@ func TestRetryExecutorAppliesTimeout(t *testing.T) {
 		SuccessThreshold: 1,
 		Timeout:          time.Minute,
 	})
-	executor := newRetryExecutor(tool, toolspolicy.NewToolPolicy(policyCfg), breakers)
+	executor := newRetryExecutor(tool, toolspolicy.NewToolPolicy(policyCfg), breakers, nil)
 
 	result, err := executor.Execute(context.Background(), ports.ToolCall{ID: "call-3", Name: "timeout_tool"})
 	if err != nil {
@@ -234,6 +302,119 @@ func TestRetryExecutorAppliesTimeout(t *testing.T) {
 	}
 }
 
+// hungTool ignores context cancellation and blocks until released.
+type hungTool struct {
+	meta     ports.ToolMetadata
+	release  chan struct{}
+	finished chan struct{}
+}
+
+func (t *hungTool) Execute(_ context.Context, call ports.ToolCall) (*ports.ToolResult, error) {
+	defer close(t.finished)
+	<-t.release
+	return &ports.ToolResult{CallID: call.ID, Content: "late"}, nil
+}
+
+func (t *hungTool) Definition() ports.ToolDefinition {
+	return ports.ToolDefinition{Name: t.meta.Name}
+}
+
+func (t *hungTool) Metadata() ports.ToolMetadata { return t.meta }
+
+func TestRetryExecutorReturnsWhenToolIgnoresTimeout(t *testing.T) {
+	tool := &hungTool{
+		// Metadata timeout overrides the one-minute policy default.
+		meta:     ports.ToolMetadata{Name: "web_fetch", Timeout: 30 * time.Millisecond},
+		release:  make(chan struct{}),
+		finished: make(chan struct{}),
+	}
+	policyCfg := toolspolicy.ToolPolicyConfig{
+		Timeout: toolspolicy.ToolTimeoutConfig{Default: time.Minute},
+	}
+	executor := newRetryExecutor(tool, toolspolicy.NewToolPolicy(policyCfg), nil, nil)
+
+	start := time.Now()
+	result, err := executor.Execute(context.Background(), ports.ToolCall{ID: "call-h", Name: "web_fetch"})
+	elapsed := time.Since(start)
+	if err != nil {
+		t.Fatalf("Execute returned error: %v", err)
+	}
+	if elapsed > time.Second {
+		t.Fatalf("expected hung tool to be abandoned at its 30ms timeout, took %v", elapsed)
+	}
+	if result == nil || !errors.Is(result.Error, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline error, got %+v", result)
+	}
+	if got := result.Metadata[attemptsMetadataKey]; got != 1 {
+		t.Fatalf("expected attempts=1, got %v", got)
+	}
+	if got := result.Metadata[errorClassMetadataKey]; got != errorClassRetryable {
+		t.Fatalf("expected error_class=%q, got %v", errorClassRetryable, got)
+	}
+
+	close(tool.release)
+	select {
+	case <-tool.finished:
+	case <-time.After(time.Second):
+		t.Fatal("expected tool goroutine to exit once the tool returned")
+	}
+}
+
+func TestRetryExecutorAnnotatesAttemptsAndErrorClass(t *testing.T) {
+	policyCfg := toolspolicy.ToolPolicyConfig{
+		Retry: toolspolicy.ToolRetryConfig{
+			MaxRetries:     3,
+			InitialBackoff: time.Millisecond,
+			MaxBackoff:     time.Millisecond,
+			BackoffFactor:  1,
+		},
+	}
+	policy := toolspolicy.NewToolPolicy(policyCfg)
+
+	ok, err := newRetryExecutor(&timeoutProbeTool{}, policy, nil, nil).Execute(context.Background(), ports.ToolCall{ID: "call-ok", Name: "timeout_tool"})
+	if err != nil {
+		t.Fatalf("Execute returned error: %v", err)
+	}
+	if got := ok.Metadata[attemptsMetadataKey]; got != 1 {
+		t.Fatalf("expected attempts=1 on success, got %v", got)
+	}
+	if _, set := ok.Metadata[errorClassMetadataKey]; set {
+		t.Fatal("expected no error_class on success")
+	}
+
+	terminal, err := newRetryExecutor(&appFailTool{failAll: true}, policy, nil, nil).Execute(context.Background(), ports.ToolCall{ID: "call-t", Name: "app_fail_tool"})
+	if err != nil {
+		t.Fatalf("Execute returned error: %v", err)
+	}
+	if got := terminal.Metadata[errorClassMetadataKey]; got != errorClassTerminal {
+		t.Fatalf("expected error_class=%q, got %v", errorClassTerminal, got)
+	}
+
+	// Metadata caps retries below the policy's three.
+	one := 1
+	stub := &retryStubTool{failUntil: 10}
+	limited := &safetyLevelTool{meta: ports.ToolMetadata{Name: "retry_tool", MaxRetries: &one}}
+	executor := &retryExecutor{delegate: stub, policy: policy}
+	if resolved := executor.resolvePolicy(context.Background(), ports.ToolCall{Name: "retry_tool"}); resolved.Retry.MaxRetries != 3 {
+		t.Fatalf("expected policy default of 3 retries without override, got %d", resolved.Retry.MaxRetries)
+	}
+	executor = &retryExecutor{delegate: limited, policy: policy}
+	if resolved := executor.resolvePolicy(context.Background(), ports.ToolCall{Name: "retry_tool"}); resolved.Retry.MaxRetries != 1 {
+		t.Fatalf("expected metadata override of 1 retry, got %d", resolved.Retry.MaxRetries)
+	}
+
+	failed, err := newRetryExecutor(stub, policy, nil, nil).Execute(context.Background(), ports.ToolCall{ID: "call-r", Name: "retry_tool"})
+	if err != nil {
+		t.Fatalf("Execute returned error: %v", err)
+	}
+	if got := failed.Metadata[attemptsMetadataKey]; got != 4 {
+		t.Fatalf("expected attempts=4 after 3 retries, got %v", got)
+	}
+	if got := failed.Metadata[errorClassMetadataKey]; got != errorClassRetryable {
+		t.Fatalf("expected error_class=%q, got %v", errorClassRetryable, got)
+	}
+}
+
 // safetyLevelTool is a stub with explicit SafetyLevel for policy tests.
 type safetyLevelTool struct {
 	meta ports.ToolMetadata
@@ -259,7 +440,7 @@ func TestRetryExecutorPropagatesSafetyLevelToPolicy(t *testing.T) {
 	// Override global retry to verify the rule overrides it
 	policyCfg.Retry.MaxRetries = 5
 
-	executor := newRetryExecutor(tool, toolspolicy.NewToolPolicy(policyCfg), nil)
+	executor := newRetryExecutor(tool, toolspolicy.NewToolPolicy(policyCfg), nil, nil)
 	re := executor.(*retryExecutor)
 	resolved := re.resolvePolicy(context.Background(), ports.ToolCall{ID: "c1", Name: "dangerous_delete"})
 
@@ -281,7 +462,7 @@ func TestRetryExecutorSafetyLevelFallsBackFromDangerous(t *testing.T) {
 	policyCfg := toolspolicy.DefaultToolPolicyConfigWithRules()
 	policyCfg.Retry.MaxRetries = 5
 
-	executor := newRetryExecutor(tool, toolspolicy.NewToolPolicy(policyCfg), nil)
+	executor := newRetryExecutor(tool, toolspolicy.NewToolPolicy(policyCfg), nil, nil)
 	re := executor.(*retryExecutor)
 	resolved := re.resolvePolicy(context.Background(), ports.ToolCall{ID: "c2", Name: "risky_write"})
 
@@ -297,3 +478,4 @@ var _ tools.ToolExecutor = (*retryStubTool)(nil)
 var _ tools.ToolExecutor = (*infraFailTool)(nil)
 var _ tools.ToolExecutor = (*appFailTool)(nil)
 var _ tools.ToolExecutor = (*safetyLevelTool)(nil)
+var _ tools.ToolExecutor = (*hungTool)(nil)
@@ -15,3 +15,9 @@ func DefaultWorkingDir() string {
 func WithWorkingDir(ctx context.Context, workingDir string) context.Context {
 	return pathutil.WithWorkingDir(ctx, workingDir)
 }
+
+// WithWorkspace confines file tools in ctx to root, enforcing quotaBytes
+// (0 = unlimited) on writes.
+func WithWorkspace(ctx context.Context, root string, quotaBytes int64) context.Context {
+	return pathutil.WithWorkspace(ctx, pathutil.Workspace{Root: root, QuotaBytes: quotaBytes})
+}
@@ -0,0 +1,59 @@
+package errors
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// Retry categories group transient errors by cause so callers can tune
+// backoff per category (e.g. wait longer after a rate limit).
+const (
+	RetryCategoryRateLimit   = "rate_limit"
+	RetryCategoryTimeout     = "timeout"
+	RetryCategoryUnavailable = "unavailable"
+	RetryCategoryNetwork     = "network"
+	RetryCategoryOther       = "other"
+)
+
+// RetryCategory classifies a transient error by cause. It returns "" for
+// errors that IsTransient rejects.
+func RetryCategory(err error) string {
+	if !IsTransient(err) {
+		return ""
+	}
+
+	statusCode := 0
+	var transientErr *TransientError
+	if errors.As(err, &transientErr) {
+		statusCode = transientErr.StatusCode
+	}
+	if statusCode == 0 {
+		statusCode = extractHTTPStatusCode(err)
+	}
+	switch {
+	case statusCode == http.StatusTooManyRequests:
+		return RetryCategoryRateLimit
+	case statusCode == http.StatusGatewayTimeout:
+		return RetryCategoryTimeout
+	case statusCode >= 500 && statusCode <= 599:
+		return RetryCategoryUnavailable
+	}
+
+	lowerErr := strings.ToLower(err.Error())
+	if strings.Contains(lowerErr, "rate limit") || strings.Contains(lowerErr, "too many requests") {
+		return RetryCategoryRateLimit
+	}
+	var netErr net.Error
+	if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()) ||
+		strings.Contains(lowerErr, "timeout") || strings.Contains(lowerErr, "timed out") ||
+		strings.Contains(lowerErr, "deadline exceeded") {
+		return RetryCategoryTimeout
+	}
+	if isNetworkError(err) || isSyscallError(err) {
+		return RetryCategoryNetwork
+	}
+	return RetryCategoryOther
+}
@@ -371,3 +371,29 @@ func indexString(s, substr string) int {
 	}
 	return -1
 }
+
+func TestRetryCategory(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected string
+	}{
+		{name: "nil error", err: nil, expected: ""},
+		{name: "permanent error", err: errors.New("HTTP 404 not found"), expected: ""},
+		{name: "rate limit status", err: &TransientError{Err: errors.New("slow down"), StatusCode: 429}, expected: RetryCategoryRateLimit},
+		{name: "rate limit message", err: errors.New("status 429: rate limit exceeded"), expected: RetryCategoryRateLimit},
+		{name: "bad gateway", err: errors.New("sandbox returned status 502"), expected: RetryCategoryUnavailable},
+		{name: "gateway timeout", err: errors.New("HTTP 504"), expected: RetryCategoryTimeout},
+		{name: "fetch timeout", err: fmt.Errorf("web_fetch: %w", errors.New("i/o timeout")), expected: RetryCategoryTimeout},
+		{name: "connection reset", err: syscall.ECONNRESET, expected: RetryCategoryNetwork},
+		{name: "explicit transient", err: NewTransientError(errors.New("flaky"), "try again"), expected: RetryCategoryOther},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RetryCategory(tt.err); got != tt.expected {
+				t.Errorf("RetryCategory(%v) = %q, want %q", tt.err, got, tt.expected)
+			}
+		})
+	}
+}
@@ -1,8 +1,8 @@
 package lark
 
 import (
+	"context"
 	"fmt"
-	"sort"
 	"sync"
 	"time"
 
@@ -165,6 +165,27 @@ func (c *AIChatCoordinator) EndSession(chatID string) {
 	}
 }
 
+// RegisterRoutes adds the coordinator's callback routes: a session ends
+// when its chat is disbanded or the bot is removed from it.
+func (c *AIChatCoordinator) RegisterRoutes(router *EventRouter) {
+	endSession := func(_ context.Context, event *RoutedEvent) (any, error) {
+		var payload struct {
+			Event struct {
+				ChatID string `json:"chat_id"`
+			} `json:"event"`
+		}
+		if err := event.Decode(&payload); err != nil {
+			return nil, err
+		}
+		if payload.Event.ChatID != "" {
+			c.EndSession(payload.Event.ChatID)
+		}
+		return nil, nil
+	}
+	router.Handle(eventTypeChatDisbanded, endSession)
+	router.Handle(eventTypeBotDeleted, endSession)
+}
+
 // IsMessageFromParticipantBot checks if a message is from a bot that's part of an active session.
 func (c *AIChatCoordinator) IsMessageFromParticipantBot(chatID, senderID string) bool {
 	c.mu.RLock()
@@ -215,23 +236,24 @@ func (c *AIChatCoordinator) CleanupExpiredSessions(maxAge time.Duration) int {
 	return removed
 }
 
-// extractMentionedBots filters mentions to only include known bot IDs.
+// extractMentionedBots filters mentions to only include known bot IDs, in
+// mention order. Repeated mentions of the same bot count once.
 func (c *AIChatCoordinator) extractMentionedBots(mentions []string) []string {
 	var bots []string
+	seen := make(map[string]bool, len(mentions))
 	for _, mention := range mentions {
-		if c.botIDs[mention] {
+		if c.botIDs[mention] && !seen[mention] {
+			seen[mention] = true
 			bots = append(bots, mention)
 		}
 	}
 	return bots
 }
 
-// orderParticipants determines the order in which bots should respond.
-// Currently uses a simple deterministic order based on ID sorting.
+// orderParticipants determines the order in which bots should respond:
+// mention order, so the first-mentioned bot takes the first turn.
 func (c *AIChatCoordinator) orderParticipants(bots []string, thisBotID string) []string {
-	// Sort for deterministic ordering
 	ordered := make([]string, len(bots))
 	copy(ordered, bots)
-	sort.Strings(ordered)
 	return ordered
 }
@@ -1,6 +1,7 @@
 package lark
 
 import (
+	"strings"
 	"testing"
 	"time"
 
@@ -76,6 +77,21 @@ func TestAIChatCoordinator_DetectAndStartSession(t *testing.T) {
 	}
 }
 
+func TestAIChatCoordinator_FirstMentionedBotTakesTurn(t *testing.T) {
+	coord := NewAIChatCoordinator(logging.OrNop(nil), []string{"bot1", "bot2"})
+	mentions := []string{"bot2", "bot1", "bot2"}
+
+	if participate, wait := coord.DetectAndStartSession("chat_order", "msg_1", "user_1", mentions, "bot2"); !participate || wait {
+		t.Fatalf("expected first-mentioned bot2 to respond now, got participate=%v wait=%v", participate, wait)
+	}
+	if participate, wait := coord.DetectAndStartSession("chat_order", "msg_1", "user_1", mentions, "bot1"); !participate || !wait {
+		t.Fatalf("expected bot1 to wait, got participate=%v wait=%v", participate, wait)
+	}
+	if info, _ := coord.GetSessionInfo("chat_order"); !strings.Contains(info, "participants=[bot2 bot1]") {
+		t.Fatalf("expected mention-ordered participants, got %q", info)
+	}
+}
+
 func TestAIChatCoordinator_TurnTaking(t *testing.T) {
 	logger := logging.OrNop(nil)
 	coord := NewAIChatCoordinator(logger, []string{"bot1", "bot2"})
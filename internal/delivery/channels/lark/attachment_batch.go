@@ -0,0 +1,132 @@
+package lark
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const (
+	defaultInlineImageLimit    = 4
+	defaultPostImageLimit      = 9
+	defaultInlineImageMaxBytes = 1024 * 1024
+	imageBundleFileName        = "images.zip"
+)
+
+// resolvedAttachment is an attachment whose bytes have been fetched and that
+// passed the allowlist and size checks.
+type resolvedAttachment struct {
+	name      string
+	fileName  string
+	mediaType string
+	payload   []byte
+}
+
+// attachmentDeliveryReport collects per-attachment outcomes for the summary
+// message sent after uploads.
+type attachmentDeliveryReport struct {
+	delivered []string
+	failed    []string
+}
+
+func (r *attachmentDeliveryReport) deliver(fileName string, size int, bundle string) {
+	line := fmt.Sprintf("- %s (%s)", fileName, formatAttachmentSize(size))
+	if bundle != "" {
+		line += " → " + bundle
+	}
+	r.delivered = append(r.delivered, line)
+}
+
+func (r *attachmentDeliveryReport) fail(fileName, reason string) {
+	r.failed = append(r.failed, fmt.Sprintf("- %s: %s", fileName, reason))
+}
+
+// summary renders delivered names and sizes, followed by failures.
+func (r *attachmentDeliveryReport) summary() string {
+	var sections []string
+	if len(r.delivered) > 0 {
+		sections = append(sections, "[Attachments]\n"+strings.Join(r.delivered, "\n"))
+	}
+	if len(r.failed) > 0 {
+		sections = append(sections, "[Attachment Failures]\n"+strings.Join(r.failed, "\n"))
+	}
+	return strings.Join(sections, "\n\n")
+}
+
+func (g *Gateway) inlineImageLimit() int {
+	if g.cfg.AutoUploadInlineImageLimit > 0 {
+		return g.cfg.AutoUploadInlineImageLimit
+	}
+	return defaultInlineImageLimit
+}
+
+// postImageLimit caps images embedded in one post message. It never drops
+// below the inline limit.
+func (g *Gateway) postImageLimit() int {
+	limit := g.cfg.AutoUploadPostImageLimit
+	if limit <= 0 {
+		limit = defaultPostImageLimit
+	}
+	if inline := g.inlineImageLimit(); limit < inline {
+		return inline
+	}
+	return limit
+}
+
+func (g *Gateway) inlineImageMaxBytes() int {
+	if g.cfg.AutoUploadInlineImageMaxBytes > 0 {
+		return g.cfg.AutoUploadInlineImageMaxBytes
+	}
+	return defaultInlineImageMaxBytes
+}
+
+// imagePostContent builds a post message with one image per line.
+func imagePostContent(imageKeys []string) string {
+	lines := make([][]map[string]string, 0, len(imageKeys))
+	for _, key := range imageKeys {
+		lines = append(lines, []map[string]string{{"tag": "img", "image_key": key}})
+	}
+	payload, _ := json.Marshal(map[string]any{
+		"zh_cn": map[string]any{"content": lines},
+	})
+	return string(payload)
+}
+
+// zipAttachments bundles items into an in-memory ZIP archive, keeping file
+// names unique.
+func zipAttachments(items []resolvedAttachment) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	used := make(map[string]int, len(items))
+	for _, item := range items {
+		name := item.fileName
+		if n := used[name]; n > 0 {
+			name = fmt.Sprintf("%d_%s", n, name)
+		}
+		used[item.fileName]++
+		w, err := zw.Create(name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := w.Write(item.payload); err != nil {
+			return nil, err
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func formatAttachmentSize(size int) string {
+	switch {
+	case size < 1024:
+		return fmt.Sprintf("%d B", size)
+	case size < 1024*1024:
+		return fmt.Sprintf("%.1f KB", float64(size)/1024)
+	default:
+		return fmt.Sprintf("%.1f MB", float64(size)/(1024*1024))
+	}
+}
@@ -0,0 +1,161 @@
+package lark
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	ports "alex/internal/domain/agent/ports"
+	agent "alex/internal/domain/agent/ports/agent"
+	"alex/internal/shared/logging"
+)
+
+func newAttachmentTestGateway(recorder *RecordingMessenger) *Gateway {
+	return &Gateway{
+		cfg:       Config{AutoUploadFiles: true},
+		messenger: recorder,
+		logger:    logging.OrNop(nil),
+	}
+}
+
+func chartAttachments(count int) map[string]ports.Attachment {
+	attachments := make(map[string]ports.Attachment, count)
+	for i := 0; i < count; i++ {
+		name := fmt.Sprintf("chart%02d.png", i)
+		attachments[name] = ports.Attachment{
+			Name:      name,
+			MediaType: "image/png",
+			Data:      base64.StdEncoding.EncodeToString([]byte("png-" + name)),
+		}
+	}
+	return attachments
+}
+
+func lastReplyText(t *testing.T, recorder *RecordingMessenger) string {
+	t.Helper()
+	replies := recorder.CallsByMethod("ReplyMessage")
+	if len(replies) == 0 {
+		t.Fatal("expected at least one reply")
+	}
+	last := replies[len(replies)-1]
+	if last.MsgType != "text" {
+		t.Fatalf("expected trailing text summary, got %s", last.MsgType)
+	}
+	return extractTextContent(last.Content, nil)
+}
+
+func TestSendAttachments_FewImagesSentInline(t *testing.T) {
+	recorder := NewRecordingMessenger()
+	gw := newAttachmentTestGateway(recorder)
+
+	gw.sendAttachments(context.Background(), "oc_chat", "om_msg", &agent.TaskResult{Attachments: chartAttachments(3)})
+
+	if got := len(recorder.CallsByMethod("UploadImage")); got != 3 {
+		t.Fatalf("expected 3 image uploads, got %d", got)
+	}
+	var images int
+	for _, call := range recorder.CallsByMethod("ReplyMessage") {
+		if call.MsgType == "image" {
+			images++
+		}
+	}
+	if images != 3 {
+		t.Fatalf("expected 3 inline image messages, got %d", images)
+	}
+	summary := lastReplyText(t, recorder)
+	if !strings.HasPrefix(summary, "[Attachments]") || !strings.Contains(summary, "chart02.png (") {
+		t.Fatalf("unexpected summary: %q", summary)
+	}
+}
+
+func TestSendAttachments_ManyImagesEmbeddedInOnePost(t *testing.T) {
+	recorder := NewRecordingMessenger()
+	gw := newAttachmentTestGateway(recorder)
+
+	gw.sendAttachments(context.Background(), "oc_chat", "om_msg", &agent.TaskResult{Attachments: chartAttachments(6)})
+
+	if got := len(recorder.CallsByMethod("UploadImage")); got != 6 {
+		t.Fatalf("expected 6 image uploads, got %d", got)
+	}
+	var posts []MessengerCall
+	for _, call := range recorder.CallsByMethod("ReplyMessage") {
+		switch call.MsgType {
+		case "image":
+			t.Fatal("expected no standalone image messages when batching")
+		case "post":
+			posts = append(posts, call)
+		}
+	}
+	if len(posts) != 1 || strings.Count(posts[0].Content, `"tag":"img"`) != 6 {
+		t.Fatalf("expected one post with 6 images, got %#v", posts)
+	}
+}
+
+func TestSendAttachments_TooManyImagesZipped(t *testing.T) {
+	recorder := NewRecordingMessenger()
+	gw := newAttachmentTestGateway(recorder)
+
+	gw.sendAttachments(context.Background(), "oc_chat", "om_msg", &agent.TaskResult{Attachments: chartAttachments(15)})
+
+	if got := len(recorder.CallsByMethod("UploadImage")); got != 0 {
+		t.Fatalf("expected no image uploads, got %d", got)
+	}
+	uploads := recorder.CallsByMethod("UploadFile")
+	if len(uploads) != 1 || uploads[0].FileName != imageBundleFileName {
+		t.Fatalf("expected a single %s upload, got %#v", imageBundleFileName, uploads)
+	}
+	zr, err := zip.NewReader(bytes.NewReader(uploads[0].Payload), int64(len(uploads[0].Payload)))
+	if err != nil {
+		t.Fatalf("bundle is not a zip: %v", err)
+	}
+	if len(zr.File) != 15 {
+		t.Fatalf("expected 15 files in bundle, got %d", len(zr.File))
+	}
+	if summary := lastReplyText(t, recorder); !strings.Contains(summary, "chart14.png") || !strings.Contains(summary, imageBundleFileName) {
+		t.Fatalf("summary should list bundled images: %q", summary)
+	}
+}
+
+func TestSendAttachments_OversizedImageUploadsAsFile(t *testing.T) {
+	recorder := NewRecordingMessenger()
+	gw := newAttachmentTestGateway(recorder)
+	gw.cfg.AutoUploadInlineImageMaxBytes = 8
+	result := &agent.TaskResult{Attachments: map[string]ports.Attachment{
+		"big.png": {Name: "big.png", MediaType: "image/png", Data: base64.StdEncoding.EncodeToString([]byte("large-image-bytes"))},
+	}}
+
+	gw.sendAttachments(context.Background(), "oc_chat", "om_msg", result)
+
+	if got := len(recorder.CallsByMethod("UploadImage")); got != 0 {
+		t.Fatalf("expected no inline image upload, got %d", got)
+	}
+	if uploads := recorder.CallsByMethod("UploadFile"); len(uploads) != 1 || uploads[0].FileName != "big.png" {
+		t.Fatalf("expected big.png uploaded as file, got %#v", uploads)
+	}
+}
+
+func TestSendAttachments_UploadFailureDoesNotAbortRest(t *testing.T) {
+	recorder := NewRecordingMessenger()
+	recorder.NextError = errors.New("upload boom")
+	gw := newAttachmentTestGateway(recorder)
+	result := &agent.TaskResult{Attachments: chartAttachments(2)}
+	result.Attachments["report.pdf"] = ports.Attachment{Name: "report.pdf", MediaType: "application/pdf", Data: base64.StdEncoding.EncodeToString([]byte("pdf"))}
+
+	gw.sendAttachments(context.Background(), "oc_chat", "om_msg", result)
+
+	if got := len(recorder.CallsByMethod("UploadFile")); got != 1 {
+		t.Fatalf("expected report.pdf upload after image failure, got %d", got)
+	}
+	summary := lastReplyText(t, recorder)
+	if !strings.Contains(summary, "- chart01.png (") || !strings.Contains(summary, "- report.pdf (") {
+		t.Fatalf("summary should list delivered attachments: %q", summary)
+	}
+	if !strings.HasSuffix(summary, "[Attachment Failures]\n- chart00.png: 上传失败") {
+		t.Fatalf("summary should end with failures: %q", summary)
+	}
+}
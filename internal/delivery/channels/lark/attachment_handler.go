@@ -18,6 +18,11 @@ import (
 	"alex/internal/shared/utils"
 )
 
+// sendAttachments uploads the result's attachments after the reply. Images
+// are sent inline when there are few of them, embedded in one post message
+// when there are more, and zipped into a single file beyond that. Oversized
+// images upload as files. A failure on one attachment never blocks the rest;
+// a trailing summary lists what was delivered and what failed.
 func (g *Gateway) sendAttachments(ctx context.Context, chatID, messageID string, result *agent.TaskResult) {
 	if !g.cfg.AutoUploadFiles {
 		return
@@ -35,7 +40,10 @@ func (g *Gateway) sendAttachments(ctx context.Context, chatID, messageID string,
 	ctx = toolports.WithAttachmentContext(ctx, attachments, nil)
 	client := artifactruntime.NewAttachmentHTTPClient(artifactruntime.AttachmentFetchTimeout, "LarkAttachment")
 	maxBytes, allowExts := autoUploadLimits(ctx)
+	inlineMaxBytes := g.inlineImageMaxBytes()
 
+	report := &attachmentDeliveryReport{}
+	var images, files []resolvedAttachment
 	seen := make(map[string]struct{})
 	names := sortedAttachmentNames(attachments)
 	for _, name := range names {
@@ -43,6 +51,7 @@ func (g *Gateway) sendAttachments(ctx context.Context, chatID, messageID string,
 		payload, mediaType, err := artifactruntime.ResolveAttachmentBytes(ctx, "["+name+"]", client)
 		if err != nil {
 			g.logger.Warn("Lark attachment %s resolve failed: %v", name, err)
+			report.fail(name, "无法读取")
 			continue
 		}
 
@@ -62,29 +71,95 @@ func (g *Gateway) sendAttachments(ctx context.Context, chatID, messageID string,
 		}
 		if maxBytes > 0 && len(payload) > maxBytes {
 			g.logger.Warn("Lark attachment %s exceeds max size %d bytes", fileName, maxBytes)
+			report.fail(fileName, fmt.Sprintf("超过大小上限 %s", formatAttachmentSize(maxBytes)))
 			continue
 		}
 
-		target := replyTarget(messageID, true)
+		item := resolvedAttachment{name: name, fileName: fileName, mediaType: mediaType, payload: payload}
+		if isImageAttachment(att, mediaType, name) && len(payload) <= inlineMaxBytes {
+			images = append(images, item)
+		} else {
+			files = append(files, item)
+		}
+	}
+
+	target := replyTarget(messageID, true)
+	g.sendImageAttachments(ctx, chatID, target, images, report)
+	for _, item := range files {
+		g.sendFileAttachment(ctx, chatID, target, item, report)
+	}
+
+	if summary := report.summary(); summary != "" {
+		g.dispatch(ctx, chatID, target, "text", textContent(summary))
+	}
+}
 
-		if isImageAttachment(att, mediaType, name) {
-			imageKey, err := g.uploadImage(ctx, payload)
+// sendImageAttachments delivers images inline, as one post message, or as a
+// ZIP bundle depending on how many there are.
+func (g *Gateway) sendImageAttachments(ctx context.Context, chatID, target string, images []resolvedAttachment, report *attachmentDeliveryReport) {
+	switch {
+	case len(images) == 0:
+		return
+	case len(images) <= g.inlineImageLimit():
+		for _, item := range images {
+			imageKey, err := g.uploadImage(ctx, item.payload)
 			if err != nil {
-				g.logger.Warn("Lark image upload failed (%s): %v", name, err)
+				g.logger.Warn("Lark image upload failed (%s): %v", item.name, err)
+				report.fail(item.fileName, "上传失败")
 				continue
 			}
 			g.dispatch(ctx, chatID, target, "image", imageContent(imageKey))
-			continue
+			report.deliver(item.fileName, len(item.payload), "")
 		}
-
-		fileType := larkFileType(fileTypeForAttachment(fileName, mediaType))
-		fileKey, err := g.uploadFile(ctx, payload, fileName, fileType)
+	case len(images) <= g.postImageLimit():
+		var keys []string
+		for _, item := range images {
+			imageKey, err := g.uploadImage(ctx, item.payload)
+			if err != nil {
+				g.logger.Warn("Lark image upload failed (%s): %v", item.name, err)
+				report.fail(item.fileName, "上传失败")
+				continue
+			}
+			keys = append(keys, imageKey)
+			report.deliver(item.fileName, len(item.payload), "")
+		}
+		if len(keys) > 0 {
+			g.dispatch(ctx, chatID, target, "post", imagePostContent(keys))
+		}
+	default:
+		bundle, err := zipAttachments(images)
 		if err != nil {
-			g.logger.Warn("Lark file upload failed (%s): %v", name, err)
-			continue
+			g.logger.Warn("Lark image bundle failed: %v", err)
+			for _, item := range images {
+				report.fail(item.fileName, "打包失败")
+			}
+			return
+		}
+		fileKey, err := g.uploadFile(ctx, bundle, imageBundleFileName, "stream")
+		if err != nil {
+			g.logger.Warn("Lark image bundle upload failed: %v", err)
+			for _, item := range images {
+				report.fail(item.fileName, "上传失败")
+			}
+			return
 		}
 		g.dispatch(ctx, chatID, target, "file", fileContent(fileKey))
+		for _, item := range images {
+			report.deliver(item.fileName, len(item.payload), imageBundleFileName)
+		}
+	}
+}
+
+func (g *Gateway) sendFileAttachment(ctx context.Context, chatID, target string, item resolvedAttachment, report *attachmentDeliveryReport) {
+	fileType := larkFileType(fileTypeForAttachment(item.fileName, item.mediaType))
+	fileKey, err := g.uploadFile(ctx, item.payload, item.fileName, fileType)
+	if err != nil {
+		g.logger.Warn("Lark file upload failed (%s): %v", item.name, err)
+		report.fail(item.fileName, "上传失败")
+		return
 	}
+	g.dispatch(ctx, chatID, target, "file", fileContent(fileKey))
+	report.deliver(item.fileName, len(item.payload), "")
 }
 
 func autoUploadLimits(ctx context.Context) (int, []string) {
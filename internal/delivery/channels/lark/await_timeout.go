@@ -0,0 +1,181 @@
+package lark
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	agent "alex/internal/domain/agent/ports/agent"
+)
+
+// pendingAwait is a task stopped on await_user_input whose wait is bounded.
+// taskToken identifies the stopped task; any newer task on the slot (a user
+// reply, /new, /stop) invalidates the pending timers.
+type pendingAwait struct {
+	chatID    string
+	chatType  string
+	senderID  string
+	messageID string
+	taskToken uint64
+	timeout   time.Duration
+	prompt    agent.AwaitUserInputPrompt
+}
+
+// policy returns the declared on_timeout fallback, defaulting to
+// abort so an unanswered approval gate never proceeds on its own.
+func (p pendingAwait) policy() string {
+	if agent.IsValidAwaitTimeoutPolicy(p.prompt.OnTimeout) {
+		return p.prompt.OnTimeout
+	}
+	return agent.AwaitTimeoutAbort
+}
+
+// armAwaitTimeout schedules a reminder at half the timeout and a synthesized
+// resume at expiry for a task that just stopped on await_user_input. The
+// request's own timeout wins over Config.AwaitInputTimeoutSeconds.
+func (g *Gateway) armAwaitTimeout(msg *incomingMessage, taskToken uint64, prompt agent.AwaitUserInputPrompt) {
+	if msg == nil || taskToken == 0 {
+		return
+	}
+	timeout := prompt.Timeout
+	if timeout <= 0 {
+		timeout = time.Duration(g.cfg.AwaitInputTimeoutSeconds) * time.Second
+	}
+
+	slot := g.getOrCreateSlot(msg.chatID)
+	slot.mu.Lock()
+	defer slot.mu.Unlock()
+	slot.stopAwaitTimerLocked()
+	if timeout <= 0 {
+		return
+	}
+	pending := pendingAwait{
+		chatID:    msg.chatID,
+		chatType:  msg.chatType,
+		senderID:  msg.senderID,
+		messageID: msg.messageID,
+		taskToken: taskToken,
+		timeout:   timeout,
+		prompt:    prompt,
+	}
+	slot.awaitTimer = time.AfterFunc(timeout/2, func() { g.remindAwaitingUser(slot, pending) })
+	g.logger.Info("Await input timeout armed: chat=%s token=%d timeout=%s on_timeout=%s", msg.chatID, taskToken, timeout, pending.policy())
+}
+
+// stopAwaitTimerLocked cancels a pending reminder or expiry. Must be called
+// with s.mu held.
+func (s *sessionSlot) stopAwaitTimerLocked() {
+	if s.awaitTimer != nil {
+		s.awaitTimer.Stop()
+		s.awaitTimer = nil
+	}
+}
+
+// stillAwaitingLocked reports whether the task behind p is still waiting for
+// the user. Must be called with s.mu held.
+func (s *sessionSlot) stillAwaitingLocked(p pendingAwait) bool {
+	return s.phase == slotAwaitingInput && s.taskToken == p.taskToken
+}
+
+func (g *Gateway) remindAwaitingUser(slot *sessionSlot, p pendingAwait) {
+	slot.mu.Lock()
+	if !slot.stillAwaitingLocked(p) {
+		slot.awaitTimer = nil
+		slot.mu.Unlock()
+		return
+	}
+	slot.awaitTimer = time.AfterFunc(p.timeout-p.timeout/2, func() { g.expireAwaitingInput(slot, p) })
+	slot.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+	g.dispatch(ctx, p.chatID, replyTarget(p.messageID, true), "text", textContent(awaitReminderText(p)))
+}
+
+// expireAwaitingInput resumes the stopped task with a synthesized input that
+// describes the timeout, so the model applies the declared fallback and the
+// slot leaves the awaiting phase through the normal resume path.
+func (g *Gateway) expireAwaitingInput(slot *sessionSlot, p pendingAwait) {
+	slot.mu.Lock()
+	if !slot.stillAwaitingLocked(p) {
+		slot.awaitTimer = nil
+		slot.mu.Unlock()
+		return
+	}
+	slot.awaitTimer = nil
+	slot.pendingOptions = nil
+	slot.mu.Unlock()
+
+	g.logger.Info("Await input timed out: chat=%s token=%d after=%s on_timeout=%s", p.chatID, p.taskToken, p.timeout, p.policy())
+	g.replayUserInput(p.chatID, p.chatType, agent.UserInput{
+		Content:     synthesizedTimeoutInput(p),
+		SenderID:    p.senderID,
+		MessageID:   p.messageID,
+		Synthesized: true,
+	})
+}
+
+// stopAwaitTimers cancels every pending await timeout; used on shutdown.
+func (g *Gateway) stopAwaitTimers() {
+	g.activeSlots.Range(func(_, value any) bool {
+		if slot, ok := value.(*sessionSlot); ok {
+			slot.mu.Lock()
+			slot.stopAwaitTimerLocked()
+			slot.mu.Unlock()
+		}
+		return true
+	})
+}
+
+func awaitReminderText(p pendingAwait) string {
+	var b strings.Builder
+	b.WriteString("提醒：仍在等待你的回复。")
+	if question := strings.TrimSpace(p.prompt.Question); question != "" {
+		b.WriteString("\n")
+		b.WriteString(question)
+	}
+	remaining := formatAwaitDuration(p.timeout - p.timeout/2)
+	switch p.policy() {
+	case agent.AwaitTimeoutProceedDefault:
+		fmt.Fprintf(&b, "\n%s内未回复将按默认答案「%s」继续。", remaining, p.prompt.DefaultAnswer)
+	case agent.AwaitTimeoutRemind:
+		fmt.Fprintf(&b, "\n%s内未回复将暂停该操作并再次提醒。", remaining)
+	default:
+		fmt.Fprintf(&b, "\n%s内未回复将取消该操作。", remaining)
+	}
+	return b.String()
+}
+
+func synthesizedTimeoutInput(p pendingAwait) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "[Auto-generated, not written by the user] No reply within %s", formatAwaitDurationEN(p.timeout))
+	if question := strings.TrimSpace(p.prompt.Question); question != "" {
+		fmt.Fprintf(&b, " to: %q", question)
+	}
+	b.WriteString(".\n")
+	switch p.policy() {
+	case agent.AwaitTimeoutProceedDefault:
+		fmt.Fprintf(&b, "Declared fallback: proceed_default. Continue as if the user answered %q, and tell them you went ahead with the default.", p.prompt.DefaultAnswer)
+	case agent.AwaitTimeoutRemind:
+		b.WriteString("Declared fallback: remind. Do not take the pending action. Ask the user again in one short message and stop.")
+	default:
+		b.WriteString("Declared fallback: abort. Do not take the pending action. Tell the user briefly that the request timed out and was cancelled, and stop.")
+	}
+	return b.String()
+}
+
+// formatAwaitDuration renders d for user-facing Chinese notices.
+func formatAwaitDuration(d time.Duration) string {
+	if d >= time.Minute {
+		return fmt.Sprintf("%d 分钟", int((d+time.Minute-1)/time.Minute))
+	}
+	return fmt.Sprintf("%d 秒", int((d+time.Second-1)/time.Second))
+}
+
+func formatAwaitDurationEN(d time.Duration) string {
+	if d >= time.Minute {
+		return fmt.Sprintf("%d minute(s)", int((d+time.Minute-1)/time.Minute))
+	}
+	return fmt.Sprintf("%d second(s)", int((d+time.Second-1)/time.Second))
+}
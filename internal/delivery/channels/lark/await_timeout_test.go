@@ -0,0 +1,163 @@
+package lark
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"alex/internal/delivery/channels"
+	ports "alex/internal/domain/agent/ports"
+	agent "alex/internal/domain/agent/ports/agent"
+	storage "alex/internal/domain/agent/ports/storage"
+	"alex/internal/shared/logging"
+
+	larkim "github.com/larksuite/oapi-sdk-go/v3/service/im/v1"
+)
+
+// timeoutAwaitExecutor stops the first run on a bounded ask_user request and
+// records the input that resumes the second run.
+type timeoutAwaitExecutor struct {
+	mu       sync.Mutex
+	calls    int
+	metadata map[string]any
+	resumed  []agent.UserInput
+	done     chan struct{}
+}
+
+func (e *timeoutAwaitExecutor) EnsureSession(_ context.Context, sessionID string) (*storage.Session, error) {
+	return &storage.Session{ID: sessionID, Metadata: map[string]string{}}, nil
+}
+
+func (e *timeoutAwaitExecutor) ExecuteTask(ctx context.Context, _ string, _ string, _ agent.EventListener) (*agent.TaskResult, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.calls++
+	if e.calls == 1 {
+		return &agent.TaskResult{
+			StopReason: "await_user_input",
+			Messages: []ports.Message{{
+				Role:        "tool",
+				ToolResults: []ports.ToolResult{{CallID: "call-1", Metadata: e.metadata}},
+			}},
+		}, nil
+	}
+	select {
+	case input := <-agent.UserInputChFromContext(ctx):
+		e.resumed = append(e.resumed, input)
+	default:
+	}
+	close(e.done)
+	return &agent.TaskResult{Answer: "cancelled the deploy"}, nil
+}
+
+func newAwaitTimeoutGateway(executor AgentExecutor, recorder *RecordingMessenger) *Gateway {
+	gw := &Gateway{
+		cfg:       Config{BaseConfig: channels.BaseConfig{SessionPrefix: "lark", AllowDirect: true}, AppID: "test", AppSecret: "secret"},
+		agent:     executor,
+		logger:    logging.OrNop(nil),
+		messenger: recorder,
+		now:       time.Now,
+	}
+	gw.dedup = newEventDedup(nil)
+	return gw
+}
+
+func textEvent(chatID, msgID, text string) *larkim.P2MessageReceiveV1 {
+	openID := "ou_await_timeout"
+	msgType := "text"
+	chatType := "p2p"
+	content := textContent(text)
+	return &larkim.P2MessageReceiveV1{
+		Event: &larkim.P2MessageReceiveV1Data{
+			Message: &larkim.EventMessage{
+				MessageType: &msgType,
+				ChatType:    &chatType,
+				ChatId:      &chatID,
+				MessageId:   &msgID,
+				Content:     &content,
+			},
+			Sender: &larkim.EventSender{SenderId: &larkim.UserId{OpenId: &openID}},
+		},
+	}
+}
+
+func TestAwaitTimeoutRemindsThenResumesWithSynthesizedInput(t *testing.T) {
+	executor := &timeoutAwaitExecutor{
+		metadata: map[string]any{
+			"needs_user_input":      true,
+			"message":               "Approve deploy to prod?",
+			"input_timeout_seconds": 0.2,
+			"on_timeout":            agent.AwaitTimeoutAbort,
+		},
+		done: make(chan struct{}),
+	}
+	recorder := NewRecordingMessenger()
+	gw := newAwaitTimeoutGateway(executor, recorder)
+
+	if err := gw.handleMessage(context.Background(), textEvent("oc_await_timeout", "om_1", "deploy")); err != nil {
+		t.Fatalf("handleMessage: %v", err)
+	}
+	select {
+	case <-executor.done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("task was not resumed after the await timeout")
+	}
+	gw.WaitForTasks()
+
+	executor.mu.Lock()
+	resumed := executor.resumed
+	executor.mu.Unlock()
+	if len(resumed) != 1 || !resumed[0].Synthesized {
+		t.Fatalf("expected one synthesized resume input, got %+v", resumed)
+	}
+	if !strings.Contains(resumed[0].Content, "Declared fallback: abort") || !strings.Contains(resumed[0].Content, "Approve deploy to prod?") {
+		t.Fatalf("synthesized input should describe the timeout and fallback: %q", resumed[0].Content)
+	}
+
+	reminded := false
+	for _, call := range append(recorder.CallsByMethod("ReplyMessage"), recorder.CallsByMethod("SendMessage")...) {
+		if strings.Contains(extractTextContent(call.Content, nil), "提醒：仍在等待你的回复") {
+			reminded = true
+		}
+	}
+	if !reminded {
+		t.Fatal("expected a reminder at half the timeout")
+	}
+
+	slot := gw.getOrCreateSlot("oc_await_timeout")
+	slot.mu.Lock()
+	defer slot.mu.Unlock()
+	if slot.phase != slotIdle || slot.awaitTimer != nil {
+		t.Fatalf("slot should leave awaiting input cleanly, phase=%v timer=%v", slot.phase, slot.awaitTimer)
+	}
+}
+
+func TestAwaitTimeoutIgnoredAfterUserReplies(t *testing.T) {
+	gw := newAwaitTimeoutGateway(&timeoutAwaitExecutor{done: make(chan struct{})}, NewRecordingMessenger())
+	slot := gw.getOrCreateSlot("oc_replied")
+	slot.mu.Lock()
+	slot.phase = slotAwaitingInput
+	slot.taskToken = 3
+	slot.mu.Unlock()
+
+	gw.armAwaitTimeout(&incomingMessage{chatID: "oc_replied", messageID: "om_1"}, 3, agent.AwaitUserInputPrompt{Timeout: time.Hour})
+	slot.mu.Lock()
+	timer := slot.awaitTimer
+	slot.phase = slotRunning // the user replied and a new task took the slot
+	slot.taskToken = 4
+	slot.mu.Unlock()
+	if timer == nil {
+		t.Fatal("expected timeout to be armed")
+	}
+
+	p := pendingAwait{chatID: "oc_replied", taskToken: 3, timeout: time.Hour}
+	gw.remindAwaitingUser(slot, p)
+	gw.expireAwaitingInput(slot, p)
+	slot.mu.Lock()
+	defer slot.mu.Unlock()
+	if slot.awaitTimer != nil || slot.phase != slotRunning {
+		t.Fatalf("stale timeout must not touch the new task: phase=%v timer=%v", slot.phase, slot.awaitTimer)
+	}
+}
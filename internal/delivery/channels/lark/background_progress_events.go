@@ -235,7 +235,11 @@ func (l *backgroundProgressListener) handleCompletion(taskID, status, answer, er
 	}
 	t.mu.Unlock()
 
-	l.flush(t, true)
+	// In digest mode finished tasks are reported by the scheduled digest.
+	digestMode := l.g != nil && l.g.taskDigestEnabledFor(l.chatID)
+	if !digestMode || !isDigestTaskStatus(normalizedStatus) {
+		l.flush(t, true)
+	}
 
 	// Sync final status to TaskStore.
 	finalStatus := normalizedStatus
@@ -276,8 +280,10 @@ func (l *backgroundProgressListener) handleCompletion(taskID, status, answer, er
 	t.stop()
 
 	if shouldClose {
-		l.logger.Info("All %d background tasks completed, generating team summary", completedCount)
-		l.sendTeamCompletionSummary()
+		if !digestMode {
+			l.logger.Info("All %d background tasks completed, generating team summary", completedCount)
+			l.sendTeamCompletionSummary()
+		}
 		l.Close()
 	}
 }
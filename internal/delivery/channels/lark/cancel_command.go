@@ -0,0 +1,146 @@
+package lark
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"alex/internal/shared/utils"
+)
+
+// foregroundTaskID names the chat's foreground run in /tasks and /cancel.
+// It has no TaskStore record; workers use "#N" and background tasks use
+// their store IDs.
+const foregroundTaskID = "current"
+
+// isTaskControlCommand checks whether the message is /tasks, /cancel,
+// /digest or its /task cancel spelling. They must work while a task is
+// running, so they are routed before in-flight input injection.
+func (g *Gateway) isTaskControlCommand(trimmed string) bool {
+	lower := utils.TrimLower(trimmed)
+	for _, cmd := range []string{"/tasks", "/cancel", "/digest", "/task cancel", "/task stop"} {
+		if lower == cmd || strings.HasPrefix(lower, cmd+" ") {
+			return true
+		}
+	}
+	return false
+}
+
+// handleTaskControlCommand processes /tasks, /cancel <task-id> (also spelled
+// /task cancel) and /digest now. Replies are sent verbatim so task IDs stay
+// copyable.
+func (g *Gateway) handleTaskControlCommand(msg *incomingMessage) {
+	if g == nil || msg == nil {
+		return
+	}
+	execCtx := g.buildTaskCommandContext(msg)
+	fields := strings.Fields(strings.TrimSpace(msg.content))
+	if strings.EqualFold(fields[0], "/task") {
+		// /task cancel <id> and /task stop <id> are spellings of /cancel.
+		fields = append([]string{"/cancel"}, fields[2:]...)
+	}
+	var reply string
+	switch strings.ToLower(fields[0]) {
+	case "/digest":
+		g.handleDigestCommand(execCtx, msg, fields[1:])
+		return
+	case "/tasks":
+		reply = g.handleTaskList(execCtx, msg)
+	default:
+		if len(fields) < 2 {
+			reply = "用法: /cancel <task_id>\n\n使用 /tasks 查看任务 ID。"
+		} else {
+			reply = g.handleTaskCancel(execCtx, msg.chatID, fields[1])
+		}
+	}
+	g.dispatch(execCtx, msg.chatID, replyTarget(msg.messageID, true), "text", textContent(reply))
+}
+
+// chatTaskEntry is one row of the /tasks list.
+type chatTaskEntry struct {
+	ID          string
+	Label       string
+	StartedAt   time.Time
+	Description string
+}
+
+// runningSlotTasks lists the chat's in-memory runs: the foreground slot and
+// any conversation-process workers, oldest first.
+func (g *Gateway) runningSlotTasks(chatID string) []chatTaskEntry {
+	var entries []chatTaskEntry
+	collect := func(id string, s *sessionSlot) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if s.phase != slotRunning {
+			return
+		}
+		entries = append(entries, chatTaskEntry{ID: id, Label: "running", StartedAt: s.taskStartTime, Description: s.taskDesc})
+	}
+	if raw, ok := g.activeSlots.Load(chatID); ok {
+		collect(foregroundTaskID, raw.(*sessionSlot))
+	}
+	if raw, ok := g.activeChatSlots.Load(chatID); ok {
+		raw.(*chatSlotMap).forEachSlot(collect)
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].StartedAt.Before(entries[j].StartedAt)
+	})
+	return entries
+}
+
+// cancelForegroundTask cancels the chat's foreground run like /stop does,
+// including its queued follow-ups.
+func (g *Gateway) cancelForegroundTask(chatID string) string {
+	raw, ok := g.activeSlots.Load(chatID)
+	if !ok {
+		return "当前没有正在运行的任务，无需取消。"
+	}
+	slot := raw.(*sessionSlot)
+	slot.mu.Lock()
+	cancel := slot.taskCancel
+	desc := slot.taskDesc
+	if slot.phase != slotRunning || cancel == nil {
+		slot.mu.Unlock()
+		return "当前没有正在运行的任务，无需取消。"
+	}
+	slot.intentionalCancelToken = slot.taskToken
+	slot.followUps = nil
+	slot.lastTouched = g.currentTime()
+	slot.mu.Unlock()
+
+	cancel()
+	return fmt.Sprintf("已取消任务: %s (%s)", foregroundTaskID, truncateForLark(desc, 60))
+}
+
+// cancelWorkerTask stops a conversation-process worker and, when the ID is
+// also tracked in TaskStore, marks that record cancelled.
+func (g *Gateway) cancelWorkerTask(ctx context.Context, chatID, taskID string) string {
+	raw, ok := g.activeChatSlots.Load(chatID)
+	if !ok {
+		return fmt.Sprintf("未找到任务: %s", taskID)
+	}
+	slotMap := raw.(*chatSlotMap)
+	slotMap.mu.Lock()
+	slot, ok := slotMap.slots[taskID]
+	slotMap.mu.Unlock()
+	if !ok {
+		return fmt.Sprintf("未找到任务: %s", taskID)
+	}
+	slot.mu.Lock()
+	desc := slot.taskDesc
+	slot.mu.Unlock()
+
+	if !slotMap.stopByTaskID(taskID) {
+		return fmt.Sprintf("任务 %s 已经结束，无需取消。", taskID)
+	}
+	if g.taskStore != nil {
+		if task, found, err := g.taskStore.GetTask(ctx, taskID); err == nil && found && !isTerminalTaskStatus(task.Status) {
+			if err := g.taskStore.UpdateStatus(ctx, taskID, taskStatusCancelled, WithErrorText("user cancelled")); err != nil {
+				g.logger.Warn("Cancel %s: TaskStore update failed: %v", taskID, err)
+			}
+		}
+	}
+	return fmt.Sprintf("已取消任务: %s (%s)", taskID, truncateForLark(desc, 60))
+}
@@ -0,0 +1,169 @@
+package lark
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"alex/internal/delivery/channels"
+)
+
+func TestIsTaskControlCommand(t *testing.T) {
+	g := &Gateway{}
+	tests := []struct {
+		input string
+		want  bool
+	}{
+		{"/tasks", true},
+		{"/TASKS", true},
+		{"/cancel", true},
+		{"/cancel #1", true},
+		{"/cancel bg-abc", true},
+		{"/digest now", true},
+		{"/task cancel abc", true},
+		{"/task stop #1", true},
+		{"/task list", false},
+		{"/cancelled", false},
+		{"/tasksfoo", false},
+		{"cancel it", false},
+	}
+	for _, tt := range tests {
+		if got := g.isTaskControlCommand(tt.input); got != tt.want {
+			t.Errorf("isTaskControlCommand(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+}
+
+func lastReplyContent(t *testing.T, rec *RecordingMessenger) string {
+	t.Helper()
+	replies := rec.CallsByMethod("ReplyMessage")
+	if len(replies) == 0 {
+		t.Fatal("expected a reply")
+	}
+	return replies[len(replies)-1].Content
+}
+
+func TestTasksAndCancelWhileForegroundTaskRuns(t *testing.T) {
+	rec := NewRecordingMessenger()
+	executor := &cancelOnContextExecutor{started: make(chan struct{})}
+	gw := newTestGatewayWithMessenger(executor, rec, channels.BaseConfig{SessionPrefix: "lark", AllowDirect: true})
+	chatID := "oc_cancel_fg"
+	ctx := context.Background()
+
+	go func() {
+		if err := gw.InjectMessage(ctx, chatID, "p2p", "ou_user", "om_run", "refactor the parser"); err != nil {
+			t.Errorf("InjectMessage failed: %v", err)
+		}
+	}()
+	<-executor.started
+
+	if err := gw.InjectMessage(ctx, chatID, "p2p", "ou_user", "om_tasks", "/tasks"); err != nil {
+		t.Fatalf("InjectMessage failed: %v", err)
+	}
+	list := lastReplyContent(t, rec)
+	if !strings.Contains(list, "[current] running") || !strings.Contains(list, "refactor the parser") {
+		t.Fatalf("expected foreground task in list, got %q", list)
+	}
+
+	if err := gw.InjectMessage(ctx, chatID, "p2p", "ou_user", "om_cancel", "/cancel current"); err != nil {
+		t.Fatalf("InjectMessage failed: %v", err)
+	}
+	if reply := lastReplyContent(t, rec); !strings.Contains(reply, "已取消任务: current") {
+		t.Fatalf("expected cancel confirmation, got %q", reply)
+	}
+	gw.WaitForTasks()
+
+	executor.mu.Lock()
+	calls := executor.callCount
+	executor.mu.Unlock()
+	if calls != 1 {
+		t.Fatalf("expected commands not to reach the executor, got %d calls", calls)
+	}
+
+	// /task cancel is the same command as /cancel.
+	if err := gw.InjectMessage(ctx, chatID, "p2p", "ou_user", "om_cancel_again", "/task cancel current"); err != nil {
+		t.Fatalf("InjectMessage failed: %v", err)
+	}
+	if reply := lastReplyContent(t, rec); !strings.Contains(reply, "无需取消") {
+		t.Fatalf("expected graceful reply for finished task, got %q", reply)
+	}
+}
+
+func TestCancelBackgroundTaskUpdatesStore(t *testing.T) {
+	rec := NewRecordingMessenger()
+	gw := newTestGatewayWithMessenger(&stubExecutor{}, rec, channels.BaseConfig{SessionPrefix: "lark", AllowDirect: true})
+	store := NewTaskMemoryStore(time.Hour, 10)
+	gw.taskStore = store
+	chatID := "oc_cancel_bg"
+	ctx := context.Background()
+
+	for _, rec := range []TaskRecord{
+		{TaskID: "bg-running", ChatID: chatID, AgentType: "codex", Status: taskStatusRunning, Description: "port the cache", CreatedAt: time.Now()},
+		{TaskID: "bg-done", ChatID: chatID, AgentType: "codex", Status: taskStatusCompleted, Description: "old work", CreatedAt: time.Now()},
+	} {
+		if err := store.SaveTask(ctx, rec); err != nil {
+			t.Fatalf("SaveTask failed: %v", err)
+		}
+	}
+
+	if err := gw.InjectMessage(ctx, chatID, "p2p", "ou_user", "om_tasks", "/tasks"); err != nil {
+		t.Fatalf("InjectMessage failed: %v", err)
+	}
+	if list := lastReplyContent(t, rec); !strings.Contains(list, "[bg-running] codex · running") || strings.Contains(list, "bg-done") {
+		t.Fatalf("expected only the active background task, got %q", list)
+	}
+
+	if err := gw.InjectMessage(ctx, chatID, "p2p", "ou_user", "om_cancel", "/cancel bg-running"); err != nil {
+		t.Fatalf("InjectMessage failed: %v", err)
+	}
+	if reply := lastReplyContent(t, rec); !strings.Contains(reply, "已取消任务: bg-running") {
+		t.Fatalf("expected cancel confirmation, got %q", reply)
+	}
+	task, _, err := store.GetTask(ctx, "bg-running")
+	if err != nil || task.Status != taskStatusCancelled {
+		t.Fatalf("expected cancelled record, got %+v (%v)", task, err)
+	}
+
+	if err := gw.InjectMessage(ctx, chatID, "p2p", "ou_user", "om_cancel_done", "/cancel bg-done"); err != nil {
+		t.Fatalf("InjectMessage failed: %v", err)
+	}
+	if reply := lastReplyContent(t, rec); !strings.Contains(reply, "无需取消") {
+		t.Fatalf("expected graceful reply for finished task, got %q", reply)
+	}
+}
+
+func TestCancelWithoutTaskIDShowsUsage(t *testing.T) {
+	rec := NewRecordingMessenger()
+	gw := newTestGatewayWithMessenger(&stubExecutor{}, rec, channels.BaseConfig{SessionPrefix: "lark", AllowDirect: true})
+	if err := gw.InjectMessage(context.Background(), "oc_cancel_usage", "p2p", "ou_user", "om_cancel", "/cancel"); err != nil {
+		t.Fatalf("InjectMessage failed: %v", err)
+	}
+	if reply := lastReplyContent(t, rec); !strings.Contains(reply, "用法: /cancel") {
+		t.Fatalf("expected usage, got %q", reply)
+	}
+}
+
+func TestCancelRejectsTaskOfAnotherChat(t *testing.T) {
+	rec := NewRecordingMessenger()
+	gw := newTestGatewayWithMessenger(&stubExecutor{}, rec, channels.BaseConfig{SessionPrefix: "lark", AllowDirect: true})
+	store := NewTaskMemoryStore(time.Hour, 10)
+	gw.taskStore = store
+	ctx := context.Background()
+	if err := store.SaveTask(ctx, TaskRecord{TaskID: "bg-other", ChatID: "oc_owner", AgentType: "codex", Status: taskStatusRunning, Description: "secret work", CreatedAt: time.Now()}); err != nil {
+		t.Fatalf("SaveTask failed: %v", err)
+	}
+
+	for _, command := range []string{"/cancel bg-other", "/task cancel bg-other"} {
+		if err := gw.InjectMessage(ctx, "oc_intruder", "p2p", "ou_user", "om_cancel", command); err != nil {
+			t.Fatalf("InjectMessage failed: %v", err)
+		}
+		if reply := lastReplyContent(t, rec); !strings.Contains(reply, "未找到任务: bg-other") {
+			t.Fatalf("%s: expected not found, got %q", command, reply)
+		}
+	}
+	task, _, err := store.GetTask(ctx, "bg-other")
+	if err != nil || task.Status != taskStatusRunning {
+		t.Fatalf("expected task of another chat to keep running, got %+v (%v)", task, err)
+	}
+}
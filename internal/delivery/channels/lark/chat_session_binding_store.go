@@ -10,7 +10,10 @@ type ChatSessionBinding struct {
 	Channel   string
 	ChatID    string
 	SessionID string
-	UpdatedAt time.Time
+	// AgentPreset is the chat's /preset override; empty uses the channel
+	// default.
+	AgentPreset string `json:",omitempty"`
+	UpdatedAt   time.Time
 }
 
 // ChatSessionBindingStore persists chat->session bindings so a chat can keep
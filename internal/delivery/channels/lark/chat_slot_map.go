@@ -61,6 +61,7 @@ func (m *chatSlotMap) stopAll(intentional bool) {
 		if s.phase == slotRunning && s.taskCancel != nil {
 			if intentional {
 				s.intentionalCancelToken = s.taskToken
+				s.followUps = nil
 			}
 			toCancel = append(toCancel, struct {
 				token  uint64
@@ -0,0 +1,67 @@
+package lark
+
+import "strings"
+
+// routeCommand handles the slash commands that bypass task dispatch and
+// in-flight input injection. It is called with slot.mu held and returns true
+// when msg was handled, in which case slot.mu has been released.
+//
+// With the conversation process enabled only session, settings and
+// task-control commands are routed here; /stop, /usage, /notice and
+// natural-language status queries go through the conversation LLM instead.
+func (g *Gateway) routeCommand(slot *sessionSlot, msg *incomingMessage, trimmed string) bool {
+	if !g.conversationProcessEnabled() {
+		switch {
+		case g.isNaturalTaskStatusQuery(trimmed):
+			slot.mu.Unlock()
+			g.handleNaturalTaskStatusQuery(msg)
+			return true
+		case g.isNoticeCommand(trimmed):
+			slot.mu.Unlock()
+			g.handleNoticeCommand(msg)
+			return true
+		case g.isUsageCommand(trimmed):
+			slot.mu.Unlock()
+			g.handleUsageCommand(msg)
+			return true
+		case g.isStopCommand(trimmed):
+			g.handleStopCommand(slot, msg) // releases slot.mu
+			return true
+		}
+	}
+
+	switch {
+	case trimmed == "/new":
+		g.handleNewSessionCommand(slot, msg) // releases slot.mu
+	case trimmed == "/reset":
+		g.handleResetCommand(slot, msg) // releases slot.mu
+	case strings.HasPrefix(trimmed, "/model"):
+		slot.mu.Unlock()
+		g.handleModelCommand(msg)
+	case g.isPresetCommand(trimmed):
+		slot.mu.Unlock()
+		g.handlePresetCommand(msg)
+	case g.isPreferencesCommand(trimmed):
+		slot.mu.Unlock()
+		g.handlePreferencesCommand(msg)
+	case g.isTitleCommand(trimmed):
+		sessionID := slotTitleSessionID(slot)
+		slot.mu.Unlock()
+		g.handleTitleCommand(msg, sessionID)
+	case g.isToolsCommand(trimmed):
+		sessionID := slotTitleSessionID(slot)
+		slot.mu.Unlock()
+		g.handleToolsCommand(msg, sessionID)
+	case g.isSessionsCommand(trimmed):
+		slot.mu.Unlock()
+		g.handleSessionsCommand(msg)
+	case g.isTaskControlCommand(trimmed):
+		// /tasks, /cancel and /digest inspect and stop running tasks, so
+		// they must not be injected into one.
+		slot.mu.Unlock()
+		g.handleTaskControlCommand(msg)
+	default:
+		return false
+	}
+	return true
+}
@@ -12,18 +12,26 @@ type Config struct {
 	Enabled                       bool
 	AppID                         string
 	AppSecret                     string
+	VerificationToken             string // Event subscription verification token; checked on webhook callbacks when set.
+	EncryptKey                    string // Event subscription encrypt key; enables payload decryption and signature checks.
 	TenantCalendarID              string
 	BaseDomain                    string
 	WorkspaceDir                  string
 	AutoUploadFiles               bool
 	AutoUploadMaxBytes            int
 	AutoUploadAllowExt            []string
+	AutoUploadInlineImageLimit    int // Max images sent as individual image messages. Default 4.
+	AutoUploadPostImageLimit      int // Max images embedded in one post message; more are zipped. Default 9.
+	AutoUploadInlineImageMaxBytes int // Images larger than this upload as files. Default 1MB.
 	Browser                       BrowserConfig
 	ProcessingReactEmoji          string // Emoji reaction while task is running. Removed on completion. Default "OnIt".
 	InjectionAckReactEmoji        string // Emoji reaction for injected user messages while a task is running. Default THINKING.
 	ShowToolProgress              bool   // Show real-time tool progress in chat. Default false.
+	ProgressEditInPlace           bool   // With ShowToolProgress, edit one status message and replace it with the final reply. Default false.
+	ShowCost                      bool   // Append a compact token/cost line to final replies. Default false.
 	SlowProgressSummaryEnabled    *bool  // Emit periodic progress summaries when foreground task exceeds delay. Default true.
 	SlowProgressSummaryDelay      time.Duration
+	ProgressUpdateInterval        time.Duration
 	ShowPlanClarifyMessages       bool  // Send plan/clarify tool outputs as chat messages. Default false.
 	ToolFailureAbortThreshold     int   // Abort foreground run after N consecutive tool failures. Default 6.
 	AutoChatContextSize           int   // Number of recent messages to fetch for auto chat context. Default 20.
@@ -54,11 +62,24 @@ type Config struct {
 	DeliveryDocThreshold            int           `yaml:"delivery_doc_threshold" json:"delivery_doc_threshold"`     // Rune count above which replies overflow to a Feishu doc. Default 800.
 	DeliveryMode                    string        // Terminal delivery strategy: direct|shadow|outbox.
 	DeliveryWorker                  DeliveryWorkerConfig
-	AttentionGate                   AttentionGateConfig // Attention gate for message urgency filtering.
+	TaskDigest                      TaskDigestConfig      // Scheduled digest of finished background tasks.
+	AttentionGate                   AttentionGateConfig   // Attention gate for message urgency filtering.
+	SessionRotation                 SessionRotationConfig // Automatic rotation of oversized chat sessions.
 	// AIChatBotIDs is a list of bot IDs that participate in coordinated multi-bot chats.
 	// When multiple bots from this list are mentioned in a group message, they will
 	// take turns responding instead of all responding simultaneously.
 	AIChatBotIDs []string
+	// RequireMention makes the bot act on group messages only when it is
+	// @-mentioned. The mention is stripped from the task text and replies
+	// are threaded under the triggering message. Direct chats are unaffected.
+	RequireMention bool `yaml:"require_mention"`
+	// BotOpenID is the bot's own open_id, used to recognise @-mentions of
+	// this bot. AppID is also accepted, as in AI chat coordination.
+	BotOpenID string `yaml:"bot_open_id"`
+	// PresetAdminRoles lists the Lark group roles allowed to switch a group
+	// chat's /preset: "owner", "manager" or "member" (anyone). Direct chats
+	// are unaffected. Default: owner and manager.
+	PresetAdminRoles []string `yaml:"preset_admin_roles"`
 	// BtwEnabled enables the fork (btw) mode: when a task is running and a new
 	// message arrives, a child session is spawned to handle it independently.
 	// When false (default), the new message is injected directly into the parent
@@ -88,6 +109,13 @@ type Config struct {
 	// MaxConcurrentWorkers is the max simultaneous background workers per chat
 	// in conversation-process mode. Default 5.
 	MaxConcurrentWorkers int `yaml:"max_concurrent_workers"`
+	// FollowUpQueueDepth is the max number of follow-up messages queued per
+	// chat while a task runs. Queued messages run in order once the task
+	// completes; further messages get a "queue full" reply. Default 5.
+	FollowUpQueueDepth int `yaml:"follow_up_queue_depth"`
+	// AwaitInputTimeoutSeconds bounds how long a task may wait for the user
+	// when its ask_user request declares no timeout. 0 waits indefinitely.
+	AwaitInputTimeoutSeconds int `yaml:"await_input_timeout_seconds"`
 	// ConversationWorkerCapabilities is an optional description of what the
 	// background worker agent can do. When set, it is injected into the
 	// conversation router's system prompt so the chat LLM can accurately
@@ -127,6 +155,27 @@ type DeliveryWorkerConfig struct {
 	JitterRatio  float64
 }
 
+// defaultTaskDigestCron posts the task digest daily at 09:00.
+const defaultTaskDigestCron = "0 9 * * *"
+
+// TaskDigestConfig replaces per-task background completion messages with a
+// scheduled summary per chat.
+type TaskDigestConfig struct {
+	Enabled bool
+	Cron    string // Standard 5-field cron spec. Default "0 9 * * *".
+	ChatID  string // Limit digest mode to one chat. Empty applies it to all chats.
+}
+
+// SessionRotationConfig starts a fresh session for a chat once the bound
+// session grows past any configured limit. Zero disables a limit; rotation
+// is off when all limits are zero.
+type SessionRotationConfig struct {
+	MaxMessages     int           // Persisted message count that triggers rotation.
+	MaxTokens       int           // Cumulative tokens recorded in session metadata that trigger rotation.
+	MaxAge          time.Duration // Session age (since creation) that triggers rotation.
+	SummaryMaxRunes int           // Cap on the carried-over summary. Default 1200.
+}
+
 // CCHooksAutoConfig holds parameters for automatic Claude Code hooks setup.
 type CCHooksAutoConfig struct {
 	ServerURL string
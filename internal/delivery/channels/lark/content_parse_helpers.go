@@ -16,6 +16,7 @@ type larkPostElement struct {
 	Text     string `json:"text"`
 	UserID   string `json:"user_id"`
 	UserName string `json:"user_name"`
+	ImageKey string `json:"image_key"`
 }
 
 type larkPostPayload struct {
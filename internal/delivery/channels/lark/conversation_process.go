@@ -425,7 +425,7 @@ func (g *Gateway) prefetchQueryContext(ctx context.Context, msg *incomingMessage
 				ch <- result{"tasks", ""}
 				return
 			}
-			ch <- result{"tasks", g.formatActiveTaskList(tasks)}
+			ch <- result{"tasks", g.formatActiveTaskList(nil, tasks)}
 		}()
 	}
 	if hasUsage && g.costTracker != nil {
@@ -1002,6 +1002,8 @@ func (g *Gateway) spawnWorkerInSlotMap(ctx context.Context, msg *incomingMessage
 // spawnOrInjectWorker tries to inject into the most recently active worker in
 // the slotMap; if no running worker exists, spawns a new one.
 // Returns (taskID, injected). When injected=true, taskID is the existing task's ID.
+// If that task's input channel is full the message joins its follow-up queue
+// instead, which also counts as injected.
 func (g *Gateway) spawnOrInjectWorker(ctx context.Context, msg *incomingMessage, slotMap *chatSlotMap, taskContent string) (string, bool) {
 	// Look for a running slot to inject into.
 	var injected bool
@@ -1018,12 +1020,11 @@ func (g *Gateway) spawnOrInjectWorker(ctx context.Context, msg *incomingMessage,
 				injected = true
 				injectedTaskID = taskID
 			default:
-				lang := detectLang(msg.content)
-				if lang == "en" {
-					g.dispatchFormattedReply(ctx, msg.chatID, replyTarget(msg.messageID, true), "Message received, will be processed after current task.")
-				} else {
-					g.dispatchFormattedReply(ctx, msg.chatID, replyTarget(msg.messageID, true), "消息已收到，等待当前任务处理完毕后执行")
-				}
+				// Treat the full-channel case as handled so no extra worker spawns.
+				queued := s.enqueueFollowUp(agent.UserInput{Content: taskContent, SenderID: msg.senderID, MessageID: msg.messageID}, g.followUpQueueDepth())
+				g.replyFollowUpQueued(ctx, msg, queued)
+				injected = true
+				injectedTaskID = taskID
 			}
 		}
 	})
@@ -1046,7 +1047,9 @@ func (g *Gateway) launchWorkerGoroutineForSlotMap(msg *incomingMessage, slot *se
 		awaitingInput, answerPreview := g.runTask(taskCtx, msg, sessionID, inputCh, isResume, taskToken)
 
 		slot.mu.Lock()
-		if slot.taskToken == taskToken {
+		current := slot.taskToken == taskToken
+		stopped := current && slot.intentionalCancelToken == taskToken
+		if current {
 			if slot.intentionalCancelToken == taskToken {
 				slot.intentionalCancelToken = 0
 			}
@@ -1067,10 +1070,13 @@ func (g *Gateway) launchWorkerGoroutineForSlotMap(msg *incomingMessage, slot *se
 		}
 		slot.mu.Unlock()
 
-		if awaitingInput {
+		switch {
+		case awaitingInput:
 			g.drainAndReprocess(inputCh, msg.chatID, msg.chatType, taskToken)
-		} else {
+		case !current || stopped:
 			g.discardPendingInputs(inputCh, msg.chatID)
+		default:
+			g.dispatchQueuedFollowUp(slot, inputCh, msg.chatID, msg.chatType, msg.messageID)
 		}
 	}(taskCtx, taskCancel, taskToken)
 }
@@ -124,7 +124,7 @@ func (g *Gateway) queryTasksActive(ctx context.Context, msg *incomingMessage) st
 	if len(tasks) == 0 {
 		return "No active tasks."
 	}
-	return g.formatActiveTaskList(tasks)
+	return g.formatActiveTaskList(nil, tasks)
 }
 
 func (g *Gateway) queryTasksStatus(ctx context.Context, taskID string) string {
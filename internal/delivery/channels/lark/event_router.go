@@ -0,0 +1,401 @@
+package lark
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	"alex/internal/shared/logging"
+
+	larkevent "github.com/larksuite/oapi-sdk-go/v3/event"
+	"github.com/larksuite/oapi-sdk-go/v3/event/dispatcher"
+)
+
+// Lark callback types handled by the gateway's built-in routes.
+const (
+	eventTypeMessageReceive    = "im.message.receive_v1"
+	eventTypeMessageRead       = "im.message.message_read_v1"
+	eventTypeReactionCreated   = "im.message.reaction.created_v1"
+	eventTypeReactionDeleted   = "im.message.reaction.deleted_v1"
+	eventTypeBotP2PChatEntered = "im.chat.access_event.bot_p2p_chat_entered_v1"
+	eventTypeBotAdded          = "im.chat.member.bot.added_v1"
+	eventTypeBotDeleted        = "im.chat.member.bot.deleted_v1"
+	eventTypeChatDisbanded     = "im.chat.disbanded_v1"
+	eventTypeCardAction        = "card.action.trigger"
+)
+
+// Routing outcomes reported to EventMetrics.
+const (
+	EventOutcomeRouted   = "routed"
+	EventOutcomeUnknown  = "unknown"
+	EventOutcomeFailed   = "failed"
+	EventOutcomeRejected = "rejected"
+)
+
+const (
+	// Unknown event types are logged for their first few occurrences and
+	// then sampled, so a chatty subscription cannot flood the log.
+	unknownEventLogFirst  = 3
+	unknownEventLogEvery  = 100
+	unknownEventSampleLen = 512
+	maxEventBodyBytes     = 1 << 20
+)
+
+var (
+	errEventSignature = errors.New("lark event signature mismatch")
+	errEventToken     = errors.New("lark event verification token mismatch")
+)
+
+// EventMetrics records per-type routing outcomes.
+// Satisfied by *observability.MetricsCollector.
+type EventMetrics interface {
+	RecordLarkEvent(ctx context.Context, eventType, outcome string)
+}
+
+// RoutedEvent is a verified, decrypted Lark callback handed to a route.
+type RoutedEvent struct {
+	Type    string
+	EventID string
+	Body    []byte
+}
+
+// Decode unmarshals the callback body into a typed SDK event, e.g.
+// *larkim.P2MessageReadV1.
+func (e *RoutedEvent) Decode(v any) error {
+	return json.Unmarshal(e.Body, v)
+}
+
+// EventHandler handles one routed callback. The returned value is written
+// back as the webhook response body (card actions use it for toasts); nil
+// means a plain acknowledgement.
+type EventHandler func(ctx context.Context, event *RoutedEvent) (any, error)
+
+// EventRouterStats is a snapshot of routing counters.
+type EventRouterStats struct {
+	Routed   int64            `json:"routed"`
+	Unknown  int64            `json:"unknown"`
+	Failed   int64            `json:"failed"`
+	Rejected int64            `json:"rejected"`
+	ByType   map[string]int64 `json:"by_type"` // "<event_type>/<outcome>" → count
+}
+
+// EventRouter maps Lark event types to handlers. It verifies webhook
+// callbacks centrally (encrypt key + signature, verification token) and
+// sends unmatched types to a default handler that counts them and samples
+// their payloads into the log.
+type EventRouter struct {
+	verificationToken string
+	encryptKey        string
+	logger            logging.Logger
+
+	mu      sync.RWMutex
+	routes  map[string]EventHandler
+	metrics EventMetrics
+
+	statsMu sync.Mutex
+	counts  map[string]int64
+	totals  map[string]int64
+}
+
+// NewEventRouter creates an empty router. verificationToken and encryptKey
+// come from the Lark app's event subscription settings; either may be empty
+// when the corresponding check is disabled in the developer console.
+func NewEventRouter(verificationToken, encryptKey string, logger logging.Logger) *EventRouter {
+	return &EventRouter{
+		verificationToken: strings.TrimSpace(verificationToken),
+		encryptKey:        strings.TrimSpace(encryptKey),
+		logger:            logging.OrNop(logger),
+		routes:            make(map[string]EventHandler),
+		counts:            make(map[string]int64),
+		totals:            make(map[string]int64),
+	}
+}
+
+// Handle registers h for eventType, replacing any existing route.
+func (r *EventRouter) Handle(eventType string, h EventHandler) {
+	eventType = strings.TrimSpace(eventType)
+	if r == nil || eventType == "" || h == nil {
+		return
+	}
+	r.mu.Lock()
+	r.routes[eventType] = h
+	r.mu.Unlock()
+}
+
+// SetMetrics configures the optional metrics sink.
+func (r *EventRouter) SetMetrics(metrics EventMetrics) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	r.metrics = metrics
+	r.mu.Unlock()
+}
+
+// Types returns the registered event types in sorted order.
+func (r *EventRouter) Types() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	types := make([]string, 0, len(r.routes))
+	for eventType := range r.routes {
+		types = append(types, eventType)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// Stats returns a copy of the routing counters.
+func (r *EventRouter) Stats() EventRouterStats {
+	r.statsMu.Lock()
+	defer r.statsMu.Unlock()
+	stats := EventRouterStats{
+		Routed:   r.totals[EventOutcomeRouted],
+		Unknown:  r.totals[EventOutcomeUnknown],
+		Failed:   r.totals[EventOutcomeFailed],
+		Rejected: r.totals[EventOutcomeRejected],
+		ByType:   make(map[string]int64, len(r.counts)),
+	}
+	for key, n := range r.counts {
+		stats.ByType[key] = n
+	}
+	return stats
+}
+
+// eventEnvelope covers both the v2 schema (header.event_type) and the
+// legacy v1 / url_verification shapes (type + event.type).
+type eventEnvelope struct {
+	Encrypt   string                 `json:"encrypt"`
+	Type      string                 `json:"type"`
+	Token     string                 `json:"token"`
+	Challenge string                 `json:"challenge"`
+	UUID      string                 `json:"uuid"`
+	Header    *larkevent.EventHeader `json:"header"`
+	Event     *struct {
+		Type string `json:"type"`
+	} `json:"event"`
+}
+
+func (e eventEnvelope) eventType() string {
+	if e.Header != nil && e.Header.EventType != "" {
+		return e.Header.EventType
+	}
+	if e.Event != nil {
+		return e.Event.Type
+	}
+	return ""
+}
+
+func (e eventEnvelope) eventID() string {
+	if e.Header != nil {
+		return e.Header.EventID
+	}
+	return e.UUID
+}
+
+func (e eventEnvelope) token() string {
+	if e.Header != nil && e.Header.Token != "" {
+		return e.Header.Token
+	}
+	return e.Token
+}
+
+// Route dispatches a plaintext callback body. Unknown types go to the
+// default handler and are not an error.
+func (r *EventRouter) Route(ctx context.Context, body []byte) (any, error) {
+	var env eventEnvelope
+	if err := json.Unmarshal(body, &env); err != nil {
+		return nil, fmt.Errorf("decode lark event: %w", err)
+	}
+	return r.route(ctx, env, body)
+}
+
+func (r *EventRouter) route(ctx context.Context, env eventEnvelope, body []byte) (any, error) {
+	event := &RoutedEvent{Type: env.eventType(), EventID: env.eventID(), Body: body}
+	r.mu.RLock()
+	handler := r.routes[event.Type]
+	r.mu.RUnlock()
+	if handler == nil {
+		r.handleUnknown(ctx, event)
+		return nil, nil
+	}
+	resp, err := handler(ctx, event)
+	if err != nil {
+		r.record(ctx, event.Type, EventOutcomeFailed)
+		return nil, err
+	}
+	r.record(ctx, event.Type, EventOutcomeRouted)
+	return resp, nil
+}
+
+// handleUnknown is the default route: count every unmatched type and log a
+// truncated payload sample for the first few and then every Nth occurrence.
+func (r *EventRouter) handleUnknown(ctx context.Context, event *RoutedEvent) {
+	eventType := event.Type
+	if eventType == "" {
+		eventType = "(none)"
+	}
+	n := r.record(ctx, eventType, EventOutcomeUnknown)
+	if n <= unknownEventLogFirst || n%unknownEventLogEvery == 0 {
+		r.logger.Warn("Lark event unrouted: type=%s event_id=%s seen=%d sample=%s",
+			eventType, event.EventID, n, truncateForLark(string(event.Body), unknownEventSampleLen))
+	}
+}
+
+// record bumps the counters for eventType/outcome and returns the new
+// per-type count.
+func (r *EventRouter) record(ctx context.Context, eventType, outcome string) int64 {
+	r.statsMu.Lock()
+	key := eventType + "/" + outcome
+	r.counts[key]++
+	n := r.counts[key]
+	r.totals[outcome]++
+	r.statsMu.Unlock()
+
+	r.mu.RLock()
+	metrics := r.metrics
+	r.mu.RUnlock()
+	if metrics != nil {
+		metrics.RecordLarkEvent(ctx, eventType, outcome)
+	}
+	return n
+}
+
+// ServeHTTP implements the Lark event subscription webhook: decrypt,
+// answer url_verification challenges, verify signature and token, then
+// route. Verification failures are counted and answered with 401.
+func (r *EventRouter) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	raw, err := io.ReadAll(io.LimitReader(req.Body, maxEventBodyBytes))
+	if err != nil {
+		http.Error(w, "read body failed", http.StatusBadRequest)
+		return
+	}
+	plain, env, err := r.open(raw)
+	if err != nil {
+		r.reject(req.Context(), w, env.eventType(), err)
+		return
+	}
+
+	if larkevent.ReqType(env.Type) == larkevent.ReqTypeChallenge {
+		if !r.tokenMatches(env.token()) {
+			r.reject(req.Context(), w, string(larkevent.ReqTypeChallenge), errEventToken)
+			return
+		}
+		writeEventJSON(w, map[string]string{"challenge": env.Challenge})
+		return
+	}
+	if err := r.verifySignature(req.Header, raw); err != nil {
+		r.reject(req.Context(), w, env.eventType(), err)
+		return
+	}
+	if !r.tokenMatches(env.token()) {
+		r.reject(req.Context(), w, env.eventType(), errEventToken)
+		return
+	}
+
+	resp, err := r.route(req.Context(), env, plain)
+	if err != nil {
+		r.logger.Warn("Lark event handler failed: type=%s event_id=%s err=%v", env.eventType(), env.eventID(), err)
+		http.Error(w, "event handler failed", http.StatusInternalServerError)
+		return
+	}
+	if resp == nil {
+		resp = map[string]string{"msg": "success"}
+	}
+	writeEventJSON(w, resp)
+}
+
+// open decrypts raw when an encrypt key is configured and parses the
+// envelope. The returned body is always plaintext JSON.
+func (r *EventRouter) open(raw []byte) ([]byte, eventEnvelope, error) {
+	var env eventEnvelope
+	if err := json.Unmarshal(raw, &env); err != nil {
+		return nil, env, fmt.Errorf("decode lark event: %w", err)
+	}
+	if r.encryptKey == "" {
+		if env.Encrypt != "" {
+			return nil, env, errors.New("lark event is encrypted but no encrypt key is configured")
+		}
+		return raw, env, nil
+	}
+	if env.Encrypt == "" {
+		return nil, env, errors.New("lark event is not encrypted")
+	}
+	plain, err := larkevent.EventDecrypt(env.Encrypt, r.encryptKey)
+	if err != nil {
+		return nil, env, fmt.Errorf("decrypt lark event: %w", err)
+	}
+	env = eventEnvelope{}
+	if err := json.Unmarshal(plain, &env); err != nil {
+		return nil, env, fmt.Errorf("decode lark event: %w", err)
+	}
+	return plain, env, nil
+}
+
+// verifySignature checks X-Lark-Signature over the raw request body. Lark
+// only signs callbacks when an encrypt key is configured.
+func (r *EventRouter) verifySignature(header http.Header, raw []byte) error {
+	if r.encryptKey == "" {
+		return nil
+	}
+	want := larkevent.Signature(
+		header.Get(larkevent.EventRequestTimestamp),
+		header.Get(larkevent.EventRequestNonce),
+		r.encryptKey,
+		string(raw),
+	)
+	got := header.Get(larkevent.EventSignature)
+	if subtle.ConstantTimeCompare([]byte(want), []byte(got)) != 1 {
+		return errEventSignature
+	}
+	return nil
+}
+
+func (r *EventRouter) tokenMatches(token string) bool {
+	if r.verificationToken == "" {
+		return true
+	}
+	return subtle.ConstantTimeCompare([]byte(r.verificationToken), []byte(token)) == 1
+}
+
+func (r *EventRouter) reject(ctx context.Context, w http.ResponseWriter, eventType string, err error) {
+	if eventType == "" {
+		eventType = "(none)"
+	}
+	r.record(ctx, eventType, EventOutcomeRejected)
+	r.logger.Warn("Lark event rejected: type=%s err=%v", eventType, err)
+	http.Error(w, "invalid lark event", http.StatusUnauthorized)
+}
+
+func writeEventJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		http.Error(w, "encode response failed", http.StatusInternalServerError)
+	}
+}
+
+// sdkDispatcher adapts the routing table to the SDK dispatcher used by the
+// WebSocket client. Frames arriving over the authenticated WebSocket are
+// already plaintext, so only routing applies. The SDK rejects types without
+// a registered handler before they reach the router; unknown-type metrics
+// therefore come from the webhook path.
+func (r *EventRouter) sdkDispatcher() *dispatcher.EventDispatcher {
+	d := dispatcher.NewEventDispatcher("", "")
+	for _, eventType := range r.Types() {
+		d.OnCustomizedEvent(eventType, func(ctx context.Context, req *larkevent.EventReq) error {
+			_, err := r.Route(ctx, req.Body)
+			return err
+		})
+	}
+	return d
+}
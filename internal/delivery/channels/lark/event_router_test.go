@@ -0,0 +1,304 @@
+package lark
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	larkevent "github.com/larksuite/oapi-sdk-go/v3/event"
+)
+
+const (
+	testEventToken      = "verify-token"
+	testEventEncryptKey = "encrypt-key"
+)
+
+// Fixture callback payloads, as Lark delivers them after decryption.
+var eventFixtures = map[string]string{
+	eventTypeMessageReceive: `{"schema":"2.0","header":{"event_id":"ev_recv","event_type":"im.message.receive_v1","token":"verify-token"},
+		"event":{"sender":{"sender_id":{"open_id":"ou_user"},"sender_type":"user"},
+		"message":{"message_id":"om_in","chat_id":"oc_dm","chat_type":"p2p","message_type":"text","content":"{\"text\":\"hi\"}"}}}`,
+	eventTypeReactionCreated: `{"schema":"2.0","header":{"event_id":"ev_react","event_type":"im.message.reaction.created_v1","token":"verify-token"},
+		"event":{"message_id":"om_1","reaction_type":{"emoji_type":"THUMBSUP"}}}`,
+	eventTypeBotP2PChatEntered: `{"schema":"2.0","header":{"event_id":"ev_p2p","event_type":"im.chat.access_event.bot_p2p_chat_entered_v1","token":"verify-token"},
+		"event":{"chat_id":"oc_dm","operator_id":{"open_id":"ou_user"}}}`,
+	eventTypeBotAdded: `{"schema":"2.0","header":{"event_id":"ev_added","event_type":"im.chat.member.bot.added_v1","token":"verify-token"},
+		"event":{"chat_id":"oc_new_group","operator_id":{"open_id":"ou_admin"},"name":"Team"}}`,
+	eventTypeMessageRead: `{"schema":"2.0","header":{"event_id":"ev_read","event_type":"im.message.message_read_v1","token":"verify-token"},
+		"event":{"reader":{"reader_id":{"open_id":"ou_reader"},"read_time":"1760000000000"},"message_id_list":["om_recorded_1","om_foreign"]}}`,
+	eventTypeCardAction: `{"schema":"2.0","header":{"event_id":"ev_card","event_type":"card.action.trigger","token":"verify-token"},
+		"event":{"operator":{"open_id":"ou_user"},"action":{"tag":"button","value":{"action":"plan_review_revise"}},
+		"context":{"open_message_id":"om_card","open_chat_id":"oc_group"}}}`,
+	eventTypeChatDisbanded: `{"schema":"2.0","header":{"event_id":"ev_disband","event_type":"im.chat.disbanded_v1","token":"verify-token"},
+		"event":{"chat_id":"oc_ai","operator_id":{"open_id":"ou_admin"}}}`,
+}
+
+const unknownEventFixture = `{"schema":"2.0","header":{"event_id":"ev_unknown","event_type":"im.chat.updated_v1","token":"verify-token"},"event":{"chat_id":"oc_group"}}`
+
+type recordedEventMetric struct{ eventType, outcome string }
+
+type fakeEventMetrics struct {
+	mu      sync.Mutex
+	records []recordedEventMetric
+}
+
+func (m *fakeEventMetrics) RecordLarkEvent(_ context.Context, eventType, outcome string) {
+	m.mu.Lock()
+	m.records = append(m.records, recordedEventMetric{eventType, outcome})
+	m.mu.Unlock()
+}
+
+func (m *fakeEventMetrics) count(eventType, outcome string) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	n := 0
+	for _, r := range m.records {
+		if r.eventType == eventType && r.outcome == outcome {
+			n++
+		}
+	}
+	return n
+}
+
+type readReceipt struct {
+	taskID, readerID string
+	readAt           time.Time
+}
+
+// receiptTaskStore adds ReadReceiptRecorder to the in-memory task store.
+type receiptTaskStore struct {
+	*TaskLocalStore
+	mu       sync.Mutex
+	receipts []readReceipt
+}
+
+func (s *receiptTaskStore) RecordReadReceipt(_ context.Context, taskID, readerID string, readAt time.Time) error {
+	s.mu.Lock()
+	s.receipts = append(s.receipts, readReceipt{taskID, readerID, readAt})
+	s.mu.Unlock()
+	return nil
+}
+
+type eventRouterFixture struct {
+	gw       *Gateway
+	recorder *RecordingMessenger
+	metrics  *fakeEventMetrics
+	tasks    *receiptTaskStore
+	server   *httptest.Server
+}
+
+func newEventRouterFixture(t *testing.T) *eventRouterFixture {
+	t.Helper()
+	gw, err := NewGateway(Config{
+		AppID:             "cli_test",
+		AppSecret:         "secret",
+		VerificationToken: testEventToken,
+		EncryptKey:        testEventEncryptKey,
+		AIChatBotIDs:      []string{"bot_a", "bot_b"},
+	}, &stubExecutor{}, nil)
+	if err != nil {
+		t.Fatalf("NewGateway: %v", err)
+	}
+	f := &eventRouterFixture{
+		gw:       gw,
+		recorder: NewRecordingMessenger(),
+		metrics:  &fakeEventMetrics{},
+		tasks:    &receiptTaskStore{TaskLocalStore: NewTaskMemoryStore(time.Hour, 10)},
+	}
+	gw.SetMessenger(f.recorder)
+	gw.SetTaskStore(f.tasks)
+	gw.SetEventMetrics(f.metrics)
+	f.server = httptest.NewServer(gw.EventWebhookHandler())
+	t.Cleanup(f.server.Close)
+	return f
+}
+
+// post encrypts and signs payload the way Lark does and posts it to the
+// webhook. A non-empty badSignature replaces the computed signature.
+func (f *eventRouterFixture) post(t *testing.T, payload, badSignature string) (int, string) {
+	t.Helper()
+	body, err := json.Marshal(map[string]string{"encrypt": encryptEventForTest(t, payload, testEventEncryptKey)})
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	req, err := http.NewRequest(http.MethodPost, f.server.URL, bytes.NewReader(body))
+	if err != nil {
+		t.Fatalf("NewRequest: %v", err)
+	}
+	ts, nonce := "1760000000", "nonce-1"
+	signature := larkevent.Signature(ts, nonce, testEventEncryptKey, string(body))
+	if badSignature != "" {
+		signature = badSignature
+	}
+	req.Header.Set(larkevent.EventRequestTimestamp, ts)
+	req.Header.Set(larkevent.EventRequestNonce, nonce)
+	req.Header.Set(larkevent.EventSignature, signature)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("post: %v", err)
+	}
+	defer resp.Body.Close()
+	var buf bytes.Buffer
+	_, _ = buf.ReadFrom(resp.Body)
+	return resp.StatusCode, buf.String()
+}
+
+func encryptEventForTest(t *testing.T, plaintext, secret string) string {
+	t.Helper()
+	key := sha256.Sum256([]byte(secret))
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		t.Fatalf("NewCipher: %v", err)
+	}
+	data := []byte(plaintext)
+	pad := aes.BlockSize - len(data)%aes.BlockSize
+	data = append(data, bytes.Repeat([]byte{byte(pad)}, pad)...)
+	iv := bytes.Repeat([]byte{7}, aes.BlockSize)
+	out := make([]byte, len(data))
+	cipher.NewCBCEncrypter(block, iv).CryptBlocks(out, data)
+	return base64.StdEncoding.EncodeToString(append(iv, out...))
+}
+
+func TestEventRouterRoutesFixturePayloads(t *testing.T) {
+	f := newEventRouterFixture(t)
+	if err := f.tasks.SaveTask(context.Background(), TaskRecord{ChatID: "oc_group", TaskID: "task-1", Status: "completed"}); err != nil {
+		t.Fatalf("SaveTask: %v", err)
+	}
+	// The bot's reply becomes om_recorded_1, the message the read fixture references.
+	f.gw.dispatch(context.Background(), "oc_group", "", "text", textContent("done"))
+	f.gw.aiCoordinator.DetectAndStartSession("oc_ai", "om_ai", "ou_user", []string{"bot_a", "bot_b"}, "bot_a")
+
+	for eventType, payload := range eventFixtures {
+		status, body := f.post(t, payload, "")
+		if status != http.StatusOK {
+			t.Fatalf("%s: expected 200, got %d: %s", eventType, status, body)
+		}
+		if eventType == eventTypeCardAction && !strings.Contains(body, "请直接回复修改意见") {
+			t.Fatalf("expected revise toast in card response, got %s", body)
+		}
+		if got := f.metrics.count(eventType, EventOutcomeRouted); got != 1 {
+			t.Fatalf("%s: expected 1 routed metric, got %d", eventType, got)
+		}
+	}
+
+	stats := f.gw.EventRouter().Stats()
+	if stats.Routed != int64(len(eventFixtures)) || stats.Unknown != 0 || stats.Rejected != 0 || stats.Failed != 0 {
+		t.Fatalf("unexpected stats: %+v", stats)
+	}
+
+	onboarding := false
+	for _, call := range f.recorder.CallsByMethod(MethodSendMessage) {
+		if call.ChatID == "oc_new_group" && strings.Contains(call.Content, "/cc") && strings.Contains(call.Content, "/tasks") {
+			onboarding = true
+		}
+	}
+	if !onboarding {
+		t.Fatalf("expected onboarding message with commands in oc_new_group, got %+v", f.recorder.Calls())
+	}
+
+	if len(f.tasks.receipts) != 1 {
+		t.Fatalf("expected one read receipt, got %+v", f.tasks.receipts)
+	}
+	receipt := f.tasks.receipts[0]
+	if receipt.taskID != "task-1" || receipt.readerID != "ou_reader" || !receipt.readAt.Equal(time.UnixMilli(1760000000000)) {
+		t.Fatalf("unexpected read receipt: %+v", receipt)
+	}
+
+	if info, _ := f.gw.aiCoordinator.GetSessionInfo("oc_ai"); !strings.Contains(info, "active=false") {
+		t.Fatalf("expected disbanded chat to end the AI chat session, got %q", info)
+	}
+}
+
+func TestEventRouterRejectsBadSignature(t *testing.T) {
+	f := newEventRouterFixture(t)
+
+	status, _ := f.post(t, eventFixtures[eventTypeBotAdded], "forged")
+	if status != http.StatusUnauthorized {
+		t.Fatalf("expected 401 for bad signature, got %d", status)
+	}
+	if len(f.recorder.Calls()) != 0 {
+		t.Fatalf("expected no handler side effects, got %+v", f.recorder.Calls())
+	}
+	if got := f.metrics.count(eventTypeBotAdded, EventOutcomeRejected); got != 1 {
+		t.Fatalf("expected 1 rejected metric, got %d", got)
+	}
+	if stats := f.gw.EventRouter().Stats(); stats.Rejected != 1 || stats.Routed != 0 {
+		t.Fatalf("unexpected stats: %+v", stats)
+	}
+}
+
+func TestEventRouterRejectsWrongToken(t *testing.T) {
+	f := newEventRouterFixture(t)
+
+	payload := strings.Replace(eventFixtures[eventTypeBotAdded], testEventToken, "other-token", 1)
+	if status, _ := f.post(t, payload, ""); status != http.StatusUnauthorized {
+		t.Fatalf("expected 401 for wrong token, got %d", status)
+	}
+	if got := f.metrics.count(eventTypeBotAdded, EventOutcomeRejected); got != 1 {
+		t.Fatalf("expected 1 rejected metric, got %d", got)
+	}
+}
+
+func TestEventRouterCountsUnknownTypes(t *testing.T) {
+	f := newEventRouterFixture(t)
+
+	for i := 0; i < 5; i++ {
+		if status, body := f.post(t, unknownEventFixture, ""); status != http.StatusOK {
+			t.Fatalf("expected unknown types to be acknowledged, got %d: %s", status, body)
+		}
+	}
+	if got := f.metrics.count("im.chat.updated_v1", EventOutcomeUnknown); got != 5 {
+		t.Fatalf("expected 5 unknown metrics, got %d", got)
+	}
+	stats := f.gw.EventRouter().Stats()
+	if stats.Unknown != 5 || stats.ByType["im.chat.updated_v1/unknown"] != 5 || stats.Routed != 0 {
+		t.Fatalf("unexpected stats: %+v", stats)
+	}
+}
+
+func TestEventRouterAnswersChallenge(t *testing.T) {
+	f := newEventRouterFixture(t)
+
+	status, body := f.post(t, `{"type":"url_verification","token":"verify-token","challenge":"c-123"}`, "")
+	if status != http.StatusOK || !strings.Contains(body, `"challenge":"c-123"`) {
+		t.Fatalf("unexpected challenge response: %d %s", status, body)
+	}
+}
+
+func TestEventRouterRouteIsExtensible(t *testing.T) {
+	router := NewEventRouter("", "", nil)
+	var got string
+	router.Handle("custom.event_v1", func(_ context.Context, event *RoutedEvent) (any, error) {
+		got = event.EventID
+		return nil, nil
+	})
+	if _, err := router.Route(context.Background(), []byte(`{"schema":"2.0","header":{"event_id":"ev_custom","event_type":"custom.event_v1"}}`)); err != nil {
+		t.Fatalf("Route: %v", err)
+	}
+	if got != "ev_custom" {
+		t.Fatalf("expected custom route to receive the event, got %q", got)
+	}
+	if types := router.Types(); len(types) != 1 || types[0] != "custom.event_v1" {
+		t.Fatalf("unexpected types: %v", types)
+	}
+}
+
+func TestCardActionUnknownNameReturnsWarningToast(t *testing.T) {
+	f := newEventRouterFixture(t)
+
+	payload := strings.Replace(eventFixtures[eventTypeCardAction], cardActionPlanReviewRevise, "stale_action", 1)
+	status, body := f.post(t, payload, "")
+	if status != http.StatusOK || !strings.Contains(body, `"type":"warning"`) {
+		t.Fatalf("unexpected response for stale card action: %d %s", status, body)
+	}
+}
@@ -0,0 +1,270 @@
+package lark
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/larksuite/oapi-sdk-go/v3/event/dispatcher/callback"
+	larkim "github.com/larksuite/oapi-sdk-go/v3/service/im/v1"
+)
+
+const (
+	defaultSentMessageIndexSize = 4096
+
+	cardActionPlanReviewApprove = "plan_review_approve"
+	cardActionPlanReviewRevise  = "plan_review_revise"
+)
+
+// ReadReceiptRecorder is an optional TaskStore extension that appends a
+// read receipt to a task's timeline.
+type ReadReceiptRecorder interface {
+	RecordReadReceipt(ctx context.Context, taskID, readerID string, readAt time.Time) error
+}
+
+// CardAction is a parsed card.action.trigger callback. Name is the
+// "action" key of the clicked button's value.
+type CardAction struct {
+	Name       string
+	ChatID     string
+	MessageID  string
+	OperatorID string
+	Value      map[string]any
+}
+
+// CardActionHandler handles one card button action. A non-empty toast is
+// shown to the operator in the Lark client.
+type CardActionHandler func(ctx context.Context, action *CardAction) (toast string, err error)
+
+// EventRouter returns the gateway's callback routing table so other
+// components can register additional event types.
+func (g *Gateway) EventRouter() *EventRouter { return g.eventRouter }
+
+// EventWebhookHandler returns the HTTP handler for Lark's event
+// subscription webhook (request URL mode).
+func (g *Gateway) EventWebhookHandler() http.Handler { return g.eventRouter }
+
+// SetEventMetrics configures the metrics sink for routed callbacks.
+func (g *Gateway) SetEventMetrics(metrics EventMetrics) { g.eventRouter.SetMetrics(metrics) }
+
+// HandleCardAction registers h for card buttons whose value carries
+// {"action": name}, replacing any existing handler.
+func (g *Gateway) HandleCardAction(name string, h CardActionHandler) {
+	name = strings.TrimSpace(name)
+	if name == "" || h == nil {
+		return
+	}
+	g.cardActionsMu.Lock()
+	if g.cardActions == nil {
+		g.cardActions = make(map[string]CardActionHandler)
+	}
+	g.cardActions[name] = h
+	g.cardActionsMu.Unlock()
+}
+
+// registerEventRoutes installs the gateway's built-in callback routes.
+func (g *Gateway) registerEventRoutes() {
+	r := g.eventRouter
+	r.Handle(eventTypeMessageReceive, func(ctx context.Context, event *RoutedEvent) (any, error) {
+		var msg larkim.P2MessageReceiveV1
+		if err := event.Decode(&msg); err != nil {
+			return nil, err
+		}
+		return nil, g.handleMessage(ctx, &msg)
+	})
+	// Acknowledged but intentionally ignored; routing them keeps them out
+	// of the unknown-event counters.
+	for _, eventType := range []string{eventTypeReactionCreated, eventTypeReactionDeleted, eventTypeBotP2PChatEntered} {
+		r.Handle(eventType, acknowledgeEvent)
+	}
+	r.Handle(eventTypeBotAdded, g.handleBotAddedEvent)
+	r.Handle(eventTypeMessageRead, g.handleMessageReadEvent)
+	r.Handle(eventTypeCardAction, g.handleCardActionEvent)
+
+	g.HandleCardAction(handoffActionRetry, g.handoffCardAction)
+	g.HandleCardAction(handoffActionAbort, g.handoffCardAction)
+	g.HandleCardAction(handoffActionProvideInput, g.handoffCardAction)
+	g.HandleCardAction(cardActionPlanReviewApprove, g.planReviewApproveCardAction)
+	g.HandleCardAction(cardActionPlanReviewRevise, g.planReviewReviseCardAction)
+}
+
+func acknowledgeEvent(context.Context, *RoutedEvent) (any, error) { return nil, nil }
+
+// handleBotAddedEvent greets a group the bot was just added to with the
+// available commands.
+func (g *Gateway) handleBotAddedEvent(ctx context.Context, event *RoutedEvent) (any, error) {
+	var added larkim.P2ChatMemberBotAddedV1
+	if err := event.Decode(&added); err != nil {
+		return nil, err
+	}
+	if added.Event == nil {
+		return nil, nil
+	}
+	chatID := strings.TrimSpace(deref(added.Event.ChatId))
+	if chatID == "" {
+		return nil, nil
+	}
+	g.logger.Info("Lark bot added to chat=%s", chatID)
+	g.dispatch(ctx, chatID, "", "text", textContent(onboardingMessage()))
+	return nil, nil
+}
+
+func onboardingMessage() string {
+	return strings.TrimSpace(`
+大家好，我已加入本群。@我 并直接描述需求即可开始任务。
+
+常用命令：
+  /cc <描述>       交给 Claude Code 执行
+  /codex <描述>    交给 Codex 执行
+  /task <描述>     交给默认 Agent 执行
+  /tasks           查看进行中的任务
+  /cancel <id>     取消指定任务
+  /digest now      立即发送后台任务摘要
+  /stop            终止当前任务
+  /new             开始新会话
+  /plan on|off     开关计划确认
+  /model           查看或切换模型
+  /preset          查看或切换本群 preset
+  /notice          将本群设为通知群
+  /prefs           查看个人偏好
+  /title           查看或修改会话标题
+  /tools           查看或调整本会话可用工具
+  /usage           查看用量统计
+`)
+}
+
+// handleMessageReadEvent maps read bot messages back to their chat and
+// records a read receipt on that chat's latest task.
+func (g *Gateway) handleMessageReadEvent(ctx context.Context, event *RoutedEvent) (any, error) {
+	var read larkim.P2MessageReadV1
+	if err := event.Decode(&read); err != nil {
+		return nil, err
+	}
+	if read.Event == nil || g.taskStore == nil {
+		return nil, nil
+	}
+	recorder, ok := g.taskStore.(ReadReceiptRecorder)
+	if !ok {
+		return nil, nil
+	}
+	readerID, readAt := "", g.currentTime()
+	if reader := read.Event.Reader; reader != nil {
+		if reader.ReaderId != nil {
+			readerID = deref(reader.ReaderId.OpenId)
+		}
+		if ms, err := strconv.ParseInt(deref(reader.ReadTime), 10, 64); err == nil && ms > 0 {
+			readAt = time.UnixMilli(ms)
+		}
+	}
+
+	seen := make(map[string]bool)
+	for _, messageID := range read.Event.MessageIdList {
+		chatID, ok := g.sentMessages.chatFor(messageID)
+		if !ok || seen[chatID] {
+			continue
+		}
+		seen[chatID] = true
+		tasks, err := g.taskStore.ListByChat(ctx, chatID, false, 1)
+		if err != nil || len(tasks) == 0 {
+			continue
+		}
+		if err := recorder.RecordReadReceipt(ctx, tasks[0].TaskID, readerID, readAt); err != nil {
+			g.logger.Warn("Lark read receipt for task %s failed: %v", tasks[0].TaskID, err)
+		}
+	}
+	return nil, nil
+}
+
+// handleCardActionEvent dispatches card.action.trigger callbacks through
+// the card action table.
+func (g *Gateway) handleCardActionEvent(ctx context.Context, event *RoutedEvent) (any, error) {
+	var trigger callback.CardActionTriggerEvent
+	if err := event.Decode(&trigger); err != nil {
+		return nil, err
+	}
+	req := trigger.Event
+	if req == nil || req.Action == nil {
+		return nil, nil
+	}
+	action := &CardAction{Value: req.Action.Value}
+	if name, ok := req.Action.Value["action"].(string); ok {
+		action.Name = strings.TrimSpace(name)
+	}
+	if req.Context != nil {
+		action.ChatID = req.Context.OpenChatID
+		action.MessageID = req.Context.OpenMessageID
+	}
+	if req.Operator != nil {
+		action.OperatorID = req.Operator.OpenID
+	}
+
+	g.cardActionsMu.RLock()
+	handler := g.cardActions[action.Name]
+	g.cardActionsMu.RUnlock()
+	if handler == nil {
+		g.logger.Warn("Lark card action unhandled: action=%q chat=%s", action.Name, action.ChatID)
+		return cardToast("warning", "该操作已失效。"), nil
+	}
+	toast, err := handler(ctx, action)
+	if err != nil {
+		return nil, err
+	}
+	if toast == "" {
+		return nil, nil
+	}
+	return cardToast("info", toast), nil
+}
+
+func cardToast(kind, content string) *callback.CardActionTriggerResponse {
+	return &callback.CardActionTriggerResponse{Toast: &callback.Toast{Type: kind, Content: content}}
+}
+
+func (g *Gateway) handoffCardAction(ctx context.Context, action *CardAction) (string, error) {
+	sessionID, _ := action.Value["session_id"].(string)
+	g.HandleHandoffAction(ctx, action.ChatID, action.Name, sessionID)
+	return "", nil
+}
+
+// sentMessageIndex remembers which chat recent outbound messages belong to,
+// since message_read callbacks only carry message IDs. Oldest entries are
+// evicted first.
+type sentMessageIndex struct {
+	mu    sync.Mutex
+	max   int
+	chats map[string]string
+	order []string
+}
+
+func newSentMessageIndex(max int) *sentMessageIndex {
+	return &sentMessageIndex{max: max, chats: make(map[string]string, max)}
+}
+
+func (x *sentMessageIndex) record(messageID, chatID string) {
+	if x == nil || messageID == "" || chatID == "" {
+		return
+	}
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	if _, exists := x.chats[messageID]; exists {
+		return
+	}
+	x.chats[messageID] = chatID
+	x.order = append(x.order, messageID)
+	if len(x.order) > x.max {
+		delete(x.chats, x.order[0])
+		x.order = x.order[1:]
+	}
+}
+
+func (x *sentMessageIndex) chatFor(messageID string) (string, bool) {
+	if x == nil {
+		return "", false
+	}
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	chatID, ok := x.chats[messageID]
+	return chatID, ok
+}
@@ -0,0 +1,99 @@
+package lark
+
+import (
+	"context"
+	"fmt"
+
+	agent "alex/internal/domain/agent/ports/agent"
+)
+
+// defaultFollowUpQueueDepth bounds how many follow-up messages a chat can
+// queue behind a running task when they cannot be injected into it.
+const defaultFollowUpQueueDepth = 5
+
+// followUpQueueDepth returns the configured per-chat queue depth.
+func (g *Gateway) followUpQueueDepth() int {
+	if g.cfg.FollowUpQueueDepth > 0 {
+		return g.cfg.FollowUpQueueDepth
+	}
+	return defaultFollowUpQueueDepth
+}
+
+// enqueueFollowUp appends input to the slot's follow-up queue and reports
+// whether it fit. The caller must hold s.mu.
+func (s *sessionSlot) enqueueFollowUp(input agent.UserInput, depth int) bool {
+	if len(s.followUps) >= depth {
+		return false
+	}
+	s.followUps = append(s.followUps, input)
+	return true
+}
+
+// queueFollowUp enqueues a message that could not be injected into the
+// running task and tells the user whether it was queued or rejected.
+func (g *Gateway) queueFollowUp(ctx context.Context, slot *sessionSlot, msg *incomingMessage) {
+	slot.mu.Lock()
+	queued := slot.enqueueFollowUp(agent.UserInput{Content: msg.content, SenderID: msg.senderID, MessageID: msg.messageID}, g.followUpQueueDepth())
+	slot.mu.Unlock()
+	g.replyFollowUpQueued(ctx, msg, queued)
+}
+
+// replyFollowUpQueued acknowledges a queued follow-up, or explains that the
+// queue is full and the message was not kept.
+func (g *Gateway) replyFollowUpQueued(ctx context.Context, msg *incomingMessage, queued bool) {
+	en := detectLang(msg.content) == "en"
+	var notice string
+	switch {
+	case queued && en:
+		notice = "Message received, will be processed after current task."
+	case queued:
+		notice = "消息已收到，等待当前任务处理完毕后执行"
+	case en:
+		notice = fmt.Sprintf("Queue full (%d messages waiting). Please wait for the current task to finish.", g.followUpQueueDepth())
+	default:
+		notice = fmt.Sprintf("排队消息已满（%d 条），请等待当前任务完成后再发送。", g.followUpQueueDepth())
+	}
+	g.dispatchFormattedReply(ctx, msg.chatID, replyTarget(msg.messageID, true), notice)
+}
+
+// dispatchQueuedFollowUp runs after a task ends without awaiting input.
+// Follow-ups that reached the input channel but were never consumed move to
+// the front of the slot's queue, then the oldest queued message is replayed
+// as a new task. The rest wait for that task to finish in turn. Inputs
+// carrying ownMessageID are the task's own seeded resume reply and are
+// dropped rather than replayed.
+func (g *Gateway) dispatchQueuedFollowUp(slot *sessionSlot, ch chan agent.UserInput, chatID, chatType, ownMessageID string) {
+	var leftover []agent.UserInput
+	for drained := false; !drained; {
+		select {
+		case input := <-ch:
+			if ownMessageID != "" && input.MessageID == ownMessageID {
+				continue
+			}
+			leftover = append(leftover, input)
+		default:
+			drained = true
+		}
+	}
+
+	slot.mu.Lock()
+	if len(leftover) > 0 {
+		slot.followUps = append(leftover, slot.followUps...)
+	}
+	// A newer task already claimed the slot; its completion dequeues instead.
+	if slot.phase != slotIdle || len(slot.followUps) == 0 {
+		slot.mu.Unlock()
+		return
+	}
+	next := slot.followUps[0]
+	slot.followUps = slot.followUps[1:]
+	remaining := len(slot.followUps)
+	slot.mu.Unlock()
+
+	g.logger.Info("Dequeued follow-up for chat %s (msg_id=%s, %d still queued)", chatID, next.MessageID, remaining)
+	g.taskWG.Add(1)
+	go func() {
+		defer g.taskWG.Done()
+		g.replayUserInput(chatID, chatType, next)
+	}()
+}
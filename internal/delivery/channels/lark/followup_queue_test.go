@@ -0,0 +1,133 @@
+package lark
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"alex/internal/delivery/channels"
+	agent "alex/internal/domain/agent/ports/agent"
+	storage "alex/internal/domain/agent/ports/storage"
+)
+
+// orderedBlockingExecutor blocks its first run until finish is closed and
+// records every task it is given, in call order.
+type orderedBlockingExecutor struct {
+	mu          sync.Mutex
+	started     chan struct{}
+	finish      chan struct{}
+	startedOnce sync.Once
+	tasks       []string
+}
+
+func (e *orderedBlockingExecutor) EnsureSession(_ context.Context, sessionID string) (*storage.Session, error) {
+	if sessionID == "" {
+		sessionID = "lark-session"
+	}
+	return &storage.Session{ID: sessionID, Metadata: map[string]string{}}, nil
+}
+
+func (e *orderedBlockingExecutor) ExecuteTask(_ context.Context, task string, _ string, _ agent.EventListener) (*agent.TaskResult, error) {
+	e.mu.Lock()
+	e.tasks = append(e.tasks, task)
+	e.mu.Unlock()
+	e.startedOnce.Do(func() {
+		close(e.started)
+	})
+	<-e.finish
+	return &agent.TaskResult{Answer: "done"}, nil
+}
+
+func (e *orderedBlockingExecutor) recordedTasks() []string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return append([]string(nil), e.tasks...)
+}
+
+func TestSessionSlotEnqueueFollowUpRespectsDepth(t *testing.T) {
+	slot := &sessionSlot{}
+	if !slot.enqueueFollowUp(agent.UserInput{Content: "a"}, 2) || !slot.enqueueFollowUp(agent.UserInput{Content: "b"}, 2) {
+		t.Fatal("expected first two follow-ups to fit")
+	}
+	if slot.enqueueFollowUp(agent.UserInput{Content: "c"}, 2) {
+		t.Fatal("expected third follow-up to overflow")
+	}
+	if len(slot.followUps) != 2 || slot.followUps[0].Content != "a" || slot.followUps[1].Content != "b" {
+		t.Fatalf("unexpected queue %+v", slot.followUps)
+	}
+}
+
+func TestFollowUpQueueRunsInOrderAndRejectsOverflow(t *testing.T) {
+	rec := NewRecordingMessenger()
+	executor := &orderedBlockingExecutor{started: make(chan struct{}), finish: make(chan struct{})}
+	gw := newTestGatewayWithMessenger(executor, rec, channels.BaseConfig{SessionPrefix: "lark", AllowDirect: true})
+	gw.cfg.FollowUpQueueDepth = 2
+	chatID := "oc_followup_queue"
+
+	go func() {
+		if err := gw.InjectMessage(context.Background(), chatID, "p2p", "ou_user", "om_first", "first"); err != nil {
+			t.Errorf("InjectMessage failed: %v", err)
+		}
+	}()
+	<-executor.started
+
+	// 16 messages fill the running task's input channel, the next two are
+	// queued and the last one overflows.
+	var want []string
+	for i := 0; i < 19; i++ {
+		content := fmt.Sprintf("follow up %d", i)
+		if err := gw.InjectMessage(context.Background(), chatID, "p2p", "ou_user", fmt.Sprintf("om_follow_%d", i), content); err != nil {
+			t.Fatalf("InjectMessage failed: %v", err)
+		}
+		if i < 18 {
+			want = append(want, content)
+		}
+	}
+
+	var overflowReplies int
+	for _, call := range rec.CallsByMethod("ReplyMessage") {
+		if strings.Contains(call.Content, "Queue full") {
+			overflowReplies++
+		}
+	}
+	if overflowReplies != 1 {
+		t.Fatalf("expected one queue-full reply, got %d", overflowReplies)
+	}
+
+	close(executor.finish)
+	gw.WaitForTasks()
+
+	tasks := executor.recordedTasks()
+	if len(tasks) != len(want)+1 {
+		t.Fatalf("expected %d runs, got %d: %#v", len(want)+1, len(tasks), tasks)
+	}
+	for i, content := range want {
+		if !strings.Contains(tasks[i+1], content) {
+			t.Fatalf("run %d: expected %q, got %q", i+1, content, tasks[i+1])
+		}
+	}
+}
+
+func TestStopCommandClearsFollowUpQueue(t *testing.T) {
+	rec := NewRecordingMessenger()
+	gw := newTestGatewayWithMessenger(&capturingExecutor{result: &agent.TaskResult{Answer: "ok"}}, rec, channels.BaseConfig{SessionPrefix: "lark", AllowDirect: true})
+	chatID := "oc_followup_stop"
+
+	slot := gw.getOrCreateSlot(chatID)
+	slot.mu.Lock()
+	slot.followUps = []agent.UserInput{{Content: "later", MessageID: "om_later"}}
+	slot.mu.Unlock()
+
+	if err := gw.InjectMessage(context.Background(), chatID, "p2p", "ou_user", "om_stop", "/stop"); err != nil {
+		t.Fatalf("InjectMessage failed: %v", err)
+	}
+	gw.WaitForTasks()
+
+	slot.mu.Lock()
+	defer slot.mu.Unlock()
+	if len(slot.followUps) != 0 {
+		t.Fatalf("expected /stop to clear queued follow-ups, got %+v", slot.followUps)
+	}
+}
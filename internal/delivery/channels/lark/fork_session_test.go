@@ -267,13 +267,18 @@ func TestBtwDisabledFallsBackToInject(t *testing.T) {
 
 	time.Sleep(100 * time.Millisecond) // give any spurious goroutine time to appear
 
-	// Unblock parent and wait.
+	// Only 1 ExecuteTask call expected while the parent runs (no fork).
+	if exec.callCount() != 1 {
+		t.Fatalf("expected exactly 1 ExecuteTask call (no fork), got %d; calls=%v", exec.callCount(), exec.allCalls())
+	}
+
+	// Unblock parent and wait. The executor never consumed the injected
+	// message, so it runs as a queued follow-up once the parent completes.
 	close(unblock)
 	gw.WaitForTasks()
 
-	// Only 1 ExecuteTask call expected (no fork).
-	if exec.callCount() != 1 {
-		t.Fatalf("expected exactly 1 ExecuteTask call (no fork), got %d; calls=%v", exec.callCount(), exec.allCalls())
+	if calls := exec.allCalls(); len(calls) != 2 || calls[1] != "injected msg" {
+		t.Fatalf("expected unconsumed input to run after the parent, got %v", calls)
 	}
 }
 
@@ -12,6 +12,7 @@ import (
 	"alex/internal/runtime/hooks"
 	agent "alex/internal/domain/agent/ports/agent"
 	portsllm "alex/internal/domain/agent/ports/llm"
+	"alex/internal/infra/analytics"
 	larkoauth "alex/internal/infra/lark/oauth"
 	builtinshared "alex/internal/infra/tools/builtin/shared"
 	runtimeconfig "alex/internal/shared/config"
@@ -70,6 +71,12 @@ type sessionSlot struct {
 	taskID                 string    // "#1", "#2", etc. — set when allocated from chatSlotMap
 	lastProgressAt         time.Time // updated on every tool event; used for stuck detection
 	lastResultPreview      string    // truncated answer from most recent completed task; used for cross-task references
+	// followUps holds messages that arrived while a task was running and were
+	// not consumed by it, oldest first. Bounded by Config.FollowUpQueueDepth.
+	followUps []agent.UserInput
+	// awaitTimer is the pending reminder or expiry of a bounded
+	// await_user_input stop; see armAwaitTimeout.
+	awaitTimer *time.Timer
 }
 
 const maxSlotProgress = 8
@@ -96,9 +103,11 @@ type Gateway struct {
 	wsClient            *larkws.Client
 	messenger           LarkMessenger
 	eventListener       agent.EventListener
+	analytics           analytics.Client // optional; task lifecycle events
 	dedup               *eventDedup
 	now                 func() time.Time
 	planReviewStore     PlanReviewStore
+	planReviewMu        sync.Mutex // serializes pending plan review take/check
 	oauth               builtinshared.LarkOAuthService
 	llmSelections       *subscription.SelectionStore
 	llmResolver         *subscription.SelectionResolver
@@ -107,10 +116,23 @@ type Gateway struct {
 	llmFactory          portsllm.LLMClientFactory // optional; for lightweight LLM calls (auto-reply)
 	llmProfile          runtimeconfig.LLMProfile  // shared runtime LLM profile for auto-reply
 	taskStore           TaskStore
+	taskDigestMu        sync.Mutex // serializes scheduled and /digest now runs
 	costTracker         CostTrackerReader // optional; for /usage dashboard
 	chatSessionStore    ChatSessionBindingStore
 	deliveryOutboxStore DeliveryOutboxStore
 	noticeState         *noticeStateStore
+	preferences         PreferencesStore // optional; for /prefs command
+	sessionTitles       SessionTitler    // optional; for /title command
+	sessionTools        SessionToolOverrides // optional; for /tools command
+	sessionSearch       SessionSearcher      // optional; for /sessions command
+	notificationDedup   NotificationDeduper // optional; suppresses duplicate in-app notifications
+	maintenance         MaintenanceNotices  // optional; scheduled maintenance notices
+	maintenanceNotices  maintenanceNoticeTracker
+	eventRouter         *EventRouter        // Lark callback routing table (WebSocket + webhook)
+	cardActionsMu       sync.RWMutex
+	cardActions         map[string]CardActionHandler // card button "action" → handler
+	sentMessages        *sentMessageIndex            // outbound message ID → chat, for read receipts
+	threadReplyTargets  *sentMessageIndex            // triggering message ID → chat, for messages answered in-thread
 	activeSlots             sync.Map  // chatID → *sessionSlot
 	activeChatSlots         sync.Map  // chatID → *chatSlotMap (conversation-process path only)
 	chatContexts            sync.Map  // chatID → *chatConversationContext (sliding tool context)
@@ -167,6 +189,7 @@ func NewGateway(cfg Config, agent AgentExecutor, logger logging.Logger) (*Gatewa
 	if cfg.ToolPreset == "" {
 		cfg.ToolPreset = "full"
 	}
+	cfg.PresetAdminRoles = normalizePresetAdminRoles(cfg.PresetAdminRoles)
 	if cfg.BackgroundProgressEnabled == nil {
 		enabled := true
 		cfg.BackgroundProgressEnabled = &enabled
@@ -225,7 +248,7 @@ func NewGateway(cfg Config, agent AgentExecutor, logger logging.Logger) (*Gatewa
 	}
 
 	selectionPath := subscription.ResolveSelectionStorePath(runtimeconfig.DefaultEnvLookup, nil)
-	return &Gateway{
+	gw := &Gateway{
 		cfg:           cfg,
 		agent:         agent,
 		logger:        logger,
@@ -244,12 +267,23 @@ func NewGateway(cfg Config, agent AgentExecutor, logger logging.Logger) (*Gatewa
 		},
 		aiCoordinator: aiCoordinator,
 		attentionGate: NewAttentionGate(cfg.AttentionGate),
-	}, nil
+		eventRouter:   NewEventRouter(cfg.VerificationToken, cfg.EncryptKey, logger),
+		sentMessages:  newSentMessageIndex(defaultSentMessageIndexSize),
+		threadReplyTargets: newSentMessageIndex(defaultSentMessageIndexSize),
+	}
+	gw.registerEventRoutes()
+	if aiCoordinator != nil {
+		aiCoordinator.RegisterRoutes(gw.eventRouter)
+	}
+	return gw, nil
 }
 
 // SetEventListener configures an optional listener to receive workflow events.
 func (g *Gateway) SetEventListener(listener agent.EventListener) { g.eventListener = listener }
 
+// SetAnalyticsClient configures the client that receives task lifecycle events.
+func (g *Gateway) SetAnalyticsClient(client analytics.Client) { g.analytics = client }
+
 // SetPlanReviewStore configures the pending plan review store.
 func (g *Gateway) SetPlanReviewStore(store PlanReviewStore) { g.planReviewStore = store }
 
@@ -272,6 +306,28 @@ func (g *Gateway) SetMessenger(m LarkMessenger) { g.messenger = wrapInjectCaptur
 // SetTaskStore configures the task persistence store.
 func (g *Gateway) SetTaskStore(store TaskStore) { g.taskStore = store }
 
+// SetPreferencesStore configures the per-user preferences store for the /prefs command.
+func (g *Gateway) SetPreferencesStore(store PreferencesStore) { g.preferences = store }
+
+// SetSessionTitler configures the session titler for the /title command.
+func (g *Gateway) SetSessionTitler(titler SessionTitler) { g.sessionTitles = titler }
+
+// SetSessionToolOverrides configures per-session tool overrides for the /tools command.
+func (g *Gateway) SetSessionToolOverrides(overrides SessionToolOverrides) {
+	g.sessionTools = overrides
+}
+
+// SetSessionSearcher configures session search for the /sessions command.
+func (g *Gateway) SetSessionSearcher(searcher SessionSearcher) { g.sessionSearch = searcher }
+
+// SetMaintenanceNotices surfaces scheduled maintenance as one pinned notice
+// per chat.
+func (g *Gateway) SetMaintenanceNotices(notices MaintenanceNotices) { g.maintenance = notices }
+
+// SetNotificationDeduper records Lark-delivered events so the in-app
+// notification center does not notify about them again.
+func (g *Gateway) SetNotificationDeduper(deduper NotificationDeduper) { g.notificationDedup = deduper }
+
 // SetCostTracker configures the cost tracker for the /usage dashboard.
 func (g *Gateway) SetCostTracker(ct CostTrackerReader) { g.costTracker = ct }
 
@@ -319,7 +375,12 @@ func (g *Gateway) NoticeLoader() func() (string, bool, error) {
 }
 
 // SetAIChatCoordinator configures the AI chat coordinator for multi-bot conversations.
-func (g *Gateway) SetAIChatCoordinator(coordinator *AIChatCoordinator) { g.aiCoordinator = coordinator }
+func (g *Gateway) SetAIChatCoordinator(coordinator *AIChatCoordinator) {
+	g.aiCoordinator = coordinator
+	if coordinator != nil {
+		coordinator.RegisterRoutes(g.eventRouter)
+	}
+}
 
 // SetRuntimeBus configures the runtime event bus used for handoff action callbacks.
 func (g *Gateway) SetRuntimeBus(bus hooks.Bus) { g.runtimeBus = bus }
@@ -102,13 +102,23 @@ func (g *Gateway) dispatchMessage(ctx context.Context, chatID, replyToID, msgTyp
 	}
 
 	send := func(currentType, currentContent string) (string, error) {
-		if prefersStandaloneLarkMessage(currentType) {
-			return g.messenger.SendMessage(ctx, chatID, currentType, currentContent)
+		var (
+			mid string
+			err error
+		)
+		switch {
+		case prefersStandaloneLarkMessage(currentType) || replyToID == "":
+			mid, err = g.messenger.SendMessage(ctx, chatID, currentType, currentContent)
+		case g.repliesInThread(replyToID):
+			mid, err = g.messenger.(threadReplier).ReplyMessageInThread(ctx, replyToID, currentType, currentContent)
+		default:
+			mid, err = g.messenger.ReplyMessage(ctx, replyToID, currentType, currentContent)
 		}
-		if replyToID != "" {
-			return g.messenger.ReplyMessage(ctx, replyToID, currentType, currentContent)
+		if err == nil {
+			// Remembered so message_read callbacks can be traced to the chat.
+			g.sentMessages.record(mid, chatID)
 		}
-		return g.messenger.SendMessage(ctx, chatID, currentType, currentContent)
+		return mid, err
 	}
 
 	messageID, err := send(msgType, content)
@@ -189,6 +199,17 @@ func (g *Gateway) dispatch(ctx context.Context, chatID, replyToID, msgType, cont
 	g.logger.Info("dispatch: SENT chat=%s reply_to=%s type=%s sent_msg=%s preview=%s", chatID, replyToID, msgType, mid, truncateForLark(content, 80))
 }
 
+// repliesInThread reports whether replies to messageID belong in a thread:
+// the message triggered a mention-gated group task and the messenger
+// supports thread replies.
+func (g *Gateway) repliesInThread(messageID string) bool {
+	if _, ok := g.messenger.(threadReplier); !ok {
+		return false
+	}
+	_, ok := g.threadReplyTargets.chatFor(messageID)
+	return ok
+}
+
 // replyTarget returns the message ID to reply to when allowed.
 // An empty ID or disallowed replies indicates no reply target.
 func replyTarget(messageID string, allowReply bool) string {
@@ -23,7 +23,9 @@ type incomingMessage struct {
 	content             string
 	isGroup             bool
 	isFromBot           bool
-	aiChatSessionActive bool // true if this message is part of an AI chat session
+	synthesized         bool              // generated by the gateway (await timeout), not sent by the user
+	aiChatSessionActive bool              // true if this message is part of an AI chat session
+	resources           []inboundResource // images and files to download before the task runs
 }
 
 // isResultAwaitingInput reports whether the task result indicates an
@@ -33,7 +35,9 @@ func isResultAwaitingInput(result *agent.TaskResult) bool {
 }
 
 type messageProcessingOptions struct {
-	skipDedup bool
+	skipDedup       bool
+	skipMentionGate bool // replayed input already passed the RequireMention gate
+	synthesized     bool // input generated by the gateway rather than the user
 }
 
 // handleMessage is the P2MessageReceiveV1 event handler.
@@ -51,6 +55,7 @@ func (g *Gateway) handleMessageWithOptions(ctx context.Context, event *larkim.P2
 	ctx = id.WithLogID(ctx, logID)
 	msgLogger := logging.WithLogID(g.logger, logID)
 	msgLogger.Info("Lark message received: chat_id=%s msg_id=%s sender=%s group=%t len=%d", msg.chatID, msg.messageID, msg.senderID, msg.isGroup, len(msg.content))
+	g.surfaceMaintenanceNotice(ctx, msg.chatID)
 
 	// AI Chat Coordination: Check if this is a multi-bot chat scenario
 	if g.aiCoordinator != nil && msg.isGroup {
@@ -83,72 +88,21 @@ func (g *Gateway) handleMessageWithOptions(ctx context.Context, event *larkim.P2
 	slot.lastTouched = g.currentTime()
 	trimmedContent := strings.TrimSpace(msg.content)
 
-	// When conversation process is enabled, only /new, /reset, /model are
-	// handled as direct commands. Everything else (task queries, usage,
-	// notice, stop, natural language) goes through the conversation LLM.
-	if g.conversationProcessEnabled() {
-		if trimmedContent == "/new" {
-			g.handleNewSessionCommand(slot, msg) // releases slot.mu
-			return nil
-		}
-		if trimmedContent == "/reset" {
-			g.handleResetCommand(slot, msg) // releases slot.mu
-			return nil
-		}
-		if strings.HasPrefix(trimmedContent, "/model") {
-			slot.mu.Unlock()
-			g.handleModelCommand(msg)
-			return nil
-		}
-		slot.mu.Unlock()
-		msgLogger.Info("message routed: conversation_process=true msg=%s", msg.messageID)
-		g.handleViaConversationProcess(ctx, msg)
+	if g.routeCommand(slot, msg, trimmedContent) {
 		return nil
 	}
 
-	// Legacy path: direct command routing when conversation process is disabled.
-
-	// Natural-language status query should be handled immediately, even when a
-	// foreground task is currently running.
-	if g.isNaturalTaskStatusQuery(trimmedContent) {
-		slot.mu.Unlock()
-		g.handleNaturalTaskStatusQuery(msg)
-		return nil
-	}
-	if g.isNoticeCommand(trimmedContent) {
-		slot.mu.Unlock()
-		g.handleNoticeCommand(msg)
-		return nil
-	}
-	if g.isUsageCommand(trimmedContent) {
+	// When conversation process is enabled everything that is not a direct
+	// command (task queries, usage, notice, stop, natural language) goes
+	// through the conversation LLM.
+	if g.conversationProcessEnabled() {
 		slot.mu.Unlock()
-		g.handleUsageCommand(msg)
-		return nil
-	}
-	if g.isStopCommand(trimmedContent) {
-		g.handleStopCommand(slot, msg) // releases slot.mu
-		return nil
-	}
-
-	// Handle /new and /reset before in-flight input injection so command
-	// intent is not swallowed by the running task input channel.
-	if trimmedContent == "/new" {
-		g.handleNewSessionCommand(slot, msg) // releases slot.mu
-		return nil
-	}
-
-	if trimmedContent == "/reset" {
-		g.handleResetCommand(slot, msg) // releases slot.mu
+		msgLogger.Info("message routed: conversation_process=true msg=%s", msg.messageID)
+		g.handleViaConversationProcess(ctx, msg)
 		return nil
 	}
 
-	// /model command must be handled before dispatch so it works
-	// in chat+worker mode without being routed through the chat LLM.
-	if strings.HasPrefix(trimmedContent, "/model") {
-		slot.mu.Unlock()
-		g.handleModelCommand(msg)
-		return nil
-	}
+	// Legacy path: task dispatch when conversation process is disabled.
 
 	// If a task is already running for this chat, either inject the new message
 	// into the running ReAct loop or fork a child session (btw mode).
@@ -164,7 +118,8 @@ func (g *Gateway) handleMessageWithOptions(ctx context.Context, event *larkim.P2
 			case ch <- agent.UserInput{Content: msg.content, SenderID: msg.senderID, MessageID: msg.messageID}:
 				g.logger.Info("btw disabled: injecting message into running session %s", activeSessionID)
 			default:
-				g.logger.Warn("btw disabled: inputCh full, dropping message for session %s", activeSessionID)
+				g.logger.Warn("btw disabled: inputCh full, queueing message for session %s", activeSessionID)
+				g.queueFollowUp(ctx, slot, msg)
 			}
 			return nil
 		}
@@ -276,7 +231,9 @@ func (g *Gateway) launchWorkerGoroutine(msg *incomingMessage, slot *sessionSlot,
 		awaitingInput, _ := g.runTask(taskCtx, msg, sessionID, inputCh, isResume, taskToken)
 
 		slot.mu.Lock()
-		if slot.taskToken == taskToken {
+		current := slot.taskToken == taskToken
+		stopped := current && slot.intentionalCancelToken == taskToken
+		if current {
 			if slot.intentionalCancelToken == taskToken {
 				slot.intentionalCancelToken = 0
 			}
@@ -293,10 +250,13 @@ func (g *Gateway) launchWorkerGoroutine(msg *incomingMessage, slot *sessionSlot,
 			slot.lastTouched = g.currentTime()
 		}
 		slot.mu.Unlock()
-		if awaitingInput {
+		switch {
+		case awaitingInput:
 			g.drainAndReprocess(inputCh, msg.chatID, msg.chatType, taskToken)
-		} else {
+		case !current || stopped:
 			g.discardPendingInputs(inputCh, msg.chatID)
+		default:
+			g.dispatchQueuedFollowUp(slot, inputCh, msg.chatID, msg.chatType, msg.messageID)
 		}
 	}(taskCtx, taskCancel, taskToken)
 }
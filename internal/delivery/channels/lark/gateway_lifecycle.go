@@ -8,7 +8,6 @@ import (
 	lark "github.com/larksuite/oapi-sdk-go/v3"
 	larkcore "github.com/larksuite/oapi-sdk-go/v3/core"
 	"github.com/larksuite/oapi-sdk-go/v3/event/dispatcher"
-	larkim "github.com/larksuite/oapi-sdk-go/v3/service/im/v1"
 	larkws "github.com/larksuite/oapi-sdk-go/v3/ws"
 )
 
@@ -45,6 +44,7 @@ func (g *Gateway) Start(ctx context.Context) error {
 	g.messenger = wrapInjectCaptureHub(g.messenger)
 	g.startDeliveryWorker(runCtx)
 	g.startDrainQueueTimer(runCtx)
+	g.startTaskDigestScheduler(runCtx)
 
 	// Build the event dispatcher (shared across reconnections).
 	eventDispatcher := g.buildEventDispatcher()
@@ -55,27 +55,10 @@ func (g *Gateway) Start(ctx context.Context) error {
 	return err
 }
 
-// buildEventDispatcher creates the Lark event dispatcher with all registered handlers.
+// buildEventDispatcher creates the SDK event dispatcher from the gateway's
+// routing table. Routes must be registered before Start.
 func (g *Gateway) buildEventDispatcher() *dispatcher.EventDispatcher {
-	eventDispatcher := dispatcher.NewEventDispatcher("", "")
-	eventDispatcher.OnP2MessageReceiveV1(g.handleMessage)
-
-	// Register no-op handlers for events we intentionally ignore.
-	// Without these, the SDK logs "unhandled event" warnings on every
-	// reaction, read receipt, and bot-entered notification.
-	eventDispatcher.OnP2MessageReactionCreatedV1(func(_ context.Context, _ *larkim.P2MessageReactionCreatedV1) error {
-		return nil
-	})
-	eventDispatcher.OnP2MessageReactionDeletedV1(func(_ context.Context, _ *larkim.P2MessageReactionDeletedV1) error {
-		return nil
-	})
-	eventDispatcher.OnP2ChatAccessEventBotP2pChatEnteredV1(func(_ context.Context, _ *larkim.P2ChatAccessEventBotP2pChatEnteredV1) error {
-		return nil
-	})
-	eventDispatcher.OnP2MessageReadV1(func(_ context.Context, _ *larkim.P2MessageReadV1) error {
-		return nil
-	})
-	return eventDispatcher
+	return g.eventRouter.sdkDispatcher()
 }
 
 // wsConnectLoop connects the WebSocket client and automatically reconnects
@@ -131,6 +114,7 @@ func (g *Gateway) Stop() {
 		g.attentionGate.StopDrainTimer()
 	}
 	g.stopStateCleanupLoop()
+	g.stopAwaitTimers()
 }
 
 // NotifyRunningTaskInterruptions cancels in-flight foreground tasks and sends
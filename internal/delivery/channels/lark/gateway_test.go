@@ -805,7 +805,7 @@ func TestHandleMessageReusesAwaitingSession(t *testing.T) {
 	}
 }
 
-func TestHandleMessageDropsInFlightFollowUpWhenRunCompletes(t *testing.T) {
+func TestHandleMessageQueuesInFlightFollowUpWhenRunCompletes(t *testing.T) {
 	openID := "ou_sender_inflight"
 	chatID := "oc_chat_inflight"
 	msgID := "om_msg_inflight"
@@ -885,16 +885,15 @@ func TestHandleMessageDropsInFlightFollowUpWhenRunCompletes(t *testing.T) {
 	wg.Wait()
 	gw.WaitForTasks()
 
-	time.Sleep(100 * time.Millisecond)
 	executor.mu.Lock()
 	finalCalls := executor.callCount
 	executor.mu.Unlock()
-	if finalCalls != 1 {
-		t.Fatalf("expected no automatic reprocess after completed run, got %d calls", finalCalls)
+	if finalCalls != 2 {
+		t.Fatalf("expected queued follow-up to run after completion, got %d calls", finalCalls)
 	}
 }
 
-func TestHandleMessageDropsInFlightFollowUpForGroupChat(t *testing.T) {
+func TestHandleMessageQueuesInFlightFollowUpForGroupChat(t *testing.T) {
 	openID := "ou_sender_inflight_group"
 	chatID := "oc_chat_inflight_group"
 	msgID := "om_msg_inflight_group"
@@ -974,12 +973,11 @@ func TestHandleMessageDropsInFlightFollowUpForGroupChat(t *testing.T) {
 	wg.Wait()
 	gw.WaitForTasks()
 
-	time.Sleep(100 * time.Millisecond)
 	executor.mu.Lock()
 	finalCalls := executor.callCount
 	executor.mu.Unlock()
-	if finalCalls != 1 {
-		t.Fatalf("expected no automatic reprocess for completed group run, got %d calls", finalCalls)
+	if finalCalls != 2 {
+		t.Fatalf("expected queued follow-up to run after completion, got %d calls", finalCalls)
 	}
 }
 
@@ -1449,7 +1447,7 @@ func TestHandleMessageSeedsPendingUserInput(t *testing.T) {
 	}
 }
 
-func TestHandleMessageSendsPlanReviewTextWhenEnabled(t *testing.T) {
+func TestHandleMessageSendsPlanReviewCardWhenEnabled(t *testing.T) {
 	openID := "ou_sender_plan"
 	chatID := "oc_chat_plan"
 	msgID := "om_msg_plan"
@@ -1510,12 +1508,11 @@ func TestHandleMessageSendsPlanReviewTextWhenEnabled(t *testing.T) {
 	if len(calls) == 0 {
 		t.Fatal("expected a reply message")
 	}
-	if calls[0].MsgType != "text" {
-		t.Fatalf("expected text reply, got %q", calls[0].MsgType)
+	if calls[0].MsgType != "interactive" {
+		t.Fatalf("expected plan review card, got %q", calls[0].MsgType)
 	}
-	replyText := extractTextContent(calls[0].Content, nil)
-	if !strings.Contains(replyText, "goal-9") {
-		t.Fatalf("expected plan review goal in reply, got %q", replyText)
+	if !strings.Contains(calls[0].Content, "goal-9") || !strings.Contains(calls[0].Content, cardActionPlanReviewApprove) {
+		t.Fatalf("expected plan review goal and approve button in card, got %q", calls[0].Content)
 	}
 }
 
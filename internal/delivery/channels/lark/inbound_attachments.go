@@ -0,0 +1,185 @@
+package lark
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	appcontext "alex/internal/app/agent/context"
+	ports "alex/internal/domain/agent/ports"
+	"alex/internal/shared/utils"
+)
+
+// maxInboundAttachmentBytes caps a single image or file downloaded from a
+// user message. Larger resources are skipped with an error reply.
+const maxInboundAttachmentBytes = 20 << 20
+
+// inboundResource identifies an image or file attached to a received message.
+type inboundResource struct {
+	Key  string // image_key or file_key
+	Type string // "image" or "file", as the message resource API expects
+	Name string // original file name; empty for images
+}
+
+type larkImagePayload struct {
+	ImageKey string `json:"image_key"`
+}
+
+type larkFilePayload struct {
+	FileKey  string `json:"file_key"`
+	FileName string `json:"file_name"`
+}
+
+// extractInboundResources collects the downloadable resources of an image,
+// file or post message. Post messages contribute their embedded images.
+func extractInboundResources(msgType, raw string) []inboundResource {
+	switch msgType {
+	case "image":
+		var parsed larkImagePayload
+		if err := json.Unmarshal([]byte(raw), &parsed); err != nil || utils.IsBlank(parsed.ImageKey) {
+			return nil
+		}
+		return []inboundResource{{Key: strings.TrimSpace(parsed.ImageKey), Type: "image"}}
+	case "file":
+		var parsed larkFilePayload
+		if err := json.Unmarshal([]byte(raw), &parsed); err != nil || utils.IsBlank(parsed.FileKey) {
+			return nil
+		}
+		return []inboundResource{{Key: strings.TrimSpace(parsed.FileKey), Type: "file", Name: strings.TrimSpace(parsed.FileName)}}
+	case "post":
+		parsed, ok := parseLarkPostPayload(raw)
+		if !ok {
+			return nil
+		}
+		var resources []inboundResource
+		for _, line := range parsed.Content {
+			for _, el := range line {
+				if el.Tag == "img" && utils.HasContent(el.ImageKey) {
+					resources = append(resources, inboundResource{Key: strings.TrimSpace(el.ImageKey), Type: "image"})
+				}
+			}
+		}
+		return resources
+	}
+	return nil
+}
+
+// inboundResourcePlaceholder renders resources as message text for messages
+// that carry no text of their own, matching the chat history rendering.
+func inboundResourcePlaceholder(resources []inboundResource) string {
+	parts := make([]string, 0, len(resources))
+	for _, res := range resources {
+		if res.Type == "file" && res.Name != "" {
+			parts = append(parts, fmt.Sprintf("[file: %s]", res.Name))
+			continue
+		}
+		parts = append(parts, fmt.Sprintf("[%s]", res.Type))
+	}
+	return strings.Join(parts, " ")
+}
+
+// attachInboundResources downloads the message's images and files and hands
+// them to the agent as user attachments. References are appended to
+// taskContent so the agent can cite them by name. Oversized or failed
+// downloads are reported to the user and skipped; the task still runs.
+func (g *Gateway) attachInboundResources(ctx context.Context, msg *incomingMessage, taskContent string) (context.Context, string) {
+	if len(msg.resources) == 0 {
+		return ctx, taskContent
+	}
+	downloader, ok := g.messenger.(messageResourceDownloader)
+	if !ok {
+		g.logger.Warn("Lark messenger cannot download message resources; dropping %d attachment(s)", len(msg.resources))
+		return ctx, taskContent
+	}
+
+	var attachments []ports.Attachment
+	var failures []string
+	for i, res := range msg.resources {
+		payload, fileName, err := downloader.DownloadMessageResource(ctx, msg.messageID, res.Key, res.Type)
+		label := res.Name
+		if label == "" {
+			label = res.Type
+		}
+		if err != nil {
+			g.logger.Warn("Lark resource download failed (msg_id=%s key=%s): %v", msg.messageID, res.Key, err)
+			failures = append(failures, fmt.Sprintf("%s 下载失败", label))
+			continue
+		}
+		if len(payload) > maxInboundAttachmentBytes {
+			failures = append(failures, fmt.Sprintf("%s 超过 %dMB 大小限制", label, maxInboundAttachmentBytes>>20))
+			continue
+		}
+		attachments = append(attachments, buildInboundAttachment(res, payload, fileName, i))
+	}
+
+	if len(failures) > 0 {
+		reply := "以下附件未能处理：\n" + strings.Join(failures, "\n")
+		g.dispatch(ctx, msg.chatID, replyTarget(msg.messageID, true), "text", textContent(reply))
+	}
+	if len(attachments) == 0 {
+		return ctx, taskContent
+	}
+
+	ctx = appcontext.WithUserAttachments(ctx, attachments)
+	if taskContent == "" {
+		return ctx, taskContent
+	}
+	refs := make([]string, 0, len(attachments))
+	for _, att := range attachments {
+		refs = append(refs, fmt.Sprintf("[%s]", att.Name))
+	}
+	return ctx, taskContent + "\n\nAttachments: " + strings.Join(refs, " ")
+}
+
+// buildInboundAttachment converts a downloaded resource into an inline
+// attachment. The media type comes from the file extension when there is
+// one and is sniffed from the payload otherwise.
+func buildInboundAttachment(res inboundResource, payload []byte, fileName string, index int) ports.Attachment {
+	name := strings.TrimSpace(res.Name)
+	if name == "" {
+		name = strings.TrimSpace(fileName)
+	}
+
+	mediaType := ""
+	if ext := filepath.Ext(name); ext != "" {
+		mediaType = mime.TypeByExtension(strings.ToLower(ext))
+	}
+	if mediaType == "" {
+		mediaType = http.DetectContentType(payload)
+	}
+	if idx := strings.Index(mediaType, ";"); idx >= 0 {
+		mediaType = strings.TrimSpace(mediaType[:idx])
+	}
+
+	if name == "" {
+		name = fmt.Sprintf("%s-%d%s", res.Type, index+1, inboundImageExtension(mediaType))
+	}
+	return ports.Attachment{
+		Name:      name,
+		MediaType: mediaType,
+		Data:      base64.StdEncoding.EncodeToString(payload),
+		Source:    "user_upload",
+	}
+}
+
+func inboundImageExtension(mediaType string) string {
+	switch mediaType {
+	case "image/png":
+		return ".png"
+	case "image/jpeg":
+		return ".jpg"
+	case "image/gif":
+		return ".gif"
+	case "image/webp":
+		return ".webp"
+	case "image/bmp":
+		return ".bmp"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,138 @@
+package lark
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	appcontext "alex/internal/app/agent/context"
+	"alex/internal/delivery/channels"
+	agent "alex/internal/domain/agent/ports/agent"
+
+	larkim "github.com/larksuite/oapi-sdk-go/v3/service/im/v1"
+)
+
+var testPNG = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+func TestExtractInboundResources(t *testing.T) {
+	tests := []struct {
+		name    string
+		msgType string
+		raw     string
+		want    []inboundResource
+	}{
+		{"image", "image", `{"image_key":"img_1"}`, []inboundResource{{Key: "img_1", Type: "image"}}},
+		{"file", "file", `{"file_key":"file_1","file_name":"report.pdf"}`, []inboundResource{{Key: "file_1", Type: "file", Name: "report.pdf"}}},
+		{"post images", "post", `{"zh_cn":{"content":[[{"tag":"text","text":"look"},{"tag":"img","image_key":"img_a"}],[{"tag":"img","image_key":"img_b"}]]}}`,
+			[]inboundResource{{Key: "img_a", Type: "image"}, {Key: "img_b", Type: "image"}}},
+		{"post text only", "post", `{"content":[[{"tag":"text","text":"hi"}]]}`, nil},
+		{"missing key", "image", `{}`, nil},
+		{"text", "text", `{"text":"hi"}`, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := extractInboundResources(tt.msgType, tt.raw)
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %+v, want %+v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("resource %d: got %+v, want %+v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func sendTestEvent(t *testing.T, gw *Gateway, msgType, content string) {
+	t.Helper()
+	chatID, chatType, msgID, openID := "oc_attach", "p2p", "om_attach", "ou_user"
+	event := &larkim.P2MessageReceiveV1{
+		Event: &larkim.P2MessageReceiveV1Data{
+			Message: &larkim.EventMessage{
+				MessageType: &msgType,
+				ChatType:    &chatType,
+				ChatId:      &chatID,
+				MessageId:   &msgID,
+				Content:     &content,
+			},
+			Sender: &larkim.EventSender{SenderId: &larkim.UserId{OpenId: &openID}},
+		},
+	}
+	if err := gw.handleMessage(context.Background(), event); err != nil {
+		t.Fatalf("handleMessage failed: %v", err)
+	}
+	gw.WaitForTasks()
+}
+
+func TestImageMessageReachesAgentAsAttachment(t *testing.T) {
+	rec := NewRecordingMessenger()
+	rec.SetResource("img_1", testPNG, "")
+	executor := &capturingExecutor{result: &agent.TaskResult{Answer: "ok"}}
+	gw := newTestGatewayWithMessenger(executor, rec, channels.BaseConfig{SessionPrefix: "lark", AllowDirect: true})
+
+	sendTestEvent(t, gw, "image", `{"image_key":"img_1"}`)
+
+	if executor.capturedCtx == nil {
+		t.Fatal("expected ExecuteTask to be called")
+	}
+	atts := appcontext.GetUserAttachments(executor.capturedCtx)
+	if len(atts) != 1 {
+		t.Fatalf("expected one attachment, got %+v", atts)
+	}
+	if atts[0].Name != "image-1.png" || atts[0].MediaType != "image/png" || atts[0].Source != "user_upload" || atts[0].Data == "" {
+		t.Fatalf("unexpected attachment %+v", atts[0])
+	}
+	if !strings.Contains(executor.capturedTask, "[image-1.png]") {
+		t.Fatalf("expected attachment reference in task, got %q", executor.capturedTask)
+	}
+	downloads := rec.CallsByMethod(MethodDownloadResource)
+	if len(downloads) != 1 || downloads[0].MsgID != "om_attach" || downloads[0].FileType != "image" {
+		t.Fatalf("unexpected download calls %+v", downloads)
+	}
+}
+
+func TestPostMessageCollectsEmbeddedImages(t *testing.T) {
+	rec := NewRecordingMessenger()
+	rec.SetResource("img_a", testPNG, "")
+	executor := &capturingExecutor{result: &agent.TaskResult{Answer: "ok"}}
+	gw := newTestGatewayWithMessenger(executor, rec, channels.BaseConfig{SessionPrefix: "lark", AllowDirect: true})
+
+	sendTestEvent(t, gw, "post", `{"zh_cn":{"content":[[{"tag":"text","text":"what is this chart"},{"tag":"img","image_key":"img_a"}]]}}`)
+
+	if !strings.Contains(executor.capturedTask, "what is this chart") {
+		t.Fatalf("expected post text in task, got %q", executor.capturedTask)
+	}
+	if atts := appcontext.GetUserAttachments(executor.capturedCtx); len(atts) != 1 {
+		t.Fatalf("expected embedded image attachment, got %+v", atts)
+	}
+}
+
+func TestOversizedFileIsRejectedWithReply(t *testing.T) {
+	rec := NewRecordingMessenger()
+	rec.SetResource("file_big", make([]byte, maxInboundAttachmentBytes+1), "big.pdf")
+	executor := &capturingExecutor{result: &agent.TaskResult{Answer: "ok"}}
+	gw := newTestGatewayWithMessenger(executor, rec, channels.BaseConfig{SessionPrefix: "lark", AllowDirect: true})
+
+	sendTestEvent(t, gw, "file", `{"file_key":"file_big","file_name":"big.pdf"}`)
+
+	if atts := appcontext.GetUserAttachments(executor.capturedCtx); len(atts) != 0 {
+		t.Fatalf("expected oversized file to be dropped, got %+v", atts)
+	}
+	var rejected bool
+	for _, call := range rec.CallsByMethod(MethodReplyMessage) {
+		if strings.Contains(call.Content, "big.pdf 超过 20MB") {
+			rejected = true
+		}
+	}
+	if !rejected {
+		t.Fatalf("expected size limit reply, got %+v", rec.CallsByMethod(MethodReplyMessage))
+	}
+}
+
+func TestBuildInboundAttachmentUsesFileExtension(t *testing.T) {
+	att := buildInboundAttachment(inboundResource{Key: "file_1", Type: "file", Name: "notes.txt"}, []byte("hello"), "", 0)
+	if att.Name != "notes.txt" || att.MediaType != "text/plain" {
+		t.Fatalf("unexpected attachment %+v", att)
+	}
+}
@@ -55,6 +55,24 @@ func (h *injectCaptureHub) ReplyMessage(ctx context.Context, replyToID, msgType,
 	return id, err
 }
 
+// ReplyMessageInThread forwards to the inner messenger's thread reply when
+// supported and falls back to a plain reply otherwise.
+func (h *injectCaptureHub) ReplyMessageInThread(ctx context.Context, replyToID, msgType, content string) (string, error) {
+	replyToID = strings.TrimSpace(replyToID)
+	h.mu.RLock()
+	synthetic := h.syntheticChat[h.messageToChat[replyToID]]
+	h.mu.RUnlock()
+	replier, ok := h.inner.(threadReplier)
+	if synthetic || !ok {
+		return h.ReplyMessage(ctx, replyToID, msgType, content)
+	}
+
+	id, err := replier.ReplyMessageInThread(ctx, replyToID, msgType, content)
+	h.recordAll(MessengerCall{Method: "ReplyMessage", ReplyTo: replyToID, MsgType: msgType, Content: content, InThread: true})
+	h.recordSyntheticReply(replyToID, id, msgType, content, time.Now())
+	return id, err
+}
+
 func (h *injectCaptureHub) UpdateMessage(ctx context.Context, messageID, msgType, content string) error {
 	messageID = strings.TrimSpace(messageID)
 	h.mu.RLock()
@@ -73,6 +91,51 @@ func (h *injectCaptureHub) UpdateMessage(ctx context.Context, messageID, msgType
 	return err
 }
 
+// PinMessage forwards to the inner messenger when it supports pinning.
+// Synthetic inject chats have nothing to pin.
+func (h *injectCaptureHub) PinMessage(ctx context.Context, messageID string) error {
+	pinner, ok := h.inner.(messagePinner)
+	if !ok || h.isSyntheticMessage(messageID) {
+		return nil
+	}
+	return pinner.PinMessage(ctx, messageID)
+}
+
+// UnpinMessage forwards to the inner messenger when it supports pinning.
+func (h *injectCaptureHub) UnpinMessage(ctx context.Context, messageID string) error {
+	pinner, ok := h.inner.(messagePinner)
+	if !ok || h.isSyntheticMessage(messageID) {
+		return nil
+	}
+	return pinner.UnpinMessage(ctx, messageID)
+}
+
+// DownloadMessageResource forwards to the inner messenger when it supports
+// resource downloads.
+func (h *injectCaptureHub) DownloadMessageResource(ctx context.Context, messageID, fileKey, resourceType string) ([]byte, string, error) {
+	downloader, ok := h.inner.(messageResourceDownloader)
+	if !ok {
+		return nil, "", fmt.Errorf("lark messenger does not support resource download")
+	}
+	return downloader.DownloadMessageResource(ctx, messageID, fileKey, resourceType)
+}
+
+// ChatAdmins forwards to the inner messenger when it can look up group
+// admins.
+func (h *injectCaptureHub) ChatAdmins(ctx context.Context, chatID string) (string, []string, error) {
+	lister, ok := h.inner.(chatAdminLister)
+	if !ok {
+		return "", nil, fmt.Errorf("lark messenger does not support chat admin lookup")
+	}
+	return lister.ChatAdmins(ctx, chatID)
+}
+
+func (h *injectCaptureHub) isSyntheticMessage(messageID string) bool {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.syntheticChat[h.messageToChat[strings.TrimSpace(messageID)]]
+}
+
 func (h *injectCaptureHub) AddReaction(ctx context.Context, messageID, emojiType string) (string, error) {
 	messageID = strings.TrimSpace(messageID)
 	h.mu.RLock()
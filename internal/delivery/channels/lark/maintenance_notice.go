@@ -0,0 +1,118 @@
+package lark
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"alex/internal/app/maintenance"
+)
+
+const maintenanceNoticeTimeLayout = "2006-01-02 15:04 UTC"
+
+// MaintenanceNotices reports the current scheduled-maintenance notice.
+type MaintenanceNotices interface {
+	Notice() (maintenance.Notice, bool)
+}
+
+// maintenanceNoticeState is the notice last posted to one chat.
+type maintenanceNoticeState struct {
+	windowID  string
+	phase     maintenance.Phase
+	messageID string
+}
+
+// maintenanceNoticeTracker remembers which notice each chat has seen so a
+// window is announced once per chat rather than on every message.
+type maintenanceNoticeTracker struct {
+	mu    sync.Mutex
+	chats map[string]maintenanceNoticeState
+}
+
+// surfaceMaintenanceNotice posts and pins the current maintenance notice the
+// first time chatID is active during a window's lead time. When the window
+// starts the same message is edited in place, and once the window ends or is
+// cancelled the notice is unpinned.
+func (g *Gateway) surfaceMaintenanceNotice(ctx context.Context, chatID string) {
+	if g.maintenance == nil || chatID == "" {
+		return
+	}
+	notice, ok := g.maintenance.Notice()
+
+	tracker := &g.maintenanceNotices
+	tracker.mu.Lock()
+	if tracker.chats == nil {
+		tracker.chats = make(map[string]maintenanceNoticeState)
+	}
+	prev, had := tracker.chats[chatID]
+	switch {
+	case !ok && !had:
+		tracker.mu.Unlock()
+		return
+	case !ok:
+		delete(tracker.chats, chatID)
+		tracker.mu.Unlock()
+		g.unpinMaintenanceNotice(ctx, prev.messageID)
+		return
+	case had && prev.windowID == notice.WindowID && prev.phase == notice.Phase:
+		tracker.mu.Unlock()
+		return
+	}
+	sameWindow := had && prev.windowID == notice.WindowID && prev.messageID != ""
+	next := maintenanceNoticeState{windowID: notice.WindowID, phase: notice.Phase}
+	if sameWindow {
+		next.messageID = prev.messageID
+	}
+	// Claim the chat before sending so concurrent messages do not post twice.
+	tracker.chats[chatID] = next
+	tracker.mu.Unlock()
+
+	content := textContent(formatMaintenanceNotice(notice))
+	if sameWindow {
+		if err := g.updateMessage(ctx, prev.messageID, "text", content); err == nil {
+			return
+		}
+		g.logger.Warn("Lark maintenance notice update failed for chat=%s; posting a new notice", chatID)
+	}
+	if had {
+		g.unpinMaintenanceNotice(ctx, prev.messageID)
+	}
+	messageID, err := g.dispatchMessage(ctx, chatID, "", "text", content)
+	if err != nil {
+		g.logger.Warn("Lark maintenance notice send failed for chat=%s: %v", chatID, err)
+		tracker.mu.Lock()
+		delete(tracker.chats, chatID)
+		tracker.mu.Unlock()
+		return
+	}
+	tracker.mu.Lock()
+	if state, ok := tracker.chats[chatID]; ok && state.windowID == next.windowID {
+		state.messageID = messageID
+		tracker.chats[chatID] = state
+	}
+	tracker.mu.Unlock()
+	if pinner, ok := g.messenger.(messagePinner); ok && messageID != "" {
+		if err := pinner.PinMessage(ctx, messageID); err != nil {
+			g.logger.Warn("Lark maintenance notice pin failed for chat=%s: %v", chatID, err)
+		}
+	}
+}
+
+func (g *Gateway) unpinMaintenanceNotice(ctx context.Context, messageID string) {
+	if messageID == "" {
+		return
+	}
+	if pinner, ok := g.messenger.(messagePinner); ok {
+		if err := pinner.UnpinMessage(ctx, messageID); err != nil {
+			g.logger.Warn("Lark maintenance notice unpin failed for msg=%s: %v", messageID, err)
+		}
+	}
+}
+
+func formatMaintenanceNotice(notice maintenance.Notice) string {
+	window := fmt.Sprintf("%s – %s", notice.StartsAt.UTC().Format(maintenanceNoticeTimeLayout), notice.EndsAt.UTC().Format(maintenanceNoticeTimeLayout))
+	if notice.Phase == maintenance.PhaseActive {
+		return fmt.Sprintf("🔧 系统维护中：%s\n维护时间：%s\n维护期间服务可能暂不可用，结束后自动恢复。", notice.Message, window)
+	}
+	return fmt.Sprintf("🔧 维护预告：%s\n维护时间：%s", notice.Message, window)
+}
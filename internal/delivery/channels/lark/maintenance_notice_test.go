@@ -0,0 +1,87 @@
+package lark
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"alex/internal/app/maintenance"
+	"alex/internal/shared/logging"
+)
+
+type stubMaintenanceNotices struct {
+	notice maintenance.Notice
+	ok     bool
+}
+
+func (s *stubMaintenanceNotices) Notice() (maintenance.Notice, bool) { return s.notice, s.ok }
+
+func TestSurfaceMaintenanceNoticePostsOncePerChat(t *testing.T) {
+	start := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	notices := &stubMaintenanceNotices{ok: true, notice: maintenance.Notice{
+		WindowID: "mw-1",
+		Phase:    maintenance.PhaseUpcoming,
+		Message:  "database upgrade",
+		StartsAt: start,
+		EndsAt:   start.Add(time.Hour),
+	}}
+	recorder := NewRecordingMessenger()
+	gw := &Gateway{logger: logging.OrNop(nil), messenger: recorder, maintenance: notices}
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		gw.surfaceMaintenanceNotice(ctx, "oc_a")
+	}
+	gw.surfaceMaintenanceNotice(ctx, "oc_b")
+
+	sends := recorder.CallsByMethod(MethodSendMessage)
+	if len(sends) != 2 || sends[0].ChatID != "oc_a" || sends[1].ChatID != "oc_b" {
+		t.Fatalf("expected one notice per chat, got %+v", sends)
+	}
+	if text := extractTextContent(sends[0].Content, nil); !strings.Contains(text, "维护预告") || !strings.Contains(text, "database upgrade") || !strings.Contains(text, "2026-03-01 12:00 UTC") {
+		t.Fatalf("unexpected notice text: %q", text)
+	}
+	pins := recorder.CallsByMethod(MethodPinMessage)
+	if len(pins) != 2 {
+		t.Fatalf("expected each notice to be pinned, got %+v", pins)
+	}
+
+	// The window starting edits the pinned notice instead of posting again.
+	notices.notice.Phase = maintenance.PhaseActive
+	gw.surfaceMaintenanceNotice(ctx, "oc_a")
+	gw.surfaceMaintenanceNotice(ctx, "oc_a")
+	updates := recorder.CallsByMethod(MethodUpdateMessage)
+	if len(updates) != 1 || updates[0].MsgID != pins[0].MsgID || !strings.Contains(extractTextContent(updates[0].Content, nil), "系统维护中") {
+		t.Fatalf("expected pinned notice to be edited in place, got %+v", updates)
+	}
+	if got := len(recorder.CallsByMethod(MethodSendMessage)); got != 2 {
+		t.Fatalf("expected no extra notice messages, got %d sends", got)
+	}
+
+	// Once the window clears the notice is unpinned, and nothing is posted.
+	notices.ok = false
+	gw.surfaceMaintenanceNotice(ctx, "oc_a")
+	gw.surfaceMaintenanceNotice(ctx, "oc_a")
+	unpins := recorder.CallsByMethod(MethodUnpinMessage)
+	if len(unpins) != 1 || unpins[0].MsgID != pins[0].MsgID {
+		t.Fatalf("expected the notice to be unpinned once, got %+v", unpins)
+	}
+	if got := len(recorder.CallsByMethod(MethodSendMessage)); got != 2 {
+		t.Fatalf("expected no messages after clear, got %d sends", got)
+	}
+}
+
+func TestSurfaceMaintenanceNoticeRetriesAfterSendFailure(t *testing.T) {
+	notices := &stubMaintenanceNotices{ok: true, notice: maintenance.Notice{WindowID: "mw-1", Phase: maintenance.PhaseActive, Message: "upgrade"}}
+	recorder := NewRecordingMessenger()
+	recorder.NextError = context.DeadlineExceeded
+	gw := &Gateway{logger: logging.OrNop(nil), messenger: recorder, maintenance: notices}
+
+	gw.surfaceMaintenanceNotice(context.Background(), "oc_a")
+	gw.surfaceMaintenanceNotice(context.Background(), "oc_a")
+	gw.surfaceMaintenanceNotice(context.Background(), "oc_a")
+	if got := len(recorder.CallsByMethod(MethodSendMessage)); got != 2 {
+		t.Fatalf("expected a failed notice to be retried once on the next message, got %d sends", got)
+	}
+}
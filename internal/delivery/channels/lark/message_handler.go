@@ -21,7 +21,9 @@ func (g *Gateway) parseIncomingMessage(event *larkim.P2MessageReceiveV1, opts me
 	raw := event.Event.Message
 
 	msgType := utils.TrimLower(deref(raw.MessageType))
-	if msgType != "text" && msgType != "post" {
+	switch msgType {
+	case "text", "post", "image", "file":
+	default:
 		return nil
 	}
 
@@ -34,7 +36,17 @@ func (g *Gateway) parseIncomingMessage(event *larkim.P2MessageReceiveV1, opts me
 		return nil
 	}
 
-	content := g.extractMessageContent(msgType, deref(raw.Content), raw.Mentions)
+	mentionMap := mentionKeyMap(raw.Mentions)
+	mentionGated := isGroup && g.cfg.RequireMention
+	if mentionGated && !opts.skipMentionGate && !g.markSelfMentions(mentionMap) {
+		return nil
+	}
+
+	content := g.extractMessageContent(msgType, deref(raw.Content), mentionMap)
+	resources := extractInboundResources(msgType, deref(raw.Content))
+	if content == "" {
+		content = inboundResourcePlaceholder(resources)
+	}
 	if content == "" {
 		return nil
 	}
@@ -55,29 +67,57 @@ func (g *Gateway) parseIncomingMessage(event *larkim.P2MessageReceiveV1, opts me
 		return nil
 	}
 
+	if mentionGated {
+		g.threadReplyTargets.record(messageID, chatID)
+	}
+
 	return &incomingMessage{
-		chatID:    chatID,
-		chatType:  chatType,
-		messageID: messageID,
-		senderID:  extractSenderID(event),
-		content:   content,
-		isGroup:   isGroup,
-		isFromBot: isBotSender(event),
+		chatID:      chatID,
+		chatType:    chatType,
+		messageID:   messageID,
+		senderID:    extractSenderID(event),
+		content:     content,
+		isGroup:     isGroup,
+		isFromBot:   isBotSender(event),
+		resources:   resources,
+		synthesized: opts.synthesized,
 	}
 }
 
 // extractMessageContent parses the JSON content from a Lark message.
 // Supports "text" and "post" message types, returning a trimmed string.
-func (g *Gateway) extractMessageContent(msgType, raw string, mentions []*larkim.MentionEvent) string {
-	if msgType == "text" {
-		return extractTextContent(raw, mentions)
+// Image and file messages carry no text; see extractInboundResources.
+// Mentions marked Self are stripped from the result.
+func (g *Gateway) extractMessageContent(msgType, raw string, mentionMap map[string]mentionInfo) string {
+	switch msgType {
+	case "text":
+		return renderTextPayload(raw, mentionMap)
+	case "post":
+		return renderPostPayload(raw, mentionMap)
 	}
-	return extractPostContent(raw, mentions)
+	return ""
+}
+
+// markSelfMentions flags the mentions that address this bot and reports
+// whether there were any. The bot is identified by BotOpenID and, as in
+// AI chat coordination, by AppID.
+func (g *Gateway) markSelfMentions(mentionMap map[string]mentionInfo) bool {
+	found := false
+	for key, info := range mentionMap {
+		if info.ID == "" || (info.ID != g.cfg.AppID && info.ID != g.cfg.BotOpenID) {
+			continue
+		}
+		info.Self = true
+		mentionMap[key] = info
+		found = true
+	}
+	return found
 }
 
 type mentionInfo struct {
 	Name string
 	ID   string
+	Self bool // mention of this bot; rendered as nothing
 }
 
 func trimDeref(value *string) string {
@@ -179,6 +219,10 @@ func renderIncomingMentionPlaceholders(text string, mentionMap map[string]mentio
 	out := text
 	for _, key := range keys {
 		info := mentionMap[key]
+		if info.Self {
+			out = strings.ReplaceAll(out, key, "")
+			continue
+		}
 		repl := formatReadableMention(info.Name, info.ID, key)
 		if repl == "" || repl == key {
 			continue
@@ -190,6 +234,10 @@ func renderIncomingMentionPlaceholders(text string, mentionMap map[string]mentio
 
 // extractTextContent parses a Lark text message content JSON: {"text":"..."}.
 func extractTextContent(raw string, mentions []*larkim.MentionEvent) string {
+	return renderTextPayload(raw, mentionKeyMap(mentions))
+}
+
+func renderTextPayload(raw string, mentionMap map[string]mentionInfo) string {
 	if raw == "" {
 		return ""
 	}
@@ -200,7 +248,6 @@ func extractTextContent(raw string, mentions []*larkim.MentionEvent) string {
 	if text == "" {
 		return ""
 	}
-	mentionMap := mentionKeyMap(mentions)
 	text = renderIncomingMentionPlaceholders(text, mentionMap)
 	text = renderTextMentions(text, mentionMap)
 	return strings.TrimSpace(text)
@@ -223,6 +270,9 @@ func renderTextMentions(text string, mentionMap map[string]mentionInfo) string {
 		mentionID := userID
 		if mentionMap != nil {
 			if info, ok := mentionMap[userID]; ok {
+				if info.Self {
+					return ""
+				}
 				if name == "" {
 					name = info.Name
 				}
@@ -248,6 +298,10 @@ func renderTextMentions(text string, mentionMap map[string]mentionInfo) string {
 // The content field is a JSON string like:
 // {"title":"...","content":[[{"tag":"text","text":"..."}]]}
 func extractPostContent(raw string, mentions []*larkim.MentionEvent) string {
+	return renderPostPayload(raw, mentionKeyMap(mentions))
+}
+
+func renderPostPayload(raw string, mentionMap map[string]mentionInfo) string {
 	if raw == "" {
 		return ""
 	}
@@ -257,7 +311,6 @@ func extractPostContent(raw string, mentions []*larkim.MentionEvent) string {
 		return strings.TrimSpace(raw)
 	}
 
-	mentionMap := mentionKeyMap(mentions)
 	return flattenLarkPostPayload(
 		parsed,
 		func(el larkPostElement) string {
@@ -269,6 +322,9 @@ func extractPostContent(raw string, mentions []*larkim.MentionEvent) string {
 			name := strings.TrimSpace(el.UserName)
 			if mentionMap != nil {
 				if info, exists := mentionMap[rawUserID]; exists {
+					if info.Self {
+						return ""
+					}
 					if name == "" {
 						name = info.Name
 					}
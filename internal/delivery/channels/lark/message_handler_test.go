@@ -1,8 +1,12 @@
 package lark
 
 import (
+	"context"
 	"testing"
 
+	"alex/internal/delivery/channels"
+	agent "alex/internal/domain/agent/ports/agent"
+
 	larkim "github.com/larksuite/oapi-sdk-go/v3/service/im/v1"
 )
 
@@ -392,3 +396,86 @@ func TestIsBotSender_User(t *testing.T) {
 		t.Fatal("expected false for user sender")
 	}
 }
+
+// --- RequireMention gating ---
+
+func mentionEvent(chatType, msgType, content string, mentions ...*larkim.MentionEvent) *larkim.P2MessageReceiveV1 {
+	return &larkim.P2MessageReceiveV1{
+		Event: &larkim.P2MessageReceiveV1Data{
+			Message: &larkim.EventMessage{
+				MessageType: strPtr(msgType),
+				ChatType:    strPtr(chatType),
+				ChatId:      strPtr("oc_mention"),
+				MessageId:   strPtr("om_mention_" + chatType),
+				Content:     strPtr(content),
+				Mentions:    mentions,
+			},
+			Sender: &larkim.EventSender{SenderId: &larkim.UserId{OpenId: strPtr("ou_user")}},
+		},
+	}
+}
+
+func newMentionGatedGateway(rec *RecordingMessenger, exec AgentExecutor) *Gateway {
+	gw := newTestGatewayWithMessenger(exec, rec, channels.BaseConfig{SessionPrefix: "lark", AllowGroups: true, AllowDirect: true})
+	gw.cfg.RequireMention = true
+	gw.cfg.BotOpenID = "ou_bot"
+	gw.threadReplyTargets = newSentMessageIndex(16)
+	return gw
+}
+
+func TestParseIncomingMessage_RequireMention(t *testing.T) {
+	gw := newMentionGatedGateway(NewRecordingMessenger(), &stubExecutor{})
+	botMention := &larkim.MentionEvent{Key: strPtr("@_user_1"), Name: strPtr("Elephant"), Id: &larkim.UserId{OpenId: strPtr("ou_bot")}}
+	otherMention := &larkim.MentionEvent{Key: strPtr("@_user_2"), Name: strPtr("Bob"), Id: &larkim.UserId{OpenId: strPtr("ou_bob")}}
+
+	tests := []struct {
+		name  string
+		event *larkim.P2MessageReceiveV1
+		want  string // "" means skipped
+	}{
+		{"group without mention", mentionEvent("group", "text", `{"text":"deploy it"}`), ""},
+		{"group mentioning someone else", mentionEvent("group", "text", `{"text":"@_user_2 deploy it"}`, otherMention), ""},
+		{"group mentioning bot", mentionEvent("group", "text", `{"text":"@_user_1 deploy it"}`, botMention), "deploy it"},
+		{"group keeps other mentions", mentionEvent("group", "text", `{"text":"@_user_1 ask @_user_2"}`, botMention, otherMention), "ask @Bob(ou_bob)"},
+		{"group post mentioning bot", mentionEvent("group", "post", `{"content":[[{"tag":"at","user_id":"@_user_1"},{"tag":"text","text":" summarize"}]]}`, botMention), "summarize"},
+		{"direct chat needs no mention", mentionEvent("p2p", "text", `{"text":"deploy it"}`), "deploy it"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg := gw.parseIncomingMessage(tt.event, messageProcessingOptions{skipDedup: true})
+			if tt.want == "" {
+				if msg != nil {
+					t.Fatalf("expected message to be skipped, got %q", msg.content)
+				}
+				return
+			}
+			if msg == nil || msg.content != tt.want {
+				t.Fatalf("expected %q, got %+v", tt.want, msg)
+			}
+		})
+	}
+}
+
+func TestRequireMentionRepliesInThread(t *testing.T) {
+	rec := NewRecordingMessenger()
+	gw := newMentionGatedGateway(rec, &capturingExecutor{result: &agent.TaskResult{Answer: "done"}})
+	botMention := &larkim.MentionEvent{Key: strPtr("@_user_1"), Id: &larkim.UserId{OpenId: strPtr("ou_bot")}}
+
+	if err := gw.handleMessage(context.Background(), mentionEvent("group", "text", `{"text":"@_user_1 deploy it"}`, botMention)); err != nil {
+		t.Fatalf("handleMessage failed: %v", err)
+	}
+	gw.WaitForTasks()
+
+	replies := rec.CallsByMethod(MethodReplyMessage)
+	if len(replies) == 0 {
+		t.Fatal("expected a reply")
+	}
+	for _, call := range replies {
+		if !call.InThread || call.ReplyTo != "om_mention_group" {
+			t.Fatalf("expected thread reply to the triggering message, got %+v", call)
+		}
+	}
+	if sends := rec.CallsByMethod(MethodSendMessage); len(sends) != 0 {
+		t.Fatalf("expected no top-level messages, got %+v", sends)
+	}
+}
@@ -34,3 +34,33 @@ type LarkMessenger interface {
 	// ListMessages retrieves recent messages from a chat.
 	ListMessages(ctx context.Context, chatID string, pageSize int) ([]*larkim.Message, error)
 }
+
+// messagePinner is implemented by messengers that can pin messages to the
+// top of a chat. It is optional so test doubles and wrappers need not
+// support it.
+type messagePinner interface {
+	PinMessage(ctx context.Context, messageID string) error
+	UnpinMessage(ctx context.Context, messageID string) error
+}
+
+// threadReplier is implemented by messengers that can reply to a message
+// as a thread rather than a quoted reply in the main chat flow.
+type threadReplier interface {
+	ReplyMessageInThread(ctx context.Context, replyToID, msgType, content string) (messageID string, err error)
+}
+
+// messageResourceDownloader is implemented by messengers that can fetch the
+// images and files attached to a received message. It is optional for the
+// same reason as messagePinner.
+type messageResourceDownloader interface {
+	// DownloadMessageResource returns the resource bytes and, when Lark
+	// reports one, its file name. resourceType is "image" or "file".
+	DownloadMessageResource(ctx context.Context, messageID, fileKey, resourceType string) (payload []byte, fileName string, err error)
+}
+
+// chatAdminLister is implemented by messengers that can look up a group's
+// owner and managers. It is optional for the same reason as messagePinner.
+type chatAdminLister interface {
+	// ChatAdmins returns the open_ids of the group owner and its managers.
+	ChatAdmins(ctx context.Context, chatID string) (ownerID string, managerIDs []string, err error)
+}
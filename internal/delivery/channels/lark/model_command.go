@@ -57,7 +57,7 @@ func (g *Gateway) handleModelCommand(msg *incomingMessage) {
 	if len(fields) > 1 {
 		sub = utils.TrimLower(fields[1])
 	}
-	chatOnly := hasFlag(fields, "--chat")
+	target := modelPinTargetFromFields(fields)
 
 	var reply string
 	switch sub {
@@ -75,13 +75,13 @@ func (g *Gateway) handleModelCommand(msg *incomingMessage) {
 			reply = modelCommandUsage()
 			break
 		}
-		if err := g.setModelSelection(execCtx, msg, spec, chatOnly); err != nil {
+		if err := g.setModelSelection(execCtx, msg, spec, target); err != nil {
 			reply = fmt.Sprintf("设置失败：%v\n\n%s", err, modelCommandUsage())
 			break
 		}
 		reply = g.buildModelStatus(execCtx, msg)
 	case "clear", "reset":
-		if err := g.clearModelSelection(execCtx, msg, chatOnly); err != nil {
+		if err := g.clearModelSelection(execCtx, msg, target); err != nil {
 			reply = fmt.Sprintf("清除失败：%v\n\n%s", err, modelCommandUsage())
 			break
 		}
@@ -103,17 +103,44 @@ Model command usage:
   /model                                List available subscription models
   /model use <provider>/<model>         Select globally (all Lark chats)
   /model use <provider>/<model> --chat  Select for this chat only (override)
-  /model status                         Show current pinned selection
+  /model use <provider>/<model> --me    Select for yourself in every chat
+  /model status                         Show which selection is in effect and why
   /model clear                          Clear global selection
   /model clear --chat                   Clear this chat's override only
+  /model clear --me                     Clear your personal selection only
+
+Resolution order: --me → --chat → global → config default.
 
 Examples:
   /model use codex/gpt-5.2-codex
   /model use anthropic/claude-sonnet-4-20250514 --chat
+  /model use codex/gpt-5.2-codex --me
   /model use llama_server/local-model
 `)
 }
 
+// modelPinTarget is the scope a /model use or /model clear applies to.
+type modelPinTarget int
+
+const (
+	pinGlobal modelPinTarget = iota
+	pinChat
+	pinUser
+)
+
+// modelPinTargetFromFields maps --me / --chat to a pin target; --me wins if
+// both are given.
+func modelPinTargetFromFields(fields []string) modelPinTarget {
+	switch {
+	case hasFlag(fields, "--me"):
+		return pinUser
+	case hasFlag(fields, "--chat"):
+		return pinChat
+	default:
+		return pinGlobal
+	}
+}
+
 // hasFlag checks whether a flag like "--chat" appears in the fields slice.
 func hasFlag(fields []string, flag string) bool {
 	for _, f := range fields {
@@ -147,6 +174,19 @@ func chatScope(msg *incomingMessage) subscription.SelectionScope {
 	return subscription.SelectionScope{Channel: "lark", ChatID: strings.TrimSpace(msg.chatID)}
 }
 
+// userScope returns the sender's personal selection scope, which applies in
+// every chat.
+func userScope(msg *incomingMessage) (subscription.SelectionScope, bool) {
+	if msg == nil {
+		return subscription.SelectionScope{}, false
+	}
+	userID := strings.TrimSpace(msg.senderID)
+	if userID == "" {
+		return subscription.SelectionScope{}, false
+	}
+	return subscription.SelectionScope{Channel: "lark", UserID: userID}, true
+}
+
 // legacyChatUserScope returns the historical chat+user scope shape.
 func legacyChatUserScope(msg *incomingMessage) (subscription.SelectionScope, bool) {
 	if msg == nil {
@@ -161,9 +201,13 @@ func legacyChatUserScope(msg *incomingMessage) (subscription.SelectionScope, boo
 }
 
 // selectionScopes builds lookup scopes from most specific to least specific.
-// Order: chat-level -> legacy chat+user (DM-only compatibility) -> channel-level.
+// Order: user-level -> chat-level -> legacy chat+user (DM-only compatibility)
+// -> channel-level.
 func selectionScopes(msg *incomingMessage) []subscription.SelectionScope {
-	scopes := make([]subscription.SelectionScope, 0, 3)
+	scopes := make([]subscription.SelectionScope, 0, 4)
+	if user, ok := userScope(msg); ok {
+		scopes = append(scopes, user)
+	}
 	if msg != nil {
 		if chatID := strings.TrimSpace(msg.chatID); chatID != "" {
 			scopes = append(scopes, subscription.SelectionScope{Channel: "lark", ChatID: chatID})
@@ -183,19 +227,28 @@ func (g *Gateway) buildModelStatus(ctx context.Context, msg *incomingMessage) st
 	if g == nil || msg == nil || g.llmSelections == nil {
 		return "（模型选择不可用）"
 	}
-	selection, matchedScope, ok, err := g.llmSelections.GetWithFallback(ctx, selectionScopes(msg)...)
+	matches, err := g.llmSelections.Matches(ctx, selectionScopes(msg)...)
 	if err != nil {
 		return fmt.Sprintf("读取失败：%v", err)
 	}
-	if !ok {
+	if len(matches) == 0 {
 		return "当前未设置订阅模型选择；后续将使用配置默认值。"
 	}
 
-	scopeLabel := "[全局]"
-	if matchedScope.ChatID != "" {
-		scopeLabel = "[当前会话]"
+	active := matches[0]
+	lines := []string{g.formatActiveSelection(active), "生效原因：" + pinReason(active.Scope)}
+	if !active.PinnedAt.IsZero() {
+		lines = append(lines, "设置时间："+active.PinnedAt.Local().Format("2006-01-02 15:04"))
+	}
+	for _, overridden := range matches[1:] {
+		lines = append(lines, fmt.Sprintf("已被覆盖：%s %s/%s", pinScopeLabel(overridden.Scope), overridden.Selection.Provider, overridden.Selection.Model))
 	}
+	return strings.Join(lines, "\n")
+}
 
+func (g *Gateway) formatActiveSelection(match subscription.SelectionMatch) string {
+	selection := match.Selection
+	scopeLabel := pinScopeLabel(match.Scope)
 	if g.llmResolver != nil {
 		if resolved, ok := g.llmResolver.Resolve(selection); ok {
 			source := strings.TrimSpace(resolved.Source)
@@ -214,6 +267,30 @@ func (g *Gateway) buildModelStatus(ctx context.Context, msg *incomingMessage) st
 	return fmt.Sprintf("当前订阅模型选择 %s：%s/%s", scopeLabel, selection.Provider, selection.Model)
 }
 
+func pinScopeLabel(scope subscription.SelectionScope) string {
+	switch {
+	case scope.UserID != "" && scope.ChatID == "":
+		return "[个人]"
+	case scope.ChatID != "":
+		return "[当前会话]"
+	default:
+		return "[全局]"
+	}
+}
+
+func pinReason(scope subscription.SelectionScope) string {
+	switch {
+	case scope.UserID != "" && scope.ChatID == "":
+		return "你通过 /model use … --me 设置了个人模型，在所有会话中优先于会话与全局设置。"
+	case scope.UserID != "":
+		return "本私聊存在旧版个人设置，优先于全局设置；重新执行 /model use 会将其清除。"
+	case scope.ChatID != "":
+		return "本会话通过 /model use … --chat 设置了会话模型，优先于全局设置。"
+	default:
+		return "全局设置（/model use …）适用于所有未单独设置的会话与用户。"
+	}
+}
+
 func (g *Gateway) buildModelList(ctx context.Context, msg *incomingMessage) string {
 	status := g.buildModelStatus(ctx, msg)
 	catalog := g.loadUsableModelCatalog(ctx)
@@ -320,7 +397,7 @@ func filterUsableCatalogProviders(creds runtimeconfig.CLICredentials, providers
 	return out
 }
 
-func (g *Gateway) setModelSelection(ctx context.Context, msg *incomingMessage, spec string, chatOnly bool) error {
+func (g *Gateway) setModelSelection(ctx context.Context, msg *incomingMessage, spec string, target modelPinTarget) error {
 	if g == nil || msg == nil || g.llmSelections == nil {
 		return fmt.Errorf("selection store not available")
 	}
@@ -343,9 +420,9 @@ func (g *Gateway) setModelSelection(ctx context.Context, msg *incomingMessage, s
 		Model:    model,
 		Source:   string(cred.Source),
 	}
-	scope := channelScope()
-	if chatOnly {
-		scope = chatScope(msg)
+	scope, err := pinTargetScope(msg, target)
+	if err != nil {
+		return err
 	}
 	if err := g.llmSelections.Set(ctx, scope, selection); err != nil {
 		return err
@@ -362,13 +439,17 @@ func (g *Gateway) setModelSelection(ctx context.Context, msg *incomingMessage, s
 	return nil
 }
 
-func (g *Gateway) clearModelSelection(ctx context.Context, msg *incomingMessage, chatOnly bool) error {
+func (g *Gateway) clearModelSelection(ctx context.Context, msg *incomingMessage, target modelPinTarget) error {
 	if g == nil || msg == nil || g.llmSelections == nil {
 		return fmt.Errorf("selection store not available")
 	}
 
-	if !chatOnly {
-		return g.llmSelections.Clear(ctx, channelScope())
+	if target != pinChat {
+		scope, err := pinTargetScope(msg, target)
+		if err != nil {
+			return err
+		}
+		return g.llmSelections.Clear(ctx, scope)
 	}
 
 	if err := g.llmSelections.Clear(ctx, chatScope(msg)); err != nil {
@@ -382,6 +463,22 @@ func (g *Gateway) clearModelSelection(ctx context.Context, msg *incomingMessage,
 	return nil
 }
 
+// pinTargetScope returns the selection scope for a pin target.
+func pinTargetScope(msg *incomingMessage, target modelPinTarget) (subscription.SelectionScope, error) {
+	switch target {
+	case pinUser:
+		scope, ok := userScope(msg)
+		if !ok {
+			return subscription.SelectionScope{}, fmt.Errorf("sender id unavailable for --me")
+		}
+		return scope, nil
+	case pinChat:
+		return chatScope(msg), nil
+	default:
+		return channelScope(), nil
+	}
+}
+
 func resolveLlamaServerTarget(lookup runtimeconfig.EnvLookup) (subscription.LlamaServerTarget, bool) {
 	if lookup == nil {
 		lookup = runtimeconfig.DefaultEnvLookup
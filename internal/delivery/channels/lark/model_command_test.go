@@ -234,7 +234,7 @@ func TestSetModelDefaultsToChannelScope(t *testing.T) {
 	ctx := context.Background()
 	msg := &incomingMessage{chatID: "oc_chat", senderID: "ou_user"}
 
-	if err := gw.setModelSelection(ctx, msg, "llama_server/llama3:latest", false); err != nil {
+	if err := gw.setModelSelection(ctx, msg, "llama_server/llama3:latest", pinGlobal); err != nil {
 		t.Fatalf("setModelSelection: %v", err)
 	}
 
@@ -255,7 +255,7 @@ func TestSetModelWithChatFlag(t *testing.T) {
 	ctx := context.Background()
 	msg := &incomingMessage{chatID: "oc_chat", senderID: "ou_user"}
 
-	if err := gw.setModelSelection(ctx, msg, "llama_server/chat-only-model", true); err != nil {
+	if err := gw.setModelSelection(ctx, msg, "llama_server/chat-only-model", pinChat); err != nil {
 		t.Fatalf("setModelSelection: %v", err)
 	}
 
@@ -354,7 +354,7 @@ func TestBuildModelStatusShowsScopeLabel(t *testing.T) {
 	}
 
 	// Set global.
-	if err := gw.setModelSelection(ctx, msg, "llama_server/global-model", false); err != nil {
+	if err := gw.setModelSelection(ctx, msg, "llama_server/global-model", pinGlobal); err != nil {
 		t.Fatalf("set: %v", err)
 	}
 	status = gw.buildModelStatus(ctx, msg)
@@ -363,7 +363,7 @@ func TestBuildModelStatusShowsScopeLabel(t *testing.T) {
 	}
 
 	// Override per-chat.
-	if err := gw.setModelSelection(ctx, msg, "llama_server/override-model", true); err != nil {
+	if err := gw.setModelSelection(ctx, msg, "llama_server/override-model", pinChat); err != nil {
 		t.Fatalf("set chat: %v", err)
 	}
 	status = gw.buildModelStatus(ctx, msg)
@@ -381,10 +381,10 @@ func TestClearChannelLevel(t *testing.T) {
 	ctx := context.Background()
 	msg := &incomingMessage{chatID: "oc_chat", senderID: "ou_user"}
 
-	if err := gw.setModelSelection(ctx, msg, "llama_server/model-x", false); err != nil {
+	if err := gw.setModelSelection(ctx, msg, "llama_server/model-x", pinGlobal); err != nil {
 		t.Fatalf("set: %v", err)
 	}
-	if err := gw.clearModelSelection(ctx, msg, false); err != nil {
+	if err := gw.clearModelSelection(ctx, msg, pinGlobal); err != nil {
 		t.Fatalf("clear: %v", err)
 	}
 	status := gw.buildModelStatus(ctx, msg)
@@ -400,15 +400,15 @@ func TestClearChatOnly(t *testing.T) {
 	msg := &incomingMessage{chatID: "oc_chat", senderID: "ou_user"}
 
 	// Set both levels.
-	if err := gw.setModelSelection(ctx, msg, "llama_server/global-model", false); err != nil {
+	if err := gw.setModelSelection(ctx, msg, "llama_server/global-model", pinGlobal); err != nil {
 		t.Fatalf("set global: %v", err)
 	}
-	if err := gw.setModelSelection(ctx, msg, "llama_server/chat-model", true); err != nil {
+	if err := gw.setModelSelection(ctx, msg, "llama_server/chat-model", pinChat); err != nil {
 		t.Fatalf("set chat: %v", err)
 	}
 
 	// Clear only the chat override.
-	if err := gw.clearModelSelection(ctx, msg, true); err != nil {
+	if err := gw.clearModelSelection(ctx, msg, pinChat); err != nil {
 		t.Fatalf("clear chat: %v", err)
 	}
 
@@ -444,3 +444,59 @@ func TestFirstNonFlag(t *testing.T) {
 		t.Fatalf("expected empty, got %q", got)
 	}
 }
+
+func TestSetModelWithMeFlagPinsSenderAcrossChats(t *testing.T) {
+	t.Parallel()
+	gw := newTestGatewayWithStore(t)
+	ctx := context.Background()
+	group := &incomingMessage{chatID: "oc_group", senderID: "ou_me", isGroup: true}
+
+	if err := gw.setModelSelection(ctx, group, "llama_server/chat-model", pinChat); err != nil {
+		t.Fatalf("set chat: %v", err)
+	}
+	if err := gw.setModelSelection(ctx, group, "llama_server/my-model", pinUser); err != nil {
+		t.Fatalf("set user: %v", err)
+	}
+
+	status := gw.buildModelStatus(ctx, group)
+	if !strings.Contains(status, "[个人]") || !strings.Contains(status, "my-model") {
+		t.Fatalf("expected personal pin to win in group chat, got: %s", status)
+	}
+	if !strings.Contains(status, "生效原因") || !strings.Contains(status, "已被覆盖：[当前会话] llama_server/chat-model") {
+		t.Fatalf("expected reason and overridden chat pin, got: %s", status)
+	}
+
+	// Another member of the same group keeps the chat pin.
+	other := &incomingMessage{chatID: "oc_group", senderID: "ou_other", isGroup: true}
+	if status := gw.buildModelStatus(ctx, other); !strings.Contains(status, "chat-model") || strings.Contains(status, "my-model") {
+		t.Fatalf("personal pin must not affect other senders, got: %s", status)
+	}
+
+	// The personal pin follows the sender into other chats.
+	elsewhere := &incomingMessage{chatID: "oc_dm", senderID: "ou_me"}
+	if status := gw.buildModelStatus(ctx, elsewhere); !strings.Contains(status, "my-model") {
+		t.Fatalf("expected personal pin in other chat, got: %s", status)
+	}
+
+	if err := gw.clearModelSelection(ctx, group, pinUser); err != nil {
+		t.Fatalf("clear user: %v", err)
+	}
+	if status := gw.buildModelStatus(ctx, group); !strings.Contains(status, "[当前会话]") {
+		t.Fatalf("expected chat pin after clearing personal pin, got: %s", status)
+	}
+}
+
+func TestModelPinTargetFromFields(t *testing.T) {
+	t.Parallel()
+	cases := map[string]modelPinTarget{
+		"/model use a/b":             pinGlobal,
+		"/model use a/b --chat":      pinChat,
+		"/model use a/b --me":        pinUser,
+		"/model use a/b --chat --me": pinUser,
+	}
+	for input, want := range cases {
+		if got := modelPinTargetFromFields(strings.Fields(input)); got != want {
+			t.Fatalf("%q: got %v, want %v", input, got, want)
+		}
+	}
+}
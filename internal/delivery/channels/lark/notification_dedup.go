@@ -0,0 +1,35 @@
+package lark
+
+import (
+	"alex/internal/app/notifications"
+	agent "alex/internal/domain/agent/ports/agent"
+)
+
+// NotificationDeduper records events the user was already told about in
+// Lark so the in-app notification center can skip them.
+// Satisfied by *notifications.Center.
+type NotificationDeduper interface {
+	MarkDelivered(eventID string)
+}
+
+// notificationDedupListener marks notification-worthy events as delivered
+// before forwarding them, so the center (which consumes downstream of inner)
+// always sees the mark first.
+type notificationDedupListener struct {
+	inner   agent.EventListener
+	deduper NotificationDeduper
+}
+
+func newNotificationDedupListener(inner agent.EventListener, deduper NotificationDeduper) agent.EventListener {
+	if deduper == nil {
+		return inner
+	}
+	return &notificationDedupListener{inner: inner, deduper: deduper}
+}
+
+func (l *notificationDedupListener) OnEvent(event agent.AgentEvent) {
+	if _, ok := notifications.TypeForEvent(event); ok {
+		l.deduper.MarkDelivered(event.GetEventID())
+	}
+	l.inner.OnEvent(event)
+}
@@ -0,0 +1,66 @@
+package lark
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"alex/internal/app/agent/eventbus"
+	"alex/internal/app/notifications"
+	domain "alex/internal/domain/agent"
+	agent "alex/internal/domain/agent/ports/agent"
+	"alex/internal/domain/agent/types"
+)
+
+func TestNotificationDedupListenerSuppressesInAppCopy(t *testing.T) {
+	store, err := notifications.NewStore("")
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	center := notifications.New(store, notifications.Config{}, nil, nil)
+	bus := eventbus.New(nil)
+	if _, err := bus.Subscribe(eventbus.Subscription{Name: "notifications", Consumer: center}); err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+
+	spy := &spyListener{}
+	larkListener := newNotificationDedupListener(multiListener{spy, bus}, center)
+
+	final := func(answer string) *domain.WorkflowEventEnvelope {
+		return &domain.WorkflowEventEnvelope{
+			BaseEvent: domain.NewBaseEvent(agent.LevelCore, "lark-sess", "run", "", time.Now()),
+			Event:     types.EventResultFinal,
+			Payload:   map[string]any{"final_answer": answer},
+		}
+	}
+	larkListener.OnEvent(final("answered in Lark"))
+	bus.OnEvent(final("answered elsewhere"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := bus.Flush(ctx); err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+	if spy.count() != 1 {
+		t.Fatalf("expected the Lark listener to forward the event, got %d", spy.count())
+	}
+	list, _, _ := center.List(context.Background(), "", false, 0)
+	if len(list) != 1 || list[0].Body != "answered elsewhere" {
+		t.Fatalf("expected only the non-Lark event to notify, got %+v", list)
+	}
+}
+
+func TestNewNotificationDedupListenerWithoutDeduper(t *testing.T) {
+	spy := &spyListener{}
+	if got := newNotificationDedupListener(spy, nil); got != agent.EventListener(spy) {
+		t.Fatalf("expected the inner listener to be returned unchanged")
+	}
+}
+
+type multiListener []agent.EventListener
+
+func (m multiListener) OnEvent(event agent.AgentEvent) {
+	for _, l := range m {
+		l.OnEvent(event)
+	}
+}
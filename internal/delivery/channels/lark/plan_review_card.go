@@ -0,0 +1,175 @@
+package lark
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	storage "alex/internal/domain/agent/ports/storage"
+	"alex/internal/shared/utils"
+)
+
+// planReviewStepKeys are the internal_plan fields that may hold the step
+// list, in lookup order.
+var planReviewStepKeys = []string{"steps", "tasks", "phases", "milestones"}
+
+// planReviewStepTextKeys are the step fields used as the step's label.
+var planReviewStepTextKeys = []string{"title", "description", "name", "step", "task"}
+
+// buildPlanReviewCard renders a pending plan as an interactive card with
+// Approve / Request changes buttons. The markdown element doubles as the
+// text fallback for clients that cannot render cards.
+func buildPlanReviewCard(marker planReviewMarker, requesterID string) string {
+	var b strings.Builder
+	if marker.OverallGoalUI != "" {
+		b.WriteString(fmt.Sprintf("**目标:** %s\n", marker.OverallGoalUI))
+	}
+	if steps := planReviewSteps(marker.InternalPlan); len(steps) > 0 {
+		b.WriteString("\n**步骤:**\n")
+		for i, step := range steps {
+			b.WriteString(fmt.Sprintf("%d. %s\n", i+1, step))
+		}
+	}
+	b.WriteString("\n也可以直接回复 OK 确认，或回复修改意见。")
+
+	return buildLarkCard("计划待确认", "blue", []any{
+		map[string]any{
+			"tag":     "markdown",
+			"content": strings.TrimSpace(b.String()),
+		},
+		map[string]any{
+			"tag": "action",
+			"actions": []any{
+				planReviewButton("Approve", "primary", cardActionPlanReviewApprove, requesterID, marker.RunID),
+				planReviewButton("Request changes", "default", cardActionPlanReviewRevise, requesterID, marker.RunID),
+			},
+		},
+	})
+}
+
+// planReviewButton builds a card button carrying the plan's requester and
+// run ID so stale or foreign clicks can be rejected.
+func planReviewButton(text, btnType, action, requesterID, runID string) map[string]any {
+	return map[string]any{
+		"tag":  "button",
+		"text": map[string]any{"tag": "plain_text", "content": text},
+		"type": btnType,
+		"value": map[string]any{
+			"action":  action,
+			"user_id": requesterID,
+			"run_id":  runID,
+		},
+	}
+}
+
+// planReviewSteps extracts display labels from a free-form internal plan:
+// a plain-text plan, a list of steps, or an object with a step list under
+// one of planReviewStepKeys. Steps may be strings or objects with a label
+// field.
+func planReviewSteps(plan any) []string {
+	var items []any
+	switch v := plan.(type) {
+	case string:
+		items = []any{v}
+	case []any:
+		items = v
+	case map[string]any:
+		for _, key := range planReviewStepKeys {
+			if list, ok := v[key].([]any); ok {
+				items = list
+				break
+			}
+		}
+	}
+	steps := make([]string, 0, len(items))
+	for _, item := range items {
+		if label := planReviewStepLabel(item); label != "" {
+			steps = append(steps, label)
+		}
+	}
+	return steps
+}
+
+func planReviewStepLabel(item any) string {
+	switch v := item.(type) {
+	case string:
+		return strings.TrimSpace(v)
+	case map[string]any:
+		for _, key := range planReviewStepTextKeys {
+			if s, ok := v[key].(string); ok && utils.HasContent(s) {
+				return strings.TrimSpace(s)
+			}
+		}
+	}
+	return ""
+}
+
+// takePlanReviewPending loads the pending review and clears it from the
+// store in one step, so a typed reply and a card click racing on the same
+// plan cannot both consume it.
+func (g *Gateway) takePlanReviewPending(ctx context.Context, session *storage.Session, userID, chatID string) (PlanReviewPending, bool) {
+	g.planReviewMu.Lock()
+	defer g.planReviewMu.Unlock()
+	pending, ok := g.loadPlanReviewPending(ctx, session, userID, chatID)
+	if !ok || g.planReviewStore == nil {
+		return pending, ok
+	}
+	if err := g.planReviewStore.ClearPending(ctx, userID, chatID); err != nil {
+		g.logger.Warn("Lark plan review pending clear failed: %v", err)
+	}
+	return pending, true
+}
+
+// checkPlanReviewCardAction returns a rejection toast when the clicked card
+// is not actionable: the operator is not the plan's requester, or the plan
+// was already decided or superseded by a newer one.
+func (g *Gateway) checkPlanReviewCardAction(ctx context.Context, action *CardAction) (string, error) {
+	if requester, _ := action.Value["user_id"].(string); requester != "" && requester != action.OperatorID {
+		return "仅计划发起人可以处理该计划。", nil
+	}
+	if g.planReviewStore == nil {
+		return "", nil
+	}
+	g.planReviewMu.Lock()
+	pending, ok, err := g.planReviewStore.GetPending(ctx, action.OperatorID, action.ChatID)
+	g.planReviewMu.Unlock()
+	if err != nil {
+		return "", err
+	}
+	if !ok {
+		return "该计划已处理。", nil
+	}
+	if runID, _ := action.Value["run_id"].(string); runID != "" && pending.RunID != "" && runID != pending.RunID {
+		return "该计划已过期。", nil
+	}
+	return "", nil
+}
+
+// planReviewApproveCardAction approves a pending plan as if the operator
+// had replied "OK". The card's message ID doubles as the dedup key, so a
+// second click on the same card is ignored.
+func (g *Gateway) planReviewApproveCardAction(ctx context.Context, action *CardAction) (string, error) {
+	if action.ChatID == "" || action.OperatorID == "" {
+		return "", nil
+	}
+	if toast, err := g.checkPlanReviewCardAction(ctx, action); toast != "" || err != nil {
+		return toast, err
+	}
+	if err := g.InjectMessage(ctx, action.ChatID, "", action.OperatorID, action.MessageID, "OK"); err != nil {
+		return "", err
+	}
+	return "已确认计划，开始执行。", nil
+}
+
+// planReviewReviseCardAction asks the operator for change requests. The
+// pending review is left in place so their next message becomes the
+// <plan_feedback> payload.
+func (g *Gateway) planReviewReviseCardAction(ctx context.Context, action *CardAction) (string, error) {
+	if toast, err := g.checkPlanReviewCardAction(ctx, action); toast != "" || err != nil {
+		return toast, err
+	}
+	if action.ChatID != "" {
+		g.dispatch(ctx, action.ChatID, replyTarget(action.MessageID, true), "text", textContent("请回复修改意见，我会据此调整计划。"))
+	}
+	return "请直接回复修改意见。", nil
+}
@@ -0,0 +1,163 @@
+package lark
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"alex/internal/delivery/channels"
+	agent "alex/internal/domain/agent/ports/agent"
+)
+
+func TestPlanReviewSteps(t *testing.T) {
+	tests := []struct {
+		name string
+		plan any
+		want []string
+	}{
+		{"string list", []any{"a", " b "}, []string{"a", "b"}},
+		{"steps object", map[string]any{"steps": []any{"x", "y"}}, []string{"x", "y"}},
+		{"task objects", map[string]any{"tasks": []any{map[string]any{"title": "write"}, map[string]any{"description": "test"}}}, []string{"write", "test"}},
+		{"free text", "just do it", []string{"just do it"}},
+		{"unknown object", map[string]any{"goal": "x"}, []string{}},
+		{"nil", nil, []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := planReviewSteps(tt.plan)
+			if strings.Join(got, "|") != strings.Join(tt.want, "|") {
+				t.Fatalf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildPlanReviewCardKeepsTextFallback(t *testing.T) {
+	card := buildPlanReviewCard(planReviewMarker{
+		RunID:         "run-1",
+		OverallGoalUI: "ship feature",
+		InternalPlan:  map[string]any{"steps": []any{"design", "build"}},
+	}, "ou_user")
+
+	for _, want := range []string{"Approve", "Request changes", cardActionPlanReviewApprove, cardActionPlanReviewRevise, `"user_id":"ou_user"`, `"run_id":"run-1"`} {
+		if !strings.Contains(card, want) {
+			t.Fatalf("expected %q in card, got %s", want, card)
+		}
+	}
+	fallback := extractCardMarkdown(card)
+	if !strings.Contains(fallback, "ship feature") || !strings.Contains(fallback, "1. design") || !strings.Contains(fallback, "2. build") {
+		t.Fatalf("expected goal and numbered steps in text fallback, got %q", fallback)
+	}
+}
+
+func newPlanReviewCardGateway(t *testing.T, executor AgentExecutor, rec *RecordingMessenger) (*Gateway, *PlanReviewLocalStore) {
+	t.Helper()
+	gw := newTestGatewayWithMessenger(executor, rec, channels.BaseConfig{SessionPrefix: "lark", AllowDirect: true})
+	gw.cfg.PlanReviewEnabled = true
+	store := NewPlanReviewMemoryStore(time.Hour)
+	if err := store.SavePending(context.Background(), PlanReviewPending{
+		UserID:        "ou_user",
+		ChatID:        "oc_plan",
+		RunID:         "run-1",
+		OverallGoalUI: "ship feature",
+		InternalPlan:  map[string]any{"steps": []any{"design"}},
+	}); err != nil {
+		t.Fatalf("SavePending failed: %v", err)
+	}
+	gw.SetPlanReviewStore(store)
+	return gw, store
+}
+
+func planReviewCardAction(name, messageID, operatorID string) *CardAction {
+	return &CardAction{
+		Name:       name,
+		ChatID:     "oc_plan",
+		MessageID:  messageID,
+		OperatorID: operatorID,
+		Value:      map[string]any{"action": name, "user_id": "ou_user", "run_id": "run-1"},
+	}
+}
+
+func TestPlanReviewApproveConsumesPendingOnce(t *testing.T) {
+	executor := &capturingExecutor{result: &agent.TaskResult{Answer: "done"}}
+	gw, store := newPlanReviewCardGateway(t, executor, NewRecordingMessenger())
+	ctx := context.Background()
+
+	toast, err := gw.planReviewApproveCardAction(ctx, planReviewCardAction(cardActionPlanReviewApprove, "om_card", "ou_user"))
+	if err != nil || toast != "已确认计划，开始执行。" {
+		t.Fatalf("unexpected approve result: %q, %v", toast, err)
+	}
+	gw.WaitForTasks()
+	if !strings.Contains(executor.capturedTask, "<plan_feedback>") || !strings.Contains(executor.capturedTask, "OK") {
+		t.Fatalf("expected approval feedback block, got %q", executor.capturedTask)
+	}
+	if _, ok, _ := store.GetPending(ctx, "ou_user", "oc_plan"); ok {
+		t.Fatal("expected pending review to be cleared")
+	}
+
+	executor.capturedTask = ""
+	toast, err = gw.planReviewApproveCardAction(ctx, planReviewCardAction(cardActionPlanReviewApprove, "om_card_copy", "ou_user"))
+	if err != nil || toast != "该计划已处理。" {
+		t.Fatalf("expected already-handled toast, got %q, %v", toast, err)
+	}
+	gw.WaitForTasks()
+	if executor.capturedTask != "" {
+		t.Fatalf("expected no second run, got %q", executor.capturedTask)
+	}
+}
+
+func TestPlanReviewReviseUsesNextReplyAsFeedback(t *testing.T) {
+	executor := &capturingExecutor{result: &agent.TaskResult{Answer: "done"}}
+	rec := NewRecordingMessenger()
+	gw, store := newPlanReviewCardGateway(t, executor, rec)
+	ctx := context.Background()
+
+	toast, err := gw.planReviewReviseCardAction(ctx, planReviewCardAction(cardActionPlanReviewRevise, "om_card", "ou_user"))
+	if err != nil || toast != "请直接回复修改意见。" {
+		t.Fatalf("unexpected revise result: %q, %v", toast, err)
+	}
+	if reply := lastReplyContent(t, rec); !strings.Contains(reply, "请回复修改意见") {
+		t.Fatalf("expected revise prompt, got %q", reply)
+	}
+	if _, ok, _ := store.GetPending(ctx, "ou_user", "oc_plan"); !ok {
+		t.Fatal("expected pending review to survive the revise click")
+	}
+
+	if err := gw.InjectMessage(ctx, "oc_plan", "p2p", "ou_user", "om_feedback", "split the design step"); err != nil {
+		t.Fatalf("InjectMessage failed: %v", err)
+	}
+	gw.WaitForTasks()
+	if !strings.Contains(executor.capturedTask, "<plan_feedback>") || !strings.Contains(executor.capturedTask, "split the design step") {
+		t.Fatalf("expected revise text as plan feedback, got %q", executor.capturedTask)
+	}
+	if _, ok, _ := store.GetPending(ctx, "ou_user", "oc_plan"); ok {
+		t.Fatal("expected pending review to be cleared after feedback")
+	}
+}
+
+func TestPlanReviewCardActionRejectsForeignAndStaleClicks(t *testing.T) {
+	executor := &capturingExecutor{result: &agent.TaskResult{Answer: "done"}}
+	gw, store := newPlanReviewCardGateway(t, executor, NewRecordingMessenger())
+	ctx := context.Background()
+
+	toast, _ := gw.planReviewApproveCardAction(ctx, planReviewCardAction(cardActionPlanReviewApprove, "om_card", "ou_other"))
+	if toast != "仅计划发起人可以处理该计划。" {
+		t.Fatalf("expected requester check, got %q", toast)
+	}
+
+	stale := planReviewCardAction(cardActionPlanReviewApprove, "om_old_card", "ou_user")
+	stale.Value["run_id"] = "run-0"
+	toast, _ = gw.planReviewApproveCardAction(ctx, stale)
+	if toast != "该计划已过期。" {
+		t.Fatalf("expected stale plan toast, got %q", toast)
+	}
+
+	gw.WaitForTasks()
+	if executor.capturedTask != "" {
+		t.Fatalf("expected rejected clicks not to run, got %q", executor.capturedTask)
+	}
+	if _, ok, _ := store.GetPending(ctx, "ou_user", "oc_plan"); !ok {
+		t.Fatal("expected pending review to be kept")
+	}
+}
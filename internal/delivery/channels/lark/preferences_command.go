@@ -0,0 +1,139 @@
+package lark
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"alex/internal/app/preferences"
+	"alex/internal/shared/utils"
+)
+
+// PreferencesStore is the narrow preferences port used by the /prefs command.
+// Satisfied by *preferences.Store.
+type PreferencesStore interface {
+	Get(ctx context.Context, userID string) (preferences.Record, bool, error)
+	Update(ctx context.Context, userID string, expectedVersion int64, prefs preferences.Preferences) (preferences.Record, error)
+}
+
+// preferenceFields maps /prefs field names (and aliases) to setters.
+var preferenceFields = map[string]func(*preferences.Preferences, string){
+	"language":     func(p *preferences.Preferences, v string) { p.Language = v },
+	"lang":         func(p *preferences.Preferences, v string) { p.Language = v },
+	"tone":         func(p *preferences.Preferences, v string) { p.Tone = v },
+	"code_style":   func(p *preferences.Preferences, v string) { p.CodeStyle = v },
+	"code":         func(p *preferences.Preferences, v string) { p.CodeStyle = v },
+	"length":       func(p *preferences.Preferences, v string) { p.ResponseLength = preferences.ResponseLength(v) },
+	"instructions": func(p *preferences.Preferences, v string) { p.CustomInstructions = v },
+	"custom":       func(p *preferences.Preferences, v string) { p.CustomInstructions = v },
+}
+
+// isPreferencesCommand checks whether the message is a /prefs command.
+func (g *Gateway) isPreferencesCommand(trimmed string) bool {
+	lower := utils.TrimLower(trimmed)
+	return lower == "/prefs" || strings.HasPrefix(lower, "/prefs ")
+}
+
+// handlePreferencesCommand shows or updates the sender's preferences. The
+// sender ID is the same user-scope identity attached to their task contexts,
+// so changes apply to the next task in any chat.
+func (g *Gateway) handlePreferencesCommand(msg *incomingMessage) {
+	if g == nil || msg == nil {
+		return
+	}
+	execCtx := g.buildTaskCommandContext(msg)
+	reply := g.preferencesReply(execCtx, msg.senderID, strings.TrimSpace(msg.content))
+	g.dispatch(execCtx, msg.chatID, replyTarget(msg.messageID, true), "text", textContent(reply))
+}
+
+func (g *Gateway) preferencesReply(ctx context.Context, userID, content string) string {
+	if g.preferences == nil {
+		return "偏好设置不可用：存储未配置。"
+	}
+	fields := strings.Fields(content)
+	sub := ""
+	if len(fields) > 1 {
+		sub = utils.TrimLower(fields[1])
+	}
+	switch sub {
+	case "", "show", "status":
+		return g.showPreferences(ctx, userID)
+	case "set":
+		if len(fields) < 4 {
+			return preferencesCommandUsage()
+		}
+		return g.updatePreferences(ctx, userID, utils.TrimLower(fields[2]), textAfterFields(content, 3))
+	case "clear", "unset":
+		if len(fields) < 3 {
+			return g.replacePreferences(ctx, userID, func(p *preferences.Preferences) { *p = preferences.Preferences{} })
+		}
+		return g.updatePreferences(ctx, userID, utils.TrimLower(fields[2]), "")
+	default:
+		return preferencesCommandUsage()
+	}
+}
+
+func (g *Gateway) showPreferences(ctx context.Context, userID string) string {
+	record, ok, err := g.preferences.Get(ctx, userID)
+	if err != nil {
+		g.logger.Warn("Preferences load failed: %v", err)
+		return fmt.Sprintf("读取偏好设置失败：%v", err)
+	}
+	if !ok || record.Preferences.IsZero() {
+		return "当前没有偏好设置。\n\n" + preferencesCommandUsage()
+	}
+	return fmt.Sprintf("当前偏好设置 (version %d):\n%s", record.Version, preferences.Render(record.Preferences))
+}
+
+func (g *Gateway) updatePreferences(ctx context.Context, userID, field, value string) string {
+	setter, ok := preferenceFields[field]
+	if !ok {
+		return fmt.Sprintf("未知字段：%s\n\n%s", field, preferencesCommandUsage())
+	}
+	return g.replacePreferences(ctx, userID, func(p *preferences.Preferences) { setter(p, value) })
+}
+
+func (g *Gateway) replacePreferences(ctx context.Context, userID string, mutate func(*preferences.Preferences)) string {
+	current, _, err := g.preferences.Get(ctx, userID)
+	if err != nil {
+		return fmt.Sprintf("读取偏好设置失败：%v", err)
+	}
+	next := current.Preferences
+	mutate(&next)
+	record, err := g.preferences.Update(ctx, userID, current.Version, next)
+	switch {
+	case errors.Is(err, preferences.ErrVersionConflict):
+		return "偏好设置已被其他渠道修改，请重试。"
+	case err != nil:
+		return fmt.Sprintf("更新偏好设置失败：%v", err)
+	case record.Preferences.IsZero():
+		return "已清除全部偏好设置。"
+	}
+	return fmt.Sprintf("已更新偏好设置 (version %d):\n%s", record.Version, preferences.Render(record.Preferences))
+}
+
+// textAfterFields drops the first n whitespace-separated fields and keeps the
+// remainder verbatim so multi-line instructions survive.
+func textAfterFields(content string, n int) string {
+	rest := strings.TrimSpace(content)
+	for i := 0; i < n && rest != ""; i++ {
+		idx := strings.IndexFunc(rest, unicode.IsSpace)
+		if idx < 0 {
+			return ""
+		}
+		rest = strings.TrimSpace(rest[idx:])
+	}
+	return rest
+}
+
+func preferencesCommandUsage() string {
+	return strings.TrimSpace(`
+Preferences command usage:
+  /prefs                         Show current preferences
+  /prefs set <field> <value>     Update one field
+  /prefs clear [field]           Clear one field, or all when omitted
+Fields: language, tone, code_style, length (concise|balanced|detailed), instructions
+`)
+}
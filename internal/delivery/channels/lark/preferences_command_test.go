@@ -0,0 +1,90 @@
+package lark
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"alex/internal/app/preferences"
+	"alex/internal/delivery/channels"
+	"alex/internal/shared/logging"
+)
+
+func newPreferencesTestGateway(t *testing.T) (*Gateway, *RecordingMessenger, *preferences.Store) {
+	t.Helper()
+	store, err := preferences.NewStore("")
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	recorder := NewRecordingMessenger()
+	gw := &Gateway{
+		cfg:         Config{BaseConfig: channels.BaseConfig{SessionPrefix: "lark", AllowDirect: true}, AppID: "test", AppSecret: "secret"},
+		logger:      logging.OrNop(nil),
+		messenger:   recorder,
+		preferences: store,
+	}
+	return gw, recorder, store
+}
+
+func sendPreferencesCommand(t *testing.T, gw *Gateway, recorder *RecordingMessenger, content string) string {
+	t.Helper()
+	before := len(recorder.CallsByMethod("ReplyMessage"))
+	gw.handlePreferencesCommand(&incomingMessage{chatID: "oc_prefs", messageID: "om_prefs", senderID: "ou_prefs", content: content})
+	calls := recorder.CallsByMethod("ReplyMessage")
+	if len(calls) != before+1 {
+		t.Fatalf("expected one reply for %q, got %d", content, len(calls)-before)
+	}
+	return extractTextContent(calls[len(calls)-1].Content, nil)
+}
+
+func TestIsPreferencesCommand(t *testing.T) {
+	g := &Gateway{}
+	for input, want := range map[string]bool{"/prefs": true, "/Prefs set tone calm": true, "/prefsx": false, "prefs": false} {
+		if got := g.isPreferencesCommand(input); got != want {
+			t.Fatalf("isPreferencesCommand(%q) = %v, want %v", input, got, want)
+		}
+	}
+}
+
+func TestHandlePreferencesCommandSetShowClear(t *testing.T) {
+	gw, recorder, store := newPreferencesTestGateway(t)
+
+	if reply := sendPreferencesCommand(t, gw, recorder, "/prefs"); !strings.Contains(reply, "当前没有偏好设置") {
+		t.Fatalf("unexpected empty reply: %q", reply)
+	}
+	reply := sendPreferencesCommand(t, gw, recorder, "/prefs set instructions never use emojis\nprefer tables")
+	if !strings.Contains(reply, "version 1") {
+		t.Fatalf("unexpected set reply: %q", reply)
+	}
+	record, ok, _ := store.Get(context.Background(), "ou_prefs")
+	if !ok || record.Preferences.CustomInstructions != "never use emojis\nprefer tables" {
+		t.Fatalf("expected verbatim instructions stored for sender, got %#v", record)
+	}
+	if reply := sendPreferencesCommand(t, gw, recorder, "/prefs show"); !strings.Contains(reply, "- Custom instructions: never use emojis") {
+		t.Fatalf("unexpected show reply: %q", reply)
+	}
+	if reply := sendPreferencesCommand(t, gw, recorder, "/prefs clear"); !strings.Contains(reply, "已清除全部偏好设置") {
+		t.Fatalf("unexpected clear reply: %q", reply)
+	}
+}
+
+func TestHandlePreferencesCommandRejectsInvalidInput(t *testing.T) {
+	gw, recorder, _ := newPreferencesTestGateway(t)
+
+	if reply := sendPreferencesCommand(t, gw, recorder, "/prefs set mood happy"); !strings.Contains(reply, "未知字段") {
+		t.Fatalf("expected unknown field reply, got %q", reply)
+	}
+	if reply := sendPreferencesCommand(t, gw, recorder, "/prefs set length huge"); !strings.Contains(reply, "response_length") {
+		t.Fatalf("expected validation reply, got %q", reply)
+	}
+}
+
+func TestHandlePreferencesCommandSharesStoreWithAPI(t *testing.T) {
+	gw, recorder, store := newPreferencesTestGateway(t)
+	if _, err := store.Update(context.Background(), "ou_prefs", preferences.AnyVersion, preferences.Preferences{Language: "Chinese"}); err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+	if reply := sendPreferencesCommand(t, gw, recorder, "/prefs"); !strings.Contains(reply, "- Language: Chinese") {
+		t.Fatalf("expected API-written preferences in Lark reply, got %q", reply)
+	}
+}
@@ -0,0 +1,185 @@
+package lark
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"alex/internal/delivery/channels"
+	"alex/internal/domain/agent/presets"
+	"alex/internal/shared/utils"
+)
+
+// Lark group roles accepted in Config.PresetAdminRoles.
+const (
+	presetRoleOwner   = "owner"
+	presetRoleManager = "manager"
+	presetRoleMember  = "member"
+)
+
+// normalizePresetAdminRoles lower-cases roles, drops unknown ones and falls
+// back to owner and manager when none remain.
+func normalizePresetAdminRoles(roles []string) []string {
+	normalized := make([]string, 0, len(roles))
+	for _, role := range roles {
+		role = utils.TrimLower(role)
+		switch role {
+		case presetRoleOwner, presetRoleManager, presetRoleMember:
+			if !slices.Contains(normalized, role) {
+				normalized = append(normalized, role)
+			}
+		}
+	}
+	if len(normalized) == 0 {
+		return []string{presetRoleOwner, presetRoleManager}
+	}
+	return normalized
+}
+
+// isPresetCommand checks whether the message is a /preset command.
+func (g *Gateway) isPresetCommand(trimmed string) bool {
+	lower := utils.TrimLower(trimmed)
+	return lower == "/preset" || strings.HasPrefix(lower, "/preset ")
+}
+
+// handlePresetCommand shows or switches the chat's agent preset. Switching
+// starts a fresh session because the system prompt differs per preset.
+func (g *Gateway) handlePresetCommand(msg *incomingMessage) {
+	if g == nil || msg == nil {
+		return
+	}
+	execCtx := channels.BuildBaseContext(g.cfg.BaseConfig, "lark", "", msg.senderID, msg.chatID, msg.isGroup)
+	execCtx = g.withLarkContext(execCtx, msg.chatID, msg.messageID)
+	reply := g.presetReply(execCtx, msg)
+	g.dispatch(execCtx, msg.chatID, replyTarget(msg.messageID, true), "text", textContent(reply))
+}
+
+func (g *Gateway) presetReply(ctx context.Context, msg *incomingMessage) string {
+	fields := strings.Fields(strings.TrimSpace(msg.content))
+	if len(fields) < 2 || utils.TrimLower(fields[1]) == "show" {
+		return g.presetShowReply(ctx, msg.chatID)
+	}
+	if len(fields) > 2 || utils.TrimLower(fields[1]) == "help" {
+		return presetCommandUsage()
+	}
+	name := utils.TrimLower(fields[1])
+	if _, err := presets.GetPromptConfig(presets.AgentPreset(name)); err != nil {
+		return fmt.Sprintf("未知 preset：%s\n\n%s", fields[1], presetCommandUsage())
+	}
+	if g.chatSessionStore == nil {
+		return "Preset 切换不可用：未配置会话绑定存储。"
+	}
+	if msg.isGroup {
+		allowed, err := g.canChangeGroupPreset(ctx, msg)
+		if err != nil {
+			g.logger.Warn("Lark /preset admin lookup failed: chat=%s sender=%s err=%v", msg.chatID, msg.senderID, err)
+			return "无法确认群管理员身份，请稍后重试。"
+		}
+		if !allowed {
+			return "仅群主或群管理员可以切换本群的 preset。"
+		}
+	}
+	if name == g.chatAgentPreset(ctx, msg.chatID) {
+		return fmt.Sprintf("当前已在使用 preset：%s", name)
+	}
+
+	slot := g.getOrCreateSlot(msg.chatID)
+	slot.mu.Lock()
+	newSessionID, wasRunning := g.switchToNewSession(slot, msg, "/preset") // releases slot.mu
+	if err := g.saveChatAgentPreset(ctx, msg.chatID, newSessionID, name); err != nil {
+		g.logger.Warn("Lark /preset save failed: chat=%s preset=%s err=%v", msg.chatID, name, err)
+		return fmt.Sprintf("保存 preset 失败：%v", err)
+	}
+	g.logger.Info("Lark /preset: chat=%s sender=%s preset=%s session=%s", msg.chatID, msg.senderID, name, newSessionID)
+	if wasRunning {
+		return fmt.Sprintf("已停止当前调用，切换到 preset：%s，并开启新会话。", name)
+	}
+	return fmt.Sprintf("已切换到 preset：%s，并开启新会话。", name)
+}
+
+func (g *Gateway) presetShowReply(ctx context.Context, chatID string) string {
+	var b strings.Builder
+	if preset := g.chatAgentPreset(ctx, chatID); preset != "" {
+		fmt.Fprintf(&b, "当前 preset：%s（本会话设置）", preset)
+	} else if g.cfg.AgentPreset != "" {
+		fmt.Fprintf(&b, "当前 preset：%s（渠道默认）", g.cfg.AgentPreset)
+	} else {
+		b.WriteString("当前使用渠道默认 preset。")
+	}
+	b.WriteString("\n\n可用 preset：")
+	for _, preset := range presets.AgentPresets() {
+		cfg, err := presets.GetPromptConfig(preset)
+		if err != nil {
+			continue
+		}
+		fmt.Fprintf(&b, "\n  %s — %s", preset, cfg.Description)
+	}
+	return b.String()
+}
+
+// canChangeGroupPreset reports whether the sender holds one of the
+// configured group roles.
+func (g *Gateway) canChangeGroupPreset(ctx context.Context, msg *incomingMessage) (bool, error) {
+	roles := g.cfg.PresetAdminRoles
+	if slices.Contains(roles, presetRoleMember) {
+		return true, nil
+	}
+	lister, ok := g.messenger.(chatAdminLister)
+	if !ok {
+		return false, fmt.Errorf("lark messenger does not support chat admin lookup")
+	}
+	owner, managers, err := lister.ChatAdmins(ctx, msg.chatID)
+	if err != nil {
+		return false, err
+	}
+	if slices.Contains(roles, presetRoleOwner) && owner != "" && owner == msg.senderID {
+		return true, nil
+	}
+	return slices.Contains(roles, presetRoleManager) && slices.Contains(managers, msg.senderID), nil
+}
+
+// chatAgentPreset returns the chat's /preset override, or "" when unset.
+func (g *Gateway) chatAgentPreset(ctx context.Context, chatID string) string {
+	if g.chatSessionStore == nil {
+		return ""
+	}
+	binding, ok, err := g.chatSessionStore.GetBinding(ctx, chatSessionBindingChannel, strings.TrimSpace(chatID))
+	if err != nil {
+		g.logger.Warn("Load chat preset failed: chat=%s err=%v", chatID, err)
+		return ""
+	}
+	if !ok {
+		return ""
+	}
+	return binding.AgentPreset
+}
+
+func (g *Gateway) saveChatAgentPreset(ctx context.Context, chatID, sessionID, preset string) error {
+	return g.chatSessionStore.SaveBinding(context.WithoutCancel(ctx), ChatSessionBinding{
+		Channel:     chatSessionBindingChannel,
+		ChatID:      strings.TrimSpace(chatID),
+		SessionID:   sessionID,
+		AgentPreset: preset,
+		UpdatedAt:   g.currentTime(),
+	})
+}
+
+// applyChatPresets applies the channel presets to ctx, with the chat's
+// /preset override taking precedence over the channel agent preset.
+func (g *Gateway) applyChatPresets(ctx context.Context, chatID string) context.Context {
+	base := g.cfg.BaseConfig
+	if preset := g.chatAgentPreset(ctx, chatID); preset != "" {
+		base.AgentPreset = preset
+	}
+	return channels.ApplyPresets(ctx, base)
+}
+
+func presetCommandUsage() string {
+	return strings.TrimSpace(`
+Preset command usage:
+  /preset               Show the current preset and available presets
+  /preset show          Same as /preset
+  /preset <name>        Switch this chat's preset and start a new session
+`)
+}
@@ -0,0 +1,120 @@
+package lark
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	appcontext "alex/internal/app/agent/context"
+	"alex/internal/delivery/channels"
+	"alex/internal/shared/logging"
+)
+
+type adminListingMessenger struct {
+	*RecordingMessenger
+	owner    string
+	managers []string
+}
+
+func (m *adminListingMessenger) ChatAdmins(context.Context, string) (string, []string, error) {
+	return m.owner, m.managers, nil
+}
+
+func newPresetTestGateway(recorder *RecordingMessenger, store ChatSessionBindingStore, roles []string) *Gateway {
+	return &Gateway{
+		cfg: Config{
+			BaseConfig:       channels.BaseConfig{SessionPrefix: "lark", AllowDirect: true, AllowGroups: true, ToolPreset: "full"},
+			AppID:            "test",
+			AppSecret:        "secret",
+			PresetAdminRoles: normalizePresetAdminRoles(roles),
+		},
+		logger:           logging.OrNop(nil),
+		messenger:        &adminListingMessenger{RecordingMessenger: recorder, owner: "ou_owner", managers: []string{"ou_manager"}},
+		chatSessionStore: store,
+	}
+}
+
+func sendPresetCommand(t *testing.T, gw *Gateway, recorder *RecordingMessenger, msg incomingMessage) string {
+	t.Helper()
+	before := len(recorder.CallsByMethod("ReplyMessage"))
+	gw.handlePresetCommand(&msg)
+	calls := recorder.CallsByMethod("ReplyMessage")
+	if len(calls) != before+1 {
+		t.Fatalf("expected one reply for %q, got %d", msg.content, len(calls)-before)
+	}
+	return extractTextContent(calls[len(calls)-1].Content, nil)
+}
+
+func TestIsPresetCommand(t *testing.T) {
+	g := &Gateway{}
+	for input, want := range map[string]bool{"/preset": true, "/Preset researcher": true, "/presets": false, "preset": false} {
+		if got := g.isPresetCommand(input); got != want {
+			t.Fatalf("isPresetCommand(%q) = %v, want %v", input, got, want)
+		}
+	}
+}
+
+func TestHandlePresetCommandSwitchesAndStartsNewSession(t *testing.T) {
+	recorder := NewRecordingMessenger()
+	store := &stubChatSessionBindingStore{}
+	gw := newPresetTestGateway(recorder, store, nil)
+	slot := gw.getOrCreateSlot("oc_p2p")
+	slot.lastSessionID = "lark-old"
+	msg := incomingMessage{chatID: "oc_p2p", messageID: "om_1", senderID: "ou_user"}
+
+	msg.content = "/preset bogus"
+	if reply := sendPresetCommand(t, gw, recorder, msg); !strings.Contains(reply, "未知 preset") {
+		t.Fatalf("unexpected invalid reply: %q", reply)
+	}
+
+	msg.content = "/preset Researcher"
+	if reply := sendPresetCommand(t, gw, recorder, msg); !strings.Contains(reply, "researcher") || !strings.Contains(reply, "新会话") {
+		t.Fatalf("unexpected switch reply: %q", reply)
+	}
+	binding, ok, _ := store.GetBinding(context.Background(), chatSessionBindingChannel, "oc_p2p")
+	if !ok || binding.AgentPreset != "researcher" {
+		t.Fatalf("preset not persisted: %+v", binding)
+	}
+	if binding.SessionID == "lark-old" || binding.SessionID != slot.lastSessionID {
+		t.Fatalf("expected a fresh bound session, binding=%q slot=%q", binding.SessionID, slot.lastSessionID)
+	}
+
+	msg.content = "/preset show"
+	if reply := sendPresetCommand(t, gw, recorder, msg); !strings.Contains(reply, "当前 preset：researcher（本会话设置）") {
+		t.Fatalf("unexpected show reply: %q", reply)
+	}
+
+	// Session rotation and /new keep the override.
+	gw.persistChatSessionBinding(context.Background(), "oc_p2p", "lark-next")
+	ctx := gw.applyChatPresets(context.Background(), "oc_p2p")
+	presetCfg, _ := ctx.Value(appcontext.PresetContextKey{}).(appcontext.PresetConfig)
+	if presetCfg.AgentPreset != "researcher" || presetCfg.ToolPreset != "full" {
+		t.Fatalf("unexpected context presets: %+v", presetCfg)
+	}
+}
+
+func TestHandlePresetCommandRequiresGroupRole(t *testing.T) {
+	recorder := NewRecordingMessenger()
+	store := &stubChatSessionBindingStore{}
+	gw := newPresetTestGateway(recorder, store, nil)
+	msg := incomingMessage{chatID: "oc_group", messageID: "om_1", senderID: "ou_member", isGroup: true, content: "/preset devops"}
+
+	if reply := sendPresetCommand(t, gw, recorder, msg); !strings.Contains(reply, "仅群主或群管理员") {
+		t.Fatalf("expected member to be rejected, got %q", reply)
+	}
+	if preset := gw.chatAgentPreset(context.Background(), "oc_group"); preset != "" {
+		t.Fatalf("rejected change was persisted: %q", preset)
+	}
+
+	msg.senderID = "ou_manager"
+	if reply := sendPresetCommand(t, gw, recorder, msg); !strings.Contains(reply, "devops") {
+		t.Fatalf("expected manager to switch, got %q", reply)
+	}
+
+	gw.cfg.PresetAdminRoles = normalizePresetAdminRoles([]string{" Member "})
+	msg.senderID = "ou_member"
+	msg.content = "/preset architect"
+	if reply := sendPresetCommand(t, gw, recorder, msg); !strings.Contains(reply, "architect") {
+		t.Fatalf("expected member role to allow anyone, got %q", reply)
+	}
+}
@@ -2,6 +2,7 @@ package lark
 
 import (
 	"context"
+	"fmt"
 	"strings"
 	"sync"
 	"time"
@@ -19,6 +20,9 @@ const (
 	// to avoid rate-limiting (Lark imposes 5 QPS on message updates).
 	// 800ms keeps updates snappy while staying well within the 5 QPS limit.
 	progressFlushInterval = 800 * time.Millisecond
+	// progressMinUpdateInterval floors a configured ProgressUpdateInterval at
+	// Lark's 5 QPS per-message update limit.
+	progressMinUpdateInterval = 200 * time.Millisecond
 )
 
 // progressSender abstracts send/update for testability.
@@ -56,6 +60,14 @@ type progressListener struct {
 	closed    bool
 	iteration int  // current ReAct iteration count
 	nodeActive bool // true when in thinking phase (no active tools)
+
+	interval    time.Duration  // minimum time between progress API calls
+	editInPlace bool           // edit one status message instead of sending new ones
+	messageID   string         // edited status message (editInPlace only)
+	lastText    string         // last text sent or edited in, to skip no-op edits
+	editFailed  bool           // an edit failed; stop editing and reply normally
+	inflight    sync.WaitGroup // flushes that have released mu but not finished
+	flushMu     sync.Mutex     // serializes flushes so the first send sets messageID
 }
 
 // newProgressListener creates a progress listener that delegates all events
@@ -68,14 +80,34 @@ func newProgressListener(ctx context.Context, inner agent.EventListener, sender
 		ctx:       ctx,
 		now:       time.Now,
 		toolIndex: make(map[string]*toolStatus),
+		interval:  progressFlushInterval,
+	}
+}
+
+// withUpdateMode sets the flush interval and switches between standalone
+// progress messages (default) and a single status message edited in place.
+func (p *progressListener) withUpdateMode(interval time.Duration, editInPlace bool) *progressListener {
+	if interval > 0 {
+		p.interval = interval
+		if p.interval < progressMinUpdateInterval {
+			p.interval = progressMinUpdateInterval
+		}
 	}
+	p.editInPlace = editInPlace
+	return p
 }
 
-// MessageID always returns empty — progress messages are standalone
-// conversational messages, not edited in-place. The final reply is
-// always sent as a new message.
+// MessageID returns the status message to edit into the final reply. It is
+// empty for standalone progress messages, where the final reply is always a
+// new message, and when editing failed (e.g. the messenger cannot update
+// messages), which falls back to the same single-reply behavior.
 func (p *progressListener) MessageID() string {
-	return ""
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if !p.editInPlace || p.editFailed {
+		return ""
+	}
+	return p.messageID
 }
 
 // OnEvent forwards the event to the inner listener and tracks tool lifecycle.
@@ -144,7 +176,7 @@ func (p *progressListener) onEventToolStarted(e *domain.Event) {
 	p.toolIndex[e.Data.CallID] = ts
 	p.nodeActive = false
 
-	if uxphrases.IsKeyTool(e.Data.ToolName) {
+	if p.editInPlace || uxphrases.IsKeyTool(e.Data.ToolName) {
 		p.dirty = true
 		p.scheduleFlush()
 	}
@@ -166,6 +198,10 @@ func (p *progressListener) onEventToolCompleted(e *domain.Event) {
 	ts.done = true
 	ts.errored = e.Data.Error != nil
 	ts.duration = e.Data.Duration
+	if p.editInPlace {
+		p.dirty = true
+		p.scheduleFlush()
+	}
 }
 
 func (p *progressListener) onEnvelope(e *domain.WorkflowEventEnvelope) {
@@ -227,7 +263,7 @@ func (p *progressListener) onEnvelopeToolStarted(e *domain.WorkflowEventEnvelope
 	p.toolIndex[callID] = ts
 	p.nodeActive = false
 
-	if uxphrases.IsKeyTool(toolName) {
+	if p.editInPlace || uxphrases.IsKeyTool(toolName) {
 		p.dirty = true
 		p.scheduleFlush()
 	}
@@ -256,6 +292,10 @@ func (p *progressListener) onEnvelopeToolCompleted(e *domain.WorkflowEventEnvelo
 	} else {
 		ts.duration = p.clock().Sub(ts.started)
 	}
+	if p.editInPlace {
+		p.dirty = true
+		p.scheduleFlush()
+	}
 }
 
 func payloadString(e *domain.WorkflowEventEnvelope, key string) string {
@@ -326,11 +366,11 @@ func (p *progressListener) scheduleFlush() {
 	}
 
 	elapsed := p.clock().Sub(p.lastFlush)
-	if elapsed >= progressFlushInterval {
+	if elapsed >= p.interval {
 		// Enough time has passed; flush immediately in a goroutine.
 		p.timer = time.AfterFunc(0, p.flush)
 	} else {
-		remaining := progressFlushInterval - elapsed
+		remaining := p.interval - elapsed
 		p.timer = time.AfterFunc(remaining, p.flush)
 	}
 }
@@ -345,26 +385,69 @@ func (p *progressListener) doSend(text, logPrefix string) {
 	}
 }
 
-// flush sends the progress message.
+// doEdit sends the status message on first use and edits it afterwards. A
+// failed edit disables editing for the rest of the run.
+// Must be called WITHOUT p.mu held.
+func (p *progressListener) doEdit(text, messageID string) {
+	if messageID == "" {
+		newID, err := p.sender.SendProgress(p.ctx, text)
+		if err != nil {
+			p.logger.Warn("Lark progress status send failed: %v", err)
+			return
+		}
+		p.mu.Lock()
+		p.messageID = newID
+		p.mu.Unlock()
+		return
+	}
+	if err := p.sender.UpdateProgress(p.ctx, messageID, text); err != nil {
+		p.logger.Warn("Lark progress edit failed, falling back to a separate reply: %v", err)
+		p.mu.Lock()
+		p.editFailed = true
+		p.mu.Unlock()
+	}
+}
+
+// flush sends the progress message, or edits the status message in place.
 func (p *progressListener) flush() {
+	p.flushMu.Lock()
+	defer p.flushMu.Unlock()
+
 	p.mu.Lock()
-	if !p.dirty || p.closed {
+	if !p.dirty || p.closed || p.editFailed {
 		p.timer = nil
 		p.mu.Unlock()
 		return
 	}
 
 	text := p.buildText()
+	if p.editInPlace {
+		text = p.buildStatusText()
+	}
 	p.dirty = false
-	p.lastFlush = p.clock()
 	p.timer = nil
+	if p.editInPlace && text == p.lastText {
+		p.mu.Unlock()
+		return
+	}
+	p.lastText = text
+	p.lastFlush = p.clock()
+	editInPlace, messageID := p.editInPlace, p.messageID
+	p.inflight.Add(1)
 	p.mu.Unlock()
+	defer p.inflight.Done()
 
+	if editInPlace {
+		p.doEdit(text, messageID)
+		return
+	}
 	p.doSend(text, "")
 }
 
-// Close cleans up timers. No final flush — the last progress message
-// is already sent; the final reply will follow as a separate message.
+// Close cleans up timers and waits for an in-flight flush, so a late
+// progress edit cannot overwrite the final reply. No final flush — the last
+// progress message is already sent; the final reply follows as a separate
+// message or, in edit mode, replaces the status message.
 func (p *progressListener) Close() {
 	p.mu.Lock()
 	if p.closed {
@@ -377,6 +460,7 @@ func (p *progressListener) Close() {
 		p.timer = nil
 	}
 	p.mu.Unlock()
+	p.inflight.Wait()
 }
 
 // Natural conversational progress phrases — spoken like a helpful colleague.
@@ -406,6 +490,29 @@ func (p *progressListener) buildText() string {
 	return uxphrases.PickPhrase(naturalThinkingPhrases, p.iteration)
 }
 
+// buildStatusText renders the edited status message: the running tool (or a
+// working-on-it line) and how many tool steps have finished.
+// Must be called with p.mu held.
+func (p *progressListener) buildStatusText() string {
+	if len(p.tools) == 0 {
+		return "⏳ 收到，正在处理…"
+	}
+	done := 0
+	running := ""
+	for _, ts := range p.tools {
+		if ts.done {
+			done++
+		} else {
+			running = ts.toolName
+		}
+	}
+	line := "⏳ 思考中…"
+	if running != "" {
+		line = fmt.Sprintf("⏳ 正在运行 %s…", running)
+	}
+	return fmt.Sprintf("%s\n已完成 %d/%d 步", line, done, len(p.tools))
+}
+
 func (p *progressListener) clock() time.Time {
 	return p.now()
 }
@@ -419,7 +526,7 @@ type larkProgressSender struct {
 }
 
 func (s *larkProgressSender) SendProgress(ctx context.Context, text string) (string, error) {
-	return s.gateway.dispatchMessage(ctx, s.chatID, replyTarget(s.messageID, false), "text", textContent(text))
+	return s.gateway.dispatchMessage(ctx, s.chatID, replyTarget(s.messageID, s.gateway.repliesInThread(s.messageID)), "text", textContent(text))
 }
 
 func (s *larkProgressSender) UpdateProgress(ctx context.Context, messageID, text string) error {
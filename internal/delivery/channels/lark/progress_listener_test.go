@@ -45,8 +45,9 @@ type spySender struct {
 		messageID string
 		text      string
 	}
-	nextID string
-	err    error
+	nextID    string
+	err       error
+	updateErr error // fails UpdateProgress only
 }
 
 func (s *spySender) SendProgress(_ context.Context, text string) (string, error) {
@@ -70,6 +71,9 @@ func (s *spySender) UpdateProgress(_ context.Context, messageID, text string) er
 		messageID string
 		text      string
 	}{messageID, text})
+	if s.updateErr != nil {
+		return s.updateErr
+	}
 	return s.err
 }
 
@@ -411,6 +415,92 @@ func TestProgressListenerMessageIDAlwaysEmpty(t *testing.T) {
 	pl.Close()
 }
 
+func TestProgressListenerEditInPlaceEditsSingleMessage(t *testing.T) {
+	clk := newTestClock(time.Date(2026, 1, 29, 12, 0, 0, 0, time.UTC))
+	sender := &spySender{nextID: "om_status"}
+	pl := newProgressListener(context.Background(), nil, sender, nil).withUpdateMode(0, true)
+	pl.now = clk.Now
+
+	pl.OnEvent(makeNodeStarted(1))
+	time.Sleep(100 * time.Millisecond)
+	if sender.sendCount() != 1 || !strings.Contains(sender.lastSendText(), "正在处理") {
+		t.Fatalf("expected initial working-on-it message, got sends=%d text=%q", sender.sendCount(), sender.lastSendText())
+	}
+
+	clk.Advance(time.Second)
+	pl.OnEvent(makeToolStarted("call-1", "read_file")) // non-key tools update too
+	time.Sleep(100 * time.Millisecond)
+	if got := sender.lastUpdateText(); !strings.Contains(got, "正在运行 read_file") || !strings.Contains(got, "已完成 0/1 步") {
+		t.Fatalf("unexpected status edit %q", got)
+	}
+
+	clk.Advance(time.Second)
+	pl.OnEvent(makeEnvelopeToolCompleted("call-1", "read_file", 20*time.Millisecond, nil))
+	time.Sleep(100 * time.Millisecond)
+	if got := sender.lastUpdateText(); !strings.Contains(got, "已完成 1/1 步") {
+		t.Fatalf("expected completed step count, got %q", got)
+	}
+
+	pl.Close()
+	if sender.sendCount() != 1 {
+		t.Fatalf("expected a single status message, got %d sends", sender.sendCount())
+	}
+	if sender.updateCount() != 2 {
+		t.Fatalf("expected 2 edits, got %d", sender.updateCount())
+	}
+	if id := pl.MessageID(); id != "om_status" {
+		t.Fatalf("expected status message to be edited into the reply, got %q", id)
+	}
+}
+
+func TestProgressListenerEditFailureFallsBackToReply(t *testing.T) {
+	clk := newTestClock(time.Date(2026, 1, 29, 12, 0, 0, 0, time.UTC))
+	sender := &spySender{nextID: "om_status", updateErr: fmt.Errorf("update not supported")}
+	pl := newProgressListener(context.Background(), nil, sender, nil).withUpdateMode(0, true)
+	pl.now = clk.Now
+
+	pl.OnEvent(makeNodeStarted(1))
+	time.Sleep(100 * time.Millisecond)
+	clk.Advance(time.Second)
+	pl.OnEvent(makeToolStarted("call-1", "web_search"))
+	time.Sleep(100 * time.Millisecond)
+	clk.Advance(time.Second)
+	pl.OnEvent(makeToolStarted("call-2", "shell_exec"))
+	time.Sleep(100 * time.Millisecond)
+	pl.Close()
+
+	if sender.updateCount() != 1 {
+		t.Fatalf("expected editing to stop after the first failure, got %d edits", sender.updateCount())
+	}
+	if sender.sendCount() != 1 {
+		t.Fatalf("expected no follow-up messages after edit failure, got %d sends", sender.sendCount())
+	}
+	if id := pl.MessageID(); id != "" {
+		t.Fatalf("expected empty MessageID after edit failure, got %q", id)
+	}
+}
+
+func TestProgressListenerUpdateInterval(t *testing.T) {
+	cases := []struct {
+		name     string
+		interval time.Duration
+		want     time.Duration
+	}{
+		{"default", 0, progressFlushInterval},
+		{"configured", 3 * time.Second, 3 * time.Second},
+		{"floored at rate limit", 10 * time.Millisecond, progressMinUpdateInterval},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			pl := newProgressListener(context.Background(), nil, &spySender{}, nil).withUpdateMode(tc.interval, false)
+			defer pl.Close()
+			if pl.interval != tc.want {
+				t.Fatalf("interval = %v, want %v", pl.interval, tc.want)
+			}
+		})
+	}
+}
+
 // --- helpers ---
 
 func isNaturalThinkingPhrase(text string) bool {
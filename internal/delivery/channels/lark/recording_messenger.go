@@ -10,14 +10,17 @@ import (
 
 // Messenger method name constants used in MessengerCall.Method.
 const (
-	MethodSendMessage    = "SendMessage"
-	MethodReplyMessage   = "ReplyMessage"
-	MethodUpdateMessage  = "UpdateMessage"
-	MethodAddReaction    = "AddReaction"
-	MethodDeleteReaction = "DeleteReaction"
-	MethodUploadImage    = "UploadImage"
-	MethodUploadFile     = "UploadFile"
-	MethodListMessages   = "ListMessages"
+	MethodSendMessage      = "SendMessage"
+	MethodReplyMessage     = "ReplyMessage"
+	MethodUpdateMessage    = "UpdateMessage"
+	MethodAddReaction      = "AddReaction"
+	MethodDeleteReaction   = "DeleteReaction"
+	MethodUploadImage      = "UploadImage"
+	MethodUploadFile       = "UploadFile"
+	MethodListMessages     = "ListMessages"
+	MethodPinMessage       = "PinMessage"
+	MethodUnpinMessage     = "UnpinMessage"
+	MethodDownloadResource = "DownloadMessageResource"
 )
 
 // MessengerCall records a single outbound call made through a LarkMessenger.
@@ -34,6 +37,7 @@ type MessengerCall struct {
 	FileType   string
 	PageSize   int
 	Payload    []byte
+	InThread   bool // ReplyMessage sent as a thread reply
 }
 
 // RecordingMessenger implements LarkMessenger by recording all outbound calls
@@ -58,12 +62,21 @@ type RecordingMessenger struct {
 	// ListMessagesResult is returned by ListMessages.
 	ListMessagesResult []*larkim.Message
 
+	// resources maps a file key to the payload returned by
+	// DownloadMessageResource.
+	resources map[string]recordedResource
+
 	// updateMessageError, when set, is always returned by UpdateMessage.
 	updateMessageError error
 
 	sendCount int
 }
 
+type recordedResource struct {
+	payload  []byte
+	fileName string
+}
+
 // NewRecordingMessenger creates a RecordingMessenger with sensible defaults.
 func NewRecordingMessenger() *RecordingMessenger {
 	return &RecordingMessenger{}
@@ -112,6 +125,16 @@ func (r *RecordingMessenger) ReplyMessage(_ context.Context, replyToID, msgType,
 	return r.nextMsgID(), nil
 }
 
+func (r *RecordingMessenger) ReplyMessageInThread(_ context.Context, replyToID, msgType, content string) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.record(MessengerCall{Method: MethodReplyMessage, ReplyTo: replyToID, MsgType: msgType, Content: content, InThread: true})
+	if err := r.popError(); err != nil {
+		return "", err
+	}
+	return r.nextMsgID(), nil
+}
+
 func (r *RecordingMessenger) UpdateMessage(_ context.Context, messageID, msgType, content string) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
@@ -122,6 +145,34 @@ func (r *RecordingMessenger) UpdateMessage(_ context.Context, messageID, msgType
 	return r.popError()
 }
 
+func (r *RecordingMessenger) PinMessage(_ context.Context, messageID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.record(MessengerCall{Method: MethodPinMessage, MsgID: messageID})
+	return r.popError()
+}
+
+func (r *RecordingMessenger) UnpinMessage(_ context.Context, messageID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.record(MessengerCall{Method: MethodUnpinMessage, MsgID: messageID})
+	return r.popError()
+}
+
+func (r *RecordingMessenger) DownloadMessageResource(_ context.Context, messageID, fileKey, resourceType string) ([]byte, string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.record(MessengerCall{Method: MethodDownloadResource, MsgID: messageID, FileName: fileKey, FileType: resourceType})
+	if err := r.popError(); err != nil {
+		return nil, "", err
+	}
+	res, ok := r.resources[fileKey]
+	if !ok {
+		return nil, "", fmt.Errorf("resource %s not found", fileKey)
+	}
+	return res.payload, res.fileName, nil
+}
+
 func (r *RecordingMessenger) AddReaction(_ context.Context, messageID, emojiType string) (string, error) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
@@ -211,6 +262,17 @@ func (r *RecordingMessenger) SetUploadFileResult(fileKey string, err error) {
 	}
 }
 
+// SetResource configures the payload and file name DownloadMessageResource
+// returns for fileKey.
+func (r *RecordingMessenger) SetResource(fileKey string, payload []byte, fileName string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.resources == nil {
+		r.resources = make(map[string]recordedResource)
+	}
+	r.resources[fileKey] = recordedResource{payload: payload, fileName: fileName}
+}
+
 // SetUpdateMessageError configures a persistent error for all UpdateMessage calls.
 func (r *RecordingMessenger) SetUpdateMessageError(err error) {
 	r.mu.Lock()
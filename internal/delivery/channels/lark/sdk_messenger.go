@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"io"
 
 	lark "github.com/larksuite/oapi-sdk-go/v3"
 	larkim "github.com/larksuite/oapi-sdk-go/v3/service/im/v1"
@@ -64,6 +65,28 @@ func (m *sdkMessenger) ReplyMessage(ctx context.Context, replyToID, msgType, con
 	return *resp.Data.MessageId, nil
 }
 
+func (m *sdkMessenger) ReplyMessageInThread(ctx context.Context, replyToID, msgType, content string) (string, error) {
+	req := larkim.NewReplyMessageReqBuilder().
+		MessageId(replyToID).
+		Body(larkim.NewReplyMessageReqBodyBuilder().
+			MsgType(msgType).
+			Content(content).
+			ReplyInThread(true).
+			Build()).
+		Build()
+	resp, err := m.client.Im.Message.Reply(ctx, req)
+	if err != nil {
+		return "", err
+	}
+	if !resp.Success() {
+		return "", fmt.Errorf("lark thread reply error: code=%d msg=%s", resp.Code, resp.Msg)
+	}
+	if resp.Data == nil || resp.Data.MessageId == nil {
+		return "", nil
+	}
+	return *resp.Data.MessageId, nil
+}
+
 func (m *sdkMessenger) UpdateMessage(ctx context.Context, messageID, msgType, content string) error {
 	req := larkim.NewUpdateMessageReqBuilder().
 		MessageId(messageID).
@@ -180,3 +203,78 @@ func (m *sdkMessenger) ListMessages(ctx context.Context, chatID string, pageSize
 	}
 	return resp.Data.Items, nil
 }
+
+func (m *sdkMessenger) PinMessage(ctx context.Context, messageID string) error {
+	req := larkim.NewCreatePinReqBuilder().
+		Body(larkim.NewCreatePinReqBodyBuilder().
+			MessageId(messageID).
+			Build()).
+		Build()
+	resp, err := m.client.Im.Pin.Create(ctx, req)
+	if err != nil {
+		return err
+	}
+	if !resp.Success() {
+		return fmt.Errorf("lark pin message error: code=%d msg=%s", resp.Code, resp.Msg)
+	}
+	return nil
+}
+
+func (m *sdkMessenger) UnpinMessage(ctx context.Context, messageID string) error {
+	req := larkim.NewDeletePinReqBuilder().
+		MessageId(messageID).
+		Build()
+	resp, err := m.client.Im.Pin.Delete(ctx, req)
+	if err != nil {
+		return err
+	}
+	if !resp.Success() {
+		return fmt.Errorf("lark unpin message error: code=%d msg=%s", resp.Code, resp.Msg)
+	}
+	return nil
+}
+
+func (m *sdkMessenger) DownloadMessageResource(ctx context.Context, messageID, fileKey, resourceType string) ([]byte, string, error) {
+	req := larkim.NewGetMessageResourceReqBuilder().
+		MessageId(messageID).
+		FileKey(fileKey).
+		Type(resourceType).
+		Build()
+	resp, err := m.client.Im.MessageResource.Get(ctx, req)
+	if err != nil {
+		return nil, "", fmt.Errorf("lark message resource API call failed: %w", err)
+	}
+	if !resp.Success() {
+		return nil, "", fmt.Errorf("lark message resource API error: code=%d msg=%s", resp.Code, resp.Msg)
+	}
+	if resp.File == nil {
+		return nil, "", fmt.Errorf("lark message resource missing body")
+	}
+	payload, err := io.ReadAll(resp.File)
+	if err != nil {
+		return nil, "", fmt.Errorf("lark message resource read failed: %w", err)
+	}
+	return payload, resp.FileName, nil
+}
+
+func (m *sdkMessenger) ChatAdmins(ctx context.Context, chatID string) (string, []string, error) {
+	req := larkim.NewGetChatReqBuilder().
+		ChatId(chatID).
+		UserIdType("open_id").
+		Build()
+	resp, err := m.client.Im.Chat.Get(ctx, req)
+	if err != nil {
+		return "", nil, err
+	}
+	if !resp.Success() {
+		return "", nil, fmt.Errorf("lark get chat error: code=%d msg=%s", resp.Code, resp.Msg)
+	}
+	if resp.Data == nil {
+		return "", nil, nil
+	}
+	owner := ""
+	if resp.Data.OwnerId != nil {
+		owner = *resp.Data.OwnerId
+	}
+	return owner, resp.Data.UserManagerIdList, nil
+}
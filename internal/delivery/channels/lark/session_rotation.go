@@ -0,0 +1,184 @@
+package lark
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	ports "alex/internal/domain/agent/ports"
+	storage "alex/internal/domain/agent/ports/storage"
+	id "alex/internal/shared/utils/id"
+)
+
+const (
+	defaultRotationSummaryMaxRunes = 1200
+	rotationSummaryEntryMaxRunes   = 160
+	rotationSummaryHeader          = "[Previous Session Summary]"
+	rotationNotice                 = "会话过长，已开启新会话（上一会话已摘要）。"
+
+	// sessionTokensMetadataKey holds cumulative LLM tokens recorded by the
+	// coordinator after each run.
+	sessionTokensMetadataKey = "total_tokens"
+)
+
+// sessionRotation describes a completed rotation: the new session plus the
+// summary of the old one to carry into the first turn.
+type sessionRotation struct {
+	newSessionID string
+	session      *storage.Session
+	summary      string
+}
+
+// sessionRotationEnabled reports whether any rotation limit is configured.
+func (g *Gateway) sessionRotationEnabled() bool {
+	rc := g.cfg.SessionRotation
+	return rc.MaxMessages > 0 || rc.MaxTokens > 0 || rc.MaxAge > 0
+}
+
+// sessionRotationReason returns a non-empty reason when session exceeds one of
+// the configured rotation limits.
+func (g *Gateway) sessionRotationReason(session *storage.Session) string {
+	if session == nil {
+		return ""
+	}
+	rc := g.cfg.SessionRotation
+	if rc.MaxMessages > 0 && len(session.Messages) >= rc.MaxMessages {
+		return fmt.Sprintf("messages=%d", len(session.Messages))
+	}
+	if rc.MaxTokens > 0 && session.Metadata != nil {
+		if tokens, err := strconv.Atoi(strings.TrimSpace(session.Metadata[sessionTokensMetadataKey])); err == nil && tokens >= rc.MaxTokens {
+			return fmt.Sprintf("tokens=%d", tokens)
+		}
+	}
+	if rc.MaxAge > 0 && !session.CreatedAt.IsZero() {
+		if age := g.currentTime().Sub(session.CreatedAt); age >= rc.MaxAge {
+			return fmt.Sprintf("age=%s", age.Truncate(time.Minute))
+		}
+	}
+	return ""
+}
+
+// maybeRotateSession replaces an oversized session with a fresh one before a
+// new turn starts. Rotation never fires while the chat is answering an
+// await_user_input question or a pending plan review, and never for btw fork
+// sessions. On success the chat binding and slot are moved to the new
+// session; the caller prepends the summary to the task content.
+func (g *Gateway) maybeRotateSession(ctx context.Context, session *storage.Session, msg *incomingMessage, sessionID string, isResume bool, taskToken uint64) (*sessionRotation, bool) {
+	if !g.sessionRotationEnabled() || isResume || taskToken == 0 {
+		return nil, false
+	}
+	if strings.Contains(sessionID, "/btw/") || sessionHasAwaitFlag(session) {
+		return nil, false
+	}
+	reason := g.sessionRotationReason(session)
+	if reason == "" {
+		return nil, false
+	}
+	// Only the chat's foreground slot owns the binding; conversation-process
+	// workers run in their own slot map and keep their sessions.
+	raw, ok := g.activeSlots.Load(msg.chatID)
+	if !ok {
+		return nil, false
+	}
+	slot, ok := raw.(*sessionSlot)
+	if !ok {
+		return nil, false
+	}
+	if g.cfg.PlanReviewEnabled {
+		g.planReviewMu.Lock()
+		_, pending := g.loadPlanReviewPending(ctx, session, msg.senderID, msg.chatID)
+		g.planReviewMu.Unlock()
+		if pending {
+			return nil, false
+		}
+	}
+
+	newSessionID := g.newSessionID()
+	newSession, err := g.agent.EnsureSession(id.WithSessionID(ctx, newSessionID), newSessionID)
+	if err != nil {
+		g.logger.Warn("Lark session rotation: ensure new session failed: chat=%s old=%s err=%v", msg.chatID, sessionID, err)
+		return nil, false
+	}
+	if newSession != nil && newSession.ID != "" {
+		newSessionID = newSession.ID
+	}
+
+	slot.mu.Lock()
+	if slot.taskToken != taskToken {
+		// A /new or another control action superseded this task.
+		slot.mu.Unlock()
+		return nil, false
+	}
+	slot.sessionID = newSessionID
+	slot.lastSessionID = newSessionID
+	slot.mu.Unlock()
+
+	g.logger.Info("Lark session rotation: chat=%s old=%s new=%s reason=%s", msg.chatID, sessionID, newSessionID, reason)
+	return &sessionRotation{
+		newSessionID: newSessionID,
+		session:      newSession,
+		summary:      buildRotationSummary(session, g.rotationSummaryMaxRunes()),
+	}, true
+}
+
+func (g *Gateway) rotationSummaryMaxRunes() int {
+	if g.cfg.SessionRotation.SummaryMaxRunes > 0 {
+		return g.cfg.SessionRotation.SummaryMaxRunes
+	}
+	return defaultRotationSummaryMaxRunes
+}
+
+// buildRotationSummary compacts the old session into a short block: its title
+// (when set) and the most recent user/assistant turns, newest last, within
+// maxRunes.
+func buildRotationSummary(session *storage.Session, maxRunes int) string {
+	if session == nil {
+		return ""
+	}
+	var entries []string
+	used := 0
+	for i := len(session.Messages) - 1; i >= 0; i-- {
+		entry := rotationSummaryEntry(session.Messages[i])
+		if entry == "" {
+			continue
+		}
+		size := len([]rune(entry)) + 1
+		if used+size > maxRunes {
+			break
+		}
+		used += size
+		entries = append(entries, entry)
+	}
+	if len(entries) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString(rotationSummaryHeader)
+	if title := strings.TrimSpace(session.Metadata["title"]); title != "" {
+		sb.WriteString("\nTitle: ")
+		sb.WriteString(title)
+	}
+	for i := len(entries) - 1; i >= 0; i-- {
+		sb.WriteString("\n")
+		sb.WriteString(entries[i])
+	}
+	return sb.String()
+}
+
+func rotationSummaryEntry(msg ports.Message) string {
+	role := strings.ToLower(strings.TrimSpace(msg.Role))
+	if role != "user" && role != "assistant" {
+		return ""
+	}
+	if msg.Source == ports.MessageSourceSystemPrompt || msg.Source == ports.MessageSourceUserHistory {
+		return ""
+	}
+	content := strings.Join(strings.Fields(msg.Content), " ")
+	if content == "" {
+		return ""
+	}
+	return "- " + role + ": " + truncateRunes(content, rotationSummaryEntryMaxRunes)
+}
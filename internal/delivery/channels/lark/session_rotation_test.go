@@ -0,0 +1,163 @@
+package lark
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"alex/internal/delivery/channels"
+	ports "alex/internal/domain/agent/ports"
+	agent "alex/internal/domain/agent/ports/agent"
+	storage "alex/internal/domain/agent/ports/storage"
+)
+
+// rotationExecutor serves pre-seeded sessions and records the executed task.
+type rotationExecutor struct {
+	sessions          map[string]*storage.Session
+	capturedSessionID string
+	capturedTask      string
+}
+
+func (r *rotationExecutor) EnsureSession(_ context.Context, sessionID string) (*storage.Session, error) {
+	if session, ok := r.sessions[sessionID]; ok {
+		return session, nil
+	}
+	return &storage.Session{ID: sessionID, Metadata: map[string]string{}}, nil
+}
+
+func (r *rotationExecutor) ExecuteTask(_ context.Context, task string, sessionID string, _ agent.EventListener) (*agent.TaskResult, error) {
+	r.capturedTask = task
+	r.capturedSessionID = sessionID
+	return &agent.TaskResult{Answer: "ok"}, nil
+}
+
+func longSession(sessionID string, turns int) *storage.Session {
+	session := &storage.Session{ID: sessionID, Metadata: map[string]string{"title": "Weekly sync"}, CreatedAt: time.Now()}
+	for i := 0; i < turns; i++ {
+		session.Messages = append(session.Messages,
+			ports.Message{Role: "user", Content: fmt.Sprintf("question %d", i)},
+			ports.Message{Role: "assistant", Content: fmt.Sprintf("answer %d", i)},
+		)
+	}
+	return session
+}
+
+func TestRunTaskRotatesOversizedSession(t *testing.T) {
+	rec := NewRecordingMessenger()
+	executor := &rotationExecutor{sessions: map[string]*storage.Session{"test-old": longSession("test-old", 5)}}
+	gw := newTestGatewayWithMessenger(executor, rec, channels.BaseConfig{SessionPrefix: "test", AllowDirect: true})
+	gw.cfg.SessionRotation = SessionRotationConfig{MaxMessages: 10}
+	gw.getOrCreateSlot("oc_rotate").lastSessionID = "test-old"
+
+	if err := gw.InjectMessage(context.Background(), "oc_rotate", "p2p", "ou_user", "om_rotate", "next question"); err != nil {
+		t.Fatalf("InjectMessage failed: %v", err)
+	}
+	gw.WaitForTasks()
+
+	if executor.capturedSessionID == "" || executor.capturedSessionID == "test-old" {
+		t.Fatalf("expected a fresh session, got %q", executor.capturedSessionID)
+	}
+	if !strings.HasPrefix(executor.capturedTask, rotationSummaryHeader) || !strings.Contains(executor.capturedTask, "answer 4") {
+		t.Fatalf("expected summary carried into task, got %q", executor.capturedTask)
+	}
+	if !strings.HasSuffix(executor.capturedTask, "next question") {
+		t.Fatalf("expected user message after summary, got %q", executor.capturedTask)
+	}
+	slot := gw.getOrCreateSlot("oc_rotate")
+	slot.mu.Lock()
+	lastSessionID := slot.lastSessionID
+	slot.mu.Unlock()
+	if lastSessionID != executor.capturedSessionID {
+		t.Fatalf("slot not rebound: got %q, want %q", lastSessionID, executor.capturedSessionID)
+	}
+
+	var notified bool
+	for _, call := range rec.CallsByMethod("ReplyMessage") {
+		if strings.Contains(call.Content, rotationNotice) {
+			notified = true
+		}
+	}
+	if !notified {
+		t.Fatal("expected rotation notice in chat")
+	}
+}
+
+func TestRunTaskKeepsSessionUnderLimits(t *testing.T) {
+	rec := NewRecordingMessenger()
+	executor := &rotationExecutor{sessions: map[string]*storage.Session{"test-old": longSession("test-old", 2)}}
+	gw := newTestGatewayWithMessenger(executor, rec, channels.BaseConfig{SessionPrefix: "test", AllowDirect: true})
+	gw.cfg.SessionRotation = SessionRotationConfig{MaxMessages: 10, MaxTokens: 5000, MaxAge: time.Hour}
+	executor.sessions["test-old"].Metadata[sessionTokensMetadataKey] = "4999"
+	gw.getOrCreateSlot("oc_keep").lastSessionID = "test-old"
+
+	if err := gw.InjectMessage(context.Background(), "oc_keep", "p2p", "ou_user", "om_keep", "hello"); err != nil {
+		t.Fatalf("InjectMessage failed: %v", err)
+	}
+	gw.WaitForTasks()
+
+	if executor.capturedSessionID != "test-old" || executor.capturedTask != "hello" {
+		t.Fatalf("unexpected rotation: session=%q task=%q", executor.capturedSessionID, executor.capturedTask)
+	}
+}
+
+func TestSessionRotationReason(t *testing.T) {
+	now := time.Date(2026, 1, 2, 12, 0, 0, 0, time.UTC)
+	gw := &Gateway{
+		cfg: Config{SessionRotation: SessionRotationConfig{MaxTokens: 1000, MaxAge: 24 * time.Hour}},
+		now: func() time.Time { return now },
+	}
+	tokens := &storage.Session{Metadata: map[string]string{sessionTokensMetadataKey: "1000"}, CreatedAt: now}
+	if reason := gw.sessionRotationReason(tokens); reason != "tokens=1000" {
+		t.Fatalf("token reason = %q", reason)
+	}
+	aged := &storage.Session{CreatedAt: now.Add(-25 * time.Hour)}
+	if reason := gw.sessionRotationReason(aged); !strings.HasPrefix(reason, "age=") {
+		t.Fatalf("age reason = %q", reason)
+	}
+	fresh := &storage.Session{Metadata: map[string]string{sessionTokensMetadataKey: "junk"}, CreatedAt: now}
+	if reason := gw.sessionRotationReason(fresh); reason != "" {
+		t.Fatalf("unexpected reason %q", reason)
+	}
+}
+
+func TestMaybeRotateSessionSkipsAwaitingInput(t *testing.T) {
+	executor := &rotationExecutor{}
+	gw := newTestGatewayWithMessenger(executor, NewRecordingMessenger(), channels.BaseConfig{SessionPrefix: "test"})
+	gw.cfg.SessionRotation = SessionRotationConfig{MaxMessages: 2}
+	slot := gw.getOrCreateSlot("oc_await")
+	slot.taskToken = 1
+	msg := &incomingMessage{chatID: "oc_await", senderID: "ou_user"}
+
+	session := longSession("test-old", 3)
+	if _, rotated := gw.maybeRotateSession(context.Background(), session, msg, "test-old", true, 1); rotated {
+		t.Fatal("rotation must not fire on await resume")
+	}
+	session.Metadata["await_user_input"] = "true"
+	if _, rotated := gw.maybeRotateSession(context.Background(), session, msg, "test-old", false, 1); rotated {
+		t.Fatal("rotation must not fire while session awaits input")
+	}
+	delete(session.Metadata, "await_user_input")
+	if _, rotated := gw.maybeRotateSession(context.Background(), session, msg, "test-old", false, 1); !rotated {
+		t.Fatal("expected rotation once the exchange is complete")
+	}
+}
+
+func TestBuildRotationSummaryKeepsRecentTurnsWithinBudget(t *testing.T) {
+	session := longSession("s", 20)
+	session.Messages = append(session.Messages, ports.Message{Role: "tool", Content: "tool output"})
+	summary := buildRotationSummary(session, 100)
+	if !strings.HasPrefix(summary, rotationSummaryHeader+"\nTitle: Weekly sync") {
+		t.Fatalf("unexpected summary header: %q", summary)
+	}
+	if strings.Contains(summary, "tool output") || strings.Contains(summary, "question 0") {
+		t.Fatalf("summary should skip tool output and old turns: %q", summary)
+	}
+	if !strings.HasSuffix(summary, "- assistant: answer 19") {
+		t.Fatalf("summary should end with the latest turn: %q", summary)
+	}
+	if buildRotationSummary(&storage.Session{}, 100) != "" {
+		t.Fatal("expected empty summary for empty session")
+	}
+}
@@ -0,0 +1,82 @@
+package lark
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"alex/internal/delivery/channels"
+	storage "alex/internal/domain/agent/ports/storage"
+	"alex/internal/shared/utils"
+)
+
+// sessionSearchReplyLimit caps hits listed in one /sessions reply.
+const sessionSearchReplyLimit = 5
+
+// SessionSearcher is the narrow session-search port used by the /sessions
+// command. Satisfied by session stores implementing storage.SessionSearcher.
+type SessionSearcher interface {
+	SearchSessions(ctx context.Context, query storage.SessionQuery) (storage.SessionSearchResult, error)
+}
+
+// isSessionsCommand checks whether the message is a /sessions command.
+func (g *Gateway) isSessionsCommand(trimmed string) bool {
+	lower := utils.TrimLower(trimmed)
+	return lower == "/sessions" || strings.HasPrefix(lower, "/sessions ")
+}
+
+// handleSessionsCommand searches the sender's past sessions on this channel.
+func (g *Gateway) handleSessionsCommand(msg *incomingMessage) {
+	if g == nil || msg == nil {
+		return
+	}
+	execCtx := channels.BuildBaseContext(g.cfg.BaseConfig, "lark", "", msg.senderID, msg.chatID, msg.isGroup)
+	execCtx = g.withLarkContext(execCtx, msg.chatID, msg.messageID)
+	reply := g.sessionsReply(execCtx, msg.senderID, textAfterFields(strings.TrimSpace(msg.content), 1))
+	g.dispatch(execCtx, msg.chatID, replyTarget(msg.messageID, true), "text", textContent(reply))
+}
+
+func (g *Gateway) sessionsReply(ctx context.Context, senderID, text string) string {
+	if g.sessionSearch == nil {
+		return "会话搜索不可用：未配置。"
+	}
+	if strings.TrimSpace(text) == "" {
+		return sessionsCommandUsage()
+	}
+	result, err := g.sessionSearch.SearchSessions(ctx, storage.SessionQuery{
+		Text:          text,
+		ChannelPrefix: g.cfg.SessionPrefix,
+		UserID:        senderID,
+		Limit:         sessionSearchReplyLimit,
+	})
+	if err != nil {
+		return fmt.Sprintf("搜索会话失败：%v", err)
+	}
+	if result.Total == 0 {
+		return fmt.Sprintf("没有找到包含「%s」的会话。", text)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "找到 %d 个包含「%s」的会话：", result.Total, text)
+	for i, hit := range result.Hits {
+		title := hit.Title
+		if title == "" {
+			title = "（无标题）"
+		}
+		fmt.Fprintf(&b, "\n%d. %s · %s", i+1, title, hit.UpdatedAt.Local().Format("2006-01-02 15:04"))
+		for _, snippet := range hit.Snippets {
+			b.WriteString("\n   " + snippet)
+		}
+	}
+	if rest := result.Total - len(result.Hits); rest > 0 {
+		fmt.Fprintf(&b, "\n…另有 %d 个结果，请使用更具体的关键词。", rest)
+	}
+	return b.String()
+}
+
+func sessionsCommandUsage() string {
+	return strings.TrimSpace(`
+Sessions command usage:
+  /sessions <keywords>      Search your past sessions (all keywords must match)
+`)
+}
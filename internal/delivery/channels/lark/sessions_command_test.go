@@ -0,0 +1,82 @@
+package lark
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"alex/internal/delivery/channels"
+	storage "alex/internal/domain/agent/ports/storage"
+	"alex/internal/shared/logging"
+)
+
+type fakeSessionSearcher struct {
+	queries []storage.SessionQuery
+	result  storage.SessionSearchResult
+}
+
+func (f *fakeSessionSearcher) SearchSessions(_ context.Context, query storage.SessionQuery) (storage.SessionSearchResult, error) {
+	f.queries = append(f.queries, query)
+	return f.result, nil
+}
+
+func TestIsSessionsCommand(t *testing.T) {
+	g := &Gateway{}
+	for input, want := range map[string]bool{"/sessions": true, "/Sessions billing": true, "/sessionsx": false, "sessions": false} {
+		if got := g.isSessionsCommand(input); got != want {
+			t.Fatalf("isSessionsCommand(%q) = %v, want %v", input, got, want)
+		}
+	}
+}
+
+func TestHandleSessionsCommandScopesSearchToSender(t *testing.T) {
+	searcher := &fakeSessionSearcher{result: storage.SessionSearchResult{
+		Total: 7,
+		Hits: []storage.SessionSearchHit{
+			{SessionID: "lark-1", Title: "Billing", Snippets: []string{"plan the **billing** migration"}, UpdatedAt: time.Now()},
+			{SessionID: "lark-2", UpdatedAt: time.Now()},
+		},
+	}}
+	recorder := NewRecordingMessenger()
+	gw := &Gateway{
+		cfg:           Config{BaseConfig: channels.BaseConfig{SessionPrefix: "lark", AllowDirect: true}, AppID: "test", AppSecret: "secret"},
+		logger:        logging.OrNop(nil),
+		messenger:     recorder,
+		sessionSearch: searcher,
+	}
+
+	gw.handleSessionsCommand(&incomingMessage{chatID: "oc_s", messageID: "om_s", senderID: "ou_s", content: "/sessions billing  migration"})
+
+	if len(searcher.queries) != 1 {
+		t.Fatalf("expected one search, got %d", len(searcher.queries))
+	}
+	query := searcher.queries[0]
+	if query.Text != "billing  migration" || query.ChannelPrefix != "lark" || query.UserID != "ou_s" || query.Limit != sessionSearchReplyLimit {
+		t.Fatalf("unexpected query %+v", query)
+	}
+	calls := recorder.CallsByMethod("ReplyMessage")
+	if len(calls) != 1 {
+		t.Fatalf("expected one reply, got %d", len(calls))
+	}
+	reply := extractTextContent(calls[0].Content, nil)
+	for _, want := range []string{"找到 7 个", "1. Billing", "plan the **billing** migration", "2. （无标题）", "另有 5 个结果"} {
+		if !strings.Contains(reply, want) {
+			t.Fatalf("reply missing %q: %q", want, reply)
+		}
+	}
+}
+
+func TestSessionsReplyWithoutQueryOrSearcher(t *testing.T) {
+	gw := &Gateway{}
+	if reply := gw.sessionsReply(context.Background(), "ou", "billing"); !strings.Contains(reply, "未配置") {
+		t.Fatalf("unexpected reply without searcher: %q", reply)
+	}
+	gw.sessionSearch = &fakeSessionSearcher{}
+	if reply := gw.sessionsReply(context.Background(), "ou", ""); !strings.Contains(reply, "/sessions <keywords>") {
+		t.Fatalf("expected usage, got %q", reply)
+	}
+	if reply := gw.sessionsReply(context.Background(), "ou", "nothing"); !strings.Contains(reply, "没有找到") {
+		t.Fatalf("expected empty result reply, got %q", reply)
+	}
+}
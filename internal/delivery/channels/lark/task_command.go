@@ -18,7 +18,8 @@ const (
 	defaultTaskAgent          = "claude_code"
 )
 
-// isTaskCommand checks whether the message is a task management command.
+// isTaskCommand checks whether the message is a /cc, /codex or /task
+// command. /tasks belongs to the task-control commands.
 func (g *Gateway) isTaskCommand(trimmed string) bool {
 	lower := strings.ToLower(trimmed)
 	if strings.HasPrefix(lower, "/cc ") || lower == "/cc" {
@@ -27,8 +28,7 @@ func (g *Gateway) isTaskCommand(trimmed string) bool {
 	if strings.HasPrefix(lower, "/codex ") || lower == "/codex" {
 		return true
 	}
-	if lower == "/task" || strings.HasPrefix(lower, "/task ") ||
-		lower == "/tasks" || strings.HasPrefix(lower, "/tasks ") {
+	if lower == "/task" || strings.HasPrefix(lower, "/task ") {
 		return true
 	}
 	return false
@@ -87,8 +87,6 @@ func (g *Gateway) handleTaskCommand(msg *incomingMessage) {
 		reply = g.handleDirectDispatch(execCtx, msg, "claude_code", fields[1:])
 	case "/codex":
 		reply = g.handleDirectDispatch(execCtx, msg, "codex", fields[1:])
-	case "/tasks":
-		reply = g.handleTaskList(execCtx, msg)
 	case "/task":
 		reply = g.handleTaskSubcommand(execCtx, msg, fields[1:])
 	default:
@@ -137,7 +135,7 @@ func (g *Gateway) handleDirectDispatch(ctx context.Context, msg *incomingMessage
 			g.logger.Warn("Task store list failed: %v", err)
 		} else if len(active) >= max {
 			return fmt.Sprintf("当前会话已有 %d 个活跃任务（上限 %d）。请等待任务完成或使用 /task cancel <id> 取消。\n\n%s",
-				len(active), max, g.formatActiveTaskList(active))
+				len(active), max, g.formatActiveTaskList(nil, active))
 		}
 	}
 
@@ -176,7 +174,7 @@ func (g *Gateway) dispatchViaForegroundTask(msg *incomingMessage, agentType, des
 
 	execCtx, cancelExec := g.buildExecContext(context.Background(), msg, sessionID, inputCh)
 	defer cancelExec()
-	execCtx = channels.ApplyPresets(execCtx, g.cfg.BaseConfig)
+	execCtx = g.applyChatPresets(execCtx, msg.chatID)
 	execCtx, cancelTimeout := channels.ApplyTimeout(execCtx, g.cfg.BaseConfig)
 	defer cancelTimeout()
 
@@ -244,7 +242,8 @@ Use the text inside user_task_description as both the description and prompt.
 Do NOT do any other work. Just dispatch the task and report the task ID.`, agentType, description)
 }
 
-// handleTaskSubcommand routes /task subcommands.
+// handleTaskSubcommand routes /task subcommands. /task cancel is a
+// task-control command and never reaches it.
 func (g *Gateway) handleTaskSubcommand(ctx context.Context, msg *incomingMessage, args []string) string {
 	if len(args) == 0 {
 		return g.handleTaskList(ctx, msg)
@@ -259,11 +258,6 @@ func (g *Gateway) handleTaskSubcommand(ctx context.Context, msg *incomingMessage
 			return "用法: /task status <task_id>"
 		}
 		return g.handleTaskStatus(ctx, strings.TrimSpace(args[1]))
-	case "cancel", "stop":
-		if len(args) < 2 {
-			return "用法: /task cancel <task_id>"
-		}
-		return g.handleTaskCancel(ctx, strings.TrimSpace(args[1]))
 	case "history":
 		return g.handleTaskHistory(ctx, msg)
 	case "help", "-h", "--help":
@@ -275,19 +269,24 @@ func (g *Gateway) handleTaskSubcommand(ctx context.Context, msg *incomingMessage
 	}
 }
 
-// handleTaskList shows active tasks for the current chat.
+// handleTaskList shows the chat's in-memory runs and active TaskStore tasks.
 func (g *Gateway) handleTaskList(ctx context.Context, msg *incomingMessage) string {
-	if g.taskStore == nil {
+	runs := g.runningSlotTasks(msg.chatID)
+	if g.taskStore == nil && len(runs) == 0 {
 		return "任务管理未启用（需要 Postgres 数据库）。"
 	}
-	tasks, err := g.taskStore.ListByChat(ctx, msg.chatID, true, 10)
-	if err != nil {
-		return fmt.Sprintf("查询任务列表失败: %v", err)
+	var tasks []TaskRecord
+	if g.taskStore != nil {
+		var err error
+		tasks, err = g.taskStore.ListByChat(ctx, msg.chatID, true, 10)
+		if err != nil {
+			return fmt.Sprintf("查询任务列表失败: %v", err)
+		}
 	}
-	if len(tasks) == 0 {
+	if len(runs) == 0 && len(tasks) == 0 {
 		return "当前没有活跃任务。\n\n使用 /cc <描述> 或 /codex <描述> 创建新任务。"
 	}
-	return g.formatActiveTaskList(tasks)
+	return g.formatActiveTaskList(runs, tasks)
 }
 
 // handleTaskStatus shows details for a specific task.
@@ -305,8 +304,17 @@ func (g *Gateway) handleTaskStatus(ctx context.Context, taskID string) string {
 	return formatTaskDetail(task)
 }
 
-// handleTaskCancel cancels a running task.
-func (g *Gateway) handleTaskCancel(ctx context.Context, taskID string) string {
+// handleTaskCancel cancels a task owned by chatID: the foreground run
+// ("current"), a conversation-process worker ("#N") or a TaskStore task.
+// Tasks of other chats are reported as not found; a task that already
+// finished gets an explanatory reply, not an error.
+func (g *Gateway) handleTaskCancel(ctx context.Context, chatID, taskID string) string {
+	switch {
+	case strings.EqualFold(taskID, foregroundTaskID):
+		return g.cancelForegroundTask(chatID)
+	case strings.HasPrefix(taskID, "#"):
+		return g.cancelWorkerTask(ctx, chatID, taskID)
+	}
 	if g.taskStore == nil {
 		return "任务管理未启用。"
 	}
@@ -314,7 +322,7 @@ func (g *Gateway) handleTaskCancel(ctx context.Context, taskID string) string {
 	if err != nil {
 		return fmt.Sprintf("查询任务失败: %v", err)
 	}
-	if !ok {
+	if !ok || task.ChatID != chatID {
 		return fmt.Sprintf("未找到任务: %s", taskID)
 	}
 	if isTerminalTaskStatus(task.Status) {
@@ -350,8 +358,9 @@ func (g *Gateway) handleTaskHistory(ctx context.Context, msg *incomingMessage) s
 	return formatTaskHistory(tasks)
 }
 
-// formatActiveTaskList formats a list of active tasks.
-func (g *Gateway) formatActiveTaskList(tasks []TaskRecord) string {
+// formatActiveTaskList formats in-memory runs followed by TaskStore tasks.
+// Full IDs are shown so they can be passed to /cancel.
+func (g *Gateway) formatActiveTaskList(runs []chatTaskEntry, tasks []TaskRecord) string {
 	max := g.cfg.MaxConcurrentTasks
 	if max <= 0 {
 		max = defaultMaxConcurrentTasks
@@ -367,17 +376,26 @@ func (g *Gateway) formatActiveTaskList(tasks []TaskRecord) string {
 	var sb strings.Builder
 	sb.WriteString(fmt.Sprintf("活跃任务 (%d/%d)\n", activeCount, max))
 
-	for i, t := range tasks {
-		elapsed := g.currentTime().Sub(t.CreatedAt)
-		statusIcon := taskStatusLabel(t.Status)
-		sb.WriteString(fmt.Sprintf("\n[%d] %s · %s · %s · %s",
-			i+1, shortID(t.TaskID), t.AgentType, statusIcon, formatDuration(elapsed)))
+	now := g.currentTime()
+	for _, r := range runs {
+		elapsed := "—"
+		if !r.StartedAt.IsZero() {
+			elapsed = formatDuration(now.Sub(r.StartedAt))
+		}
+		sb.WriteString(fmt.Sprintf("\n[%s] %s · %s", r.ID, r.Label, elapsed))
+		if r.Description != "" {
+			sb.WriteString(fmt.Sprintf("\n    %s", truncateForLark(r.Description, 60)))
+		}
+	}
+	for _, t := range tasks {
+		sb.WriteString(fmt.Sprintf("\n[%s] %s · %s · %s",
+			t.TaskID, t.AgentType, taskStatusLabel(t.Status), formatDuration(now.Sub(t.CreatedAt))))
 		if t.Description != "" {
 			sb.WriteString(fmt.Sprintf("\n    %s", truncateForLark(t.Description, 60)))
 		}
 	}
 
-	sb.WriteString("\n\n回复 /task status <id> 查看详情，/task cancel <id> 取消任务。")
+	sb.WriteString("\n\n回复 /task status <id> 查看详情，/cancel <id> 取消任务。")
 	return sb.String()
 }
 
@@ -441,8 +459,10 @@ Task command usage:
   /codex <desc>           Dispatch to Codex
   /task <desc>            Dispatch to default agent
   /tasks                  List active tasks
+  /cancel <id>            Cancel a task listed by /tasks
+  /digest now             Post the background task digest now
   /task status <id>       Show task details
-  /task cancel <id>       Cancel a running task
+  /task cancel <id>       Same as /cancel <id>
   /task history           Show completed tasks
   /task help              Show this help
 
@@ -18,10 +18,9 @@ func TestIsTaskCommand(t *testing.T) {
 		{"/CC refactor", true},
 		{"/codex optimize", true},
 		{"/codex", true},
-		{"/tasks", true},
+		{"/tasks", false}, // task-control command
 		{"/task list", true},
 		{"/task status abc", true},
-		{"/task cancel abc", true},
 		{"/task help", true},
 		{"/task refactor auth", true},
 		{"/model use codex/gpt-5", false},
@@ -240,7 +239,7 @@ func TestHandleTaskStatus_NoStore(t *testing.T) {
 
 func TestHandleTaskCancel_NoStore(t *testing.T) {
 	g := &Gateway{}
-	reply := g.handleTaskCancel(context.Background(), "task1")
+	reply := g.handleTaskCancel(context.Background(), "chat1", "task1")
 	if !strings.Contains(reply, "未启用") {
 		t.Errorf("expected disabled message, got: %s", reply)
 	}
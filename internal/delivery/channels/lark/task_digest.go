@@ -0,0 +1,171 @@
+package lark
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/robfig/cron/v3"
+)
+
+// maxTaskDigestEntries caps how many tasks one digest message lists. The
+// rest stay undigested and appear in the next digest.
+const maxTaskDigestEntries = 30
+
+// taskDigestEnabledFor reports whether finished background tasks in chatID
+// are reported by the scheduled digest instead of one message per task.
+func (g *Gateway) taskDigestEnabledFor(chatID string) bool {
+	digest := g.cfg.TaskDigest
+	if !digest.Enabled {
+		return false
+	}
+	only := strings.TrimSpace(digest.ChatID)
+	return only == "" || only == strings.TrimSpace(chatID)
+}
+
+// startTaskDigestScheduler registers the digest cron job. It stops with ctx.
+func (g *Gateway) startTaskDigestScheduler(ctx context.Context) {
+	if !g.cfg.TaskDigest.Enabled {
+		return
+	}
+	if _, ok := g.taskStore.(TaskDigestStore); !ok {
+		g.logger.Warn("Lark task digest enabled but task store does not support digests; completion messages will be held")
+		return
+	}
+	spec := strings.TrimSpace(g.cfg.TaskDigest.Cron)
+	if spec == "" {
+		spec = defaultTaskDigestCron
+	}
+	c := cron.New()
+	if _, err := c.AddFunc(spec, func() {
+		if _, err := g.postTaskDigests(ctx, strings.TrimSpace(g.cfg.TaskDigest.ChatID), ""); err != nil {
+			g.logger.Warn("Lark task digest failed: %v", err)
+		}
+	}); err != nil {
+		g.logger.Warn("Lark task digest disabled: invalid cron %q: %v", spec, err)
+		return
+	}
+	c.Start()
+	g.logger.Info("Lark task digest scheduled (cron=%q)", spec)
+
+	g.cleanupWG.Add(1)
+	go func() {
+		defer g.cleanupWG.Done()
+		<-ctx.Done()
+		<-c.Stop().Done()
+	}()
+}
+
+// postTaskDigests sends one digest message per chat listing its undigested
+// tasks, then stamps them. Tasks are marked only after their message was
+// sent, so a failed send is retried by the next digest. An empty chatID
+// covers every chat in digest mode. Returns the number of digests sent.
+func (g *Gateway) postTaskDigests(ctx context.Context, chatID, replyToID string) (int, error) {
+	store, ok := g.taskStore.(TaskDigestStore)
+	if !ok {
+		return 0, fmt.Errorf("task store does not support digests")
+	}
+	g.taskDigestMu.Lock()
+	defer g.taskDigestMu.Unlock()
+
+	records, err := store.ListUndigested(ctx, chatID)
+	if err != nil {
+		return 0, err
+	}
+	var chats []string
+	byChat := make(map[string][]TaskRecord)
+	for _, rec := range records {
+		if !g.taskDigestEnabledFor(rec.ChatID) {
+			continue
+		}
+		if _, seen := byChat[rec.ChatID]; !seen {
+			chats = append(chats, rec.ChatID)
+		}
+		byChat[rec.ChatID] = append(byChat[rec.ChatID], rec)
+	}
+
+	sent, failed := 0, 0
+	for _, chat := range chats {
+		tasks := byChat[chat]
+		remaining := 0
+		if len(tasks) > maxTaskDigestEntries {
+			remaining = len(tasks) - maxTaskDigestEntries
+			tasks = tasks[:maxTaskDigestEntries]
+		}
+		if _, err := g.dispatchMessage(ctx, chat, replyToID, "text", textContent(buildTaskDigest(tasks, remaining))); err != nil {
+			g.logger.Warn("Lark task digest send failed for chat %s: %v", chat, err)
+			failed++
+			continue
+		}
+		ids := make([]string, 0, len(tasks))
+		for _, t := range tasks {
+			ids = append(ids, t.TaskID)
+		}
+		if err := store.MarkDigested(ctx, ids, g.currentTime()); err != nil {
+			return sent, fmt.Errorf("mark digested: %w", err)
+		}
+		sent++
+	}
+	if failed > 0 {
+		return sent, fmt.Errorf("digest send failed for %d chat(s)", failed)
+	}
+	return sent, nil
+}
+
+// buildTaskDigest renders the digest for one chat. remaining counts tasks
+// held back for the next digest.
+func buildTaskDigest(tasks []TaskRecord, remaining int) string {
+	completed, failed := 0, 0
+	for _, t := range tasks {
+		if normalizeTaskStatus(t.Status) == taskStatusFailed {
+			failed++
+		} else {
+			completed++
+		}
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("后台任务摘要：完成 %d · 失败 %d\n", completed, failed))
+	for _, t := range tasks {
+		icon, label, detail := "✅", "结果", t.AnswerPreview
+		if normalizeTaskStatus(t.Status) == taskStatusFailed {
+			icon, label, detail = "❌", "原因", t.Error
+		}
+		sb.WriteString(fmt.Sprintf("\n%s [%s] %s", icon, t.TaskID, t.AgentType))
+		if desc := strings.TrimSpace(t.Description); desc != "" {
+			sb.WriteString(" · " + truncateForLark(desc, 60))
+		}
+		if detail = strings.Join(strings.Fields(detail), " "); detail != "" {
+			sb.WriteString(fmt.Sprintf("\n    %s：%s", label, truncateForLark(detail, 120)))
+		}
+	}
+	if remaining > 0 {
+		sb.WriteString(fmt.Sprintf("\n\n还有 %d 个任务将在下次摘要中列出。", remaining))
+	}
+	sb.WriteString("\n\n使用 /task status <id> 查看详情。")
+	return sb.String()
+}
+
+// handleDigestCommand handles /digest now, posting this chat's digest
+// immediately as a reply to the command.
+func (g *Gateway) handleDigestCommand(ctx context.Context, msg *incomingMessage, args []string) {
+	reply := ""
+	switch {
+	case len(args) == 0 || !strings.EqualFold(args[0], "now"):
+		reply = "用法: /digest now"
+	case !g.taskDigestEnabledFor(msg.chatID):
+		reply = "当前会话未开启任务摘要。"
+	default:
+		sent, err := g.postTaskDigests(ctx, msg.chatID, replyTarget(msg.messageID, true))
+		switch {
+		case err != nil:
+			g.logger.Warn("Lark task digest failed for chat %s: %v", msg.chatID, err)
+			reply = "任务摘要生成失败，请稍后重试。"
+		case sent == 0:
+			reply = "自上次摘要以来没有新结束的后台任务。"
+		}
+	}
+	if reply != "" {
+		g.dispatch(ctx, msg.chatID, replyTarget(msg.messageID, true), "text", textContent(reply))
+	}
+}
@@ -0,0 +1,124 @@
+package lark
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"alex/internal/delivery/channels"
+)
+
+func seedDigestTasks(t *testing.T, store *TaskLocalStore) {
+	t.Helper()
+	ctx := context.Background()
+	for _, rec := range []TaskRecord{
+		{TaskID: "bg-1", ChatID: "oc_digest", AgentType: "codex", Description: "fix flaky test"},
+		{TaskID: "bg-2", ChatID: "oc_digest", AgentType: "claude_code", Description: "write docs"},
+		{TaskID: "bg-3", ChatID: "oc_digest", AgentType: "codex", Description: "cancelled work"},
+		{TaskID: "bg-4", ChatID: "oc_digest", AgentType: "codex", Description: "still running"},
+		{TaskID: "bg-5", ChatID: "oc_other", AgentType: "codex", Description: "other chat"},
+	} {
+		if err := store.SaveTask(ctx, rec); err != nil {
+			t.Fatalf("SaveTask(%s) failed: %v", rec.TaskID, err)
+		}
+	}
+	updates := []struct {
+		id, status string
+		opts       []TaskUpdateOption
+	}{
+		{"bg-1", taskStatusCompleted, []TaskUpdateOption{WithAnswerPreview("all green")}},
+		{"bg-2", taskStatusFailed, []TaskUpdateOption{WithErrorText("permission denied")}},
+		{"bg-3", taskStatusCancelled, nil},
+		{"bg-4", taskStatusRunning, nil},
+		{"bg-5", taskStatusCompleted, nil},
+	}
+	for _, u := range updates {
+		if err := store.UpdateStatus(ctx, u.id, u.status, u.opts...); err != nil {
+			t.Fatalf("UpdateStatus(%s) failed: %v", u.id, err)
+		}
+	}
+}
+
+func TestTaskLocalStoreListUndigested(t *testing.T) {
+	store := NewTaskMemoryStore(time.Hour, 100)
+	seedDigestTasks(t, store)
+	ctx := context.Background()
+
+	got, err := store.ListUndigested(ctx, "oc_digest")
+	if err != nil {
+		t.Fatalf("ListUndigested failed: %v", err)
+	}
+	if len(got) != 2 || got[0].TaskID != "bg-1" || got[1].TaskID != "bg-2" {
+		t.Fatalf("expected finished tasks bg-1 and bg-2, got %+v", got)
+	}
+	all, _ := store.ListUndigested(ctx, "")
+	if len(all) != 3 {
+		t.Fatalf("expected finished tasks across chats, got %d", len(all))
+	}
+
+	if err := store.MarkDigested(ctx, []string{"bg-1", "missing"}, time.Now()); err != nil {
+		t.Fatalf("MarkDigested failed: %v", err)
+	}
+	got, _ = store.ListUndigested(ctx, "oc_digest")
+	if len(got) != 1 || got[0].TaskID != "bg-2" {
+		t.Fatalf("expected only bg-2 after marking, got %+v", got)
+	}
+}
+
+func TestBuildTaskDigest(t *testing.T) {
+	digest := buildTaskDigest([]TaskRecord{
+		{TaskID: "bg-1", AgentType: "codex", Description: "fix flaky test", Status: taskStatusCompleted, AnswerPreview: "all\ngreen"},
+		{TaskID: "bg-2", AgentType: "claude_code", Status: taskStatusFailed, Error: "permission denied"},
+	}, 4)
+
+	for _, want := range []string{
+		"完成 1 · 失败 1",
+		"✅ [bg-1] codex · fix flaky test",
+		"结果：all green",
+		"❌ [bg-2] claude_code",
+		"原因：permission denied",
+		"还有 4 个任务",
+		"/task status <id>",
+	} {
+		if !strings.Contains(digest, want) {
+			t.Fatalf("expected %q in digest, got:\n%s", want, digest)
+		}
+	}
+}
+
+func newTaskDigestGateway(t *testing.T, rec *RecordingMessenger) *Gateway {
+	t.Helper()
+	gw := newTestGatewayWithMessenger(nil, rec, channels.BaseConfig{SessionPrefix: "lark", AllowDirect: true})
+	gw.cfg.TaskDigest = TaskDigestConfig{Enabled: true, ChatID: "oc_digest"}
+	store := NewTaskMemoryStore(time.Hour, 100)
+	seedDigestTasks(t, store)
+	gw.SetTaskStore(store)
+	return gw
+}
+
+func TestDigestNowPostsOnce(t *testing.T) {
+	rec := NewRecordingMessenger()
+	gw := newTaskDigestGateway(t, rec)
+	msg := &incomingMessage{chatID: "oc_digest", chatType: "p2p", messageID: "om_cmd", senderID: "ou_user", content: "/digest now"}
+
+	gw.handleTaskControlCommand(msg)
+	if reply := lastReplyContent(t, rec); !strings.Contains(reply, "[bg-1]") || !strings.Contains(reply, "[bg-2]") || strings.Contains(reply, "[bg-5]") {
+		t.Fatalf("expected this chat's finished tasks in digest, got %q", reply)
+	}
+
+	gw.handleTaskControlCommand(msg)
+	if reply := lastReplyContent(t, rec); !strings.Contains(reply, "没有新结束的后台任务") {
+		t.Fatalf("expected empty digest reply, got %q", reply)
+	}
+}
+
+func TestDigestNowRejectsChatOutsideDigestMode(t *testing.T) {
+	rec := NewRecordingMessenger()
+	gw := newTaskDigestGateway(t, rec)
+
+	gw.handleTaskControlCommand(&incomingMessage{chatID: "oc_other", chatType: "p2p", messageID: "om_cmd", senderID: "ou_user", content: "/digest now"})
+	if reply := lastReplyContent(t, rec); reply == "" || !strings.Contains(reply, "未开启任务摘要") {
+		t.Fatalf("expected digest-disabled reply, got %q", reply)
+	}
+}
@@ -52,10 +52,26 @@ func (g *Gateway) injectUserInput(ch chan agent.UserInput, activeSessionID strin
 // and rebinding this chat to it. The caller must hold slot.mu; this method
 // releases it.
 func (g *Gateway) handleNewSessionCommand(slot *sessionSlot, msg *incomingMessage) {
-	newSessionID := g.newSessionID()
+	newSessionID, wasRunning := g.switchToNewSession(slot, msg, "/new")
+
+	execCtx := channels.BuildBaseContext(g.cfg.BaseConfig, "lark", newSessionID, msg.senderID, msg.chatID, msg.isGroup)
+	execCtx = g.withLarkContext(execCtx, msg.chatID, msg.messageID)
+	g.persistChatSessionBinding(execCtx, msg.chatID, newSessionID)
+	confirmation := "已开启新会话，后续消息将使用新的上下文。"
+	if wasRunning {
+		confirmation = "已停止当前调用并开启新会话，后续消息将使用新的上下文。"
+	}
+	g.dispatch(execCtx, msg.chatID, replyTarget(msg.messageID, true), "text", textContent(confirmation))
+}
+
+// switchToNewSession points the slot at a fresh session, cancelling any
+// running task and conversation-process workers. It does not persist the
+// binding. The caller must hold slot.mu; this method releases it.
+func (g *Gateway) switchToNewSession(slot *sessionSlot, msg *incomingMessage, command string) (newSessionID string, wasRunning bool) {
+	newSessionID = g.newSessionID()
 	oldSessionID := slot.sessionID
 	cancel := slot.taskCancel
-	wasRunning := slot.phase == slotRunning && cancel != nil
+	wasRunning = slot.phase == slotRunning && cancel != nil
 	if wasRunning {
 		slot.intentionalCancelToken = slot.taskToken
 	}
@@ -66,12 +82,13 @@ func (g *Gateway) handleNewSessionCommand(slot *sessionSlot, msg *incomingMessag
 	slot.inputCh = nil
 	slot.taskCancel = nil
 	slot.pendingOptions = nil
+	slot.followUps = nil
 	slot.lastTouched = g.currentTime()
 	slot.mu.Unlock()
 
 	if wasRunning {
 		cancel()
-		g.logger.Info("Lark /new: cancelled running session %s and switched to %s", oldSessionID, newSessionID)
+		g.logger.Info("Lark %s: cancelled running session %s and switched to %s", command, oldSessionID, newSessionID)
 	}
 
 	// Also stop conversation-process workers if in that mode.
@@ -80,15 +97,7 @@ func (g *Gateway) handleNewSessionCommand(slot *sessionSlot, msg *incomingMessag
 			slotMap.stopAll(true)
 		}
 	}
-
-	execCtx := channels.BuildBaseContext(g.cfg.BaseConfig, "lark", newSessionID, msg.senderID, msg.chatID, msg.isGroup)
-	execCtx = g.withLarkContext(execCtx, msg.chatID, msg.messageID)
-	g.persistChatSessionBinding(execCtx, msg.chatID, newSessionID)
-	confirmation := "已开启新会话，后续消息将使用新的上下文。"
-	if wasRunning {
-		confirmation = "已停止当前调用并开启新会话，后续消息将使用新的上下文。"
-	}
-	g.dispatch(execCtx, msg.chatID, replyTarget(msg.messageID, true), "text", textContent(confirmation))
+	return newSessionID, wasRunning
 }
 
 // handleResetCommand processes a /reset message. The command is deprecated; it
@@ -116,7 +125,7 @@ func (g *Gateway) isStopCommand(trimmed string) bool {
 }
 
 // handleStopCommand processes /stop message. It cancels an in-flight foreground
-// task for this chat when one exists.
+// task for this chat when one exists and drops any queued follow-ups.
 // The caller must hold slot.mu; this method releases it.
 func (g *Gateway) handleStopCommand(slot *sessionSlot, msg *incomingMessage) {
 	sessionID := slot.sessionID
@@ -131,6 +140,7 @@ func (g *Gateway) handleStopCommand(slot *sessionSlot, msg *incomingMessage) {
 	if running {
 		slot.intentionalCancelToken = slot.taskToken
 	}
+	slot.followUps = nil
 	slot.lastTouched = g.currentTime()
 	slot.mu.Unlock()
 
@@ -213,11 +223,17 @@ func (g *Gateway) persistChatSessionBinding(ctx context.Context, chatID, session
 		return
 	}
 	storeCtx := context.WithoutCancel(ctx)
-	err := g.chatSessionStore.SaveBinding(storeCtx, ChatSessionBinding{
-		Channel:   chatSessionBindingChannel,
-		ChatID:    chatID,
-		SessionID: sessionID,
-		UpdatedAt: g.currentTime(),
+	// Keep the chat's preset override when the session changes.
+	binding, _, err := g.chatSessionStore.GetBinding(storeCtx, chatSessionBindingChannel, chatID)
+	if err != nil {
+		g.logger.Warn("Load chat session binding failed: chat=%s err=%v", chatID, err)
+	}
+	err = g.chatSessionStore.SaveBinding(storeCtx, ChatSessionBinding{
+		Channel:     chatSessionBindingChannel,
+		ChatID:      chatID,
+		SessionID:   sessionID,
+		AgentPreset: binding.AgentPreset,
+		UpdatedAt:   g.currentTime(),
 	})
 	if err != nil {
 		g.logger.Warn("Persist chat session binding failed: chat=%s session=%s err=%v", chatID, sessionID, err)
@@ -190,11 +190,17 @@ func (g *Gateway) reprocessMessage(chatID, chatType string, input agent.UserInpu
 		}
 	}
 
+	g.logger.Info("Reprocessing drained message for chat %s (msg_id=%s)", chatID, input.MessageID)
+	g.replayUserInput(chatID, chatType, input)
+}
+
+// replayUserInput feeds a user input back through handleMessage as a
+// synthetic P2MessageReceiveV1 event, skipping dedup and the mention gate
+// since the original message was already accepted once.
+func (g *Gateway) replayUserInput(chatID, chatType string, input agent.UserInput) {
 	msgID := input.MessageID
 	content := input.Content
 
-	g.logger.Info("Reprocessing drained message for chat %s (msg_id=%s)", chatID, msgID)
-
 	chatType = utils.TrimLower(chatType)
 	if chatType == "" {
 		chatType = "p2p"
@@ -218,7 +224,8 @@ func (g *Gateway) reprocessMessage(chatID, chatType string, input agent.UserInpu
 			},
 		},
 	}
-	if err := g.handleMessageWithOptions(context.Background(), event, messageProcessingOptions{skipDedup: true}); err != nil {
+	opts := messageProcessingOptions{skipDedup: true, skipMentionGate: true, synthesized: input.Synthesized}
+	if err := g.handleMessageWithOptions(context.Background(), event, opts); err != nil {
 		g.logger.Warn("Reprocess message failed for chat %s: %v", chatID, err)
 	}
 }
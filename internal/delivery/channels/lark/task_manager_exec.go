@@ -10,6 +10,7 @@ import (
 	"alex/internal/delivery/channels"
 	agent "alex/internal/domain/agent/ports/agent"
 	storage "alex/internal/domain/agent/ports/storage"
+	"alex/internal/infra/analytics"
 	builtinshared "alex/internal/infra/tools/builtin/shared"
 	id "alex/internal/shared/utils/id"
 )
@@ -40,7 +41,6 @@ func (g *Gateway) runTask(taskCtx context.Context, msg *incomingMessage, session
 		sessionID = session.ID
 		execCtx = id.WithSessionID(execCtx, sessionID)
 	}
-	g.persistChatSessionBinding(execCtx, msg.chatID, sessionID)
 
 	// Reconcile in-memory isResume with persisted session metadata.
 	// This handles the cold-start case where the gateway restarted while
@@ -50,18 +50,36 @@ func (g *Gateway) runTask(taskCtx context.Context, msg *incomingMessage, session
 		isResume = true
 	}
 
-	execCtx = channels.ApplyPresets(execCtx, g.cfg.BaseConfig)
+	// Rotate oversized sessions before the turn starts; the summary of the
+	// old session is carried into the first task of the new one.
+	rotation, rotated := g.maybeRotateSession(execCtx, session, msg, sessionID, isResume, taskToken)
+	if rotated {
+		session = rotation.session
+		sessionID = rotation.newSessionID
+		execCtx = id.WithSessionID(execCtx, sessionID)
+	}
+	g.persistChatSessionBinding(execCtx, msg.chatID, sessionID)
+	if rotated {
+		g.dispatch(execCtx, msg.chatID, replyTarget(msg.messageID, true), "text", textContent(rotationNotice))
+	}
+
+	execCtx = g.applyChatPresets(execCtx, msg.chatID)
 	execCtx, cancelTimeout := channels.ApplyTimeout(execCtx, g.cfg.BaseConfig)
 	defer cancelTimeout()
 
 	awaitTracker := &awaitQuestionTracker{}
 	listener, cleanupListeners, progressLn := g.setupListeners(execCtx, msg, nil, awaitTracker)
 	defer cleanupListeners()
+	var usageTally *usageCostTally
+	if g.cfg.ShowCost {
+		usageTally = newUsageCostTally(listener)
+		listener = usageTally
+	}
 	guardListener, guardState := newToolFailureGuardListener(listener, g.cfg.ToolFailureAbortThreshold, cancelExec)
 	listener = guardListener
 	execCtx = builtinshared.WithParentListener(execCtx, listener)
 
-	// Resolve task content from three distinct concerns:
+	// Resolve task content from four distinct concerns:
 	// 1. Plan review feedback (if any pending plan review exists)
 	taskContent, hasPlanReview := g.resolvePlanReviewFeedback(execCtx, session, msg)
 
@@ -72,7 +90,15 @@ func (g *Gateway) runTask(taskCtx context.Context, msg *incomingMessage, session
 		taskContent = ""
 	}
 
-	// 3. Chat context enrichment from IM recent rounds (only when there is content)
+	// Carry the rotated-out session's summary into the fresh session.
+	if rotated && rotation.summary != "" && taskContent != "" {
+		taskContent = rotation.summary + "\n\n" + taskContent
+	}
+
+	// 3. Inbound images and files: download them and attach to the run
+	execCtx, taskContent = g.attachInboundResources(execCtx, msg, taskContent)
+
+	// 4. Chat context enrichment from IM recent rounds (only when there is content)
 	taskContent = g.enrichWithChatContext(execCtx, taskContent, msg, hasPlanReview)
 
 	// Add processing reaction to indicate the task is in progress.
@@ -92,7 +118,7 @@ func (g *Gateway) runTask(taskCtx context.Context, msg *incomingMessage, session
 		progressMsgID = progressLn.MessageID()
 	}
 
-	g.dispatchResult(execCtx, msg, result, execErr, awaitTracker, progressMsgID, taskToken, guardState)
+	g.dispatchResult(execCtx, msg, result, execErr, awaitTracker, progressMsgID, taskToken, guardState, usageTally)
 
 	// Notify AI chat coordinator that this bot's turn is complete
 	if g.aiCoordinator != nil && msg.aiChatSessionActive {
@@ -198,6 +224,7 @@ func (g *Gateway) setupListeners(execCtx context.Context, msg *incomingMessage,
 	if listener == nil {
 		listener = agent.NoopEventListener{}
 	}
+	listener = newNotificationDedupListener(listener, g.notificationDedup)
 
 	// Record tool progress into the session slot so the conversation process
 	// can report recent activity to the user.
@@ -215,7 +242,8 @@ func (g *Gateway) setupListeners(execCtx context.Context, msg *incomingMessage,
 
 	if g.cfg.ShowToolProgress {
 		sender := &larkProgressSender{gateway: g, chatID: msg.chatID, messageID: msg.messageID, isGroup: msg.isGroup}
-		progressLn = newProgressListener(execCtx, listener, sender, g.logger)
+		progressLn = newProgressListener(execCtx, listener, sender, g.logger).
+			withUpdateMode(g.cfg.ProgressUpdateInterval, g.cfg.ProgressEditInPlace)
 		cleanups = append(cleanups, progressLn.Close)
 		listener = progressLn
 	}
@@ -236,6 +264,7 @@ func (g *Gateway) setupListeners(execCtx context.Context, msg *incomingMessage,
 	}
 
 	listener = newPreanalysisEmojiReactionListener(execCtx, listener, g, msg.messageID)
+	listener = g.wrapTaskAnalytics(execCtx, listener, msg.senderID)
 
 	cleanup := func() {
 		for i := len(cleanups) - 1; i >= 0; i-- {
@@ -245,6 +274,24 @@ func (g *Gateway) setupListeners(execCtx context.Context, msg *incomingMessage,
 	return listener, cleanup, progressLn
 }
 
+// wrapTaskAnalytics decorates listener with task lifecycle analytics when an
+// analytics client is configured. The sender ID is hashed by the decorator.
+func (g *Gateway) wrapTaskAnalytics(execCtx context.Context, listener agent.EventListener, senderID string) agent.EventListener {
+	if g.analytics == nil {
+		return listener
+	}
+	preset := g.cfg.AgentPreset
+	if presetCfg, ok := execCtx.Value(appcontext.PresetContextKey{}).(appcontext.PresetConfig); ok && presetCfg.AgentPreset != "" {
+		preset = presetCfg.AgentPreset
+	}
+	return analytics.NewTaskLifecycleListener(execCtx, listener, g.analytics, analytics.TaskLifecycleOptions{
+		Channel: "lark",
+		Preset:  preset,
+		Toolset: g.cfg.ToolPreset,
+		UserID:  senderID,
+	})
+}
+
 // resolvePlanReviewFeedback checks for a pending plan review and, if found,
 // wraps the user's reply into a plan feedback block. Returns the task content
 // and whether a pending plan review was found.
@@ -252,17 +299,11 @@ func (g *Gateway) resolvePlanReviewFeedback(execCtx context.Context, session *st
 	if !g.cfg.PlanReviewEnabled {
 		return msg.content, false
 	}
-	pending, ok := g.loadPlanReviewPending(execCtx, session, msg.senderID, msg.chatID)
+	pending, ok := g.takePlanReviewPending(execCtx, session, msg.senderID, msg.chatID)
 	if !ok {
 		return msg.content, false
 	}
-	taskContent := buildPlanFeedbackBlock(pending, msg.content)
-	if g.planReviewStore != nil {
-		if err := g.planReviewStore.ClearPending(execCtx, msg.senderID, msg.chatID); err != nil {
-			g.logger.Warn("Lark plan review pending clear failed: %v", err)
-		}
-	}
-	return taskContent, true
+	return buildPlanFeedbackBlock(pending, msg.content), true
 }
 
 // seedAwaitResumeInput seeds the user's reply into the input channel for an
@@ -272,13 +313,13 @@ func (g *Gateway) seedAwaitResumeInput(inputCh chan agent.UserInput, msg *incomi
 	resolvedContent := msg.content
 	slot := g.getOrCreateSlot(msg.chatID)
 	slot.mu.Lock()
-	if options := slot.pendingOptions; len(options) > 0 {
+	if options := slot.pendingOptions; len(options) > 0 && !msg.synthesized {
 		resolvedContent = parseNumberedReply(msg.content, options)
 		slot.pendingOptions = nil
 	}
 	slot.mu.Unlock()
 	select {
-	case inputCh <- agent.UserInput{Content: resolvedContent, SenderID: msg.senderID, MessageID: msg.messageID}:
+	case inputCh <- agent.UserInput{Content: resolvedContent, SenderID: msg.senderID, MessageID: msg.messageID, Synthesized: msg.synthesized}:
 		g.logger.Info("Seeded pending user input for session %s", sessionID)
 	default:
 		g.logger.Warn("Pending user input channel full for session %s; message dropped", sessionID)
@@ -17,8 +17,9 @@ import (
 // dispatchResult builds the reply from the execution result and sends it to
 // the Lark chat, including any attachments. When progressMsgID is non-empty,
 // the progress message is edited in-place to become the final reply, avoiding
-// message fragmentation.
-func (g *Gateway) dispatchResult(execCtx context.Context, msg *incomingMessage, result *agent.TaskResult, execErr error, awaitTracker *awaitQuestionTracker, progressMsgID string, taskToken uint64, guardState *toolFailureGuardState) {
+// message fragmentation. When usageTally is non-nil (ShowCost), final
+// replies end with a compact token/cost line.
+func (g *Gateway) dispatchResult(execCtx context.Context, msg *incomingMessage, result *agent.TaskResult, execErr error, awaitTracker *awaitQuestionTracker, progressMsgID string, taskToken uint64, guardState *toolFailureGuardState, usageTally *usageCostTally) {
 	if errors.Is(execErr, context.Canceled) && g.isIntentionalTaskCancellation(msg.chatID, taskToken) {
 		g.logger.Info("Lark task cancelled intentionally: chat=%s msg=%s token=%d", msg.chatID, msg.messageID, taskToken)
 		return
@@ -34,6 +35,7 @@ func (g *Gateway) dispatchResult(execCtx context.Context, msg *incomingMessage,
 	awaitPrompt, hasAwaitPrompt := agent.AwaitUserInputPrompt{}, false
 	if isAwait && result != nil {
 		awaitPrompt, hasAwaitPrompt = agent.ExtractAwaitUserInputPrompt(result.Messages)
+		g.armAwaitTimeout(msg, taskToken, awaitPrompt)
 	}
 	reply := ""
 	replyContent := ""
@@ -41,7 +43,7 @@ func (g *Gateway) dispatchResult(execCtx context.Context, msg *incomingMessage,
 	attachmentSummary := ""
 
 	if isAwait && g.cfg.PlanReviewEnabled {
-		reply, _, replyContent = g.buildPlanReviewReplyContent(execCtx, msg, result)
+		reply, replyMsgType, replyContent = g.buildPlanReviewReplyContent(execCtx, msg, result)
 	}
 
 	skipReply := isAwait && awaitTracker.Sent()
@@ -90,6 +92,9 @@ func (g *Gateway) dispatchResult(execCtx context.Context, msg *incomingMessage,
 		if attachmentSummary != "" {
 			reply += "\n\n" + attachmentSummary
 		}
+		if costLine := usageTally.Line(); costLine != "" && !isAwait {
+			reply += "\n\n" + costLine
+		}
 
 		replyMsgType, replyContent = smartContent(reply)
 	}
@@ -148,7 +153,7 @@ func (g *Gateway) buildPlanReviewReplyContent(execCtx context.Context, msg *inco
 		}
 	}
 
-	return reply, "text", ""
+	return reply, "interactive", buildPlanReviewCard(marker, msg.senderID)
 }
 
 // truncateWithDoc uploads the full reply as a text file and returns a short
@@ -42,6 +42,17 @@ func isTerminalTaskStatus(status string) bool {
 	}
 }
 
+// isDigestTaskStatus reports whether a task is reported by the scheduled
+// task digest. Cancelled tasks are user-initiated and left out.
+func isDigestTaskStatus(status string) bool {
+	switch normalizeTaskStatus(status) {
+	case taskStatusCompleted, taskStatusFailed:
+		return true
+	default:
+		return false
+	}
+}
+
 func isActiveTaskStatus(status string) bool {
 	switch normalizeTaskStatus(status) {
 	case taskStatusPending, taskStatusRunning, taskStatusWaitingInput:
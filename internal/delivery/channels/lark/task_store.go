@@ -20,6 +20,7 @@ type TaskRecord struct {
 	Error         string
 	TokensUsed    int
 	MergeStatus   string
+	DigestedAt    time.Time // when a scheduled digest reported the task; zero until then
 }
 
 // TaskStore persists task records for the Lark gateway.
@@ -33,6 +34,17 @@ type TaskStore interface {
 	MarkStaleRunning(ctx context.Context, reason string) error
 }
 
+// TaskDigestStore is an optional TaskStore extension used by the scheduled
+// task digest. Digested tasks are stamped in the store rather than tracked
+// in memory, so a restart between digests neither repeats nor drops them.
+type TaskDigestStore interface {
+	// ListUndigested returns completed and failed tasks not yet stamped by
+	// MarkDigested, oldest completion first. An empty chatID spans all chats.
+	ListUndigested(ctx context.Context, chatID string) ([]TaskRecord, error)
+	// MarkDigested sets DigestedAt on the given tasks.
+	MarkDigested(ctx context.Context, taskIDs []string, at time.Time) error
+}
+
 // TaskUpdateOption is a functional option for UpdateStatus.
 type TaskUpdateOption func(*taskUpdateOptions)
 
@@ -220,6 +220,49 @@ func (s *TaskLocalStore) ListByChat(ctx context.Context, chatID string, activeOn
 	return out, nil
 }
 
+// ListUndigested returns completed and failed tasks without DigestedAt,
+// oldest completion first.
+func (s *TaskLocalStore) ListUndigested(ctx context.Context, chatID string) ([]TaskRecord, error) {
+	if err := s.ensureReady(ctx); err != nil {
+		return nil, err
+	}
+	chatID = strings.TrimSpace(chatID)
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var out []TaskRecord
+	for _, rec := range s.tasks {
+		if chatID != "" && rec.ChatID != chatID {
+			continue
+		}
+		if !rec.DigestedAt.IsZero() || !isDigestTaskStatus(rec.Status) {
+			continue
+		}
+		out = append(out, rec)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].CompletedAt.Before(out[j].CompletedAt)
+	})
+	return out, nil
+}
+
+// MarkDigested stamps DigestedAt on the given tasks. Unknown IDs are skipped.
+func (s *TaskLocalStore) MarkDigested(ctx context.Context, taskIDs []string, at time.Time) error {
+	if err := s.ensureReady(ctx); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, id := range taskIDs {
+		rec, ok := s.tasks[strings.TrimSpace(id)]
+		if !ok {
+			continue
+		}
+		rec.DigestedAt = at
+		s.tasks[rec.TaskID] = rec
+	}
+	return s.persistLocked()
+}
+
 // DeleteExpired removes tasks created before the cutoff.
 func (s *TaskLocalStore) DeleteExpired(ctx context.Context, before time.Time) error {
 	if err := s.ensureReady(ctx); err != nil {
@@ -380,4 +423,7 @@ func (s *TaskLocalStore) persistLocked() error {
 	return nil
 }
 
-var _ TaskStore = (*TaskLocalStore)(nil)
+var (
+	_ TaskStore       = (*TaskLocalStore)(nil)
+	_ TaskDigestStore = (*TaskLocalStore)(nil)
+)
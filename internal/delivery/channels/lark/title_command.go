@@ -0,0 +1,116 @@
+package lark
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"alex/internal/app/agent/sessiontitle"
+	"alex/internal/delivery/channels"
+	storage "alex/internal/domain/agent/ports/storage"
+	"alex/internal/shared/utils"
+)
+
+// SessionTitler is the narrow session-title port used by the /title command.
+// Satisfied by *sessiontitle.Service.
+type SessionTitler interface {
+	Current(ctx context.Context, sessionID string) (string, []string, error)
+	SetTitle(ctx context.Context, sessionID, title string, tags []string) (*storage.Session, error)
+}
+
+// isTitleCommand checks whether the message is a /title command.
+func (g *Gateway) isTitleCommand(trimmed string) bool {
+	lower := utils.TrimLower(trimmed)
+	return lower == "/title" || strings.HasPrefix(lower, "/title ")
+}
+
+// handleTitleCommand shows or overrides the title of the chat's current
+// session. sessionID is the slot's active or last session; when empty the
+// persisted chat binding is used.
+func (g *Gateway) handleTitleCommand(msg *incomingMessage, sessionID string) {
+	if g == nil || msg == nil {
+		return
+	}
+	if sessionID == "" {
+		sessionID = g.loadPersistedChatSessionBinding(context.Background(), msg.chatID)
+	}
+	execCtx := channels.BuildBaseContext(g.cfg.BaseConfig, "lark", sessionID, msg.senderID, msg.chatID, msg.isGroup)
+	execCtx = g.withLarkContext(execCtx, msg.chatID, msg.messageID)
+	reply := g.titleReply(execCtx, sessionID, strings.TrimSpace(msg.content))
+	g.dispatch(execCtx, msg.chatID, replyTarget(msg.messageID, true), "text", textContent(reply))
+}
+
+// slotTitleSessionID returns the slot's active or most recent session.
+// The caller must hold slot.mu.
+func slotTitleSessionID(slot *sessionSlot) string {
+	if slot.sessionID != "" {
+		return slot.sessionID
+	}
+	return slot.lastSessionID
+}
+
+func (g *Gateway) titleReply(ctx context.Context, sessionID, content string) string {
+	if g.sessionTitles == nil {
+		return "会话标题不可用：未配置。"
+	}
+	if sessionID == "" {
+		return "当前没有会话，发送一条消息后再设置标题。"
+	}
+	fields := strings.Fields(content)
+	if len(fields) < 2 {
+		title, tags, err := g.sessionTitles.Current(ctx, sessionID)
+		if err != nil {
+			return fmt.Sprintf("读取会话标题失败：%v", err)
+		}
+		if title == "" {
+			return "当前会话还没有标题。\n\n" + titleCommandUsage()
+		}
+		return formatTitleReply("当前会话标题", title, tags)
+	}
+
+	var (
+		title string
+		tags  []string
+	)
+	if utils.TrimLower(fields[1]) == "tags" {
+		tags = splitTitleTags(textAfterFields(content, 2))
+	} else {
+		title = textAfterFields(content, 1)
+	}
+	session, err := g.sessionTitles.SetTitle(ctx, sessionID, title, tags)
+	if err != nil {
+		return fmt.Sprintf("更新会话标题失败：%v", err)
+	}
+	return formatTitleReply("已更新会话标题", session.Metadata[sessiontitle.MetadataTitle], sessiontitle.Tags(session.Metadata))
+}
+
+func formatTitleReply(header, title string, tags []string) string {
+	reply := fmt.Sprintf("%s：%s", header, title)
+	if len(tags) > 0 {
+		reply += "\n标签：" + strings.Join(tags, ", ")
+	}
+	return reply
+}
+
+// splitTitleTags accepts comma- or space-separated tags. An empty input
+// yields an empty (non-nil) slice so "/title tags" clears them.
+func splitTitleTags(raw string) []string {
+	tags := []string{}
+	for _, tag := range strings.FieldsFunc(raw, func(r rune) bool {
+		return r == ',' || r == '，' || r == ' ' || r == '\t'
+	}) {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+func titleCommandUsage() string {
+	return strings.TrimSpace(`
+Title command usage:
+  /title                    Show the current session title and tags
+  /title <text>             Set the session title
+  /title tags <a, b, c>     Set up to 3 topic tags (empty clears them)
+`)
+}
@@ -0,0 +1,85 @@
+package lark
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"alex/internal/app/agent/sessiontitle"
+	"alex/internal/delivery/channels"
+	storage "alex/internal/domain/agent/ports/storage"
+	"alex/internal/shared/logging"
+)
+
+type fakeSessionTitler struct {
+	sessions map[string]*storage.Session
+}
+
+func (f *fakeSessionTitler) Current(_ context.Context, sessionID string) (string, []string, error) {
+	session, ok := f.sessions[sessionID]
+	if !ok {
+		return "", nil, fmt.Errorf("session %s not found", sessionID)
+	}
+	return session.Metadata[sessiontitle.MetadataTitle], sessiontitle.Tags(session.Metadata), nil
+}
+
+func (f *fakeSessionTitler) SetTitle(_ context.Context, sessionID, title string, tags []string) (*storage.Session, error) {
+	session, ok := f.sessions[sessionID]
+	if !ok {
+		return nil, fmt.Errorf("session %s not found", sessionID)
+	}
+	if err := sessiontitle.ApplyOverride(session, title, tags); err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+func sendTitleCommand(t *testing.T, gw *Gateway, recorder *RecordingMessenger, sessionID, content string) string {
+	t.Helper()
+	before := len(recorder.CallsByMethod("ReplyMessage"))
+	gw.handleTitleCommand(&incomingMessage{chatID: "oc_title", messageID: "om_title", senderID: "ou_title", content: content}, sessionID)
+	calls := recorder.CallsByMethod("ReplyMessage")
+	if len(calls) != before+1 {
+		t.Fatalf("expected one reply for %q, got %d", content, len(calls)-before)
+	}
+	return extractTextContent(calls[len(calls)-1].Content, nil)
+}
+
+func TestIsTitleCommand(t *testing.T) {
+	g := &Gateway{}
+	for input, want := range map[string]bool{"/title": true, "/Title Weekly sync": true, "/titles": false, "title": false} {
+		if got := g.isTitleCommand(input); got != want {
+			t.Fatalf("isTitleCommand(%q) = %v, want %v", input, got, want)
+		}
+	}
+}
+
+func TestHandleTitleCommandShowSetAndTags(t *testing.T) {
+	titler := &fakeSessionTitler{sessions: map[string]*storage.Session{"lark-s1": {ID: "lark-s1"}}}
+	recorder := NewRecordingMessenger()
+	gw := &Gateway{
+		cfg:           Config{BaseConfig: channels.BaseConfig{SessionPrefix: "lark", AllowDirect: true}, AppID: "test", AppSecret: "secret"},
+		logger:        logging.OrNop(nil),
+		messenger:     recorder,
+		sessionTitles: titler,
+	}
+
+	if reply := sendTitleCommand(t, gw, recorder, "lark-s1", "/title"); !strings.Contains(reply, "还没有标题") {
+		t.Fatalf("unexpected empty reply: %q", reply)
+	}
+	if reply := sendTitleCommand(t, gw, recorder, "lark-s1", "/title Weekly infra sync"); !strings.Contains(reply, "Weekly infra sync") {
+		t.Fatalf("unexpected set reply: %q", reply)
+	}
+	reply := sendTitleCommand(t, gw, recorder, "lark-s1", "/title tags infra，oncall, Ops")
+	if !strings.Contains(reply, "标签：infra, oncall, ops") {
+		t.Fatalf("unexpected tags reply: %q", reply)
+	}
+	meta := titler.sessions["lark-s1"].Metadata
+	if meta[sessiontitle.MetadataTitle] != "Weekly infra sync" || meta[sessiontitle.MetadataSource] != sessiontitle.SourceUser {
+		t.Fatalf("override not recorded: %v", meta)
+	}
+	if reply := sendTitleCommand(t, gw, recorder, "", "/title x"); !strings.Contains(reply, "当前没有会话") {
+		t.Fatalf("expected no-session reply, got %q", reply)
+	}
+}
@@ -0,0 +1,103 @@
+package lark
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"alex/internal/delivery/channels"
+	tools "alex/internal/domain/agent/ports/tools"
+	"alex/internal/shared/utils"
+)
+
+// SessionToolOverrides is the narrow port used by the /tools command.
+// Satisfied by *sessiontools.Service.
+type SessionToolOverrides interface {
+	Current(ctx context.Context, sessionID string) (tools.ToolOverrides, error)
+	Set(ctx context.Context, sessionID string, overrides tools.ToolOverrides) (tools.ToolOverrides, error)
+	Disable(ctx context.Context, sessionID, name string) (tools.ToolOverrides, error)
+	Enable(ctx context.Context, sessionID, name string) (tools.ToolOverrides, error)
+}
+
+// isToolsCommand checks whether the message is a /tools command.
+func (g *Gateway) isToolsCommand(trimmed string) bool {
+	lower := utils.TrimLower(trimmed)
+	return lower == "/tools" || strings.HasPrefix(lower, "/tools ")
+}
+
+// handleToolsCommand shows or edits the tool overrides of the chat's current
+// session. Changes apply from the next task in that session.
+func (g *Gateway) handleToolsCommand(msg *incomingMessage, sessionID string) {
+	if g == nil || msg == nil {
+		return
+	}
+	if sessionID == "" {
+		sessionID = g.loadPersistedChatSessionBinding(context.Background(), msg.chatID)
+	}
+	execCtx := channels.BuildBaseContext(g.cfg.BaseConfig, "lark", sessionID, msg.senderID, msg.chatID, msg.isGroup)
+	execCtx = g.withLarkContext(execCtx, msg.chatID, msg.messageID)
+	reply := g.toolsReply(execCtx, sessionID, strings.TrimSpace(msg.content))
+	g.dispatch(execCtx, msg.chatID, replyTarget(msg.messageID, true), "text", textContent(reply))
+}
+
+func (g *Gateway) toolsReply(ctx context.Context, sessionID, content string) string {
+	if g.sessionTools == nil {
+		return "会话工具设置不可用：未配置。"
+	}
+	if sessionID == "" {
+		return "当前没有会话，发送一条消息后再调整工具。"
+	}
+	fields := strings.Fields(content)
+	if len(fields) < 2 {
+		overrides, err := g.sessionTools.Current(ctx, sessionID)
+		if err != nil {
+			return fmt.Sprintf("读取会话工具设置失败：%v", err)
+		}
+		return formatToolOverrides("当前会话工具设置", overrides)
+	}
+
+	var (
+		overrides tools.ToolOverrides
+		err       error
+	)
+	switch sub := utils.TrimLower(fields[1]); {
+	case sub == "reset" && len(fields) == 2:
+		overrides, err = g.sessionTools.Set(ctx, sessionID, tools.ToolOverrides{})
+	case (sub == "disable" || sub == "enable") && len(fields) == 3:
+		if sub == "disable" {
+			overrides, err = g.sessionTools.Disable(ctx, sessionID, fields[2])
+		} else {
+			overrides, err = g.sessionTools.Enable(ctx, sessionID, fields[2])
+		}
+	default:
+		return toolsCommandUsage()
+	}
+	if err != nil {
+		return fmt.Sprintf("更新会话工具设置失败：%v", err)
+	}
+	return formatToolOverrides("已更新会话工具设置（下一条消息生效）", overrides)
+}
+
+func formatToolOverrides(header string, overrides tools.ToolOverrides) string {
+	if overrides.IsEmpty() {
+		return header + "：使用默认工具集。"
+	}
+	lines := []string{header + "："}
+	if len(overrides.Allow) > 0 {
+		lines = append(lines, "仅允许："+strings.Join(overrides.Allow, ", "))
+	}
+	if len(overrides.Deny) > 0 {
+		lines = append(lines, "已禁用："+strings.Join(overrides.Deny, ", "))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func toolsCommandUsage() string {
+	return strings.TrimSpace(`
+Tools command usage:
+  /tools                    Show this session's tool overrides
+  /tools disable <name>     Disable a tool for this session
+  /tools enable <name>      Re-enable a disabled tool
+  /tools reset              Restore the default tool set
+`)
+}
@@ -0,0 +1,72 @@
+package lark
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"alex/internal/app/agent/sessiontools"
+	"alex/internal/delivery/channels"
+	"alex/internal/infra/tape"
+	"alex/internal/shared/logging"
+)
+
+func sendToolsCommand(t *testing.T, gw *Gateway, recorder *RecordingMessenger, sessionID, content string) string {
+	t.Helper()
+	before := len(recorder.CallsByMethod("ReplyMessage"))
+	gw.handleToolsCommand(&incomingMessage{chatID: "oc_tools", messageID: "om_tools", senderID: "ou_tools", content: content}, sessionID)
+	calls := recorder.CallsByMethod("ReplyMessage")
+	if len(calls) != before+1 {
+		t.Fatalf("expected one reply for %q, got %d", content, len(calls)-before)
+	}
+	return extractTextContent(calls[len(calls)-1].Content, nil)
+}
+
+func TestIsToolsCommand(t *testing.T) {
+	g := &Gateway{}
+	for input, want := range map[string]bool{"/tools": true, "/Tools disable shell_exec": true, "/toolset": false, "tools": false} {
+		if got := g.isToolsCommand(input); got != want {
+			t.Fatalf("isToolsCommand(%q) = %v, want %v", input, got, want)
+		}
+	}
+}
+
+func TestHandleToolsCommandDisableEnableReset(t *testing.T) {
+	store := tape.NewSessionAdapter(tape.NewMemoryStore())
+	session, err := store.Create(context.Background())
+	if err != nil {
+		t.Fatalf("create session: %v", err)
+	}
+	svc := sessiontools.New(store)
+	recorder := NewRecordingMessenger()
+	gw := &Gateway{
+		cfg:          Config{BaseConfig: channels.BaseConfig{SessionPrefix: "lark", AllowDirect: true}, AppID: "test", AppSecret: "secret"},
+		logger:       logging.OrNop(nil),
+		messenger:    recorder,
+		sessionTools: svc,
+	}
+
+	if reply := sendToolsCommand(t, gw, recorder, session.ID, "/tools"); !strings.Contains(reply, "默认工具集") {
+		t.Fatalf("unexpected initial reply: %q", reply)
+	}
+	if reply := sendToolsCommand(t, gw, recorder, session.ID, "/tools disable shell_exec"); !strings.Contains(reply, "已禁用：shell_exec") {
+		t.Fatalf("unexpected disable reply: %q", reply)
+	}
+	current, err := svc.Current(context.Background(), session.ID)
+	if err != nil || current.Allows("shell_exec") {
+		t.Fatalf("expected shell_exec denied, got %+v err=%v", current, err)
+	}
+	if reply := sendToolsCommand(t, gw, recorder, session.ID, "/tools enable shell_exec"); !strings.Contains(reply, "默认工具集") {
+		t.Fatalf("unexpected enable reply: %q", reply)
+	}
+	sendToolsCommand(t, gw, recorder, session.ID, "/tools disable web_fetch")
+	if reply := sendToolsCommand(t, gw, recorder, session.ID, "/tools reset"); !strings.Contains(reply, "默认工具集") {
+		t.Fatalf("unexpected reset reply: %q", reply)
+	}
+	if reply := sendToolsCommand(t, gw, recorder, session.ID, "/tools disable"); !strings.Contains(reply, "Tools command usage") {
+		t.Fatalf("expected usage for missing name, got %q", reply)
+	}
+	if reply := sendToolsCommand(t, gw, recorder, "", "/tools reset"); !strings.Contains(reply, "当前没有会话") {
+		t.Fatalf("expected no-session reply, got %q", reply)
+	}
+}
@@ -17,6 +17,7 @@ import (
 type CostTrackerReader interface {
 	GetDailyCost(ctx context.Context, date time.Time) (*agentstorage.CostSummary, error)
 	GetDateRangeCost(ctx context.Context, start, end time.Time) (*agentstorage.CostSummary, error)
+	QueryUsage(ctx context.Context, query agentstorage.UsageQuery) (*agentstorage.UsageReport, error)
 }
 
 // isUsageCommand checks whether the message is a /usage or /stats command.
@@ -48,13 +49,16 @@ func (g *Gateway) buildUsageReply(ctx context.Context, msg *incomingMessage) str
 	// Section 1: Current model info
 	sb.WriteString(g.formatCurrentModel(ctx, msg))
 
-	// Section 2: Cost tracker data (today + this week)
+	// Section 2: The requesting user's spend this month
+	sb.WriteString(g.formatUserMonthUsage(ctx, msg.senderID, now))
+
+	// Section 3: Cost tracker data (today + this week)
 	sb.WriteString(g.formatCostSummary(ctx, now))
 
-	// Section 3: Top 3 tasks by token usage (from TaskStore)
+	// Section 4: Top 3 tasks by token usage (from TaskStore)
 	sb.WriteString(g.formatTopTasks(ctx, msg.chatID))
 
-	// Section 4: Active task count
+	// Section 5: Active task count
 	sb.WriteString(g.formatActiveTaskSummary(ctx, msg.chatID))
 
 	sb.WriteString("\n\u56de\u590d /tasks \u67e5\u770b\u4efb\u52a1\u5217\u8868\uff0c/model \u67e5\u770b\u6a21\u578b\u914d\u7f6e\u3002") // "回复 /tasks 查看任务列表，/model 查看模型配置。"
@@ -128,6 +132,45 @@ func (g *Gateway) formatCostSummary(ctx context.Context, now time.Time) string {
 	return sb.String()
 }
 
+// formatUserMonthUsage returns the sender's spend for the current UTC month.
+func (g *Gateway) formatUserMonthUsage(ctx context.Context, senderID string, now time.Time) string {
+	if g.costTracker == nil || strings.TrimSpace(senderID) == "" {
+		return ""
+	}
+	now = now.UTC()
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	report, err := g.costTracker.QueryUsage(ctx, agentstorage.UsageQuery{
+		UserID:  senderID,
+		From:    monthStart,
+		To:      now,
+		GroupBy: agentstorage.UsageGroupByModel,
+	})
+	if err != nil || report == nil {
+		return ""
+	}
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("\n\u4f60\u7684\u672c\u6708\u7528\u91cf (%s):\n", monthStart.Format("2006-01"))) // "你的本月用量"
+	if report.Totals.RequestCount == 0 {
+		sb.WriteString("  \u6682\u65e0\u8bb0\u5f55\n") // "暂无记录"
+		return sb.String()
+	}
+	totals := report.Totals
+	sb.WriteString(fmt.Sprintf("  Tokens: %s (in: %s, out: %s)\n",
+		formatTokens(totals.TotalTokens), formatTokens(totals.InputTokens), formatTokens(totals.OutputTokens)))
+	sb.WriteString(fmt.Sprintf("  \u8d39\u7528: $%.4f\n", totals.TotalCost))       // "费用"
+	sb.WriteString(fmt.Sprintf("  \u8bf7\u6c42\u6570: %d\n", totals.RequestCount)) // "请求数"
+	if len(report.Groups) > 0 {
+		parts := make([]string, 0, len(report.Groups))
+		for _, group := range report.Groups {
+			parts = append(parts, fmt.Sprintf("%s $%.4f", group.Key, group.TotalCost))
+		}
+		sb.WriteString("  \u6a21\u578b\u5206\u5e03: ") // "模型分布: "
+		sb.WriteString(strings.Join(parts, ", "))
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
 // formatCostSummaryBlock formats a CostSummary into readable lines.
 func formatCostSummaryBlock(s *agentstorage.CostSummary) string {
 	if s == nil {
@@ -99,6 +99,8 @@ func TestFormatCostSummaryBlockNil(t *testing.T) {
 type mockCostTracker struct {
 	daily  *agentstorage.CostSummary
 	weekly *agentstorage.CostSummary
+	month  *agentstorage.UsageReport
+	query  agentstorage.UsageQuery
 }
 
 func (m *mockCostTracker) GetDailyCost(_ context.Context, _ time.Time) (*agentstorage.CostSummary, error) {
@@ -109,6 +111,33 @@ func (m *mockCostTracker) GetDateRangeCost(_ context.Context, _, _ time.Time) (*
 	return m.weekly, nil
 }
 
+func (m *mockCostTracker) QueryUsage(_ context.Context, query agentstorage.UsageQuery) (*agentstorage.UsageReport, error) {
+	m.query = query
+	return m.month, nil
+}
+
+func TestFormatUserMonthUsage(t *testing.T) {
+	ct := &mockCostTracker{month: &agentstorage.UsageReport{
+		Totals: agentstorage.UsageTotals{TotalTokens: 12000, InputTokens: 9000, OutputTokens: 3000, TotalCost: 0.42, RequestCount: 7},
+		Groups: []agentstorage.UsageGroup{{Key: "gpt-4o", UsageTotals: agentstorage.UsageTotals{TotalCost: 0.42}}},
+	}}
+	g := &Gateway{costTracker: ct}
+	now := time.Date(2026, 3, 13, 9, 0, 0, 0, time.UTC)
+	result := g.formatUserMonthUsage(context.Background(), "ou_alice", now)
+
+	if ct.query.UserID != "ou_alice" || !ct.query.From.Equal(time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("expected a query for ou_alice from the first of the month, got %+v", ct.query)
+	}
+	for _, want := range []string{"你的本月用量 (2026-03)", "12.0k", "$0.4200", "gpt-4o $0.4200"} {
+		if !strings.Contains(result, want) {
+			t.Errorf("expected %q in: %s", want, result)
+		}
+	}
+	if got := g.formatUserMonthUsage(context.Background(), "", now); got != "" {
+		t.Errorf("expected no section without a sender, got: %q", got)
+	}
+}
+
 func TestFormatCostSummary_WithData(t *testing.T) {
 	ct := &mockCostTracker{
 		daily: &agentstorage.CostSummary{
@@ -0,0 +1,84 @@
+package lark
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	domain "alex/internal/domain/agent"
+	agent "alex/internal/domain/agent/ports/agent"
+	"alex/internal/domain/agent/types"
+)
+
+// usageCostTally records the cumulative token usage and cost reported by
+// iteration usage envelopes so the final reply can carry a compact cost line
+// (ShowCost). Subagent runs are tracked separately and summed, so delegated
+// work is included. All events are forwarded unchanged.
+type usageCostTally struct {
+	delegate agent.EventListener
+
+	mu    sync.Mutex
+	byRun map[string]usageCostSnapshot
+}
+
+type usageCostSnapshot struct {
+	tokens  int
+	costUSD float64
+}
+
+func newUsageCostTally(delegate agent.EventListener) *usageCostTally {
+	return &usageCostTally{delegate: delegate, byRun: make(map[string]usageCostSnapshot)}
+}
+
+func (t *usageCostTally) OnEvent(event agent.AgentEvent) {
+	if env, ok := event.(*domain.WorkflowEventEnvelope); ok && env.Event == types.EventIterationUsage {
+		t.mu.Lock()
+		t.byRun[strings.TrimSpace(env.GetRunID())] = usageCostSnapshot{
+			tokens:  asInt(env.Payload["cumulative_tokens"]),
+			costUSD: asFloat(env.Payload["cumulative_cost_usd"]),
+		}
+		t.mu.Unlock()
+	}
+	t.delegate.OnEvent(event)
+}
+
+// Line renders the compact cost line, or "" when no usage was reported.
+func (t *usageCostTally) Line() string {
+	if t == nil {
+		return ""
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if len(t.byRun) == 0 {
+		return ""
+	}
+	var total usageCostSnapshot
+	for _, snapshot := range t.byRun {
+		total.tokens += snapshot.tokens
+		total.costUSD += snapshot.costUSD
+	}
+	return fmt.Sprintf("用量：%s tokens · 约 $%.4f", formatTokenCount(total.tokens), total.costUSD)
+}
+
+// formatTokenCount abbreviates large counts (12345 → "12.3k").
+func formatTokenCount(tokens int) string {
+	if tokens < 1000 {
+		return fmt.Sprintf("%d", tokens)
+	}
+	return fmt.Sprintf("%.1fk", float64(tokens)/1000)
+}
+
+func asFloat(v any) float64 {
+	switch x := v.(type) {
+	case float64:
+		return x
+	case float32:
+		return float64(x)
+	case int:
+		return float64(x)
+	case int64:
+		return float64(x)
+	default:
+		return 0
+	}
+}
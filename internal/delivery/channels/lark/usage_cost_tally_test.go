@@ -0,0 +1,43 @@
+package lark
+
+import (
+	"testing"
+	"time"
+
+	domain "alex/internal/domain/agent"
+	agent "alex/internal/domain/agent/ports/agent"
+	"alex/internal/domain/agent/types"
+)
+
+func usageEnvelope(runID string, level agent.AgentLevel, tokens int, costUSD float64) *domain.WorkflowEventEnvelope {
+	return &domain.WorkflowEventEnvelope{
+		BaseEvent: domain.NewBaseEvent(level, "sess", runID, "", time.Now()),
+		Version:   1,
+		Event:     types.EventIterationUsage,
+		NodeKind:  "generation",
+		Payload: map[string]any{
+			"cumulative_tokens":   tokens,
+			"cumulative_cost_usd": costUSD,
+		},
+	}
+}
+
+func TestUsageCostTallySumsLatestPerRun(t *testing.T) {
+	tally := newUsageCostTally(agent.NoopEventListener{})
+	if got := tally.Line(); got != "" {
+		t.Fatalf("expected no line before usage events, got %q", got)
+	}
+
+	tally.OnEvent(usageEnvelope("run", agent.LevelCore, 4000, 0.02))
+	tally.OnEvent(usageEnvelope("run", agent.LevelCore, 9000, 0.05))
+	tally.OnEvent(usageEnvelope("sub", agent.LevelSubagent, 3500, 0.0125))
+
+	if got, want := tally.Line(), "用量：12.5k tokens · 约 $0.0625"; got != want {
+		t.Fatalf("Line() = %q, want %q", got, want)
+	}
+
+	var nilTally *usageCostTally
+	if got := nilTally.Line(); got != "" {
+		t.Fatalf("nil tally Line() = %q, want empty", got)
+	}
+}
@@ -3,10 +3,14 @@ package bootstrap
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	"gopkg.in/yaml.v3"
 )
 
+// ingestTokenEnv matches the variable the foundation eval client reads.
+const ingestTokenEnv = "ALEX_EVAL_SERVER_TOKEN"
+
 // EvalServerConfig holds the configuration for the evaluation server.
 type EvalServerConfig struct {
 	Port           string   `yaml:"port"`
@@ -15,9 +19,34 @@ type EvalServerConfig struct {
 	EvalOutputDir  string   `yaml:"eval_output_dir"`
 	RLOutputDir    string   `yaml:"rl_output_dir"`
 	SessionDir     string   `yaml:"session_dir"`
+	// IngestToken guards POST /api/foundation-runs. Falls back to
+	// ALEX_EVAL_SERVER_TOKEN; when both are empty, ingestion is open.
+	IngestToken string `yaml:"ingest_token"`
+
+	// MaxConcurrentRuns bounds evaluation runs executing at once; further
+	// submissions wait in a queue of at most MaxQueuedRuns.
+	MaxConcurrentRuns int `yaml:"max_concurrent_runs"`
+	MaxQueuedRuns     int `yaml:"max_queued_runs"`
+
+	// PublicURL is the externally reachable base URL used for links in
+	// webhook payloads. Defaults to http://localhost:<port>.
+	PublicURL string `yaml:"public_url"`
 
 	// Judge configuration for RL quality gate
 	Judge JudgeConfig `yaml:"judge"`
+
+	// Webhooks are notified when evaluation runs complete or fail.
+	Webhooks []WebhookConfig `yaml:"webhooks"`
+}
+
+// WebhookConfig is one webhook target.
+type WebhookConfig struct {
+	Name string `yaml:"name"`
+	URL  string `yaml:"url"`
+	// Secret signs payloads with HMAC-SHA256 in the X-Eval-Signature header.
+	Secret string `yaml:"secret"`
+	// Events filters deliveries: "completed", "failed". Empty means both.
+	Events []string `yaml:"events"`
 }
 
 // JudgeConfig holds LLM judge provider settings.
@@ -38,6 +67,16 @@ func DefaultConfig() *EvalServerConfig {
 		EvalOutputDir:  "./evaluation_results",
 		RLOutputDir:    "./rl_data",
 		SessionDir:     "./.sessions",
+
+		MaxConcurrentRuns: 1,
+		MaxQueuedRuns:     100,
+	}
+}
+
+// applyEnvDefaults fills settings left empty in the file from the environment.
+func applyEnvDefaults(cfg *EvalServerConfig) {
+	if strings.TrimSpace(cfg.IngestToken) == "" {
+		cfg.IngestToken = strings.TrimSpace(os.Getenv(ingestTokenEnv))
 	}
 }
 
@@ -54,5 +93,6 @@ func LoadConfig(path string) (*EvalServerConfig, error) {
 	if cfg.Port == "" {
 		cfg.Port = "8081"
 	}
+	applyEnvDefaults(cfg)
 	return cfg, nil
 }
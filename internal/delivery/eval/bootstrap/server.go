@@ -12,9 +12,12 @@ import (
 	"syscall"
 	"time"
 
+	agent_eval "alex/evaluation/agent_eval"
 	"alex/evaluation/rl"
 	"alex/evaluation/task_mgmt"
 	evalHTTP "alex/internal/delivery/eval/http"
+	"alex/internal/delivery/eval/runqueue"
+	"alex/internal/delivery/eval/webhook"
 	serverApp "alex/internal/delivery/server/app"
 	portsllm "alex/internal/domain/agent/ports/llm"
 	llminfra "alex/internal/infra/llm"
@@ -70,7 +73,32 @@ func RunEvalServer(configPath string) error {
 	taskMgr := task_mgmt.NewTaskManager(taskStore)
 	log.Printf("[eval-server] task management ready (store=%s)", taskStoreDir)
 
-	// Phase 4: Wire HTTP router
+	// Phase 4: Webhook notifications
+	notifier := newWebhookNotifier(cfg)
+	if notifier.TargetCount() > 0 {
+		publicURL := cfg.PublicURL
+		if publicURL == "" {
+			publicURL = "http://localhost:" + cfg.Port
+		}
+		evalSvc.OnJobFinished(func(job *agent_eval.EvaluationJob) {
+			notifier.Notify(webhook.PayloadFromJob(job, publicURL))
+		})
+		log.Printf("[eval-server] webhooks enabled (targets=%d)", notifier.TargetCount())
+	}
+
+	// Phase 5: Run queue
+	runQueue, err := runqueue.New(evalSvc, runqueue.Config{
+		Dir:       filepath.Join(cfg.EvalOutputDir, "runs"),
+		Workers:   cfg.MaxConcurrentRuns,
+		MaxQueued: cfg.MaxQueuedRuns,
+	})
+	if err != nil {
+		return fmt.Errorf("init run queue: %w", err)
+	}
+	runQueue.Start()
+	log.Printf("[eval-server] run queue ready (workers=%d)", runQueue.Workers())
+
+	// Phase 6: Wire HTTP router
 	router := evalHTTP.NewEvalRouter(evalHTTP.EvalRouterDeps{
 		Evaluation:  evalSvc,
 		RLStorage:   rlStorage,
@@ -79,10 +107,13 @@ func RunEvalServer(configPath string) error {
 		RLConfig:    rlConfig,
 		RLJudge:     judge,
 		TaskManager: taskMgr,
+		Webhooks:    notifier,
+		Runs:        runQueue,
 	}, evalHTTP.EvalRouterConfig{
 		Environment:    cfg.Environment,
 		AllowedOrigins: cfg.AllowedOrigins,
 		RLOutputDir:    cfg.RLOutputDir,
+		IngestToken:    cfg.IngestToken,
 	})
 
 	server := &http.Server{
@@ -93,7 +124,7 @@ func RunEvalServer(configPath string) error {
 		IdleTimeout:  120 * time.Second,
 	}
 
-	// Phase 4: Graceful shutdown
+	// Phase 7: Graceful shutdown
 	errCh := make(chan error, 1)
 	go func() {
 		log.Printf("[eval-server] listening on :%s", cfg.Port)
@@ -121,11 +152,30 @@ func RunEvalServer(configPath string) error {
 	if err := server.Shutdown(ctx); err != nil {
 		return fmt.Errorf("shutdown: %w", err)
 	}
+	if err := runQueue.Close(ctx); err != nil {
+		log.Printf("[eval-server] evaluation runs still stopping at shutdown: %v", err)
+	}
+	if err := notifier.Close(ctx); err != nil {
+		log.Printf("[eval-server] webhook deliveries still in flight at shutdown: %v", err)
+	}
 
 	log.Println("[eval-server] stopped")
 	return nil
 }
 
+func newWebhookNotifier(cfg *EvalServerConfig) *webhook.Notifier {
+	targets := make([]webhook.Target, 0, len(cfg.Webhooks))
+	for _, wh := range cfg.Webhooks {
+		targets = append(targets, webhook.Target{
+			Name:   wh.Name,
+			URL:    wh.URL,
+			Secret: wh.Secret,
+			Events: wh.Events,
+		})
+	}
+	return webhook.NewNotifier(webhook.Config{Targets: targets})
+}
+
 func createLLMJudge(cfg JudgeConfig) (rl.Judge, error) {
 	factory := llminfra.NewFactory()
 	client, err := factory.GetClient(cfg.Provider, cfg.Model, portsllm.LLMConfig{
@@ -151,5 +201,7 @@ func resolveConfig(path string) (*EvalServerConfig, error) {
 			return LoadConfig(c)
 		}
 	}
-	return DefaultConfig(), nil
+	cfg := DefaultConfig()
+	applyEnvDefaults(cfg)
+	return cfg, nil
 }
@@ -42,6 +42,36 @@ judge:
 	}
 }
 
+func TestLoadConfigWebhooks(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "eval-server.yaml")
+	content := `
+public_url: https://eval.example
+webhooks:
+  - name: relay
+    url: https://relay.example/hook
+    secret: s3cret
+    events: [failed]
+  - url: https://other.example/hook
+`
+	if err := os.WriteFile(configPath, []byte(strings.TrimSpace(content)), 0o644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	cfg, err := LoadConfig(configPath)
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+	if cfg.PublicURL != "https://eval.example" || len(cfg.Webhooks) != 2 {
+		t.Fatalf("unexpected config %#v", cfg)
+	}
+	if wh := cfg.Webhooks[0]; wh.Name != "relay" || wh.Secret != "s3cret" || len(wh.Events) != 1 || wh.Events[0] != "failed" {
+		t.Fatalf("Webhooks[0] = %#v", wh)
+	}
+	if n := newWebhookNotifier(cfg); n.TargetCount() != 2 {
+		t.Fatalf("TargetCount() = %d, want 2", n.TargetCount())
+	}
+}
+
 func TestResolveConfig(t *testing.T) {
 	t.Run("explicit path wins", func(t *testing.T) {
 		dir := t.TempDir()
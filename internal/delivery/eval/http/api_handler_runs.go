@@ -0,0 +1,179 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	agent_eval "alex/evaluation/agent_eval"
+	"alex/internal/delivery/eval/runqueue"
+	serverApp "alex/internal/delivery/server/app"
+)
+
+const runStreamHeartbeat = 15 * time.Second
+
+// runHandler serves the queued evaluation run API.
+type runHandler struct {
+	evaluation *serverApp.EvaluationService
+	queue      *runqueue.Queue
+}
+
+// submitRun validates options and queues the run, returning the HTTP status
+// to report on failure.
+func submitRun(evaluation *serverApp.EvaluationService, queue *runqueue.Queue, options *agent_eval.EvaluationOptions) (runqueue.Run, int, error) {
+	config, err := evaluation.Prepare(options)
+	if err != nil {
+		return runqueue.Run{}, http.StatusBadRequest, err
+	}
+	run, err := queue.Submit(config)
+	switch {
+	case errors.Is(err, runqueue.ErrQueueFull):
+		return runqueue.Run{}, http.StatusTooManyRequests, err
+	case err != nil:
+		return runqueue.Run{}, http.StatusServiceUnavailable, err
+	}
+	return run, http.StatusAccepted, nil
+}
+
+func (h *runHandler) available(w http.ResponseWriter) bool {
+	if h.queue == nil || h.evaluation == nil {
+		writeJSONError(w, http.StatusServiceUnavailable, "Run queue not configured")
+		return false
+	}
+	return true
+}
+
+func (h *runHandler) handleListRuns(w http.ResponseWriter, _ *http.Request) {
+	if !h.available(w) {
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"runs": h.queue.List()})
+}
+
+func (h *runHandler) handleSubmitRun(w http.ResponseWriter, r *http.Request) {
+	if !h.available(w) {
+		return
+	}
+	var req startEvalRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1<<18)).Decode(&req); err != nil {
+		writeJSONError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	run, status, err := submitRun(h.evaluation, h.queue, req.options())
+	if err != nil {
+		writeJSONError(w, status, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusAccepted, map[string]any{"run": run})
+}
+
+func (h *runHandler) handleGetRun(w http.ResponseWriter, r *http.Request) {
+	if !h.available(w) {
+		return
+	}
+	run, err := h.queue.Get(r.PathValue("run_id"))
+	if err != nil {
+		writeJSONError(w, http.StatusNotFound, "Run not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"run": run})
+}
+
+func (h *runHandler) handleCancelRun(w http.ResponseWriter, r *http.Request) {
+	if !h.available(w) {
+		return
+	}
+	run, err := h.queue.Cancel(r.PathValue("run_id"))
+	switch {
+	case errors.Is(err, runqueue.ErrNotFound):
+		writeJSONError(w, http.StatusNotFound, "Run not found")
+	case errors.Is(err, runqueue.ErrFinished):
+		writeJSONError(w, http.StatusConflict, fmt.Sprintf("Run already %s", run.Status))
+	case err != nil:
+		writeJSONError(w, http.StatusInternalServerError, "Failed to cancel run")
+	default:
+		writeJSON(w, http.StatusAccepted, map[string]any{"run": run})
+	}
+}
+
+// handleStreamRun streams status transitions as SSE "status" events until
+// the run reaches a terminal state or the client disconnects.
+func (h *runHandler) handleStreamRun(w http.ResponseWriter, r *http.Request) {
+	if !h.available(w) {
+		return
+	}
+	runID := r.PathValue("run_id")
+	current, updates, unsubscribe, err := h.queue.Subscribe(runID)
+	if err != nil {
+		writeJSONError(w, http.StatusNotFound, "Run not found")
+		return
+	}
+	defer unsubscribe()
+
+	rc := http.NewResponseController(w)
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	send := func(run runqueue.Run) bool {
+		data, err := json.Marshal(run)
+		if err != nil {
+			return false
+		}
+		if _, err := fmt.Fprintf(w, "event: status\ndata: %s\n\n", data); err != nil {
+			return false
+		}
+		return rc.Flush() == nil
+	}
+	if !send(current) {
+		return
+	}
+
+	heartbeat := time.NewTicker(runStreamHeartbeat)
+	defer heartbeat.Stop()
+	last := current.Status
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": ping\n\n"); err != nil || rc.Flush() != nil {
+				return
+			}
+		case run, ok := <-updates:
+			if !ok {
+				// Updates may have been dropped for a slow reader; always end
+				// with the stored terminal state.
+				if final, err := h.queue.Get(runID); err == nil && final.Status != last {
+					send(final)
+				}
+				return
+			}
+			if !send(run) {
+				return
+			}
+			last = run.Status
+		}
+	}
+}
+
+func (h *runHandler) handleRunLogs(w http.ResponseWriter, r *http.Request) {
+	if !h.available(w) {
+		return
+	}
+	data, err := h.queue.ReadLog(r.PathValue("run_id"))
+	if errors.Is(err, runqueue.ErrNotFound) {
+		writeJSONError(w, http.StatusNotFound, "Run not found")
+		return
+	}
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, "Failed to read run log")
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(data)
+}
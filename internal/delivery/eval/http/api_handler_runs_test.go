@@ -0,0 +1,108 @@
+package http
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	agent_eval "alex/evaluation/agent_eval"
+	"alex/internal/delivery/eval/runqueue"
+	serverApp "alex/internal/delivery/server/app"
+)
+
+type cancellableExecutor struct{ started chan struct{} }
+
+func (e *cancellableExecutor) Execute(ctx context.Context, jobID string, _ *agent_eval.EvaluationConfig) *agent_eval.EvaluationJob {
+	close(e.started)
+	<-ctx.Done()
+	return &agent_eval.EvaluationJob{ID: jobID, Status: agent_eval.JobStatusCancelled, Error: ctx.Err()}
+}
+
+func TestRunEndpoints(t *testing.T) {
+	evalSvc, err := serverApp.NewEvaluationService(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewEvaluationService() error = %v", err)
+	}
+	exec := &cancellableExecutor{started: make(chan struct{})}
+	queue, err := runqueue.New(exec, runqueue.Config{Dir: t.TempDir()})
+	if err != nil {
+		t.Fatalf("runqueue.New() error = %v", err)
+	}
+	queue.Start()
+	defer queue.Close(context.Background())
+
+	srv := httptest.NewServer(NewEvalRouter(EvalRouterDeps{Evaluation: evalSvc, Runs: queue}, EvalRouterConfig{Environment: "development"}))
+	defer srv.Close()
+
+	run, err := queue.Submit(&agent_eval.EvaluationConfig{DatasetPath: "suite.json"})
+	if err != nil {
+		t.Fatalf("Submit() error = %v", err)
+	}
+	<-exec.started
+
+	resp, err := http.Get(srv.URL + "/api/eval/runs/" + run.ID + "/stream")
+	if err != nil {
+		t.Fatalf("stream request error = %v", err)
+	}
+	defer resp.Body.Close()
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Fatalf("Content-Type = %q", ct)
+	}
+	reader := bufio.NewReader(resp.Body)
+	nextStatus := func() string {
+		t.Helper()
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				t.Fatalf("read stream: %v", err)
+			}
+			if data, ok := strings.CutPrefix(strings.TrimSpace(line), "data: "); ok {
+				var got runqueue.Run
+				if err := json.Unmarshal([]byte(data), &got); err != nil {
+					t.Fatalf("decode event: %v", err)
+				}
+				return string(got.Status)
+			}
+		}
+	}
+	if got := nextStatus(); got != "running" {
+		t.Fatalf("first stream status = %s, want running", got)
+	}
+
+	req, _ := http.NewRequest(http.MethodDelete, srv.URL+"/api/eval/runs/"+run.ID, nil)
+	cancelResp, err := http.DefaultClient.Do(req)
+	if err != nil || cancelResp.StatusCode != http.StatusAccepted {
+		t.Fatalf("cancel = %v, %v", cancelResp, err)
+	}
+	cancelResp.Body.Close()
+	if got := nextStatus(); got != "cancelled" {
+		t.Fatalf("final stream status = %s, want cancelled", got)
+	}
+
+	rec := httptest.NewRecorder()
+	NewEvalRouter(EvalRouterDeps{Evaluation: evalSvc, Runs: queue}, EvalRouterConfig{}).
+		ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/eval/runs/"+run.ID+"/logs", nil))
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), "Run cancelled") {
+		t.Fatalf("logs = %d %q", rec.Code, rec.Body.String())
+	}
+
+	for _, tc := range []struct {
+		method, target string
+		status         int
+	}{
+		{http.MethodDelete, "/api/eval/runs/" + run.ID, http.StatusConflict},
+		{http.MethodGet, "/api/eval/runs/missing", http.StatusNotFound},
+		{http.MethodGet, "/api/eval/runs", http.StatusOK},
+	} {
+		rec := httptest.NewRecorder()
+		NewEvalRouter(EvalRouterDeps{Evaluation: evalSvc, Runs: queue}, EvalRouterConfig{}).
+			ServeHTTP(rec, httptest.NewRequest(tc.method, tc.target, nil))
+		if rec.Code != tc.status {
+			t.Fatalf("%s %s = %d, want %d", tc.method, tc.target, rec.Code, tc.status)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"alex/internal/delivery/eval/webhook"
+)
+
+// webhookHandler exposes webhook delivery status and test sends.
+type webhookHandler struct {
+	notifier *webhook.Notifier
+}
+
+func (h *webhookHandler) handleListDeliveries(w http.ResponseWriter, r *http.Request) {
+	if h.notifier == nil {
+		writeJSONError(w, http.StatusServiceUnavailable, "Webhooks not configured")
+		return
+	}
+	limit := 0
+	if raw := r.URL.Query().Get("limit"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 0 {
+			writeJSONError(w, http.StatusBadRequest, "Invalid limit")
+			return
+		}
+		limit = parsed
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"deliveries": h.notifier.Deliveries(limit)})
+}
+
+type webhookTestRequest struct {
+	// Target selects one configured webhook by name; empty sends to all.
+	Target string `json:"target"`
+}
+
+func (h *webhookHandler) handleTestWebhook(w http.ResponseWriter, r *http.Request) {
+	if h.notifier == nil || h.notifier.TargetCount() == 0 {
+		writeJSONError(w, http.StatusServiceUnavailable, "Webhooks not configured")
+		return
+	}
+	var req webhookTestRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1<<12)).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		writeJSONError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	deliveries, err := h.notifier.SendTest(r.Context(), req.Target)
+	if err != nil {
+		writeJSONError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"deliveries": deliveries})
+}
@@ -0,0 +1,58 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"alex/internal/delivery/eval/webhook"
+)
+
+func TestWebhookEndpoints(t *testing.T) {
+	relay := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get(webhook.EventHeader) != webhook.EventTest {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer relay.Close()
+
+	notifier := webhook.NewNotifier(webhook.Config{Targets: []webhook.Target{{Name: "relay", URL: relay.URL}}})
+	router := NewEvalRouter(EvalRouterDeps{Webhooks: notifier}, EvalRouterConfig{Environment: "development"})
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/webhooks/test", strings.NewReader(`{"target":"nope"}`)))
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("unknown target status = %d, want %d", rec.Code, http.StatusNotFound)
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/webhooks/test", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("test status = %d, body %s", rec.Code, rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/webhooks/deliveries?limit=5", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("deliveries status = %d", rec.Code)
+	}
+	var resp struct {
+		Deliveries []webhook.Delivery `json:"deliveries"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if len(resp.Deliveries) != 1 || resp.Deliveries[0].Status != webhook.StatusDelivered || resp.Deliveries[0].Target != "relay" {
+		t.Fatalf("unexpected deliveries %+v", resp.Deliveries)
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/webhooks/deliveries?limit=x", nil))
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("invalid limit status = %d", rec.Code)
+	}
+}
@@ -1,6 +1,7 @@
 package http
 
 import (
+	"crypto/subtle"
 	"encoding/json"
 	"log"
 	"net/http"
@@ -10,6 +11,8 @@ import (
 	agent_eval "alex/evaluation/agent_eval"
 	"alex/evaluation/rl"
 	"alex/evaluation/task_mgmt"
+	"alex/internal/delivery/eval/runqueue"
+	"alex/internal/delivery/eval/webhook"
 	serverApp "alex/internal/delivery/server/app"
 	serverHTTP "alex/internal/delivery/server/http"
 )
@@ -23,6 +26,8 @@ type EvalRouterDeps struct {
 	RLConfig    rl.QualityConfig
 	RLJudge     rl.Judge // may be nil
 	TaskManager *task_mgmt.TaskManager
+	Webhooks    *webhook.Notifier // may be nil
+	Runs        *runqueue.Queue   // may be nil
 }
 
 // EvalRouterConfig holds configuration for the eval-server router.
@@ -30,6 +35,9 @@ type EvalRouterConfig struct {
 	Environment    string
 	AllowedOrigins []string
 	RLOutputDir    string
+	// IngestToken, when set, is the bearer token required to publish
+	// foundation runs.
+	IngestToken string
 }
 
 // NewEvalRouter creates the HTTP router for the eval-server.
@@ -38,7 +46,9 @@ func NewEvalRouter(deps EvalRouterDeps, cfg EvalRouterConfig) http.Handler {
 
 	handler := &evalHandler{
 		evaluation:  deps.Evaluation,
+		runs:        deps.Runs,
 		rlOutputDir: cfg.RLOutputDir,
+		ingestToken: cfg.IngestToken,
 	}
 
 	// Health check
@@ -50,6 +60,20 @@ func NewEvalRouter(deps EvalRouterDeps, cfg EvalRouterConfig) http.Handler {
 	mux.HandleFunc("GET /api/evaluations/{evaluation_id}", handler.handleGetEvaluation)
 	mux.HandleFunc("DELETE /api/evaluations/{evaluation_id}", handler.handleDeleteEvaluation)
 
+	// Queued evaluation runs
+	runH := &runHandler{evaluation: deps.Evaluation, queue: deps.Runs}
+	mux.HandleFunc("GET /api/eval/runs", runH.handleListRuns)
+	mux.HandleFunc("POST /api/eval/runs", runH.handleSubmitRun)
+	mux.HandleFunc("GET /api/eval/runs/{run_id}", runH.handleGetRun)
+	mux.HandleFunc("DELETE /api/eval/runs/{run_id}", runH.handleCancelRun)
+	mux.HandleFunc("GET /api/eval/runs/{run_id}/stream", runH.handleStreamRun)
+	mux.HandleFunc("GET /api/eval/runs/{run_id}/logs", runH.handleRunLogs)
+
+	// Foundation eval runs published by `alex eval foundation --publish-url`
+	mux.HandleFunc("GET /api/foundation-runs", handler.handleListFoundationRuns)
+	mux.HandleFunc("POST /api/foundation-runs", handler.handleIngestFoundationRun)
+	mux.HandleFunc("GET /api/foundation-runs/{run_id}", handler.handleGetFoundationRun)
+
 	// Agent catalog
 	mux.HandleFunc("GET /api/agents", handler.handleListAgents)
 	mux.HandleFunc("GET /api/agents/{agent_id}", handler.handleGetAgent)
@@ -79,6 +103,11 @@ func NewEvalRouter(deps EvalRouterDeps, cfg EvalRouterConfig) http.Handler {
 	mux.HandleFunc("DELETE /api/eval-tasks/{task_id}", taskH.handleDeleteTask)
 	mux.HandleFunc("POST /api/eval-tasks/{task_id}/run", taskH.handleRunTask)
 
+	// Webhook notifications
+	webhookH := &webhookHandler{notifier: deps.Webhooks}
+	mux.HandleFunc("GET /api/webhooks/deliveries", webhookH.handleListDeliveries)
+	mux.HandleFunc("POST /api/webhooks/test", webhookH.handleTestWebhook)
+
 	// Middleware stack (lightweight — no auth, no streaming guards)
 	var root http.Handler = mux
 	root = loggingMiddleware(root)
@@ -90,7 +119,9 @@ func NewEvalRouter(deps EvalRouterDeps, cfg EvalRouterConfig) http.Handler {
 
 type evalHandler struct {
 	evaluation  *serverApp.EvaluationService
+	runs        *runqueue.Queue
 	rlOutputDir string
+	ingestToken string
 }
 
 func (h *evalHandler) handleHealth(w http.ResponseWriter, _ *http.Request) {
@@ -120,24 +151,12 @@ type startEvalRequest struct {
 	AgentID       string `json:"agent_id,omitempty"`
 }
 
-func (h *evalHandler) handleStartEvaluation(w http.ResponseWriter, r *http.Request) {
-	if h.evaluation == nil {
-		writeJSONError(w, http.StatusServiceUnavailable, "Evaluation service unavailable")
-		return
-	}
-
-	var req startEvalRequest
-	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1<<18)).Decode(&req); err != nil {
-		writeJSONError(w, http.StatusBadRequest, "Invalid request body")
-		return
-	}
-
+func (req startEvalRequest) options() *agent_eval.EvaluationOptions {
 	enableMetrics := true
 	if req.EnableMetrics != nil {
 		enableMetrics = *req.EnableMetrics
 	}
-
-	options := &agent_eval.EvaluationOptions{
+	return &agent_eval.EvaluationOptions{
 		DatasetPath:    req.DatasetPath,
 		InstanceLimit:  req.InstanceLimit,
 		MaxWorkers:     req.MaxWorkers,
@@ -147,8 +166,35 @@ func (h *evalHandler) handleStartEvaluation(w http.ResponseWriter, r *http.Reque
 		EnableMetrics:  enableMetrics,
 		ReportFormat:   req.ReportFormat,
 	}
+}
+
+func (h *evalHandler) handleStartEvaluation(w http.ResponseWriter, r *http.Request) {
+	if h.evaluation == nil {
+		writeJSONError(w, http.StatusServiceUnavailable, "Evaluation service unavailable")
+		return
+	}
+
+	var req startEvalRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1<<18)).Decode(&req); err != nil {
+		writeJSONError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
 
-	job, err := h.evaluation.Start(r.Context(), options)
+	// With a run queue configured, submissions share its concurrency limit.
+	if h.runs != nil {
+		run, status, err := submitRun(h.evaluation, h.runs, req.options())
+		if err != nil {
+			writeJSONError(w, status, err.Error())
+			return
+		}
+		writeJSON(w, http.StatusAccepted, map[string]any{
+			"id":     run.ID,
+			"status": string(run.Status),
+		})
+		return
+	}
+
+	job, err := h.evaluation.Start(r.Context(), req.options())
 	if err != nil {
 		writeJSONError(w, http.StatusBadRequest, err.Error())
 		return
@@ -250,6 +296,66 @@ func (h *evalHandler) handleListAgentEvaluations(w http.ResponseWriter, r *http.
 	writeJSON(w, http.StatusOK, map[string]any{"evaluations": evals})
 }
 
+func (h *evalHandler) handleListFoundationRuns(w http.ResponseWriter, _ *http.Request) {
+	if h.evaluation == nil {
+		writeJSONError(w, http.StatusServiceUnavailable, "Evaluation service unavailable")
+		return
+	}
+	runs, err := h.evaluation.ListFoundationRuns()
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, "Failed to list foundation runs")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"runs": runs})
+}
+
+func (h *evalHandler) handleIngestFoundationRun(w http.ResponseWriter, r *http.Request) {
+	if h.evaluation == nil {
+		writeJSONError(w, http.StatusServiceUnavailable, "Evaluation service unavailable")
+		return
+	}
+	if h.ingestToken != "" {
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(strings.TrimSpace(token)), []byte(h.ingestToken)) != 1 {
+			writeJSONError(w, http.StatusUnauthorized, "Invalid or missing ingest token")
+			return
+		}
+	}
+
+	var result agent_eval.FoundationEvaluationResult
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 8<<20)).Decode(&result); err != nil {
+		writeJSONError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if strings.TrimSpace(result.RunID) == "" {
+		writeJSONError(w, http.StatusBadRequest, "run_id is required")
+		return
+	}
+	if err := h.evaluation.IngestFoundationRun(&result); err != nil {
+		writeJSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusCreated, map[string]any{"run_id": result.RunID})
+}
+
+func (h *evalHandler) handleGetFoundationRun(w http.ResponseWriter, r *http.Request) {
+	if h.evaluation == nil {
+		writeJSONError(w, http.StatusServiceUnavailable, "Evaluation service unavailable")
+		return
+	}
+	runID := r.PathValue("run_id")
+	if runID == "" {
+		writeJSONError(w, http.StatusBadRequest, "run_id is required")
+		return
+	}
+	result, err := h.evaluation.GetFoundationRun(runID)
+	if err != nil {
+		writeJSONError(w, http.StatusNotFound, "Foundation run not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
 // writeJSON encodes v as JSON and writes it with the given status code.
 func writeJSON(w http.ResponseWriter, status int, v any) {
 	w.Header().Set("Content-Type", "application/json")
@@ -287,3 +393,5 @@ func (rw *responseWriter) WriteHeader(code int) {
 	rw.status = code
 	rw.ResponseWriter.WriteHeader(code)
 }
+
+func (rw *responseWriter) Unwrap() http.ResponseWriter { return rw.ResponseWriter }
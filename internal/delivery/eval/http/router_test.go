@@ -74,6 +74,20 @@ func TestNewEvalRouterDependencyGuards(t *testing.T) {
 			status:  http.StatusServiceUnavailable,
 			message: "Task management not configured",
 		},
+		{
+			name:    "run queue unavailable",
+			method:  http.MethodGet,
+			target:  "/api/eval/runs",
+			status:  http.StatusServiceUnavailable,
+			message: "Run queue not configured",
+		},
+		{
+			name:    "webhooks unavailable",
+			method:  http.MethodPost,
+			target:  "/api/webhooks/test",
+			status:  http.StatusServiceUnavailable,
+			message: "Webhooks not configured",
+		},
 		{
 			name:    "invalid rl config body",
 			method:  http.MethodPut,
@@ -221,3 +235,63 @@ func TestNewEvalRouterCriticalPaths(t *testing.T) {
 		}
 	})
 }
+
+func TestNewEvalRouterFoundationRuns(t *testing.T) {
+	evalSvc, err := serverApp.NewEvaluationService(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewEvaluationService() error = %v", err)
+	}
+	router := NewEvalRouter(EvalRouterDeps{Evaluation: evalSvc}, EvalRouterConfig{
+		Environment: "development",
+		IngestToken: "secret",
+	})
+
+	post := func(token, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/foundation-runs", strings.NewReader(body))
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	if rec := post("wrong", `{"run_id":"run-1"}`); rec.Code != http.StatusUnauthorized {
+		t.Fatalf("bad token status = %d, want %d", rec.Code, http.StatusUnauthorized)
+	}
+	if rec := post("secret", `{"overall_score":1}`); rec.Code != http.StatusBadRequest {
+		t.Fatalf("missing run_id status = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+	body := `{"run_id":"run-1","generated_at":"2026-03-01T00:00:00Z","overall_score":88.5,"implicit":{"pass_at_1_rate":0.7,"pass_at_5_rate":0.95}}`
+	if rec := post("secret", body); rec.Code != http.StatusCreated {
+		t.Fatalf("ingest status = %d, want %d: %s", rec.Code, http.StatusCreated, rec.Body.String())
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/foundation-runs", nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("list status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	var listed struct {
+		Runs []struct {
+			RunID        string  `json:"run_id"`
+			OverallScore float64 `json:"overall_score"`
+			PassAt1Rate  float64 `json:"pass_at_1_rate"`
+			PassAt5Rate  float64 `json:"pass_at_5_rate"`
+		} `json:"runs"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &listed); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if len(listed.Runs) != 1 || listed.Runs[0].RunID != "run-1" || listed.Runs[0].OverallScore != 88.5 || listed.Runs[0].PassAt5Rate != 0.95 {
+		t.Fatalf("runs = %+v, want run-1 summary", listed.Runs)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/api/foundation-runs/missing", nil)
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("missing run status = %d, want %d", rec.Code, http.StatusNotFound)
+	}
+}
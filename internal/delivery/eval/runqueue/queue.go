@@ -0,0 +1,460 @@
+// Package runqueue queues eval-server evaluation runs and executes them on a
+// bounded worker pool, persisting status transitions and per-run logs.
+package runqueue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	agent_eval "alex/evaluation/agent_eval"
+	"alex/internal/shared/async"
+	id "alex/internal/shared/utils/id"
+)
+
+// Status is the lifecycle state of a queued run.
+type Status string
+
+const (
+	StatusQueued    Status = "queued"
+	StatusRunning   Status = "running"
+	StatusCompleted Status = "completed"
+	StatusFailed    Status = "failed"
+	StatusCancelled Status = "cancelled"
+)
+
+// Terminal reports whether no further transitions follow s.
+func (s Status) Terminal() bool {
+	return s == StatusCompleted || s == StatusFailed || s == StatusCancelled
+}
+
+const (
+	defaultWorkers   = 1
+	defaultMaxQueued = 100
+	maxLogBytes      = 4 << 20
+	subscriberBuffer = 8
+)
+
+var (
+	ErrNotFound  = errors.New("run not found")
+	ErrQueueFull = errors.New("run queue is full")
+	ErrFinished  = errors.New("run already finished")
+	ErrClosed    = errors.New("run queue is closed")
+)
+
+// Run is the persisted record of one queued evaluation.
+type Run struct {
+	ID         string                       `json:"id"`
+	Status     Status                       `json:"status"`
+	Config     *agent_eval.EvaluationConfig `json:"config,omitempty"`
+	Error      string                       `json:"error,omitempty"`
+	CreatedAt  time.Time                    `json:"created_at"`
+	StartedAt  *time.Time                   `json:"started_at,omitempty"`
+	FinishedAt *time.Time                   `json:"finished_at,omitempty"`
+}
+
+// Executor runs one evaluation to completion. Cancelling ctx must stop it.
+type Executor interface {
+	Execute(ctx context.Context, jobID string, config *agent_eval.EvaluationConfig) *agent_eval.EvaluationJob
+}
+
+// Config configures a Queue.
+type Config struct {
+	// Dir holds <run_id>.json status records and <run_id>.log run logs.
+	Dir string
+	// Workers bounds concurrently executing runs. Defaults to 1.
+	Workers int
+	// MaxQueued bounds runs waiting for a worker. Defaults to 100.
+	MaxQueued int
+}
+
+// Queue accepts evaluation runs and executes them on a fixed worker pool.
+type Queue struct {
+	exec    Executor
+	dir     string
+	workers int
+	pending chan string
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	mu            sync.Mutex
+	runs          map[string]*Run
+	running       map[string]context.CancelFunc
+	userCancelled map[string]bool
+	subscribers   map[string]map[chan Run]struct{}
+	started       bool
+	closed        bool
+}
+
+// New loads persisted runs from cfg.Dir. Runs left queued or running by a
+// previous process are marked failed, since their work was lost.
+func New(exec Executor, cfg Config) (*Queue, error) {
+	if exec == nil {
+		return nil, fmt.Errorf("run queue executor is required")
+	}
+	if strings.TrimSpace(cfg.Dir) == "" {
+		return nil, fmt.Errorf("run queue dir is required")
+	}
+	if err := os.MkdirAll(cfg.Dir, 0o755); err != nil {
+		return nil, fmt.Errorf("create run queue dir: %w", err)
+	}
+	if cfg.Workers <= 0 {
+		cfg.Workers = defaultWorkers
+	}
+	if cfg.MaxQueued <= 0 {
+		cfg.MaxQueued = defaultMaxQueued
+	}
+	q := &Queue{
+		exec:          exec,
+		dir:           cfg.Dir,
+		workers:       cfg.Workers,
+		pending:       make(chan string, cfg.MaxQueued),
+		runs:          make(map[string]*Run),
+		running:       make(map[string]context.CancelFunc),
+		userCancelled: make(map[string]bool),
+		subscribers:   make(map[string]map[chan Run]struct{}),
+	}
+	q.ctx, q.cancel = context.WithCancel(context.Background())
+	if err := q.load(); err != nil {
+		return nil, err
+	}
+	return q, nil
+}
+
+// Start launches the worker pool. It is a no-op after the first call.
+func (q *Queue) Start() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.started || q.closed {
+		return
+	}
+	q.started = true
+	for i := 0; i < q.workers; i++ {
+		q.wg.Add(1)
+		async.Go(panicLogger{}, "eval-runqueue.worker", func() {
+			defer q.wg.Done()
+			q.work()
+		})
+	}
+}
+
+// Workers returns the worker pool size.
+func (q *Queue) Workers() int {
+	return q.workers
+}
+
+// Submit queues a prepared evaluation and returns its record immediately.
+func (q *Queue) Submit(config *agent_eval.EvaluationConfig) (Run, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closed {
+		return Run{}, ErrClosed
+	}
+	run := &Run{
+		ID:        "eval_" + id.NewKSUID(),
+		Status:    StatusQueued,
+		Config:    config,
+		CreatedAt: time.Now(),
+	}
+	select {
+	case q.pending <- run.ID:
+	default:
+		return Run{}, ErrQueueFull
+	}
+	q.runs[run.ID] = run
+	q.persistLocked(run)
+	q.appendLog(run.ID, "Run queued (dataset=%s)", config.DatasetPath)
+	return *run, nil
+}
+
+// Get returns one run.
+func (q *Queue) Get(runID string) (Run, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	run, ok := q.runs[runID]
+	if !ok {
+		return Run{}, ErrNotFound
+	}
+	return *run, nil
+}
+
+// List returns all runs, newest first.
+func (q *Queue) List() []Run {
+	q.mu.Lock()
+	out := make([]Run, 0, len(q.runs))
+	for _, run := range q.runs {
+		out = append(out, *run)
+	}
+	q.mu.Unlock()
+	sort.Slice(out, func(i, j int) bool { return out[i].CreatedAt.After(out[j].CreatedAt) })
+	return out
+}
+
+// Cancel cancels a queued run immediately, or cancels a running run's
+// context; the worker records the cancelled status once execution stops.
+func (q *Queue) Cancel(runID string) (Run, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	run, ok := q.runs[runID]
+	if !ok {
+		return Run{}, ErrNotFound
+	}
+	if run.Status.Terminal() {
+		return *run, ErrFinished
+	}
+	q.userCancelled[runID] = true
+	if cancel, ok := q.running[runID]; ok {
+		q.appendLog(runID, "Cancellation requested")
+		cancel()
+		return *run, nil
+	}
+	now := time.Now()
+	run.Status = StatusCancelled
+	run.FinishedAt = &now
+	q.persistLocked(run)
+	q.appendLog(runID, "Run cancelled before start")
+	q.publishLocked(run)
+	return *run, nil
+}
+
+// Subscribe returns the run's current state and a channel receiving every
+// later transition. The channel closes after a terminal status; call the
+// returned func to unsubscribe early.
+func (q *Queue) Subscribe(runID string) (Run, <-chan Run, func(), error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	run, ok := q.runs[runID]
+	if !ok {
+		return Run{}, nil, nil, ErrNotFound
+	}
+	ch := make(chan Run, subscriberBuffer)
+	if run.Status.Terminal() {
+		close(ch)
+		return *run, ch, func() {}, nil
+	}
+	if q.subscribers[runID] == nil {
+		q.subscribers[runID] = make(map[chan Run]struct{})
+	}
+	q.subscribers[runID][ch] = struct{}{}
+	unsubscribe := func() {
+		q.mu.Lock()
+		defer q.mu.Unlock()
+		if subs, ok := q.subscribers[runID]; ok {
+			if _, ok := subs[ch]; ok {
+				delete(subs, ch)
+				close(ch)
+			}
+		}
+	}
+	return *run, ch, unsubscribe, nil
+}
+
+// ReadLog returns the run's captured log, truncated to the most recent 4 MiB.
+func (q *Queue) ReadLog(runID string) ([]byte, error) {
+	if _, err := q.Get(runID); err != nil {
+		return nil, err
+	}
+	f, err := os.Open(q.logPath(runID))
+	if errors.Is(err, os.ErrNotExist) {
+		return []byte{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if info.Size() > maxLogBytes {
+		if _, err := f.Seek(info.Size()-maxLogBytes, io.SeekStart); err != nil {
+			return nil, err
+		}
+	}
+	return io.ReadAll(f)
+}
+
+// Close stops accepting runs, cancels running ones and waits for workers up
+// to ctx's deadline. Interrupted runs are recorded as failed.
+func (q *Queue) Close(ctx context.Context) error {
+	q.mu.Lock()
+	q.closed = true
+	q.mu.Unlock()
+	q.cancel()
+	done := make(chan struct{})
+	go func() {
+		q.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (q *Queue) work() {
+	for {
+		select {
+		case <-q.ctx.Done():
+			return
+		case runID := <-q.pending:
+			q.execute(runID)
+		}
+	}
+}
+
+func (q *Queue) execute(runID string) {
+	q.mu.Lock()
+	run, ok := q.runs[runID]
+	if !ok || run.Status != StatusQueued || q.closed {
+		q.mu.Unlock()
+		return
+	}
+	ctx, cancel := context.WithCancel(q.ctx)
+	defer cancel()
+	now := time.Now()
+	run.Status = StatusRunning
+	run.StartedAt = &now
+	q.running[runID] = cancel
+	config := run.Config
+	q.persistLocked(run)
+	q.publishLocked(run)
+	q.mu.Unlock()
+
+	logFile, err := os.OpenFile(q.logPath(runID), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		log.Printf("[eval-server] run %s: open log: %v", runID, err)
+	} else {
+		defer logFile.Close()
+		ctx = agent_eval.WithRunLog(ctx, logFile)
+	}
+	job := q.exec.Execute(ctx, runID, config)
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	delete(q.running, runID)
+	finished := time.Now()
+	run.FinishedAt = &finished
+	run.Status, run.Error = q.outcomeLocked(runID, job)
+	delete(q.userCancelled, runID)
+	q.persistLocked(run)
+	q.appendLog(runID, "Run %s", run.Status)
+	q.publishLocked(run)
+}
+
+func (q *Queue) outcomeLocked(runID string, job *agent_eval.EvaluationJob) (Status, string) {
+	if job == nil {
+		return StatusFailed, "evaluation returned no job"
+	}
+	errMsg := ""
+	if job.Error != nil {
+		errMsg = job.Error.Error()
+	}
+	switch job.Status {
+	case agent_eval.JobStatusCompleted:
+		return StatusCompleted, ""
+	case agent_eval.JobStatusCancelled:
+		if q.userCancelled[runID] {
+			return StatusCancelled, errMsg
+		}
+		return StatusFailed, "interrupted by server shutdown"
+	default:
+		return StatusFailed, errMsg
+	}
+}
+
+func (q *Queue) publishLocked(run *Run) {
+	subs := q.subscribers[run.ID]
+	for ch := range subs {
+		select {
+		case ch <- *run:
+		default:
+			// Slow subscriber: it re-reads the final state when the channel closes.
+		}
+		if run.Status.Terminal() {
+			close(ch)
+		}
+	}
+	if run.Status.Terminal() {
+		delete(q.subscribers, run.ID)
+	}
+}
+
+func (q *Queue) load() error {
+	entries, err := os.ReadDir(q.dir)
+	if err != nil {
+		return fmt.Errorf("read run queue dir: %w", err)
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(q.dir, entry.Name()))
+		if err != nil {
+			return fmt.Errorf("read run record: %w", err)
+		}
+		var run Run
+		if err := json.Unmarshal(data, &run); err != nil || run.ID == "" {
+			log.Printf("[eval-server] skipping unreadable run record %s: %v", entry.Name(), err)
+			continue
+		}
+		if !run.Status.Terminal() {
+			now := time.Now()
+			run.Status = StatusFailed
+			run.Error = "interrupted by server restart"
+			run.FinishedAt = &now
+			q.persistLocked(&run)
+		}
+		q.runs[run.ID] = &run
+	}
+	return nil
+}
+
+func (q *Queue) persistLocked(run *Run) {
+	data, err := json.MarshalIndent(run, "", "  ")
+	if err != nil {
+		log.Printf("[eval-server] run %s: encode record: %v", run.ID, err)
+		return
+	}
+	path := filepath.Join(q.dir, run.ID+".json")
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		log.Printf("[eval-server] run %s: persist record: %v", run.ID, err)
+		return
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		log.Printf("[eval-server] run %s: persist record: %v", run.ID, err)
+	}
+}
+
+func (q *Queue) appendLog(runID, format string, args ...any) {
+	f, err := os.OpenFile(q.logPath(runID), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+	_, _ = fmt.Fprintf(f, "%s %s\n", time.Now().UTC().Format(time.RFC3339), fmt.Sprintf(format, args...))
+}
+
+func (q *Queue) logPath(runID string) string {
+	return filepath.Join(q.dir, runID+".log")
+}
+
+type panicLogger struct{}
+
+func (panicLogger) Error(format string, args ...any) {
+	log.Printf(format, args...)
+}
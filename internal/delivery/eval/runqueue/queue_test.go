@@ -0,0 +1,181 @@
+package runqueue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	agent_eval "alex/evaluation/agent_eval"
+)
+
+// blockingExecutor runs until released or cancelled and tracks concurrency.
+type blockingExecutor struct {
+	mu      sync.Mutex
+	active  int
+	peak    int
+	started chan string
+	release chan struct{}
+}
+
+func newBlockingExecutor() *blockingExecutor {
+	return &blockingExecutor{started: make(chan string, 16), release: make(chan struct{})}
+}
+
+func (e *blockingExecutor) Execute(ctx context.Context, jobID string, _ *agent_eval.EvaluationConfig) *agent_eval.EvaluationJob {
+	e.mu.Lock()
+	e.active++
+	if e.active > e.peak {
+		e.peak = e.active
+	}
+	e.mu.Unlock()
+	defer func() {
+		e.mu.Lock()
+		e.active--
+		e.mu.Unlock()
+	}()
+
+	e.started <- jobID
+	select {
+	case <-ctx.Done():
+		return &agent_eval.EvaluationJob{ID: jobID, Status: agent_eval.JobStatusCancelled, Error: ctx.Err()}
+	case <-e.release:
+		return &agent_eval.EvaluationJob{ID: jobID, Status: agent_eval.JobStatusCompleted}
+	}
+}
+
+func waitStatus(t *testing.T, updates <-chan Run, want Status) {
+	t.Helper()
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case run, ok := <-updates:
+			if !ok {
+				t.Fatalf("updates closed before %s", want)
+			}
+			if run.Status == want {
+				return
+			}
+		case <-timeout:
+			t.Fatalf("timed out waiting for %s", want)
+		}
+	}
+}
+
+func TestQueueRunsWithinWorkerLimit(t *testing.T) {
+	exec := newBlockingExecutor()
+	q, err := New(exec, Config{Dir: t.TempDir(), Workers: 1})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	q.Start()
+	defer q.Close(context.Background())
+
+	first, err := q.Submit(&agent_eval.EvaluationConfig{DatasetPath: "a.json"})
+	if err != nil || first.Status != StatusQueued {
+		t.Fatalf("Submit() = %+v, %v", first, err)
+	}
+	second, _ := q.Submit(&agent_eval.EvaluationConfig{DatasetPath: "b.json"})
+	_, updates, unsubscribe, err := q.Subscribe(second.ID)
+	if err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+	defer unsubscribe()
+
+	if got := <-exec.started; got != first.ID {
+		t.Fatalf("expected first run to start, got %s", got)
+	}
+	if run, _ := q.Get(second.ID); run.Status != StatusQueued {
+		t.Fatalf("second run should wait for the worker, got %s", run.Status)
+	}
+	exec.release <- struct{}{}
+	<-exec.started
+	waitStatus(t, updates, StatusRunning)
+	exec.release <- struct{}{}
+	waitStatus(t, updates, StatusCompleted)
+
+	if exec.peak != 1 {
+		t.Fatalf("expected at most one concurrent run, got %d", exec.peak)
+	}
+	if run, _ := q.Get(first.ID); run.Status != StatusCompleted || run.StartedAt == nil || run.FinishedAt == nil {
+		t.Fatalf("unexpected first run %+v", run)
+	}
+}
+
+func TestQueueCancelQueuedAndRunning(t *testing.T) {
+	exec := newBlockingExecutor()
+	dir := t.TempDir()
+	q, err := New(exec, Config{Dir: dir, Workers: 1})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	q.Start()
+	defer q.Close(context.Background())
+
+	running, _ := q.Submit(&agent_eval.EvaluationConfig{})
+	queued, _ := q.Submit(&agent_eval.EvaluationConfig{})
+	<-exec.started
+
+	if run, err := q.Cancel(queued.ID); err != nil || run.Status != StatusCancelled {
+		t.Fatalf("Cancel(queued) = %+v, %v", run, err)
+	}
+	_, updates, unsubscribe, _ := q.Subscribe(running.ID)
+	defer unsubscribe()
+	if _, err := q.Cancel(running.ID); err != nil {
+		t.Fatalf("Cancel(running) error = %v", err)
+	}
+	waitStatus(t, updates, StatusCancelled)
+
+	if _, err := q.Cancel(running.ID); !errors.Is(err, ErrFinished) {
+		t.Fatalf("expected ErrFinished, got %v", err)
+	}
+	if _, err := q.Cancel("missing"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+
+	logData, err := q.ReadLog(running.ID)
+	if err != nil || !strings.Contains(string(logData), "Cancellation requested") || !strings.Contains(string(logData), "Run cancelled") {
+		t.Fatalf("unexpected log %q err=%v", logData, err)
+	}
+	var persisted Run
+	data, _ := os.ReadFile(filepath.Join(dir, running.ID+".json"))
+	if err := json.Unmarshal(data, &persisted); err != nil || persisted.Status != StatusCancelled {
+		t.Fatalf("unexpected persisted record %s err=%v", data, err)
+	}
+}
+
+func TestQueueMarksInterruptedRunsFailedOnLoad(t *testing.T) {
+	dir := t.TempDir()
+	q, err := New(newBlockingExecutor(), Config{Dir: dir})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	run, _ := q.Submit(&agent_eval.EvaluationConfig{})
+
+	reloaded, err := New(newBlockingExecutor(), Config{Dir: dir})
+	if err != nil {
+		t.Fatalf("New() reload error = %v", err)
+	}
+	got, err := reloaded.Get(run.ID)
+	if err != nil || got.Status != StatusFailed || got.Error != "interrupted by server restart" {
+		t.Fatalf("unexpected reloaded run %+v err=%v", got, err)
+	}
+}
+
+func TestQueueRejectsWhenFull(t *testing.T) {
+	q, err := New(newBlockingExecutor(), Config{Dir: t.TempDir(), MaxQueued: 1})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if _, err := q.Submit(&agent_eval.EvaluationConfig{}); err != nil {
+		t.Fatalf("Submit() error = %v", err)
+	}
+	if _, err := q.Submit(&agent_eval.EvaluationConfig{}); !errors.Is(err, ErrQueueFull) {
+		t.Fatalf("expected ErrQueueFull, got %v", err)
+	}
+}
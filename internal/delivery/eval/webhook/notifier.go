@@ -0,0 +1,402 @@
+// Package webhook delivers signed eval-server notifications to external
+// endpoints (Slack/Lark relays, CI hooks) when evaluation runs finish.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"alex/internal/shared/async"
+	id "alex/internal/shared/utils/id"
+)
+
+// Event names carried in the payload and the X-Eval-Event header.
+const (
+	EventCompleted = "evaluation.completed"
+	EventFailed    = "evaluation.failed"
+	EventTest      = "webhook.test"
+)
+
+// Request headers set on every delivery. SignatureHeader carries
+// "sha256=<hex HMAC-SHA256 of the body>" when the target has a secret.
+const (
+	SignatureHeader = "X-Eval-Signature"
+	EventHeader     = "X-Eval-Event"
+	DeliveryHeader  = "X-Eval-Delivery"
+)
+
+// Delivery statuses.
+const (
+	StatusPending   = "pending"
+	StatusDelivered = "delivered"
+	StatusFailed    = "failed"
+)
+
+const (
+	defaultMaxAttempts    = 4
+	defaultInitialBackoff = 2 * time.Second
+	defaultRequestTimeout = 10 * time.Second
+	defaultHistoryLimit   = 200
+	maxErrorBodyBytes     = 512
+)
+
+// ErrNoTargets is returned by SendTest when no target matches.
+var ErrNoTargets = errors.New("no webhook targets configured")
+
+// Target is one webhook endpoint.
+type Target struct {
+	Name   string
+	URL    string
+	Secret string
+	// Events filters which events are sent: "completed", "failed", or the
+	// full event names. Empty means all events.
+	Events []string
+}
+
+func (t Target) wants(event string) bool {
+	if event == EventTest || len(t.Events) == 0 {
+		return true
+	}
+	short := strings.TrimPrefix(event, "evaluation.")
+	for _, want := range t.Events {
+		want = strings.ToLower(strings.TrimSpace(want))
+		if want == event || want == short || want == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+// Payload is the JSON body posted to targets.
+type Payload struct {
+	Event           string    `json:"event"`
+	RunID           string    `json:"run_id"`
+	Suite           string    `json:"suite"`
+	Status          string    `json:"status"`
+	PassRate        float64   `json:"pass_rate"`
+	Passed          int       `json:"passed"`
+	Total           int       `json:"total"`
+	DurationSeconds float64   `json:"duration_seconds"`
+	Link            string    `json:"link,omitempty"`
+	Error           string    `json:"error,omitempty"`
+	StartedAt       time.Time `json:"started_at"`
+	FinishedAt      time.Time `json:"finished_at"`
+}
+
+// Delivery records the outcome of sending one event to one target.
+type Delivery struct {
+	ID         string    `json:"id"`
+	Target     string    `json:"target"`
+	URL        string    `json:"url"`
+	Event      string    `json:"event"`
+	RunID      string    `json:"run_id"`
+	Status     string    `json:"status"`
+	Attempts   int       `json:"attempts"`
+	StatusCode int       `json:"status_code,omitempty"`
+	Error      string    `json:"error,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// Config configures a Notifier. Zero values fall back to defaults.
+type Config struct {
+	Targets []Target
+	// MaxAttempts bounds delivery attempts per event, including the first.
+	MaxAttempts int
+	// InitialBackoff is the wait before the first retry; it doubles per retry.
+	InitialBackoff time.Duration
+	RequestTimeout time.Duration
+	// HistoryLimit caps the in-memory delivery log.
+	HistoryLimit int
+	Client       *http.Client
+}
+
+// Notifier posts signed payloads to the configured targets and keeps a
+// bounded in-memory log of delivery outcomes.
+type Notifier struct {
+	targets        []Target
+	maxAttempts    int
+	initialBackoff time.Duration
+	historyLimit   int
+	client         *http.Client
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	mu         sync.Mutex
+	deliveries []*Delivery
+}
+
+// NewNotifier builds a notifier. Targets without a URL are dropped.
+func NewNotifier(cfg Config) *Notifier {
+	n := &Notifier{
+		maxAttempts:    cfg.MaxAttempts,
+		initialBackoff: cfg.InitialBackoff,
+		historyLimit:   cfg.HistoryLimit,
+		client:         cfg.Client,
+	}
+	for i, target := range cfg.Targets {
+		target.URL = strings.TrimSpace(target.URL)
+		if target.URL == "" {
+			continue
+		}
+		if strings.TrimSpace(target.Name) == "" {
+			target.Name = fmt.Sprintf("webhook-%d", i+1)
+		}
+		n.targets = append(n.targets, target)
+	}
+	if n.maxAttempts <= 0 {
+		n.maxAttempts = defaultMaxAttempts
+	}
+	if n.initialBackoff <= 0 {
+		n.initialBackoff = defaultInitialBackoff
+	}
+	if n.historyLimit <= 0 {
+		n.historyLimit = defaultHistoryLimit
+	}
+	if n.client == nil {
+		timeout := cfg.RequestTimeout
+		if timeout <= 0 {
+			timeout = defaultRequestTimeout
+		}
+		n.client = &http.Client{Timeout: timeout}
+	}
+	n.ctx, n.cancel = context.WithCancel(context.Background())
+	return n
+}
+
+// TargetCount returns the number of usable targets.
+func (n *Notifier) TargetCount() int {
+	if n == nil {
+		return 0
+	}
+	return len(n.targets)
+}
+
+// Notify sends payload asynchronously to every target subscribed to its
+// event, retrying failures with exponential backoff.
+func (n *Notifier) Notify(payload Payload) {
+	if n == nil {
+		return
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		log.Printf("[eval-server] webhook: encode payload for %s: %v", payload.RunID, err)
+		return
+	}
+	for _, target := range n.targets {
+		if !target.wants(payload.Event) {
+			continue
+		}
+		target := target
+		delivery := n.record(target, payload)
+		n.wg.Add(1)
+		async.Go(panicLogger{}, "eval-webhook.deliver", func() {
+			defer n.wg.Done()
+			n.deliver(context.Background(), target, delivery, body, n.maxAttempts)
+		})
+	}
+}
+
+// SendTest synchronously sends a sample payload to the named target, or to
+// all targets when name is empty, with a single attempt each.
+func (n *Notifier) SendTest(ctx context.Context, name string) ([]Delivery, error) {
+	if n == nil || len(n.targets) == 0 {
+		return nil, ErrNoTargets
+	}
+	payload := SamplePayload()
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("encode payload: %w", err)
+	}
+	var results []Delivery
+	for _, target := range n.targets {
+		if name != "" && target.Name != name {
+			continue
+		}
+		delivery := n.record(target, payload)
+		n.deliver(ctx, target, delivery, body, 1)
+		results = append(results, n.snapshot(delivery))
+	}
+	if len(results) == 0 {
+		return nil, fmt.Errorf("webhook target %q not found: %w", name, ErrNoTargets)
+	}
+	return results, nil
+}
+
+// Deliveries returns up to limit recent deliveries, newest first. A
+// non-positive limit returns the full log.
+func (n *Notifier) Deliveries(limit int) []Delivery {
+	if n == nil {
+		return []Delivery{}
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if limit <= 0 || limit > len(n.deliveries) {
+		limit = len(n.deliveries)
+	}
+	out := make([]Delivery, 0, limit)
+	for i := len(n.deliveries) - 1; i >= 0 && len(out) < limit; i-- {
+		out = append(out, *n.deliveries[i])
+	}
+	return out
+}
+
+// Close abandons pending retries and waits for in-flight attempts, up to
+// ctx's deadline.
+func (n *Notifier) Close(ctx context.Context) error {
+	if n == nil {
+		return nil
+	}
+	n.cancel()
+	done := make(chan struct{})
+	go func() {
+		n.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Sign returns the SignatureHeader value for body.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// SamplePayload returns the payload sent by SendTest.
+func SamplePayload() Payload {
+	now := time.Now().UTC()
+	return Payload{
+		Event:           EventTest,
+		RunID:           "eval_test",
+		Suite:           "sample",
+		Status:          "completed",
+		PassRate:        0.8,
+		Passed:          8,
+		Total:           10,
+		DurationSeconds: 90,
+		StartedAt:       now.Add(-90 * time.Second),
+		FinishedAt:      now,
+	}
+}
+
+func (n *Notifier) deliver(ctx context.Context, target Target, delivery *Delivery, body []byte, maxAttempts int) {
+	backoff := n.initialBackoff
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		code, err := n.post(ctx, target, delivery.ID, delivery.Event, body)
+		retryable := err != nil || code >= 500 || code == http.StatusTooManyRequests
+		n.update(delivery, func(d *Delivery) {
+			d.Attempts = attempt
+			d.StatusCode = code
+			d.Error = ""
+			switch {
+			case err != nil:
+				d.Error = err.Error()
+			case code < 200 || code >= 300:
+				d.Error = fmt.Sprintf("unexpected status %d", code)
+			default:
+				d.Status = StatusDelivered
+			}
+			if d.Status != StatusDelivered && (!retryable || attempt == maxAttempts) {
+				d.Status = StatusFailed
+			}
+		})
+		if err == nil && code >= 200 && code < 300 {
+			return
+		}
+		if !retryable || attempt == maxAttempts {
+			log.Printf("[eval-server] webhook %s: delivery %s for %s failed after %d attempt(s): %s",
+				target.Name, delivery.ID, delivery.RunID, attempt, n.snapshot(delivery).Error)
+			return
+		}
+		select {
+		case <-n.ctx.Done():
+			n.update(delivery, func(d *Delivery) {
+				d.Status = StatusFailed
+				d.Error = "abandoned at shutdown: " + d.Error
+			})
+			return
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+func (n *Notifier) post(ctx context.Context, target Target, deliveryID, event string, body []byte) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventHeader, event)
+	req.Header.Set(DeliveryHeader, deliveryID)
+	if target.Secret != "" {
+		req.Header.Set(SignatureHeader, Sign(target.Secret, body))
+	}
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxErrorBodyBytes))
+	return resp.StatusCode, nil
+}
+
+func (n *Notifier) record(target Target, payload Payload) *Delivery {
+	now := time.Now()
+	delivery := &Delivery{
+		ID:        id.NewKSUID(),
+		Target:    target.Name,
+		URL:       target.URL,
+		Event:     payload.Event,
+		RunID:     payload.RunID,
+		Status:    StatusPending,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.deliveries = append(n.deliveries, delivery)
+	if overflow := len(n.deliveries) - n.historyLimit; overflow > 0 {
+		n.deliveries = append([]*Delivery(nil), n.deliveries[overflow:]...)
+	}
+	return delivery
+}
+
+func (n *Notifier) update(delivery *Delivery, fn func(*Delivery)) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	fn(delivery)
+	delivery.UpdatedAt = time.Now()
+}
+
+func (n *Notifier) snapshot(delivery *Delivery) Delivery {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return *delivery
+}
+
+type panicLogger struct{}
+
+func (panicLogger) Error(format string, args ...any) {
+	log.Printf(format, args...)
+}
@@ -0,0 +1,162 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	agent_eval "alex/evaluation/agent_eval"
+	"alex/evaluation/swe_bench"
+)
+
+func waitForDelivery(t *testing.T, n *Notifier) Delivery {
+	t.Helper()
+	n.wg.Wait()
+	deliveries := n.Deliveries(0)
+	if len(deliveries) != 1 {
+		t.Fatalf("expected one delivery, got %d", len(deliveries))
+	}
+	return deliveries[0]
+}
+
+func TestNotifierSignsAndRetries(t *testing.T) {
+	var calls atomic.Int32
+	var gotBody []byte
+	var gotSig, gotEvent string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		gotBody, _ = io.ReadAll(r.Body)
+		gotSig = r.Header.Get(SignatureHeader)
+		gotEvent = r.Header.Get(EventHeader)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	n := NewNotifier(Config{
+		Targets:        []Target{{Name: "relay", URL: srv.URL, Secret: "s3cret"}},
+		InitialBackoff: time.Millisecond,
+	})
+	n.Notify(Payload{Event: EventCompleted, RunID: "eval_1", PassRate: 0.5})
+
+	delivery := waitForDelivery(t, n)
+	if delivery.Status != StatusDelivered || delivery.Attempts != 2 || delivery.StatusCode != http.StatusNoContent {
+		t.Fatalf("unexpected delivery %+v", delivery)
+	}
+	if gotSig != Sign("s3cret", gotBody) || gotEvent != EventCompleted {
+		t.Fatalf("unexpected headers sig=%q event=%q", gotSig, gotEvent)
+	}
+	var payload Payload
+	if err := json.Unmarshal(gotBody, &payload); err != nil || payload.RunID != "eval_1" {
+		t.Fatalf("unexpected body %s err=%v", gotBody, err)
+	}
+}
+
+func TestNotifierStopsOnClientErrorAndFiltersEvents(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer srv.Close()
+
+	n := NewNotifier(Config{
+		Targets: []Target{
+			{Name: "failures-only", URL: srv.URL, Events: []string{"failed"}},
+			{Name: "no-url"},
+		},
+		InitialBackoff: time.Millisecond,
+	})
+	if n.TargetCount() != 1 {
+		t.Fatalf("expected targets without URL to be dropped, got %d", n.TargetCount())
+	}
+	n.Notify(Payload{Event: EventCompleted, RunID: "eval_ok"})
+	n.Notify(Payload{Event: EventFailed, RunID: "eval_bad"})
+
+	delivery := waitForDelivery(t, n)
+	if delivery.RunID != "eval_bad" || delivery.Status != StatusFailed || delivery.Attempts != 1 {
+		t.Fatalf("unexpected delivery %+v", delivery)
+	}
+	if calls.Load() != 1 {
+		t.Fatalf("expected 4xx not to be retried, got %d calls", calls.Load())
+	}
+}
+
+func TestNotifierCloseAbandonsRetries(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	n := NewNotifier(Config{Targets: []Target{{URL: srv.URL}}, InitialBackoff: time.Hour})
+	n.Notify(Payload{Event: EventCompleted, RunID: "eval_1"})
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := n.Close(ctx); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if got := n.Deliveries(0)[0]; got.Status != StatusFailed || got.Attempts != 1 {
+		t.Fatalf("unexpected delivery %+v", got)
+	}
+}
+
+func TestNotifierSendTestAndHistoryLimit(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	n := NewNotifier(Config{Targets: []Target{{Name: "relay", URL: srv.URL}}, HistoryLimit: 2})
+	if _, err := n.SendTest(context.Background(), "missing"); !errors.Is(err, ErrNoTargets) {
+		t.Fatalf("expected ErrNoTargets for unknown target, got %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		results, err := n.SendTest(context.Background(), "")
+		if err != nil || len(results) != 1 || results[0].Status != StatusDelivered || results[0].Event != EventTest {
+			t.Fatalf("unexpected test result %+v err=%v", results, err)
+		}
+	}
+	if got := n.Deliveries(0); len(got) != 2 {
+		t.Fatalf("expected history capped at 2, got %d", len(got))
+	}
+	if got := n.Deliveries(1); len(got) != 1 {
+		t.Fatalf("expected limit to apply, got %d", len(got))
+	}
+}
+
+func TestPayloadFromJob(t *testing.T) {
+	start := time.Date(2026, 3, 1, 2, 0, 0, 0, time.UTC)
+	job := &agent_eval.EvaluationJob{
+		ID:        "eval_7",
+		Status:    agent_eval.JobStatusFailed,
+		Config:    &agent_eval.EvaluationConfig{DatasetType: "swe_bench", DatasetPath: "/data/nightly.json"},
+		StartTime: start,
+		EndTime:   start.Add(90 * time.Second),
+		Error:     errors.New("boom"),
+		Results: &agent_eval.EvaluationResults{Results: []swe_bench.WorkerResult{
+			{Status: swe_bench.StatusCompleted},
+			{Status: swe_bench.StatusFailed},
+			{Status: swe_bench.StatusCompleted},
+			{Status: swe_bench.StatusTimeout},
+		}},
+	}
+
+	payload := PayloadFromJob(job, "https://eval.example/")
+	if payload.Event != EventFailed || payload.Suite != "swe_bench/nightly" || payload.Error != "boom" {
+		t.Fatalf("unexpected payload %+v", payload)
+	}
+	if payload.PassRate != 0.5 || payload.Passed != 2 || payload.Total != 4 || payload.DurationSeconds != 90 {
+		t.Fatalf("unexpected stats %+v", payload)
+	}
+	if payload.Link != "https://eval.example/api/evaluations/eval_7" {
+		t.Fatalf("unexpected link %q", payload.Link)
+	}
+}
@@ -0,0 +1,61 @@
+package webhook
+
+import (
+	"path/filepath"
+	"strings"
+
+	agent_eval "alex/evaluation/agent_eval"
+	"alex/evaluation/swe_bench"
+)
+
+// PayloadFromJob builds the notification for a finished job. baseURL is the
+// eval-server's public address; when set, Link points at the job's API
+// resource.
+func PayloadFromJob(job *agent_eval.EvaluationJob, baseURL string) Payload {
+	payload := Payload{
+		Event:      EventCompleted,
+		RunID:      job.ID,
+		Status:     string(job.Status),
+		StartedAt:  job.StartTime,
+		FinishedAt: job.EndTime,
+	}
+	if job.Status != agent_eval.JobStatusCompleted {
+		payload.Event = EventFailed
+	}
+	if job.Error != nil {
+		payload.Error = job.Error.Error()
+	}
+	if !job.EndTime.IsZero() && !job.StartTime.IsZero() {
+		payload.DurationSeconds = job.EndTime.Sub(job.StartTime).Seconds()
+	}
+	payload.Suite = suiteName(job.Config)
+	if job.Results != nil {
+		payload.Total = len(job.Results.Results)
+		for _, result := range job.Results.Results {
+			if result.Status == swe_bench.StatusCompleted {
+				payload.Passed++
+			}
+		}
+		if payload.Total > 0 {
+			payload.PassRate = float64(payload.Passed) / float64(payload.Total)
+		}
+	}
+	if base := strings.TrimRight(strings.TrimSpace(baseURL), "/"); base != "" {
+		payload.Link = base + "/api/evaluations/" + job.ID
+	}
+	return payload
+}
+
+func suiteName(cfg *agent_eval.EvaluationConfig) string {
+	if cfg == nil {
+		return ""
+	}
+	if cfg.DatasetPath == "" {
+		return cfg.DatasetType
+	}
+	name := strings.TrimSuffix(filepath.Base(cfg.DatasetPath), filepath.Ext(cfg.DatasetPath))
+	if cfg.DatasetType == "" {
+		return name
+	}
+	return cfg.DatasetType + "/" + name
+}
@@ -17,6 +17,7 @@ var toolDisplayNames = map[string]string{
 	"write_file":      "file.write",
 	"shell_exec":      "shell.exec",
 	"web_search":      "web.search",
+	"web_fetch":       "web.fetch",
 }
 
 func truncateInlinePreview(preview string, limit int) string {
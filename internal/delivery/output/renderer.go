@@ -79,6 +79,7 @@ func CategorizeToolName(toolName string) types.ToolCategory {
 		"replace_in_file": types.CategoryFile,
 		"shell_exec":      types.CategoryShell,
 		"web_search":      types.CategoryWeb,
+		"web_fetch":       types.CategoryWeb,
 		"ask_user":        types.CategoryTask,
 	}
 	if cat, ok := categories[toolName]; ok {
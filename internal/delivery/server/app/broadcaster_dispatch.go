@@ -67,6 +67,18 @@ func isGlobalSessionID(sessionID string) bool {
 	return sessionID == globalHighVolumeSessionID
 }
 
+// NewGlobalDiagnosticEnvelope builds a diagnostic envelope that OnEvent fans
+// out to every connected session instead of a single one.
+func NewGlobalDiagnosticEnvelope(eventType string, payload map[string]any) *domain.WorkflowEventEnvelope {
+	return &domain.WorkflowEventEnvelope{
+		BaseEvent: domain.NewBaseEvent(agentports.LevelCore, globalHighVolumeSessionID, "", "", time.Now()),
+		Version:   1,
+		Event:     eventType,
+		NodeKind:  "diagnostic",
+		Payload:   payload,
+	}
+}
+
 // broadcastToClients sends event to all clients in the list
 func (b *EventBroadcaster) broadcastToClients(sessionID string, clients []chan agentports.AgentEvent, event agentports.AgentEvent) {
 	for i, ch := range clients {
@@ -18,6 +18,8 @@ type EvaluationService struct {
 	defaultConfig agent_eval.EvaluationConfig
 	logger        *utils.Logger
 	baseOutputDir string
+
+	foundationRuns *agent_eval.FoundationRunStore
 }
 
 func canonicalizePath(path string) string {
@@ -74,6 +76,8 @@ func NewEvaluationService(baseOutputDir string) (*EvaluationService, error) {
 		defaultConfig: defaultConfig,
 		logger:        utils.NewComponentLogger("EvaluationService"),
 		baseOutputDir: baseOutputDir,
+
+		foundationRuns: agent_eval.NewFoundationRunStore(filepath.Join(baseOutputDir, "foundation_runs")),
 	}
 
 	_ = svc.manager.HydrateFromStore()
@@ -83,6 +87,26 @@ func NewEvaluationService(baseOutputDir string) (*EvaluationService, error) {
 
 // Start kicks off an evaluation job based on the provided options.
 func (s *EvaluationService) Start(ctx context.Context, options *agent_eval.EvaluationOptions) (*agent_eval.EvaluationJob, error) {
+	config, err := s.Prepare(options)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Scheduling evaluation: dataset=%s limit=%d workers=%d", config.DatasetPath, config.InstanceLimit, config.MaxWorkers)
+	// Detach from request context cancellation; evaluations are async background jobs.
+	return s.manager.ScheduleEvaluation(context.WithoutCancel(ctx), config)
+}
+
+// Execute runs a prepared evaluation inline under jobID and returns the final
+// job snapshot. Cancelling ctx cancels the run.
+func (s *EvaluationService) Execute(ctx context.Context, jobID string, config *agent_eval.EvaluationConfig) *agent_eval.EvaluationJob {
+	s.logger.Info("Running evaluation %s: dataset=%s limit=%d workers=%d", jobID, config.DatasetPath, config.InstanceLimit, config.MaxWorkers)
+	return s.manager.RunEvaluation(ctx, jobID, config)
+}
+
+// Prepare merges options over the service defaults, validates them and
+// creates the output directory, returning the config a run will use.
+func (s *EvaluationService) Prepare(options *agent_eval.EvaluationOptions) (*agent_eval.EvaluationConfig, error) {
 	_ = s.manager.HydrateFromStore()
 
 	if options == nil {
@@ -108,10 +132,13 @@ func (s *EvaluationService) Start(ctx context.Context, options *agent_eval.Evalu
 	if err := os.MkdirAll(config.OutputDir, 0o755); err != nil {
 		return nil, fmt.Errorf("failed to create output dir %s: %w", config.OutputDir, err)
 	}
+	return config, nil
+}
 
-	s.logger.Info("Scheduling evaluation: dataset=%s limit=%d workers=%d", config.DatasetPath, config.InstanceLimit, config.MaxWorkers)
-	// Detach from request context cancellation; evaluations are async background jobs.
-	return s.manager.ScheduleEvaluation(context.WithoutCancel(ctx), config)
+// OnJobFinished registers a callback invoked once per job when it completes
+// or fails.
+func (s *EvaluationService) OnJobFinished(fn func(*agent_eval.EvaluationJob)) {
+	s.manager.SetJobFinishedHook(fn)
 }
 
 // ListJobs returns snapshots for all known evaluation jobs.
@@ -159,6 +186,21 @@ func (s *EvaluationService) QueryEvaluations(query agent_eval.EvaluationQuery) (
 	return s.manager.QueryEvaluations(query)
 }
 
+// IngestFoundationRun stores a foundation eval result published by a client.
+func (s *EvaluationService) IngestFoundationRun(result *agent_eval.FoundationEvaluationResult) error {
+	return s.foundationRuns.Save(result)
+}
+
+// ListFoundationRuns returns summaries of ingested foundation runs, newest first.
+func (s *EvaluationService) ListFoundationRuns() ([]agent_eval.FoundationRunSummary, error) {
+	return s.foundationRuns.List()
+}
+
+// GetFoundationRun returns one ingested foundation result.
+func (s *EvaluationService) GetFoundationRun(runID string) (*agent_eval.FoundationEvaluationResult, error) {
+	return s.foundationRuns.Get(runID)
+}
+
 func (s *EvaluationService) mergeOptions(options *agent_eval.EvaluationOptions) *agent_eval.EvaluationConfig {
 	config := s.defaultConfig
 
@@ -0,0 +1,250 @@
+package app
+
+import (
+	"bufio"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	defaultSegmentMaxBytes = 50 * 1024 * 1024
+	segmentGzipSuffix      = ".gz"
+)
+
+// EventHistorySegment describes one on-disk file of a session's event journal.
+type EventHistorySegment struct {
+	Path       string
+	Index      int // rolled segment number; 0 for the active file
+	Size       int64
+	Compressed bool
+	Active     bool
+	ModTime    time.Time
+}
+
+// Segments lists the session's rolled segments oldest first, followed by the
+// active file when present.
+func (s *FileEventHistoryStore) Segments(sessionID string) ([]EventHistorySegment, error) {
+	active := s.sessionPath(sessionID)
+	segments, err := s.rolledSegments(active)
+	if err != nil {
+		return nil, err
+	}
+	info, err := os.Stat(active)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return segments, nil
+		}
+		return nil, err
+	}
+	return append(segments, EventHistorySegment{
+		Path:    active,
+		Size:    info.Size(),
+		Active:  true,
+		ModTime: info.ModTime(),
+	}), nil
+}
+
+// SweepExpiredSegments removes rolled segments, across all sessions, last
+// modified before now-retention. Active files are never removed. Returns the
+// number of segments deleted.
+func (s *FileEventHistoryStore) SweepExpiredSegments(now time.Time, retention time.Duration) (int, error) {
+	if retention <= 0 {
+		return 0, nil
+	}
+	entries, err := os.ReadDir(s.eventsDir())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, nil
+		}
+		return 0, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cutoff := now.Add(-retention)
+	removed := 0
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		if _, _, ok := parseSegmentName(entry.Name()); !ok {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil || !info.ModTime().Before(cutoff) {
+			continue
+		}
+		if err := os.Remove(filepath.Join(s.eventsDir(), entry.Name())); err != nil && !os.IsNotExist(err) {
+			return removed, fmt.Errorf("remove expired segment: %w", err)
+		}
+		removed++
+	}
+	return removed, nil
+}
+
+// rolledSegments returns the numbered segments belonging to the active file
+// at path, ordered by index. When both a plain and a compressed copy of the
+// same index exist (an interrupted compression), the plain copy wins.
+func (s *FileEventHistoryStore) rolledSegments(active string) ([]EventHistorySegment, error) {
+	entries, err := os.ReadDir(filepath.Dir(active))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	prefix := filepath.Base(active) + "."
+	byIndex := make(map[int]EventHistorySegment)
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		index, compressed, ok := parseSegmentSuffix(strings.TrimPrefix(name, prefix))
+		if !ok {
+			continue
+		}
+		if existing, seen := byIndex[index]; seen && !existing.Compressed {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		byIndex[index] = EventHistorySegment{
+			Path:       filepath.Join(filepath.Dir(active), name),
+			Index:      index,
+			Size:       info.Size(),
+			Compressed: compressed,
+			ModTime:    info.ModTime(),
+		}
+	}
+
+	segments := make([]EventHistorySegment, 0, len(byIndex))
+	for _, seg := range byIndex {
+		segments = append(segments, seg)
+	}
+	sort.Slice(segments, func(i, j int) bool { return segments[i].Index < segments[j].Index })
+	return segments, nil
+}
+
+// rollSegment renames the active file to the next numbered segment and
+// compresses every uncompressed rolled segment. Callers must hold s.mu.
+func (s *FileEventHistoryStore) rollSegment(active string) error {
+	segments, err := s.rolledSegments(active)
+	if err != nil {
+		return err
+	}
+	next := 1
+	if len(segments) > 0 {
+		next = segments[len(segments)-1].Index + 1
+	}
+	rolled := fmt.Sprintf("%s.%d", active, next)
+	if err := os.Rename(active, rolled); err != nil {
+		return err
+	}
+	segments = append(segments, EventHistorySegment{Path: rolled, Index: next})
+
+	for _, seg := range segments {
+		if seg.Compressed {
+			continue
+		}
+		if err := gzipSegment(seg.Path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// gzipSegment writes path+".gz" atomically and removes the plain file.
+func gzipSegment(path string) error {
+	src, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	tmp := path + segmentGzipSuffix + ".tmp"
+	dst, err := os.OpenFile(tmp, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+	zw := gzip.NewWriter(dst)
+	_, err = io.Copy(zw, src)
+	if closeErr := zw.Close(); err == nil {
+		err = closeErr
+	}
+	if closeErr := dst.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp, path+segmentGzipSuffix)
+	}
+	if err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("compress %s: %w", filepath.Base(path), err)
+	}
+	return os.Remove(path)
+}
+
+// openSegments opens every segment of the session under the write lock so a
+// concurrent roll cannot rename files between listing and opening them.
+func (s *FileEventHistoryStore) openSegments(sessionID string) ([]*os.File, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	segments, err := s.Segments(sessionID)
+	if err != nil {
+		return nil, err
+	}
+	files := make([]*os.File, 0, len(segments))
+	for _, seg := range segments {
+		f, err := os.Open(seg.Path)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			for _, opened := range files {
+				opened.Close()
+			}
+			return nil, err
+		}
+		files = append(files, f)
+	}
+	return files, nil
+}
+
+// segmentReader returns a reader over the decoded contents of f.
+func segmentReader(f *os.File) (io.Reader, error) {
+	if !strings.HasSuffix(f.Name(), segmentGzipSuffix) {
+		return f, nil
+	}
+	return gzip.NewReader(bufio.NewReader(f))
+}
+
+// parseSegmentName reports whether name is a rolled segment file
+// ({session}.jsonl.{N} or {session}.jsonl.{N}.gz).
+func parseSegmentName(name string) (int, bool, bool) {
+	idx := strings.LastIndex(strings.TrimSuffix(name, segmentGzipSuffix), ".jsonl.")
+	if idx < 0 {
+		return 0, false, false
+	}
+	return parseSegmentSuffix(name[idx+len(".jsonl."):])
+}
+
+func parseSegmentSuffix(suffix string) (int, bool, bool) {
+	compressed := strings.HasSuffix(suffix, segmentGzipSuffix)
+	index, err := strconv.Atoi(strings.TrimSuffix(suffix, segmentGzipSuffix))
+	if err != nil || index <= 0 {
+		return 0, false, false
+	}
+	return index, compressed, true
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
@@ -20,15 +21,34 @@ import (
 // FileEventHistoryStore is a file-backed implementation of EventHistoryStore.
 // Events are stored as JSONL files — one file per session under {dir}/events/{session_id}.jsonl.
 // Each line is a self-describing JSON record that can reconstruct an AgentEvent.
+// Once the active file reaches the segment size it is rolled to
+// {session_id}.jsonl.{N}.gz; readers replay rolled segments in order before
+// the active file.
 type FileEventHistoryStore struct {
-	dir string
-	mu  sync.Mutex // serialises writes to the same session
+	dir             string
+	segmentMaxBytes int64
+	mu              sync.Mutex // serialises writes to the same session
+}
+
+// FileEventHistoryOption configures a FileEventHistoryStore.
+type FileEventHistoryOption func(*FileEventHistoryStore)
+
+// WithSegmentMaxBytes sets the size at which a session file is rolled into a
+// compressed segment. Zero or negative disables rotation.
+func WithSegmentMaxBytes(n int64) FileEventHistoryOption {
+	return func(s *FileEventHistoryStore) {
+		s.segmentMaxBytes = n
+	}
 }
 
 // NewFileEventHistoryStore creates a file-backed event history store.
 // dir is the root directory; session files will be at {dir}/events/{session_id}.jsonl.
-func NewFileEventHistoryStore(dir string) *FileEventHistoryStore {
-	return &FileEventHistoryStore{dir: dir}
+func NewFileEventHistoryStore(dir string, opts ...FileEventHistoryOption) *FileEventHistoryStore {
+	s := &FileEventHistoryStore{dir: dir, segmentMaxBytes: defaultSegmentMaxBytes}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
 // EnsureSchema creates the events directory if it does not exist.
@@ -64,30 +84,41 @@ func (s *FileEventHistoryStore) Append(ctx context.Context, event agent.AgentEve
 	if err != nil {
 		return fmt.Errorf("open event file: %w", err)
 	}
-	defer f.Close()
 
 	if _, err := f.Write(line); err != nil {
+		f.Close()
 		return fmt.Errorf("write event line: %w", err)
 	}
+	info, err := f.Stat()
+	f.Close()
+	if err != nil || s.segmentMaxBytes <= 0 || info.Size() < s.segmentMaxBytes {
+		return nil
+	}
+	// The event is already durable; a failed roll leaves the active file in
+	// place and is retried on the next append.
+	if err := s.rollSegment(path); err != nil {
+		return fmt.Errorf("roll event segment: %w", err)
+	}
 	return nil
 }
 
-// Stream reads the session's JSONL file line-by-line, filters by event types,
-// and calls fn for each matching event. Events are replayed in append order.
+// Stream reads the session's rolled segments and active JSONL file
+// line-by-line, filters by event types, and calls fn for each matching event.
+// Events are replayed in append order.
 func (s *FileEventHistoryStore) Stream(ctx context.Context, filter EventHistoryFilter, fn func(agent.AgentEvent) error) error {
 	if ctx.Err() != nil {
 		return ctx.Err()
 	}
 
-	path := s.sessionPath(filter.SessionID)
-	f, err := os.Open(path)
+	files, err := s.openSegments(filter.SessionID)
 	if err != nil {
-		if os.IsNotExist(err) {
-			return nil // no events for this session
-		}
 		return fmt.Errorf("open event file: %w", err)
 	}
-	defer f.Close()
+	defer func() {
+		for _, f := range files {
+			f.Close()
+		}
+	}()
 
 	typeSet := make(map[string]struct{}, len(filter.EventTypes))
 	for _, t := range filter.EventTypes {
@@ -95,7 +126,20 @@ func (s *FileEventHistoryStore) Stream(ctx context.Context, filter EventHistoryF
 	}
 	filterByType := len(typeSet) > 0
 
-	scanner := bufio.NewScanner(f)
+	for _, f := range files {
+		r, err := segmentReader(f)
+		if err != nil {
+			return fmt.Errorf("read event segment %s: %w", filepath.Base(f.Name()), err)
+		}
+		if err := streamRecords(ctx, r, typeSet, filterByType, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func streamRecords(ctx context.Context, r io.Reader, typeSet map[string]struct{}, filterByType bool, fn func(agent.AgentEvent) error) error {
+	scanner := bufio.NewScanner(r)
 	scanner.Buffer(make([]byte, 0, 256*1024), 2*1024*1024) // 2 MB max line
 	for scanner.Scan() {
 		if ctx.Err() != nil {
@@ -126,25 +170,35 @@ func (s *FileEventHistoryStore) Stream(ctx context.Context, filter EventHistoryF
 	return scanner.Err()
 }
 
-// DeleteSession removes the session's event file.
+// DeleteSession removes the session's event file and rolled segments.
 func (s *FileEventHistoryStore) DeleteSession(_ context.Context, sessionID string) error {
-	path := s.sessionPath(sessionID)
-	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	segments, err := s.Segments(sessionID)
+	if err != nil {
 		return fmt.Errorf("delete session events: %w", err)
 	}
+	for _, seg := range segments {
+		if err := os.Remove(seg.Path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("delete session events: %w", err)
+		}
+	}
 	return nil
 }
 
-// HasSessionEvents checks whether a session's event file exists and is non-empty.
+// HasSessionEvents checks whether any of the session's event segments is non-empty.
 func (s *FileEventHistoryStore) HasSessionEvents(_ context.Context, sessionID string) (bool, error) {
-	info, err := os.Stat(s.sessionPath(sessionID))
+	segments, err := s.Segments(sessionID)
 	if err != nil {
-		if os.IsNotExist(err) {
-			return false, nil
-		}
 		return false, err
 	}
-	return info.Size() > 0, nil
+	for _, seg := range segments {
+		if seg.Size > 0 {
+			return true, nil
+		}
+	}
+	return false, nil
 }
 
 // --- path helpers ---
@@ -1,7 +1,11 @@
 package app
 
 import (
+	"compress/gzip"
 	"context"
+	"io"
+	"os"
+	"strings"
 	"testing"
 	"time"
 
@@ -141,3 +145,125 @@ func TestEnvelopeCausalityRoundTrip(t *testing.T) {
 		t.Errorf("envelope agentLevel: got %q, want %q", got.GetAgentLevel(), agent.LevelSubagent)
 	}
 }
+
+func appendNodeEvents(t *testing.T, store *FileEventHistoryStore, sessionID string, from, to int) {
+	t.Helper()
+	for i := from; i < to; i++ {
+		base := domain.NewBaseEventFull(agent.LevelCore, sessionID, "run", "", "", "", uint64(i), time.Now())
+		if err := store.Append(context.Background(), domain.NewEvent(types.EventNodeStarted, base)); err != nil {
+			t.Fatalf("Append %d: %v", i, err)
+		}
+	}
+}
+
+func TestFileEventHistoryStoreRollsAndReplaysSegments(t *testing.T) {
+	dir := t.TempDir()
+	store := NewFileEventHistoryStore(dir, WithSegmentMaxBytes(1024))
+	appendNodeEvents(t, store, "sess-roll", 0, 40)
+
+	segments, err := store.Segments("sess-roll")
+	if err != nil {
+		t.Fatalf("Segments: %v", err)
+	}
+	if len(segments) < 3 {
+		t.Fatalf("expected several rolled segments, got %d", len(segments))
+	}
+	for i, seg := range segments[:len(segments)-1] {
+		if !seg.Compressed || seg.Index != i+1 || !strings.HasSuffix(seg.Path, ".gz") {
+			t.Fatalf("segment %d not a compressed rolled segment: %+v", i, seg)
+		}
+	}
+
+	// Simulate a crash between rename and compression: a plain segment must
+	// still replay in order.
+	last := segments[len(segments)-2]
+	plain := strings.TrimSuffix(last.Path, ".gz")
+	zr := mustGunzip(t, last.Path)
+	if err := os.WriteFile(plain, zr, 0o600); err != nil {
+		t.Fatalf("write plain segment: %v", err)
+	}
+	if err := os.Remove(last.Path); err != nil {
+		t.Fatalf("remove gz segment: %v", err)
+	}
+
+	var seqs []uint64
+	err = NewFileEventHistoryStore(dir).Stream(context.Background(), EventHistoryFilter{SessionID: "sess-roll"}, func(e agent.AgentEvent) error {
+		seqs = append(seqs, e.GetSeq())
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Stream: %v", err)
+	}
+	if len(seqs) != 40 {
+		t.Fatalf("expected 40 replayed events, got %d", len(seqs))
+	}
+	for i, seq := range seqs {
+		if seq != uint64(i) {
+			t.Fatalf("event %d replayed out of order: seq=%d", i, seq)
+		}
+	}
+
+	if err := store.DeleteSession(context.Background(), "sess-roll"); err != nil {
+		t.Fatalf("DeleteSession: %v", err)
+	}
+	if has, _ := store.HasSessionEvents(context.Background(), "sess-roll"); has {
+		t.Fatal("expected all segments deleted")
+	}
+}
+
+func TestFileEventHistoryStoreSweepsExpiredSegments(t *testing.T) {
+	dir := t.TempDir()
+	store := NewFileEventHistoryStore(dir, WithSegmentMaxBytes(512))
+	appendNodeEvents(t, store, "sess-old", 0, 10)
+	appendNodeEvents(t, store, "sess-new", 0, 10)
+
+	old, err := store.Segments("sess-old")
+	if err != nil {
+		t.Fatalf("Segments: %v", err)
+	}
+	stale := time.Now().Add(-72 * time.Hour)
+	rolled := 0
+	for _, seg := range old {
+		if !seg.Active {
+			rolled++
+		}
+		if err := os.Chtimes(seg.Path, stale, stale); err != nil {
+			t.Fatalf("Chtimes: %v", err)
+		}
+	}
+
+	removed, err := store.SweepExpiredSegments(time.Now(), 48*time.Hour)
+	if err != nil {
+		t.Fatalf("SweepExpiredSegments: %v", err)
+	}
+	if removed != rolled || rolled == 0 {
+		t.Fatalf("expected %d rolled segments removed, got %d", rolled, removed)
+	}
+	remaining, _ := store.Segments("sess-old")
+	for _, seg := range remaining {
+		if !seg.Active {
+			t.Fatalf("expected only the active file to survive, got %+v", remaining)
+		}
+	}
+	if fresh, _ := store.Segments("sess-new"); len(fresh) < 2 {
+		t.Fatalf("fresh session segments should be kept, got %d", len(fresh))
+	}
+}
+
+func mustGunzip(t *testing.T, path string) []byte {
+	t.Helper()
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	defer f.Close()
+	zr, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatalf("gzip: %v", err)
+	}
+	data, err := io.ReadAll(zr)
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	return data
+}
@@ -2,41 +2,89 @@ package app
 
 import (
 	"context"
+	"fmt"
 	"sync"
+	"time"
 
 	"alex/internal/app/di"
 	"alex/internal/delivery/server/ports"
+	agentports "alex/internal/domain/agent/ports/agent"
+	"alex/internal/shared/logging"
 )
 
-// HealthCheckerImpl aggregates health probes for all components
+// HealthCheckerImpl aggregates health probes for all components. It keeps a
+// sliding window of each probe's check latency, downgrades slow probes to
+// degraded, and announces status transitions.
 type HealthCheckerImpl struct {
-	probes []ports.HealthProbe
+	probes []kindedProbe
 	mu     sync.RWMutex
+	logger logging.Logger
+
+	statsMu    sync.Mutex
+	thresholds map[string]time.Duration
+	windows    map[string]*latencyWindow
+	states     map[string]ports.HealthStatus
+	events     agentports.EventListener
+}
+
+type kindedProbe struct {
+	kind  ports.ProbeKind
+	probe ports.HealthProbe
 }
 
 // NewHealthChecker creates a new health checker
 func NewHealthChecker() *HealthCheckerImpl {
 	return &HealthCheckerImpl{
-		probes: make([]ports.HealthProbe, 0),
+		probes:     make([]kindedProbe, 0),
+		logger:     logging.NewComponentLogger("HealthChecker"),
+		thresholds: make(map[string]time.Duration),
+		windows:    make(map[string]*latencyWindow),
+		states:     make(map[string]ports.HealthStatus),
 	}
 }
 
-// RegisterProbe adds a health probe
+// RegisterProbe adds a readiness probe
 func (h *HealthCheckerImpl) RegisterProbe(probe ports.HealthProbe) {
+	h.RegisterProbeKind(ports.ProbeKindReadiness, probe)
+}
+
+// RegisterProbeKind adds a probe of the given kind
+func (h *HealthCheckerImpl) RegisterProbeKind(kind ports.ProbeKind, probe ports.HealthProbe) {
 	h.mu.Lock()
 	defer h.mu.Unlock()
-	h.probes = append(h.probes, probe)
+	h.probes = append(h.probes, kindedProbe{kind: kind, probe: probe})
 }
 
 // CheckAll returns health status for all components
 func (h *HealthCheckerImpl) CheckAll(ctx context.Context) []ports.ComponentHealth {
-	h.mu.RLock()
-	defer h.mu.RUnlock()
+	return h.check(ctx, func(ports.ProbeKind) bool { return true })
+}
 
+// CheckKind returns health status for probes of the given kind
+func (h *HealthCheckerImpl) CheckKind(ctx context.Context, kind ports.ProbeKind) []ports.ComponentHealth {
+	return h.check(ctx, func(k ports.ProbeKind) bool { return k == kind })
+}
+
+func (h *HealthCheckerImpl) check(ctx context.Context, match func(ports.ProbeKind) bool) []ports.ComponentHealth {
+	h.mu.RLock()
 	results := make([]ports.ComponentHealth, 0, len(h.probes))
-	for _, probe := range h.probes {
-		results = append(results, probe.Check(ctx))
+	var transitions []healthTransition
+	for _, p := range h.probes {
+		if !match(p.kind) {
+			continue
+		}
+		start := time.Now()
+		result := p.probe.Check(ctx)
+		elapsed := time.Since(start)
+		result.CheckDurationMS = float64(elapsed.Microseconds()) / 1000
+		if transition := h.observe(&result, elapsed); transition != nil {
+			transitions = append(transitions, *transition)
+		}
+		results = append(results, result)
 	}
+	h.mu.RUnlock()
+
+	h.announce(transitions)
 	return results
 }
 
@@ -45,8 +93,8 @@ func (h *HealthCheckerImpl) CheckAll(ctx context.Context) []ports.ComponentHealt
 func (h *HealthCheckerImpl) ModelHealthDetails() interface{} {
 	h.mu.RLock()
 	defer h.mu.RUnlock()
-	for _, probe := range h.probes {
-		if mp, ok := probe.(*LLMModelHealthProbe); ok {
+	for _, p := range h.probes {
+		if mp, ok := p.probe.(*LLMModelHealthProbe); ok {
 			return mp.DetailedHealth()
 		}
 	}
@@ -89,6 +137,62 @@ func (p *DegradedProbe) Check(ctx context.Context) ports.ComponentHealth {
 	}
 }
 
+// StartedSource reports whether container lifecycle startup has completed.
+// Satisfied by di.Container.
+type StartedSource interface {
+	Started() bool
+}
+
+// ComponentStatusSource exposes per-component startup outcomes.
+// Satisfied by di.Container.
+type ComponentStatusSource interface {
+	ComponentStatuses() []di.ComponentStatus
+}
+
+// ContainerStartedProbe reports not ready until the container has started.
+// When the source also reports component statuses, they are included as
+// details; optional components that failed do not affect readiness.
+type ContainerStartedProbe struct {
+	source StartedSource
+}
+
+// NewContainerStartedProbe creates a probe that gates readiness on container startup.
+func NewContainerStartedProbe(source StartedSource) *ContainerStartedProbe {
+	return &ContainerStartedProbe{source: source}
+}
+
+// Check reports ready once the container's Start has completed.
+func (p *ContainerStartedProbe) Check(ctx context.Context) ports.ComponentHealth {
+	if p.source == nil || !p.source.Started() {
+		return ports.ComponentHealth{
+			Name:    "container",
+			Status:  ports.HealthStatusNotReady,
+			Message: "Container startup has not completed",
+		}
+	}
+	health := ports.ComponentHealth{
+		Name:    "container",
+		Status:  ports.HealthStatusReady,
+		Message: "Container started",
+	}
+	if src, ok := p.source.(ComponentStatusSource); ok {
+		statuses := src.ComponentStatuses()
+		unhealthy := 0
+		for _, status := range statuses {
+			if status.State != di.ComponentOK && status.State != di.ComponentSkipped {
+				unhealthy++
+			}
+		}
+		if unhealthy > 0 {
+			health.Message = fmt.Sprintf("Container started; %d component(s) degraded or failed", unhealthy)
+		}
+		if len(statuses) > 0 {
+			health.Details = statuses
+		}
+	}
+	return health
+}
+
 // LLMModelHealthProbe reports aggregate LLM health via the public /health endpoint.
 // Per-model telemetry (error rates, latency percentiles) is only available through
 // the debug endpoint /api/debug/health/models.
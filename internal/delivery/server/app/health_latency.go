@@ -0,0 +1,135 @@
+package app
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"alex/internal/delivery/server/ports"
+	agentports "alex/internal/domain/agent/ports/agent"
+	"alex/internal/domain/agent/types"
+)
+
+// healthLatencyWindow is how many recent check durations are kept per probe
+// for the percentiles reported on /health.
+const healthLatencyWindow = 100
+
+// latencyWindow is a fixed-size ring of recent check durations in ms.
+type latencyWindow struct {
+	samples []float64
+	next    int
+}
+
+func (w *latencyWindow) add(ms float64) {
+	if len(w.samples) < healthLatencyWindow {
+		w.samples = append(w.samples, ms)
+		return
+	}
+	w.samples[w.next] = ms
+	w.next = (w.next + 1) % healthLatencyWindow
+}
+
+func (w *latencyWindow) summary() *ports.LatencySummary {
+	if len(w.samples) == 0 {
+		return nil
+	}
+	sorted := append([]float64(nil), w.samples...)
+	sort.Float64s(sorted)
+	return &ports.LatencySummary{
+		P50MS:   percentile(sorted, 0.50),
+		P95MS:   percentile(sorted, 0.95),
+		Samples: len(sorted),
+	}
+}
+
+// percentile uses the nearest-rank method on an ascending slice.
+func percentile(sorted []float64, p float64) float64 {
+	rank := int(math.Ceil(p*float64(len(sorted)))) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	return sorted[rank]
+}
+
+// healthTransition is a probe status change observed by the checker.
+type healthTransition struct {
+	name     string
+	from, to ports.HealthStatus
+	message  string
+}
+
+// SetDegradedThresholds sets per-component latency thresholds, keyed by the
+// component name a probe reports. A ready probe whose check takes longer than
+// its threshold is reported as degraded.
+func (h *HealthCheckerImpl) SetDegradedThresholds(thresholds map[string]time.Duration) {
+	h.statsMu.Lock()
+	defer h.statsMu.Unlock()
+	h.thresholds = make(map[string]time.Duration, len(thresholds))
+	for name, threshold := range thresholds {
+		if threshold > 0 {
+			h.thresholds[name] = threshold
+		}
+	}
+}
+
+// SetEventListener registers the sink for health_changed diagnostics.
+func (h *HealthCheckerImpl) SetEventListener(listener agentports.EventListener) {
+	h.statsMu.Lock()
+	defer h.statsMu.Unlock()
+	h.events = listener
+}
+
+// observe records a check's latency, applies the degraded threshold and
+// attaches the latency summary. It returns the status transition, if any;
+// the first observation of a component only sets its baseline.
+func (h *HealthCheckerImpl) observe(result *ports.ComponentHealth, elapsed time.Duration) *healthTransition {
+	h.statsMu.Lock()
+	defer h.statsMu.Unlock()
+
+	window, ok := h.windows[result.Name]
+	if !ok {
+		window = &latencyWindow{}
+		h.windows[result.Name] = window
+	}
+	window.add(result.CheckDurationMS)
+	result.Latency = window.summary()
+
+	if threshold, ok := h.thresholds[result.Name]; ok && result.Status == ports.HealthStatusReady && elapsed > threshold {
+		result.Status = ports.HealthStatusDegraded
+		slow := fmt.Sprintf("check took %dms, above the %dms threshold", elapsed.Milliseconds(), threshold.Milliseconds())
+		if result.Message == "" {
+			result.Message = slow
+		} else {
+			result.Message += "; " + slow
+		}
+	}
+
+	previous, seen := h.states[result.Name]
+	h.states[result.Name] = result.Status
+	if !seen || previous == result.Status {
+		return nil
+	}
+	return &healthTransition{name: result.Name, from: previous, to: result.Status, message: result.Message}
+}
+
+// announce broadcasts a health_changed diagnostic for each transition so the
+// UI can show which dependency is degraded or down.
+func (h *HealthCheckerImpl) announce(transitions []healthTransition) {
+	h.statsMu.Lock()
+	events := h.events
+	h.statsMu.Unlock()
+	for _, t := range transitions {
+		h.logger.Warn("Health of %s changed from %s to %s: %s", t.name, t.from, t.to, t.message)
+		if events == nil {
+			continue
+		}
+		events.OnEvent(NewGlobalDiagnosticEnvelope(types.EventDiagnosticHealthChanged, map[string]any{
+			"component": t.name,
+			"from":      string(t.from),
+			"to":        string(t.to),
+			"message":   t.message,
+			"summary":   fmt.Sprintf("%s %s", t.name, t.to),
+		}))
+	}
+}
@@ -10,6 +10,9 @@ import (
 	"alex/internal/app/di"
 	"alex/internal/app/scheduler"
 	"alex/internal/delivery/server/ports"
+	domain "alex/internal/domain/agent"
+	agentports "alex/internal/domain/agent/ports/agent"
+	"alex/internal/domain/agent/types"
 )
 
 func TestHealthChecker(t *testing.T) {
@@ -508,3 +511,163 @@ func TestSchedulerProbe_NotOverdueWithinGrace(t *testing.T) {
 		t.Error("expected no overdue flag for future NextRun")
 	}
 }
+
+type slowProbe struct{ delay time.Duration }
+
+func (p slowProbe) Check(ctx context.Context) ports.ComponentHealth {
+	time.Sleep(p.delay)
+	return ports.ComponentHealth{Name: "slow", Status: ports.HealthStatusReady}
+}
+
+type startedFlag bool
+
+func (s startedFlag) Started() bool { return bool(s) }
+
+func TestHealthCheckerCheckKindFiltersAndTimesProbes(t *testing.T) {
+	checker := NewHealthChecker()
+	checker.RegisterProbe(slowProbe{delay: 2 * time.Millisecond})
+	checker.RegisterProbeKind(ports.ProbeKindLiveness, &mockHealthProbe{health: ports.ComponentHealth{Name: "process", Status: ports.HealthStatusReady}})
+	checker.RegisterProbeKind(ports.ProbeKindInformational, &mockHealthProbe{health: ports.ComponentHealth{Name: "info", Status: ports.HealthStatusNotReady}})
+
+	if all := checker.CheckAll(context.Background()); len(all) != 3 {
+		t.Fatalf("CheckAll should report every probe, got %d", len(all))
+	}
+	readiness := checker.CheckKind(context.Background(), ports.ProbeKindReadiness)
+	if len(readiness) != 1 || readiness[0].Name != "slow" {
+		t.Fatalf("unexpected readiness probes: %+v", readiness)
+	}
+	if readiness[0].CheckDurationMS < 2 {
+		t.Fatalf("expected latency >= 2ms, got %v", readiness[0].CheckDurationMS)
+	}
+	liveness := checker.CheckKind(context.Background(), ports.ProbeKindLiveness)
+	if len(liveness) != 1 || liveness[0].Name != "process" {
+		t.Fatalf("unexpected liveness probes: %+v", liveness)
+	}
+}
+
+func TestContainerStartedProbe(t *testing.T) {
+	if got := NewContainerStartedProbe(nil).Check(context.Background()); got.Status != ports.HealthStatusNotReady {
+		t.Fatalf("nil source should be not ready, got %s", got.Status)
+	}
+	if got := NewContainerStartedProbe(startedFlag(false)).Check(context.Background()); got.Status != ports.HealthStatusNotReady {
+		t.Fatalf("unstarted container should be not ready, got %s", got.Status)
+	}
+	if got := NewContainerStartedProbe(startedFlag(true)).Check(context.Background()); got.Status != ports.HealthStatusReady {
+		t.Fatalf("started container should be ready, got %s", got.Status)
+	}
+
+	container := &di.Container{}
+	if container.Started() {
+		t.Fatal("container should not report started before Start")
+	}
+	if err := container.Start(); err != nil || !container.Started() {
+		t.Fatalf("container should report started after Start, err=%v", err)
+	}
+}
+
+type componentStatusesSource struct {
+	statuses []di.ComponentStatus
+}
+
+func (s componentStatusesSource) Started() bool { return true }
+
+func (s componentStatusesSource) ComponentStatuses() []di.ComponentStatus { return s.statuses }
+
+func TestContainerStartedProbeReportsComponentStatuses(t *testing.T) {
+	source := componentStatusesSource{statuses: []di.ComponentStatus{
+		{Name: "llm-factory", State: di.ComponentOK, Required: true},
+		{Name: "memory-indexer", State: di.ComponentFailed, Message: "timed out after 20s"},
+		{Name: "mcp", State: di.ComponentSkipped},
+	}}
+
+	got := NewContainerStartedProbe(source).Check(context.Background())
+	if got.Status != ports.HealthStatusReady {
+		t.Fatalf("failed optional component should not affect readiness, got %s", got.Status)
+	}
+	if !strings.Contains(got.Message, "1 component(s)") {
+		t.Fatalf("expected message to count the failed component, got %q", got.Message)
+	}
+	details, ok := got.Details.([]di.ComponentStatus)
+	if !ok || len(details) != 3 {
+		t.Fatalf("expected component statuses as details, got %#v", got.Details)
+	}
+}
+
+type toggleProbe struct {
+	name  string
+	delay time.Duration
+}
+
+func (p *toggleProbe) Check(context.Context) ports.ComponentHealth {
+	time.Sleep(p.delay)
+	return ports.ComponentHealth{Name: p.name, Status: ports.HealthStatusReady, Message: "reachable"}
+}
+
+type recordingListener struct {
+	events []agentports.AgentEvent
+}
+
+func (l *recordingListener) OnEvent(event agentports.AgentEvent) {
+	l.events = append(l.events, event)
+}
+
+func TestHealthCheckerDegradesSlowProbesAndAnnouncesTransitions(t *testing.T) {
+	probe := &toggleProbe{name: "llm_models"}
+	listener := &recordingListener{}
+	checker := NewHealthChecker()
+	checker.RegisterProbe(probe)
+	checker.SetDegradedThresholds(map[string]time.Duration{"llm_models": 5 * time.Millisecond})
+	checker.SetEventListener(listener)
+
+	first := checker.CheckAll(context.Background())[0]
+	if first.Status != ports.HealthStatusReady || first.Latency == nil || first.Latency.Samples != 1 {
+		t.Fatalf("expected a ready probe with one latency sample, got %+v", first)
+	}
+	if len(listener.events) != 0 {
+		t.Fatalf("the first observation must only set the baseline, got %d events", len(listener.events))
+	}
+
+	probe.delay = 10 * time.Millisecond
+	slow := checker.CheckAll(context.Background())[0]
+	if slow.Status != ports.HealthStatusDegraded || !strings.Contains(slow.Message, "above the 5ms threshold") {
+		t.Fatalf("expected the slow probe to be degraded, got %+v", slow)
+	}
+	if slow.Latency.Samples != 2 || slow.Latency.P95MS < 10 || slow.Latency.P50MS > slow.Latency.P95MS {
+		t.Fatalf("unexpected latency summary %+v", slow.Latency)
+	}
+	if len(listener.events) != 1 {
+		t.Fatalf("expected one transition event, got %d", len(listener.events))
+	}
+	envelope, ok := listener.events[0].(*domain.WorkflowEventEnvelope)
+	if !ok || envelope.Event != types.EventDiagnosticHealthChanged {
+		t.Fatalf("unexpected event %#v", listener.events[0])
+	}
+	if envelope.Payload["component"] != "llm_models" || envelope.Payload["from"] != "ready" || envelope.Payload["to"] != "degraded" {
+		t.Fatalf("unexpected payload %+v", envelope.Payload)
+	}
+
+	checker.CheckAll(context.Background())
+	if len(listener.events) != 1 {
+		t.Fatalf("an unchanged status must not be announced again, got %d events", len(listener.events))
+	}
+	probe.delay = 0
+	checker.CheckAll(context.Background())
+	if len(listener.events) != 2 || listener.events[1].(*domain.WorkflowEventEnvelope).Payload["to"] != "ready" {
+		t.Fatalf("expected the recovery to be announced, got %+v", listener.events)
+	}
+}
+
+func TestLatencyWindowKeepsMostRecentSamples(t *testing.T) {
+	window := &latencyWindow{}
+	for i := 1; i <= healthLatencyWindow+20; i++ {
+		window.add(float64(i))
+	}
+	summary := window.summary()
+	if summary.Samples != healthLatencyWindow {
+		t.Fatalf("expected %d samples, got %d", healthLatencyWindow, summary.Samples)
+	}
+	// Samples 21..120 remain: nearest-rank p50 is 70, p95 is 115.
+	if summary.P50MS != 70 || summary.P95MS != 115 {
+		t.Fatalf("unexpected percentiles %+v", summary)
+	}
+}
@@ -285,8 +285,11 @@ func TestAnalyticsCapture(t *testing.T) {
 	}
 
 	capture := analyticsMock.captures[0]
-	if capture.distinctID != "session-analytics" {
-		t.Errorf("expected distinctID session-analytics, got %s", capture.distinctID)
+	if want := analytics.HashIdentifier("session-analytics"); capture.distinctID != want {
+		t.Errorf("expected hashed distinctID %s, got %s", want, capture.distinctID)
+	}
+	if _, ok := capture.properties["session_id"]; ok {
+		t.Errorf("expected raw session_id to be stripped, got %v", capture.properties["session_id"])
 	}
 	if capture.event != analytics.EventTaskExecutionStarted {
 		t.Errorf("expected event %s, got %s", analytics.EventTaskExecutionStarted, capture.event)
@@ -779,8 +782,8 @@ func TestAppliesTaskExecutionRuntimeConfig(t *testing.T) {
 	if tasks.leaseRenewInterval != leaseRenewInterval {
 		t.Fatalf("expected lease renew interval %s, got %s", leaseRenewInterval, tasks.leaseRenewInterval)
 	}
-	if cap(tasks.admissionSem) != 3 {
-		t.Fatalf("expected admission capacity 3, got %d", cap(tasks.admissionSem))
+	if tasks.queue.slots != 3 {
+		t.Fatalf("expected admission capacity 3, got %d", tasks.queue.slots)
 	}
 	if tasks.resumeClaimBatchSize != 7 {
 		t.Fatalf("expected resume claim batch size 7, got %d", tasks.resumeClaimBatchSize)
@@ -799,8 +802,8 @@ func TestTaskExecutionRuntimeConfig_AllowsDisablingAdmissionLimiter(t *testing.T
 		nil, nil,
 	)
 
-	if tasks.admissionSem != nil {
-		t.Fatalf("expected admission limiter to be disabled when max_in_flight=0, got capacity=%d", cap(tasks.admissionSem))
+	if tasks.queue.slots > 0 {
+		t.Fatalf("expected admission limiter to be disabled when max_in_flight=0, got capacity=%d", tasks.queue.slots)
 	}
 }
 
@@ -0,0 +1,66 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"alex/internal/app/annotations"
+)
+
+// AnnotateMessage attaches a note and/or bookmark to the message at index in
+// the session transcript.
+func (svc *SessionService) AnnotateMessage(ctx context.Context, sessionID string, index int, in annotations.Input) (annotations.Annotation, error) {
+	if svc.annotations == nil {
+		return annotations.Annotation{}, UnavailableError("annotations not configured")
+	}
+	session, err := svc.sessionStore.Get(ctx, sessionID)
+	if err != nil {
+		return annotations.Annotation{}, fmt.Errorf("get session: %w", err)
+	}
+	annotation, err := svc.annotations.Add(ctx, session.ID, session.Messages, index, in)
+	return annotation, mapAnnotationError(err)
+}
+
+// ListAnnotations returns the session's annotations re-anchored against its
+// current transcript. It returns nil when annotations are not configured.
+func (svc *SessionService) ListAnnotations(ctx context.Context, sessionID string) ([]annotations.Annotation, error) {
+	if svc.annotations == nil {
+		return nil, nil
+	}
+	session, err := svc.sessionStore.Get(ctx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("get session: %w", err)
+	}
+	return svc.annotations.List(ctx, session.ID, session.Messages)
+}
+
+// UpdateAnnotation edits the text or bookmark flag of an annotation.
+func (svc *SessionService) UpdateAnnotation(ctx context.Context, sessionID, annotationID string, patch annotations.Patch) (annotations.Annotation, error) {
+	if svc.annotations == nil {
+		return annotations.Annotation{}, UnavailableError("annotations not configured")
+	}
+	annotation, err := svc.annotations.Update(ctx, sessionID, annotationID, patch)
+	return annotation, mapAnnotationError(err)
+}
+
+// DeleteAnnotation removes one annotation from the session.
+func (svc *SessionService) DeleteAnnotation(ctx context.Context, sessionID, annotationID string) error {
+	if svc.annotations == nil {
+		return UnavailableError("annotations not configured")
+	}
+	return mapAnnotationError(svc.annotations.Delete(ctx, sessionID, annotationID))
+}
+
+func mapAnnotationError(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, annotations.ErrInvalidAnnotation):
+		return ValidationError(err.Error())
+	case errors.Is(err, annotations.ErrMessageNotFound), errors.Is(err, annotations.ErrAnnotationNotFound):
+		return NotFoundError(err.Error())
+	default:
+		return err
+	}
+}
@@ -6,8 +6,12 @@ import (
 	"fmt"
 	"strings"
 
+	"alex/internal/app/agent/sessiontitle"
+	"alex/internal/app/agent/sessiontools"
+	"alex/internal/app/annotations"
 	"alex/internal/domain/agent/ports"
 	storage "alex/internal/domain/agent/ports/storage"
+	tools "alex/internal/domain/agent/ports/tools"
 	sessionstate "alex/internal/infra/session/state_store"
 	"alex/internal/shared/logging"
 	id "alex/internal/shared/utils/id"
@@ -20,6 +24,7 @@ type SessionService struct {
 	sessionStore     storage.SessionStore
 	stateStore       sessionstate.Store
 	historyStore     sessionstate.Store
+	annotations      *annotations.Store
 	broadcaster      *EventBroadcaster
 	logger           logging.Logger
 }
@@ -62,6 +67,14 @@ func WithSessionHistoryStore(store sessionstate.Store) SessionServiceOption {
 	}
 }
 
+// WithSessionAnnotationStore wires message annotations, which are removed
+// together with their session.
+func WithSessionAnnotationStore(store *annotations.Store) SessionServiceOption {
+	return func(svc *SessionService) {
+		svc.annotations = store
+	}
+}
+
 // GetSession retrieves a session by ID.
 func (svc *SessionService) GetSession(ctx context.Context, id string) (*storage.Session, error) {
 	return svc.sessionStore.Get(ctx, id)
@@ -83,6 +96,42 @@ func (svc *SessionService) UpdateSessionPersona(ctx context.Context, sessionID s
 	return session, nil
 }
 
+// UpdateSessionTitle applies a user-chosen title and/or tags. Overridden
+// sessions are excluded from automatic re-titling. A nil tags slice keeps the
+// current tags.
+func (svc *SessionService) UpdateSessionTitle(ctx context.Context, sessionID string, title string, tags []string) (*storage.Session, error) {
+	session, err := svc.sessionStore.Get(ctx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("get session: %w", err)
+	}
+	if err := sessiontitle.ApplyOverride(session, title, tags); err != nil {
+		if errors.Is(err, sessiontitle.ErrEmptyOverride) {
+			return nil, ValidationError(err.Error())
+		}
+		return nil, err
+	}
+	if err := svc.sessionStore.Save(ctx, session); err != nil {
+		return nil, fmt.Errorf("save session title: %w", err)
+	}
+	return session, nil
+}
+
+// UpdateSessionToolOverrides replaces the session's tool allow/deny
+// overrides. Empty overrides restore the mode's default tool set.
+func (svc *SessionService) UpdateSessionToolOverrides(ctx context.Context, sessionID string, overrides tools.ToolOverrides) (*storage.Session, error) {
+	session, err := svc.sessionStore.Get(ctx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("get session: %w", err)
+	}
+	if err := sessiontools.Apply(session, overrides); err != nil {
+		return nil, err
+	}
+	if err := svc.sessionStore.Save(ctx, session); err != nil {
+		return nil, fmt.Errorf("save session tool overrides: %w", err)
+	}
+	return session, nil
+}
+
 // ListSessions returns session IDs with optional pagination.
 func (svc *SessionService) ListSessions(ctx context.Context, limit int, offset int) ([]string, error) {
 	return svc.sessionStore.List(ctx, limit, offset)
@@ -108,6 +157,7 @@ func (svc *SessionService) ListSessionItems(ctx context.Context, limit int, offs
 		items = append(items, storage.SessionListItem{
 			ID:        session.ID,
 			Title:     strings.TrimSpace(session.Metadata["title"]),
+			Tags:      sessiontitle.Tags(session.Metadata),
 			CreatedAt: session.CreatedAt,
 			UpdatedAt: session.UpdatedAt,
 		})
@@ -115,6 +165,58 @@ func (svc *SessionService) ListSessionItems(ctx context.Context, limit int, offs
 	return items, nil
 }
 
+// sessionSearchScanBatch is the List page size used by the search fallback.
+const sessionSearchScanBatch = 200
+
+// SearchSessions finds sessions by content and metadata. Stores without a
+// search index fall back to a linear scan over every session.
+func (svc *SessionService) SearchSessions(ctx context.Context, query storage.SessionQuery) (storage.SessionSearchResult, error) {
+	query = query.Normalize()
+	if searcher, ok := svc.sessionStore.(storage.SessionSearcher); ok {
+		return searcher.SearchSessions(ctx, query)
+	}
+
+	var sessionIDs []string
+	for offset := 0; ; offset += sessionSearchScanBatch {
+		batch, err := svc.sessionStore.List(ctx, sessionSearchScanBatch, offset)
+		if err != nil {
+			return storage.SessionSearchResult{}, err
+		}
+		sessionIDs = append(sessionIDs, batch...)
+		if len(batch) < sessionSearchScanBatch {
+			break
+		}
+	}
+	keywords := query.Keywords()
+	var hits []storage.SessionSearchHit
+	for _, sessionID := range sessionIDs {
+		session, err := svc.sessionStore.Get(ctx, sessionID)
+		if err != nil || !query.MatchesMetadata(session.ID, session.Metadata, session.UpdatedAt) {
+			continue
+		}
+		var texts []string
+		for _, msg := range session.Messages {
+			if text, ok := storage.SearchableText(msg); ok {
+				texts = append(texts, text)
+			}
+		}
+		matches, snippets, ok := storage.MatchSessionTexts(texts, keywords)
+		if !ok {
+			continue
+		}
+		hits = append(hits, storage.SessionSearchHit{
+			SessionID: session.ID,
+			Title:     strings.TrimSpace(session.Metadata["title"]),
+			Snippets:  snippets,
+			Matches:   matches,
+			CreatedAt: session.CreatedAt,
+			UpdatedAt: session.UpdatedAt,
+		})
+	}
+	storage.SortSearchHits(hits)
+	return storage.PageSearchHits(hits, query), nil
+}
+
 // CreateSession creates a new session record without executing a task.
 func (svc *SessionService) CreateSession(ctx context.Context) (*storage.Session, error) {
 	if svc.agentCoordinator == nil {
@@ -149,6 +251,11 @@ func (svc *SessionService) DeleteSession(ctx context.Context, sessionID string)
 			combined = errors.Join(combined, err)
 		}
 	}
+	if svc.annotations != nil {
+		if err := svc.annotations.DeleteSession(ctx, sessionID); err != nil {
+			combined = errors.Join(combined, err)
+		}
+	}
 	if svc.broadcaster != nil {
 		svc.broadcaster.ClearEventHistory(sessionID)
 	}
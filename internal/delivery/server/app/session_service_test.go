@@ -510,3 +510,31 @@ func TestValidateShareToken_NoMetadata(t *testing.T) {
 		t.Fatalf("expected ErrShareTokenInvalid, got %v", err)
 	}
 }
+
+func TestSessionService_SearchSessions_FallsBackToScan(t *testing.T) {
+	store := newStrictSessionStore()
+	store.sessions["lark-1"] = &storage.Session{
+		ID:        "lark-1",
+		Metadata:  map[string]string{"title": "Ops", "user_id": "ou_1"},
+		Messages:  []core.Message{{Role: "user", Content: "rotate the api keys", Source: core.MessageSourceUserInput}},
+		UpdatedAt: time.Now(),
+	}
+	store.sessions["lark-2"] = &storage.Session{
+		ID:        "lark-2",
+		Metadata:  map[string]string{"user_id": "ou_2"},
+		Messages:  []core.Message{{Role: "user", Content: "rotate logs"}},
+		UpdatedAt: time.Now(),
+	}
+	svc := NewSessionService(nil, store, nil)
+
+	result, err := svc.SearchSessions(context.Background(), storage.SessionQuery{Text: "rotate", UserID: "ou_1"})
+	if err != nil {
+		t.Fatalf("SearchSessions: %v", err)
+	}
+	if result.Total != 1 || result.Hits[0].SessionID != "lark-1" || result.Hits[0].Title != "Ops" {
+		t.Fatalf("unexpected result %+v", result)
+	}
+	if got := result.Hits[0].Snippets; len(got) != 1 || got[0] != "**rotate** the api keys" {
+		t.Fatalf("unexpected snippets %q", got)
+	}
+}
@@ -0,0 +1,60 @@
+package app
+
+import (
+	"context"
+	"sync"
+
+	agent "alex/internal/domain/agent/ports/agent"
+	"alex/internal/infra/analytics"
+)
+
+// TaskAnalyticsTracker implements agent.EventListener, capturing task
+// lifecycle analytics for registered runs. It subscribes to the event bus
+// like the progress tracker, so analytics capture never runs inside the
+// agent loop; events of unregistered runs are ignored.
+type TaskAnalyticsTracker struct {
+	client analytics.Client
+	runs   map[string]agent.EventListener // runID → lifecycle listener
+	mu     sync.RWMutex
+}
+
+// NewTaskAnalyticsTracker creates a tracker that captures to client. A nil
+// client disables capture.
+func NewTaskAnalyticsTracker(client analytics.Client) *TaskAnalyticsTracker {
+	return &TaskAnalyticsTracker{
+		client: client,
+		runs:   make(map[string]agent.EventListener),
+	}
+}
+
+// OnEvent implements agent.EventListener.
+func (t *TaskAnalyticsTracker) OnEvent(event agent.AgentEvent) {
+	if event == nil {
+		return
+	}
+	t.mu.RLock()
+	listener, ok := t.runs[event.GetRunID()]
+	t.mu.RUnlock()
+	if ok {
+		listener.OnEvent(event)
+	}
+}
+
+// RegisterRun starts lifecycle tracking for runID.
+func (t *TaskAnalyticsTracker) RegisterRun(ctx context.Context, runID string, opts analytics.TaskLifecycleOptions) {
+	if t.client == nil || runID == "" {
+		return
+	}
+	listener := analytics.NewTaskLifecycleListener(ctx, agent.NoopEventListener{}, t.client, opts)
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.runs[runID] = listener
+}
+
+// UnregisterRun stops lifecycle tracking for runID. Call it after the bus has
+// been flushed so the run's terminal event is captured.
+func (t *TaskAnalyticsTracker) UnregisterRun(runID string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.runs, runID)
+}
@@ -11,11 +11,48 @@ import (
 	domain "alex/internal/domain/agent"
 	"alex/internal/domain/agent/ports"
 	agentports "alex/internal/domain/agent/ports/agent"
+	"alex/internal/domain/agent/types"
 	"alex/internal/infra/analytics"
 	"alex/internal/shared/logging"
 	id "alex/internal/shared/utils/id"
 )
 
+// eventBusFlushTimeout bounds how long a finished task waits for bus
+// subscribers to drain its events.
+const eventBusFlushTimeout = 5 * time.Second
+
+// eventSink returns the listener task events are published to: the event bus
+// when configured, otherwise the broadcaster (plus trackers) directly.
+func (svc *TaskExecutionService) eventSink() agentports.EventListener {
+	if svc.eventBus != nil {
+		return svc.eventBus
+	}
+	listeners := []agentports.EventListener{svc.broadcaster}
+	if svc.progressTracker != nil {
+		listeners = append(listeners, svc.progressTracker)
+	}
+	if svc.analyticsTracker != nil {
+		listeners = append(listeners, svc.analyticsTracker)
+	}
+	if len(listeners) == 1 {
+		return svc.broadcaster
+	}
+	return NewMultiEventListener(listeners...)
+}
+
+// flushEventBus waits until bus subscribers have handled every event of the
+// finished task, so the journal is complete before the task status changes.
+func (svc *TaskExecutionService) flushEventBus(ctx context.Context, logger logging.Logger) {
+	if svc.eventBus == nil {
+		return
+	}
+	flushCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), eventBusFlushTimeout)
+	defer cancel()
+	if err := svc.eventBus.Flush(flushCtx); err != nil {
+		logger.Warn("Event bus flush incomplete: %v", err)
+	}
+}
+
 func (svc *TaskExecutionService) captureAnalytics(ctx context.Context, distinctID string, event string, props map[string]any) {
 	if svc.analytics == nil {
 		return
@@ -32,8 +69,13 @@ func (svc *TaskExecutionService) captureAnalytics(ctx context.Context, distinctI
 		}
 		payload[key] = value
 	}
+	// Session IDs identify users; only their hashes leave the process.
+	if sessionID, ok := payload["session_id"].(string); ok {
+		delete(payload, "session_id")
+		payload["session_id_hash"] = analytics.HashIdentifier(sessionID)
+	}
 
-	if err := svc.analytics.Capture(ctx, distinctID, event, payload); err != nil {
+	if err := svc.analytics.Capture(ctx, analytics.HashIdentifier(distinctID), event, payload); err != nil {
 		logger.Debug("Analytics capture failed for event %s: %v", event, err)
 	}
 }
@@ -72,7 +114,7 @@ func (svc *TaskExecutionService) emitWorkflowInputReceivedEvent(ctx context.Cont
 	if logID := id.LogIDFromContext(ctx); logID != "" {
 		event.SetLogID(logID)
 	}
-	svc.broadcaster.OnEvent(event)
+	svc.eventSink().OnEvent(event)
 
 	attachmentCount := len(attachmentMap)
 	props := map[string]any{
@@ -110,6 +152,7 @@ func (svc *TaskExecutionService) emitWorkflowResultCancelledEvent(ctx context.Co
 		requestedBy,
 		time.Now(),
 	)
+	sink := svc.eventSink()
 	envelope := domain.NewWorkflowEnvelopeFromEvent(event, "workflow.result.cancelled")
 	if envelope != nil {
 		envelope.NodeKind = "result"
@@ -117,9 +160,28 @@ func (svc *TaskExecutionService) emitWorkflowResultCancelledEvent(ctx context.Co
 			"reason":       reason,
 			"requested_by": requestedBy,
 		}
-		svc.broadcaster.OnEvent(envelope)
+		sink.OnEvent(envelope)
+	}
+	sink.OnEvent(event)
+}
+
+// emitQueuedEvent reports a waiting task's queue position and estimated wait.
+func (svc *TaskExecutionService) emitQueuedEvent(ticket *queueTicket, position int, wait time.Duration) {
+	if svc.broadcaster == nil || ticket == nil {
+		return
 	}
-	svc.broadcaster.OnEvent(event)
+	svc.eventSink().OnEvent(&domain.WorkflowEventEnvelope{
+		BaseEvent: domain.NewBaseEvent(agentports.LevelCore, ticket.sessionID, ticket.taskID, ticket.parentRunID, time.Now()),
+		Version:   1,
+		Event:     types.EventQueued,
+		NodeKind:  "queue",
+		Payload: map[string]any{
+			"position":               position,
+			"estimated_wait_seconds": int64(wait.Round(time.Second) / time.Second),
+			"priority":               ticket.priority,
+			"queued_at":              ticket.queuedAt,
+		},
+	})
 }
 
 // CancelTask cancels a running task.
@@ -231,27 +231,6 @@ func (svc *TaskExecutionService) nextLeaseDeadline(now time.Time) time.Time {
 	return now.Add(svc.leaseTTL)
 }
 
-func (svc *TaskExecutionService) acquireAdmission(ctx context.Context) (func(), error) {
-	if svc.admissionSem == nil {
-		return func() {}, nil
-	}
-	if ctx == nil {
-		ctx = context.Background()
-	}
-
-	select {
-	case svc.admissionSem <- struct{}{}:
-		var once sync.Once
-		return func() {
-			once.Do(func() {
-				<-svc.admissionSem
-			})
-		}, nil
-	case <-ctx.Done():
-		return nil, context.Cause(ctx)
-	}
-}
-
 func (svc *TaskExecutionService) startTaskLeaseRenewer(ctx context.Context, taskID string) func() {
 	if svc.leaseTTL <= 0 || svc.leaseRenewInterval <= 0 || taskID == "" {
 		return func() {}
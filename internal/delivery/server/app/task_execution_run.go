@@ -66,18 +66,23 @@ func (svc *TaskExecutionService) ExecuteTaskAsync(ctx context.Context, task stri
 		return taskRecord, UnavailableError("broadcaster not initialized")
 	}
 
-	releaseAdmission, err := svc.acquireAdmission(ctx)
-	if err != nil {
-		admissionErr := UnavailableError("task admission timed out")
-		logger.Warn("Admission wait failed for task %s: %v", taskID, err)
-		_ = svc.taskStore.SetError(context.Background(), taskID, admissionErr)
-		return taskRecord, admissionErr
+	priority := taskPriorityFromContext(ctx)
+	ticket := &queueTicket{taskID: taskID, sessionID: confirmedSessionID, parentRunID: parentRunID, priority: priority}
+	if err := svc.queue.enqueue(ticket, true); err != nil {
+		logger.Warn("Queue rejected task %s: %v", taskID, err)
+		_ = svc.taskStore.SetError(context.Background(), taskID, err)
+		return taskRecord, err
+	}
+	if err := svc.taskStore.SetQueued(ctx, taskID, priority, ticket.queuedAt); err != nil {
+		logger.Warn("Failed to record queue entry for task %s: %v", taskID, err)
 	}
+	taskRecord.Priority = priority
+	taskRecord.QueuedAt = &ticket.queuedAt
 
 	leaseUntil := svc.nextLeaseDeadline(time.Now())
 	claimed, err := svc.taskStore.TryClaimTask(ctx, taskID, svc.ownerID, leaseUntil)
 	if err != nil {
-		releaseAdmission()
+		svc.queue.withdraw(ticket)
 		logger.Error("Failed to claim task %s: %v", taskID, err)
 		_ = svc.taskStore.SetError(context.Background(), taskID, fmt.Errorf("failed to claim task: %w", err))
 		return taskRecord, fmt.Errorf("claim task ownership: %w", err)
@@ -85,7 +90,7 @@ func (svc *TaskExecutionService) ExecuteTaskAsync(ctx context.Context, task stri
 	if !claimed {
 		claimErr := ConflictError("task already claimed by another worker")
 		logger.Warn("Claim rejected for task %s", taskID)
-		releaseAdmission()
+		svc.queue.withdraw(ticket)
 		return taskRecord, claimErr
 	}
 
@@ -97,7 +102,7 @@ func (svc *TaskExecutionService) ExecuteTaskAsync(ctx context.Context, task stri
 
 	taskCopy := *taskRecord
 	async.Go(svc.logger, "server.executeTask", func() {
-		svc.executeTaskInBackground(taskCtx, taskID, task, confirmedSessionID, agentPreset, toolPreset, releaseAdmission)
+		svc.executeTaskInBackground(taskCtx, taskID, task, confirmedSessionID, agentPreset, toolPreset, ticket)
 	})
 
 	logger.Debug("Task created: task_id=%s session_id=%s", taskID, taskSessionID)
@@ -113,6 +118,7 @@ type taskExecContext struct {
 	toolPreset  string
 	parentRunID string
 	startTime   time.Time
+	queueWait   time.Duration
 }
 
 // baseProps returns the common analytics properties shared across all task
@@ -123,6 +129,9 @@ func (tc *taskExecContext) baseProps() map[string]any {
 		"session_id":  tc.sessionID,
 		"duration_ms": time.Since(tc.startTime).Milliseconds(),
 	}
+	if tc.queueWait > 0 {
+		props["queue_wait_ms"] = tc.queueWait.Milliseconds()
+	}
 	if tc.parentRunID != "" {
 		props["parent_run_id"] = tc.parentRunID
 	}
@@ -135,7 +144,8 @@ func (tc *taskExecContext) baseProps() map[string]any {
 	return props
 }
 
-// executeTaskInBackground runs the actual task execution in a background goroutine.
+// executeTaskInBackground waits for the task's queue slot and then runs it in
+// a background goroutine. A nil ticket queues the task at normal priority.
 func (svc *TaskExecutionService) executeTaskInBackground(
 	ctx context.Context,
 	taskID string,
@@ -143,7 +153,7 @@ func (svc *TaskExecutionService) executeTaskInBackground(
 	sessionID string,
 	agentPreset string,
 	toolPreset string,
-	releaseAdmission func(),
+	ticket *queueTicket,
 ) {
 	svc.taskWg.Add(1)
 	defer svc.taskWg.Done()
@@ -151,10 +161,11 @@ func (svc *TaskExecutionService) executeTaskInBackground(
 	logger := logging.FromContext(ctx, svc.logger)
 	stopLeaseRenew := svc.startTaskLeaseRenewer(ctx, taskID)
 
+	var releaseSlot func()
 	defer func() {
 		stopLeaseRenew()
-		if releaseAdmission != nil {
-			releaseAdmission()
+		if releaseSlot != nil {
+			releaseSlot()
 		}
 		if err := svc.taskStore.ReleaseTaskLease(context.Background(), taskID, svc.ownerID); err != nil {
 			logger.Warn("Failed to release lease for task %s: %v", taskID, err)
@@ -172,19 +183,22 @@ func (svc *TaskExecutionService) executeTaskInBackground(
 		}
 	}()
 
-	if releaseAdmission == nil {
-		acquiredRelease, err := svc.acquireAdmission(ctx)
-		if err != nil {
-			if ctx.Err() != nil {
-				_ = svc.taskStore.SetStatus(context.Background(), taskID, serverPorts.TaskStatusCancelled)
-				_ = svc.taskStore.SetTerminationReason(context.Background(), taskID, serverPorts.TerminationReasonCancelled)
-			} else {
-				_ = svc.taskStore.SetError(context.Background(), taskID, UnavailableError("task admission failed"))
-			}
-			return
+	if ticket == nil {
+		ticket = &queueTicket{taskID: taskID, sessionID: sessionID, parentRunID: id.ParentRunIDFromContext(ctx)}
+		_ = svc.queue.enqueue(ticket, false)
+		if err := svc.taskStore.SetQueued(context.Background(), taskID, ticket.priority, ticket.queuedAt); err != nil {
+			logger.Warn("Failed to record queue entry for task %s: %v", taskID, err)
 		}
-		releaseAdmission = acquiredRelease
 	}
+	acquiredRelease, err := svc.queue.wait(ctx, ticket)
+	if err != nil {
+		// Cancelled while queued: the task never starts.
+		logger.Info("Task cancelled while queued: task_id=%s reason=%v", taskID, err)
+		_ = svc.taskStore.SetStatus(context.Background(), taskID, serverPorts.TaskStatusCancelled)
+		_ = svc.taskStore.SetTerminationReason(context.Background(), taskID, serverPorts.TerminationReasonCancelled)
+		return
+	}
+	releaseSlot = acquiredRelease
 
 	logger.Debug("Starting task execution: task_id=%s session_id=%s", taskID, sessionID)
 
@@ -195,6 +209,7 @@ func (svc *TaskExecutionService) executeTaskInBackground(
 		toolPreset:  toolPreset,
 		parentRunID: id.ParentRunIDFromContext(ctx),
 		startTime:   time.Now(),
+		queueWait:   time.Since(ticket.queuedAt),
 	}
 
 	status := "success"
@@ -247,13 +262,26 @@ func (svc *TaskExecutionService) executeTaskInBackground(
 		logger.Debug("Using presets: agent=%s tool=%s", agentPreset, toolPreset)
 	}
 
-	var listener agent.EventListener = svc.broadcaster
-	if svc.progressTracker != nil {
-		listener = NewMultiEventListener(svc.broadcaster, svc.progressTracker)
+	scopedCtx, err := svc.workspace.scope(ctx)
+	if err != nil {
+		svc.handleTaskFailed(ctx, tc, err, logger, &status, &spanErr)
+		return
+	}
+	ctx = scopedCtx
+
+	if svc.analyticsTracker != nil {
+		svc.analyticsTracker.RegisterRun(ctx, taskID, analytics.TaskLifecycleOptions{
+			Channel: "web",
+			Preset:  agentPreset,
+			Toolset: toolPreset,
+		})
+		defer svc.analyticsTracker.UnregisterRun(taskID)
 	}
 
+	listener := svc.eventSink()
 	ctx = builtinshared.WithParentListener(ctx, listener)
 	result, err := svc.agentCoordinator.ExecuteTask(ctx, task, sessionID, listener)
+	svc.flushEventBus(ctx, logger)
 
 	if ctx.Err() != nil {
 		svc.handleTaskCancelled(ctx, tc, logger, &status, &spanErr)
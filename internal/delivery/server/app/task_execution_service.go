@@ -6,6 +6,7 @@ import (
 	"sync"
 	"time"
 
+	"alex/internal/app/agent/eventbus"
 	serverPorts "alex/internal/delivery/server/ports"
 	"alex/internal/infra/analytics"
 	"alex/internal/infra/observability"
@@ -16,6 +17,7 @@ const (
 	defaultTaskLeaseTTL           = 45 * time.Second
 	defaultTaskLeaseRenewInterval = 15 * time.Second
 	defaultTaskMaxInFlight        = 64
+	defaultTaskMaxQueued          = 256
 	defaultResumeClaimBatchSize   = 128
 )
 
@@ -41,6 +43,8 @@ type TaskExecutionService struct {
 	agentCoordinator AgentExecutor
 	broadcaster      *EventBroadcaster
 	progressTracker  *TaskProgressTracker
+	analyticsTracker *TaskAnalyticsTracker
+	eventBus         *eventbus.Bus
 	taskStore        serverPorts.TaskStore
 	stateStore       interface {
 		Init(ctx context.Context, sessionID string) error
@@ -59,7 +63,8 @@ type TaskExecutionService struct {
 	leaseTTL             time.Duration
 	leaseRenewInterval   time.Duration
 	resumeClaimBatchSize int
-	admissionSem         chan struct{}
+	queue                *taskQueue
+	workspace            TaskWorkspaceConfig
 }
 
 // SessionTaskSummary captures task_count/last_task style metadata for a session.
@@ -92,13 +97,14 @@ func NewTaskExecutionService(
 		leaseTTL:             defaultTaskLeaseTTL,
 		leaseRenewInterval:   defaultTaskLeaseRenewInterval,
 		resumeClaimBatchSize: defaultResumeClaimBatchSize,
-		admissionSem:         make(chan struct{}, defaultTaskMaxInFlight),
+		queue:                newTaskQueue(defaultTaskMaxInFlight, defaultTaskMaxQueued),
 	}
 	for _, opt := range opts {
 		if opt != nil {
 			opt(svc)
 		}
 	}
+	svc.queue.onPosition = svc.emitQueuedEvent
 	return svc
 }
 
@@ -130,6 +136,23 @@ func WithTaskProgressTracker(tracker *TaskProgressTracker) TaskExecutionServiceO
 	}
 }
 
+// WithTaskAnalyticsTracker wires the tracker that captures task lifecycle
+// analytics from task events.
+func WithTaskAnalyticsTracker(tracker *TaskAnalyticsTracker) TaskExecutionServiceOption {
+	return func(svc *TaskExecutionService) {
+		svc.analyticsTracker = tracker
+	}
+}
+
+// WithTaskEventBus routes task events through an async event bus. The
+// broadcaster and both trackers must be subscribed to the bus by the caller;
+// the service then publishes each event once instead of calling them directly.
+func WithTaskEventBus(bus *eventbus.Bus) TaskExecutionServiceOption {
+	return func(svc *TaskExecutionService) {
+		svc.eventBus = bus
+	}
+}
+
 // WithTaskStateStore wires a state store for session init.
 func WithTaskStateStore(store interface {
 	Init(ctx context.Context, sessionID string) error
@@ -171,15 +194,19 @@ func WithTaskLeaseConfig(ttl, renewInterval time.Duration) TaskExecutionServiceO
 	}
 }
 
-// WithTaskAdmissionLimit configures global in-flight task admission.
-// maxInFlight <= 0 disables the admission limiter.
+// WithTaskAdmissionLimit configures how many tasks run concurrently; the
+// rest wait in the priority queue. maxInFlight <= 0 disables the limit.
 func WithTaskAdmissionLimit(maxInFlight int) TaskExecutionServiceOption {
 	return func(svc *TaskExecutionService) {
-		if maxInFlight <= 0 {
-			svc.admissionSem = nil
-			return
-		}
-		svc.admissionSem = make(chan struct{}, maxInFlight)
+		svc.queue.slots = maxInFlight
+	}
+}
+
+// WithTaskQueueLimit configures how many submitted tasks may wait for a run
+// slot before new submissions are rejected. maxQueued <= 0 disables the limit.
+func WithTaskQueueLimit(maxQueued int) TaskExecutionServiceOption {
+	return func(svc *TaskExecutionService) {
+		svc.queue.maxQueued = maxQueued
 	}
 }
 
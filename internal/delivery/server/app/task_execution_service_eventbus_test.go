@@ -0,0 +1,192 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"alex/internal/app/agent/eventbus"
+	serverPorts "alex/internal/delivery/server/ports"
+	"alex/internal/domain/agent"
+	agent "alex/internal/domain/agent/ports/agent"
+	"alex/internal/domain/agent/types"
+	"alex/internal/infra/analytics"
+	sessionstate "alex/internal/infra/session/state_store"
+	id "alex/internal/shared/utils/id"
+)
+
+// emittingAgentCoordinator publishes a fixed number of events before returning.
+type emittingAgentCoordinator struct {
+	*MockAgentCoordinator
+	count int
+}
+
+func (c *emittingAgentCoordinator) ExecuteTask(ctx context.Context, task string, sessionID string, listener agent.EventListener) (*agent.TaskResult, error) {
+	for i := 0; i < c.count; i++ {
+		listener.OnEvent(&domain.WorkflowEventEnvelope{
+			BaseEvent: domain.NewBaseEvent(agent.LevelCore, sessionID, "run", "", time.Now()),
+			Event:     fmt.Sprintf("test.step.%d", i),
+		})
+	}
+	return c.MockAgentCoordinator.ExecuteTask(ctx, task, sessionID, listener)
+}
+
+func TestTaskExecutionServiceRoutesEventsThroughBus(t *testing.T) {
+	sessionStore := NewMockSessionStore()
+	taskStore := NewInMemoryTaskStore()
+	broadcaster := NewEventBroadcaster()
+	coordinator := &emittingAgentCoordinator{MockAgentCoordinator: NewMockAgentCoordinator(sessionStore), count: 20}
+
+	bus := eventbus.New(nil)
+	if _, err := bus.Subscribe(eventbus.Subscription{Name: "broadcaster", Consumer: eventbus.ListenerConsumer(broadcaster), QueueSize: 2}); err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+	defer func() { _ = bus.Close(context.Background()) }()
+
+	tasks, _, _ := buildServices(coordinator, broadcaster, sessionStore, taskStore, sessionstate.NewInMemoryStore(),
+		[]TaskExecutionServiceOption{WithTaskEventBus(bus)}, nil, nil)
+
+	task, err := tasks.ExecuteTaskAsync(context.Background(), "hello", "", "", "")
+	if err != nil {
+		t.Fatalf("ExecuteTaskAsync failed: %v", err)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		stored, err := taskStore.Get(context.Background(), task.ID)
+		if err == nil && stored.Status == serverPorts.TaskStatusCompleted {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// The task is only marked complete after the bus flush, so the journal
+	// must already hold every event, in publish order.
+	history := broadcaster.GetEventHistory(task.SessionID)
+	if len(history) != coordinator.count+1 {
+		t.Fatalf("expected %d events in history, got %d", coordinator.count+1, len(history))
+	}
+	if history[0].EventType() != types.EventInputReceived {
+		t.Fatalf("expected input received first, got %s", history[0].EventType())
+	}
+	for i, event := range history[1:] {
+		if want := fmt.Sprintf("test.step.%d", i); event.EventType() != want {
+			t.Fatalf("event %d = %s, want %s", i, event.EventType(), want)
+		}
+	}
+}
+
+// iteratingAgentCoordinator emits one node-started event per iteration and a
+// final result event for the run in ctx.
+type iteratingAgentCoordinator struct {
+	*MockAgentCoordinator
+	iterations int
+}
+
+func (c *iteratingAgentCoordinator) ExecuteTask(ctx context.Context, task string, sessionID string, listener agent.EventListener) (*agent.TaskResult, error) {
+	base := func() domain.BaseEvent {
+		return domain.NewBaseEvent(agent.LevelCore, sessionID, id.RunIDFromContext(ctx), "", time.Now())
+	}
+	for i := 1; i <= c.iterations; i++ {
+		listener.OnEvent(domain.NewNodeStartedEvent(base(), i, c.iterations, i, "", nil, nil))
+	}
+	listener.OnEvent(domain.NewResultFinalEvent(base(), "done", c.iterations, 10*c.iterations, "completed", time.Second, false, false, nil))
+	return c.MockAgentCoordinator.ExecuteTask(ctx, task, sessionID, listener)
+}
+
+// lockedAnalytics records captures from the bus and execution goroutines.
+type lockedAnalytics struct {
+	mu     sync.Mutex
+	events map[string]map[string]any
+}
+
+func (a *lockedAnalytics) Capture(_ context.Context, _ string, event string, properties map[string]any) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.events[event] = properties
+	return nil
+}
+
+func (a *lockedAnalytics) Close() error { return nil }
+
+func (a *lockedAnalytics) captured(event string) (map[string]any, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	props, ok := a.events[event]
+	return props, ok
+}
+
+func TestTaskExecutionServiceSaturatedBusKeepsFinalState(t *testing.T) {
+	sessionStore := NewMockSessionStore()
+	taskStore := NewInMemoryTaskStore()
+	broadcaster := NewEventBroadcaster()
+	coordinator := &iteratingAgentCoordinator{MockAgentCoordinator: NewMockAgentCoordinator(sessionStore), iterations: 30}
+	client := &lockedAnalytics{events: make(map[string]map[string]any)}
+	progress := NewTaskProgressTracker(taskStore)
+	lifecycle := NewTaskAnalyticsTracker(client)
+
+	// One-slot queues behind slow consumers keep every subscriber saturated
+	// for the whole run.
+	slow := func(listener agent.EventListener) eventbus.Consumer {
+		return eventbus.ConsumerFunc(func(event agent.AgentEvent) error {
+			time.Sleep(time.Millisecond)
+			listener.OnEvent(event)
+			return nil
+		})
+	}
+	bus := eventbus.New(nil)
+	for _, sub := range []eventbus.Subscription{
+		{Name: "broadcaster", Consumer: eventbus.ListenerConsumer(broadcaster), QueueSize: 1},
+		{Name: "progress_tracker", Consumer: slow(progress), QueueSize: 1},
+		{Name: "analytics", Consumer: slow(lifecycle), QueueSize: 1},
+	} {
+		if _, err := bus.Subscribe(sub); err != nil {
+			t.Fatalf("Subscribe %s: %v", sub.Name, err)
+		}
+	}
+	defer func() { _ = bus.Close(context.Background()) }()
+
+	tasks, _, _ := buildServices(coordinator, broadcaster, sessionStore, taskStore, sessionstate.NewInMemoryStore(),
+		[]TaskExecutionServiceOption{
+			WithTaskEventBus(bus),
+			WithTaskProgressTracker(progress),
+			WithTaskAnalyticsTracker(lifecycle),
+			WithTaskAnalytics(client),
+		}, nil, nil)
+
+	task, err := tasks.ExecuteTaskAsync(context.Background(), "hello", "", "", "")
+	if err != nil {
+		t.Fatalf("ExecuteTaskAsync failed: %v", err)
+	}
+
+	var stored *serverPorts.Task
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		stored, err = taskStore.Get(context.Background(), task.ID)
+		if err == nil && stored.Status == serverPorts.TaskStatusCompleted {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if stored == nil || stored.Status != serverPorts.TaskStatusCompleted {
+		t.Fatalf("task did not complete: %+v", stored)
+	}
+
+	for _, stats := range bus.Stats() {
+		if stats.Dropped != 0 {
+			t.Fatalf("subscriber %s dropped %d events", stats.Name, stats.Dropped)
+		}
+	}
+	if stored.CurrentIteration != coordinator.iterations {
+		t.Fatalf("expected final progress iteration %d, got %d", coordinator.iterations, stored.CurrentIteration)
+	}
+	props, ok := client.captured(analytics.EventTaskCompleted)
+	if !ok {
+		t.Fatalf("expected %s capture from the bus", analytics.EventTaskCompleted)
+	}
+	if props["run_id"] != task.ID || props["iterations"] != coordinator.iterations {
+		t.Fatalf("unexpected lifecycle props: %+v", props)
+	}
+}
@@ -68,33 +68,33 @@ func (t *TaskProgressTracker) OnEvent(event agent.AgentEvent) {
 		iter := intFromPayload(e.Payload, "iteration")
 		switch e.EventType() {
 		case types.EventNodeStarted:
-			task, err := t.taskStore.Get(ctx, taskID)
-			if err == nil {
-				_ = t.taskStore.UpdateProgress(ctx, taskID, iter, task.TokensUsed)
-			}
+			t.updateProgress(ctx, taskID, iter, -1)
 		case types.EventNodeCompleted:
-			tokens := intFromPayload(e.Payload, "tokens_used")
-			_ = t.taskStore.UpdateProgress(ctx, taskID, iter, tokens)
+			t.updateProgress(ctx, taskID, iter, intFromPayload(e.Payload, "tokens_used"))
 		case types.EventResultFinal:
-			totalIters := intFromPayload(e.Payload, "total_iterations")
-			totalTokens := intFromPayload(e.Payload, "total_tokens")
-			_ = t.taskStore.UpdateProgress(ctx, taskID, totalIters, totalTokens)
+			t.updateProgress(ctx, taskID, intFromPayload(e.Payload, "total_iterations"), intFromPayload(e.Payload, "total_tokens"))
 		}
 	case *domain.Event:
 		switch e.Kind {
 		case types.EventResultFinal:
-			_ = t.taskStore.UpdateProgress(ctx, taskID, e.Data.TotalIterations, e.Data.TotalTokens)
+			t.updateProgress(ctx, taskID, e.Data.TotalIterations, e.Data.TotalTokens)
 		case types.EventNodeCompleted:
-			_ = t.taskStore.UpdateProgress(ctx, taskID, e.Data.Iteration, e.Data.TokensUsed)
+			t.updateProgress(ctx, taskID, e.Data.Iteration, e.Data.TokensUsed)
 		case types.EventNodeStarted:
-			task, err := t.taskStore.Get(ctx, taskID)
-			if err == nil {
-				_ = t.taskStore.UpdateProgress(ctx, taskID, e.Data.Iteration, task.TokensUsed)
-			}
+			t.updateProgress(ctx, taskID, e.Data.Iteration, -1)
 		}
 	}
 }
 
+// updateProgress merges progress into the task without clobbering a result
+// written concurrently by the execution goroutine. A negative tokensUsed
+// keeps the stored token count.
+func (t *TaskProgressTracker) updateProgress(ctx context.Context, taskID string, iteration, tokensUsed int) {
+	if err := updateTask(ctx, t.taskStore, taskID, mergeTaskProgress(iteration, tokensUsed)); err != nil {
+		t.logger.Debug("Task progress update skipped: task_id=%s err=%v", taskID, err)
+	}
+}
+
 // RegisterRunSession associates a runID with a sessionID for progress tracking.
 func (t *TaskProgressTracker) RegisterRunSession(sessionID, runID string) {
 	t.mu.Lock()
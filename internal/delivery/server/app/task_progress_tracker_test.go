@@ -101,6 +101,34 @@ func TestTrackerUpdatesOnResultFinal(t *testing.T) {
 	}
 }
 
+func TestTrackerDoesNotOverwriteResultTotals(t *testing.T) {
+	tracker, store := newTestTracker(t)
+
+	ctx := context.Background()
+	task, err := store.Create(ctx, "session-1", "test task", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tracker.RegisterRunSession("session-1", task.ID)
+	if err := store.SetResult(ctx, task.ID, &agent.TaskResult{SessionID: "session-1", Iterations: 6, TokensUsed: 2000}); err != nil {
+		t.Fatal(err)
+	}
+
+	tracker.OnEvent(&domain.WorkflowEventEnvelope{
+		BaseEvent: domain.NewBaseEvent(agent.LevelCore, "session-1", task.ID, "", time.Now()),
+		Event:     types.EventNodeCompleted,
+		Payload:   map[string]any{"iteration": 3, "tokens_used": 900},
+	})
+
+	got, err := store.Get(ctx, task.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.TokensUsed != 2000 {
+		t.Fatalf("late progress overwrote result tokens: got %d", got.TokensUsed)
+	}
+}
+
 func TestTrackerIgnoresUnregisteredSessions(t *testing.T) {
 	tracker, store := newTestTracker(t)
 
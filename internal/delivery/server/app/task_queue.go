@@ -0,0 +1,208 @@
+package app
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// queueRunEMAWeight is the weight of the newest run when updating the
+// average run duration used for wait estimates.
+const queueRunEMAWeight = 0.3
+
+// taskQueue admits tasks in priority order while bounding how many run at
+// once. Waiting tasks are ordered by priority (higher first), then by arrival.
+type taskQueue struct {
+	mu        sync.Mutex
+	slots     int // max concurrently running tasks; <= 0 means unlimited
+	maxQueued int // max waiting tasks; <= 0 means unlimited
+	running   int
+	waiting   []*queueTicket
+	seq       uint64
+	avgRun    time.Duration
+
+	// onPosition is called outside the lock whenever a waiting ticket's
+	// position changes.
+	onPosition func(ticket *queueTicket, position int, wait time.Duration)
+}
+
+// queueTicket is one task's place in the queue.
+type queueTicket struct {
+	taskID      string
+	sessionID   string
+	parentRunID string
+	priority    int
+	seq         uint64
+	queuedAt    time.Time
+	ready       chan struct{}
+	admitted    bool
+	position    int
+}
+
+// queuePosition is a pending onPosition notification.
+type queuePosition struct {
+	ticket   *queueTicket
+	position int
+	wait     time.Duration
+}
+
+func newTaskQueue(slots, maxQueued int) *taskQueue {
+	return &taskQueue{slots: slots, maxQueued: maxQueued}
+}
+
+// enqueue adds a ticket, admitting it immediately when a slot is free.
+// bounded tickets are rejected with ErrUnavailable when the waiting list is
+// full; resumed tasks pass bounded=false because they were accepted earlier.
+func (q *taskQueue) enqueue(ticket *queueTicket, bounded bool) error {
+	q.mu.Lock()
+	q.seq++
+	ticket.seq = q.seq
+	ticket.ready = make(chan struct{})
+	if ticket.queuedAt.IsZero() {
+		ticket.queuedAt = time.Now()
+	}
+	if q.slots <= 0 || (q.running < q.slots && len(q.waiting) == 0) {
+		q.admitLocked(ticket)
+		q.mu.Unlock()
+		return nil
+	}
+	if bounded && q.maxQueued > 0 && len(q.waiting) >= q.maxQueued {
+		q.mu.Unlock()
+		return UnavailableError("task queue is full")
+	}
+	idx := sort.Search(len(q.waiting), func(i int) bool {
+		return queuedBefore(ticket, q.waiting[i])
+	})
+	q.waiting = append(q.waiting, nil)
+	copy(q.waiting[idx+1:], q.waiting[idx:])
+	q.waiting[idx] = ticket
+	updates := q.positionsLocked()
+	q.mu.Unlock()
+	q.notify(updates)
+	return nil
+}
+
+// wait blocks until the ticket is admitted and returns the release func for
+// its slot. If ctx ends first the ticket is withdrawn and never admitted.
+func (q *taskQueue) wait(ctx context.Context, ticket *queueTicket) (func(), error) {
+	select {
+	case <-ticket.ready:
+	case <-ctx.Done():
+	}
+	if err := ctx.Err(); err != nil {
+		q.withdraw(ticket)
+		return nil, context.Cause(ctx)
+	}
+	startedAt := time.Now()
+	var once sync.Once
+	return func() {
+		once.Do(func() { q.release(time.Since(startedAt)) })
+	}, nil
+}
+
+// withdraw removes a ticket that will not run, freeing its slot if it was
+// already admitted.
+func (q *taskQueue) withdraw(ticket *queueTicket) {
+	q.mu.Lock()
+	if ticket.admitted {
+		q.running--
+	} else {
+		for i, waiting := range q.waiting {
+			if waiting == ticket {
+				q.waiting = append(q.waiting[:i], q.waiting[i+1:]...)
+				break
+			}
+		}
+	}
+	q.promoteLocked()
+	updates := q.positionsLocked()
+	q.mu.Unlock()
+	q.notify(updates)
+}
+
+func (q *taskQueue) release(ran time.Duration) {
+	q.mu.Lock()
+	q.running--
+	if q.avgRun == 0 {
+		q.avgRun = ran
+	} else {
+		q.avgRun = time.Duration(queueRunEMAWeight*float64(ran) + (1-queueRunEMAWeight)*float64(q.avgRun))
+	}
+	q.promoteLocked()
+	updates := q.positionsLocked()
+	q.mu.Unlock()
+	q.notify(updates)
+}
+
+func (q *taskQueue) admitLocked(ticket *queueTicket) {
+	q.running++
+	ticket.admitted = true
+	ticket.position = 0
+	close(ticket.ready)
+}
+
+func (q *taskQueue) promoteLocked() {
+	for len(q.waiting) > 0 && (q.slots <= 0 || q.running < q.slots) {
+		next := q.waiting[0]
+		q.waiting = q.waiting[1:]
+		q.admitLocked(next)
+	}
+}
+
+// positionsLocked records the 1-based position of every waiting ticket and
+// returns the ones that changed.
+func (q *taskQueue) positionsLocked() []queuePosition {
+	var updates []queuePosition
+	for i, ticket := range q.waiting {
+		position := i + 1
+		if ticket.position == position {
+			continue
+		}
+		ticket.position = position
+		updates = append(updates, queuePosition{ticket: ticket, position: position, wait: q.estimateLocked(position)})
+	}
+	return updates
+}
+
+// estimateLocked approximates the wait for position from the average run
+// duration; it is zero until a run has completed.
+func (q *taskQueue) estimateLocked(position int) time.Duration {
+	if q.slots <= 0 || q.avgRun <= 0 {
+		return 0
+	}
+	rounds := (position + q.slots - 1) / q.slots
+	return time.Duration(rounds) * q.avgRun
+}
+
+func (q *taskQueue) notify(updates []queuePosition) {
+	if q.onPosition == nil {
+		return
+	}
+	for _, update := range updates {
+		q.onPosition(update.ticket, update.position, update.wait)
+	}
+}
+
+func queuedBefore(a, b *queueTicket) bool {
+	if a.priority != b.priority {
+		return a.priority > b.priority
+	}
+	return a.seq < b.seq
+}
+
+type taskPriorityKey struct{}
+
+// WithTaskPriority returns a context whose submitted task is queued with the
+// given priority (see serverPorts.TaskPriorityHigh and friends).
+func WithTaskPriority(ctx context.Context, priority int) context.Context {
+	return context.WithValue(ctx, taskPriorityKey{}, priority)
+}
+
+func taskPriorityFromContext(ctx context.Context) int {
+	if ctx == nil {
+		return 0
+	}
+	priority, _ := ctx.Value(taskPriorityKey{}).(int)
+	return priority
+}
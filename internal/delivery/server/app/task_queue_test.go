@@ -0,0 +1,222 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	serverPorts "alex/internal/delivery/server/ports"
+	domain "alex/internal/domain/agent"
+	agent "alex/internal/domain/agent/ports/agent"
+	"alex/internal/domain/agent/types"
+	sessionstate "alex/internal/infra/session/state_store"
+)
+
+func TestTaskQueueAdmitsByPriorityThenArrival(t *testing.T) {
+	q := newTaskQueue(1, 0)
+	positions := map[string]int{}
+	q.onPosition = func(ticket *queueTicket, position int, _ time.Duration) {
+		positions[ticket.taskID] = position
+	}
+
+	running := &queueTicket{taskID: "running"}
+	low := &queueTicket{taskID: "low", priority: serverPorts.TaskPriorityLow}
+	normal := &queueTicket{taskID: "normal"}
+	high := &queueTicket{taskID: "high", priority: serverPorts.TaskPriorityHigh}
+	for _, ticket := range []*queueTicket{running, low, normal, high} {
+		if err := q.enqueue(ticket, true); err != nil {
+			t.Fatalf("enqueue %s: %v", ticket.taskID, err)
+		}
+	}
+	if positions["high"] != 1 || positions["normal"] != 2 || positions["low"] != 3 {
+		t.Fatalf("unexpected positions %v", positions)
+	}
+
+	release, err := q.wait(context.Background(), running)
+	if err != nil {
+		t.Fatalf("wait running: %v", err)
+	}
+	release()
+	select {
+	case <-high.ready:
+	default:
+		t.Fatal("expected high priority ticket to be admitted first")
+	}
+	if positions["normal"] != 1 || positions["low"] != 2 {
+		t.Fatalf("expected positions to move up after release, got %v", positions)
+	}
+}
+
+func TestTaskQueueRejectsWhenFullUnlessUnbounded(t *testing.T) {
+	q := newTaskQueue(1, 1)
+	if err := q.enqueue(&queueTicket{taskID: "a"}, true); err != nil {
+		t.Fatalf("enqueue a: %v", err)
+	}
+	if err := q.enqueue(&queueTicket{taskID: "b"}, true); err != nil {
+		t.Fatalf("enqueue b: %v", err)
+	}
+	if err := q.enqueue(&queueTicket{taskID: "c"}, true); !errors.Is(err, ErrUnavailable) {
+		t.Fatalf("expected ErrUnavailable for full queue, got %v", err)
+	}
+	if err := q.enqueue(&queueTicket{taskID: "resumed"}, false); err != nil {
+		t.Fatalf("expected unbounded enqueue to succeed, got %v", err)
+	}
+}
+
+func TestTaskQueueCancelledTicketIsNeverAdmitted(t *testing.T) {
+	q := newTaskQueue(1, 0)
+	first := &queueTicket{taskID: "first"}
+	queued := &queueTicket{taskID: "queued"}
+	_ = q.enqueue(first, true)
+	_ = q.enqueue(queued, true)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := q.wait(ctx, queued); err == nil {
+		t.Fatal("expected cancelled wait to fail")
+	}
+	release, _ := q.wait(context.Background(), first)
+	release()
+
+	if queued.admitted || len(q.waiting) != 0 || q.running != 0 {
+		t.Fatalf("cancelled ticket must not be admitted: admitted=%v waiting=%d running=%d", queued.admitted, len(q.waiting), q.running)
+	}
+}
+
+func TestTaskQueueEstimatesWaitFromRunDurations(t *testing.T) {
+	q := newTaskQueue(2, 0)
+	q.avgRun = 10 * time.Second
+	if got := q.estimateLocked(1); got != 10*time.Second {
+		t.Fatalf("position 1 wait = %s", got)
+	}
+	if got := q.estimateLocked(3); got != 20*time.Second {
+		t.Fatalf("position 3 wait = %s", got)
+	}
+}
+
+// gatedAgentCoordinator blocks every execution until gate is closed and
+// records which tasks started.
+type gatedAgentCoordinator struct {
+	*MockAgentCoordinator
+	gate    chan struct{}
+	mu      sync.Mutex
+	started []string
+}
+
+func (c *gatedAgentCoordinator) ExecuteTask(ctx context.Context, task string, sessionID string, listener agent.EventListener) (*agent.TaskResult, error) {
+	c.mu.Lock()
+	c.started = append(c.started, task)
+	c.mu.Unlock()
+	select {
+	case <-c.gate:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	return c.MockAgentCoordinator.ExecuteTask(ctx, task, sessionID, listener)
+}
+
+func (c *gatedAgentCoordinator) startedTasks() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]string(nil), c.started...)
+}
+
+func TestTaskExecutionService_CancelWhileQueuedNeverStarts(t *testing.T) {
+	ctx := context.Background()
+	sessionStore := NewMockSessionStore()
+	taskStore := NewInMemoryTaskStore()
+	defer taskStore.Close()
+	broadcaster := NewEventBroadcaster()
+	coordinator := &gatedAgentCoordinator{MockAgentCoordinator: NewMockAgentCoordinator(sessionStore), gate: make(chan struct{})}
+
+	svc := NewTaskExecutionService(
+		coordinator,
+		broadcaster,
+		taskStore,
+		WithTaskStateStore(sessionstate.NewInMemoryStore()),
+		WithTaskAdmissionLimit(1),
+	)
+
+	first, err := svc.ExecuteTaskAsync(ctx, "first", "", "", "")
+	if err != nil {
+		t.Fatalf("submit first: %v", err)
+	}
+	waitForTaskStatus(t, taskStore, first.ID, serverPorts.TaskStatusRunning)
+
+	events := make(chan agent.AgentEvent, 16)
+	broadcaster.RegisterClient(first.SessionID, events)
+	defer broadcaster.UnregisterClient(first.SessionID, events)
+
+	queued, err := svc.ExecuteTaskAsync(ctx, "queued", first.SessionID, "", "")
+	if err != nil {
+		t.Fatalf("submit queued: %v", err)
+	}
+	if queued.QueuedAt == nil {
+		t.Fatal("expected queued_at on submitted task")
+	}
+	if position := waitForQueuedPosition(t, events, queued.ID); position != 1 {
+		t.Fatalf("expected queue position 1, got %d", position)
+	}
+
+	if err := svc.CancelTask(ctx, queued.ID); err != nil {
+		t.Fatalf("cancel queued: %v", err)
+	}
+	waitForTaskStatus(t, taskStore, queued.ID, serverPorts.TaskStatusCancelled)
+
+	close(coordinator.gate)
+	waitForTaskStatus(t, taskStore, first.ID, serverPorts.TaskStatusCompleted)
+	svc.taskWg.Wait()
+
+	if started := coordinator.startedTasks(); len(started) != 1 || started[0] != "first" {
+		t.Fatalf("cancelled queued task must never start, started=%v", started)
+	}
+	got, err := taskStore.Get(ctx, queued.ID)
+	if err != nil {
+		t.Fatalf("get queued: %v", err)
+	}
+	if got.StartedAt != nil {
+		t.Fatalf("expected no started_at for task cancelled in queue, got %v", got.StartedAt)
+	}
+	done, err := taskStore.Get(ctx, first.ID)
+	if err != nil {
+		t.Fatalf("get first: %v", err)
+	}
+	if done.QueuedAt == nil || done.StartedAt == nil || done.StartedAt.Before(*done.QueuedAt) {
+		t.Fatalf("expected queued_at <= started_at, got queued=%v started=%v", done.QueuedAt, done.StartedAt)
+	}
+}
+
+func waitForTaskStatus(t *testing.T, store serverPorts.TaskStore, taskID string, status serverPorts.TaskStatus) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if got, err := store.Get(context.Background(), taskID); err == nil && got.Status == status {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("timed out waiting for task %s to reach %s", taskID, status)
+}
+
+func waitForQueuedPosition(t *testing.T, events <-chan agent.AgentEvent, taskID string) int {
+	t.Helper()
+	timeout := time.After(2 * time.Second)
+	for {
+		select {
+		case evt := <-events:
+			if evt.EventType() != types.EventQueued || evt.GetRunID() != taskID {
+				continue
+			}
+			env, ok := evt.(*domain.WorkflowEventEnvelope)
+			if !ok {
+				continue
+			}
+			position, _ := env.Payload["position"].(int)
+			return position
+		case <-timeout:
+			t.Fatalf("timed out waiting for %s event", types.EventQueued)
+		}
+	}
+}
@@ -34,6 +34,7 @@ func (s *InMemoryTaskStore) Create(ctx context.Context, sessionID string, descri
 		Metadata:     make(map[string]string),
 		AgentPreset:  agentPreset,
 		ToolPreset:   toolPreset,
+		Revision:     1,
 	}
 
 	s.tasks[taskID] = task
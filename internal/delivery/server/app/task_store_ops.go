@@ -59,6 +59,7 @@ func (s *InMemoryTaskStore) SetStatus(ctx context.Context, taskID string, status
 		s.releaseOwnershipLocked(taskID)
 	}
 
+	task.Revision++
 	s.persistLocked()
 	return nil
 }
@@ -80,6 +81,7 @@ func (s *InMemoryTaskStore) SetError(ctx context.Context, taskID string, err err
 	task.CompletedAt = &now
 	s.releaseOwnershipLocked(taskID)
 
+	task.Revision++
 	s.persistLocked()
 	return nil
 }
@@ -110,6 +112,7 @@ func (s *InMemoryTaskStore) SetResult(ctx context.Context, taskID string, result
 		task.ParentTaskID = result.ParentRunID
 	}
 
+	task.Revision++
 	s.persistLocked()
 	return nil
 }
@@ -127,6 +130,7 @@ func (s *InMemoryTaskStore) UpdateProgress(ctx context.Context, taskID string, i
 	task.CurrentIteration = iteration
 	task.TokensUsed = tokensUsed
 
+	task.Revision++
 	s.persistLocked()
 	return nil
 }
@@ -143,6 +147,25 @@ func (s *InMemoryTaskStore) SetTerminationReason(ctx context.Context, taskID str
 
 	task.TerminationReason = reason
 
+	task.Revision++
+	s.persistLocked()
+	return nil
+}
+
+// SetQueued records the task priority and when it entered the execution queue.
+func (s *InMemoryTaskStore) SetQueued(ctx context.Context, taskID string, priority int, queuedAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	task, exists := s.tasks[taskID]
+	if !exists {
+		return NotFoundError(fmt.Sprintf("task %s", taskID))
+	}
+
+	task.Priority = priority
+	task.QueuedAt = &queuedAt
+
+	task.Revision++
 	s.persistLocked()
 	return nil
 }
@@ -244,3 +267,47 @@ func (s *InMemoryTaskStore) isTaskClaimableLocked(taskID, ownerID string, now ti
 	currentLease := s.leases[taskID]
 	return currentOwner == "" || currentOwner == ownerID || currentLease.IsZero() || currentLease.Before(now)
 }
+
+// GetWithRevision retrieves a task together with its current revision.
+func (s *InMemoryTaskStore) GetWithRevision(ctx context.Context, taskID string) (*ports.Task, int64, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	task, exists := s.tasks[taskID]
+	if !exists {
+		return nil, 0, NotFoundError(fmt.Sprintf("task %s", taskID))
+	}
+	taskCopy := *task
+	return &taskCopy, task.Revision, nil
+}
+
+// CompareAndSwap replaces the task when its revision equals expected and
+// returns the new revision. A transition to a terminal status releases
+// ownership, as SetStatus does.
+func (s *InMemoryTaskStore) CompareAndSwap(ctx context.Context, taskID string, expected int64, updated *ports.Task) (int64, error) {
+	if updated == nil {
+		return 0, fmt.Errorf("task is nil")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	task, exists := s.tasks[taskID]
+	if !exists {
+		return 0, NotFoundError(fmt.Sprintf("task %s", taskID))
+	}
+	if task.Revision != expected {
+		return 0, fmt.Errorf("task %s: expected revision %d, have %d: %w", taskID, expected, task.Revision, ports.ErrTaskConflict)
+	}
+
+	taskCopy := *updated
+	taskCopy.ID = taskID
+	taskCopy.CreatedAt = task.CreatedAt
+	taskCopy.Revision = task.Revision + 1
+	if taskCopy.Status.IsTerminal() && !task.Status.IsTerminal() {
+		s.releaseOwnershipLocked(taskID)
+	}
+	s.tasks[taskID] = &taskCopy
+	s.persistLocked()
+	return taskCopy.Revision, nil
+}
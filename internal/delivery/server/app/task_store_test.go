@@ -3,8 +3,10 @@ package app
 import (
 	"context"
 	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
+	"sync"
 	"testing"
 	"time"
 
@@ -849,3 +851,90 @@ func TestTaskStatus_IsTerminal(t *testing.T) {
 		}
 	}
 }
+
+func TestInMemoryTaskStore_CompareAndSwapRejectsStaleRevision(t *testing.T) {
+	ctx := context.Background()
+	store := NewInMemoryTaskStore()
+	defer store.Close()
+
+	task, _ := store.Create(ctx, "session-1", "cas", "", "")
+	got, rev, err := store.GetWithRevision(ctx, task.ID)
+	if err != nil {
+		t.Fatalf("GetWithRevision: %v", err)
+	}
+	if err := store.UpdateProgress(ctx, task.ID, 2, 100); err != nil {
+		t.Fatalf("UpdateProgress: %v", err)
+	}
+
+	got.Status = serverPorts.TaskStatusCompleted
+	if _, err := store.CompareAndSwap(ctx, task.ID, rev, got); !errors.Is(err, serverPorts.ErrTaskConflict) {
+		t.Fatalf("expected ErrTaskConflict, got %v", err)
+	}
+	latest, _ := store.Get(ctx, task.ID)
+	if latest.TokensUsed != 100 || latest.Status != serverPorts.TaskStatusPending {
+		t.Fatalf("stale write must not land: %+v", latest)
+	}
+}
+
+func TestUpdateTask_ConcurrentWritersLoseNoUpdates(t *testing.T) {
+	const (
+		writers    = 16
+		increments = 50
+	)
+	ctx := context.Background()
+	store := NewInMemoryTaskStore()
+	defer store.Close()
+	task, _ := store.Create(ctx, "session-1", "stress", "", "")
+
+	var wg sync.WaitGroup
+	wg.Add(writers)
+	for w := 0; w < writers; w++ {
+		go func(workerID int) {
+			defer wg.Done()
+			key := fmt.Sprintf("w%d", workerID)
+			merge := func(value string) func(*serverPorts.Task) bool {
+				return func(task *serverPorts.Task) bool {
+					task.TokensUsed++
+					metadata := make(map[string]string, len(task.Metadata)+1)
+					for k, v := range task.Metadata {
+						metadata[k] = v
+					}
+					metadata[key] = value
+					task.Metadata = metadata
+					return true
+				}
+			}
+			for i := 0; i < increments; i++ {
+				// With this many writers on one task the bounded retry can
+				// run out; the caller retries the whole update.
+				for {
+					err := updateTask(ctx, store, task.ID, merge(fmt.Sprint(i+1)))
+					if err == nil {
+						break
+					}
+					if !errors.Is(err, serverPorts.ErrTaskConflict) {
+						t.Errorf("updateTask: %v", err)
+						return
+					}
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	got, rev, err := store.GetWithRevision(ctx, task.ID)
+	if err != nil {
+		t.Fatalf("GetWithRevision: %v", err)
+	}
+	if got.TokensUsed != writers*increments {
+		t.Fatalf("TokensUsed = %d, want %d (lost updates)", got.TokensUsed, writers*increments)
+	}
+	if rev != 1+writers*increments {
+		t.Fatalf("revision = %d, want %d", rev, 1+writers*increments)
+	}
+	for w := 0; w < writers; w++ {
+		if v := got.Metadata[fmt.Sprintf("w%d", w)]; v != fmt.Sprint(increments) {
+			t.Fatalf("writer %d metadata = %q, want %d", w, v, increments)
+		}
+	}
+}
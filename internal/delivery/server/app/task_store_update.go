@@ -0,0 +1,49 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"alex/internal/delivery/server/ports"
+)
+
+// maxTaskUpdateAttempts bounds the read-merge-swap loop in updateTask. Each
+// conflict means another writer made progress, so a small bound suffices.
+const maxTaskUpdateAttempts = 16
+
+// updateTask applies merge to the latest revision of a task and writes it back
+// with CompareAndSwap, re-reading and re-merging when another writer got there
+// first. merge returns false to skip the write.
+func updateTask(ctx context.Context, store ports.TaskVersioner, taskID string, merge func(*ports.Task) bool) error {
+	for attempt := 0; attempt < maxTaskUpdateAttempts; attempt++ {
+		task, revision, err := store.GetWithRevision(ctx, taskID)
+		if err != nil {
+			return err
+		}
+		if !merge(task) {
+			return nil
+		}
+		_, err = store.CompareAndSwap(ctx, taskID, revision, task)
+		if err == nil || !errors.Is(err, ports.ErrTaskConflict) {
+			return err
+		}
+	}
+	return fmt.Errorf("update task %s after %d attempts: %w", taskID, maxTaskUpdateAttempts, ports.ErrTaskConflict)
+}
+
+// mergeTaskProgress owns CurrentIteration and, when tokensUsed is
+// non-negative, TokensUsed. Once a task is terminal its totals belong to the
+// result, so late progress events are dropped.
+func mergeTaskProgress(iteration, tokensUsed int) func(*ports.Task) bool {
+	return func(task *ports.Task) bool {
+		if task.Status.IsTerminal() {
+			return false
+		}
+		task.CurrentIteration = iteration
+		if tokensUsed >= 0 {
+			task.TokensUsed = tokensUsed
+		}
+		return true
+	}
+}
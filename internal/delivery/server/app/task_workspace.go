@@ -0,0 +1,86 @@
+package app
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"alex/internal/app/workdir"
+	"alex/internal/shared/utils/id"
+)
+
+const (
+	// WorkspaceModeGlobal keeps every task in the process working directory
+	// (single-tenant compatibility).
+	WorkspaceModeGlobal = "global"
+	// WorkspaceModePerSession gives each session its own root under Root, so
+	// tasks of one session share files and other sessions cannot see them.
+	WorkspaceModePerSession = "per_session"
+)
+
+// TaskWorkspaceConfig controls where file tools of web tasks are confined.
+type TaskWorkspaceConfig struct {
+	Mode string
+	// Root is the parent of per-session workspaces; ignored in global mode.
+	Root string
+	// QuotaBytes caps the size of each workspace (0 = unlimited).
+	QuotaBytes int64
+}
+
+// WithTaskWorkspace configures per-task workspace isolation.
+func WithTaskWorkspace(cfg TaskWorkspaceConfig) TaskExecutionServiceOption {
+	return func(svc *TaskExecutionService) {
+		svc.workspace = cfg
+	}
+}
+
+// scope attaches the workspace of the session in ctx. Global mode
+// without a quota leaves ctx untouched so file tools keep the process root.
+func (cfg TaskWorkspaceConfig) scope(ctx context.Context) (context.Context, error) {
+	var root string
+	switch strings.TrimSpace(cfg.Mode) {
+	case "", WorkspaceModeGlobal:
+		if cfg.QuotaBytes <= 0 {
+			return ctx, nil
+		}
+		root = workdir.DefaultWorkingDir()
+	case WorkspaceModePerSession:
+		if strings.TrimSpace(cfg.Root) == "" {
+			return ctx, fmt.Errorf("per-session workspace root is not configured")
+		}
+		sessionID := strings.TrimSpace(id.SessionIDFromContext(ctx))
+		if sessionID == "" {
+			return ctx, fmt.Errorf("per-session workspace requires a session ID")
+		}
+		root = filepath.Join(cfg.Root, workspaceDirName(sessionID))
+	default:
+		return ctx, fmt.Errorf("unknown workspace mode %q", cfg.Mode)
+	}
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		return ctx, fmt.Errorf("create workspace: %w", err)
+	}
+	return workdir.WithWorkspace(ctx, root, cfg.QuotaBytes), nil
+}
+
+// workspaceDirName maps a session ID to a directory name that cannot traverse
+// and cannot collide with another session's: the readable prefix is sanitized
+// and a digest of the raw ID is appended.
+func workspaceDirName(sessionID string) string {
+	prefix := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_':
+			return r
+		default:
+			return '_'
+		}
+	}, sessionID)
+	if len(prefix) > 32 {
+		prefix = prefix[:32]
+	}
+	sum := sha256.Sum256([]byte(sessionID))
+	return prefix + "-" + hex.EncodeToString(sum[:4])
+}
@@ -0,0 +1,77 @@
+package app
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"alex/internal/infra/tools/builtin/pathutil"
+	"alex/internal/shared/utils/id"
+)
+
+func TestTaskWorkspaceGlobalModeLeavesContextUnscoped(t *testing.T) {
+	ctx, err := TaskWorkspaceConfig{Mode: WorkspaceModeGlobal, Root: t.TempDir()}.scope(context.Background())
+	if err != nil {
+		t.Fatalf("scope: %v", err)
+	}
+	if _, ok := pathutil.WorkspaceFromContext(ctx); ok {
+		t.Fatal("global mode without quota must keep the process root")
+	}
+}
+
+func TestTaskWorkspacePerSessionSeparatesSessions(t *testing.T) {
+	parent := t.TempDir()
+	cfg := TaskWorkspaceConfig{Mode: WorkspaceModePerSession, Root: parent, QuotaBytes: 1024}
+
+	roots := make(map[string]string)
+	for _, session := range []string{"session-a", "session-b", "../session-b"} {
+		ctx, err := cfg.scope(id.WithSessionID(context.Background(), session))
+		if err != nil {
+			t.Fatalf("scope(%q): %v", session, err)
+		}
+		ws, ok := pathutil.WorkspaceFromContext(ctx)
+		if !ok {
+			t.Fatalf("scope(%q) did not attach a workspace", session)
+		}
+		if filepath.Dir(ws.Root) != parent {
+			t.Fatalf("workspace %q for %q is not directly under %q", ws.Root, session, parent)
+		}
+		if ws.QuotaBytes != 1024 {
+			t.Fatalf("quota = %d, want 1024", ws.QuotaBytes)
+		}
+		if info, err := os.Stat(ws.Root); err != nil || !info.IsDir() {
+			t.Fatalf("workspace %q was not created: %v", ws.Root, err)
+		}
+		for other, root := range roots {
+			if root == ws.Root {
+				t.Fatalf("sessions %q and %q share workspace %q", other, session, root)
+			}
+		}
+		roots[session] = ws.Root
+	}
+	if !strings.HasPrefix(filepath.Base(roots["session-a"]), "session-a-") {
+		t.Fatalf("workspace name should keep a readable session prefix: %q", roots["session-a"])
+	}
+
+	again, err := cfg.scope(id.WithSessionID(context.Background(), "session-a"))
+	if err != nil {
+		t.Fatalf("scope again: %v", err)
+	}
+	if ws, _ := pathutil.WorkspaceFromContext(again); ws.Root != roots["session-a"] {
+		t.Fatalf("tasks of one session should share a workspace: %q vs %q", ws.Root, roots["session-a"])
+	}
+}
+
+func TestTaskWorkspaceRejectsMisconfiguration(t *testing.T) {
+	if _, err := (TaskWorkspaceConfig{Mode: WorkspaceModePerSession}).scope(id.WithSessionID(context.Background(), "s")); err == nil {
+		t.Fatal("expected per_session mode without root to fail")
+	}
+	if _, err := (TaskWorkspaceConfig{Mode: WorkspaceModePerSession, Root: t.TempDir()}).scope(context.Background()); err == nil {
+		t.Fatal("expected per_session mode without a session ID to fail")
+	}
+	if _, err := (TaskWorkspaceConfig{Mode: "per_user"}).scope(context.Background()); err == nil {
+		t.Fatal("expected unknown mode to fail")
+	}
+}
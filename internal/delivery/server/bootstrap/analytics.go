@@ -1,6 +1,7 @@
 package bootstrap
 
 import (
+	"fmt"
 	"strings"
 
 	"alex/internal/infra/analytics"
@@ -8,14 +9,30 @@ import (
 	"alex/internal/shared/logging"
 )
 
-func BuildAnalyticsClient(cfg runtimeconfig.AnalyticsConfig, logger logging.Logger) (analytics.Client, func()) {
+const defaultAnalyticsSpoolDir = "~/.alex/analytics/spool"
+
+// BuildAnalyticsClient returns the PostHog client when an API key is
+// configured and a no-op client otherwise. When the PostHog client cannot be
+// created it still returns the no-op client and cleanup, along with the error.
+func BuildAnalyticsClient(cfg runtimeconfig.AnalyticsConfig, metrics analytics.Metrics, logger logging.Logger) (analytics.Client, func(), error) {
 	logger = logging.OrNop(logger)
 	client := analytics.NewNoopClient()
+	var buildErr error
 
 	if apiKey := strings.TrimSpace(cfg.PostHogAPIKey); apiKey != "" {
-		posthogClient, err := analytics.NewPostHogClient(apiKey, strings.TrimSpace(cfg.PostHogHost))
+		spoolDir := strings.TrimSpace(cfg.SpoolDir)
+		if spoolDir == "" {
+			spoolDir = defaultAnalyticsSpoolDir
+		}
+		posthogClient, err := analytics.NewPostHogClient(apiKey, analytics.PostHogOptions{
+			Host:          strings.TrimSpace(cfg.PostHogHost),
+			SpoolDir:      expandHome(spoolDir),
+			SpoolMaxBytes: cfg.SpoolMaxBytes,
+			Metrics:       metrics,
+			Logger:        logger,
+		})
 		if err != nil {
-			logger.Warn("Analytics disabled: %v", err)
+			buildErr = fmt.Errorf("create posthog client: %w", err)
 		} else {
 			client = posthogClient
 			logger.Info("Analytics client initialized (PostHog)")
@@ -33,5 +50,5 @@ func BuildAnalyticsClient(cfg runtimeconfig.AnalyticsConfig, logger logging.Logg
 		}
 	}
 
-	return client, cleanup
+	return client, cleanup, buildErr
 }
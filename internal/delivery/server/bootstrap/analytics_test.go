@@ -7,7 +7,10 @@ import (
 )
 
 func TestBuildAnalyticsClient_NoKeyUsesNoop(t *testing.T) {
-	client, cleanup := BuildAnalyticsClient(runtimeconfig.AnalyticsConfig{}, nil)
+	client, cleanup, err := BuildAnalyticsClient(runtimeconfig.AnalyticsConfig{}, nil, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
 	if client == nil {
 		t.Fatalf("expected client, got nil")
 	}
@@ -355,7 +355,7 @@ func TestWriteJSON_NilValue(t *testing.T) {
 func TestStartLarkGateway_DisabledConfig(t *testing.T) {
 	cfg := Config{}
 	// Lark not enabled.
-	cleanup, err := startLarkGateway(context.Background(), cfg, nil, nil, nil)
+	cleanup, err := startLarkGateway(context.Background(), cfg, nil, nil, nil, nil)
 	if err != nil {
 		t.Fatalf("disabled config should not error: %v", err)
 	}
@@ -370,7 +370,7 @@ func TestStartLarkGateway_NilContainer(t *testing.T) {
 	}
 	cfg.Channels.SetLarkConfig(LarkGatewayConfig{Enabled: true})
 
-	_, err := startLarkGateway(context.Background(), cfg, nil, nil, nil)
+	_, err := startLarkGateway(context.Background(), cfg, nil, nil, nil, nil)
 	if err == nil {
 		t.Fatal("expected error for nil container")
 	}
@@ -391,7 +391,7 @@ func TestStartLarkGateway_InvalidToolMode(t *testing.T) {
 	// Need a non-nil container to get past the container check.
 	// We use a minimal placeholder — the function will fail on tool_mode validation.
 	container := &dummyContainerForTest{}
-	_, err := startLarkGateway(context.Background(), cfg, container.asDI(), nil, nil)
+	_, err := startLarkGateway(context.Background(), cfg, container.asDI(), nil, nil, nil)
 	if err == nil {
 		t.Fatal("expected error for invalid tool_mode")
 	}
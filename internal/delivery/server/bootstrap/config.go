@@ -32,15 +32,43 @@ type Config struct {
 	LeaderAPIToken     string
 	TaskExecution      TaskExecutionConfig
 	EventHistory       EventHistoryConfig
-	Attachment         attachments.StoreConfig
+	Notifications      NotificationsConfig
+	Maintenance        MaintenanceConfig
+	// DiagnosticsHistorySize is how many diagnostics payloads are kept per kind.
+	DiagnosticsHistorySize int
+	// HealthDegradedThresholds maps /health component names to the check
+	// latency above which they are reported as degraded.
+	HealthDegradedThresholds map[string]time.Duration
+	Startup                  StartupConfig
+	Attachment               attachments.StoreConfig
+	Workspace                WorkspaceConfig
 }
 
 // EventHistoryConfig captures event history storage tuning.
 type EventHistoryConfig struct {
-	Retention   time.Duration
-	MaxSessions int
-	SessionTTL  time.Duration
-	MaxEvents   int
+	Retention    time.Duration // rolled journal segments older than this are swept
+	MaxSessions  int
+	SessionTTL   time.Duration
+	MaxEvents    int
+	SegmentMaxMB int // journal file size that triggers a roll; 0 disables rotation
+}
+
+// NotificationsConfig captures in-app notification center settings.
+type NotificationsConfig struct {
+	Types      []string // notification types to create; empty enables all
+	MaxPerUser int
+}
+
+// WorkspaceConfig captures file-tool workspace isolation for web tasks.
+type WorkspaceConfig struct {
+	Mode       string // "global" (shared process root) or "per_session"
+	Root       string // parent of per-session workspaces
+	QuotaBytes int64  // per-workspace size cap; 0 disables
+}
+
+// MaintenanceConfig captures scheduled maintenance notice settings.
+type MaintenanceConfig struct {
+	NoticeLead time.Duration // how long before a window its notice is shown
 }
 
 // StreamGuardConfig captures SSE stream guard limits.
@@ -52,9 +80,13 @@ type StreamGuardConfig struct {
 
 // RateLimitConfig captures HTTP rate limiting parameters.
 type RateLimitConfig struct {
-	RequestsPerMinute int
-	Burst             int
-	TrustedProxies    []string // CIDR ranges whose X-Forwarded-For is trusted
+	RequestsPerMinute     int
+	Burst                 int
+	TaskRequestsPerMinute int
+	TaskBurst             int
+	ExemptPaths           []string
+	RedisURL              string
+	TrustedProxies        []string // CIDR ranges whose X-Forwarded-For is trusted
 }
 
 // TaskExecutionConfig captures task admission and lease settings.
@@ -63,6 +95,7 @@ type TaskExecutionConfig struct {
 	LeaseTTL             time.Duration
 	LeaseRenewInterval   time.Duration
 	MaxInFlight          int
+	MaxQueued            int
 	ResumeClaimBatchSize int
 }
 
@@ -141,16 +174,24 @@ type LarkGatewayConfig struct {
 	Enabled                       bool
 	AppID                         string
 	AppSecret                     string
+	VerificationToken             string
+	EncryptKey                    string
 	TenantCalendarID              string
 	BaseDomain                    string
 	WorkspaceDir                  string
 	AutoUploadFiles               bool
 	AutoUploadMaxBytes            int
 	AutoUploadAllowExt            []string
+	AutoUploadInlineImageLimit    int
+	AutoUploadPostImageLimit      int
+	AutoUploadInlineImageMaxBytes int
 	Browser                       lark.BrowserConfig
 	ToolMode                      string
 	InjectionAckReactEmoji        string
 	ShowToolProgress              bool
+	ProgressEditInPlace           bool
+	ShowCost                      bool
+	ProgressUpdateInterval        time.Duration
 	SlowProgressSummaryEnabled    bool
 	SlowProgressSummaryDelay      time.Duration
 	ShowPlanClarifyMessages       bool
@@ -174,7 +215,9 @@ type LarkGatewayConfig struct {
 	DefaultPlanMode               string
 	DeliveryMode                  string
 	DeliveryWorker                lark.DeliveryWorkerConfig
+	TaskDigest                    lark.TaskDigestConfig
 	AttentionGate                 lark.AttentionGateConfig
+	SessionRotation               lark.SessionRotationConfig
 	RateLimiterEnabled            bool
 	RateLimiterChatHourlyLimit    int
 	RateLimiterUserDailyLimit     int
@@ -188,6 +231,13 @@ type LarkGatewayConfig struct {
 	ConversationProcessEnabled     bool
 	MaxConcurrentWorkers           int
 	ConversationWorkerCapabilities string
+	FollowUpQueueDepth             int
+	AwaitInputTimeoutSeconds       int
+	// Group chat mention gating
+	RequireMention bool
+	BotOpenID      string
+	// Group roles allowed to switch a chat's /preset
+	PresetAdminRoles []string
 }
 
 // HooksBridgeConfig controls the Claude Code hooks → Lark bridge endpoint.
@@ -203,6 +253,8 @@ type ConfigResult struct {
 	ConfigManager *configadmin.Manager
 	Resolver      func(context.Context) (runtimeconfig.RuntimeConfig, runtimeconfig.Metadata, error)
 	RuntimeCache  *runtimeconfig.RuntimeConfigCache
+	// Diagnostics holds the non-blocking findings of ValidateConfig.
+	Diagnostics runtimeconfig.ValidationReport
 }
 
 var defaultAllowedOrigins = []string{
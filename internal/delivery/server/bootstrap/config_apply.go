@@ -22,12 +22,37 @@ func applyServerHTTPConfig(cfg *Config, file runtimeconfig.FileConfig) {
 	applyRateLimitConfig(&cfg.RateLimit, file.Server)
 	applyTaskExecutionConfig(&cfg.TaskExecution, file.Server)
 	applyEventHistoryConfig(&cfg.EventHistory, file.Server)
+	applyNotificationsConfig(&cfg.Notifications, file.Server)
+	applyPositiveDuration(&cfg.Maintenance.NoticeLead, file.Server.MaintenanceNoticeLeadSeconds, time.Second)
+	applyPositiveInt(&cfg.DiagnosticsHistorySize, file.Server.DiagnosticsHistorySize)
+	applyWorkspaceConfig(&cfg.Workspace, file.Server)
+	applyHealthDegradedThresholds(cfg, file.Server.HealthDegradedThresholdsMS)
 	if file.Server.AllowedOrigins != nil {
 		cfg.AllowedOrigins = normalizeAllowedOrigins(file.Server.AllowedOrigins)
 	}
 	if len(file.Server.TrustedProxies) > 0 {
 		cfg.RateLimit.TrustedProxies = append([]string(nil), file.Server.TrustedProxies...)
 	}
+	if file.Server.RequiredComponents != nil {
+		cfg.Startup.RequiredComponents = append([]string(nil), file.Server.RequiredComponents...)
+	}
+	if file.Server.OptionalComponents != nil {
+		cfg.Startup.OptionalComponents = append([]string(nil), file.Server.OptionalComponents...)
+	}
+}
+
+func applyHealthDegradedThresholds(cfg *Config, thresholdsMS map[string]int) {
+	if thresholdsMS == nil {
+		return
+	}
+	cfg.HealthDegradedThresholds = make(map[string]time.Duration, len(thresholdsMS))
+	for name, ms := range thresholdsMS {
+		name = strings.TrimSpace(name)
+		if name == "" || ms <= 0 {
+			continue
+		}
+		cfg.HealthDegradedThresholds[name] = time.Duration(ms) * time.Millisecond
+	}
 }
 
 func applyStreamGuardConfig(dst *StreamGuardConfig, srv *runtimeconfig.ServerConfig) {
@@ -39,6 +64,12 @@ func applyStreamGuardConfig(dst *StreamGuardConfig, srv *runtimeconfig.ServerCon
 func applyRateLimitConfig(dst *RateLimitConfig, srv *runtimeconfig.ServerConfig) {
 	applyPositiveInt(&dst.RequestsPerMinute, srv.RateLimitRequestsPerMinute)
 	applyPositiveInt(&dst.Burst, srv.RateLimitBurst)
+	applyPositiveInt(&dst.TaskRequestsPerMinute, srv.RateLimitTaskRequestsPerMinute)
+	applyPositiveInt(&dst.TaskBurst, srv.RateLimitTaskBurst)
+	if srv.RateLimitExemptPaths != nil {
+		dst.ExemptPaths = append([]string{}, srv.RateLimitExemptPaths...)
+	}
+	applyTrimmedString(&dst.RedisURL, srv.RateLimitRedisURL)
 }
 
 func applyTaskExecutionConfig(dst *TaskExecutionConfig, srv *runtimeconfig.ServerConfig) {
@@ -46,6 +77,7 @@ func applyTaskExecutionConfig(dst *TaskExecutionConfig, srv *runtimeconfig.Serve
 	applyPositiveDuration(&dst.LeaseTTL, srv.TaskExecutionLeaseTTLSeconds, time.Second)
 	applyPositiveDuration(&dst.LeaseRenewInterval, srv.TaskExecutionLeaseRenewIntervalSeconds, time.Second)
 	applyNonNegativeInt(&dst.MaxInFlight, srv.TaskExecutionMaxInFlight)
+	applyNonNegativeInt(&dst.MaxQueued, srv.TaskExecutionMaxQueued)
 	applyPositiveInt(&dst.ResumeClaimBatchSize, srv.TaskExecutionResumeClaimBatchSize)
 }
 
@@ -54,6 +86,22 @@ func applyEventHistoryConfig(dst *EventHistoryConfig, srv *runtimeconfig.ServerC
 	applyNonNegativeInt(&dst.MaxSessions, srv.EventHistoryMaxSessions)
 	applyNonNegativeDuration(&dst.SessionTTL, srv.EventHistorySessionTTL, time.Second)
 	applyNonNegativeInt(&dst.MaxEvents, srv.EventHistoryMaxEvents)
+	applyNonNegativeInt(&dst.SegmentMaxMB, srv.EventHistorySegmentMaxMB)
+}
+
+func applyNotificationsConfig(dst *NotificationsConfig, srv *runtimeconfig.ServerConfig) {
+	if srv.NotificationTypes != nil {
+		dst.Types = append([]string(nil), srv.NotificationTypes...)
+	}
+	applyPositiveInt(&dst.MaxPerUser, srv.NotificationMaxPerUser)
+}
+
+func applyWorkspaceConfig(dst *WorkspaceConfig, srv *runtimeconfig.ServerConfig) {
+	applyTrimmedLowerString(&dst.Mode, srv.WorkspaceMode)
+	applyTrimmedString(&dst.Root, srv.WorkspaceRoot)
+	if srv.WorkspaceQuotaBytes != nil {
+		dst.QuotaBytes = max(*srv.WorkspaceQuotaBytes, 0)
+	}
 }
 
 func applySessionConfig(cfg *Config, file runtimeconfig.FileConfig) {
@@ -72,6 +120,8 @@ func applyAnalyticsConfig(cfg *Config, file runtimeconfig.FileConfig) {
 	cfg.Analytics = runtimeconfig.AnalyticsConfig{
 		PostHogAPIKey: strings.TrimSpace(file.Analytics.PostHogAPIKey),
 		PostHogHost:   strings.TrimSpace(file.Analytics.PostHogHost),
+		SpoolDir:      strings.TrimSpace(file.Analytics.SpoolDir),
+		SpoolMaxBytes: file.Analytics.SpoolMaxBytes,
 	}
 }
 
@@ -9,6 +9,8 @@ import (
 	"alex/internal/shared/utils"
 
 	"alex/internal/delivery/channels/lark"
+
+	"github.com/robfig/cron/v3"
 )
 
 func applyLarkEnvFallback(cfg *Config, lookup runtimeconfig.EnvLookup) {
@@ -29,11 +31,16 @@ func applyLarkConfig(cfg *Config, file runtimeconfig.FileConfig) {
 	applyOptionalBool(&target.Enabled, larkCfg.Enabled)
 	applyTrimmedString(&target.AppID, larkCfg.AppID)
 	applyTrimmedString(&target.AppSecret, larkCfg.AppSecret)
+	applyTrimmedString(&target.VerificationToken, larkCfg.VerificationToken)
+	applyTrimmedString(&target.EncryptKey, larkCfg.EncryptKey)
 	applyTrimmedString(&target.TenantCalendarID, larkCfg.TenantCalendarID)
 	applyTrimmedString(&target.BaseDomain, larkCfg.BaseDomain)
 	applyTrimmedString(&target.WorkspaceDir, larkCfg.WorkspaceDir)
 	applyOptionalBool(&target.AutoUploadFiles, larkCfg.AutoUploadFiles)
 	applyPositiveInt(&target.AutoUploadMaxBytes, larkCfg.AutoUploadMaxBytes)
+	applyPositiveInt(&target.AutoUploadInlineImageLimit, larkCfg.AutoUploadInlineImageLimit)
+	applyPositiveInt(&target.AutoUploadPostImageLimit, larkCfg.AutoUploadPostImageLimit)
+	applyPositiveInt(&target.AutoUploadInlineImageMaxBytes, larkCfg.AutoUploadInlineImageMaxBytes)
 	if len(larkCfg.AutoUploadAllowExt) > 0 {
 		target.AutoUploadAllowExt = append([]string(nil), larkCfg.AutoUploadAllowExt...)
 	}
@@ -52,6 +59,9 @@ func applyLarkConfig(cfg *Config, file runtimeconfig.FileConfig) {
 	applyOptionalBool(&target.SlowProgressSummaryEnabled, larkCfg.SlowProgressSummaryEnabled)
 	applyPositiveDuration(&target.SlowProgressSummaryDelay, larkCfg.SlowProgressSummaryDelaySecs, time.Second)
 	applyOptionalBool(&target.ShowPlanClarifyMessages, larkCfg.ShowPlanClarifyMessages)
+	applyOptionalBool(&target.ProgressEditInPlace, larkCfg.ProgressEditInPlace)
+	applyOptionalBool(&target.ShowCost, larkCfg.ShowCost)
+	applyPositiveDuration(&target.ProgressUpdateInterval, larkCfg.ProgressUpdateIntervalMs, time.Millisecond)
 	applyPositiveInt(&target.ToolFailureAbortThreshold, larkCfg.ToolFailureAbortThreshold)
 	applyPositiveInt(&target.AutoChatContextSize, larkCfg.AutoChatContextSize)
 	applyOptionalBool(&target.PlanReviewEnabled, larkCfg.PlanReviewEnabled)
@@ -67,6 +77,8 @@ func applyLarkConfig(cfg *Config, file runtimeconfig.FileConfig) {
 	applyLarkPersistenceConfig(&target, larkCfg.Persistence)
 	applyLarkDeliveryConfig(&target, larkCfg.Delivery)
 	applyLarkRateLimiterConfig(&target, larkCfg.RateLimiter)
+	applyLarkTaskDigestConfig(&target, larkCfg.TaskDigest)
+	applyLarkSessionRotationConfig(&target, larkCfg.SessionRotation)
 	applyPositiveInt(&target.MaxConcurrentTasks, larkCfg.MaxConcurrentTasks)
 	applyOptionalTrimmedString(&target.DefaultPlanMode, larkCfg.DefaultPlanMode)
 	// Btw / fork mode
@@ -81,6 +93,14 @@ func applyLarkConfig(cfg *Config, file runtimeconfig.FileConfig) {
 	applyOptionalBool(&target.ConversationProcessEnabled, larkCfg.ConversationProcessEnabled)
 	applyPositiveInt(&target.MaxConcurrentWorkers, larkCfg.MaxConcurrentWorkers)
 	applyOptionalTrimmedString(&target.ConversationWorkerCapabilities, larkCfg.ConversationWorkerCapabilities)
+	applyPositiveInt(&target.FollowUpQueueDepth, larkCfg.FollowUpQueueDepth)
+	applyPositiveInt(&target.AwaitInputTimeoutSeconds, larkCfg.AwaitInputTimeoutSeconds)
+	// Group chat mention gating
+	applyOptionalBool(&target.RequireMention, larkCfg.RequireMention)
+	applyTrimmedString(&target.BotOpenID, larkCfg.BotOpenID)
+	if len(larkCfg.PresetAdminRoles) > 0 {
+		target.PresetAdminRoles = append([]string(nil), larkCfg.PresetAdminRoles...)
+	}
 	cfg.Channels.SetLarkConfig(target)
 }
 
@@ -134,6 +154,39 @@ func applyLarkRateLimiterConfig(dst *LarkGatewayConfig, rl *runtimeconfig.LarkRa
 	applyPositiveInt(&dst.RateLimiterUserDailyLimit, rl.UserDailyLimit)
 }
 
+func applyLarkTaskDigestConfig(dst *LarkGatewayConfig, digest *runtimeconfig.LarkTaskDigestConfig) {
+	if dst == nil || digest == nil {
+		return
+	}
+	applyOptionalBool(&dst.TaskDigest.Enabled, digest.Enabled)
+	applyTrimmedString(&dst.TaskDigest.Cron, digest.Cron)
+	applyTrimmedString(&dst.TaskDigest.ChatID, digest.ChatID)
+}
+
+func applyLarkSessionRotationConfig(dst *LarkGatewayConfig, rotation *runtimeconfig.LarkSessionRotationConfig) {
+	if dst == nil || rotation == nil {
+		return
+	}
+	applyPositiveInt(&dst.SessionRotation.MaxMessages, rotation.MaxMessages)
+	applyPositiveInt(&dst.SessionRotation.MaxTokens, rotation.MaxTokens)
+	applyPositiveDuration(&dst.SessionRotation.MaxAge, rotation.MaxAgeHours, time.Hour)
+	applyPositiveInt(&dst.SessionRotation.SummaryMaxRunes, rotation.SummaryMaxRunes)
+}
+
+func validateLarkTaskDigestConfig(cfg *Config) error {
+	if cfg == nil {
+		return nil
+	}
+	digest := cfg.Channels.LarkConfig().TaskDigest
+	if !digest.Enabled || digest.Cron == "" {
+		return nil
+	}
+	if _, err := cron.ParseStandard(digest.Cron); err != nil {
+		return fmt.Errorf("channels.lark.task_digest.cron %q: %w", digest.Cron, err)
+	}
+	return nil
+}
+
 func validateLarkPersistenceConfig(cfg *Config) error {
 	if cfg == nil {
 		return nil
@@ -2,14 +2,17 @@ package bootstrap
 
 import (
 	"context"
-	"fmt"
 	"strings"
 	"time"
 
+	"alex/internal/app/maintenance"
+	"alex/internal/app/notifications"
 	"alex/internal/delivery/channels"
 	"alex/internal/delivery/channels/lark"
+	serverApp "alex/internal/delivery/server/app"
 	"alex/internal/domain/agent/presets"
 	"alex/internal/infra/attachments"
+	"alex/internal/infra/diagnostics"
 	runtimeconfig "alex/internal/shared/config"
 	configadmin "alex/internal/shared/config/admin"
 	"alex/internal/shared/utils"
@@ -69,22 +72,33 @@ func LoadConfig() (ConfigResult, error) { //nolint:cyclop // sequential config l
 			MaxConcurrent: 128,
 		},
 		RateLimit: RateLimitConfig{
-			RequestsPerMinute: 600,
-			Burst:             120,
+			RequestsPerMinute:     600,
+			Burst:                 120,
+			TaskRequestsPerMinute: 60,
+			TaskBurst:             10,
 		},
 		NonStreamTimeout: 30 * time.Second,
 		TaskExecution: TaskExecutionConfig{
 			LeaseTTL:             45 * time.Second,
 			LeaseRenewInterval:   15 * time.Second,
 			MaxInFlight:          64,
+			MaxQueued:            256,
 			ResumeClaimBatchSize: 128,
 		},
 		EventHistory: EventHistoryConfig{
-			Retention:   30 * 24 * time.Hour,
-			MaxSessions: 100,
-			SessionTTL:  1 * time.Hour,
-			MaxEvents:   1000,
+			Retention:    30 * 24 * time.Hour,
+			MaxSessions:  100,
+			SessionTTL:   1 * time.Hour,
+			MaxEvents:    1000,
+			SegmentMaxMB: 50,
 		},
+		Notifications: NotificationsConfig{
+			MaxPerUser: notifications.DefaultMaxPerUser,
+		},
+		Maintenance: MaintenanceConfig{
+			NoticeLead: maintenance.DefaultNoticeLead,
+		},
+		DiagnosticsHistorySize: diagnostics.DefaultHistorySize,
 		Session: runtimeconfig.SessionConfig{
 			Dir: "~/.alex/sessions",
 		},
@@ -95,6 +109,10 @@ func LoadConfig() (ConfigResult, error) { //nolint:cyclop // sequential config l
 			Provider: attachments.ProviderLocal,
 			Dir:      "~/.alex/attachments",
 		},
+		Workspace: WorkspaceConfig{
+			Mode: serverApp.WorkspaceModeGlobal,
+			Root: "~/.alex/workspaces",
+		},
 	}
 
 	// Register default channel configs in the registry.
@@ -114,17 +132,16 @@ func LoadConfig() (ConfigResult, error) { //nolint:cyclop // sequential config l
 	if err := validateLarkDeliveryConfig(&cfg); err != nil {
 		return ConfigResult{}, err
 	}
+	if err := validateLarkTaskDigestConfig(&cfg); err != nil {
+		return ConfigResult{}, err
+	}
 	if err := validateTelegramPersistenceConfig(&cfg); err != nil {
 		return ConfigResult{}, err
 	}
 
-	report := runtimeconfig.ValidateRuntimeConfig(cfg.Runtime)
-	if report.HasErrors() {
-		var ids []string
-		for _, item := range report.Errors {
-			ids = append(ids, item.ID)
-		}
-		return ConfigResult{}, fmt.Errorf("runtime config validation failed: %s", strings.Join(ids, ","))
+	report := ValidateConfig(cfg)
+	if err := configValidationError(report); err != nil {
+		return ConfigResult{}, err
 	}
 
 	return ConfigResult{
@@ -132,6 +149,7 @@ func LoadConfig() (ConfigResult, error) { //nolint:cyclop // sequential config l
 		ConfigManager: manager,
 		Resolver:      runtimeCache.Resolve,
 		RuntimeCache:  runtimeCache,
+		Diagnostics:   report,
 	}, nil
 }
 
@@ -45,12 +45,17 @@ func LogServerConfiguration(logger logging.Logger, config Config) {
 	logger.Debug("Temperature: %.2f (provided=%t; source=%s)", runtimeCfg.Temperature, runtimeCfg.TemperatureProvided, config.RuntimeMeta.Source("temperature"))
 	logger.Info("Environment: %s (source=%s)", runtimeCfg.Environment, config.RuntimeMeta.Source("environment"))
 	logger.Info("Port: %s", config.Port)
-	logger.Debug("HTTP Rate Limit: %d rpm (burst=%d)", config.RateLimit.RequestsPerMinute, config.RateLimit.Burst)
+	logger.Debug("HTTP Rate Limit: %d rpm (burst=%d), tasks %d rpm (burst=%d), shared=%t",
+		config.RateLimit.RequestsPerMinute, config.RateLimit.Burst,
+		config.RateLimit.TaskRequestsPerMinute, config.RateLimit.TaskBurst,
+		config.RateLimit.RedisURL != "")
 	logger.Debug("HTTP Non-Stream Timeout: %s", config.NonStreamTimeout)
 	logger.Debug("Event History Retention: %s", config.EventHistory.Retention)
 	logger.Debug("Event History Max Sessions: %d", config.EventHistory.MaxSessions)
 	logger.Debug("Event History Session TTL: %s", config.EventHistory.SessionTTL)
 	logger.Debug("Event History Max Events: %d", config.EventHistory.MaxEvents)
+	logger.Debug("Event History Segment Max MB: %d", config.EventHistory.SegmentMaxMB)
+	logger.Debug("Notifications: types=%v max_per_user=%d", config.Notifications.Types, config.Notifications.MaxPerUser)
 	larkCfg := config.Channels.LarkConfig()
 	if larkCfg.Enabled {
 		logger.Info(
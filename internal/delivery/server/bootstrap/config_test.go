@@ -7,6 +7,8 @@ import (
 	"strings"
 	"testing"
 	"time"
+
+	runtimeconfig "alex/internal/shared/config"
 )
 
 func clearLoadConfigValidationEnv(t *testing.T) {
@@ -164,6 +166,11 @@ runtime:
 channels:
   lark:
     tool_failure_abort_threshold: 5
+    progress_edit_in_place: true
+    progress_update_interval_ms: 1500
+    follow_up_queue_depth: 3
+    require_mention: true
+    bot_open_id: " ou_bot "
     active_slot_ttl_minutes: 90
     active_slot_max_entries: 1200
     pending_input_relay_ttl_minutes: 25
@@ -186,6 +193,15 @@ channels:
 	if lark.ToolFailureAbortThreshold != 5 {
 		t.Fatalf("expected tool failure abort threshold 5, got %d", lark.ToolFailureAbortThreshold)
 	}
+	if !lark.ProgressEditInPlace || lark.ProgressUpdateInterval != 1500*time.Millisecond {
+		t.Fatalf("expected progress edit-in-place at 1.5s, got %v / %s", lark.ProgressEditInPlace, lark.ProgressUpdateInterval)
+	}
+	if lark.FollowUpQueueDepth != 3 {
+		t.Fatalf("expected follow-up queue depth 3, got %d", lark.FollowUpQueueDepth)
+	}
+	if !lark.RequireMention || lark.BotOpenID != "ou_bot" {
+		t.Fatalf("expected mention gating for ou_bot, got %v / %q", lark.RequireMention, lark.BotOpenID)
+	}
 	if lark.ActiveSlotTTL != 90*time.Minute {
 		t.Fatalf("expected active slot ttl 90m, got %s", lark.ActiveSlotTTL)
 	}
@@ -274,6 +290,7 @@ server:
   task_execution_lease_ttl_seconds: 90
   task_execution_lease_renew_interval_seconds: 30
   task_execution_max_in_flight: 33
+  task_execution_max_queued: 12
   task_execution_resume_claim_batch_size: 77
 `)
 	if err := os.WriteFile(configPath, configContent, 0o600); err != nil {
@@ -300,6 +317,9 @@ server:
 	if cfg.TaskExecution.MaxInFlight != 33 {
 		t.Fatalf("expected task execution max in flight 33, got %d", cfg.TaskExecution.MaxInFlight)
 	}
+	if cfg.TaskExecution.MaxQueued != 12 {
+		t.Fatalf("expected task execution max queued 12, got %d", cfg.TaskExecution.MaxQueued)
+	}
 	if cfg.TaskExecution.ResumeClaimBatchSize != 77 {
 		t.Fatalf("expected task execution resume claim batch size 77, got %d", cfg.TaskExecution.ResumeClaimBatchSize)
 	}
@@ -329,6 +349,82 @@ server:
 	}
 }
 
+func TestLoadConfig_ServerStartupComponentClassification(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	configContent := []byte(`
+runtime:
+  llm_provider: mock
+server:
+  required_components: [maintenance, lark-gateway]
+  optional_components: [observability]
+`)
+	if err := os.WriteFile(configPath, configContent, 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	t.Setenv("ALEX_CONFIG_PATH", configPath)
+	t.Setenv("LLM_PROVIDER", "mock")
+
+	cr, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	startup := cr.Config.Startup
+	if len(startup.RequiredComponents) != 2 || startup.RequiredComponents[1] != "lark-gateway" {
+		t.Fatalf("unexpected required components: %v", startup.RequiredComponents)
+	}
+	if len(startup.OptionalComponents) != 1 || startup.OptionalComponents[0] != "observability" {
+		t.Fatalf("unexpected optional components: %v", startup.OptionalComponents)
+	}
+}
+
+func TestLoadConfig_ServerWorkspaceIsolation(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	configContent := []byte(`
+runtime:
+  llm_provider: mock
+server:
+  workspace_mode: Global
+  workspace_root: /srv/alex/workspaces
+  workspace_quota_bytes: 1048576
+`)
+	if err := os.WriteFile(configPath, configContent, 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	t.Setenv("ALEX_CONFIG_PATH", configPath)
+	t.Setenv("LLM_PROVIDER", "mock")
+
+	cr, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	ws := cr.Config.Workspace
+	if ws.Mode != "global" || ws.Root != "/srv/alex/workspaces" || ws.QuotaBytes != 1048576 {
+		t.Fatalf("unexpected workspace config: %+v", ws)
+	}
+
+	configContent = []byte(strings.Replace(string(configContent), "Global", "Per_Session", 1))
+	if err := os.WriteFile(configPath, configContent, 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	cr, err = LoadConfig()
+	if err != nil {
+		t.Fatalf("LoadConfig per_session failed: %v", err)
+	}
+	if cr.Config.Workspace.Mode != "per_session" {
+		t.Fatalf("unexpected workspace mode: %q", cr.Config.Workspace.Mode)
+	}
+
+	// Web tasks carry no user identity, so there is no per_user mode.
+	configContent = []byte(strings.Replace(string(configContent), "Per_Session", "per_user", 1))
+	if err := os.WriteFile(configPath, configContent, 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	_, err = LoadConfig()
+	if err == nil || !strings.Contains(err.Error(), `[server-workspace-mode] server.workspace_mode="per_user"`) {
+		t.Fatalf("expected per_user workspace mode to be rejected, got %v", err)
+	}
+}
+
 func TestLoadConfig_ProductionProfileRequiresAPIKey(t *testing.T) {
 	clearLoadConfigValidationEnv(t)
 	configPath := filepath.Join(t.TempDir(), "config.yaml")
@@ -374,3 +470,118 @@ runtime:
 		t.Fatalf("expected runtime profile quickstart, got %q", cr.Config.Runtime.Profile)
 	}
 }
+
+func TestLoadConfig_LarkTaskDigest(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	configContent := []byte(`
+runtime:
+  llm_provider: mock
+channels:
+  lark:
+    task_digest:
+      enabled: true
+      cron: "30 18 * * 1-5"
+      chat_id: " oc_team "
+`)
+	if err := os.WriteFile(configPath, configContent, 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	t.Setenv("ALEX_CONFIG_PATH", configPath)
+	t.Setenv("LLM_PROVIDER", "mock")
+
+	cr, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	digest := cr.Config.Channels.LarkConfig().TaskDigest
+	if !digest.Enabled || digest.Cron != "30 18 * * 1-5" || digest.ChatID != "oc_team" {
+		t.Fatalf("unexpected task digest config: %+v", digest)
+	}
+}
+
+func TestLoadConfig_InvalidLarkTaskDigestCron(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	configContent := []byte(`
+runtime:
+  llm_provider: mock
+channels:
+  lark:
+    task_digest:
+      enabled: true
+      cron: "every morning"
+`)
+	if err := os.WriteFile(configPath, configContent, 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	t.Setenv("ALEX_CONFIG_PATH", configPath)
+	t.Setenv("LLM_PROVIDER", "mock")
+
+	_, err := LoadConfig()
+	if err == nil || !strings.Contains(err.Error(), "channels.lark.task_digest.cron") {
+		t.Fatalf("expected invalid cron error, got %v", err)
+	}
+}
+
+func TestLoadConfig_ReportsEveryValidationError(t *testing.T) {
+	clearLoadConfigValidationEnv(t)
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	configContent := []byte(`
+runtime:
+  profile: production
+  llm_provider: openai
+  llm_model: gpt-4o-mini
+  max_tokens: -1
+server:
+  allowed_origins:
+    - https://app.example.com/
+`)
+	if err := os.WriteFile(configPath, configContent, 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	t.Setenv("ALEX_CONFIG_PATH", configPath)
+
+	_, err := LoadConfig()
+	if err == nil {
+		t.Fatal("expected LoadConfig to fail")
+	}
+	got := err.Error()
+	for _, want := range []string{
+		"3 error(s)",
+		`[llm-api-key] runtime.api_key`,
+		`[llm-max-tokens] runtime.max_tokens="-1"`,
+		`server.allowed_origins="https://app.example.com/"`,
+		"fix: Use https://app.example.com instead.",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected error to contain %q, got:\n%s", want, got)
+		}
+	}
+}
+
+func TestValidateConfigWarnings(t *testing.T) {
+	cfg := Config{
+		AllowedOrigins: []string{"*", "http://localhost:3000"},
+		Analytics:      runtimeconfig.AnalyticsConfig{PostHogAPIKey: "phx_personalsecret"},
+	}
+	cfg.Runtime.Profile = "standard"
+	cfg.Runtime.LLMProvider = "openai"
+	cfg.Runtime.LLMModel = "gpt-4o-mini"
+	cfg.Runtime.APIKey = "sk-test"
+	cfg.Runtime.TavilyAPIKey = "tvly-test"
+	report := ValidateConfig(cfg)
+	if report.HasErrors() {
+		t.Fatalf("unexpected errors: %+v", report.Errors)
+	}
+	if len(report.Warnings) != 1 || report.Warnings[0].Key != "analytics.posthog_api_key" || report.Warnings[0].Value != "phx_****" {
+		t.Fatalf("expected a redacted analytics key warning, got %+v", report.Warnings)
+	}
+
+	missing := filepath.Join(t.TempDir(), "observability.yaml")
+	issues := validateObservabilityConfigPath(missing)
+	if len(issues) != 1 || issues[0].Value != missing {
+		t.Fatalf("expected a missing observability config warning, got %+v", issues)
+	}
+	if issues := validateObservabilityConfigPath(""); issues != nil {
+		t.Fatalf("an unset observability path must not warn, got %+v", issues)
+	}
+}
@@ -0,0 +1,155 @@
+package bootstrap
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+
+	serverApp "alex/internal/delivery/server/app"
+	runtimeconfig "alex/internal/shared/config"
+	"alex/internal/shared/logging"
+)
+
+// ValidateConfig checks a loaded server config before the container is
+// built. It extends the runtime validation with server-level checks so every
+// problem is reported at once instead of surfacing later as a runtime error.
+func ValidateConfig(cfg Config) runtimeconfig.ValidationReport {
+	report := runtimeconfig.ValidateRuntimeConfig(cfg.Runtime)
+	report.Errors = append(report.Errors, validateAllowedOrigins(cfg.AllowedOrigins)...)
+	report.Errors = append(report.Errors, validateWorkspaceConfig(cfg.Workspace)...)
+	errs, warnings := validateAnalyticsConfig(cfg.Analytics)
+	report.Errors = append(report.Errors, errs...)
+	report.Warnings = append(report.Warnings, warnings...)
+	return report
+}
+
+func validateAllowedOrigins(origins []string) []runtimeconfig.ValidationIssue {
+	var issues []runtimeconfig.ValidationIssue
+	for _, origin := range origins {
+		if origin == "*" {
+			continue
+		}
+		parsed, err := url.Parse(origin)
+		switch {
+		case err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "":
+			issues = append(issues, runtimeconfig.ValidationIssue{
+				ID:      "server-allowed-origin",
+				Key:     "server.allowed_origins",
+				Value:   origin,
+				Message: "allowed origin is not an http(s) origin",
+				Hint:    "Use scheme://host[:port], e.g. https://app.example.com, or * to allow any origin.",
+			})
+		case parsed.Path != "" || parsed.RawQuery != "" || parsed.Fragment != "":
+			issues = append(issues, runtimeconfig.ValidationIssue{
+				ID:      "server-allowed-origin",
+				Key:     "server.allowed_origins",
+				Value:   origin,
+				Message: "allowed origin has a path or trailing slash; browsers never send one, so it cannot match",
+				Hint:    fmt.Sprintf("Use %s://%s instead.", parsed.Scheme, parsed.Host),
+			})
+		}
+	}
+	return issues
+}
+
+// validateWorkspaceConfig rejects unknown workspace modes.
+func validateWorkspaceConfig(cfg WorkspaceConfig) []runtimeconfig.ValidationIssue {
+	switch cfg.Mode {
+	case "", serverApp.WorkspaceModeGlobal, serverApp.WorkspaceModePerSession:
+		return nil
+	default:
+		return []runtimeconfig.ValidationIssue{{
+			ID:      "server-workspace-mode",
+			Key:     "server.workspace_mode",
+			Value:   cfg.Mode,
+			Message: "unknown workspace mode",
+			Hint:    "Use global or per_session.",
+		}}
+	}
+}
+
+func validateAnalyticsConfig(cfg runtimeconfig.AnalyticsConfig) (errs, warnings []runtimeconfig.ValidationIssue) {
+	if key := strings.TrimSpace(cfg.PostHogAPIKey); key != "" && !strings.HasPrefix(key, "phc_") {
+		warnings = append(warnings, runtimeconfig.ValidationIssue{
+			ID:      "analytics-posthog-key",
+			Key:     "analytics.posthog_api_key",
+			Value:   redactKey(key),
+			Message: "PostHog key does not look like a project API key (phc_...)",
+			Hint:    "Use the project API key from PostHog project settings; personal keys (phx_...) must not be shipped to clients.",
+		})
+	}
+	if host := strings.TrimSpace(cfg.PostHogHost); host != "" {
+		if parsed, err := url.Parse(host); err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			errs = append(errs, runtimeconfig.ValidationIssue{
+				ID:      "analytics-posthog-host",
+				Key:     "analytics.posthog_host",
+				Value:   host,
+				Message: "PostHog host is not an http(s) URL",
+				Hint:    "Use a full URL such as https://us.i.posthog.com, or remove it to use the default.",
+			})
+		}
+	}
+	return errs, warnings
+}
+
+// validateObservabilityConfigPath warns when an explicitly configured
+// observability config file is missing; observability then silently falls
+// back to its defaults.
+func validateObservabilityConfigPath(path string) []runtimeconfig.ValidationIssue {
+	path = strings.TrimSpace(path)
+	if path == "" {
+		return nil
+	}
+	if _, err := os.Stat(path); err != nil {
+		return []runtimeconfig.ValidationIssue{{
+			ID:      "observability-config-path",
+			Key:     "ALEX_OBSERVABILITY_CONFIG",
+			Value:   path,
+			Message: fmt.Sprintf("observability config is not readable (%v); defaults are in use", err),
+			Hint:    "Point ALEX_OBSERVABILITY_CONFIG at an existing config.yaml or unset it.",
+		}}
+	}
+	return nil
+}
+
+// configValidationError lists every blocking issue in report, or returns
+// nil when there are none.
+func configValidationError(report runtimeconfig.ValidationReport) error {
+	if !report.HasErrors() {
+		return nil
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "config validation failed with %d error(s):", len(report.Errors))
+	for _, issue := range report.Errors {
+		b.WriteString("\n  - " + formatValidationIssue(issue))
+	}
+	return fmt.Errorf("%s", b.String())
+}
+
+// logConfigWarnings logs each non-blocking validation finding.
+func logConfigWarnings(logger logging.Logger, report runtimeconfig.ValidationReport) {
+	for _, issue := range report.Warnings {
+		logging.OrNop(logger).Warn("[ConfigValidation] %s", formatValidationIssue(issue))
+	}
+}
+
+func formatValidationIssue(issue runtimeconfig.ValidationIssue) string {
+	text := fmt.Sprintf("[%s] %s", issue.ID, issue.Key)
+	if issue.Value != "" {
+		text += fmt.Sprintf("=%q", issue.Value)
+	}
+	text += ": " + issue.Message
+	if issue.Hint != "" {
+		text += " (fix: " + issue.Hint + ")"
+	}
+	return text
+}
+
+// redactKey keeps only a key's prefix so diagnostics can show its shape.
+func redactKey(key string) string {
+	if len(key) <= 4 {
+		return "****"
+	}
+	return key[:4] + "****"
+}
@@ -0,0 +1,56 @@
+package bootstrap
+
+import (
+	serverHTTP "alex/internal/delivery/server/http"
+	agentdomain "alex/internal/domain/agent"
+	agent "alex/internal/domain/agent/ports/agent"
+	"alex/internal/domain/agent/types"
+	"alex/internal/infra/diagnostics"
+)
+
+// diagnosticsHistory exposes the diagnostics ring buffer to the HTTP layer.
+type diagnosticsHistory struct{}
+
+func (diagnosticsHistory) Recent(kind string, limit int) []serverHTTP.DiagnosticEntry {
+	return toDiagnosticEntries(diagnostics.Recent(kind, limit))
+}
+
+func (diagnosticsHistory) Latest() []serverHTTP.DiagnosticEntry {
+	return toDiagnosticEntries(diagnostics.LatestByKind())
+}
+
+// SnapshotEvents converts the latest entry of each kind that has a stream
+// event type into a workflow envelope; other kinds are only served by the
+// recent endpoint.
+func (diagnosticsHistory) SnapshotEvents() []agent.AgentEvent {
+	var events []agent.AgentEvent
+	for _, entry := range diagnostics.LatestByKind() {
+		if payload, ok := entry.Payload.(diagnostics.EnvironmentPayload); ok {
+			events = append(events, environmentSnapshotEnvelope(payload))
+		}
+	}
+	return events
+}
+
+func environmentSnapshotEnvelope(payload diagnostics.EnvironmentPayload) *agentdomain.WorkflowEventEnvelope {
+	event := agentdomain.NewDiagnosticEnvironmentSnapshotEvent(payload.Host, payload.Captured)
+	env := agentdomain.NewWorkflowEnvelopeFromEvent(event, types.EventDiagnosticEnvironmentSnapshot)
+	env.NodeKind = "diagnostic"
+	env.Payload = map[string]any{
+		"host":     event.Data.Host,
+		"captured": payload.Captured,
+	}
+	return env
+}
+
+func toDiagnosticEntries(entries []diagnostics.Entry) []serverHTTP.DiagnosticEntry {
+	out := make([]serverHTTP.DiagnosticEntry, 0, len(entries))
+	for _, entry := range entries {
+		out = append(out, serverHTTP.DiagnosticEntry{
+			Kind:       entry.Kind,
+			Payload:    entry.Payload,
+			RecordedAt: entry.Recorded,
+		})
+	}
+	return out
+}
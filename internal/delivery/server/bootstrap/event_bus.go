@@ -0,0 +1,50 @@
+package bootstrap
+
+import (
+	"context"
+	"time"
+
+	"alex/internal/app/agent/eventbus"
+	serverApp "alex/internal/delivery/server/app"
+	"alex/internal/shared/logging"
+)
+
+const eventBusCloseTimeout = 5 * time.Second
+
+// buildEventBus creates the server event bus and subscribes the built-in
+// consumers. The broadcaster persists event history (the journal), so it gets
+// guaranteed in-order delivery. The progress and analytics trackers are
+// guaranteed too, since a dropped final event would leave stored progress or
+// lifecycle analytics stale.
+// Additional consumers (webhooks, metrics) subscribe to the returned bus
+// without touching the task execution path.
+func buildEventBus(broadcaster *serverApp.EventBroadcaster, tracker *serverApp.TaskProgressTracker, analyticsTracker *serverApp.TaskAnalyticsTracker, logger logging.Logger) (*eventbus.Bus, func()) {
+	bus := eventbus.New(logging.NewComponentLogger("EventBus"))
+	subs := []eventbus.Subscription{
+		{Name: "broadcaster", Consumer: eventbus.ListenerConsumer(broadcaster), Mode: eventbus.DeliveryGuaranteed, MaxFailures: -1},
+	}
+	if tracker != nil {
+		subs = append(subs, eventbus.Subscription{Name: "progress_tracker", Consumer: eventbus.ListenerConsumer(tracker), Mode: eventbus.DeliveryGuaranteed})
+	}
+	if analyticsTracker != nil {
+		subs = append(subs, eventbus.Subscription{Name: "analytics", Consumer: eventbus.ListenerConsumer(analyticsTracker), Mode: eventbus.DeliveryGuaranteed})
+	}
+	for _, sub := range subs {
+		if _, err := bus.Subscribe(sub); err != nil {
+			logger.Warn("Event bus subscribe %s failed: %v", sub.Name, err)
+		}
+	}
+	return bus, func() {
+		ctx, cancel := context.WithTimeout(context.Background(), eventBusCloseTimeout)
+		defer cancel()
+		if err := bus.Close(ctx); err != nil {
+			logger.Warn("Event bus close incomplete: %v", err)
+		}
+		for _, stats := range bus.Stats() {
+			if stats.Dropped > 0 || stats.Failures > 0 {
+				logger.Info("Event bus subscriber %s: delivered=%d dropped=%d failures=%d detached=%v",
+					stats.Name, stats.Delivered, stats.Dropped, stats.Failures, stats.Detached)
+			}
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package bootstrap
+
+import (
+	"context"
+	"time"
+
+	serverApp "alex/internal/delivery/server/app"
+	"alex/internal/shared/async"
+	"alex/internal/shared/logging"
+)
+
+const eventSegmentSweepInterval = time.Hour
+
+// startEventSegmentSweeper periodically deletes rolled event journal segments
+// older than retention. The returned stop func ends the loop; it is a no-op
+// when retention is disabled.
+func startEventSegmentSweeper(store *serverApp.FileEventHistoryStore, retention time.Duration, logger logging.Logger) func() {
+	if store == nil || retention <= 0 {
+		return func() {}
+	}
+	logger = logging.OrNop(logger)
+	ctx, cancel := context.WithCancel(context.Background())
+	sweep := func() {
+		removed, err := store.SweepExpiredSegments(time.Now(), retention)
+		if err != nil {
+			logger.Warn("Event journal sweep failed: %v", err)
+			return
+		}
+		if removed > 0 {
+			logger.Info("Event journal sweep removed %d expired segments", removed)
+		}
+	}
+	async.Go(logger, "event-history.sweeper", func() {
+		sweep()
+		ticker := time.NewTicker(eventSegmentSweepInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				sweep()
+			}
+		}
+	})
+	return cancel
+}
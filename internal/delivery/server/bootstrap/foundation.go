@@ -28,6 +28,7 @@ type Foundation struct {
 	Obs           *observability.Observability
 	Logger        logging.Logger
 	Degraded      *DegradedComponents
+	Startup       *StartupErrors
 	HostEnv       map[string]string
 	EnvCapturedAt time.Time
 
@@ -44,8 +45,9 @@ func BootstrapFoundation(observabilityConfigPath string, logger logging.Logger)
 		Degraded: NewDegradedComponents(),
 	}
 
-	// 1. Observability
-	obs, cleanupObs := InitObservability(observabilityConfigPath, logger)
+	// 1. Observability. Its failure is reported once config has loaded and
+	// the required/optional classification is known.
+	obs, cleanupObs, obsErr := InitObservability(observabilityConfigPath, logger)
 	f.Obs = obs
 	if cleanupObs != nil {
 		f.addCleanup(cleanupObs)
@@ -59,9 +61,17 @@ func BootstrapFoundation(observabilityConfigPath string, logger logging.Logger)
 	}
 	f.ConfigResult = cr
 	f.Config = cr.Config
+	f.Startup = NewStartupErrors(f.Config.Startup, f.Degraded, logger)
+	if err := f.Startup.Report("observability", false, obsErr); err != nil {
+		f.Cleanup()
+		return nil, err
+	}
 
 	// Validate config YAML against JSON Schema (warn-only, non-blocking).
 	validateConfigSchema(logger)
+	f.ConfigResult.Diagnostics.Warnings = append(f.ConfigResult.Diagnostics.Warnings,
+		validateObservabilityConfigPath(observabilityConfigPath)...)
+	logConfigWarnings(logger, f.ConfigResult.Diagnostics)
 
 	LogServerConfiguration(logger, f.Config)
 
@@ -93,9 +103,14 @@ func BootstrapFoundation(observabilityConfigPath string, logger logging.Logger)
 	if cr.Resolver != nil && container.AgentCoordinator != nil {
 		container.AgentCoordinator.SetRuntimeConfigResolver(cr.Resolver)
 	}
+	if obs != nil && container.AgentCoordinator != nil {
+		tracing := obs.Config().Tracing
+		container.AgentCoordinator.SetTraceToolArguments(tracing.Enabled && tracing.ToolArgumentCaptureEnabled())
+	}
 
-	if err := container.Start(); err != nil {
-		logger.Warn("Container start failed: %v (continuing with limited functionality)", err)
+	if err := f.Startup.Report("container-start", false, container.Start()); err != nil {
+		f.Cleanup()
+		return nil, err
 	}
 
 	if summary := f.Config.EnvironmentSummary; summary != "" {
@@ -213,9 +228,9 @@ func (f *Foundation) TimerManagerStage(sm *SubsystemManager) BootstrapStage {
 			return sm.Start(context.Background(), &gatewaySubsystem{
 				name: "timer-manager",
 				startFn: func(ctx context.Context) (func(), error) {
-					mgr := startTimerManager(ctx, f.Config, f.Container, f.Logger)
-					if mgr == nil {
-						return nil, fmt.Errorf("timer-manager init returned nil")
+					mgr, err := startTimerManager(ctx, f.Config, f.Container, f.Logger)
+					if err != nil {
+						return nil, err
 					}
 					return mgr.Stop, nil
 				},
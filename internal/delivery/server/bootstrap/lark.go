@@ -10,7 +10,9 @@ import (
 	"syscall"
 	"time"
 
+	"alex/internal/infra/analytics"
 	"alex/internal/infra/diagnostics"
+	"alex/internal/infra/observability"
 	"alex/internal/shared/async"
 	"alex/internal/shared/logging"
 	"alex/internal/shared/utils"
@@ -51,16 +53,33 @@ func RunLark(observabilityConfigPath string) error { //nolint:cyclop // multi-ph
 	profile.record("P1 Foundation", p1Duration)
 	logger.Info("Phase 1 Foundation: %dms", p1Duration.Milliseconds())
 
-	// ── Phase 2: Optional services (attachments only) ──
+	// ── Phase 2: Optional services (attachments, analytics) ──
 
 	p2Start := time.Now()
+	var analyticsClient analytics.Client
+	var analyticsCleanup func()
 	optionalStages := []BootstrapStage{
 		f.AttachmentStage(),
+		{
+			Name: "analytics", Required: false,
+			Init: func() error {
+				var metrics *observability.MetricsCollector
+				if f.Obs != nil {
+					metrics = f.Obs.Metrics
+				}
+				var err error
+				analyticsClient, analyticsCleanup, err = BuildAnalyticsClient(config.Analytics, metrics, logger)
+				return err
+			},
+		},
 	}
 
-	if err := RunStages(optionalStages, f.Degraded, logger); err != nil {
+	if err := f.Startup.RunStages(optionalStages); err != nil {
 		return fmt.Errorf("optional stages: %w", err)
 	}
+	if analyticsCleanup != nil {
+		defer analyticsCleanup()
+	}
 
 	// ── Phase 2b: In-memory EventBroadcaster (for SSE debug stream) ──
 
@@ -69,6 +88,7 @@ func RunLark(observabilityConfigPath string) error { //nolint:cyclop // multi-ph
 		sessionDir = container.SessionDir()
 	}
 	broadcaster := buildDebugBroadcaster(f.Obs, sessionDir, logger)
+	diagnostics.SetHistorySize(config.DiagnosticsHistorySize)
 	cleanupDiagnostics := subscribeDiagnostics(broadcaster)
 	defer cleanupDiagnostics()
 
@@ -86,8 +106,20 @@ func RunLark(observabilityConfigPath string) error { //nolint:cyclop // multi-ph
 	subsystems := NewSubsystemManager(logger)
 	defer subsystems.StopAll()
 
+	if container != nil {
+		_, err := buildNotificationCenter(config.Notifications, container)
+		if reportErr := f.Startup.Report("notifications", false, err); reportErr != nil {
+			return reportErr
+		}
+		_, stopMaintenance, err := buildMaintenanceService(config.Maintenance, container, logger)
+		if reportErr := f.Startup.Report("maintenance", false, err); reportErr != nil {
+			return reportErr
+		}
+		defer stopMaintenance()
+	}
+
 	// Register channel plugins into the registry.
-	registerLarkChannel(config, config.Channels.Registry, container, logger, broadcaster)
+	registerLarkChannel(config, config.Channels.Registry, container, logger, broadcaster, analyticsClient)
 	registerTelegramChannel(config, config.Channels.Registry, container, logger, broadcaster)
 
 	// Build gateway stages from the channel registry.
@@ -109,13 +141,11 @@ func RunLark(observabilityConfigPath string) error { //nolint:cyclop // multi-ph
 		f.TimerManagerStage(subsystems),
 	)
 
-	if err := RunStages(gatewayStages, f.Degraded, logger); err != nil {
+	if err := f.Startup.RunStages(gatewayStages); err != nil {
 		return fmt.Errorf("gateway stages: %w", err)
 	}
 
-	if !f.Degraded.IsEmpty() {
-		logger.Warn("[Bootstrap] Lark standalone starting in degraded mode: %v", f.Degraded.Map())
-	}
+	f.Startup.LogSummary("Lark standalone")
 
 	// ── Phase 3b: Runtime watchdog ──
 
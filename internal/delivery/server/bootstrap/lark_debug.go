@@ -15,8 +15,10 @@ import (
 	"alex/internal/app/di"
 	"alex/internal/app/lifecycle"
 	"alex/internal/app/subscription"
+	"alex/internal/delivery/channels/lark"
 	serverApp "alex/internal/delivery/server/app"
 	serverHTTP "alex/internal/delivery/server/http"
+	"alex/internal/delivery/server/ports"
 	"alex/internal/infra/observability"
 	"alex/internal/runtime/hooks"
 	runtimeconfig "alex/internal/shared/config"
@@ -33,6 +35,20 @@ func withStartupProfile(sp *startupProfile) debugServerOption {
 	}
 }
 
+// wireConfigHandlerHotReload lets runtime LLM setting updates drop cached
+// clients and announce the change to connected SSE clients.
+func wireConfigHandlerHotReload(handler *serverHTTP.ConfigHandler, container *di.Container, broadcaster *serverApp.EventBroadcaster) {
+	if handler == nil {
+		return
+	}
+	if container != nil {
+		handler.SetLLMClientInvalidator(container.InvalidateLLMClients)
+	}
+	if broadcaster != nil {
+		handler.SetEventListener(broadcaster)
+	}
+}
+
 // BuildDebugHTTPServer creates a lightweight HTTP server for the Lark standalone
 // binary. It exposes health, SSE, dev/debug, config, hooks-bridge, and runtime
 // endpoints on cfg.DebugPort (default "9090") — no auth, no rate limiting.
@@ -45,14 +61,20 @@ func BuildDebugHTTPServer(f *Foundation, broadcaster *serverApp.EventBroadcaster
 	// Health checker — mirrors the probes from RunServer.
 	healthChecker := serverApp.NewHealthChecker()
 	if container != nil {
+		healthChecker.RegisterProbe(serverApp.NewContainerStartedProbe(container))
 		healthChecker.RegisterProbe(serverApp.NewLLMFactoryProbe(container))
 	}
-	healthChecker.RegisterProbe(serverApp.NewDegradedProbe(f.Degraded))
-	healthChecker.RegisterProbe(serverApp.NewSchedulerProbeFromScheduler(f.Scheduler, 0))
+	healthChecker.RegisterProbeKind(ports.ProbeKindInformational, serverApp.NewDegradedProbe(f.Degraded))
+	healthChecker.RegisterProbeKind(ports.ProbeKindInformational, serverApp.NewSchedulerProbeFromScheduler(f.Scheduler, 0))
+	healthChecker.SetDegradedThresholds(cfg.HealthDegradedThresholds)
+	if broadcaster != nil {
+		healthChecker.SetEventListener(broadcaster)
+	}
 
 	// Config handler for runtime config inspection/mutation.
 	runtimeUpdates, runtimeReloader := f.RuntimeCacheUpdates()
 	configHandler := serverHTTP.NewConfigHandler(f.ConfigManager(), f.Resolver(), runtimeUpdates, runtimeReloader)
+	wireConfigHandlerHotReload(configHandler, container, broadcaster)
 
 	// Onboarding state handler.
 	onboardingStore := subscription.NewOnboardingStateStore(
@@ -65,8 +87,12 @@ func BuildDebugHTTPServer(f *Foundation, broadcaster *serverApp.EventBroadcaster
 	var memoryEngine serverHTTP.MemoryEngine
 	var larkInjectGateway serverHTTP.LarkInjectGateway
 	var larkOAuthHandler *serverHTTP.LarkOAuthHandler
+	var preferencesHandler *serverHTTP.PreferencesHandler
+	var notificationsHandler *serverHTTP.NotificationsHandler
+	var larkEventWebhook http.Handler
 	if container != nil {
 		if container.LarkGateway != nil {
+			larkEventWebhook = buildLarkEventWebhook(container.LarkGateway, f.Obs)
 			if hb := buildHooksBridge(cfg, container, logger); hb != nil {
 				hooksBridge = hb
 				container.Drainables = append(container.Drainables,
@@ -79,6 +105,12 @@ func BuildDebugHTTPServer(f *Foundation, broadcaster *serverApp.EventBroadcaster
 			larkOAuthHandler = serverHTTP.NewLarkOAuthHandler(container.LarkOAuth, logger)
 		}
 		memoryEngine = container.MemoryEngine
+		if container.PreferencesStore != nil {
+			preferencesHandler = serverHTTP.NewPreferencesHandler(container.PreferencesStore)
+		}
+		if container.Notifications != nil {
+			notificationsHandler = serverHTTP.NewNotificationsHandler(container.Notifications)
+		}
 	}
 
 	// Runtime hooks bridge — translates CC hook events into runtime bus events.
@@ -103,6 +135,10 @@ func BuildDebugHTTPServer(f *Foundation, broadcaster *serverApp.EventBroadcaster
 		HealthChecker:          healthChecker,
 		ConfigHandler:          configHandler,
 		OnboardingStateHandler: onboardingStateHandler,
+		PreferencesHandler:     preferencesHandler,
+		Diagnostics:            diagnosticsHistory{},
+		ConfigDiagnostics:      &f.ConfigResult.Diagnostics,
+		NotificationsHandler:   notificationsHandler,
 		Obs:                    f.Obs,
 		Environment:            cfg.Runtime.Environment,
 		AllowedOrigins:         append([]string(nil), cfg.AllowedOrigins...),
@@ -110,6 +146,7 @@ func BuildDebugHTTPServer(f *Foundation, broadcaster *serverApp.EventBroadcaster
 		HooksBridge:            hooksBridge,
 		LarkInjectGateway:      larkInjectGateway,
 		LarkOAuthHandler:       larkOAuthHandler,
+		LarkEventWebhook:       larkEventWebhook,
 		RuntimeHooksBridge:     runtimeHooksHandler,
 		RuntimeAPI:             runtimeAPI,
 		RuntimePoolAPI:         runtimePoolAPI,
@@ -237,6 +274,26 @@ func (h *startupProfileHandler) ServeHTTP(w http.ResponseWriter, _ *http.Request
 	_ = json.NewEncoder(w).Encode(h.profile.snapshot())
 }
 
+// larkEventGateway is the optional Lark gateway surface for the event
+// subscription webhook.
+type larkEventGateway interface {
+	EventWebhookHandler() http.Handler
+	SetEventMetrics(metrics lark.EventMetrics)
+}
+
+// buildLarkEventWebhook exposes the gateway's event router over HTTP and
+// reports its routing outcomes to the metrics collector when available.
+func buildLarkEventWebhook(gateway di.LarkGateway, obs *observability.Observability) http.Handler {
+	eventGateway, ok := gateway.(larkEventGateway)
+	if !ok {
+		return nil
+	}
+	if obs != nil && obs.Metrics != nil {
+		eventGateway.SetEventMetrics(obs.Metrics)
+	}
+	return eventGateway.EventWebhookHandler()
+}
+
 // buildDebugBroadcaster creates the EventBroadcaster for Lark standalone mode.
 // It keeps an in-memory window and also persists event history to local files
 // (when sessionDir is provided) so diagnostics can replay timing for recent runs.
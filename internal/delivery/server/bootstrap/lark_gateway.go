@@ -13,6 +13,7 @@ import (
 	"alex/internal/delivery/channels/lark"
 	serverApp "alex/internal/delivery/server/app"
 	"alex/internal/domain/agent/presets"
+	"alex/internal/infra/analytics"
 	larkoauth "alex/internal/infra/lark/oauth"
 	infra_skills "alex/internal/infra/skills"
 	"alex/internal/shared/async"
@@ -23,7 +24,7 @@ import (
 // registerLarkChannel registers the Lark channel plugin into the registry
 // if Lark is enabled. The plugin factory captures the full Config and
 // dependencies needed to start the gateway.
-func registerLarkChannel(cfg Config, registry *ChannelRegistry, container *di.Container, logger logging.Logger, broadcaster *serverApp.EventBroadcaster) {
+func registerLarkChannel(cfg Config, registry *ChannelRegistry, container *di.Container, logger logging.Logger, broadcaster *serverApp.EventBroadcaster, analyticsClient analytics.Client) {
 	larkCfg := cfg.Channels.LarkConfig()
 	if !larkCfg.Enabled {
 		return
@@ -32,12 +33,12 @@ func registerLarkChannel(cfg Config, registry *ChannelRegistry, container *di.Co
 		Name:     "lark",
 		Required: true,
 		Build: func(ctx context.Context) (func(), error) {
-			return startLarkGateway(ctx, cfg, container, logger, broadcaster)
+			return startLarkGateway(ctx, cfg, container, logger, broadcaster, analyticsClient)
 		},
 	})
 }
 
-func startLarkGateway(ctx context.Context, cfg Config, container *di.Container, logger logging.Logger, broadcaster *serverApp.EventBroadcaster) (func(), error) { //nolint:cyclop // gateway startup with validation
+func startLarkGateway(ctx context.Context, cfg Config, container *di.Container, logger logging.Logger, broadcaster *serverApp.EventBroadcaster, analyticsClient analytics.Client) (func(), error) { //nolint:cyclop // gateway startup with validation
 	logger = logging.OrNop(logger)
 	larkCfg := cfg.Channels.LarkConfig()
 	if !larkCfg.Enabled {
@@ -98,7 +99,7 @@ func startLarkGateway(ctx context.Context, cfg Config, container *di.Container,
 	}
 	container.LarkGateway = gateway
 
-	wireLarkGateway(ctx, gateway, cfg, container, broadcaster, stores, logger)
+	wireLarkGateway(ctx, gateway, cfg, container, broadcaster, analyticsClient, stores, logger)
 
 	async.Go(logger, "lark.gateway", func() {
 		if err := gateway.Start(ctx); err != nil {
@@ -137,15 +138,23 @@ func buildLarkGatewayConfig(larkCfg LarkGatewayConfig, cfg Config) lark.Config {
 		Enabled:                       larkCfg.Enabled,
 		AppID:                         larkCfg.AppID,
 		AppSecret:                     larkCfg.AppSecret,
+		VerificationToken:             larkCfg.VerificationToken,
+		EncryptKey:                    larkCfg.EncryptKey,
 		TenantCalendarID:              larkCfg.TenantCalendarID,
 		BaseDomain:                    larkCfg.BaseDomain,
 		WorkspaceDir:                  larkCfg.WorkspaceDir,
 		AutoUploadFiles:               larkCfg.AutoUploadFiles,
 		AutoUploadMaxBytes:            larkCfg.AutoUploadMaxBytes,
 		AutoUploadAllowExt:            append([]string(nil), larkCfg.AutoUploadAllowExt...),
+		AutoUploadInlineImageLimit:    larkCfg.AutoUploadInlineImageLimit,
+		AutoUploadPostImageLimit:      larkCfg.AutoUploadPostImageLimit,
+		AutoUploadInlineImageMaxBytes: larkCfg.AutoUploadInlineImageMaxBytes,
 		Browser:                       larkCfg.Browser,
 		InjectionAckReactEmoji:        larkCfg.InjectionAckReactEmoji,
 		ShowToolProgress:              larkCfg.ShowToolProgress,
+		ProgressEditInPlace:           larkCfg.ProgressEditInPlace,
+		ShowCost:                      larkCfg.ShowCost,
+		ProgressUpdateInterval:        larkCfg.ProgressUpdateInterval,
 		SlowProgressSummaryEnabled:    &larkCfg.SlowProgressSummaryEnabled,
 		SlowProgressSummaryDelay:      larkCfg.SlowProgressSummaryDelay,
 		AutoChatContextSize:           larkCfg.AutoChatContextSize,
@@ -168,7 +177,9 @@ func buildLarkGatewayConfig(larkCfg LarkGatewayConfig, cfg Config) lark.Config {
 		DefaultPlanMode:               lark.PlanMode(larkCfg.DefaultPlanMode),
 		DeliveryMode:                  larkCfg.DeliveryMode,
 		DeliveryWorker:                larkCfg.DeliveryWorker,
+		TaskDigest:                    larkCfg.TaskDigest,
 		AttentionGate:                 larkCfg.AttentionGate,
+		SessionRotation:               larkCfg.SessionRotation,
 		BtwEnabled:                    larkCfg.BtwEnabled,
 		BtwIntentRouterEnabled:        &larkCfg.BtwIntentRouterEnabled,
 		BtwIntentRouterModel:          larkCfg.BtwIntentRouterModel,
@@ -177,6 +188,11 @@ func buildLarkGatewayConfig(larkCfg LarkGatewayConfig, cfg Config) lark.Config {
 		ConversationProcessEnabled:     &larkCfg.ConversationProcessEnabled,
 		MaxConcurrentWorkers:           larkCfg.MaxConcurrentWorkers,
 		ConversationWorkerCapabilities: larkCfg.ConversationWorkerCapabilities,
+		FollowUpQueueDepth:             larkCfg.FollowUpQueueDepth,
+		AwaitInputTimeoutSeconds:       larkCfg.AwaitInputTimeoutSeconds,
+		RequireMention:                 larkCfg.RequireMention,
+		BotOpenID:                      larkCfg.BotOpenID,
+		PresetAdminRoles:               larkCfg.PresetAdminRoles,
 	}
 
 	hooksPort := strings.TrimPrefix(cfg.DebugPort, ":")
@@ -245,16 +261,22 @@ func initLarkStores(ctx context.Context, gatewayCfg *lark.Config, container *di.
 	return s, nil
 }
 
-func wireLarkGateway(ctx context.Context, gateway *lark.Gateway, cfg Config, container *di.Container, broadcaster *serverApp.EventBroadcaster, stores larkStores, logger logging.Logger) {
+func wireLarkGateway(ctx context.Context, gateway *lark.Gateway, cfg Config, container *di.Container, broadcaster *serverApp.EventBroadcaster, analyticsClient analytics.Client, stores larkStores, logger logging.Logger) {
 	if oauthSvc := buildLarkOAuthService(ctx, cfg, container, logger); oauthSvc != nil {
 		container.LarkOAuth = oauthSvc
 		gateway.SetOAuthService(oauthSvc)
 		gateway.EnableAutoAuth(oauthSvc, logger)
 	}
 
-	if broadcaster != nil {
+	if container.Notifications != nil {
+		gateway.SetEventListener(buildNotificationListener(broadcaster, container, logger))
+		gateway.SetNotificationDeduper(container.Notifications)
+	} else if broadcaster != nil {
 		gateway.SetEventListener(broadcaster)
 	}
+	if analyticsClient != nil {
+		gateway.SetAnalyticsClient(analyticsClient)
+	}
 	if stores.planReview != nil {
 		gateway.SetPlanReviewStore(stores.planReview)
 	}
@@ -271,6 +293,21 @@ func wireLarkGateway(ctx context.Context, gateway *lark.Gateway, cfg Config, con
 	if container.CostTracker != nil {
 		gateway.SetCostTracker(container.CostTracker)
 	}
+	if container.PreferencesStore != nil {
+		gateway.SetPreferencesStore(container.PreferencesStore)
+	}
+	if container.SessionTitler != nil {
+		gateway.SetSessionTitler(container.SessionTitler)
+	}
+	if container.SessionTools != nil {
+		gateway.SetSessionToolOverrides(container.SessionTools)
+	}
+	if searcher, ok := container.SessionStore.(lark.SessionSearcher); ok {
+		gateway.SetSessionSearcher(searcher)
+	}
+	if container.Maintenance != nil {
+		gateway.SetMaintenanceNotices(container.Maintenance)
+	}
 
 	gateway.SetTaskStore(stores.task)
 	if err := stores.task.MarkStaleRunning(ctx, "gateway restart"); err != nil {
@@ -0,0 +1,30 @@
+package bootstrap
+
+import (
+	"context"
+	"fmt"
+
+	"alex/internal/app/di"
+	"alex/internal/app/maintenance"
+	"alex/internal/shared/async"
+	runtimeconfig "alex/internal/shared/config"
+	"alex/internal/shared/logging"
+)
+
+// buildMaintenanceService loads scheduled maintenance windows, starts the
+// auto-clear loop, and records the service on the container so the HTTP
+// router and channel gateways share one instance. The returned stop func ends
+// the loop. Returns an error when the store cannot be loaded.
+func buildMaintenanceService(cfg MaintenanceConfig, container *di.Container, logger logging.Logger) (*maintenance.Service, func(), error) {
+	store, err := maintenance.NewStore(maintenance.ResolveStorePath(runtimeconfig.DefaultEnvLookup, nil))
+	if err != nil {
+		return nil, func() {}, fmt.Errorf("load maintenance store: %w", err)
+	}
+	svc := maintenance.NewService(store, maintenance.Config{NoticeLead: cfg.NoticeLead}, logging.NewComponentLogger("Maintenance"))
+	ctx, cancel := context.WithCancel(context.Background())
+	async.Go(logger, "maintenance", func() { svc.Run(ctx) })
+	if container != nil {
+		container.Maintenance = svc
+	}
+	return svc, cancel, nil
+}
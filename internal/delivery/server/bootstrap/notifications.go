@@ -0,0 +1,79 @@
+package bootstrap
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"alex/internal/app/agent/eventbus"
+	"alex/internal/app/di"
+	"alex/internal/app/lifecycle"
+	"alex/internal/app/notifications"
+	serverApp "alex/internal/delivery/server/app"
+	agent "alex/internal/domain/agent/ports/agent"
+	runtimeconfig "alex/internal/shared/config"
+	"alex/internal/shared/logging"
+)
+
+const notificationsSubscription = "notifications"
+
+// buildNotificationCenter creates the in-app notification center and records
+// it on the container so channel gateways and HTTP routers share one instance.
+// Returns an error when the store cannot be loaded.
+func buildNotificationCenter(cfg NotificationsConfig, container *di.Container) (*notifications.Center, error) {
+	store, err := notifications.NewStore(notifications.ResolveStorePath(runtimeconfig.DefaultEnvLookup, nil))
+	if err != nil {
+		return nil, fmt.Errorf("load notification store: %w", err)
+	}
+	var resolver notifications.UserResolver
+	if container != nil && container.SessionStore != nil {
+		sessions := container.SessionStore
+		resolver = func(ctx context.Context, sessionID string) string {
+			session, err := sessions.Get(ctx, sessionID)
+			if err != nil || session == nil || session.Metadata == nil {
+				return ""
+			}
+			return strings.TrimSpace(session.Metadata["user_id"])
+		}
+	}
+	center := notifications.New(store, notifications.Config{
+		Types:      notifications.ParseTypes(cfg.Types),
+		MaxPerUser: cfg.MaxPerUser,
+	}, resolver, logging.NewComponentLogger("Notifications"))
+	if container != nil {
+		container.Notifications = center
+	}
+	return center, nil
+}
+
+// subscribeNotifications attaches the center to the server event bus. Delivery
+// is guaranteed so terminal task events are never silently dropped.
+func subscribeNotifications(bus *eventbus.Bus, center *notifications.Center, logger logging.Logger) {
+	if bus == nil || center == nil {
+		return
+	}
+	if _, err := bus.Subscribe(eventbus.Subscription{Name: notificationsSubscription, Consumer: center, Mode: eventbus.DeliveryGuaranteed}); err != nil {
+		logger.Warn("Event bus subscribe %s failed: %v", notificationsSubscription, err)
+	}
+}
+
+// buildNotificationListener fans Lark-mode task events out to the broadcaster
+// and, through a private bus, to container.Notifications (which must be set).
+// The broadcaster stays on the synchronous path; the bus is drained with the
+// container.
+func buildNotificationListener(broadcaster *serverApp.EventBroadcaster, container *di.Container, logger logging.Logger) agent.EventListener {
+	bus := eventbus.New(logging.NewComponentLogger("NotificationBus"))
+	subscribeNotifications(bus, container.Notifications, logger)
+	container.Drainables = append(container.Drainables, lifecycle.DrainFunc{
+		DrainName: "notifications-bus",
+		Fn: func(ctx context.Context) {
+			if err := bus.Close(ctx); err != nil {
+				logger.Warn("Notification bus close incomplete: %v", err)
+			}
+		},
+	})
+	if broadcaster == nil {
+		return bus
+	}
+	return serverApp.NewMultiEventListener(broadcaster, bus)
+}
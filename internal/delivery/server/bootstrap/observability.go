@@ -2,18 +2,19 @@ package bootstrap
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"alex/internal/infra/observability"
 	"alex/internal/shared/logging"
 )
 
-// InitObservability best-effort initializes observability and returns a cleanup hook.
-func InitObservability(configPath string, logger logging.Logger) (*observability.Observability, func()) {
+// InitObservability initializes observability and returns a cleanup hook.
+// The caller decides whether an error is fatal; on error obs and cleanup are nil.
+func InitObservability(configPath string, logger logging.Logger) (*observability.Observability, func(), error) {
 	obs, err := observability.New(configPath)
 	if err != nil {
-		logging.OrNop(logger).Warn("Observability disabled: %v", err)
-		return nil, nil
+		return nil, nil, fmt.Errorf("init observability: %w", err)
 	}
 
 	cleanup := func() {
@@ -24,5 +25,5 @@ func InitObservability(configPath string, logger logging.Logger) (*observability
 		}
 	}
 
-	return obs, cleanup
+	return obs, cleanup, nil
 }
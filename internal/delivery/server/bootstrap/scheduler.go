@@ -20,6 +20,7 @@ import (
 	"alex/internal/shared/async"
 	"alex/internal/shared/logging"
 	"alex/internal/shared/notification"
+	"alex/internal/shared/runhistory"
 )
 
 // instrumentedNotifier returns a notifier wrapped with alert outcome telemetry
@@ -41,10 +42,12 @@ func startScheduler(ctx context.Context, cfg Config, container *di.Container, me
 	notifier := BuildNotifiers(cfg, "Scheduler", logger)
 
 	var jobStore scheduler.JobStore
+	historyDir := ""
 	jobStorePath := strings.TrimSpace(cfg.Runtime.Proactive.Scheduler.JobStorePath)
 	if jobStorePath != "" {
 		jobStorePath = expandHome(jobStorePath)
 		jobStore = scheduler.NewFileJobStore(jobStorePath)
+		historyDir = filepath.Join(jobStorePath, "history")
 		logger.Info("Scheduler: job store initialized at %s", jobStorePath)
 	}
 
@@ -80,6 +83,11 @@ func startScheduler(ctx context.Context, cfg Config, container *di.Container, me
 		RecoveryMaxRetries:  cfg.Runtime.Proactive.Scheduler.RecoveryMaxRetries,
 		RecoveryBackoff:     time.Duration(cfg.Runtime.Proactive.Scheduler.RecoveryBackoffSeconds) * time.Second,
 		LeaderLock:          buildLeaderLock(cfg, logger),
+		History:             runhistory.NewStore(historyDir, cfg.Runtime.Proactive.Scheduler.HistoryMaxRecords),
+		FailureAlert: runhistory.AlertConfig{
+			Threshold:  cfg.Runtime.Proactive.Scheduler.FailureAlertThreshold,
+			WebhookURL: cfg.Runtime.Proactive.Scheduler.FailureAlertWebhook,
+		},
 	}
 
 	sched := scheduler.New(schedCfg, container.AgentCoordinator, notifier, logger)
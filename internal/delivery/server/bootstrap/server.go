@@ -13,12 +13,16 @@ import (
 	"time"
 
 	"alex/internal/app/lifecycle"
+	"alex/internal/app/sessionimport"
 	"alex/internal/app/subscription"
 	serverApp "alex/internal/delivery/server/app"
 	serverHTTP "alex/internal/delivery/server/http"
+	"alex/internal/delivery/server/ports"
 	agentdomain "alex/internal/domain/agent"
+	agent "alex/internal/domain/agent/ports/agent"
 	"alex/internal/infra/analytics"
 	"alex/internal/infra/diagnostics"
+	"alex/internal/infra/observability"
 	"alex/internal/shared/async"
 	runtimeconfig "alex/internal/shared/config"
 	"alex/internal/shared/logging"
@@ -45,6 +49,8 @@ func RunServer(observabilityConfigPath string) error { //nolint:cyclop // multi-
 	var historyStore serverApp.EventHistoryStore
 	var analyticsClient analytics.Client
 	var analyticsCleanup func()
+	stopSegmentSweeper := func() {}
+	defer func() { stopSegmentSweeper() }()
 
 	optionalStages := []BootstrapStage{
 		f.AttachmentStage(),
@@ -52,24 +58,31 @@ func RunServer(observabilityConfigPath string) error { //nolint:cyclop // multi-
 			Name: "event-history", Required: false,
 			Init: func() error {
 				eventsDir := filepath.Join(container.SessionDir(), "_server")
-				fileHistory := serverApp.NewFileEventHistoryStore(eventsDir)
+				fileHistory := serverApp.NewFileEventHistoryStore(eventsDir,
+					serverApp.WithSegmentMaxBytes(int64(config.EventHistory.SegmentMaxMB)*1024*1024))
 				if err := fileHistory.EnsureSchema(context.Background()); err != nil {
 					return err
 				}
 				historyStore = fileHistory
+				stopSegmentSweeper = startEventSegmentSweeper(fileHistory, config.EventHistory.Retention, logger)
 				return nil
 			},
 		},
 		{
 			Name: "analytics", Required: false,
 			Init: func() error {
-				analyticsClient, analyticsCleanup = BuildAnalyticsClient(config.Analytics, logger)
-				return nil
+				var metrics *observability.MetricsCollector
+				if f.Obs != nil {
+					metrics = f.Obs.Metrics
+				}
+				var err error
+				analyticsClient, analyticsCleanup, err = BuildAnalyticsClient(config.Analytics, metrics, logger)
+				return err
 			},
 		},
 	}
 
-	if err := RunStages(optionalStages, f.Degraded, logger); err != nil {
+	if err := f.Startup.RunStages(optionalStages); err != nil {
 		return fmt.Errorf("optional stages: %w", err)
 	}
 
@@ -95,8 +108,25 @@ func RunServer(observabilityConfigPath string) error { //nolint:cyclop // multi-
 		return err
 	}
 	progressTracker := serverApp.NewTaskProgressTracker(taskStore)
+	analyticsTracker := serverApp.NewTaskAnalyticsTracker(analyticsClient)
+
+	eventBus, closeEventBus := buildEventBus(broadcaster, progressTracker, analyticsTracker, logger)
+	defer closeEventBus()
+
+	notificationCenter, err := buildNotificationCenter(config.Notifications, container)
+	if reportErr := f.Startup.Report("notifications", false, err); reportErr != nil {
+		return reportErr
+	}
+	subscribeNotifications(eventBus, notificationCenter, logger)
 
-	cleanupDiagnostics := subscribeDiagnostics(broadcaster)
+	maintenanceSvc, stopMaintenance, err := buildMaintenanceService(config.Maintenance, container, logger)
+	if reportErr := f.Startup.Report("maintenance", false, err); reportErr != nil {
+		return reportErr
+	}
+	defer stopMaintenance()
+
+	diagnostics.SetHistorySize(config.DiagnosticsHistorySize)
+	cleanupDiagnostics := subscribeDiagnostics(eventBus)
 	defer cleanupDiagnostics()
 
 	// ── Build the 3 standalone services ──
@@ -105,6 +135,8 @@ func RunServer(observabilityConfigPath string) error { //nolint:cyclop // multi-
 		serverApp.WithTaskAnalytics(analyticsClient),
 		serverApp.WithTaskObservability(f.Obs),
 		serverApp.WithTaskProgressTracker(progressTracker),
+		serverApp.WithTaskAnalyticsTracker(analyticsTracker),
+		serverApp.WithTaskEventBus(eventBus),
 		serverApp.WithTaskStateStore(container.StateStore),
 	}
 	if ownerID := strings.TrimSpace(config.TaskExecution.OwnerID); ownerID != "" {
@@ -115,9 +147,15 @@ func RunServer(observabilityConfigPath string) error { //nolint:cyclop // multi-
 	}
 	// MaxInFlight == 0 explicitly disables admission limiter.
 	taskOpts = append(taskOpts, serverApp.WithTaskAdmissionLimit(config.TaskExecution.MaxInFlight))
+	taskOpts = append(taskOpts, serverApp.WithTaskQueueLimit(config.TaskExecution.MaxQueued))
 	if config.TaskExecution.ResumeClaimBatchSize > 0 {
 		taskOpts = append(taskOpts, serverApp.WithResumeClaimBatchSize(config.TaskExecution.ResumeClaimBatchSize))
 	}
+	taskOpts = append(taskOpts, serverApp.WithTaskWorkspace(serverApp.TaskWorkspaceConfig{
+		Mode:       config.Workspace.Mode,
+		Root:       expandHome(config.Workspace.Root),
+		QuotaBytes: config.Workspace.QuotaBytes,
+	}))
 
 	tasksSvc := serverApp.NewTaskExecutionService(
 		container.AgentCoordinator,
@@ -139,6 +177,7 @@ func RunServer(observabilityConfigPath string) error { //nolint:cyclop // multi-
 		broadcaster,
 		serverApp.WithSessionStateStore(container.StateStore),
 		serverApp.WithSessionHistoryStore(container.HistoryStore),
+		serverApp.WithSessionAnnotationStore(container.AnnotationStore),
 	)
 
 	snapshotsSvc := serverApp.NewSnapshotService(
@@ -168,27 +207,56 @@ func RunServer(observabilityConfigPath string) error { //nolint:cyclop // multi-
 		f.TimerManagerStage(subsystems),
 	}
 
-	if err := RunStages(gatewayStages, f.Degraded, logger); err != nil {
+	if err := f.Startup.RunStages(gatewayStages); err != nil {
 		return fmt.Errorf("gateway stages: %w", err)
 	}
 
 	// ── Phase 4: HTTP layer ──
 
 	healthChecker := serverApp.NewHealthChecker()
+	healthChecker.RegisterProbe(serverApp.NewContainerStartedProbe(container))
 	healthChecker.RegisterProbe(serverApp.NewLLMFactoryProbe(container))
-	healthChecker.RegisterProbe(serverApp.NewDegradedProbe(f.Degraded))
-	healthChecker.RegisterProbe(serverApp.NewLLMModelHealthProbe(container))
-	healthChecker.RegisterProbe(serverApp.NewSchedulerProbeFromScheduler(f.Scheduler, 0))
+	// Degraded optional components and upstream model health are reported on
+	// /health but do not take the instance out of rotation.
+	healthChecker.RegisterProbeKind(ports.ProbeKindInformational, serverApp.NewDegradedProbe(f.Degraded))
+	healthChecker.RegisterProbeKind(ports.ProbeKindInformational, serverApp.NewLLMModelHealthProbe(container))
+	healthChecker.RegisterProbeKind(ports.ProbeKindInformational, serverApp.NewSchedulerProbeFromScheduler(f.Scheduler, 0))
+	healthChecker.SetDegradedThresholds(config.HealthDegradedThresholds)
+	if broadcaster != nil {
+		healthChecker.SetEventListener(broadcaster)
+	}
 
 	runtimeUpdates, runtimeReloader := f.RuntimeCacheUpdates()
 	configHandler := serverHTTP.NewConfigHandler(f.ConfigManager(), f.Resolver(), runtimeUpdates, runtimeReloader)
+	wireConfigHandlerHotReload(configHandler, container, broadcaster)
 	onboardingStore := subscription.NewOnboardingStateStore(
 		subscription.ResolveOnboardingStatePath(runtimeconfig.DefaultEnvLookup, nil),
 	)
 	onboardingStateHandler := serverHTTP.NewOnboardingStateHandler(onboardingStore)
 	evaluationService, err := serverApp.NewEvaluationService("./evaluation_results")
-	if err != nil {
-		logger.Warn("Evaluation service disabled: %v", err)
+	if reportErr := f.Startup.Report("evaluation", false, err); reportErr != nil {
+		return reportErr
+	}
+	var preferencesHandler *serverHTTP.PreferencesHandler
+	if container.PreferencesStore != nil {
+		preferencesHandler = serverHTTP.NewPreferencesHandler(container.PreferencesStore)
+	}
+	var notificationsHandler *serverHTTP.NotificationsHandler
+	if notificationCenter != nil {
+		notificationsHandler = serverHTTP.NewNotificationsHandler(notificationCenter)
+	}
+	var importHandler *serverHTTP.ImportHandler
+	if container.SessionStore != nil {
+		importer := sessionimport.New(container.SessionStore, sessionimport.NewMemoryCapture(container.MemoryEngine), logging.NewComponentLogger("SessionImport"))
+		importHandler = serverHTTP.NewImportHandler(sessionimport.NewJobs(importer, logging.NewComponentLogger("SessionImport")))
+	}
+	var schedulerHandler *serverHTTP.SchedulerHandler
+	if f.Scheduler != nil {
+		schedulerHandler = serverHTTP.NewSchedulerHandler(f.Scheduler)
+	}
+	var outputPolicyHandler *serverHTTP.OutputPolicyHandler
+	if container.OutputPolicy != nil {
+		outputPolicyHandler = serverHTTP.NewOutputPolicyHandler(container.OutputPolicy)
 	}
 	var larkOAuthHandler *serverHTTP.LarkOAuthHandler
 	if container.LarkOAuth != nil {
@@ -213,6 +281,18 @@ func RunServer(observabilityConfigPath string) error { //nolint:cyclop // multi-
 		startHandoffNotifier(context.Background(), runtimeBus, container.LarkGateway, config.HooksBridge.DefaultChatID, logger)
 	}
 
+	// A nil *llm.ResponseCache must stay a nil interface.
+	var llmResponseCache serverHTTP.LLMResponseCachePurger
+	if cache := container.LLMResponseCache(); cache != nil {
+		llmResponseCache = cache
+	}
+
+	// A nil CostTracker must stay a nil interface.
+	var usageReporter serverHTTP.UsageReporter
+	if container.CostTracker != nil {
+		usageReporter = container.CostTracker
+	}
+
 	router := serverHTTP.NewRouter(
 		serverHTTP.RouterDeps{
 			Tasks:                  tasksSvc,
@@ -223,6 +303,16 @@ func RunServer(observabilityConfigPath string) error { //nolint:cyclop // multi-
 			HealthChecker:          healthChecker,
 			ConfigHandler:          configHandler,
 			OnboardingStateHandler: onboardingStateHandler,
+			PreferencesHandler:     preferencesHandler,
+			NotificationsHandler:   notificationsHandler,
+			ImportHandler:          importHandler,
+			SchedulerHandler:       schedulerHandler,
+			OutputPolicyHandler:    outputPolicyHandler,
+			Maintenance:            maintenanceSvc,
+			Diagnostics:            diagnosticsHistory{},
+			ConfigDiagnostics:      &f.ConfigResult.Diagnostics,
+			LLMResponseCache:       llmResponseCache,
+			Usage:                  usageReporter,
 			Evaluation:             evaluationService,
 			Obs:                    f.Obs,
 			AttachmentCfg:          config.Attachment,
@@ -241,18 +331,20 @@ func RunServer(observabilityConfigPath string) error { //nolint:cyclop // multi-
 				MaxConcurrent: config.StreamGuard.MaxConcurrent,
 			},
 			RateLimit: serverHTTP.RateLimitConfig{
-				RequestsPerMinute: config.RateLimit.RequestsPerMinute,
-				Burst:             config.RateLimit.Burst,
-				TrustedProxies:    config.RateLimit.TrustedProxies,
+				RequestsPerMinute:     config.RateLimit.RequestsPerMinute,
+				Burst:                 config.RateLimit.Burst,
+				TaskRequestsPerMinute: config.RateLimit.TaskRequestsPerMinute,
+				TaskBurst:             config.RateLimit.TaskBurst,
+				ExemptPaths:           config.RateLimit.ExemptPaths,
+				RedisURL:              config.RateLimit.RedisURL,
+				TrustedProxies:        config.RateLimit.TrustedProxies,
 			},
 			NonStreamTimeout: config.NonStreamTimeout,
 			LeaderAPIToken:   config.LeaderAPIToken,
 		},
 	)
 
-	if !f.Degraded.IsEmpty() {
-		logger.Warn("[Bootstrap] Server starting in degraded mode: %v", f.Degraded.Map())
-	}
+	f.Startup.LogSummary("Server")
 
 	diagnostics.PublishEnvironments(diagnostics.EnvironmentPayload{
 		Host:     f.HostEnv,
@@ -294,10 +386,10 @@ func (g *gatewaySubsystem) Stop() {
 	}
 }
 
-func subscribeDiagnostics(broadcaster *serverApp.EventBroadcaster) func() {
+func subscribeDiagnostics(sink agent.EventListener) func() {
 	unsubscribeEnv := diagnostics.SubscribeEnvironments(func(payload diagnostics.EnvironmentPayload) {
 		event := agentdomain.NewDiagnosticEnvironmentSnapshotEvent(payload.Host, payload.Captured)
-		broadcaster.OnEvent(event)
+		sink.OnEvent(event)
 	})
 
 	return func() {
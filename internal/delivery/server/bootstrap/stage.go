@@ -1,7 +1,6 @@
 package bootstrap
 
 import (
-	"fmt"
 	"sync"
 
 	"alex/internal/shared/logging"
@@ -56,17 +55,5 @@ func (d *DegradedComponents) IsEmpty() bool {
 // RunStages executes stages in order. Required stages abort on error;
 // optional stages are recorded as degraded and execution continues.
 func RunStages(stages []BootstrapStage, degraded *DegradedComponents, logger logging.Logger) error {
-	for _, stage := range stages {
-		logger.Info("[Bootstrap] Running stage: %s (required=%v)", stage.Name, stage.Required)
-		if err := stage.Init(); err != nil {
-			if stage.Required {
-				return fmt.Errorf("required stage %q failed: %w", stage.Name, err)
-			}
-			logger.Warn("[Bootstrap] Optional stage %q failed: %v (continuing in degraded mode)", stage.Name, err)
-			if degraded != nil {
-				degraded.Record(stage.Name, err.Error())
-			}
-		}
-	}
-	return nil
+	return NewStartupErrors(StartupConfig{}, degraded, logger).RunStages(stages)
 }
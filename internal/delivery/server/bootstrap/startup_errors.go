@@ -0,0 +1,148 @@
+package bootstrap
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"alex/internal/shared/logging"
+)
+
+// StartupConfig classifies bootstrap components as required or optional.
+// Entries override the built-in default for the named component: a failure of
+// a required component aborts startup, a failure of an optional one is
+// recorded as degraded.
+type StartupConfig struct {
+	RequiredComponents []string
+	OptionalComponents []string
+}
+
+// ComponentResult is the outcome of one reported component initialization.
+type ComponentResult struct {
+	Name     string
+	Required bool
+	Err      error
+}
+
+// StartupErrors collects component failures during bootstrap and decides,
+// per component, whether a failure is fatal or leaves the server degraded.
+// Degraded failures are recorded in the shared DegradedComponents so the
+// readiness probe and diagnostics bundle report them.
+type StartupErrors struct {
+	required map[string]bool
+	degraded *DegradedComponents
+	logger   logging.Logger
+
+	mu      sync.Mutex
+	results []ComponentResult
+}
+
+// NewStartupErrors creates an aggregator that applies cfg's classification
+// and records degraded components into degraded.
+func NewStartupErrors(cfg StartupConfig, degraded *DegradedComponents, logger logging.Logger) *StartupErrors {
+	required := make(map[string]bool, len(cfg.RequiredComponents)+len(cfg.OptionalComponents))
+	for _, name := range cfg.OptionalComponents {
+		if name = strings.TrimSpace(name); name != "" {
+			required[name] = false
+		}
+	}
+	// Required wins when a component is listed in both.
+	for _, name := range cfg.RequiredComponents {
+		if name = strings.TrimSpace(name); name != "" {
+			required[name] = true
+		}
+	}
+	return &StartupErrors{
+		required: required,
+		degraded: degraded,
+		logger:   logging.OrNop(logger),
+	}
+}
+
+// IsRequired reports whether a failure of the named component aborts startup.
+// fallback is the component's built-in classification.
+func (s *StartupErrors) IsRequired(name string, fallback bool) bool {
+	if s == nil {
+		return fallback
+	}
+	if required, ok := s.required[name]; ok {
+		return required
+	}
+	return fallback
+}
+
+// Report records the outcome of initializing a component. It returns a
+// non-nil error only when err is non-nil and the component is required;
+// optional failures are recorded as degraded and startup continues.
+func (s *StartupErrors) Report(name string, requiredByDefault bool, err error) error {
+	if s == nil {
+		if err != nil && requiredByDefault {
+			return fmt.Errorf("required component %q failed: %w", name, err)
+		}
+		return nil
+	}
+	required := s.IsRequired(name, requiredByDefault)
+	s.mu.Lock()
+	s.results = append(s.results, ComponentResult{Name: name, Required: required, Err: err})
+	s.mu.Unlock()
+	if err == nil {
+		return nil
+	}
+	if required {
+		s.logger.Error("[Bootstrap] Required component %q failed: %v", name, err)
+		return fmt.Errorf("required component %q failed: %w", name, err)
+	}
+	s.logger.Warn("[Bootstrap] Optional component %q failed: %v (continuing in degraded mode)", name, err)
+	if s.degraded != nil {
+		s.degraded.Record(name, err.Error())
+	}
+	return nil
+}
+
+// RunStages executes stages in order, reporting each through Report. It
+// stops at the first fatal failure.
+func (s *StartupErrors) RunStages(stages []BootstrapStage) error {
+	for _, stage := range stages {
+		s.logger.Info("[Bootstrap] Running stage: %s (required=%v)", stage.Name, s.IsRequired(stage.Name, stage.Required))
+		if err := s.Report(stage.Name, stage.Required, stage.Init()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Results returns a snapshot of every reported component, in report order.
+func (s *StartupErrors) Results() []ComponentResult {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]ComponentResult(nil), s.results...)
+}
+
+// Summary renders a one-line startup summary: how many components started
+// and which ones are degraded.
+func (s *StartupErrors) Summary() string {
+	results := s.Results()
+	var failed []string
+	for _, r := range results {
+		if r.Err != nil {
+			failed = append(failed, fmt.Sprintf("%s (%v)", r.Name, r.Err))
+		}
+	}
+	if len(failed) == 0 {
+		return fmt.Sprintf("%d components initialized, none degraded", len(results))
+	}
+	sort.Strings(failed)
+	return fmt.Sprintf("%d components initialized, %d degraded: %s",
+		len(results)-len(failed), len(failed), strings.Join(failed, "; "))
+}
+
+// LogSummary logs the consolidated startup summary, as a warning when any
+// component is degraded.
+func (s *StartupErrors) LogSummary(mode string) {
+	if s.degraded != nil && !s.degraded.IsEmpty() {
+		s.logger.Warn("[Bootstrap] %s starting in degraded mode: %s", mode, s.Summary())
+		return
+	}
+	s.logger.Info("[Bootstrap] %s startup: %s", mode, s.Summary())
+}
@@ -0,0 +1,89 @@
+package bootstrap
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestStartupErrorsOptionalFailureRecordsDegraded(t *testing.T) {
+	degraded := NewDegradedComponents()
+	startup := NewStartupErrors(StartupConfig{}, degraded, nil)
+
+	if err := startup.Report("notifications", false, errors.New("disk full")); err != nil {
+		t.Fatalf("optional failure should not abort startup: %v", err)
+	}
+	if err := startup.Report("analytics", false, nil); err != nil {
+		t.Fatalf("success should not abort startup: %v", err)
+	}
+
+	if got := degraded.Map()["notifications"]; got != "disk full" {
+		t.Fatalf("expected notifications degraded with reason, got %v", degraded.Map())
+	}
+	summary := startup.Summary()
+	if !strings.Contains(summary, "1 components initialized, 1 degraded: notifications (disk full)") {
+		t.Fatalf("unexpected summary: %q", summary)
+	}
+}
+
+func TestStartupErrorsRequiredFailureIsFatal(t *testing.T) {
+	degraded := NewDegradedComponents()
+	startup := NewStartupErrors(StartupConfig{}, degraded, nil)
+
+	cause := errors.New("no credentials")
+	err := startup.Report("lark-gateway", true, cause)
+	if err == nil || !errors.Is(err, cause) {
+		t.Fatalf("expected fatal error wrapping cause, got %v", err)
+	}
+	if !degraded.IsEmpty() {
+		t.Fatalf("fatal failures must not be recorded as degraded: %v", degraded.Map())
+	}
+}
+
+func TestStartupErrorsConfigOverridesDefaults(t *testing.T) {
+	degraded := NewDegradedComponents()
+	startup := NewStartupErrors(StartupConfig{
+		RequiredComponents: []string{" maintenance ", "scheduler"},
+		OptionalComponents: []string{"lark-gateway", "scheduler"},
+	}, degraded, nil)
+
+	if err := startup.Report("maintenance", false, errors.New("store unreadable")); err == nil {
+		t.Fatal("expected maintenance configured as required to abort startup")
+	}
+	if err := startup.Report("lark-gateway", true, errors.New("bad secret")); err != nil {
+		t.Fatalf("expected lark-gateway configured as optional to degrade, got %v", err)
+	}
+	if !startup.IsRequired("scheduler", false) {
+		t.Fatal("required list should win over optional list")
+	}
+	if startup.IsRequired("unlisted", false) || !startup.IsRequired("unlisted", true) {
+		t.Fatal("unlisted components should keep their default classification")
+	}
+	if _, ok := degraded.Map()["lark-gateway"]; !ok {
+		t.Fatalf("expected lark-gateway degraded, got %v", degraded.Map())
+	}
+}
+
+func TestStartupErrorsRunStagesUsesClassification(t *testing.T) {
+	degraded := NewDegradedComponents()
+	startup := NewStartupErrors(StartupConfig{RequiredComponents: []string{"attachments"}}, degraded, nil)
+
+	ran := false
+	err := startup.RunStages([]BootstrapStage{
+		{Name: "event-history", Init: func() error { return errors.New("schema") }},
+		{Name: "attachments", Init: func() error { return errors.New("bucket missing") }},
+		{Name: "analytics", Init: func() error { ran = true; return nil }},
+	})
+	if err == nil || !strings.Contains(err.Error(), `"attachments"`) {
+		t.Fatalf("expected attachments failure to abort, got %v", err)
+	}
+	if ran {
+		t.Fatal("stages after a fatal failure must not run")
+	}
+	if got := degraded.Map(); len(got) != 1 || got["event-history"] != "schema" {
+		t.Fatalf("unexpected degraded components: %v", got)
+	}
+	if results := startup.Results(); len(results) != 2 || !results[1].Required {
+		t.Fatalf("unexpected results: %+v", results)
+	}
+}
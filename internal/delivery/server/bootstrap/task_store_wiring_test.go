@@ -18,6 +18,12 @@ func (stubUnifiedTaskStore) Create(context.Context, *taskdomain.Task) error { re
 func (stubUnifiedTaskStore) Get(context.Context, string) (*taskdomain.Task, error) {
 	return &taskdomain.Task{}, nil
 }
+func (stubUnifiedTaskStore) GetWithRevision(context.Context, string) (*taskdomain.Task, int64, error) {
+	return &taskdomain.Task{}, 0, nil
+}
+func (stubUnifiedTaskStore) CompareAndSwap(context.Context, string, int64, *taskdomain.Task) (int64, error) {
+	return 0, nil
+}
 func (stubUnifiedTaskStore) SetStatus(context.Context, string, taskdomain.Status, ...taskdomain.TransitionOption) error {
 	return nil
 }
@@ -31,6 +37,9 @@ func (stubUnifiedTaskStore) SetError(context.Context, string, string) error { re
 func (stubUnifiedTaskStore) SetBridgeMeta(context.Context, string, taskdomain.BridgeMeta) error {
 	return nil
 }
+func (stubUnifiedTaskStore) SetQueued(context.Context, string, int, time.Time) error {
+	return nil
+}
 func (stubUnifiedTaskStore) Delete(context.Context, string) error { return nil }
 func (stubUnifiedTaskStore) TryClaimTask(context.Context, string, string, time.Time) (bool, error) {
 	return true, nil
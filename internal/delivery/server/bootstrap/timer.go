@@ -3,18 +3,20 @@ package bootstrap
 import (
 	"context"
 	"fmt"
+	"path/filepath"
 	"strings"
 	"time"
 
 	"alex/internal/app/di"
 	"alex/internal/shared/async"
 	"alex/internal/shared/logging"
+	"alex/internal/shared/runhistory"
 	"alex/internal/shared/timer"
 )
 
 // startTimerManager creates and starts the agent timer manager.
-// Returns the timer manager instance or nil if initialization fails.
-func startTimerManager(ctx context.Context, cfg Config, container *di.Container, logger logging.Logger) *timer.TimerManager {
+// Returns an error if the timer manager cannot be created.
+func startTimerManager(ctx context.Context, cfg Config, container *di.Container, logger logging.Logger) (*timer.TimerManager, error) {
 	logger = logging.OrNop(logger)
 
 	timerCfg := cfg.Runtime.Proactive.Timer
@@ -42,12 +44,16 @@ func startTimerManager(ctx context.Context, cfg Config, container *di.Container,
 		StorePath:   storePath,
 		MaxTimers:   maxTimers,
 		TaskTimeout: taskTimeout,
+		History:     runhistory.NewStore(filepath.Join(storePath, "history"), timerCfg.HistoryMaxRecords),
+		FailureAlert: runhistory.AlertConfig{
+			Threshold:  timerCfg.FailureAlertThreshold,
+			WebhookURL: timerCfg.FailureAlertWebhook,
+		},
 	}
 
 	mgr, err := timer.NewTimerManager(mgrCfg, container.AgentCoordinator, notifier, logger)
 	if err != nil {
-		logger.Warn("TimerManager: failed to create: %v", err)
-		return nil
+		return nil, fmt.Errorf("create timer manager: %w", err)
 	}
 
 	// Wire the timer manager into the coordinator so tools can access it via context.
@@ -70,7 +76,7 @@ func startTimerManager(ctx context.Context, cfg Config, container *di.Container,
 	})
 
 	logger.Info("TimerManager started (store=%s, max_timers=%d, timeout=%s)", storePath, maxTimers, taskTimeout)
-	return mgr
+	return mgr, nil
 }
 
 func ensureHeartbeatTimer(mgr *timer.TimerManager, minutes int, logger logging.Logger) error {
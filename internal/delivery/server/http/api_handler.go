@@ -36,6 +36,7 @@ type APIHandler struct {
 	maxCreateTaskBodySize int64
 	selectionResolver     *subscription.SelectionResolver
 	memoryEngine          MemoryEngine
+	maintenance           maintenanceNotices
 }
 
 // APIHandlerOption configures API handler behavior.
@@ -77,6 +78,13 @@ func WithMemoryEngine(engine MemoryEngine) APIHandlerOption {
 	}
 }
 
+// WithMaintenance refuses new tasks while a maintenance window is active.
+func WithMaintenance(notices maintenanceNotices) APIHandlerOption {
+	return func(handler *APIHandler) {
+		handler.maintenance = notices
+	}
+}
+
 // WithMaxCreateTaskBodySize overrides the maximum accepted body size for CreateTask requests.
 func WithMaxCreateTaskBodySize(limit int64) APIHandlerOption {
 	return func(handler *APIHandler) {
@@ -0,0 +1,119 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"alex/internal/app/annotations"
+	id "alex/internal/shared/utils/id"
+)
+
+// CreateAnnotationRequest is the POST /api/sessions/{session_id}/messages/{index}/annotations payload.
+type CreateAnnotationRequest struct {
+	Text     string `json:"text"`
+	Bookmark bool   `json:"bookmark"`
+}
+
+// UpdateAnnotationRequest is the PATCH /api/sessions/{session_id}/annotations/{annotation_id}
+// payload. Omitted fields are left unchanged.
+type UpdateAnnotationRequest struct {
+	Text     *string `json:"text"`
+	Bookmark *bool   `json:"bookmark"`
+}
+
+type SessionAnnotationsResponse struct {
+	SessionID   string                   `json:"session_id"`
+	Annotations []annotations.Annotation `json:"annotations"`
+}
+
+// HandleCreateAnnotation handles POST /api/sessions/{session_id}/messages/{index}/annotations
+func (h *APIHandler) HandleCreateAnnotation(w http.ResponseWriter, r *http.Request) {
+	sessionID, err := extractRequiredSessionIDFromPath(r)
+	if err != nil {
+		h.writeJSONError(w, http.StatusBadRequest, err.Error(), err)
+		return
+	}
+	index, err := strconv.Atoi(r.PathValue("index"))
+	if err != nil {
+		h.writeJSONError(w, http.StatusBadRequest, "index must be numeric", err)
+		return
+	}
+
+	var req CreateAnnotationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeJSONError(w, http.StatusBadRequest, "Invalid request payload", err)
+		return
+	}
+
+	annotation, err := h.sessions.AnnotateMessage(r.Context(), sessionID, index, annotations.Input{
+		Text:     req.Text,
+		Bookmark: req.Bookmark,
+		Author:   id.UserIDFromContext(r.Context()),
+	})
+	if err != nil {
+		h.writeMappedError(w, err, http.StatusInternalServerError, "Failed to annotate message")
+		return
+	}
+	h.writeJSON(w, http.StatusCreated, annotation)
+}
+
+// HandleListAnnotations handles GET /api/sessions/{session_id}/annotations
+func (h *APIHandler) HandleListAnnotations(w http.ResponseWriter, r *http.Request) {
+	sessionID, err := extractRequiredSessionIDFromPath(r)
+	if err != nil {
+		h.writeJSONError(w, http.StatusBadRequest, err.Error(), err)
+		return
+	}
+
+	items, err := h.sessions.ListAnnotations(r.Context(), sessionID)
+	if err != nil {
+		h.writeMappedError(w, err, http.StatusInternalServerError, "Failed to list annotations")
+		return
+	}
+	if items == nil {
+		items = []annotations.Annotation{}
+	}
+	h.writeJSON(w, http.StatusOK, SessionAnnotationsResponse{SessionID: sessionID, Annotations: items})
+}
+
+// HandleUpdateAnnotation handles PATCH /api/sessions/{session_id}/annotations/{annotation_id}
+func (h *APIHandler) HandleUpdateAnnotation(w http.ResponseWriter, r *http.Request) {
+	sessionID, err := extractRequiredSessionIDFromPath(r)
+	if err != nil {
+		h.writeJSONError(w, http.StatusBadRequest, err.Error(), err)
+		return
+	}
+
+	var req UpdateAnnotationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeJSONError(w, http.StatusBadRequest, "Invalid request payload", err)
+		return
+	}
+
+	annotation, err := h.sessions.UpdateAnnotation(r.Context(), sessionID, strings.TrimSpace(r.PathValue("annotation_id")), annotations.Patch{
+		Text:     req.Text,
+		Bookmark: req.Bookmark,
+	})
+	if err != nil {
+		h.writeMappedError(w, err, http.StatusInternalServerError, "Failed to update annotation")
+		return
+	}
+	h.writeJSON(w, http.StatusOK, annotation)
+}
+
+// HandleDeleteAnnotation handles DELETE /api/sessions/{session_id}/annotations/{annotation_id}
+func (h *APIHandler) HandleDeleteAnnotation(w http.ResponseWriter, r *http.Request) {
+	sessionID, err := extractRequiredSessionIDFromPath(r)
+	if err != nil {
+		h.writeJSONError(w, http.StatusBadRequest, err.Error(), err)
+		return
+	}
+
+	if err := h.sessions.DeleteAnnotation(r.Context(), sessionID, strings.TrimSpace(r.PathValue("annotation_id"))); err != nil {
+		h.writeMappedError(w, err, http.StatusInternalServerError, "Failed to delete annotation")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,121 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"alex/internal/app/annotations"
+	"alex/internal/delivery/server/app"
+	core "alex/internal/domain/agent/ports"
+	"alex/internal/infra/tape"
+	id "alex/internal/shared/utils/id"
+)
+
+func TestSessionAnnotationsCRUDExportAndCascade(t *testing.T) {
+	ctx := context.Background()
+	sessionStore := tape.NewSessionAdapter(tape.NewMemoryStore())
+	annotationStore, err := annotations.NewStore("")
+	if err != nil {
+		t.Fatalf("new annotation store: %v", err)
+	}
+	broadcaster := app.NewEventBroadcaster()
+	sessions := app.NewSessionService(&stubAgentCoordinator{}, sessionStore, broadcaster, app.WithSessionAnnotationStore(annotationStore))
+	handler := NewAPIHandler(nil, sessions, nil, app.NewHealthChecker(), false)
+	mux := http.NewServeMux()
+	registerSessionRoutes(mux, handler)
+
+	session, err := sessionStore.Create(ctx)
+	if err != nil {
+		t.Fatalf("create session: %v", err)
+	}
+	session.Messages = []core.Message{
+		{Role: "user", Content: "design the orders schema"},
+		{Role: "assistant", Content: "I'll use a single denormalized table."},
+	}
+	if err := sessionStore.Save(ctx, session); err != nil {
+		t.Fatalf("save session: %v", err)
+	}
+	base := "/api/sessions/" + session.ID
+
+	do := func(method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, bytes.NewBufferString(body))
+		req = req.WithContext(id.WithUserID(req.Context(), "ou_reviewer"))
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		return rec
+	}
+
+	if rec := do(http.MethodPost, base+"/messages/1/annotations", `{}`); rec.Code != http.StatusBadRequest {
+		t.Fatalf("empty annotation status = %d body=%s", rec.Code, rec.Body.String())
+	}
+	if rec := do(http.MethodPost, base+"/messages/7/annotations", `{"bookmark":true}`); rec.Code != http.StatusNotFound {
+		t.Fatalf("out-of-range status = %d body=%s", rec.Code, rec.Body.String())
+	}
+
+	rec := do(http.MethodPost, base+"/messages/1/annotations", `{"text":"this is where it chose the wrong schema","bookmark":true}`)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("create status = %d body=%s", rec.Code, rec.Body.String())
+	}
+	var created annotations.Annotation
+	if err := json.Unmarshal(rec.Body.Bytes(), &created); err != nil {
+		t.Fatalf("decode created: %v", err)
+	}
+	if created.Author != "ou_reviewer" || created.MessageIndex != 1 || created.Snippet != "I'll use a single denormalized table." {
+		t.Fatalf("unexpected created annotation: %+v", created)
+	}
+	if rec := do(http.MethodPost, base+"/messages/0/annotations", `{"text":"good prompt"}`); rec.Code != http.StatusCreated {
+		t.Fatalf("second create status = %d", rec.Code)
+	}
+
+	rec = do(http.MethodPatch, base+"/annotations/"+created.ID, `{"bookmark":false}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("update status = %d body=%s", rec.Code, rec.Body.String())
+	}
+
+	rec = do(http.MethodGet, base+"/annotations", "")
+	var list SessionAnnotationsResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &list); err != nil || rec.Code != http.StatusOK {
+		t.Fatalf("list status=%d err=%v body=%s", rec.Code, err, rec.Body.String())
+	}
+	if len(list.Annotations) != 2 || list.Annotations[0].Text != "good prompt" || list.Annotations[1].ID != created.ID || list.Annotations[1].Bookmark {
+		t.Fatalf("unexpected annotation list: %+v", list.Annotations)
+	}
+
+	// Shared session exports carry the annotations.
+	token, err := sessions.EnsureSessionShareToken(ctx, session.ID, false)
+	if err != nil {
+		t.Fatalf("share token: %v", err)
+	}
+	share := NewShareHandler(sessions, NewSSEHandler(broadcaster))
+	shareReq := httptest.NewRequest(http.MethodGet, "/api/share/sessions/"+session.ID+"?token="+token, nil)
+	shareReq.SetPathValue("session_id", session.ID)
+	shareRec := httptest.NewRecorder()
+	share.HandleSharedSession(shareRec, shareReq)
+	var exported ShareSessionResponse
+	if err := json.Unmarshal(shareRec.Body.Bytes(), &exported); err != nil || shareRec.Code != http.StatusOK {
+		t.Fatalf("share status=%d err=%v body=%s", shareRec.Code, err, shareRec.Body.String())
+	}
+	if len(exported.Annotations) != 2 || exported.Annotations[1].Text != created.Text {
+		t.Fatalf("expected annotations in export, got %+v", exported.Annotations)
+	}
+
+	if rec := do(http.MethodDelete, base+"/annotations/"+created.ID, ""); rec.Code != http.StatusNoContent {
+		t.Fatalf("delete status = %d", rec.Code)
+	}
+	if rec := do(http.MethodDelete, base+"/annotations/"+created.ID, ""); rec.Code != http.StatusNotFound {
+		t.Fatalf("second delete status = %d", rec.Code)
+	}
+
+	// Deleting the session removes its remaining annotations.
+	if rec := do(http.MethodDelete, base, ""); rec.Code != http.StatusNoContent {
+		t.Fatalf("delete session status = %d body=%s", rec.Code, rec.Body.String())
+	}
+	remaining, err := annotationStore.List(ctx, session.ID, nil)
+	if err != nil || len(remaining) != 0 {
+		t.Fatalf("expected annotations cascaded with session, got %+v err=%v", remaining, err)
+	}
+}
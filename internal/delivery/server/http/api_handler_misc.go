@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"strings"
 
+	serverPorts "alex/internal/delivery/server/ports"
 	"alex/internal/shared/logging"
 )
 
@@ -169,6 +170,41 @@ func (h *APIHandler) HandleHealthCheck(w http.ResponseWriter, r *http.Request) {
 	h.writeJSON(w, httpStatus, response)
 }
 
+// HandleLiveness handles GET /livez. It succeeds whenever the process can
+// serve requests; only a liveness probe reporting an error fails it.
+func (h *APIHandler) HandleLiveness(w http.ResponseWriter, r *http.Request) {
+	probes := h.healthChecker.CheckKind(r.Context(), serverPorts.ProbeKindLiveness)
+	status, httpStatus := "alive", http.StatusOK
+	for _, probe := range probes {
+		if probe.Status == serverPorts.HealthStatusError {
+			status, httpStatus = "dead", http.StatusServiceUnavailable
+			break
+		}
+	}
+	h.writeJSON(w, httpStatus, map[string]interface{}{
+		"status": status,
+		"probes": probes,
+	})
+}
+
+// HandleReadiness handles GET /readyz. It fails until every readiness probe,
+// including container startup, reports ready, degraded or disabled.
+func (h *APIHandler) HandleReadiness(w http.ResponseWriter, r *http.Request) {
+	probes := h.healthChecker.CheckKind(r.Context(), serverPorts.ProbeKindReadiness)
+	status, httpStatus := "ready", http.StatusOK
+	for _, probe := range probes {
+		if probe.Status != serverPorts.HealthStatusReady && probe.Status != serverPorts.HealthStatusDegraded &&
+			probe.Status != serverPorts.HealthStatusDisabled {
+			status, httpStatus = "not_ready", http.StatusServiceUnavailable
+			break
+		}
+	}
+	h.writeJSON(w, httpStatus, map[string]interface{}{
+		"status": status,
+		"probes": probes,
+	})
+}
+
 // HandleModelHealthDebug handles GET /api/debug/health/models — returns per-model
 // sanitized telemetry (error rates, health scores, state). Only exposed on the
 // debug server, never on the public /health endpoint.
@@ -90,6 +90,17 @@ func (h *APIHandler) writeJSON(w http.ResponseWriter, status int, payload any) {
 	}
 }
 
+// writeETaggedJSON is writeJSON for cacheable GET resources: it sets a
+// content-hash ETag and answers a matching If-None-Match with 304.
+func (h *APIHandler) writeETaggedJSON(w http.ResponseWriter, r *http.Request, payload any) {
+	body, etag, err := encodeETagged(payload)
+	if err != nil {
+		h.writeJSONError(w, http.StatusInternalServerError, "Failed to encode response", err)
+		return
+	}
+	writeETaggedBody(w, r, etag, body)
+}
+
 func (h *APIHandler) parseOptionalQueryInt(
 	w http.ResponseWriter,
 	r *http.Request,
@@ -8,9 +8,14 @@ import (
 	"strings"
 	"time"
 
+	"alex/internal/app/agent/sessiontitle"
+	"alex/internal/app/agent/sessiontools"
+	"alex/internal/app/annotations"
 	"alex/internal/delivery/server/app"
 	core "alex/internal/domain/agent/ports"
 	agent "alex/internal/domain/agent/ports/agent"
+	storage "alex/internal/domain/agent/ports/storage"
+	tools "alex/internal/domain/agent/ports/tools"
 )
 
 const (
@@ -40,6 +45,17 @@ type SessionPersonaResponse struct {
 	UserPersona *core.UserPersonaProfile `json:"user_persona,omitempty"`
 }
 
+type SessionToolOverridesRequest struct {
+	Allow []string `json:"allow"`
+	Deny  []string `json:"deny"`
+}
+
+type SessionToolOverridesResponse struct {
+	SessionID string   `json:"session_id"`
+	Allow     []string `json:"allow"`
+	Deny      []string `json:"deny"`
+}
+
 type TurnSnapshotResponse struct {
 	SessionID  string                 `json:"session_id"`
 	TurnID     int                    `json:"turn_id"`
@@ -56,12 +72,20 @@ type TurnSnapshotResponse struct {
 
 // SessionResponse matches TypeScript Session interface
 type SessionResponse struct {
-	ID        string `json:"id"`
-	Title     string `json:"title,omitempty"`
-	CreatedAt string `json:"created_at"`
-	UpdatedAt string `json:"updated_at"`
-	TaskCount int    `json:"task_count"`
-	LastTask  string `json:"last_task,omitempty"`
+	ID        string   `json:"id"`
+	Title     string   `json:"title,omitempty"`
+	Tags      []string `json:"tags,omitempty"`
+	CreatedAt string   `json:"created_at"`
+	UpdatedAt string   `json:"updated_at"`
+	TaskCount int      `json:"task_count"`
+	LastTask  string   `json:"last_task,omitempty"`
+}
+
+// UpdateSessionRequest is the PATCH /api/sessions/{session_id} payload.
+// Omitted tags keep the current tags; an empty list clears them.
+type UpdateSessionRequest struct {
+	Title string   `json:"title"`
+	Tags  []string `json:"tags"`
 }
 
 // SessionListResponse matches TypeScript SessionListResponse interface
@@ -70,17 +94,36 @@ type SessionListResponse struct {
 	Total    int               `json:"total"`
 }
 
+// SessionSearchHitResponse is one session matched by GET /api/sessions/search.
+type SessionSearchHitResponse struct {
+	SessionID string   `json:"session_id"`
+	Title     string   `json:"title,omitempty"`
+	Snippets  []string `json:"snippets"`
+	Matches   int      `json:"matches"`
+	CreatedAt string   `json:"created_at"`
+	UpdatedAt string   `json:"updated_at"`
+}
+
+// SessionSearchResponse is one page of session search hits.
+type SessionSearchResponse struct {
+	Hits   []SessionSearchHitResponse `json:"hits"`
+	Total  int                        `json:"total"`
+	Limit  int                        `json:"limit"`
+	Offset int                        `json:"offset"`
+}
+
 type CreateSessionResponse struct {
 	SessionID string `json:"session_id"`
 }
 
 type ShareSessionResponse struct {
-	SessionID  string           `json:"session_id"`
-	ShareToken string           `json:"share_token"`
-	Events     []map[string]any `json:"events,omitempty"`
-	Title      string           `json:"title,omitempty"`
-	CreatedAt  string           `json:"created_at,omitempty"`
-	UpdatedAt  string           `json:"updated_at,omitempty"`
+	SessionID   string                   `json:"session_id"`
+	ShareToken  string                   `json:"share_token"`
+	Events      []map[string]any         `json:"events,omitempty"`
+	Annotations []annotations.Annotation `json:"annotations,omitempty"`
+	Title       string                   `json:"title,omitempty"`
+	CreatedAt   string                   `json:"created_at,omitempty"`
+	UpdatedAt   string                   `json:"updated_at,omitempty"`
 }
 
 // HandleGetSession handles GET /api/sessions/{session_id}
@@ -96,7 +139,53 @@ func (h *APIHandler) HandleGetSession(w http.ResponseWriter, r *http.Request) {
 		h.writeMappedError(w, err, http.StatusNotFound, "Session not found")
 		return
 	}
-	h.writeJSON(w, http.StatusOK, session)
+	h.writeETaggedJSON(w, r, session)
+}
+
+// HandleUpdateSession handles PATCH /api/sessions/{session_id}. It sets a
+// user title and/or tags, which automatic titling will not overwrite.
+// If-Match and the returned ETag refer to the GET /api/sessions/{session_id}
+// representation.
+func (h *APIHandler) HandleUpdateSession(w http.ResponseWriter, r *http.Request) {
+	sessionID, err := extractRequiredSessionIDFromPath(r)
+	if err != nil {
+		h.writeJSONError(w, http.StatusBadRequest, "Invalid session ID", err)
+		return
+	}
+
+	var req UpdateSessionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeJSONError(w, http.StatusBadRequest, "Invalid request payload", err)
+		return
+	}
+	if hasIfMatch(r) {
+		current, err := h.sessions.GetSession(r.Context(), sessionID)
+		if err != nil {
+			h.writeMappedError(w, err, http.StatusNotFound, "Session not found")
+			return
+		}
+		if !ifMatchSatisfied(r, etagOf(current)) {
+			h.writeJSONError(w, http.StatusPreconditionFailed, "Session was modified", errPreconditionFailed)
+			return
+		}
+	}
+
+	session, err := h.sessions.UpdateSessionTitle(r.Context(), sessionID, req.Title, req.Tags)
+	if err != nil {
+		h.writeMappedError(w, err, http.StatusInternalServerError, "Failed to update session")
+		return
+	}
+	// Re-read so the ETag matches what GET returns after store normalization.
+	if stored, err := h.sessions.GetSession(r.Context(), sessionID); err == nil {
+		setETag(w, etagOf(stored))
+	}
+	h.writeJSON(w, http.StatusOK, SessionResponse{
+		ID:        session.ID,
+		Title:     session.Metadata[sessiontitle.MetadataTitle],
+		Tags:      sessiontitle.Tags(session.Metadata),
+		CreatedAt: session.CreatedAt.Format(time.RFC3339),
+		UpdatedAt: session.UpdatedAt.Format(time.RFC3339),
+	})
 }
 
 // HandleGetSessionPersona handles GET /api/sessions/{session_id}/persona
@@ -112,10 +201,7 @@ func (h *APIHandler) HandleGetSessionPersona(w http.ResponseWriter, r *http.Requ
 		h.writeMappedError(w, err, http.StatusNotFound, "Session not found")
 		return
 	}
-	h.writeJSON(w, http.StatusOK, SessionPersonaResponse{
-		SessionID:   session.ID,
-		UserPersona: session.UserPersona,
-	})
+	h.writeETaggedJSON(w, r, newSessionPersonaResponse(session))
 }
 
 // HandleUpdateSessionPersona handles PUT /api/sessions/{session_id}/persona
@@ -138,16 +224,99 @@ func (h *APIHandler) HandleUpdateSessionPersona(w http.ResponseWriter, r *http.R
 	if req.UserPersona.UpdatedAt.IsZero() {
 		req.UserPersona.UpdatedAt = time.Now()
 	}
+	if hasIfMatch(r) {
+		current, err := h.sessions.GetSession(r.Context(), sessionID)
+		if err != nil {
+			h.writeMappedError(w, err, http.StatusNotFound, "Session not found")
+			return
+		}
+		if !ifMatchSatisfied(r, etagOf(newSessionPersonaResponse(current))) {
+			h.writeJSONError(w, http.StatusPreconditionFailed, "Persona was modified", errPreconditionFailed)
+			return
+		}
+	}
 
 	session, err := h.sessions.UpdateSessionPersona(r.Context(), sessionID, req.UserPersona)
 	if err != nil {
 		h.writeMappedError(w, err, http.StatusInternalServerError, "Failed to update persona")
 		return
 	}
-	h.writeJSON(w, http.StatusOK, SessionPersonaResponse{
+	if stored, err := h.sessions.GetSession(r.Context(), sessionID); err == nil {
+		session = stored
+	}
+	h.writeETaggedJSON(w, r, newSessionPersonaResponse(session))
+}
+
+// HandleGetSessionToolOverrides handles GET /api/sessions/{session_id}/tool-overrides
+func (h *APIHandler) HandleGetSessionToolOverrides(w http.ResponseWriter, r *http.Request) {
+	sessionID, err := extractRequiredSessionIDFromPath(r)
+	if err != nil {
+		h.writeJSONError(w, http.StatusBadRequest, "Invalid session ID", err)
+		return
+	}
+
+	session, err := h.sessions.GetSession(r.Context(), sessionID)
+	if err != nil {
+		h.writeMappedError(w, err, http.StatusNotFound, "Session not found")
+		return
+	}
+	h.writeETaggedJSON(w, r, newSessionToolOverridesResponse(session))
+}
+
+// HandleUpdateSessionToolOverrides handles PUT /api/sessions/{session_id}/tool-overrides
+func (h *APIHandler) HandleUpdateSessionToolOverrides(w http.ResponseWriter, r *http.Request) {
+	sessionID, err := extractRequiredSessionIDFromPath(r)
+	if err != nil {
+		h.writeJSONError(w, http.StatusBadRequest, "Invalid session ID", err)
+		return
+	}
+
+	var req SessionToolOverridesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeJSONError(w, http.StatusBadRequest, "Invalid request payload", err)
+		return
+	}
+	if hasIfMatch(r) {
+		current, err := h.sessions.GetSession(r.Context(), sessionID)
+		if err != nil {
+			h.writeMappedError(w, err, http.StatusNotFound, "Session not found")
+			return
+		}
+		if !ifMatchSatisfied(r, etagOf(newSessionToolOverridesResponse(current))) {
+			h.writeJSONError(w, http.StatusPreconditionFailed, "Tool overrides were modified", errPreconditionFailed)
+			return
+		}
+	}
+
+	overrides := tools.ToolOverrides{Allow: req.Allow, Deny: req.Deny}
+	session, err := h.sessions.UpdateSessionToolOverrides(r.Context(), sessionID, overrides)
+	if err != nil {
+		h.writeMappedError(w, err, http.StatusInternalServerError, "Failed to update tool overrides")
+		return
+	}
+	h.writeETaggedJSON(w, r, newSessionToolOverridesResponse(session))
+}
+
+// newSessionToolOverridesResponse always returns non-nil lists so clients
+// can render an empty state without null checks. A corrupt stored value is
+// reported as no overrides.
+func newSessionToolOverridesResponse(session *storage.Session) SessionToolOverridesResponse {
+	overrides, _ := sessiontools.FromSession(session)
+	resp := SessionToolOverridesResponse{SessionID: session.ID, Allow: overrides.Allow, Deny: overrides.Deny}
+	if resp.Allow == nil {
+		resp.Allow = []string{}
+	}
+	if resp.Deny == nil {
+		resp.Deny = []string{}
+	}
+	return resp
+}
+
+func newSessionPersonaResponse(session *storage.Session) SessionPersonaResponse {
+	return SessionPersonaResponse{
 		SessionID:   session.ID,
 		UserPersona: session.UserPersona,
-	})
+	}
 }
 
 // HandleCreateSession handles POST /api/sessions
@@ -232,6 +401,7 @@ func (h *APIHandler) HandleListSessions(w http.ResponseWriter, r *http.Request)
 		sessions = append(sessions, SessionResponse{
 			ID:        item.ID,
 			Title:     item.Title,
+			Tags:      item.Tags,
 			CreatedAt: item.CreatedAt.Format(time.RFC3339),
 			UpdatedAt: item.UpdatedAt.Format(time.RFC3339),
 			TaskCount: summary.TaskCount,
@@ -246,6 +416,83 @@ func (h *APIHandler) HandleListSessions(w http.ResponseWriter, r *http.Request)
 	h.writeJSON(w, http.StatusOK, response)
 }
 
+// HandleSearchSessions handles GET /api/sessions/search
+func (h *APIHandler) HandleSearchSessions(w http.ResponseWriter, r *http.Request) {
+	limit, ok := h.parseOptionalQueryInt(w, r, "limit", storage.DefaultSessionSearchLimit, 1, storage.MaxSessionSearchLimit, "limit must be a positive integer", nil)
+	if !ok {
+		return
+	}
+	offset, ok := h.parseOptionalQueryInt(w, r, "offset", 0, 0, 0, "offset must be a non-negative integer", nil)
+	if !ok {
+		return
+	}
+	values := r.URL.Query()
+	since, err := parseSearchTime(values.Get("since"), false)
+	if err != nil {
+		h.writeJSONError(w, http.StatusBadRequest, "since must be RFC3339 or YYYY-MM-DD", err)
+		return
+	}
+	until, err := parseSearchTime(values.Get("until"), true)
+	if err != nil {
+		h.writeJSONError(w, http.StatusBadRequest, "until must be RFC3339 or YYYY-MM-DD", err)
+		return
+	}
+
+	query := storage.SessionQuery{
+		Text:          values.Get("q"),
+		ChannelPrefix: values.Get("channel"),
+		UserID:        values.Get("user_id"),
+		Since:         since,
+		Until:         until,
+		Limit:         limit,
+		Offset:        offset,
+	}
+	result, err := h.sessions.SearchSessions(r.Context(), query)
+	if err != nil {
+		h.writeJSONError(w, http.StatusInternalServerError, "Failed to search sessions", err)
+		return
+	}
+
+	hits := make([]SessionSearchHitResponse, 0, len(result.Hits))
+	for _, hit := range result.Hits {
+		snippets := hit.Snippets
+		if snippets == nil {
+			snippets = []string{}
+		}
+		hits = append(hits, SessionSearchHitResponse{
+			SessionID: hit.SessionID,
+			Title:     hit.Title,
+			Snippets:  snippets,
+			Matches:   hit.Matches,
+			CreatedAt: hit.CreatedAt.Format(time.RFC3339),
+			UpdatedAt: hit.UpdatedAt.Format(time.RFC3339),
+		})
+	}
+	h.writeJSON(w, http.StatusOK, SessionSearchResponse{
+		Hits:   hits,
+		Total:  result.Total,
+		Limit:  limit,
+		Offset: offset,
+	})
+}
+
+// parseSearchTime accepts RFC3339 timestamps or plain dates; empty means
+// unset. With endOfDay a plain date covers the whole day.
+func parseSearchTime(raw string, endOfDay bool) (time.Time, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, raw); err == nil {
+		return t, nil
+	}
+	day, err := time.Parse(time.DateOnly, raw)
+	if err != nil || !endOfDay {
+		return day, err
+	}
+	return day.Add(24*time.Hour - time.Nanosecond), nil
+}
+
 // HandleDeleteSession handles DELETE /api/sessions/{session_id}
 func (h *APIHandler) HandleDeleteSession(w http.ResponseWriter, r *http.Request) {
 	sessionID, err := extractRequiredSessionIDFromPath(r)
@@ -336,7 +583,7 @@ func (h *APIHandler) HandleGetTurnSnapshot(w http.ResponseWriter, r *http.Reques
 		Messages:   snapshot.Messages,
 		Feedback:   snapshot.Feedback,
 	}
-	h.writeJSON(w, http.StatusOK, resp)
+	h.writeETaggedJSON(w, r, resp)
 }
 
 // HandleForkSession handles POST /api/sessions/{session_id}/fork
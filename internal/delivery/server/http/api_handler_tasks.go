@@ -11,6 +11,7 @@ import (
 
 	appcontext "alex/internal/app/agent/context"
 	"alex/internal/app/subscription"
+	"alex/internal/delivery/server/app"
 	serverPorts "alex/internal/delivery/server/ports"
 	agentports "alex/internal/domain/agent/ports"
 	"alex/internal/domain/agent/types"
@@ -30,6 +31,7 @@ type CreateTaskRequest struct {
 	ToolPreset   string                  `json:"tool_preset,omitempty"`  // Tool access preset
 	Attachments  []AttachmentPayload     `json:"attachments,omitempty"`
 	LLMSelection *subscription.Selection `json:"llm_selection,omitempty"`
+	Priority     string                  `json:"priority,omitempty"` // low | normal | high (high requires internal mode)
 }
 
 // CreateTaskResponse matches TypeScript CreateTaskResponse interface
@@ -63,8 +65,17 @@ func toTaskStatusResponse(task *serverPorts.Task) TaskStatusResponse {
 		ParentRunID: task.ParentTaskID,
 		Status:      string(task.Status),
 		CreatedAt:   task.CreatedAt.Format(time.RFC3339),
+		Priority:    task.Priority,
 		Error:       task.Error,
 	}
+	if task.QueuedAt != nil {
+		queuedAt := task.QueuedAt.Format(time.RFC3339)
+		response.QueuedAt = &queuedAt
+	}
+	if task.StartedAt != nil {
+		startedAt := task.StartedAt.Format(time.RFC3339)
+		response.StartedAt = &startedAt
+	}
 	if task.CompletedAt != nil {
 		completedAt := task.CompletedAt.Format(time.RFC3339)
 		response.CompletedAt = &completedAt
@@ -82,6 +93,16 @@ func toTaskStatusResponses(tasks []*serverPorts.Task) []TaskStatusResponse {
 
 // HandleCreateTask handles POST /api/tasks - creates and executes a new task
 func (h *APIHandler) HandleCreateTask(w http.ResponseWriter, r *http.Request) {
+	if h.maintenance != nil {
+		if window, ok := h.maintenance.Active(); ok {
+			// Retry-After carries the window end so clients resubmit once
+			// maintenance is over; reads and running streams are unaffected.
+			w.Header().Set("Retry-After", window.EndsAt.UTC().Format(http.TimeFormat))
+			h.writeJSONError(w, http.StatusServiceUnavailable, "Service under maintenance: "+window.Message, nil)
+			return
+		}
+	}
+
 	var req CreateTaskRequest
 	if !h.decodeJSONBody(w, r, &req, h.maxCreateTaskBodySize) {
 		return
@@ -105,6 +126,12 @@ func (h *APIHandler) HandleCreateTask(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	priority, err := h.parseTaskPriority(req.Priority)
+	if err != nil {
+		h.writeJSONError(w, http.StatusBadRequest, err.Error(), err)
+		return
+	}
+
 	h.logger.Info("Creating task: task='%s', sessionID='%s'", req.Task, req.SessionID)
 
 	ctx := id.WithSessionID(r.Context(), req.SessionID)
@@ -116,6 +143,9 @@ func (h *APIHandler) HandleCreateTask(w http.ResponseWriter, r *http.Request) {
 			ctx = appcontext.WithLLMSelection(ctx, resolved)
 		}
 	}
+	if priority != serverPorts.TaskPriorityNormal {
+		ctx = app.WithTaskPriority(ctx, priority)
+	}
 
 	// Execute task asynchronously - coordinator returns immediately after creating task record
 	// Background goroutine will handle actual execution and update status
@@ -142,6 +172,24 @@ func (h *APIHandler) HandleCreateTask(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// parseTaskPriority maps the request priority to a queue priority. Raising
+// priority is an admin action, so "high" is only accepted in internal mode.
+func (h *APIHandler) parseTaskPriority(raw string) (int, error) {
+	switch strings.ToLower(strings.TrimSpace(raw)) {
+	case "", "normal":
+		return serverPorts.TaskPriorityNormal, nil
+	case "low":
+		return serverPorts.TaskPriorityLow, nil
+	case "high":
+		if !h.internalMode {
+			return 0, fmt.Errorf("priority high requires internal mode")
+		}
+		return serverPorts.TaskPriorityHigh, nil
+	default:
+		return 0, fmt.Errorf("priority must be one of low, normal, high")
+	}
+}
+
 func (h *APIHandler) parseAttachments(payloads []AttachmentPayload) ([]agentports.Attachment, error) {
 	if len(payloads) == 0 {
 		return nil, nil
@@ -249,13 +297,7 @@ func (h *APIHandler) HandleGetTask(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Convert to TaskStatusResponse
-	response := toTaskStatusResponse(task)
-
-	w.Header().Set("Content-Type", "application/json")
-	if err := json.NewEncoder(w).Encode(response); err != nil {
-		h.writeJSONError(w, http.StatusInternalServerError, "Failed to encode response", err)
-	}
+	h.writeETaggedJSON(w, r, toTaskStatusResponse(task))
 }
 
 // HandleListTasks handles GET /api/tasks
@@ -482,6 +482,57 @@ func TestHandleListSessionsIncludesTaskSummaryFields(t *testing.T) {
 	}
 }
 
+func TestHandleUpdateSessionSetsTitleOverride(t *testing.T) {
+	sessionStore := tape.NewSessionAdapter(tape.NewMemoryStore())
+	taskStore := app.NewInMemoryTaskStore()
+	defer taskStore.Close()
+	tasks, sessions, snapshots := buildTestServices(
+		storeBackedAgentCoordinator{store: sessionStore},
+		app.NewEventBroadcaster(),
+		sessionStore,
+		taskStore,
+		nil,
+	)
+	handler := NewAPIHandler(tasks, sessions, snapshots, app.NewHealthChecker(), false)
+
+	ctx := context.Background()
+	session, err := sessionStore.Create(ctx)
+	if err != nil {
+		t.Fatalf("create session: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodPatch, "/api/sessions/"+session.ID, strings.NewReader(`{"title":"Billing cleanup","tags":["finance","Ops"]}`))
+	req.SetPathValue("session_id", session.ID)
+	resp := httptest.NewRecorder()
+	handler.HandleUpdateSession(resp, req)
+	if resp.Code != http.StatusOK {
+		t.Fatalf("expected 200 got %d: %s", resp.Code, resp.Body.String())
+	}
+	var payload SessionResponse
+	if err := json.Unmarshal(resp.Body.Bytes(), &payload); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if payload.Title != "Billing cleanup" || strings.Join(payload.Tags, ",") != "finance,ops" {
+		t.Fatalf("unexpected response %+v", payload)
+	}
+
+	stored, err := sessionStore.Get(ctx, session.ID)
+	if err != nil {
+		t.Fatalf("get session: %v", err)
+	}
+	if stored.Metadata["title_source"] != "user" {
+		t.Fatalf("expected user title source, got %v", stored.Metadata)
+	}
+
+	req = httptest.NewRequest(http.MethodPatch, "/api/sessions/"+session.ID, strings.NewReader(`{}`))
+	req.SetPathValue("session_id", session.ID)
+	resp = httptest.NewRecorder()
+	handler.HandleUpdateSession(resp, req)
+	if resp.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for empty override, got %d", resp.Code)
+	}
+}
+
 func TestHandleGetContextSnapshotsReturnsLightweightSummary(t *testing.T) {
 	broadcaster := app.NewEventBroadcaster()
 	tasks, sessions, snapshots := buildTestServices(
@@ -868,3 +919,52 @@ func TestHandleGetTaskStats(t *testing.T) {
 	}
 }
 
+func TestHandleSearchSessionsReturnsSnippetsAndFilters(t *testing.T) {
+	sessionStore := tape.NewSessionAdapter(tape.NewMemoryStore())
+	stateStore := sessionstate.NewInMemoryStore()
+	broadcaster := app.NewEventBroadcaster()
+	taskStore := app.NewInMemoryTaskStore()
+	defer taskStore.Close()
+	tasks, sessions, snapshots := buildTestServices(
+		storeBackedAgentCoordinator{store: sessionStore},
+		broadcaster,
+		sessionStore,
+		taskStore,
+		stateStore,
+	)
+	handler := NewAPIHandler(tasks, sessions, snapshots, app.NewHealthChecker(), false)
+
+	ctx := context.Background()
+	for _, id := range []string{"lark-1", "web-1"} {
+		session := storage.NewSession(id, time.Now())
+		session.Metadata["title"] = "Release " + id
+		session.Messages = []core.Message{{Role: "user", Content: "prepare the release checklist", Source: core.MessageSourceUserInput}}
+		if err := sessionStore.Save(ctx, session); err != nil {
+			t.Fatalf("save %s: %v", id, err)
+		}
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/sessions/search?q=checklist&channel=lark", nil)
+	resp := httptest.NewRecorder()
+	handler.HandleSearchSessions(resp, req)
+	if resp.Code != http.StatusOK {
+		t.Fatalf("expected 200 got %d: %s", resp.Code, resp.Body.String())
+	}
+	var payload SessionSearchResponse
+	if err := json.Unmarshal(resp.Body.Bytes(), &payload); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if payload.Total != 1 || len(payload.Hits) != 1 || payload.Hits[0].SessionID != "lark-1" {
+		t.Fatalf("unexpected payload %+v", payload)
+	}
+	if got := payload.Hits[0].Snippets; len(got) != 1 || got[0] != "prepare the release **checklist**" {
+		t.Fatalf("unexpected snippets %q", got)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/api/sessions/search?q=release&since=yesterday", nil)
+	resp = httptest.NewRecorder()
+	handler.HandleSearchSessions(resp, req)
+	if resp.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for bad since, got %d", resp.Code)
+	}
+}
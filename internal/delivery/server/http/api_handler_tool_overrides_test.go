@@ -0,0 +1,77 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"alex/internal/delivery/server/app"
+	tools "alex/internal/domain/agent/ports/tools"
+	"alex/internal/infra/tape"
+)
+
+func TestSessionToolOverridesGetAndUpdate(t *testing.T) {
+	ctx := context.Background()
+	sessionStore := tape.NewSessionAdapter(tape.NewMemoryStore())
+	broadcaster := app.NewEventBroadcaster()
+	sessions := app.NewSessionService(&stubAgentCoordinator{}, sessionStore, broadcaster)
+	handler := NewAPIHandler(nil, sessions, nil, app.NewHealthChecker(), false)
+	mux := http.NewServeMux()
+	registerSessionRoutes(mux, handler)
+
+	session, err := sessionStore.Create(ctx)
+	if err != nil {
+		t.Fatalf("create session: %v", err)
+	}
+	path := "/api/sessions/" + session.ID + "/tool-overrides"
+
+	do := func(method, body string) (*httptest.ResponseRecorder, SessionToolOverridesResponse) {
+		req := httptest.NewRequest(method, path, bytes.NewBufferString(body))
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		var resp SessionToolOverridesResponse
+		if rec.Code == http.StatusOK {
+			if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("decode response: %v", err)
+			}
+		}
+		return rec, resp
+	}
+
+	rec, resp := do(http.MethodGet, "")
+	if rec.Code != http.StatusOK || len(resp.Allow) != 0 || len(resp.Deny) != 0 || resp.Allow == nil {
+		t.Fatalf("unexpected initial overrides: status=%d body=%s", rec.Code, rec.Body.String())
+	}
+
+	rec, resp = do(http.MethodPut, `{"deny":["shell_exec"," web_fetch ","shell_exec"]}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("update status = %d body=%s", rec.Code, rec.Body.String())
+	}
+	if !reflect.DeepEqual(resp.Deny, []string{"shell_exec", "web_fetch"}) {
+		t.Fatalf("expected normalized deny list, got %v", resp.Deny)
+	}
+	stored, err := sessionStore.Get(ctx, session.ID)
+	if err != nil {
+		t.Fatalf("get session: %v", err)
+	}
+	if got, _ := tools.ParseToolOverrides(stored.Metadata[tools.ToolOverridesMetadataKey]); got.Allows("shell_exec") {
+		t.Fatalf("expected shell_exec to be denied in stored metadata, got %+v", got)
+	}
+
+	if rec, _ := do(http.MethodPut, `{`); rec.Code != http.StatusBadRequest {
+		t.Fatalf("invalid payload status = %d", rec.Code)
+	}
+
+	rec, resp = do(http.MethodPut, `{}`)
+	if rec.Code != http.StatusOK || len(resp.Deny) != 0 {
+		t.Fatalf("reset failed: status=%d body=%s", rec.Code, rec.Body.String())
+	}
+	stored, _ = sessionStore.Get(ctx, session.ID)
+	if got, err := tools.ParseToolOverrides(stored.Metadata[tools.ToolOverridesMetadataKey]); err != nil || !got.IsEmpty() {
+		t.Fatalf("expected reset to persist, got %+v err=%v", got, err)
+	}
+}
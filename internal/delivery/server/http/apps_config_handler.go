@@ -50,10 +50,11 @@ func (h *AppsConfigHandler) HandleGetAppsConfig(w http.ResponseWriter, r *http.R
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
-	writeJSON(w, http.StatusOK, payload)
+	writeETaggedJSON(w, r, payload)
 }
 
-// HandleUpdateAppsConfig persists apps configuration provided by the UI.
+// HandleUpdateAppsConfig persists apps configuration provided by the UI. An
+// If-Match header must name the ETag of the current snapshot.
 func (h *AppsConfigHandler) HandleUpdateAppsConfig(w http.ResponseWriter, r *http.Request) {
 	if h == nil {
 		http.NotFound(w, r)
@@ -68,6 +69,17 @@ func (h *AppsConfigHandler) HandleUpdateAppsConfig(w http.ResponseWriter, r *htt
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
+	if hasIfMatch(r) {
+		current, err := h.snapshot()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if !ifMatchSatisfied(r, etagOf(current)) {
+			http.Error(w, "apps config was modified", http.StatusPreconditionFailed)
+			return
+		}
+	}
 	if _, err := h.save(apps); err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -77,7 +89,7 @@ func (h *AppsConfigHandler) HandleUpdateAppsConfig(w http.ResponseWriter, r *htt
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
-	writeJSON(w, http.StatusOK, payload)
+	writeETaggedJSON(w, r, payload)
 }
 
 func validateAppsConfig(apps config.AppsConfig) error {
@@ -0,0 +1,64 @@
+package http
+
+import (
+	"net/http"
+
+	runtimeconfig "alex/internal/shared/config"
+)
+
+// ConfigDiagnosticsHandler serves the config validation findings recorded at
+// startup. Blocking errors abort startup, so a running server normally
+// reports warnings only.
+type ConfigDiagnosticsHandler struct {
+	response configDiagnosticsResponse
+}
+
+// ConfigIssue is one config validation finding.
+type ConfigIssue struct {
+	ID      string `json:"id"`
+	Key     string `json:"key"`
+	Value   string `json:"value,omitempty"`
+	Message string `json:"message"`
+	Fix     string `json:"fix,omitempty"`
+}
+
+type configDiagnosticsResponse struct {
+	Profile  string        `json:"profile"`
+	Errors   []ConfigIssue `json:"errors"`
+	Warnings []ConfigIssue `json:"warnings"`
+}
+
+// NewConfigDiagnosticsHandler returns nil when no report was recorded.
+func NewConfigDiagnosticsHandler(report *runtimeconfig.ValidationReport) *ConfigDiagnosticsHandler {
+	if report == nil {
+		return nil
+	}
+	return &ConfigDiagnosticsHandler{response: configDiagnosticsResponse{
+		Profile:  report.Profile,
+		Errors:   toConfigIssues(report.Errors),
+		Warnings: toConfigIssues(report.Warnings),
+	}}
+}
+
+func toConfigIssues(items []runtimeconfig.ValidationIssue) []ConfigIssue {
+	issues := make([]ConfigIssue, 0, len(items))
+	for _, item := range items {
+		issues = append(issues, ConfigIssue{
+			ID:      item.ID,
+			Key:     item.Key,
+			Value:   item.Value,
+			Message: item.Message,
+			Fix:     item.Hint,
+		})
+	}
+	return issues
+}
+
+// HandleGetConfigDiagnostics handles GET /api/config/diagnostics.
+func (h *ConfigDiagnosticsHandler) HandleGetConfigDiagnostics(w http.ResponseWriter, r *http.Request) {
+	if h == nil {
+		http.NotFound(w, r)
+		return
+	}
+	writeJSON(w, http.StatusOK, h.response)
+}
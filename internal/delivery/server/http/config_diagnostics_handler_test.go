@@ -0,0 +1,42 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	runtimeconfig "alex/internal/shared/config"
+)
+
+func TestConfigDiagnosticsHandlerReportsWarnings(t *testing.T) {
+	if NewConfigDiagnosticsHandler(nil) != nil {
+		t.Fatal("expected nil handler without a report")
+	}
+	handler := NewConfigDiagnosticsHandler(&runtimeconfig.ValidationReport{
+		Profile: "standard",
+		Warnings: []runtimeconfig.ValidationIssue{{
+			ID:      "analytics-posthog-key",
+			Key:     "analytics.posthog_api_key",
+			Value:   "phx_****",
+			Message: "PostHog key does not look like a project API key (phc_...)",
+			Hint:    "Use the project API key.",
+		}},
+	})
+
+	rec := httptest.NewRecorder()
+	handler.HandleGetConfigDiagnostics(rec, httptest.NewRequest(http.MethodGet, "/api/config/diagnostics", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, body=%s", rec.Code, rec.Body.String())
+	}
+	var body configDiagnosticsResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if body.Profile != "standard" || body.Errors == nil || len(body.Errors) != 0 {
+		t.Fatalf("unexpected response %+v", body)
+	}
+	if len(body.Warnings) != 1 || body.Warnings[0].Key != "analytics.posthog_api_key" || body.Warnings[0].Fix != "Use the project API key." {
+		t.Fatalf("unexpected warnings %+v", body.Warnings)
+	}
+}
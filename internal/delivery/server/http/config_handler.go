@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"alex/internal/app/subscription"
+	agent "alex/internal/domain/agent/ports/agent"
 	runtimeconfig "alex/internal/shared/config"
 	configadmin "alex/internal/shared/config/admin"
 	"alex/internal/shared/httpclient"
@@ -23,6 +24,9 @@ type ConfigHandler struct {
 	catalogService  SubscriptionCatalogService
 	runtimeUpdates  <-chan struct{}
 	runtimeReloader func(context.Context) error
+
+	invalidateLLMClients func()
+	events               agent.EventListener
 }
 
 // NewConfigHandler constructs a handler when a manager is available.
@@ -83,10 +87,11 @@ func (h *ConfigHandler) HandleGetRuntimeConfig(w http.ResponseWriter, r *http.Re
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
-	writeJSON(w, http.StatusOK, payload)
+	writeETaggedJSON(w, r, payload)
 }
 
-// HandleUpdateRuntimeConfig persists overrides provided by the UI.
+// HandleUpdateRuntimeConfig persists overrides provided by the UI. An
+// If-Match header must name the ETag of the current snapshot.
 func (h *ConfigHandler) HandleUpdateRuntimeConfig(w http.ResponseWriter, r *http.Request) {
 	var body struct {
 		Overrides runtimeconfig.Overrides `json:"overrides"`
@@ -94,6 +99,17 @@ func (h *ConfigHandler) HandleUpdateRuntimeConfig(w http.ResponseWriter, r *http
 	if !decodeJSONRequest(w, r, &body, "invalid JSON payload") {
 		return
 	}
+	if hasIfMatch(r) {
+		current, err := h.snapshot(r.Context())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if !ifMatchSatisfied(r, etagOf(current)) {
+			http.Error(w, "runtime config was modified", http.StatusPreconditionFailed)
+			return
+		}
+	}
 	if err := h.manager.UpdateOverrides(r.Context(), body.Overrides); err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -109,7 +125,7 @@ func (h *ConfigHandler) HandleUpdateRuntimeConfig(w http.ResponseWriter, r *http
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
-	writeJSON(w, http.StatusOK, payload)
+	writeETaggedJSON(w, r, payload)
 }
 
 // HandleRuntimeStream streams updates via SSE.
@@ -0,0 +1,184 @@
+package http
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strings"
+
+	serverApp "alex/internal/delivery/server/app"
+	agent "alex/internal/domain/agent/ports/agent"
+	"alex/internal/domain/agent/types"
+	runtimeconfig "alex/internal/shared/config"
+	"alex/internal/shared/logging"
+)
+
+const (
+	maxLLMModelNameLength = 256
+	maxLLMTemperature     = 2.0
+	maxLLMIterations      = 1000
+)
+
+// llmSettingsRequest is the subset of runtime settings that can change without
+// a restart. Omitted fields keep their current value.
+type llmSettingsRequest struct {
+	Model         *string  `json:"model,omitempty"`
+	Temperature   *float64 `json:"temperature,omitempty"`
+	MaxTokens     *int     `json:"max_tokens,omitempty"`
+	MaxIterations *int     `json:"max_iterations,omitempty"`
+}
+
+// llmSettingsValidationError is the 400 body listing every rejected field.
+type llmSettingsValidationError struct {
+	Error  string            `json:"error"`
+	Fields map[string]string `json:"fields"`
+}
+
+// SetLLMClientInvalidator registers the hook that drops cached LLM clients
+// after the runtime LLM settings change.
+func (h *ConfigHandler) SetLLMClientInvalidator(invalidate func()) {
+	h.invalidateLLMClients = invalidate
+}
+
+// SetEventListener registers the sink for config change diagnostics.
+func (h *ConfigHandler) SetEventListener(listener agent.EventListener) {
+	h.events = listener
+}
+
+// HandleUpdateLLMSettings applies model, temperature, max token and iteration
+// changes to the runtime overrides and reloads the runtime config. New tasks
+// resolve the updated settings; running tasks keep the client they hold.
+func (h *ConfigHandler) HandleUpdateLLMSettings(w http.ResponseWriter, r *http.Request) {
+	var req llmSettingsRequest
+	if !decodeJSONRequest(w, r, &req, "invalid JSON payload") {
+		return
+	}
+	if fields := req.validate(); len(fields) > 0 {
+		writeJSON(w, http.StatusBadRequest, llmSettingsValidationError{
+			Error:  "invalid runtime LLM settings",
+			Fields: fields,
+		})
+		return
+	}
+
+	ctx := r.Context()
+	previous, _, err := h.resolver(ctx)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	overrides, err := h.manager.CurrentOverrides(ctx)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	req.apply(&overrides)
+	if err := h.manager.UpdateOverrides(ctx, overrides); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if h.runtimeReloader != nil {
+		if err := h.runtimeReloader(ctx); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+	if h.invalidateLLMClients != nil {
+		h.invalidateLLMClients()
+	}
+
+	payload, err := h.snapshot(ctx)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	h.announceLLMSettingsChange(r, previous, payload.Effective)
+	writeETaggedJSON(w, r, payload)
+}
+
+func (req llmSettingsRequest) validate() map[string]string {
+	fields := make(map[string]string)
+	if req.Model == nil && req.Temperature == nil && req.MaxTokens == nil && req.MaxIterations == nil {
+		fields["body"] = "at least one of model, temperature, max_tokens or max_iterations is required"
+		return fields
+	}
+	if req.Model != nil {
+		model := strings.TrimSpace(*req.Model)
+		switch {
+		case model == "":
+			fields["model"] = "must not be empty"
+		case len(model) > maxLLMModelNameLength:
+			fields["model"] = fmt.Sprintf("must be at most %d characters", maxLLMModelNameLength)
+		}
+	}
+	if req.Temperature != nil {
+		temp := *req.Temperature
+		if math.IsNaN(temp) || temp < 0 || temp > maxLLMTemperature {
+			fields["temperature"] = fmt.Sprintf("must be between 0 and %g", maxLLMTemperature)
+		}
+	}
+	if req.MaxTokens != nil && *req.MaxTokens <= 0 {
+		fields["max_tokens"] = "must be a positive integer"
+	}
+	if req.MaxIterations != nil && (*req.MaxIterations <= 0 || *req.MaxIterations > maxLLMIterations) {
+		fields["max_iterations"] = fmt.Sprintf("must be between 1 and %d", maxLLMIterations)
+	}
+	return fields
+}
+
+func (req llmSettingsRequest) apply(overrides *runtimeconfig.Overrides) {
+	if req.Model != nil {
+		model := strings.TrimSpace(*req.Model)
+		overrides.LLMModel = &model
+	}
+	if req.Temperature != nil {
+		temp := *req.Temperature
+		overrides.Temperature = &temp
+	}
+	if req.MaxTokens != nil {
+		maxTokens := *req.MaxTokens
+		overrides.MaxTokens = &maxTokens
+	}
+	if req.MaxIterations != nil {
+		maxIterations := *req.MaxIterations
+		overrides.MaxIterations = &maxIterations
+	}
+}
+
+// announceLLMSettingsChange logs the effective change and broadcasts a
+// config_changed diagnostic to every connected client.
+func (h *ConfigHandler) announceLLMSettingsChange(r *http.Request, previous, current runtimeconfig.RuntimeConfig) {
+	changes := make(map[string]any)
+	if previous.LLMModel != current.LLMModel {
+		changes["model"] = map[string]any{"from": previous.LLMModel, "to": current.LLMModel}
+	}
+	if previous.Temperature != current.Temperature {
+		changes["temperature"] = map[string]any{"from": previous.Temperature, "to": current.Temperature}
+	}
+	if previous.MaxTokens != current.MaxTokens {
+		changes["max_tokens"] = map[string]any{"from": previous.MaxTokens, "to": current.MaxTokens}
+	}
+	if previous.MaxIterations != current.MaxIterations {
+		changes["max_iterations"] = map[string]any{"from": previous.MaxIterations, "to": current.MaxIterations}
+	}
+	if len(changes) == 0 {
+		return
+	}
+
+	summary := fmt.Sprintf("LLM settings updated (model=%s temperature=%g max_tokens=%d max_iterations=%d)",
+		current.LLMModel, current.Temperature, current.MaxTokens, current.MaxIterations)
+	if _, ok := changes["model"]; ok {
+		summary = fmt.Sprintf("model switched to %s", current.LLMModel)
+	}
+	logging.FromContext(r.Context(), logging.NewComponentLogger("ConfigHandler")).
+		Info("Runtime LLM settings changed: %s", summary)
+
+	if h.events == nil {
+		return
+	}
+	h.events.OnEvent(serverApp.NewGlobalDiagnosticEnvelope(types.EventDiagnosticConfigChanged, map[string]any{
+		"summary": summary,
+		"changes": changes,
+		"model":   current.LLMModel,
+	}))
+}
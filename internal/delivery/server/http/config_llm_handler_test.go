@@ -0,0 +1,140 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	domain "alex/internal/domain/agent"
+	agent "alex/internal/domain/agent/ports/agent"
+	"alex/internal/domain/agent/types"
+	runtimeconfig "alex/internal/shared/config"
+	configadmin "alex/internal/shared/config/admin"
+)
+
+// overridesResolver applies the manager's stored overrides on top of base so
+// tests observe the effective config after an update.
+func overridesResolver(manager *configadmin.Manager, base runtimeconfig.RuntimeConfig) RuntimeConfigResolver {
+	return func(ctx context.Context) (runtimeconfig.RuntimeConfig, runtimeconfig.Metadata, error) {
+		cfg := base
+		overrides, err := manager.CurrentOverrides(ctx)
+		if err != nil {
+			return cfg, runtimeconfig.Metadata{}, err
+		}
+		if overrides.LLMModel != nil {
+			cfg.LLMModel = *overrides.LLMModel
+		}
+		if overrides.Temperature != nil {
+			cfg.Temperature = *overrides.Temperature
+		}
+		if overrides.MaxTokens != nil {
+			cfg.MaxTokens = *overrides.MaxTokens
+		}
+		if overrides.MaxIterations != nil {
+			cfg.MaxIterations = *overrides.MaxIterations
+		}
+		return cfg, runtimeconfig.Metadata{}, nil
+	}
+}
+
+func TestConfigHandlerHandleUpdateLLMSettings(t *testing.T) {
+	t.Parallel()
+
+	mem := &memoryStore{}
+	manager := configadmin.NewManager(mem, runtimeconfig.Overrides{})
+	base := runtimeconfig.RuntimeConfig{
+		LLMSettings:   runtimeconfig.LLMSettings{LLMProvider: "openai", LLMModel: "gpt-4o", Temperature: 0.7, MaxTokens: 4096},
+		MaxIterations: 50,
+	}
+
+	var reloads, invalidations int
+	var emitted []agent.AgentEvent
+	handler := NewConfigHandler(manager, overridesResolver(manager, base), nil, func(context.Context) error {
+		reloads++
+		return nil
+	})
+	handler.SetLLMClientInvalidator(func() { invalidations++ })
+	handler.SetEventListener(domain.EventListenerFunc(func(evt agent.AgentEvent) { emitted = append(emitted, evt) }))
+
+	body := []byte(`{"model":" gpt-4.1 ","temperature":0.2,"max_iterations":80}`)
+	req := httptest.NewRequest(http.MethodPut, "/api/internal/config/runtime/llm", bytes.NewReader(body))
+	rr := httptest.NewRecorder()
+
+	handler.HandleUpdateLLMSettings(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	var payload runtimeConfigResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &payload); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if payload.Effective.LLMModel != "gpt-4.1" || payload.Effective.Temperature != 0.2 || payload.Effective.MaxIterations != 80 {
+		t.Fatalf("unexpected effective config: %+v", payload.Effective)
+	}
+	if payload.Effective.MaxTokens != 4096 {
+		t.Fatalf("expected omitted max_tokens to keep its value, got %d", payload.Effective.MaxTokens)
+	}
+	if mem.overrides.LLMModel == nil || *mem.overrides.LLMModel != "gpt-4.1" {
+		t.Fatalf("expected trimmed model override persisted, got %+v", mem.overrides.LLMModel)
+	}
+	if mem.overrides.MaxTokens != nil {
+		t.Fatalf("expected max_tokens override to stay unset, got %v", *mem.overrides.MaxTokens)
+	}
+	if reloads != 1 || invalidations != 1 {
+		t.Fatalf("expected one reload and one client invalidation, got %d/%d", reloads, invalidations)
+	}
+
+	if len(emitted) != 1 {
+		t.Fatalf("expected one config_changed event, got %d", len(emitted))
+	}
+	envelope, ok := emitted[0].(*domain.WorkflowEventEnvelope)
+	if !ok || envelope.Event != types.EventDiagnosticConfigChanged {
+		t.Fatalf("expected config_changed envelope, got %#v", emitted[0])
+	}
+	if envelope.GetSessionID() != "__global__" {
+		t.Fatalf("expected event fanned out to all sessions, got session %q", envelope.GetSessionID())
+	}
+	if envelope.Payload["summary"] != "model switched to gpt-4.1" {
+		t.Fatalf("unexpected summary: %v", envelope.Payload["summary"])
+	}
+	changes, _ := envelope.Payload["changes"].(map[string]any)
+	if _, ok := changes["temperature"]; !ok || len(changes) != 3 {
+		t.Fatalf("unexpected changes: %+v", changes)
+	}
+}
+
+func TestConfigHandlerHandleUpdateLLMSettingsRejectsInvalidFields(t *testing.T) {
+	t.Parallel()
+
+	mem := &memoryStore{}
+	manager := configadmin.NewManager(mem, runtimeconfig.Overrides{})
+	invalidated := false
+	handler := NewConfigHandler(manager, overridesResolver(manager, runtimeconfig.RuntimeConfig{}), nil, nil)
+	handler.SetLLMClientInvalidator(func() { invalidated = true })
+
+	body := []byte(`{"model":"  ","temperature":3,"max_tokens":0,"max_iterations":-1}`)
+	req := httptest.NewRequest(http.MethodPut, "/api/internal/config/runtime/llm", bytes.NewReader(body))
+	rr := httptest.NewRecorder()
+
+	handler.HandleUpdateLLMSettings(rr, req)
+
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400, got %d", rr.Code)
+	}
+	var payload llmSettingsValidationError
+	if err := json.Unmarshal(rr.Body.Bytes(), &payload); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	for _, field := range []string{"model", "temperature", "max_tokens", "max_iterations"} {
+		if payload.Fields[field] == "" {
+			t.Fatalf("expected %s to be rejected, got %+v", field, payload.Fields)
+		}
+	}
+	if mem.overrides.LLMModel != nil || invalidated {
+		t.Fatalf("expected invalid update to leave overrides and clients untouched")
+	}
+}
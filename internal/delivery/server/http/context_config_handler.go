@@ -145,7 +145,7 @@ func (h *ContextConfigHandler) HandleGetContextConfig(w http.ResponseWriter, r *
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
-	writeJSON(w, http.StatusOK, ContextConfigResponse{
+	writeETaggedJSON(w, r, ContextConfigResponse{
 		Root:  h.root,
 		Files: files,
 	})
@@ -164,6 +164,17 @@ func (h *ContextConfigHandler) HandleUpdateContextConfig(w http.ResponseWriter,
 		http.Error(w, "no context files provided", http.StatusBadRequest)
 		return
 	}
+	if hasIfMatch(r) {
+		files, err := h.loadContextFiles()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if !ifMatchSatisfied(r, etagOf(ContextConfigResponse{Root: h.root, Files: files})) {
+			http.Error(w, "context config was modified", http.StatusPreconditionFailed)
+			return
+		}
+	}
 
 	updates := make(map[string]ContextConfigUpdate, len(body.Files))
 	for _, file := range body.Files {
@@ -195,7 +206,7 @@ func (h *ContextConfigHandler) HandleUpdateContextConfig(w http.ResponseWriter,
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
-	writeJSON(w, http.StatusOK, ContextConfigResponse{
+	writeETaggedJSON(w, r, ContextConfigResponse{
 		Root:  h.root,
 		Files: files,
 	})
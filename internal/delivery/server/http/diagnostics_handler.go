@@ -0,0 +1,81 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	agent "alex/internal/domain/agent/ports/agent"
+)
+
+const (
+	defaultDiagnosticsLimit = 50
+	maxDiagnosticsLimit     = 500
+)
+
+// DiagnosticEntry is one recorded diagnostics payload.
+type DiagnosticEntry struct {
+	Kind       string    `json:"kind"`
+	Payload    any       `json:"payload"`
+	RecordedAt time.Time `json:"recorded_at"`
+}
+
+// DiagnosticsHistory exposes diagnostics published before a client connected.
+type DiagnosticsHistory interface {
+	// Recent returns up to limit entries of kind, newest first.
+	Recent(kind string, limit int) []DiagnosticEntry
+	// Latest returns the newest entry of every recorded kind.
+	Latest() []DiagnosticEntry
+	// SnapshotEvents returns the newest payload of each kind as stream
+	// events, replayed to SSE subscribers when they connect.
+	SnapshotEvents() []agent.AgentEvent
+}
+
+// DiagnosticsHandler serves the recent diagnostics API.
+type DiagnosticsHandler struct {
+	history DiagnosticsHistory
+}
+
+func NewDiagnosticsHandler(history DiagnosticsHistory) *DiagnosticsHandler {
+	if history == nil {
+		return nil
+	}
+	return &DiagnosticsHandler{history: history}
+}
+
+type diagnosticsRecentResponse struct {
+	Kind    string            `json:"kind,omitempty"`
+	Entries []DiagnosticEntry `json:"entries"`
+}
+
+// HandleRecent handles GET /api/diagnostics/recent. With ?kind= it returns
+// that kind's entries newest first; without it, the latest entry of every
+// kind.
+func (h *DiagnosticsHandler) HandleRecent(w http.ResponseWriter, r *http.Request) {
+	if h == nil {
+		http.NotFound(w, r)
+		return
+	}
+	query := r.URL.Query()
+	limit := defaultDiagnosticsLimit
+	if raw := strings.TrimSpace(query.Get("limit")); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed <= 0 {
+			http.Error(w, "limit must be a positive integer", http.StatusBadRequest)
+			return
+		}
+		limit = min(parsed, maxDiagnosticsLimit)
+	}
+	kind := strings.TrimSpace(query.Get("kind"))
+	var entries []DiagnosticEntry
+	if kind == "" {
+		entries = h.history.Latest()
+	} else {
+		entries = h.history.Recent(kind, limit)
+	}
+	if entries == nil {
+		entries = []DiagnosticEntry{}
+	}
+	writeJSON(w, http.StatusOK, diagnosticsRecentResponse{Kind: kind, Entries: entries})
+}
@@ -0,0 +1,115 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	serverapp "alex/internal/delivery/server/app"
+	domain "alex/internal/domain/agent"
+	agent "alex/internal/domain/agent/ports/agent"
+	"alex/internal/domain/agent/types"
+)
+
+type stubDiagnosticsHistory struct {
+	entries   []DiagnosticEntry
+	lastKind  string
+	lastLimit int
+}
+
+func (s *stubDiagnosticsHistory) Recent(kind string, limit int) []DiagnosticEntry {
+	s.lastKind, s.lastLimit = kind, limit
+	var out []DiagnosticEntry
+	for _, entry := range s.entries {
+		if entry.Kind == kind {
+			out = append(out, entry)
+		}
+	}
+	return out
+}
+
+func (s *stubDiagnosticsHistory) Latest() []DiagnosticEntry {
+	return s.entries[:1]
+}
+
+func (s *stubDiagnosticsHistory) SnapshotEvents() []agent.AgentEvent {
+	env := domain.NewWorkflowEnvelopeFromEvent(
+		domain.NewDiagnosticEnvironmentSnapshotEvent(nil, time.Now()),
+		types.EventDiagnosticEnvironmentSnapshot,
+	)
+	env.NodeKind = "diagnostic"
+	env.Payload = map[string]any{"host": map[string]string{"OS": "linux"}}
+	return []agent.AgentEvent{env}
+}
+
+func TestDiagnosticsHandlerRecent(t *testing.T) {
+	recorded := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	history := &stubDiagnosticsHistory{entries: []DiagnosticEntry{
+		{Kind: "sandbox_progress", Payload: map[string]any{"stage": "init"}, RecordedAt: recorded},
+		{Kind: "environment", Payload: map[string]any{"host": "x"}, RecordedAt: recorded},
+	}}
+	handler := NewDiagnosticsHandler(history)
+
+	rec := httptest.NewRecorder()
+	handler.HandleRecent(rec, httptest.NewRequest(http.MethodGet, "/api/diagnostics/recent?kind=sandbox_progress&limit=1000", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d body=%s", rec.Code, rec.Body.String())
+	}
+	var resp diagnosticsRecentResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if resp.Kind != "sandbox_progress" || len(resp.Entries) != 1 || !resp.Entries[0].RecordedAt.Equal(recorded) {
+		t.Fatalf("unexpected response %+v", resp)
+	}
+	if history.lastLimit != maxDiagnosticsLimit {
+		t.Fatalf("expected limit clamped to %d, got %d", maxDiagnosticsLimit, history.lastLimit)
+	}
+
+	rec = httptest.NewRecorder()
+	handler.HandleRecent(rec, httptest.NewRequest(http.MethodGet, "/api/diagnostics/recent?kind=missing", nil))
+	if !strings.Contains(rec.Body.String(), `"entries":[]`) {
+		t.Fatalf("expected empty entries array, got %s", rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	handler.HandleRecent(rec, httptest.NewRequest(http.MethodGet, "/api/diagnostics/recent?limit=0", nil))
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for invalid limit, got %d", rec.Code)
+	}
+}
+
+func TestSSEHandlerReplaysDiagnosticsSnapshotOnConnect(t *testing.T) {
+	handler := NewSSEHandler(serverapp.NewEventBroadcaster(), WithSSEDiagnostics(&stubDiagnosticsHistory{}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	req := httptest.NewRequest(http.MethodGet, "/api/sse?session_id=session-diag&replay=none", nil).WithContext(ctx)
+	rec := newSSERecorder()
+	done := make(chan struct{})
+	go func() {
+		handler.HandleSSEStream(rec, req)
+		close(done)
+	}()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for !strings.Contains(rec.BodyString(), types.EventDiagnosticEnvironmentSnapshot) {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for snapshot in %q", rec.BodyString())
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	cancel()
+	<-done
+
+	for _, evt := range parseSSEStream(t, rec.BodyString()) {
+		if evt.event == types.EventDiagnosticEnvironmentSnapshot {
+			return
+		}
+	}
+	t.Fatalf("snapshot event not parsed from %q", rec.BodyString())
+}
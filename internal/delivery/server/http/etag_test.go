@@ -0,0 +1,214 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"alex/internal/delivery/server/app"
+	"alex/internal/infra/tape"
+	"alex/internal/shared/config"
+	id "alex/internal/shared/utils/id"
+)
+
+func TestEtagListMatches(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name   string
+		header string
+		etag   string
+		weak   bool
+		want   bool
+	}{
+		{name: "empty header", header: "", etag: `"a"`, weak: true, want: false},
+		{name: "exact", header: `"a"`, etag: `"a"`, want: true},
+		{name: "list", header: `"x", "a"`, etag: `"a"`, want: true},
+		{name: "wildcard", header: "*", etag: `"a"`, want: true},
+		{name: "mismatch", header: `"b"`, etag: `"a"`, weak: true, want: false},
+		{name: "weak tag for if-none-match", header: `W/"a"`, etag: `"a"`, weak: true, want: true},
+		{name: "weak tag rejected for if-match", header: `W/"a"`, etag: `"a"`, weak: false, want: false},
+	}
+	for _, tt := range tests {
+		if got := etagListMatches(tt.header, tt.etag, tt.weak); got != tt.want {
+			t.Errorf("%s: etagListMatches(%q, %q) = %v, want %v", tt.name, tt.header, tt.etag, got, tt.want)
+		}
+	}
+}
+
+func TestEncodeETaggedIgnoresMapOrdering(t *testing.T) {
+	t.Parallel()
+	build := func(reverse bool) map[string]any {
+		out := make(map[string]any)
+		for i := 0; i < 64; i++ {
+			n := i
+			if reverse {
+				n = 63 - i
+			}
+			out[fmt.Sprintf("key-%02d", n)] = map[string]int{"v": n}
+		}
+		return out
+	}
+	first := etagOf(build(false))
+	for i := 0; i < 20; i++ {
+		if got := etagOf(build(i%2 == 1)); got != first {
+			t.Fatalf("etag changed for equal content: %s vs %s", got, first)
+		}
+	}
+	changed := build(false)
+	changed["key-07"] = map[string]int{"v": 700}
+	if etagOf(changed) == first {
+		t.Fatal("expected etag to change with content")
+	}
+}
+
+func TestSessionETagConditionalRequests(t *testing.T) {
+	sessionStore := tape.NewSessionAdapter(tape.NewMemoryStore())
+	taskStore := app.NewInMemoryTaskStore()
+	defer taskStore.Close()
+	tasks, sessions, snapshots := buildTestServices(
+		storeBackedAgentCoordinator{store: sessionStore},
+		app.NewEventBroadcaster(),
+		sessionStore,
+		taskStore,
+		nil,
+	)
+	handler := NewAPIHandler(tasks, sessions, snapshots, app.NewHealthChecker(), false)
+
+	session, err := sessionStore.Create(context.Background())
+	if err != nil {
+		t.Fatalf("create session: %v", err)
+	}
+	get := func(ifNoneMatch string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/sessions/"+session.ID, nil)
+		req.SetPathValue("session_id", session.ID)
+		if ifNoneMatch != "" {
+			req.Header.Set("If-None-Match", ifNoneMatch)
+		}
+		rr := httptest.NewRecorder()
+		handler.HandleGetSession(rr, req)
+		return rr
+	}
+	patch := func(ifMatch, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPatch, "/api/sessions/"+session.ID, strings.NewReader(body))
+		req.SetPathValue("session_id", session.ID)
+		req.Header.Set("If-Match", ifMatch)
+		rr := httptest.NewRecorder()
+		handler.HandleUpdateSession(rr, req)
+		return rr
+	}
+
+	first := get("")
+	etag := first.Header().Get("ETag")
+	if first.Code != http.StatusOK || etag == "" {
+		t.Fatalf("expected 200 with ETag, got %d etag=%q", first.Code, etag)
+	}
+	if again := get(""); again.Header().Get("ETag") != etag {
+		t.Fatalf("etag changed without a write: %q vs %q", again.Header().Get("ETag"), etag)
+	}
+	notModified := get(etag)
+	if notModified.Code != http.StatusNotModified || notModified.Body.Len() != 0 {
+		t.Fatalf("expected empty 304, got %d with %d bytes", notModified.Code, notModified.Body.Len())
+	}
+
+	if rr := patch(`"stale"`, `{"title":"Stale write"}`); rr.Code != http.StatusPreconditionFailed {
+		t.Fatalf("expected 412 for stale If-Match, got %d", rr.Code)
+	}
+	if stored, _ := sessionStore.Get(context.Background(), session.ID); stored.Metadata["title"] == "Stale write" {
+		t.Fatal("stale If-Match must not apply the update")
+	}
+
+	updated := patch(etag, `{"title":"Fresh write"}`)
+	newETag := updated.Header().Get("ETag")
+	if updated.Code != http.StatusOK || newETag == "" || newETag == etag {
+		t.Fatalf("expected 200 with a new ETag, got %d etag=%q", updated.Code, newETag)
+	}
+	if rr := get(etag); rr.Code != http.StatusOK || rr.Header().Get("ETag") != newETag {
+		t.Fatalf("expected 200 with updated ETag %q, got %d etag=%q", newETag, rr.Code, rr.Header().Get("ETag"))
+	}
+	if rr := patch(etag, `{"title":"Lost update"}`); rr.Code != http.StatusPreconditionFailed {
+		t.Fatalf("expected 412 for superseded ETag, got %d", rr.Code)
+	}
+}
+
+func TestPreferencesETagTracksVersion(t *testing.T) {
+	t.Parallel()
+	handler, _ := newTestPreferencesHandler(t)
+	ctx := id.WithUserID(context.Background(), "ou_etag")
+
+	get := func() *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/me/preferences", nil).WithContext(ctx)
+		rr := httptest.NewRecorder()
+		handler.HandleGetPreferences(rr, req)
+		return rr
+	}
+	put := func(ifMatch, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPut, "/api/me/preferences", strings.NewReader(body)).WithContext(ctx)
+		req.Header.Set("If-Match", ifMatch)
+		rr := httptest.NewRecorder()
+		handler.HandleUpdatePreferences(rr, req)
+		return rr
+	}
+
+	etag := get().Header().Get("ETag")
+	if etag == "" || get().Header().Get("ETag") != etag {
+		t.Fatalf("expected stable ETag across reads, got %q", etag)
+	}
+	other := httptest.NewRequest(http.MethodGet, "/api/me/preferences", nil).WithContext(id.WithUserID(context.Background(), "ou_other"))
+	otherRR := httptest.NewRecorder()
+	handler.HandleGetPreferences(otherRR, other)
+	if otherRR.Header().Get("ETag") == etag {
+		t.Fatal("users at the same version must not share an ETag")
+	}
+
+	updated := put(etag, `{"preferences":{"tone":"calm"}}`)
+	if updated.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", updated.Code, updated.Body.String())
+	}
+	newETag := updated.Header().Get("ETag")
+	if newETag == etag || get().Header().Get("ETag") != newETag {
+		t.Fatalf("expected write to move ETag from %q, got %q", etag, newETag)
+	}
+	if rr := put(etag, `{"preferences":{"tone":"direct"}}`); rr.Code != http.StatusPreconditionFailed {
+		t.Fatalf("expected 412 for stale If-Match, got %d", rr.Code)
+	}
+}
+
+func TestAppsConfigIfMatchConflict(t *testing.T) {
+	saved := 0
+	current := config.AppsConfig{Plugins: []config.AppPluginConfig{{ID: "lark", Name: "Lark"}}}
+	handler := NewAppsConfigHandler(
+		func(...config.Option) (config.AppsConfig, string, error) { return current, "/tmp/config.yaml", nil },
+		func(apps config.AppsConfig, _ ...config.Option) (string, error) {
+			saved++
+			current = apps
+			return "/tmp/config.yaml", nil
+		},
+	)
+
+	getRR := httptest.NewRecorder()
+	handler.HandleGetAppsConfig(getRR, httptest.NewRequest(http.MethodGet, "/api/internal/config/apps", nil))
+	etag := getRR.Header().Get("ETag")
+
+	body := `{"apps":{"plugins":[{"id":"lark","name":"Feishu"}]}}`
+	req := httptest.NewRequest(http.MethodPut, "/api/internal/config/apps", strings.NewReader(body))
+	req.Header.Set("If-Match", `"stale"`)
+	rr := httptest.NewRecorder()
+	handler.HandleUpdateAppsConfig(rr, req)
+	if rr.Code != http.StatusPreconditionFailed || saved != 0 {
+		t.Fatalf("expected 412 without saving, got %d saved=%d", rr.Code, saved)
+	}
+
+	req = httptest.NewRequest(http.MethodPut, "/api/internal/config/apps", strings.NewReader(body))
+	req.Header.Set("If-Match", etag)
+	rr = httptest.NewRecorder()
+	handler.HandleUpdateAppsConfig(rr, req)
+	if rr.Code != http.StatusOK || saved != 1 {
+		t.Fatalf("expected 200 with one save, got %d saved=%d", rr.Code, saved)
+	}
+	if got := rr.Header().Get("ETag"); got == "" || got == etag {
+		t.Fatalf("expected new ETag after update, got %q", got)
+	}
+}
@@ -124,3 +124,58 @@ func TestHealthEndpoint_Integration(t *testing.T) {
 		}
 	}
 }
+
+type stubStartedSource struct{ started bool }
+
+func (s *stubStartedSource) Started() bool { return s.started }
+
+func TestLivezAndReadyzEndpoints(t *testing.T) {
+	started := &stubStartedSource{}
+	healthChecker := app.NewHealthChecker()
+	healthChecker.RegisterProbe(app.NewContainerStartedProbe(started))
+	healthChecker.RegisterProbeKind(ports.ProbeKindInformational, app.NewDegradedProbe(degradedSourceStub{"analytics": "boom"}))
+
+	router := NewRouter(
+		RouterDeps{
+			Broadcaster:   app.NewEventBroadcaster(),
+			HealthChecker: healthChecker,
+			AttachmentCfg: attachments.StoreConfig{Dir: t.TempDir()},
+		},
+		RouterConfig{Environment: "development"},
+	)
+
+	get := func(path string) (int, string, []ports.ComponentHealth) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", path, nil))
+		var body struct {
+			Status string                  `json:"status"`
+			Probes []ports.ComponentHealth `json:"probes"`
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+			t.Fatalf("%s: decode: %v body=%s", path, err, w.Body.String())
+		}
+		return w.Code, body.Status, body.Probes
+	}
+
+	if code, status, probes := get("/livez"); code != 200 || status != "alive" || len(probes) != 0 {
+		t.Fatalf("livez before start = %d %q %+v", code, status, probes)
+	}
+	code, status, probes := get("/readyz")
+	if code != 503 || status != "not_ready" {
+		t.Fatalf("readyz before start = %d %q", code, status)
+	}
+	if len(probes) != 1 || probes[0].Name != "container" || probes[0].Status != ports.HealthStatusNotReady {
+		t.Fatalf("expected only the container readiness probe, got %+v", probes)
+	}
+
+	// Informational probes stay out of readiness even when degraded.
+	started.started = true
+	if code, status, _ := get("/readyz"); code != 200 || status != "ready" {
+		t.Fatalf("readyz after start = %d %q", code, status)
+	}
+}
+
+type degradedSourceStub map[string]string
+
+func (d degradedSourceStub) Map() map[string]string { return d }
+func (d degradedSourceStub) IsEmpty() bool          { return len(d) == 0 }
@@ -0,0 +1,157 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"alex/internal/app/sessionimport"
+	id "alex/internal/shared/utils/id"
+)
+
+// maxImportUploadBytes caps a single export upload. Exports are spooled to a
+// temp file and stream-parsed, so the cap only guards disk usage.
+const maxImportUploadBytes int64 = 2 << 30
+
+type importJobs interface {
+	Start(filePath string, opts sessionimport.Options, cleanup func()) (sessionimport.Job, error)
+	Get(jobID string) (sessionimport.Job, bool)
+}
+
+// ImportHandler serves asynchronous conversation-history imports.
+type ImportHandler struct {
+	jobs     importJobs
+	maxBytes int64
+}
+
+func NewImportHandler(jobs importJobs) *ImportHandler {
+	if jobs == nil {
+		return nil
+	}
+	return &ImportHandler{jobs: jobs, maxBytes: maxImportUploadBytes}
+}
+
+// HandleCreateImport handles POST /api/import. The request is multipart with
+// a "source" field (chatgpt|claude), an optional "capture_memory" flag, and
+// the export in a "file" part. Responds 202 with the queued job.
+func (h *ImportHandler) HandleCreateImport(w http.ResponseWriter, r *http.Request) {
+	if h == nil {
+		http.NotFound(w, r)
+		return
+	}
+	r.Body = http.MaxBytesReader(w, r.Body, h.maxBytes)
+	reader, err := r.MultipartReader()
+	if err != nil {
+		http.Error(w, "multipart/form-data body is required", http.StatusBadRequest)
+		return
+	}
+
+	var (
+		opts     sessionimport.Options
+		filePath string
+	)
+	cleanup := func() {
+		if filePath != "" {
+			_ = os.Remove(filePath)
+		}
+	}
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			cleanup()
+			http.Error(w, "invalid multipart body: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		switch part.FormName() {
+		case "source":
+			value, _ := io.ReadAll(io.LimitReader(part, 64))
+			source, err := sessionimport.ParseSource(string(value))
+			if err != nil {
+				cleanup()
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			opts.Source = source
+		case "capture_memory":
+			value, _ := io.ReadAll(io.LimitReader(part, 16))
+			opts.CaptureMemory, _ = strconv.ParseBool(strings.TrimSpace(string(value)))
+		case "file":
+			if filePath != "" {
+				continue
+			}
+			filePath, err = spoolImportUpload(part)
+			if err != nil {
+				cleanup()
+				status := http.StatusInternalServerError
+				var maxErr *http.MaxBytesError
+				if errors.As(err, &maxErr) {
+					status = http.StatusRequestEntityTooLarge
+				}
+				http.Error(w, err.Error(), status)
+				return
+			}
+		}
+		_ = part.Close()
+	}
+	if opts.Source == "" || filePath == "" {
+		cleanup()
+		http.Error(w, "source and file are required", http.StatusBadRequest)
+		return
+	}
+	opts.UserID = id.UserIDFromContext(r.Context())
+
+	job, err := h.jobs.Start(filePath, opts, cleanup)
+	if err != nil {
+		cleanup()
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+	w.Header().Set("Location", "/api/import/"+job.ID)
+	writeJSON(w, http.StatusAccepted, job)
+}
+
+// HandleGetImport handles GET /api/import/{id}.
+func (h *ImportHandler) HandleGetImport(w http.ResponseWriter, r *http.Request) {
+	if h == nil {
+		http.NotFound(w, r)
+		return
+	}
+	jobID := strings.TrimSpace(r.PathValue("id"))
+	job, ok := h.jobs.Get(jobID)
+	if !ok || job.UserID != id.UserIDFromContext(r.Context()) {
+		http.Error(w, "import job not found", http.StatusNotFound)
+		return
+	}
+	writeJSON(w, http.StatusOK, job)
+}
+
+// spoolImportUpload copies an uploaded export to a temp file, keeping the
+// .zip extension so the importer can tell archives from raw JSON.
+func spoolImportUpload(part io.Reader) (string, error) {
+	ext := ".json"
+	if named, ok := part.(interface{ FileName() string }); ok && strings.EqualFold(filepath.Ext(named.FileName()), ".zip") {
+		ext = ".zip"
+	}
+	f, err := os.CreateTemp("", "alex-import-*"+ext)
+	if err != nil {
+		return "", fmt.Errorf("spool upload: %w", err)
+	}
+	if _, err := io.Copy(f, part); err != nil {
+		_ = f.Close()
+		_ = os.Remove(f.Name())
+		return "", fmt.Errorf("spool upload: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		_ = os.Remove(f.Name())
+		return "", fmt.Errorf("spool upload: %w", err)
+	}
+	return f.Name(), nil
+}
@@ -0,0 +1,114 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"alex/internal/app/sessionimport"
+	id "alex/internal/shared/utils/id"
+)
+
+type fakeImportJobs struct {
+	started []sessionimport.Options
+	body    []byte
+	jobs    map[string]sessionimport.Job
+}
+
+func (f *fakeImportJobs) Start(filePath string, opts sessionimport.Options, cleanup func()) (sessionimport.Job, error) {
+	f.started = append(f.started, opts)
+	f.body, _ = os.ReadFile(filePath)
+	cleanup()
+	job := sessionimport.Job{ID: "job-1", Status: sessionimport.JobPending, Source: opts.Source, UserID: opts.UserID}
+	f.jobs[job.ID] = job
+	return job, nil
+}
+
+func (f *fakeImportJobs) Get(jobID string) (sessionimport.Job, bool) {
+	job, ok := f.jobs[jobID]
+	return job, ok
+}
+
+func newImportRequest(t *testing.T, fields map[string]string, file string) *http.Request {
+	t.Helper()
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	for key, value := range fields {
+		if err := mw.WriteField(key, value); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if file != "" {
+		fw, err := mw.CreateFormFile("file", "conversations.json")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := fw.Write([]byte(file)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := mw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	req := httptest.NewRequest(http.MethodPost, "/api/import", &body)
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	return req.WithContext(id.WithUserID(req.Context(), "ou_web"))
+}
+
+func TestImportHandlerQueuesJob(t *testing.T) {
+	t.Parallel()
+	jobs := &fakeImportJobs{jobs: map[string]sessionimport.Job{}}
+	mux := http.NewServeMux()
+	registerImportRoutes(mux, NewImportHandler(jobs))
+
+	rr := httptest.NewRecorder()
+	mux.ServeHTTP(rr, newImportRequest(t, map[string]string{"source": "claude", "capture_memory": "true"}, `[]`))
+	if rr.Code != http.StatusAccepted {
+		t.Fatalf("expected 202, got %d: %s", rr.Code, rr.Body.String())
+	}
+	if len(jobs.started) != 1 || jobs.started[0].Source != sessionimport.SourceClaude || !jobs.started[0].CaptureMemory || jobs.started[0].UserID != "ou_web" {
+		t.Fatalf("unexpected job options: %+v", jobs.started)
+	}
+	if string(jobs.body) != `[]` {
+		t.Fatalf("expected spooled upload, got %q", jobs.body)
+	}
+	if rr.Header().Get("Location") != "/api/import/job-1" {
+		t.Fatalf("unexpected Location header %q", rr.Header().Get("Location"))
+	}
+
+	get := httptest.NewRequest(http.MethodGet, "/api/import/job-1", nil)
+	rr = httptest.NewRecorder()
+	mux.ServeHTTP(rr, get.WithContext(id.WithUserID(get.Context(), "ou_web")))
+	var job sessionimport.Job
+	if err := json.NewDecoder(rr.Body).Decode(&job); err != nil || job.ID != "job-1" {
+		t.Fatalf("expected job-1 status, got %d %v", rr.Code, err)
+	}
+
+	other := httptest.NewRequest(http.MethodGet, "/api/import/job-1", nil)
+	rr = httptest.NewRecorder()
+	mux.ServeHTTP(rr, other.WithContext(id.WithUserID(other.Context(), "ou_other")))
+	if rr.Code != http.StatusNotFound {
+		t.Fatalf("expected other users to get 404, got %d", rr.Code)
+	}
+}
+
+func TestImportHandlerRejectsMissingFields(t *testing.T) {
+	t.Parallel()
+	mux := http.NewServeMux()
+	registerImportRoutes(mux, NewImportHandler(&fakeImportJobs{jobs: map[string]sessionimport.Job{}}))
+
+	for name, req := range map[string]*http.Request{
+		"unknown source": newImportRequest(t, map[string]string{"source": "bard"}, `[]`),
+		"missing file":   newImportRequest(t, map[string]string{"source": "chatgpt"}, ""),
+	} {
+		rr := httptest.NewRecorder()
+		mux.ServeHTTP(rr, req)
+		if rr.Code != http.StatusBadRequest {
+			t.Fatalf("%s: expected 400, got %d", name, rr.Code)
+		}
+	}
+}
@@ -86,6 +86,9 @@ type ScheduledJobDTO struct {
 	Status   string    `json:"status"`
 	NextRun  time.Time `json:"next_run,omitempty"`
 	LastRun  time.Time `json:"last_run,omitempty"`
+	// LastOutcome and FailureStreak surface silently failing jobs.
+	LastOutcome   string `json:"last_outcome,omitempty"`
+	FailureStreak int    `json:"failure_streak,omitempty"`
 }
 
 // HandleGetDashboard handles GET /api/leader/dashboard.
@@ -365,11 +368,13 @@ func (h *LeaderDashboardHandler) gatherScheduledJobs(ctx context.Context) []Sche
 	dtos := make([]ScheduledJobDTO, 0, len(jobs))
 	for _, j := range jobs {
 		dtos = append(dtos, ScheduledJobDTO{
-			Name:     j.Name,
-			CronExpr: j.CronExpr,
-			Status:   j.Status,
-			NextRun:  j.NextRun,
-			LastRun:  j.LastRun,
+			Name:          j.Name,
+			CronExpr:      j.CronExpr,
+			Status:        j.Status,
+			NextRun:       j.NextRun,
+			LastRun:       j.LastRun,
+			LastOutcome:   j.LastOutcome,
+			FailureStreak: j.FailureCount,
 		})
 	}
 	return dtos
@@ -0,0 +1,36 @@
+package http
+
+import "net/http"
+
+// LLMResponseCachePurger is the subset of the LLM response cache exposed to
+// admins.
+type LLMResponseCachePurger interface {
+	Purge() (int, error)
+}
+
+// LLMCacheHandler serves the admin API for the LLM response cache.
+type LLMCacheHandler struct {
+	cache LLMResponseCachePurger
+}
+
+// NewLLMCacheHandler returns nil when the response cache is disabled.
+func NewLLMCacheHandler(cache LLMResponseCachePurger) *LLMCacheHandler {
+	if cache == nil {
+		return nil
+	}
+	return &LLMCacheHandler{cache: cache}
+}
+
+// HandlePurge handles DELETE /api/internal/llm/response-cache.
+func (h *LLMCacheHandler) HandlePurge(w http.ResponseWriter, r *http.Request) {
+	if h == nil {
+		http.NotFound(w, r)
+		return
+	}
+	removed, err := h.cache.Purge()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"purged": removed})
+}
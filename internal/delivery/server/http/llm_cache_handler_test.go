@@ -0,0 +1,44 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type stubResponseCache struct {
+	entries int
+}
+
+func (s *stubResponseCache) Purge() (int, error) {
+	removed := s.entries
+	s.entries = 0
+	return removed, nil
+}
+
+func TestLLMCacheHandlerPurge(t *testing.T) {
+	cache := &stubResponseCache{entries: 3}
+	handler := NewLLMCacheHandler(cache)
+
+	rec := httptest.NewRecorder()
+	handler.HandlePurge(rec, httptest.NewRequest(http.MethodDelete, "/api/internal/llm/response-cache", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d body=%s", rec.Code, rec.Body.String())
+	}
+	if !strings.Contains(rec.Body.String(), `"purged":3`) {
+		t.Fatalf("unexpected body: %s", rec.Body.String())
+	}
+	if cache.entries != 0 {
+		t.Fatalf("expected cache to be purged, %d entries left", cache.entries)
+	}
+}
+
+func TestLLMCacheHandlerDisabled(t *testing.T) {
+	handler := NewLLMCacheHandler(nil)
+	rec := httptest.NewRecorder()
+	handler.HandlePurge(rec, httptest.NewRequest(http.MethodDelete, "/api/internal/llm/response-cache", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("status = %d, want 404", rec.Code)
+	}
+}
@@ -0,0 +1,100 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"alex/internal/app/maintenance"
+)
+
+// maintenanceNotices reports the current maintenance state to request paths.
+type maintenanceNotices interface {
+	Notice() (maintenance.Notice, bool)
+	Active() (maintenance.Window, bool)
+	Subscribe() (<-chan struct{}, func())
+}
+
+// maintenanceScheduler is the subset of the maintenance service exposed to
+// admins.
+type maintenanceScheduler interface {
+	Windows(ctx context.Context) ([]maintenance.Window, error)
+	Schedule(ctx context.Context, w maintenance.Window) (maintenance.Window, error)
+	Cancel(ctx context.Context, windowID string) error
+}
+
+// MaintenanceHandler serves the admin API for scheduled maintenance windows.
+type MaintenanceHandler struct {
+	scheduler maintenanceScheduler
+}
+
+// NewMaintenanceHandler returns nil when no maintenance service is configured.
+func NewMaintenanceHandler(scheduler maintenanceScheduler) *MaintenanceHandler {
+	if scheduler == nil {
+		return nil
+	}
+	return &MaintenanceHandler{scheduler: scheduler}
+}
+
+type maintenanceScheduleRequest struct {
+	StartsAt time.Time `json:"starts_at"`
+	EndsAt   time.Time `json:"ends_at"`
+	Message  string    `json:"message"`
+}
+
+// HandleListWindows handles GET /api/internal/maintenance.
+func (h *MaintenanceHandler) HandleListWindows(w http.ResponseWriter, r *http.Request) {
+	if h == nil {
+		http.NotFound(w, r)
+		return
+	}
+	windows, err := h.scheduler.Windows(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"windows": windows})
+}
+
+// HandleScheduleWindow handles POST /api/internal/maintenance.
+func (h *MaintenanceHandler) HandleScheduleWindow(w http.ResponseWriter, r *http.Request) {
+	if h == nil {
+		http.NotFound(w, r)
+		return
+	}
+	var body maintenanceScheduleRequest
+	if !decodeJSONRequest(w, r, &body, "invalid JSON payload") {
+		return
+	}
+	window, err := h.scheduler.Schedule(r.Context(), maintenance.Window{
+		StartsAt: body.StartsAt,
+		EndsAt:   body.EndsAt,
+		Message:  body.Message,
+	})
+	switch {
+	case errors.Is(err, maintenance.ErrInvalidWindow):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case err != nil:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	default:
+		writeJSON(w, http.StatusCreated, window)
+	}
+}
+
+// HandleCancelWindow handles DELETE /api/internal/maintenance/{id}.
+func (h *MaintenanceHandler) HandleCancelWindow(w http.ResponseWriter, r *http.Request) {
+	if h == nil {
+		http.NotFound(w, r)
+		return
+	}
+	err := h.scheduler.Cancel(r.Context(), r.PathValue("id"))
+	switch {
+	case errors.Is(err, maintenance.ErrWindowNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case err != nil:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	default:
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
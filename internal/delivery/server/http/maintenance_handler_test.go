@@ -0,0 +1,202 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"alex/internal/app/maintenance"
+	serverapp "alex/internal/delivery/server/app"
+)
+
+func newTestMaintenance(t *testing.T) *maintenance.Service {
+	t.Helper()
+	store, err := maintenance.NewStore("")
+	if err != nil {
+		t.Fatalf("new store: %v", err)
+	}
+	return maintenance.NewService(store, maintenance.Config{NoticeLead: time.Hour}, nil)
+}
+
+func scheduleTestWindow(t *testing.T, svc *maintenance.Service, startsIn, lasts time.Duration) maintenance.Window {
+	t.Helper()
+	start := time.Now().Add(startsIn).Truncate(time.Second)
+	window, err := svc.Schedule(context.Background(), maintenance.Window{StartsAt: start, EndsAt: start.Add(lasts), Message: "database upgrade"})
+	if err != nil {
+		t.Fatalf("schedule: %v", err)
+	}
+	return window
+}
+
+func TestMaintenanceHandlerSchedulesAndCancelsWindows(t *testing.T) {
+	svc := newTestMaintenance(t)
+	mux := http.NewServeMux()
+	registerMaintenanceRoutes(mux, NewMaintenanceHandler(svc))
+	do := func(method, path, body string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(method, path, bytes.NewBufferString(body)))
+		return rec
+	}
+
+	if rec := do(http.MethodPost, "/api/internal/maintenance", `{"starts_at":"2030-01-01T02:00:00Z","ends_at":"2030-01-01T01:00:00Z","message":"x"}`); rec.Code != http.StatusBadRequest {
+		t.Fatalf("invalid window status = %d", rec.Code)
+	}
+	rec := do(http.MethodPost, "/api/internal/maintenance", `{"starts_at":"2030-01-01T01:00:00Z","ends_at":"2030-01-01T02:00:00Z","message":"database upgrade"}`)
+	var created maintenance.Window
+	if err := json.Unmarshal(rec.Body.Bytes(), &created); err != nil || rec.Code != http.StatusCreated || created.ID == "" {
+		t.Fatalf("schedule status=%d err=%v body=%s", rec.Code, err, rec.Body.String())
+	}
+
+	rec = do(http.MethodGet, "/api/internal/maintenance", "")
+	var list struct {
+		Windows []maintenance.Window `json:"windows"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &list); err != nil || len(list.Windows) != 1 || list.Windows[0].ID != created.ID {
+		t.Fatalf("unexpected list: %s err=%v", rec.Body.String(), err)
+	}
+
+	if rec := do(http.MethodDelete, "/api/internal/maintenance/"+created.ID, ""); rec.Code != http.StatusNoContent {
+		t.Fatalf("cancel status = %d", rec.Code)
+	}
+	if rec := do(http.MethodDelete, "/api/internal/maintenance/"+created.ID, ""); rec.Code != http.StatusNotFound {
+		t.Fatalf("second cancel status = %d", rec.Code)
+	}
+}
+
+func TestCreateTaskRefusedDuringMaintenanceWindow(t *testing.T) {
+	svc := newTestMaintenance(t)
+	window := scheduleTestWindow(t, svc, -time.Minute, 2*time.Hour)
+	handler := NewAPIHandler(nil, nil, nil, nil, false, WithMaintenance(svc))
+
+	rec := httptest.NewRecorder()
+	handler.HandleCreateTask(rec, httptest.NewRequest(http.MethodPost, "/api/tasks", strings.NewReader(`{"task":"hello"}`)))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("status = %d, want 503", rec.Code)
+	}
+	retryAfter, err := http.ParseTime(rec.Header().Get("Retry-After"))
+	if err != nil || !retryAfter.Equal(window.EndsAt) {
+		t.Fatalf("Retry-After = %q (%v), want %s", rec.Header().Get("Retry-After"), err, window.EndsAt)
+	}
+	if !strings.Contains(rec.Body.String(), "database upgrade") {
+		t.Fatalf("expected maintenance message in body, got %s", rec.Body.String())
+	}
+}
+
+func TestMaintenanceNoticeMiddlewareAnnotatesJSONResponses(t *testing.T) {
+	svc := newTestMaintenance(t)
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/object", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]any{"ok": true})
+	})
+	mux.HandleFunc("GET /api/empty", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]any{})
+	})
+	mux.HandleFunc("GET /api/list", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, http.StatusOK, []int{1, 2})
+	})
+	mux.HandleFunc("GET /api/text", func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "plain", http.StatusNotFound)
+	})
+	handler := MaintenanceNoticeMiddleware(svc)(mux)
+	get := func(path string) map[string]json.RawMessage {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(rec.Body.Bytes(), &fields); err != nil {
+			t.Fatalf("%s: decode %q: %v", path, rec.Body.String(), err)
+		}
+		return fields
+	}
+
+	if _, ok := get("/api/object")[maintenanceNoticeField]; ok {
+		t.Fatal("expected no notice without a scheduled window")
+	}
+
+	window := scheduleTestWindow(t, svc, 30*time.Minute, time.Hour)
+	fields := get("/api/object")
+	var notice maintenance.Notice
+	if err := json.Unmarshal(fields[maintenanceNoticeField], &notice); err != nil {
+		t.Fatalf("decode notice: %v", err)
+	}
+	if notice.WindowID != window.ID || notice.Phase != maintenance.PhaseUpcoming || string(fields["ok"]) != "true" {
+		t.Fatalf("unexpected annotated body: %v", fields)
+	}
+	if _, ok := get("/api/empty")[maintenanceNoticeField]; !ok {
+		t.Fatal("expected notice on empty object response")
+	}
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/list", nil))
+	if strings.TrimSpace(rec.Body.String()) != "[1,2]" {
+		t.Fatalf("array body changed: %q", rec.Body.String())
+	}
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/text", nil))
+	if rec.Code != http.StatusNotFound || strings.TrimSpace(rec.Body.String()) != "plain" {
+		t.Fatalf("plain response changed: %d %q", rec.Code, rec.Body.String())
+	}
+}
+
+func TestSSEStreamsCarryMaintenanceNotices(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		path  string
+		serve func(*SSEHandler, http.ResponseWriter, *http.Request)
+	}{
+		{name: "session", path: "/api/sse?session_id=session-mw&replay=none", serve: (*SSEHandler).HandleSSEStream},
+		{name: "task", path: "/api/tasks/task-mw/events?session_id=session-mw", serve: (*SSEHandler).HandleTaskSSEStream},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := newTestMaintenance(t)
+			upcoming := scheduleTestWindow(t, svc, 10*time.Minute, time.Hour)
+			handler := NewSSEHandler(serverapp.NewEventBroadcaster(), WithSSEMaintenance(svc))
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			req := httptest.NewRequest(http.MethodGet, tc.path, nil).WithContext(ctx)
+			req.SetPathValue("task_id", "task-mw")
+			rec := newSSERecorder()
+			done := make(chan struct{})
+			go func() {
+				tc.serve(handler, rec, req)
+				close(done)
+			}()
+
+			waitFor := func(fragment string) {
+				t.Helper()
+				deadline := time.Now().Add(2 * time.Second)
+				for !strings.Contains(rec.BodyString(), fragment) {
+					if time.Now().After(deadline) {
+						t.Fatalf("timed out waiting for %q in %q", fragment, rec.BodyString())
+					}
+					time.Sleep(5 * time.Millisecond)
+				}
+			}
+			waitFor(`"phase":"upcoming"`)
+			if err := svc.Cancel(context.Background(), upcoming.ID); err != nil {
+				t.Fatalf("cancel: %v", err)
+			}
+			waitFor(`"phase":"cleared"`)
+			active := scheduleTestWindow(t, svc, -time.Minute, time.Hour)
+			waitFor(fmt.Sprintf(`"window_id":"%s","phase":"active"`, active.ID))
+			cancel()
+			<-done
+
+			var notices []streamedEvent
+			for _, evt := range parseSSEStream(t, rec.BodyString()) {
+				if evt.event == sseMaintenanceNoticeEvent {
+					notices = append(notices, evt)
+				}
+			}
+			if len(notices) != 3 || notices[1].data["window_id"] != upcoming.ID {
+				t.Fatalf("expected upcoming, cleared, active notices, got %v", notices)
+			}
+		})
+	}
+}
@@ -6,6 +6,10 @@ import (
 	"strings"
 )
 
+// corsAllowHeaders includes the conditional-request headers so browser
+// clients can revalidate with ETags.
+const corsAllowHeaders = "Content-Type, Authorization, If-Match, If-None-Match"
+
 // CORSMiddleware handles CORS headers
 func CORSMiddleware(environment string, allowedOrigins []string) func(http.Handler) http.Handler {
 	allowedSet := make(map[string]struct{}, len(allowedOrigins))
@@ -37,12 +41,14 @@ func CORSMiddleware(environment string, allowedOrigins []string) func(http.Handl
 				w.Header().Set("Access-Control-Allow-Origin", origin)
 				appendVary(w, "Origin")
 				w.Header().Set("Access-Control-Allow-Credentials", "true")
-				w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-				w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+				w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
+				w.Header().Set("Access-Control-Allow-Headers", corsAllowHeaders)
+				w.Header().Set("Access-Control-Expose-Headers", "ETag")
 			} else if origin != "" && allowAny {
 				w.Header().Set("Access-Control-Allow-Origin", "*")
-				w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-				w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+				w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
+				w.Header().Set("Access-Control-Allow-Headers", corsAllowHeaders)
+				w.Header().Set("Access-Control-Expose-Headers", "ETag")
 			}
 
 			if r.Method == http.MethodOptions {
@@ -0,0 +1,120 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+const maintenanceNoticeField = "maintenance_notice"
+
+// MaintenanceNoticeMiddleware adds a top-level "maintenance_notice" field to
+// JSON object responses under /api/ while a maintenance notice is current.
+// Streams are left alone; SSE handlers emit a maintenance_notice event
+// instead. It must sit inside CompressionMiddleware so it edits plain JSON.
+func MaintenanceNoticeMiddleware(notices maintenanceNotices) func(http.Handler) http.Handler {
+	if notices == nil {
+		return func(next http.Handler) http.Handler { return next }
+	}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !strings.HasPrefix(r.URL.Path, "/api/") || isStreamRequest(r) {
+				next.ServeHTTP(w, r)
+				return
+			}
+			notice, ok := notices.Notice()
+			if !ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+			encoded, err := json.Marshal(notice)
+			if err != nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+			mw := &maintenanceNoticeWriter{ResponseWriter: w, notice: encoded}
+			next.ServeHTTP(mw, r)
+			mw.finish()
+		})
+	}
+}
+
+// maintenanceNoticeWriter buffers JSON bodies so the notice can be spliced in
+// once the handler is done; other content types pass straight through.
+type maintenanceNoticeWriter struct {
+	http.ResponseWriter
+	notice      []byte
+	status      int
+	wroteHeader bool
+	buffering   bool
+	body        bytes.Buffer
+}
+
+func (w *maintenanceNoticeWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+func (w *maintenanceNoticeWriter) WriteHeader(status int) {
+	if w.wroteHeader {
+		return
+	}
+	w.wroteHeader = true
+	w.status = status
+	if status != http.StatusNoContent && status != http.StatusNotModified && isJSONContentType(w.Header().Get("Content-Type")) {
+		w.buffering = true
+		return
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *maintenanceNoticeWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	if w.buffering {
+		return w.body.Write(b)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *maintenanceNoticeWriter) finish() {
+	if !w.buffering {
+		return
+	}
+	body := injectMaintenanceNotice(w.body.Bytes(), w.notice)
+	w.Header().Del("Content-Length")
+	w.ResponseWriter.WriteHeader(w.status)
+	_, _ = w.ResponseWriter.Write(body)
+}
+
+func isJSONContentType(value string) bool {
+	mediaType, _, err := mime.ParseMediaType(value)
+	return err == nil && mediaType == "application/json"
+}
+
+// injectMaintenanceNotice returns body with notice added as the first field
+// of its top-level object. Arrays, invalid JSON, and bodies that already carry
+// the field are returned unchanged.
+func injectMaintenanceNotice(body, notice []byte) []byte {
+	trimmed := bytes.TrimLeft(body, " \t\r\n")
+	if len(trimmed) == 0 || trimmed[0] != '{' {
+		return body
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(trimmed, &fields); err != nil {
+		return body
+	}
+	if _, exists := fields[maintenanceNoticeField]; exists {
+		return body
+	}
+	rest := bytes.TrimLeft(trimmed[1:], " \t\r\n")
+	out := make([]byte, 0, len(body)+len(notice)+24)
+	out = append(out, `{"`+maintenanceNoticeField+`":`...)
+	out = append(out, notice...)
+	if len(fields) > 0 {
+		out = append(out, ',')
+	}
+	return append(out, rest...)
+}
@@ -1,20 +1,79 @@
 package http
 
 import (
+	"context"
+	"fmt"
+	"math"
 	"net"
 	"net/http"
+	"strconv"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"alex/internal/shared/logging"
+
+	"github.com/redis/go-redis/v9"
 	"golang.org/x/time/rate"
 )
 
+// RateLimitConfig configures request-scoped token buckets. Requests are keyed
+// by client IP only: the server has no per-user identity on HTTP requests, so
+// callers behind one NAT or untrusted proxy share a bucket. Task-creation
+// endpoints draw from their own, usually stricter, bucket.
 type RateLimitConfig struct {
 	RequestsPerMinute int
 	Burst             int
-	EntryTTL          time.Duration
-	CleanupInterval   time.Duration
-	TrustedProxies    []string // CIDR ranges whose X-Forwarded-For is trusted
+	// TaskRequestsPerMinute and TaskBurst limit task-creation endpoints
+	// (POST /api/tasks, POST /api/evaluations). Zero disables the separate
+	// bucket and those endpoints share the read bucket.
+	TaskRequestsPerMinute int
+	TaskBurst             int
+	// ExemptPaths bypass limiting entirely; defaults to the health endpoints.
+	ExemptPaths []string
+	// RedisURL shares buckets across replicas. Empty, unparsable or
+	// unreachable Redis degrades to in-process buckets.
+	RedisURL        string
+	EntryTTL        time.Duration
+	CleanupInterval time.Duration
+	TrustedProxies  []string // CIDR ranges whose X-Forwarded-For is trusted
+}
+
+var defaultRateLimitExemptPaths = []string{"/health", "/livez", "/readyz"}
+
+// taskCreationRoutes start LLM work and are charged to the task bucket.
+var taskCreationRoutes = map[string]bool{
+	"/api/tasks":       true,
+	"/api/evaluations": true,
+}
+
+// rateLimitPolicy is one named bucket shape.
+type rateLimitPolicy struct {
+	name  string
+	limit rate.Limit // tokens per second
+	burst int
+}
+
+func newRateLimitPolicy(name string, perMinute, burst int) rateLimitPolicy {
+	return rateLimitPolicy{
+		name:  name,
+		limit: rate.Every(time.Minute / time.Duration(perMinute)),
+		burst: burst,
+	}
+}
+
+// rateLimitDecision is the outcome of taking one token.
+type rateLimitDecision struct {
+	allowed    bool
+	remaining  int
+	retryAfter time.Duration // until one token is available; zero when allowed
+	reset      time.Duration // until the bucket is full again
+}
+
+// rateLimitStore takes tokens from buckets keyed by policy and client.
+type rateLimitStore interface {
+	take(ctx context.Context, key string, policy rateLimitPolicy, now time.Time) (rateLimitDecision, error)
 }
 
 type rateLimitEntry struct {
@@ -22,10 +81,9 @@ type rateLimitEntry struct {
 	lastSeen time.Time
 }
 
+// rateLimiter is the in-process bucket store.
 type rateLimiter struct {
 	mu              sync.Mutex
-	limit           rate.Limit
-	burst           int
 	entries         map[string]*rateLimitEntry
 	entryTTL        time.Duration
 	cleanupInterval time.Duration
@@ -42,8 +100,6 @@ func newRateLimiter(cfg RateLimitConfig) *rateLimiter {
 		cleanup = 5 * time.Minute
 	}
 	return &rateLimiter{
-		limit:           rate.Every(time.Minute / time.Duration(cfg.RequestsPerMinute)),
-		burst:           cfg.Burst,
 		entries:         make(map[string]*rateLimitEntry),
 		entryTTL:        ttl,
 		cleanupInterval: cleanup,
@@ -51,13 +107,7 @@ func newRateLimiter(cfg RateLimitConfig) *rateLimiter {
 	}
 }
 
-func (r *rateLimiter) allow(key string) bool {
-	if r == nil || key == "" {
-		return true
-	}
-
-	now := time.Now()
-
+func (r *rateLimiter) take(_ context.Context, key string, policy rateLimitPolicy, now time.Time) (rateLimitDecision, error) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
@@ -70,30 +120,192 @@ func (r *rateLimiter) allow(key string) bool {
 		r.lastCleanup = now
 	}
 
-	entry, ok := r.entries[key]
+	bucketKey := policy.name + "|" + key
+	entry, ok := r.entries[bucketKey]
 	if !ok {
-		entry = &rateLimitEntry{
-			limiter:  rate.NewLimiter(r.limit, r.burst),
-			lastSeen: now,
+		entry = &rateLimitEntry{limiter: rate.NewLimiter(policy.limit, policy.burst)}
+		r.entries[bucketKey] = entry
+	}
+	entry.lastSeen = now
+
+	allowed := entry.limiter.AllowN(now, 1)
+	return bucketDecision(allowed, entry.limiter.TokensAt(now), policy), nil
+}
+
+// bucketDecision derives headers from the tokens left after a take.
+func bucketDecision(allowed bool, tokens float64, policy rateLimitPolicy) rateLimitDecision {
+	decision := rateLimitDecision{allowed: allowed, remaining: int(math.Max(0, math.Floor(tokens)))}
+	perToken := float64(time.Second) / float64(policy.limit)
+	if !allowed {
+		decision.retryAfter = time.Duration((1 - tokens) * perToken)
+	}
+	if missing := float64(policy.burst) - tokens; missing > 0 {
+		decision.reset = time.Duration(missing * perToken)
+	}
+	return decision
+}
+
+// redisTokenBucket is the same token bucket kept in a Redis hash so replicas
+// share it. tokens/ts are refilled lazily on each take.
+var redisTokenBucket = redis.NewScript(`
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+local state = redis.call("HMGET", KEYS[1], "tokens", "ts")
+local tokens = tonumber(state[1])
+local ts = tonumber(state[2])
+if tokens == nil then
+  tokens = burst
+  ts = now
+end
+tokens = math.min(burst, tokens + math.max(0, now - ts) / 1000 * rate)
+local allowed = 0
+if tokens >= 1 then
+  tokens = tokens - 1
+  allowed = 1
+end
+redis.call("HSET", KEYS[1], "tokens", tostring(tokens), "ts", now)
+redis.call("PEXPIRE", KEYS[1], math.ceil(burst / rate * 1000) + 1000)
+return {allowed, tostring(tokens)}
+`)
+
+type redisRateLimiter struct {
+	client redis.Scripter
+}
+
+func (r *redisRateLimiter) take(ctx context.Context, key string, policy rateLimitPolicy, now time.Time) (rateLimitDecision, error) {
+	res, err := redisTokenBucket.Run(ctx, r.client,
+		[]string{"alex:ratelimit:" + policy.name + ":" + key},
+		float64(policy.limit), policy.burst, now.UnixMilli(),
+	).Slice()
+	if err != nil {
+		return rateLimitDecision{}, err
+	}
+	if len(res) != 2 {
+		return rateLimitDecision{}, fmt.Errorf("unexpected rate limit script reply: %v", res)
+	}
+	allowed, _ := res[0].(int64)
+	raw, _ := res[1].(string)
+	tokens, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return rateLimitDecision{}, err
+	}
+	return bucketDecision(allowed == 1, tokens, policy), nil
+}
+
+const (
+	// rateLimitRedisTimeout bounds each Redis round trip so an outage costs
+	// requests little latency before they fall back.
+	rateLimitRedisTimeout = 250 * time.Millisecond
+	// rateLimitRedisRetryAfter is how long the shared store is skipped after
+	// an error.
+	rateLimitRedisRetryAfter = 10 * time.Second
+)
+
+// fallbackRateLimiter prefers the shared store and degrades to in-process
+// buckets while it errors, so a Redis outage never rejects traffic.
+type fallbackRateLimiter struct {
+	primary  rateLimitStore
+	fallback rateLimitStore
+	logger   logging.Logger
+	retryAt  atomic.Int64 // unix nanos before which primary is skipped
+	degraded atomic.Bool
+}
+
+func (f *fallbackRateLimiter) take(ctx context.Context, key string, policy rateLimitPolicy, now time.Time) (rateLimitDecision, error) {
+	if now.UnixNano() < f.retryAt.Load() {
+		return f.fallback.take(ctx, key, policy, now)
+	}
+	decision, err := f.primary.take(ctx, key, policy, now)
+	if err == nil {
+		if f.degraded.CompareAndSwap(true, false) {
+			f.logger.Info("shared rate limit store recovered")
 		}
-		r.entries[key] = entry
-	} else {
-		entry.lastSeen = now
+		return decision, nil
 	}
+	f.retryAt.Store(now.Add(rateLimitRedisRetryAfter).UnixNano())
+	if f.degraded.CompareAndSwap(false, true) {
+		f.logger.Warn("shared rate limit store unavailable, using in-process buckets: %v", err)
+	}
+	return f.fallback.take(ctx, key, policy, now)
+}
 
-	return entry.limiter.Allow()
+func newRateLimitStore(cfg RateLimitConfig, logger logging.Logger) rateLimitStore {
+	local := newRateLimiter(cfg)
+	redisURL := strings.TrimSpace(cfg.RedisURL)
+	if redisURL == "" {
+		return local
+	}
+	opts, err := redis.ParseURL(redisURL)
+	if err != nil {
+		logger.Warn("invalid rate limit redis URL, using in-process buckets: %v", err)
+		return local
+	}
+	for _, timeout := range []*time.Duration{&opts.DialTimeout, &opts.ReadTimeout, &opts.WriteTimeout} {
+		if *timeout == 0 {
+			*timeout = rateLimitRedisTimeout
+		}
+	}
+	// Fail fast instead of retrying: the in-process bucket is a fine answer.
+	opts.MaxRetries = -1
+	opts.DialerRetries = 1
+	return &fallbackRateLimiter{
+		primary:  &redisRateLimiter{client: redis.NewClient(opts)},
+		fallback: local,
+		logger:   logger,
+	}
 }
 
+// RateLimitMiddleware rejects requests over their bucket with 429 plus
+// Retry-After and X-RateLimit-* headers. It only gates the start of a
+// request, so SSE streams that are already established are never cut off.
 func RateLimitMiddleware(cfg RateLimitConfig) func(http.Handler) http.Handler {
 	if cfg.RequestsPerMinute <= 0 || cfg.Burst <= 0 {
 		return func(next http.Handler) http.Handler { return next }
 	}
-	limiter := newRateLimiter(cfg)
+	logger := logging.NewComponentLogger("RateLimit")
+	store := newRateLimitStore(cfg, logger)
 	trustedNets := ParseTrustedProxies(cfg.TrustedProxies)
+
+	readPolicy := newRateLimitPolicy("read", cfg.RequestsPerMinute, cfg.Burst)
+	taskPolicy := readPolicy
+	if cfg.TaskRequestsPerMinute > 0 && cfg.TaskBurst > 0 {
+		taskPolicy = newRateLimitPolicy("task", cfg.TaskRequestsPerMinute, cfg.TaskBurst)
+	}
+	exempt := cfg.ExemptPaths
+	if exempt == nil {
+		exempt = defaultRateLimitExemptPaths
+	}
+	exemptSet := make(map[string]bool, len(exempt))
+	for _, path := range exempt {
+		exemptSet[strings.TrimSpace(path)] = true
+	}
+
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if exemptSet[r.URL.Path] {
+				next.ServeHTTP(w, r)
+				return
+			}
 			key := rateLimitKey(r, trustedNets)
-			if !limiter.allow(key) {
+			if key == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			policy := readPolicy
+			if r.Method == http.MethodPost && taskCreationRoutes[strings.TrimSuffix(r.URL.Path, "/")] {
+				policy = taskPolicy
+			}
+
+			decision, err := store.take(r.Context(), key, policy, time.Now())
+			if err != nil {
+				logger.Warn("rate limit check failed: %v", err)
+				next.ServeHTTP(w, r)
+				return
+			}
+			writeRateLimitHeaders(w.Header(), policy, decision)
+			if !decision.allowed {
+				w.Header().Set("Retry-After", strconv.Itoa(ceilSeconds(decision.retryAfter)))
 				http.Error(w, "rate limit exceeded", http.StatusTooManyRequests)
 				return
 			}
@@ -102,6 +314,22 @@ func RateLimitMiddleware(cfg RateLimitConfig) func(http.Handler) http.Handler {
 	}
 }
 
+func writeRateLimitHeaders(h http.Header, policy rateLimitPolicy, decision rateLimitDecision) {
+	h.Set("X-RateLimit-Limit", strconv.Itoa(policy.burst))
+	h.Set("X-RateLimit-Remaining", strconv.Itoa(decision.remaining))
+	h.Set("X-RateLimit-Reset", strconv.Itoa(ceilSeconds(decision.reset)))
+	h.Set("X-RateLimit-Policy", policy.name)
+}
+
+func ceilSeconds(d time.Duration) int {
+	if d <= 0 {
+		return 0
+	}
+	return int(math.Ceil(d.Seconds()))
+}
+
+// rateLimitKey identifies the caller by client IP, honouring forwarded
+// headers only from trusted proxies.
 func rateLimitKey(r *http.Request, trustedProxies []net.IPNet) string {
 	if r == nil {
 		return ""
@@ -0,0 +1,135 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"alex/internal/shared/logging"
+	id "alex/internal/shared/utils/id"
+)
+
+func serveRateLimited(handler http.Handler, method, path, remoteAddr string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, nil)
+	req.RemoteAddr = remoteAddr
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestRateLimitMiddleware_RejectsWithRetryHeaders(t *testing.T) {
+	handler := RateLimitMiddleware(RateLimitConfig{RequestsPerMinute: 60, Burst: 2})(okHandler())
+
+	for i := 0; i < 2; i++ {
+		if rec := serveRateLimited(handler, http.MethodGet, "/api/sessions", "192.0.2.1:1000"); rec.Code != http.StatusOK {
+			t.Fatalf("request %d: expected 200, got %d", i, rec.Code)
+		}
+	}
+	rec := serveRateLimited(handler, http.MethodGet, "/api/sessions", "192.0.2.1:1000")
+	if rec.Code != http.StatusTooManyRequests {
+		t.Fatalf("expected 429, got %d", rec.Code)
+	}
+	if got, _ := strconv.Atoi(rec.Header().Get("Retry-After")); got < 1 {
+		t.Fatalf("expected Retry-After >= 1, got %q", rec.Header().Get("Retry-After"))
+	}
+	if rec.Header().Get("X-RateLimit-Limit") != "2" || rec.Header().Get("X-RateLimit-Remaining") != "0" {
+		t.Fatalf("unexpected rate limit headers: %v", rec.Header())
+	}
+	if reset, _ := strconv.Atoi(rec.Header().Get("X-RateLimit-Reset")); reset < 1 {
+		t.Fatalf("expected X-RateLimit-Reset >= 1, got %q", rec.Header().Get("X-RateLimit-Reset"))
+	}
+
+	if rec := serveRateLimited(handler, http.MethodGet, "/api/sessions", "192.0.2.2:1000"); rec.Code != http.StatusOK {
+		t.Fatalf("expected other clients to keep their own bucket, got %d", rec.Code)
+	}
+}
+
+func TestRateLimitMiddleware_TaskBucketAndExemptions(t *testing.T) {
+	handler := RateLimitMiddleware(RateLimitConfig{
+		RequestsPerMinute:     60,
+		Burst:                 1,
+		TaskRequestsPerMinute: 60,
+		TaskBurst:             1,
+	})(okHandler())
+	addr := "192.0.2.3:1000"
+
+	if rec := serveRateLimited(handler, http.MethodPost, "/api/tasks", addr); rec.Code != http.StatusOK {
+		t.Fatalf("expected first task to pass, got %d", rec.Code)
+	}
+	rec := serveRateLimited(handler, http.MethodPost, "/api/tasks", addr)
+	if rec.Code != http.StatusTooManyRequests || rec.Header().Get("X-RateLimit-Policy") != "task" {
+		t.Fatalf("expected task bucket to reject, got %d %v", rec.Code, rec.Header())
+	}
+	if rec := serveRateLimited(handler, http.MethodGet, "/api/tasks", addr); rec.Code != http.StatusOK {
+		t.Fatalf("expected read bucket to be independent, got %d", rec.Code)
+	}
+	for i := 0; i < 5; i++ {
+		if rec := serveRateLimited(handler, http.MethodGet, "/health", addr); rec.Code != http.StatusOK {
+			t.Fatalf("expected health to be exempt, got %d", rec.Code)
+		}
+	}
+}
+
+func TestRateLimitKey_UsesClientIP(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/api/sessions", nil)
+	r.RemoteAddr = "192.0.2.4:1000"
+	r = r.WithContext(id.WithUserID(r.Context(), "ou_1"))
+	if key := rateLimitKey(r, nil); key != "ip:192.0.2.4" {
+		t.Fatalf("expected ip key, got %s", key)
+	}
+}
+
+type failingRateLimitStore struct{ calls int }
+
+func (f *failingRateLimitStore) take(context.Context, string, rateLimitPolicy, time.Time) (rateLimitDecision, error) {
+	f.calls++
+	return rateLimitDecision{}, errors.New("connection refused")
+}
+
+func TestFallbackRateLimiter_DegradesToLocalBuckets(t *testing.T) {
+	primary := &failingRateLimitStore{}
+	store := &fallbackRateLimiter{
+		primary:  primary,
+		fallback: newRateLimiter(RateLimitConfig{}),
+		logger:   logging.OrNop(nil),
+	}
+	policy := newRateLimitPolicy("read", 60, 1)
+	now := time.Now()
+
+	first, err := store.take(context.Background(), "ip:x", policy, now)
+	if err != nil || !first.allowed {
+		t.Fatalf("expected local bucket to admit, got %+v err=%v", first, err)
+	}
+	second, err := store.take(context.Background(), "ip:x", policy, now)
+	if err != nil || second.allowed {
+		t.Fatalf("expected local bucket to limit, got %+v err=%v", second, err)
+	}
+	if primary.calls != 1 {
+		t.Fatalf("expected primary to be skipped during cooldown, got %d calls", primary.calls)
+	}
+	if _, err := store.take(context.Background(), "ip:x", policy, now.Add(rateLimitRedisRetryAfter)); err != nil {
+		t.Fatalf("take after cooldown: %v", err)
+	}
+	if primary.calls != 2 {
+		t.Fatalf("expected primary to be retried after cooldown, got %d calls", primary.calls)
+	}
+}
+
+func TestRateLimitMiddleware_UnreachableRedisStillLimits(t *testing.T) {
+	handler := RateLimitMiddleware(RateLimitConfig{
+		RequestsPerMinute: 1,
+		Burst:             1,
+		RedisURL:          "redis://127.0.0.1:1/0",
+	})(okHandler())
+
+	if rec := serveRateLimited(handler, http.MethodGet, "/api/sessions", "192.0.2.5:1000"); rec.Code != http.StatusOK {
+		t.Fatalf("expected fallback bucket to admit, got %d", rec.Code)
+	}
+	if rec := serveRateLimited(handler, http.MethodGet, "/api/sessions", "192.0.2.5:1000"); rec.Code != http.StatusTooManyRequests {
+		t.Fatalf("expected fallback bucket to limit, got %d", rec.Code)
+	}
+}
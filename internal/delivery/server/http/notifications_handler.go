@@ -0,0 +1,150 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"alex/internal/app/notifications"
+	id "alex/internal/shared/utils/id"
+)
+
+const (
+	defaultNotificationsLimit = 50
+	maxNotificationsLimit     = 200
+)
+
+type notificationCenter interface {
+	List(ctx context.Context, userID string, unreadOnly bool, limit int) ([]notifications.Notification, int, error)
+	MarkRead(ctx context.Context, userID, notificationID string) (notifications.Notification, error)
+	MarkAllRead(ctx context.Context, userID string) (int, error)
+	UnreadCount(ctx context.Context, userID string) (int, error)
+	Subscribe(userID string) (<-chan notifications.Update, func())
+}
+
+// NotificationsHandler serves the per-user in-app notifications API.
+type NotificationsHandler struct {
+	center notificationCenter
+}
+
+func NewNotificationsHandler(center notificationCenter) *NotificationsHandler {
+	if center == nil {
+		return nil
+	}
+	return &NotificationsHandler{center: center}
+}
+
+type notificationsListResponse struct {
+	Notifications []notifications.Notification `json:"notifications"`
+	Unread        int                          `json:"unread"`
+}
+
+// HandleListNotifications handles GET /api/me/notifications.
+func (h *NotificationsHandler) HandleListNotifications(w http.ResponseWriter, r *http.Request) {
+	if h == nil {
+		http.NotFound(w, r)
+		return
+	}
+	query := r.URL.Query()
+	unreadOnly, _ := strconv.ParseBool(strings.TrimSpace(query.Get("unread")))
+	limit := defaultNotificationsLimit
+	if raw := strings.TrimSpace(query.Get("limit")); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed <= 0 {
+			http.Error(w, "limit must be a positive integer", http.StatusBadRequest)
+			return
+		}
+		limit = min(parsed, maxNotificationsLimit)
+	}
+	list, unread, err := h.center.List(r.Context(), id.UserIDFromContext(r.Context()), unreadOnly, limit)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if list == nil {
+		list = []notifications.Notification{}
+	}
+	writeJSON(w, http.StatusOK, notificationsListResponse{Notifications: list, Unread: unread})
+}
+
+// HandleMarkRead handles POST /api/me/notifications/{id}/read.
+func (h *NotificationsHandler) HandleMarkRead(w http.ResponseWriter, r *http.Request) {
+	if h == nil {
+		http.NotFound(w, r)
+		return
+	}
+	notificationID := strings.TrimSpace(r.PathValue("id"))
+	if notificationID == "" {
+		http.Error(w, "notification id is required", http.StatusBadRequest)
+		return
+	}
+	updated, err := h.center.MarkRead(r.Context(), id.UserIDFromContext(r.Context()), notificationID)
+	switch {
+	case errors.Is(err, notifications.ErrNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case err != nil:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	default:
+		writeJSON(w, http.StatusOK, updated)
+	}
+}
+
+// HandleMarkAllRead handles POST /api/me/notifications/read-all.
+func (h *NotificationsHandler) HandleMarkAllRead(w http.ResponseWriter, r *http.Request) {
+	if h == nil {
+		http.NotFound(w, r)
+		return
+	}
+	changed, err := h.center.MarkAllRead(r.Context(), id.UserIDFromContext(r.Context()))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]int{"marked": changed, "unread": 0})
+}
+
+// HandleStream handles GET /api/me/notifications/stream. The first frame
+// carries the current unread count; later frames carry new notifications and
+// unread-count changes for live badge updates.
+func (h *NotificationsHandler) HandleStream(w http.ResponseWriter, r *http.Request) {
+	if h == nil {
+		http.NotFound(w, r)
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+	ctx := r.Context()
+	userID := id.UserIDFromContext(ctx)
+	updates, unsubscribe := h.center.Subscribe(userID)
+	defer unsubscribe()
+
+	unread, err := h.center.UnreadCount(ctx, userID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	if err := writeSSEPayload(w, notifications.Update{Unread: unread}); err != nil {
+		return
+	}
+	flusher.Flush()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case update := <-updates:
+			if err := writeSSEPayload(w, update); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"alex/internal/app/notifications"
+	id "alex/internal/shared/utils/id"
+)
+
+func newTestNotificationsHandler(t *testing.T) (*NotificationsHandler, *notifications.Center) {
+	t.Helper()
+	store, err := notifications.NewStore("")
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	center := notifications.New(store, notifications.Config{}, nil, nil)
+	return NewNotificationsHandler(center), center
+}
+
+func TestNotificationsHandlerReadFlow(t *testing.T) {
+	t.Parallel()
+	handler, center := newTestNotificationsHandler(t)
+	mux := http.NewServeMux()
+	registerNotificationRoutes(mux, handler)
+
+	ctx := context.Background()
+	first, _, err := center.Notify(ctx, notifications.Notification{UserID: "ou_web", Type: notifications.TypeTaskCompleted, Title: "Task completed"})
+	if err != nil {
+		t.Fatalf("Notify: %v", err)
+	}
+	if _, _, err := center.Notify(ctx, notifications.Notification{UserID: "ou_web", Type: notifications.TypeInputRequested, Title: "Your input is needed"}); err != nil {
+		t.Fatalf("Notify: %v", err)
+	}
+
+	do := func(method, path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, nil)
+		req = req.WithContext(id.WithUserID(req.Context(), "ou_web"))
+		rr := httptest.NewRecorder()
+		mux.ServeHTTP(rr, req)
+		return rr
+	}
+
+	rr := do(http.MethodPost, "/api/me/notifications/"+first.ID+"/read")
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200 from read, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	rr = do(http.MethodGet, "/api/me/notifications?unread=true")
+	var list notificationsListResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &list); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(list.Notifications) != 1 || list.Unread != 1 || list.Notifications[0].Type != notifications.TypeInputRequested {
+		t.Fatalf("unexpected unread list: %#v", list)
+	}
+
+	if rr := do(http.MethodPost, "/api/me/notifications/ntf-missing/read"); rr.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 for unknown notification, got %d", rr.Code)
+	}
+	if rr := do(http.MethodPost, "/api/me/notifications/read-all"); rr.Code != http.StatusOK || !strings.Contains(rr.Body.String(), `"marked":1`) {
+		t.Fatalf("unexpected read-all response: %d %s", rr.Code, rr.Body.String())
+	}
+	if rr := do(http.MethodGet, "/api/me/notifications?limit=0"); rr.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for invalid limit, got %d", rr.Code)
+	}
+}
+
+func TestNotificationsHandlerIsolatesUsers(t *testing.T) {
+	t.Parallel()
+	handler, center := newTestNotificationsHandler(t)
+	if _, _, err := center.Notify(context.Background(), notifications.Notification{UserID: "ou_other", Type: notifications.TypeDigest, Title: "Digest"}); err != nil {
+		t.Fatalf("Notify: %v", err)
+	}
+
+	rr := httptest.NewRecorder()
+	handler.HandleListNotifications(rr, httptest.NewRequest(http.MethodGet, "/api/me/notifications", nil))
+	if rr.Code != http.StatusOK || strings.TrimSpace(rr.Body.String()) != `{"notifications":[],"unread":0}` {
+		t.Fatalf("unexpected response for default user: %d %s", rr.Code, rr.Body.String())
+	}
+}
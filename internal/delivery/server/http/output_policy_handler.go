@@ -0,0 +1,186 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"alex/internal/app/outputpolicy"
+)
+
+// outputPolicyAdmin is the subset of the output policy service exposed to
+// admins.
+type outputPolicyAdmin interface {
+	ListRuleSets(ctx context.Context) ([]outputpolicy.RuleSet, error)
+	RuleSet(ctx context.Context, workspace string) (outputpolicy.RuleSet, bool, error)
+	PutRuleSet(ctx context.Context, expectedVersion int64, set outputpolicy.RuleSet) (outputpolicy.RuleSet, error)
+	DeleteRuleSet(ctx context.Context, workspace string) error
+	DryRun(set outputpolicy.RuleSet, text string) (outputpolicy.Result, error)
+	Audit(ctx context.Context, workspace string, limit int) ([]outputpolicy.AuditEntry, error)
+}
+
+// OutputPolicyHandler serves the admin API for per-workspace output rules
+// and the audit journal of original answers.
+type OutputPolicyHandler struct {
+	policy outputPolicyAdmin
+}
+
+// NewOutputPolicyHandler returns nil when no policy service is configured.
+func NewOutputPolicyHandler(policy outputPolicyAdmin) *OutputPolicyHandler {
+	if policy == nil {
+		return nil
+	}
+	return &OutputPolicyHandler{policy: policy}
+}
+
+// outputPolicyUpdateRequest carries an optional version for optimistic
+// concurrency; omitting it overwrites unconditionally.
+type outputPolicyUpdateRequest struct {
+	RuleSet outputpolicy.RuleSet `json:"rule_set"`
+	Version *int64               `json:"version,omitempty"`
+}
+
+// outputPolicyDryRunRequest tests RuleSet, or the workspace's stored rules
+// when RuleSet is omitted, against Text without storing anything.
+type outputPolicyDryRunRequest struct {
+	Workspace string                `json:"workspace,omitempty"`
+	RuleSet   *outputpolicy.RuleSet `json:"rule_set,omitempty"`
+	Text      string                `json:"text"`
+}
+
+// HandleListRuleSets handles GET /api/internal/output-policy.
+func (h *OutputPolicyHandler) HandleListRuleSets(w http.ResponseWriter, r *http.Request) {
+	if h == nil {
+		http.NotFound(w, r)
+		return
+	}
+	sets, err := h.policy.ListRuleSets(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"rule_sets": sets})
+}
+
+// HandleGetRuleSet handles GET /api/internal/output-policy/{workspace}.
+func (h *OutputPolicyHandler) HandleGetRuleSet(w http.ResponseWriter, r *http.Request) {
+	if h == nil {
+		http.NotFound(w, r)
+		return
+	}
+	set, ok, err := h.policy.RuleSet(r.Context(), r.PathValue("workspace"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if !ok {
+		http.Error(w, "no output policy for workspace", http.StatusNotFound)
+		return
+	}
+	writeJSON(w, http.StatusOK, set)
+}
+
+// HandlePutRuleSet handles PUT /api/internal/output-policy/{workspace}. The
+// path workspace wins over any workspace in the body.
+func (h *OutputPolicyHandler) HandlePutRuleSet(w http.ResponseWriter, r *http.Request) {
+	if h == nil {
+		http.NotFound(w, r)
+		return
+	}
+	var body outputPolicyUpdateRequest
+	if !decodeJSONRequest(w, r, &body, "invalid JSON payload") {
+		return
+	}
+	body.RuleSet.Workspace = r.PathValue("workspace")
+	expected := outputpolicy.AnyVersion
+	if body.Version != nil {
+		expected = *body.Version
+	}
+	set, err := h.policy.PutRuleSet(r.Context(), expected, body.RuleSet)
+	switch {
+	case errors.Is(err, outputpolicy.ErrInvalidRuleSet):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, outputpolicy.ErrVersionConflict):
+		http.Error(w, err.Error(), http.StatusConflict)
+	case err != nil:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	default:
+		writeJSON(w, http.StatusOK, set)
+	}
+}
+
+// HandleDeleteRuleSet handles DELETE /api/internal/output-policy/{workspace}.
+func (h *OutputPolicyHandler) HandleDeleteRuleSet(w http.ResponseWriter, r *http.Request) {
+	if h == nil {
+		http.NotFound(w, r)
+		return
+	}
+	if err := h.policy.DeleteRuleSet(r.Context(), r.PathValue("workspace")); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// HandleDryRun handles POST /api/internal/output-policy/dry-run.
+func (h *OutputPolicyHandler) HandleDryRun(w http.ResponseWriter, r *http.Request) {
+	if h == nil {
+		http.NotFound(w, r)
+		return
+	}
+	var body outputPolicyDryRunRequest
+	if !decodeJSONRequest(w, r, &body, "invalid JSON payload") {
+		return
+	}
+	var set outputpolicy.RuleSet
+	if body.RuleSet != nil {
+		set = *body.RuleSet
+	} else {
+		stored, ok, err := h.policy.RuleSet(r.Context(), body.Workspace)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if !ok {
+			http.Error(w, "no output policy for workspace", http.StatusNotFound)
+			return
+		}
+		set = stored
+	}
+	result, err := h.policy.DryRun(set, body.Text)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, outputpolicy.ErrInvalidRuleSet) {
+			status = http.StatusBadRequest
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+// HandleListAudit handles GET /api/internal/output-policy/{workspace}/audit.
+// Entries hold the original, unfiltered answers and are newest first.
+func (h *OutputPolicyHandler) HandleListAudit(w http.ResponseWriter, r *http.Request) {
+	if h == nil {
+		http.NotFound(w, r)
+		return
+	}
+	limit := 0
+	if raw := strings.TrimSpace(r.URL.Query().Get("limit")); raw != "" {
+		value, err := strconv.Atoi(raw)
+		if err != nil || value < 1 {
+			http.Error(w, "limit must be a positive integer", http.StatusBadRequest)
+			return
+		}
+		limit = value
+	}
+	entries, err := h.policy.Audit(r.Context(), r.PathValue("workspace"), limit)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"entries": entries})
+}
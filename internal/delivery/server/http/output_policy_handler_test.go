@@ -0,0 +1,72 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"alex/internal/app/outputpolicy"
+)
+
+func TestOutputPolicyHandlerManagesRulesAndAudit(t *testing.T) {
+	store, err := outputpolicy.NewStore("")
+	if err != nil {
+		t.Fatalf("new store: %v", err)
+	}
+	svc := outputpolicy.NewService(store, nil, nil)
+	mux := http.NewServeMux()
+	registerOutputPolicyRoutes(mux, NewOutputPolicyHandler(svc))
+
+	do := func(method, path, body string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(method, path, bytes.NewBufferString(body)))
+		return rec
+	}
+
+	rec := do(http.MethodPut, "/api/internal/output-policy/acme", `{"rule_set":{"redactions":[{"id":"r","pattern":"("}]}}`)
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("invalid rule set status = %d", rec.Code)
+	}
+	rec = do(http.MethodPut, "/api/internal/output-policy/acme", `{"rule_set":{"blocked_terms":[{"id":"codename","terms":["falcon"],"action":"block"}]},"version":0}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("put status = %d, body=%s", rec.Code, rec.Body.String())
+	}
+	rec = do(http.MethodPut, "/api/internal/output-policy/acme", `{"rule_set":{},"version":0}`)
+	if rec.Code != http.StatusConflict {
+		t.Fatalf("stale version status = %d", rec.Code)
+	}
+
+	rec = do(http.MethodPost, "/api/internal/output-policy/dry-run", `{"workspace":"acme","text":"Falcon ships Friday"}`)
+	var result outputpolicy.Result
+	if err := json.Unmarshal(rec.Body.Bytes(), &result); err != nil || rec.Code != http.StatusOK {
+		t.Fatalf("dry run status=%d err=%v", rec.Code, err)
+	}
+	if result.Outcome != outputpolicy.OutcomeBlocked || result.Violations[0].RuleID != "codename" {
+		t.Fatalf("unexpected dry run result: %+v", result)
+	}
+	if rec := do(http.MethodPost, "/api/internal/output-policy/dry-run", `{"workspace":"globex","text":"x"}`); rec.Code != http.StatusNotFound {
+		t.Fatalf("dry run without rules status = %d", rec.Code)
+	}
+
+	svc.Enforce(context.Background(), outputpolicy.Target{Workspace: "acme", RunID: "run-1"}, "Falcon ships Friday")
+	rec = do(http.MethodGet, "/api/internal/output-policy/acme/audit?limit=5", "")
+	var audit struct {
+		Entries []outputpolicy.AuditEntry `json:"entries"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &audit); err != nil || rec.Code != http.StatusOK {
+		t.Fatalf("audit status=%d err=%v", rec.Code, err)
+	}
+	if len(audit.Entries) != 1 || audit.Entries[0].Original != "Falcon ships Friday" {
+		t.Fatalf("unexpected audit entries: %+v", audit.Entries)
+	}
+
+	if rec := do(http.MethodDelete, "/api/internal/output-policy/acme", ""); rec.Code != http.StatusNoContent {
+		t.Fatalf("delete status = %d", rec.Code)
+	}
+	if rec := do(http.MethodGet, "/api/internal/output-policy/acme", ""); rec.Code != http.StatusNotFound {
+		t.Fatalf("get after delete status = %d", rec.Code)
+	}
+}
@@ -0,0 +1,125 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"alex/internal/app/preferences"
+	id "alex/internal/shared/utils/id"
+)
+
+type preferencesStore interface {
+	Get(ctx context.Context, userID string) (preferences.Record, bool, error)
+	Update(ctx context.Context, userID string, expectedVersion int64, prefs preferences.Preferences) (preferences.Record, error)
+}
+
+// PreferencesHandler serves the per-user preferences API.
+type PreferencesHandler struct {
+	store preferencesStore
+}
+
+func NewPreferencesHandler(store preferencesStore) *PreferencesHandler {
+	if store == nil {
+		return nil
+	}
+	return &PreferencesHandler{store: store}
+}
+
+type preferencesResponse struct {
+	UserID      string                  `json:"user_id"`
+	Preferences preferences.Preferences `json:"preferences"`
+	Version     int64                   `json:"version"`
+	UpdatedAt   *time.Time              `json:"updated_at,omitempty"`
+	Applied     string                  `json:"applied,omitempty"`
+}
+
+// preferencesUpdateRequest carries an optional version for optimistic concurrency;
+// omitting it overwrites unconditionally.
+type preferencesUpdateRequest struct {
+	Preferences preferences.Preferences `json:"preferences"`
+	Version     *int64                  `json:"version,omitempty"`
+}
+
+// HandleGetPreferences handles GET /api/me/preferences.
+func (h *PreferencesHandler) HandleGetPreferences(w http.ResponseWriter, r *http.Request) {
+	if h == nil {
+		http.NotFound(w, r)
+		return
+	}
+	userID := id.UserIDFromContext(r.Context())
+	record, _, err := h.store.Get(r.Context(), userID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeJSONWithETag(w, r, preferencesETag(userID, record), newPreferencesResponse(record))
+}
+
+// HandleUpdatePreferences handles PUT /api/me/preferences. An If-Match
+// header pins the update to the version its ETag names; a stale ETag, or a
+// concurrent write racing it, yields 412.
+func (h *PreferencesHandler) HandleUpdatePreferences(w http.ResponseWriter, r *http.Request) {
+	if h == nil {
+		http.NotFound(w, r)
+		return
+	}
+	var body preferencesUpdateRequest
+	if !decodeJSONRequest(w, r, &body, "invalid JSON payload") {
+		return
+	}
+	prefs := preferences.Normalize(body.Preferences)
+	if err := preferences.Validate(prefs); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	userID := id.UserIDFromContext(r.Context())
+	expected := preferences.AnyVersion
+	if body.Version != nil {
+		expected = *body.Version
+	}
+	conflictStatus := http.StatusConflict
+	if hasIfMatch(r) {
+		current, _, err := h.store.Get(r.Context(), userID)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if !ifMatchSatisfied(r, preferencesETag(userID, current)) {
+			http.Error(w, "preferences were modified", http.StatusPreconditionFailed)
+			return
+		}
+		if body.Version == nil {
+			expected = current.Version
+			conflictStatus = http.StatusPreconditionFailed
+		}
+	}
+	record, err := h.store.Update(r.Context(), userID, expected, prefs)
+	switch {
+	case errors.Is(err, preferences.ErrVersionConflict):
+		http.Error(w, err.Error(), conflictStatus)
+	case err != nil:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	default:
+		writeJSONWithETag(w, r, preferencesETag(userID, record), newPreferencesResponse(record))
+	}
+}
+
+// preferencesETag reuses the store's optimistic-concurrency version.
+func preferencesETag(userID string, record preferences.Record) string {
+	return revisionETag(userID, record.Version)
+}
+
+func newPreferencesResponse(record preferences.Record) preferencesResponse {
+	resp := preferencesResponse{
+		UserID:      record.UserID,
+		Preferences: record.Preferences,
+		Version:     record.Version,
+		Applied:     preferences.Render(record.Preferences),
+	}
+	if !record.UpdatedAt.IsZero() {
+		resp.UpdatedAt = &record.UpdatedAt
+	}
+	return resp
+}
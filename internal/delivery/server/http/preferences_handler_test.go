@@ -0,0 +1,91 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"alex/internal/app/preferences"
+	id "alex/internal/shared/utils/id"
+)
+
+func newTestPreferencesHandler(t *testing.T) (*PreferencesHandler, *preferences.Store) {
+	t.Helper()
+	store, err := preferences.NewStore("")
+	if err != nil {
+		t.Fatalf("NewStore: %v", err)
+	}
+	return NewPreferencesHandler(store), store
+}
+
+func TestPreferencesHandlerGetEmpty(t *testing.T) {
+	t.Parallel()
+	handler, _ := newTestPreferencesHandler(t)
+
+	rr := httptest.NewRecorder()
+	handler.HandleGetPreferences(rr, httptest.NewRequest(http.MethodGet, "/api/me/preferences", nil))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rr.Code)
+	}
+	var payload preferencesResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &payload); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if payload.UserID != preferences.DefaultUserID || payload.Version != 0 || payload.Applied != "" {
+		t.Fatalf("unexpected empty payload: %#v", payload)
+	}
+}
+
+func TestPreferencesHandlerUpdateUsesRequestIdentity(t *testing.T) {
+	t.Parallel()
+	handler, store := newTestPreferencesHandler(t)
+
+	body := `{"preferences":{"language":"Chinese","response_length":"Concise"},"version":0}`
+	req := httptest.NewRequest(http.MethodPut, "/api/me/preferences", strings.NewReader(body))
+	req = req.WithContext(id.WithUserID(req.Context(), "ou_web"))
+	rr := httptest.NewRecorder()
+	handler.HandleUpdatePreferences(rr, req)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	var payload preferencesResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &payload); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if payload.Version != 1 || payload.Preferences.ResponseLength != preferences.ResponseLengthConcise {
+		t.Fatalf("unexpected payload: %#v", payload)
+	}
+	if !strings.Contains(payload.Applied, "- Language: Chinese") {
+		t.Fatalf("expected applied block, got %q", payload.Applied)
+	}
+	record, ok, _ := store.Get(req.Context(), "ou_web")
+	if !ok || record.Preferences.Language != "Chinese" {
+		t.Fatalf("expected stored preferences for request user, got ok=%v %#v", ok, record)
+	}
+}
+
+func TestPreferencesHandlerUpdateErrors(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		body string
+		want int
+	}{
+		{name: "invalid json", body: `{`, want: http.StatusBadRequest},
+		{name: "invalid enum", body: `{"preferences":{"response_length":"huge"}}`, want: http.StatusBadRequest},
+		{name: "oversized instructions", body: `{"preferences":{"custom_instructions":"` + strings.Repeat("x", preferences.MaxCustomInstructionsChars+1) + `"}}`, want: http.StatusBadRequest},
+		{name: "stale version", body: `{"preferences":{"tone":"calm"},"version":5}`, want: http.StatusConflict},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, _ := newTestPreferencesHandler(t)
+			rr := httptest.NewRecorder()
+			handler.HandleUpdatePreferences(rr, httptest.NewRequest(http.MethodPut, "/api/me/preferences", strings.NewReader(tt.body)))
+			if rr.Code != tt.want {
+				t.Fatalf("expected %d, got %d: %s", tt.want, rr.Code, rr.Body.String())
+			}
+		})
+	}
+}
@@ -31,6 +31,12 @@ func NewRouter(deps RouterDeps, cfg RouterConfig) http.Handler {
 		dataCache = NewDataCache(512, 30*time.Minute)
 	}
 
+	// A nil *maintenance.Service must stay a nil interface.
+	var notices maintenanceNotices
+	if deps.Maintenance != nil {
+		notices = deps.Maintenance
+	}
+
 	// Create handlers
 	sseHandler := NewSSEHandler(
 		deps.Broadcaster,
@@ -38,6 +44,8 @@ func NewRouter(deps RouterDeps, cfg RouterConfig) http.Handler {
 		WithSSEAttachmentStore(attachmentStore),
 		WithSSEDataCache(dataCache),
 		WithSSERunTracker(deps.RunTracker),
+		WithSSEMaintenance(notices),
+		WithSSEDiagnostics(deps.Diagnostics),
 	)
 	shareHandler := NewShareHandler(deps.Sessions, sseHandler)
 	internalMode := strings.EqualFold(normalizedEnv, "internal") || strings.EqualFold(normalizedEnv, "evaluation")
@@ -54,6 +62,7 @@ func NewRouter(deps RouterDeps, cfg RouterConfig) http.Handler {
 		WithDevMode(devMode),
 		WithMaxCreateTaskBodySize(taskBodyLimit),
 		WithMemoryEngine(deps.MemoryEngine),
+		WithMaintenance(notices),
 	)
 
 	// Create mux using Go 1.22+ method-specific patterns.
@@ -75,9 +84,23 @@ func NewRouter(deps RouterDeps, cfg RouterConfig) http.Handler {
 
 	if internalMode || devMode {
 		registerRuntimeConfigRoutes(mux, deps.ConfigHandler)
+		// Findings echo config values, so they stay admin-only.
+		registerConfigDiagnosticsRoutes(mux, NewConfigDiagnosticsHandler(deps.ConfigDiagnostics))
 	}
 	if internalMode || devMode {
 		registerOnboardingStateRoutes(mux, deps.OnboardingStateHandler)
+		// Output policy rules and audit copies of original answers are admin-only.
+		registerOutputPolicyRoutes(mux, deps.OutputPolicyHandler)
+		if deps.Maintenance != nil {
+			registerMaintenanceRoutes(mux, NewMaintenanceHandler(deps.Maintenance))
+		}
+		registerLLMCacheRoutes(mux, NewLLMCacheHandler(deps.LLMResponseCache))
+		// Requests carry no user identity, so spend is readable for every
+		// user and stays admin-only.
+		registerUsageRoutes(mux, NewUsageHandler(deps.Usage))
+		if deps.Obs != nil {
+			registerSamplingRoutes(mux, NewSamplingHandler(deps.Obs))
+		}
 	}
 	if internalMode {
 		appsConfigHandler := NewAppsConfigHandler(config.LoadAppsConfig, config.SaveAppsConfig)
@@ -99,6 +122,7 @@ func NewRouter(deps RouterDeps, cfg RouterConfig) http.Handler {
 		registerRoute(mux, "/api/data/", "/api/data", dataCache.Handler())
 	}
 	registerHandler(mux, "POST /api/metrics/web-vitals", "/api/metrics/web-vitals", apiHandler.HandleWebVitals)
+	registerDiagnosticsRoutes(mux, NewDiagnosticsHandler(deps.Diagnostics))
 
 	// ── Task endpoints ──
 
@@ -112,6 +136,16 @@ func NewRouter(deps RouterDeps, cfg RouterConfig) http.Handler {
 
 	registerSessionRoutes(mux, apiHandler)
 
+	// ── Current user ──
+
+	registerPreferencesRoutes(mux, deps.PreferencesHandler)
+	registerNotificationRoutes(mux, deps.NotificationsHandler)
+	registerImportRoutes(mux, deps.ImportHandler)
+
+	// ── Scheduler ──
+
+	registerSchedulerRoutes(mux, deps.SchedulerHandler)
+
 	// ── Leader dashboard ──
 
 	registerLeaderRoutes(mux, deps.LeaderDashboard, cfg.LeaderAPIToken)
@@ -127,10 +161,13 @@ func NewRouter(deps RouterDeps, cfg RouterConfig) http.Handler {
 	// ── Health check ──
 
 	registerHandler(mux, "GET /health", "/health", apiHandler.HandleHealthCheck)
+	registerHandler(mux, "GET /livez", "/livez", apiHandler.HandleLiveness)
+	registerHandler(mux, "GET /readyz", "/readyz", apiHandler.HandleReadiness)
 
 	// ── Middleware stack ──
 
 	var handler http.Handler = mux
+	handler = MaintenanceNoticeMiddleware(notices)(handler)
 	handler = ObservabilityMiddleware(deps.Obs, latencyLogger)(handler)
 	handler = LoggingMiddleware(logger)(handler)
 	handler = RateLimitMiddleware(cfg.RateLimit)(handler)
@@ -6,6 +6,7 @@ import (
 
 	"alex/internal/delivery/server/app"
 	"alex/internal/infra/observability"
+	runtimeconfig "alex/internal/shared/config"
 	"alex/internal/shared/logging"
 	promclient "github.com/prometheus/client_golang/prometheus/promhttp"
 )
@@ -19,6 +20,10 @@ type DebugRouterDeps struct {
 	HealthChecker          *app.HealthCheckerImpl
 	ConfigHandler          *ConfigHandler
 	OnboardingStateHandler *OnboardingStateHandler
+	PreferencesHandler     *PreferencesHandler             // may be nil
+	NotificationsHandler   *NotificationsHandler           // may be nil
+	Diagnostics            DiagnosticsHistory              // may be nil
+	ConfigDiagnostics      *runtimeconfig.ValidationReport // may be nil; GET /api/config/diagnostics
 	Obs                    *observability.Observability
 	Environment            string
 	AllowedOrigins         []string
@@ -28,6 +33,7 @@ type DebugRouterDeps struct {
 	LarkOAuthHandler       *LarkOAuthHandler // may be nil
 	RuntimeHooksBridge     http.Handler      // may be nil; POST /api/hooks/runtime
 	GitHubWebhook          http.Handler      // may be nil; POST /api/webhooks/github
+	LarkEventWebhook       http.Handler      // may be nil; POST /api/lark/events
 	RuntimeAPI             http.Handler      // may be nil; POST+GET /api/runtime/sessions
 	RuntimePoolAPI         http.Handler      // may be nil; POST+GET /api/runtime/pool
 	StartupProfileHandler  http.Handler      // may be nil; GET /api/health/startup-profile
@@ -54,6 +60,7 @@ func NewDebugRouter(deps DebugRouterDeps) http.Handler {
 		deps.Broadcaster,
 		WithSSEObservability(deps.Obs),
 		WithSSERunTracker(deps.RunTracker),
+		WithSSEDiagnostics(deps.Diagnostics),
 	)
 
 	// API handler — services are nil in debug mode (task endpoints disabled).
@@ -72,6 +79,8 @@ func NewDebugRouter(deps DebugRouterDeps) http.Handler {
 
 	// ── Health ──
 	registerHandler(mux, "GET /health", "/health", apiHandler.HandleHealthCheck)
+	registerHandler(mux, "GET /livez", "/livez", apiHandler.HandleLiveness)
+	registerHandler(mux, "GET /readyz", "/readyz", apiHandler.HandleReadiness)
 	registerHandler(mux, "GET /api/debug/health/models", "/api/debug/health/models", apiHandler.HandleModelHealthDebug)
 	if deps.StartupProfileHandler != nil {
 		registerRoute(mux, "GET /api/health/startup-profile", "/api/health/startup-profile", deps.StartupProfileHandler)
@@ -79,6 +88,11 @@ func NewDebugRouter(deps DebugRouterDeps) http.Handler {
 
 	// ── SSE event stream ──
 	registerHandler(mux, "GET /api/sse", "/api/sse", sseHandler.HandleSSEStream)
+	registerDiagnosticsRoutes(mux, NewDiagnosticsHandler(deps.Diagnostics))
+	registerConfigDiagnosticsRoutes(mux, NewConfigDiagnosticsHandler(deps.ConfigDiagnostics))
+	if deps.Obs != nil {
+		registerSamplingRoutes(mux, NewSamplingHandler(deps.Obs))
+	}
 
 	// ── Dev / debug endpoints ──
 	registerHandler(mux, "GET /api/dev/logs", "/api/dev/logs", apiHandler.HandleDevLogTrace)
@@ -102,6 +116,8 @@ func NewDebugRouter(deps DebugRouterDeps) http.Handler {
 
 	registerRuntimeConfigRoutes(mux, deps.ConfigHandler)
 	registerOnboardingStateRoutes(mux, deps.OnboardingStateHandler)
+	registerPreferencesRoutes(mux, deps.PreferencesHandler)
+	registerNotificationRoutes(mux, deps.NotificationsHandler)
 
 	// ── Claude Code hooks bridge ──
 	registerHookRoutes(mux, deps.HooksBridge, deps.RuntimeHooksBridge)
@@ -125,6 +141,9 @@ func NewDebugRouter(deps DebugRouterDeps) http.Handler {
 	// ── Lark OAuth ──
 	registerLarkOAuthRoutes(mux, deps.LarkOAuthHandler)
 
+	// ── Lark event subscription webhook ──
+	registerLarkEventRoutes(mux, deps.LarkEventWebhook)
+
 	// ── Lark inject (local e2e testing) ──
 	if deps.LarkInjectGateway != nil {
 		injectHandler := NewLarkInjectHandler(deps.LarkInjectGateway)
@@ -208,6 +208,38 @@ func TestNewDebugRouter_LarkOAuthOptional(t *testing.T) {
 	}
 }
 
+func TestNewDebugRouter_LarkEventWebhookOptional(t *testing.T) {
+	broadcaster := app.NewEventBroadcaster()
+	healthChecker := app.NewHealthChecker()
+
+	router := NewDebugRouter(DebugRouterDeps{
+		Broadcaster:   broadcaster,
+		HealthChecker: healthChecker,
+	})
+	req := httptest.NewRequest("POST", "/api/lark/events", strings.NewReader("{}"))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusNotFound && w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("POST /api/lark/events without webhook returned %d; expected 404/405", w.Code)
+	}
+
+	called := false
+	routerWithWebhook := NewDebugRouter(DebugRouterDeps{
+		Broadcaster:   broadcaster,
+		HealthChecker: healthChecker,
+		LarkEventWebhook: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			called = true
+			w.WriteHeader(http.StatusOK)
+		}),
+	})
+	req = httptest.NewRequest("POST", "/api/lark/events", strings.NewReader("{}"))
+	w = httptest.NewRecorder()
+	routerWithWebhook.ServeHTTP(w, req)
+	if !called || w.Code != http.StatusOK {
+		t.Errorf("POST /api/lark/events with webhook returned %d (called=%t)", w.Code, called)
+	}
+}
+
 func TestNewDebugRouter_HooksBridgeOptional(t *testing.T) {
 	broadcaster := app.NewEventBroadcaster()
 	healthChecker := app.NewHealthChecker()
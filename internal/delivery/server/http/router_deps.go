@@ -4,9 +4,11 @@ import (
 	"net/http"
 	"time"
 
+	"alex/internal/app/maintenance"
 	"alex/internal/delivery/server/app"
 	"alex/internal/infra/attachments"
 	"alex/internal/infra/observability"
+	runtimeconfig "alex/internal/shared/config"
 )
 
 // RouterDeps holds all service dependencies needed to construct the HTTP router.
@@ -19,6 +21,16 @@ type RouterDeps struct {
 	HealthChecker          *app.HealthCheckerImpl
 	ConfigHandler          *ConfigHandler
 	OnboardingStateHandler *OnboardingStateHandler
+	PreferencesHandler     *PreferencesHandler
+	NotificationsHandler   *NotificationsHandler
+	ImportHandler          *ImportHandler
+	SchedulerHandler       *SchedulerHandler
+	OutputPolicyHandler    *OutputPolicyHandler
+	Maintenance            *maintenance.Service // optional: scheduled maintenance windows
+	Diagnostics            DiagnosticsHistory   // optional: recent diagnostics + SSE snapshot replay
+	LLMResponseCache       LLMResponseCachePurger // optional: admin purge of cached LLM responses
+	Usage                  UsageReporter          // optional: aggregated spend for GET /api/usage
+	ConfigDiagnostics      *runtimeconfig.ValidationReport // optional: startup config validation findings
 	Evaluation             *app.EvaluationService
 	Obs                    *observability.Observability
 	AttachmentCfg          attachments.StoreConfig
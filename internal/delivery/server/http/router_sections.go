@@ -28,11 +28,40 @@ func registerRuntimeConfigRoutes(mux *http.ServeMux, handler *ConfigHandler) {
 	}
 	registerHandler(mux, "GET /api/internal/config/runtime", "/api/internal/config/runtime", handler.HandleGetRuntimeConfig)
 	registerHandler(mux, "PUT /api/internal/config/runtime", "/api/internal/config/runtime", handler.HandleUpdateRuntimeConfig)
+	registerHandler(mux, "PUT /api/internal/config/runtime/llm", "/api/internal/config/runtime/llm", handler.HandleUpdateLLMSettings)
 	registerHandler(mux, "GET /api/internal/config/runtime/stream", "/api/internal/config/runtime/stream", handler.HandleRuntimeStream)
 	registerHandler(mux, "GET /api/internal/config/runtime/models", "/api/internal/config/runtime/models", handler.HandleGetRuntimeModels)
 	registerHandler(mux, "GET /api/internal/subscription/catalog", "/api/internal/subscription/catalog", handler.HandleGetSubscriptionCatalog)
 }
 
+func registerOutputPolicyRoutes(mux *http.ServeMux, handler *OutputPolicyHandler) {
+	if handler == nil {
+		return
+	}
+	registerHandler(mux, "GET /api/internal/output-policy", "/api/internal/output-policy", handler.HandleListRuleSets)
+	registerHandler(mux, "POST /api/internal/output-policy/dry-run", "/api/internal/output-policy/dry-run", handler.HandleDryRun)
+	registerHandler(mux, "GET /api/internal/output-policy/{workspace}", "/api/internal/output-policy/:workspace", handler.HandleGetRuleSet)
+	registerHandler(mux, "PUT /api/internal/output-policy/{workspace}", "/api/internal/output-policy/:workspace", handler.HandlePutRuleSet)
+	registerHandler(mux, "DELETE /api/internal/output-policy/{workspace}", "/api/internal/output-policy/:workspace", handler.HandleDeleteRuleSet)
+	registerHandler(mux, "GET /api/internal/output-policy/{workspace}/audit", "/api/internal/output-policy/:workspace/audit", handler.HandleListAudit)
+}
+
+func registerMaintenanceRoutes(mux *http.ServeMux, handler *MaintenanceHandler) {
+	if handler == nil {
+		return
+	}
+	registerHandler(mux, "GET /api/internal/maintenance", "/api/internal/maintenance", handler.HandleListWindows)
+	registerHandler(mux, "POST /api/internal/maintenance", "/api/internal/maintenance", handler.HandleScheduleWindow)
+	registerHandler(mux, "DELETE /api/internal/maintenance/{id}", "/api/internal/maintenance/:id", handler.HandleCancelWindow)
+}
+
+func registerLLMCacheRoutes(mux *http.ServeMux, handler *LLMCacheHandler) {
+	if handler == nil {
+		return
+	}
+	registerHandler(mux, "DELETE /api/internal/llm/response-cache", "/api/internal/llm/response-cache", handler.HandlePurge)
+}
+
 func registerOnboardingStateRoutes(mux *http.ServeMux, handler *OnboardingStateHandler) {
 	if handler == nil {
 		return
@@ -41,6 +70,68 @@ func registerOnboardingStateRoutes(mux *http.ServeMux, handler *OnboardingStateH
 	registerHandler(mux, "PUT /api/internal/onboarding/state", "/api/internal/onboarding/state", handler.HandleUpdateOnboardingState)
 }
 
+func registerPreferencesRoutes(mux *http.ServeMux, handler *PreferencesHandler) {
+	if handler == nil {
+		return
+	}
+	registerHandler(mux, "GET /api/me/preferences", "/api/me/preferences", handler.HandleGetPreferences)
+	registerHandler(mux, "PUT /api/me/preferences", "/api/me/preferences", handler.HandleUpdatePreferences)
+}
+
+func registerNotificationRoutes(mux *http.ServeMux, handler *NotificationsHandler) {
+	if handler == nil {
+		return
+	}
+	registerHandler(mux, "GET /api/me/notifications", "/api/me/notifications", handler.HandleListNotifications)
+	registerHandler(mux, "GET /api/me/notifications/stream", "/api/me/notifications/stream", handler.HandleStream)
+	registerHandler(mux, "POST /api/me/notifications/read-all", "/api/me/notifications/read-all", handler.HandleMarkAllRead)
+	registerHandler(mux, "POST /api/me/notifications/{id}/read", "/api/me/notifications/:id/read", handler.HandleMarkRead)
+}
+
+func registerDiagnosticsRoutes(mux *http.ServeMux, handler *DiagnosticsHandler) {
+	if handler == nil {
+		return
+	}
+	registerHandler(mux, "GET /api/diagnostics/recent", "/api/diagnostics/recent", handler.HandleRecent)
+}
+
+func registerConfigDiagnosticsRoutes(mux *http.ServeMux, handler *ConfigDiagnosticsHandler) {
+	if handler == nil {
+		return
+	}
+	registerHandler(mux, "GET /api/config/diagnostics", "/api/config/diagnostics", handler.HandleGetConfigDiagnostics)
+}
+
+func registerSamplingRoutes(mux *http.ServeMux, handler *SamplingHandler) {
+	if handler == nil {
+		return
+	}
+	registerHandler(mux, "GET /api/observability/sampling", "/api/observability/sampling", handler.HandleGetSampling)
+	registerHandler(mux, "PUT /api/observability/sampling", "/api/observability/sampling", handler.HandleUpdateSampling)
+}
+
+func registerImportRoutes(mux *http.ServeMux, handler *ImportHandler) {
+	if handler == nil {
+		return
+	}
+	registerHandler(mux, "POST /api/import", "/api/import", handler.HandleCreateImport)
+	registerHandler(mux, "GET /api/import/{id}", "/api/import/:id", handler.HandleGetImport)
+}
+
+func registerSchedulerRoutes(mux *http.ServeMux, handler *SchedulerHandler) {
+	if handler == nil {
+		return
+	}
+	registerHandler(mux, "GET /api/scheduler/jobs/{id}/history", "/api/scheduler/jobs/:id/history", handler.HandleGetJobHistory)
+}
+
+func registerUsageRoutes(mux *http.ServeMux, handler *UsageHandler) {
+	if handler == nil {
+		return
+	}
+	registerHandler(mux, "GET /api/usage", "/api/usage", handler.HandleGetUsage)
+}
+
 func registerLarkOAuthRoutes(mux *http.ServeMux, handler *LarkOAuthHandler) {
 	if handler == nil {
 		return
@@ -54,6 +145,10 @@ func registerHookRoutes(mux *http.ServeMux, hooksBridge, runtimeHooksBridge http
 	registerRoute(mux, "POST /api/hooks/runtime", "/api/hooks/runtime", runtimeHooksBridge)
 }
 
+func registerLarkEventRoutes(mux *http.ServeMux, larkEvents http.Handler) {
+	registerRoute(mux, "POST /api/lark/events", "/api/lark/events", larkEvents)
+}
+
 func registerWebhookRoutes(mux *http.ServeMux, githubWebhook http.Handler) {
 	if githubWebhook == nil {
 		return
@@ -84,14 +179,22 @@ func registerEvaluationRoutes(mux *http.ServeMux, apiHandler *APIHandler) {
 func registerSessionRoutes(mux *http.ServeMux, apiHandler *APIHandler) {
 	registerHandler(mux, "GET /api/sessions", "/api/sessions", apiHandler.HandleListSessions)
 	registerHandler(mux, "POST /api/sessions", "/api/sessions", apiHandler.HandleCreateSession)
+	registerHandler(mux, "GET /api/sessions/search", "/api/sessions/search", apiHandler.HandleSearchSessions)
 	registerHandler(mux, "GET /api/sessions/{session_id}", "/api/sessions/:session_id", apiHandler.HandleGetSession)
+	registerHandler(mux, "PATCH /api/sessions/{session_id}", "/api/sessions/:session_id", apiHandler.HandleUpdateSession)
 	registerHandler(mux, "DELETE /api/sessions/{session_id}", "/api/sessions/:session_id", apiHandler.HandleDeleteSession)
 	registerHandler(mux, "GET /api/sessions/{session_id}/persona", "/api/sessions/:session_id/persona", apiHandler.HandleGetSessionPersona)
 	registerHandler(mux, "PUT /api/sessions/{session_id}/persona", "/api/sessions/:session_id/persona", apiHandler.HandleUpdateSessionPersona)
+	registerHandler(mux, "GET /api/sessions/{session_id}/tool-overrides", "/api/sessions/:session_id/tool-overrides", apiHandler.HandleGetSessionToolOverrides)
+	registerHandler(mux, "PUT /api/sessions/{session_id}/tool-overrides", "/api/sessions/:session_id/tool-overrides", apiHandler.HandleUpdateSessionToolOverrides)
 	registerHandler(mux, "GET /api/sessions/{session_id}/snapshots", "/api/sessions/:session_id/snapshots", apiHandler.HandleListSnapshots)
 	registerHandler(mux, "GET /api/sessions/{session_id}/turns/{turn_id}", "/api/sessions/:session_id/turns/:turn_id", apiHandler.HandleGetTurnSnapshot)
 	registerHandler(mux, "POST /api/sessions/{session_id}/share", "/api/sessions/:session_id/share", apiHandler.HandleCreateSessionShare)
 	registerHandler(mux, "POST /api/sessions/{session_id}/fork", "/api/sessions/:session_id/fork", apiHandler.HandleForkSession)
+	registerHandler(mux, "POST /api/sessions/{session_id}/messages/{index}/annotations", "/api/sessions/:session_id/messages/:index/annotations", apiHandler.HandleCreateAnnotation)
+	registerHandler(mux, "GET /api/sessions/{session_id}/annotations", "/api/sessions/:session_id/annotations", apiHandler.HandleListAnnotations)
+	registerHandler(mux, "PATCH /api/sessions/{session_id}/annotations/{annotation_id}", "/api/sessions/:session_id/annotations/:annotation_id", apiHandler.HandleUpdateAnnotation)
+	registerHandler(mux, "DELETE /api/sessions/{session_id}/annotations/{annotation_id}", "/api/sessions/:session_id/annotations/:annotation_id", apiHandler.HandleDeleteAnnotation)
 }
 
 func registerLeaderRoutes(mux *http.ServeMux, handler *LeaderDashboardHandler, leaderAPIToken string) {
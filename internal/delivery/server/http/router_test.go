@@ -1,6 +1,7 @@
 package http
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
@@ -12,6 +13,7 @@ import (
 
 	serverapp "alex/internal/delivery/server/app"
 	"alex/internal/infra/attachments"
+	"alex/internal/infra/observability"
 )
 
 func TestRouterRegistersDataCacheEndpoint(t *testing.T) {
@@ -118,3 +120,57 @@ func TestRouterRegistersOnboardingStateEndpoint(t *testing.T) {
 		t.Fatalf("expected status %d, got %d body=%s", http.StatusOK, w.Code, w.Body.String())
 	}
 }
+
+func TestRouterSamplingRoutesAreInternalOnly(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	configContent := `
+observability:
+  metrics:
+    enabled: false
+  tracing:
+    enabled: true
+    exporter: otlp
+    otlp_endpoint: 127.0.0.1:1
+    sample_rate: 0
+`
+	if err := os.WriteFile(configPath, []byte(configContent), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	obs, err := observability.New(configPath)
+	if err != nil {
+		t.Fatalf("observability: %v", err)
+	}
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		_ = obs.Shutdown(ctx)
+	})
+
+	put := func(environment string) *httptest.ResponseRecorder {
+		router := NewRouter(
+			RouterDeps{
+				Broadcaster:   serverapp.NewEventBroadcaster(),
+				HealthChecker: serverapp.NewHealthChecker(),
+				AttachmentCfg: attachments.StoreConfig{Dir: t.TempDir()},
+				Obs:           obs,
+			},
+			RouterConfig{Environment: environment},
+		)
+		req := httptest.NewRequest(http.MethodPut, "/api/observability/sampling", strings.NewReader(`{"routes":{"/health":1}}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// Internal deployments carry no user identity; the route itself is the gate.
+	if w := put("internal"); w.Code != http.StatusOK {
+		t.Fatalf("internal: expected status %d, got %d body=%s", http.StatusOK, w.Code, w.Body.String())
+	}
+	if routes := obs.CurrentConfig().Tracing.RouteSampleRates; routes["/health"] != 1 {
+		t.Fatalf("expected /health override, got %v", routes)
+	}
+	if w := put("production"); w.Code != http.StatusNotFound {
+		t.Fatalf("production: expected status %d, got %d", http.StatusNotFound, w.Code)
+	}
+}
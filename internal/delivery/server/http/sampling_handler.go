@@ -0,0 +1,118 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"alex/internal/infra/observability"
+	"alex/internal/shared/logging"
+)
+
+// SamplingController is the subset of observability that adjusts trace
+// sampling at runtime.
+type SamplingController interface {
+	SetSamplerRatio(ratio float64) error
+	SetRouteSampleRates(routes map[string]float64) error
+	CurrentConfig() observability.Config
+}
+
+// SamplingHandler serves the trace sampling control API. Access control is
+// the router's: the routes are only registered in internal and development
+// deployments.
+type SamplingHandler struct {
+	controller SamplingController
+	logger     logging.Logger
+}
+
+// NewSamplingHandler returns nil when observability is not initialized.
+func NewSamplingHandler(controller SamplingController) *SamplingHandler {
+	if controller == nil {
+		return nil
+	}
+	return &SamplingHandler{
+		controller: controller,
+		logger:     logging.NewComponentLogger("SamplingHandler"),
+	}
+}
+
+type samplingRequest struct {
+	// Ratio is the base sampling ratio; omitted keeps the current one.
+	Ratio *float64 `json:"ratio,omitempty"`
+	// Routes replaces the per-route overrides; omitted keeps them and an
+	// empty object clears them.
+	Routes map[string]float64 `json:"routes"`
+}
+
+type samplingResponse struct {
+	Enabled bool               `json:"enabled"`
+	Ratio   float64            `json:"ratio"`
+	Routes  map[string]float64 `json:"routes"`
+}
+
+func (h *SamplingHandler) current() samplingResponse {
+	tracing := h.controller.CurrentConfig().Tracing
+	routes := tracing.RouteSampleRates
+	if routes == nil {
+		routes = map[string]float64{}
+	}
+	return samplingResponse{Enabled: tracing.Enabled, Ratio: tracing.SampleRate, Routes: routes}
+}
+
+// HandleGetSampling handles GET /api/observability/sampling.
+func (h *SamplingHandler) HandleGetSampling(w http.ResponseWriter, r *http.Request) {
+	if h == nil {
+		http.NotFound(w, r)
+		return
+	}
+	writeJSON(w, http.StatusOK, h.current())
+}
+
+// HandleUpdateSampling handles PUT /api/observability/sampling. Changes apply
+// to spans started after the request and are logged with the peer address.
+func (h *SamplingHandler) HandleUpdateSampling(w http.ResponseWriter, r *http.Request) {
+	if h == nil {
+		http.NotFound(w, r)
+		return
+	}
+	var body samplingRequest
+	if !decodeJSONRequest(w, r, &body, "invalid JSON payload") {
+		return
+	}
+	if body.Ratio == nil && body.Routes == nil {
+		http.Error(w, "ratio or routes is required", http.StatusBadRequest)
+		return
+	}
+	// Check the ratio up front so a bad request never applies half a change.
+	if body.Ratio != nil && (*body.Ratio < 0 || *body.Ratio > 1) {
+		http.Error(w, "ratio must be between 0 and 1", http.StatusBadRequest)
+		return
+	}
+
+	before := h.current()
+	if body.Routes != nil {
+		if err := h.controller.SetRouteSampleRates(body.Routes); err != nil {
+			writeSamplingError(w, err)
+			return
+		}
+	}
+	if body.Ratio != nil {
+		if err := h.controller.SetSamplerRatio(*body.Ratio); err != nil {
+			writeSamplingError(w, err)
+			return
+		}
+	}
+	after := h.current()
+	logging.FromContext(r.Context(), h.logger).Info(
+		"Trace sampling changed by %s: ratio %g -> %g, routes %v -> %v",
+		clientIP(r, nil), before.Ratio, after.Ratio, before.Routes, after.Routes,
+	)
+	writeJSON(w, http.StatusOK, after)
+}
+
+func writeSamplingError(w http.ResponseWriter, err error) {
+	if errors.Is(err, observability.ErrTracingDisabled) {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+	http.Error(w, err.Error(), http.StatusBadRequest)
+}
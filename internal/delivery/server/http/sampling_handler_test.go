@@ -0,0 +1,76 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"alex/internal/infra/observability"
+)
+
+type stubSamplingController struct {
+	config observability.Config
+}
+
+func (s *stubSamplingController) SetSamplerRatio(ratio float64) error {
+	if !s.config.Tracing.Enabled {
+		return observability.ErrTracingDisabled
+	}
+	s.config.Tracing.SampleRate = ratio
+	return nil
+}
+
+func (s *stubSamplingController) SetRouteSampleRates(routes map[string]float64) error {
+	if !s.config.Tracing.Enabled {
+		return observability.ErrTracingDisabled
+	}
+	s.config.Tracing.RouteSampleRates = routes
+	return nil
+}
+
+func (s *stubSamplingController) CurrentConfig() observability.Config { return s.config }
+
+func TestSamplingHandlerUpdatesSampling(t *testing.T) {
+	controller := &stubSamplingController{}
+	controller.config.Tracing.Enabled = true
+	controller.config.Tracing.SampleRate = 0.1
+	handler := NewSamplingHandler(controller)
+
+	put := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPut, "/api/observability/sampling", strings.NewReader(body))
+		rec := httptest.NewRecorder()
+		handler.HandleUpdateSampling(rec, req)
+		return rec
+	}
+
+	if rec := put(`{"ratio":2}`); rec.Code != http.StatusBadRequest {
+		t.Fatalf("out of range ratio: status = %d, want 400", rec.Code)
+	}
+	if rec := put(`{}`); rec.Code != http.StatusBadRequest {
+		t.Fatalf("empty change: status = %d, want 400", rec.Code)
+	}
+
+	rec := put(`{"ratio":1,"routes":{"/health":0.01,"alex.session.solve_task":1}}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d body=%s", rec.Code, rec.Body.String())
+	}
+	var got samplingResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if got.Ratio != 1 || got.Routes["/health"] != 0.01 || got.Routes["alex.session.solve_task"] != 1 {
+		t.Fatalf("unexpected sampling %+v", got)
+	}
+
+	// An empty routes object clears the overrides and keeps the ratio.
+	if rec := put(`{"routes":{}}`); rec.Code != http.StatusOK || controller.config.Tracing.SampleRate != 1 || len(controller.config.Tracing.RouteSampleRates) != 0 {
+		t.Fatalf("clear routes: status = %d, config %+v", rec.Code, controller.config.Tracing)
+	}
+
+	controller.config.Tracing.Enabled = false
+	if rec := put(`{"ratio":0.5}`); rec.Code != http.StatusConflict {
+		t.Fatalf("tracing disabled: status = %d, want 409", rec.Code)
+	}
+}
@@ -0,0 +1,76 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"alex/internal/app/scheduler"
+	"alex/internal/shared/runhistory"
+)
+
+// schedulerJobHistory is the subset of the scheduler API needed for history.
+type schedulerJobHistory interface {
+	JobHistory(ctx context.Context, jobID string, limit int) ([]runhistory.Record, error)
+	HistoryRetention() int
+}
+
+// SchedulerHandler serves scheduler job execution history.
+type SchedulerHandler struct {
+	scheduler schedulerJobHistory
+}
+
+// NewSchedulerHandler returns nil when no scheduler is running.
+func NewSchedulerHandler(sched schedulerJobHistory) *SchedulerHandler {
+	if sched == nil {
+		return nil
+	}
+	return &SchedulerHandler{scheduler: sched}
+}
+
+// JobHistoryResponse is the JSON response for GET /api/scheduler/jobs/{id}/history.
+type JobHistoryResponse struct {
+	JobID     string              `json:"job_id"`
+	Retention int                 `json:"retention"`
+	Records   []runhistory.Record `json:"records"`
+}
+
+// HandleGetJobHistory handles GET /api/scheduler/jobs/{id}/history. Records
+// are newest first; an optional "limit" query parameter trims the response
+// below the retention cap.
+func (h *SchedulerHandler) HandleGetJobHistory(w http.ResponseWriter, r *http.Request) {
+	if h == nil {
+		http.NotFound(w, r)
+		return
+	}
+	jobID := strings.TrimSpace(r.PathValue("id"))
+	limit := 0
+	if raw := strings.TrimSpace(r.URL.Query().Get("limit")); raw != "" {
+		value, err := strconv.Atoi(raw)
+		if err != nil || value < 1 {
+			http.Error(w, "limit must be a positive integer", http.StatusBadRequest)
+			return
+		}
+		limit = value
+	}
+
+	records, err := h.scheduler.JobHistory(r.Context(), jobID, limit)
+	if err != nil {
+		if errors.Is(err, scheduler.ErrJobNotFound) {
+			http.Error(w, "scheduler job not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if records == nil {
+		records = []runhistory.Record{}
+	}
+	writeJSON(w, http.StatusOK, JobHistoryResponse{
+		JobID:     jobID,
+		Retention: h.scheduler.HistoryRetention(),
+		Records:   records,
+	})
+}
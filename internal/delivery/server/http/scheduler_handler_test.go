@@ -0,0 +1,68 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"alex/internal/app/scheduler"
+	"alex/internal/shared/runhistory"
+)
+
+type fakeJobHistory struct {
+	store *runhistory.Store
+}
+
+func (f *fakeJobHistory) JobHistory(_ context.Context, jobID string, limit int) ([]runhistory.Record, error) {
+	if jobID != "digest" {
+		return nil, fmt.Errorf("jobstore: %w: %s", scheduler.ErrJobNotFound, jobID)
+	}
+	return f.store.List(jobID, limit)
+}
+
+func (f *fakeJobHistory) HistoryRetention() int {
+	return f.store.MaxRecords()
+}
+
+func TestSchedulerHandlerJobHistory(t *testing.T) {
+	store := runhistory.NewStore("", 5)
+	base := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	for i := 0; i < 3; i++ {
+		_ = store.Append("digest", runhistory.Record{ScheduledAt: base.Add(time.Duration(i) * time.Hour), Outcome: runhistory.OutcomeFailure, Error: "boom"})
+	}
+	handler := NewSchedulerHandler(&fakeJobHistory{store: store})
+	mux := http.NewServeMux()
+	registerSchedulerRoutes(mux, handler)
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/scheduler/jobs/digest/history?limit=2", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, body=%s", rec.Code, rec.Body.String())
+	}
+	var resp JobHistoryResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if resp.JobID != "digest" || resp.Retention != 5 || len(resp.Records) != 2 {
+		t.Fatalf("unexpected response: %+v", resp)
+	}
+	if !resp.Records[0].ScheduledAt.Equal(base.Add(2 * time.Hour)) {
+		t.Fatalf("expected newest record first, got %+v", resp.Records[0])
+	}
+
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/scheduler/jobs/unknown/history", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("unknown job status = %d, want 404", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/scheduler/jobs/digest/history?limit=-1", nil))
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("bad limit status = %d, want 400", rec.Code)
+	}
+}
@@ -63,17 +63,23 @@ func (h *ShareHandler) HandleSharedSession(w http.ResponseWriter, r *http.Reques
 		serialized = append(serialized, payload)
 	}
 
+	notes, err := h.sessions.ListAnnotations(r.Context(), session.ID)
+	if err != nil {
+		h.logger.Warn("Failed to load annotations for shared session %s: %v", session.ID, err)
+	}
+
 	title := ""
 	if session.Metadata != nil {
 		title = strings.TrimSpace(session.Metadata["title"])
 	}
 
 	resp := ShareSessionResponse{
-		SessionID: session.ID,
-		Title:     title,
-		CreatedAt: session.CreatedAt.Format(time.RFC3339),
-		UpdatedAt: session.UpdatedAt.Format(time.RFC3339),
-		Events:    serialized,
+		SessionID:   session.ID,
+		Title:       title,
+		CreatedAt:   session.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:   session.UpdatedAt.Format(time.RFC3339),
+		Events:      serialized,
+		Annotations: notes,
 	}
 
 	w.Header().Set("Content-Type", "application/json")
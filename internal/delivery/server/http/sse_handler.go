@@ -23,16 +23,20 @@ var sseAllowlist = map[string]bool{
 	types.EventNodeFailed:                    true,
 	types.EventNodeOutputDelta:               true,
 	types.EventNodeOutputSummary:             true,
+	types.EventIterationUsage:                true,
 	types.EventToolStarted:                   true,
 	types.EventToolProgress:                  true,
 	types.EventToolCompleted:                 true,
 	types.EventArtifactManifest:              true,
 	types.EventInputReceived:                 true,
+	types.EventQueued:                        true,
 	types.EventSubflowProgress:               true,
 	types.EventSubflowCompleted:              true,
 	types.EventResultFinal:                   true,
 	types.EventResultCancelled:               true,
 	types.EventDiagnosticEnvironmentSnapshot: true,
+	types.EventDiagnosticConfigChanged:       true,
+	types.EventDiagnosticHealthChanged:       true,
 }
 
 // sseDebugAllowlist enumerates events that are only relevant in debug streams.
@@ -61,6 +65,8 @@ type SSEHandler struct {
 	obs             *observability.Observability
 	dataCache       *DataCache
 	attachmentStore *AttachmentStore
+	maintenance     maintenanceNotices
+	diagnostics     DiagnosticsHistory
 }
 
 // SSEHandlerOption configures optional instrumentation for the SSE handler.
@@ -95,6 +101,14 @@ func WithSSERunTracker(tracker app.RunTracker) SSEHandlerOption {
 	}
 }
 
+// WithSSEDiagnostics replays the latest diagnostics snapshot of each kind
+// to every new session stream.
+func WithSSEDiagnostics(history DiagnosticsHistory) SSEHandlerOption {
+	return func(handler *SSEHandler) {
+		handler.diagnostics = history
+	}
+}
+
 // NewSSEHandler creates a new SSE handler
 func NewSSEHandler(broadcaster *app.EventBroadcaster, opts ...SSEHandlerOption) *SSEHandler {
 	handler := &SSEHandler{
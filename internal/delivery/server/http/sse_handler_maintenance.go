@@ -0,0 +1,68 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"alex/internal/app/maintenance"
+)
+
+const sseMaintenanceNoticeEvent = "maintenance_notice"
+
+// WithSSEMaintenance emits maintenance_notice events on every stream.
+func WithSSEMaintenance(notices maintenanceNotices) SSEHandlerOption {
+	return func(handler *SSEHandler) {
+		handler.maintenance = notices
+	}
+}
+
+// sseMaintenanceStream tracks the notice last sent on one connection so
+// subscribers only see a maintenance_notice event when it changes.
+type sseMaintenanceStream struct {
+	notices maintenanceNotices
+	updates <-chan struct{}
+	close   func()
+	last    maintenance.Notice
+	shown   bool
+}
+
+// openMaintenanceStream subscribes to notice changes. The returned stream's
+// updates channel is nil when maintenance is not configured, so selecting on
+// it never fires.
+func (h *SSEHandler) openMaintenanceStream() *sseMaintenanceStream {
+	stream := &sseMaintenanceStream{notices: h.maintenance, close: func() {}}
+	if h.maintenance != nil {
+		stream.updates, stream.close = h.maintenance.Subscribe()
+	}
+	return stream
+}
+
+// sync writes a maintenance_notice event when the current notice differs
+// from the last one sent, including a "cleared" event once it goes away.
+func (s *sseMaintenanceStream) sync(w http.ResponseWriter, flusher http.Flusher) error {
+	if s.notices == nil {
+		return nil
+	}
+	notice, ok := s.notices.Notice()
+	var next maintenance.Notice
+	switch {
+	case ok:
+		if s.shown && notice == s.last {
+			return nil
+		}
+		next = notice
+	case s.shown:
+		next = s.last.Cleared()
+	default:
+		return nil
+	}
+	payload, err := json.Marshal(next)
+	if err != nil {
+		return err
+	}
+	if err := writeAndFlushSSEEvent(w, flusher, sseMaintenanceNoticeEvent, string(payload)); err != nil {
+		return err
+	}
+	s.last, s.shown = notice, ok
+	return nil
+}
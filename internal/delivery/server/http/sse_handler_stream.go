@@ -108,6 +108,12 @@ func (h *SSEHandler) HandleSSEStream(w http.ResponseWriter, r *http.Request) {
 		writeFailureLabel:     "Failed to send SSE message",
 	})
 
+	if h.diagnostics != nil {
+		for _, event := range h.diagnostics.SnapshotEvents() {
+			sendEvent(event)
+		}
+	}
+
 	if req.includeGlobalHistory {
 		if err := h.replayHistory(r.Context(), app.EventHistoryFilter{SessionID: ""}, sendEvent); err != nil {
 			logger.Warn("Failed to replay global events: %v", err)
@@ -122,7 +128,15 @@ func (h *SSEHandler) HandleSSEStream(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 	drainQueuedEvents(clientChan, sendEvent)
-	lifecycle.closeReason = h.runSessionStreamLoop(r.Context(), w, flusher, clientChan, logger, req.sessionID, sendEvent)
+
+	notices := h.openMaintenanceStream()
+	defer notices.close()
+	if err := notices.sync(w, flusher); err != nil {
+		lifecycle.closeReason = "maintenance_write_failed"
+		lifecycle.Finish(err)
+		return
+	}
+	lifecycle.closeReason = h.runSessionStreamLoop(r.Context(), w, flusher, clientChan, logger, req.sessionID, sendEvent, notices)
 }
 
 func shouldSendEvent(event agent.AgentEvent, seenEventIDs *stringLRU, lastSeqByRun *runSeqLRU) bool {
@@ -267,7 +281,14 @@ func (h *SSEHandler) HandleTaskSSEStream(w http.ResponseWriter, r *http.Request)
 
 	_ = h.replayHistory(r.Context(), app.EventHistoryFilter{SessionID: req.sessionID}, sendEvent)
 	drainQueuedEvents(clientChan, sendEvent)
-	h.runTaskStreamLoop(r.Context(), w, flusher, clientChan, logger, req.taskID, matchesTask, sendEvent)
+
+	notices := h.openMaintenanceStream()
+	defer notices.close()
+	if err := notices.sync(w, flusher); err != nil {
+		logger.Error("Failed to send task SSE maintenance notice: %v", err)
+		return
+	}
+	h.runTaskStreamLoop(r.Context(), w, flusher, clientChan, logger, req.taskID, matchesTask, sendEvent, notices)
 }
 
 func parseSessionSSERequest(r *http.Request) (sessionSSERequest, error) {
@@ -493,6 +514,7 @@ func (h *SSEHandler) runSessionStreamLoop(
 	logger logging.Logger,
 	sessionID string,
 	sendEvent func(agent.AgentEvent) bool,
+	notices *sseMaintenanceStream,
 ) string {
 	ticker := time.NewTicker(sseHeartbeatInterval)
 	defer ticker.Stop()
@@ -503,6 +525,11 @@ func (h *SSEHandler) runSessionStreamLoop(
 			if !sendEvent(event) {
 				continue
 			}
+		case <-notices.updates:
+			if err := notices.sync(w, flusher); err != nil {
+				logger.Error("Failed to send maintenance notice: %v", err)
+				return "maintenance_write_failed"
+			}
 		case <-ticker.C:
 			if err := writeSSEComment(w, "heartbeat"); err != nil {
 				logger.Error("Failed to send heartbeat: %v", err)
@@ -528,6 +555,7 @@ func (h *SSEHandler) runTaskStreamLoop(
 	taskID string,
 	matchesTask func(agent.AgentEvent) bool,
 	sendEvent func(agent.AgentEvent) bool,
+	notices *sseMaintenanceStream,
 ) {
 	ticker := time.NewTicker(sseHeartbeatInterval)
 	defer ticker.Stop()
@@ -545,6 +573,10 @@ func (h *SSEHandler) runTaskStreamLoop(
 					return
 				}
 			}
+		case <-notices.updates:
+			if err := notices.sync(w, flusher); err != nil {
+				return
+			}
 		case <-ticker.C:
 			if err := writeSSEComment(w, "heartbeat"); err != nil {
 				return
@@ -0,0 +1,145 @@
+package http
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	agentstorage "alex/internal/domain/agent/ports/storage"
+)
+
+const usageDateLayout = "2006-01-02"
+
+// UsageReporter is the subset of the cost tracker needed for usage reports.
+type UsageReporter interface {
+	QueryUsage(ctx context.Context, query agentstorage.UsageQuery) (*agentstorage.UsageReport, error)
+}
+
+// UsageHandler serves aggregated LLM usage and spend. It is an admin
+// endpoint: requests carry no user identity, so it cannot limit a caller to
+// their own spend and is only registered in internal and development
+// deployments, where any user's spend may be queried. Users see their own
+// spend through the Lark /usage command instead.
+type UsageHandler struct {
+	reporter UsageReporter
+}
+
+// NewUsageHandler returns nil when no cost tracker is available.
+func NewUsageHandler(reporter UsageReporter) *UsageHandler {
+	if reporter == nil {
+		return nil
+	}
+	return &UsageHandler{reporter: reporter}
+}
+
+// HandleGetUsage handles GET /api/usage?user=&from=&to=&group_by=&format=.
+// from and to are inclusive UTC dates (YYYY-MM-DD); from defaults to the
+// first of the current month and to to today. format=csv returns the groups
+// as CSV with a trailing total row. An empty user aggregates every user.
+func (h *UsageHandler) HandleGetUsage(w http.ResponseWriter, r *http.Request) {
+	if h == nil {
+		http.NotFound(w, r)
+		return
+	}
+	params := r.URL.Query()
+	user := strings.TrimSpace(params.Get("user"))
+
+	now := time.Now().UTC()
+	from := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	to := now
+	if raw := strings.TrimSpace(params.Get("from")); raw != "" {
+		parsed, err := time.Parse(usageDateLayout, raw)
+		if err != nil {
+			http.Error(w, "from must be a date in YYYY-MM-DD form", http.StatusBadRequest)
+			return
+		}
+		from = parsed
+	}
+	if raw := strings.TrimSpace(params.Get("to")); raw != "" {
+		parsed, err := time.Parse(usageDateLayout, raw)
+		if err != nil {
+			http.Error(w, "to must be a date in YYYY-MM-DD form", http.StatusBadRequest)
+			return
+		}
+		to = parsed
+	}
+	// to names the last day included; the query bound is exclusive.
+	to = time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, 1)
+
+	format := strings.ToLower(strings.TrimSpace(params.Get("format")))
+	if format != "" && format != "json" && format != "csv" {
+		http.Error(w, "format must be json or csv", http.StatusBadRequest)
+		return
+	}
+
+	report, err := h.reporter.QueryUsage(r.Context(), agentstorage.UsageQuery{
+		UserID:  user,
+		From:    from,
+		To:      to,
+		GroupBy: agentstorage.UsageGroupBy(strings.ToLower(strings.TrimSpace(params.Get("group_by")))),
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if format == "csv" {
+		data, err := usageReportCSV(report)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=usage-%s-%s.csv",
+			report.From.Format(usageDateLayout), report.To.AddDate(0, 0, -1).Format(usageDateLayout)))
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(data)
+		return
+	}
+	writeJSON(w, http.StatusOK, UsageResponse{User: user, UsageReport: report})
+}
+
+// UsageResponse is the JSON response for GET /api/usage.
+type UsageResponse struct {
+	User string `json:"user,omitempty"`
+	*agentstorage.UsageReport
+}
+
+func usageReportCSV(report *agentstorage.UsageReport) ([]byte, error) {
+	var buf strings.Builder
+	writer := csv.NewWriter(&buf)
+	keyColumn := string(report.GroupBy)
+	if keyColumn == "" {
+		keyColumn = "group"
+	}
+	if err := writer.Write([]string{keyColumn, "requests", "input_tokens", "output_tokens", "total_tokens", "total_cost"}); err != nil {
+		return nil, err
+	}
+	row := func(key string, totals agentstorage.UsageTotals) []string {
+		return []string{
+			key,
+			strconv.Itoa(totals.RequestCount),
+			strconv.Itoa(totals.InputTokens),
+			strconv.Itoa(totals.OutputTokens),
+			strconv.Itoa(totals.TotalTokens),
+			strconv.FormatFloat(totals.TotalCost, 'f', 6, 64),
+		}
+	}
+	for _, group := range report.Groups {
+		if err := writer.Write(row(group.Key, group.UsageTotals)); err != nil {
+			return nil, err
+		}
+	}
+	if err := writer.Write(row("total", report.Totals)); err != nil {
+		return nil, err
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return nil, err
+	}
+	return []byte(buf.String()), nil
+}
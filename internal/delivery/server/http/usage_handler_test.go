@@ -0,0 +1,88 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	agentstorage "alex/internal/domain/agent/ports/storage"
+)
+
+type fakeUsageReporter struct {
+	query agentstorage.UsageQuery
+}
+
+func (f *fakeUsageReporter) QueryUsage(_ context.Context, query agentstorage.UsageQuery) (*agentstorage.UsageReport, error) {
+	f.query = query
+	return &agentstorage.UsageReport{
+		From:    query.From,
+		To:      query.To,
+		GroupBy: query.GroupBy,
+		Totals:  agentstorage.UsageTotals{TotalTokens: 30, TotalCost: 0.3, RequestCount: 3},
+		Groups: []agentstorage.UsageGroup{
+			{Key: "2026-03-01", UsageTotals: agentstorage.UsageTotals{TotalTokens: 10, TotalCost: 0.1, RequestCount: 1}},
+			{Key: "2026-03-02", UsageTotals: agentstorage.UsageTotals{TotalTokens: 20, TotalCost: 0.2, RequestCount: 2}},
+		},
+	}, nil
+}
+
+func serveUsage(t *testing.T, target string) (*httptest.ResponseRecorder, *fakeUsageReporter) {
+	t.Helper()
+	reporter := &fakeUsageReporter{}
+	mux := http.NewServeMux()
+	registerUsageRoutes(mux, NewUsageHandler(reporter))
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+	return rec, reporter
+}
+
+func TestUsageHandlerQueriesRequestedUser(t *testing.T) {
+	rec, reporter := serveUsage(t, "/api/usage?user=alice&from=2026-03-01&to=2026-03-02&group_by=day")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, body=%s", rec.Code, rec.Body.String())
+	}
+	if reporter.query.UserID != "alice" || reporter.query.GroupBy != agentstorage.UsageGroupByDay {
+		t.Fatalf("unexpected query %+v", reporter.query)
+	}
+	if !reporter.query.To.Equal(time.Date(2026, 3, 3, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("to should include the whole last day, got %v", reporter.query.To)
+	}
+	var resp UsageResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if resp.User != "alice" || resp.UsageReport == nil || resp.Totals.RequestCount != 3 || len(resp.Groups) != 2 {
+		t.Fatalf("unexpected response %+v", resp)
+	}
+
+	if rec, reporter := serveUsage(t, "/api/usage"); rec.Code != http.StatusOK || reporter.query.UserID != "" {
+		t.Fatalf("omitting user should aggregate every user: status %d, query %+v", rec.Code, reporter.query)
+	}
+	if rec, _ := serveUsage(t, "/api/usage?from=March"); rec.Code != http.StatusBadRequest {
+		t.Fatalf("bad date status = %d, want 400", rec.Code)
+	}
+}
+
+func TestUsageHandlerCSVExport(t *testing.T) {
+	rec, _ := serveUsage(t, "/api/usage?user=alice&from=2026-03-01&to=2026-03-02&group_by=day&format=csv")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, body=%s", rec.Code, rec.Body.String())
+	}
+	if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/csv") {
+		t.Fatalf("Content-Type = %q", ct)
+	}
+	if cd := rec.Header().Get("Content-Disposition"); !strings.Contains(cd, "usage-2026-03-01-2026-03-02.csv") {
+		t.Fatalf("Content-Disposition = %q", cd)
+	}
+	want := "day,requests,input_tokens,output_tokens,total_tokens,total_cost\n" +
+		"2026-03-01,1,0,0,10,0.100000\n" +
+		"2026-03-02,2,0,0,20,0.200000\n" +
+		"total,3,0,0,30,0.300000\n"
+	if rec.Body.String() != want {
+		t.Fatalf("CSV body:\n%s\nwant:\n%s", rec.Body.String(), want)
+	}
+}
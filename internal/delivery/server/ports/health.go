@@ -8,16 +8,42 @@ type HealthStatus string
 const (
 	HealthStatusReady    HealthStatus = "ready"
 	HealthStatusNotReady HealthStatus = "not_ready"
+	// HealthStatusDegraded means the component works but is slower than its
+	// configured threshold. It does not fail readiness.
+	HealthStatusDegraded HealthStatus = "degraded"
 	HealthStatusDisabled HealthStatus = "disabled"
 	HealthStatusError    HealthStatus = "error"
 )
 
+// ProbeKind classifies which endpoint a probe gates. Every probe is reported
+// on /health; only liveness probes gate /livez and only readiness probes gate
+// /readyz.
+type ProbeKind string
+
+const (
+	ProbeKindLiveness  ProbeKind = "liveness"
+	ProbeKindReadiness ProbeKind = "readiness"
+	// ProbeKindInformational probes are reported on /health only.
+	ProbeKindInformational ProbeKind = "informational"
+)
+
 // ComponentHealth represents the health of a single component
 type ComponentHealth struct {
 	Name    string       `json:"name"`
 	Status  HealthStatus `json:"status"`
 	Message string       `json:"message,omitempty"`
 	Details interface{}  `json:"details,omitempty"`
+	// CheckDurationMS is how long the probe's Check took; set by the checker.
+	CheckDurationMS float64 `json:"check_duration_ms,omitempty"`
+	// Latency summarises the probe's recent check durations; set by the checker.
+	Latency *LatencySummary `json:"latency,omitempty"`
+}
+
+// LatencySummary holds percentiles over a probe's recent check durations.
+type LatencySummary struct {
+	P50MS   float64 `json:"p50_ms"`
+	P95MS   float64 `json:"p95_ms"`
+	Samples int     `json:"samples"`
 }
 
 // HealthProbe checks the health of a component
@@ -31,8 +57,14 @@ type HealthChecker interface {
 	// CheckAll returns health status for all components
 	CheckAll(ctx context.Context) []ComponentHealth
 
-	// RegisterProbe adds a health probe
+	// CheckKind returns health status for probes of the given kind
+	CheckKind(ctx context.Context, kind ProbeKind) []ComponentHealth
+
+	// RegisterProbe adds a readiness probe
 	RegisterProbe(probe HealthProbe)
+
+	// RegisterProbeKind adds a probe of the given kind
+	RegisterProbeKind(kind ProbeKind, probe HealthProbe)
 }
 
 // ModelHealthProvider supplies pre-processed model health data.
@@ -2,6 +2,7 @@ package ports
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	agent "alex/internal/domain/agent/ports/agent"
@@ -30,6 +31,14 @@ const (
 	TerminationReasonNone      TerminationReason = ""
 )
 
+// Task priorities order the execution queue: higher values start first and
+// tasks of equal priority start in submission order.
+const (
+	TaskPriorityLow    = -1
+	TaskPriorityNormal = 0
+	TaskPriorityHigh   = 1
+)
+
 // Task represents a task execution
 type Task struct {
 	ID                string            `json:"task_id"`
@@ -38,11 +47,13 @@ type Task struct {
 	Status            TaskStatus        `json:"status"`
 	Description       string            `json:"task"`
 	CreatedAt         time.Time         `json:"created_at"`
+	QueuedAt          *time.Time        `json:"queued_at,omitempty"`
 	StartedAt         *time.Time        `json:"started_at,omitempty"`
 	CompletedAt       *time.Time        `json:"completed_at,omitempty"`
 	Error             string            `json:"error,omitempty"`
 	Result            *agent.TaskResult `json:"result,omitempty"`
 	TerminationReason TerminationReason `json:"termination_reason,omitempty"`
+	Priority          int               `json:"priority,omitempty"`
 
 	// Progress tracking
 	CurrentIteration int `json:"current_iteration"` // Current iteration during execution (no omitempty - always show)
@@ -56,6 +67,9 @@ type Task struct {
 	// Preset configuration
 	AgentPreset string `json:"agent_preset,omitempty"` // Agent persona preset used
 	ToolPreset  string `json:"tool_preset,omitempty"`  // Tool access preset used
+
+	// Revision increases on every write; see TaskVersioner.
+	Revision int64 `json:"revision"`
 }
 
 // TaskReader provides read-only access to tasks.
@@ -75,6 +89,20 @@ type TaskWriter interface {
 	SetResult(ctx context.Context, taskID string, result *agent.TaskResult) error
 	UpdateProgress(ctx context.Context, taskID string, iteration int, tokensUsed int) error
 	SetTerminationReason(ctx context.Context, taskID string, reason TerminationReason) error
+	// SetQueued records that the task entered the execution queue.
+	SetQueued(ctx context.Context, taskID string, priority int, queuedAt time.Time) error
+}
+
+// ErrTaskConflict is returned by CompareAndSwap when the task changed since the
+// caller read it.
+var ErrTaskConflict = errors.New("task revision conflict")
+
+// TaskVersioner provides optimistic-concurrency access to task records.
+// Callers read a task with GetWithRevision, modify the fields they own, and
+// retry on ErrTaskConflict.
+type TaskVersioner interface {
+	GetWithRevision(ctx context.Context, taskID string) (*Task, int64, error)
+	CompareAndSwap(ctx context.Context, taskID string, expected int64, task *Task) (int64, error)
 }
 
 // TaskClaimer provides distributed task ownership operations.
@@ -91,6 +119,7 @@ type TaskClaimer interface {
 type TaskStore interface {
 	TaskReader
 	TaskWriter
+	TaskVersioner
 	TaskClaimer
 }
 
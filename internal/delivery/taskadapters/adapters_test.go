@@ -55,6 +55,28 @@ func (m *mockStore) Get(_ context.Context, taskID string) (*taskdomain.Task, err
 	return &copy, nil
 }
 
+func (m *mockStore) GetWithRevision(ctx context.Context, taskID string) (*taskdomain.Task, int64, error) {
+	t, err := m.Get(ctx, taskID)
+	if err != nil {
+		return nil, 0, err
+	}
+	return t, t.Revision, nil
+}
+
+func (m *mockStore) CompareAndSwap(_ context.Context, taskID string, expected int64, updated *taskdomain.Task) (int64, error) {
+	t, ok := m.tasks[taskID]
+	if !ok {
+		return 0, taskdomain.NotFoundError(taskID)
+	}
+	if t.Revision != expected {
+		return 0, taskdomain.ConflictError(taskID, expected, t.Revision)
+	}
+	cp := *updated
+	cp.Revision = expected + 1
+	m.tasks[taskID] = &cp
+	return cp.Revision, nil
+}
+
 func (m *mockStore) SetStatus(_ context.Context, taskID string, status taskdomain.Status, opts ...taskdomain.TransitionOption) error {
 	t, ok := m.tasks[taskID]
 	if !ok {
@@ -141,6 +163,16 @@ func (m *mockStore) SetBridgeMeta(_ context.Context, taskID string, meta taskdom
 	return nil
 }
 
+func (m *mockStore) SetQueued(_ context.Context, taskID string, priority int, queuedAt time.Time) error {
+	t, ok := m.tasks[taskID]
+	if !ok {
+		return taskdomain.NotFoundError(taskID)
+	}
+	t.Priority = priority
+	t.QueuedAt = &queuedAt
+	return nil
+}
+
 func (m *mockStore) TryClaimTask(_ context.Context, taskID, ownerID string, leaseUntil time.Time) (bool, error) {
 	if _, ok := m.tasks[taskID]; !ok {
 		return false, taskdomain.NotFoundError(taskID)
@@ -535,6 +567,37 @@ func TestServerAdapter_UpdateProgress(t *testing.T) {
 	}
 }
 
+func TestServerAdapter_CompareAndSwap(t *testing.T) {
+	store := newMockStore()
+	adapter := NewServerAdapter(store)
+	ctx := context.Background()
+
+	task, _ := adapter.Create(ctx, "s1", "task", "", "")
+	store.tasks[task.ID].ChatID = "oc_chat"
+
+	got, rev, err := adapter.GetWithRevision(ctx, task.ID)
+	if err != nil {
+		t.Fatalf("GetWithRevision() error = %v", err)
+	}
+	got.CurrentIteration = 4
+	got.Result = &agent.TaskResult{Answer: "done"}
+	newRev, err := adapter.CompareAndSwap(ctx, task.ID, rev, got)
+	if err != nil {
+		t.Fatalf("CompareAndSwap() error = %v", err)
+	}
+	if newRev != rev+1 {
+		t.Errorf("revision = %d, want %d", newRev, rev+1)
+	}
+	raw := store.tasks[task.ID]
+	if raw.CurrentIteration != 4 || raw.AnswerPreview != "done" || raw.ChatID != "oc_chat" {
+		t.Errorf("unexpected stored task: %+v", raw)
+	}
+
+	if _, err := adapter.CompareAndSwap(ctx, task.ID, rev, got); !errors.Is(err, ports.ErrTaskConflict) {
+		t.Fatalf("stale CompareAndSwap error = %v, want ErrTaskConflict", err)
+	}
+}
+
 func TestServerAdapter_SetTerminationReason(t *testing.T) {
 	store := newMockStore()
 	adapter := NewServerAdapter(store)
@@ -1113,3 +1176,69 @@ type testBridgeInfo struct {
 
 func (t testBridgeInfo) BridgePID() int           { return t.pid }
 func (t testBridgeInfo) BridgeOutputFile() string { return t.outputFile }
+
+func TestLarkAdapter_RecordReadReceipt(t *testing.T) {
+	store := newMockStore()
+	adapter := NewLarkAdapter(store)
+	ctx := context.Background()
+
+	if err := adapter.SaveTask(ctx, lark.TaskRecord{ChatID: "chat1", TaskID: "read-test", Status: "completed"}); err != nil {
+		t.Fatalf("SaveTask() error = %v", err)
+	}
+	readAt := time.Date(2026, 3, 1, 8, 0, 0, 0, time.UTC)
+	if err := adapter.RecordReadReceipt(ctx, "read-test", "ou_reader", readAt); err != nil {
+		t.Fatalf("RecordReadReceipt() error = %v", err)
+	}
+
+	task := store.tasks["read-test"]
+	if task.Status != taskdomain.StatusCompleted {
+		t.Errorf("Status = %q, want completed (unchanged)", task.Status)
+	}
+	if task.Metadata[larkReadByMetaKey] != "ou_reader" || task.Metadata[larkReadAtMetaKey] != "2026-03-01T08:00:00Z" {
+		t.Errorf("Metadata = %v, want read receipt fields", task.Metadata)
+	}
+
+	if err := adapter.RecordReadReceipt(ctx, "missing", "ou_reader", readAt); !errors.Is(err, taskdomain.ErrTaskNotFound) {
+		t.Errorf("RecordReadReceipt(missing) error = %v, want ErrTaskNotFound", err)
+	}
+}
+
+func TestLarkAdapter_DigestRoundTrip(t *testing.T) {
+	store := newMockStore()
+	adapter := NewLarkAdapter(store)
+	ctx := context.Background()
+
+	for _, rec := range []lark.TaskRecord{
+		{ChatID: "chat1", TaskID: "done", Status: "running"},
+		{ChatID: "chat1", TaskID: "broken", Status: "running"},
+		{ChatID: "chat1", TaskID: "active", Status: "running"},
+		{ChatID: "chat2", TaskID: "other", Status: "running"},
+	} {
+		if err := adapter.SaveTask(ctx, rec); err != nil {
+			t.Fatalf("SaveTask() error = %v", err)
+		}
+	}
+	_ = adapter.UpdateStatus(ctx, "done", "completed")
+	_ = adapter.UpdateStatus(ctx, "broken", "failed")
+	_ = adapter.UpdateStatus(ctx, "other", "completed")
+
+	undigested, err := adapter.ListUndigested(ctx, "chat1")
+	if err != nil {
+		t.Fatalf("ListUndigested() error = %v", err)
+	}
+	if len(undigested) != 2 {
+		t.Fatalf("got %d undigested tasks, want 2: %+v", len(undigested), undigested)
+	}
+
+	digestedAt := time.Date(2026, 3, 13, 9, 0, 0, 0, time.UTC)
+	if err := adapter.MarkDigested(ctx, []string{"done", "missing"}, digestedAt); err != nil {
+		t.Fatalf("MarkDigested() error = %v", err)
+	}
+	if rec, _, _ := adapter.GetTask(ctx, "done"); !rec.DigestedAt.Equal(digestedAt) || rec.Status != "completed" {
+		t.Errorf("GetTask(done) = %+v, want digested and still completed", rec)
+	}
+	undigested, _ = adapter.ListUndigested(ctx, "")
+	if len(undigested) != 2 || undigested[0].TaskID == "done" || undigested[1].TaskID == "done" {
+		t.Errorf("ListUndigested(all) = %+v, want broken and other", undigested)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"sort"
 	"strings"
 	"time"
 
@@ -16,9 +17,20 @@ type LarkAdapter struct {
 	store taskdomain.Store
 }
 
-var _ lark.TaskStore = (*LarkAdapter)(nil)
+var (
+	_ lark.TaskStore           = (*LarkAdapter)(nil)
+	_ lark.ReadReceiptRecorder = (*LarkAdapter)(nil)
+	_ lark.TaskDigestStore     = (*LarkAdapter)(nil)
+)
 
-const larkMergeStatusMetaKey = "merge_status"
+const (
+	larkMergeStatusMetaKey = "merge_status"
+	larkReadByMetaKey      = "read_by"
+	larkReadAtMetaKey      = "read_at"
+	larkReadReceiptReason  = "message_read"
+	larkDigestedAtMetaKey  = "digested_at"
+	larkDigestedReason     = "task_digest"
+)
 
 // NewLarkAdapter wraps a unified task store to satisfy the Lark gateway's TaskStore port.
 func NewLarkAdapter(store taskdomain.Store) *LarkAdapter {
@@ -156,6 +168,85 @@ func (a *LarkAdapter) MarkStaleRunning(ctx context.Context, reason string) error
 	return nil
 }
 
+// RecordReadReceipt appends a same-status transition so the read receipt
+// shows up on the task timeline.
+func (a *LarkAdapter) RecordReadReceipt(ctx context.Context, taskID, readerID string, readAt time.Time) error {
+	t, err := a.store.Get(ctx, taskID)
+	if err != nil {
+		return err
+	}
+	return a.store.SetStatus(ctx, taskID, t.Status,
+		taskdomain.WithTransitionReason(larkReadReceiptReason),
+		taskdomain.WithTransitionMeta(map[string]any{
+			larkReadByMetaKey: readerID,
+			larkReadAtMetaKey: readAt.UTC().Format(time.RFC3339),
+		}),
+	)
+}
+
+// ListUndigested returns completed and failed Lark tasks not yet stamped by
+// MarkDigested, oldest completion first.
+func (a *LarkAdapter) ListUndigested(ctx context.Context, chatID string) ([]lark.TaskRecord, error) {
+	tasks, err := a.store.ListByStatus(ctx, taskdomain.StatusCompleted, taskdomain.StatusFailed)
+	if err != nil {
+		return nil, err
+	}
+	chatID = strings.TrimSpace(chatID)
+	var records []lark.TaskRecord
+	for _, t := range tasks {
+		if t == nil || t.Channel != "lark" || (chatID != "" && t.ChatID != chatID) {
+			continue
+		}
+		rec := domainToLarkRecord(t)
+		if rec.DigestedAt.IsZero() {
+			transitions, err := a.store.Transitions(ctx, t.TaskID)
+			if err != nil {
+				return nil, err
+			}
+			if hasDigestTransition(transitions) {
+				continue
+			}
+			records = append(records, rec)
+		}
+	}
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].CompletedAt.Before(records[j].CompletedAt)
+	})
+	return records, nil
+}
+
+// MarkDigested appends a same-status transition per task recording that a
+// digest reported it, like RecordReadReceipt.
+func (a *LarkAdapter) MarkDigested(ctx context.Context, taskIDs []string, at time.Time) error {
+	for _, taskID := range taskIDs {
+		t, err := a.store.Get(ctx, taskID)
+		if err != nil {
+			if errors.Is(err, taskdomain.ErrTaskNotFound) {
+				continue
+			}
+			return err
+		}
+		if err := a.store.SetStatus(ctx, taskID, t.Status,
+			taskdomain.WithTransitionReason(larkDigestedReason),
+			taskdomain.WithTransitionMeta(map[string]any{
+				larkDigestedAtMetaKey: at.UTC().Format(time.RFC3339),
+			}),
+		); err != nil && !errors.Is(err, taskdomain.ErrTaskNotFound) {
+			return err
+		}
+	}
+	return nil
+}
+
+func hasDigestTransition(transitions []taskdomain.Transition) bool {
+	for _, tr := range transitions {
+		if tr.Reason == larkDigestedReason {
+			return true
+		}
+	}
+	return false
+}
+
 // SetBridgeMeta persists bridge subprocess metadata for resilience.
 // The info parameter is expected to implement BridgeInfoProvider, or be a
 // map[string]any with "pid" and "output_file" keys.
@@ -238,6 +329,11 @@ func domainToLarkRecord(t *taskdomain.Task) lark.TaskRecord {
 		if mergeStatus, ok := t.Metadata[larkMergeStatusMetaKey]; ok {
 			rec.MergeStatus = mergeStatus
 		}
+		if raw, ok := t.Metadata[larkDigestedAtMetaKey]; ok {
+			if at, err := time.Parse(time.RFC3339, raw); err == nil {
+				rec.DigestedAt = at
+			}
+		}
 	}
 	if t.CompletedAt != nil {
 		rec.CompletedAt = *t.CompletedAt
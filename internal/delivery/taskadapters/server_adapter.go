@@ -67,6 +67,36 @@ func (a *ServerAdapter) Get(ctx context.Context, taskID string) (*ports.Task, er
 	return domainToServerTask(t), nil
 }
 
+// GetWithRevision retrieves a task together with its current revision.
+func (a *ServerAdapter) GetWithRevision(ctx context.Context, taskID string) (*ports.Task, int64, error) {
+	t, rev, err := a.store.GetWithRevision(ctx, taskID)
+	if err != nil {
+		return nil, 0, err
+	}
+	return domainToServerTask(t), rev, nil
+}
+
+// CompareAndSwap writes the server-visible fields of task onto the unified
+// record when its revision equals expected. Fields the server view does not
+// carry (channel binding, bridge metadata, ...) are preserved.
+func (a *ServerAdapter) CompareAndSwap(ctx context.Context, taskID string, expected int64, task *ports.Task) (int64, error) {
+	current, rev, err := a.store.GetWithRevision(ctx, taskID)
+	if err != nil {
+		return 0, err
+	}
+	if rev != expected {
+		return 0, fmt.Errorf("task %s: expected revision %d, have %d: %w", taskID, expected, rev, ports.ErrTaskConflict)
+	}
+	if err := applyServerTask(current, task); err != nil {
+		return 0, err
+	}
+	newRev, err := a.store.CompareAndSwap(ctx, taskID, expected, current)
+	if errors.Is(err, taskdomain.ErrConflict) {
+		return 0, fmt.Errorf("%w: %w", ports.ErrTaskConflict, err)
+	}
+	return newRev, err
+}
+
 // List returns tasks with pagination.
 func (a *ServerAdapter) List(ctx context.Context, limit int, offset int) ([]*ports.Task, int, error) {
 	tasks, total, err := a.store.List(ctx, limit, offset)
@@ -140,6 +170,11 @@ func (a *ServerAdapter) UpdateProgress(ctx context.Context, taskID string, itera
 	return a.store.UpdateProgress(ctx, taskID, iteration, tokensUsed, 0)
 }
 
+// SetQueued records when the task entered the execution queue.
+func (a *ServerAdapter) SetQueued(ctx context.Context, taskID string, priority int, queuedAt time.Time) error {
+	return a.store.SetQueued(ctx, taskID, priority, queuedAt)
+}
+
 // SetTerminationReason sets the termination reason for a task.
 func (a *ServerAdapter) SetTerminationReason(ctx context.Context, taskID string, reason ports.TerminationReason) error {
 	domainReason := serverTermToDomain(reason)
@@ -193,10 +228,12 @@ func domainToServerTask(t *taskdomain.Task) *ports.Task {
 		Status:            domainStatusToServer(t.Status),
 		Description:       t.Description,
 		CreatedAt:         t.CreatedAt,
+		QueuedAt:          t.QueuedAt,
 		StartedAt:         t.StartedAt,
 		CompletedAt:       t.CompletedAt,
 		Error:             t.Error,
 		TerminationReason: domainTermToServer(t.TerminationReason),
+		Priority:          t.Priority,
 		CurrentIteration:  t.CurrentIteration,
 		TotalIterations:   t.TotalIterations,
 		TokensUsed:        t.TokensUsed,
@@ -204,6 +241,7 @@ func domainToServerTask(t *taskdomain.Task) *ports.Task {
 		Metadata:          t.Metadata,
 		AgentPreset:       t.AgentPreset,
 		ToolPreset:        t.ToolPreset,
+		Revision:          t.Revision,
 	}
 
 	if t.ResultJSON != nil {
@@ -216,6 +254,32 @@ func domainToServerTask(t *taskdomain.Task) *ports.Task {
 	return pt
 }
 
+// applyServerTask copies the mutable fields of a server task onto a unified record.
+func applyServerTask(dst *taskdomain.Task, src *ports.Task) error {
+	dst.SessionID = src.SessionID
+	dst.ParentTaskID = src.ParentTaskID
+	dst.Status = serverStatusToDomain(src.Status)
+	dst.QueuedAt = src.QueuedAt
+	dst.StartedAt = src.StartedAt
+	dst.CompletedAt = src.CompletedAt
+	dst.Error = src.Error
+	dst.TerminationReason = serverTermToDomain(src.TerminationReason)
+	dst.Priority = src.Priority
+	dst.CurrentIteration = src.CurrentIteration
+	dst.TotalIterations = src.TotalIterations
+	dst.TokensUsed = src.TokensUsed
+	dst.Metadata = src.Metadata
+	if src.Result != nil {
+		resultJSON, err := json.Marshal(src.Result)
+		if err != nil {
+			return fmt.Errorf("marshal result: %w", err)
+		}
+		dst.ResultJSON = resultJSON
+		dst.AnswerPreview = src.Result.Answer
+	}
+	return nil
+}
+
 func domainStatusToServer(s taskdomain.Status) ports.TaskStatus {
 	switch s {
 	case taskdomain.StatusPending:
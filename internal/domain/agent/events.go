@@ -68,6 +68,14 @@ type EventData struct {
 	// --- Node output summary ------------------------------------------------
 	ToolCallCount int `json:"tool_call_count,omitempty"`
 
+	// --- Iteration usage ----------------------------------------------------
+	// SourceModel names the model; CostUSD carries the iteration cost.
+	PromptTokens      int     `json:"prompt_tokens,omitempty"`
+	CompletionTokens  int     `json:"completion_tokens,omitempty"`
+	CachedTokens      int     `json:"cached_tokens,omitempty"`
+	CumulativeTokens  int     `json:"cumulative_tokens,omitempty"`
+	CumulativeCostUSD float64 `json:"cumulative_cost_usd,omitempty"`
+
 	// --- Lifecycle updated --------------------------------------------------
 	WorkflowID        string                 `json:"workflow_id,omitempty"`
 	WorkflowEventType workflow.EventType     `json:"workflow_event_type,omitempty"`
@@ -77,6 +77,24 @@ func NewNodeOutputSummaryEvent(base BaseEvent, iteration int, content string, to
 	}
 }
 
+// NewIterationUsageEvent constructs an iteration usage event from the token
+// usage the provider reported for one LLM round. Cost fields are filled in
+// by the coordinator, which owns model pricing.
+func NewIterationUsageEvent(base BaseEvent, iteration int, model string, usage ports.TokenUsage) *Event {
+	return &Event{
+		BaseEvent: base,
+		Kind:      types.EventIterationUsage,
+		Data: EventData{
+			Iteration:        iteration,
+			SourceModel:      model,
+			PromptTokens:     usage.PromptTokens,
+			CompletionTokens: usage.CompletionTokens,
+			CachedTokens:     usage.CachedTokens,
+			TokensUsed:       usage.TotalTokens,
+		},
+	}
+}
+
 // NewLifecycleUpdatedEvent constructs a workflow lifecycle updated event.
 func NewLifecycleUpdatedEvent(base BaseEvent, workflowID string, wfEventType workflow.EventType, phase workflow.WorkflowPhase, node *workflow.NodeSnapshot, wf *workflow.WorkflowSnapshot) *Event {
 	return &Event{
@@ -136,3 +136,21 @@ func TestNewNodeFailedEvent_NilError(t *testing.T) {
 		t.Errorf("expected empty error string, got %s", e.Data.ErrorStr)
 	}
 }
+
+func TestNewIterationUsageEvent(t *testing.T) {
+	base := NewBaseEvent(agent.LevelCore, "s", "r", "", time.Now())
+	usage := ports.TokenUsage{PromptTokens: 1200, CompletionTokens: 300, TotalTokens: 1500, CachedTokens: 800}
+	e := NewIterationUsageEvent(base, 2, "gpt-4o", usage)
+	if e.Kind != types.EventIterationUsage {
+		t.Errorf("wrong kind: %s", e.Kind)
+	}
+	if e.Data.Iteration != 2 || e.Data.SourceModel != "gpt-4o" {
+		t.Errorf("wrong iteration/model: %d %s", e.Data.Iteration, e.Data.SourceModel)
+	}
+	if e.Data.PromptTokens != 1200 || e.Data.CompletionTokens != 300 || e.Data.CachedTokens != 800 || e.Data.TokensUsed != 1500 {
+		t.Errorf("wrong token fields: %+v", e.Data)
+	}
+	if e.Data.CostUSD != 0 || e.Data.CumulativeCostUSD != 0 {
+		t.Error("cost should be left for the coordinator to fill")
+	}
+}
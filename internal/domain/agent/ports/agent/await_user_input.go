@@ -2,6 +2,7 @@ package agent
 
 import (
 	"strings"
+	"time"
 
 	core "alex/internal/domain/agent/ports"
 )
@@ -11,13 +12,38 @@ const (
 	awaitUserQuestionKey    = "question_to_user"
 	awaitUserMessageKey     = "message"
 	awaitUserOptionsKey     = "options"
+	awaitUserTimeoutKey     = "input_timeout_seconds"
+	awaitUserOnTimeoutKey   = "on_timeout"
+	awaitUserDefaultKey     = "default_answer"
 	awaitUserInputTrueValue = "true"
 )
 
+// Fallbacks a pending user-input request declares for when the user never
+// answers.
+const (
+	AwaitTimeoutProceedDefault = "proceed_default"
+	AwaitTimeoutAbort          = "abort"
+	AwaitTimeoutRemind         = "remind"
+)
+
 // AwaitUserInputPrompt captures the extracted await-user-input payload.
+// Timeout is zero when the request did not declare one.
 type AwaitUserInputPrompt struct {
-	Question string
-	Options  []string
+	Question      string
+	Options       []string
+	Timeout       time.Duration
+	OnTimeout     string
+	DefaultAnswer string
+}
+
+// IsValidAwaitTimeoutPolicy reports whether policy is a known on_timeout value.
+func IsValidAwaitTimeoutPolicy(policy string) bool {
+	switch policy {
+	case AwaitTimeoutProceedDefault, AwaitTimeoutAbort, AwaitTimeoutRemind:
+		return true
+	default:
+		return false
+	}
 }
 
 // ExtractAwaitUserInputPrompt scans messages for the most recent tool result
@@ -48,8 +74,11 @@ func ExtractAwaitUserInputPrompt(messages []core.Message) (AwaitUserInputPrompt,
 				return AwaitUserInputPrompt{}, false
 			}
 			return AwaitUserInputPrompt{
-				Question: question,
-				Options:  toolResultOptionsMeta(result, awaitUserOptionsKey),
+				Question:      question,
+				Options:       toolResultOptionsMeta(result, awaitUserOptionsKey),
+				Timeout:       toolResultSecondsMeta(result, awaitUserTimeoutKey),
+				OnTimeout:     toolResultStringMeta(result, awaitUserOnTimeoutKey),
+				DefaultAnswer: toolResultStringMeta(result, awaitUserDefaultKey),
 			}, true
 		}
 	}
@@ -99,6 +128,27 @@ func toolResultStringMeta(result core.ToolResult, key string) string {
 	return strings.TrimSpace(value)
 }
 
+func toolResultSecondsMeta(result core.ToolResult, key string) time.Duration {
+	if result.Metadata == nil {
+		return 0
+	}
+	var seconds float64
+	switch value := result.Metadata[key].(type) {
+	case int:
+		seconds = float64(value)
+	case int64:
+		seconds = float64(value)
+	case float64:
+		seconds = value
+	default:
+		return 0
+	}
+	if seconds <= 0 {
+		return 0
+	}
+	return time.Duration(seconds * float64(time.Second))
+}
+
 func toolResultOptionsMeta(result core.ToolResult, key string) []string {
 	if result.Metadata == nil {
 		return nil
@@ -2,6 +2,7 @@ package agent
 
 import (
 	"testing"
+	"time"
 
 	core "alex/internal/domain/agent/ports"
 )
@@ -151,3 +152,30 @@ func TestExtractAwaitUserInputQuestion(t *testing.T) {
 		}
 	})
 }
+
+func TestExtractAwaitUserInputPromptTimeout(t *testing.T) {
+	messages := []core.Message{{
+		ToolResults: []core.ToolResult{{
+			Metadata: map[string]any{
+				"needs_user_input":      true,
+				"message":               "Approve the release?",
+				"input_timeout_seconds": float64(600), // JSON round-trip form
+				"on_timeout":            AwaitTimeoutProceedDefault,
+				"default_answer":        "approve",
+			},
+		}},
+	}}
+
+	prompt, ok := ExtractAwaitUserInputPrompt(messages)
+	if !ok {
+		t.Fatal("expected prompt to be found")
+	}
+	if prompt.Timeout != 10*time.Minute || prompt.OnTimeout != AwaitTimeoutProceedDefault || prompt.DefaultAnswer != "approve" {
+		t.Fatalf("unexpected timeout fields: %+v", prompt)
+	}
+
+	messages[0].ToolResults[0].Metadata["input_timeout_seconds"] = "soon"
+	if prompt, _ := ExtractAwaitUserInputPrompt(messages); prompt.Timeout != 0 {
+		t.Fatalf("non-numeric timeout must be ignored, got %s", prompt.Timeout)
+	}
+}
@@ -52,6 +52,7 @@ type ContextWindowConfig struct {
 	ReplyTagsEnabled   bool
 	Skills             SkillsConfig
 	OKRContext         string // Pre-rendered OKR goals section for system prompt injection
+	PreferencesContext string // Pre-rendered user preferences block for system prompt injection
 	Unattended         bool   // If true, agent runs autonomously without user interaction
 	Channel            string // Delivery channel (e.g. "lark", "cli", "web") for format-aware prompts
 	ChannelHint        string // Pre-rendered channel-specific formatting hint (replaces hardcoded channel checks)
@@ -60,12 +61,19 @@ type ContextWindowConfig struct {
 
 // ContextWindow exposes the layered context returned by the manager.
 type ContextWindow struct {
-	SessionID    string         `json:"session_id"`
-	Messages     []core.Message `json:"messages"`
-	SystemPrompt string         `json:"system_prompt"`
-	Static       StaticContext  `json:"static"`
-	Dynamic      DynamicContext `json:"dynamic"`
-	Meta         MetaContext    `json:"meta"`
+	SessionID       string               `json:"session_id"`
+	Messages        []core.Message       `json:"messages"`
+	SystemPrompt    string               `json:"system_prompt"`
+	PromptBreakdown []PromptSectionUsage `json:"prompt_breakdown,omitempty"`
+	Static          StaticContext        `json:"static"`
+	Dynamic         DynamicContext       `json:"dynamic"`
+	Meta            MetaContext          `json:"meta"`
+}
+
+// PromptSectionUsage attributes estimated system prompt tokens to a named section.
+type PromptSectionUsage struct {
+	Section string `json:"section"`
+	Tokens  int    `json:"tokens"`
 }
 
 // ContextWindowPreview bundles the constructed window with metadata useful for
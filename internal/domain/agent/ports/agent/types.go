@@ -27,6 +27,7 @@ type TurnRecorder interface {
 // ServiceBundle contains all dependencies required by the domain engine.
 type ServiceBundle struct {
 	LLM          llm.StreamingLLMClient
+	LLMProvider  string // Optional: provider behind LLM, recorded on trace spans
 	ToolExecutor tools.ToolRegistry
 	ToolLimiter  tools.ToolExecutionLimiter
 	Parser       FunctionCallParser
@@ -172,4 +173,14 @@ type TaskResult struct {
 	Important      map[string]core.ImportantNote
 	Workflow       *workflow.WorkflowSnapshot
 	Attachments    map[string]core.Attachment // Resolved attachments for the final answer
+	OutputPolicy   *OutputPolicyMark          // Set when the workspace output policy changed Answer
+}
+
+// OutputPolicyMark records how the workspace output policy changed a final
+// answer. The original text is kept in the policy audit journal under AuditID.
+type OutputPolicyMark struct {
+	Workspace string   `json:"workspace"`
+	Outcome   string   `json:"outcome"`
+	RuleIDs   []string `json:"rule_ids"`
+	AuditID   string   `json:"audit_id,omitempty"`
 }
@@ -2,11 +2,19 @@ package agent
 
 import "context"
 
+// SynthesizedInputMetadataKey marks a conversation message whose user input
+// was generated by the channel (for example on an await-input timeout) rather
+// than written by the user.
+const SynthesizedInputMetadataKey = "synthesized_input"
+
 // UserInput represents a message injected by a user into a running ReAct loop.
 type UserInput struct {
 	Content   string
 	SenderID  string
 	MessageID string
+	// Synthesized is true when the channel generated the input on the user's
+	// behalf instead of relaying a user message.
+	Synthesized bool
 }
 
 type userInputChKey struct{}
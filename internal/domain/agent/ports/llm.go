@@ -32,6 +32,42 @@ type CompletionResponse struct {
 	Metadata   map[string]any `json:"metadata,omitempty"`
 }
 
+// Response metadata keys written by the LLM client stack. ResponseMetaProvider
+// and ResponseMetaModel name the provider that actually served the call;
+// ResponseMetaFailoverFrom ("provider/model") is set only when a fallback
+// provider answered instead of the requested one. ResponseMetaCache is
+// ResponseCacheHit or ResponseCacheMiss when the response cache was consulted.
+const (
+	ResponseMetaProvider     = "llm_provider"
+	ResponseMetaModel        = "llm_model"
+	ResponseMetaFailoverFrom = "llm_failover_from"
+	ResponseMetaCache        = "llm_cache"
+
+	ResponseCacheHit  = "hit"
+	ResponseCacheMiss = "miss"
+)
+
+// ServedBy returns the provider and model recorded in the response metadata,
+// or empty strings when the client stack did not record them.
+func (r *CompletionResponse) ServedBy() (provider, model string) {
+	if r == nil || r.Metadata == nil {
+		return "", ""
+	}
+	provider, _ = r.Metadata[ResponseMetaProvider].(string)
+	model, _ = r.Metadata[ResponseMetaModel].(string)
+	return provider, model
+}
+
+// CacheStatus returns ResponseCacheHit, ResponseCacheMiss, or an empty string
+// when the response did not go through the response cache.
+func (r *CompletionResponse) CacheStatus() string {
+	if r == nil || r.Metadata == nil {
+		return ""
+	}
+	status, _ := r.Metadata[ResponseMetaCache].(string)
+	return status
+}
+
 // Thinking captures model-generated reasoning content across providers.
 type Thinking struct {
 	Parts []ThinkingPart `json:"parts,omitempty"`
@@ -63,6 +99,9 @@ type TokenUsage struct {
 	PromptTokens     int `json:"prompt_tokens"`
 	CompletionTokens int `json:"completion_tokens"`
 	TotalTokens      int `json:"total_tokens"`
+	// CachedTokens is the part of PromptTokens served from the provider's
+	// prompt cache, when the provider reports it.
+	CachedTokens int `json:"cached_tokens,omitempty"`
 }
 
 // Message represents a conversation message
@@ -148,13 +148,12 @@ func newUIAskUserExecutor() tools.ToolExecutor {
 }
 
 func newScriptedLLMClient(responses ...ports.CompletionResponse) *MockLLMClient {
-	if len(responses) == 0 {
-		panic("newScriptedLLMClient requires at least one completion response")
-	}
-
 	callCount := 0
 	return &MockLLMClient{
 		CompleteFunc: func(ctx context.Context, req ports.CompletionRequest) (*ports.CompletionResponse, error) {
+			if len(responses) == 0 {
+				return nil, fmt.Errorf("scripted LLM client has no completion responses")
+			}
 			callCount++
 
 			idx := callCount - 1
@@ -13,12 +13,14 @@ type CostTracker interface {
 	GetMonthlyCost(ctx context.Context, year int, month int) (*CostSummary, error)
 	GetDateRangeCost(ctx context.Context, start, end time.Time) (*CostSummary, error)
 	Export(ctx context.Context, format ExportFormat, filter ExportFilter) ([]byte, error)
+	QueryUsage(ctx context.Context, query UsageQuery) (*UsageReport, error)
 }
 
 // UsageRecord represents a single LLM usage event
 type UsageRecord struct {
 	ID              string         `json:"id"`
 	SessionID       string         `json:"session_id"`
+	UserID          string         `json:"user_id,omitempty"`
 	Model           string         `json:"model"`
 	Provider        string         `json:"provider"`
 	InputTokens     int            `json:"input_tokens"`
@@ -29,6 +31,8 @@ type UsageRecord struct {
 	TotalCost       float64        `json:"total_cost"`
 	Timestamp       time.Time      `json:"timestamp"`
 	RequestMetadata map[string]any `json:"request_metadata,omitempty"`
+	// CacheStatus is "hit" or "miss" when the LLM response cache was consulted.
+	CacheStatus string `json:"cache_status,omitempty"`
 }
 
 // CostSummary aggregates cost and usage data
@@ -38,6 +42,8 @@ type CostSummary struct {
 	OutputTokens int                `json:"output_tokens"`
 	TotalTokens  int                `json:"total_tokens"`
 	RequestCount int                `json:"request_count"`
+	CacheHits    int                `json:"cache_hits"`
+	CacheMisses  int                `json:"cache_misses"`
 	ByModel      map[string]float64 `json:"by_model"`
 	ByProvider   map[string]float64 `json:"by_provider"`
 	StartTime    time.Time          `json:"start_time"`
@@ -52,6 +58,8 @@ type SessionStats struct {
 	OutputTokens int                `json:"output_tokens"`
 	TotalTokens  int                `json:"total_tokens"`
 	RequestCount int                `json:"request_count"`
+	CacheHits    int                `json:"cache_hits"`
+	CacheMisses  int                `json:"cache_misses"`
 	ByModel      map[string]float64 `json:"by_model,omitempty"`
 	ByProvider   map[string]float64 `json:"by_provider,omitempty"`
 	FirstRequest time.Time          `json:"first_request"`
@@ -76,6 +84,67 @@ type ExportFilter struct {
 	EndDate   time.Time
 }
 
+// UsageAggregate is the usage of one user, session and model on one UTC day.
+type UsageAggregate struct {
+	Day          string  `json:"day"` // YYYY-MM-DD in UTC
+	UserID       string  `json:"user_id,omitempty"`
+	SessionID    string  `json:"session_id,omitempty"`
+	Model        string  `json:"model"`
+	Provider     string  `json:"provider,omitempty"`
+	InputTokens  int     `json:"input_tokens"`
+	OutputTokens int     `json:"output_tokens"`
+	TotalTokens  int     `json:"total_tokens"`
+	TotalCost    float64 `json:"total_cost"`
+	RequestCount int     `json:"request_count"`
+}
+
+// UsageGroupBy selects how a usage report breaks its totals down.
+type UsageGroupBy string
+
+const (
+	UsageGroupByNone    UsageGroupBy = ""
+	UsageGroupByDay     UsageGroupBy = "day"
+	UsageGroupByModel   UsageGroupBy = "model"
+	UsageGroupByUser    UsageGroupBy = "user"
+	UsageGroupBySession UsageGroupBy = "session"
+)
+
+// UsageQuery filters aggregated usage. From is inclusive and To exclusive;
+// both are truncated to UTC days. Empty string filters match everything.
+type UsageQuery struct {
+	UserID    string
+	SessionID string
+	Model     string
+	From      time.Time
+	To        time.Time
+	GroupBy   UsageGroupBy
+}
+
+// UsageTotals sums usage over a set of aggregates.
+type UsageTotals struct {
+	InputTokens  int     `json:"input_tokens"`
+	OutputTokens int     `json:"output_tokens"`
+	TotalTokens  int     `json:"total_tokens"`
+	TotalCost    float64 `json:"total_cost"`
+	RequestCount int     `json:"request_count"`
+}
+
+// UsageGroup is one row of a grouped usage report.
+type UsageGroup struct {
+	Key string `json:"key"`
+	UsageTotals
+}
+
+// UsageReport answers a UsageQuery. Groups are sorted by key and empty when
+// the query has no GroupBy.
+type UsageReport struct {
+	From    time.Time    `json:"from"`
+	To      time.Time    `json:"to"`
+	GroupBy UsageGroupBy `json:"group_by,omitempty"`
+	Totals  UsageTotals  `json:"totals"`
+	Groups  []UsageGroup `json:"groups"`
+}
+
 // ModelPricing holds pricing information per 1K tokens
 type ModelPricing struct {
 	InputPer1K  float64
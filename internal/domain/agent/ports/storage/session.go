@@ -33,6 +33,7 @@ type SessionStore interface {
 type SessionListItem struct {
 	ID        string
 	Title     string
+	Tags      []string
 	CreatedAt time.Time
 	UpdatedAt time.Time
 }
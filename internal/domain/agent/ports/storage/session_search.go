@@ -0,0 +1,280 @@
+package storage
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	core "alex/internal/domain/agent/ports"
+)
+
+const (
+	// DefaultSessionSearchLimit is the page size used when SessionQuery.Limit is unset.
+	DefaultSessionSearchLimit = 20
+	// MaxSessionSearchLimit caps SessionQuery.Limit.
+	MaxSessionSearchLimit = 100
+
+	maxSearchSnippets     = 3
+	searchSnippetContext  = 40
+	searchHighlightMarker = "**"
+)
+
+// SessionQuery filters sessions by content and metadata. All set fields must
+// match. Text is split into whitespace-separated keywords; each keyword must
+// occur (case-insensitive substring) in the session's user messages or final
+// answers.
+type SessionQuery struct {
+	Text string
+	// ChannelPrefix matches the session ID prefix, e.g. "lark" for "lark-…".
+	ChannelPrefix string
+	// UserID matches the session's "user_id" metadata.
+	UserID string
+	// Since and Until bound the session's last update time (inclusive).
+	Since  time.Time
+	Until  time.Time
+	Limit  int
+	Offset int
+}
+
+// SessionSearchHit is one matching session with highlighted snippets.
+type SessionSearchHit struct {
+	SessionID string
+	Title     string
+	// Snippets are excerpts around matches with keywords wrapped in "**".
+	Snippets  []string
+	Matches   int
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// SessionSearchResult is one page of hits plus the total match count.
+type SessionSearchResult struct {
+	Hits  []SessionSearchHit
+	Total int
+}
+
+// SessionSearcher is an optional SessionStore extension for content search.
+type SessionSearcher interface {
+	SearchSessions(ctx context.Context, query SessionQuery) (SessionSearchResult, error)
+}
+
+// Normalize trims the query and clamps pagination to sane bounds.
+func (q SessionQuery) Normalize() SessionQuery {
+	q.Text = strings.TrimSpace(q.Text)
+	q.ChannelPrefix = strings.TrimSpace(q.ChannelPrefix)
+	q.UserID = strings.TrimSpace(q.UserID)
+	if q.Limit <= 0 {
+		q.Limit = DefaultSessionSearchLimit
+	}
+	if q.Limit > MaxSessionSearchLimit {
+		q.Limit = MaxSessionSearchLimit
+	}
+	if q.Offset < 0 {
+		q.Offset = 0
+	}
+	return q
+}
+
+// Keywords returns the lowercased, de-duplicated query keywords.
+func (q SessionQuery) Keywords() []string {
+	seen := make(map[string]struct{})
+	var keywords []string
+	for _, field := range strings.Fields(strings.ToLower(q.Text)) {
+		if _, ok := seen[field]; ok {
+			continue
+		}
+		seen[field] = struct{}{}
+		keywords = append(keywords, field)
+	}
+	return keywords
+}
+
+// MatchesMetadata reports whether a session passes the non-text filters.
+func (q SessionQuery) MatchesMetadata(sessionID string, metadata map[string]string, updatedAt time.Time) bool {
+	if q.ChannelPrefix != "" && !strings.HasPrefix(sessionID, q.ChannelPrefix) {
+		return false
+	}
+	if q.UserID != "" && strings.TrimSpace(metadata["user_id"]) != q.UserID {
+		return false
+	}
+	if !q.Since.IsZero() && updatedAt.Before(q.Since) {
+		return false
+	}
+	if !q.Until.IsZero() && updatedAt.After(q.Until) {
+		return false
+	}
+	return true
+}
+
+// SearchableText returns the text of a message that session search covers:
+// user input and final assistant answers. Tool traffic, system prompts and
+// intermediate assistant turns that only call tools are skipped.
+func SearchableText(msg core.Message) (string, bool) {
+	content := strings.TrimSpace(msg.Content)
+	if content == "" {
+		return "", false
+	}
+	switch strings.ToLower(strings.TrimSpace(msg.Role)) {
+	case "user":
+		if msg.Source == core.MessageSourceUnknown || msg.Source == core.MessageSourceUserInput || msg.Source == core.MessageSourceUserHistory {
+			return content, true
+		}
+	case "assistant":
+		if len(msg.ToolCalls) > 0 {
+			return "", false
+		}
+		if msg.Source == core.MessageSourceUnknown || msg.Source == core.MessageSourceAssistantReply {
+			return content, true
+		}
+	}
+	return "", false
+}
+
+// MatchSessionTexts checks that every keyword occurs in texts and returns the
+// number of keyword occurrences plus up to three highlighted snippets. With no
+// keywords every session matches with no snippets.
+func MatchSessionTexts(texts []string, keywords []string) (int, []string, bool) {
+	if len(keywords) == 0 {
+		return 0, nil, true
+	}
+	lowered := make([]string, len(texts))
+	for i, text := range texts {
+		lowered[i] = strings.ToLower(text)
+	}
+	matches := 0
+	for _, keyword := range keywords {
+		count := 0
+		for _, text := range lowered {
+			count += strings.Count(text, keyword)
+		}
+		if count == 0 {
+			return 0, nil, false
+		}
+		matches += count
+	}
+
+	var snippets []string
+	for i, text := range texts {
+		if len(snippets) >= maxSearchSnippets {
+			break
+		}
+		if snippet, ok := highlightSnippet(text, lowered[i], keywords); ok {
+			snippets = append(snippets, snippet)
+		}
+	}
+	return matches, snippets, true
+}
+
+// SortSearchHits orders hits by match count, then most recent update.
+func SortSearchHits(hits []SessionSearchHit) {
+	sort.SliceStable(hits, func(i, j int) bool {
+		if hits[i].Matches != hits[j].Matches {
+			return hits[i].Matches > hits[j].Matches
+		}
+		return hits[i].UpdatedAt.After(hits[j].UpdatedAt)
+	})
+}
+
+// PageSearchHits applies the query's offset and limit to sorted hits.
+func PageSearchHits(hits []SessionSearchHit, query SessionQuery) SessionSearchResult {
+	result := SessionSearchResult{Total: len(hits), Hits: []SessionSearchHit{}}
+	if query.Offset >= len(hits) {
+		return result
+	}
+	end := query.Offset + query.Limit
+	if end > len(hits) {
+		end = len(hits)
+	}
+	result.Hits = hits[query.Offset:end]
+	return result
+}
+
+type matchSpan struct{ start, end int }
+
+// highlightSnippet cuts a window around the first keyword match in text and
+// wraps every match inside it in highlight markers. lowered must be
+// strings.ToLower(text); match offsets are only reused when lowering kept
+// the byte length unchanged.
+func highlightSnippet(text, lowered string, keywords []string) (string, bool) {
+	if len(lowered) != len(text) {
+		text = lowered
+	}
+	var spans []matchSpan
+	for _, keyword := range keywords {
+		for from := 0; from < len(lowered); {
+			idx := strings.Index(lowered[from:], keyword)
+			if idx < 0 {
+				break
+			}
+			start := from + idx
+			spans = append(spans, matchSpan{start: start, end: start + len(keyword)})
+			from = start + len(keyword)
+		}
+	}
+	if len(spans) == 0 {
+		return "", false
+	}
+	spans = mergeSpans(spans)
+
+	windowStart := backRunes(text, spans[0].start, searchSnippetContext)
+	windowEnd := forwardRunes(text, spans[0].end, searchSnippetContext)
+
+	var b strings.Builder
+	if windowStart > 0 {
+		b.WriteString("…")
+	}
+	cursor := windowStart
+	for _, span := range spans {
+		if span.start >= windowEnd {
+			break
+		}
+		end := span.end
+		if end > windowEnd {
+			windowEnd = end
+		}
+		b.WriteString(text[cursor:span.start])
+		b.WriteString(searchHighlightMarker)
+		b.WriteString(text[span.start:end])
+		b.WriteString(searchHighlightMarker)
+		cursor = end
+	}
+	b.WriteString(text[cursor:windowEnd])
+	if windowEnd < len(text) {
+		b.WriteString("…")
+	}
+	return strings.Join(strings.Fields(b.String()), " "), true
+}
+
+func mergeSpans(spans []matchSpan) []matchSpan {
+	sort.Slice(spans, func(i, j int) bool { return spans[i].start < spans[j].start })
+	merged := spans[:1]
+	for _, span := range spans[1:] {
+		last := &merged[len(merged)-1]
+		if span.start <= last.end {
+			if span.end > last.end {
+				last.end = span.end
+			}
+			continue
+		}
+		merged = append(merged, span)
+	}
+	return merged
+}
+
+func backRunes(text string, pos, n int) int {
+	for ; n > 0 && pos > 0; n-- {
+		_, size := utf8.DecodeLastRuneInString(text[:pos])
+		pos -= size
+	}
+	return pos
+}
+
+func forwardRunes(text string, pos, n int) int {
+	for ; n > 0 && pos < len(text); n-- {
+		_, size := utf8.DecodeRuneInString(text[pos:])
+		pos += size
+	}
+	return pos
+}
@@ -0,0 +1,54 @@
+package storage
+
+import (
+	"strings"
+	"testing"
+
+	core "alex/internal/domain/agent/ports"
+)
+
+func TestMatchSessionTexts_RequiresEveryKeywordAndHighlights(t *testing.T) {
+	texts := []string{
+		strings.Repeat("lead ", 20) + "Deploy the API gateway today",
+		"gateway notes",
+	}
+	matches, snippets, ok := MatchSessionTexts(texts, SessionQuery{Text: "gateway deploy"}.Keywords())
+	if !ok || matches != 3 {
+		t.Fatalf("expected 3 matches, got %d ok=%v", matches, ok)
+	}
+	if len(snippets) != 2 {
+		t.Fatalf("expected a snippet per matching text, got %q", snippets)
+	}
+	if !strings.HasPrefix(snippets[0], "…") || !strings.Contains(snippets[0], "**Deploy** the API **gateway** today") {
+		t.Fatalf("unexpected snippet %q", snippets[0])
+	}
+
+	if _, _, ok := MatchSessionTexts(texts, []string{"gateway", "rollback"}); ok {
+		t.Fatal("expected a missing keyword to reject the session")
+	}
+}
+
+func TestSearchableText_SkipsToolTrafficAndPrompts(t *testing.T) {
+	cases := []struct {
+		msg  core.Message
+		want bool
+	}{
+		{core.Message{Role: "user", Content: "hi", Source: core.MessageSourceUserInput}, true},
+		{core.Message{Role: "assistant", Content: "done"}, true},
+		{core.Message{Role: "assistant", Content: "calling", ToolCalls: []core.ToolCall{{ID: "1"}}}, false},
+		{core.Message{Role: "user", Content: "ctx", Source: core.MessageSourceProactive}, false},
+		{core.Message{Role: "system", Content: "rules", Source: core.MessageSourceSystemPrompt}, false},
+	}
+	for _, tc := range cases {
+		if _, got := SearchableText(tc.msg); got != tc.want {
+			t.Fatalf("SearchableText(%+v) = %v, want %v", tc.msg, got, tc.want)
+		}
+	}
+}
+
+func TestSessionQueryNormalize_ClampsPagination(t *testing.T) {
+	q := SessionQuery{Text: "  x ", Limit: 1000, Offset: -2}.Normalize()
+	if q.Text != "x" || q.Limit != MaxSessionSearchLimit || q.Offset != 0 {
+		t.Fatalf("unexpected normalized query %+v", q)
+	}
+}
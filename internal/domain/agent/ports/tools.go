@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"errors"
 	"strings"
+	"time"
 )
 
 // ToolCall represents a request to execute a tool
@@ -156,6 +157,21 @@ type ToolMetadata struct {
 	Dangerous            bool                     `json:"dangerous"`
 	SafetyLevel          int                      `json:"safety_level,omitempty"`
 	MaterialCapabilities ToolMaterialCapabilities `json:"material_capabilities,omitempty"`
+	// Idempotent marks a mutating tool as safe to re-run after a transient
+	// failure. Read-only tools are always retriable.
+	Idempotent bool `json:"idempotent,omitempty"`
+	// Timeout overrides the category default execution timeout; runtime
+	// per-tool config still wins. Zero keeps the policy default.
+	Timeout time.Duration `json:"timeout,omitempty"`
+	// MaxRetries overrides the category default retry budget for transient
+	// failures. Nil keeps the policy default.
+	MaxRetries *int `json:"max_retries,omitempty"`
+}
+
+// Retriable reports whether transient failures may be retried
+// automatically without risking duplicate side effects.
+func (m ToolMetadata) Retriable() bool {
+	return m.Idempotent || m.EffectiveSafetyLevel() == SafetyLevelReadOnly
 }
 
 // EffectiveSafetyLevel returns the safety level, falling back to Dangerous flag
@@ -0,0 +1,100 @@
+package tools
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ToolOverridesMetadataKey is the session metadata key that persists a
+// session's ToolOverrides.
+const ToolOverridesMetadataKey = "tool_overrides"
+
+// ToolOverrides narrows the tools available to a single session on top of the
+// mode preset and tool policy. Deny always wins; a non-empty Allow keeps only
+// the listed tools.
+type ToolOverrides struct {
+	Allow []string `json:"allow,omitempty"`
+	Deny  []string `json:"deny,omitempty"`
+}
+
+// IsEmpty reports whether the overrides leave the tool set unchanged.
+func (o ToolOverrides) IsEmpty() bool {
+	return len(o.Allow) == 0 && len(o.Deny) == 0
+}
+
+// Allows reports whether the named tool survives the overrides.
+func (o ToolOverrides) Allows(name string) bool {
+	name = strings.TrimSpace(name)
+	for _, denied := range o.Deny {
+		if denied == name {
+			return false
+		}
+	}
+	if len(o.Allow) == 0 {
+		return true
+	}
+	for _, allowed := range o.Allow {
+		if allowed == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Normalize trims, de-duplicates and sorts both lists.
+func (o ToolOverrides) Normalize() ToolOverrides {
+	return ToolOverrides{Allow: normalizeToolNames(o.Allow), Deny: normalizeToolNames(o.Deny)}
+}
+
+// Encode serializes the overrides for session metadata. Empty overrides
+// encode to "" so callers can drop the key.
+func (o ToolOverrides) Encode() string {
+	o = o.Normalize()
+	if o.IsEmpty() {
+		return ""
+	}
+	data, err := json.Marshal(o)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+// ParseToolOverrides decodes overrides stored under ToolOverridesMetadataKey.
+func ParseToolOverrides(raw string) (ToolOverrides, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return ToolOverrides{}, nil
+	}
+	var overrides ToolOverrides
+	if err := json.Unmarshal([]byte(raw), &overrides); err != nil {
+		return ToolOverrides{}, fmt.Errorf("decode tool overrides: %w", err)
+	}
+	return overrides.Normalize(), nil
+}
+
+func normalizeToolNames(names []string) []string {
+	if len(names) == 0 {
+		return nil
+	}
+	seen := make(map[string]struct{}, len(names))
+	out := make([]string, 0, len(names))
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if _, ok := seen[name]; ok {
+			continue
+		}
+		seen[name] = struct{}{}
+		out = append(out, name)
+	}
+	if len(out) == 0 {
+		return nil
+	}
+	sort.Strings(out)
+	return out
+}
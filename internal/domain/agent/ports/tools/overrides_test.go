@@ -0,0 +1,47 @@
+package tools
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestToolOverridesAllows(t *testing.T) {
+	cases := []struct {
+		name      string
+		overrides ToolOverrides
+		tool      string
+		want      bool
+	}{
+		{"empty allows everything", ToolOverrides{}, "shell_exec", true},
+		{"deny blocks", ToolOverrides{Deny: []string{"shell_exec"}}, "shell_exec", false},
+		{"allow list restricts", ToolOverrides{Allow: []string{"file_read"}}, "shell_exec", false},
+		{"allow list admits", ToolOverrides{Allow: []string{"file_read"}}, "file_read", true},
+		{"deny wins over allow", ToolOverrides{Allow: []string{"file_read"}, Deny: []string{"file_read"}}, "file_read", false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.overrides.Allows(tc.tool); got != tc.want {
+				t.Fatalf("Allows(%q) = %v, want %v", tc.tool, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestToolOverridesEncodeRoundTrip(t *testing.T) {
+	overrides := ToolOverrides{Allow: []string{" web_search", "file_read", "file_read"}, Deny: []string{"", "shell_exec"}}
+	encoded := overrides.Encode()
+	decoded, err := ParseToolOverrides(encoded)
+	if err != nil {
+		t.Fatalf("ParseToolOverrides() error = %v", err)
+	}
+	want := ToolOverrides{Allow: []string{"file_read", "web_search"}, Deny: []string{"shell_exec"}}
+	if !reflect.DeepEqual(decoded, want) {
+		t.Fatalf("round trip = %+v, want %+v", decoded, want)
+	}
+	if got := (ToolOverrides{Deny: []string{" "}}).Encode(); got != "" {
+		t.Fatalf("expected blank overrides to encode empty, got %q", got)
+	}
+	if _, err := ParseToolOverrides("{broken"); err == nil {
+		t.Fatal("expected decode error")
+	}
+}
@@ -22,6 +22,32 @@ type ToolRetryConfig struct {
 	InitialBackoff time.Duration `yaml:"initial_backoff" json:"initial_backoff"`
 	MaxBackoff     time.Duration `yaml:"max_backoff" json:"max_backoff"`
 	BackoffFactor  float64       `yaml:"backoff_factor" json:"backoff_factor"`
+	// Categories overrides the settings above for a transient error
+	// category (rate_limit, timeout, unavailable, network, other). Zero
+	// fields inherit from the parent; nested Categories are ignored.
+	Categories map[string]ToolRetryConfig `yaml:"categories,omitempty" json:"categories,omitempty"`
+}
+
+// ForCategory returns the retry settings for a transient error category,
+// layering any category override on top of c.
+func (c ToolRetryConfig) ForCategory(category string) ToolRetryConfig {
+	out := c
+	out.Categories = nil
+	override, ok := c.Categories[category]
+	if !ok {
+		return out
+	}
+	out.MaxRetries = override.MaxRetries
+	if override.InitialBackoff > 0 {
+		out.InitialBackoff = override.InitialBackoff
+	}
+	if override.MaxBackoff > 0 {
+		out.MaxBackoff = override.MaxBackoff
+	}
+	if override.BackoffFactor > 0 {
+		out.BackoffFactor = override.BackoffFactor
+	}
+	return out
 }
 
 // ToolCallContext carries runtime context about the current tool invocation
@@ -33,6 +59,10 @@ type ToolCallContext struct {
 	Dangerous   bool
 	Channel     string // cli, web, lark, wechat
 	SafetyLevel int    // effective safety level (1-4; 0=unset)
+	// Timeout and MaxRetries carry the tool's own metadata overrides,
+	// applied over rule defaults (zero/nil = unset).
+	Timeout    time.Duration
+	MaxRetries *int
 }
 
 // ResolvedPolicy is the final, flattened result of evaluating all policy
@@ -0,0 +1,37 @@
+package tools
+
+import (
+	"testing"
+	"time"
+)
+
+func TestToolRetryConfigForCategory(t *testing.T) {
+	base := ToolRetryConfig{
+		MaxRetries:     3,
+		InitialBackoff: time.Second,
+		MaxBackoff:     10 * time.Second,
+		BackoffFactor:  2,
+		Categories: map[string]ToolRetryConfig{
+			"rate_limit": {MaxRetries: 1, InitialBackoff: 5 * time.Second},
+			"timeout":    {MaxRetries: 0},
+		},
+	}
+
+	rateLimit := base.ForCategory("rate_limit")
+	if rateLimit.MaxRetries != 1 || rateLimit.InitialBackoff != 5*time.Second {
+		t.Fatalf("expected rate_limit override, got %+v", rateLimit)
+	}
+	if rateLimit.MaxBackoff != 10*time.Second || rateLimit.BackoffFactor != 2 {
+		t.Fatalf("expected unset fields to inherit, got %+v", rateLimit)
+	}
+	if rateLimit.Categories != nil {
+		t.Fatal("expected category result to drop nested categories")
+	}
+
+	if got := base.ForCategory("timeout").MaxRetries; got != 0 {
+		t.Fatalf("expected timeout override to disable retries, got %d", got)
+	}
+	if got := base.ForCategory("network"); got.MaxRetries != 3 || got.InitialBackoff != time.Second {
+		t.Fatalf("expected unknown category to use base config, got %+v", got)
+	}
+}
@@ -3,6 +3,9 @@ package presets
 import (
 	"strings"
 	"testing"
+
+	core "alex/internal/domain/agent/ports"
+	tools "alex/internal/domain/agent/ports/tools"
 )
 
 func TestGetPromptConfig(t *testing.T) {
@@ -259,3 +262,37 @@ func TestToolPresetAllowsAllTools(t *testing.T) {
 		}
 	}
 }
+
+type listOnlyRegistry struct {
+	defs []core.ToolDefinition
+}
+
+func (r *listOnlyRegistry) Register(tools.ToolExecutor) error { return nil }
+func (r *listOnlyRegistry) Get(string) (tools.ToolExecutor, error) {
+	return nil, nil
+}
+func (r *listOnlyRegistry) List() []core.ToolDefinition { return r.defs }
+func (r *listOnlyRegistry) Unregister(string) error     { return nil }
+
+func TestWithToolOverridesFiltersListAndGet(t *testing.T) {
+	parent := &listOnlyRegistry{defs: []core.ToolDefinition{{Name: "file_read"}, {Name: "shell_exec"}, {Name: "web_fetch"}}}
+
+	if got := WithToolOverrides(parent, tools.ToolOverrides{}); got != tools.ToolRegistry(parent) {
+		t.Fatal("expected empty overrides to return the parent registry")
+	}
+
+	registry := WithToolOverrides(parent, tools.ToolOverrides{Deny: []string{"shell_exec"}})
+	var names []string
+	for _, def := range registry.List() {
+		names = append(names, def.Name)
+	}
+	if strings.Join(names, ",") != "file_read,web_fetch" {
+		t.Fatalf("unexpected filtered tools: %v", names)
+	}
+	if _, err := registry.Get("shell_exec"); err == nil || !strings.Contains(err.Error(), "disabled for this session") {
+		t.Fatalf("expected disabled error, got %v", err)
+	}
+	if _, err := registry.Get("file_read"); err != nil {
+		t.Fatalf("expected allowed tool to resolve, got %v", err)
+	}
+}
@@ -15,6 +15,19 @@ const (
 	PresetArchitect       AgentPreset = "architect"
 )
 
+// AgentPresets returns every agent preset in display order.
+func AgentPresets() []AgentPreset {
+	return []AgentPreset{
+		PresetDefault,
+		PresetCodeExpert,
+		PresetResearcher,
+		PresetDevOps,
+		PresetSecurityAnalyst,
+		PresetDesigner,
+		PresetArchitect,
+	}
+}
+
 const sevenCResponseSection = `
 ## 7C Response Quality (priority: Clear > Coherent > Concise > Concrete)
 
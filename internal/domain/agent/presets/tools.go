@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 
+	core "alex/internal/domain/agent/ports"
 	tools "alex/internal/domain/agent/ports/tools"
 )
 
@@ -91,7 +92,8 @@ func GetToolConfig(mode ToolMode, preset ToolPreset) (*ToolConfig, error) {
 }
 
 // NewFilteredToolRegistry returns the parent registry unchanged because all
-// presets grant unrestricted tool access. The function is kept for API
+// presets grant unrestricted tool access, so List serves the parent's cached
+// definition snapshot without rebuilding it. The function is kept for API
 // compatibility with existing callers.
 func NewFilteredToolRegistry(parent tools.ToolRegistry, mode ToolMode, preset ToolPreset) (tools.ToolRegistry, error) {
 	if _, err := GetToolConfig(mode, preset); err != nil {
@@ -109,3 +111,45 @@ func IsValidToolPreset(preset string) bool {
 		return false
 	}
 }
+
+// WithToolOverrides narrows parent to the tools a session's overrides allow.
+// Filtered tools disappear from List, so they are never offered to the LLM,
+// and Get reports them as unavailable. Empty overrides return parent.
+func WithToolOverrides(parent tools.ToolRegistry, overrides tools.ToolOverrides) tools.ToolRegistry {
+	overrides = overrides.Normalize()
+	if parent == nil || overrides.IsEmpty() {
+		return parent
+	}
+	return &overrideToolRegistry{parent: parent, overrides: overrides}
+}
+
+type overrideToolRegistry struct {
+	parent    tools.ToolRegistry
+	overrides tools.ToolOverrides
+}
+
+func (r *overrideToolRegistry) Register(tool tools.ToolExecutor) error {
+	return r.parent.Register(tool)
+}
+
+func (r *overrideToolRegistry) Get(name string) (tools.ToolExecutor, error) {
+	if !r.overrides.Allows(name) {
+		return nil, fmt.Errorf("tool disabled for this session: %s", name)
+	}
+	return r.parent.Get(name)
+}
+
+func (r *overrideToolRegistry) List() []core.ToolDefinition {
+	defs := r.parent.List()
+	filtered := make([]core.ToolDefinition, 0, len(defs))
+	for _, def := range defs {
+		if r.overrides.Allows(def.Name) {
+			filtered = append(filtered, def)
+		}
+	}
+	return filtered
+}
+
+func (r *overrideToolRegistry) Unregister(name string) error {
+	return r.parent.Unregister(name)
+}
@@ -33,6 +33,7 @@ type ReactEngine struct {
 	seq                 domain.SeqCounter // Monotonic event sequence per run
 	iterationHook       agent.IterationHook
 	sessionPersister    agent.SessionPersister // Optional: async save session after each iteration
+	traceToolArguments  bool                   // Record sanitized tool arguments on tool spans
 
 	// Background task support: executor closure for internal agent delegation.
 	backgroundExecutor func(ctx context.Context, prompt, sessionID string,
@@ -127,6 +128,9 @@ type ReactEngineConfig struct {
 	BackgroundManager *BackgroundTaskManager
 	// AtomicFileWriter writes files atomically (for context compaction artifacts).
 	AtomicFileWriter agent.AtomicFileWriter
+	// TraceToolArguments records sanitized tool arguments on tool spans.
+	// Leave false where arguments may carry private data.
+	TraceToolArguments bool
 }
 type toolDefinitionTokenCache struct {
 	mu        sync.RWMutex
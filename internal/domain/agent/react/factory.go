@@ -81,6 +81,7 @@ func NewReactEngine(cfg ReactEngineConfig) *ReactEngine {
 		workflow:            cfg.Workflow,
 		iterationHook:       cfg.IterationHook,
 		sessionPersister:    cfg.SessionPersister,
+		traceToolArguments:  cfg.TraceToolArguments,
 		backgroundExecutor: cfg.BackgroundExecutor,
 		backgroundManager:  cfg.BackgroundManager,
 		atomicWriter:       cfg.AtomicFileWriter,
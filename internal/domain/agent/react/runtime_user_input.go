@@ -2,6 +2,7 @@ package react
 
 import (
 	"alex/internal/domain/agent/ports"
+	agent "alex/internal/domain/agent/ports/agent"
 )
 
 // injectUserInput drains the user input channel and appends each message as a
@@ -19,12 +20,16 @@ func (r *reactRuntime) injectUserInput() {
 				r.userInputCh = nil
 				return
 			}
-			r.state.Messages = append(r.state.Messages, ports.Message{
+			msg := ports.Message{
 				Role:    "user",
 				Content: input.Content,
 				Source:  ports.MessageSourceUserInput,
-			})
-			r.engine.logger.Info("Injected user input from sender %s (msg_id=%s)", input.SenderID, input.MessageID)
+			}
+			if input.Synthesized {
+				msg.Metadata = map[string]any{agent.SynthesizedInputMetadataKey: true}
+			}
+			r.state.Messages = append(r.state.Messages, msg)
+			r.engine.logger.Info("Injected user input from sender %s (msg_id=%s synthesized=%t)", input.SenderID, input.MessageID, input.Synthesized)
 		default:
 			return
 		}
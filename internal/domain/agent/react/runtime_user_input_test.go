@@ -51,6 +51,23 @@ func TestInjectUserInput_SingleMessage(t *testing.T) {
 	require.Equal(t, ports.MessageSourceUserInput, r.state.Messages[0].Source)
 }
 
+func TestInjectUserInput_MarksSynthesizedInput(t *testing.T) {
+	ch := make(chan agent.UserInput, 4)
+	ch <- agent.UserInput{Content: "no reply within 10 minutes", MessageID: "msg1", Synthesized: true}
+	ch <- agent.UserInput{Content: "real reply", MessageID: "msg2"}
+
+	r := &reactRuntime{
+		engine:      newEngineForUserInputTest(),
+		state:       &TaskState{},
+		userInputCh: ch,
+	}
+	r.injectUserInput()
+
+	require.Len(t, r.state.Messages, 2)
+	require.Equal(t, true, r.state.Messages[0].Metadata[agent.SynthesizedInputMetadataKey])
+	require.Nil(t, r.state.Messages[1].Metadata)
+}
+
 func TestInjectUserInput_MultipleMessages(t *testing.T) {
 	ch := make(chan agent.UserInput, 4)
 	ch <- agent.UserInput{Content: "msg A", SenderID: "u1"}
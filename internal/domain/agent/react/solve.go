@@ -138,6 +138,7 @@ func (e *ReactEngine) think(
 		state,
 		attribute.Int(traceAttrIteration, state.Iterations),
 		attribute.String(traceAttrModel, modelName),
+		attribute.String(traceAttrProvider, strings.TrimSpace(services.LLMProvider)),
 		attribute.String("alex.llm.request_id", requestID),
 		attribute.Int("alex.llm.filtered_messages", len(filteredMessages)),
 		attribute.Int("alex.llm.tools", len(tools)),
@@ -220,6 +221,10 @@ func (e *ReactEngine) think(
 
 	// Accumulate actual LLM-reported token usage for precise tracking.
 	state.TokenBreakdown.AccumulateThink(resp.Usage)
+	e.emitEvent(domain.NewIterationUsageEvent(
+		e.newBaseEvent(ctx, state.SessionID, state.RunID, state.ParentRunID),
+		state.Iterations, modelName, resp.Usage,
+	))
 
 	flushStreamBuffer()
 
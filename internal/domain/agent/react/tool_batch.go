@@ -106,11 +106,9 @@ func (b *toolCallBatch) runCall(idx int, tc ToolCall) {
 
 	spanCtx, toolSpan := startReactSpan(
 		b.ctx,
-		traceSpanToolExecute,
+		toolSpanName(tc.Name),
 		b.state,
-		attribute.Int(traceAttrIteration, b.iteration),
-		attribute.String(traceAttrToolName, tc.Name),
-		attribute.String("alex.tool.call_id", tc.ID),
+		toolCallSpanAttrs(tc, b.iteration, b.engine.traceToolArguments)...,
 	)
 
 	nodeID := ""
@@ -131,6 +129,7 @@ func (b *toolCallBatch) runCall(idx int, tc ToolCall) {
 		b.finalize(idx, tc, nodeID, ToolResult{Error: missing}, startTime, toolSpan)
 		return
 	}
+	toolSpan.SetAttributes(attribute.Int(traceAttrToolSafetyLevel, tool.Metadata().EffectiveSafetyLevel()))
 
 	toolCtx := tools.WithAttachmentContext(spanCtx, b.attachments, b.attachmentIterations)
 	toolCtx = tools.WithToolProgressEmitter(toolCtx, func(chunk string, isComplete bool) {
@@ -196,6 +195,8 @@ func (b *toolCallBatch) finalize(idx int, tc ToolCall, nodeID string, result Too
 		span.SetAttributes(
 			attribute.Int64("alex.tool.duration_ms", duration.Milliseconds()),
 			attribute.Int("alex.tool.result_chars", len(normalized.Content)),
+			attribute.Int(traceAttrToolOutputBytes, len(normalized.Content)),
+			attribute.Bool(traceAttrToolSuccess, normalized.Error == nil),
 		)
 		markSpanResult(span, normalized.Error)
 		span.End()
@@ -2,6 +2,7 @@ package react
 
 import (
 	"context"
+	"encoding/json"
 
 	id "alex/internal/shared/utils/id"
 
@@ -16,7 +17,8 @@ const (
 
 	traceSpanReactIteration = "alex.react.iteration"
 	traceSpanLLMGenerate    = "alex.llm.generate"
-	traceSpanToolExecute    = "alex.tool.execute"
+	// Tool spans are named tool.<name> so backends group latency per tool.
+	traceSpanToolPrefix = "tool."
 
 	traceAttrSessionID   = "alex.session_id"
 	traceAttrRunID       = "alex.run_id"
@@ -25,8 +27,23 @@ const (
 	traceAttrStatus      = "alex.status"
 	traceAttrToolName    = "alex.tool_name"
 	traceAttrModel       = "alex.llm.model"
+	traceAttrProvider    = "alex.llm.provider"
+
+	traceAttrToolSafetyLevel = "alex.tool.safety_level"
+	traceAttrToolInputBytes  = "alex.tool.input_bytes"
+	traceAttrToolOutputBytes = "alex.tool.output_bytes"
+	traceAttrToolSuccess     = "alex.tool.success"
+	traceAttrToolArguments   = "alex.tool.arguments"
+
+	// maxTracedToolArgumentChars caps the sanitized arguments recorded on a
+	// tool span.
+	maxTracedToolArgumentChars = 2048
 )
 
+func toolSpanName(toolName string) string {
+	return traceSpanToolPrefix + toolName
+}
+
 func startReactSpan(ctx context.Context, spanName string, state *TaskState, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
 	ids := id.IDsFromContext(ctx)
 	if state != nil {
@@ -56,6 +73,28 @@ func startReactSpan(ctx context.Context, spanName string, state *TaskState, attr
 	return otel.Tracer(traceScopeReact).Start(ctx, spanName, trace.WithAttributes(spanAttrs...))
 }
 
+// toolCallSpanAttrs describes a tool call before it runs. Argument values are
+// recorded, sanitized and truncated, only when captureArgs is set; their
+// encoded size is always recorded.
+func toolCallSpanAttrs(tc ToolCall, iteration int, captureArgs bool) []attribute.KeyValue {
+	inputBytes := 0
+	if len(tc.Arguments) > 0 {
+		if encoded, err := json.Marshal(tc.Arguments); err == nil {
+			inputBytes = len(encoded)
+		}
+	}
+	attrs := []attribute.KeyValue{
+		attribute.Int(traceAttrIteration, iteration),
+		attribute.String(traceAttrToolName, tc.Name),
+		attribute.String("alex.tool.call_id", tc.ID),
+		attribute.Int(traceAttrToolInputBytes, inputBytes),
+	}
+	if captureArgs {
+		attrs = append(attrs, attribute.String(traceAttrToolArguments, truncateWithEllipsis(formatToolArgumentsForLog(tc.Arguments), maxTracedToolArgumentChars)))
+	}
+	return attrs
+}
+
 func markSpanResult(span trace.Span, err error) {
 	if span == nil {
 		return
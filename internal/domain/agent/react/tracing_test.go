@@ -2,18 +2,97 @@ package react
 
 import (
 	"context"
+	"slices"
+	"strings"
 	"testing"
 
 	"alex/internal/domain/agent/ports"
+	agent "alex/internal/domain/agent/ports/agent"
 	"alex/internal/domain/agent/ports/mocks"
 	tools "alex/internal/domain/agent/ports/tools"
 
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 )
 
 func TestReactEngine_EmitsTraceSpansForIterationLLMAndTool(t *testing.T) {
+	spans := solveWithTracing(t, newReactEngineForTest(4))
+
+	counts := map[string]int{}
+	for _, span := range spans {
+		counts[span.Name()]++
+	}
+
+	if counts[traceSpanReactIteration] == 0 {
+		t.Fatalf("expected %q span, spans=%v", traceSpanReactIteration, counts)
+	}
+	if counts[traceSpanLLMGenerate] == 0 {
+		t.Fatalf("expected %q span, spans=%v", traceSpanLLMGenerate, counts)
+	}
+	if counts[toolSpanName("echo")] == 0 {
+		t.Fatalf("expected %q span, spans=%v", toolSpanName("echo"), counts)
+	}
+}
+
+func TestReactEngine_ToolAndLLMSpanAttributes(t *testing.T) {
+	spans := solveWithTracing(t, newReactEngineForTest(4))
+
+	toolSpan := findEndedSpan(t, spans, toolSpanName("echo"))
+	attrs := spanAttrMap(toolSpan)
+	if got := attrs[traceAttrToolSafetyLevel].AsInt64(); got != int64(ports.SafetyLevelReadOnly) {
+		t.Fatalf("safety level = %d, want %d", got, ports.SafetyLevelReadOnly)
+	}
+	if got := attrs[traceAttrToolInputBytes].AsInt64(); got != int64(len(`{"text":"hello"}`)) {
+		t.Fatalf("input bytes = %d", got)
+	}
+	if got := attrs[traceAttrToolOutputBytes].AsInt64(); got != 2 {
+		t.Fatalf("output bytes = %d, want 2", got)
+	}
+	if !attrs[traceAttrToolSuccess].AsBool() {
+		t.Fatalf("expected successful tool span, attrs=%v", attrs)
+	}
+	if _, ok := attrs[traceAttrToolArguments]; ok {
+		t.Fatalf("arguments must not be captured unless enabled")
+	}
+
+	var iterationSpanIDs []string
+	for _, span := range spans {
+		if span.Name() == traceSpanReactIteration {
+			iterationSpanIDs = append(iterationSpanIDs, span.SpanContext().SpanID().String())
+		}
+	}
+	if !slices.Contains(iterationSpanIDs, toolSpan.Parent().SpanID().String()) {
+		t.Fatalf("tool span should nest under an iteration span")
+	}
+
+	llmAttrs := spanAttrMap(findEndedSpan(t, spans, traceSpanLLMGenerate))
+	if got := llmAttrs[traceAttrProvider].AsString(); got != "trace-provider" {
+		t.Fatalf("provider = %q, want trace-provider", got)
+	}
+	if got := llmAttrs[traceAttrModel].AsString(); got != "trace-test-model" {
+		t.Fatalf("model = %q, want trace-test-model", got)
+	}
+}
+
+func TestReactEngine_ToolSpanCapturesArgumentsWhenEnabled(t *testing.T) {
+	engine := NewReactEngine(ReactEngineConfig{
+		MaxIterations:      4,
+		Logger:             agent.NoopLogger{},
+		Clock:              agent.SystemClock{},
+		TraceToolArguments: true,
+	})
+	spans := solveWithTracing(t, engine)
+
+	attrs := spanAttrMap(findEndedSpan(t, spans, toolSpanName("echo")))
+	if got := attrs[traceAttrToolArguments].AsString(); !strings.Contains(got, "hello") {
+		t.Fatalf("arguments = %q, want captured text", got)
+	}
+}
+
+func solveWithTracing(t *testing.T, engine *ReactEngine) []sdktrace.ReadOnlySpan {
+	t.Helper()
 	recorder := tracetest.NewSpanRecorder()
 	tp := sdktrace.NewTracerProvider()
 	tp.RegisterSpanProcessor(recorder)
@@ -55,7 +134,6 @@ func TestReactEngine_EmitsTraceSpansForIterationLLMAndTool(t *testing.T) {
 		},
 	}
 
-	engine := newReactEngineForTest(4)
 	state := &TaskState{
 		SessionID:   "session-trace",
 		RunID:       "run-trace",
@@ -63,6 +141,7 @@ func TestReactEngine_EmitsTraceSpansForIterationLLMAndTool(t *testing.T) {
 	}
 	services := Services{
 		LLM:          mockLLM,
+		LLMProvider:  "trace-provider",
 		ToolExecutor: mockTools,
 		Parser:       &mocks.MockParser{},
 		Context:      &mocks.MockContextManager{},
@@ -76,19 +155,24 @@ func TestReactEngine_EmitsTraceSpansForIterationLLMAndTool(t *testing.T) {
 	if len(spans) == 0 {
 		t.Fatalf("expected spans to be recorded")
 	}
+	return spans
+}
 
-	counts := map[string]int{}
+func findEndedSpan(t *testing.T, spans []sdktrace.ReadOnlySpan, name string) sdktrace.ReadOnlySpan {
+	t.Helper()
 	for _, span := range spans {
-		counts[span.Name()]++
+		if span.Name() == name {
+			return span
+		}
 	}
+	t.Fatalf("span %q not recorded", name)
+	return nil
+}
 
-	if counts[traceSpanReactIteration] == 0 {
-		t.Fatalf("expected %q span, spans=%v", traceSpanReactIteration, counts)
-	}
-	if counts[traceSpanLLMGenerate] == 0 {
-		t.Fatalf("expected %q span, spans=%v", traceSpanLLMGenerate, counts)
-	}
-	if counts[traceSpanToolExecute] == 0 {
-		t.Fatalf("expected %q span, spans=%v", traceSpanToolExecute, counts)
+func spanAttrMap(span sdktrace.ReadOnlySpan) map[string]attribute.Value {
+	attrs := make(map[string]attribute.Value, len(span.Attributes()))
+	for _, kv := range span.Attributes() {
+		attrs[string(kv.Key)] = kv.Value
 	}
+	return attrs
 }
@@ -7,6 +7,7 @@ const (
 	// Core workflow lifecycle
 	EventInputReceived    = "workflow.input.received"
 	EventLifecycleUpdated = "workflow.lifecycle.updated"
+	EventQueued           = "workflow.queued"
 
 	// Node lifecycle
 	EventNodeStarted       = "workflow.node.started"
@@ -15,6 +16,9 @@ const (
 	EventNodeOutputDelta   = "workflow.node.output.delta"
 	EventNodeOutputSummary = "workflow.node.output.summary"
 
+	// Iteration accounting (token usage and cost after each LLM round)
+	EventIterationUsage = "workflow.iteration.usage"
+
 	// Tool lifecycle
 	EventToolStarted   = "workflow.tool.started"
 	EventToolProgress  = "workflow.tool.progress"
@@ -36,6 +40,8 @@ const (
 	EventDiagnosticEnvironmentSnapshot = "workflow.diagnostic.environment_snapshot"
 	EventDiagnosticToolFiltering       = "workflow.diagnostic.tool_filtering"
 	EventDiagnosticContextCheckpoint   = "workflow.diagnostic.context_checkpoint"
+	EventDiagnosticConfigChanged       = "workflow.diagnostic.config_changed"
+	EventDiagnosticHealthChanged       = "workflow.diagnostic.health_changed"
 
 	// Artifact
 	EventArtifactManifest = "workflow.artifact.manifest"
@@ -7,6 +7,9 @@ type AgentTask struct {
 	ParentRunID string  `json:"parent_run_id,omitempty"`
 	Status      string  `json:"status"`
 	CreatedAt   string  `json:"created_at"`
+	QueuedAt    *string `json:"queued_at,omitempty"`
+	StartedAt   *string `json:"started_at,omitempty"`
 	CompletedAt *string `json:"completed_at,omitempty"`
+	Priority    int     `json:"priority,omitempty"`
 	Error       string  `json:"error,omitempty"`
 }
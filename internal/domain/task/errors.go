@@ -8,7 +8,15 @@ import (
 // ErrTaskNotFound indicates a task lookup or mutation targeted a missing task.
 var ErrTaskNotFound = errors.New("task not found")
 
+// ErrConflict indicates a compare-and-swap targeted a stale task revision.
+var ErrConflict = errors.New("task revision conflict")
+
 // NotFoundError annotates ErrTaskNotFound with the missing task ID.
 func NotFoundError(taskID string) error {
 	return fmt.Errorf("task %s: %w", taskID, ErrTaskNotFound)
 }
+
+// ConflictError annotates ErrConflict with the expected and current revisions.
+func ConflictError(taskID string, expected, current int64) error {
+	return fmt.Errorf("task %s: expected revision %d, have %d: %w", taskID, expected, current, ErrConflict)
+}
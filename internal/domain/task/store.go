@@ -85,9 +85,11 @@ type Task struct {
 	// Lifecycle
 	Status            Status            `json:"status"`
 	TerminationReason TerminationReason `json:"termination_reason,omitempty"`
+	Priority          int               `json:"priority,omitempty"` // execution queue order; higher starts first
 
 	// Timestamps
 	CreatedAt   time.Time  `json:"created_at"`
+	QueuedAt    *time.Time `json:"queued_at,omitempty"`
 	StartedAt   *time.Time `json:"started_at,omitempty"`
 	UpdatedAt   time.Time  `json:"updated_at"`
 	CompletedAt *time.Time `json:"completed_at,omitempty"`
@@ -114,6 +116,10 @@ type Task struct {
 
 	// Extensible metadata
 	Metadata map[string]string `json:"metadata,omitempty"`
+
+	// Revision increases on every mutation; CompareAndSwap requires the
+	// caller's last-read revision to match.
+	Revision int64 `json:"revision"`
 }
 
 // Transition records a state change in the task lifecycle.
@@ -185,6 +191,14 @@ type Store interface {
 	// Get retrieves a task by ID.
 	Get(ctx context.Context, taskID string) (*Task, error)
 
+	// GetWithRevision retrieves a task together with its current revision.
+	GetWithRevision(ctx context.Context, taskID string) (*Task, int64, error)
+
+	// CompareAndSwap replaces the stored task when its revision still equals
+	// expected and returns the new revision. It fails with ErrConflict when
+	// another write landed first.
+	CompareAndSwap(ctx context.Context, taskID string, expected int64, updated *Task) (int64, error)
+
 	// SetStatus updates the task status and writes a transition record atomically.
 	SetStatus(ctx context.Context, taskID string, status Status, opts ...TransitionOption) error
 
@@ -200,6 +214,10 @@ type Store interface {
 	// SetBridgeMeta persists bridge checkpoint data.
 	SetBridgeMeta(ctx context.Context, taskID string, meta BridgeMeta) error
 
+	// SetQueued records the task's queue priority and the time it entered
+	// the execution queue.
+	SetQueued(ctx context.Context, taskID string, priority int, queuedAt time.Time) error
+
 	// Delete removes a task.
 	Delete(ctx context.Context, taskID string) error
 
@@ -0,0 +1,123 @@
+package internal_test
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// forbiddenImportCalls lists package-level functions that terminate the
+// process. Library code under internal/ must return errors instead; only the
+// cmd/ binaries may decide to exit.
+var forbiddenImportCalls = map[string]map[string]bool{
+	"log": {"Fatal": true, "Fatalf": true, "Fatalln": true, "Panic": true, "Panicf": true, "Panicln": true},
+	"os":  {"Exit": true},
+}
+
+func TestInternalPackagesDoNotPanicOrExit(t *testing.T) {
+	fset := token.NewFileSet()
+	var violations []string
+	err := filepath.WalkDir(".", func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if d.Name() == "testdata" || strings.HasPrefix(d.Name(), ".") && path != "." {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+			return nil
+		}
+		file, err := parser.ParseFile(fset, path, nil, parser.SkipObjectResolution)
+		if err != nil {
+			return err
+		}
+		violations = append(violations, forbiddenCalls(fset, file)...)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("walk internal tree: %v", err)
+	}
+	if len(violations) > 0 {
+		t.Fatalf("internal packages must return errors instead of panicking or exiting:\n  %s",
+			strings.Join(violations, "\n  "))
+	}
+}
+
+// forbiddenCalls returns the positions of panic calls and forbidden
+// log/os calls in file, resolving import aliases.
+func forbiddenCalls(fset *token.FileSet, file *ast.File) []string {
+	aliases := map[string]string{} // local name → import path
+	for _, imp := range file.Imports {
+		path, err := strconv.Unquote(imp.Path.Value)
+		if err != nil || forbiddenImportCalls[path] == nil {
+			continue
+		}
+		name := path
+		if imp.Name != nil {
+			name = imp.Name.Name
+		}
+		aliases[name] = path
+	}
+
+	var found []string
+	ast.Inspect(file, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		switch fn := call.Fun.(type) {
+		case *ast.Ident:
+			if fn.Name == "panic" {
+				found = append(found, fset.Position(call.Pos()).String()+": panic")
+			}
+		case *ast.SelectorExpr:
+			pkg, ok := fn.X.(*ast.Ident)
+			if !ok {
+				return true
+			}
+			if path, ok := aliases[pkg.Name]; ok && forbiddenImportCalls[path][fn.Sel.Name] {
+				found = append(found, fset.Position(call.Pos()).String()+": "+path+"."+fn.Sel.Name)
+			}
+		}
+		return true
+	})
+	return found
+}
+
+func TestForbiddenCallsDetectsAliasedAndBuiltinCalls(t *testing.T) {
+	const src = `package sample
+
+import (
+	stdlog "log"
+	"os"
+)
+
+func run(err error) {
+	if err != nil {
+		stdlog.Fatalf("boom: %v", err)
+	}
+	// panic("comments are ignored")
+	_ = "log.Fatal in a string is ignored"
+	panic(err)
+	os.Exit(1)
+}
+`
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "sample.go", src, parser.SkipObjectResolution)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	got := forbiddenCalls(fset, file)
+	want := []string{"sample.go:10:3: log.Fatalf", "sample.go:14:2: panic", "sample.go:15:2: os.Exit"}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Fatalf("forbiddenCalls = %v, want %v", got, want)
+	}
+}
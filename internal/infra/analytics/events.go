@@ -10,4 +10,9 @@ const (
 	EventTaskExecutionCompleted    = "task_execution_completed"
 	EventTaskExecutionFailed       = "task_execution_failed"
 	EventTaskExecutionCancelled    = "task_execution_cancelled"
+
+	// Task lifecycle events captured by TaskLifecycleListener.
+	EventTaskStarted   = "task_started"
+	EventTaskCompleted = "task_completed"
+	EventTaskFailed    = "task_failed"
 )
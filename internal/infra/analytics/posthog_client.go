@@ -3,64 +3,365 @@ package analytics
 import (
 	"context"
 	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/posthog/posthog-go"
+
+	"alex/internal/shared/async"
+	"alex/internal/shared/logging"
+	id "alex/internal/shared/utils/id"
+)
+
+const (
+	defaultPostHogHost = "https://app.posthog.com"
+
+	defaultBatchSize     = 50
+	defaultFlushInterval = 5 * time.Second
+	defaultMaxRetries    = 3
+	defaultRetryBackoff  = 500 * time.Millisecond
+	defaultCloseTimeout  = 5 * time.Second
+	defaultSpoolMaxBytes = 10 << 20
+)
+
+// Drop reasons reported through Metrics.RecordAnalyticsDropped.
+const (
+	DropRejected      = "rejected"
+	DropUndelivered   = "undelivered"
+	DropSpoolOverflow = "spool_overflow"
+	DropSpoolError    = "spool_error"
 )
 
-const defaultPostHogHost = "https://app.posthog.com"
+// Metrics receives analytics client health signals.
+// *observability.MetricsCollector satisfies it.
+type Metrics interface {
+	RecordAnalyticsSpool(ctx context.Context, spoolBytes int64)
+	RecordAnalyticsDropped(ctx context.Context, reason string, count int)
+}
 
-// PostHogClient emits events to PostHog.
+// PostHogOptions tunes the client. Zero values use defaults.
+type PostHogOptions struct {
+	Host          string
+	BatchSize     int
+	FlushInterval time.Duration
+	MaxRetries    int
+	RetryBackoff  time.Duration
+	// SpoolDir holds events the library gave up on. Empty disables
+	// spooling, in which case undeliverable events are dropped.
+	SpoolDir      string
+	SpoolMaxBytes int64
+	CloseTimeout  time.Duration
+	Transport     http.RoundTripper
+	Metrics       Metrics
+	Logger        logging.Logger
+}
+
+// Stats is a point-in-time view of the client's spool and drops.
+type Stats struct {
+	SpoolBytes int64          `json:"spool_bytes"`
+	Dropped    map[string]int `json:"dropped,omitempty"`
+}
+
+// PostHogClient sends events through posthog-go, which batches, retries and
+// bounds the final flush on Close. Events the library gives up on are
+// spooled to disk and replayed, oldest first, once a later batch succeeds or
+// on the next startup.
 type PostHogClient struct {
 	client posthog.Client
+	opts   PostHogOptions
+	spool  *spool
+	logger logging.Logger
+
+	mu        sync.Mutex
+	failed    []postHogEvent
+	dropped   map[string]int
+	replaying atomic.Bool
 }
 
-// NewPostHogClient creates a PostHog-backed analytics client.
-func NewPostHogClient(apiKey string, host string) (Client, error) {
+// postHogEvent is the spooled form of a capture.
+type postHogEvent struct {
+	UUID       string         `json:"uuid"`
+	Event      string         `json:"event"`
+	DistinctID string         `json:"distinct_id"`
+	Properties map[string]any `json:"properties,omitempty"`
+	Timestamp  time.Time      `json:"timestamp"`
+}
+
+// NewPostHogClient creates a PostHog-backed analytics client. Any events
+// spooled by a previous run are replayed first.
+func NewPostHogClient(apiKey string, opts PostHogOptions) (*PostHogClient, error) {
 	if apiKey == "" {
 		return nil, errors.New("posthog api key is required")
 	}
+	opts = opts.withDefaults()
 
-	endpoint := host
-	if endpoint == "" {
-		endpoint = defaultPostHogHost
+	c := &PostHogClient{
+		opts:    opts,
+		logger:  logging.OrNop(opts.Logger),
+		dropped: make(map[string]int),
+	}
+	if opts.SpoolDir != "" {
+		sp, err := newSpool(opts.SpoolDir, opts.SpoolMaxBytes)
+		if err != nil {
+			return nil, err
+		}
+		c.spool = sp
 	}
 
-	phClient, err := posthog.NewWithConfig(apiKey, posthog.Config{Endpoint: endpoint})
+	maxRetries := opts.MaxRetries
+	backoff := opts.RetryBackoff
+	client, err := posthog.NewWithConfig(apiKey, posthog.Config{
+		Endpoint:        strings.TrimRight(opts.Host, "/"),
+		Interval:        opts.FlushInterval,
+		BatchSize:       opts.BatchSize,
+		MaxRetries:      &maxRetries,
+		RetryAfter:      func(attempt int) time.Duration { return backoff << attempt },
+		ShutdownTimeout: opts.CloseTimeout,
+		Transport:       opts.Transport,
+		Logger:          postHogLogger{c.logger},
+		Callback:        c,
+	})
 	if err != nil {
 		return nil, err
 	}
+	c.client = client
+
+	if c.spool != nil && c.spool.size() > 0 {
+		c.startReplay()
+	}
+	return c, nil
+}
 
-	return &PostHogClient{client: phClient}, nil
+func (o PostHogOptions) withDefaults() PostHogOptions {
+	if strings.TrimSpace(o.Host) == "" {
+		o.Host = defaultPostHogHost
+	}
+	if o.BatchSize <= 0 {
+		o.BatchSize = defaultBatchSize
+	}
+	if o.FlushInterval <= 0 {
+		o.FlushInterval = defaultFlushInterval
+	}
+	if o.MaxRetries < 0 {
+		o.MaxRetries = 0
+	} else if o.MaxRetries == 0 {
+		o.MaxRetries = defaultMaxRetries
+	}
+	if o.RetryBackoff <= 0 {
+		o.RetryBackoff = defaultRetryBackoff
+	}
+	if o.SpoolMaxBytes <= 0 {
+		o.SpoolMaxBytes = defaultSpoolMaxBytes
+	}
+	if o.CloseTimeout <= 0 {
+		o.CloseTimeout = defaultCloseTimeout
+	}
+	return o
 }
 
-// Capture sends an event to PostHog.
-func (c *PostHogClient) Capture(ctx context.Context, distinctID string, event string, properties map[string]any) error {
+// Capture enqueues an event; posthog-go delivers it in the background.
+func (c *PostHogClient) Capture(_ context.Context, distinctID string, event string, properties map[string]any) error {
 	if c == nil || c.client == nil {
 		return errors.New("posthog client not initialized")
 	}
-
 	if distinctID == "" {
 		distinctID = "anonymous"
 	}
+	return c.enqueue(postHogEvent{
+		UUID:       id.NewUUIDv7(),
+		Event:      event,
+		DistinctID: distinctID,
+		Properties: properties,
+		Timestamp:  time.Now().UTC(),
+	})
+}
 
+func (c *PostHogClient) enqueue(evt postHogEvent) error {
 	props := posthog.NewProperties()
-	for key, value := range properties {
+	for key, value := range evt.Properties {
 		props = props.Set(key, value)
 	}
-
 	return c.client.Enqueue(posthog.Capture{
-		DistinctId: distinctID,
-		Event:      event,
+		Uuid:       evt.UUID,
+		DistinctId: evt.DistinctID,
+		Event:      evt.Event,
 		Properties: props,
-		Timestamp:  time.Now(),
+		Timestamp:  evt.Timestamp,
 	})
 }
 
-// Close flushes any buffered events and releases resources.
+// Stats reports spool size and drop counts by reason.
+func (c *PostHogClient) Stats() Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	stats := Stats{Dropped: make(map[string]int, len(c.dropped))}
+	for reason, count := range c.dropped {
+		stats.Dropped[reason] = count
+	}
+	if c.spool != nil {
+		stats.SpoolBytes = c.spool.size()
+	}
+	return stats
+}
+
+// Close flushes through posthog-go, bounded by CloseTimeout, then spools
+// whatever the library failed to deliver for the next run.
 func (c *PostHogClient) Close() error {
 	if c == nil || c.client == nil {
 		return nil
 	}
-	return c.client.Close()
+	err := c.client.Close()
+	if errors.Is(err, posthog.ErrClosed) {
+		return nil
+	}
+	c.spoolFailed(0)
+	return err
 }
+
+// Success implements posthog.Callback. A delivered batch means the endpoint
+// is reachable again, so buffered and spooled failures are replayed.
+func (c *PostHogClient) Success(posthog.APIMessage) {
+	if c.spool == nil {
+		return
+	}
+	c.spoolFailed(0)
+	if c.spool.size() > 0 {
+		c.startReplay()
+	}
+}
+
+// Failure implements posthog.Callback. Rejected events are dropped; others
+// are buffered and spooled a batch at a time.
+func (c *PostHogClient) Failure(msg posthog.APIMessage, err error) {
+	capture, ok := msg.(posthog.CaptureInApi)
+	if !ok {
+		return
+	}
+	if isRejected(err) {
+		c.logger.Warn("PostHog rejected event %s: %v", capture.Event, err)
+		c.drop(DropRejected, 1)
+		return
+	}
+	if c.spool == nil {
+		c.drop(DropUndelivered, 1)
+		return
+	}
+	c.mu.Lock()
+	c.failed = append(c.failed, postHogEvent{
+		UUID:       capture.Uuid,
+		Event:      capture.Event,
+		DistinctID: capture.DistinctId,
+		Properties: capture.Properties,
+		Timestamp:  capture.Timestamp,
+	})
+	c.mu.Unlock()
+	c.spoolFailed(c.opts.BatchSize)
+}
+
+// spoolFailed writes buffered failures to the spool once at least threshold
+// of them are waiting.
+func (c *PostHogClient) spoolFailed(threshold int) {
+	if c.spool == nil {
+		return
+	}
+	c.mu.Lock()
+	if len(c.failed) == 0 || len(c.failed) < threshold {
+		c.mu.Unlock()
+		return
+	}
+	events := c.failed
+	c.failed = nil
+	c.mu.Unlock()
+
+	evicted, err := c.spool.write(events)
+	if err != nil {
+		c.logger.Warn("PostHog spool write failed: %v", err)
+		c.drop(DropSpoolError, len(events))
+	}
+	if evicted > 0 {
+		c.drop(DropSpoolOverflow, evicted)
+	}
+	c.reportSpool()
+}
+
+// startReplay re-enqueues spooled events, oldest first, unless a replay is
+// already running. Events that fail again are spooled anew.
+func (c *PostHogClient) startReplay() {
+	if !c.replaying.CompareAndSwap(false, true) {
+		return
+	}
+	async.Go(c.logger, "analytics.posthog.replay", func() {
+		defer c.replaying.Store(false)
+		defer c.reportSpool()
+		for {
+			name, events, err := c.spool.oldest()
+			if name == "" {
+				return
+			}
+			if err != nil {
+				c.logger.Warn("PostHog spool read failed, discarding %s: %v", name, err)
+				c.drop(DropSpoolError, c.spool.remove(name))
+				continue
+			}
+			for _, evt := range events {
+				if err := c.enqueue(evt); err != nil {
+					// Closed: keep the batch for the next run. Events of it
+					// already enqueued may be sent twice; PostHog
+					// deduplicates them by UUID.
+					return
+				}
+			}
+			c.spool.remove(name)
+		}
+	})
+}
+
+func (c *PostHogClient) drop(reason string, count int) {
+	if count <= 0 {
+		return
+	}
+	c.mu.Lock()
+	c.dropped[reason] += count
+	c.mu.Unlock()
+	if c.opts.Metrics != nil {
+		c.opts.Metrics.RecordAnalyticsDropped(context.Background(), reason, count)
+	}
+}
+
+func (c *PostHogClient) reportSpool() {
+	if c.opts.Metrics != nil && c.spool != nil {
+		c.opts.Metrics.RecordAnalyticsSpool(context.Background(), c.spool.size())
+	}
+}
+
+// isRejected reports a failure retrying cannot fix: an oversized event or a
+// 4xx response other than 408/429. posthog-go does not export its HTTP error
+// type, so the status is read from its "<code> <status>" message.
+func isRejected(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, posthog.ErrMessageTooBig) {
+		return true
+	}
+	var status int
+	if _, scanErr := fmt.Sscanf(err.Error(), "%d ", &status); scanErr != nil {
+		return false
+	}
+	return status >= 400 && status < 500 && status != http.StatusRequestTimeout && status != http.StatusTooManyRequests
+}
+
+// postHogLogger routes posthog-go logs to the service logger. The library
+// logs every failed response at Logf level, so that maps to Debug.
+type postHogLogger struct {
+	logger logging.Logger
+}
+
+func (l postHogLogger) Debugf(format string, args ...any) { l.logger.Debug(format, args...) }
+func (l postHogLogger) Logf(format string, args ...any)   { l.logger.Debug(format, args...) }
+func (l postHogLogger) Warnf(format string, args ...any)  { l.logger.Warn(format, args...) }
+func (l postHogLogger) Errorf(format string, args ...any) { l.logger.Error(format, args...) }
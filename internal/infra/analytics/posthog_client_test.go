@@ -0,0 +1,270 @@
+package analytics
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type fakePostHog struct {
+	server   *httptest.Server
+	down     atomic.Bool
+	attempts atomic.Int32
+	mu       sync.Mutex
+	events   []string
+}
+
+func newFakePostHog(t *testing.T) *fakePostHog {
+	t.Helper()
+	f := &fakePostHog{}
+	f.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		f.attempts.Add(1)
+		if f.down.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		var batch struct {
+			APIKey string `json:"api_key"`
+			Batch  []struct {
+				Event string `json:"event"`
+			} `json:"batch"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&batch); err != nil || batch.APIKey != "key" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		f.mu.Lock()
+		for _, evt := range batch.Batch {
+			f.events = append(f.events, evt.Event)
+		}
+		f.mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(f.server.Close)
+	return f
+}
+
+func (f *fakePostHog) received() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.events...)
+}
+
+func testOptions(host, spoolDir string) PostHogOptions {
+	return PostHogOptions{
+		Host:          host,
+		BatchSize:     10,
+		FlushInterval: time.Hour,
+		MaxRetries:    1,
+		RetryBackoff:  time.Millisecond,
+		SpoolDir:      spoolDir,
+		CloseTimeout:  2 * time.Second,
+	}
+}
+
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(3 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met before deadline")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestPostHogClientCaptureDoesNotBlockWhenEndpointHangs(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	opts := testOptions(server.URL, t.TempDir())
+	opts.CloseTimeout = 50 * time.Millisecond
+	client, err := NewPostHogClient("key", opts)
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	start := time.Now()
+	for i := 0; i < 50; i++ {
+		if err := client.Capture(context.Background(), "u1", "evt", nil); err != nil {
+			t.Fatalf("capture: %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("capture blocked for %v", elapsed)
+	}
+	_ = client.Close()
+}
+
+func TestPostHogClientSpoolsDuringOutageAndReplaysInOrder(t *testing.T) {
+	fake := newFakePostHog(t)
+	fake.down.Store(true)
+	spoolDir := t.TempDir()
+
+	client, err := NewPostHogClient("key", testOptions(fake.server.URL, spoolDir))
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	for _, name := range []string{"a", "b", "c"} {
+		_ = client.Capture(context.Background(), "u1", name, nil)
+	}
+	if err := client.Close(); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+	if got := len(fake.received()); got != 0 {
+		t.Fatalf("expected nothing delivered during outage, got %d", got)
+	}
+
+	// A new client on the same spool replays it once the endpoint recovers.
+	fake.down.Store(false)
+	opts := testOptions(fake.server.URL, spoolDir)
+	opts.FlushInterval = 20 * time.Millisecond
+	client, err = NewPostHogClient("key", opts)
+	if err != nil {
+		t.Fatalf("reopen client: %v", err)
+	}
+	defer client.Close()
+	waitFor(t, func() bool { return len(fake.received()) == 3 })
+	got := fake.received()
+	if got[0] != "a" || got[1] != "b" || got[2] != "c" {
+		t.Fatalf("unexpected replay order: %v", got)
+	}
+	if stats := client.Stats(); stats.SpoolBytes != 0 {
+		t.Fatalf("expected empty spool after replay, got %d bytes", stats.SpoolBytes)
+	}
+}
+
+func TestPostHogClientCloseFlushesQueue(t *testing.T) {
+	fake := newFakePostHog(t)
+	opts := testOptions(fake.server.URL, "")
+
+	client, err := NewPostHogClient("key", opts)
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	_ = client.Capture(context.Background(), "u1", "one", map[string]any{"k": "v"})
+	_ = client.Capture(context.Background(), "u2", "two", nil)
+	if err := client.Close(); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+	if got := fake.received(); len(got) != 2 || got[0] != "one" || got[1] != "two" {
+		t.Fatalf("expected queued events flushed on close, got %v", got)
+	}
+	if err := client.Capture(context.Background(), "u1", "late", nil); err == nil {
+		t.Fatal("expected capture after close to fail")
+	}
+}
+
+func TestPostHogClientReplaysSpoolWhenEndpointRecovers(t *testing.T) {
+	fake := newFakePostHog(t)
+	fake.down.Store(true)
+
+	opts := testOptions(fake.server.URL, t.TempDir())
+	opts.FlushInterval = 20 * time.Millisecond
+	client, err := NewPostHogClient("key", opts)
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	defer client.Close()
+	_ = client.Capture(context.Background(), "u1", "during-outage", nil)
+	waitFor(t, func() bool { return fake.attempts.Load() >= 2 })
+
+	// The next delivered batch triggers replay of the failed event.
+	fake.down.Store(false)
+	_ = client.Capture(context.Background(), "u1", "after-outage", nil)
+	waitFor(t, func() bool { return len(fake.received()) == 2 })
+	if got := fake.received(); got[0] != "after-outage" || got[1] != "during-outage" {
+		t.Fatalf("unexpected delivery: %v", got)
+	}
+}
+
+func TestPostHogClientDropsUndeliveredWithoutSpool(t *testing.T) {
+	fake := newFakePostHog(t)
+	fake.down.Store(true)
+	opts := testOptions(fake.server.URL, "")
+	metrics := &recordingMetrics{}
+	opts.Metrics = metrics
+
+	client, err := NewPostHogClient("key", opts)
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	_ = client.Capture(context.Background(), "u1", "lost", nil)
+	_ = client.Close()
+	if metrics.dropped(DropUndelivered) != 1 {
+		t.Fatalf("expected 1 undelivered drop, got %d", metrics.dropped(DropUndelivered))
+	}
+}
+
+func TestPostHogClientDropsRejectedEventsInsteadOfSpooling(t *testing.T) {
+	fake := newFakePostHog(t)
+	opts := testOptions(fake.server.URL, t.TempDir())
+	metrics := &recordingMetrics{}
+	opts.Metrics = metrics
+
+	// The fake answers 400 for an unknown API key.
+	client, err := NewPostHogClient("wrong-key", opts)
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	_ = client.Capture(context.Background(), "u1", "bad", nil)
+	_ = client.Close()
+	if metrics.dropped(DropRejected) != 1 {
+		t.Fatalf("expected 1 rejected drop, got %d", metrics.dropped(DropRejected))
+	}
+	if spooled := client.Stats().SpoolBytes; spooled != 0 {
+		t.Fatalf("rejected events must not be spooled, got %d bytes", spooled)
+	}
+}
+
+func TestSpoolEvictsOldestBeyondLimit(t *testing.T) {
+	sp, err := newSpool(t.TempDir(), 200)
+	if err != nil {
+		t.Fatalf("new spool: %v", err)
+	}
+	evicted := 0
+	for _, name := range []string{"first", "second", "third"} {
+		n, err := sp.write([]postHogEvent{{Event: name, DistinctID: "u1", Timestamp: time.Unix(0, 0)}})
+		if err != nil {
+			t.Fatalf("write: %v", err)
+		}
+		evicted += n
+	}
+	if evicted == 0 || sp.size() > 200 {
+		t.Fatalf("expected eviction to bound spool, evicted=%d size=%d", evicted, sp.size())
+	}
+	_, events, err := sp.oldest()
+	if err != nil || len(events) != 1 || events[0].Event == "first" {
+		t.Fatalf("expected oldest batch evicted, got %+v err=%v", events, err)
+	}
+}
+
+type recordingMetrics struct {
+	mu    sync.Mutex
+	drops map[string]int
+}
+
+func (m *recordingMetrics) RecordAnalyticsSpool(context.Context, int64) {}
+
+func (m *recordingMetrics) RecordAnalyticsDropped(_ context.Context, reason string, count int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.drops == nil {
+		m.drops = make(map[string]int)
+	}
+	m.drops[reason] += count
+}
+
+func (m *recordingMetrics) dropped(reason string) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.drops[reason]
+}
@@ -0,0 +1,138 @@
+package analytics
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const spoolFileExt = ".json"
+
+// spool stores undelivered batches as one JSON file per batch. File names
+// sort by creation time, so replay is oldest first. When the directory grows
+// beyond maxBytes the oldest batches are evicted.
+type spool struct {
+	dir      string
+	maxBytes int64
+
+	mu    sync.Mutex
+	seq   uint64
+	files []spoolFile
+	bytes int64
+}
+
+type spoolFile struct {
+	name   string
+	size   int64
+	events int
+}
+
+func newSpool(dir string, maxBytes int64) (*spool, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("create analytics spool dir: %w", err)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("read analytics spool dir: %w", err)
+	}
+	s := &spool{dir: dir, maxBytes: maxBytes}
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), spoolFileExt) {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		s.files = append(s.files, spoolFile{name: entry.Name(), size: info.Size(), events: -1})
+		s.bytes += info.Size()
+	}
+	sort.Slice(s.files, func(i, j int) bool { return s.files[i].name < s.files[j].name })
+	return s, nil
+}
+
+func (s *spool) size() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.bytes
+}
+
+// write persists one batch and returns the number of events evicted to stay
+// within maxBytes.
+func (s *spool) write(events []postHogEvent) (int, error) {
+	if len(events) == 0 {
+		return 0, nil
+	}
+	data, err := json.Marshal(events)
+	if err != nil {
+		return 0, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.seq++
+	name := fmt.Sprintf("%020d-%06d%s", time.Now().UnixNano(), s.seq%1_000_000, spoolFileExt)
+	path := filepath.Join(s.dir, name)
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return 0, err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		_ = os.Remove(tmp)
+		return 0, err
+	}
+	s.files = append(s.files, spoolFile{name: name, size: int64(len(data)), events: len(events)})
+	s.bytes += int64(len(data))
+
+	evicted := 0
+	for s.bytes > s.maxBytes && len(s.files) > 1 {
+		evicted += s.removeLocked(s.files[0].name)
+	}
+	return evicted, nil
+}
+
+// oldest returns the oldest spooled batch. An empty name means the spool is
+// empty; a non-empty name with an error means the file is unreadable.
+func (s *spool) oldest() (string, []postHogEvent, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.files) == 0 {
+		return "", nil, nil
+	}
+	name := s.files[0].name
+	data, err := os.ReadFile(filepath.Join(s.dir, name))
+	if err != nil {
+		return name, nil, err
+	}
+	var events []postHogEvent
+	if err := json.Unmarshal(data, &events); err != nil {
+		return name, nil, err
+	}
+	s.files[0].events = len(events)
+	return name, events, nil
+}
+
+// remove deletes a spooled batch and returns how many events it held.
+func (s *spool) remove(name string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.removeLocked(name)
+}
+
+func (s *spool) removeLocked(name string) int {
+	for i, file := range s.files {
+		if file.name != name {
+			continue
+		}
+		_ = os.Remove(filepath.Join(s.dir, name))
+		s.files = append(s.files[:i], s.files[i+1:]...)
+		s.bytes -= file.size
+		return max(file.events, 0)
+	}
+	return 0
+}
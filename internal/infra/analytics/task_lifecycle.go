@@ -0,0 +1,228 @@
+package analytics
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	coreerrors "alex/internal/core/errors"
+	domain "alex/internal/domain/agent"
+	agent "alex/internal/domain/agent/ports/agent"
+	"alex/internal/domain/agent/types"
+)
+
+// identifierHashBytes is how much of the SHA-256 digest HashIdentifier keeps.
+const identifierHashBytes = 16
+
+// HashIdentifier returns a stable one-way hash of a user or session
+// identifier so raw IDs never leave the process. Empty input hashes to "".
+func HashIdentifier(value string) string {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:identifierHashBytes])
+}
+
+// TaskLifecycleOptions describes the task a lifecycle listener observes.
+type TaskLifecycleOptions struct {
+	// Channel names the entry point, e.g. "web" or "lark".
+	Channel string
+	Preset  string
+	Toolset string
+	// UserID is the distinct ID when set; otherwise the session ID is used.
+	// Both are hashed before capture.
+	UserID string
+	Now    func() time.Time
+}
+
+// TaskLifecycleListener decorates an agent EventListener and captures
+// task_started, task_completed and task_failed analytics events for the
+// first core-level run it observes. Events are always forwarded to the
+// wrapped listener; subagent runs only reach analytics through the parent's
+// tool calls.
+type TaskLifecycleListener struct {
+	next   agent.EventListener
+	client Client
+	ctx    context.Context
+	opts   TaskLifecycleOptions
+
+	mu         sync.Mutex
+	runID      string
+	sessionID  string
+	startedAt  time.Time
+	iterations int
+	toolCalls  map[string]int
+	toolErrors int
+	finished   bool
+}
+
+// NewTaskLifecycleListener wraps next with task lifecycle analytics. A nil
+// client returns next unchanged.
+func NewTaskLifecycleListener(ctx context.Context, next agent.EventListener, client Client, opts TaskLifecycleOptions) agent.EventListener {
+	if client == nil {
+		return next
+	}
+	if next == nil {
+		next = agent.NoopEventListener{}
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if opts.Now == nil {
+		opts.Now = time.Now
+	}
+	return &TaskLifecycleListener{
+		next:      next,
+		client:    client,
+		ctx:       context.WithoutCancel(ctx),
+		opts:      opts,
+		toolCalls: make(map[string]int),
+	}
+}
+
+// OnEvent records lifecycle state and forwards the event.
+func (l *TaskLifecycleListener) OnEvent(event agent.AgentEvent) {
+	l.observe(event)
+	l.next.OnEvent(event)
+}
+
+func (l *TaskLifecycleListener) observe(event agent.AgentEvent) {
+	if event == nil || event.GetAgentLevel() == agent.LevelSubagent {
+		return
+	}
+	e, ok := event.(*domain.Event)
+	if !ok {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.finished {
+		return
+	}
+	if l.runID == "" {
+		l.runID = e.GetRunID()
+		l.sessionID = e.GetSessionID()
+		l.startedAt = l.opts.Now()
+		l.capture(EventTaskStarted, l.baseProps())
+	} else if e.GetRunID() != l.runID {
+		return
+	}
+
+	switch e.Kind {
+	case types.EventNodeStarted:
+		if e.Data.Iteration > l.iterations {
+			l.iterations = e.Data.Iteration
+		}
+	case types.EventToolCompleted:
+		name := strings.TrimSpace(e.Data.ToolName)
+		if name == "" {
+			name = "unknown"
+		}
+		l.toolCalls[name]++
+		if e.Data.Error != nil || e.Data.ErrorStr != "" {
+			l.toolErrors++
+		}
+	case types.EventResultFinal:
+		if e.Data.IsStreaming && !e.Data.StreamFinished {
+			return
+		}
+		l.finished = true
+		props := l.outcomeProps()
+		props["duration_ms"] = e.Data.Duration.Milliseconds()
+		props["iterations"] = e.Data.TotalIterations
+		props["tokens"] = e.Data.TotalTokens
+		if e.Data.StopReason != "" {
+			props["stop_reason"] = e.Data.StopReason
+		}
+		l.capture(EventTaskCompleted, props)
+	case types.EventNodeFailed:
+		if e.Data.Recoverable {
+			return
+		}
+		l.finished = true
+		props := l.outcomeProps()
+		props["error_class"] = taskErrorClass(e.Data.Error, e.Data.ErrorStr)
+		if e.Data.PhaseLabel != "" {
+			props["phase"] = e.Data.PhaseLabel
+		}
+		l.capture(EventTaskFailed, props)
+	case types.EventResultCancelled:
+		l.finished = true
+		props := l.outcomeProps()
+		props["error_class"] = "cancelled"
+		l.capture(EventTaskFailed, props)
+	}
+}
+
+func (l *TaskLifecycleListener) baseProps() map[string]any {
+	props := map[string]any{
+		"run_id":          l.runID,
+		"session_id_hash": HashIdentifier(l.sessionID),
+	}
+	if l.opts.Channel != "" {
+		props["channel"] = l.opts.Channel
+	}
+	if l.opts.Preset != "" {
+		props["preset"] = l.opts.Preset
+	}
+	if l.opts.Toolset != "" {
+		props["toolset"] = l.opts.Toolset
+	}
+	return props
+}
+
+// outcomeProps adds the duration and tool usage breakdown shared by
+// task_completed and task_failed.
+func (l *TaskLifecycleListener) outcomeProps() map[string]any {
+	props := l.baseProps()
+	props["duration_ms"] = l.opts.Now().Sub(l.startedAt).Milliseconds()
+	props["iterations"] = l.iterations
+	toolCalls := make(map[string]int, len(l.toolCalls))
+	total := 0
+	for name, count := range l.toolCalls {
+		toolCalls[name] = count
+		total += count
+	}
+	props["tool_calls"] = toolCalls
+	props["tool_call_count"] = total
+	props["tool_error_count"] = l.toolErrors
+	return props
+}
+
+func (l *TaskLifecycleListener) capture(event string, props map[string]any) {
+	distinctID := HashIdentifier(l.opts.UserID)
+	if distinctID == "" {
+		distinctID = HashIdentifier(l.sessionID)
+	}
+	_ = l.client.Capture(l.ctx, distinctID, event, props)
+}
+
+// taskErrorClass maps a terminal failure to a coarse label. Raw error text
+// is never captured because it can carry user content.
+func taskErrorClass(err error, message string) string {
+	lower := strings.ToLower(message)
+	switch {
+	case errors.Is(err, context.Canceled) || strings.Contains(lower, "context canceled"):
+		return "cancelled"
+	case errors.Is(err, context.DeadlineExceeded) || strings.Contains(lower, "deadline exceeded") || strings.Contains(lower, "timeout"):
+		return "timeout"
+	case err == nil:
+		return "unknown"
+	case coreerrors.IsDegraded(err):
+		return "degraded"
+	case coreerrors.IsTransient(err):
+		return "transient"
+	case coreerrors.IsPermanent(err):
+		return "permanent"
+	default:
+		return "unknown"
+	}
+}
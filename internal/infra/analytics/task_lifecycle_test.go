@@ -0,0 +1,174 @@
+package analytics
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	domain "alex/internal/domain/agent"
+	agent "alex/internal/domain/agent/ports/agent"
+)
+
+type capturedEvent struct {
+	distinctID string
+	event      string
+	props      map[string]any
+}
+
+type recordingClient struct {
+	mu       sync.Mutex
+	captured []capturedEvent
+}
+
+func (c *recordingClient) Capture(_ context.Context, distinctID string, event string, properties map[string]any) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.captured = append(c.captured, capturedEvent{distinctID: distinctID, event: event, props: properties})
+	return nil
+}
+
+func (c *recordingClient) Close() error { return nil }
+
+func (c *recordingClient) events() []capturedEvent {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]capturedEvent(nil), c.captured...)
+}
+
+func newLifecycleListener(t *testing.T, next agent.EventListener, opts TaskLifecycleOptions) (agent.EventListener, *recordingClient) {
+	t.Helper()
+	client := &recordingClient{}
+	clock := time.Unix(1_700_000_000, 0)
+	opts.Now = func() time.Time {
+		clock = clock.Add(time.Second)
+		return clock
+	}
+	return NewTaskLifecycleListener(context.Background(), next, client, opts), client
+}
+
+func coreBase(runID string) domain.BaseEvent {
+	return domain.NewBaseEvent(agent.LevelCore, "session-1", runID, "", time.Now())
+}
+
+func TestTaskLifecycleListenerCompletedRun(t *testing.T) {
+	var forwarded int
+	next := domain.EventListenerFunc(func(agent.AgentEvent) { forwarded++ })
+	listener, client := newLifecycleListener(t, next, TaskLifecycleOptions{
+		Channel: "lark",
+		Preset:  "default",
+		Toolset: "full",
+		UserID:  "ou_user",
+	})
+
+	events := []agent.AgentEvent{
+		domain.NewNodeStartedEvent(coreBase("run-1"), 1, 3, 0, "", nil, nil),
+		domain.NewToolCompletedEvent(coreBase("run-1"), "c1", "web_search", "ok", nil, time.Millisecond, nil, nil),
+		domain.NewToolCompletedEvent(coreBase("run-1"), "c2", "web_search", "", errors.New("boom"), time.Millisecond, nil, nil),
+		domain.NewNodeStartedEvent(coreBase("run-1"), 2, 3, 0, "", nil, nil),
+		domain.NewToolCompletedEvent(coreBase("run-1"), "c3", "read_file", "ok", nil, time.Millisecond, nil, nil),
+		// Subagent tool calls are attributed through the parent's own tools.
+		domain.NewToolCompletedEvent(domain.NewBaseEvent(agent.LevelSubagent, "session-1", "sub-1", "run-1", time.Now()), "c4", "shell_exec", "ok", nil, time.Millisecond, nil, nil),
+		domain.NewResultFinalEvent(coreBase("run-1"), "partial", 2, 120, "final_answer", 0, true, false, nil),
+		domain.NewResultFinalEvent(coreBase("run-1"), "done", 2, 150, "final_answer", 4*time.Second, false, true, nil),
+	}
+	for _, evt := range events {
+		listener.OnEvent(evt)
+	}
+
+	if forwarded != len(events) {
+		t.Fatalf("expected all %d events forwarded, got %d", len(events), forwarded)
+	}
+	captured := client.events()
+	if len(captured) != 2 {
+		t.Fatalf("expected task_started and task_completed, got %+v", captured)
+	}
+
+	started := captured[0]
+	if started.event != EventTaskStarted {
+		t.Fatalf("expected %s first, got %s", EventTaskStarted, started.event)
+	}
+	if started.props["channel"] != "lark" || started.props["preset"] != "default" || started.props["toolset"] != "full" {
+		t.Fatalf("unexpected started props: %+v", started.props)
+	}
+	if started.props["session_id_hash"] != HashIdentifier("session-1") {
+		t.Fatalf("expected hashed session id, got %v", started.props["session_id_hash"])
+	}
+
+	completed := captured[1]
+	if completed.event != EventTaskCompleted {
+		t.Fatalf("expected %s, got %s", EventTaskCompleted, completed.event)
+	}
+	if completed.props["iterations"] != 2 || completed.props["tokens"] != 150 || completed.props["stop_reason"] != "final_answer" {
+		t.Fatalf("unexpected completion props: %+v", completed.props)
+	}
+	if completed.props["duration_ms"] != int64(4000) {
+		t.Fatalf("expected duration from final result, got %v", completed.props["duration_ms"])
+	}
+	toolCalls, ok := completed.props["tool_calls"].(map[string]int)
+	if !ok || toolCalls["web_search"] != 2 || toolCalls["read_file"] != 1 || len(toolCalls) != 2 {
+		t.Fatalf("unexpected tool breakdown: %+v", completed.props["tool_calls"])
+	}
+	if completed.props["tool_call_count"] != 3 || completed.props["tool_error_count"] != 1 {
+		t.Fatalf("unexpected tool totals: %+v", completed.props)
+	}
+
+	for _, evt := range captured {
+		if evt.distinctID != HashIdentifier("ou_user") {
+			t.Fatalf("expected hashed user id as distinct id, got %q", evt.distinctID)
+		}
+		for key, value := range evt.props {
+			if s, ok := value.(string); ok && (strings.Contains(s, "ou_user") || s == "session-1") {
+				t.Fatalf("raw identifier leaked in %s=%q", key, s)
+			}
+		}
+	}
+}
+
+func TestTaskLifecycleListenerFailedRun(t *testing.T) {
+	listener, client := newLifecycleListener(t, nil, TaskLifecycleOptions{Channel: "web"})
+
+	listener.OnEvent(domain.NewNodeStartedEvent(coreBase("run-2"), 1, 3, 0, "", nil, nil))
+	listener.OnEvent(domain.NewNodeFailedEvent(coreBase("run-2"), 1, "think", errors.New("retry me"), true))
+	listener.OnEvent(domain.NewNodeFailedEvent(coreBase("run-2"), 1, "think", context.DeadlineExceeded, false))
+	listener.OnEvent(domain.NewResultFinalEvent(coreBase("run-2"), "", 1, 10, "error", 0, false, true, nil))
+
+	captured := client.events()
+	if len(captured) != 2 || captured[1].event != EventTaskFailed {
+		t.Fatalf("expected task_started then a single task_failed, got %+v", captured)
+	}
+	failed := captured[1]
+	if failed.props["error_class"] != "timeout" || failed.props["phase"] != "think" {
+		t.Fatalf("unexpected failure props: %+v", failed.props)
+	}
+	if failed.distinctID != HashIdentifier("session-1") {
+		t.Fatalf("expected hashed session id as distinct id, got %q", failed.distinctID)
+	}
+}
+
+func TestTaskErrorClass(t *testing.T) {
+	cases := []struct {
+		err     error
+		message string
+		want    string
+	}{
+		{err: context.Canceled, want: "cancelled"},
+		{message: "request timeout after 30s", want: "timeout"},
+		{err: errors.New("bad request"), message: "bad request", want: "permanent"},
+		{message: "boom", want: "unknown"},
+	}
+	for _, tc := range cases {
+		if got := taskErrorClass(tc.err, tc.message); got != tc.want {
+			t.Errorf("taskErrorClass(%v, %q) = %q, want %q", tc.err, tc.message, got, tc.want)
+		}
+	}
+}
+
+func TestNewTaskLifecycleListenerWithoutClientReturnsNext(t *testing.T) {
+	next := agent.NoopEventListener{}
+	if got := NewTaskLifecycleListener(context.Background(), next, nil, TaskLifecycleOptions{}); got != agent.EventListener(next) {
+		t.Fatalf("expected wrapped listener to be returned unchanged, got %T", got)
+	}
+}
@@ -59,6 +59,9 @@ func TestTrackingPlanMatchesImplementedEvents(t *testing.T) {
 		EventTaskExecutionCompleted,
 		EventTaskExecutionFailed,
 		EventTaskExecutionCancelled,
+		EventTaskStarted,
+		EventTaskCompleted,
+		EventTaskFailed,
 	}
 
 	for _, event := range serverEvents {
@@ -8,8 +8,8 @@ import (
 )
 
 type EnvironmentPayload struct {
-	Host     map[string]string
-	Captured time.Time
+	Host     map[string]string `json:"host"`
+	Captured time.Time         `json:"captured"`
 }
 
 type EnvironmentListener func(EnvironmentPayload)
@@ -22,9 +22,11 @@ var (
 	listenerSeq int
 )
 
-// PublishEnvironments stores the latest environment payload and notifies subscribers.
+// PublishEnvironments stores the latest environment payload, records it in
+// the KindEnvironment history and notifies subscribers.
 func PublishEnvironments(payload EnvironmentPayload) {
 	clone := clonePayload(payload)
+	Record(KindEnvironment, clonePayload(payload))
 
 	envMu.Lock()
 	latestEnv = clone
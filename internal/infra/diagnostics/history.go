@@ -0,0 +1,136 @@
+package diagnostics
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// KindEnvironment is the history kind recorded by PublishEnvironments.
+const KindEnvironment = "environment"
+
+// DefaultHistorySize is the number of payloads retained per kind.
+const DefaultHistorySize = 50
+
+// Entry is one recorded diagnostics payload.
+type Entry struct {
+	Kind     string
+	Payload  any
+	Recorded time.Time
+}
+
+// ring keeps the last len(items) entries of one kind; next is the slot the
+// following entry overwrites.
+type ring struct {
+	items []Entry
+	next  int
+	full  bool
+}
+
+var (
+	historyMu   sync.RWMutex
+	historySize = DefaultHistorySize
+	history     = map[string]*ring{}
+)
+
+// SetHistorySize changes how many payloads are kept per kind. Non-positive
+// sizes fall back to DefaultHistorySize. Existing entries are trimmed to the
+// newest ones that fit.
+func SetHistorySize(size int) {
+	if size <= 0 {
+		size = DefaultHistorySize
+	}
+	historyMu.Lock()
+	defer historyMu.Unlock()
+	historySize = size
+	for kind, buf := range history {
+		history[kind] = resizeRing(buf, size)
+	}
+}
+
+// Record appends payload to the history of kind. Payloads must not be
+// mutated after recording.
+func Record(kind string, payload any) {
+	recordAt(kind, payload, time.Now())
+}
+
+func recordAt(kind string, payload any, at time.Time) {
+	if kind == "" {
+		return
+	}
+	historyMu.Lock()
+	defer historyMu.Unlock()
+	buf := history[kind]
+	if buf == nil {
+		buf = &ring{items: make([]Entry, historySize)}
+		history[kind] = buf
+	}
+	buf.items[buf.next] = Entry{Kind: kind, Payload: payload, Recorded: at}
+	buf.next = (buf.next + 1) % len(buf.items)
+	if buf.next == 0 {
+		buf.full = true
+	}
+}
+
+// Recent returns up to limit entries of kind, newest first. A non-positive
+// limit returns everything retained.
+func Recent(kind string, limit int) []Entry {
+	historyMu.RLock()
+	defer historyMu.RUnlock()
+	buf := history[kind]
+	if buf == nil {
+		return nil
+	}
+	entries := buf.newestFirst()
+	if limit > 0 && len(entries) > limit {
+		entries = entries[:limit]
+	}
+	return entries
+}
+
+// LatestByKind returns the newest entry of every recorded kind, sorted by
+// kind.
+func LatestByKind() []Entry {
+	historyMu.RLock()
+	defer historyMu.RUnlock()
+	latest := make([]Entry, 0, len(history))
+	for _, buf := range history {
+		if entries := buf.newestFirst(); len(entries) > 0 {
+			latest = append(latest, entries[0])
+		}
+	}
+	sort.Slice(latest, func(i, j int) bool { return latest[i].Kind < latest[j].Kind })
+	return latest
+}
+
+func (r *ring) len() int {
+	if r.full {
+		return len(r.items)
+	}
+	return r.next
+}
+
+func (r *ring) newestFirst() []Entry {
+	n := r.len()
+	entries := make([]Entry, 0, n)
+	for i := 1; i <= n; i++ {
+		entries = append(entries, r.items[(r.next-i+len(r.items))%len(r.items)])
+	}
+	return entries
+}
+
+func resizeRing(r *ring, size int) *ring {
+	entries := r.newestFirst()
+	if len(entries) > size {
+		entries = entries[:size]
+	}
+	resized := &ring{items: make([]Entry, size)}
+	for i := len(entries) - 1; i >= 0; i-- {
+		resized.items[resized.next] = entries[i]
+		resized.next = (resized.next + 1) % size
+		if resized.next == 0 {
+			resized.full = true
+		}
+	}
+	return resized
+}
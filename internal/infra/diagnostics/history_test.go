@@ -0,0 +1,84 @@
+package diagnostics
+
+import (
+	"testing"
+	"time"
+)
+
+func resetHistory(t *testing.T, size int) {
+	t.Helper()
+	historyMu.Lock()
+	history = map[string]*ring{}
+	historyMu.Unlock()
+	SetHistorySize(size)
+	t.Cleanup(func() {
+		historyMu.Lock()
+		history = map[string]*ring{}
+		historyMu.Unlock()
+		SetHistorySize(DefaultHistorySize)
+	})
+}
+
+func TestRecentKeepsNewestEntriesPerKind(t *testing.T) {
+	resetHistory(t, 3)
+	base := time.Now()
+	for i := 0; i < 5; i++ {
+		recordAt("sandbox_progress", i, base.Add(time.Duration(i)*time.Second))
+	}
+	recordAt("other", "x", base)
+
+	entries := Recent("sandbox_progress", 0)
+	if len(entries) != 3 {
+		t.Fatalf("expected 3 retained entries, got %d", len(entries))
+	}
+	for i, want := range []int{4, 3, 2} {
+		if entries[i].Payload != want || entries[i].Kind != "sandbox_progress" {
+			t.Fatalf("entry %d = %+v, want payload %d", i, entries[i], want)
+		}
+	}
+	if limited := Recent("sandbox_progress", 2); len(limited) != 2 || limited[0].Payload != 4 {
+		t.Fatalf("unexpected limited entries %+v", limited)
+	}
+	if missing := Recent("missing", 10); len(missing) != 0 {
+		t.Fatalf("expected no entries for unknown kind, got %+v", missing)
+	}
+}
+
+func TestLatestByKindAndResize(t *testing.T) {
+	resetHistory(t, 4)
+	for i := 0; i < 4; i++ {
+		Record("b", i)
+	}
+	Record("a", "only")
+
+	latest := LatestByKind()
+	if len(latest) != 2 || latest[0].Kind != "a" || latest[1].Payload != 3 {
+		t.Fatalf("unexpected latest entries %+v", latest)
+	}
+
+	SetHistorySize(2)
+	entries := Recent("b", 0)
+	if len(entries) != 2 || entries[0].Payload != 3 || entries[1].Payload != 2 {
+		t.Fatalf("expected newest entries after shrink, got %+v", entries)
+	}
+	Record("b", 4)
+	if entries := Recent("b", 0); len(entries) != 2 || entries[0].Payload != 4 {
+		t.Fatalf("unexpected entries after record, got %+v", entries)
+	}
+}
+
+func TestPublishEnvironmentsRecordsHistory(t *testing.T) {
+	resetHistory(t, DefaultHistorySize)
+	host := map[string]string{"A": "1"}
+	PublishEnvironments(EnvironmentPayload{Host: host, Captured: time.Now()})
+	host["A"] = "mutated"
+
+	entries := Recent(KindEnvironment, 1)
+	if len(entries) != 1 {
+		t.Fatalf("expected environment entry, got %+v", entries)
+	}
+	payload, ok := entries[0].Payload.(EnvironmentPayload)
+	if !ok || payload.Host["A"] != "1" {
+		t.Fatalf("unexpected recorded payload %+v", entries[0].Payload)
+	}
+}
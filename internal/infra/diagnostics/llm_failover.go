@@ -0,0 +1,27 @@
+package diagnostics
+
+import "time"
+
+// KindLLMFailover is the history kind recorded by PublishLLMFailover.
+const KindLLMFailover = "llm_failover"
+
+// LLMFailoverPayload describes one call that was served by a fallback
+// provider because the requested provider failed at the provider level.
+type LLMFailoverPayload struct {
+	FromProvider string    `json:"from_provider"`
+	FromModel    string    `json:"from_model"`
+	ToProvider   string    `json:"to_provider"`
+	ToModel      string    `json:"to_model"`
+	Reason       string    `json:"reason"`
+	Skipped      []string  `json:"skipped,omitempty"` // fallbacks passed over before ToProvider answered
+	Occurred     time.Time `json:"occurred"`
+}
+
+// PublishLLMFailover records a failover in the KindLLMFailover history.
+func PublishLLMFailover(payload LLMFailoverPayload) {
+	if payload.Occurred.IsZero() {
+		payload.Occurred = time.Now()
+	}
+	payload.Skipped = append([]string(nil), payload.Skipped...)
+	Record(KindLLMFailover, payload)
+}
@@ -0,0 +1,291 @@
+package environment
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"alex/internal/shared/async"
+	"alex/internal/shared/logging"
+)
+
+// probeTimeout bounds each runtime probe; a probe that overruns is skipped
+// so a hung nvidia-smi or docker daemon never stalls summary collection.
+const probeTimeout = time.Second
+
+const cgroupRoot = "/sys/fs/cgroup"
+
+// runtimeDetails holds the GPU, container and resource fields of a Summary.
+type runtimeDetails struct {
+	GPUs              []string
+	ContainerRuntimes []string
+	Container         string
+	ResourceLimits    string
+	DiskSpace         string
+}
+
+// collectRuntimeDetails runs every probe concurrently, each bounded by
+// probeTimeout.
+func collectRuntimeDetails(workingDir string) runtimeDetails {
+	logger := logging.NewComponentLogger("EnvironmentProbes")
+	var (
+		mu      sync.Mutex
+		details runtimeDetails
+		wg      sync.WaitGroup
+	)
+	probe := func(name string, fn func(ctx context.Context, d *runtimeDetails)) {
+		wg.Add(1)
+		async.Go(logger, "environment.probe."+name, func() {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(context.Background(), probeTimeout)
+			defer cancel()
+			var local runtimeDetails
+			done := make(chan struct{})
+			async.Go(logger, "environment.probe."+name+".run", func() {
+				defer close(done)
+				fn(ctx, &local)
+			})
+			select {
+			case <-done:
+			case <-ctx.Done():
+				return
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			mergeRuntimeDetails(&details, local)
+		})
+	}
+
+	probe("gpu", func(ctx context.Context, d *runtimeDetails) {
+		d.GPUs = parseNvidiaSMI(runProbeCommand(ctx, "nvidia-smi", "--query-gpu=name,driver_version,memory.total", "--format=csv,noheader"))
+	})
+	probe("container_runtimes", func(ctx context.Context, d *runtimeDetails) {
+		d.ContainerRuntimes = detectContainerRuntimes(ctx)
+	})
+	probe("container", func(_ context.Context, d *runtimeDetails) {
+		d.Container = detectContainer("/", os.Getenv)
+	})
+	probe("cgroup", func(_ context.Context, d *runtimeDetails) {
+		d.ResourceLimits = readCgroupLimits(cgroupRoot)
+	})
+	probe("disk", func(_ context.Context, d *runtimeDetails) {
+		d.DiskSpace = diskSpace(workingDir)
+	})
+
+	wg.Wait()
+	return details
+}
+
+func mergeRuntimeDetails(dst *runtimeDetails, src runtimeDetails) {
+	if len(src.GPUs) > 0 {
+		dst.GPUs = src.GPUs
+	}
+	if len(src.ContainerRuntimes) > 0 {
+		dst.ContainerRuntimes = src.ContainerRuntimes
+	}
+	if src.Container != "" {
+		dst.Container = src.Container
+	}
+	if src.ResourceLimits != "" {
+		dst.ResourceLimits = src.ResourceLimits
+	}
+	if src.DiskSpace != "" {
+		dst.DiskSpace = src.DiskSpace
+	}
+}
+
+func runProbeCommand(ctx context.Context, name string, args ...string) string {
+	if _, err := exec.LookPath(name); err != nil {
+		return ""
+	}
+	output, err := exec.CommandContext(ctx, name, args...).Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(output))
+}
+
+// parseNvidiaSMI renders `nvidia-smi --query-gpu=name,driver_version,memory.total
+// --format=csv,noheader` output as "model (driver X, N MiB)" per GPU.
+func parseNvidiaSMI(output string) []string {
+	var gpus []string
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Split(line, ",")
+		if len(fields) != 3 {
+			continue
+		}
+		name := strings.TrimSpace(fields[0])
+		if name == "" {
+			continue
+		}
+		gpus = append(gpus, fmt.Sprintf("%s (driver %s, %s)", name, strings.TrimSpace(fields[1]), strings.TrimSpace(fields[2])))
+	}
+	return gpus
+}
+
+var containerRuntimeChecks = []struct {
+	Program string
+	Socket  string
+	Args    []string
+}{
+	{Program: "docker", Socket: "/var/run/docker.sock", Args: []string{"version", "--format", "{{.Server.Version}}"}},
+	{Program: "containerd", Socket: "/run/containerd/containerd.sock", Args: []string{"--version"}},
+	{Program: "podman", Args: []string{"version", "--format", "{{.Version}}"}},
+}
+
+// detectContainerRuntimes reports container runtimes whose CLI or socket is
+// present, with the server version when the runtime answers in time.
+func detectContainerRuntimes(ctx context.Context) []string {
+	var runtimes []string
+	for _, check := range containerRuntimeChecks {
+		_, cliErr := exec.LookPath(check.Program)
+		socket := check.Socket != "" && fileExists(check.Socket)
+		if cliErr != nil && !socket {
+			continue
+		}
+		entry := check.Program
+		if cliErr == nil {
+			if version := firstLine(runProbeCommand(ctx, check.Program, check.Args...)); version != "" {
+				entry = fmt.Sprintf("%s %s", check.Program, strings.TrimPrefix(version, check.Program+" "))
+			} else if check.Program == "docker" {
+				entry = "docker (daemon unreachable)"
+			}
+		}
+		runtimes = append(runtimes, entry)
+	}
+	return runtimes
+}
+
+// detectContainer reports the container environment the process runs in,
+// or "" on a bare host. root is "/" outside tests.
+func detectContainer(root string, getenv func(string) string) string {
+	if getenv("KUBERNETES_SERVICE_HOST") != "" {
+		return "kubernetes"
+	}
+	if fileExists(filepath.Join(root, ".dockerenv")) {
+		return "docker"
+	}
+	if fileExists(filepath.Join(root, "run", ".containerenv")) {
+		return "podman"
+	}
+	data, err := os.ReadFile(filepath.Join(root, "proc", "1", "cgroup"))
+	if err != nil {
+		return ""
+	}
+	content := string(data)
+	for _, marker := range []string{"kubepods", "docker", "containerd", "lxc"} {
+		if strings.Contains(content, marker) {
+			if marker == "kubepods" {
+				return "kubernetes"
+			}
+			return marker
+		}
+	}
+	return ""
+}
+
+// readCgroupLimits reports the CPU and memory limits of the current cgroup,
+// e.g. "cgroup v2: cpu=2 cores, memory=4.0 GiB". Unlimited values are
+// reported as "unlimited".
+func readCgroupLimits(root string) string {
+	if cpuMax, err := os.ReadFile(filepath.Join(root, "cpu.max")); err == nil {
+		memMax, _ := os.ReadFile(filepath.Join(root, "memory.max"))
+		return fmt.Sprintf("cgroup v2: cpu=%s, memory=%s",
+			parseCgroupV2CPU(string(cpuMax)), parseCgroupMemory(string(memMax)))
+	}
+	quota, quotaErr := os.ReadFile(filepath.Join(root, "cpu", "cpu.cfs_quota_us"))
+	period, _ := os.ReadFile(filepath.Join(root, "cpu", "cpu.cfs_period_us"))
+	mem, memErr := os.ReadFile(filepath.Join(root, "memory", "memory.limit_in_bytes"))
+	if quotaErr != nil && memErr != nil {
+		return ""
+	}
+	return fmt.Sprintf("cgroup v1: cpu=%s, memory=%s",
+		formatCPUQuota(strings.TrimSpace(string(quota)), strings.TrimSpace(string(period))), parseCgroupMemory(string(mem)))
+}
+
+func parseCgroupV2CPU(content string) string {
+	fields := strings.Fields(content)
+	if len(fields) == 0 {
+		return "unknown"
+	}
+	period := "100000"
+	if len(fields) > 1 {
+		period = fields[1]
+	}
+	return formatCPUQuota(fields[0], period)
+}
+
+func formatCPUQuota(quota, period string) string {
+	if quota == "" || quota == "max" || quota == "-1" {
+		return "unlimited"
+	}
+	q, qErr := strconv.ParseFloat(quota, 64)
+	p, pErr := strconv.ParseFloat(period, 64)
+	if qErr != nil || pErr != nil || p <= 0 {
+		return "unknown"
+	}
+	return strconv.FormatFloat(q/p, 'f', -1, 64) + " cores"
+}
+
+// cgroupV1Unlimited is the smallest memory.limit_in_bytes treated as "no
+// limit"; v1 reports unlimited as a page-rounded int64 max.
+const cgroupV1Unlimited = 1 << 62
+
+func parseCgroupMemory(content string) string {
+	value := strings.TrimSpace(content)
+	if value == "" {
+		return "unknown"
+	}
+	if value == "max" {
+		return "unlimited"
+	}
+	bytes, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return "unknown"
+	}
+	if bytes >= cgroupV1Unlimited {
+		return "unlimited"
+	}
+	return formatBytes(bytes)
+}
+
+func diskSpace(dir string) string {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(dir, &stat); err != nil {
+		return ""
+	}
+	blockSize := uint64(stat.Bsize)
+	return fmt.Sprintf("%s free of %s (%s)", formatBytes(stat.Bavail*blockSize), formatBytes(stat.Blocks*blockSize), dir)
+}
+
+func formatBytes(n uint64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	value := float64(n)
+	suffixes := []string{"KiB", "MiB", "GiB", "TiB", "PiB"}
+	i := -1
+	for value >= unit && i < len(suffixes)-1 {
+		value /= unit
+		i++
+	}
+	return fmt.Sprintf("%.1f %s", value, suffixes[i])
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+func firstLine(s string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(s), "\n")
+	return strings.TrimSpace(line)
+}
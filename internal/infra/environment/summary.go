@@ -24,6 +24,17 @@ type Summary struct {
 	Kernel           string
 	Capabilities     []string
 	EnvironmentHints []string
+	// GPUs lists detected GPUs as "model (driver X, N MiB)".
+	GPUs []string
+	// ContainerRuntimes lists available runtimes, e.g. "docker 24.0.7".
+	ContainerRuntimes []string
+	// Container names the container environment the process runs in
+	// ("docker", "kubernetes", …); empty on a bare host.
+	Container string
+	// ResourceLimits describes cgroup CPU and memory limits.
+	ResourceLimits string
+	// DiskSpace reports free space on the working directory's mount.
+	DiskSpace string
 }
 
 // IsEmpty reports whether the summary has any populated fields.
@@ -33,7 +44,12 @@ func (s Summary) IsEmpty() bool {
 		utils.IsBlank(s.OperatingSystem) &&
 		utils.IsBlank(s.Kernel) &&
 		len(s.Capabilities) == 0 &&
-		len(s.EnvironmentHints) == 0
+		len(s.EnvironmentHints) == 0 &&
+		len(s.GPUs) == 0 &&
+		len(s.ContainerRuntimes) == 0 &&
+		utils.IsBlank(s.Container) &&
+		utils.IsBlank(s.ResourceLimits) &&
+		utils.IsBlank(s.DiskSpace)
 }
 
 // FormatSummary renders the summary into a human-readable multi-line description.
@@ -71,6 +87,21 @@ func FormatSummary(summary Summary) string {
 	if len(summary.EnvironmentHints) > 0 {
 		builder.WriteString(fmt.Sprintf("- Runtime environment: %s\n", sortedJoin(summary.EnvironmentHints, ", ")))
 	}
+	if len(summary.GPUs) > 0 {
+		builder.WriteString(fmt.Sprintf("- GPUs: %s\n", strings.Join(summary.GPUs, "; ")))
+	}
+	if summary.Container != "" {
+		builder.WriteString(fmt.Sprintf("- Running inside container: %s\n", summary.Container))
+	}
+	if len(summary.ContainerRuntimes) > 0 {
+		builder.WriteString(fmt.Sprintf("- Container runtimes: %s\n", strings.Join(summary.ContainerRuntimes, ", ")))
+	}
+	if summary.ResourceLimits != "" {
+		builder.WriteString(fmt.Sprintf("- Resource limits: %s\n", summary.ResourceLimits))
+	}
+	if summary.DiskSpace != "" {
+		builder.WriteString(fmt.Sprintf("- Disk space: %s\n", summary.DiskSpace))
+	}
 
 	return strings.TrimSpace(builder.String())
 }
@@ -106,6 +137,21 @@ func SummaryMap(summary Summary) map[string]string {
 	if len(summary.EnvironmentHints) > 0 {
 		result["runtime_environment"] = sortedJoin(summary.EnvironmentHints, ", ")
 	}
+	if len(summary.GPUs) > 0 {
+		result["gpus"] = strings.Join(summary.GPUs, "; ")
+	}
+	if summary.Container != "" {
+		result["container"] = summary.Container
+	}
+	if len(summary.ContainerRuntimes) > 0 {
+		result["container_runtimes"] = strings.Join(summary.ContainerRuntimes, ", ")
+	}
+	if summary.ResourceLimits != "" {
+		result["resource_limits"] = summary.ResourceLimits
+	}
+	if summary.DiskSpace != "" {
+		result["disk_space"] = summary.DiskSpace
+	}
 
 	return result
 }
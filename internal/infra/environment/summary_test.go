@@ -1,6 +1,8 @@
 package environment
 
 import (
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 )
@@ -75,3 +77,99 @@ func TestCollectEnvironmentHintsFromMapRedactsSecrets(t *testing.T) {
 		t.Fatalf("expected unknown ALEX_* env keys to be omitted from hints, got %q", rendered)
 	}
 }
+
+func TestFormatSummaryIncludesRuntimeDetails(t *testing.T) {
+	summary := Summary{
+		GPUs:              []string{"NVIDIA A100 (driver 535.104.05, 40960 MiB)"},
+		ContainerRuntimes: []string{"docker 24.0.7"},
+		Container:         "kubernetes",
+		ResourceLimits:    "cgroup v2: cpu=2 cores, memory=4.0 GiB",
+		DiskSpace:         "10.0 GiB free of 50.0 GiB (/workspace)",
+	}
+	formatted := FormatSummary(summary)
+	for _, fragment := range []string{
+		"GPUs: NVIDIA A100 (driver 535.104.05, 40960 MiB)",
+		"Running inside container: kubernetes",
+		"Container runtimes: docker 24.0.7",
+		"Resource limits: cgroup v2: cpu=2 cores, memory=4.0 GiB",
+		"Disk space: 10.0 GiB free of 50.0 GiB (/workspace)",
+	} {
+		if !strings.Contains(formatted, fragment) {
+			t.Fatalf("expected formatted summary to contain %q, got %q", fragment, formatted)
+		}
+	}
+	m := SummaryMap(summary)
+	for _, key := range []string{"gpus", "container", "container_runtimes", "resource_limits", "disk_space"} {
+		if m[key] == "" {
+			t.Fatalf("expected summary map key %q, got %v", key, m)
+		}
+	}
+}
+
+func TestParseNvidiaSMI(t *testing.T) {
+	output := "NVIDIA A100-SXM4-40GB, 535.104.05, 40960 MiB\nTesla T4, 535.104.05, 15360 MiB\n\ngarbage"
+	gpus := parseNvidiaSMI(output)
+	if len(gpus) != 2 || gpus[0] != "NVIDIA A100-SXM4-40GB (driver 535.104.05, 40960 MiB)" {
+		t.Fatalf("unexpected gpus %v", gpus)
+	}
+}
+
+func TestReadCgroupLimits(t *testing.T) {
+	v2 := t.TempDir()
+	writeTestFile(t, filepath.Join(v2, "cpu.max"), "200000 100000\n")
+	writeTestFile(t, filepath.Join(v2, "memory.max"), "4294967296\n")
+	if got := readCgroupLimits(v2); got != "cgroup v2: cpu=2 cores, memory=4.0 GiB" {
+		t.Fatalf("unexpected v2 limits %q", got)
+	}
+
+	writeTestFile(t, filepath.Join(v2, "cpu.max"), "max 100000\n")
+	writeTestFile(t, filepath.Join(v2, "memory.max"), "max\n")
+	if got := readCgroupLimits(v2); got != "cgroup v2: cpu=unlimited, memory=unlimited" {
+		t.Fatalf("unexpected unlimited v2 limits %q", got)
+	}
+
+	v1 := t.TempDir()
+	writeTestFile(t, filepath.Join(v1, "cpu", "cpu.cfs_quota_us"), "50000\n")
+	writeTestFile(t, filepath.Join(v1, "cpu", "cpu.cfs_period_us"), "100000\n")
+	writeTestFile(t, filepath.Join(v1, "memory", "memory.limit_in_bytes"), "9223372036854771712\n")
+	if got := readCgroupLimits(v1); got != "cgroup v1: cpu=0.5 cores, memory=unlimited" {
+		t.Fatalf("unexpected v1 limits %q", got)
+	}
+
+	if got := readCgroupLimits(t.TempDir()); got != "" {
+		t.Fatalf("expected no limits without cgroup files, got %q", got)
+	}
+}
+
+func TestDetectContainer(t *testing.T) {
+	noEnv := func(string) string { return "" }
+	root := t.TempDir()
+	if got := detectContainer(root, noEnv); got != "" {
+		t.Fatalf("expected bare host, got %q", got)
+	}
+	writeTestFile(t, filepath.Join(root, "proc", "1", "cgroup"), "0::/kubepods/besteffort/pod1234\n")
+	if got := detectContainer(root, noEnv); got != "kubernetes" {
+		t.Fatalf("expected kubernetes from cgroup, got %q", got)
+	}
+	writeTestFile(t, filepath.Join(root, ".dockerenv"), "")
+	if got := detectContainer(root, noEnv); got != "docker" {
+		t.Fatalf("expected docker from .dockerenv, got %q", got)
+	}
+}
+
+func TestCollectRuntimeDetailsReportsDiskSpace(t *testing.T) {
+	details := collectRuntimeDetails(t.TempDir())
+	if !strings.Contains(details.DiskSpace, "free of") {
+		t.Fatalf("expected disk space for temp dir, got %q", details.DiskSpace)
+	}
+}
+
+func writeTestFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("write %s: %v", path, err)
+	}
+}
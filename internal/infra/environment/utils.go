@@ -42,15 +42,21 @@ func CollectLocalSummary(maxFileEntries int) Summary {
 
 	capabilities := collectLocalCapabilities()
 	environmentHints := collectEnvironmentHints(8)
+	details := collectRuntimeDetails(workingDir)
 
 	return Summary{
-		WorkingDirectory: workingDir,
-		FileEntries:      files,
-		HasMoreFiles:     more,
-		OperatingSystem:  osDescription,
-		Kernel:           kernel,
-		Capabilities:     capabilities,
-		EnvironmentHints: environmentHints,
+		WorkingDirectory:  workingDir,
+		FileEntries:       files,
+		HasMoreFiles:      more,
+		OperatingSystem:   osDescription,
+		Kernel:            kernel,
+		Capabilities:      capabilities,
+		EnvironmentHints:  environmentHints,
+		GPUs:              details.GPUs,
+		ContainerRuntimes: details.ContainerRuntimes,
+		Container:         details.Container,
+		ResourceLimits:    details.ResourceLimits,
+		DiskSpace:         details.DiskSpace,
 	}
 }
 
@@ -0,0 +1,397 @@
+// Package httpcache is a shared on-disk HTTP response cache for agent fetch
+// tools. Entries are keyed by canonical URL (plus an optional partition for
+// auth-bearing requests), revalidated with ETag/Last-Modified, and bounded by
+// total size with least-recently-used eviction.
+//
+// No registered tool uses the cache yet: its only caller, web_fetch, is
+// retired from the core toolset.
+package httpcache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"alex/internal/infra/filestore"
+	runtimeconfig "alex/internal/shared/config"
+	jsonx "alex/internal/shared/json"
+	"alex/internal/shared/utils"
+)
+
+const (
+	indexDocVersion = 1
+	indexFilename   = "index.json"
+	bodySuffix      = ".body"
+
+	DefaultMaxBytes      int64 = 256 << 20
+	DefaultMaxEntryBytes int64 = 8 << 20
+	DefaultTTL                 = 15 * time.Minute
+	DefaultMaxAge              = 24 * time.Hour
+)
+
+// Config bounds cache size and freshness.
+type Config struct {
+	// Dir holds the index and response bodies. Empty keeps everything in memory.
+	Dir string
+	// MaxBytes caps the total stored body size (LRU eviction beyond it).
+	MaxBytes int64
+	// MaxEntries caps the number of stored responses (0 = unlimited).
+	MaxEntries int
+	// MaxEntryBytes skips caching responses larger than this.
+	MaxEntryBytes int64
+	// DefaultTTL is the freshness lifetime for responses without max-age.
+	DefaultTTL time.Duration
+	// MaxAge caps any freshness lifetime, including server-provided max-age.
+	MaxAge time.Duration
+	// CacheAuthRequests allows requests carrying credentials to be cached in
+	// their partition. When false they always bypass the cache.
+	CacheAuthRequests bool
+}
+
+// Entry is the stored metadata for a cached response.
+type Entry struct {
+	Key          string        `json:"key"`
+	URL          string        `json:"url"`
+	Host         string        `json:"host"`
+	Partition    string        `json:"partition,omitempty"`
+	StatusCode   int           `json:"status_code"`
+	Header       http.Header   `json:"header,omitempty"`
+	ETag         string        `json:"etag,omitempty"`
+	LastModified string        `json:"last_modified,omitempty"`
+	StoredAt     time.Time     `json:"stored_at"`
+	FreshFor     time.Duration `json:"fresh_for"`
+	Size         int64         `json:"size"`
+	LastAccess   time.Time     `json:"last_access"`
+}
+
+// Fresh reports whether the entry can be served without revalidation.
+func (e Entry) Fresh(now time.Time) bool {
+	return e.FreshFor > 0 && now.Sub(e.StoredAt) < e.FreshFor
+}
+
+type indexDoc struct {
+	Version int     `json:"version"`
+	Entries []Entry `json:"entries"`
+}
+
+// Stats summarizes cache occupancy.
+type Stats struct {
+	Entries int
+	Bytes   int64
+}
+
+// Cache stores response bodies with an LRU index.
+type Cache struct {
+	cfg   Config
+	dir   string
+	index *filestore.Collection[string, Entry]
+
+	mu     sync.Mutex
+	bodies map[string][]byte // in-memory mode only
+}
+
+// ResolveDir returns the cache directory.
+//
+// Priority:
+//  1. Explicit ALEX_WEB_CACHE_DIR.
+//  2. Sibling to the resolved config path (defaults to ~/.alex/cache/web).
+func ResolveDir(envLookup runtimeconfig.EnvLookup, homeDir func() (string, error)) string {
+	if envLookup == nil {
+		envLookup = runtimeconfig.DefaultEnvLookup
+	}
+	if value, ok := envLookup("ALEX_WEB_CACHE_DIR"); ok {
+		if trimmed := strings.TrimSpace(value); trimmed != "" {
+			return trimmed
+		}
+	}
+	configPath, _ := runtimeconfig.ResolveConfigPath(envLookup, homeDir)
+	return filepath.Join(filepath.Dir(configPath), "cache", "web")
+}
+
+// New opens (or creates) a cache.
+func New(cfg Config) (*Cache, error) {
+	cfg = normalizeConfig(cfg)
+	dir := strings.TrimSpace(cfg.Dir)
+	indexPath := ""
+	if dir != "" {
+		if err := filestore.EnsureDir(dir); err != nil {
+			return nil, fmt.Errorf("create web cache dir: %w", err)
+		}
+		indexPath = filepath.Join(dir, indexFilename)
+	}
+	index := filestore.NewCollection[string, Entry](filestore.CollectionConfig{
+		FilePath: indexPath,
+		Name:     "web_cache",
+	})
+	index.SetMarshalDoc(marshalIndex)
+	index.SetUnmarshalDoc(unmarshalIndex)
+	if err := index.Load(); err != nil {
+		return nil, fmt.Errorf("load web cache index: %w", err)
+	}
+	return &Cache{cfg: cfg, dir: dir, index: index, bodies: make(map[string][]byte)}, nil
+}
+
+func normalizeConfig(cfg Config) Config {
+	if cfg.MaxBytes <= 0 {
+		cfg.MaxBytes = DefaultMaxBytes
+	}
+	if cfg.MaxEntryBytes <= 0 {
+		cfg.MaxEntryBytes = DefaultMaxEntryBytes
+	}
+	if cfg.DefaultTTL <= 0 {
+		cfg.DefaultTTL = DefaultTTL
+	}
+	if cfg.MaxAge <= 0 {
+		cfg.MaxAge = DefaultMaxAge
+	}
+	return cfg
+}
+
+// Lookup returns the entry and body for key, marking it recently used.
+func (c *Cache) Lookup(key string) (Entry, []byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.index.Get(key)
+	if !ok {
+		return Entry{}, nil, false
+	}
+	body, err := c.readBody(key)
+	if err != nil {
+		c.removeLocked(key)
+		return Entry{}, nil, false
+	}
+	entry.LastAccess = c.index.Now()
+	_ = c.index.Put(key, entry)
+	return entry, body, true
+}
+
+// Store saves a response and evicts least-recently-used entries beyond the
+// configured bounds. Oversized bodies are not stored.
+func (c *Cache) Store(entry Entry, body []byte) error {
+	if int64(len(body)) > c.cfg.MaxEntryBytes {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.writeBody(entry.Key, body); err != nil {
+		return err
+	}
+	now := c.index.Now()
+	entry.Size = int64(len(body))
+	entry.LastAccess = now
+	if entry.StoredAt.IsZero() {
+		entry.StoredAt = now
+	}
+	var evicted []string
+	err := c.index.Mutate(func(items map[string]Entry) error {
+		items[entry.Key] = entry
+		evicted = evictLRU(items, c.cfg.MaxBytes, c.cfg.MaxEntries)
+		return nil
+	})
+	for _, key := range evicted {
+		c.deleteBody(key)
+	}
+	return err
+}
+
+// Touch refreshes an entry after a successful revalidation (304).
+func (c *Cache) Touch(key string, storedAt time.Time, freshFor time.Duration, header http.Header) (Entry, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var updated Entry
+	err := c.index.Mutate(func(items map[string]Entry) error {
+		entry, ok := items[key]
+		if !ok {
+			return errEntryMissing
+		}
+		entry.StoredAt = storedAt
+		entry.FreshFor = freshFor
+		entry.LastAccess = storedAt
+		mergeValidators(&entry, header)
+		items[key] = entry
+		updated = entry
+		return nil
+	})
+	return updated, err
+}
+
+// Remove deletes a single entry.
+func (c *Cache) Remove(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.removeLocked(key)
+}
+
+// PurgeDomain removes entries whose host equals domain or is a subdomain of
+// it. An empty domain purges everything. Returns the number removed.
+func (c *Cache) PurgeDomain(domain string) (int, error) {
+	domain = strings.TrimPrefix(utils.TrimLower(domain), ".")
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var removed []string
+	err := c.index.Mutate(func(items map[string]Entry) error {
+		for key, entry := range items {
+			if domain == "" || entry.Host == domain || strings.HasSuffix(entry.Host, "."+domain) {
+				delete(items, key)
+				removed = append(removed, key)
+			}
+		}
+		return nil
+	})
+	for _, key := range removed {
+		c.deleteBody(key)
+	}
+	return len(removed), err
+}
+
+// Stats returns current occupancy.
+func (c *Cache) Stats() Stats {
+	var stats Stats
+	c.index.ReadLocked(func(items map[string]Entry) {
+		stats.Entries = len(items)
+		for _, entry := range items {
+			stats.Bytes += entry.Size
+		}
+	})
+	return stats
+}
+
+var errEntryMissing = errors.New("cache entry missing")
+
+func (c *Cache) removeLocked(key string) {
+	_ = c.index.Delete(key)
+	c.deleteBody(key)
+}
+
+func (c *Cache) bodyPath(key string) string {
+	return filepath.Join(c.dir, hashKey(key)+bodySuffix)
+}
+
+func (c *Cache) readBody(key string) ([]byte, error) {
+	if c.dir == "" {
+		body, ok := c.bodies[key]
+		if !ok {
+			return nil, os.ErrNotExist
+		}
+		return body, nil
+	}
+	return os.ReadFile(c.bodyPath(key))
+}
+
+func (c *Cache) writeBody(key string, body []byte) error {
+	if c.dir == "" {
+		c.bodies[key] = append([]byte(nil), body...)
+		return nil
+	}
+	return filestore.AtomicWrite(c.bodyPath(key), body, 0o600)
+}
+
+func (c *Cache) deleteBody(key string) {
+	if c.dir == "" {
+		delete(c.bodies, key)
+		return
+	}
+	_ = os.Remove(c.bodyPath(key))
+}
+
+// evictLRU removes least-recently-used entries until both bounds hold and
+// returns the removed keys.
+func evictLRU(items map[string]Entry, maxBytes int64, maxEntries int) []string {
+	var total int64
+	for _, entry := range items {
+		total += entry.Size
+	}
+	if total <= maxBytes && (maxEntries <= 0 || len(items) <= maxEntries) {
+		return nil
+	}
+	ordered := make([]Entry, 0, len(items))
+	for _, entry := range items {
+		ordered = append(ordered, entry)
+	}
+	sort.Slice(ordered, func(i, j int) bool {
+		return ordered[i].LastAccess.Before(ordered[j].LastAccess)
+	})
+	var evicted []string
+	for _, entry := range ordered {
+		if total <= maxBytes && (maxEntries <= 0 || len(items) <= maxEntries) {
+			break
+		}
+		delete(items, entry.Key)
+		total -= entry.Size
+		evicted = append(evicted, entry.Key)
+	}
+	return evicted
+}
+
+// CanonicalURL normalizes a URL for cache keys: lowercase scheme and host,
+// default ports and fragments dropped, query parameters sorted.
+func CanonicalURL(raw string) (string, error) {
+	parsed, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil {
+		return "", err
+	}
+	parsed.Scheme = strings.ToLower(parsed.Scheme)
+	host := strings.ToLower(parsed.Hostname())
+	if port := parsed.Port(); port != "" && !isDefaultPort(parsed.Scheme, port) {
+		host = host + ":" + port
+	}
+	parsed.Host = host
+	parsed.Fragment = ""
+	parsed.RawFragment = ""
+	if parsed.Path == "" {
+		parsed.Path = "/"
+	}
+	if parsed.RawQuery != "" {
+		parsed.RawQuery = parsed.Query().Encode()
+	}
+	return parsed.String(), nil
+}
+
+// Key builds the cache key for a canonical URL within a partition.
+func Key(partition, canonicalURL string) string {
+	if partition == "" {
+		return canonicalURL
+	}
+	return partition + "|" + canonicalURL
+}
+
+func isDefaultPort(scheme, port string) bool {
+	return (scheme == "http" && port == "80") || (scheme == "https" && port == "443")
+}
+
+func hashKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+func marshalIndex(items map[string]Entry) ([]byte, error) {
+	doc := indexDoc{Version: indexDocVersion, Entries: make([]Entry, 0, len(items))}
+	for _, entry := range items {
+		doc.Entries = append(doc.Entries, entry)
+	}
+	sort.Slice(doc.Entries, func(i, j int) bool { return doc.Entries[i].Key < doc.Entries[j].Key })
+	return filestore.MarshalJSONIndent(doc)
+}
+
+func unmarshalIndex(data []byte) (map[string]Entry, error) {
+	var doc indexDoc
+	if err := jsonx.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("decode web cache index: %w", err)
+	}
+	items := make(map[string]Entry, len(doc.Entries))
+	for _, entry := range doc.Entries {
+		if entry.Key != "" {
+			items[entry.Key] = entry
+		}
+	}
+	return items, nil
+}
@@ -0,0 +1,231 @@
+package httpcache
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type testOrigin struct {
+	server       *httptest.Server
+	hits         atomic.Int32
+	conditionals atomic.Int32
+	cacheControl string
+}
+
+func newTestOrigin(t *testing.T, cacheControl string) *testOrigin {
+	t.Helper()
+	origin := &testOrigin{cacheControl: cacheControl}
+	origin.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		origin.hits.Add(1)
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			origin.conditionals.Add(1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Last-Modified", "Mon, 02 Jan 2006 15:04:05 GMT")
+		w.Header().Set("Content-Type", "text/plain")
+		if origin.cacheControl != "" {
+			w.Header().Set("Cache-Control", origin.cacheControl)
+		}
+		_, _ = w.Write([]byte("body for " + r.URL.Path))
+	}))
+	t.Cleanup(origin.server.Close)
+	return origin
+}
+
+func newTestFetcher(t *testing.T, cfg Config) (*Fetcher, *Cache) {
+	t.Helper()
+	if cfg.Dir == "" {
+		cfg.Dir = t.TempDir()
+	}
+	cache, err := New(cfg)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	return NewFetcher(nil, cache, 0), cache
+}
+
+func mustGet(t *testing.T, f *Fetcher, req Request) *Response {
+	t.Helper()
+	resp, err := f.Get(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Get(%s): %v", req.URL, err)
+	}
+	return resp
+}
+
+func TestFetcherServesFreshEntriesFromCache(t *testing.T) {
+	origin := newTestOrigin(t, "max-age=60")
+	fetcher, _ := newTestFetcher(t, Config{})
+
+	first := mustGet(t, fetcher, Request{URL: origin.server.URL + "/docs?b=2&a=1"})
+	if first.FromCache {
+		t.Fatal("first fetch must hit the origin")
+	}
+	second := mustGet(t, fetcher, Request{URL: origin.server.URL + "/docs?a=1&b=2#section"})
+	if !second.FromCache || second.Revalidated {
+		t.Fatalf("expected fresh cache hit for canonical-equal URL, got %+v", second)
+	}
+	if string(second.Body) != "body for /docs" {
+		t.Fatalf("unexpected cached body %q", second.Body)
+	}
+	if got := origin.hits.Load(); got != 1 {
+		t.Fatalf("expected 1 origin hit, got %d", got)
+	}
+}
+
+func TestFetcherRevalidatesStaleEntriesWith304(t *testing.T) {
+	origin := newTestOrigin(t, "max-age=60")
+	fetcher, _ := newTestFetcher(t, Config{})
+	clock := time.Now()
+	fetcher.now = func() time.Time { return clock }
+
+	mustGet(t, fetcher, Request{URL: origin.server.URL + "/page"})
+	clock = clock.Add(2 * time.Minute)
+
+	resp := mustGet(t, fetcher, Request{URL: origin.server.URL + "/page"})
+	if !resp.FromCache || !resp.Revalidated {
+		t.Fatalf("expected revalidated cache response, got %+v", resp)
+	}
+	if string(resp.Body) != "body for /page" {
+		t.Fatalf("expected cached body after 304, got %q", resp.Body)
+	}
+	if origin.conditionals.Load() != 1 {
+		t.Fatalf("expected one conditional request, got %d", origin.conditionals.Load())
+	}
+
+	// The 304 refreshed the entry, so the next read is fresh again.
+	clock = clock.Add(10 * time.Second)
+	again := mustGet(t, fetcher, Request{URL: origin.server.URL + "/page"})
+	if !again.FromCache || again.Revalidated || again.Age != 10*time.Second {
+		t.Fatalf("expected fresh hit aged 10s, got %+v", again)
+	}
+}
+
+func TestFetcherCapsServerMaxAge(t *testing.T) {
+	origin := newTestOrigin(t, "max-age=86400")
+	fetcher, _ := newTestFetcher(t, Config{MaxAge: time.Minute})
+	clock := time.Now()
+	fetcher.now = func() time.Time { return clock }
+
+	mustGet(t, fetcher, Request{URL: origin.server.URL + "/capped"})
+	clock = clock.Add(2 * time.Minute)
+	if resp := mustGet(t, fetcher, Request{URL: origin.server.URL + "/capped"}); !resp.Revalidated {
+		t.Fatalf("expected revalidation once the capped lifetime elapsed, got %+v", resp)
+	}
+}
+
+func TestFetcherHonorsNoStore(t *testing.T) {
+	origin := newTestOrigin(t, "no-store")
+	fetcher, cache := newTestFetcher(t, Config{})
+
+	mustGet(t, fetcher, Request{URL: origin.server.URL + "/secret"})
+	resp := mustGet(t, fetcher, Request{URL: origin.server.URL + "/secret"})
+	if resp.FromCache {
+		t.Fatal("no-store response must not be served from cache")
+	}
+	if cache.Stats().Entries != 0 {
+		t.Fatalf("expected no stored entries, got %+v", cache.Stats())
+	}
+	if origin.hits.Load() != 2 {
+		t.Fatalf("expected both requests to reach origin, got %d", origin.hits.Load())
+	}
+}
+
+func TestFetcherBypassesCacheForAuthRequests(t *testing.T) {
+	origin := newTestOrigin(t, "max-age=60")
+	header := http.Header{"Authorization": []string{"Bearer token"}}
+
+	fetcher, cache := newTestFetcher(t, Config{})
+	mustGet(t, fetcher, Request{URL: origin.server.URL + "/me", Header: header, Partition: "/ws/a"})
+	if resp := mustGet(t, fetcher, Request{URL: origin.server.URL + "/me", Header: header, Partition: "/ws/a"}); resp.FromCache {
+		t.Fatal("auth requests must bypass the cache by default")
+	}
+	if cache.Stats().Entries != 0 {
+		t.Fatalf("expected auth response not stored, got %+v", cache.Stats())
+	}
+
+	partitioned, _ := newTestFetcher(t, Config{CacheAuthRequests: true})
+	mustGet(t, partitioned, Request{URL: origin.server.URL + "/me", Header: header, Partition: "/ws/a"})
+	if resp := mustGet(t, partitioned, Request{URL: origin.server.URL + "/me", Header: header, Partition: "/ws/a"}); !resp.FromCache {
+		t.Fatal("expected cache hit within the same workspace partition")
+	}
+	if resp := mustGet(t, partitioned, Request{URL: origin.server.URL + "/me", Header: header, Partition: "/ws/b"}); resp.FromCache {
+		t.Fatal("partitions must not share auth-bearing entries")
+	}
+	if resp := mustGet(t, partitioned, Request{URL: origin.server.URL + "/me"}); resp.FromCache {
+		t.Fatal("anonymous requests must not see partitioned entries")
+	}
+}
+
+func TestCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	origin := newTestOrigin(t, "max-age=60")
+	// Each body is "body for /pN" (12 bytes); room for two entries.
+	fetcher, cache := newTestFetcher(t, Config{MaxBytes: 30})
+
+	mustGet(t, fetcher, Request{URL: origin.server.URL + "/p1"})
+	time.Sleep(2 * time.Millisecond)
+	mustGet(t, fetcher, Request{URL: origin.server.URL + "/p2"})
+	time.Sleep(2 * time.Millisecond)
+	mustGet(t, fetcher, Request{URL: origin.server.URL + "/p1"}) // touch p1
+	time.Sleep(2 * time.Millisecond)
+	mustGet(t, fetcher, Request{URL: origin.server.URL + "/p3"})
+
+	if stats := cache.Stats(); stats.Entries != 2 || stats.Bytes > 30 {
+		t.Fatalf("expected two entries within budget, got %+v", stats)
+	}
+	if resp := mustGet(t, fetcher, Request{URL: origin.server.URL + "/p1"}); !resp.FromCache {
+		t.Fatal("recently used entry should survive eviction")
+	}
+	if resp := mustGet(t, fetcher, Request{URL: origin.server.URL + "/p2"}); resp.FromCache {
+		t.Fatal("least recently used entry should have been evicted")
+	}
+}
+
+func TestCachePersistsAndPurgesByDomain(t *testing.T) {
+	dir := t.TempDir()
+	cache, err := New(Config{Dir: dir})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	for _, raw := range []string{"https://docs.example.com/a", "https://example.com/b", "https://other.org/c"} {
+		canonical, _ := CanonicalURL(raw)
+		if err := cache.Store(Entry{Key: Key("", canonical), URL: canonical, Host: hostOf(canonical), StatusCode: 200}, []byte("x")); err != nil {
+			t.Fatalf("Store: %v", err)
+		}
+	}
+
+	reopened, err := New(Config{Dir: dir})
+	if err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	if reopened.Stats().Entries != 3 {
+		t.Fatalf("expected entries to persist, got %+v", reopened.Stats())
+	}
+	removed, err := reopened.PurgeDomain("Example.com")
+	if err != nil || removed != 2 {
+		t.Fatalf("expected 2 entries purged, got %d (%v)", removed, err)
+	}
+	if _, _, ok := reopened.Lookup("https://other.org/c"); !ok {
+		t.Fatal("unrelated domain must survive purge")
+	}
+}
+
+func TestCanonicalURL(t *testing.T) {
+	got, err := CanonicalURL("HTTPS://Example.COM:443?b=2&a=1#frag")
+	if err != nil {
+		t.Fatalf("CanonicalURL: %v", err)
+	}
+	if want := "https://example.com/?a=1&b=2"; got != want {
+		t.Fatalf("CanonicalURL = %q, want %q", got, want)
+	}
+	if !strings.HasPrefix(hostOf(got), "example.com") {
+		t.Fatalf("unexpected host %q", hostOf(got))
+	}
+}
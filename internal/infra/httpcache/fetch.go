@@ -0,0 +1,288 @@
+package httpcache
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"alex/internal/shared/httpclient"
+	"alex/internal/shared/utils"
+)
+
+// storedHeaders are the response headers kept with a cache entry.
+var storedHeaders = []string{"Content-Type", "Content-Language", "Cache-Control", "ETag", "Last-Modified", "Expires"}
+
+// authHeaders mark a request as carrying credentials.
+var authHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie"}
+
+// Request describes a cacheable GET.
+type Request struct {
+	URL    string
+	Header http.Header
+	// Partition scopes auth-bearing requests (typically the workspace path).
+	Partition string
+}
+
+// Response is a fetched or cached response with freshness details.
+type Response struct {
+	URL         string
+	StatusCode  int
+	Header      http.Header
+	Body        []byte
+	FromCache   bool
+	Revalidated bool
+	Age         time.Duration
+}
+
+// Fetcher performs GET requests through the cache.
+type Fetcher struct {
+	client       *http.Client
+	cache        *Cache
+	maxBodyBytes int64
+	now          func() time.Time
+}
+
+// NewFetcher wraps client with cache. A nil cache disables caching.
+func NewFetcher(client *http.Client, cache *Cache, maxBodyBytes int64) *Fetcher {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &Fetcher{client: client, cache: cache, maxBodyBytes: maxBodyBytes, now: time.Now}
+}
+
+// Get fetches req.URL, serving fresh cache entries directly and revalidating
+// stale ones with conditional requests.
+func (f *Fetcher) Get(ctx context.Context, req Request) (*Response, error) {
+	key, cacheable := f.cacheKey(req)
+	if !cacheable {
+		return f.fetch(ctx, req, nil)
+	}
+
+	entry, body, hit := f.cache.Lookup(key)
+	now := f.now()
+	if hit && entry.Fresh(now) {
+		return cachedResponse(entry, body, false, now), nil
+	}
+
+	resp, err := f.fetch(ctx, req, conditionalHeaders(entry, hit))
+	if err != nil {
+		return nil, err
+	}
+	directives := parseCacheControl(resp.Header.Get("Cache-Control"))
+
+	if hit && resp.StatusCode == http.StatusNotModified {
+		freshFor := f.freshness(resp.Header, directives, now)
+		if updated, err := f.cache.Touch(key, now, freshFor, resp.Header); err == nil {
+			entry = updated
+		}
+		return cachedResponse(entry, body, true, now), nil
+	}
+
+	switch {
+	case directives.has("no-store"):
+		f.cache.Remove(key)
+	case directives.has("private") && partitionFor(req) == "":
+		f.cache.Remove(key)
+	case resp.StatusCode == http.StatusOK:
+		stored := Entry{
+			Key:        key,
+			URL:        resp.URL,
+			Host:       hostOf(resp.URL),
+			Partition:  partitionFor(req),
+			StatusCode: resp.StatusCode,
+			Header:     pickHeaders(resp.Header),
+			StoredAt:   now,
+			FreshFor:   f.freshness(resp.Header, directives, now),
+		}
+		mergeValidators(&stored, resp.Header)
+		_ = f.cache.Store(stored, resp.Body)
+	}
+	return resp, nil
+}
+
+// cacheKey returns the key for req and whether the cache may be used.
+func (f *Fetcher) cacheKey(req Request) (string, bool) {
+	if f.cache == nil {
+		return "", false
+	}
+	if parseCacheControl(req.Header.Get("Cache-Control")).has("no-store") {
+		return "", false
+	}
+	canonical, err := CanonicalURL(req.URL)
+	if err != nil {
+		return "", false
+	}
+	if hasAuth(req.Header) {
+		if !f.cache.cfg.CacheAuthRequests || strings.TrimSpace(req.Partition) == "" {
+			return "", false
+		}
+		return Key(strings.TrimSpace(req.Partition), canonical), true
+	}
+	return Key("", canonical), true
+}
+
+func (f *Fetcher) fetch(ctx context.Context, req Request, extra http.Header) (*Response, error) {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, req.URL, nil)
+	if err != nil {
+		return nil, err
+	}
+	for name, values := range req.Header {
+		for _, value := range values {
+			httpReq.Header.Add(name, value)
+		}
+	}
+	for name, values := range extra {
+		httpReq.Header[name] = values
+	}
+	resp, err := f.client.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	var body []byte
+	if f.maxBodyBytes > 0 {
+		body, err = httpclient.ReadAllWithLimit(resp.Body, f.maxBodyBytes)
+		if httpclient.IsResponseTooLarge(err) {
+			return nil, fmt.Errorf("response exceeds %d bytes", f.maxBodyBytes)
+		}
+	} else {
+		body, err = httpclient.ReadAllWithLimit(resp.Body, DefaultMaxEntryBytes)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &Response{
+		URL:        resp.Request.URL.String(),
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header,
+		Body:       body,
+	}, nil
+}
+
+// freshness computes how long a response may be served without revalidation.
+func (f *Fetcher) freshness(header http.Header, directives cacheControl, now time.Time) time.Duration {
+	cfg := f.cache.cfg
+	if directives.has("no-cache") {
+		return 0
+	}
+	ttl := cfg.DefaultTTL
+	if maxAge, ok := directives.seconds("max-age"); ok {
+		ttl = maxAge
+	} else if expires, err := http.ParseTime(header.Get("Expires")); err == nil {
+		ttl = expires.Sub(now)
+	}
+	if ttl < 0 {
+		ttl = 0
+	}
+	if ttl > cfg.MaxAge {
+		ttl = cfg.MaxAge
+	}
+	return ttl
+}
+
+func cachedResponse(entry Entry, body []byte, revalidated bool, now time.Time) *Response {
+	return &Response{
+		URL:         entry.URL,
+		StatusCode:  entry.StatusCode,
+		Header:      entry.Header.Clone(),
+		Body:        body,
+		FromCache:   true,
+		Revalidated: revalidated,
+		Age:         now.Sub(entry.StoredAt),
+	}
+}
+
+func conditionalHeaders(entry Entry, hit bool) http.Header {
+	if !hit {
+		return nil
+	}
+	header := http.Header{}
+	if entry.ETag != "" {
+		header.Set("If-None-Match", entry.ETag)
+	}
+	if entry.LastModified != "" {
+		header.Set("If-Modified-Since", entry.LastModified)
+	}
+	return header
+}
+
+func mergeValidators(entry *Entry, header http.Header) {
+	if etag := header.Get("ETag"); etag != "" {
+		entry.ETag = etag
+	}
+	if lastModified := header.Get("Last-Modified"); lastModified != "" {
+		entry.LastModified = lastModified
+	}
+}
+
+func pickHeaders(header http.Header) http.Header {
+	picked := http.Header{}
+	for _, name := range storedHeaders {
+		if value := header.Get(name); value != "" {
+			picked.Set(name, value)
+		}
+	}
+	return picked
+}
+
+func hasAuth(header http.Header) bool {
+	for _, name := range authHeaders {
+		if header.Get(name) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+func partitionFor(req Request) string {
+	if hasAuth(req.Header) {
+		return strings.TrimSpace(req.Partition)
+	}
+	return ""
+}
+
+func hostOf(raw string) string {
+	parsed, err := url.Parse(raw)
+	if err != nil {
+		return ""
+	}
+	return strings.ToLower(parsed.Hostname())
+}
+
+// cacheControl holds parsed Cache-Control directives.
+type cacheControl map[string]string
+
+func parseCacheControl(value string) cacheControl {
+	directives := cacheControl{}
+	for _, part := range strings.Split(value, ",") {
+		name, arg, _ := strings.Cut(strings.TrimSpace(part), "=")
+		name = utils.TrimLower(name)
+		if name == "" {
+			continue
+		}
+		directives[name] = strings.Trim(strings.TrimSpace(arg), `"`)
+	}
+	return directives
+}
+
+func (c cacheControl) has(name string) bool {
+	_, ok := c[name]
+	return ok
+}
+
+func (c cacheControl) seconds(name string) (time.Duration, bool) {
+	raw, ok := c[name]
+	if !ok {
+		return 0, false
+	}
+	n, err := strconv.Atoi(raw)
+	if err != nil || n < 0 {
+		return 0, false
+	}
+	return time.Duration(n) * time.Second, true
+}
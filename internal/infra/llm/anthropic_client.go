@@ -251,6 +251,7 @@ func (c *anthropicClient) Complete(ctx context.Context, req ports.CompletionRequ
 		PromptTokens:     apiResp.Usage.InputTokens,
 		CompletionTokens: apiResp.Usage.OutputTokens,
 		TotalTokens:      apiResp.Usage.InputTokens + apiResp.Usage.OutputTokens,
+		CachedTokens:     apiResp.Usage.CacheReadInputTokens,
 	}
 
 	result := &ports.CompletionResponse{
@@ -316,6 +317,9 @@ func (c *anthropicClient) consumeAnthropicSSE(body io.Reader) (*ports.Completion
 					if v, ok := u["input_tokens"].(float64); ok {
 						usage.PromptTokens = int(v)
 					}
+					if v, ok := u["cache_read_input_tokens"].(float64); ok {
+						usage.CachedTokens = int(v)
+					}
 				}
 			}
 		case "content_block_start":
@@ -712,8 +716,9 @@ type anthropicResponse struct {
 }
 
 type anthropicUsage struct {
-	InputTokens  int `json:"input_tokens"`
-	OutputTokens int `json:"output_tokens"`
+	InputTokens          int `json:"input_tokens"`
+	OutputTokens         int `json:"output_tokens"`
+	CacheReadInputTokens int `json:"cache_read_input_tokens"`
 }
 
 type anthropicError struct {
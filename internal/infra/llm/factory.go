@@ -27,6 +27,27 @@ type FallbackRule struct {
 	BaseURL  string
 }
 
+// FallbackProvider is one entry in the ordered provider failover chain.
+// Models maps a primary model name to the equivalent model on this provider;
+// Model is used for primary models without a mapping. An entry with neither
+// is skipped for that primary model.
+type FallbackProvider struct {
+	Provider string
+	Model    string
+	Models   map[string]string
+	APIKey   string
+	BaseURL  string
+}
+
+// ModelFor returns the model this provider should serve in place of primary,
+// or "" when it has no equivalent.
+func (p FallbackProvider) ModelFor(primary string) string {
+	if mapped := strings.TrimSpace(p.Models[primary]); mapped != "" {
+		return mapped
+	}
+	return strings.TrimSpace(p.Model)
+}
+
 type Factory struct {
 	cache                *lru.Cache[string, cacheEntry]
 	cacheTTL             time.Duration
@@ -43,6 +64,10 @@ type Factory struct {
 	healthRegistry       *healthRegistry
 	registry             *Registry
 	fallbackRules        map[string]FallbackRule // model → fallback target
+	fallbackProviders    []FallbackProvider      // ordered provider failover chain
+	failoverBreakers     *alexerrors.CircuitBreakerManager
+	responseCache        *ResponseCache
+	forceResponseCache   bool
 }
 
 type cacheEntry struct {
@@ -65,6 +90,7 @@ func NewFactory() *Factory {
 		userRateBurst:        1,
 		kimiRateBurst:        1,
 		registry:             NewDefaultRegistry(),
+		failoverBreakers:     newFailoverBreakers(),
 	}
 }
 
@@ -77,6 +103,18 @@ func (f *Factory) SetCacheOptions(size int, ttl time.Duration) {
 	f.cacheTTL = ttl
 }
 
+// InvalidateCache drops every cached client so the next GetClient call builds
+// a fresh one from the current settings. Clients already handed out keep
+// working, so in-flight tasks finish on the client they started with.
+func (f *Factory) InvalidateCache() {
+	f.mu.RLock()
+	cache := f.cache
+	f.mu.RUnlock()
+	if cache != nil {
+		cache.Purge()
+	}
+}
+
 func newLLMCache(size int) *lru.Cache[string, cacheEntry] {
 	if size <= 0 {
 		return nil
@@ -127,6 +165,34 @@ func (f *Factory) SetFallbackRules(rules map[string]FallbackRule) {
 	f.fallbackRules = rules
 }
 
+// SetFallbackProviders configures the ordered provider failover chain. When a
+// primary provider fails at the provider level (5xx, timeouts, revoked
+// credentials), the request is replayed against each provider in order, after
+// any model-specific fallback rule. A provider that keeps failing is skipped
+// for a cool-off period.
+func (f *Factory) SetFallbackProviders(providers []FallbackProvider) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.fallbackProviders = append([]FallbackProvider(nil), providers...)
+}
+
+// SetResponseCache serves repeated requests from cache. Requests with a
+// temperature above zero bypass it unless force is set. A nil cache disables
+// response caching.
+func (f *Factory) SetResponseCache(cache *ResponseCache, force bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.responseCache = cache
+	f.forceResponseCache = force
+}
+
+// ResponseCache returns the configured response cache, or nil.
+func (f *Factory) ResponseCache() *ResponseCache {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.responseCache
+}
+
 // EnableHealth activates per-model health tracking.
 func (f *Factory) EnableHealth() {
 	f.mu.Lock()
@@ -165,14 +231,14 @@ func (f *Factory) EnableToolCallParsing(parser agent.FunctionCallParser) {
 // GetClient implements portsllm.LLMClientFactory interface
 // Creates or retrieves a cached LLM client
 func (f *Factory) GetClient(provider, model string, config portsllm.LLMConfig) (portsllm.LLMClient, error) {
-	return f.getClient(provider, model, adaptConfig(config), true)
+	return f.getClient(provider, model, adaptConfig(config), true, true)
 }
 
 // GetIsolatedClient implements portsllm.LLMClientFactory interface
 // Creates a new non-cached client instance for session isolation
 // This is useful when per-session state (like cost tracking callbacks) needs to be isolated
 func (f *Factory) GetIsolatedClient(provider, model string, config portsllm.LLMConfig) (portsllm.LLMClient, error) {
-	return f.getClient(provider, model, adaptConfig(config), false)
+	return f.getClient(provider, model, adaptConfig(config), false, true)
 }
 
 // adaptConfig converts portsllm.LLMConfig to internal Config
@@ -186,7 +252,9 @@ func adaptConfig(config portsllm.LLMConfig) Config {
 	}
 }
 
-func (f *Factory) getClient(provider, model string, config Config, useCache bool) (portsllm.LLMClient, error) {
+// getClient builds (or reuses) a client. withFailover is false for the
+// fallback clients themselves so a failover never cascades into another.
+func (f *Factory) getClient(provider, model string, config Config, useCache, withFailover bool) (portsllm.LLMClient, error) {
 	cacheKey := fmt.Sprintf("%s:%s", provider, model)
 	now := time.Now()
 
@@ -203,6 +271,10 @@ func (f *Factory) getClient(provider, model string, config Config, useCache bool
 	healthRegistry := f.healthRegistry
 	registry := f.registry
 	fallbackRules := f.fallbackRules
+	fallbackProviders := f.fallbackProviders
+	failoverBreakers := f.failoverBreakers
+	responseCache := f.responseCache
+	forceResponseCache := f.forceResponseCache
 	f.mu.RUnlock()
 
 	// Check cache if enabled
@@ -239,6 +311,11 @@ func (f *Factory) getClient(provider, model string, config Config, useCache bool
 		kimiLimiter:          kimiLimiter,
 		healthRegistry:       healthRegistry,
 		fallbackRules:        fallbackRules,
+		fallbackProviders:    fallbackProviders,
+		failoverBreakers:     failoverBreakers,
+		withFailover:         withFailover,
+		responseCache:        responseCache,
+		forceResponseCache:   forceResponseCache,
 	})
 
 	// Cache only if requested
@@ -290,10 +367,16 @@ type middlewareOpts struct {
 	kimiLimiter          *rate.Limiter
 	healthRegistry       *healthRegistry
 	fallbackRules        map[string]FallbackRule
+	fallbackProviders    []FallbackProvider
+	failoverBreakers     *alexerrors.CircuitBreakerManager
+	withFailover         bool
+	responseCache        *ResponseCache
+	forceResponseCache   bool
 }
 
 // applyMiddleware wraps a base client with the standard middleware pipeline:
-// streaming → shared rate limit → retry/health → user rate limit → tool call parsing.
+// streaming → shared rate limit → retry/health → user rate limit → tool call
+// parsing → response cache.
 func (f *Factory) applyMiddleware(client portsllm.LLMClient, provider, model string, config Config, opts middlewareOpts) portsllm.LLMClient {
 	client = EnsureStreamingClient(client)
 
@@ -317,25 +400,12 @@ func (f *Factory) applyMiddleware(client portsllm.LLMClient, provider, model str
 			}
 		}
 
-		// Wire model-level fallback for transient failures.
-		if rule, ok := opts.fallbackRules[model]; ok && rule.Provider != "" && rule.Model != "" {
-			if rc, ok := client.(*retryClient); ok {
-				fbProvider := rule.Provider
-				fbModel := rule.Model
-				fbAPIKey := rule.APIKey
-				if fbAPIKey == "" {
-					fbAPIKey = config.APIKey
-				}
-				fbBaseURL := rule.BaseURL
-				rc.fallbackProvider = fbProvider
-				rc.fallbackModel = fbModel
-				rc.fallbackClientFn = func() (portsllm.LLMClient, error) {
-					return f.getClient(fbProvider, fbModel, Config{
-						APIKey:  fbAPIKey,
-						BaseURL: fbBaseURL,
-						Timeout: config.Timeout,
-						Headers: config.Headers,
-					}, false)
+		// Wire the provider failover chain and the primary's shared cool-off.
+		if rc, ok := client.(*retryClient); ok {
+			if chain := f.failoverChain(provider, model, config, opts); len(chain) > 0 {
+				rc.fallbacks = chain
+				if opts.failoverBreakers != nil {
+					rc.providerBreaker = opts.failoverBreakers.Get(failoverBreakerName(provider, config.BaseURL))
 				}
 			}
 		}
@@ -349,9 +419,78 @@ func (f *Factory) applyMiddleware(client portsllm.LLMClient, provider, model str
 		client = WrapWithToolCallParsing(client, opts.toolCallParser)
 	}
 
+	// Fallback clients are only reached through a primary, whose cache
+	// already covers them.
+	if opts.responseCache != nil && opts.withFailover {
+		client = WrapWithResponseCache(client, opts.responseCache, provider, model, opts.forceResponseCache)
+	}
+
 	return client
 }
 
+// failoverChain builds the ordered fallback targets for a primary model: its
+// fallback rule first, then every fallback provider with an equivalent model.
+// Targets identical to the primary, or to an earlier target, are dropped.
+func (f *Factory) failoverChain(provider, model string, config Config, opts middlewareOpts) []fallbackTarget {
+	if !opts.withFailover {
+		return nil
+	}
+	var candidates []FallbackRule
+	if rule, ok := opts.fallbackRules[model]; ok && rule.Provider != "" && rule.Model != "" {
+		candidates = append(candidates, rule)
+	}
+	for _, fp := range opts.fallbackProviders {
+		if target := fp.ModelFor(model); fp.Provider != "" && target != "" {
+			candidates = append(candidates, FallbackRule{
+				Provider: fp.Provider,
+				Model:    target,
+				APIKey:   fp.APIKey,
+				BaseURL:  fp.BaseURL,
+			})
+		}
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	seen := map[string]bool{provider + "/" + model + "@" + config.BaseURL: true}
+	chain := make([]fallbackTarget, 0, len(candidates))
+	for _, rule := range candidates {
+		key := rule.Provider + "/" + rule.Model + "@" + rule.BaseURL
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		chain = append(chain, f.newFallbackTarget(rule, config, opts.failoverBreakers))
+	}
+	return chain
+}
+
+// newFallbackTarget binds a fallback to a lazily built client. The API key is
+// inherited from the primary when the rule leaves it empty.
+func (f *Factory) newFallbackTarget(rule FallbackRule, primary Config, breakers *alexerrors.CircuitBreakerManager) fallbackTarget {
+	apiKey := rule.APIKey
+	if apiKey == "" {
+		apiKey = primary.APIKey
+	}
+	target := fallbackTarget{
+		provider: rule.Provider,
+		model:    rule.Model,
+		clientFn: func() (portsllm.LLMClient, error) {
+			return f.getClient(rule.Provider, rule.Model, Config{
+				APIKey:  apiKey,
+				BaseURL: rule.BaseURL,
+				Timeout: primary.Timeout,
+				Headers: utils.CloneMap(primary.Headers),
+			}, false, false)
+		},
+	}
+	if breakers != nil {
+		target.breaker = breakers.Get(failoverBreakerName(rule.Provider, rule.BaseURL))
+	}
+	return target
+}
+
 func isKimiTarget(provider, model, baseURL string) bool {
 	provider = utils.TrimLower(provider)
 	model = utils.TrimLower(model)
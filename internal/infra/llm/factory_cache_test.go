@@ -63,3 +63,23 @@ func TestFactoryCacheExpiresTTL(t *testing.T) {
 		t.Fatalf("expected TTL to expire cached client")
 	}
 }
+
+func TestFactoryInvalidateCacheRebuildsClients(t *testing.T) {
+	factory := NewFactory()
+	cfg := portsllm.LLMConfig{}
+
+	before, err := factory.GetClient("mock", "model-a", cfg)
+	if err != nil {
+		t.Fatalf("expected client, got error: %v", err)
+	}
+
+	factory.InvalidateCache()
+
+	after, err := factory.GetClient("mock", "model-a", cfg)
+	if err != nil {
+		t.Fatalf("expected client after invalidation, got error: %v", err)
+	}
+	if before == after {
+		t.Fatalf("expected invalidation to rebuild the cached client")
+	}
+}
@@ -279,3 +279,107 @@ func TestFactory_MockProvider(t *testing.T) {
 		t.Fatal("expected non-nil mock client")
 	}
 }
+
+// --- Factory failover chain ---
+
+func TestFactory_FailoverChainOrderAndModelMapping(t *testing.T) {
+	factory := NewFactory()
+	factory.SetFallbackRules(map[string]FallbackRule{
+		"claude-sonnet-4-6": {Provider: "mock", Model: "rule-model"},
+	})
+	factory.SetFallbackProviders([]FallbackProvider{
+		{Provider: "mock", Models: map[string]string{"claude-sonnet-4-6": "mapped-model"}},
+		{Provider: "mock", Model: "rule-model"},                     // duplicate of the rule, dropped
+		{Provider: "mock", Models: map[string]string{"other": "x"}}, // no equivalent, skipped
+		{Provider: "mock", Model: "default-model"},
+	})
+
+	client, err := factory.GetIsolatedClient("mock", "claude-sonnet-4-6", portsllm.LLMConfig{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	rc, ok := client.(*retryClient)
+	if !ok {
+		t.Fatalf("expected *retryClient, got %T", client)
+	}
+	var got []string
+	for _, target := range rc.fallbacks {
+		got = append(got, target.label())
+	}
+	want := []string{"mock/rule-model", "mock/mapped-model", "mock/default-model"}
+	if len(got) != len(want) {
+		t.Fatalf("expected chain %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("expected chain %v, got %v", want, got)
+		}
+	}
+	if rc.providerBreaker == nil {
+		t.Fatal("expected primary provider breaker when a chain is configured")
+	}
+
+	fallback, err := rc.fallbacks[0].clientFn()
+	if err != nil {
+		t.Fatalf("fallback client: %v", err)
+	}
+	if frc, ok := fallback.(*retryClient); ok && len(frc.fallbacks) > 0 {
+		t.Fatal("fallback clients must not carry their own failover chain")
+	}
+}
+
+func TestFactory_FailoverBreakersSharedAcrossClients(t *testing.T) {
+	factory := NewFactory()
+	factory.SetFallbackProviders([]FallbackProvider{{Provider: "mock", Model: "backup"}})
+
+	a, _ := factory.GetIsolatedClient("mock", "primary", portsllm.LLMConfig{})
+	b, _ := factory.GetIsolatedClient("mock", "primary", portsllm.LLMConfig{})
+	rcA, rcB := a.(*retryClient), b.(*retryClient)
+	if rcA.providerBreaker != rcB.providerBreaker {
+		t.Fatal("expected isolated clients to share the provider cool-off breaker")
+	}
+	if rcA.fallbacks[0].breaker != rcB.fallbacks[0].breaker {
+		t.Fatal("expected fallback targets to share the provider cool-off breaker")
+	}
+}
+
+func TestFactory_NoFailoverChainWithoutConfig(t *testing.T) {
+	factory := NewFactory()
+	client, _ := factory.GetIsolatedClient("mock", "primary", portsllm.LLMConfig{})
+	rc := client.(*retryClient)
+	if len(rc.fallbacks) != 0 || rc.providerBreaker != nil {
+		t.Fatal("expected no failover wiring without configuration")
+	}
+}
+
+// --- Factory response cache ---
+
+func TestFactory_ResponseCacheWrapsPrimaryClientsOnly(t *testing.T) {
+	factory := NewFactory()
+	cache, err := NewResponseCache(ResponseCacheConfig{})
+	if err != nil {
+		t.Fatalf("NewResponseCache: %v", err)
+	}
+	factory.SetResponseCache(cache, false)
+	factory.SetFallbackProviders([]FallbackProvider{{Provider: "mock", Model: "backup"}})
+
+	client, err := factory.GetIsolatedClient("mock", "primary", portsllm.LLMConfig{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cached, ok := client.(*responseCachingClient)
+	if !ok {
+		t.Fatalf("expected *responseCachingClient, got %T", client)
+	}
+	rc, ok := cached.underlying.(*retryClient)
+	if !ok {
+		t.Fatalf("expected retry client under the cache, got %T", cached.underlying)
+	}
+	fallback, err := rc.fallbacks[0].clientFn()
+	if err != nil {
+		t.Fatalf("fallback client: %v", err)
+	}
+	if _, ok := fallback.(*responseCachingClient); ok {
+		t.Fatal("fallback clients must not be wrapped by the response cache")
+	}
+}
@@ -0,0 +1,23 @@
+package llm
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"alex/internal/shared/utils"
+)
+
+// TestMain points the request logger at a temp dir so client tests never
+// write LLM request logs into the source tree.
+func TestMain(m *testing.M) {
+	dir, err := os.MkdirTemp("", "alex-llm-request-logs-")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "create request log dir: %v\n", err)
+		os.Exit(1)
+	}
+	os.Setenv(utils.RequestLogEnvVar, dir)
+	code := m.Run()
+	os.RemoveAll(dir)
+	os.Exit(code)
+}
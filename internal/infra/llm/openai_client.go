@@ -94,9 +94,12 @@ func (c *openaiClient) Complete(ctx context.Context, req ports.CompletionRequest
 			FinishReason string `json:"finish_reason"`
 		} `json:"choices"`
 		Usage struct {
-			PromptTokens     int `json:"prompt_tokens"`
-			CompletionTokens int `json:"completion_tokens"`
-			TotalTokens      int `json:"total_tokens"`
+			PromptTokens        int `json:"prompt_tokens"`
+			CompletionTokens    int `json:"completion_tokens"`
+			TotalTokens         int `json:"total_tokens"`
+			PromptTokensDetails struct {
+				CachedTokens int `json:"cached_tokens"`
+			} `json:"prompt_tokens_details"`
 		} `json:"usage"`
 		Error *struct {
 			Type    string           `json:"type"`
@@ -139,6 +142,7 @@ func (c *openaiClient) Complete(ctx context.Context, req ports.CompletionRequest
 			PromptTokens:     oaiResp.Usage.PromptTokens,
 			CompletionTokens: oaiResp.Usage.CompletionTokens,
 			TotalTokens:      oaiResp.Usage.TotalTokens,
+			CachedTokens:     oaiResp.Usage.PromptTokensDetails.CachedTokens,
 		},
 		Metadata: map[string]any{
 			"request_id": requestID,
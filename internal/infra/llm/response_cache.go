@@ -0,0 +1,351 @@
+package llm
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"alex/internal/domain/agent/ports"
+	"alex/internal/infra/filestore"
+	jsonx "alex/internal/shared/json"
+)
+
+const (
+	responseCacheDocVersion = 1
+	responseCacheIndexFile  = "index.json"
+	responseCacheBodySuffix = ".json"
+
+	// ResponseCacheDirName is the response cache directory under the session dir.
+	ResponseCacheDirName = "llm_cache"
+
+	DefaultResponseCacheTTL            = 24 * time.Hour
+	DefaultResponseCacheMaxBytes int64 = 256 << 20
+)
+
+// ResponseCacheConfig bounds the on-disk LLM response cache.
+type ResponseCacheConfig struct {
+	// Dir holds the index and cached responses. Empty keeps everything in memory.
+	Dir string
+	// TTL is how long a stored response may be replayed.
+	TTL time.Duration
+	// MaxBytes caps the total stored response size (LRU eviction beyond it).
+	MaxBytes int64
+	// MaxEntries caps the number of stored responses (0 = unlimited).
+	MaxEntries int
+}
+
+// ResponseCacheEntry is the stored metadata for a cached response.
+type ResponseCacheEntry struct {
+	Key        string    `json:"key"`
+	Provider   string    `json:"provider"`
+	Model      string    `json:"model"`
+	StoredAt   time.Time `json:"stored_at"`
+	Size       int64     `json:"size"`
+	LastAccess time.Time `json:"last_access"`
+}
+
+type responseCacheDoc struct {
+	Version int                  `json:"version"`
+	Entries []ResponseCacheEntry `json:"entries"`
+}
+
+// ResponseCacheStats summarizes cache occupancy.
+type ResponseCacheStats struct {
+	Entries int   `json:"entries"`
+	Bytes   int64 `json:"bytes"`
+}
+
+// ResponseCache is a content-addressed store of completed LLM responses,
+// keyed by ResponseCacheKey and bounded by TTL and total size.
+type ResponseCache struct {
+	cfg   ResponseCacheConfig
+	dir   string
+	index *filestore.Collection[string, ResponseCacheEntry]
+
+	mu     sync.Mutex
+	bodies map[string][]byte // in-memory mode only
+}
+
+// ResponseCacheDir returns the response cache directory for a session dir.
+func ResponseCacheDir(sessionDir string) string {
+	return filepath.Join(sessionDir, ResponseCacheDirName)
+}
+
+// NewResponseCache opens (or creates) a response cache.
+func NewResponseCache(cfg ResponseCacheConfig) (*ResponseCache, error) {
+	if cfg.TTL <= 0 {
+		cfg.TTL = DefaultResponseCacheTTL
+	}
+	if cfg.MaxBytes <= 0 {
+		cfg.MaxBytes = DefaultResponseCacheMaxBytes
+	}
+	dir := strings.TrimSpace(cfg.Dir)
+	indexPath := ""
+	if dir != "" {
+		if err := filestore.EnsureDir(dir); err != nil {
+			return nil, fmt.Errorf("create llm response cache dir: %w", err)
+		}
+		indexPath = filepath.Join(dir, responseCacheIndexFile)
+	}
+	index := filestore.NewCollection[string, ResponseCacheEntry](filestore.CollectionConfig{
+		FilePath: indexPath,
+		Name:     "llm_response_cache",
+	})
+	index.SetMarshalDoc(marshalResponseCacheIndex)
+	index.SetUnmarshalDoc(unmarshalResponseCacheIndex)
+	if err := index.Load(); err != nil {
+		return nil, fmt.Errorf("load llm response cache index: %w", err)
+	}
+	return &ResponseCache{cfg: cfg, dir: dir, index: index, bodies: make(map[string][]byte)}, nil
+}
+
+// Lookup returns a copy of the fresh response stored under key. Expired or
+// unreadable entries are dropped.
+func (c *ResponseCache) Lookup(key string) (*ports.CompletionResponse, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.index.Get(key)
+	if !ok {
+		return nil, false
+	}
+	now := c.index.Now()
+	if now.Sub(entry.StoredAt) >= c.cfg.TTL {
+		c.removeLocked(key)
+		return nil, false
+	}
+	body, err := c.readBody(key)
+	if err != nil {
+		c.removeLocked(key)
+		return nil, false
+	}
+	var resp ports.CompletionResponse
+	if err := jsonx.Unmarshal(body, &resp); err != nil {
+		c.removeLocked(key)
+		return nil, false
+	}
+	entry.LastAccess = now
+	_ = c.index.Put(key, entry)
+	return &resp, true
+}
+
+// Store saves resp under key and evicts least-recently-used entries beyond
+// the configured bounds.
+func (c *ResponseCache) Store(key, provider, model string, resp *ports.CompletionResponse) error {
+	if resp == nil {
+		return nil
+	}
+	body, err := jsonx.Marshal(resp)
+	if err != nil {
+		return fmt.Errorf("encode llm response: %w", err)
+	}
+	if int64(len(body)) > c.cfg.MaxBytes {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.writeBody(key, body); err != nil {
+		return err
+	}
+	now := c.index.Now()
+	entry := ResponseCacheEntry{
+		Key:        key,
+		Provider:   provider,
+		Model:      model,
+		StoredAt:   now,
+		Size:       int64(len(body)),
+		LastAccess: now,
+	}
+	var evicted []string
+	err = c.index.Mutate(func(items map[string]ResponseCacheEntry) error {
+		items[key] = entry
+		evicted = evictResponseCacheLRU(items, c.cfg.MaxBytes, c.cfg.MaxEntries)
+		return nil
+	})
+	for _, evictedKey := range evicted {
+		c.deleteBody(evictedKey)
+	}
+	return err
+}
+
+// Purge removes every entry and returns the number removed.
+func (c *ResponseCache) Purge() (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var removed []string
+	err := c.index.Mutate(func(items map[string]ResponseCacheEntry) error {
+		for key := range items {
+			delete(items, key)
+			removed = append(removed, key)
+		}
+		return nil
+	})
+	for _, key := range removed {
+		c.deleteBody(key)
+	}
+	return len(removed), err
+}
+
+// Stats returns current occupancy.
+func (c *ResponseCache) Stats() ResponseCacheStats {
+	var stats ResponseCacheStats
+	c.index.ReadLocked(func(items map[string]ResponseCacheEntry) {
+		stats.Entries = len(items)
+		for _, entry := range items {
+			stats.Bytes += entry.Size
+		}
+	})
+	return stats
+}
+
+func (c *ResponseCache) removeLocked(key string) {
+	_ = c.index.Delete(key)
+	c.deleteBody(key)
+}
+
+func (c *ResponseCache) bodyPath(key string) string {
+	return filepath.Join(c.dir, key+responseCacheBodySuffix)
+}
+
+func (c *ResponseCache) readBody(key string) ([]byte, error) {
+	if c.dir == "" {
+		body, ok := c.bodies[key]
+		if !ok {
+			return nil, os.ErrNotExist
+		}
+		return body, nil
+	}
+	return os.ReadFile(c.bodyPath(key))
+}
+
+func (c *ResponseCache) writeBody(key string, body []byte) error {
+	if c.dir == "" {
+		c.bodies[key] = body
+		return nil
+	}
+	return filestore.AtomicWrite(c.bodyPath(key), body, 0o600)
+}
+
+func (c *ResponseCache) deleteBody(key string) {
+	if c.dir == "" {
+		delete(c.bodies, key)
+		return
+	}
+	_ = os.Remove(c.bodyPath(key))
+}
+
+// responseCacheKeyDoc is the hashed projection of a request. Request and
+// message metadata are left out: they carry run IDs and timestamps that never
+// reach the provider and would defeat the cache.
+type responseCacheKeyDoc struct {
+	Provider    string                 `json:"provider"`
+	Model       string                 `json:"model"`
+	Messages    []responseCacheMessage `json:"messages"`
+	Tools       []ports.ToolDefinition `json:"tools,omitempty"`
+	Temperature float64                `json:"temperature"`
+	MaxTokens   int                    `json:"max_tokens,omitempty"`
+	TopP        float64                `json:"top_p,omitempty"`
+	Stop        []string               `json:"stop,omitempty"`
+	Thinking    ports.ThinkingConfig   `json:"thinking,omitempty"`
+}
+
+type responseCacheMessage struct {
+	Role        string                      `json:"role"`
+	Content     string                      `json:"content"`
+	ToolCalls   []ports.ToolCall            `json:"tool_calls,omitempty"`
+	ToolResults []ports.ToolResult          `json:"tool_results,omitempty"`
+	ToolCallID  string                      `json:"tool_call_id,omitempty"`
+	Attachments map[string]ports.Attachment `json:"attachments,omitempty"`
+}
+
+// ResponseCacheKey hashes the parts of a request that determine the answer:
+// provider, model, messages, tools and sampling parameters.
+func ResponseCacheKey(provider, model string, req ports.CompletionRequest) (string, error) {
+	doc := responseCacheKeyDoc{
+		Provider:    provider,
+		Model:       model,
+		Messages:    make([]responseCacheMessage, 0, len(req.Messages)),
+		Tools:       req.Tools,
+		Temperature: req.Temperature,
+		MaxTokens:   req.MaxTokens,
+		TopP:        req.TopP,
+		Stop:        req.StopSequences,
+		Thinking:    req.Thinking,
+	}
+	for _, msg := range req.Messages {
+		doc.Messages = append(doc.Messages, responseCacheMessage{
+			Role:        msg.Role,
+			Content:     msg.Content,
+			ToolCalls:   msg.ToolCalls,
+			ToolResults: msg.ToolResults,
+			ToolCallID:  msg.ToolCallID,
+			Attachments: msg.Attachments,
+		})
+	}
+	data, err := jsonx.Marshal(doc)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// evictResponseCacheLRU removes least-recently-used entries until both bounds
+// hold and returns the removed keys.
+func evictResponseCacheLRU(items map[string]ResponseCacheEntry, maxBytes int64, maxEntries int) []string {
+	var total int64
+	for _, entry := range items {
+		total += entry.Size
+	}
+	within := func() bool {
+		return total <= maxBytes && (maxEntries <= 0 || len(items) <= maxEntries)
+	}
+	if within() {
+		return nil
+	}
+	ordered := make([]ResponseCacheEntry, 0, len(items))
+	for _, entry := range items {
+		ordered = append(ordered, entry)
+	}
+	sort.Slice(ordered, func(i, j int) bool {
+		return ordered[i].LastAccess.Before(ordered[j].LastAccess)
+	})
+	var evicted []string
+	for _, entry := range ordered {
+		if within() {
+			break
+		}
+		delete(items, entry.Key)
+		total -= entry.Size
+		evicted = append(evicted, entry.Key)
+	}
+	return evicted
+}
+
+func marshalResponseCacheIndex(items map[string]ResponseCacheEntry) ([]byte, error) {
+	doc := responseCacheDoc{Version: responseCacheDocVersion, Entries: make([]ResponseCacheEntry, 0, len(items))}
+	for _, entry := range items {
+		doc.Entries = append(doc.Entries, entry)
+	}
+	sort.Slice(doc.Entries, func(i, j int) bool { return doc.Entries[i].Key < doc.Entries[j].Key })
+	return filestore.MarshalJSONIndent(doc)
+}
+
+func unmarshalResponseCacheIndex(data []byte) (map[string]ResponseCacheEntry, error) {
+	var doc responseCacheDoc
+	if err := jsonx.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("decode llm response cache index: %w", err)
+	}
+	items := make(map[string]ResponseCacheEntry, len(doc.Entries))
+	for _, entry := range doc.Entries {
+		if entry.Key != "" {
+			items[entry.Key] = entry
+		}
+	}
+	return items, nil
+}
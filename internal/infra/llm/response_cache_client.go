@@ -0,0 +1,135 @@
+package llm
+
+import (
+	"context"
+
+	"alex/internal/domain/agent/ports"
+	portsllm "alex/internal/domain/agent/ports/llm"
+	"alex/internal/shared/logging"
+)
+
+// responseCachingClient replays cached responses for identical requests.
+// Only deterministic requests (temperature <= 0) are cached unless force is
+// set. Hits carry zero usage so they are recorded at zero cost.
+type responseCachingClient struct {
+	underlying portsllm.StreamingLLMClient
+	cache      *ResponseCache
+	provider   string
+	model      string
+	force      bool
+	logger     logging.Logger
+}
+
+var (
+	_ portsllm.LLMClient          = (*responseCachingClient)(nil)
+	_ portsllm.StreamingLLMClient = (*responseCachingClient)(nil)
+)
+
+// WrapWithResponseCache serves repeated requests from cache. A nil cache
+// returns client unchanged.
+func WrapWithResponseCache(client portsllm.LLMClient, cache *ResponseCache, provider, model string, force bool) portsllm.LLMClient {
+	if client == nil || cache == nil {
+		return client
+	}
+	return &responseCachingClient{
+		underlying: EnsureStreamingClient(client),
+		cache:      cache,
+		provider:   provider,
+		model:      model,
+		force:      force,
+		logger:     logging.NewComponentLogger("llm-response-cache"),
+	}
+}
+
+func (c *responseCachingClient) Complete(ctx context.Context, req ports.CompletionRequest) (*ports.CompletionResponse, error) {
+	key, ok := c.cacheKey(req)
+	if !ok {
+		return c.underlying.Complete(ctx, req)
+	}
+	if resp, hit := c.lookup(key); hit {
+		return resp, nil
+	}
+	resp, err := c.underlying.Complete(ctx, req)
+	if err != nil {
+		return resp, err
+	}
+	return c.store(key, resp), nil
+}
+
+func (c *responseCachingClient) StreamComplete(
+	ctx context.Context,
+	req ports.CompletionRequest,
+	callbacks ports.CompletionStreamCallbacks,
+) (*ports.CompletionResponse, error) {
+	key, ok := c.cacheKey(req)
+	if !ok {
+		return c.underlying.StreamComplete(ctx, req, callbacks)
+	}
+	if resp, hit := c.lookup(key); hit {
+		if callbacks.OnContentDelta != nil {
+			if resp.Content != "" {
+				callbacks.OnContentDelta(ports.ContentDelta{Delta: resp.Content})
+			}
+			callbacks.OnContentDelta(ports.ContentDelta{Final: true})
+		}
+		return resp, nil
+	}
+	resp, err := c.underlying.StreamComplete(ctx, req, callbacks)
+	if err != nil {
+		return resp, err
+	}
+	return c.store(key, resp), nil
+}
+
+func (c *responseCachingClient) Model() string {
+	return c.underlying.Model()
+}
+
+func (c *responseCachingClient) SetUsageCallback(callback func(usage ports.TokenUsage, model string, provider string)) {
+	if trackingClient, ok := c.underlying.(portsllm.UsageTrackingClient); ok {
+		trackingClient.SetUsageCallback(callback)
+	}
+}
+
+func (c *responseCachingClient) cacheKey(req ports.CompletionRequest) (string, bool) {
+	if req.Temperature > 0 && !c.force {
+		return "", false
+	}
+	key, err := ResponseCacheKey(c.provider, c.model, req)
+	if err != nil {
+		c.logger.Warn("Skipping response cache: %v", err)
+		return "", false
+	}
+	return key, true
+}
+
+func (c *responseCachingClient) lookup(key string) (*ports.CompletionResponse, bool) {
+	resp, ok := c.cache.Lookup(key)
+	if !ok {
+		return nil, false
+	}
+	resp.Usage = ports.TokenUsage{}
+	if resp.Metadata == nil {
+		resp.Metadata = make(map[string]any, 1)
+	}
+	resp.Metadata[ports.ResponseMetaCache] = ports.ResponseCacheHit
+	return resp, true
+}
+
+// store caches resp unless a fallback provider served it: the key names the
+// requested provider, and a degraded answer should not outlive the outage.
+func (c *responseCachingClient) store(key string, resp *ports.CompletionResponse) *ports.CompletionResponse {
+	if resp == nil {
+		return resp
+	}
+	if !servedByFailover(resp) && len(resp.ToolCalls)+len(resp.Content) > 0 {
+		if err := c.cache.Store(key, c.provider, c.model, resp); err != nil {
+			c.logger.Warn("Failed to store cached response: %v", err)
+		}
+	}
+	if resp.Metadata == nil {
+		resp.Metadata = make(map[string]any, 1)
+	}
+	resp.Metadata[ports.ResponseMetaCache] = ports.ResponseCacheMiss
+	return resp
+}
@@ -0,0 +1,126 @@
+package llm
+
+import (
+	"context"
+	"testing"
+
+	"alex/internal/domain/agent/ports"
+
+	"github.com/stretchr/testify/require"
+)
+
+func cacheTestRequest(content string) ports.CompletionRequest {
+	return ports.CompletionRequest{
+		Messages: []ports.Message{
+			{Role: "system", Content: "pick a tool"},
+			{Role: "user", Content: content},
+		},
+		Tools: []ports.ToolDefinition{{Name: "web_search"}},
+	}
+}
+
+func TestResponseCacheKeyIgnoresMetadata(t *testing.T) {
+	req := cacheTestRequest("find docs")
+	base, err := ResponseCacheKey("openai", "gpt-4o", req)
+	require.NoError(t, err)
+
+	req.Metadata = map[string]any{"run_id": "run-2"}
+	req.Messages[1].Metadata = map[string]any{"ts": "later"}
+	withMeta, err := ResponseCacheKey("openai", "gpt-4o", req)
+	require.NoError(t, err)
+	require.Equal(t, base, withMeta)
+
+	otherModel, err := ResponseCacheKey("openai", "gpt-4o-mini", req)
+	require.NoError(t, err)
+	require.NotEqual(t, base, otherModel)
+
+	req.Temperature = 0.7
+	warmer, err := ResponseCacheKey("openai", "gpt-4o", req)
+	require.NoError(t, err)
+	require.NotEqual(t, base, warmer)
+}
+
+func TestResponseCachingClientServesRepeatsAtZeroCost(t *testing.T) {
+	cache, err := NewResponseCache(ResponseCacheConfig{Dir: t.TempDir()})
+	require.NoError(t, err)
+	underlying := &scriptedClient{model: "gpt-4o", content: "use web_search"}
+	client := WrapWithResponseCache(underlying, cache, "openai", "gpt-4o", false)
+
+	first, err := client.Complete(context.Background(), cacheTestRequest("find docs"))
+	require.NoError(t, err)
+	require.Equal(t, ports.ResponseCacheMiss, first.CacheStatus())
+	require.Equal(t, 10, first.Usage.TotalTokens)
+
+	second, err := client.Complete(context.Background(), cacheTestRequest("find docs"))
+	require.NoError(t, err)
+	require.Equal(t, ports.ResponseCacheHit, second.CacheStatus())
+	require.Equal(t, "use web_search", second.Content)
+	require.Zero(t, second.Usage.TotalTokens)
+	require.Equal(t, 1, underlying.calls)
+}
+
+func TestResponseCachingClientSkipsSampledRequestsUnlessForced(t *testing.T) {
+	req := cacheTestRequest("write a poem")
+	req.Temperature = 0.8
+
+	cache, err := NewResponseCache(ResponseCacheConfig{})
+	require.NoError(t, err)
+	underlying := &scriptedClient{model: "gpt-4o", content: "roses"}
+	client := WrapWithResponseCache(underlying, cache, "openai", "gpt-4o", false)
+	for i := 0; i < 2; i++ {
+		resp, err := client.Complete(context.Background(), req)
+		require.NoError(t, err)
+		require.Empty(t, resp.CacheStatus())
+	}
+	require.Equal(t, 2, underlying.calls)
+	require.Zero(t, cache.Stats().Entries)
+
+	forced := WrapWithResponseCache(underlying, cache, "openai", "gpt-4o", true)
+	for i := 0; i < 2; i++ {
+		_, err := forced.Complete(context.Background(), req)
+		require.NoError(t, err)
+	}
+	require.Equal(t, 3, underlying.calls)
+}
+
+func TestResponseCachingClientStreamsHits(t *testing.T) {
+	cache, err := NewResponseCache(ResponseCacheConfig{})
+	require.NoError(t, err)
+	underlying := &scriptedClient{model: "gpt-4o", content: "cached answer"}
+	client := WrapWithResponseCache(underlying, cache, "openai", "gpt-4o", false).(*responseCachingClient)
+
+	_, err = client.Complete(context.Background(), cacheTestRequest("q"))
+	require.NoError(t, err)
+
+	var deltas []ports.ContentDelta
+	resp, err := client.StreamComplete(context.Background(), cacheTestRequest("q"), ports.CompletionStreamCallbacks{
+		OnContentDelta: func(delta ports.ContentDelta) { deltas = append(deltas, delta) },
+	})
+	require.NoError(t, err)
+	require.Equal(t, ports.ResponseCacheHit, resp.CacheStatus())
+	require.Equal(t, []ports.ContentDelta{{Delta: "cached answer"}, {Final: true}}, deltas)
+	require.Equal(t, 1, underlying.calls)
+}
+
+func TestResponseCachePersistsAndPurges(t *testing.T) {
+	dir := t.TempDir()
+	cache, err := NewResponseCache(ResponseCacheConfig{Dir: dir, MaxEntries: 2})
+	require.NoError(t, err)
+	for _, key := range []string{"a", "b", "c"} {
+		require.NoError(t, cache.Store(key, "openai", "gpt-4o", &ports.CompletionResponse{Content: key}))
+	}
+	require.Equal(t, 2, cache.Stats().Entries, "oldest entry is evicted beyond MaxEntries")
+
+	reopened, err := NewResponseCache(ResponseCacheConfig{Dir: dir})
+	require.NoError(t, err)
+	resp, ok := reopened.Lookup("c")
+	require.True(t, ok)
+	require.Equal(t, "c", resp.Content)
+	_, ok = reopened.Lookup("a")
+	require.False(t, ok)
+
+	removed, err := reopened.Purge()
+	require.NoError(t, err)
+	require.Equal(t, 2, removed)
+	require.Zero(t, reopened.Stats().Entries)
+}
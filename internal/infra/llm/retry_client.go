@@ -56,11 +56,14 @@ type retryClient struct {
 	rlConsecutive429  int
 	rlCircuitOpenedAt time.Time
 
-	// fallback support: when all retries are exhausted on a transient error,
-	// attempt a single call to the fallback client.
-	fallbackClientFn func() (portsllm.LLMClient, error)
-	fallbackProvider string
-	fallbackModel    string
+	// Provider failover: when the primary fails at the provider level
+	// (transient retries exhausted, circuit open, credentials revoked) the
+	// request is replayed against each fallback target in order.
+	fallbacks       []fallbackTarget
+	providerBreaker *alexerrors.CircuitBreaker // shared cool-off for the primary provider; nil disables it
+
+	usageMu       sync.RWMutex
+	usageCallback func(usage ports.TokenUsage, model string, provider string)
 }
 
 var _ portsllm.StreamingLLMClient = (*retryClient)(nil)
@@ -97,6 +100,9 @@ func (c *retryClient) Model() string {
 
 // SetUsageCallback sets the usage callback for cost tracking
 func (c *retryClient) SetUsageCallback(callback func(usage ports.TokenUsage, model string, provider string)) {
+	c.usageMu.Lock()
+	c.usageCallback = callback
+	c.usageMu.Unlock()
 	if trackingClient, ok := c.underlying.(portsllm.UsageTrackingClient); ok {
 		trackingClient.SetUsageCallback(callback)
 	}
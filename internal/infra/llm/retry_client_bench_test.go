@@ -184,11 +184,13 @@ func BenchmarkComplete_529ProviderFailover(b *testing.B) {
 		rc.provider = "anthropic"
 		rc.model = "claude-sonnet-4-6"
 		rc.sleepFn = func(_ context.Context, _ time.Duration) error { return nil }
-		rc.fallbackProvider = "kimi"
-		rc.fallbackModel = "kimi-for-coding"
-		rc.fallbackClientFn = func() (portsllm.LLMClient, error) {
-			return &instantFallbackMock{}, nil
-		}
+		rc.fallbacks = []fallbackTarget{{
+			provider: "kimi",
+			model:    "kimi-for-coding",
+			clientFn: func() (portsllm.LLMClient, error) {
+				return &instantFallbackMock{}, nil
+			},
+		}}
 		b.StartTimer()
 
 		resp, err := rc.Complete(ctx, req)
@@ -128,7 +128,16 @@ func (c *retryClient) classifyLLMError(err error) error {
 					}
 				}
 				if rule.permanent {
-					return alexerrors.NewPermanentError(err, rule.message)
+					perr := alexerrors.NewPermanentError(err, rule.message)
+					// Tag auth rejections so failover can tell them from
+					// request-content errors.
+					switch pattern {
+					case "401", "unauthorized":
+						perr.StatusCode = 401
+					case "403", "forbidden":
+						perr.StatusCode = 403
+					}
+					return perr
 				}
 				terr := alexerrors.NewTransientError(err, rule.message)
 				// Tag rate-limit errors with StatusCode so retryDelay and the
@@ -34,8 +34,11 @@ func (c *retryClient) Complete(ctx context.Context, req ports.CompletionRequest)
 		return nil, fmt.Errorf("%s", formattedErr)
 	}
 
-	c.recordHealthLatency(duration)
-	c.logLLMCallSummary(ctx, "complete", req, duration, resp, nil)
+	// A fallback's own retry wrapper already recorded its health and summary.
+	if !servedByFailover(resp) {
+		c.recordHealthLatency(duration)
+		c.logLLMCallSummary(ctx, "complete", req, duration, resp, nil)
+	}
 
 	if duration > 5*time.Second {
 		c.logger.Debug("LLM request succeeded after %v", duration)
@@ -45,6 +48,28 @@ func (c *retryClient) Complete(ctx context.Context, req ports.CompletionRequest)
 }
 
 func (c *retryClient) completeWithRetry(ctx context.Context, req ports.CompletionRequest) (*ports.CompletionResponse, error) {
+	// A provider still cooling off goes straight to the fallback chain.
+	if err := c.allowPrimary(); err != nil {
+		return c.failoverComplete(ctx, req, err)
+	}
+
+	resp, exhausted, err := c.completeOnPrimary(ctx, req)
+	c.markPrimary(err)
+	if err == nil {
+		return c.annotatePrimary(resp), nil
+	}
+	if c.shouldFailover(ctx, err) {
+		return c.failoverComplete(ctx, req, err)
+	}
+	if exhausted {
+		return nil, fmt.Errorf("max retries exceeded: %w", err)
+	}
+	return nil, err
+}
+
+// completeOnPrimary runs the retry loop against the primary client. The bool
+// reports whether the error is the last of a full set of transient retries.
+func (c *retryClient) completeOnPrimary(ctx context.Context, req ports.CompletionRequest) (*ports.CompletionResponse, bool, error) {
 	maxAttempts := c.retryConfig.MaxAttempts + 1
 	var lastErr error
 
@@ -52,13 +77,13 @@ func (c *retryClient) completeWithRetry(ctx context.Context, req ports.Completio
 		select {
 		case <-ctx.Done():
 			c.logger.Debug("Context cancelled, stopping retries")
-			return nil, fmt.Errorf("context cancelled: %w", ctx.Err())
+			return nil, false, fmt.Errorf("context cancelled: %w", ctx.Err())
 		default:
 		}
 
 		// Check the rate-limit circuit before each attempt.
 		if err := c.checkRateLimitCircuit(); err != nil {
-			return nil, err
+			return nil, false, err
 		}
 
 		if attempt == 0 {
@@ -81,7 +106,7 @@ func (c *retryClient) completeWithRetry(ctx context.Context, req ports.Completio
 			if attempt > 0 {
 				c.logger.Info("Retry succeeded after %d attempts", attempt+1)
 			}
-			return resp, nil
+			return resp, false, nil
 		}
 
 		lastErr = err
@@ -89,19 +114,19 @@ func (c *retryClient) completeWithRetry(ctx context.Context, req ports.Completio
 
 		if !coreerrors.IsTransient(err) {
 			// Thinking degradation: retry without thinking before giving up.
-			if req.Thinking.Enabled {
+			if req.Thinking.Enabled && !isProviderFailure(err) {
 				c.logger.Warn("[THINKING_DEGRADE] Primary %s/%s rejected thinking request; retrying without thinking: %v",
 					c.provider, c.model, err)
 				degradedReq := req
 				degradedReq.Thinking.Enabled = false
 				degradedResp, degradedErr := c.underlying.Complete(ctx, degradedReq)
 				if degradedErr == nil {
-					return degradedResp, nil
+					return degradedResp, false, nil
 				}
 				c.logger.Warn("[THINKING_DEGRADE] Degraded request also failed for %s/%s: %v", c.provider, c.model, degradedErr)
 			}
 			c.logger.Debug("Error is not transient, stopping retries")
-			return nil, err
+			return nil, false, err
 		}
 
 		if attempt == maxAttempts-1 {
@@ -112,36 +137,9 @@ func (c *retryClient) completeWithRetry(ctx context.Context, req ports.Completio
 		delay := c.retryDelay(attempt, err)
 		c.logger.Debug("Waiting %v before next retry", delay)
 		if err := c.waitForRetry(ctx, delay); err != nil {
-			return nil, err
+			return nil, false, err
 		}
 	}
 
-	// All retries exhausted — try fallback if available and error is transient.
-	if c.fallbackClientFn != nil && coreerrors.IsTransient(lastErr) {
-		return c.tryFallbackComplete(ctx, req, lastErr)
-	}
-
-	return nil, fmt.Errorf("max retries exceeded: %w", lastErr)
-}
-
-// tryFallbackComplete attempts a single Complete call on the fallback client.
-func (c *retryClient) tryFallbackComplete(ctx context.Context, req ports.CompletionRequest, originalErr error) (*ports.CompletionResponse, error) {
-	c.logger.Warn("[FALLBACK] Primary %s/%s exhausted retries (transient); attempting fallback to %s/%s: %v",
-		c.provider, c.model, c.fallbackProvider, c.fallbackModel, originalErr)
-
-	fallbackClient, err := c.fallbackClientFn()
-	if err != nil {
-		c.logger.Warn("[FALLBACK] Failed to create fallback client %s/%s: %v", c.fallbackProvider, c.fallbackModel, err)
-		return nil, fmt.Errorf("max retries exceeded (fallback unavailable): %w", originalErr)
-	}
-
-	resp, err := fallbackClient.Complete(ctx, req)
-	if err != nil {
-		c.logger.Warn("[FALLBACK] Fallback %s/%s also failed: %v", c.fallbackProvider, c.fallbackModel, err)
-		return nil, fmt.Errorf("max retries exceeded (fallback %s/%s also failed): %w", c.fallbackProvider, c.fallbackModel, originalErr)
-	}
-
-	c.logger.Info("[FALLBACK] Successfully completed via fallback %s/%s (primary %s/%s was unavailable)",
-		c.fallbackProvider, c.fallbackModel, c.provider, c.model)
-	return resp, nil
+	return nil, true, lastErr
 }
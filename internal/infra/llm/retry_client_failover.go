@@ -0,0 +1,333 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	coreerrors "alex/internal/core/errors"
+	"alex/internal/domain/agent/ports"
+	portsllm "alex/internal/domain/agent/ports/llm"
+	"alex/internal/infra/diagnostics"
+	alexerrors "alex/internal/shared/errors"
+	"alex/internal/shared/utils"
+)
+
+// Provider failover constants. One breaker failure is a whole primary or
+// fallback attempt (retries already exhausted), so the threshold is low.
+const (
+	// failoverBreakerThreshold is the number of consecutive provider-level
+	// failures before a provider is skipped for failoverCooldown.
+	failoverBreakerThreshold = 2
+
+	// failoverCooldown is how long a down provider is skipped before a single
+	// probe request is let through again.
+	failoverCooldown = 60 * time.Second
+
+	// failoverDeadline bounds each fallback attempt. It is independent of the
+	// parent deadline, which the primary's retries may already have used up.
+	failoverDeadline = 90 * time.Second
+)
+
+// fallbackTarget is one entry in a retry client's ordered failover chain.
+type fallbackTarget struct {
+	provider string
+	model    string
+	clientFn func() (portsllm.LLMClient, error)
+	breaker  *alexerrors.CircuitBreaker // shared per provider endpoint; nil disables the cool-off
+}
+
+func (t fallbackTarget) label() string {
+	return t.provider + "/" + t.model
+}
+
+// newFailoverBreakers returns the manager that hands out the per-provider
+// cool-off breakers shared by every client of a factory.
+func newFailoverBreakers() *alexerrors.CircuitBreakerManager {
+	return alexerrors.NewCircuitBreakerManager(alexerrors.CircuitBreakerConfig{
+		FailureThreshold: failoverBreakerThreshold,
+		SuccessThreshold: 1,
+		Timeout:          failoverCooldown,
+	})
+}
+
+// failoverBreakerName keys breakers by provider and endpoint, so two
+// OpenAI-compatible backends behind different base URLs cool off separately.
+func failoverBreakerName(provider, baseURL string) string {
+	name := "llm-provider-" + strings.TrimSpace(provider)
+	if baseURL = strings.TrimSpace(baseURL); baseURL != "" {
+		name += "@" + baseURL
+	}
+	return name
+}
+
+// isProviderFailure reports whether err means the provider itself is
+// unavailable (5xx/overload, timeouts and transport errors, an open circuit,
+// rejected credentials) rather than something wrong with the request content.
+func isProviderFailure(err error) bool {
+	if err == nil {
+		return false
+	}
+	if coreerrors.IsTransient(err) || coreerrors.IsDegraded(err) {
+		return true
+	}
+	return isAuthFailure(err)
+}
+
+var (
+	authFailureMarkers = []string{"unauthorized", "forbidden", "invalid api key", "invalid_api_key"}
+	// authStatusPattern matches a 401/403 status in unclassified error text
+	// ("status 401", "HTTP 403", "status code: 401") but not the same digits
+	// inside other numbers, such as "max_tokens 4016".
+	authStatusPattern = regexp.MustCompile(`(?i)\b(?:status|http)(?: code)?[:= ]*40[13]\b`)
+)
+
+// isAuthFailure matches rejected or revoked credentials. Classified errors
+// are decided by their status code alone; unclassified ones are matched on
+// their text.
+func isAuthFailure(err error) bool {
+	var permanentErr *coreerrors.PermanentError
+	if errors.As(err, &permanentErr) {
+		return permanentErr.StatusCode == 401 || permanentErr.StatusCode == 403
+	}
+	lower := strings.ToLower(err.Error())
+	for _, marker := range authFailureMarkers {
+		if strings.Contains(lower, marker) {
+			return true
+		}
+	}
+	return authStatusPattern.MatchString(lower)
+}
+
+// explicitlyCancelled mirrors utils.WithFreshDeadline: an expired parent
+// deadline does not stop failover, an explicit cancel does.
+func explicitlyCancelled(ctx context.Context) bool {
+	return errors.Is(ctx.Err(), context.Canceled)
+}
+
+func (c *retryClient) shouldFailover(ctx context.Context, err error) bool {
+	return len(c.fallbacks) > 0 && !explicitlyCancelled(ctx) && isProviderFailure(err)
+}
+
+// allowPrimary consults the shared provider breaker before the primary is
+// tried. Every nil return must be paired with markPrimary.
+func (c *retryClient) allowPrimary() error {
+	if c.providerBreaker == nil {
+		return nil
+	}
+	return c.providerBreaker.Allow()
+}
+
+func (c *retryClient) markPrimary(err error) {
+	if c.providerBreaker != nil {
+		markProviderOutcome(c.providerBreaker, err)
+	}
+}
+
+// markProviderOutcome only counts provider-level failures against a breaker;
+// a rejected request still proves the provider is up.
+func markProviderOutcome(breaker *alexerrors.CircuitBreaker, err error) {
+	if isProviderFailure(err) {
+		breaker.Mark(err)
+		return
+	}
+	breaker.Mark(nil)
+}
+
+func (c *retryClient) primaryModel() string {
+	if c.model != "" {
+		return c.model
+	}
+	return c.underlying.Model()
+}
+
+func (c *retryClient) primaryLabel() string {
+	return c.provider + "/" + c.primaryModel()
+}
+
+// annotateServedBy records which provider answered in the response metadata.
+// failoverFrom is the "provider/model" that was requested, or empty.
+func annotateServedBy(resp *ports.CompletionResponse, provider, model, failoverFrom string) *ports.CompletionResponse {
+	if resp == nil || provider == "" {
+		return resp
+	}
+	if resp.Metadata == nil {
+		resp.Metadata = make(map[string]any, 3)
+	}
+	resp.Metadata[ports.ResponseMetaProvider] = provider
+	if model != "" {
+		resp.Metadata[ports.ResponseMetaModel] = model
+	}
+	if failoverFrom != "" {
+		resp.Metadata[ports.ResponseMetaFailoverFrom] = failoverFrom
+	}
+	return resp
+}
+
+func (c *retryClient) annotatePrimary(resp *ports.CompletionResponse) *ports.CompletionResponse {
+	return annotateServedBy(resp, c.provider, c.primaryModel(), "")
+}
+
+func servedByFailover(resp *ports.CompletionResponse) bool {
+	if resp == nil || resp.Metadata == nil {
+		return false
+	}
+	_, ok := resp.Metadata[ports.ResponseMetaFailoverFrom]
+	return ok
+}
+
+// failoverComplete replays a Complete call against each fallback in order.
+func (c *retryClient) failoverComplete(ctx context.Context, req ports.CompletionRequest, cause error) (*ports.CompletionResponse, error) {
+	c.logger.Warn("[FALLBACK] Primary %s failed at the provider level; trying %d fallback(s): %v",
+		c.primaryLabel(), len(c.fallbacks), cause)
+
+	var skipped []string
+	for _, target := range c.fallbacks {
+		if explicitlyCancelled(ctx) {
+			break
+		}
+		resp, err := c.callFallback(target, func(client portsllm.LLMClient) (*ports.CompletionResponse, error) {
+			fallbackCtx, cancel := utils.WithFreshDeadline(ctx, failoverDeadline)
+			defer cancel()
+			return client.Complete(fallbackCtx, req)
+		})
+		if err == nil {
+			return c.finishFailover(target, resp, cause, skipped), nil
+		}
+		c.logger.Warn("[FALLBACK] Fallback %s also failed: %v", target.label(), err)
+		skipped = append(skipped, target.label())
+		if !isProviderFailure(err) {
+			break
+		}
+	}
+	return nil, failoverError(cause, skipped)
+}
+
+// failoverStream replays a streaming call against each fallback in order.
+// The chain stops as soon as any fallback has emitted output, since a later
+// provider would duplicate what the caller already received.
+func (c *retryClient) failoverStream(
+	ctx context.Context,
+	req ports.CompletionRequest,
+	callbacks ports.CompletionStreamCallbacks,
+	cause error,
+) (*ports.CompletionResponse, error) {
+	c.logger.Warn("[FALLBACK] Primary %s failed streaming at the provider level; trying %d fallback(s): %v",
+		c.primaryLabel(), len(c.fallbacks), cause)
+
+	var skipped []string
+	for _, target := range c.fallbacks {
+		if explicitlyCancelled(ctx) {
+			break
+		}
+		emitted := false
+		attemptCallbacks := callbacks
+		if callbacks.OnContentDelta != nil {
+			original := callbacks.OnContentDelta
+			attemptCallbacks.OnContentDelta = func(delta ports.ContentDelta) {
+				if delta.Delta != "" || delta.Final {
+					emitted = true
+				}
+				original(delta)
+			}
+		}
+		resp, err := c.callFallback(target, func(client portsllm.LLMClient) (*ports.CompletionResponse, error) {
+			fallbackCtx, cancel := utils.WithFreshDeadline(ctx, failoverDeadline)
+			defer cancel()
+			return EnsureStreamingClient(client).StreamComplete(fallbackCtx, req, attemptCallbacks)
+		})
+		if err == nil {
+			return c.finishFailover(target, resp, cause, skipped), nil
+		}
+		c.logger.Warn("[FALLBACK] Fallback streaming %s also failed: %v", target.label(), err)
+		skipped = append(skipped, target.label())
+		if emitted || !isProviderFailure(err) {
+			break
+		}
+	}
+	return nil, failoverError(cause, skipped)
+}
+
+// callFallback runs one fallback attempt behind that provider's breaker.
+func (c *retryClient) callFallback(
+	target fallbackTarget,
+	call func(portsllm.LLMClient) (*ports.CompletionResponse, error),
+) (*ports.CompletionResponse, error) {
+	if target.breaker != nil {
+		if err := target.breaker.Allow(); err != nil {
+			return nil, err
+		}
+	}
+	client, err := target.clientFn()
+	var resp *ports.CompletionResponse
+	if err == nil {
+		c.applyUsageCallback(client)
+		resp, err = call(client)
+	}
+	if target.breaker != nil {
+		markProviderOutcome(target.breaker, err)
+	}
+	return resp, err
+}
+
+// applyUsageCallback hands the caller's usage callback to a fallback client so
+// tokens are reported under the provider and model that actually responded.
+func (c *retryClient) applyUsageCallback(client portsllm.LLMClient) {
+	c.usageMu.RLock()
+	callback := c.usageCallback
+	c.usageMu.RUnlock()
+	if callback == nil {
+		return
+	}
+	if trackingClient, ok := client.(portsllm.UsageTrackingClient); ok {
+		trackingClient.SetUsageCallback(callback)
+	}
+}
+
+func (c *retryClient) finishFailover(
+	target fallbackTarget,
+	resp *ports.CompletionResponse,
+	cause error,
+	skipped []string,
+) *ports.CompletionResponse {
+	from := c.primaryLabel()
+	c.recordHealthError(cause)
+	c.logger.Info("[FALLBACK] Served by fallback %s (primary %s was unavailable)", target.label(), from)
+	diagnostics.PublishLLMFailover(diagnostics.LLMFailoverPayload{
+		FromProvider: c.provider,
+		FromModel:    c.primaryModel(),
+		ToProvider:   target.provider,
+		ToModel:      target.model,
+		Reason:       failoverReason(cause),
+		Skipped:      skipped,
+	})
+	return annotateServedBy(resp, target.provider, target.model, from)
+}
+
+// failoverReason names the provider-level failure that triggered failover.
+func failoverReason(err error) string {
+	switch {
+	case coreerrors.IsDegraded(err):
+		return "circuit_open"
+	case coreerrors.IsTransient(err):
+		return "transient"
+	case isAuthFailure(err):
+		return "auth"
+	default:
+		return "unknown"
+	}
+}
+
+func failoverError(cause error, tried []string) error {
+	detail := "no fallback available"
+	if len(tried) > 0 {
+		detail = fmt.Sprintf("fallbacks %s also failed", strings.Join(tried, ", "))
+	}
+	if coreerrors.IsTransient(cause) {
+		return fmt.Errorf("max retries exceeded (%s): %w", detail, cause)
+	}
+	return fmt.Errorf("%w (%s)", cause, detail)
+}
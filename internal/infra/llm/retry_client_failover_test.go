@@ -0,0 +1,252 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	coreerrors "alex/internal/core/errors"
+	"alex/internal/domain/agent/ports"
+	portsllm "alex/internal/domain/agent/ports/llm"
+	"alex/internal/infra/diagnostics"
+	alexerrors "alex/internal/shared/errors"
+
+	"github.com/stretchr/testify/require"
+)
+
+// scriptedClient returns err when set, otherwise a response with content.
+type scriptedClient struct {
+	model   string
+	content string
+	err     error
+	calls   int
+	usage   func(usage ports.TokenUsage, model string, provider string)
+}
+
+func (m *scriptedClient) Complete(ctx context.Context, req ports.CompletionRequest) (*ports.CompletionResponse, error) {
+	m.calls++
+	if m.err != nil {
+		return nil, m.err
+	}
+	resp := &ports.CompletionResponse{Content: m.content, Usage: ports.TokenUsage{PromptTokens: 7, CompletionTokens: 3, TotalTokens: 10}}
+	if m.usage != nil {
+		m.usage(resp.Usage, m.model, "fallback-provider")
+	}
+	return resp, nil
+}
+
+func (m *scriptedClient) SetUsageCallback(callback func(usage ports.TokenUsage, model string, provider string)) {
+	m.usage = callback
+}
+
+func (m *scriptedClient) Model() string { return m.model }
+
+func newFailoverTestClient(t *testing.T, primary portsllm.LLMClient, targets ...fallbackTarget) *retryClient {
+	t.Helper()
+	breaker := alexerrors.NewCircuitBreaker("test", alexerrors.CircuitBreakerConfig{
+		FailureThreshold: 100,
+		SuccessThreshold: 1,
+		Timeout:          time.Minute,
+	})
+	rc := NewRetryClient(primary, alexerrors.RetryConfig{MaxAttempts: 1}, breaker).(*retryClient)
+	rc.provider = "anthropic"
+	rc.model = "claude-sonnet-4-6"
+	rc.sleepFn = func(context.Context, time.Duration) error { return nil }
+	rc.fallbacks = targets
+	return rc
+}
+
+func staticTarget(provider string, client *scriptedClient) fallbackTarget {
+	return fallbackTarget{
+		provider: provider,
+		model:    client.model,
+		clientFn: func() (portsllm.LLMClient, error) { return client, nil },
+	}
+}
+
+func TestRetryClientAnnotatesPrimaryProvider(t *testing.T) {
+	rc := newFailoverTestClient(t, &scriptedClient{model: "claude-sonnet-4-6", content: "ok"})
+
+	resp, err := rc.Complete(context.Background(), ports.CompletionRequest{})
+	require.NoError(t, err)
+	provider, model := resp.ServedBy()
+	require.Equal(t, "anthropic", provider)
+	require.Equal(t, "claude-sonnet-4-6", model)
+	require.NotContains(t, resp.Metadata, ports.ResponseMetaFailoverFrom)
+}
+
+func TestRetryClientFailsOverOnRevokedCredentials(t *testing.T) {
+	primary := &scriptedClient{model: "claude-sonnet-4-6", err: errors.New("HTTP 401: unauthorized")}
+	fallback := &scriptedClient{model: "gpt-4o", content: "fallback-ok"}
+	rc := newFailoverTestClient(t, primary, staticTarget("openai", fallback))
+
+	resp, err := rc.Complete(context.Background(), ports.CompletionRequest{})
+	require.NoError(t, err)
+	require.Equal(t, "fallback-ok", resp.Content)
+	require.Equal(t, 1, primary.calls, "auth failures are not retried on the primary")
+
+	provider, model := resp.ServedBy()
+	require.Equal(t, "openai", provider)
+	require.Equal(t, "gpt-4o", model)
+	require.Equal(t, "anthropic/claude-sonnet-4-6", resp.Metadata[ports.ResponseMetaFailoverFrom])
+
+	entries := diagnostics.Recent(diagnostics.KindLLMFailover, 1)
+	require.Len(t, entries, 1)
+	payload, ok := entries[0].Payload.(diagnostics.LLMFailoverPayload)
+	require.True(t, ok)
+	require.Equal(t, "anthropic", payload.FromProvider)
+	require.Equal(t, "openai", payload.ToProvider)
+	require.Equal(t, "gpt-4o", payload.ToModel)
+	require.Equal(t, "auth", payload.Reason)
+}
+
+func TestIsAuthFailureIgnoresStatusDigitsInContentErrors(t *testing.T) {
+	for text, want := range map[string]bool{
+		"HTTP 401: unauthorized":                        true,
+		"request failed: status 403":                    true,
+		"status code: 401":                              true,
+		"invalid_api_key":                               true,
+		"max_tokens 4016 exceeds limit":                 false,
+		"prompt is 40312 tokens, context window is 32k": false,
+		"request id req_1401 failed":                    false,
+	} {
+		require.Equal(t, want, isAuthFailure(errors.New(text)), text)
+	}
+
+	classified := coreerrors.NewPermanentError(errors.New("status 401 in upstream body"), "bad request")
+	classified.StatusCode = 400
+	require.False(t, isAuthFailure(classified))
+	classified.StatusCode = 401
+	require.True(t, isAuthFailure(classified))
+}
+
+func TestRetryClientDoesNotFailOverOnContentErrorWithAuthDigits(t *testing.T) {
+	contentErr := coreerrors.NewPermanentError(errors.New("HTTP 400: max_tokens 4016 exceeds limit"), "max_tokens 4016 exceeds limit")
+	contentErr.StatusCode = 400
+	primary := &scriptedClient{model: "claude-sonnet-4-6", err: contentErr}
+	fallback := &scriptedClient{model: "gpt-4o", content: "never"}
+	rc := newFailoverTestClient(t, primary, staticTarget("openai", fallback))
+
+	_, err := rc.Complete(context.Background(), ports.CompletionRequest{})
+	require.Error(t, err)
+	require.Zero(t, fallback.calls)
+}
+
+func TestRetryClientFailoverWalksChainInOrder(t *testing.T) {
+	primary := &scriptedClient{model: "claude-sonnet-4-6", err: errors.New("HTTP 503: service unavailable")}
+	first := &scriptedClient{model: "deepseek-chat", err: errors.New("HTTP 502: bad gateway")}
+	second := &scriptedClient{model: "gpt-4o", content: "second-ok"}
+	third := &scriptedClient{model: "kimi-for-coding", content: "third-ok"}
+	rc := newFailoverTestClient(t, primary,
+		staticTarget("deepseek", first), staticTarget("openai", second), staticTarget("kimi", third))
+
+	resp, err := rc.Complete(context.Background(), ports.CompletionRequest{})
+	require.NoError(t, err)
+	require.Equal(t, "second-ok", resp.Content)
+	require.Equal(t, 1, first.calls)
+	require.Equal(t, 1, second.calls)
+	require.Zero(t, third.calls)
+
+	payload := diagnostics.Recent(diagnostics.KindLLMFailover, 1)[0].Payload.(diagnostics.LLMFailoverPayload)
+	require.Equal(t, []string{"deepseek/deepseek-chat"}, payload.Skipped)
+	require.Equal(t, "transient", payload.Reason)
+}
+
+func TestRetryClientFailoverStopsOnFallbackContentError(t *testing.T) {
+	primary := &scriptedClient{model: "claude-sonnet-4-6", err: errors.New("HTTP 503: service unavailable")}
+	first := &scriptedClient{model: "gpt-4o", err: errors.New("HTTP 400: bad request")}
+	second := &scriptedClient{model: "kimi-for-coding", content: "never"}
+	rc := newFailoverTestClient(t, primary, staticTarget("openai", first), staticTarget("kimi", second))
+
+	_, err := rc.Complete(context.Background(), ports.CompletionRequest{})
+	require.Error(t, err)
+	require.Zero(t, second.calls)
+}
+
+func TestRetryClientProviderBreakerSkipsDownPrimary(t *testing.T) {
+	primary := &scriptedClient{model: "claude-sonnet-4-6", err: errors.New("HTTP 503: service unavailable")}
+	fallback := &scriptedClient{model: "gpt-4o", content: "fallback-ok"}
+	rc := newFailoverTestClient(t, primary, staticTarget("openai", fallback))
+	rc.providerBreaker = newFailoverBreakers().Get(failoverBreakerName("anthropic", ""))
+
+	for i := 0; i < failoverBreakerThreshold; i++ {
+		_, err := rc.Complete(context.Background(), ports.CompletionRequest{})
+		require.NoError(t, err)
+	}
+	callsBeforeCoolOff := primary.calls
+
+	resp, err := rc.Complete(context.Background(), ports.CompletionRequest{})
+	require.NoError(t, err)
+	require.Equal(t, "fallback-ok", resp.Content)
+	require.Equal(t, callsBeforeCoolOff, primary.calls, "primary must not be called while cooling off")
+	require.Equal(t, failoverBreakerThreshold+1, fallback.calls)
+}
+
+func TestRetryClientFallbackBreakerSkipsDownTarget(t *testing.T) {
+	primary := &scriptedClient{model: "claude-sonnet-4-6", err: errors.New("HTTP 503: service unavailable")}
+	down := &scriptedClient{model: "deepseek-chat", err: errors.New("HTTP 502: bad gateway")}
+	up := &scriptedClient{model: "gpt-4o", content: "ok"}
+	downTarget := staticTarget("deepseek", down)
+	downTarget.breaker = newFailoverBreakers().Get(failoverBreakerName("deepseek", ""))
+	rc := newFailoverTestClient(t, primary, downTarget, staticTarget("openai", up))
+
+	for i := 0; i < failoverBreakerThreshold+2; i++ {
+		_, err := rc.Complete(context.Background(), ports.CompletionRequest{})
+		require.NoError(t, err)
+	}
+	require.Equal(t, failoverBreakerThreshold, down.calls, "down fallback is skipped once its breaker opens")
+}
+
+func TestRetryClientFailoverAttributesUsageToServingProvider(t *testing.T) {
+	primary := &scriptedClient{model: "claude-sonnet-4-6", err: errors.New("HTTP 529: overloaded")}
+	fallback := &scriptedClient{model: "gpt-4o", content: "ok"}
+	rc := newFailoverTestClient(t, primary, staticTarget("openai", fallback))
+
+	var gotModel, gotProvider string
+	var gotTokens int
+	rc.SetUsageCallback(func(usage ports.TokenUsage, model string, provider string) {
+		gotModel, gotProvider, gotTokens = model, provider, usage.TotalTokens
+	})
+
+	_, err := rc.Complete(context.Background(), ports.CompletionRequest{})
+	require.NoError(t, err)
+	require.Equal(t, "gpt-4o", gotModel)
+	require.Equal(t, "fallback-provider", gotProvider)
+	require.Equal(t, 10, gotTokens)
+}
+
+func TestRetryClientStreamFailoverAnnotatesResponse(t *testing.T) {
+	primary := &overloadedStreamMock{}
+	fallback := &fallbackStreamMock{}
+	breaker := alexerrors.NewCircuitBreaker("test", alexerrors.DefaultCircuitBreakerConfig())
+	rc := NewRetryClient(primary, alexerrors.RetryConfig{MaxAttempts: 0}, breaker).(*retryClient)
+	rc.provider = "anthropic"
+	rc.model = "claude-sonnet-4-6"
+	rc.fallbacks = []fallbackTarget{{
+		provider: "kimi",
+		model:    "kimi-for-coding",
+		clientFn: func() (portsllm.LLMClient, error) { return fallback, nil },
+	}}
+
+	resp, err := rc.StreamComplete(context.Background(), ports.CompletionRequest{}, ports.CompletionStreamCallbacks{
+		OnContentDelta: func(ports.ContentDelta) {},
+	})
+	require.NoError(t, err)
+	provider, model := resp.ServedBy()
+	require.Equal(t, "kimi", provider)
+	require.Equal(t, "kimi-for-coding", model)
+	require.Equal(t, "anthropic/claude-sonnet-4-6", resp.Metadata[ports.ResponseMetaFailoverFrom])
+}
+
+func TestRetryClientFailoverHonoursExplicitCancel(t *testing.T) {
+	primary := &scriptedClient{model: "claude-sonnet-4-6", err: errors.New("HTTP 503: service unavailable")}
+	fallback := &scriptedClient{model: "gpt-4o", content: "ok"}
+	rc := newFailoverTestClient(t, primary, staticTarget("openai", fallback))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := rc.Complete(ctx, ports.CompletionRequest{})
+	require.Error(t, err)
+	require.Zero(t, fallback.calls)
+}
@@ -6,9 +6,9 @@ import (
 	"time"
 
 	"alex/internal/domain/agent/ports"
+	portsllm "alex/internal/domain/agent/ports/llm"
 	alexerrors "alex/internal/shared/errors"
 	coreerrors "alex/internal/core/errors"
-	"alex/internal/shared/utils"
 )
 
 // StreamComplete proxies streaming requests to the underlying client when supported.
@@ -40,6 +40,59 @@ func (c *retryClient) StreamComplete(
 
 	startTime := time.Now()
 
+	var resp *ports.CompletionResponse
+	var err error
+	observedStreamOutput := false
+
+	// A provider still cooling off goes straight to the fallback chain.
+	if err = c.allowPrimary(); err == nil {
+		resp, observedStreamOutput, err = c.streamOnPrimary(ctx, streamingClient, req, callbacks)
+		c.markPrimary(err)
+	}
+
+	// Fail over only when no output was emitted; a second provider would
+	// otherwise duplicate what the caller already received.
+	if err != nil && !observedStreamOutput && c.shouldFailover(ctx, err) {
+		if fbResp, fbErr := c.failoverStream(ctx, req, callbacks, err); fbErr == nil {
+			return fbResp, nil
+		}
+		// Every fallback failed too — report the primary's error below.
+	}
+
+	duration := time.Since(startTime)
+
+	if err != nil {
+		c.recordHealthError(err)
+		c.logLLMCallSummary(ctx, "stream", req, duration, nil, err)
+		if coreerrors.IsDegraded(err) {
+			return nil, fmt.Errorf("%s", coreerrors.FormatForLLM(err))
+		}
+		formattedErr := c.formatStreamingError(err, duration)
+		if coreerrors.IsTransient(err) {
+			return nil, coreerrors.NewTransientError(err, formattedErr)
+		}
+		return nil, fmt.Errorf("%s", formattedErr)
+	}
+
+	c.recordHealthLatency(duration)
+	c.logLLMCallSummary(ctx, "stream", req, duration, resp, nil)
+
+	if duration > 5*time.Second {
+		c.logger.Debug("LLM streaming request succeeded after %v", duration)
+	}
+
+	return c.annotatePrimary(resp), nil
+}
+
+// streamOnPrimary runs the pre-output retry loop against the primary client,
+// followed by thinking degradation. The bool reports whether any streamed
+// output reached the caller, after which nothing may be retried.
+func (c *retryClient) streamOnPrimary(
+	ctx context.Context,
+	streamingClient portsllm.StreamingLLMClient,
+	req ports.CompletionRequest,
+	callbacks ports.CompletionStreamCallbacks,
+) (*ports.CompletionResponse, bool, error) {
 	// Retry loop for transient stream failures before output is emitted.
 	// These errors are only safe to retry before any streamed content has been emitted.
 	maxAttempts := c.retryConfig.MaxAttempts + 1
@@ -88,8 +141,8 @@ func (c *retryClient) StreamComplete(
 		if attempt < maxAttempts-1 {
 			delay := c.retryDelay(attempt, err)
 			c.logger.Debug("Streaming request failed, retrying in %v (attempt %d/%d): %v", delay, attempt+1, maxAttempts, err)
-			if err := c.waitForRetry(ctx, delay); err != nil {
-				return nil, err
+			if waitErr := c.waitForRetry(ctx, delay); waitErr != nil {
+				return nil, false, waitErr
 			}
 		}
 	}
@@ -97,85 +150,17 @@ func (c *retryClient) StreamComplete(
 	// Thinking degradation: if the request had thinking enabled and we got
 	// a permanent error (e.g. 400 invalid_request), retry once without
 	// thinking on the same provider before escalating to fallback.
-	if err != nil && !observedStreamOutput && req.Thinking.Enabled && !coreerrors.IsTransient(err) {
+	if err != nil && !observedStreamOutput && req.Thinking.Enabled && !isProviderFailure(err) {
 		c.logger.Warn("[THINKING_DEGRADE] Primary %s/%s rejected thinking request; retrying without thinking: %v",
 			c.provider, c.model, err)
 		degradedReq := req
 		degradedReq.Thinking.Enabled = false
 		degradedResp, degradedErr := streamingClient.StreamComplete(ctx, degradedReq, callbacks)
 		if degradedErr == nil {
-			duration := time.Since(startTime)
-			c.recordHealthLatency(duration)
-			c.logLLMCallSummary(ctx, "stream", degradedReq, duration, degradedResp, nil)
-			return degradedResp, nil
+			return degradedResp, true, nil
 		}
 		c.logger.Warn("[THINKING_DEGRADE] Degraded streaming also failed for %s/%s: %v", c.provider, c.model, degradedErr)
-		// Fall through to fallback logic below
-	}
-
-	// All retries exhausted — try streaming fallback if available, transient, and no output was emitted.
-	if err != nil && c.fallbackClientFn != nil && coreerrors.IsTransient(err) && !observedStreamOutput {
-		fbResp, fbErr := c.tryFallbackStreamComplete(ctx, req, callbacks, err)
-		if fbErr == nil {
-			return fbResp, nil
-		}
-		// Fallback also failed — continue to normal error reporting with the original error.
-	}
-
-	duration := time.Since(startTime)
-
-	if err != nil {
-		c.recordHealthError(err)
-		c.logLLMCallSummary(ctx, "stream", req, duration, nil, err)
-		if coreerrors.IsDegraded(err) {
-			return nil, fmt.Errorf("%s", coreerrors.FormatForLLM(err))
-		}
-		formattedErr := c.formatStreamingError(err, duration)
-		if coreerrors.IsTransient(err) {
-			return nil, coreerrors.NewTransientError(err, formattedErr)
-		}
-		return nil, fmt.Errorf("%s", formattedErr)
-	}
-
-	c.recordHealthLatency(duration)
-	c.logLLMCallSummary(ctx, "stream", req, duration, resp, nil)
-
-	if duration > 5*time.Second {
-		c.logger.Debug("LLM streaming request succeeded after %v", duration)
-	}
-
-	return resp, nil
-}
-
-// tryFallbackStreamComplete attempts a single StreamComplete call on the fallback client.
-func (c *retryClient) tryFallbackStreamComplete(
-	ctx context.Context,
-	req ports.CompletionRequest,
-	callbacks ports.CompletionStreamCallbacks,
-	originalErr error,
-) (*ports.CompletionResponse, error) {
-	c.logger.Warn("[FALLBACK] Primary %s/%s exhausted streaming retries (transient); attempting fallback to %s/%s: %v",
-		c.provider, c.model, c.fallbackProvider, c.fallbackModel, originalErr)
-
-	fallbackClient, err := c.fallbackClientFn()
-	if err != nil {
-		c.logger.Warn("[FALLBACK] Failed to create fallback client %s/%s: %v", c.fallbackProvider, c.fallbackModel, err)
-		return nil, err
-	}
-
-	streamingFB := EnsureStreamingClient(fallbackClient)
-
-	const fallbackDeadline = 90 * time.Second
-	fallbackCtx, fallbackCancel := utils.WithFreshDeadline(ctx, fallbackDeadline)
-	defer fallbackCancel()
-
-	resp, err := streamingFB.StreamComplete(fallbackCtx, req, callbacks)
-	if err != nil {
-		c.logger.Warn("[FALLBACK] Fallback streaming %s/%s also failed: %v", c.fallbackProvider, c.fallbackModel, err)
-		return nil, err
 	}
 
-	c.logger.Info("[FALLBACK] Successfully completed streaming via fallback %s/%s (primary %s/%s was unavailable)",
-		c.fallbackProvider, c.fallbackModel, c.provider, c.model)
-	return resp, nil
+	return resp, observedStreamOutput, err
 }
@@ -387,11 +387,13 @@ func TestRetryClientFallbackOnTransientExhaustion(t *testing.T) {
 	rc.sleepFn = func(ctx context.Context, d time.Duration) error { return nil }
 
 	fallback := &fallbackCompleteMock{}
-	rc.fallbackProvider = "kimi"
-	rc.fallbackModel = "kimi-for-coding"
-	rc.fallbackClientFn = func() (portsllm.LLMClient, error) {
-		return fallback, nil
-	}
+	rc.fallbacks = []fallbackTarget{{
+		provider: "kimi",
+		model:    "kimi-for-coding",
+		clientFn: func() (portsllm.LLMClient, error) {
+			return fallback, nil
+		},
+	}}
 
 	resp, err := rc.Complete(context.Background(), ports.CompletionRequest{})
 	require.NoError(t, err)
@@ -400,8 +402,20 @@ func TestRetryClientFallbackOnTransientExhaustion(t *testing.T) {
 	require.Equal(t, 1, fallback.calls, "fallback should be called once")
 }
 
-func TestRetryClientNoFallbackOnPermanentError(t *testing.T) {
-	mock := &authRefreshMock{} // returns 401 permanent on first call
+// badRequestCompleteMock rejects every request as invalid content.
+type badRequestCompleteMock struct {
+	calls int
+}
+
+func (m *badRequestCompleteMock) Complete(ctx context.Context, req ports.CompletionRequest) (*ports.CompletionResponse, error) {
+	m.calls++
+	return nil, errors.New("HTTP 400: bad request: messages too long")
+}
+
+func (m *badRequestCompleteMock) Model() string { return "mock" }
+
+func TestRetryClientNoFallbackOnContentError(t *testing.T) {
+	mock := &badRequestCompleteMock{} // 400 is a content error, not a provider failure
 	breaker := alexerrors.NewCircuitBreaker("test", alexerrors.DefaultCircuitBreakerConfig())
 	client := NewRetryClient(mock, alexerrors.RetryConfig{
 		MaxAttempts: 0,
@@ -414,16 +428,18 @@ func TestRetryClientNoFallbackOnPermanentError(t *testing.T) {
 	rc.sleepFn = func(ctx context.Context, d time.Duration) error { return nil }
 
 	fallbackCalled := false
-	rc.fallbackProvider = "kimi"
-	rc.fallbackModel = "kimi-for-coding"
-	rc.fallbackClientFn = func() (portsllm.LLMClient, error) {
-		fallbackCalled = true
-		return &fallbackCompleteMock{}, nil
-	}
+	rc.fallbacks = []fallbackTarget{{
+		provider: "kimi",
+		model:    "kimi-for-coding",
+		clientFn: func() (portsllm.LLMClient, error) {
+			fallbackCalled = true
+			return &fallbackCompleteMock{}, nil
+		},
+	}}
 
 	_, err := rc.Complete(context.Background(), ports.CompletionRequest{})
 	require.Error(t, err)
-	require.False(t, fallbackCalled, "fallback should NOT be called for permanent errors")
+	require.False(t, fallbackCalled, "fallback should NOT be called for content errors")
 }
 
 func TestRetryClientFallbackStreamingOnTransientExhaustion(t *testing.T) {
@@ -443,11 +459,13 @@ func TestRetryClientFallbackStreamingOnTransientExhaustion(t *testing.T) {
 	rc.sleepFn = func(ctx context.Context, d time.Duration) error { return nil }
 
 	fallbackStream := &fallbackStreamMock{}
-	rc.fallbackProvider = "kimi"
-	rc.fallbackModel = "kimi-for-coding"
-	rc.fallbackClientFn = func() (portsllm.LLMClient, error) {
-		return fallbackStream, nil
-	}
+	rc.fallbacks = []fallbackTarget{{
+		provider: "kimi",
+		model:    "kimi-for-coding",
+		clientFn: func() (portsllm.LLMClient, error) {
+			return fallbackStream, nil
+		},
+	}}
 
 	var deltas []ports.ContentDelta
 	streaming := portsllm.StreamingLLMClient(rc)
@@ -16,6 +16,9 @@ type CleanupConfig struct {
 	ArchiveAfterDays int           // move entries older than N days (0 disables)
 	CleanupInterval  time.Duration // how often to scan
 	InitialDelay     time.Duration // delay before first run (default 5s)
+	// Compaction archives expired, duplicate and over-cap entries on each
+	// pass. The zero policy disables it.
+	Compaction CompactionPolicy
 }
 
 // CleanupResult reports what a single cleanup pass did.
@@ -93,9 +96,10 @@ func archiveFile(dailyDir, archiveDir, name string) error {
 }
 
 // StartCleanupLoop launches a background goroutine that periodically runs
-// CleanupExpired. It stops when ctx is cancelled.
+// CleanupExpired and Compact. It is a no-op unless archiving or compaction is
+// enabled, and stops when ctx is cancelled.
 func (e *MarkdownEngine) StartCleanupLoop(ctx context.Context, cfg CleanupConfig) {
-	if cfg.ArchiveAfterDays <= 0 || cfg.CleanupInterval <= 0 {
+	if cfg.CleanupInterval <= 0 || (cfg.ArchiveAfterDays <= 0 && !cfg.Compaction.Enabled()) {
 		return
 	}
 
@@ -115,7 +119,7 @@ func (e *MarkdownEngine) StartCleanupLoop(ctx context.Context, cfg CleanupConfig
 			return
 		case <-time.After(initialDelay):
 		}
-		e.runCleanup(logger, cfg.ArchiveAfterDays)
+		e.runCleanup(ctx, logger, cfg)
 
 		ticker := time.NewTicker(cfg.CleanupInterval)
 		defer ticker.Stop()
@@ -124,20 +128,33 @@ func (e *MarkdownEngine) StartCleanupLoop(ctx context.Context, cfg CleanupConfig
 			case <-ctx.Done():
 				return
 			case <-ticker.C:
-				e.runCleanup(logger, cfg.ArchiveAfterDays)
+				e.runCleanup(ctx, logger, cfg)
 			}
 		}
 	}()
 }
 
-func (e *MarkdownEngine) runCleanup(logger logging.Logger, archiveAfterDays int) {
-	cutoff := time.Now().AddDate(0, 0, -archiveAfterDays)
-	result, err := e.CleanupExpired(cutoff)
+func (e *MarkdownEngine) runCleanup(ctx context.Context, logger logging.Logger, cfg CleanupConfig) {
+	if cfg.ArchiveAfterDays > 0 {
+		cutoff := time.Now().AddDate(0, 0, -cfg.ArchiveAfterDays)
+		result, err := e.CleanupExpired(cutoff)
+		if err != nil {
+			logger.Warn("memory cleanup failed: %v", err)
+		} else if result.Archived > 0 || result.Errors > 0 {
+			logger.Info("archived %d entries older than %d days (errors: %d)", result.Archived, cfg.ArchiveAfterDays, result.Errors)
+		}
+	}
+
+	if !cfg.Compaction.Enabled() {
+		return
+	}
+	// Compact after archiving so archived days are not rewritten.
+	compacted, err := e.Compact(ctx, cfg.Compaction)
 	if err != nil {
-		logger.Warn("memory cleanup failed: %v", err)
+		logger.Warn("memory compaction failed: %v", err)
 		return
 	}
-	if result.Archived > 0 || result.Errors > 0 {
-		logger.Info("archived %d entries older than %d days (errors: %d)", result.Archived, archiveAfterDays, result.Errors)
+	if compacted.Evicted() > 0 || compacted.Errors > 0 {
+		logger.Info("memory compaction: %s", compacted)
 	}
 }
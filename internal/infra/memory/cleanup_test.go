@@ -248,7 +248,8 @@ func TestStartCleanupLoop_NopWhenDisabled(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	// Should not launch a goroutine when ArchiveAfterDays is 0.
+	// Should not launch a goroutine when ArchiveAfterDays is 0 and
+	// compaction is off.
 	engine.StartCleanupLoop(ctx, CleanupConfig{
 		ArchiveAfterDays: 0,
 		CleanupInterval:  time.Second,
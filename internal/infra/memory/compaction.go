@@ -0,0 +1,369 @@
+package memory
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"alex/internal/infra/filestore"
+	"alex/internal/shared/utils"
+)
+
+// CompactionPolicy controls how Compact evicts daily memory entries.
+// Compaction is opt-in: the zero policy is disabled. When enabled, expired
+// entries are always evicted as well.
+type CompactionPolicy struct {
+	// MaxEntries caps live daily entries per memory root, keeping the most
+	// important (then newest). 0 disables the cap.
+	MaxEntries int
+	// DuplicateThreshold is the cosine similarity of term and adjacent-term
+	// weights at or above which two entries merge into the newer one. 0
+	// disables merging.
+	DuplicateThreshold float64
+	// Now overrides the clock used for expiry; zero uses the engine clock.
+	Now time.Time
+}
+
+// CompactionResult reports what a single compaction pass did.
+type CompactionResult struct {
+	Scanned        int
+	Expired        int
+	Merged         int
+	Capped         int
+	FilesRewritten int
+	Errors         int
+}
+
+// Enabled reports whether the policy evicts anything.
+func (p CompactionPolicy) Enabled() bool {
+	return p.MaxEntries > 0 || p.DuplicateThreshold > 0
+}
+
+// Evicted is the number of entries moved to the archive by the pass.
+func (r CompactionResult) Evicted() int {
+	return r.Expired + r.Merged + r.Capped
+}
+
+// String summarizes the result for logs.
+func (r CompactionResult) String() string {
+	return fmt.Sprintf("scanned %d, expired %d, merged %d, capped %d, rewrote %d files (errors: %d)",
+		r.Scanned, r.Expired, r.Merged, r.Capped, r.FilesRewritten, r.Errors)
+}
+
+// compactionEntry is a daily entry considered by a compaction pass.
+type compactionEntry struct {
+	path      string
+	seq       int // position across the root, oldest first
+	hash      string
+	meta      entryMeta
+	weights   map[string]float64
+	dropped   bool
+	rewritten bool // meta changed by a merge
+}
+
+// Compact evicts daily entries in three steps: it drops expired entries,
+// merges near-duplicates into the newer copy (keeping the higher importance
+// and the later expiry), then enforces MaxEntries by importance. Evicted
+// entries are appended to archive/compacted/<day>.md rather than deleted.
+// MEMORY.md is curated by hand and never compacted. A disabled policy is a
+// no-op.
+//
+// Decisions are made on a snapshot; each file is then rewritten under the
+// same flock AppendDaily takes, so entries appended meanwhile are kept.
+func (e *MarkdownEngine) Compact(ctx context.Context, policy CompactionPolicy) (CompactionResult, error) {
+	var result CompactionResult
+	if !policy.Enabled() {
+		return result, nil
+	}
+	root, err := e.requireRoot()
+	if err != nil {
+		return result, err
+	}
+	now := policy.Now
+	if now.IsZero() {
+		now = e.currentTime()
+	}
+
+	entries, err := loadCompactionEntries(filepath.Join(root, dailyDirName))
+	if err != nil {
+		return result, err
+	}
+	result.Scanned = len(entries)
+
+	live := make([]*compactionEntry, 0, len(entries))
+	for _, entry := range entries {
+		if entry.meta.expired(now) {
+			entry.dropped = true
+			result.Expired++
+			continue
+		}
+		live = append(live, entry)
+	}
+	if policy.DuplicateThreshold > 0 {
+		live, result.Merged = mergeDuplicateEntries(live, policy.DuplicateThreshold)
+	}
+	if policy.MaxEntries > 0 && len(live) > policy.MaxEntries {
+		sort.SliceStable(live, func(i, j int) bool {
+			if a, b := live[i].meta.importance(), live[j].meta.importance(); a != b {
+				return a > b
+			}
+			return live[i].seq > live[j].seq
+		})
+		for _, entry := range live[policy.MaxEntries:] {
+			entry.dropped = true
+			result.Capped++
+		}
+	}
+
+	byPath := make(map[string][]*compactionEntry)
+	for _, entry := range entries {
+		if entry.dropped || entry.rewritten {
+			byPath[entry.path] = append(byPath[entry.path], entry)
+		}
+	}
+	paths := make([]string, 0, len(byPath))
+	for path := range byPath {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	archiveDir := filepath.Join(root, archiveDirName, compactedDirName)
+	for _, path := range paths {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+		if err := rewriteDailyFile(path, archiveDir, byPath[path]); err != nil {
+			result.Errors++
+			continue
+		}
+		result.FilesRewritten++
+	}
+	return result, nil
+}
+
+// loadCompactionEntries parses every daily log, oldest file first.
+func loadCompactionEntries(dailyDir string) ([]*compactionEntry, error) {
+	files, err := os.ReadDir(dailyDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("read daily dir: %w", err)
+	}
+	names := make([]string, 0, len(files))
+	for _, f := range files {
+		if !f.IsDir() && strings.HasSuffix(f.Name(), ".md") {
+			names = append(names, f.Name())
+		}
+	}
+	sort.Strings(names)
+
+	var entries []*compactionEntry
+	for _, name := range names {
+		path := filepath.Join(dailyDir, name)
+		lines, err := readLines(path)
+		if err != nil {
+			continue
+		}
+		for _, block := range parseDailyBlocks(lines) {
+			body := strings.Join(lines[block.bodyStart():block.end], "\n")
+			entries = append(entries, &compactionEntry{
+				path:    path,
+				seq:     len(entries),
+				hash:    blockHash(lines[block.start:block.end]),
+				meta:    block.meta,
+				weights: termWeights(entryTitle(lines[block.start]) + "\n" + body),
+			})
+		}
+	}
+	return entries, nil
+}
+
+// mergeDuplicateEntries folds each entry into the newest near-duplicate that
+// follows it. Input must be oldest first; the survivors are returned.
+func mergeDuplicateEntries(entries []*compactionEntry, threshold float64) ([]*compactionEntry, int) {
+	survivors := make([]*compactionEntry, 0, len(entries))
+	merged := 0
+	for _, entry := range entries {
+		match := -1
+		for idx, kept := range survivors {
+			if termCosine(kept.weights, entry.weights) >= threshold {
+				match = idx
+				break
+			}
+		}
+		if match < 0 {
+			survivors = append(survivors, entry)
+			continue
+		}
+		older := survivors[match]
+		older.dropped = true
+		merged++
+		combined := entryMeta{
+			ExpiresAt:  laterExpiry(older.meta.ExpiresAt, entry.meta.ExpiresAt),
+			Importance: math.Max(older.meta.Importance, entry.meta.Importance),
+		}
+		if combined != entry.meta {
+			entry.meta = combined
+			entry.rewritten = true
+		}
+		survivors[match] = entry
+	}
+	return survivors, merged
+}
+
+// laterExpiry keeps the longer-lived expiry; zero (never) wins.
+func laterExpiry(a, b time.Time) time.Time {
+	if a.IsZero() || b.IsZero() {
+		return time.Time{}
+	}
+	if a.After(b) {
+		return a
+	}
+	return b
+}
+
+// rewriteDailyFile applies drop/meta decisions to one daily log. Blocks are
+// matched by content hash, so blocks that changed or were appended since the
+// snapshot are left alone. Dropped blocks are archived first and the log is
+// then replaced atomically, so a crash never loses entries.
+func rewriteDailyFile(path, archiveDir string, decisions []*compactionEntry) error {
+	f, err := lockDailyFile(path, os.O_RDONLY)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	defer syscall.Flock(int(f.Fd()), syscall.LOCK_UN) //nolint:errcheck
+
+	lines, err := readLines(path)
+	if err != nil {
+		return err
+	}
+	pending := make(map[string][]*compactionEntry, len(decisions))
+	for _, d := range decisions {
+		pending[d.hash] = append(pending[d.hash], d)
+	}
+
+	blocks := parseDailyBlocks(lines)
+	out := make([]string, 0, len(lines))
+	if len(blocks) > 0 {
+		out = append(out, lines[:blocks[0].start]...)
+	} else {
+		out = append(out, lines...)
+	}
+	var archived []string
+	changed := false
+	for _, block := range blocks {
+		hash := blockHash(lines[block.start:block.end])
+		queue := pending[hash]
+		if len(queue) == 0 {
+			out = append(out, lines[block.start:block.end]...)
+			continue
+		}
+		decision := queue[0]
+		pending[hash] = queue[1:]
+		changed = true
+		if decision.dropped {
+			archived = append(archived, lines[block.start:block.end]...)
+			continue
+		}
+		out = append(out, lines[block.start])
+		if meta := formatEntryMeta(decision.meta); meta != "" {
+			out = append(out, meta)
+		}
+		out = append(out, lines[block.bodyStart():block.end]...)
+	}
+	if !changed {
+		return nil
+	}
+
+	if len(archived) > 0 {
+		if err := archiveDailyBlocks(archiveDir, filepath.Base(path), archived); err != nil {
+			return fmt.Errorf("archive evicted entries: %w", err)
+		}
+	}
+	return filestore.AtomicWrite(path, []byte(strings.Join(out, "\n")+"\n"), 0o644)
+}
+
+// archiveDailyBlocks appends evicted blocks to the archive copy of a daily
+// log, creating it with the day's header.
+func archiveDailyBlocks(archiveDir, name string, lines []string) error {
+	if err := os.MkdirAll(archiveDir, 0o755); err != nil {
+		return err
+	}
+	path := filepath.Join(archiveDir, name)
+	if err := ensureDailyHeader(path, strings.TrimSuffix(name, ".md")); err != nil {
+		return err
+	}
+	block := strings.Join(lines, "\n") + "\n"
+	if needsLeadingNewline(path) {
+		block = "\n" + block
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.WriteString(block); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func blockHash(lines []string) string {
+	sum := sha256.Sum256([]byte(strings.Join(lines, "\n")))
+	return fmt.Sprintf("%x", sum[:])
+}
+
+// entryTitle strips the "## <time> - " prefix from a heading.
+func entryTitle(heading string) string {
+	heading = strings.TrimPrefix(heading, "## ")
+	if _, title, ok := strings.Cut(heading, " - "); ok {
+		return title
+	}
+	return heading
+}
+
+// termWeights builds a frequency vector over terms and adjacent term pairs,
+// using the tokenizer the lexical search uses. The pairs make word order
+// count, so reordered sentences with a different meaning score lower.
+func termWeights(text string) map[string]float64 {
+	weights := make(map[string]float64)
+	prev := ""
+	for _, term := range tokenize(text) {
+		trimmed := utils.TrimLower(term)
+		if trimmed == "" {
+			continue
+		}
+		weights[trimmed]++
+		if prev != "" {
+			weights[prev+" "+trimmed]++
+		}
+		prev = trimmed
+	}
+	return weights
+}
+
+func termCosine(a, b map[string]float64) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+	var dot, normA, normB float64
+	for term, wa := range a {
+		normA += wa * wa
+		dot += wa * b[term]
+	}
+	for _, wb := range b {
+		normB += wb * wb
+	}
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+}
@@ -0,0 +1,255 @@
+package memory
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"alex/internal/infra/filestore"
+)
+
+func appendTestEntries(t *testing.T, eng *MarkdownEngine, entries ...DailyEntry) {
+	t.Helper()
+	for _, entry := range entries {
+		if _, err := eng.AppendDaily(context.Background(), "", entry); err != nil {
+			t.Fatalf("AppendDaily(%q): %v", entry.Title, err)
+		}
+	}
+}
+
+func dailyContent(t *testing.T, dir string, day time.Time) string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(dir, dailyDirName, day.Format("2006-01-02")+".md"))
+	if err != nil {
+		t.Fatalf("read daily: %v", err)
+	}
+	return string(data)
+}
+
+func TestSearchSkipsExpiredEntriesBeforeCompaction(t *testing.T) {
+	dir := t.TempDir()
+	eng := NewMarkdownEngine(dir)
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	eng.now = func() time.Time { return now }
+	day := time.Date(2026, 3, 9, 9, 0, 0, 0, time.UTC)
+
+	appendTestEntries(t, eng,
+		DailyEntry{Title: "Deploy freeze", Content: "Deploy freeze until Friday for the payments cluster.", CreatedAt: day, ExpiresAt: now.Add(-time.Hour)},
+		DailyEntry{Title: "Deploy window", Content: "Deploy window for the search cluster is Tuesday.", CreatedAt: day.Add(time.Minute), ExpiresAt: now.Add(24 * time.Hour), Importance: 0.9},
+	)
+	if content := dailyContent(t, dir, day); !strings.Contains(content, "<!-- memory: expires_at=") || !strings.Contains(content, "importance=0.90") {
+		t.Fatalf("expected metadata comment in daily log, got:\n%s", content)
+	}
+
+	eng.SetChunkConfig(8, 0)
+	hits, err := eng.Search(context.Background(), "", "deploy cluster", 10, 0.1)
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if len(hits) == 0 {
+		t.Fatal("expected the live entry to be found")
+	}
+	for _, hit := range hits {
+		if strings.Contains(hit.Snippet, "payments") {
+			t.Fatalf("expired entry leaked into search: %+v", hit)
+		}
+		if strings.Contains(hit.Snippet, "<!-- memory:") {
+			t.Fatalf("metadata comment leaked into snippet: %q", hit.Snippet)
+		}
+	}
+
+	if _, err := eng.AppendDaily(context.Background(), "", DailyEntry{Content: "x", Importance: 1.5}); err == nil {
+		t.Fatal("expected out-of-range importance to be rejected")
+	}
+}
+
+func TestDropInactiveHitsFiltersIndexResults(t *testing.T) {
+	dir := t.TempDir()
+	eng := NewMarkdownEngine(dir)
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	day := time.Date(2026, 3, 9, 9, 0, 0, 0, time.UTC)
+	appendTestEntries(t, eng,
+		DailyEntry{Title: "Old", Content: "stale fact", CreatedAt: day, ExpiresAt: now.Add(-time.Minute)},
+		DailyEntry{Title: "New", Content: "fresh fact", CreatedAt: day.Add(time.Minute)},
+	)
+	root := ResolveUserRoot(dir, "")
+	rel := filepath.Join(dailyDirName, "2026-03-09.md")
+	hits := []SearchHit{
+		{Path: rel, StartLine: 3, EndLine: 5, Snippet: "stale fact"},
+		{Path: rel, StartLine: 3, EndLine: 7, Snippet: "stale fact fresh fact"},
+		{Path: filepath.Join(dailyDirName, "missing.md"), StartLine: 1, EndLine: 1, Snippet: "kept"},
+	}
+	got := dropInactiveHits(root, hits, now)
+	if len(got) != 2 {
+		t.Fatalf("expected expired-only hit dropped, got %+v", got)
+	}
+	if strings.Contains(got[0].Snippet, "stale") || !strings.Contains(got[0].Snippet, "fresh fact") {
+		t.Fatalf("expected snippet rebuilt from live lines, got %q", got[0].Snippet)
+	}
+	if got[1].Snippet != "kept" {
+		t.Fatalf("expected unreadable path kept as-is, got %+v", got[1])
+	}
+}
+
+func TestCompactExpiresMergesAndCaps(t *testing.T) {
+	dir := t.TempDir()
+	eng := NewMarkdownEngine(dir)
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	day1 := time.Date(2026, 3, 8, 9, 0, 0, 0, time.UTC)
+	day2 := time.Date(2026, 3, 9, 9, 0, 0, 0, time.UTC)
+
+	appendTestEntries(t, eng,
+		DailyEntry{Title: "Temp", Content: "One-off reminder about the standup room.", CreatedAt: day1, ExpiresAt: now.Add(-time.Hour)},
+		DailyEntry{Title: "Preference", Content: "User prefers concise answers in Chinese.", CreatedAt: day1.Add(time.Minute), Importance: 0.9},
+		DailyEntry{Title: "Trivia", Content: "Coffee machine on floor three is broken.", CreatedAt: day1.Add(2 * time.Minute), Importance: 0.1},
+		DailyEntry{Title: "Preference", Content: "User prefers concise answers in Chinese!", CreatedAt: day2, ExpiresAt: now.Add(48 * time.Hour)},
+		DailyEntry{Title: "Project", Content: "Billing migration targets April.", CreatedAt: day2.Add(time.Minute)},
+	)
+
+	result, err := eng.Compact(context.Background(), CompactionPolicy{MaxEntries: 2, DuplicateThreshold: 0.9, Now: now})
+	if err != nil {
+		t.Fatalf("Compact: %v", err)
+	}
+	if result.Scanned != 5 || result.Expired != 1 || result.Merged != 1 || result.Capped != 1 || result.Errors != 0 {
+		t.Fatalf("unexpected result: %s", result)
+	}
+	if result.Evicted() != 3 || result.FilesRewritten != 2 {
+		t.Fatalf("unexpected eviction summary: %s", result)
+	}
+
+	first := dailyContent(t, dir, day1)
+	if !strings.HasPrefix(first, "# 2026-03-08\n") {
+		t.Fatalf("expected header preserved, got:\n%s", first)
+	}
+	for _, gone := range []string{"standup room", "concise answers", "Coffee machine"} {
+		if strings.Contains(first, gone) {
+			t.Fatalf("expected %q evicted from day 1, got:\n%s", gone, first)
+		}
+	}
+
+	second := dailyContent(t, dir, day2)
+	if !strings.Contains(second, "concise answers in Chinese!") || !strings.Contains(second, "Billing migration") {
+		t.Fatalf("expected survivors on day 2, got:\n%s", second)
+	}
+	// The merged survivor keeps the higher importance and drops the expiry
+	// because the older copy never expired.
+	if !strings.Contains(second, "<!-- memory: importance=0.90 -->") || strings.Contains(second, "expires_at") {
+		t.Fatalf("expected merged metadata on survivor, got:\n%s", second)
+	}
+
+	// Evicted entries are archived, not deleted.
+	archived, err := os.ReadFile(filepath.Join(dir, archiveDirName, compactedDirName, "2026-03-08.md"))
+	if err != nil {
+		t.Fatalf("read compacted archive: %v", err)
+	}
+	for _, kept := range []string{"# 2026-03-08\n", "standup room", "concise answers in Chinese.", "Coffee machine"} {
+		if !strings.Contains(string(archived), kept) {
+			t.Fatalf("expected %q in archive, got:\n%s", kept, archived)
+		}
+	}
+
+	again, err := eng.Compact(context.Background(), CompactionPolicy{MaxEntries: 2, DuplicateThreshold: 0.9, Now: now})
+	if err != nil {
+		t.Fatalf("second Compact: %v", err)
+	}
+	if again.Evicted() != 0 || again.FilesRewritten != 0 {
+		t.Fatalf("expected compaction to be idempotent, got %s", again)
+	}
+}
+
+func TestCompactDisabledByZeroPolicy(t *testing.T) {
+	dir := t.TempDir()
+	eng := NewMarkdownEngine(dir)
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	day := time.Date(2026, 3, 9, 9, 0, 0, 0, time.UTC)
+	appendTestEntries(t, eng, DailyEntry{Title: "Temp", Content: "expired reminder", CreatedAt: day, ExpiresAt: now.Add(-time.Hour)})
+
+	result, err := eng.Compact(context.Background(), CompactionPolicy{Now: now})
+	if err != nil || result.Scanned != 0 || result.FilesRewritten != 0 {
+		t.Fatalf("expected zero policy to be a no-op, got %s, err %v", result, err)
+	}
+	if !strings.Contains(dailyContent(t, dir, day), "expired reminder") {
+		t.Fatal("expected entry to stay in place")
+	}
+}
+
+func TestCompactKeepsReorderedEntriesApart(t *testing.T) {
+	dir := t.TempDir()
+	eng := NewMarkdownEngine(dir)
+	day := time.Date(2026, 3, 9, 9, 0, 0, 0, time.UTC)
+	appendTestEntries(t, eng,
+		DailyEntry{Title: "Debt", Content: "Alice owes Bob money", CreatedAt: day},
+		DailyEntry{Title: "Debt", Content: "Bob owes Alice money", CreatedAt: day.Add(time.Minute)},
+	)
+
+	result, err := eng.Compact(context.Background(), CompactionPolicy{DuplicateThreshold: 0.9})
+	if err != nil {
+		t.Fatalf("Compact: %v", err)
+	}
+	if result.Merged != 0 {
+		t.Fatalf("expected same words in a different order to stay apart, got %s", result)
+	}
+}
+
+func TestRewriteDailyFileKeepsBlocksAppendedAfterSnapshot(t *testing.T) {
+	dir := t.TempDir()
+	eng := NewMarkdownEngine(dir)
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	day := time.Date(2026, 3, 9, 9, 0, 0, 0, time.UTC)
+	appendTestEntries(t, eng, DailyEntry{Title: "Gone", Content: "expired", CreatedAt: day, ExpiresAt: now.Add(-time.Minute)})
+
+	entries, err := loadCompactionEntries(filepath.Join(ResolveUserRoot(dir, ""), dailyDirName))
+	if err != nil || len(entries) != 1 {
+		t.Fatalf("loadCompactionEntries() = %d entries, err %v", len(entries), err)
+	}
+	entries[0].dropped = true
+
+	appendTestEntries(t, eng, DailyEntry{Title: "Late", Content: "appended during compaction", CreatedAt: day.Add(time.Minute)})
+	if err := rewriteDailyFile(entries[0].path, filepath.Join(dir, archiveDirName, compactedDirName), entries); err != nil {
+		t.Fatalf("rewriteDailyFile: %v", err)
+	}
+	content := dailyContent(t, dir, day)
+	if strings.Contains(content, "expired") || !strings.Contains(content, "appended during compaction") {
+		t.Fatalf("unexpected rewritten content:\n%s", content)
+	}
+}
+
+func TestLockDailyFileFollowsReplacedFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "2026-03-09.md")
+	if err := os.WriteFile(path, []byte("# 2026-03-09\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	held, err := lockDailyFile(path, os.O_RDONLY)
+	if err != nil {
+		t.Fatalf("lockDailyFile: %v", err)
+	}
+
+	got := make(chan *os.File, 1)
+	go func() {
+		f, err := lockDailyFile(path, os.O_WRONLY|os.O_APPEND)
+		if err != nil {
+			t.Errorf("lockDailyFile while replaced: %v", err)
+		}
+		got <- f
+	}()
+	// Replace the log while the waiter is blocked on the old file's lock.
+	time.Sleep(20 * time.Millisecond)
+	if err := filestore.AtomicWrite(path, []byte("# 2026-03-09\n\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	held.Close()
+
+	f := <-got
+	if f == nil {
+		return
+	}
+	defer f.Close()
+	lockedInfo, _ := f.Stat()
+	currentInfo, _ := os.Stat(path)
+	if !os.SameFile(lockedInfo, currentInfo) {
+		t.Fatal("expected the lock to be taken on the replacement file")
+	}
+}
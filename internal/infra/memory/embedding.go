@@ -1,8 +1,140 @@
 package memory
 
-import "context"
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"alex/internal/shared/httpclient"
+	"alex/internal/shared/logging"
+)
 
 // EmbeddingProvider generates embeddings for text.
 type EmbeddingProvider interface {
 	Embed(ctx context.Context, texts []string) ([][]float32, error)
 }
+
+const (
+	defaultEmbedBatchSize = 32
+	defaultEmbedTimeout   = 30 * time.Second
+)
+
+// OpenAIEmbedderConfig configures an OpenAI-compatible embeddings client.
+type OpenAIEmbedderConfig struct {
+	// BaseURL is the API root, e.g. https://api.openai.com/v1 or
+	// http://localhost:11434/v1 for a local Ollama model.
+	BaseURL   string
+	APIKey    string
+	Model     string
+	BatchSize int
+	Timeout   time.Duration
+	// HTTPClient overrides the default client (tests).
+	HTTPClient *http.Client
+}
+
+// OpenAIEmbedder calls POST {BaseURL}/embeddings, splitting large inputs into
+// BatchSize requests. The default client trips a circuit breaker after
+// repeated failures so recall falls back to lexical search without waiting on
+// an unavailable provider.
+type OpenAIEmbedder struct {
+	cfg    OpenAIEmbedderConfig
+	client *http.Client
+}
+
+// NewOpenAIEmbedder validates cfg and returns an embeddings client.
+func NewOpenAIEmbedder(cfg OpenAIEmbedderConfig, logger logging.Logger) (*OpenAIEmbedder, error) {
+	cfg.BaseURL = strings.TrimRight(strings.TrimSpace(cfg.BaseURL), "/")
+	cfg.Model = strings.TrimSpace(cfg.Model)
+	cfg.APIKey = strings.TrimSpace(cfg.APIKey)
+	if cfg.BaseURL == "" {
+		return nil, fmt.Errorf("embedding base URL required")
+	}
+	if cfg.Model == "" {
+		return nil, fmt.Errorf("embedding model required")
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = defaultEmbedBatchSize
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = defaultEmbedTimeout
+	}
+	client := cfg.HTTPClient
+	if client == nil {
+		client = httpclient.NewWithCircuitBreaker(cfg.Timeout, logger, "memory-embedder")
+	}
+	return &OpenAIEmbedder{cfg: cfg, client: client}, nil
+}
+
+type embeddingRequest struct {
+	Model string   `json:"model"`
+	Input []string `json:"input"`
+}
+
+type embeddingResponse struct {
+	Data []struct {
+		Index     int       `json:"index"`
+		Embedding []float32 `json:"embedding"`
+	} `json:"data"`
+}
+
+// Embed returns one vector per input text, in input order.
+func (e *OpenAIEmbedder) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	out := make([][]float32, 0, len(texts))
+	for start := 0; start < len(texts); start += e.cfg.BatchSize {
+		end := min(start+e.cfg.BatchSize, len(texts))
+		vectors, err := e.embedBatch(ctx, texts[start:end])
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, vectors...)
+	}
+	return out, nil
+}
+
+func (e *OpenAIEmbedder) embedBatch(ctx context.Context, texts []string) ([][]float32, error) {
+	body, err := json.Marshal(embeddingRequest{Model: e.cfg.Model, Input: texts})
+	if err != nil {
+		return nil, fmt.Errorf("encode embedding request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.cfg.BaseURL+"/embeddings", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("build embedding request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if e.cfg.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+e.cfg.APIKey)
+	}
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("embedding request: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		snippet, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return nil, fmt.Errorf("embedding request: status %d: %s", resp.StatusCode, strings.TrimSpace(string(snippet)))
+	}
+
+	var decoded embeddingResponse
+	if err := json.NewDecoder(resp.Body).Decode(&decoded); err != nil {
+		return nil, fmt.Errorf("decode embedding response: %w", err)
+	}
+	if len(decoded.Data) != len(texts) {
+		return nil, fmt.Errorf("embedding response has %d vectors for %d inputs", len(decoded.Data), len(texts))
+	}
+	vectors := make([][]float32, len(texts))
+	for _, item := range decoded.Data {
+		if item.Index < 0 || item.Index >= len(texts) || vectors[item.Index] != nil {
+			return nil, fmt.Errorf("embedding response has invalid index %d", item.Index)
+		}
+		if len(item.Embedding) == 0 {
+			return nil, fmt.Errorf("empty embedding returned")
+		}
+		vectors[item.Index] = item.Embedding
+	}
+	return vectors, nil
+}
@@ -0,0 +1,78 @@
+package memory
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestOpenAIEmbedderBatchesAndOrdersVectors(t *testing.T) {
+	var batches [][]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/embeddings" || r.Header.Get("Authorization") != "Bearer sk-test" {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		var req embeddingRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Model != "nomic-embed-text" {
+			http.Error(w, "bad body", http.StatusBadRequest)
+			return
+		}
+		batches = append(batches, req.Input)
+		// Reply out of order; the client must reorder by index.
+		type item struct {
+			Index     int       `json:"index"`
+			Embedding []float32 `json:"embedding"`
+		}
+		data := make([]item, 0, len(req.Input))
+		for idx := len(req.Input) - 1; idx >= 0; idx-- {
+			data = append(data, item{Index: idx, Embedding: []float32{float32(len(req.Input[idx]))}})
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"data": data})
+	}))
+	defer server.Close()
+
+	embedder, err := NewOpenAIEmbedder(OpenAIEmbedderConfig{
+		BaseURL:    server.URL + "/v1/",
+		APIKey:     "sk-test",
+		Model:      "nomic-embed-text",
+		BatchSize:  2,
+		HTTPClient: server.Client(),
+	}, nil)
+	if err != nil {
+		t.Fatalf("NewOpenAIEmbedder: %v", err)
+	}
+	vectors, err := embedder.Embed(context.Background(), []string{"a", "bb", "ccc"})
+	if err != nil {
+		t.Fatalf("Embed: %v", err)
+	}
+	if len(batches) != 2 || len(batches[0]) != 2 || len(batches[1]) != 1 {
+		t.Fatalf("expected batches of 2 and 1, got %v", batches)
+	}
+	for idx, want := range []float32{1, 2, 3} {
+		if vectors[idx][0] != want {
+			t.Fatalf("vector %d = %v, want %v", idx, vectors[idx], want)
+		}
+	}
+}
+
+func TestOpenAIEmbedderReportsProviderErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "model not found", http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	embedder, err := NewOpenAIEmbedder(OpenAIEmbedderConfig{BaseURL: server.URL, Model: "missing", HTTPClient: server.Client()}, nil)
+	if err != nil {
+		t.Fatalf("NewOpenAIEmbedder: %v", err)
+	}
+	if _, err := embedder.Embed(context.Background(), []string{"x"}); err == nil || !strings.Contains(err.Error(), "model not found") {
+		t.Fatalf("expected provider error, got %v", err)
+	}
+	if _, err := NewOpenAIEmbedder(OpenAIEmbedderConfig{Model: "m"}, nil); err == nil {
+		t.Fatal("expected missing base URL to be rejected")
+	}
+}
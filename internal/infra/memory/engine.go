@@ -10,6 +10,12 @@ type DailyEntry struct {
 	Title     string
 	Content   string
 	CreatedAt time.Time
+	// ExpiresAt hides the entry from search once reached; compaction then
+	// removes it. Zero never expires.
+	ExpiresAt time.Time
+	// Importance in (0, 1] decides which entries survive the per-root cap.
+	// Zero means unset and ranks as 0.5.
+	Importance float64
 }
 
 // SearchHit is a scored match returned from memory search.
@@ -23,6 +29,11 @@ type SearchHit struct {
 	NodeID    string
 	// RelatedCount is the number of linked memory entries connected to this hit.
 	RelatedCount int
+	// VectorScore and LexicalScore are the components fused into Score, kept
+	// for the recall trace. VectorScore is the cosine similarity to the query
+	// and stays zero when recall ran lexically only.
+	VectorScore  float64
+	LexicalScore float64
 }
 
 // RelatedHit is a graph-adjacent memory result for a given memory node/span.
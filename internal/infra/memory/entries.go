@@ -0,0 +1,153 @@
+package memory
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// entryMetaPrefix opens the optional metadata comment written directly
+	// under a daily entry heading, e.g.
+	// "<!-- memory: expires_at=2026-01-02T15:04:05Z importance=0.80 -->".
+	entryMetaPrefix = "<!-- memory:"
+	entryMetaSuffix = "-->"
+	// defaultEntryImportance ranks entries that carry no importance.
+	defaultEntryImportance = 0.5
+)
+
+// entryMeta is the eviction metadata attached to a daily entry.
+type entryMeta struct {
+	ExpiresAt  time.Time
+	Importance float64 // 0 means unset
+}
+
+func (m entryMeta) isZero() bool {
+	return m.ExpiresAt.IsZero() && m.Importance == 0
+}
+
+func (m entryMeta) expired(now time.Time) bool {
+	return !m.ExpiresAt.IsZero() && !now.Before(m.ExpiresAt)
+}
+
+func (m entryMeta) importance() float64 {
+	if m.Importance <= 0 {
+		return defaultEntryImportance
+	}
+	return m.Importance
+}
+
+// formatEntryMeta renders m as a Markdown comment, or "" when m is empty.
+func formatEntryMeta(m entryMeta) string {
+	if m.isZero() {
+		return ""
+	}
+	var fields []string
+	if !m.ExpiresAt.IsZero() {
+		fields = append(fields, "expires_at="+m.ExpiresAt.UTC().Format(time.RFC3339))
+	}
+	if m.Importance > 0 {
+		fields = append(fields, "importance="+strconv.FormatFloat(m.Importance, 'f', 2, 64))
+	}
+	return entryMetaPrefix + " " + strings.Join(fields, " ") + " " + entryMetaSuffix
+}
+
+// parseEntryMeta decodes a metadata comment line. Unknown or malformed
+// fields are ignored so a hand-edited line never hides an entry.
+func parseEntryMeta(line string) (entryMeta, bool) {
+	trimmed := strings.TrimSpace(line)
+	if !strings.HasPrefix(trimmed, entryMetaPrefix) || !strings.HasSuffix(trimmed, entryMetaSuffix) {
+		return entryMeta{}, false
+	}
+	body := strings.TrimSuffix(strings.TrimPrefix(trimmed, entryMetaPrefix), entryMetaSuffix)
+	var meta entryMeta
+	for _, field := range strings.Fields(body) {
+		key, value, ok := strings.Cut(field, "=")
+		if !ok {
+			continue
+		}
+		switch key {
+		case "expires_at":
+			if t, err := time.Parse(time.RFC3339, value); err == nil {
+				meta.ExpiresAt = t
+			}
+		case "importance":
+			if f, err := strconv.ParseFloat(value, 64); err == nil && f > 0 && f <= 1 {
+				meta.Importance = f
+			}
+		}
+	}
+	return meta, true
+}
+
+func validateEntryImportance(importance float64) error {
+	if importance < 0 || importance > 1 {
+		return fmt.Errorf("importance must be between 0 and 1, got %v", importance)
+	}
+	return nil
+}
+
+// dailyBlock is one "## <time> - <title>" entry of a daily log.
+type dailyBlock struct {
+	start   int // 0-based index of the heading line
+	end     int // exclusive
+	meta    entryMeta
+	hasMeta bool // line start+1 is a metadata comment
+}
+
+// parseDailyBlocks splits a daily log into its entries. Lines before the
+// first heading (the "# <date>" header) belong to no block.
+func parseDailyBlocks(lines []string) []dailyBlock {
+	var blocks []dailyBlock
+	for idx, line := range lines {
+		if !strings.HasPrefix(line, "## ") {
+			continue
+		}
+		if n := len(blocks); n > 0 {
+			blocks[n-1].end = idx
+		}
+		block := dailyBlock{start: idx, end: len(lines)}
+		if idx+1 < len(lines) {
+			block.meta, block.hasMeta = parseEntryMeta(lines[idx+1])
+		}
+		blocks = append(blocks, block)
+	}
+	return blocks
+}
+
+// bodyStart is the first content line of b, past the heading and metadata.
+func (b dailyBlock) bodyStart() int {
+	if b.hasMeta {
+		return b.start + 2
+	}
+	return b.start + 1
+}
+
+// maskInactiveLines blanks metadata comments and every line of entries that
+// expired at now, keeping line numbers stable for chunking. It returns the
+// input unchanged (and false) when nothing was masked.
+func maskInactiveLines(lines []string, now time.Time) ([]string, bool) {
+	var masked []string
+	mask := func(idx int) {
+		if masked == nil {
+			masked = append([]string(nil), lines...)
+		}
+		masked[idx] = ""
+	}
+	for _, block := range parseDailyBlocks(lines) {
+		if block.meta.expired(now) {
+			for idx := block.start; idx < block.end; idx++ {
+				mask(idx)
+			}
+			continue
+		}
+		if block.hasMeta {
+			mask(block.start + 1)
+		}
+	}
+	if masked == nil {
+		return lines, false
+	}
+	return masked, true
+}
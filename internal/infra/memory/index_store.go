@@ -14,6 +14,7 @@ import (
 	"path/filepath"
 	"sort"
 	"strings"
+	"unicode"
 
 	"alex/internal/shared/utils"
 
@@ -60,6 +61,13 @@ type MemoryEdge struct {
 	Direction string
 }
 
+// ChunkKey identifies a chunk by its source span.
+type ChunkKey struct {
+	Path      string
+	StartLine int
+	EndLine   int
+}
+
 // RelatedMatch captures a linked memory entry returned by graph traversal.
 type RelatedMatch struct {
 	Path      string
@@ -157,8 +165,10 @@ CREATE TABLE IF NOT EXISTS memory_edges (
 		return err
 	}
 	if dim > 0 {
+		// Cosine distance (1 - cosine similarity) keeps vector scores
+		// comparable across embedding models regardless of vector norm.
 		stmt := fmt.Sprintf(
-			`CREATE VIRTUAL TABLE IF NOT EXISTS chunks_vec USING vec0(embedding float[%d]);`,
+			`CREATE VIRTUAL TABLE IF NOT EXISTS chunks_vec USING vec0(embedding float[%d] distance_metric=cosine);`,
 			dim,
 		)
 		if _, err := s.db.ExecContext(ctx, stmt); err != nil {
@@ -168,6 +178,21 @@ CREATE TABLE IF NOT EXISTS memory_edges (
 	return nil
 }
 
+// Reset drops every index table so the next EnsureSchema starts empty. It is
+// used when re-indexing with a different embedding model or dimension.
+func (s *IndexStore) Reset(ctx context.Context) error {
+	if s == nil || s.db == nil {
+		return fmt.Errorf("index store not initialized")
+	}
+	for _, table := range []string{"chunks_vec", "chunks_fts", "memory_edges", "embedding_cache", "chunks"} {
+		if _, err := s.db.ExecContext(ctx, `DROP TABLE IF EXISTS `+table+`;`); err != nil && !isMissingModule(err) {
+			return err
+		}
+	}
+	s.ftsEnabled = false
+	return nil
+}
+
 // Close releases database resources.
 func (s *IndexStore) Close() error {
 	if s == nil || s.db == nil {
@@ -212,6 +237,49 @@ func (s *IndexStore) LookupEmbeddings(ctx context.Context, hashes []string) (map
 	return out, nil
 }
 
+// ChunkEmbeddings returns stored embeddings for the given chunk spans. Spans
+// that are not indexed are omitted.
+func (s *IndexStore) ChunkEmbeddings(ctx context.Context, keys []ChunkKey) (map[ChunkKey][]float32, error) {
+	if s == nil || s.db == nil {
+		return nil, fmt.Errorf("index store not initialized")
+	}
+	out := make(map[ChunkKey][]float32, len(keys))
+	if len(keys) == 0 {
+		return out, nil
+	}
+	clauses := make([]string, 0, len(keys))
+	args := make([]any, 0, len(keys)*3)
+	for _, key := range keys {
+		clauses = append(clauses, `(c.path = ? AND c.start_line = ? AND c.end_line = ?)`)
+		args = append(args, key.Path, key.StartLine, key.EndLine)
+	}
+	query := `
+SELECT c.path, c.start_line, c.end_line, e.embedding
+FROM chunks c
+JOIN embedding_cache e ON e.hash = c.hash
+WHERE ` + strings.Join(clauses, " OR ") + `;`
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		if isMissingTable(err) {
+			return out, nil
+		}
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var key ChunkKey
+		var blob []byte
+		if err := rows.Scan(&key.Path, &key.StartLine, &key.EndLine, &blob); err != nil {
+			return nil, err
+		}
+		out[key] = bytesToFloat32s(blob)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ReplaceChunks replaces all chunks for a path with the provided chunks.
 func (s *IndexStore) ReplaceChunks(ctx context.Context, path string, chunks []IndexedChunk) error {
 	if s == nil || s.db == nil {
@@ -349,7 +417,7 @@ func (s *IndexStore) SearchBM25(ctx context.Context, queryText string, limit int
 	if !s.ftsEnabled {
 		return nil, nil
 	}
-	queryText = strings.TrimSpace(queryText)
+	queryText = ftsQuery(queryText)
 	if queryText == "" {
 		return nil, nil
 	}
@@ -526,6 +594,25 @@ func (s *IndexStore) fetchRelatedRows(ctx context.Context, query string, args ..
 	return matches, nil
 }
 
+// ftsQuery quotes each word of a free-form query and ORs them together so
+// punctuation such as "ACME-42" cannot be parsed as FTS5 syntax.
+func ftsQuery(text string) string {
+	fields := strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	seen := make(map[string]struct{}, len(fields))
+	terms := make([]string, 0, len(fields))
+	for _, field := range fields {
+		lower := strings.ToLower(field)
+		if _, ok := seen[lower]; ok {
+			continue
+		}
+		seen[lower] = struct{}{}
+		terms = append(terms, `"`+field+`"`)
+	}
+	return strings.Join(terms, " OR ")
+}
+
 func deleteByPathTx(ctx context.Context, tx *sql.Tx, path string) error {
 	if err := deleteBySourcePathTx(ctx, tx, path); err != nil {
 		return err
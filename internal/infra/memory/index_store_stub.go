@@ -50,6 +50,13 @@ type MemoryEdge struct {
 	Direction string
 }
 
+// ChunkKey identifies a chunk by its source span.
+type ChunkKey struct {
+	Path      string
+	StartLine int
+	EndLine   int
+}
+
 // RelatedMatch captures a linked memory entry returned by graph traversal.
 type RelatedMatch struct {
 	Path      string
@@ -74,6 +81,11 @@ func (s *IndexStore) EnsureSchema(_ context.Context, _ int) error {
 	return fmt.Errorf(errSQLiteVecCGODisabled)
 }
 
+// Reset returns an error when CGO is disabled.
+func (s *IndexStore) Reset(_ context.Context) error {
+	return fmt.Errorf(errSQLiteVecCGODisabled)
+}
+
 // Close is a no-op stub.
 func (s *IndexStore) Close() error {
 	return nil
@@ -84,6 +96,11 @@ func (s *IndexStore) LookupEmbeddings(_ context.Context, _ []string) (map[string
 	return nil, fmt.Errorf(errSQLiteVecCGODisabled)
 }
 
+// ChunkEmbeddings returns an error when CGO is disabled.
+func (s *IndexStore) ChunkEmbeddings(_ context.Context, _ []ChunkKey) (map[ChunkKey][]float32, error) {
+	return nil, fmt.Errorf(errSQLiteVecCGODisabled)
+}
+
 // ReplaceChunks returns an error when CGO is disabled.
 func (s *IndexStore) ReplaceChunks(_ context.Context, _ string, _ []IndexedChunk) error {
 	return fmt.Errorf(errSQLiteVecCGODisabled)
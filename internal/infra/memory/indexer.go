@@ -6,6 +6,7 @@ import (
 	"path/filepath"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"alex/internal/shared/logging"
@@ -35,6 +36,15 @@ type Indexer struct {
 	ensureSchemaFn func(ctx context.Context, store *IndexStore, dim int) error
 	countRelatedFn func(ctx context.Context, store *IndexStore, path string, fromLine, toLine int) (int, error)
 
+	chunkEmbeddingsFn func(ctx context.Context, store *IndexStore, keys []ChunkKey) (map[ChunkKey][]float32, error)
+	// lexicalFn supplies lexical candidates in place of FTS5, which is not
+	// compiled into the default SQLite build.
+	lexicalFn func(ctx context.Context, query string, maxResults int) ([]SearchHit, error)
+	// degraded is set while the embedding provider is failing.
+	degraded atomic.Bool
+	// passMu serializes full index passes (startup scan and Reindex).
+	passMu sync.Mutex
+
 	mu       sync.Mutex
 	watcher  *fsnotify.Watcher
 	timers   map[string]*time.Timer
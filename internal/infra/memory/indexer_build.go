@@ -10,54 +10,120 @@ import (
 	"strings"
 )
 
+// ReindexOptions controls a full re-index pass.
+type ReindexOptions struct {
+	// Force drops the existing index and embedding cache first, re-embedding
+	// every chunk. Use it after changing the embedding model.
+	Force bool
+}
+
+// ReindexStats summarizes a re-index pass.
+type ReindexStats struct {
+	Files    int `json:"files"`
+	Chunks   int `json:"chunks"`
+	Embedded int `json:"embedded"`
+	Failed   int `json:"failed"`
+}
+
+// Reindex walks every memory file and indexes it. Chunks whose text is
+// unchanged reuse cached embeddings; the rest are sent to the embedding
+// provider in batches. Per-file failures are logged and counted so one bad
+// file does not abort the pass.
+func (i *Indexer) Reindex(ctx context.Context, opts ReindexOptions) (ReindexStats, error) {
+	if i == nil {
+		return ReindexStats{}, fmt.Errorf("indexer not initialized")
+	}
+	i.passMu.Lock()
+	defer i.passMu.Unlock()
+
+	if opts.Force {
+		store, err := i.storeForUser()
+		if err != nil {
+			return ReindexStats{}, err
+		}
+		if err := store.Reset(ctx); err != nil {
+			return ReindexStats{}, fmt.Errorf("reset memory index: %w", err)
+		}
+	}
+	return i.indexFiles(ctx)
+}
+
 func (i *Indexer) indexAll(ctx context.Context) error {
+	i.passMu.Lock()
+	defer i.passMu.Unlock()
+	_, err := i.indexFiles(ctx)
+	return err
+}
+
+func (i *Indexer) indexFiles(ctx context.Context) (ReindexStats, error) {
 	paths, err := collectAllMemoryFiles(i.rootDir)
 	if err != nil {
-		return err
+		return ReindexStats{}, err
 	}
+	var stats ReindexStats
 	for _, path := range paths {
-		if err := i.indexPath(ctx, path); err != nil {
+		if err := ctx.Err(); err != nil {
+			return stats, err
+		}
+		chunks, embedded, err := i.indexFile(ctx, path)
+		stats.Files++
+		stats.Chunks += chunks
+		stats.Embedded += embedded
+		if err != nil {
+			stats.Failed++
 			i.logger.Warn("Memory index failed for %s: %v", path, err)
 		}
 	}
-	return nil
+	return stats, nil
 }
 
 func (i *Indexer) indexPath(ctx context.Context, path string) error {
+	_, _, err := i.indexFile(ctx, path)
+	return err
+}
+
+// indexFile indexes one file and reports how many chunks it holds and how
+// many of them needed fresh embeddings.
+func (i *Indexer) indexFile(ctx context.Context, path string) (chunkCount, embedded int, err error) {
 	if !isMemoryFile(path) {
-		return nil
+		return 0, 0, nil
 	}
 	relPath, ok := resolveUserPath(i.rootDir, path)
 	if !ok {
-		return nil
+		return 0, 0, nil
 	}
 	store, err := i.storeForUser()
 	if err != nil {
-		return err
+		return 0, 0, err
 	}
 	if _, err := os.Stat(path); err != nil {
 		if os.IsNotExist(err) {
-			return store.DeleteByPath(ctx, relPath)
+			return 0, 0, store.DeleteByPath(ctx, relPath)
 		}
-		return err
+		return 0, 0, err
 	}
 
 	lines, err := readLines(path)
 	if err != nil {
-		return err
+		return 0, 0, err
 	}
 	chunks := buildChunks(lines, i.cfg.ChunkTokens, i.cfg.ChunkOverlap)
 	if len(chunks) == 0 {
-		return store.DeleteByPath(ctx, relPath)
+		return 0, 0, store.DeleteByPath(ctx, relPath)
 	}
 
 	hashes := make([]string, 0, len(chunks))
 	for _, chunk := range chunks {
 		hashes = append(hashes, hashText(chunk.Text))
 	}
+	// Create the base tables before the cache lookup; the vector table
+	// follows once the embedding dimension is known.
+	if err := store.EnsureSchema(ctx, 0); err != nil {
+		return 0, 0, err
+	}
 	cache, err := store.LookupEmbeddings(ctx, hashes)
 	if err != nil {
-		return err
+		return 0, 0, err
 	}
 
 	var missingTexts []string
@@ -74,10 +140,10 @@ func (i *Indexer) indexPath(ctx context.Context, path string) error {
 	if len(missingTexts) > 0 {
 		embeddings, err := i.embedder.Embed(ctx, missingTexts)
 		if err != nil {
-			return err
+			return len(chunks), 0, err
 		}
 		if len(embeddings) != len(missingTexts) {
-			return fmt.Errorf("embedding batch mismatch")
+			return len(chunks), 0, fmt.Errorf("embedding batch mismatch")
 		}
 		for idx, emb := range embeddings {
 			cache[missingHashes[idx]] = emb
@@ -86,7 +152,7 @@ func (i *Indexer) indexPath(ctx context.Context, path string) error {
 
 	dim := len(cache[hashes[0]])
 	if err := store.EnsureSchema(ctx, dim); err != nil {
-		return err
+		return len(chunks), len(missingTexts), err
 	}
 
 	indexed := make([]IndexedChunk, 0, len(chunks))
@@ -94,7 +160,7 @@ func (i *Indexer) indexPath(ctx context.Context, path string) error {
 		hash := hashes[idx]
 		embedding := cache[hash]
 		if len(embedding) == 0 {
-			return fmt.Errorf("empty embedding for chunk %s", hash)
+			return len(chunks), len(missingTexts), fmt.Errorf("empty embedding for chunk %s", hash)
 		}
 		indexed = append(indexed, IndexedChunk{
 			Path:      relPath,
@@ -106,7 +172,7 @@ func (i *Indexer) indexPath(ctx context.Context, path string) error {
 			Edges:     extractMemoryEdges(chunk.Text),
 		})
 	}
-	return store.ReplaceChunks(ctx, relPath, indexed)
+	return len(chunks), len(missingTexts), store.ReplaceChunks(ctx, relPath, indexed)
 }
 
 type chunkWindow struct {
@@ -0,0 +1,257 @@
+//go:build cgo
+// +build cgo
+
+package memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// conceptEmbedder stands in for a semantic embedding model: each dimension is
+// a concept, and synonyms or translations of the concept land on the same
+// dimension, so paraphrases embed close together without sharing words.
+type conceptEmbedder struct {
+	err error
+}
+
+var recallConcepts = [][]string{
+	{"deploy", "rollout", "release", "shipped", "ship", "prod", "部署", "上线"},
+	{"failed", "fail", "wrong", "incident", "broke", "坑", "问题"},
+	{"database", "migration", "table", "schema"},
+	{"prefers", "prefer", "like", "喜欢"},
+	{"answers", "replies", "reply", "formatted", "concise", "回复"},
+	{"chinese", "english", "中文"},
+	{"flights", "trip", "travel", "offsite", "出差"},
+	{"tokyo", "japan", "日本"},
+	{"invoice", "bill", "pay", "due", "账单"},
+	{"npm", "pnpm", "package manager", "installs", "依赖"},
+}
+
+func (c conceptEmbedder) Embed(_ context.Context, texts []string) ([][]float32, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	out := make([][]float32, len(texts))
+	for idx, text := range texts {
+		lower := strings.ToLower(text)
+		vec := make([]float32, len(recallConcepts)+1)
+		vec[len(recallConcepts)] = 0.05
+		for dim, words := range recallConcepts {
+			for _, word := range words {
+				vec[dim] += float32(strings.Count(lower, word))
+			}
+		}
+		out[idx] = vec
+	}
+	return out, nil
+}
+
+var paraphraseMemories = map[string]string{
+	"2026-01-01": "## Release incident\nThe production rollout failed because the database migration locked the orders table.",
+	"2026-01-02": "## Preferences\nUser prefers concise answers written in Chinese.",
+	"2026-01-03": "## Travel\nBooked flights to Tokyo for the March offsite.",
+	"2026-01-04": "## Billing\nInvoice for the cloud account is due on the 5th.",
+	"2026-01-05": "## Tooling\nSwitched the team from npm to pnpm for faster installs.",
+}
+
+var paraphraseQueries = []struct {
+	query string
+	want  string
+}{
+	{query: "上次那个部署的坑", want: "2026-01-01"},
+	{query: "what went wrong when we shipped to prod last time", want: "2026-01-01"},
+	{query: "how does the user like replies formatted", want: "2026-01-02"},
+	{query: "trip to Japan plans", want: "2026-01-03"},
+	{query: "when do we need to pay the cloud bill", want: "2026-01-04"},
+	{query: "which package manager do we use", want: "2026-01-05"},
+}
+
+func writeDailyMemories(t testing.TB, root string, entries map[string]string) {
+	t.Helper()
+	dir := filepath.Join(root, dailyDirName)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	for day, content := range entries {
+		if err := os.WriteFile(filepath.Join(dir, day+".md"), []byte(content+"\n"), 0o644); err != nil {
+			t.Fatalf("write %s: %v", day, err)
+		}
+	}
+}
+
+func newHybridEngine(t testing.TB, root string, embedder EmbeddingProvider, chunkTokens, chunkOverlap int) (*MarkdownEngine, *Indexer) {
+	t.Helper()
+	engine := NewMarkdownEngine(root)
+	engine.SetChunkConfig(chunkTokens, chunkOverlap)
+	indexer, err := NewIndexer(root, IndexerConfig{
+		DBPath:       filepath.Join(t.TempDir(), indexFileName),
+		ChunkTokens:  chunkTokens,
+		ChunkOverlap: chunkOverlap,
+	}, embedder, nil)
+	if err != nil {
+		t.Fatalf("NewIndexer: %v", err)
+	}
+	engine.SetIndexer(indexer)
+	t.Cleanup(func() { _ = indexer.Drain(context.Background()) })
+	return engine, indexer
+}
+
+func paraphraseHitRate(t *testing.T, engine *MarkdownEngine) int {
+	t.Helper()
+	hits := 0
+	for _, tc := range paraphraseQueries {
+		results, err := engine.Search(context.Background(), "", tc.query, 1, 0.2)
+		if err != nil {
+			t.Fatalf("search %q: %v", tc.query, err)
+		}
+		if len(results) > 0 && strings.Contains(results[0].Path, tc.want) {
+			hits++
+		}
+	}
+	return hits
+}
+
+func TestHybridRecallBeatsLexicalOnParaphrases(t *testing.T) {
+	root := t.TempDir()
+	writeDailyMemories(t, root, paraphraseMemories)
+
+	lexical := NewMarkdownEngine(root)
+	hybrid, indexer := newHybridEngine(t, root, conceptEmbedder{}, chunkTokenSize, chunkTokenOverlap)
+	stats, err := indexer.Reindex(context.Background(), ReindexOptions{})
+	if err != nil {
+		t.Fatalf("Reindex: %v", err)
+	}
+	if stats.Files != len(paraphraseMemories) || stats.Embedded != len(paraphraseMemories) || stats.Failed != 0 {
+		t.Fatalf("unexpected reindex stats: %+v", stats)
+	}
+
+	lexicalHits := paraphraseHitRate(t, lexical)
+	hybridHits := paraphraseHitRate(t, hybrid)
+	t.Logf("paraphrase hit@1: lexical=%d/%d hybrid=%d/%d", lexicalHits, len(paraphraseQueries), hybridHits, len(paraphraseQueries))
+	if hybridHits != len(paraphraseQueries) {
+		t.Fatalf("expected hybrid recall to find every paraphrase, got %d/%d", hybridHits, len(paraphraseQueries))
+	}
+	if lexicalHits >= hybridHits {
+		t.Fatalf("expected hybrid (%d) to beat lexical (%d)", hybridHits, lexicalHits)
+	}
+
+	// The recall trace carries both score components.
+	results, err := hybrid.Search(context.Background(), "", "database migration rollout failed", 1, 0.2)
+	if err != nil || len(results) != 1 {
+		t.Fatalf("search: %v (%d hits)", err, len(results))
+	}
+	if results[0].VectorScore <= 0 || results[0].LexicalScore <= 0 {
+		t.Fatalf("expected both score components, got %+v", results[0])
+	}
+}
+
+func TestHybridRecallDegradesToLexicalWhenEmbedderFails(t *testing.T) {
+	root := t.TempDir()
+	writeDailyMemories(t, root, paraphraseMemories)
+	engine, indexer := newHybridEngine(t, root, conceptEmbedder{err: errors.New("connection refused")}, chunkTokenSize, chunkTokenOverlap)
+
+	stats, err := indexer.Reindex(context.Background(), ReindexOptions{})
+	if err != nil {
+		t.Fatalf("Reindex: %v", err)
+	}
+	if stats.Failed != len(paraphraseMemories) {
+		t.Fatalf("expected every file to fail embedding, got %+v", stats)
+	}
+
+	results, err := engine.Search(context.Background(), "", "pnpm installs", 3, 0.2)
+	if err != nil {
+		t.Fatalf("search: %v", err)
+	}
+	if len(results) == 0 || !strings.Contains(results[0].Path, "2026-01-05") {
+		t.Fatalf("expected lexical fallback hit, got %+v", results)
+	}
+	if results[0].VectorScore != 0 || results[0].LexicalScore != results[0].Score {
+		t.Fatalf("expected lexical-only score components, got %+v", results[0])
+	}
+	if !indexer.degraded.Load() {
+		t.Fatal("expected indexer to record the degraded state")
+	}
+}
+
+func TestReindexForceReembedsEveryChunk(t *testing.T) {
+	root := t.TempDir()
+	writeDailyMemories(t, root, paraphraseMemories)
+	_, indexer := newHybridEngine(t, root, conceptEmbedder{}, chunkTokenSize, chunkTokenOverlap)
+	ctx := context.Background()
+
+	if _, err := indexer.Reindex(ctx, ReindexOptions{}); err != nil {
+		t.Fatalf("first reindex: %v", err)
+	}
+	cached, err := indexer.Reindex(ctx, ReindexOptions{})
+	if err != nil || cached.Embedded != 0 || cached.Chunks != len(paraphraseMemories) {
+		t.Fatalf("expected cached embeddings to be reused, got %+v err=%v", cached, err)
+	}
+	forced, err := indexer.Reindex(ctx, ReindexOptions{Force: true})
+	if err != nil || forced.Embedded != len(paraphraseMemories) {
+		t.Fatalf("expected forced reindex to re-embed, got %+v err=%v", forced, err)
+	}
+}
+
+// setupRecallBenchmark writes 10k one-line memories across 100 daily files.
+func setupRecallBenchmark(b *testing.B, hybrid bool) *MarkdownEngine {
+	b.Helper()
+	root := b.TempDir()
+	topics := []string{
+		"deploy rollout of the billing service",
+		"database migration on the orders table",
+		"user prefers concise replies",
+		"flights booked for the offsite",
+		"switched the repo to pnpm",
+	}
+	entries := make(map[string]string, 100)
+	for day := 0; day < 100; day++ {
+		lines := make([]string, 0, 100)
+		for n := 0; n < 100; n++ {
+			lines = append(lines, fmt.Sprintf("- note %d-%d about %s with detail %d", day, n, topics[(day+n)%len(topics)], n))
+		}
+		entries[fmt.Sprintf("2025-%02d-%02d", day/28+1, day%28+1)] = strings.Join(lines, "\n")
+	}
+	writeDailyMemories(b, root, entries)
+
+	// Ten tokens per chunk keeps each note in its own chunk.
+	if !hybrid {
+		engine := NewMarkdownEngine(root)
+		engine.SetChunkConfig(10, 0)
+		return engine
+	}
+	engine, indexer := newHybridEngine(b, root, conceptEmbedder{}, 10, 0)
+	stats, err := indexer.Reindex(context.Background(), ReindexOptions{})
+	if err != nil || stats.Failed != 0 {
+		b.Fatalf("reindex: %+v err=%v", stats, err)
+	}
+	if stats.Chunks < 10000 {
+		b.Fatalf("expected 10k chunks, got %d", stats.Chunks)
+	}
+	return engine
+}
+
+func BenchmarkRecallLexical10k(b *testing.B) {
+	engine := setupRecallBenchmark(b, false)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := engine.Search(context.Background(), "", "上次那个部署的坑", 6, 0.2); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkRecallHybrid10k(b *testing.B) {
+	engine := setupRecallBenchmark(b, true)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := engine.Search(context.Background(), "", "上次那个部署的坑", 6, 0.2); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -3,13 +3,19 @@ package memory
 import (
 	"context"
 	"fmt"
+	"math"
 	"path/filepath"
 	"sort"
 	"strings"
 	"sync"
 )
 
-// Search performs hybrid (vector + BM25) retrieval.
+// Search performs hybrid retrieval: cosine similarity from the vector index
+// fused with a lexical score using the configured weights. Lexical candidates
+// come from the attached lexical search (the Markdown scan) or FTS5 BM25.
+// Every candidate is scored on both components so hits found by only one leg
+// still rank fairly. An embedding failure is returned so callers can fall back
+// to pure lexical recall.
 func (i *Indexer) Search(ctx context.Context, _ string, query string, maxResults int, minScore float64) ([]SearchHit, error) {
 	if i == nil {
 		return nil, fmt.Errorf("indexer not initialized")
@@ -29,27 +35,22 @@ func (i *Indexer) Search(ctx context.Context, _ string, query string, maxResults
 	if err != nil {
 		return nil, err
 	}
-	embeddings, err := i.embedder.Embed(ctx, []string{query})
+	queryVec, err := i.embedQuery(ctx, query)
 	if err != nil {
 		return nil, err
 	}
-	if len(embeddings) != 1 {
-		return nil, fmt.Errorf("unexpected embedding response size")
-	}
-	if len(embeddings[0]) == 0 {
-		return nil, fmt.Errorf("empty embedding returned")
-	}
-	if err := i.ensureSchema(ctx, store, len(embeddings[0])); err != nil {
+	if err := i.ensureSchema(ctx, store, len(queryVec)); err != nil {
 		return nil, err
 	}
 
+	candidates := recallCandidateLimit(maxResults)
 	var (
-		vecMatches  []VectorMatch
-		textMatches []TextMatch
+		vecMatches []VectorMatch
+		lexMatches []lexicalMatch
 	)
 	group, searchCtx := newIndexerErrGroup(ctx)
 	group.Go(func() error {
-		matches, err := i.searchVector(searchCtx, store, embeddings[0], maxResults)
+		matches, err := i.searchVector(searchCtx, store, queryVec, candidates)
 		if err != nil {
 			return err
 		}
@@ -57,19 +58,21 @@ func (i *Indexer) Search(ctx context.Context, _ string, query string, maxResults
 		return nil
 	})
 	group.Go(func() error {
-		matches, err := i.searchBM25(searchCtx, store, query, maxResults)
+		matches, err := i.searchLexical(searchCtx, store, query, candidates)
 		if err != nil {
 			return err
 		}
-		textMatches = matches
+		lexMatches = matches
 		return nil
 	})
 	if err := group.Wait(); err != nil {
 		return nil, err
 	}
-	results := mergeMatches(vecMatches, textMatches, maxResults, minScore, i.cfg.FusionWeightVector, i.cfg.FusionWeightBM25)
+
+	fused := fuseMatches(query, vecMatches, lexMatches)
+	i.scoreMissingVectors(ctx, store, queryVec, fused)
+	results := rankHits(fused, maxResults, minScore, i.cfg.FusionWeightVector, i.cfg.FusionWeightBM25)
 	for idx := range results {
-		results[idx].NodeID = buildNodeID(results[idx].Path, results[idx].StartLine, results[idx].EndLine)
 		relatedCount, err := i.countRelated(ctx, store, results[idx].Path, results[idx].StartLine, results[idx].EndLine)
 		if err != nil {
 			continue
@@ -79,6 +82,56 @@ func (i *Indexer) Search(ctx context.Context, _ string, query string, maxResults
 	return results, nil
 }
 
+// embedQuery embeds query and tracks provider availability so an outage is
+// logged once rather than on every recall.
+func (i *Indexer) embedQuery(ctx context.Context, query string) ([]float32, error) {
+	embeddings, err := i.embedder.Embed(ctx, []string{query})
+	if err == nil && len(embeddings) != 1 {
+		err = fmt.Errorf("unexpected embedding response size")
+	}
+	if err == nil && len(embeddings[0]) == 0 {
+		err = fmt.Errorf("empty embedding returned")
+	}
+	if err != nil {
+		if i.degraded.CompareAndSwap(false, true) {
+			i.logger.Warn("Memory embedding provider unavailable; recall degraded to lexical: %v", err)
+		}
+		return nil, err
+	}
+	if i.degraded.CompareAndSwap(true, false) {
+		i.logger.Info("Memory embedding provider recovered; hybrid recall restored")
+	}
+	return embeddings[0], nil
+}
+
+// scoreMissingVectors fills the cosine similarity of lexical-only candidates
+// from their stored chunk embeddings.
+func (i *Indexer) scoreMissingVectors(ctx context.Context, store *IndexStore, queryVec []float32, candidates []*recallCandidate) {
+	var keys []ChunkKey
+	for _, candidate := range candidates {
+		if !candidate.hasVector {
+			keys = append(keys, candidate.key)
+		}
+	}
+	if len(keys) == 0 {
+		return
+	}
+	embeddings, err := i.chunkEmbeddings(ctx, store, keys)
+	if err != nil {
+		i.logger.Warn("Memory recall: load chunk embeddings failed: %v", err)
+		return
+	}
+	for _, candidate := range candidates {
+		if candidate.hasVector {
+			continue
+		}
+		if embedding, ok := embeddings[candidate.key]; ok {
+			candidate.vector = clampUnit(cosineSimilarity(queryVec, embedding))
+			candidate.hasVector = true
+		}
+	}
+}
+
 // Related returns graph-adjacent memory entries for a source path or range.
 func (i *Indexer) Related(ctx context.Context, _ string, path string, fromLine, toLine, maxResults int) ([]RelatedHit, error) {
 	if i == nil {
@@ -138,6 +191,45 @@ func (i *Indexer) searchBM25(
 	return store.SearchBM25(ctx, query, maxResults)
 }
 
+// lexicalMatch is a lexical candidate with a score normalized to [0, 1].
+type lexicalMatch struct {
+	Key   ChunkKey
+	Text  string
+	Score float64
+}
+
+// searchLexical returns lexical candidates from the attached lexical search,
+// or from FTS5 BM25 when none is attached.
+func (i *Indexer) searchLexical(ctx context.Context, store *IndexStore, query string, maxResults int) ([]lexicalMatch, error) {
+	if i.lexicalFn != nil {
+		hits, err := i.lexicalFn(ctx, query, maxResults)
+		if err != nil {
+			return nil, err
+		}
+		matches := make([]lexicalMatch, 0, len(hits))
+		for _, hit := range hits {
+			matches = append(matches, lexicalMatch{
+				Key:   ChunkKey{Path: filepath.ToSlash(hit.Path), StartLine: hit.StartLine, EndLine: hit.EndLine},
+				Text:  hit.Snippet,
+				Score: clampUnit(hit.Score),
+			})
+		}
+		return matches, nil
+	}
+	textMatches, err := i.searchBM25(ctx, store, query, maxResults)
+	if err != nil {
+		return nil, err
+	}
+	return normalizeBM25(textMatches), nil
+}
+
+func (i *Indexer) chunkEmbeddings(ctx context.Context, store *IndexStore, keys []ChunkKey) (map[ChunkKey][]float32, error) {
+	if i.chunkEmbeddingsFn != nil {
+		return i.chunkEmbeddingsFn(ctx, store, keys)
+	}
+	return store.ChunkEmbeddings(ctx, keys)
+}
+
 func (i *Indexer) ensureSchema(ctx context.Context, store *IndexStore, dim int) error {
 	if i.ensureSchemaFn != nil {
 		return i.ensureSchemaFn(ctx, store, dim)
@@ -198,77 +290,143 @@ func (g *indexerErrGroup) Wait() error {
 	return g.err
 }
 
-func mergeMatches(vec []VectorMatch, text []TextMatch, limit int, minScore float64, weightVector, weightBM25 float64) []SearchHit {
-	if limit <= 0 {
-		limit = defaultSearchMax
+// recallCandidate accumulates both score components for one chunk span.
+type recallCandidate struct {
+	key       ChunkKey
+	text      string
+	vector    float64
+	lexical   float64
+	hasVector bool
+}
+
+// recallCandidateLimit widens each leg beyond maxResults so fusion can
+// promote hits that rank lower on a single component.
+func recallCandidateLimit(maxResults int) int {
+	return max(maxResults*4, 20)
+}
+
+// fuseMatches merges vector and lexical candidates by chunk span. Vector-only
+// candidates get a lexical score computed from their text.
+func fuseMatches(query string, vec []VectorMatch, lex []lexicalMatch) []*recallCandidate {
+	byKey := make(map[ChunkKey]*recallCandidate, len(vec)+len(lex))
+	order := make([]*recallCandidate, 0, len(vec)+len(lex))
+	get := func(key ChunkKey, text string) *recallCandidate {
+		if candidate, ok := byKey[key]; ok {
+			return candidate
+		}
+		candidate := &recallCandidate{key: key, text: text, lexical: -1}
+		byKey[key] = candidate
+		order = append(order, candidate)
+		return candidate
 	}
-	if minScore <= 0 {
-		minScore = defaultSearchMinScore
+	for _, match := range vec {
+		key := ChunkKey{Path: filepath.ToSlash(match.Chunk.Path), StartLine: match.Chunk.StartLine, EndLine: match.Chunk.EndLine}
+		candidate := get(key, match.Chunk.Text)
+		candidate.vector = math.Max(candidate.vector, clampUnit(1-match.Distance))
+		candidate.hasVector = true
 	}
-	if weightVector == 0 && weightBM25 == 0 {
-		weightVector = 0.7
-		weightBM25 = 0.3
+	for _, match := range lex {
+		candidate := get(match.Key, match.Text)
+		candidate.lexical = math.Max(candidate.lexical, match.Score)
 	}
 
-	type scoreEntry struct {
-		chunk      StoredChunk
-		vector     float64
-		text       float64
-		finalScore float64
+	queryTerms := normalizeTerms(tokenize(query))
+	queryLower := strings.ToLower(query)
+	for _, candidate := range order {
+		if candidate.lexical >= 0 {
+			continue
+		}
+		candidate.lexical = scoreChunk(queryTerms, queryLower, tokenize(candidate.text), candidate.text)
 	}
-	entries := map[int64]*scoreEntry{}
+	return order
+}
 
-	for _, match := range vec {
-		entry := entries[match.Chunk.ID]
-		if entry == nil {
-			entry = &scoreEntry{chunk: match.Chunk}
-			entries[match.Chunk.ID] = entry
-		}
-		entry.vector = 1.0 / (1.0 + match.Distance)
+// rankHits combines the components with the fusion weights, drops hits below
+// minScore, and returns the best limit hits.
+func rankHits(candidates []*recallCandidate, limit int, minScore float64, weightVector, weightLexical float64) []SearchHit {
+	if limit <= 0 {
+		limit = defaultSearchMax
 	}
-	for _, match := range text {
-		entry := entries[match.Chunk.ID]
-		if entry == nil {
-			entry = &scoreEntry{chunk: match.Chunk}
-			entries[match.Chunk.ID] = entry
-		}
-		score := match.BM25
-		if score < 0 {
-			score = 0
-		}
-		entry.text = 1.0 / (1.0 + score)
+	if minScore <= 0 {
+		minScore = defaultSearchMinScore
+	}
+	if weightVector == 0 && weightLexical == 0 {
+		weightVector = 0.7
+		weightLexical = 0.3
 	}
 
-	results := make([]SearchHit, 0, len(entries))
-	for _, entry := range entries {
-		entry.finalScore = (weightVector * entry.vector) + (weightBM25 * entry.text)
-		if entry.finalScore < minScore {
+	results := make([]SearchHit, 0, len(candidates))
+	for _, candidate := range candidates {
+		score := (weightVector * candidate.vector) + (weightLexical * candidate.lexical)
+		if score < minScore {
 			continue
 		}
 		source := "memory"
-		if filepath.Base(entry.chunk.Path) == memoryFileName {
+		if filepath.Base(candidate.key.Path) == memoryFileName {
 			source = "long_term"
 		}
 		results = append(results, SearchHit{
-			Path:      entry.chunk.Path,
-			StartLine: entry.chunk.StartLine,
-			EndLine:   entry.chunk.EndLine,
-			Score:     entry.finalScore,
-			Snippet:   buildSnippet(entry.chunk.Text),
-			Source:    source,
-			NodeID:    buildNodeID(entry.chunk.Path, entry.chunk.StartLine, entry.chunk.EndLine),
-		})
-	}
-	if len(results) > 1 {
-		sort.Slice(results, func(i, j int) bool {
-			if results[i].Score == results[j].Score {
-				return results[i].Path < results[j].Path
-			}
-			return results[i].Score > results[j].Score
+			Path:         candidate.key.Path,
+			StartLine:    candidate.key.StartLine,
+			EndLine:      candidate.key.EndLine,
+			Score:        score,
+			VectorScore:  candidate.vector,
+			LexicalScore: candidate.lexical,
+			Snippet:      buildSnippet(candidate.text),
+			Source:       source,
+			NodeID:       buildNodeID(candidate.key.Path, candidate.key.StartLine, candidate.key.EndLine),
 		})
 	}
-	if limit > 0 && len(results) > limit {
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Score == results[j].Score {
+			return results[i].Path < results[j].Path
+		}
+		return results[i].Score > results[j].Score
+	})
+	if len(results) > limit {
 		return results[:limit]
 	}
 	return results
 }
+
+// normalizeBM25 maps FTS5 bm25() values, where more negative is better, onto
+// [0, 1] relative to the best match.
+func normalizeBM25(matches []TextMatch) []lexicalMatch {
+	best := 0.0
+	for _, match := range matches {
+		best = math.Max(best, -match.BM25)
+	}
+	out := make([]lexicalMatch, 0, len(matches))
+	for _, match := range matches {
+		score := 0.0
+		if best > 0 {
+			score = math.Max(0, -match.BM25) / best
+		}
+		out = append(out, lexicalMatch{
+			Key:   ChunkKey{Path: filepath.ToSlash(match.Chunk.Path), StartLine: match.Chunk.StartLine, EndLine: match.Chunk.EndLine},
+			Text:  match.Chunk.Text,
+			Score: score,
+		})
+	}
+	return out
+}
+
+func cosineSimilarity(a, b []float32) float64 {
+	if len(a) == 0 || len(a) != len(b) {
+		return 0
+	}
+	var dot, normA, normB float64
+	for idx := range a {
+		dot += float64(a[idx]) * float64(b[idx])
+		normA += float64(a[idx]) * float64(a[idx])
+		normB += float64(b[idx]) * float64(b[idx])
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+}
+
+func clampUnit(value float64) float64 {
+	return math.Min(1, math.Max(0, value))
+}
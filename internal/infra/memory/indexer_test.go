@@ -27,7 +27,7 @@ func (s stubEmbeddingProvider) Embed(_ context.Context, texts []string) ([][]flo
 	return out, nil
 }
 
-func TestMergeMatchesRanking(t *testing.T) {
+func TestFuseMatchesScoresBothComponents(t *testing.T) {
 	vecMatches := []VectorMatch{
 		{
 			Chunk: StoredChunk{
@@ -35,9 +35,9 @@ func TestMergeMatchesRanking(t *testing.T) {
 				Path:      "memory/2026-02-02.md",
 				StartLine: 1,
 				EndLine:   2,
-				Text:      "Chunk one",
+				Text:      "Deploy rollback notes",
 			},
-			Distance: 0.1,
+			Distance: 0.2,
 		},
 		{
 			Chunk: StoredChunk{
@@ -47,20 +47,10 @@ func TestMergeMatchesRanking(t *testing.T) {
 				EndLine:   4,
 				Text:      "Chunk two",
 			},
-			Distance: 0.0,
+			Distance: 0.1,
 		},
 	}
-	textMatches := []TextMatch{
-		{
-			Chunk: StoredChunk{
-				ID:        1,
-				Path:      "memory/2026-02-02.md",
-				StartLine: 1,
-				EndLine:   2,
-				Text:      "Chunk one",
-			},
-			BM25: 10,
-		},
+	lexMatches := normalizeBM25([]TextMatch{
 		{
 			Chunk: StoredChunk{
 				ID:        3,
@@ -69,25 +59,49 @@ func TestMergeMatchesRanking(t *testing.T) {
 				EndLine:   6,
 				Text:      "Chunk three",
 			},
-			BM25: 0,
+			BM25: -4,
 		},
-	}
+		{
+			Chunk: StoredChunk{
+				ID:        1,
+				Path:      "memory/2026-02-02.md",
+				StartLine: 1,
+				EndLine:   2,
+				Text:      "Deploy rollback notes",
+			},
+			BM25: -2,
+		},
+	})
 
-	results := mergeMatches(vecMatches, textMatches, 5, 0.1, 0.7, 0.3)
-	if len(results) < 3 {
+	candidates := fuseMatches("deploy rollback", vecMatches, lexMatches)
+	if len(candidates) != 3 {
+		t.Fatalf("expected 3 fused candidates, got %d", len(candidates))
+	}
+	results := rankHits(candidates, 5, 0.1, 0.7, 0.3)
+	if len(results) != 3 {
 		t.Fatalf("expected 3 results, got %d", len(results))
 	}
-	if results[0].StartLine != 3 {
-		t.Fatalf("expected chunk with best vector distance first, got %+v", results[0])
+	blended := results[0]
+	if blended.StartLine != 1 || blended.VectorScore < 0.79 || blended.LexicalScore != 0.5 {
+		t.Fatalf("expected chunk matching both legs first with both components, got %+v", blended)
+	}
+	if results[1].StartLine != 3 || results[1].LexicalScore != 0 {
+		t.Fatalf("expected vector-only chunk second with lexical score from its text, got %+v", results[1])
+	}
+	if results[2].Path != "MEMORY.md" || results[2].Source != "long_term" || results[2].VectorScore != 0 {
+		t.Fatalf("expected lexical-only long-term memory third, got %+v", results[2])
 	}
-	if results[1].StartLine != 1 {
-		t.Fatalf("expected blended chunk second, got %+v", results[1])
+}
+
+func TestCosineSimilarity(t *testing.T) {
+	if got := cosineSimilarity([]float32{1, 0}, []float32{2, 0}); got != 1 {
+		t.Fatalf("parallel vectors: got %v", got)
 	}
-	if results[2].Path != "MEMORY.md" {
-		t.Fatalf("expected long-term memory third, got %+v", results[2])
+	if got := cosineSimilarity([]float32{1, 0}, []float32{0, 1}); got != 0 {
+		t.Fatalf("orthogonal vectors: got %v", got)
 	}
-	if results[2].Source != "long_term" {
-		t.Fatalf("expected long_term source, got %+v", results[2])
+	if got := cosineSimilarity([]float32{1}, []float32{1, 0}); got != 0 {
+		t.Fatalf("dimension mismatch: got %v", got)
 	}
 }
 
@@ -105,6 +119,9 @@ func TestIndexerSearchRunsVectorAndBM25InParallel(t *testing.T) {
 	indexer.store = &IndexStore{}
 	indexer.ensureSchemaFn = func(context.Context, *IndexStore, int) error { return nil }
 	indexer.countRelatedFn = func(context.Context, *IndexStore, string, int, int) (int, error) { return 0, nil }
+	indexer.chunkEmbeddingsFn = func(context.Context, *IndexStore, []ChunkKey) (map[ChunkKey][]float32, error) {
+		return nil, nil
+	}
 
 	vectorStarted := make(chan struct{})
 	bm25Started := make(chan struct{})
@@ -144,7 +161,7 @@ func TestIndexerSearchRunsVectorAndBM25InParallel(t *testing.T) {
 					EndLine:   4,
 					Text:      "bm25 match",
 				},
-				BM25: 0,
+				BM25: -1.5,
 			},
 		}, nil
 	}
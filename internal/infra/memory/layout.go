@@ -3,6 +3,7 @@ package memory
 const (
 	dailyDirName      = "memory"
 	archiveDirName    = "archive"
+	compactedDirName  = "compacted"
 	memoryFileName    = "MEMORY.md"
 	soulFileName      = "SOUL.md"
 	userFileName      = "USER.md"
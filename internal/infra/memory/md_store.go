@@ -3,11 +3,13 @@ package memory
 import (
 	"bufio"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"alex/internal/shared/utils"
 )
@@ -27,6 +29,7 @@ type MarkdownEngine struct {
 
 	chunkTokens  int
 	chunkOverlap int
+	now          func() time.Time
 }
 
 // NewMarkdownEngine constructs a Markdown engine rooted at dir.
@@ -35,12 +38,28 @@ func NewMarkdownEngine(dir string) *MarkdownEngine {
 		rootDir:      dir,
 		chunkTokens:  chunkTokenSize,
 		chunkOverlap: chunkTokenOverlap,
+		now:          time.Now,
 	}
 }
 
-// SetIndexer attaches an indexer for hybrid memory search.
+// ErrIndexDisabled is returned by Reindex when no indexer is attached.
+var ErrIndexDisabled = errors.New("memory index disabled: configure proactive.memory.index.embedder_base_url")
+
+// SetIndexer attaches an indexer for hybrid memory search. The engine's
+// lexical scan becomes the indexer's lexical leg.
 func (e *MarkdownEngine) SetIndexer(indexer *Indexer) {
 	e.indexer = indexer
+	if indexer != nil {
+		indexer.lexicalFn = e.lexicalCandidates
+	}
+}
+
+// Reindex re-embeds existing memories through the attached indexer.
+func (e *MarkdownEngine) Reindex(ctx context.Context, opts ReindexOptions) (ReindexStats, error) {
+	if e.indexer == nil {
+		return ReindexStats{}, ErrIndexDisabled
+	}
+	return e.indexer.Reindex(ctx, opts)
 }
 
 // Name identifies the drainable memory subsystem.
@@ -90,6 +109,13 @@ func (e *MarkdownEngine) userRoot() string {
 	return ResolveUserRoot(e.rootDir, "")
 }
 
+func (e *MarkdownEngine) currentTime() time.Time {
+	if e.now == nil {
+		return time.Now()
+	}
+	return e.now()
+}
+
 func (e *MarkdownEngine) requireRoot() (string, error) {
 	root := e.userRoot()
 	if root == "" {
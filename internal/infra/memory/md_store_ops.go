@@ -17,6 +17,9 @@ func (e *MarkdownEngine) AppendDaily(_ context.Context, _ string, entry DailyEnt
 	if content == "" {
 		return "", fmt.Errorf("content is required")
 	}
+	if err := validateEntryImportance(entry.Importance); err != nil {
+		return "", err
+	}
 	createdAt := entry.CreatedAt
 	if createdAt.IsZero() {
 		createdAt = time.Now()
@@ -47,18 +50,17 @@ func (e *MarkdownEngine) AppendDaily(_ context.Context, _ string, entry DailyEnt
 		block.WriteString("\n")
 	}
 	block.WriteString(fmt.Sprintf("## %s - %s\n", timeStr, title))
+	if meta := formatEntryMeta(entryMeta{ExpiresAt: entry.ExpiresAt, Importance: entry.Importance}); meta != "" {
+		block.WriteString(meta + "\n")
+	}
 	block.WriteString(content)
 	block.WriteString("\n")
 
-	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0o644)
+	f, err := lockDailyFile(path, os.O_WRONLY|os.O_APPEND)
 	if err != nil {
 		return "", err
 	}
 	defer f.Close()
-
-	if err := syscall.Flock(int(f.Fd()), syscall.LOCK_EX); err != nil {
-		return "", fmt.Errorf("flock: %w", err)
-	}
 	defer syscall.Flock(int(f.Fd()), syscall.LOCK_UN) //nolint:errcheck
 
 	if _, err := f.WriteString(block.String()); err != nil {
@@ -68,6 +70,35 @@ func (e *MarkdownEngine) AppendDaily(_ context.Context, _ string, entry DailyEnt
 	return path, nil
 }
 
+// lockDailyFile opens a daily log and takes an exclusive flock on it.
+// Compaction replaces logs by rename, so a lock won on a file that has since
+// been replaced is dropped and taken again on the current one.
+func lockDailyFile(path string, flag int) (*os.File, error) {
+	for {
+		f, err := os.OpenFile(path, flag, 0o644)
+		if err != nil {
+			return nil, err
+		}
+		if err := syscall.Flock(int(f.Fd()), syscall.LOCK_EX); err != nil {
+			f.Close()
+			return nil, fmt.Errorf("flock: %w", err)
+		}
+		held, err := f.Stat()
+		if err != nil {
+			f.Close()
+			return nil, err
+		}
+		current, err := os.Stat(path)
+		if err == nil && os.SameFile(held, current) {
+			return f, nil
+		}
+		f.Close() // releases the lock
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
 // GetLines returns a slice of lines from the given memory path.
 func (e *MarkdownEngine) GetLines(_ context.Context, _ string, path string, fromLine, lineCount int) (string, error) {
 	if _, err := e.requireRoot(); err != nil {
@@ -5,11 +5,18 @@ import (
 	"fmt"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"alex/internal/shared/utils"
 )
 
-// Search scans MEMORY.md + daily logs for the query and returns ranked hits.
+// lexicalCandidateMinScore keeps any chunk sharing a query term as a hybrid
+// candidate; the fused score applies the real threshold.
+const lexicalCandidateMinScore = 0.01
+
+// Search ranks MEMORY.md + daily log chunks for the query. With an indexer
+// attached, lexical and embedding scores are fused; when the embedding
+// provider or index is unavailable, recall degrades to the lexical scan.
 func (e *MarkdownEngine) Search(ctx context.Context, _ string, query string, maxResults int, minScore float64) ([]SearchHit, error) {
 	query = strings.TrimSpace(query)
 	if query == "" {
@@ -30,9 +37,56 @@ func (e *MarkdownEngine) Search(ctx context.Context, _ string, query string, max
 	if e.indexer != nil {
 		results, err := e.indexer.Search(ctx, "", query, maxResults, minScore)
 		if err == nil {
-			return results, nil
+			return dropInactiveHits(root, results, e.currentTime()), nil
+		}
+	}
+	return e.lexicalSearch(root, query, maxResults, minScore)
+}
+
+// dropInactiveHits removes index hits that only cover expired entries and
+// strips expired lines and metadata comments from the remaining snippets.
+// The index lags expiry until compaction rewrites the file, so this keeps
+// expired memories out of recall in between.
+func dropInactiveHits(root string, hits []SearchHit, now time.Time) []SearchHit {
+	masked := make(map[string][]string)
+	out := hits[:0]
+	for _, hit := range hits {
+		lines, ok := masked[hit.Path]
+		if !ok {
+			if raw, err := readLines(filepath.Join(root, hit.Path)); err == nil {
+				if m, changed := maskInactiveLines(raw, now); changed {
+					lines = m
+				}
+			}
+			masked[hit.Path] = lines
+		}
+		if lines == nil {
+			out = append(out, hit)
+			continue
+		}
+		start := hit.StartLine - 1
+		end := minInt(hit.EndLine, len(lines))
+		if start < 0 || start >= end {
+			continue
 		}
+		live := make([]string, 0, end-start)
+		for _, line := range lines[start:end] {
+			if strings.TrimSpace(line) != "" {
+				live = append(live, line)
+			}
+		}
+		if len(live) == 0 {
+			continue
+		}
+		hit.Snippet = buildSnippet(strings.Join(live, "\n"))
+		out = append(out, hit)
 	}
+	return out
+}
+
+// lexicalSearch scores every chunk of the root's memory files by term
+// overlap and exact phrase match.
+func (e *MarkdownEngine) lexicalSearch(root, query string, maxResults int, minScore float64) ([]SearchHit, error) {
 	paths, err := collectMemoryFilesForRoot(root)
 	if err != nil {
 		return nil, err
@@ -47,9 +101,10 @@ func (e *MarkdownEngine) Search(ctx context.Context, _ string, query string, max
 		return nil, nil
 	}
 
+	now := e.currentTime()
 	var hits []SearchHit
 	for _, file := range paths {
-		h, err := searchFile(file, root, queryTerms, queryLower, minScore, e.chunkTokens, e.chunkOverlap)
+		h, err := searchFile(file, root, queryTerms, queryLower, minScore, e.chunkTokens, e.chunkOverlap, now)
 		if err != nil {
 			continue
 		}
@@ -63,6 +118,15 @@ func (e *MarkdownEngine) Search(ctx context.Context, _ string, query string, max
 	return selectTopHits(hits, maxResults), nil
 }
 
+// lexicalCandidates feeds the indexer's hybrid search.
+func (e *MarkdownEngine) lexicalCandidates(_ context.Context, query string, maxResults int) ([]SearchHit, error) {
+	root, err := e.requireRoot()
+	if err != nil {
+		return nil, err
+	}
+	return e.lexicalSearch(root, query, maxResults, lexicalCandidateMinScore)
+}
+
 // Related returns graph-adjacent memory entries for a path/range.
 func (e *MarkdownEngine) Related(ctx context.Context, _ string, path string, fromLine, toLine, maxResults int) ([]RelatedHit, error) {
 	if utils.IsBlank(path) {
@@ -138,11 +202,12 @@ func (e *MarkdownEngine) Related(ctx context.Context, _ string, path string, fro
 	return results, nil
 }
 
-func searchFile(path, root string, queryTerms map[string]struct{}, queryLower string, minScore float64, chunkTokens, chunkOverlap int) ([]SearchHit, error) {
+func searchFile(path, root string, queryTerms map[string]struct{}, queryLower string, minScore float64, chunkTokens, chunkOverlap int, now time.Time) ([]SearchHit, error) {
 	lines, err := readLines(path)
 	if err != nil {
 		return nil, err
 	}
+	lines, _ = maskInactiveLines(lines, now)
 	if len(lines) == 0 {
 		return nil, nil
 	}
@@ -182,13 +247,14 @@ func searchFile(path, root string, queryTerms map[string]struct{}, queryLower st
 				source = "long_term"
 			}
 			hits = append(hits, SearchHit{
-				Path:      relPath,
-				StartLine: start + 1,
-				EndLine:   end,
-				Score:     score,
-				Snippet:   snippet,
-				Source:    source,
-				NodeID:    buildNodeID(relPath, start+1, end),
+				Path:         relPath,
+				StartLine:    start + 1,
+				EndLine:      end,
+				Score:        score,
+				LexicalScore: score,
+				Snippet:      snippet,
+				Source:       source,
+				NodeID:       buildNodeID(relPath, start+1, end),
 			})
 		}
 		start = nextChunkStart(start, end, lineCounts, chunkOverlap)
@@ -114,6 +114,12 @@ func LoadConfig(configPath string) (Config, error) {
 	if fileConfig.Observability.Tracing.ServiceVersion != "" {
 		config.Tracing.ServiceVersion = fileConfig.Observability.Tracing.ServiceVersion
 	}
+	if fileConfig.Observability.Tracing.RouteSampleRates != nil {
+		config.Tracing.RouteSampleRates = fileConfig.Observability.Tracing.RouteSampleRates
+	}
+	if fileConfig.Observability.Tracing.CaptureToolArguments != nil {
+		config.Tracing.CaptureToolArguments = fileConfig.Observability.Tracing.CaptureToolArguments
+	}
 
 	return config, nil
 }
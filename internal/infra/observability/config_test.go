@@ -66,6 +66,23 @@ observability:
 	assert.Equal(t, "alex-test", config.Tracing.ServiceName)
 }
 
+func TestLoadConfig_CaptureToolArguments(t *testing.T) {
+	assert.True(t, DefaultConfig().Tracing.ToolArgumentCaptureEnabled())
+
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	configContent := `
+observability:
+  tracing:
+    enabled: true
+    capture_tool_arguments: false
+`
+	require.NoError(t, os.WriteFile(configPath, []byte(configContent), 0644))
+
+	config, err := LoadConfig(configPath)
+	require.NoError(t, err)
+	assert.False(t, config.Tracing.ToolArgumentCaptureEnabled())
+}
+
 func TestLoadConfig_PartialFile(t *testing.T) {
 	// Create temporary config file with partial settings
 	tmpDir := t.TempDir()
@@ -61,6 +61,12 @@ type MetricsCollector struct {
 	leaderAlertOutcomes     metric.Int64Counter
 	leaderAlertSendLatency  metric.Float64Histogram
 
+	// Lark event router metrics
+	larkEvents metric.Int64Counter
+
+	analyticsSpoolBytes metric.Int64Gauge
+	analyticsDropped    metric.Int64Counter
+
 	prometheusServer *http.Server
 	testHooks        MetricsTestHooks
 }
@@ -77,6 +83,7 @@ type MetricsTestHooks struct {
 	AttentionDecision func(urgencyLevel string, suppressed bool)
 	FocusTimeSuppress func(userID string)
 	AlertOutcome      func(feature, channel, outcome string, latencyMs float64)
+	LarkEvent         func(eventType, outcome string)
 }
 
 // SetTestHooks registers callbacks that are invoked whenever the matching
@@ -115,6 +122,8 @@ func NewMetricsCollector(config MetricsConfig) (*MetricsCollector, error) {
 		collector.initHTTPMetrics,
 		collector.initSystemMetrics,
 		collector.initLeaderMetrics,
+		collector.initLarkMetrics,
+		collector.initAnalyticsMetrics,
 	} {
 		if err := init(); err != nil {
 			return nil, err
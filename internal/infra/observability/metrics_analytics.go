@@ -0,0 +1,36 @@
+package observability
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+func (m *MetricsCollector) initAnalyticsMetrics() error {
+	var err error
+	if m.analyticsSpoolBytes, err = m.meter.Int64Gauge("alex.analytics.spool.size", metric.WithDescription("Bytes of undelivered analytics batches spooled to disk"), metric.WithUnit("By")); err != nil {
+		return fmt.Errorf("failed to create analytics_spool_size gauge: %w", err)
+	}
+	if m.analyticsDropped, err = m.meter.Int64Counter("alex.analytics.dropped.total", metric.WithDescription("Analytics events dropped before delivery"), metric.WithUnit("{event}")); err != nil {
+		return fmt.Errorf("failed to create analytics_dropped counter: %w", err)
+	}
+	return nil
+}
+
+// RecordAnalyticsSpool records the size of the analytics client's spool.
+func (m *MetricsCollector) RecordAnalyticsSpool(ctx context.Context, spoolBytes int64) {
+	if m == nil || m.analyticsSpoolBytes == nil {
+		return
+	}
+	m.analyticsSpoolBytes.Record(ctx, spoolBytes)
+}
+
+// RecordAnalyticsDropped records analytics events dropped for reason.
+func (m *MetricsCollector) RecordAnalyticsDropped(ctx context.Context, reason string, count int) {
+	if m == nil || m.analyticsDropped == nil || count <= 0 {
+		return
+	}
+	m.analyticsDropped.Add(ctx, int64(count), metric.WithAttributes(attribute.String("reason", reason)))
+}
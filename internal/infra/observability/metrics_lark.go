@@ -0,0 +1,36 @@
+package observability
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+func (m *MetricsCollector) initLarkMetrics() error {
+	var err error
+	if m.larkEvents, err = m.meter.Int64Counter("alex.lark.events.total", metric.WithDescription("Lark callbacks by event type and routing outcome (routed, unknown, failed, rejected)"), metric.WithUnit("{event}")); err != nil {
+		return fmt.Errorf("failed to create lark_events counter: %w", err)
+	}
+	return nil
+}
+
+// RecordLarkEvent records one Lark callback passing through the gateway
+// event router. Satisfies lark.EventMetrics.
+func (m *MetricsCollector) RecordLarkEvent(ctx context.Context, eventType, outcome string) {
+	if m == nil {
+		return
+	}
+	if hook := m.testHooks.LarkEvent; hook != nil {
+		hook(eventType, outcome)
+	}
+	if m.larkEvents == nil {
+		return
+	}
+
+	m.larkEvents.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("event_type", eventType),
+		attribute.String("outcome", outcome),
+	))
+}
@@ -402,3 +402,29 @@ func TestMetricsCollector_DisabledMetrics(t *testing.T) {
 	collector.IncrementActiveSessions(ctx)
 	collector.DecrementActiveSessions(ctx)
 }
+
+// --- Lark event router metrics ---
+
+func TestMetricsCollector_RecordLarkEvent(t *testing.T) {
+	collector, err := NewMetricsCollector(MetricsConfig{Enabled: true, PrometheusPort: 0})
+	require.NoError(t, err)
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = collector.Shutdown(ctx)
+	}()
+
+	var nilCollector *MetricsCollector
+	nilCollector.RecordLarkEvent(context.Background(), "im.message.receive_v1", "routed")
+
+	var gotType, gotOutcome string
+	collector.SetTestHooks(MetricsTestHooks{
+		LarkEvent: func(eventType, outcome string) {
+			gotType = eventType
+			gotOutcome = outcome
+		},
+	})
+	collector.RecordLarkEvent(context.Background(), "im.chat.updated_v1", "unknown")
+	assert.Equal(t, "im.chat.updated_v1", gotType)
+	assert.Equal(t, "unknown", gotOutcome)
+}
@@ -2,9 +2,20 @@ package observability
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"os"
+	"strconv"
+	"strings"
 )
 
+// SampleRateEnv overrides tracing.sample_rate at startup, e.g. to sample
+// every trace during an incident without editing the config file.
+const SampleRateEnv = "ALEX_TRACE_SAMPLE_RATE"
+
+// ErrTracingDisabled is returned when sampling is changed while tracing is off.
+var ErrTracingDisabled = errors.New("tracing is disabled")
+
 // Observability manages all observability components
 type Observability struct {
 	Logger  *Logger
@@ -27,6 +38,15 @@ func New(configPath string) (*Observability, error) {
 		Format: config.Logging.Format,
 	})
 
+	if raw, ok := os.LookupEnv(SampleRateEnv); ok && strings.TrimSpace(raw) != "" {
+		rate, err := strconv.ParseFloat(strings.TrimSpace(raw), 64)
+		if err != nil || !validSampleRate(rate) {
+			logger.Warn("Ignoring invalid trace sample rate override", "env", SampleRateEnv, "value", raw)
+		} else {
+			config.Tracing.SampleRate = rate
+		}
+	}
+
 	// Initialize metrics
 	metrics, err := NewMetricsCollector(config.Metrics)
 	if err != nil {
@@ -78,3 +98,44 @@ func (o *Observability) Shutdown(ctx context.Context) error {
 func (o *Observability) Config() Config {
 	return o.config
 }
+
+// CurrentConfig returns the configuration with the live sampling settings,
+// which may differ from startup after SetSamplerRatio or SetRouteSampleRates.
+func (o *Observability) CurrentConfig() Config {
+	config := o.config
+	if o.Tracer != nil && o.Tracer.sampler != nil {
+		config.Tracing.SampleRate, config.Tracing.RouteSampleRates = o.Tracer.sampler.current()
+	}
+	return config
+}
+
+// SetSamplerRatio changes the trace sampling ratio applied to new spans.
+func (o *Observability) SetSamplerRatio(ratio float64) error {
+	if !validSampleRate(ratio) {
+		return fmt.Errorf("sample ratio %g is outside [0, 1]", ratio)
+	}
+	if o.Tracer == nil || o.Tracer.sampler == nil {
+		return ErrTracingDisabled
+	}
+	o.Tracer.sampler.setRatio(ratio)
+	return nil
+}
+
+// SetRouteSampleRates replaces the per-route sampling overrides applied to
+// new spans. Keys match a span's http.route attribute or its name.
+func (o *Observability) SetRouteSampleRates(routes map[string]float64) error {
+	for route, rate := range routes {
+		if strings.TrimSpace(route) == "" {
+			return fmt.Errorf("route sample rate has an empty route")
+		}
+		if !validSampleRate(rate) {
+			return fmt.Errorf("sample ratio %g for %s is outside [0, 1]", rate, route)
+		}
+	}
+	if o.Tracer == nil || o.Tracer.sampler == nil {
+		return ErrTracingDisabled
+	}
+	ratio, _ := o.Tracer.sampler.current()
+	o.Tracer.sampler.set(ratio, routes)
+	return nil
+}
@@ -0,0 +1,91 @@
+package observability
+
+import (
+	"fmt"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// dynamicSampler is a TraceIDRatioBased sampler whose ratio and per-route
+// overrides can change at runtime. A route override is matched against the
+// span's http.route attribute first, then its name.
+type dynamicSampler struct {
+	mu     sync.RWMutex
+	ratio  float64
+	routes map[string]float64
+	base   sdktrace.Sampler
+	byKey  map[string]sdktrace.Sampler
+}
+
+func newDynamicSampler(ratio float64, routes map[string]float64) *dynamicSampler {
+	s := &dynamicSampler{}
+	s.set(ratio, routes)
+	return s
+}
+
+func (s *dynamicSampler) set(ratio float64, routes map[string]float64) {
+	byKey := make(map[string]sdktrace.Sampler, len(routes))
+	copied := make(map[string]float64, len(routes))
+	for key, r := range routes {
+		copied[key] = r
+		byKey[key] = sdktrace.TraceIDRatioBased(r)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.ratio = ratio
+	s.base = sdktrace.TraceIDRatioBased(ratio)
+	s.routes = copied
+	s.byKey = byKey
+}
+
+func (s *dynamicSampler) setRatio(ratio float64) {
+	s.mu.RLock()
+	routes := s.routes
+	s.mu.RUnlock()
+	s.set(ratio, routes)
+}
+
+func (s *dynamicSampler) current() (float64, map[string]float64) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	routes := make(map[string]float64, len(s.routes))
+	for key, r := range s.routes {
+		routes[key] = r
+	}
+	return s.ratio, routes
+}
+
+// ShouldSample implements sdktrace.Sampler.
+func (s *dynamicSampler) ShouldSample(p sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	s.mu.RLock()
+	sampler := s.base
+	if route, ok := routeAttribute(p.Attributes); ok && s.byKey[route] != nil {
+		sampler = s.byKey[route]
+	} else if override, ok := s.byKey[p.Name]; ok {
+		sampler = override
+	}
+	s.mu.RUnlock()
+	return sampler.ShouldSample(p)
+}
+
+// Description implements sdktrace.Sampler.
+func (s *dynamicSampler) Description() string {
+	ratio, routes := s.current()
+	return fmt.Sprintf("DynamicSampler{ratio=%g,routes=%d}", ratio, len(routes))
+}
+
+func routeAttribute(attrs []attribute.KeyValue) (string, bool) {
+	for _, attr := range attrs {
+		if attr.Key == "http.route" {
+			return attr.Value.AsString(), true
+		}
+	}
+	return "", false
+}
+
+// validSampleRate reports whether r is a usable sampling ratio.
+func validSampleRate(r float64) bool {
+	return r >= 0 && r <= 1
+}
@@ -0,0 +1,89 @@
+package observability
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func samplingDecision(s sdktrace.Sampler, name string, attrs ...attribute.KeyValue) sdktrace.SamplingDecision {
+	return s.ShouldSample(sdktrace.SamplingParameters{
+		ParentContext: context.Background(),
+		TraceID:       trace.TraceID{0x7f, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff},
+		Name:          name,
+		Attributes:    attrs,
+	}).Decision
+}
+
+func TestDynamicSamplerRouteOverrides(t *testing.T) {
+	sampler := newDynamicSampler(0, map[string]float64{
+		"/health":            0,
+		"/api/tasks":         1,
+		SpanSessionSolveTask: 1,
+	})
+
+	assert.Equal(t, sdktrace.Drop, samplingDecision(sampler, SpanLLMGenerate))
+	assert.Equal(t, sdktrace.RecordAndSample, samplingDecision(sampler, SpanSessionSolveTask))
+	assert.Equal(t, sdktrace.RecordAndSample, samplingDecision(sampler, SpanHTTPServer, attribute.String("http.route", "/api/tasks")))
+	assert.Equal(t, sdktrace.Drop, samplingDecision(sampler, SpanHTTPServer, attribute.String("http.route", "/api/sessions")))
+
+	// Raising the base ratio keeps the overrides.
+	sampler.setRatio(1)
+	assert.Equal(t, sdktrace.RecordAndSample, samplingDecision(sampler, SpanLLMGenerate))
+	assert.Equal(t, sdktrace.Drop, samplingDecision(sampler, SpanHTTPServer, attribute.String("http.route", "/health")))
+}
+
+func TestObservabilitySamplingControls(t *testing.T) {
+	t.Setenv(SampleRateEnv, "0.25")
+	configPath := writeObservabilityConfig(t, `
+observability:
+  metrics:
+    enabled: false
+  tracing:
+    enabled: true
+    exporter: otlp
+    sample_rate: 0.5
+    route_sample_rates:
+      /health: 0.01
+`)
+	obs, err := New(configPath)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		_ = obs.Shutdown(ctx)
+	})
+
+	current := obs.CurrentConfig().Tracing
+	assert.Equal(t, 0.25, current.SampleRate, "env override wins over the file")
+	assert.Equal(t, map[string]float64{"/health": 0.01}, current.RouteSampleRates)
+
+	require.NoError(t, obs.SetSamplerRatio(1))
+	require.NoError(t, obs.SetRouteSampleRates(map[string]float64{SpanSessionSolveTask: 1}))
+	current = obs.CurrentConfig().Tracing
+	assert.Equal(t, 1.0, current.SampleRate)
+	assert.Equal(t, map[string]float64{SpanSessionSolveTask: 1}, current.RouteSampleRates)
+
+	assert.Error(t, obs.SetSamplerRatio(1.5))
+	assert.Error(t, obs.SetRouteSampleRates(map[string]float64{"/health": -1}))
+	assert.Equal(t, 1.0, obs.CurrentConfig().Tracing.SampleRate, "rejected changes leave sampling untouched")
+}
+
+func TestObservabilitySamplingRequiresTracing(t *testing.T) {
+	obs, err := New(writeObservabilityConfig(t, `
+observability:
+  metrics:
+    enabled: false
+  tracing:
+    enabled: false
+`))
+	require.NoError(t, err)
+	assert.ErrorIs(t, obs.SetSamplerRatio(1), ErrTracingDisabled)
+	assert.ErrorIs(t, obs.SetRouteSampleRates(nil), ErrTracingDisabled)
+}
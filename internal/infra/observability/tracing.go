@@ -24,12 +24,25 @@ type TracingConfig struct {
 	SampleRate     float64 `yaml:"sample_rate"` // 0.0 to 1.0
 	ServiceName    string  `yaml:"service_name"`
 	ServiceVersion string  `yaml:"service_version"`
+	// RouteSampleRates overrides SampleRate per http.route or span name,
+	// e.g. {"alex.session.solve_task": 1, "/health": 0.01}.
+	RouteSampleRates map[string]float64 `yaml:"route_sample_rates"`
+	// CaptureToolArguments records sanitized tool arguments on tool spans.
+	// Defaults to true; set false to keep arguments out of the tracing backend.
+	CaptureToolArguments *bool `yaml:"capture_tool_arguments"`
+}
+
+// ToolArgumentCaptureEnabled reports whether tool spans should carry call
+// arguments.
+func (c TracingConfig) ToolArgumentCaptureEnabled() bool {
+	return c.CaptureToolArguments == nil || *c.CaptureToolArguments
 }
 
 // TracerProvider wraps OpenTelemetry tracer
 type TracerProvider struct {
 	provider *sdktrace.TracerProvider
 	tracer   trace.Tracer
+	sampler  *dynamicSampler // nil when tracing is disabled
 }
 
 // NewTracerProvider creates a new tracer provider
@@ -87,10 +100,11 @@ func NewTracerProvider(config TracingConfig) (*TracerProvider, error) {
 	}
 
 	// Create trace provider
+	sampler := newDynamicSampler(config.SampleRate, validRouteSampleRates(config.RouteSampleRates))
 	provider := sdktrace.NewTracerProvider(
 		sdktrace.WithBatcher(exporter),
 		sdktrace.WithResource(res),
-		sdktrace.WithSampler(sdktrace.TraceIDRatioBased(config.SampleRate)),
+		sdktrace.WithSampler(sampler),
 	)
 
 	otel.SetTracerProvider(provider)
@@ -98,9 +112,21 @@ func NewTracerProvider(config TracingConfig) (*TracerProvider, error) {
 	return &TracerProvider{
 		provider: provider,
 		tracer:   provider.Tracer("alex"),
+		sampler:  sampler,
 	}, nil
 }
 
+// validRouteSampleRates drops overrides outside [0, 1].
+func validRouteSampleRates(routes map[string]float64) map[string]float64 {
+	valid := make(map[string]float64, len(routes))
+	for route, rate := range routes {
+		if route != "" && validSampleRate(rate) {
+			valid[route] = rate
+		}
+	}
+	return valid
+}
+
 // Shutdown gracefully shuts down the tracer provider
 func (tp *TracerProvider) Shutdown(ctx context.Context) error {
 	if tp.provider != nil {
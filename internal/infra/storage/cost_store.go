@@ -15,6 +15,9 @@ import (
 	fstore "alex/internal/infra/filestore"
 )
 
+// aggregatesDirName holds per-day usage aggregates next to the raw records.
+const aggregatesDirName = "_aggregates"
+
 // fileCostStore implements CostStore using file-based storage
 type fileCostStore struct {
 	baseDir string
@@ -187,7 +190,7 @@ func (s *fileCostStore) ListAll(ctx context.Context) ([]agentstorage.UsageRecord
 	}
 
 	for _, entry := range entries {
-		if !entry.IsDir() || entry.Name() == "_index" {
+		if !entry.IsDir() || strings.HasPrefix(entry.Name(), "_") {
 			continue
 		}
 
@@ -276,3 +279,73 @@ func (s *fileCostStore) updateSessionIndex(record agentstorage.UsageRecord) erro
 
 	return nil
 }
+
+// LoadAggregates returns the persisted aggregates for a UTC day. found is
+// false when the day has never been written.
+func (s *fileCostStore) LoadAggregates(ctx context.Context, day string) ([]agentstorage.UsageAggregate, bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	data, err := os.ReadFile(filepath.Join(s.baseDir, aggregatesDirName, day+".json"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, false, nil
+		}
+		return nil, false, fmt.Errorf("read aggregates: %w", err)
+	}
+	var aggregates []agentstorage.UsageAggregate
+	if err := json.Unmarshal(data, &aggregates); err != nil {
+		return nil, false, fmt.Errorf("unmarshal aggregates: %w", err)
+	}
+	return aggregates, true, nil
+}
+
+// SaveAggregates replaces the persisted aggregates for a UTC day.
+func (s *fileCostStore) SaveAggregates(ctx context.Context, day string, aggregates []agentstorage.UsageAggregate) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := json.Marshal(aggregates)
+	if err != nil {
+		return fmt.Errorf("marshal aggregates: %w", err)
+	}
+	dir := filepath.Join(s.baseDir, aggregatesDirName)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("create aggregates dir: %w", err)
+	}
+	return fstore.AtomicWrite(filepath.Join(dir, day+".json"), data, 0644)
+}
+
+// AggregationEpoch returns when usage aggregation started for this store,
+// recording now on first use. Raw records older than the epoch predate
+// aggregation and are backfilled from records.jsonl on demand.
+func (s *fileCostStore) AggregationEpoch(now time.Time) (time.Time, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	dir := filepath.Join(s.baseDir, aggregatesDirName)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return time.Time{}, fmt.Errorf("create aggregates dir: %w", err)
+	}
+	path := filepath.Join(dir, "epoch")
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+	if err == nil {
+		defer func() { _ = f.Close() }()
+		if _, err := f.WriteString(now.UTC().Format(time.RFC3339Nano)); err != nil {
+			return time.Time{}, fmt.Errorf("write aggregation epoch: %w", err)
+		}
+		return now, nil
+	}
+	if !os.IsExist(err) {
+		return time.Time{}, fmt.Errorf("create aggregation epoch: %w", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("read aggregation epoch: %w", err)
+	}
+	epoch, err := time.Parse(time.RFC3339Nano, strings.TrimSpace(string(data)))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("parse aggregation epoch: %w", err)
+	}
+	return epoch, nil
+}
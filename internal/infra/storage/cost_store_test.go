@@ -319,3 +319,42 @@ func TestFileCostStore_EnvExpansion(t *testing.T) {
 		t.Fatalf("expected env-resolved directory to be created: %v", err)
 	}
 }
+
+func TestFileCostStore_AggregatesAndEpoch(t *testing.T) {
+	tmpDir := t.TempDir()
+	store, err := NewFileCostStore(tmpDir)
+	if err != nil {
+		t.Fatalf("NewFileCostStore failed: %v", err)
+	}
+	ctx := context.Background()
+
+	first := time.Date(2026, 3, 13, 8, 0, 0, 0, time.UTC)
+	epoch, err := store.AggregationEpoch(first)
+	if err != nil || !epoch.Equal(first) {
+		t.Fatalf("AggregationEpoch = %v, %v; want %v", epoch, err, first)
+	}
+	if again, err := store.AggregationEpoch(first.Add(time.Hour)); err != nil || !again.Equal(first) {
+		t.Fatalf("AggregationEpoch must keep the first epoch, got %v, %v", again, err)
+	}
+
+	if _, found, err := store.LoadAggregates(ctx, "2026-03-13"); err != nil || found {
+		t.Fatalf("LoadAggregates on empty day = found %v, err %v", found, err)
+	}
+	want := []agentstorage.UsageAggregate{{Day: "2026-03-13", UserID: "alice", Model: "gpt-4o", TotalTokens: 10, RequestCount: 1}}
+	if err := store.SaveAggregates(ctx, "2026-03-13", want); err != nil {
+		t.Fatalf("SaveAggregates failed: %v", err)
+	}
+	got, found, err := store.LoadAggregates(ctx, "2026-03-13")
+	if err != nil || !found || len(got) != 1 || got[0] != want[0] {
+		t.Fatalf("LoadAggregates = %+v, %v, %v", got, found, err)
+	}
+
+	// The aggregates directory must not be read as a date directory.
+	if err := store.SaveUsage(ctx, agentstorage.UsageRecord{ID: "r1", SessionID: "s1", Timestamp: first}); err != nil {
+		t.Fatalf("SaveUsage failed: %v", err)
+	}
+	all, err := store.ListAll(ctx)
+	if err != nil || len(all) != 1 {
+		t.Fatalf("ListAll = %d records, err %v; want 1", len(all), err)
+	}
+}
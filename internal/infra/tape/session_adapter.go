@@ -14,12 +14,13 @@ import (
 // SessionAdapter adapts a TapeStore to satisfy storage.SessionStore for
 // dual-write during migration.
 type SessionAdapter struct {
-	store coretape.TapeStore
+	store  coretape.TapeStore
+	search *sessionSearchIndex
 }
 
 // NewSessionAdapter returns a SessionAdapter wrapping the given TapeStore.
 func NewSessionAdapter(store coretape.TapeStore) *SessionAdapter {
-	return &SessionAdapter{store: store}
+	return &SessionAdapter{store: store, search: newSessionSearchIndex()}
 }
 
 // Create creates a new session by writing an anchor entry to a new tape.
@@ -143,6 +144,7 @@ func (a *SessionAdapter) Save(ctx context.Context, session *storage.Session) err
 		}
 	}
 
+	a.search.apply(session.ID, session.Messages, session.Metadata, session.CreatedAt, time.Now())
 	return nil
 }
 
@@ -168,7 +170,11 @@ func (a *SessionAdapter) List(ctx context.Context, limit int, offset int) ([]str
 
 // Delete removes a session tape.
 func (a *SessionAdapter) Delete(ctx context.Context, id string) error {
-	return a.store.Delete(ctx, id)
+	if err := a.store.Delete(ctx, id); err != nil {
+		return err
+	}
+	a.search.remove(id)
+	return nil
 }
 
 // generateSessionID creates a unique session identifier.
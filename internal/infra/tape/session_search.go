@@ -0,0 +1,224 @@
+package tape
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"alex/internal/domain/agent/ports"
+	"alex/internal/domain/agent/ports/storage"
+)
+
+// sessionSearchIndex is an in-memory inverted index over the searchable text
+// of every session tape. Terms are lowercased rune bigrams, which serve both
+// substring matches in space-delimited languages and CJK text without a
+// tokenizer; candidates from the postings are verified with a substring
+// match before they are returned.
+//
+// The index is built lazily by the first search and afterwards kept current
+// by Save and Delete, which only index newly appended messages.
+type sessionSearchIndex struct {
+	mu       sync.RWMutex
+	built    bool
+	docs     map[string]*searchDoc
+	postings map[string]map[string]struct{}
+}
+
+type searchDoc struct {
+	texts     []string
+	indexed   int // messages already seen, searchable or not
+	grams     map[string]struct{}
+	metadata  map[string]string
+	createdAt time.Time
+	updatedAt time.Time
+}
+
+func newSessionSearchIndex() *sessionSearchIndex {
+	return &sessionSearchIndex{
+		docs:     make(map[string]*searchDoc),
+		postings: make(map[string]map[string]struct{}),
+	}
+}
+
+// SearchSessions implements storage.SessionSearcher.
+func (a *SessionAdapter) SearchSessions(ctx context.Context, query storage.SessionQuery) (storage.SessionSearchResult, error) {
+	if err := a.ensureSearchIndex(ctx); err != nil {
+		return storage.SessionSearchResult{}, err
+	}
+	return a.search.search(query.Normalize()), nil
+}
+
+// ensureSearchIndex builds the index from all tapes on first use. The write
+// lock is held for the whole build so concurrent Saves queue behind it and
+// then apply only messages the build did not already see.
+func (a *SessionAdapter) ensureSearchIndex(ctx context.Context) error {
+	idx := a.search
+	idx.mu.RLock()
+	built := idx.built
+	idx.mu.RUnlock()
+	if built {
+		return nil
+	}
+
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	if idx.built {
+		return nil
+	}
+	names, err := a.store.List(ctx)
+	if err != nil {
+		return fmt.Errorf("build session search index: %w", err)
+	}
+	for _, name := range names {
+		sess, err := a.Get(ctx, name)
+		if errors.Is(err, storage.ErrSessionNotFound) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("build session search index: %w", err)
+		}
+		idx.applyLocked(sess.ID, sess.Messages, sess.Metadata, sess.CreatedAt, sess.UpdatedAt)
+	}
+	idx.built = true
+	return nil
+}
+
+// apply indexes messages past what the index has already seen for the
+// session and merges metadata. It is a no-op until the index is built.
+func (idx *sessionSearchIndex) apply(sessionID string, messages []ports.Message, metadata map[string]string, createdAt, updatedAt time.Time) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	if !idx.built {
+		return
+	}
+	idx.applyLocked(sessionID, messages, metadata, createdAt, updatedAt)
+}
+
+func (idx *sessionSearchIndex) applyLocked(sessionID string, messages []ports.Message, metadata map[string]string, createdAt, updatedAt time.Time) {
+	doc, ok := idx.docs[sessionID]
+	if !ok {
+		doc = &searchDoc{
+			grams:     make(map[string]struct{}),
+			metadata:  make(map[string]string),
+			createdAt: createdAt,
+		}
+		idx.docs[sessionID] = doc
+	}
+	for ; doc.indexed < len(messages); doc.indexed++ {
+		text, ok := storage.SearchableText(messages[doc.indexed])
+		if !ok {
+			continue
+		}
+		doc.texts = append(doc.texts, text)
+		for gram := range bigrams(strings.ToLower(text)) {
+			if _, seen := doc.grams[gram]; seen {
+				continue
+			}
+			doc.grams[gram] = struct{}{}
+			ids := idx.postings[gram]
+			if ids == nil {
+				ids = make(map[string]struct{})
+				idx.postings[gram] = ids
+			}
+			ids[sessionID] = struct{}{}
+		}
+	}
+	for key, value := range metadata {
+		doc.metadata[key] = value
+	}
+	if updatedAt.After(doc.updatedAt) {
+		doc.updatedAt = updatedAt
+	}
+}
+
+func (idx *sessionSearchIndex) remove(sessionID string) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	doc, ok := idx.docs[sessionID]
+	if !ok {
+		return
+	}
+	for gram := range doc.grams {
+		ids := idx.postings[gram]
+		delete(ids, sessionID)
+		if len(ids) == 0 {
+			delete(idx.postings, gram)
+		}
+	}
+	delete(idx.docs, sessionID)
+}
+
+func (idx *sessionSearchIndex) search(query storage.SessionQuery) storage.SessionSearchResult {
+	keywords := query.Keywords()
+
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	var hits []storage.SessionSearchHit
+	for sessionID := range idx.candidatesLocked(keywords) {
+		doc := idx.docs[sessionID]
+		if doc == nil || !query.MatchesMetadata(sessionID, doc.metadata, doc.updatedAt) {
+			continue
+		}
+		matches, snippets, ok := storage.MatchSessionTexts(doc.texts, keywords)
+		if !ok {
+			continue
+		}
+		hits = append(hits, storage.SessionSearchHit{
+			SessionID: sessionID,
+			Title:     strings.TrimSpace(doc.metadata["title"]),
+			Snippets:  snippets,
+			Matches:   matches,
+			CreatedAt: doc.createdAt,
+			UpdatedAt: doc.updatedAt,
+		})
+	}
+	storage.SortSearchHits(hits)
+	return storage.PageSearchHits(hits, query)
+}
+
+// candidatesLocked intersects the postings of every keyword bigram. Keywords
+// shorter than two runes cannot be looked up and leave the set unconstrained.
+func (idx *sessionSearchIndex) candidatesLocked(keywords []string) map[string]struct{} {
+	var candidates map[string]struct{}
+	for _, keyword := range keywords {
+		for gram := range bigrams(keyword) {
+			ids := idx.postings[gram]
+			if candidates == nil {
+				candidates = make(map[string]struct{}, len(ids))
+				for id := range ids {
+					candidates[id] = struct{}{}
+				}
+				continue
+			}
+			for id := range candidates {
+				if _, ok := ids[id]; !ok {
+					delete(candidates, id)
+				}
+			}
+		}
+		if candidates != nil && len(candidates) == 0 {
+			return candidates
+		}
+	}
+	if candidates == nil {
+		candidates = make(map[string]struct{}, len(idx.docs))
+		for id := range idx.docs {
+			candidates[id] = struct{}{}
+		}
+	}
+	return candidates
+}
+
+// bigrams returns the distinct adjacent rune pairs of s.
+func bigrams(s string) map[string]struct{} {
+	runes := []rune(s)
+	grams := make(map[string]struct{})
+	for i := 0; i+1 < len(runes); i++ {
+		grams[string(runes[i:i+2])] = struct{}{}
+	}
+	return grams
+}
@@ -0,0 +1,139 @@
+package tape
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"alex/internal/domain/agent/ports"
+	"alex/internal/domain/agent/ports/storage"
+)
+
+func saveSearchSession(t *testing.T, adapter *SessionAdapter, sess *storage.Session) {
+	t.Helper()
+	if err := adapter.Save(context.Background(), sess); err != nil {
+		t.Fatalf("Save(%s): %v", sess.ID, err)
+	}
+}
+
+func TestSessionAdapter_SearchSessions_BuildsIndexAndMatchesContent(t *testing.T) {
+	ctx := context.Background()
+	adapter := NewSessionAdapter(NewMemoryStore())
+
+	billing := storage.NewSession("lark-billing", time.Now())
+	billing.Metadata = map[string]string{"title": "Billing", "user_id": "ou_1"}
+	billing.Messages = []ports.Message{
+		{Role: "system", Content: "billing rules", Source: ports.MessageSourceSystemPrompt},
+		{Role: "user", Content: "Plan the billing migration", Source: ports.MessageSourceUserInput},
+		{Role: "assistant", Content: "", ToolCalls: []ports.ToolCall{{ID: "c1", Name: "shell"}}},
+		{Role: "tool", Content: "migration log", Source: ports.MessageSourceToolResult},
+		{Role: "assistant", Content: "The migration runs in two phases.", Source: ports.MessageSourceAssistantReply},
+	}
+	saveSearchSession(t, adapter, billing)
+
+	other := storage.NewSession("web-other", time.Now())
+	other.Messages = []ports.Message{{Role: "user", Content: "what is the weather", Source: ports.MessageSourceUserInput}}
+	saveSearchSession(t, adapter, other)
+
+	result, err := adapter.SearchSessions(ctx, storage.SessionQuery{Text: "MIGRATION billing"})
+	if err != nil {
+		t.Fatalf("SearchSessions: %v", err)
+	}
+	if result.Total != 1 || len(result.Hits) != 1 {
+		t.Fatalf("expected one hit, got %+v", result)
+	}
+	hit := result.Hits[0]
+	if hit.SessionID != "lark-billing" || hit.Title != "Billing" {
+		t.Fatalf("unexpected hit %+v", hit)
+	}
+	if hit.Matches != 3 {
+		t.Fatalf("expected 3 matches outside tool traffic, got %d", hit.Matches)
+	}
+	if len(hit.Snippets) != 2 || !strings.Contains(hit.Snippets[0], "**billing** **migration**") {
+		t.Fatalf("unexpected snippets %q", hit.Snippets)
+	}
+
+	result, err = adapter.SearchSessions(ctx, storage.SessionQuery{Text: "rules"})
+	if err != nil {
+		t.Fatalf("SearchSessions: %v", err)
+	}
+	if result.Total != 0 {
+		t.Fatalf("system prompt must not be searchable, got %+v", result)
+	}
+}
+
+func TestSessionAdapter_SearchSessions_IndexesAppendedMessagesAndDeletes(t *testing.T) {
+	ctx := context.Background()
+	adapter := NewSessionAdapter(NewMemoryStore())
+
+	sess := storage.NewSession("lark-chat", time.Now())
+	sess.Messages = []ports.Message{{Role: "user", Content: "你好", Source: ports.MessageSourceUserInput}}
+	saveSearchSession(t, adapter, sess)
+
+	// Build the index before more messages arrive.
+	if _, err := adapter.SearchSessions(ctx, storage.SessionQuery{Text: "你好"}); err != nil {
+		t.Fatalf("SearchSessions: %v", err)
+	}
+
+	sess.Messages = append(sess.Messages, ports.Message{Role: "user", Content: "帮我整理周报", Source: ports.MessageSourceUserInput})
+	sess.Metadata = map[string]string{"user_id": "ou_2"}
+	saveSearchSession(t, adapter, sess)
+
+	result, err := adapter.SearchSessions(ctx, storage.SessionQuery{Text: "周报", UserID: "ou_2"})
+	if err != nil {
+		t.Fatalf("SearchSessions: %v", err)
+	}
+	if result.Total != 1 || result.Hits[0].Snippets[0] != "帮我整理**周报**" {
+		t.Fatalf("expected appended message to be indexed, got %+v", result)
+	}
+	if got := len(adapter.search.docs["lark-chat"].texts); got != 2 {
+		t.Fatalf("expected each message indexed once, got %d texts", got)
+	}
+
+	if err := adapter.Delete(ctx, "lark-chat"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	result, err = adapter.SearchSessions(ctx, storage.SessionQuery{Text: "周报"})
+	if err != nil {
+		t.Fatalf("SearchSessions: %v", err)
+	}
+	if result.Total != 0 || len(adapter.search.postings) != 0 {
+		t.Fatalf("expected deleted session to leave the index, got %+v", result)
+	}
+}
+
+func TestSessionAdapter_SearchSessions_FiltersAndPaginates(t *testing.T) {
+	ctx := context.Background()
+	adapter := NewSessionAdapter(NewMemoryStore())
+	for _, id := range []string{"lark-a", "lark-b", "tg-c"} {
+		sess := storage.NewSession(id, time.Now())
+		sess.Metadata = map[string]string{"user_id": "ou_1"}
+		sess.Messages = []ports.Message{{Role: "user", Content: "deploy notes " + id}}
+		saveSearchSession(t, adapter, sess)
+	}
+
+	result, err := adapter.SearchSessions(ctx, storage.SessionQuery{Text: "deploy", ChannelPrefix: "lark", Limit: 1})
+	if err != nil {
+		t.Fatalf("SearchSessions: %v", err)
+	}
+	if result.Total != 2 || len(result.Hits) != 1 {
+		t.Fatalf("expected page of 1 from 2 lark hits, got %+v", result)
+	}
+
+	result, err = adapter.SearchSessions(ctx, storage.SessionQuery{ChannelPrefix: "lark", Offset: 1, Limit: 5})
+	if err != nil {
+		t.Fatalf("SearchSessions: %v", err)
+	}
+	if result.Total != 2 || len(result.Hits) != 1 {
+		t.Fatalf("expected metadata-only query to page, got %+v", result)
+	}
+
+	result, err = adapter.SearchSessions(ctx, storage.SessionQuery{Text: "deploy", Until: time.Now().Add(-time.Hour)})
+	if err != nil {
+		t.Fatalf("SearchSessions: %v", err)
+	}
+	if result.Total != 0 {
+		t.Fatalf("expected date filter to exclude recent sessions, got %+v", result)
+	}
+}
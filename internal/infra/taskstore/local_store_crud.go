@@ -27,6 +27,7 @@ func (s *LocalStore) Create(_ context.Context, t *task.Task) error {
 		cp.CreatedAt = time.Now()
 	}
 	cp.UpdatedAt = time.Now()
+	cp.Revision = 1
 	s.tasks[cp.TaskID] = &cp
 	s.persistLocked()
 	return nil
@@ -44,6 +45,53 @@ func (s *LocalStore) Get(_ context.Context, taskID string) (*task.Task, error) {
 	return s.copyTask(t), nil
 }
 
+// GetWithRevision retrieves a task by ID together with its current revision.
+func (s *LocalStore) GetWithRevision(_ context.Context, taskID string) (*task.Task, int64, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	t, ok := s.tasks[taskID]
+	if !ok {
+		return nil, 0, task.NotFoundError(taskID)
+	}
+	return s.copyTask(t), t.Revision, nil
+}
+
+// CompareAndSwap replaces the task when its revision equals expected. A status
+// change records a transition and, for terminal statuses, drops ownership.
+func (s *LocalStore) CompareAndSwap(_ context.Context, taskID string, expected int64, updated *task.Task) (int64, error) {
+	if updated == nil {
+		return 0, fmt.Errorf("task is nil")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t, ok := s.tasks[taskID]
+	if !ok {
+		return 0, task.NotFoundError(taskID)
+	}
+	if t.Revision != expected {
+		return 0, task.ConflictError(taskID, expected, t.Revision)
+	}
+
+	cp := *updated
+	cp.TaskID = taskID
+	cp.CreatedAt = t.CreatedAt
+	cp.UpdatedAt = time.Now()
+	cp.Revision = t.Revision + 1
+	if cp.Status != t.Status {
+		if cp.Status.IsTerminal() {
+			delete(s.owners, taskID)
+			delete(s.leases, taskID)
+		}
+		s.addTransitionLocked(taskID, t.Status, cp.Status, task.TransitionParams{})
+	}
+	s.tasks[taskID] = &cp
+	s.persistLocked()
+	return cp.Revision, nil
+}
+
 // SetStatus updates the task status and writes a transition record atomically.
 func (s *LocalStore) SetStatus(_ context.Context, taskID string, status task.Status, opts ...task.TransitionOption) error {
 	params := task.ApplyTransitionOptions(opts)
@@ -59,6 +107,7 @@ func (s *LocalStore) SetStatus(_ context.Context, taskID string, status task.Sta
 	from := t.Status
 	t.Status = status
 	t.UpdatedAt = time.Now()
+	t.Revision++
 
 	now := time.Now()
 	switch status {
@@ -112,6 +161,7 @@ func (s *LocalStore) UpdateProgress(_ context.Context, taskID string, iteration
 	t.TokensUsed = tokensUsed
 	t.CostUSD = costUSD
 	t.UpdatedAt = time.Now()
+	t.Revision++
 	s.persistLocked()
 	return nil
 }
@@ -129,6 +179,7 @@ func (s *LocalStore) SetResult(_ context.Context, taskID string, answer string,
 	t.ResultJSON = resultJSON
 	t.TokensUsed = tokensUsed
 	t.UpdatedAt = time.Now()
+	t.Revision++
 	s.persistLocked()
 	return nil
 }
@@ -144,6 +195,7 @@ func (s *LocalStore) SetError(_ context.Context, taskID string, errText string)
 	}
 	t.Error = errText
 	t.UpdatedAt = time.Now()
+	t.Revision++
 	s.persistLocked()
 	return nil
 }
@@ -159,6 +211,25 @@ func (s *LocalStore) SetBridgeMeta(_ context.Context, taskID string, meta task.B
 	}
 	t.BridgeMeta = &meta
 	t.UpdatedAt = time.Now()
+	t.Revision++
+	s.persistLocked()
+	return nil
+}
+
+// SetQueued records the task's queue priority and the time it entered the
+// execution queue.
+func (s *LocalStore) SetQueued(_ context.Context, taskID string, priority int, queuedAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t, ok := s.tasks[taskID]
+	if !ok {
+		return task.NotFoundError(taskID)
+	}
+	t.Priority = priority
+	t.QueuedAt = &queuedAt
+	t.UpdatedAt = time.Now()
+	t.Revision++
 	s.persistLocked()
 	return nil
 }
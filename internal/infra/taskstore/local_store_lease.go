@@ -27,6 +27,7 @@ func (s *LocalStore) TryClaimTask(_ context.Context, taskID, ownerID string, lea
 	s.owners[taskID] = ownerID
 	s.leases[taskID] = leaseUntil
 	t.UpdatedAt = time.Now()
+	t.Revision++
 	s.persistLocked()
 	return true, nil
 }
@@ -56,6 +57,7 @@ func (s *LocalStore) ClaimResumableTasks(_ context.Context, ownerID string, leas
 		s.owners[id] = ownerID
 		s.leases[id] = leaseUntil
 		t.UpdatedAt = now
+		t.Revision++
 		claimed = append(claimed, s.copyTask(t))
 		if limit > 0 && len(claimed) >= limit {
 			break
@@ -138,6 +140,7 @@ func (s *LocalStore) MarkStaleRunning(_ context.Context, reason string) error {
 		t.TerminationReason = task.TerminationError
 		t.Error = reason
 		t.UpdatedAt = now
+		t.Revision++
 		if t.CompletedAt == nil {
 			t.CompletedAt = &now
 		}
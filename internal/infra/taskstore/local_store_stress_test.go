@@ -2,6 +2,7 @@ package taskstore
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
 	"sync/atomic"
@@ -157,3 +158,75 @@ func TestConcurrentTaskStore(t *testing.T) {
 		t.Errorf("expected %d total tasks, got %d", totalCreated, total)
 	}
 }
+
+func TestCompareAndSwapConcurrentWritersLoseNoUpdates(t *testing.T) {
+	const (
+		writers    = 20
+		increments = 25
+	)
+
+	store := New(WithFilePath(t.TempDir() + "/tasks.json"))
+	defer store.Close()
+	ctx := context.Background()
+	if err := store.Create(ctx, &task.Task{TaskID: "cas", Status: task.StatusRunning}); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	var (
+		wg        sync.WaitGroup
+		conflicts atomic.Int32
+	)
+	wg.Add(writers)
+	for w := 0; w < writers; w++ {
+		go func(workerID int) {
+			defer wg.Done()
+			for i := 0; i < increments; i++ {
+				for {
+					current, rev, err := store.GetWithRevision(ctx, "cas")
+					if err != nil {
+						t.Errorf("GetWithRevision: %v", err)
+						return
+					}
+					current.TokensUsed++
+					if current.Metadata == nil {
+						current.Metadata = map[string]string{}
+					} else {
+						cp := make(map[string]string, len(current.Metadata)+1)
+						for k, v := range current.Metadata {
+							cp[k] = v
+						}
+						current.Metadata = cp
+					}
+					current.Metadata[fmt.Sprintf("w%d", workerID)] = fmt.Sprint(i + 1)
+					_, err = store.CompareAndSwap(ctx, "cas", rev, current)
+					if err == nil {
+						break
+					}
+					if !errors.Is(err, task.ErrConflict) {
+						t.Errorf("CompareAndSwap: %v", err)
+						return
+					}
+					conflicts.Add(1)
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	final, rev, err := store.GetWithRevision(ctx, "cas")
+	if err != nil {
+		t.Fatalf("GetWithRevision: %v", err)
+	}
+	if final.TokensUsed != writers*increments {
+		t.Fatalf("TokensUsed = %d, want %d (lost updates)", final.TokensUsed, writers*increments)
+	}
+	if rev != 1+writers*increments {
+		t.Fatalf("revision = %d, want %d", rev, 1+writers*increments)
+	}
+	for w := 0; w < writers; w++ {
+		if got := final.Metadata[fmt.Sprintf("w%d", w)]; got != fmt.Sprint(increments) {
+			t.Fatalf("writer %d metadata = %q, want %d", w, got, increments)
+		}
+	}
+	t.Logf("resolved %d conflicts", conflicts.Load())
+}
@@ -35,10 +35,11 @@ func NewReplaceInFile(cfg shared.FileToolConfig) tools.ToolExecutor {
 				},
 			},
 			ports.ToolMetadata{
-				Name:     "replace_in_file",
-				Version:  "0.1.0",
-				Category: "files",
-				Tags:     []string{"file", "replace", "patch", "hotfix", "inplace", "edit_existing", "modify"},
+				Name:        "replace_in_file",
+				Version:     "0.1.0",
+				Category:    "files",
+				Tags:        []string{"file", "replace", "patch", "hotfix", "inplace", "edit_existing", "modify"},
+				SafetyLevel: ports.SafetyLevelReversible,
 			},
 		),
 	}
@@ -72,7 +73,11 @@ func (t *replaceInFile) Execute(ctx context.Context, call ports.ToolCall) (*port
 	}
 
 	updated := strings.ReplaceAll(original, oldStr, newStr)
-	if err := os.WriteFile(resolved, []byte(updated), 0o644); err != nil {
+	growth := func(existing int64) int64 { return int64(len(updated)) - existing }
+	err = pathutil.WriteWithinQuota(ctx, resolved, growth, func() error {
+		return os.WriteFile(resolved, []byte(updated), 0o644)
+	})
+	if err != nil {
 		return shared.ToolError(call.ID, "%w", err)
 	}
 
@@ -46,10 +46,11 @@ func NewShellExec(cfg shared.ShellToolConfig) tools.ToolExecutor {
 				},
 			},
 			ports.ToolMetadata{
-				Name:     "shell_exec",
-				Version:  "0.1.0",
-				Category: "shell",
-				Tags:     []string{"shell", "exec", "command", "cli", "terminal", "process", "logs", "runtime", "git", "test"},
+				Name:        "shell_exec",
+				Version:     "0.1.0",
+				Category:    "shell",
+				Tags:        []string{"shell", "exec", "command", "cli", "terminal", "process", "logs", "runtime", "git", "test"},
+				SafetyLevel: ports.SafetyLevelReversible,
 			},
 		),
 	}
@@ -40,10 +40,11 @@ func NewWriteFile(cfg shared.FileToolConfig) tools.ToolExecutor {
 				},
 			},
 			ports.ToolMetadata{
-				Name:     "write_file",
-				Version:  "0.1.0",
-				Category: "files",
-				Tags:     []string{"file", "write", "create", "new_file", "markdown", "report", "brief", "runbook", "decision_record", "artifact", "handoff", "persist"},
+				Name:        "write_file",
+				Version:     "0.1.0",
+				Category:    "files",
+				Tags:        []string{"file", "write", "create", "new_file", "markdown", "report", "brief", "runbook", "decision_record", "artifact", "handoff", "persist"},
+				SafetyLevel: ports.SafetyLevelReversible,
 			},
 		),
 	}
@@ -92,23 +93,32 @@ func (t *writeFile) Execute(ctx context.Context, call ports.ToolCall) (*ports.To
 		return shared.ToolError(call.ID, "%w", err)
 	}
 
+	growth := func(existing int64) int64 {
+		if appendMode {
+			return int64(len(payload))
+		}
+		return int64(len(payload)) - existing
+	}
 	bytesWritten := 0
-	if appendMode {
+	err = pathutil.WriteWithinQuota(ctx, resolved, growth, func() error {
+		if !appendMode {
+			if err := os.WriteFile(resolved, payload, 0o644); err != nil {
+				return err
+			}
+			bytesWritten = len(payload)
+			return nil
+		}
 		file, err := os.OpenFile(resolved, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
 		if err != nil {
-			return shared.ToolError(call.ID, "%w", err)
+			return err
 		}
 		defer func() { _ = file.Close() }()
 		n, err := file.Write(payload)
-		if err != nil {
-			return shared.ToolError(call.ID, "%w", err)
-		}
 		bytesWritten = n
-	} else {
-		if err := os.WriteFile(resolved, payload, 0o644); err != nil {
-			return shared.ToolError(call.ID, "%w", err)
-		}
-		bytesWritten = len(payload)
+		return err
+	})
+	if err != nil {
+		return shared.ToolError(call.ID, "%w", err)
 	}
 
 	result := &ports.ToolResult{
@@ -2,6 +2,7 @@ package aliases
 
 import (
 	"context"
+	"errors"
 	"os"
 	"path/filepath"
 	"testing"
@@ -60,3 +61,43 @@ func TestWriteFileAutoUpload(t *testing.T) {
 		t.Fatalf("expected attachment named note.txt")
 	}
 }
+
+func TestWriteFileEnforcesWorkspaceQuota(t *testing.T) {
+	root := t.TempDir()
+	ctx := pathutil.WithWorkspace(context.Background(), pathutil.Workspace{Root: root, QuotaBytes: 10})
+	tool := NewWriteFile(shared.FileToolConfig{})
+	write := func(content string, appendMode bool) *ports.ToolResult {
+		t.Helper()
+		result, err := tool.Execute(ctx, ports.ToolCall{
+			ID:   "call-quota",
+			Name: "write_file",
+			Arguments: map[string]any{
+				"path":    "note.txt",
+				"content": content,
+				"append":  appendMode,
+			},
+		})
+		if err != nil {
+			t.Fatalf("write_file failed: %v", err)
+		}
+		return result
+	}
+
+	if result := write("12345678", false); result.Error != nil {
+		t.Fatalf("expected write within quota, got %v", result.Error)
+	}
+	if result := write("abcdefghij", false); result.Error != nil {
+		t.Fatalf("expected overwrite to count only growth, got %v", result.Error)
+	}
+	result := write("!", true)
+	if !errors.Is(result.Error, pathutil.ErrWorkspaceQuotaExceeded) {
+		t.Fatalf("expected quota error, got %v", result.Error)
+	}
+	data, err := os.ReadFile(filepath.Join(root, "note.txt"))
+	if err != nil {
+		t.Fatalf("read back: %v", err)
+	}
+	if string(data) != "abcdefghij" {
+		t.Fatalf("rejected write modified the file: %q", data)
+	}
+}
@@ -48,14 +48,15 @@ func resolveAbsolutePath(ctx context.Context, raw string, allowTemp bool) (strin
 		return candidateAbs, nil
 	}
 
-	if allowTemp {
+	// Temp files are shared across workspaces, so scoped contexts may not use them.
+	if _, scoped := WorkspaceFromContext(ctx); allowTemp && !scoped {
 		tempDir := strings.TrimSpace(os.TempDir())
 		if tempDir != "" && pathWithinBase(tempDir, candidateAbs) {
 			return candidateAbs, nil
 		}
 	}
 
-	return "", fmt.Errorf("path %q escapes workspace root %q", trimmed, workspaceRoot)
+	return "", &PathEscapeError{Path: trimmed, Root: workspaceRoot}
 }
 
 // PathWithinBase reports whether target is contained within base after resolving symlinks.
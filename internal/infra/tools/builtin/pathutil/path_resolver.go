@@ -31,7 +31,11 @@ type PathResolver struct {
 
 // NewPathResolver creates a new path resolver
 func NewPathResolver(workingDir string) *PathResolver {
-	root := defaultWorkingDir()
+	return newRootedPathResolver(defaultWorkingDir(), workingDir)
+}
+
+// newRootedPathResolver clamps workingDir to root, falling back to root.
+func newRootedPathResolver(root, workingDir string) *PathResolver {
 	normalized, ok := normalizeWorkingDir(workingDir)
 	if !ok {
 		normalized = root
@@ -119,7 +123,11 @@ func GetPathResolverFromContext(ctx context.Context) *PathResolver {
 		return NewPathResolver("")
 	}
 
-	if workingDir, ok := ctx.Value(WorkingDirKey).(string); ok {
+	workingDir, _ := ctx.Value(WorkingDirKey).(string)
+	if ws, ok := WorkspaceFromContext(ctx); ok {
+		return newRootedPathResolver(ws.Root, workingDir)
+	}
+	if workingDir != "" {
 		return NewPathResolver(workingDir)
 	}
 
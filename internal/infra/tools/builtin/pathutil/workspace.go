@@ -0,0 +1,138 @@
+package pathutil
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+const workspaceKey contextKey = "workspace"
+
+// workspaceLocks serializes quota-checked writes per workspace root so two
+// writes cannot both pass the check against the same measured usage.
+var workspaceLocks sync.Map // root -> *sync.Mutex
+
+var (
+	// ErrPathEscapesWorkspace is matched by every *PathEscapeError.
+	ErrPathEscapesWorkspace = errors.New("path escapes workspace root")
+	// ErrWorkspaceQuotaExceeded is matched by every *QuotaExceededError.
+	ErrWorkspaceQuotaExceeded = errors.New("workspace quota exceeded")
+)
+
+// Workspace confines file tools to Root. When set on the context it replaces
+// the process working directory as the containment root.
+type Workspace struct {
+	Root string
+	// QuotaBytes caps the total size of regular files under Root (0 = unlimited).
+	QuotaBytes int64
+}
+
+// PathEscapeError reports a path that resolves outside the workspace root.
+type PathEscapeError struct {
+	Path string
+	Root string
+}
+
+func (e *PathEscapeError) Error() string {
+	return fmt.Sprintf("path %q escapes workspace root %q", e.Path, e.Root)
+}
+
+func (e *PathEscapeError) Unwrap() error { return ErrPathEscapesWorkspace }
+
+// QuotaExceededError reports a write that would push a workspace past its quota.
+type QuotaExceededError struct {
+	Root  string
+	Used  int64
+	Delta int64
+	Limit int64
+}
+
+func (e *QuotaExceededError) Error() string {
+	return fmt.Sprintf("workspace %q quota exceeded: %d bytes used, write adds %d, limit %d", e.Root, e.Used, e.Delta, e.Limit)
+}
+
+func (e *QuotaExceededError) Unwrap() error { return ErrWorkspaceQuotaExceeded }
+
+// WithWorkspace scopes file tools in ctx to ws.Root. An empty root leaves ctx
+// unchanged.
+func WithWorkspace(ctx context.Context, ws Workspace) context.Context {
+	root, ok := normalizeWorkingDir(strings.TrimSpace(ws.Root))
+	if !ok {
+		return ctx
+	}
+	ws.Root = root
+	return context.WithValue(ctx, workspaceKey, ws)
+}
+
+// WorkspaceFromContext returns the workspace set by WithWorkspace.
+func WorkspaceFromContext(ctx context.Context) (Workspace, bool) {
+	if ctx == nil {
+		return Workspace{}, false
+	}
+	ws, ok := ctx.Value(workspaceKey).(Workspace)
+	return ws, ok
+}
+
+// WriteWithinQuota runs write while holding the quota lock of the workspace
+// in ctx. growth receives the current on-disk size of path and returns the
+// net bytes the write adds; when the workspace, measured on disk, would grow
+// past its quota the write is skipped and a *QuotaExceededError returned.
+// Without a quota write runs unchecked.
+func WriteWithinQuota(ctx context.Context, path string, growth func(existing int64) int64, write func() error) error {
+	ws, ok := WorkspaceFromContext(ctx)
+	if !ok || ws.QuotaBytes <= 0 {
+		return write()
+	}
+	value, _ := workspaceLocks.LoadOrStore(ws.Root, &sync.Mutex{})
+	mu := value.(*sync.Mutex)
+	mu.Lock()
+	defer mu.Unlock()
+
+	delta := growth(fileSize(path))
+	if delta > 0 {
+		used, err := workspaceUsage(ws.Root)
+		if err != nil {
+			return fmt.Errorf("measure workspace usage: %w", err)
+		}
+		if used+delta > ws.QuotaBytes {
+			return &QuotaExceededError{Root: ws.Root, Used: used, Delta: delta, Limit: ws.QuotaBytes}
+		}
+	}
+	return write()
+}
+
+// fileSize returns the size of path, or 0 when it does not exist.
+func fileSize(path string) int64 {
+	info, err := os.Stat(path)
+	if err != nil || !info.Mode().IsRegular() {
+		return 0
+	}
+	return info.Size()
+}
+
+func workspaceUsage(root string) (int64, error) {
+	var total int64
+	err := filepath.WalkDir(root, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+		total += info.Size()
+		return nil
+	})
+	return total, err
+}
@@ -0,0 +1,154 @@
+package pathutil
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWorkspaceReplacesProcessRoot(t *testing.T) {
+	root := t.TempDir()
+	ctx := WithWorkspace(context.Background(), Workspace{Root: root})
+
+	resolved, err := ResolveLocalPath(ctx, "notes/report.md")
+	if err != nil {
+		t.Fatalf("expected relative path to resolve inside workspace: %v", err)
+	}
+	if !pathWithinBase(root, resolved) {
+		t.Fatalf("resolved path %q is outside workspace %q", resolved, root)
+	}
+	if got := GetPathResolverFromContext(ctx).ResolvePath("."); got != root {
+		t.Fatalf("working dir = %q, want workspace root %q", got, root)
+	}
+
+	cwd, _ := os.Getwd()
+	if _, err := ResolveLocalPath(ctx, filepath.Join(cwd, "go.mod")); !errors.Is(err, ErrPathEscapesWorkspace) {
+		t.Fatalf("expected process cwd to be outside the workspace, got %v", err)
+	}
+}
+
+func TestWorkspaceRejectsEscapesWithTypedError(t *testing.T) {
+	parent := t.TempDir()
+	alice := filepath.Join(parent, "alice")
+	bob := filepath.Join(parent, "bob")
+	for _, dir := range []string{alice, bob} {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+	}
+	ctx := WithWorkspace(context.Background(), Workspace{Root: alice})
+
+	for _, raw := range []string{"../bob/secret.txt", filepath.Join(bob, "secret.txt")} {
+		_, err := ResolveLocalPath(ctx, raw)
+		var escape *PathEscapeError
+		if !errors.As(err, &escape) {
+			t.Fatalf("%s: expected *PathEscapeError, got %v", raw, err)
+		}
+		if escape.Root != alice {
+			t.Fatalf("%s: error root = %q, want %q", raw, escape.Root, alice)
+		}
+	}
+}
+
+func TestWorkspaceClampsWorkingDirToRoot(t *testing.T) {
+	root := t.TempDir()
+	sub := filepath.Join(root, "project")
+	if err := os.MkdirAll(sub, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	ctx := WithWorkspace(context.Background(), Workspace{Root: root})
+
+	if got := GetPathResolverFromContext(WithWorkingDir(ctx, sub)).ResolvePath("."); got != sub {
+		t.Fatalf("working dir inside workspace = %q, want %q", got, sub)
+	}
+	if got := GetPathResolverFromContext(WithWorkingDir(ctx, t.TempDir())).ResolvePath("."); got != root {
+		t.Fatalf("working dir outside workspace = %q, want root %q", got, root)
+	}
+}
+
+func TestWorkspaceDisallowsTempFallback(t *testing.T) {
+	tempFile, err := os.CreateTemp("", "workspace-temp-")
+	if err != nil {
+		t.Fatalf("create temp file: %v", err)
+	}
+	_ = tempFile.Close()
+	t.Cleanup(func() { _ = os.Remove(tempFile.Name()) })
+
+	if _, err := ResolveLocalPathOrTemp(context.Background(), tempFile.Name()); err != nil {
+		t.Fatalf("expected temp file to resolve without a workspace: %v", err)
+	}
+
+	root := filepath.Join(t.TempDir(), "ws")
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	ctx := WithWorkspace(context.Background(), Workspace{Root: root})
+	if _, err := ResolveLocalPathOrTemp(ctx, tempFile.Name()); !errors.Is(err, ErrPathEscapesWorkspace) {
+		t.Fatalf("expected shared temp dir to be rejected inside a workspace, got %v", err)
+	}
+}
+
+func TestWriteWithinQuota(t *testing.T) {
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "a.txt"), make([]byte, 60), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	grow := func(n int64) func(int64) int64 {
+		return func(int64) int64 { return n }
+	}
+	writes := 0
+	write := func() error { writes++; return nil }
+
+	if err := WriteWithinQuota(context.Background(), filepath.Join(root, "b.txt"), grow(1<<30), write); err != nil {
+		t.Fatalf("expected no quota without a workspace, got %v", err)
+	}
+
+	ctx := WithWorkspace(context.Background(), Workspace{Root: root, QuotaBytes: 100})
+	if err := WriteWithinQuota(ctx, filepath.Join(root, "b.txt"), grow(40), write); err != nil {
+		t.Fatalf("expected write up to the quota to pass, got %v", err)
+	}
+	err := WriteWithinQuota(ctx, filepath.Join(root, "b.txt"), grow(41), write)
+	var quotaErr *QuotaExceededError
+	if !errors.As(err, &quotaErr) || !errors.Is(err, ErrWorkspaceQuotaExceeded) {
+		t.Fatalf("expected *QuotaExceededError, got %v", err)
+	}
+	if quotaErr.Used != 60 || quotaErr.Limit != 100 {
+		t.Fatalf("unexpected quota error fields: %+v", quotaErr)
+	}
+	if writes != 2 {
+		t.Fatalf("expected rejected write to be skipped, got %d writes", writes)
+	}
+}
+
+func TestWriteWithinQuotaMeasuresDisk(t *testing.T) {
+	root := t.TempDir()
+	path := filepath.Join(root, "note.txt")
+	ctx := WithWorkspace(context.Background(), Workspace{Root: root, QuotaBytes: 100})
+	if err := os.WriteFile(path, make([]byte, 30), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	// growth sees the size on disk, not what the caller read earlier.
+	var seen int64
+	overwrite := func(existing int64) int64 { seen = existing; return 50 - existing }
+	if err := WriteWithinQuota(ctx, path, overwrite, func() error {
+		return os.WriteFile(path, make([]byte, 50), 0o644)
+	}); err != nil {
+		t.Fatalf("expected overwrite within quota, got %v", err)
+	}
+	if seen != 30 {
+		t.Fatalf("expected existing size 30, got %d", seen)
+	}
+
+	// Files written by other means count immediately.
+	if err := os.WriteFile(filepath.Join(root, "other.bin"), make([]byte, 40), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	err := WriteWithinQuota(ctx, filepath.Join(root, "new.txt"), func(int64) int64 { return 11 }, func() error { return nil })
+	var quotaErr *QuotaExceededError
+	if !errors.As(err, &quotaErr) || quotaErr.Used != 90 {
+		t.Fatalf("expected out-of-band file to count toward the quota, got %v", err)
+	}
+}
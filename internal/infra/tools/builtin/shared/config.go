@@ -10,8 +10,15 @@ type ShellToolConfig struct{}
 
 // WebFetchConfig configures the web_fetch tool cache behavior.
 type WebFetchConfig struct {
+	// CacheDir holds the shared HTTP cache; empty disables caching.
+	CacheDir             string        `yaml:"cache_dir"`
 	CacheTTL             time.Duration `yaml:"cache_ttl"`
+	CacheMaxAge          time.Duration `yaml:"cache_max_age"`
 	CacheMaxEntries      int           `yaml:"cache_max_entries"`
+	CacheMaxBytes        int64         `yaml:"cache_max_bytes"`
 	CacheMaxContentBytes int           `yaml:"cache_max_content_bytes"`
-	MaxResponseBytes     int           `yaml:"max_response_bytes"`
+	// CacheAuthRequests caches credentialed requests per workspace instead of
+	// bypassing the cache.
+	CacheAuthRequests bool `yaml:"cache_auth_requests"`
+	MaxResponseBytes  int  `yaml:"max_response_bytes"`
 }
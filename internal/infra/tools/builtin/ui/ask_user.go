@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"alex/internal/domain/agent/ports"
+	agent "alex/internal/domain/agent/ports/agent"
 	tools "alex/internal/domain/agent/ports/tools"
 	"alex/internal/infra/tools/builtin/shared"
 	id "alex/internal/shared/utils/id"
@@ -25,7 +26,8 @@ func NewAskUser() tools.ToolExecutor {
 - action="clarify": ask targeted clarification when requirements are truly missing/contradictory. Do not use when the user already gave clear, actionable instructions.
 - action="request": request a user decision/action for approval gates, manual steps (login, 2FA, CAPTCHA, release go/no-go).
 
-Set needs_user_input=true with question_to_user to pause for user response. Provide options to let channels render a selection UI.`,
+Set needs_user_input=true with question_to_user to pause for user response. Provide options to let channels render a selection UI.
+Set timeout (seconds) with on_timeout when the task must not wait forever: the user is reminded at half the timeout, and on expiry the task resumes with a generated note so you can apply the declared fallback.`,
 				Parameters: ports.ParameterSchema{
 					Type: "object",
 					Properties: map[string]ports.Property{
@@ -76,6 +78,19 @@ Set needs_user_input=true with question_to_user to pause for user response. Prov
 							Description: "Optional selectable options shown to the user.",
 							Items:       &ports.Property{Type: "string"},
 						},
+						"timeout": {
+							Type:        "integer",
+							Description: "Optional seconds to wait for the user's reply before on_timeout applies.",
+						},
+						"on_timeout": {
+							Type:        "string",
+							Description: `Fallback when the user does not reply in time: "proceed_default" continues with default_answer (or the first option), "abort" drops the pending action, "remind" keeps it on hold and asks again. Defaults to "abort".`,
+							Enum:        []any{agent.AwaitTimeoutProceedDefault, agent.AwaitTimeoutAbort, agent.AwaitTimeoutRemind},
+						},
+						"default_answer": {
+							Type:        "string",
+							Description: `Answer assumed when on_timeout="proceed_default".`,
+						},
 					},
 				},
 			},
@@ -174,6 +189,13 @@ func (t *uiAskUser) executeClarify(ctx context.Context, call ports.ToolCall) (*p
 	if len(options) > 0 && !needsUserInput {
 		return shared.ToolError(call.ID, "options requires needs_user_input=true")
 	}
+	timeoutMeta, errResult := parseTimeoutArgs(call, options)
+	if errResult != nil {
+		return errResult, nil
+	}
+	if len(timeoutMeta) > 0 && !needsUserInput {
+		return shared.ToolError(call.ID, "timeout and on_timeout require needs_user_input=true")
+	}
 
 	metadata := map[string]any{
 		"action":       "clarify",
@@ -191,6 +213,9 @@ func (t *uiAskUser) executeClarify(ctx context.Context, call ports.ToolCall) (*p
 		if len(options) > 0 {
 			metadata["options"] = append([]string(nil), options...)
 		}
+		for key, value := range timeoutMeta {
+			metadata[key] = value
+		}
 	}
 
 	content := taskGoalUI
@@ -232,6 +257,10 @@ func (t *uiAskUser) executeRequest(call ports.ToolCall) (*ports.ToolResult, erro
 	if errResult != nil {
 		return errResult, nil
 	}
+	timeoutMeta, errResult := parseTimeoutArgs(call, options)
+	if errResult != nil {
+		return errResult, nil
+	}
 
 	content := message
 	if title != "" {
@@ -252,6 +281,9 @@ func (t *uiAskUser) executeRequest(call ports.ToolCall) (*ports.ToolResult, erro
 	if len(options) > 0 {
 		metadata["options"] = append([]string(nil), options...)
 	}
+	for key, value := range timeoutMeta {
+		metadata[key] = value
+	}
 
 	return &ports.ToolResult{
 		CallID:   call.ID,
@@ -259,3 +291,41 @@ func (t *uiAskUser) executeRequest(call ports.ToolCall) (*ports.ToolResult, erro
 		Metadata: metadata,
 	}, nil
 }
+
+// parseTimeoutArgs validates timeout, on_timeout and default_answer and
+// returns the metadata channels use to expire the pending request.
+func parseTimeoutArgs(call ports.ToolCall, options []string) (map[string]any, *ports.ToolResult) {
+	meta := map[string]any{}
+	if _, exists := call.Arguments["timeout"]; exists {
+		seconds, ok := shared.IntArg(call.Arguments, "timeout")
+		if !ok || seconds <= 0 {
+			result, _ := shared.ToolError(call.ID, "timeout must be a positive number of seconds")
+			return nil, result
+		}
+		meta["input_timeout_seconds"] = seconds
+	}
+
+	policy := strings.TrimSpace(shared.StringArg(call.Arguments, "on_timeout"))
+	if policy != "" && !agent.IsValidAwaitTimeoutPolicy(policy) {
+		result, _ := shared.ToolError(call.ID, "on_timeout must be \"proceed_default\", \"abort\" or \"remind\"")
+		return nil, result
+	}
+	defaultAnswer := strings.TrimSpace(shared.StringArg(call.Arguments, "default_answer"))
+	if policy == agent.AwaitTimeoutProceedDefault && defaultAnswer == "" {
+		if len(options) == 0 {
+			result, _ := shared.ToolError(call.ID, "on_timeout=\"proceed_default\" requires default_answer or options")
+			return nil, result
+		}
+		defaultAnswer = options[0]
+	}
+	if policy != "" {
+		meta["on_timeout"] = policy
+	}
+	if defaultAnswer != "" {
+		meta["default_answer"] = defaultAnswer
+	}
+	if len(meta) == 0 {
+		return nil, nil
+	}
+	return meta, nil
+}
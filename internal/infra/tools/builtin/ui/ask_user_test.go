@@ -157,3 +157,42 @@ func TestAskUserRejectsInvalidAction(t *testing.T) {
 		t.Fatalf("unexpected error: %v", result.Error)
 	}
 }
+
+func TestAskUserTimeoutArguments(t *testing.T) {
+	tool := NewAskUser()
+
+	result, err := tool.Execute(context.Background(), ports.ToolCall{
+		ID: "call-timeout",
+		Arguments: map[string]any{
+			"action":     "request",
+			"message":    "Approve deploy to prod?",
+			"options":    []any{"approve", "reject"},
+			"timeout":    float64(900),
+			"on_timeout": "proceed_default",
+		},
+	})
+	if err != nil || result.Error != nil {
+		t.Fatalf("unexpected error: %v / %v", err, result.Error)
+	}
+	if result.Metadata["input_timeout_seconds"] != 900 || result.Metadata["on_timeout"] != "proceed_default" {
+		t.Fatalf("unexpected timeout metadata: %#v", result.Metadata)
+	}
+	if result.Metadata["default_answer"] != "approve" {
+		t.Fatalf("proceed_default should fall back to the first option, got %#v", result.Metadata["default_answer"])
+	}
+
+	for name, args := range map[string]map[string]any{
+		"non-positive timeout": {"action": "request", "message": "m", "timeout": float64(0)},
+		"unknown policy":       {"action": "request", "message": "m", "on_timeout": "escalate"},
+		"default missing":      {"action": "request", "message": "m", "on_timeout": "proceed_default"},
+		"clarify without wait": {"action": "clarify", "task_goal_ui": "g", "timeout": float64(60)},
+	} {
+		result, err := tool.Execute(context.Background(), ports.ToolCall{ID: "call-bad", Arguments: args})
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+		if result.Error == nil {
+			t.Fatalf("%s: expected validation error, got metadata %#v", name, result.Metadata)
+		}
+	}
+}
@@ -0,0 +1,248 @@
+package web
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"alex/internal/domain/agent/ports"
+	tools "alex/internal/domain/agent/ports/tools"
+	"alex/internal/infra/httpcache"
+	"alex/internal/infra/tools/builtin/pathutil"
+	"alex/internal/infra/tools/builtin/shared"
+	"alex/internal/shared/httpclient"
+	"alex/internal/shared/logging"
+	"golang.org/x/net/html"
+)
+
+const (
+	defaultWebFetchMaxResponseBytes = 2 << 20
+	defaultWebFetchMaxChars         = 20000
+	maxWebFetchMaxChars             = 100000
+)
+
+type webFetch struct {
+	shared.BaseTool
+	fetcher *httpcache.Fetcher
+}
+
+// NewWebFetch creates the web_fetch tool. When cfg.CacheDir is set, responses
+// are served through the shared on-disk HTTP cache. The tool is retired from
+// the core toolset and must not be re-registered as is: it forwards
+// model-supplied headers and only validates the first URL, not redirect hops
+// or the resolved IP.
+func NewWebFetch(cfg shared.WebFetchConfig) tools.ToolExecutor {
+	var cache *httpcache.Cache
+	if dir := strings.TrimSpace(cfg.CacheDir); dir != "" {
+		opened, err := httpcache.New(httpcache.Config{
+			Dir:               dir,
+			MaxBytes:          cfg.CacheMaxBytes,
+			MaxEntries:        cfg.CacheMaxEntries,
+			MaxEntryBytes:     int64(cfg.CacheMaxContentBytes),
+			DefaultTTL:        cfg.CacheTTL,
+			MaxAge:            cfg.CacheMaxAge,
+			CacheAuthRequests: cfg.CacheAuthRequests,
+		})
+		if err != nil {
+			logging.NewComponentLogger("web_fetch").Warn("Web cache disabled: %v", err)
+		} else {
+			cache = opened
+		}
+	}
+	return newWebFetch(nil, cache, cfg)
+}
+
+func newWebFetch(client *http.Client, cache *httpcache.Cache, cfg shared.WebFetchConfig) *webFetch {
+	if client == nil {
+		client = httpclient.NewWithCircuitBreaker(30*time.Second, nil, "web_fetch")
+	}
+	maxResponseBytes := cfg.MaxResponseBytes
+	if maxResponseBytes <= 0 {
+		maxResponseBytes = defaultWebFetchMaxResponseBytes
+	}
+	return &webFetch{
+		BaseTool: shared.NewBaseTool(
+			ports.ToolDefinition{
+				Name:        "web_fetch",
+				Description: `When you already have a URL → fetch the page and return its readable text. Repeated fetches are served from a shared cache when fresh; metadata reports from_cache, revalidated, and age_seconds. Use web_search first when no URL is known.`,
+				Parameters: ports.ParameterSchema{
+					Type: "object",
+					Properties: map[string]ports.Property{
+						"url": {
+							Type:        "string",
+							Description: "The http(s) URL to fetch",
+						},
+						"max_chars": {
+							Type:        "integer",
+							Description: fmt.Sprintf("Maximum characters of page text to return (default %d)", defaultWebFetchMaxChars),
+						},
+						"headers": {
+							Type:        "object",
+							Description: "Optional request headers. Requests carrying credentials (Authorization, Cookie) bypass the shared cache.",
+						},
+					},
+					Required: []string{"url"},
+				},
+			},
+			ports.ToolMetadata{
+				Name:     "web_fetch",
+				Version:  "1.0.0",
+				Category: "web",
+				Tags:     []string{"fetch", "web", "url", "docs", "reference"},
+			},
+		),
+		fetcher: httpcache.NewFetcher(client, cache, int64(maxResponseBytes)),
+	}
+}
+
+func (t *webFetch) Execute(ctx context.Context, call ports.ToolCall) (*ports.ToolResult, error) {
+	rawURL, errResult := shared.RequireStringArg(call.Arguments, call.ID, "url")
+	if errResult != nil {
+		return errResult, nil
+	}
+	opts := httpclient.DefaultURLValidationOptions()
+	if shared.AllowLocalFetch(ctx) {
+		opts.AllowLocalhost = true
+	}
+	parsed, err := httpclient.ValidateOutboundURL(rawURL, opts)
+	if err != nil {
+		return shared.ToolError(call.ID, "invalid url: %w", err)
+	}
+
+	maxChars := defaultWebFetchMaxChars
+	if n, ok := shared.IntArg(call.Arguments, "max_chars"); ok && n > 0 {
+		maxChars = min(n, maxWebFetchMaxChars)
+	}
+
+	header := http.Header{}
+	for name, value := range shared.StringMapArg(call.Arguments, "headers") {
+		header.Set(name, value)
+	}
+
+	resp, err := t.fetcher.Get(ctx, httpcache.Request{
+		URL:       parsed.String(),
+		Header:    header,
+		Partition: workspacePartition(ctx),
+	})
+	if err != nil {
+		return shared.ToolError(call.ID, "fetch failed: %w", err)
+	}
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return shared.ToolError(call.ID, "fetch failed: HTTP status %d", resp.StatusCode)
+	}
+
+	contentType := resp.Header.Get("Content-Type")
+	title, text := extractReadableText(resp.Body, contentType)
+	truncated := false
+	if utf8.RuneCountInString(text) > maxChars {
+		text = string([]rune(text)[:maxChars])
+		truncated = true
+	}
+
+	var output strings.Builder
+	output.WriteString(fmt.Sprintf("URL: %s\n", resp.URL))
+	if title != "" {
+		output.WriteString(fmt.Sprintf("Title: %s\n", title))
+	}
+	output.WriteString("\n")
+	output.WriteString(text)
+	if truncated {
+		output.WriteString("\n\n[truncated]")
+	}
+
+	return &ports.ToolResult{
+		CallID:  call.ID,
+		Content: output.String(),
+		Metadata: map[string]any{
+			"url":          resp.URL,
+			"status_code":  resp.StatusCode,
+			"content_type": contentType,
+			"title":        title,
+			"truncated":    truncated,
+			"from_cache":   resp.FromCache,
+			"revalidated":  resp.Revalidated,
+			"age_seconds":  int(resp.Age.Seconds()),
+		},
+	}, nil
+}
+
+// workspacePartition scopes auth-bearing cache entries to the task workspace.
+func workspacePartition(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	if ws, ok := pathutil.WorkspaceFromContext(ctx); ok {
+		return ws.Root
+	}
+	dir, _ := ctx.Value(pathutil.WorkingDirKey).(string)
+	return strings.TrimSpace(dir)
+}
+
+// extractReadableText returns the page title and visible text for HTML, or
+// the raw body for other text types.
+func extractReadableText(body []byte, contentType string) (string, string) {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	if mediaType != "text/html" && mediaType != "application/xhtml+xml" {
+		return "", strings.TrimSpace(string(body))
+	}
+	doc, err := html.Parse(bytes.NewReader(body))
+	if err != nil {
+		return "", strings.TrimSpace(string(body))
+	}
+
+	var title string
+	var output strings.Builder
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.ElementNode {
+			switch n.Data {
+			case "script", "style", "noscript", "svg", "template":
+				return
+			case "title":
+				if title == "" {
+					title = strings.TrimSpace(textContent(n))
+				}
+				return
+			}
+		}
+		if n.Type == html.TextNode {
+			if trimmed := strings.Join(strings.Fields(n.Data), " "); trimmed != "" {
+				output.WriteString(trimmed)
+				output.WriteString(" ")
+			}
+		}
+		for child := n.FirstChild; child != nil; child = child.NextSibling {
+			walk(child)
+		}
+		if n.Type == html.ElementNode && isBlockElement(n.Data) {
+			output.WriteString("\n")
+		}
+	}
+	walk(doc)
+	return title, collapseBlankLines(output.String())
+}
+
+func isBlockElement(tag string) bool {
+	switch tag {
+	case "p", "div", "section", "article", "header", "footer", "li", "ul", "ol", "br",
+		"h1", "h2", "h3", "h4", "h5", "h6", "pre", "table", "tr", "blockquote":
+		return true
+	}
+	return false
+}
+
+func collapseBlankLines(text string) string {
+	lines := strings.Split(text, "\n")
+	kept := make([]string, 0, len(lines))
+	for _, line := range lines {
+		if trimmed := strings.TrimSpace(line); trimmed != "" {
+			kept = append(kept, trimmed)
+		}
+	}
+	return strings.Join(kept, "\n")
+}
@@ -0,0 +1,74 @@
+package web
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"alex/internal/domain/agent/ports"
+	"alex/internal/infra/httpcache"
+	"alex/internal/infra/tools/builtin/shared"
+)
+
+func TestWebFetchExtractsTextAndReportsCacheFreshness(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Header().Set("Cache-Control", "max-age=300")
+		w.Header().Set("ETag", `"doc-1"`)
+		_, _ = w.Write([]byte(`<html><head><title>Guide</title><style>.x{}</style></head>
+<body><h1>Install</h1><p>Run the installer.</p><script>track()</script></body></html>`))
+	}))
+	defer server.Close()
+
+	cache, err := httpcache.New(httpcache.Config{Dir: t.TempDir()})
+	if err != nil {
+		t.Fatalf("httpcache.New: %v", err)
+	}
+	tool := newWebFetch(server.Client(), cache, shared.WebFetchConfig{})
+	ctx := shared.WithAllowLocalFetch(context.Background())
+	call := ports.ToolCall{ID: "call-1", Arguments: map[string]any{"url": server.URL + "/guide"}}
+
+	first, err := tool.Execute(ctx, call)
+	if err != nil || first.Error != nil {
+		t.Fatalf("Execute: %v / %v", err, first.Error)
+	}
+	if !strings.Contains(first.Content, "Title: Guide") || !strings.Contains(first.Content, "Install\nRun the installer.") {
+		t.Fatalf("unexpected content: %q", first.Content)
+	}
+	if strings.Contains(first.Content, "track()") || strings.Contains(first.Content, ".x{}") {
+		t.Fatalf("script/style leaked into content: %q", first.Content)
+	}
+	if first.Metadata["from_cache"] != false {
+		t.Fatalf("expected first fetch from origin, got %v", first.Metadata)
+	}
+
+	second, err := tool.Execute(ctx, call)
+	if err != nil || second.Error != nil {
+		t.Fatalf("Execute: %v / %v", err, second.Error)
+	}
+	if second.Metadata["from_cache"] != true || second.Metadata["revalidated"] != false {
+		t.Fatalf("expected fresh cache hit, got %v", second.Metadata)
+	}
+	if second.Content != first.Content {
+		t.Fatalf("cached content differs:\n%s\nvs\n%s", second.Content, first.Content)
+	}
+}
+
+func TestWebFetchRejectsLocalURLsAndErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+	tool := newWebFetch(server.Client(), nil, shared.WebFetchConfig{})
+
+	result, _ := tool.Execute(context.Background(), ports.ToolCall{ID: "c", Arguments: map[string]any{"url": server.URL}})
+	if result.Error == nil || !strings.Contains(result.Content, "local urls are not allowed") {
+		t.Fatalf("expected local URL rejection, got %q", result.Content)
+	}
+
+	ctx := shared.WithAllowLocalFetch(context.Background())
+	result, _ = tool.Execute(ctx, ports.ToolCall{ID: "c", Arguments: map[string]any{"url": server.URL}})
+	if result.Error == nil || !strings.Contains(result.Content, "HTTP status 404") {
+		t.Fatalf("expected status error, got %q", result.Content)
+	}
+}
@@ -207,6 +207,9 @@ func hardcodedPolicyRules() []PolicyRule {
 		InitialBackoff: 2 * time.Second,
 		MaxBackoff:     30 * time.Second,
 		BackoffFactor:  2.0,
+		Categories: map[string]ToolRetryConfig{
+			"rate_limit": {MaxRetries: 2, InitialBackoff: 10 * time.Second},
+		},
 	}
 
 	mediaRetry := ToolRetryConfig{
@@ -311,7 +314,9 @@ func (p *configToolPolicy) RetryConfigFor(toolName string, dangerous bool) ToolR
 }
 
 // Resolve evaluates rules in order against the provided context.
-// The first matching rule's non-nil fields override the defaults.
+// The first matching rule's non-nil fields override the defaults. Timeout
+// and retry then layer as: global default < matching rule (category
+// defaults) < tool metadata < runtime per-tool timeout config.
 func (p *configToolPolicy) Resolve(ctx ToolCallContext) ResolvedPolicy {
 	result := ResolvedPolicy{
 		Timeout:         p.TimeoutFor(ctx.ToolName),
@@ -345,6 +350,16 @@ func (p *configToolPolicy) Resolve(ctx ToolCallContext) ResolvedPolicy {
 		}
 	}
 
+	if ctx.Timeout > 0 {
+		result.Timeout = ctx.Timeout
+	}
+	if ctx.MaxRetries != nil && *ctx.MaxRetries >= 0 {
+		result.Retry.MaxRetries = *ctx.MaxRetries
+	}
+	if d, ok := p.cfg.Timeout.PerTool[ctx.ToolName]; ok && d > 0 {
+		result.Timeout = d
+	}
+
 	return result
 }
 
@@ -259,6 +259,27 @@ func TestResolve_FieldLevelOverrides(t *testing.T) {
 	}
 }
 
+func TestResolve_ToolMetadataAndPerToolConfigPrecedence(t *testing.T) {
+	cfg := DefaultToolPolicyConfigWithRules()
+	cfg.Timeout.PerTool["web_fetch"] = 15 * time.Second
+	p := NewToolPolicy(cfg)
+
+	// Metadata overrides the web category default of 60s and 3 retries.
+	result := p.Resolve(ToolCallContext{ToolName: "web_search", Category: "web", Timeout: 20 * time.Second, MaxRetries: ptr(1)})
+	if result.Timeout != 20*time.Second || result.Retry.MaxRetries != 1 {
+		t.Fatalf("metadata override: timeout=%v retries=%d, want 20s/1", result.Timeout, result.Retry.MaxRetries)
+	}
+
+	// Runtime per-tool config wins over both the rule and the metadata.
+	result = p.Resolve(ToolCallContext{ToolName: "web_fetch", Category: "web", Timeout: 20 * time.Second})
+	if result.Timeout != 15*time.Second {
+		t.Fatalf("per-tool config: timeout=%v, want 15s", result.Timeout)
+	}
+	if result.Retry.MaxRetries != 3 {
+		t.Fatalf("per-tool config: retries=%d, want web default 3", result.Retry.MaxRetries)
+	}
+}
+
 func TestResolve_ANDLogicAcrossFields(t *testing.T) {
 	timeout := 5 * time.Second
 	cfg := DefaultToolPolicyConfig()
@@ -28,6 +28,8 @@ type ToolSLA struct {
 	SuccessRate  float64
 	CostUSDTotal float64
 	CostUSDAvg   float64
+	// Retries is the cumulative number of automatic retry attempts.
+	Retries int64
 }
 
 // SLACollector records per-tool latency, error rate, and call count via
@@ -38,6 +40,7 @@ type SLACollector struct {
 	toolErrors      *prometheus.CounterVec
 	toolCalls       *prometheus.CounterVec
 	toolSuccessRate *prometheus.GaugeVec
+	toolRetries     *prometheus.CounterVec
 
 	mu      sync.RWMutex
 	windows map[string]*slidingWindow
@@ -52,6 +55,7 @@ type slidingWindow struct {
 	full      bool
 	total     int64
 	costTotal float64
+	retries   int64
 }
 
 func newSlidingWindow(size int) *slidingWindow {
@@ -152,6 +156,13 @@ func NewSLACollector(registerer prometheus.Registerer) (*SLACollector, error) {
 		Help:      "Sliding window success rate per tool (last 100 calls).",
 	}, []string{"tool_name"})
 
+	toolRetries := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "alex",
+		Subsystem: "tool_sla",
+		Name:      "retries_total",
+		Help:      "Automatic retry attempts after transient failures, partitioned by tool name, error category, and final outcome.",
+	}, []string{"tool_name", "category", "outcome"})
+
 	var err error
 	toolLatency, err = registerCollectorVec(registerer, toolLatency, "histogram")
 	if err != nil {
@@ -169,12 +180,17 @@ func NewSLACollector(registerer prometheus.Registerer) (*SLACollector, error) {
 	if err != nil {
 		return nil, fmt.Errorf("register tool_sla success_rate: %w", err)
 	}
+	toolRetries, err = registerCollectorVec(registerer, toolRetries, "counter")
+	if err != nil {
+		return nil, fmt.Errorf("register tool_sla retries: %w", err)
+	}
 
 	return &SLACollector{
 		toolLatency:     toolLatency,
 		toolErrors:      toolErrors,
 		toolCalls:       toolCalls,
 		toolSuccessRate: toolSuccessRate,
+		toolRetries:     toolRetries,
 		windows:         make(map[string]*slidingWindow),
 	}, nil
 }
@@ -220,6 +236,30 @@ func (c *SLACollector) RecordExecutionWithCost(toolName string, duration time.Du
 	c.toolSuccessRate.WithLabelValues(toolName).Set(rate)
 }
 
+// RecordRetries records the automatic retries spent on one tool call.
+// category is the transient error category of the last retried failure and
+// recovered reports whether a retry eventually succeeded. It is safe to call
+// on a nil receiver (no-op).
+func (c *SLACollector) RecordRetries(toolName, category string, retries int, recovered bool) {
+	if c == nil || retries <= 0 {
+		return
+	}
+	outcome := "exhausted"
+	if recovered {
+		outcome = "recovered"
+	}
+	c.toolRetries.WithLabelValues(toolName, category, outcome).Add(float64(retries))
+
+	c.mu.Lock()
+	w, ok := c.windows[toolName]
+	if !ok {
+		w = newSlidingWindow(slidingWindowSize)
+		c.windows[toolName] = w
+	}
+	w.retries += int64(retries)
+	c.mu.Unlock()
+}
+
 // GetSLA returns a point-in-time SLA snapshot for the named tool.
 // It is safe to call on a nil receiver (returns zero-value ToolSLA).
 func (c *SLACollector) GetSLA(toolName string) ToolSLA {
@@ -245,6 +285,7 @@ func (c *SLACollector) GetSLA(toolName string) ToolSLA {
 		SuccessRate:  w.successRate(),
 		CostUSDTotal: w.costTotal,
 		CostUSDAvg:   averageCostUSD(w.total, w.costTotal),
+		Retries:      w.retries,
 	}
 }
 
@@ -120,6 +120,20 @@ func TestSLACollector_RecordExecutionWithCost(t *testing.T) {
 	}
 }
 
+func TestSLACollector_RecordRetries(t *testing.T) {
+	c := newTestCollector(t)
+
+	c.RecordRetries("web_fetch", "rate_limit", 2, true)
+	c.RecordRetries("web_fetch", "network", 1, false)
+	c.RecordRetries("web_fetch", "network", 0, false)
+
+	if got := c.GetSLA("web_fetch").Retries; got != 3 {
+		t.Fatalf("expected Retries=3, got %d", got)
+	}
+	var nilCollector *SLACollector
+	nilCollector.RecordRetries("web_fetch", "timeout", 1, true)
+}
+
 // --- TestSLACollector_GetSLA ------------------------------------------------
 
 func TestSLACollector_GetSLA(t *testing.T) {
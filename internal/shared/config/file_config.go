@@ -88,6 +88,19 @@ type RuntimeFileConfig struct {
 	HTTPLimits     *HTTPLimitsFileConfig     `yaml:"http_limits"`
 	Proactive      *ProactiveFileConfig      `yaml:"proactive"`
 	ExternalAgents *ExternalAgentsFileConfig `yaml:"external_agents"`
+
+	LLMFallbackRules     []LLMFallbackRuleConfig     `yaml:"llm_fallback_rules"`
+	LLMFallbackProviders []LLMFallbackProviderConfig `yaml:"llm_fallback_providers"`
+	LLMResponseCache     *LLMResponseCacheFileConfig `yaml:"llm_response_cache"`
+}
+
+// LLMResponseCacheFileConfig mirrors LLMResponseCacheConfig for YAML decoding.
+type LLMResponseCacheFileConfig struct {
+	Enabled    *bool  `yaml:"enabled"`
+	TTLSeconds *int   `yaml:"ttl_seconds"`
+	MaxBytes   *int64 `yaml:"max_bytes"`
+	MaxEntries *int   `yaml:"max_entries"`
+	ForceCache *bool  `yaml:"force_cache"`
 }
 
 // RuntimeBrowserConfig captures local browser settings in YAML (runtime section).
@@ -118,10 +131,11 @@ type ToolTimeoutFileConfig struct {
 
 // ToolRetryFileConfig mirrors ToolRetryConfig for YAML decoding.
 type ToolRetryFileConfig struct {
-	MaxRetries     *int           `yaml:"max_retries"`
-	InitialBackoff *time.Duration `yaml:"initial_backoff"`
-	MaxBackoff     *time.Duration `yaml:"max_backoff"`
-	BackoffFactor  *float64       `yaml:"backoff_factor"`
+	MaxRetries     *int                                   `yaml:"max_retries"`
+	InitialBackoff *time.Duration                         `yaml:"initial_backoff"`
+	MaxBackoff     *time.Duration                         `yaml:"max_backoff"`
+	BackoffFactor  *float64                               `yaml:"backoff_factor"`
+	Categories     map[string]toolspolicy.ToolRetryConfig `yaml:"categories"`
 }
 
 // HTTPLimitsFileConfig mirrors HTTPLimitsConfig for YAML decoding.
@@ -227,10 +241,12 @@ type OKRFileConfig struct {
 }
 
 type MemoryFileConfig struct {
-	Enabled          *bool                  `yaml:"enabled"`
-	Index            *MemoryIndexFileConfig `yaml:"index"`
-	ArchiveAfterDays *int                   `yaml:"archive_after_days"`
-	CleanupInterval  string                 `yaml:"cleanup_interval"`
+	Enabled            *bool                  `yaml:"enabled"`
+	Index              *MemoryIndexFileConfig `yaml:"index"`
+	ArchiveAfterDays   *int                   `yaml:"archive_after_days"`
+	CleanupInterval    string                 `yaml:"cleanup_interval"`
+	MaxEntries         *int                   `yaml:"max_entries"`
+	DuplicateThreshold *float64               `yaml:"duplicate_threshold"`
 }
 
 type MemoryIndexFileConfig struct {
@@ -242,6 +258,9 @@ type MemoryIndexFileConfig struct {
 	FusionWeightVector *float64 `yaml:"fusion_weight_vector"`
 	FusionWeightBM25   *float64 `yaml:"fusion_weight_bm25"`
 	EmbedderModel      string   `yaml:"embedder_model"`
+	EmbedderBaseURL    string   `yaml:"embedder_base_url"`
+	EmbedderAPIKey     string   `yaml:"embedder_api_key"`
+	EmbedderBatchSize  *int     `yaml:"embedder_batch_size"`
 }
 
 type SkillsFileConfig struct {
@@ -286,11 +305,15 @@ type SchedulerFileConfig struct {
 	MaxConcurrent                    *int                         `yaml:"max_concurrent"`
 	RecoveryMaxRetries               *int                         `yaml:"recovery_max_retries"`
 	RecoveryBackoffSeconds           *int                         `yaml:"recovery_backoff_seconds"`
+	HistoryMaxRecords                *int                         `yaml:"history_max_records"`
+	FailureAlertThreshold            *int                         `yaml:"failure_alert_threshold"`
+	FailureAlertWebhook              string                       `yaml:"failure_alert_webhook"`
 }
 
 type SchedulerTriggerFileConfig struct {
 	Name     string `yaml:"name"`
 	Schedule string `yaml:"schedule"`
+	Timezone string `yaml:"timezone"`
 	Task     string `yaml:"task"`
 	Channel  string `yaml:"channel"`
 	UserID   string `yaml:"user_id"`
@@ -362,12 +385,15 @@ type ScopeWatchFileConfig struct {
 }
 
 type TimerFileConfig struct {
-	Enabled            *bool  `yaml:"enabled"`
-	StorePath          string `yaml:"store_path"`
-	MaxTimers          *int   `yaml:"max_timers"`
-	TaskTimeoutSeconds *int   `yaml:"task_timeout_seconds"`
-	HeartbeatEnabled   *bool  `yaml:"heartbeat_enabled"`
-	HeartbeatMinutes   *int   `yaml:"heartbeat_minutes"`
+	Enabled               *bool  `yaml:"enabled"`
+	StorePath             string `yaml:"store_path"`
+	MaxTimers             *int   `yaml:"max_timers"`
+	TaskTimeoutSeconds    *int   `yaml:"task_timeout_seconds"`
+	HeartbeatEnabled      *bool  `yaml:"heartbeat_enabled"`
+	HeartbeatMinutes      *int   `yaml:"heartbeat_minutes"`
+	HistoryMaxRecords     *int   `yaml:"history_max_records"`
+	FailureAlertThreshold *int   `yaml:"failure_alert_threshold"`
+	FailureAlertWebhook   string `yaml:"failure_alert_webhook"`
 }
 
 type AttentionFileConfig struct {
@@ -441,16 +467,25 @@ type TelegramPersistenceConfig struct {
 type LarkChannelConfig struct {
 	AppID                       string                 `json:"app_id" yaml:"app_id"`
 	AppSecret                   string                 `json:"app_secret" yaml:"app_secret"`
+	VerificationToken           string                 `json:"verification_token" yaml:"verification_token"`
+	EncryptKey                  string                 `json:"encrypt_key" yaml:"encrypt_key"`
 	TenantCalendarID            string                 `json:"tenant_calendar_id" yaml:"tenant_calendar_id"`
 	BaseDomain                  string                 `json:"base_domain" yaml:"base_domain"`
 	WorkspaceDir                string                 `json:"workspace_dir" yaml:"workspace_dir"`
 	AutoUploadFiles             *bool                  `json:"auto_upload_files" yaml:"auto_upload_files"`
 	AutoUploadMaxBytes          *int                   `json:"auto_upload_max_bytes" yaml:"auto_upload_max_bytes"`
 	AutoUploadAllowExt          []string               `json:"auto_upload_allow_ext" yaml:"auto_upload_allow_ext"`
+	// Attachment batching: inline image count, post-embedded image count (beyond it images are zipped), and inline image size cap.
+	AutoUploadInlineImageLimit    *int                   `json:"auto_upload_inline_image_limit,omitempty" yaml:"auto_upload_inline_image_limit"`
+	AutoUploadPostImageLimit      *int                   `json:"auto_upload_post_image_limit,omitempty" yaml:"auto_upload_post_image_limit"`
+	AutoUploadInlineImageMaxBytes *int                   `json:"auto_upload_inline_image_max_bytes,omitempty" yaml:"auto_upload_inline_image_max_bytes"`
 	Browser                     *LarkBrowserConfig     `json:"browser" yaml:"browser"`
 	ToolMode                    string                 `json:"tool_mode" yaml:"tool_mode"`
 	InjectionAckReactEmoji      string                 `json:"injection_ack_react_emoji" yaml:"injection_ack_react_emoji"`
 	ShowPlanClarifyMessages     *bool                  `json:"show_plan_clarify_messages" yaml:"show_plan_clarify_messages"`
+	ProgressEditInPlace         *bool                  `json:"progress_edit_in_place" yaml:"progress_edit_in_place"`
+	ProgressUpdateIntervalMs    *int                   `json:"progress_update_interval_ms" yaml:"progress_update_interval_ms"`
+	ShowCost                    *bool                  `json:"show_cost" yaml:"show_cost"`
 	ToolFailureAbortThreshold   *int                   `json:"tool_failure_abort_threshold" yaml:"tool_failure_abort_threshold"`
 	AutoChatContextSize         *int                   `json:"auto_chat_context_size" yaml:"auto_chat_context_size"`
 	PendingInputRelayTTLMinutes *int                   `json:"pending_input_relay_ttl_minutes" yaml:"pending_input_relay_ttl_minutes"`
@@ -471,8 +506,22 @@ type LarkChannelConfig struct {
 	ConversationProcessEnabled *bool `json:"conversation_process_enabled" yaml:"conversation_process_enabled"`
 	// MaxConcurrentWorkers is the max simultaneous background workers per chat in conversation-process mode.
 	MaxConcurrentWorkers *int `json:"max_concurrent_workers,omitempty" yaml:"max_concurrent_workers"`
+	// FollowUpQueueDepth is the max follow-up messages queued per chat while a task runs.
+	FollowUpQueueDepth *int `json:"follow_up_queue_depth,omitempty" yaml:"follow_up_queue_depth"`
+	// AwaitInputTimeoutSeconds is the default wait for a user reply when ask_user declares no timeout.
+	AwaitInputTimeoutSeconds *int `json:"await_input_timeout_seconds,omitempty" yaml:"await_input_timeout_seconds"`
+	// RequireMention restricts group chats to messages that @-mention the bot; replies are threaded.
+	RequireMention *bool `json:"require_mention,omitempty" yaml:"require_mention"`
+	// BotOpenID is the bot's own open_id, used to recognise @-mentions of the bot.
+	BotOpenID string `json:"bot_open_id,omitempty" yaml:"bot_open_id"`
+	// PresetAdminRoles lists the group roles (owner, manager, member) allowed to switch a group chat's /preset.
+	PresetAdminRoles []string `json:"preset_admin_roles,omitempty" yaml:"preset_admin_roles"`
 	// ConversationWorkerCapabilities overrides the auto-detected skills catalog injected into the conversation router prompt.
 	ConversationWorkerCapabilities *string `json:"conversation_worker_capabilities,omitempty" yaml:"conversation_worker_capabilities"`
+	// TaskDigest replaces per-task background completion messages with a scheduled digest.
+	TaskDigest        *LarkTaskDigestConfig `json:"task_digest,omitempty" yaml:"task_digest"`
+	// SessionRotation starts a fresh chat session once the bound one exceeds size or age limits.
+	SessionRotation *LarkSessionRotationConfig `json:"session_rotation,omitempty" yaml:"session_rotation"`
 	BaseChannelConfig              `json:",inline" yaml:",inline"`
 }
 
@@ -501,6 +550,21 @@ type LarkDeliveryWorkerConfig struct {
 	JitterRatio    *float64 `json:"jitter_ratio" yaml:"jitter_ratio"`
 }
 
+// LarkTaskDigestConfig captures the scheduled background task digest settings.
+type LarkTaskDigestConfig struct {
+	Enabled *bool  `json:"enabled" yaml:"enabled"`
+	Cron    string `json:"cron" yaml:"cron"`
+	ChatID  string `json:"chat_id" yaml:"chat_id"`
+}
+
+// LarkSessionRotationConfig captures automatic chat session rotation limits.
+type LarkSessionRotationConfig struct {
+	MaxMessages     *int `json:"max_messages" yaml:"max_messages"`
+	MaxTokens       *int `json:"max_tokens" yaml:"max_tokens"`
+	MaxAgeHours     *int `json:"max_age_hours" yaml:"max_age_hours"`
+	SummaryMaxRunes *int `json:"summary_max_runes" yaml:"summary_max_runes"`
+}
+
 // LarkRateLimiterConfig captures per-chat and per-user notification rate limits.
 type LarkRateLimiterConfig struct {
 	Enabled         *bool `json:"enabled" yaml:"enabled"`
@@ -529,18 +593,37 @@ type ServerConfig struct {
 	StreamMaxConcurrent                    *int     `yaml:"stream_max_concurrent"`
 	RateLimitRequestsPerMinute             *int     `yaml:"rate_limit_requests_per_minute"`
 	RateLimitBurst                         *int     `yaml:"rate_limit_burst"`
+	RateLimitTaskRequestsPerMinute         *int     `yaml:"rate_limit_task_requests_per_minute"`
+	RateLimitTaskBurst                     *int     `yaml:"rate_limit_task_burst"`
+	RateLimitExemptPaths                   []string `yaml:"rate_limit_exempt_paths"`
+	RateLimitRedisURL                      string   `yaml:"rate_limit_redis_url"`
 	NonStreamTimeoutSeconds                *int     `yaml:"non_stream_timeout_seconds"`
 	TaskExecutionOwnerID                   string   `yaml:"task_execution_owner_id"`
 	TaskExecutionLeaseTTLSeconds           *int     `yaml:"task_execution_lease_ttl_seconds"`
 	TaskExecutionLeaseRenewIntervalSeconds *int     `yaml:"task_execution_lease_renew_interval_seconds"`
 	TaskExecutionMaxInFlight               *int     `yaml:"task_execution_max_in_flight"`
+	TaskExecutionMaxQueued                 *int     `yaml:"task_execution_max_queued"`
 	TaskExecutionResumeClaimBatchSize      *int     `yaml:"task_execution_resume_claim_batch_size"`
 	EventHistoryRetentionDays              *int     `yaml:"event_history_retention_days"`
 	EventHistoryMaxSessions                *int     `yaml:"event_history_max_sessions"`
 	EventHistorySessionTTL                 *int     `yaml:"event_history_session_ttl_seconds"`
 	EventHistoryMaxEvents                  *int     `yaml:"event_history_max_events"`
+	EventHistorySegmentMaxMB               *int     `yaml:"event_history_segment_max_mb"`
 	LeaderAPIToken                         string   `yaml:"leader_api_token"`
 	TrustedProxies                         []string `yaml:"trusted_proxies"`
+	NotificationTypes                      []string `yaml:"notification_types"`
+	NotificationMaxPerUser                 *int     `yaml:"notification_max_per_user"`
+	MaintenanceNoticeLeadSeconds           *int     `yaml:"maintenance_notice_lead_seconds"`
+	DiagnosticsHistorySize                 *int     `yaml:"diagnostics_history_size"`
+	RequiredComponents                     []string `yaml:"required_components"`
+	OptionalComponents                     []string `yaml:"optional_components"`
+	WorkspaceMode                          string   `yaml:"workspace_mode"`
+	WorkspaceRoot                          string   `yaml:"workspace_root"`
+	WorkspaceQuotaBytes                    *int64   `yaml:"workspace_quota_bytes"`
+
+	// HealthDegradedThresholdsMS maps a /health component name to the check
+	// latency above which a ready component is reported as degraded.
+	HealthDegradedThresholdsMS map[string]int `yaml:"health_degraded_thresholds_ms"`
 }
 
 // AgentConfig captures agent-level behavioral settings.
@@ -557,6 +640,10 @@ type SessionConfig struct {
 type AnalyticsConfig struct {
 	PostHogAPIKey string `yaml:"posthog_api_key"`
 	PostHogHost   string `yaml:"posthog_host"`
+	// SpoolDir holds batches that could not be delivered; defaults to
+	// ~/.alex/analytics/spool.
+	SpoolDir      string `yaml:"spool_dir"`
+	SpoolMaxBytes int64  `yaml:"spool_max_bytes"`
 }
 
 // AttachmentsConfig captures attachment store configuration in YAML.
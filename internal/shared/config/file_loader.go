@@ -82,6 +82,7 @@ func expandFileConfigEnv(lookup EnvLookup, parsed FileConfig) FileConfig {
 	if parsed.Analytics != nil {
 		parsed.Analytics.PostHogAPIKey = expandEnvValue(lookup, parsed.Analytics.PostHogAPIKey)
 		parsed.Analytics.PostHogHost = expandEnvValue(lookup, parsed.Analytics.PostHogHost)
+		parsed.Analytics.SpoolDir = expandEnvValue(lookup, parsed.Analytics.SpoolDir)
 	}
 	if parsed.Attachments != nil {
 		parsed.Attachments.Provider = expandEnvValue(lookup, parsed.Attachments.Provider)
@@ -112,6 +113,8 @@ func expandChannelsConfigEnv(lookup EnvLookup, parsed ChannelsConfig) ChannelsCo
 	expanded := *parsed.Lark
 	expanded.AppID = expandEnvValue(lookup, expanded.AppID)
 	expanded.AppSecret = expandEnvValue(lookup, expanded.AppSecret)
+	expanded.VerificationToken = expandEnvValue(lookup, expanded.VerificationToken)
+	expanded.EncryptKey = expandEnvValue(lookup, expanded.EncryptKey)
 	expanded.TenantCalendarID = expandEnvValue(lookup, expanded.TenantCalendarID)
 	expanded.BaseDomain = expandEnvValue(lookup, expanded.BaseDomain)
 	expanded.WorkspaceDir = expandEnvValue(lookup, expanded.WorkspaceDir)
@@ -293,6 +293,11 @@ func normalizeProactiveConfig(cfg *ProactiveConfig) {
 	}
 	cfg.Memory.Index.DBPath = strings.TrimSpace(cfg.Memory.Index.DBPath)
 	cfg.Memory.Index.EmbedderModel = strings.TrimSpace(cfg.Memory.Index.EmbedderModel)
+	cfg.Memory.Index.EmbedderBaseURL = strings.TrimSpace(cfg.Memory.Index.EmbedderBaseURL)
+	cfg.Memory.Index.EmbedderAPIKey = strings.TrimSpace(cfg.Memory.Index.EmbedderAPIKey)
+	if cfg.Memory.Index.EmbedderBatchSize <= 0 {
+		cfg.Memory.Index.EmbedderBatchSize = 32
+	}
 	if cfg.Memory.Index.ChunkTokens <= 0 {
 		cfg.Memory.Index.ChunkTokens = 400
 	}
@@ -359,6 +364,13 @@ func normalizeProactiveConfig(cfg *ProactiveConfig) {
 	if cfg.Scheduler.RecoveryBackoffSeconds <= 0 {
 		cfg.Scheduler.RecoveryBackoffSeconds = 60
 	}
+	if cfg.Scheduler.HistoryMaxRecords <= 0 {
+		cfg.Scheduler.HistoryMaxRecords = 100
+	}
+	if cfg.Scheduler.FailureAlertThreshold < 0 {
+		cfg.Scheduler.FailureAlertThreshold = 0
+	}
+	cfg.Scheduler.FailureAlertWebhook = strings.TrimSpace(cfg.Scheduler.FailureAlertWebhook)
 	cfg.Scheduler.Heartbeat.Schedule = strings.TrimSpace(cfg.Scheduler.Heartbeat.Schedule)
 	if cfg.Scheduler.Heartbeat.Schedule == "" {
 		cfg.Scheduler.Heartbeat.Schedule = "*/30 * * * *"
@@ -381,6 +393,13 @@ func normalizeProactiveConfig(cfg *ProactiveConfig) {
 	if cfg.Timer.HeartbeatMinutes <= 0 {
 		cfg.Timer.HeartbeatMinutes = 30
 	}
+	if cfg.Timer.HistoryMaxRecords <= 0 {
+		cfg.Timer.HistoryMaxRecords = 100
+	}
+	if cfg.Timer.FailureAlertThreshold < 0 {
+		cfg.Timer.FailureAlertThreshold = 0
+	}
+	cfg.Timer.FailureAlertWebhook = strings.TrimSpace(cfg.Timer.FailureAlertWebhook)
 }
 
 func shouldLoadCLICredentials(cfg RuntimeConfig) bool {
@@ -371,6 +371,73 @@ runtime:
 	}
 }
 
+func TestLoadLLMFallbackChainFromFile(t *testing.T) {
+	fileData := []byte(`
+runtime:
+  llm_fallback_rules:
+    - model: "claude-sonnet-4-6"
+      fallback_provider: "openai"
+      fallback_model: "gpt-4o"
+      fallback_api_key: "${FALLBACK_KEY}"
+  llm_fallback_providers:
+    - provider: "deepseek"
+      model: "deepseek-chat"
+      api_key: "${DEEPSEEK_KEY}"
+    - provider: "openrouter"
+      base_url: "https://openrouter.ai/api/v1"
+      models:
+        claude-sonnet-4-6: "anthropic/claude-sonnet-4.6"
+`)
+	cfg, meta, err := Load(
+		WithFileReader(func(string) ([]byte, error) { return fileData, nil }),
+		WithEnv(envMap{"FALLBACK_KEY": "fb-key", "DEEPSEEK_KEY": "ds-key"}.Lookup),
+	)
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if len(cfg.LLMFallbackRules) != 1 || cfg.LLMFallbackRules[0].FallbackAPIKey != "fb-key" {
+		t.Fatalf("expected interpolated fallback rule, got %+v", cfg.LLMFallbackRules)
+	}
+	if len(cfg.LLMFallbackProviders) != 2 {
+		t.Fatalf("expected 2 fallback providers, got %+v", cfg.LLMFallbackProviders)
+	}
+	first, second := cfg.LLMFallbackProviders[0], cfg.LLMFallbackProviders[1]
+	if first.Provider != "deepseek" || first.Model != "deepseek-chat" || first.APIKey != "ds-key" {
+		t.Fatalf("unexpected first fallback provider: %+v", first)
+	}
+	if second.Provider != "openrouter" || second.Models["claude-sonnet-4-6"] != "anthropic/claude-sonnet-4.6" {
+		t.Fatalf("unexpected second fallback provider: %+v", second)
+	}
+	if meta.Source("llm_fallback_providers") != SourceFile {
+		t.Fatalf("expected file source for llm_fallback_providers, got %s", meta.Source("llm_fallback_providers"))
+	}
+}
+
+func TestLoadLLMResponseCacheFromFile(t *testing.T) {
+	fileData := []byte(`
+runtime:
+  llm_response_cache:
+    enabled: true
+    ttl_seconds: 3600
+    max_bytes: 1048576
+    force_cache: true
+`)
+	cfg, meta, err := Load(
+		WithFileReader(func(string) ([]byte, error) { return fileData, nil }),
+		WithEnv(envMap{}.Lookup),
+	)
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	want := LLMResponseCacheConfig{Enabled: true, TTLSeconds: 3600, MaxBytes: 1 << 20, ForceCache: true}
+	if cfg.LLMResponseCache != want {
+		t.Fatalf("expected %+v, got %+v", want, cfg.LLMResponseCache)
+	}
+	if meta.Source("llm_response_cache.enabled") != SourceFile {
+		t.Fatalf("expected file source for llm_response_cache.enabled, got %s", meta.Source("llm_response_cache.enabled"))
+	}
+}
+
 func TestAutoProviderResolvesFromEnv(t *testing.T) {
 	fileData := []byte(`
 runtime:
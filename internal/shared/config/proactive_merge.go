@@ -90,6 +90,12 @@ func mergeMemoryConfig(target *MemoryConfig, file *MemoryFileConfig) {
 	if utils.HasContent(file.CleanupInterval) {
 		target.CleanupInterval = strings.TrimSpace(file.CleanupInterval)
 	}
+	if file.MaxEntries != nil {
+		target.MaxEntries = *file.MaxEntries
+	}
+	if file.DuplicateThreshold != nil {
+		target.DuplicateThreshold = *file.DuplicateThreshold
+	}
 }
 
 func mergeMemoryIndexConfig(target *MemoryIndexConfig, file *MemoryIndexFileConfig) {
@@ -120,6 +126,15 @@ func mergeMemoryIndexConfig(target *MemoryIndexConfig, file *MemoryIndexFileConf
 	if utils.HasContent(file.EmbedderModel) {
 		target.EmbedderModel = strings.TrimSpace(file.EmbedderModel)
 	}
+	if utils.HasContent(file.EmbedderBaseURL) {
+		target.EmbedderBaseURL = strings.TrimSpace(file.EmbedderBaseURL)
+	}
+	if utils.HasContent(file.EmbedderAPIKey) {
+		target.EmbedderAPIKey = strings.TrimSpace(file.EmbedderAPIKey)
+	}
+	if file.EmbedderBatchSize != nil {
+		target.EmbedderBatchSize = *file.EmbedderBatchSize
+	}
 }
 
 func mergeSkillsConfig(target *SkillsConfig, file *SkillsFileConfig) {
@@ -216,6 +231,15 @@ func mergeSchedulerConfig(target *SchedulerConfig, file *SchedulerFileConfig) {
 	if file.RecoveryBackoffSeconds != nil {
 		target.RecoveryBackoffSeconds = *file.RecoveryBackoffSeconds
 	}
+	if file.HistoryMaxRecords != nil {
+		target.HistoryMaxRecords = *file.HistoryMaxRecords
+	}
+	if file.FailureAlertThreshold != nil {
+		target.FailureAlertThreshold = *file.FailureAlertThreshold
+	}
+	if utils.HasContent(file.FailureAlertWebhook) {
+		target.FailureAlertWebhook = strings.TrimSpace(file.FailureAlertWebhook)
+	}
 	if file.CalendarReminder != nil {
 		mergeCalendarReminderConfig(&target.CalendarReminder, file.CalendarReminder)
 	}
@@ -243,6 +267,7 @@ func mergeSchedulerConfig(target *SchedulerConfig, file *SchedulerFileConfig) {
 			cfg := SchedulerTriggerConfig{
 				Name:     strings.TrimSpace(trigger.Name),
 				Schedule: strings.TrimSpace(trigger.Schedule),
+				Timezone: strings.TrimSpace(trigger.Timezone),
 				Task:     strings.TrimSpace(trigger.Task),
 				Channel:  strings.TrimSpace(trigger.Channel),
 				UserID:   strings.TrimSpace(trigger.UserID),
@@ -300,6 +325,15 @@ func mergeTimerConfig(target *TimerConfig, file *TimerFileConfig) {
 	if file.TaskTimeoutSeconds != nil {
 		target.TaskTimeoutSeconds = *file.TaskTimeoutSeconds
 	}
+	if file.HistoryMaxRecords != nil {
+		target.HistoryMaxRecords = *file.HistoryMaxRecords
+	}
+	if file.FailureAlertThreshold != nil {
+		target.FailureAlertThreshold = *file.FailureAlertThreshold
+	}
+	if utils.HasContent(file.FailureAlertWebhook) {
+		target.FailureAlertWebhook = strings.TrimSpace(file.FailureAlertWebhook)
+	}
 	if file.HeartbeatEnabled != nil {
 		target.HeartbeatEnabled = *file.HeartbeatEnabled
 	}
@@ -494,6 +528,8 @@ func expandProactiveFileConfigEnv(lookup EnvLookup, file *ProactiveFileConfig) {
 	if file.Memory != nil && file.Memory.Index != nil {
 		file.Memory.Index.DBPath = expandEnvValue(lookup, file.Memory.Index.DBPath)
 		file.Memory.Index.EmbedderModel = expandEnvValue(lookup, file.Memory.Index.EmbedderModel)
+		file.Memory.Index.EmbedderBaseURL = expandEnvValue(lookup, file.Memory.Index.EmbedderBaseURL)
+		file.Memory.Index.EmbedderAPIKey = expandEnvValue(lookup, file.Memory.Index.EmbedderAPIKey)
 	}
 	if file.OKR != nil {
 		file.OKR.GoalsRoot = expandEnvValue(lookup, file.OKR.GoalsRoot)
@@ -511,6 +547,7 @@ func expandProactiveFileConfigEnv(lookup EnvLookup, file *ProactiveFileConfig) {
 	}
 	if file.Scheduler != nil {
 		file.Scheduler.LeaderLockName = expandEnvValue(lookup, file.Scheduler.LeaderLockName)
+		file.Scheduler.FailureAlertWebhook = expandEnvValue(lookup, file.Scheduler.FailureAlertWebhook)
 		for i := range file.Scheduler.Triggers {
 			file.Scheduler.Triggers[i].Task = expandEnvValue(lookup, file.Scheduler.Triggers[i].Task)
 			file.Scheduler.Triggers[i].UserID = expandEnvValue(lookup, file.Scheduler.Triggers[i].UserID)
@@ -558,5 +595,6 @@ func expandProactiveFileConfigEnv(lookup EnvLookup, file *ProactiveFileConfig) {
 	}
 	if file.Timer != nil {
 		file.Timer.StorePath = expandEnvValue(lookup, file.Timer.StorePath)
+		file.Timer.FailureAlertWebhook = expandEnvValue(lookup, file.Timer.FailureAlertWebhook)
 	}
 }
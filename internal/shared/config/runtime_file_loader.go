@@ -200,6 +200,8 @@ func applyFile(cfg *RuntimeConfig, meta *Metadata, opts loadOptions) error {
 			return err
 		}
 	}
+	applyLLMFallbackFileConfig(cfg, meta, parsed)
+	applyLLMResponseCacheFileConfig(cfg, meta, parsed.LLMResponseCache)
 
 	var fileCfg FileConfig
 	if err := yaml.Unmarshal(data, &fileCfg); err == nil {
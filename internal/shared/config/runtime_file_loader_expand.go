@@ -29,6 +29,16 @@ func expandRuntimeFileConfigEnv(lookup EnvLookup, parsed RuntimeFileConfig) Runt
 	if parsed.ExternalAgents != nil {
 		expandExternalAgentsFileConfigEnv(lookup, parsed.ExternalAgents)
 	}
+	for i := range parsed.LLMFallbackRules {
+		rule := &parsed.LLMFallbackRules[i]
+		rule.FallbackBaseURL = expandEnvValue(lookup, rule.FallbackBaseURL)
+		rule.FallbackAPIKey = expandEnvValue(lookup, rule.FallbackAPIKey)
+	}
+	for i := range parsed.LLMFallbackProviders {
+		provider := &parsed.LLMFallbackProviders[i]
+		provider.BaseURL = expandEnvValue(lookup, provider.BaseURL)
+		provider.APIKey = expandEnvValue(lookup, provider.APIKey)
+	}
 
 	if len(parsed.StopSequences) > 0 {
 		expanded := make([]string, 0, len(parsed.StopSequences))
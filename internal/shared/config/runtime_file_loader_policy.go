@@ -18,6 +18,49 @@ func applyExternalAgentsFileConfig(cfg *RuntimeConfig, meta *Metadata, external
 	return nil
 }
 
+func applyLLMFallbackFileConfig(cfg *RuntimeConfig, meta *Metadata, parsed RuntimeFileConfig) {
+	if len(parsed.LLMFallbackRules) > 0 {
+		cfg.LLMFallbackRules = append([]LLMFallbackRuleConfig(nil), parsed.LLMFallbackRules...)
+		meta.sources["llm_fallback_rules"] = SourceFile
+	}
+	if len(parsed.LLMFallbackProviders) > 0 {
+		providers := make([]LLMFallbackProviderConfig, 0, len(parsed.LLMFallbackProviders))
+		for _, provider := range parsed.LLMFallbackProviders {
+			provider.Models = utils.CloneMap(provider.Models)
+			providers = append(providers, provider)
+		}
+		cfg.LLMFallbackProviders = providers
+		meta.sources["llm_fallback_providers"] = SourceFile
+	}
+}
+
+func applyLLMResponseCacheFileConfig(cfg *RuntimeConfig, meta *Metadata, file *LLMResponseCacheFileConfig) {
+	if file == nil {
+		return
+	}
+	cache := &cfg.LLMResponseCache
+	if file.Enabled != nil {
+		cache.Enabled = *file.Enabled
+		meta.sources["llm_response_cache.enabled"] = SourceFile
+	}
+	if file.TTLSeconds != nil {
+		cache.TTLSeconds = *file.TTLSeconds
+		meta.sources["llm_response_cache.ttl_seconds"] = SourceFile
+	}
+	if file.MaxBytes != nil {
+		cache.MaxBytes = *file.MaxBytes
+		meta.sources["llm_response_cache.max_bytes"] = SourceFile
+	}
+	if file.MaxEntries != nil {
+		cache.MaxEntries = *file.MaxEntries
+		meta.sources["llm_response_cache.max_entries"] = SourceFile
+	}
+	if file.ForceCache != nil {
+		cache.ForceCache = *file.ForceCache
+		meta.sources["llm_response_cache.force_cache"] = SourceFile
+	}
+}
+
 func applyToolPolicyFileConfig(cfg *RuntimeConfig, meta *Metadata, policy *ToolPolicyFileConfig) {
 	if policy == nil {
 		return
@@ -53,6 +96,13 @@ func applyToolPolicyFileConfig(cfg *RuntimeConfig, meta *Metadata, policy *ToolP
 			cfg.ToolPolicy.Retry.BackoffFactor = *policy.Retry.BackoffFactor
 			meta.sources["tool_policy.retry.backoff_factor"] = SourceFile
 		}
+		if policy.Retry.Categories != nil {
+			cfg.ToolPolicy.Retry.Categories = make(map[string]toolspolicy.ToolRetryConfig, len(policy.Retry.Categories))
+			for category, retry := range policy.Retry.Categories {
+				cfg.ToolPolicy.Retry.Categories[category] = retry
+			}
+			meta.sources["tool_policy.retry.categories"] = SourceFile
+		}
 	}
 	if policy.Rules != nil {
 		cfg.ToolPolicy.Rules = append([]toolspolicy.PolicyRule(nil), policy.Rules...)
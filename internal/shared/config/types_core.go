@@ -104,6 +104,8 @@ type RuntimeConfig struct {
 	Proactive      ProactiveConfig              `json:"proactive" yaml:"proactive"`
 	ExternalAgents ExternalAgentsConfig         `json:"external_agents" yaml:"external_agents"`
 	LLMFallbackRules []LLMFallbackRuleConfig    `json:"llm_fallback_rules" yaml:"llm_fallback_rules"`
+	LLMFallbackProviders []LLMFallbackProviderConfig `json:"llm_fallback_providers" yaml:"llm_fallback_providers"`
+	LLMResponseCache LLMResponseCacheConfig `json:"llm_response_cache" yaml:"llm_response_cache"`
 }
 
 // EnvLookup resolves the value for an environment variable.
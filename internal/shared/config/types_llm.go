@@ -11,6 +11,32 @@ type LLMFallbackRuleConfig struct {
 	FallbackAPIKey   string `json:"fallback_api_key" yaml:"fallback_api_key"`   // fallback API key (optional; inherits primary if empty)
 }
 
+// LLMFallbackProviderConfig is one entry in the ordered provider failover
+// chain. When the primary provider fails at the provider level (5xx, timeouts,
+// revoked credentials), the request is replayed against each entry in order.
+// Models maps a primary model to the equivalent model on this provider; Model
+// is used for primary models without a mapping.
+type LLMFallbackProviderConfig struct {
+	Provider string            `json:"provider" yaml:"provider"`
+	Model    string            `json:"model" yaml:"model"`
+	Models   map[string]string `json:"models" yaml:"models"`
+	BaseURL  string            `json:"base_url" yaml:"base_url"`
+	APIKey   string            `json:"api_key" yaml:"api_key"` // optional; inherits primary if empty
+}
+
+// LLMResponseCacheConfig configures the on-disk LLM response cache. Identical
+// requests (same provider, model, messages, tools and sampling parameters)
+// are replayed from cache at zero cost. Requests with a temperature above
+// zero bypass the cache unless ForceCache is set. Entries live under the
+// session dir.
+type LLMResponseCacheConfig struct {
+	Enabled    bool  `json:"enabled" yaml:"enabled"`
+	TTLSeconds int   `json:"ttl_seconds" yaml:"ttl_seconds"`
+	MaxBytes   int64 `json:"max_bytes" yaml:"max_bytes"`
+	MaxEntries int   `json:"max_entries" yaml:"max_entries"`
+	ForceCache bool  `json:"force_cache" yaml:"force_cache"`
+}
+
 // BrowserConfig configures the browser integration backend.
 //
 // Connector modes:
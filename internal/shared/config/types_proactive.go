@@ -30,11 +30,13 @@ type OKRProactiveConfig struct {
 
 // MemoryConfig controls loading persistent Markdown memory.
 type MemoryConfig struct {
-	Enabled          bool              `json:"enabled" yaml:"enabled"`
-	Index            MemoryIndexConfig `json:"index" yaml:"index"`
-	Prediction       PredictionConfig  `json:"prediction" yaml:"prediction"`
-	ArchiveAfterDays int               `json:"archive_after_days" yaml:"archive_after_days"` // move daily entries older than N days to archive/ (default 30, 0 disables)
-	CleanupInterval  string            `json:"cleanup_interval" yaml:"cleanup_interval"`     // how often to run cleanup (default "24h", Go duration)
+	Enabled            bool              `json:"enabled" yaml:"enabled"`
+	Index              MemoryIndexConfig `json:"index" yaml:"index"`
+	Prediction         PredictionConfig  `json:"prediction" yaml:"prediction"`
+	ArchiveAfterDays   int               `json:"archive_after_days" yaml:"archive_after_days"`   // move daily entries older than N days to archive/ (default 30, 0 disables)
+	CleanupInterval    string            `json:"cleanup_interval" yaml:"cleanup_interval"`       // how often to run cleanup and compaction (default "24h", Go duration)
+	MaxEntries         int               `json:"max_entries" yaml:"max_entries"`                 // cap on daily entries; compaction archives the least important (default 0, disabled)
+	DuplicateThreshold float64           `json:"duplicate_threshold" yaml:"duplicate_threshold"` // term similarity at which entries merge (default 0, disabled)
 }
 
 // PredictionConfig controls predictive memory behavior.
@@ -53,6 +55,12 @@ type MemoryIndexConfig struct {
 	FusionWeightVector float64 `json:"fusion_weight_vector" yaml:"fusion_weight_vector"`
 	FusionWeightBM25   float64 `json:"fusion_weight_bm25" yaml:"fusion_weight_bm25"`
 	EmbedderModel      string  `json:"embedder_model" yaml:"embedder_model"`
+	// EmbedderBaseURL points at an OpenAI-compatible embeddings endpoint
+	// (e.g. http://localhost:11434/v1 for Ollama). Recall stays lexical
+	// when it is empty.
+	EmbedderBaseURL   string `json:"embedder_base_url" yaml:"embedder_base_url"`
+	EmbedderAPIKey    string `json:"embedder_api_key" yaml:"embedder_api_key"`
+	EmbedderBatchSize int    `json:"embedder_batch_size" yaml:"embedder_batch_size"`
 }
 
 // SkillsConfig controls skill activation and feedback.
@@ -96,9 +104,9 @@ func DefaultProactiveConfig() ProactiveConfig {
 			},
 		},
 		Memory: MemoryConfig{
-			Enabled:          true,
-			ArchiveAfterDays: 30,
-			CleanupInterval:  "24h",
+			Enabled:            true,
+			ArchiveAfterDays:   30,
+			CleanupInterval:    "24h",
 			Prediction: PredictionConfig{
 				Enabled:             true,
 				PredictiveBufferPct: 30,
@@ -112,6 +120,7 @@ func DefaultProactiveConfig() ProactiveConfig {
 				FusionWeightVector: 0.7,
 				FusionWeightBM25:   0.3,
 				EmbedderModel:      "nomic-embed-text",
+				EmbedderBatchSize:  32,
 			},
 		},
 		Skills: SkillsConfig{
@@ -145,6 +154,8 @@ func DefaultProactiveConfig() ProactiveConfig {
 			MaxConcurrent:                    1,
 			RecoveryMaxRetries:               0,
 			RecoveryBackoffSeconds:           60,
+			HistoryMaxRecords:                100,
+			FailureAlertThreshold:            3,
 			CalendarReminder: CalendarReminderConfig{
 				Enabled:          false,
 				Schedule:         "*/15 * * * *",
@@ -181,12 +192,14 @@ func DefaultProactiveConfig() ProactiveConfig {
 			},
 		},
 		Timer: TimerConfig{
-			Enabled:            true,
-			StorePath:          "~/.alex/timers",
-			MaxTimers:          100,
-			TaskTimeoutSeconds: 900,
-			HeartbeatEnabled:   false,
-			HeartbeatMinutes:   30,
+			Enabled:               true,
+			StorePath:             "~/.alex/timers",
+			MaxTimers:             100,
+			TaskTimeoutSeconds:    900,
+			HeartbeatEnabled:      false,
+			HeartbeatMinutes:      30,
+			HistoryMaxRecords:     100,
+			FailureAlertThreshold: 3,
 		},
 		Attention: AttentionConfig{
 			MaxDailyNotifications: 5,
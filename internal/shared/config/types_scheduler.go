@@ -14,6 +14,9 @@ type SchedulerConfig struct {
 	MaxConcurrent                    int                      `json:"max_concurrent" yaml:"max_concurrent"`
 	RecoveryMaxRetries               int                      `json:"recovery_max_retries" yaml:"recovery_max_retries"`
 	RecoveryBackoffSeconds           int                      `json:"recovery_backoff_seconds" yaml:"recovery_backoff_seconds"`
+	HistoryMaxRecords                int                      `json:"history_max_records" yaml:"history_max_records"`         // per-job execution history retention, default 100
+	FailureAlertThreshold            int                      `json:"failure_alert_threshold" yaml:"failure_alert_threshold"` // consecutive failures before alerting; 0 disables
+	FailureAlertWebhook              string                   `json:"failure_alert_webhook" yaml:"failure_alert_webhook"`     // alert via webhook instead of the owning chat
 	CalendarReminder                 CalendarReminderConfig   `json:"calendar_reminder" yaml:"calendar_reminder"`
 	Heartbeat                        HeartbeatConfig          `json:"heartbeat" yaml:"heartbeat"`
 	MilestoneCheckin                 MilestoneCheckinConfig   `json:"milestone_checkin" yaml:"milestone_checkin"`
@@ -86,6 +89,7 @@ type CalendarReminderConfig struct {
 type SchedulerTriggerConfig struct {
 	Name     string `json:"name" yaml:"name"`
 	Schedule string `json:"schedule" yaml:"schedule"`
+	Timezone string `json:"timezone,omitempty" yaml:"timezone,omitempty"` // IANA zone for schedule; empty = server local time
 	Task     string `json:"task" yaml:"task"`
 	Channel  string `json:"channel" yaml:"channel"`
 	UserID   string `json:"user_id" yaml:"user_id"` // for channel=lark, this must be Lark open_id (ou_*)
@@ -112,6 +116,10 @@ type TimerConfig struct {
 	TaskTimeoutSeconds int    `json:"task_timeout_seconds" yaml:"task_timeout_seconds"` // default: 900
 	HeartbeatEnabled   bool   `json:"heartbeat_enabled" yaml:"heartbeat_enabled"`
 	HeartbeatMinutes   int    `json:"heartbeat_minutes" yaml:"heartbeat_minutes"`
+	// Execution history and failure-streak alerting; see SchedulerConfig.
+	HistoryMaxRecords     int    `json:"history_max_records" yaml:"history_max_records"`
+	FailureAlertThreshold int    `json:"failure_alert_threshold" yaml:"failure_alert_threshold"`
+	FailureAlertWebhook   string `json:"failure_alert_webhook" yaml:"failure_alert_webhook"`
 }
 
 // GitSignalConfig configures the git signal provider for PR/commit monitoring.
@@ -1,6 +1,7 @@
 package config
 
 import (
+	"strconv"
 	"strings"
 
 	providerinfo "alex/internal/shared/provider"
@@ -9,7 +10,11 @@ import (
 
 // ValidationIssue represents a single validation finding.
 type ValidationIssue struct {
-	ID      string
+	ID string
+	// Key is the config key at fault (e.g. runtime.max_tokens).
+	Key string
+	// Value is the offending value; secrets are redacted.
+	Value   string
 	Message string
 	Hint    string
 }
@@ -66,6 +71,7 @@ func ValidateRuntimeConfig(cfg RuntimeConfig) ValidationReport {
 	if provider == "" {
 		report.Errors = append(report.Errors, ValidationIssue{
 			ID:      "llm-provider",
+			Key:     "runtime.llm_provider",
 			Message: "llm_provider is required",
 			Hint:    "Set runtime.llm_provider in config.yaml or via managed override.",
 		})
@@ -74,6 +80,7 @@ func ValidateRuntimeConfig(cfg RuntimeConfig) ValidationReport {
 	if model == "" {
 		report.Errors = append(report.Errors, ValidationIssue{
 			ID:      "llm-model",
+			Key:     "runtime.llm_model",
 			Message: "llm_model is required",
 			Hint:    "Set runtime.llm_model to a valid model name.",
 		})
@@ -82,6 +89,7 @@ func ValidateRuntimeConfig(cfg RuntimeConfig) ValidationReport {
 	if ProviderRequiresAPIKey(provider) && apiKey == "" {
 		issue := ValidationIssue{
 			ID:      "llm-api-key",
+			Key:     "runtime.api_key",
 			Message: "API key is required for the selected provider",
 			Hint:    "Set runtime.api_key, provider-specific env key, or LLM_API_KEY.",
 		}
@@ -96,14 +104,47 @@ func ValidateRuntimeConfig(cfg RuntimeConfig) ValidationReport {
 	if _, err := ResolveLLMProfile(cfg); err != nil {
 		report.Errors = append(report.Errors, ValidationIssue{
 			ID:      "llm-profile-mismatch",
+			Key:     "runtime.base_url",
+			Value:   strings.TrimSpace(cfg.BaseURL),
 			Message: err.Error(),
 			Hint:    "Align llm_provider, api_key and base_url so they target the same vendor/API family.",
 		})
 	}
 
+	if cfg.MaxTokens < 0 {
+		report.Errors = append(report.Errors, ValidationIssue{
+			ID:      "llm-max-tokens",
+			Key:     "runtime.max_tokens",
+			Value:   strconv.Itoa(cfg.MaxTokens),
+			Message: "max_tokens must not be negative",
+			Hint:    "Set runtime.max_tokens (or LLM_MAX_TOKENS) to a positive limit, or remove it to use the default.",
+		})
+	}
+
+	if cfg.MaxIterations < 0 {
+		report.Errors = append(report.Errors, ValidationIssue{
+			ID:      "agent-max-iterations",
+			Key:     "runtime.max_iterations",
+			Value:   strconv.Itoa(cfg.MaxIterations),
+			Message: "max_iterations must not be negative",
+			Hint:    "Set runtime.max_iterations (or LLM_MAX_ITERATIONS) to a positive count, or remove it to use the default.",
+		})
+	}
+
+	if cfg.TemperatureProvided && (cfg.Temperature < 0 || cfg.Temperature > 2) {
+		report.Warnings = append(report.Warnings, ValidationIssue{
+			ID:      "llm-temperature",
+			Key:     "runtime.temperature",
+			Value:   strconv.FormatFloat(cfg.Temperature, 'g', -1, 64),
+			Message: "temperature is outside the 0-2 range most providers accept",
+			Hint:    "Use a temperature between 0 and 2; providers reject or clamp other values.",
+		})
+	}
+
 	if tavilyKey == "" {
 		report.Warnings = append(report.Warnings, ValidationIssue{
 			ID:      "tavily-key",
+			Key:     "runtime.tavily_api_key",
 			Message: "Tavily API key is not configured",
 			Hint:    "Set TAVILY_API_KEY to enable web_search with external retrieval.",
 		})
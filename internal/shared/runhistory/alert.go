@@ -0,0 +1,125 @@
+package runhistory
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"alex/internal/shared/errsanitize"
+	"alex/internal/shared/httpclient"
+	"alex/internal/shared/logging"
+	"alex/internal/shared/notification"
+)
+
+const webhookTimeout = 10 * time.Second
+
+// Alert kinds identify what failed.
+const (
+	KindSchedulerJob = "scheduler_job"
+	KindTimer        = "timer"
+)
+
+// AlertConfig controls failure-streak alerting. A non-positive Threshold
+// disables alerts.
+type AlertConfig struct {
+	// Threshold is the consecutive-failure count that raises an alert.
+	Threshold int
+	// WebhookURL, when set, receives alerts as a JSON POST instead of the
+	// owning chat.
+	WebhookURL string
+}
+
+// Alert describes a job or timer whose failure streak crossed the threshold.
+type Alert struct {
+	Kind          string              `json:"kind"`
+	ID            string              `json:"id"`
+	Name          string              `json:"name"`
+	FailureStreak int                 `json:"failure_streak"`
+	LastError     string              `json:"last_error,omitempty"`
+	At            time.Time           `json:"at"`
+	Target        notification.Target `json:"-"`
+}
+
+// Alerter delivers failure-streak alerts to a webhook or the owning chat.
+type Alerter struct {
+	cfg      AlertConfig
+	notifier notification.Notifier
+	client   *http.Client
+}
+
+// NewAlerter returns an alerter, or nil when alerting is disabled.
+func NewAlerter(cfg AlertConfig, notifier notification.Notifier, logger logging.Logger) *Alerter {
+	if cfg.Threshold <= 0 {
+		return nil
+	}
+	cfg.WebhookURL = strings.TrimSpace(cfg.WebhookURL)
+	return &Alerter{
+		cfg:      cfg,
+		notifier: notifier,
+		client:   httpclient.New(webhookTimeout, logger),
+	}
+}
+
+// ShouldAlert reports whether a streak of the given length warrants an alert.
+// alerted is true when the current streak has already been reported, which
+// keeps alerts to one per streak.
+func (a *Alerter) ShouldAlert(streak int, alerted bool) bool {
+	return a != nil && !alerted && streak >= a.cfg.Threshold
+}
+
+// Send delivers alert to the configured webhook, or to the alert's target
+// chat when no webhook is configured.
+func (a *Alerter) Send(ctx context.Context, alert Alert) error {
+	if a == nil {
+		return nil
+	}
+	if a.cfg.WebhookURL != "" {
+		return a.postWebhook(ctx, alert)
+	}
+	if a.notifier == nil || alert.Target.Channel == "" {
+		return fmt.Errorf("no alert destination for %s %q", alert.Kind, alert.ID)
+	}
+	return a.notifier.Send(ctx, alert.Target, FormatAlert(alert))
+}
+
+func (a *Alerter) postWebhook(ctx context.Context, alert Alert) error {
+	body, err := json.Marshal(alert)
+	if err != nil {
+		return fmt.Errorf("encode alert: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.cfg.WebhookURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("build alert request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("post alert: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("post alert: webhook returned %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// FormatAlert renders alert as a chat message.
+func FormatAlert(alert Alert) string {
+	name := alert.Name
+	if name == "" {
+		name = alert.ID
+	}
+	label := "Scheduled job"
+	if alert.Kind == KindTimer {
+		label = "Timer"
+	}
+	msg := fmt.Sprintf("⚠️ %s '%s' has failed %d times in a row.", label, name, alert.FailureStreak)
+	if alert.LastError != "" {
+		msg += "\nLast error: " + errsanitize.ForUser(alert.LastError)
+	}
+	return msg
+}
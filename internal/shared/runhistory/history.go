@@ -0,0 +1,181 @@
+// Package runhistory records the execution history of recurring work (scheduler
+// jobs and agent timers) and raises an alert when a failure streak crosses a
+// configured threshold.
+package runhistory
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultMaxRecords is the per-key retention used when none is configured.
+const DefaultMaxRecords = 100
+
+// Outcome classifies a single scheduled fire.
+type Outcome string
+
+const (
+	OutcomeSuccess Outcome = "success"
+	OutcomeFailure Outcome = "failure"
+	// OutcomeMissed marks a fire that never ran (e.g. the process was down
+	// at the scheduled time). Missed fires do not count towards a failure
+	// streak.
+	OutcomeMissed Outcome = "missed"
+)
+
+// Record is one entry in a job's or timer's execution history.
+type Record struct {
+	ScheduledAt time.Time `json:"scheduled_at"`
+	StartedAt   time.Time `json:"started_at,omitempty"`
+	DurationMs  int64     `json:"duration_ms"`
+	Outcome     Outcome   `json:"outcome"`
+	Error       string    `json:"error,omitempty"`
+	TaskID      string    `json:"task_id,omitempty"`
+}
+
+// Store keeps a bounded, newest-last history per key. With a directory it
+// persists each key as {dir}/{key}.json; without one it is memory-only.
+type Store struct {
+	dir        string
+	maxRecords int
+
+	mu      sync.Mutex
+	records map[string][]Record
+}
+
+// NewStore returns a history store retaining at most maxRecords entries per
+// key. An empty dir keeps history in memory only.
+func NewStore(dir string, maxRecords int) *Store {
+	if maxRecords <= 0 {
+		maxRecords = DefaultMaxRecords
+	}
+	return &Store{
+		dir:        strings.TrimSpace(dir),
+		maxRecords: maxRecords,
+		records:    make(map[string][]Record),
+	}
+}
+
+// MaxRecords returns the per-key retention cap.
+func (s *Store) MaxRecords() int {
+	return s.maxRecords
+}
+
+// Append adds rec to key's history, dropping the oldest entries beyond the
+// retention cap.
+func (s *Store) Append(key string, rec Record) error {
+	if key == "" {
+		return fmt.Errorf("runhistory: key is required")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	records, err := s.loadLocked(key)
+	if err != nil {
+		return err
+	}
+	records = append(records, rec)
+	if excess := len(records) - s.maxRecords; excess > 0 {
+		records = append([]Record(nil), records[excess:]...)
+	}
+	s.records[key] = records
+	return s.persistLocked(key, records)
+}
+
+// List returns up to limit records for key, newest first. A non-positive
+// limit returns everything retained.
+func (s *Store) List(key string, limit int) ([]Record, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	records, err := s.loadLocked(key)
+	if err != nil {
+		return nil, err
+	}
+	if limit <= 0 || limit > len(records) {
+		limit = len(records)
+	}
+	out := make([]Record, 0, limit)
+	for i := len(records) - 1; i >= 0 && len(out) < limit; i-- {
+		out = append(out, records[i])
+	}
+	return out, nil
+}
+
+// Delete drops all history for key.
+func (s *Store) Delete(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.records, key)
+	if s.dir == "" {
+		return nil
+	}
+	if err := os.Remove(s.path(key)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("runhistory: delete %s: %w", key, err)
+	}
+	return nil
+}
+
+func (s *Store) loadLocked(key string) ([]Record, error) {
+	if records, ok := s.records[key]; ok {
+		return records, nil
+	}
+	if s.dir == "" {
+		return nil, nil
+	}
+	data, err := os.ReadFile(s.path(key))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("runhistory: read %s: %w", key, err)
+	}
+	var records []Record
+	if err := json.Unmarshal(data, &records); err != nil {
+		return nil, fmt.Errorf("runhistory: decode %s: %w", key, err)
+	}
+	s.records[key] = records
+	return records, nil
+}
+
+func (s *Store) persistLocked(key string, records []Record) error {
+	if s.dir == "" {
+		return nil
+	}
+	if err := os.MkdirAll(s.dir, 0o755); err != nil {
+		return fmt.Errorf("runhistory: create dir: %w", err)
+	}
+	data, err := json.Marshal(records)
+	if err != nil {
+		return fmt.Errorf("runhistory: encode %s: %w", key, err)
+	}
+	tmp := s.path(key) + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("runhistory: write %s: %w", key, err)
+	}
+	if err := os.Rename(tmp, s.path(key)); err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("runhistory: write %s: %w", key, err)
+	}
+	return nil
+}
+
+// path maps key to a file name; characters outside [A-Za-z0-9._-] (such as
+// the ":" in "okr:<goal>") are replaced so keys stay filesystem-safe.
+func (s *Store) path(key string) string {
+	safe := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '.', r == '-', r == '_':
+			return r
+		default:
+			return '_'
+		}
+	}, key)
+	return filepath.Join(s.dir, safe+".json")
+}
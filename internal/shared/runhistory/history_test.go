@@ -0,0 +1,72 @@
+package runhistory
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestStorePrunesAndPersists(t *testing.T) {
+	dir := t.TempDir()
+	store := NewStore(dir, 3)
+	base := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 5; i++ {
+		if err := store.Append("okr:goal-1", Record{ScheduledAt: base.Add(time.Duration(i) * time.Hour), Outcome: OutcomeSuccess}); err != nil {
+			t.Fatalf("Append: %v", err)
+		}
+	}
+
+	reopened := NewStore(dir, 3)
+	records, err := reopened.List("okr:goal-1", 0)
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(records) != 3 {
+		t.Fatalf("expected 3 retained records, got %d", len(records))
+	}
+	if !records[0].ScheduledAt.Equal(base.Add(4*time.Hour)) || !records[2].ScheduledAt.Equal(base.Add(2*time.Hour)) {
+		t.Fatalf("expected newest-first window of the last 3 fires, got %+v", records)
+	}
+
+	limited, err := reopened.List("okr:goal-1", 1)
+	if err != nil || len(limited) != 1 {
+		t.Fatalf("expected 1 record with limit, got %d (%v)", len(limited), err)
+	}
+
+	if err := reopened.Delete("okr:goal-1"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if records, _ := NewStore(dir, 3).List("okr:goal-1", 0); len(records) != 0 {
+		t.Fatalf("expected history removed, got %d records", len(records))
+	}
+}
+
+func TestAlerterPostsWebhook(t *testing.T) {
+	var got Alert
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("decode: %v", err)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	alerter := NewAlerter(AlertConfig{Threshold: 2, WebhookURL: srv.URL}, nil, nil)
+	if alerter.ShouldAlert(1, false) || !alerter.ShouldAlert(2, false) || alerter.ShouldAlert(5, true) {
+		t.Fatal("unexpected ShouldAlert decisions")
+	}
+	err := alerter.Send(context.Background(), Alert{Kind: KindTimer, ID: "tmr-1", Name: "standup", FailureStreak: 2, LastError: "boom"})
+	if err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if got.Kind != KindTimer || got.ID != "tmr-1" || got.FailureStreak != 2 {
+		t.Fatalf("unexpected webhook payload: %+v", got)
+	}
+
+	if NewAlerter(AlertConfig{}, nil, nil) != nil {
+		t.Fatal("expected nil alerter when threshold is unset")
+	}
+}
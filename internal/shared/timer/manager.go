@@ -2,7 +2,9 @@ package timer
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"os"
 	"sync"
 	"time"
 
@@ -10,6 +12,7 @@ import (
 	"alex/internal/shared/async"
 	"alex/internal/shared/logging"
 	"alex/internal/shared/notification"
+	"alex/internal/shared/runhistory"
 	id "alex/internal/shared/utils/id"
 
 	"github.com/robfig/cron/v3"
@@ -23,12 +26,25 @@ type agentCoordinator interface {
 // notifier routes timer results to external channels.
 type notifier = notification.Notifier
 
+// maxMissedRecords caps the missed-fire entries recorded for one recurring
+// timer on restart.
+const maxMissedRecords = 50
+
+// lateFireGrace is how far past its due time a one-shot timer may fire
+// before it is reported as late.
+const lateFireGrace = time.Minute
+
+// claimRetention is how long fire claims are kept for duplicate detection.
+const claimRetention = 7 * 24 * time.Hour
+
 // Config holds TimerManager runtime configuration.
 type Config struct {
-	Enabled     bool
-	StorePath   string
-	MaxTimers   int
-	TaskTimeout time.Duration
+	Enabled      bool
+	StorePath    string
+	MaxTimers    int
+	TaskTimeout  time.Duration
+	History      *runhistory.Store // optional; defaults to in-memory history
+	FailureAlert runhistory.AlertConfig
 }
 
 // TimerManager manages the lifecycle of agent-initiated timers.
@@ -40,6 +56,10 @@ type TimerManager struct {
 	config      Config
 	logger      logging.Logger
 	cron        *cron.Cron
+	parser      cron.Parser
+	history     *runhistory.Store
+	alerter     *runhistory.Alerter
+	now         func() time.Time
 
 	mu        sync.Mutex
 	timers    map[string]*Timer
@@ -69,6 +89,10 @@ func NewTimerManager(cfg Config, coordinator agentCoordinator, notifier notifier
 		cron.WithParser(cronParser),
 		cron.WithChain(cron.SkipIfStillRunning(cron.DefaultLogger)),
 	)
+	history := cfg.History
+	if history == nil {
+		history = runhistory.NewStore("", runhistory.DefaultMaxRecords)
+	}
 
 	return &TimerManager{
 		coordinator: coordinator,
@@ -77,6 +101,10 @@ func NewTimerManager(cfg Config, coordinator agentCoordinator, notifier notifier
 		config:      cfg,
 		logger:      logger,
 		cron:        cronInstance,
+		parser:      cronParser,
+		history:     history,
+		alerter:     runhistory.NewAlerter(cfg.FailureAlert, notifier, logger),
+		now:         time.Now,
 		timers:      make(map[string]*Timer),
 		cronIDs:     make(map[string]cron.EntryID),
 		goTimers:    make(map[string]*time.Timer),
@@ -100,13 +128,15 @@ func (m *TimerManager) Start(ctx context.Context) error {
 		return fmt.Errorf("load timers: %w", err)
 	}
 
-	now := time.Now()
+	now := m.now()
+	m.store.PruneClaims(now.Add(-claimRetention))
 	for i := range timers {
 		timer := timers[i]
 		if !timer.IsActive() {
 			continue
 		}
 		m.timers[timer.ID] = &timer
+		m.recordMissedFiresLocked(m.timers[timer.ID], now)
 		if err := m.scheduleLocked(m.timers[timer.ID]); err != nil {
 			m.logger.Warn("TimerManager: failed to re-schedule timer %q: %v", timer.Name, err)
 		}
@@ -194,14 +224,20 @@ func (m *TimerManager) Add(t *Timer) error {
 	return nil
 }
 
-// Cancel marks a timer as cancelled, stops its scheduling, and persists the change.
+// Cancel marks a timer as cancelled, stops its scheduling, and persists the
+// change. Timers created by another process sharing the store can be
+// cancelled too.
 func (m *TimerManager) Cancel(timerID string) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
 	t, ok := m.timers[timerID]
 	if !ok {
-		return fmt.Errorf("timer not found: %s", timerID)
+		persisted, err := m.store.Get(timerID)
+		if err != nil {
+			return fmt.Errorf("timer not found: %s", timerID)
+		}
+		t = &persisted
 	}
 	if !t.IsActive() {
 		return fmt.Errorf("timer %s is not active (status=%s)", timerID, t.Status)
@@ -218,16 +254,33 @@ func (m *TimerManager) Cancel(timerID string) error {
 }
 
 // List returns timers filtered by user ID. If userID is empty, returns all.
+// The persisted set is authoritative, so active timers created by another
+// process sharing the store are included and timers it cancelled or fired
+// show their stored status.
 func (m *TimerManager) List(userID string) []Timer {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	var result []Timer
+	merged := make(map[string]Timer, len(m.timers))
 	for _, t := range m.timers {
+		merged[t.ID] = *t
+	}
+	persisted, err := m.store.LoadAll()
+	if err != nil {
+		m.logger.Warn("TimerManager: failed to load persisted timers, listing in-memory set: %v", err)
+	}
+	for _, t := range persisted {
+		if _, known := merged[t.ID]; known || t.IsActive() {
+			merged[t.ID] = t
+		}
+	}
+
+	var result []Timer
+	for _, t := range merged {
 		if userID != "" && t.UserID != userID {
 			continue
 		}
-		result = append(result, *t)
+		result = append(result, t)
 	}
 	return result
 }
@@ -351,25 +404,196 @@ func (m *TimerManager) fireTimer(timerID string) {
 		defer cancel()
 	}
 
+	startedAt := m.now()
+	due := scheduledAt(t, startedAt)
+	if !m.claimFire(t, due) {
+		return
+	}
+
 	m.logger.Info("TimerManager: firing timer %q (%s) in session %s", t.Name, t.ID, sessionID)
 
-	result, err := m.coordinator.ExecuteTask(ctx, t.Task, sessionID, nil)
+	task := t.Task
+	note := lateNote(t, startedAt)
+	if note != "" {
+		task = note + "\n\n" + task
+	}
+	result, err := m.coordinator.ExecuteTask(ctx, task, sessionID, nil)
 	content := formatTimerResult(t, result, err)
+	if note != "" {
+		content = note + "\n\n" + content
+	}
 
 	// Notify.
 	m.notify(ctx, t, content)
 
+	rec := runhistory.Record{
+		ScheduledAt: due,
+		StartedAt:   startedAt,
+		DurationMs:  m.now().Sub(startedAt).Milliseconds(),
+		Outcome:     runhistory.OutcomeSuccess,
+		TaskID:      runID,
+	}
+	if err != nil {
+		rec.Outcome = runhistory.OutcomeFailure
+		rec.Error = err.Error()
+	}
+	if histErr := m.history.Append(t.ID, rec); histErr != nil {
+		m.logger.Warn("TimerManager: failed to record history for %s: %v", t.ID, histErr)
+	}
+
+	m.mu.Lock()
+	alert := m.trackOutcomeLocked(t, rec)
 	// Mark one-shot as fired.
-	if t.Type == TimerTypeOnce {
+	if t.Type == TimerTypeOnce && t.IsActive() {
+		t.Status = StatusFired
+	}
+	snapshot := *t
+	m.mu.Unlock()
+	if saveErr := m.store.Save(snapshot); saveErr != nil {
+		m.logger.Warn("TimerManager: failed to persist fire state for %s: %v", t.ID, saveErr)
+	}
+
+	if alert != nil {
+		if alertErr := m.alerter.Send(ctx, *alert); alertErr != nil {
+			m.logger.Warn("TimerManager: failed to send failure alert for %q: %v", t.Name, alertErr)
+		}
+	}
+}
+
+// claimFire re-reads the timer from the store and claims the fire due at due.
+// It reports false when the timer was cancelled or fired by another process
+// sharing the store, or when that process already claimed this fire.
+func (m *TimerManager) claimFire(t *Timer, due time.Time) bool {
+	persisted, err := m.store.Get(t.ID)
+	if errors.Is(err, os.ErrNotExist) || (err == nil && !persisted.IsActive()) {
 		m.mu.Lock()
-		if t.IsActive() {
-			t.Status = StatusFired
+		m.unscheduleLocked(t.ID)
+		if err == nil {
+			t.Status = persisted.Status
+		} else {
+			t.Status = StatusCancelled
 		}
 		m.mu.Unlock()
-		if saveErr := m.store.Save(*t); saveErr != nil {
-			m.logger.Warn("TimerManager: failed to persist fired status for %s: %v", t.ID, saveErr)
+		m.logger.Info("TimerManager: timer %q (%s) is no longer active in the store, skipping", t.Name, t.ID)
+		return false
+	}
+
+	claimed, err := m.store.Claim(t.ID, due)
+	if err != nil {
+		// Failing closed would lose the reminder; a duplicate is the lesser harm.
+		m.logger.Warn("TimerManager: failed to claim timer %q (%s), firing anyway: %v", t.Name, t.ID, err)
+		return true
+	}
+	if !claimed {
+		if t.Type == TimerTypeOnce {
+			m.mu.Lock()
+			t.Status = StatusFired
+			m.mu.Unlock()
 		}
+		m.logger.Info("TimerManager: timer %q (%s) due %s already claimed by another process", t.Name, t.ID, due.Format(time.RFC3339))
+		return false
+	}
+	return true
+}
+
+// lateNote explains a one-shot fire that runs well past its due time,
+// typically because the process was down.
+func lateNote(t *Timer, now time.Time) string {
+	if t.Type != TimerTypeOnce || t.FireAt.IsZero() {
+		return ""
+	}
+	late := now.Sub(t.FireAt)
+	if late < lateFireGrace {
+		return ""
+	}
+	return fmt.Sprintf("[Late reminder: this was due at %s and is firing %s late because the service was not running.]",
+		t.FireAt.Format(time.RFC3339), late.Round(time.Minute))
+}
+
+// scheduledAt returns when a fire was due. Cron fires land on minute
+// boundaries, so recurring timers are due at the start of the firing minute.
+func scheduledAt(t *Timer, startedAt time.Time) time.Time {
+	if t.Type == TimerTypeOnce && !t.FireAt.IsZero() {
+		return t.FireAt
+	}
+	return startedAt.Truncate(time.Minute)
+}
+
+// trackOutcomeLocked updates the timer's failure streak and returns the alert
+// to send when the streak has just crossed the threshold. Must be called with
+// m.mu held.
+func (m *TimerManager) trackOutcomeLocked(t *Timer, rec runhistory.Record) *runhistory.Alert {
+	t.LastFiredAt = rec.StartedAt
+	t.LastOutcome = string(rec.Outcome)
+	if rec.Outcome != runhistory.OutcomeFailure {
+		t.FailureStreak = 0
+		t.FailureAlerted = false
+		return nil
+	}
+	t.FailureStreak++
+	if !m.alerter.ShouldAlert(t.FailureStreak, t.FailureAlerted) {
+		return nil
+	}
+	t.FailureAlerted = true
+	return &runhistory.Alert{
+		Kind:          runhistory.KindTimer,
+		ID:            t.ID,
+		Name:          t.Name,
+		FailureStreak: t.FailureStreak,
+		LastError:     rec.Error,
+		At:            rec.StartedAt,
+		Target:        notification.Target{Channel: t.Channel, ChatID: t.ChatID},
+	}
+}
+
+// recordMissedFiresLocked records a missed entry for each recurring fire that
+// fell due while the manager was down. Must be called with m.mu held.
+func (m *TimerManager) recordMissedFiresLocked(t *Timer, now time.Time) {
+	if t.Type != TimerTypeRecurring {
+		return
+	}
+	last := t.LastFiredAt
+	if last.IsZero() {
+		last = t.CreatedAt
+	}
+	if last.IsZero() {
+		return
+	}
+	schedule, err := m.parser.Parse(t.Schedule)
+	if err != nil {
+		return
+	}
+	missed := 0
+	for at := schedule.Next(last); !at.After(now) && missed < maxMissedRecords; at = schedule.Next(at) {
+		rec := runhistory.Record{
+			ScheduledAt: at,
+			Outcome:     runhistory.OutcomeMissed,
+			Error:       "timer manager was not running at the scheduled time",
+		}
+		if err := m.history.Append(t.ID, rec); err != nil {
+			m.logger.Warn("TimerManager: failed to record history for %s: %v", t.ID, err)
+		}
+		missed++
+	}
+	if missed == 0 {
+		return
+	}
+	t.LastOutcome = string(runhistory.OutcomeMissed)
+	if err := m.store.Save(*t); err != nil {
+		m.logger.Warn("TimerManager: failed to persist timer %s: %v", t.ID, err)
+	}
+	m.logger.Warn("TimerManager: timer %q missed %d scheduled fire(s) while down", t.Name, missed)
+}
+
+// History returns up to limit execution records for a timer, newest first.
+func (m *TimerManager) History(timerID string, limit int) ([]runhistory.Record, error) {
+	m.mu.Lock()
+	_, ok := m.timers[timerID]
+	m.mu.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("timer not found: %s", timerID)
 	}
+	return m.history.List(timerID, limit)
 }
 
 func (m *TimerManager) notify(ctx context.Context, t *Timer, content string) {
@@ -3,11 +3,13 @@ package timer
 import (
 	"context"
 	"fmt"
+	"strings"
 	"sync"
 	"testing"
 	"time"
 
 	agent "alex/internal/domain/agent/ports/agent"
+	"alex/internal/shared/runhistory"
 	"alex/internal/testutil"
 )
 
@@ -715,3 +717,263 @@ func TestManagerDisabled(t *testing.T) {
 	}
 	mgr.Stop()
 }
+
+func TestManagerTracksFailureStreakAndAlertsOnce(t *testing.T) {
+	coord := newMockCoordinator(nil, fmt.Errorf("upstream down"))
+	notifier := &testutil.StubNotifier{}
+	mgr, err := NewTimerManager(Config{
+		Enabled:      true,
+		StorePath:    t.TempDir(),
+		History:      runhistory.NewStore("", 3),
+		FailureAlert: runhistory.AlertConfig{Threshold: 2},
+	}, coord, notifier, nil)
+	if err != nil {
+		t.Fatalf("NewTimerManager: %v", err)
+	}
+	now := time.Date(2026, 3, 1, 9, 0, 15, 0, time.UTC)
+	mgr.now = func() time.Time { return now }
+
+	tmr := &Timer{
+		ID:        NewTimerID(),
+		Name:      "standup",
+		Type:      TimerTypeRecurring,
+		Schedule:  "0 9 * * *",
+		Task:      "Post standup",
+		Channel:   "lark",
+		ChatID:    "oc_team",
+		CreatedAt: now,
+		Status:    StatusActive,
+	}
+	if err := mgr.Add(tmr); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+
+	alerts := func() int {
+		count := 0
+		for _, content := range notifier.Contents() {
+			if strings.Contains(content, "in a row") {
+				count++
+			}
+		}
+		return count
+	}
+	for i := 0; i < 3; i++ {
+		mgr.fireTimer(tmr.ID)
+		now = now.Add(24 * time.Hour)
+	}
+	got, _ := mgr.Get(tmr.ID)
+	if got.FailureStreak != 3 || got.LastOutcome != string(runhistory.OutcomeFailure) {
+		t.Fatalf("unexpected streak state: %+v", got)
+	}
+	if alerts() != 1 {
+		t.Fatalf("expected one alert per streak, got %d", alerts())
+	}
+
+	coord.mu.Lock()
+	coord.err = nil
+	coord.result = &agent.TaskResult{Answer: "posted"}
+	coord.mu.Unlock()
+	mgr.fireTimer(tmr.ID)
+	got, _ = mgr.Get(tmr.ID)
+	if got.FailureStreak != 0 || got.FailureAlerted || got.LastOutcome != string(runhistory.OutcomeSuccess) {
+		t.Fatalf("expected streak reset after success: %+v", got)
+	}
+
+	records, err := mgr.History(tmr.ID, 0)
+	if err != nil {
+		t.Fatalf("History: %v", err)
+	}
+	if len(records) != 3 {
+		t.Fatalf("expected retention cap of 3 records, got %d", len(records))
+	}
+	if records[0].Outcome != runhistory.OutcomeSuccess || !records[0].ScheduledAt.Equal(now.Truncate(time.Minute)) {
+		t.Fatalf("unexpected latest record: %+v", records[0])
+	}
+	if records[1].Outcome != runhistory.OutcomeFailure || records[1].Error != "upstream down" || records[1].TaskID == "" {
+		t.Fatalf("unexpected failure record: %+v", records[1])
+	}
+}
+
+func TestManagerRecordsMissedRecurringFires(t *testing.T) {
+	dir := t.TempDir()
+	store, err := newStore(dir)
+	if err != nil {
+		t.Fatalf("newStore: %v", err)
+	}
+	lastFired := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	tmr := Timer{
+		ID:            NewTimerID(),
+		Name:          "hourly",
+		Type:          TimerTypeRecurring,
+		Schedule:      "0 * * * *",
+		Task:          "Check inbox",
+		CreatedAt:     lastFired.Add(-time.Hour),
+		Status:        StatusActive,
+		LastFiredAt:   lastFired,
+		LastOutcome:   string(runhistory.OutcomeFailure),
+		FailureStreak: 1,
+	}
+	if err := store.Save(tmr); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	mgr, err := NewTimerManager(Config{Enabled: true, StorePath: dir}, newMockCoordinator(nil, nil), nil, nil)
+	if err != nil {
+		t.Fatalf("NewTimerManager: %v", err)
+	}
+	mgr.now = func() time.Time { return lastFired.Add(2*time.Hour + 30*time.Minute) }
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := mgr.Start(ctx); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	defer mgr.Stop()
+
+	records, err := mgr.History(tmr.ID, 0)
+	if err != nil {
+		t.Fatalf("History: %v", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("expected 2 missed fires, got %+v", records)
+	}
+	for i, rec := range records {
+		want := lastFired.Add(time.Duration(2-i) * time.Hour)
+		if rec.Outcome != runhistory.OutcomeMissed || !rec.ScheduledAt.Equal(want) {
+			t.Fatalf("record %d = %+v, want missed at %v", i, rec, want)
+		}
+	}
+	got, _ := mgr.Get(tmr.ID)
+	if got.LastOutcome != string(runhistory.OutcomeMissed) || got.FailureStreak != 1 {
+		t.Fatalf("missed fires must not touch the failure streak: %+v", got)
+	}
+}
+
+func TestManagerLateOneShotFiresWithNote(t *testing.T) {
+	dir := t.TempDir()
+	store, err := newStore(dir)
+	if err != nil {
+		t.Fatalf("newStore: %v", err)
+	}
+	tmr := Timer{
+		ID:        "tmr-late",
+		Name:      "stretch",
+		Type:      TimerTypeOnce,
+		FireAt:    time.Now().Add(-2 * time.Hour),
+		Task:      "Remind me to stretch",
+		SessionID: "session-late",
+		Channel:   "lark",
+		ChatID:    "oc_1",
+		CreatedAt: time.Now().Add(-3 * time.Hour),
+		Status:    StatusActive,
+	}
+	if err := store.Save(tmr); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	coord := newMockCoordinator(&agent.TaskResult{Answer: "Time to stretch"}, nil)
+	notifier := &testutil.StubNotifier{}
+	mgr, err := NewTimerManager(Config{Enabled: true, StorePath: dir}, coord, notifier, nil)
+	if err != nil {
+		t.Fatalf("NewTimerManager: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := mgr.Start(ctx); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	defer mgr.Stop()
+	coord.waitForCall(t, 5*time.Second)
+
+	calls := coord.getCalls()
+	if !strings.HasPrefix(calls[0].Task, "[Late reminder") || !strings.HasSuffix(calls[0].Task, tmr.Task) {
+		t.Fatalf("expected late note before task, got %q", calls[0].Task)
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for len(notifier.Contents()) == 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if contents := notifier.Contents(); len(contents) != 1 || !strings.Contains(contents[0], "2h0m0s late") {
+		t.Fatalf("expected late notification, got %v", contents)
+	}
+}
+
+func TestManagerReplicasSharingStoreFireOnce(t *testing.T) {
+	dir := t.TempDir()
+	newReplica := func(coord *mockCoordinator) *TimerManager {
+		mgr, err := NewTimerManager(Config{Enabled: true, StorePath: dir}, coord, nil, nil)
+		if err != nil {
+			t.Fatalf("NewTimerManager: %v", err)
+		}
+		return mgr
+	}
+	coordA := newMockCoordinator(&agent.TaskResult{Answer: "ok"}, nil)
+	coordB := newMockCoordinator(&agent.TaskResult{Answer: "ok"}, nil)
+	replicaA, replicaB := newReplica(coordA), newReplica(coordB)
+
+	tmr := &Timer{
+		ID:        NewTimerID(),
+		Name:      "shared",
+		Type:      TimerTypeOnce,
+		FireAt:    time.Now().Add(time.Hour),
+		Task:      "fire once",
+		CreatedAt: time.Now(),
+		Status:    StatusActive,
+	}
+	if err := replicaA.Add(tmr); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	copied := *tmr
+	replicaB.timers[copied.ID] = &copied
+
+	replicaA.fireTimer(tmr.ID)
+	replicaB.fireTimer(tmr.ID)
+	if a, b := len(coordA.getCalls()), len(coordB.getCalls()); a+b != 1 {
+		t.Fatalf("expected exactly one fire across replicas, got %d + %d", a, b)
+	}
+	if got, _ := replicaB.Get(tmr.ID); got.IsActive() {
+		t.Fatalf("losing replica should stop treating the timer as active: %+v", got)
+	}
+}
+
+func TestManagerCancelAndListUsePersistedSet(t *testing.T) {
+	dir := t.TempDir()
+	replicaA, err := NewTimerManager(Config{Enabled: true, StorePath: dir}, newMockCoordinator(nil, nil), nil, nil)
+	if err != nil {
+		t.Fatalf("NewTimerManager: %v", err)
+	}
+	coordB := newMockCoordinator(nil, nil)
+	replicaB, err := NewTimerManager(Config{Enabled: true, StorePath: dir}, coordB, nil, nil)
+	if err != nil {
+		t.Fatalf("NewTimerManager: %v", err)
+	}
+
+	tmr := &Timer{
+		ID:        NewTimerID(),
+		Name:      "elsewhere",
+		Type:      TimerTypeOnce,
+		FireAt:    time.Now().Add(time.Hour),
+		Task:      "created on A",
+		UserID:    "user-a",
+		CreatedAt: time.Now(),
+		Status:    StatusActive,
+	}
+	if err := replicaA.Add(tmr); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+
+	if listed := replicaB.List("user-a"); len(listed) != 1 || listed[0].ID != tmr.ID {
+		t.Fatalf("expected replica B to list the persisted timer, got %+v", listed)
+	}
+	if err := replicaB.Cancel(tmr.ID); err != nil {
+		t.Fatalf("Cancel on replica B: %v", err)
+	}
+	listed := replicaA.List("")
+	if len(listed) != 1 || listed[0].Status != StatusCancelled {
+		t.Fatalf("expected replica A to see the cancellation, got %+v", listed)
+	}
+
+	replicaA.fireTimer(tmr.ID)
+	if got, _ := replicaA.Get(tmr.ID); got.Status != StatusCancelled {
+		t.Fatalf("fire of a timer cancelled elsewhere must be skipped, status %q", got.Status)
+	}
+}
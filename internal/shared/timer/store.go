@@ -1,15 +1,21 @@
 package timer
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
 	"sync"
+	"time"
 
 	"gopkg.in/yaml.v3"
 )
 
+// claimsDir holds the fire claims that stop replicas sharing a store from
+// firing the same due time twice.
+const claimsDir = "claims"
+
 // store persists timers as individual YAML files in a directory.
 // Each timer is stored as {id}.yaml.
 type store struct {
@@ -35,13 +41,59 @@ func (s *store) Save(t Timer) error {
 	if err != nil {
 		return fmt.Errorf("marshal timer %s: %w", t.ID, err)
 	}
+	// Write to a temp file and rename so a crash never leaves a truncated
+	// timer behind for LoadAll to skip.
 	path := s.filePath(t.ID)
-	if err := os.WriteFile(path, data, 0o644); err != nil {
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("write timer %s: %w", t.ID, err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		_ = os.Remove(tmp)
 		return fmt.Errorf("write timer %s: %w", t.ID, err)
 	}
 	return nil
 }
 
+// Claim records that the fire of timer id due at due is being executed. It
+// reports false when another process sharing the store already claimed it.
+func (s *store) Claim(id string, due time.Time) (bool, error) {
+	dir := filepath.Join(s.dir, claimsDir)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return false, fmt.Errorf("create timer claims dir: %w", err)
+	}
+	path := filepath.Join(dir, fmt.Sprintf("%s-%d.claim", id, due.Unix()))
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644)
+	if err != nil {
+		if errors.Is(err, os.ErrExist) {
+			return false, nil
+		}
+		return false, fmt.Errorf("claim timer %s: %w", id, err)
+	}
+	hostname, _ := os.Hostname()
+	_, _ = fmt.Fprintf(f, "%s pid=%d\n", hostname, os.Getpid())
+	if err := f.Close(); err != nil {
+		return false, fmt.Errorf("claim timer %s: %w", id, err)
+	}
+	return true, nil
+}
+
+// PruneClaims removes fire claims created before cutoff.
+func (s *store) PruneClaims(cutoff time.Time) {
+	dir := filepath.Join(s.dir, claimsDir)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return
+	}
+	for _, entry := range entries {
+		info, err := entry.Info()
+		if err != nil || !info.ModTime().Before(cutoff) {
+			continue
+		}
+		_ = os.Remove(filepath.Join(dir, entry.Name()))
+	}
+}
+
 // Get loads a single timer by ID. Returns os.ErrNotExist if not found.
 func (s *store) Get(id string) (Timer, error) {
 	s.mu.Lock()
@@ -280,3 +280,35 @@ func TestNewTimerID(t *testing.T) {
 		t.Errorf("NewTimerID: unexpected format: %q", id1)
 	}
 }
+
+func TestStoreClaimIsExclusive(t *testing.T) {
+	dir := t.TempDir()
+	first, err := newStore(dir)
+	if err != nil {
+		t.Fatalf("newStore: %v", err)
+	}
+	second, err := newStore(dir)
+	if err != nil {
+		t.Fatalf("newStore: %v", err)
+	}
+	due := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+
+	if ok, err := first.Claim("tmr-1", due); err != nil || !ok {
+		t.Fatalf("first claim = %v, %v; want true", ok, err)
+	}
+	if ok, err := second.Claim("tmr-1", due); err != nil || ok {
+		t.Fatalf("second claim of same fire = %v, %v; want false", ok, err)
+	}
+	if ok, err := second.Claim("tmr-1", due.Add(time.Hour)); err != nil || !ok {
+		t.Fatalf("claim of next fire = %v, %v; want true", ok, err)
+	}
+
+	first.PruneClaims(time.Now().Add(time.Minute))
+	if ok, _ := second.Claim("tmr-1", due); !ok {
+		t.Fatal("expected pruned claim to be claimable again")
+	}
+	timers, err := first.LoadAll()
+	if err != nil || len(timers) != 0 {
+		t.Fatalf("claims must not show up as timers: %v, %v", timers, err)
+	}
+}
@@ -44,6 +44,13 @@ type Timer struct {
 	ChatID    string      `yaml:"chat_id,omitempty"`
 	CreatedAt time.Time   `yaml:"created_at"`
 	Status    TimerStatus `yaml:"status"`
+
+	// Execution tracking. FailureStreak counts consecutive failed fires and
+	// resets on success; FailureAlerted marks the streak as already alerted.
+	LastFiredAt    time.Time `yaml:"last_fired_at,omitempty"`
+	LastOutcome    string    `yaml:"last_outcome,omitempty"`
+	FailureStreak  int       `yaml:"failure_streak,omitempty"`
+	FailureAlerted bool      `yaml:"failure_alerted,omitempty"`
 }
 
 // NewTimerID generates a unique timer identifier with "tmr-" prefix.
@@ -1,7 +1,8 @@
 #!/usr/bin/env bash
 # diag.sh — One-shot diagnostics collector for alex-server.
-# Connects to the debug HTTP server (default :9090) and dumps heap, goroutine,
-# allocs, and CPU profiles into a timestamped directory.
+# Connects to the debug HTTP server (default :9090) and dumps the /health
+# response plus heap, goroutine, allocs, and CPU profiles into a timestamped
+# directory.
 #
 # Usage:
 #   scripts/diag.sh [port]
@@ -24,11 +25,12 @@ echo "Target:    ${BASE_URL}"
 echo "Output:    ${DUMP_DIR}"
 echo ""
 
-# Health check first.
-if ! curl -sf "${BASE_URL}/health" > /dev/null 2>&1; then
+# Health check first; the saved response lists degraded bootstrap components.
+if ! curl -sf "${BASE_URL}/health" > "${DUMP_DIR}/health.json" 2>/dev/null; then
     echo "ERROR: Cannot reach ${BASE_URL}/health — is alex-server running?"
     exit 1
 fi
+echo "[0/5] Health -> ${DUMP_DIR}/health.json"
 
 echo "[1/5] Heap profile..."
 curl -sf "${BASE_URL}/debug/pprof/heap" > "${DUMP_DIR}/heap.prof" 2>/dev/null && \
@@ -60,6 +62,11 @@ if [ -f "${DUMP_DIR}/goroutine.txt" ]; then
     echo "Goroutines:  ${GOROUTINE_COUNT}"
 fi
 
+# Degraded components recorded at startup (bootstrap component details).
+if grep -q '"name":"bootstrap","status":"not_ready"' "${DUMP_DIR}/health.json" 2>/dev/null; then
+    echo "Bootstrap:   degraded (see health.json)"
+fi
+
 echo "Files:"
 ls -lh "${DUMP_DIR}/"
 
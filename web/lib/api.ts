@@ -7,6 +7,8 @@ import {
   CreateTaskResponse,
   TaskStatusResponse,
   SessionListResponse,
+  SessionSearchParams,
+  SessionSearchResponse,
   SessionDetailsResponse,
   ShareTokenResponse,
   SharedSessionResponse,
@@ -319,6 +321,20 @@ export async function listSessions(): Promise<SessionListResponse> {
   return fetchAPI<SessionListResponse>("/api/sessions");
 }
 
+export async function searchSessions(
+  params: SessionSearchParams,
+): Promise<SessionSearchResponse> {
+  const search = new URLSearchParams();
+  if (params.q) search.set("q", params.q);
+  if (params.channel) search.set("channel", params.channel);
+  if (params.userId) search.set("user_id", params.userId);
+  if (params.since) search.set("since", params.since);
+  if (params.until) search.set("until", params.until);
+  if (params.limit !== undefined) search.set("limit", String(params.limit));
+  if (params.offset !== undefined) search.set("offset", String(params.offset));
+  return fetchAPI<SessionSearchResponse>(`/api/sessions/search?${search.toString()}`);
+}
+
 export async function getSessionDetails(
   sessionId: string,
 ): Promise<SessionDetailsResponse> {
@@ -638,6 +654,7 @@ export const apiClient = {
   cancelTask,
   createSession,
   listSessions,
+  searchSessions,
   getSessionDetails,
   getSessionRaw,
   getSessionTitle,
@@ -168,6 +168,19 @@ const WorkflowNodeOutputSummaryEventSchema = BaseAgentEventSchema.extend({
   attachments: z.record(z.string(), AttachmentPayloadSchema).nullable().optional(),
 });
 
+const WorkflowIterationUsageEventSchema = BaseAgentEventSchema.extend({
+  event_type: z.literal('workflow.iteration.usage'),
+  iteration: z.number(),
+  model: z.string().optional(),
+  prompt_tokens: z.number(),
+  completion_tokens: z.number(),
+  cached_tokens: z.number().optional(),
+  total_tokens: z.number(),
+  cost_usd: z.number(),
+  cumulative_tokens: z.number(),
+  cumulative_cost_usd: z.number(),
+});
+
 const WorkflowToolStartedEventSchema = BaseAgentEventSchema.extend({
   event_type: z.literal('workflow.tool.started'),
   call_id: z.string(),
@@ -306,6 +319,14 @@ const WorkflowInputReceivedEventSchema = BaseAgentEventSchema.extend({
   attachments: z.record(z.string(), AttachmentPayloadSchema).nullable().optional(),
 });
 
+const WorkflowQueuedEventSchema = BaseAgentEventSchema.extend({
+  event_type: z.literal('workflow.queued'),
+  position: z.number(),
+  estimated_wait_seconds: z.number(),
+  priority: z.number().optional(),
+  queued_at: z.string().optional(),
+});
+
 const EVENT_TYPE_ALIASES: Record<string, WorkflowEventType> = {};
 
 const EventSchemas = [
@@ -315,6 +336,7 @@ const EventSchemas = [
   WorkflowNodeFailedEventSchema,
   WorkflowNodeOutputDeltaEventSchema,
   WorkflowNodeOutputSummaryEventSchema,
+  WorkflowIterationUsageEventSchema,
   WorkflowToolStartedEventSchema,
   WorkflowToolProgressEventSchema,
   WorkflowToolCompletedEventSchema,
@@ -330,6 +352,7 @@ const EventSchemas = [
   WorkflowDiagnosticErrorEventSchema,
   ConnectedEventSchema,
   WorkflowInputReceivedEventSchema,
+  WorkflowQueuedEventSchema,
 ] as const;
 
 const eventSchemaList = [...EventSchemas] as [
@@ -6,6 +6,8 @@ import {
   WorkflowNodeStartedEvent,
   WorkflowNodeOutputDeltaEvent,
   WorkflowNodeOutputSummaryEvent,
+  WorkflowIterationUsageEvent,
+  WorkflowQueuedEvent,
   WorkflowToolStartedEvent,
   WorkflowToolCompletedEvent,
   WorkflowNodeCompletedEvent,
@@ -45,6 +47,16 @@ export function isWorkflowNodeOutputSummaryEvent(event: AnyAgentEvent): event is
   return isEventType(event, 'workflow.node.output.summary');
 }
 
+// Iteration Usage Event (tokens and cost after each LLM round)
+export function isWorkflowIterationUsageEvent(event: AnyAgentEvent): event is WorkflowIterationUsageEvent {
+  return isEventType(event, 'workflow.iteration.usage');
+}
+
+// Queued Event (position while waiting for an execution slot)
+export function isWorkflowQueuedEvent(event: AnyAgentEvent): event is WorkflowQueuedEvent {
+  return isEventType(event, 'workflow.queued');
+}
+
 // Tool Call Start Event
 export function isWorkflowToolStartedEvent(event: AnyAgentEvent): event is WorkflowToolStartedEvent {
   return isEventType(event, 'workflow.tool.started');
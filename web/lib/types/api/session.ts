@@ -22,6 +22,33 @@ export interface SessionListResponse {
   sessions: Session[];
 }
 
+export interface SessionSearchHit {
+  session_id: string;
+  title?: string;
+  /** Excerpts with matched keywords wrapped in `**`. */
+  snippets: string[];
+  matches: number;
+  created_at: string;
+  updated_at: string;
+}
+
+export interface SessionSearchResponse {
+  hits: SessionSearchHit[];
+  total: number;
+  limit: number;
+  offset: number;
+}
+
+export interface SessionSearchParams {
+  q?: string;
+  channel?: string;
+  userId?: string;
+  since?: string;
+  until?: string;
+  limit?: number;
+  offset?: number;
+}
+
 export interface SessionDetailsResponse {
   session: Session;
   tasks: SessionTaskSummary[];
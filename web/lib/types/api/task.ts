@@ -28,8 +28,11 @@ export interface TaskStatusResponse {
   parent_run_id?: string | null;
   status: string;
   created_at?: string;
+  queued_at?: string | null;
+  started_at?: string | null;
   completed_at?: string | null;
   updated_at?: string;
+  priority?: number;
   final_answer?: string;
   error?: string;
 }
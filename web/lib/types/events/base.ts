@@ -10,11 +10,13 @@ export type WorkflowEventType =
   | 'workflow.node.failed'
   | 'workflow.node.output.delta'
   | 'workflow.node.output.summary'
+  | 'workflow.iteration.usage'
   | 'workflow.tool.started'
   | 'workflow.tool.progress'
   | 'workflow.tool.completed'
   | 'workflow.artifact.manifest'
   | 'workflow.input.received'
+  | 'workflow.queued'
   | 'workflow.subflow.progress'
   | 'workflow.subflow.completed'
   | 'workflow.result.final'
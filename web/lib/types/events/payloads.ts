@@ -16,6 +16,13 @@ export interface WorkflowLifecycleUpdatedPayload {
   workflow?: WorkflowSnapshot;
 }
 
+export interface WorkflowQueuedPayload {
+  position: number;
+  estimated_wait_seconds: number;
+  priority?: number;
+  queued_at?: string;
+}
+
 export interface WorkflowNodeStartedPayload {
   node_id?: string;
   node_kind?: string;
@@ -68,6 +75,18 @@ export interface WorkflowNodeOutputSummaryPayload {
   attachments?: Record<string, AttachmentPayload> | null;
 }
 
+export interface WorkflowIterationUsagePayload {
+  iteration: number;
+  model?: string;
+  prompt_tokens: number;
+  completion_tokens: number;
+  cached_tokens?: number;
+  total_tokens: number;
+  cost_usd: number;
+  cumulative_tokens: number;
+  cumulative_cost_usd: number;
+}
+
 export interface WorkflowToolStartedPayload {
   call_id: string;
   tool_name: string;
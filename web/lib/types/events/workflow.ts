@@ -11,6 +11,7 @@ import type {
   WorkflowNodeFailedPayload,
   WorkflowNodeOutputDeltaPayload,
   WorkflowNodeOutputSummaryPayload,
+  WorkflowIterationUsagePayload,
   WorkflowToolStartedPayload,
   WorkflowToolProgressPayload,
   WorkflowToolCompletedPayload,
@@ -25,6 +26,7 @@ import type {
   WorkflowDiagnosticContextSnapshotPayload,
   WorkflowDiagnosticErrorPayload,
   UserTaskPayload,
+  WorkflowQueuedPayload,
 } from './payloads';
 
 export type WorkflowLifecycleUpdatedEvent = WorkflowEvent<
@@ -51,6 +53,10 @@ export type WorkflowNodeOutputSummaryEvent = WorkflowEvent<
   WorkflowNodeOutputSummaryPayload,
   'workflow.node.output.summary'
 >;
+export type WorkflowIterationUsageEvent = WorkflowEvent<
+  WorkflowIterationUsagePayload,
+  'workflow.iteration.usage'
+>;
 export type WorkflowToolStartedEvent = WorkflowEvent<
   WorkflowToolStartedPayload,
   'workflow.tool.started'
@@ -107,6 +113,10 @@ export type WorkflowInputReceivedEvent = WorkflowEvent<
   UserTaskPayload,
   'workflow.input.received'
 >;
+export type WorkflowQueuedEvent = WorkflowEvent<
+  WorkflowQueuedPayload,
+  'workflow.queued'
+>;
 
 export interface StreamDroppedPayload {
   dropped_event_type: string;
@@ -124,6 +134,7 @@ export type AnyAgentEvent =
   | WorkflowNodeFailedEvent
   | WorkflowNodeOutputDeltaEvent
   | WorkflowNodeOutputSummaryEvent
+  | WorkflowIterationUsageEvent
   | WorkflowToolStartedEvent
   | WorkflowToolProgressEvent
   | WorkflowToolCompletedEvent
@@ -138,6 +149,7 @@ export type AnyAgentEvent =
   | WorkflowDiagnosticContextSnapshotEvent
   | WorkflowDiagnosticErrorEvent
   | WorkflowInputReceivedEvent
+  | WorkflowQueuedEvent
   | WorkflowStreamDroppedEvent
   | ConnectedEvent;
 